@@ -4,7 +4,9 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"math"
 	"net/http"
 	"os"
 	"os/signal"
@@ -14,10 +16,30 @@ import (
 	"github.com/gorilla/mux"
 
 	"secrets-manager/internal/api"
+	"secrets-manager/internal/api/middleware"
+	"secrets-manager/internal/api/versioning"
 	"secrets-manager/internal/auth"
+	"secrets-manager/internal/captcha"
 	"secrets-manager/internal/config"
+	"secrets-manager/internal/events"
+	"secrets-manager/internal/evidence"
+	"secrets-manager/internal/geoip"
+	"secrets-manager/internal/jobs"
+	"secrets-manager/internal/logforward"
+	"secrets-manager/internal/logging"
+	"secrets-manager/internal/loginalerts"
+	"secrets-manager/internal/maintenance"
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/netguard"
+	"secrets-manager/internal/notifications"
+	"secrets-manager/internal/onboarding"
+	"secrets-manager/internal/preflight"
+	"secrets-manager/internal/rotation"
+	"secrets-manager/internal/server"
+	"secrets-manager/internal/storage"
 	mysqldb "secrets-manager/internal/storage/mysql"
 	"secrets-manager/internal/vault"
+	"secrets-manager/internal/webhooks"
 )
 
 func main() {
@@ -27,6 +49,13 @@ func main() {
 		log.Fatalf("Erreur de chargement de la configuration: %v", err)
 	}
 
+	// Initialiser les logs structurés
+	logLevel, err := logging.ParseLevel(cfg.Log.Level)
+	if err != nil {
+		log.Fatalf("Erreur de configuration des logs: %v", err)
+	}
+	logging.Init(logLevel)
+
 	// Initialiser la base de données
 	db, err := mysqldb.NewConnection(cfg.Database)
 	if err != nil {
@@ -34,26 +63,463 @@ func main() {
 	}
 	defer db.Close()
 
+	if cfg.Database.AutoMigrate {
+		if err := mysqldb.Migrate(context.Background(), db); err != nil {
+			log.Fatalf("Erreur d'application des migrations: %v", err)
+		}
+	}
+
 	// Initialiser le client Vault
 	vaultClient, err := vault.NewClient(&vault.Config{
 		Address: cfg.Vault.Address,
 		Token:   cfg.Vault.Token,
+		Timeout: cfg.Vault.Timeout,
 	})
 	if err != nil {
 		log.Fatalf("Erreur de connexion à Vault: %v", err)
 	}
 
+	// Un client Vault par région de résidence des données
+	regionClients := make(map[string]*vault.Client, len(cfg.Vault.Regions))
+	for _, region := range cfg.Vault.Regions {
+		regionClients[region.Name], err = vault.NewClient(&vault.Config{
+			Address: region.Address,
+			Token:   region.Token,
+			Timeout: cfg.Vault.Timeout,
+		})
+		if err != nil {
+			log.Fatalf("Erreur de connexion à Vault (région %s): %v", region.Name, err)
+		}
+	}
+
+	// Vérifier les dépendances avant d'accepter du trafic
+	if cfg.Preflight {
+		checks := []preflight.Check{
+			preflight.SchemaVersion(db),
+			preflight.VaultHealth(vaultClient),
+			preflight.VaultKVMount(vaultClient, vault.KVMount),
+			preflight.JWTSecret(cfg.JWT),
+		}
+		for _, region := range cfg.Vault.Regions {
+			for _, check := range []preflight.Check{
+				preflight.VaultHealth(regionClients[region.Name]),
+				preflight.VaultKVMount(regionClients[region.Name], vault.KVMount),
+			} {
+				check.Name += fmt.Sprintf(" (région %s)", region.Name)
+				checks = append(checks, check)
+			}
+		}
+		if cfg.SMTP.Enabled() {
+			checks = append(checks, preflight.SMTP(cfg.SMTP))
+		}
+		if !preflight.Run(context.Background(), os.Stderr, checks...) {
+			log.Println("Démarrage interrompu : corriger les erreurs ci-dessus (PREFLIGHT_CHECKS=false pour ignorer)")
+			os.Exit(1)
+		}
+	}
+
+	// Les index manquants ralentissent les requêtes sans les empêcher
+	mysqldb.WarnMissingIndexes(context.Background(), db)
+
+	// Initialiser les repositories
+	usersRepo := mysqldb.NewUsersRepository(db)
+	organizationsRepo := mysqldb.NewOrganizationsRepository(db)
+
 	// Initialiser les services
-	vaultService := vault.NewService(vaultClient)
-	authService := auth.NewService(db, cfg.JWT.Secret, cfg.JWT.Expiration)
+	// Le disjoncteur évite d'attendre Vault lorsqu'il est scellé ou injoignable ;
+	// chaque région de résidence a son propre backend et son propre disjoncteur
+	breakerConfig := vault.BreakerConfig{
+		Threshold: cfg.Vault.BreakerThreshold,
+		Cooldown:  cfg.Vault.BreakerCooldown,
+	}
+	// Les lectures du backend principal peuvent être servies par ses réplicas de performance
+	var replicas []vault.Cluster
+	for _, replica := range cfg.Vault.Replicas {
+		replicaClient, err := vault.NewClient(&vault.Config{
+			Address: replica.Address,
+			Token:   replica.Token,
+			Timeout: cfg.Vault.Timeout,
+		})
+		if err != nil {
+			log.Fatalf("Erreur de connexion à Vault (réplica %s): %v", replica.Name, err)
+		}
+		replicas = append(replicas, vault.Cluster{
+			Name:   replica.Name,
+			Store:  vault.NewBreaker(replicaClient, breakerConfig),
+			Prober: replicaClient,
+		})
+	}
+	vaultClusters := vault.NewClusters(vault.Cluster{
+		Name:   cfg.Vault.ClusterName,
+		Store:  vault.NewBreaker(vaultClient, breakerConfig),
+		Prober: vaultClient,
+	}, replicas...)
+	vaultClusters.Check(context.Background())
+	vaultStores := map[string]vault.SecretStore{
+		models.DefaultRegion: vaultClusters,
+	}
+	for name, regionClient := range regionClients {
+		vaultStores[name] = vault.NewBreaker(regionClient, breakerConfig)
+	}
+	vaultRouter := vault.NewRouter(vaultStores, organizationsRepo.GetOrganizationRegion)
+	// Moteur transit des clés de données, dans la région de chaque organisation
+	transits := map[string]vault.Transit{
+		models.DefaultRegion: vaultClient,
+	}
+	for name, regionClient := range regionClients {
+		transits[name] = regionClient
+	}
+	vaultService := vault.NewService(vaultRouter)
+	authService := auth.NewService(usersRepo, mysqldb.NewUserMFARepository(db), mysqldb.NewRefreshTokensRepository(db), cfg.JWT.Secret, auth.Issuer{Name: cfg.JWT.Issuer, Audience: cfg.JWT.Audience},
+		cfg.JWT.Expiration, cfg.JWT.RefreshExpiration)
+	trustedDevices := mysqldb.NewTrustedDevicesRepository(db)
+	authService.EnableDeviceTrust(trustedDevices, cfg.JWT.TrustedDeviceDuration)
+	serviceAccountsRepo := mysqldb.NewServiceAccountsRepository(db)
+	authService.EnableServiceAccounts(serviceAccountsRepo)
+	// Les usurpations d'identité ne sont émises qu'avec ADMIN_TOKEN ; les
+	// utilisateurs consultent toujours celles passées
+	impersonationsRepo := mysqldb.NewImpersonationsRepository(db)
+	if cfg.Admin.Token != "" {
+		authService.EnableImpersonation(impersonationsRepo)
+	} else {
+		log.Println("ADMIN_TOKEN vide : usurpation d'identité désactivée")
+	}
+
+	// Avec des clés asymétriques, les services internes vérifient les tokens
+	// avec le JWKS publié, sans partager JWT_SECRET. Chaque clé porte un kid :
+	// une rotation n'invalide pas les tokens signés par les précédentes avant
+	// leur date de retrait.
+	if len(cfg.JWT.SigningKeys) > 0 {
+		signingKeys := make([]*auth.SigningKey, 0, len(cfg.JWT.SigningKeys))
+		for _, configured := range cfg.JWT.SigningKeys {
+			key, err := auth.LoadSigningKey(context.Background(), configured.ID, configured.Source, vaultClient)
+			if err != nil {
+				log.Fatalf("Erreur de configuration des clés de signature: %v", err)
+			}
+			key.RetiresAt = configured.RetiresAt
+			signingKeys = append(signingKeys, key)
+		}
+		if err := authService.EnableSigningKeys(signingKeys, cfg.JWT.AcceptHS256); err != nil {
+			log.Fatalf("Erreur de configuration des clés de signature: %v", err)
+		}
+	}
+
+	// Les appels API sont accumulés en mémoire puis écrits par lots
+	usageBuffer := storage.NewUsageBuffer(mysqldb.NewUsageRepository(db))
+
+	// Les archives de preuves de conformité sont signées avec une clé stable
+	var evidenceSigner *evidence.Signer
+	if cfg.Evidence.Enabled() {
+		if evidenceSigner, err = evidence.NewSigner(cfg.Evidence.SigningKey); err != nil {
+			log.Fatalf("Erreur de configuration de l'export des preuves: %v", err)
+		}
+	}
+
+	// Les sommes de contrôle des secrets doivent rester stables entre serveurs
+	var checksummer *vault.Checksummer
+	if cfg.Checksum.Enabled() {
+		if checksummer, err = vault.NewChecksummer(cfg.Checksum.Key); err != nil {
+			log.Fatalf("Erreur de configuration des sommes de contrôle: %v", err)
+		}
+	}
+
+	// Notifications par email (si SMTP est configuré) et Slack
+	notificationPreferences := mysqldb.NewNotificationPreferencesRepository(db)
+	notificationEvents := mysqldb.NewNotificationEventsRepository(db)
+	var mailer notifications.Mailer
+	if cfg.SMTP.Enabled() {
+		mailer = notifications.NewSMTPMailer(cfg.SMTP.Address, cfg.SMTP.From)
+	}
+	// Webhooks des organisations, livrés avec les notifications
+	webhooksRepo := mysqldb.NewWebhooksRepository(db)
+	webhookSender := webhooks.NewSender(webhooksRepo, netguard.Guard{}, nil)
+	// Historique de la configuration des organisations
+	settingsHistory := mysqldb.NewSettingsHistoryRepository(db)
+	// Équipes, destinataires des notifications des projets et secrets qu'elles possèdent
+	teamsRepo := mysqldb.NewTeamsRepository(db)
+	notifier := notifications.NewDispatcher(organizationsRepo, notificationPreferences, notificationEvents,
+		mailer, notifications.NewSlackClient(), webhookSender, teamsRepo)
+
+	// Connexions situées d'après l'adresse IP (si une base GeoIP est
+	// configurée) et alertes des connexions inhabituelles
+	var locator geoip.Locator
+	if cfg.GeoIP.Enabled() {
+		geoDB, err := geoip.Load(cfg.GeoIP.Database)
+		if err != nil {
+			log.Fatalf("Erreur de chargement de la base GeoIP: %v", err)
+		}
+		locator = geoDB
+	}
+	loginEvents := mysqldb.NewLoginEventsRepository(db)
+	loginAlertPolicies := mysqldb.NewLoginAlertPoliciesRepository(db)
+	authService.ObserveLogins(loginalerts.NewMonitor(locator, loginEvents, loginAlertPolicies, usersRepo, notifier))
+	sessionPolicies := mysqldb.NewSessionPoliciesRepository(db)
+	authService.ApplySessionPolicies(sessionPolicies)
+	passwordPolicies := mysqldb.NewPasswordPoliciesRepository(db)
+	var breachChecker auth.BreachChecker
+	if cfg.PasswordPolicy.BreachAPIURL != "" {
+		breachChecker = auth.NewHIBPChecker(cfg.PasswordPolicy.BreachAPIURL)
+	}
+	authService.ApplyPasswordPolicies(models.PasswordPolicy{
+		MinLength:      cfg.PasswordPolicy.MinLength,
+		RejectBreached: cfg.PasswordPolicy.RejectBreached,
+	}, passwordPolicies, breachChecker)
+	invitationsRepo := mysqldb.NewInvitationsRepository(db)
+	registrationPolicy := mysqldb.NewRegistrationPolicyRepository(db)
+	authService.RestrictRegistration(models.RegistrationPolicy{
+		Mode:      cfg.Registration.Mode,
+		AllowList: cfg.Registration.AllowList,
+		DenyList:  cfg.Registration.DenyList,
+	}, registrationPolicy, invitationsRepo)
+	// Transfert des journaux d'audit vers les destinations des organisations
+	logForwarders := mysqldb.NewLogForwardersRepository(db)
+	auditForwarder := logforward.NewForwarder(logForwarders, organizationsRepo, netguard.Guard{}, nil)
 
 	// Configurer le routeur
 	router := mux.NewRouter()
-	api.ConfigureRoutes(router, vaultService, authService)
+	deps := &api.Dependencies{
+		VaultService:  vaultService,
+		AuthService:   authService,
+		Users:         usersRepo,
+		Organizations: organizationsRepo,
+		Secrets:       mysqldb.NewSecretsRepository(db),
+		Projects:      mysqldb.NewProjectsRepository(db),
+		Confirmations: mysqldb.NewConfirmationsRepository(db),
+		Usage:         usageBuffer,
+		AdminAudit:    mysqldb.NewAdminAuditRepository(db),
+		SecretReads:   mysqldb.NewSecretReadsRepository(db),
+
+		DeviceAuthorizations:  mysqldb.NewDeviceAuthorizationsRepository(db),
+		DeviceVerificationURI: cfg.Server.DeviceVerificationURI,
+		Invitations:           invitationsRepo,
+		InvitationAcceptURI:   cfg.Server.InvitationAcceptURI,
+		EmailVerificationURI:  cfg.Server.EmailVerificationURI,
+		Mailer:                mailer,
+		Teams:                 teamsRepo,
+		ServiceAccounts:       serviceAccountsRepo,
+		CustomRoles:           mysqldb.NewCustomRolesRepository(db),
+		Impersonations:        impersonationsRepo,
+		PersonalAccessTokens:  mysqldb.NewPersonalAccessTokensRepository(db),
+		APIKeys:               mysqldb.NewAPIKeysRepository(db),
+		TrustedDevices:        trustedDevices,
+		LoginEvents:           loginEvents,
+		LoginAlertPolicies:    loginAlertPolicies,
+		SessionPolicies:       sessionPolicies,
+		PasswordPolicies:      passwordPolicies,
+		RegistrationPolicy:    registrationPolicy,
+		ReadReasonPolicies:    mysqldb.NewReadReasonPoliciesRepository(db),
+		ProjectTemplates:      mysqldb.NewProjectTemplatesRepository(db),
+		AuditLogs:             mysqldb.NewAuditLogsRepository(db),
+		IntrospectionClients:  cfg.JWT.IntrospectionClients,
+
+		OrganizationDeletions: mysqldb.NewOrganizationDeletionsRepository(db),
+		AccessReviews:         mysqldb.NewAccessReviewsRepository(db),
+		BulkRotations:         mysqldb.NewBulkRotationsRepository(db),
+		Lockdowns:             mysqldb.NewLockdownsRepository(db),
+		EvidenceSigner:        evidenceSigner,
+		VaultRouter:           vaultRouter,
+		VaultClusters:         vaultClusters,
+		Checksummer:           checksummer,
+
+		NotificationPreferences: notificationPreferences,
+		NotificationEvents:      notificationEvents,
+		Notifier:                notifier,
+		Webhooks:                webhooksRepo,
+		WebhookSender:           webhookSender,
+		Events:                  events.NewBus(),
+		SecretRotators:          mysqldb.NewSecretRotatorsRepository(db),
+		Rotators:                rotation.NewRegistry(nil),
+		ScheduledSecretChanges:  mysqldb.NewScheduledSecretChangesRepository(db),
+		MaintenanceWindows:      mysqldb.NewMaintenanceWindowsRepository(db),
+		Subscriptions:           storage.NewSubscriptionService(db, settingsHistory),
+		DataKeys:                mysqldb.NewDataKeysRepository(db),
+		Transit:                 vault.NewTransitRouter(transits, organizationsRepo.GetOrganizationRegion),
+		AgeKeys:                 mysqldb.NewAgeKeysRepository(db),
+		SettingsHistory:         settingsHistory,
+		Health:                  mysqldb.NewHealthRepository(db),
+		LogForwarders:           logForwarders,
+		AuditForwarder:          auditForwarder,
+
+		SlowRequestThreshold: cfg.Server.SlowRequestThreshold,
+		ConfirmationWindow:   cfg.Server.ConfirmationWindow,
+		RecycleRetention:     cfg.Server.RecycleRetention,
+		TrialPlanID:          cfg.Trial.PlanID,
+		TrialDuration:        cfg.Trial.Duration,
+		TrialSecretsLimit:    cfg.Trial.SecretsLimit,
+	}
+	organizationDeleter := jobs.NewOrganizationDeleter(deps.OrganizationDeletions, deps.Organizations, vaultService,
+		cfg.Server.RecycleRetention, time.Minute)
+	deps.OrganizationDeleter = organizationDeleter
+	rotationService := rotation.NewService(deps.SecretRotators, deps.Secrets, deps.Projects, vaultService,
+		deps.Rotators, checksummer, notifier)
+	bulkRotator := jobs.NewBulkRotator(deps.BulkRotations, deps.SecretRotators, deps.Projects, deps.Secrets,
+		rotationService, deps.Events, time.Minute)
+	deps.BulkRotator = bulkRotator
+	// Suivi de la prise en main : chaque étape franchie donne lieu à un email
+	// d'accompagnement et à un événement livré aux webhooks
+	onboardingTracker := onboarding.NewTracker(mysqldb.NewOnboardingRepository(db), usersRepo, deps.Projects,
+		deps.Secrets)
+	onboardingTracker.Subscribe(onboarding.Notify(notifier))
+	deps.Onboarding = onboardingTracker
+	if !cfg.Server.V1DeprecatedSince.IsZero() {
+		deps.V1Deprecation = &versioning.Deprecation{
+			Since:  cfg.Server.V1DeprecatedSince,
+			Sunset: cfg.Server.V1Sunset,
+		}
+	}
+	killSwitches := make([]middleware.Switch, 0, len(cfg.Server.KillSwitches))
+	for _, sw := range cfg.Server.KillSwitches {
+		killSwitches = append(killSwitches, middleware.Switch{Name: sw.Name, Reason: sw.Reason})
+	}
+	if deps.Switches, err = middleware.NewSwitches(killSwitches...); err != nil {
+		log.Fatalf("Erreur de configuration des interrupteurs: %v", err)
+	}
+	if cfg.Captcha.Enabled() {
+		deps.Captcha, err = captcha.New(cfg.Captcha.Provider, cfg.Captcha.SiteKey, cfg.Captcha.Secret,
+			cfg.Captcha.VerifyURL, cfg.Captcha.Difficulty)
+		if err != nil {
+			log.Fatalf("Erreur de configuration du défi d'inscription: %v", err)
+		}
+	}
+	limits := cfg.AuthRateLimit
+	deps.AuthLimiter = middleware.NewAuthLimiter(middleware.AuthLimits{
+		PerIP:        limits.PerIP,
+		PerEmail:     limits.PerEmail,
+		Window:       limits.Window,
+		FreeAttempts: limits.FreeAttempts,
+		BaseDelay:    limits.BaseDelay,
+		MaxDelay:     limits.MaxDelay,
+		CaptchaAfter: limits.CaptchaAfter,
+	}, deps.Captcha)
+	api.ConfigureRoutes(router, deps)
+
+	// Tâches périodiques : purge du journal d'audit d'administration, des
+	// confirmations, des autorisations d'appareils et des signatures de
+	// requêtes expirées, écriture de l'usage de l'API, réconciliation des compteurs de secrets, purge
+	// des projets restés trop longtemps dans la corbeille, santé des clusters Vault, résumés de notification,
+	// historique de santé des composants
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	runner := jobs.NewRunner()
+	runner.Every("admin_audit_retention", 24*time.Hour, func(ctx context.Context) error {
+		_, err := deps.AdminAudit.PurgeAdminAuditLogs(ctx, time.Now().Add(-cfg.Admin.AuditRetention))
+		return err
+	})
+	runner.Every("deletion_confirmations_purge", time.Hour, func(ctx context.Context) error {
+		_, err := deps.Confirmations.PurgeExpiredConfirmations(ctx, time.Now())
+		return err
+	})
+	runner.Every("device_authorizations_purge", time.Hour, func(ctx context.Context) error {
+		_, err := deps.DeviceAuthorizations.PurgeExpiredDeviceAuthorizations(ctx, time.Now())
+		return err
+	})
+	runner.Every("request_signatures_purge", time.Hour, func(ctx context.Context) error {
+		_, err := deps.APIKeys.PurgeExpiredRequestSignatures(ctx, time.Now())
+		return err
+	})
+	runner.Every("usage_flush", cfg.Server.UsageFlushInterval, usageBuffer.Flush)
+	runner.Every("vault_clusters_health", cfg.Vault.HealthInterval, vaultClusters.Check)
+	runner.Every("secret_counts_reconciliation", time.Hour,
+		jobs.ReconcileSecretCounts(deps.Secrets, vaultService))
+	runner.Every("suspended_projects_purge", time.Hour,
+		jobs.PurgeSuspendedProjects(deps.Projects, vaultService, cfg.Server.RecycleRetention))
+	// Les modifications automatiques des environnements protégés attendent
+	// leurs fenêtres de maintenance
+	maintenanceGate := maintenance.NewGate(deps.MaintenanceWindows)
+	retirer := rotation.NewRetirer(deps.SecretRotators, deps.Secrets, vaultService, deps.Rotators, maintenanceGate)
+	runner.Every("dual_rotation_retirement", time.Minute, retirer.RetireExpired)
+	promoter := jobs.NewSecretPromoter(deps.ScheduledSecretChanges, deps.Secrets, deps.Projects, vaultService,
+		checksummer, notifier, deps.Events, maintenanceGate)
+	runner.Every("scheduled_secret_promotion", time.Minute, promoter.PromoteDue)
+	digests := notifications.NewDigests(notificationPreferences, notificationEvents, usersRepo, organizationsRepo,
+		deps.Projects, mailer, cfg.Server.RecycleRetention)
+	runner.Every("notification_digests", time.Hour, digests.Send)
+	runner.Every("notification_events_retention", 24*time.Hour, func(ctx context.Context) error {
+		// Un résumé hebdomadaire ne remonte pas au-delà de sa période
+		_, err := notificationEvents.PurgeNotificationEvents(ctx, time.Now().Add(-30*24*time.Hour))
+		return err
+	})
+	runner.Every("webhook_deliveries_retention", 24*time.Hour, func(ctx context.Context) error {
+		_, err := webhooksRepo.PurgeWebhookDeliveries(ctx, time.Now().Add(-30*24*time.Hour))
+		return err
+	})
+	// Historique de santé des composants publié par la page de statut
+	healthProbes := []jobs.HealthProbe{
+		{Component: models.ComponentDatabase, Check: db.PingContext},
+		{Component: models.ComponentVault, Check: vaultClient.Health},
+	}
+	for _, region := range cfg.Vault.Regions {
+		healthProbes = append(healthProbes, jobs.HealthProbe{
+			Component: models.ComponentVault + "-" + region.Name,
+			Check:     regionClients[region.Name].Health,
+		})
+	}
+	healthMonitor := jobs.NewHealthMonitor(deps.Health, healthProbes...)
+	runner.Every("health_checks", time.Minute, healthMonitor.Check)
+	runner.Every("health_checks_retention", 24*time.Hour, func(ctx context.Context) error {
+		// La page de statut publie 90 jours d'historique
+		_, err := deps.Health.PurgeHealthChecks(ctx, time.Now().AddDate(0, 0, -90))
+		return err
+	})
+
+	// Réplication des métadonnées vers la région de secours (voir smadmin) ;
+	// sans base de secours, le flux de changements est seulement purgé
+	if cfg.Replication.Enabled() {
+		standbyDB, err := mysqldb.NewConnection(cfg.Replication.Standby)
+		if err != nil {
+			log.Fatalf("Erreur de connexion à la base de secours: %v", err)
+		}
+		defer standbyDB.Close()
+
+		replicator := mysqldb.NewReplicator(db, standbyDB)
+		runner.Every("metadata_replication", cfg.Replication.Interval, replicator.Replicate)
+		runner.Every("metadata_changes_purge", time.Hour, func(ctx context.Context) error {
+			_, err := replicator.Purge(ctx, time.Now().Add(-cfg.Replication.Retention))
+			return err
+		})
+	} else {
+		runner.Every("metadata_changes_purge", time.Hour, func(ctx context.Context) error {
+			_, err := mysqldb.PurgeMetadataChanges(ctx, db, math.MaxInt64, time.Now().Add(-cfg.Replication.Retention))
+			return err
+		})
+	}
+	runner.Start(jobsCtx)
+
+	// Distribution des notifications en tâche de fond
+	notifierDone := make(chan struct{})
+	go func() {
+		defer close(notifierDone)
+		notifier.Run(jobsCtx)
+	}()
+
+	// Transfert des journaux d'audit en tâche de fond
+	forwarderDone := make(chan struct{})
+	go func() {
+		defer close(forwarderDone)
+		auditForwarder.Run(jobsCtx)
+	}()
+
+	// Purge des organisations supprimées en tâche de fond (reprise des purges interrompues)
+	deleterDone := make(chan struct{})
+	go func() {
+		defer close(deleterDone)
+		organizationDeleter.Run(jobsCtx)
+	}()
+
+	// Rotations groupées en tâche de fond (reprise des rotations interrompues)
+	bulkRotatorDone := make(chan struct{})
+	go func() {
+		defer close(bulkRotatorDone)
+		bulkRotator.Run(jobsCtx)
+	}()
+
+	// Ouvrir le listener (TCP, socket unix ou activation systemd)
+	listener, err := server.Listen(cfg.Server)
+	if err != nil {
+		log.Fatalf("Erreur d'ouverture du listener: %v", err)
+	}
 
 	// Configurer le serveur HTTP
 	srv := &http.Server{
-		Addr:         cfg.Server.Address,
 		Handler:      router,
 		ReadTimeout:  15 * time.Second,
 		WriteTimeout: 15 * time.Second,
@@ -62,12 +528,65 @@ func main() {
 
 	// Démarrer le serveur dans une goroutine
 	go func() {
-		log.Printf("Serveur démarré sur %s", cfg.Server.Address)
-		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		log.Printf("Serveur démarré sur %s %s", listener.Addr().Network(), listener.Addr())
+		if err := srv.Serve(listener); err != nil && err != http.ErrServerClosed {
 			log.Fatalf("Erreur de démarrage du serveur: %v", err)
 		}
 	}()
 
+	// Démarrer le serveur d'administration (pprof, debug) sur son propre listener
+	var adminSrv *http.Server
+	if cfg.Admin.Address != "" && !cfg.Admin.Enabled() {
+		log.Println("ADMIN_TOKEN vide : listener d'administration désactivé")
+	}
+	if cfg.Admin.Enabled() {
+		adminListener, err := server.ListenAdmin(cfg.Admin)
+		if err != nil {
+			log.Fatalf("Erreur d'ouverture du listener d'administration: %v", err)
+		}
+
+		adminRouter := mux.NewRouter()
+		api.ConfigureAdminRoutes(adminRouter, cfg.Admin.Token, deps)
+
+		// Pas de WriteTimeout : les profils CPU et traces durent plusieurs secondes
+		adminSrv = &http.Server{
+			Handler:     adminRouter,
+			ReadTimeout: 15 * time.Second,
+			IdleTimeout: 60 * time.Second,
+		}
+
+		go func() {
+			log.Printf("Serveur d'administration démarré sur %s", adminListener.Addr())
+			if err := adminSrv.Serve(adminListener); err != nil && err != http.ErrServerClosed {
+				log.Fatalf("Erreur de démarrage du serveur d'administration: %v", err)
+			}
+		}()
+	}
+
+	// Démarrer le listener mTLS des comptes de service : le certificat client
+	// authentifie la requête à la place d'un token
+	var mtlsSrv *http.Server
+	if cfg.MTLS.Enabled() {
+		mtlsListener, err := server.ListenMTLS(cfg.MTLS)
+		if err != nil {
+			log.Fatalf("Erreur d'ouverture du listener mTLS: %v", err)
+		}
+
+		mtlsSrv = &http.Server{
+			Handler:      middleware.ClientCertificateAuth(authService)(router),
+			ReadTimeout:  15 * time.Second,
+			WriteTimeout: 15 * time.Second,
+			IdleTimeout:  60 * time.Second,
+		}
+
+		go func() {
+			log.Printf("Serveur mTLS démarré sur %s", mtlsListener.Addr())
+			if err := mtlsSrv.Serve(mtlsListener); err != nil && err != http.ErrServerClosed {
+				log.Fatalf("Erreur de démarrage du serveur mTLS: %v", err)
+			}
+		}()
+	}
+
 	// Attendre le signal d'arrêt
 	c := make(chan os.Signal, 1)
 	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
@@ -78,9 +597,33 @@ func main() {
 	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
 	defer cancel()
 
+	stopJobs()
+	runner.Wait()
+	<-deleterDone
+	<-bulkRotatorDone
+	<-notifierDone
+	<-forwarderDone
+
+	if adminSrv != nil {
+		if err := adminSrv.Shutdown(ctx); err != nil {
+			log.Printf("Erreur lors de l'arrêt du serveur d'administration: %v", err)
+		}
+	}
+
+	if mtlsSrv != nil {
+		if err := mtlsSrv.Shutdown(ctx); err != nil {
+			log.Printf("Erreur lors de l'arrêt du serveur mTLS: %v", err)
+		}
+	}
+
 	if err := srv.Shutdown(ctx); err != nil {
 		log.Fatalf("Erreur lors de l'arrêt du serveur: %v", err)
 	}
 
+	// Écrire les derniers appels API accumulés
+	if err := usageBuffer.Flush(ctx); err != nil {
+		log.Printf("Erreur lors de l'écriture de l'usage de l'API: %v", err)
+	}
+
 	log.Println("Serveur arrêté")
 }
@@ -0,0 +1,281 @@
+// filepath: cmd/seed/main.go
+
+// Commande seed : prépare un environnement de développement local.
+// Elle applique les migrations puis crée une organisation de démonstration,
+// des utilisateurs aux mots de passe connus, des plans, des projets,
+// des environnements et des secrets d'exemple. Elle peut être relancée
+// sans dupliquer les données.
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+
+	"secrets-manager/internal/auth"
+	"secrets-manager/internal/config"
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+	mysqldb "secrets-manager/internal/storage/mysql"
+	"secrets-manager/internal/vault"
+)
+
+// Données de démonstration
+var (
+	demoPlans = []models.Plan{
+		{ID: "plan-micro", Name: "Micro", Description: "Pour découvrir le service", Price: 0, BillingCycle: "monthly", SecretsLimit: 5},
+		{ID: "plan-startup", Name: "Startup", Description: "Pour les petites équipes", Price: 19, BillingCycle: "monthly", SecretsLimit: 100},
+		{ID: "plan-business", Name: "Business", Description: "Pour les entreprises", Price: 99, BillingCycle: "monthly", SecretsLimit: 1000},
+		{ID: "plan-enterprise", Name: "Enterprise", Description: "Sans limite pratique", Price: 499, BillingCycle: "monthly", SecretsLimit: 100000},
+	}
+
+	demoUsers = []struct {
+		Email, Password, FirstName, LastName, Role string
+	}{
+		{"admin@demo.local", "demo-admin-password", "Ada", "Admin", "admin"},
+		{"dev@demo.local", "demo-member-password", "Dev", "Member", "member"},
+		{"viewer@demo.local", "demo-viewer-password", "Vic", "Viewer", "viewer"},
+	}
+
+	demoOrganization = "Demo Corp"
+	demoProjects     = []string{"backend", "frontend"}
+	demoEnvironments = []string{"dev", "staging", "prod"}
+	demoSecrets      = []string{"DATABASE_URL", "API_KEY"}
+)
+
+func main() {
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Erreur de chargement de la configuration: %v", err)
+	}
+
+	db, err := mysqldb.NewConnection(cfg.Database)
+	if err != nil {
+		log.Fatalf("Erreur de connexion à la base de données: %v", err)
+	}
+	defer db.Close()
+
+	vaultClient, err := vault.NewClient(&vault.Config{
+		Address: cfg.Vault.Address,
+		Token:   cfg.Vault.Token,
+	})
+	if err != nil {
+		log.Fatalf("Erreur de connexion à Vault: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	if err := mysqldb.Migrate(ctx, db); err != nil {
+		log.Fatalf("Erreur d'application des migrations: %v", err)
+	}
+
+	s := &seeder{
+		db:            db,
+		users:         mysqldb.NewUsersRepository(db),
+		organizations: mysqldb.NewOrganizationsRepository(db),
+		secrets:       mysqldb.NewSecretsRepository(db),
+		subscriptions: storage.NewSubscriptionService(db, mysqldb.NewSettingsHistoryRepository(db)),
+		vaultService:  vault.NewService(vaultClient),
+		authService:   auth.NewService(mysqldb.NewUsersRepository(db), mysqldb.NewUserMFARepository(db), mysqldb.NewRefreshTokensRepository(db), cfg.JWT.Secret, auth.Issuer{Name: cfg.JWT.Issuer, Audience: cfg.JWT.Audience}, cfg.JWT.Expiration, cfg.JWT.RefreshExpiration),
+	}
+
+	if err := s.run(ctx); err != nil {
+		log.Fatalf("Erreur lors du seed: %v", err)
+	}
+}
+
+type seeder struct {
+	db            *sql.DB
+	users         *mysqldb.UsersRepository
+	organizations *mysqldb.OrganizationsRepository
+	secrets       *mysqldb.SecretsRepository
+	subscriptions *storage.SubscriptionService
+	vaultService  *vault.Service
+	authService   *auth.Service
+}
+
+func (s *seeder) run(ctx context.Context) error {
+	if err := s.seedPlans(ctx); err != nil {
+		return fmt.Errorf("plans: %w", err)
+	}
+
+	userIDs := make(map[string]string, len(demoUsers))
+	for _, u := range demoUsers {
+		id, err := s.seedUser(ctx, u.Email, u.Password, u.FirstName, u.LastName)
+		if err != nil {
+			return fmt.Errorf("utilisateur %s: %w", u.Email, err)
+		}
+		userIDs[u.Email] = id
+	}
+
+	owner := demoUsers[0]
+	org, err := s.seedOrganization(ctx, userIDs[owner.Email])
+	if err != nil {
+		return fmt.Errorf("organisation: %w", err)
+	}
+
+	for _, u := range demoUsers[1:] {
+		if err := s.organizations.AddUserToOrganization(ctx, userIDs[u.Email], org.ID, u.Role); err != nil {
+			return fmt.Errorf("membre %s: %w", u.Email, err)
+		}
+	}
+
+	active, err := s.subscriptions.CheckSubscriptionStatus(ctx, org.ID)
+	if err != nil {
+		return fmt.Errorf("abonnement: %w", err)
+	}
+	if !active {
+		if err := s.subscriptions.UpgradeToPlan(ctx, org.ID, "plan-startup", 12); err != nil {
+			return fmt.Errorf("abonnement: %w", err)
+		}
+	}
+
+	for _, project := range demoProjects {
+		projectID, err := s.seedProject(ctx, org.ID, project, userIDs[owner.Email])
+		if err != nil {
+			return fmt.Errorf("projet %s: %w", project, err)
+		}
+
+		for _, env := range demoEnvironments {
+			if err := s.seedSecrets(ctx, org.ID, projectID, env, userIDs[owner.Email]); err != nil {
+				return fmt.Errorf("secrets %s/%s: %w", project, env, err)
+			}
+		}
+	}
+
+	fmt.Printf("Organisation de démonstration: %s (%s)\n", org.Name, org.ID)
+	for _, u := range demoUsers {
+		fmt.Printf("  %-8s %-20s %s\n", u.Role, u.Email, u.Password)
+	}
+	return nil
+}
+
+func (s *seeder) seedPlans(ctx context.Context) error {
+	for _, plan := range demoPlans {
+		_, err := s.db.ExecContext(ctx, `
+			INSERT INTO plans (id, name, description, price, billing_cycle, secrets_limit, created_at, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?, NOW(), NOW())
+			ON DUPLICATE KEY UPDATE name = VALUES(name), secrets_limit = VALUES(secrets_limit)
+		`, plan.ID, plan.Name, plan.Description, plan.Price, plan.BillingCycle, plan.SecretsLimit)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *seeder) seedUser(ctx context.Context, email, password, firstName, lastName string) (string, error) {
+	user, err := s.authService.RegisterUser(ctx, &auth.Credentials{Email: email, Password: password}, firstName, lastName)
+	if err == nil {
+		return user.ID, nil
+	}
+	if !errors.Is(err, auth.ErrUserExists) {
+		return "", err
+	}
+
+	existing, err := s.users.GetUserByEmail(ctx, email)
+	if err != nil {
+		return "", err
+	}
+	return existing.ID, nil
+}
+
+func (s *seeder) seedOrganization(ctx context.Context, ownerID string) (*models.Organization, error) {
+	org := &models.Organization{
+		Name:        demoOrganization,
+		Description: "Organisation de démonstration",
+		PlanID:      "plan-startup",
+		OwnerID:     ownerID,
+	}
+	err := s.organizations.CreateOrganization(ctx, org)
+	if err == nil {
+		return org, nil
+	}
+	if !errors.Is(err, storage.ErrAlreadyExists) {
+		return nil, err
+	}
+
+	orgs, err := s.organizations.ListUserOrganizations(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	for _, existing := range orgs {
+		if existing.Name == demoOrganization {
+			return existing, nil
+		}
+	}
+	return nil, fmt.Errorf("l'organisation %q existe mais n'appartient pas à %s", demoOrganization, ownerID)
+}
+
+// seedProject crée un projet et ses environnements avec des IDs déterministes
+func (s *seeder) seedProject(ctx context.Context, orgID, name, createdBy string) (string, error) {
+	projectID := uuid.NewSHA1(uuid.NameSpaceURL, []byte(orgID+"/"+name)).String()
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO projects (id, name, description, organization_id, created_at, updated_at, created_by)
+		VALUES (?, ?, ?, ?, NOW(), NOW(), ?)
+		ON DUPLICATE KEY UPDATE updated_at = updated_at
+	`, projectID, name, "Projet de démonstration "+name, orgID, createdBy)
+	if err != nil {
+		return "", err
+	}
+
+	for _, env := range demoEnvironments {
+		envID := uuid.NewSHA1(uuid.NameSpaceURL, []byte(projectID+"/"+env)).String()
+		_, err := s.db.ExecContext(ctx, `
+			INSERT INTO environments (id, name, description, project_id, created_at, updated_at)
+			VALUES (?, ?, ?, ?, NOW(), NOW())
+			ON DUPLICATE KEY UPDATE updated_at = updated_at
+		`, envID, env, "Environnement "+env, projectID)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return projectID, nil
+}
+
+func (s *seeder) seedSecrets(ctx context.Context, orgID, projectID, env, createdBy string) error {
+	for _, name := range demoSecrets {
+		existing, err := s.secrets.GetSecretMetadataByPath(ctx, orgID, projectID, env, name)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			continue
+		}
+
+		secret := &models.Secret{
+			Name:           name,
+			Value:          fmt.Sprintf("demo-%s-%s", env, uuid.New().String()[:8]),
+			Description:    "Secret de démonstration",
+			OrganizationID: orgID,
+			ProjectID:      projectID,
+			Environment:    env,
+			CreatedBy:      createdBy,
+			Version:        1,
+		}
+		if err := s.vaultService.StoreSecret(ctx, secret); err != nil {
+			return err
+		}
+
+		metadata := &models.SecretMetadata{
+			Name:           secret.Name,
+			Description:    secret.Description,
+			OrganizationID: orgID,
+			ProjectID:      projectID,
+			Environment:    env,
+			CreatedBy:      createdBy,
+			Version:        1,
+		}
+		if err := s.secrets.CreateSecretMetadata(ctx, metadata); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,249 @@
+// filepath: cmd/smadmin/main.go
+
+// Commande smadmin : opérations d'exploitation de secrets-manager.
+//
+// Les métadonnées MySQL (utilisateurs, organisations, projets, métadonnées
+// de secrets, abonnements, revues des accès...) sont répliquées de façon
+// asynchrone vers une base de secours dans une autre région lorsque DR_DB_HOST
+// est défini : les triggers du primaire alimentent metadata_changes, que le
+// serveur API rejoue sur la base de secours toutes les DR_REPLICATION_INTERVAL.
+// Les valeurs des secrets ne passent pas par ce flux : elles relèvent de la
+// réplication DR de Vault.
+//
+// Sous-commandes :
+//
+//	smadmin replication-status   position de la base de secours et retard
+//	smadmin replication-seed     rejoue toutes les lignes existantes (activation
+//	                             de la réplication sur une base déjà peuplée)
+//	smadmin failover [-force]    promeut la base de secours
+//	smadmin verify-export -key K archive.zip
+//	                             vérifie la signature d'un export (preuves de
+//	                             conformité, journal d'audit d'administration)
+//
+// Procédure de basculement après la perte de la région primaire :
+//
+//  1. Arrêter les serveurs API de la région primaire s'ils répondent encore,
+//     pour qu'aucune écriture n'arrive pendant le basculement.
+//  2. Promouvoir le cluster Vault de secours (vault write -f
+//     sys/replication/dr/secondary/promote) afin que les valeurs soient
+//     disponibles.
+//  3. Lancer smadmin failover avec la configuration habituelle (DB_* pour
+//     le primaire, DR_DB_* pour la base de secours). Si le primaire répond,
+//     les changements en attente sont appliqués avant la promotion ; s'il
+//     est injoignable, -force accepte de perdre les changements non répliqués
+//     (leur nombre n'est alors pas connu).
+//  4. Démarrer les serveurs API de la région de secours avec DB_* pointant
+//     vers la base promue, VAULT_ADDR vers le cluster Vault promu et sans
+//     DR_DB_HOST.
+//
+// Une base promue refuse toute réplication : un ancien primaire qui
+// redémarre ne peut pas l'écraser. Pour revenir à la région d'origine, la
+// reconstruire comme base de secours de la base promue puis lancer
+// smadmin replication-seed.
+//
+// verify-export ne lit pas la configuration : un auditeur vérifie une
+// archive avec la seule clé publique du serveur (GET /api/v1/evidence/public-key).
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"secrets-manager/internal/config"
+	"secrets-manager/internal/evidence"
+	mysqldb "secrets-manager/internal/storage/mysql"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	if os.Args[1] == "verify-export" {
+		if err := verifyExport(os.Args[2:]); err != nil {
+			log.Fatalf("Erreur: %v", err)
+		}
+		return
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Erreur de chargement de la configuration: %v", err)
+	}
+	if !cfg.Replication.Enabled() {
+		log.Fatal("DR_DB_HOST doit désigner la base de secours")
+	}
+
+	switch os.Args[1] {
+	case "replication-status":
+		err = replicationStatus(cfg)
+	case "replication-seed":
+		err = replicationSeed(cfg)
+	case "failover":
+		err = failover(cfg, os.Args[2:])
+	default:
+		usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		log.Fatalf("Erreur: %v", err)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: smadmin replication-status | replication-seed | failover [-force] | verify-export -key K archive.zip")
+}
+
+// verifyExport vérifie la signature et l'intégrité d'une archive exportée
+// puis affiche son manifeste
+func verifyExport(args []string) error {
+	flags := flag.NewFlagSet("verify-export", flag.ExitOnError)
+	key := flags.String("key", "", "clé publique Ed25519 du serveur (base64)")
+	flags.Parse(args)
+	if *key == "" || flags.NArg() != 1 {
+		return errors.New("usage: smadmin verify-export -key K archive.zip")
+	}
+
+	bundle, err := os.ReadFile(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	manifest, err := evidence.Verify(bundle, *key)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Signature valide.")
+	if manifest.OrganizationID != "" {
+		fmt.Printf("Organisation : %s\n", manifest.OrganizationID)
+	}
+	fmt.Printf("Période      : %s - %s\n", manifest.From.Format(time.RFC3339), manifest.To.Format(time.RFC3339))
+	fmt.Printf("Générée le   : %s par %s\n", manifest.GeneratedAt.Format(time.RFC3339), manifest.GeneratedBy)
+	for _, file := range manifest.Files {
+		fmt.Printf("  %s (%d octets, sha256 %s)\n", file.Name, file.Size, file.SHA256)
+	}
+	return nil
+}
+
+// replicationStatus affiche l'avance de la base de secours
+func replicationStatus(cfg *config.Config) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	primary, err := mysqldb.NewConnection(cfg.Database)
+	if err != nil {
+		return fmt.Errorf("primaire: %w", err)
+	}
+	defer primary.Close()
+	standby, err := mysqldb.NewConnection(cfg.Replication.Standby)
+	if err != nil {
+		return fmt.Errorf("base de secours: %w", err)
+	}
+	defer standby.Close()
+
+	status, err := mysqldb.NewReplicator(primary, standby).Status(ctx)
+	if err != nil {
+		return err
+	}
+	printStatus(status)
+	return nil
+}
+
+// replicationSeed rejoue toutes les lignes existantes des tables répliquées
+func replicationSeed(cfg *config.Config) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	primary, err := mysqldb.NewConnection(cfg.Database)
+	if err != nil {
+		return fmt.Errorf("primaire: %w", err)
+	}
+	defer primary.Close()
+	standby, err := mysqldb.NewConnection(cfg.Replication.Standby)
+	if err != nil {
+		return fmt.Errorf("base de secours: %w", err)
+	}
+	defer standby.Close()
+
+	count, err := mysqldb.NewReplicator(primary, standby).Seed(ctx)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%d lignes ajoutées au flux de réplication\n", count)
+	return nil
+}
+
+// failover applique les derniers changements si possible puis promeut la base de secours
+func failover(cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("failover", flag.ExitOnError)
+	force := flags.Bool("force", false, "promouvoir même si le primaire est injoignable ou si des changements restent en attente")
+	timeout := flags.Duration("timeout", 5*time.Minute, "durée maximale du rattrapage")
+	flags.Parse(args)
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	standby, err := mysqldb.NewConnection(cfg.Replication.Standby)
+	if err != nil {
+		return fmt.Errorf("base de secours: %w", err)
+	}
+	defer standby.Close()
+
+	primary, err := mysqldb.NewConnection(cfg.Database)
+	if err != nil {
+		if !*force {
+			return fmt.Errorf("primaire injoignable (%v) : relancer avec -force pour promouvoir sans rattrapage", err)
+		}
+		log.Printf("Primaire injoignable, promotion sans rattrapage: %v", err)
+	} else {
+		defer primary.Close()
+
+		replicator := mysqldb.NewReplicator(primary, standby)
+		if err := replicator.Replicate(ctx); err != nil {
+			if errors.Is(err, mysqldb.ErrStandbyPromoted) {
+				fmt.Println("La base de secours est déjà promue")
+				return nil
+			}
+			if !*force {
+				return fmt.Errorf("rattrapage impossible (%w) : relancer avec -force pour promouvoir quand même", err)
+			}
+			log.Printf("Rattrapage interrompu: %v", err)
+		}
+
+		status, err := replicator.Status(ctx)
+		if err != nil && !*force {
+			return err
+		}
+		if status != nil {
+			printStatus(status)
+			if status.Pending() > 0 && !*force {
+				return fmt.Errorf("%d changements en attente : arrêter les serveurs API du primaire puis relancer", status.Pending())
+			}
+		}
+	}
+
+	if err := mysqldb.PromoteStandby(ctx, standby); err != nil {
+		return fmt.Errorf("promotion: %w", err)
+	}
+	fmt.Printf("Base de secours %s promue.\n", cfg.Replication.Standby.Host)
+	fmt.Println("Démarrer les serveurs API de la région de secours avec DB_HOST sur cette base, VAULT_ADDR sur le cluster Vault promu et sans DR_DB_HOST.")
+	return nil
+}
+
+// printStatus affiche la position de la base de secours
+func printStatus(status *mysqldb.ReplicationStatus) {
+	fmt.Printf("Dernier changement du primaire : %d\n", status.LatestChangeID)
+	fmt.Printf("Dernier changement appliqué    : %d\n", status.AppliedChangeID)
+	fmt.Printf("Changements en attente         : %d\n", status.Pending())
+	if lag := status.Lag(time.Now()); lag > 0 {
+		fmt.Printf("Retard                         : %s\n", lag.Round(time.Second))
+	}
+	if status.PromotedAt != nil {
+		fmt.Printf("Promue le                      : %s\n", status.PromotedAt.Format(time.RFC3339))
+	}
+}
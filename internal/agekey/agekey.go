@@ -0,0 +1,184 @@
+// filepath: internal/agekey/agekey.go
+
+// Package agekey génère et lit les clés X25519 au format age, utilisées par
+// sops pour chiffrer les fichiers de configuration des dépôts. L'identité
+// (clé privée) s'écrit AGE-SECRET-KEY-1..., le destinataire (clé publique)
+// age1... ; les deux sont encodés en Bech32 (BIP 173).
+package agekey
+
+import (
+	"crypto/ecdh"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Préfixes Bech32 des identités et des destinataires age
+const (
+	identityPrefix  = "age-secret-key-"
+	recipientPrefix = "age"
+)
+
+// ErrInvalidIdentity indique une identité age mal formée
+var ErrInvalidIdentity = errors.New("identité age invalide")
+
+// Identity est une paire de clés age
+type Identity struct {
+	// Secret est l'identité, au format AGE-SECRET-KEY-1...
+	Secret string
+	// Recipient est le destinataire correspondant, au format age1...
+	Recipient string
+}
+
+// Generate crée une nouvelle identité age
+func Generate() (*Identity, error) {
+	key, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	return newIdentity(key)
+}
+
+// ParseIdentity lit une identité AGE-SECRET-KEY-1... et calcule son destinataire
+func ParseIdentity(secret string) (*Identity, error) {
+	hrp, data, err := decode(secret)
+	if err != nil || hrp != identityPrefix {
+		return nil, ErrInvalidIdentity
+	}
+	raw, err := convertBits(data, 5, 8, false)
+	if err != nil {
+		return nil, ErrInvalidIdentity
+	}
+	key, err := ecdh.X25519().NewPrivateKey(raw)
+	if err != nil {
+		return nil, ErrInvalidIdentity
+	}
+	return newIdentity(key)
+}
+
+func newIdentity(key *ecdh.PrivateKey) (*Identity, error) {
+	secret, err := convertBits(key.Bytes(), 8, 5, true)
+	if err != nil {
+		return nil, err
+	}
+	public, err := convertBits(key.PublicKey().Bytes(), 8, 5, true)
+	if err != nil {
+		return nil, err
+	}
+	return &Identity{
+		Secret:    strings.ToUpper(encode(identityPrefix, secret)),
+		Recipient: encode(recipientPrefix, public),
+	}, nil
+}
+
+// Format écrit l'identité comme age-keygen : date de création et
+// destinataire en commentaires, puis l'identité. Plusieurs blocs mis bout à
+// bout forment un fichier de clés accepté par SOPS_AGE_KEY.
+func (i *Identity) Format(createdAt time.Time) string {
+	return fmt.Sprintf("# created: %s\n# public key: %s\n%s\n",
+		createdAt.UTC().Format(time.RFC3339), i.Recipient, i.Secret)
+}
+
+// Alphabet de l'encodage Bech32
+const charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+var generator = [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+
+func polymod(values []byte) uint32 {
+	chk := uint32(1)
+	for _, v := range values {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i, g := range generator {
+			if (top>>i)&1 == 1 {
+				chk ^= g
+			}
+		}
+	}
+	return chk
+}
+
+func hrpExpand(hrp string) []byte {
+	expanded := make([]byte, 0, 2*len(hrp)+1)
+	for i := 0; i < len(hrp); i++ {
+		expanded = append(expanded, hrp[i]>>5)
+	}
+	expanded = append(expanded, 0)
+	for i := 0; i < len(hrp); i++ {
+		expanded = append(expanded, hrp[i]&31)
+	}
+	return expanded
+}
+
+// encode encode des groupes de 5 bits en Bech32, en minuscules
+func encode(hrp string, data []byte) string {
+	values := append(hrpExpand(hrp), data...)
+	mod := polymod(append(values, 0, 0, 0, 0, 0, 0)) ^ 1
+
+	var b strings.Builder
+	b.WriteString(hrp)
+	b.WriteByte('1')
+	for _, v := range data {
+		b.WriteByte(charset[v])
+	}
+	for i := 0; i < 6; i++ {
+		b.WriteByte(charset[(mod>>(5*(5-i)))&31])
+	}
+	return b.String()
+}
+
+// decode décode une chaîne Bech32 (tout en minuscules ou tout en
+// majuscules) et renvoie son préfixe et ses groupes de 5 bits
+func decode(s string) (string, []byte, error) {
+	if strings.ToLower(s) != s && strings.ToUpper(s) != s {
+		return "", nil, fmt.Errorf("casse mixte")
+	}
+	s = strings.ToLower(s)
+	pos := strings.LastIndexByte(s, '1')
+	if pos < 1 || pos+7 > len(s) {
+		return "", nil, fmt.Errorf("séparateur mal placé")
+	}
+
+	hrp := s[:pos]
+	data := make([]byte, 0, len(s)-pos-1)
+	for i := pos + 1; i < len(s); i++ {
+		v := strings.IndexByte(charset, s[i])
+		if v < 0 {
+			return "", nil, fmt.Errorf("caractère invalide %q", s[i])
+		}
+		data = append(data, byte(v))
+	}
+	if polymod(append(hrpExpand(hrp), data...)) != 1 {
+		return "", nil, fmt.Errorf("somme de contrôle invalide")
+	}
+	return hrp, data[:len(data)-6], nil
+}
+
+// convertBits regroupe des valeurs de from bits en valeurs de to bits
+func convertBits(data []byte, from, to uint, pad bool) ([]byte, error) {
+	var acc uint32
+	var bits uint
+	maxv := uint32(1)<<to - 1
+	converted := make([]byte, 0, len(data)*int(from)/int(to)+1)
+	for _, v := range data {
+		if uint32(v)>>from != 0 {
+			return nil, fmt.Errorf("valeur hors limites")
+		}
+		acc = acc<<from | uint32(v)
+		bits += from
+		for bits >= to {
+			bits -= to
+			converted = append(converted, byte(acc>>bits&maxv))
+		}
+	}
+	if pad {
+		if bits > 0 {
+			converted = append(converted, byte(acc<<(to-bits)&maxv))
+		}
+	} else if bits >= from || acc<<(to-bits)&maxv != 0 {
+		return nil, fmt.Errorf("remplissage invalide")
+	}
+	return converted, nil
+}
@@ -0,0 +1,51 @@
+// filepath: internal/agekey/agekey_test.go
+
+package agekey
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBech32(t *testing.T) {
+	// Vecteur de test valide de BIP 173
+	const vector = "abcdef1qpzry9x8gf2tvdw0s3jn54khce6mua7lmqqqxw"
+	hrp, data, err := decode(strings.ToUpper(vector))
+	if err != nil || hrp != "abcdef" || len(data) != 32 {
+		t.Fatalf("Expected the test vector to decode, got %q %v %v", hrp, data, err)
+	}
+	if encoded := encode(hrp, data); encoded != vector {
+		t.Errorf("Expected %s, got %s", vector, encoded)
+	}
+	if _, _, err := decode(vector[:len(vector)-1] + "q"); err == nil {
+		t.Errorf("Expected a checksum error")
+	}
+}
+
+func TestGenerateIdentity(t *testing.T) {
+	identity, err := Generate()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !strings.HasPrefix(identity.Secret, "AGE-SECRET-KEY-1") || !strings.HasPrefix(identity.Recipient, "age1") {
+		t.Errorf("Expected age encoded keys, got %+v", identity)
+	}
+
+	parsed, err := ParseIdentity(identity.Secret)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if parsed.Recipient != identity.Recipient {
+		t.Errorf("Expected recipient %s, got %s", identity.Recipient, parsed.Recipient)
+	}
+	if _, err := ParseIdentity(identity.Recipient); err != ErrInvalidIdentity {
+		t.Errorf("Expected ErrInvalidIdentity for a recipient, got %v", err)
+	}
+
+	formatted := identity.Format(time.Date(2026, 10, 16, 8, 0, 0, 0, time.UTC))
+	expected := "# created: 2026-10-16T08:00:00Z\n# public key: " + identity.Recipient + "\n" + identity.Secret + "\n"
+	if formatted != expected {
+		t.Errorf("Expected %q, got %q", expected, formatted)
+	}
+}
@@ -0,0 +1,94 @@
+// filepath: internal/api/access_review_test.go
+
+package api_test
+
+import (
+	"encoding/csv"
+	"net/http"
+	"testing"
+
+	"secrets-manager/internal/api/apitest"
+	"secrets-manager/internal/models"
+)
+
+func TestAccessReviewCampaign(t *testing.T) {
+	srv := apitest.NewServer(t)
+	ownerID := srv.Register("owner@example.com", "password123")
+	token := srv.Login("owner@example.com", "password123")
+	activeID := srv.Register("active@example.com", "password123")
+	dormantID := srv.Register("dormant@example.com", "password123")
+	org := srv.CreateOrganization("acme", ownerID)
+	srv.AddMember(org.ID, activeID, "member")
+	srv.AddMember(org.ID, dormantID, "viewer")
+
+	// Seul active@ a appelé l'API de l'organisation
+	activeToken := srv.Login("active@example.com", "password123")
+	srv.Do(http.MethodGet, "/api/v1/organizations/"+org.ID+"/projects", activeToken, nil)
+
+	base := "/api/v1/organizations/" + org.ID + "/access-reviews"
+
+	// Réservé aux administrateurs
+	resp := srv.Do(http.MethodPost, base, activeToken, nil)
+	apitest.ExpectStatus(t, resp, http.StatusForbidden)
+
+	resp = srv.Do(http.MethodPost, base, token, nil)
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+	var review models.AccessReview
+	apitest.DecodeJSON(t, resp, &review)
+	if review.TotalItems != 3 || review.PendingItems != 3 {
+		t.Fatalf("Expected 3 pending items, got %d/%d", review.PendingItems, review.TotalItems)
+	}
+	if item := review.Item("user", activeID); item == nil || item.Dormant || item.LastActivity == "" {
+		t.Errorf("Expected active member not to be dormant, got %+v", item)
+	}
+	if item := review.Item("user", dormantID); item == nil || !item.Dormant {
+		t.Errorf("Expected dormant member to be flagged, got %+v", item)
+	}
+
+	items := base + "/" + review.ID + "/items/"
+
+	// Le propriétaire ne peut pas être révoqué
+	resp = srv.Do(http.MethodPost, items+ownerID+"/revoke", token, nil)
+	apitest.ExpectStatus(t, resp, http.StatusConflict)
+
+	resp = srv.Do(http.MethodPost, items+ownerID+"/confirm", token, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	resp = srv.Do(http.MethodPost, items+activeID+"/confirm", token, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	resp = srv.Do(http.MethodPost, items+dormantID+"/revoke", token, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+
+	// Une décision est définitive
+	resp = srv.Do(http.MethodPost, items+activeID+"/revoke", token, nil)
+	apitest.ExpectStatus(t, resp, http.StatusConflict)
+
+	// Le membre révoqué a perdu l'accès à l'organisation
+	dormantToken := srv.Login("dormant@example.com", "password123")
+	resp = srv.Do(http.MethodGet, "/api/v1/organizations/"+org.ID+"/projects", dormantToken, nil)
+	apitest.ExpectStatus(t, resp, http.StatusNotFound)
+
+	resp = srv.Do(http.MethodGet, base+"/"+review.ID, token, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	apitest.DecodeJSON(t, resp, &review)
+	if review.Status != models.AccessReviewCompleted || review.PendingItems != 0 || review.CompletedAt == nil {
+		t.Errorf("Expected completed review, got status %s with %d pending", review.Status, review.PendingItems)
+	}
+
+	resp = srv.Do(http.MethodGet, base+"/"+review.ID+"/export", token, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	defer resp.Body.Close()
+	records, err := csv.NewReader(resp.Body).ReadAll()
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if len(records) != 4 {
+		t.Fatalf("Expected header and 3 rows, got %d records", len(records))
+	}
+	decisions := map[string]string{}
+	for _, record := range records[1:] {
+		decisions[record[2]] = record[6]
+	}
+	if decisions["dormant@example.com"] != models.AccessDecisionRevoked || decisions["active@example.com"] != models.AccessDecisionConfirmed {
+		t.Errorf("Unexpected exported decisions: %v", decisions)
+	}
+}
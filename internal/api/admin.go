@@ -0,0 +1,96 @@
+// filepath: internal/api/admin.go
+
+package api
+
+import (
+	"net/http"
+	"net/http/pprof"
+
+	"github.com/gorilla/mux"
+
+	"secrets-manager/internal/api/handlers"
+	"secrets-manager/internal/api/middleware"
+	"secrets-manager/internal/events"
+	"secrets-manager/internal/metrics"
+)
+
+// ConfigureAdminRoutes configure les routes du listener d'administration.
+// Ces routes ne doivent jamais être exposées sur le listener public.
+func ConfigureAdminRoutes(router *mux.Router, adminToken string, deps *Dependencies) {
+	router.Use(middleware.Recover)
+	router.Use(middleware.StaticToken(adminToken))
+	router.Use(middleware.AdminAudit(deps.AdminAudit, "/metrics"))
+
+	debugHandler := handlers.NewDebugHandler()
+	logLevelHandler := handlers.NewLogLevelHandler()
+	adminAuditHandler := handlers.NewAdminAuditHandler(deps.AdminAudit, deps.EvidenceSigner)
+	recycleBinHandler := handlers.NewRecycleBinHandler(deps.Organizations, deps.OrganizationDeletions,
+		deps.OrganizationDeleter, deps.Projects, deps.RecycleRetention)
+	vaultClustersHandler := handlers.NewVaultClustersHandler(deps.VaultClusters)
+
+	// Profilage pprof
+	router.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	router.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	router.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	router.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	router.PathPrefix("/debug/pprof/").HandlerFunc(pprof.Index)
+
+	// Métriques au format Prometheus
+	router.Handle("/metrics", metrics.Handler()).Methods("GET")
+
+	// Informations d'exécution
+	router.HandleFunc("/debug/goroutines", debugHandler.Goroutines).Methods("GET")
+	router.HandleFunc("/debug/buildinfo", debugHandler.BuildInfo).Methods("GET")
+
+	// Niveaux de log par composant (http, vault, storage)
+	router.HandleFunc("/debug/loglevel", logLevelHandler.GetLevels).Methods("GET")
+	router.HandleFunc("/debug/loglevel", logLevelHandler.SetLevel).Methods("PUT")
+
+	// Journal d'audit des routes d'administration et son export signé
+	router.Handle("/admin/audit",
+		middleware.Compress(http.HandlerFunc(adminAuditHandler.ListAdminAuditLogs))).Methods("GET")
+	router.HandleFunc("/admin/audit/export", adminAuditHandler.ExportAdminAuditLogs).Methods("GET")
+
+	// Corbeille : organisations et projets supprimés, restaurables jusqu'à leur purge
+	router.Handle("/admin/recycle-bin",
+		middleware.Compress(http.HandlerFunc(recycleBinHandler.ListRecycleBin))).Methods("GET")
+	router.Handle("/admin/recycle-bin/organizations/{orgID}/restore",
+		middleware.Invalidates(deps.Events, events.ResourceOrganization)(
+			http.HandlerFunc(recycleBinHandler.RestoreOrganization))).Methods("POST")
+	router.Handle("/admin/recycle-bin/organizations/{orgID}/projects/{projectID}/restore",
+		middleware.Invalidates(deps.Events, events.ResourceProjects, events.ResourceSecrets)(
+			http.HandlerFunc(recycleBinHandler.RestoreProject))).Methods("POST")
+
+	// Interrupteurs : coupure à chaud de routes ou de fonctionnalités
+	if deps.Switches != nil {
+		switchesHandler := handlers.NewSwitchesHandler(deps.Switches)
+		router.HandleFunc("/admin/switches", switchesHandler.ListSwitches).Methods("GET")
+		router.HandleFunc("/admin/switches", switchesHandler.DisableSwitch).Methods("PUT")
+		router.HandleFunc("/admin/switches", switchesHandler.EnableSwitch).Methods("DELETE")
+	}
+
+	// Usurpation d'identité par le support : token de courte durée agissant
+	// au nom d'un utilisateur, dont chaque requête est auditée. Sans
+	// ADMIN_TOKEN, tout processus local atteindrait ces routes : elles ne
+	// sont alors pas exposées.
+	if deps.Impersonations != nil && adminToken != "" {
+		impersonationsHandler := handlers.NewImpersonationsHandler(deps.AuthService, deps.Impersonations, deps.AdminAudit)
+		router.HandleFunc("/admin/impersonations", impersonationsHandler.StartImpersonation).Methods("POST")
+		router.HandleFunc("/admin/impersonations/{impersonationID}",
+			impersonationsHandler.EndImpersonation).Methods("DELETE")
+	}
+
+	// Politique d'inscription : mode (open, restricted, closed) et listes
+	// d'autorisation et de refus, à la place de celle de la configuration
+	if deps.RegistrationPolicy != nil {
+		registrationPolicyHandler := handlers.NewRegistrationPolicyHandler(deps.AuthService, deps.RegistrationPolicy)
+		router.HandleFunc("/admin/registration-policy", registrationPolicyHandler.GetPolicy).Methods("GET")
+		router.HandleFunc("/admin/registration-policy", registrationPolicyHandler.UpdatePolicy).Methods("PUT")
+		router.HandleFunc("/admin/registration-policy", registrationPolicyHandler.ResetPolicy).Methods("DELETE")
+	}
+
+	// Santé et réplication des clusters Vault
+	router.HandleFunc("/admin/vault/clusters", vaultClustersHandler.ListClusters).Methods("GET")
+
+	router.NotFoundHandler = http.NotFoundHandler()
+}
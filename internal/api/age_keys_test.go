@@ -0,0 +1,101 @@
+// filepath: internal/api/age_keys_test.go
+
+package api_test
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"secrets-manager/internal/agekey"
+	"secrets-manager/internal/api/apitest"
+	"secrets-manager/internal/api/handlers"
+	"secrets-manager/internal/models"
+)
+
+func TestAgeKeys(t *testing.T) {
+	srv := apitest.NewServer(t)
+	ownerID := srv.Register("owner@example.com", "password123")
+	owner := srv.Login("owner@example.com", "password123")
+	memberID := srv.Register("member@example.com", "password123")
+	member := srv.Login("member@example.com", "password123")
+	org := srv.CreateOrganization("acme", ownerID)
+	srv.AddMember(org.ID, memberID, "member")
+	project := srv.CreateProject(org.ID, "infra", ownerID)
+
+	keys := "/api/v1/organizations/" + org.ID + "/projects/" + project.ID + "/age-keys"
+	resp := srv.Do(http.MethodPost, keys, member, map[string]string{"repository": "infra"})
+	apitest.ExpectStatus(t, resp, http.StatusForbidden)
+	resp = srv.Do(http.MethodPost, keys, owner, map[string]string{"repository": ".."})
+	apitest.ExpectStatus(t, resp, http.StatusBadRequest)
+	resp = srv.Do(http.MethodPost, keys, owner, map[string]string{"repository": "infra"})
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+	var first models.AgeKey
+	apitest.DecodeJSON(t, resp, &first)
+	if !strings.HasPrefix(first.Recipient, "age1") || first.Status != models.AgeKeyActive {
+		t.Errorf("Expected an active age key, got %+v", first)
+	}
+	resp = srv.Do(http.MethodPost, keys, owner, map[string]string{"repository": "infra"})
+	apitest.ExpectStatus(t, resp, http.StatusConflict)
+	resp = srv.Do(http.MethodPost, keys+"/website:rotate", owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusNotFound)
+
+	// La rotation conserve la clé précédente pour déchiffrer
+	resp = srv.Do(http.MethodPost, keys+"/infra:rotate", owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+	var second models.AgeKey
+	apitest.DecodeJSON(t, resp, &second)
+
+	readIdentities := func(t *testing.T, token string) []string {
+		t.Helper()
+		resp := srv.Do(http.MethodGet, keys+"/infra/identities", token, nil)
+		apitest.ExpectStatus(t, resp, http.StatusOK)
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		var recipients []string
+		for _, line := range strings.Split(string(body), "\n") {
+			if strings.HasPrefix(line, "AGE-SECRET-KEY-1") {
+				identity, err := agekey.ParseIdentity(line)
+				if err != nil {
+					t.Fatalf("Expected a valid identity, got %q", line)
+				}
+				recipients = append(recipients, identity.Recipient)
+			}
+		}
+		return recipients
+	}
+	recipients := readIdentities(t, member)
+	if len(recipients) != 2 || recipients[0] != second.Recipient || recipients[1] != first.Recipient {
+		t.Fatalf("Expected the active then the retired identity, got %v", recipients)
+	}
+
+	resp = srv.Do(http.MethodGet, keys+"?repository=infra", member, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var listed []models.AgeKey
+	apitest.DecodeJSON(t, resp, &listed)
+	if len(listed) != 2 || listed[1].Status != models.AgeKeyRetired || listed[1].RetiredAt == nil {
+		t.Errorf("Expected the first key to be retired, got %+v", listed)
+	}
+
+	// Un token en lecture des secrets suffit à sops exec-env
+	resp = srv.Do(http.MethodPost, "/api/v1/me/tokens", owner, map[string]any{
+		"name": "ci", "scopes": []string{"secrets:read"}})
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+	var token handlers.CreatedToken
+	apitest.DecodeJSON(t, resp, &token)
+	if recipients := readIdentities(t, token.Token); len(recipients) != 2 {
+		t.Errorf("Expected 2 identities with a token, got %v", recipients)
+	}
+
+	// Seules les clés retirées se suppriment, elles ne sont alors plus servies
+	resp = srv.Do(http.MethodDelete, keys+"/infra/"+second.ID, owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusBadRequest)
+	resp = srv.Do(http.MethodDelete, keys+"/website/"+first.ID, owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusNotFound)
+	resp = srv.Do(http.MethodDelete, keys+"/infra/"+first.ID, owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusNoContent)
+	if recipients := readIdentities(t, member); len(recipients) != 1 || recipients[0] != second.Recipient {
+		t.Errorf("Expected only the active identity, got %v", recipients)
+	}
+}
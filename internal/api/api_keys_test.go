@@ -0,0 +1,272 @@
+// filepath: internal/api/api_keys_test.go
+
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"secrets-manager/internal/api/apitest"
+	"secrets-manager/internal/api/handlers"
+	"secrets-manager/internal/auth"
+	"secrets-manager/internal/models"
+)
+
+func TestAPIKeys(t *testing.T) {
+	srv := apitest.NewServer(t)
+	ownerID := srv.Register("owner@example.com", "password123")
+	owner := srv.Login("owner@example.com", "password123")
+	memberID := srv.Register("member@example.com", "password123")
+	member := srv.Login("member@example.com", "password123")
+	org := srv.CreateOrganization("acme", ownerID)
+	srv.AddMember(org.ID, memberID, "member")
+	project := srv.CreateProject(org.ID, "api", ownerID)
+	other := srv.CreateProject(org.ID, "billing", ownerID)
+	keys := "/api/v1/organizations/" + org.ID + "/api-keys"
+	secrets := "/api/v1/organizations/" + org.ID + "/projects/" + project.ID + "/environments/prod/secrets"
+
+	resp := srv.Do(http.MethodPost, secrets, owner, models.Secret{Name: "DB_PASSWORD", Value: "s3cret"})
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+
+	// Seuls les administrateurs gèrent les clés
+	creation := map[string]any{"name": "ci", "project_id": project.ID, "environment": "prod", "permission": "read"}
+	resp = srv.Do(http.MethodPost, keys, member, creation)
+	apitest.ExpectStatus(t, resp, http.StatusForbidden)
+	resp = srv.Do(http.MethodPost, keys, owner, map[string]any{"name": "ci", "permission": "admin"})
+	apitest.ExpectStatus(t, resp, http.StatusBadRequest)
+	resp = srv.Do(http.MethodPost, keys, owner, creation)
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+	var readKey handlers.CreatedAPIKey
+	apitest.DecodeJSON(t, resp, &readKey)
+	if !strings.HasPrefix(readKey.Key, "smak_") || !strings.HasPrefix(readKey.Key, readKey.Prefix) {
+		t.Fatalf("Expected an smak_ key matching its prefix, got %+v", readKey)
+	}
+
+	withKey := func(method, path, key string, body any) *http.Response {
+		return srv.DoWithHeaders(method, path, "", http.Header{"X-API-Key": {key}}, body)
+	}
+
+	// Lecture dans la portée de la clé, écriture refusée
+	resp = withKey(http.MethodGet, secrets+"/DB_PASSWORD", readKey.Key, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	resp = withKey(http.MethodPost, secrets, readKey.Key, models.Secret{Name: "API_TOKEN", Value: "t0ken"})
+	apitest.ExpectStatus(t, resp, http.StatusForbidden)
+
+	// Hors de la portée : autre projet, autre environnement, autres routes
+	for _, path := range []string{
+		"/api/v1/organizations/" + org.ID + "/projects/" + other.ID + "/environments/prod/secrets",
+		"/api/v1/organizations/" + org.ID + "/projects/" + project.ID + "/environments/staging/secrets",
+		"/api/v1/organizations/" + org.ID + "/projects",
+		keys,
+	} {
+		resp = withKey(http.MethodGet, path, readKey.Key, nil)
+		apitest.ExpectStatus(t, resp, http.StatusForbidden)
+	}
+
+	resp = withKey(http.MethodGet, secrets, "smak_unknown", nil)
+	apitest.ExpectStatus(t, resp, http.StatusUnauthorized)
+	resp = srv.DoWithHeaders(http.MethodGet, secrets, owner, http.Header{"X-API-Key": {readKey.Key}}, nil)
+	apitest.ExpectStatus(t, resp, http.StatusUnauthorized)
+
+	// Une clé en écriture sur tout le projet
+	resp = srv.Do(http.MethodPost, keys, owner, map[string]any{
+		"name": "deploy", "project_id": project.ID, "permission": "read_write", "expires_in_days": 30})
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+	var writeKey handlers.CreatedAPIKey
+	apitest.DecodeJSON(t, resp, &writeKey)
+	resp = withKey(http.MethodPost, secrets, writeKey.Key, models.Secret{Name: "API_TOKEN", Value: "t0ken"})
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+
+	resp = srv.Do(http.MethodGet, keys, owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var listed []map[string]any
+	apitest.DecodeJSON(t, resp, &listed)
+	if len(listed) != 2 || listed[0]["key"] != nil || listed[0]["key_hash"] != nil {
+		t.Errorf("Expected both keys without their value, got %+v", listed)
+	}
+
+	// Une clé révoquée est refusée
+	resp = srv.Do(http.MethodDelete, keys+"/"+readKey.ID, owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusNoContent)
+	resp = withKey(http.MethodGet, secrets, readKey.Key, nil)
+	apitest.ExpectStatus(t, resp, http.StatusUnauthorized)
+	resp = srv.Do(http.MethodDelete, keys+"/"+readKey.ID, owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusNotFound)
+}
+
+func TestSignedAPIKeyRequests(t *testing.T) {
+	srv := apitest.NewServer(t)
+	ownerID := srv.Register("owner@example.com", "password123")
+	owner := srv.Login("owner@example.com", "password123")
+	org := srv.CreateOrganization("acme", ownerID)
+	project := srv.CreateProject(org.ID, "api", ownerID)
+	keys := "/api/v1/organizations/" + org.ID + "/api-keys"
+	secrets := "/api/v1/organizations/" + org.ID + "/projects/" + project.ID + "/environments/prod/secrets"
+
+	resp := srv.Do(http.MethodPost, keys, owner, map[string]any{
+		"name": "ci", "project_id": project.ID, "permission": "read_write", "signed_requests": true})
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+	var signedKey handlers.CreatedAPIKey
+	apitest.DecodeJSON(t, resp, &signedKey)
+	if !signedKey.SignedRequests || signedKey.SigningKey == "" ||
+		signedKey.SigningKey == auth.HashPersonalAccessToken(signedKey.Key) {
+		t.Fatalf("Expected a signed key with its own signing key, got %+v", signedKey)
+	}
+	stored, err := srv.APIKeys.GetAPIKey(context.Background(), signedKey.ID)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if stored.WrappedSigningKey == "" || strings.Contains(stored.WrappedSigningKey, signedKey.SigningKey) {
+		t.Errorf("Expected the signing key to be stored wrapped, got %q", stored.WrappedSigningKey)
+	}
+	resp = srv.Do(http.MethodPost, keys, owner, map[string]any{"name": "legacy", "permission": "read"})
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+	var rawKey handlers.CreatedAPIKey
+	apitest.DecodeJSON(t, resp, &rawKey)
+
+	signed := func(method, path string, key handlers.CreatedAPIKey, signingKey string, at time.Time,
+		body, sent []byte) *http.Response {
+		timestamp := at.Unix()
+		header := http.Header{
+			"X-API-Key-ID":          {key.ID},
+			"X-Signature-Timestamp": {strconv.FormatInt(timestamp, 10)},
+			"X-Signature":           {auth.SignRequest(signingKey, method, path, timestamp, body)},
+		}
+		var payload any
+		if sent != nil {
+			payload = json.RawMessage(sent)
+		}
+		return srv.DoWithHeaders(method, path, "", header, payload)
+	}
+	body, err := json.Marshal(models.Secret{Name: "API_TOKEN", Value: "t0ken"})
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	now := time.Now()
+
+	// Requête signée acceptée, puis refusée si elle est rejouée
+	resp = signed(http.MethodPost, secrets, signedKey, signedKey.SigningKey, now, body, body)
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+	resp = signed(http.MethodPost, secrets, signedKey, signedKey.SigningKey, now, body, body)
+	apitest.ExpectStatus(t, resp, http.StatusUnauthorized)
+	resp = signed(http.MethodGet, secrets+"/API_TOKEN", signedKey, signedKey.SigningKey, now, nil, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+
+	// Une signature déjà retenue par une autre instance est refusée
+	replayed := now.Add(time.Second)
+	err = srv.APIKeys.ClaimRequestSignature(context.Background(), signedKey.ID,
+		auth.SignRequest(signedKey.SigningKey, http.MethodGet, secrets, replayed.Unix(), nil), replayed.Add(time.Minute))
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	resp = signed(http.MethodGet, secrets, signedKey, signedKey.SigningKey, replayed, nil, nil)
+	apitest.ExpectStatus(t, resp, http.StatusUnauthorized)
+
+	// Horodatage périmé, corps ou chemin modifiés, mauvaise clé de signature,
+	// empreinte de la clé conservée en base
+	tampered := []byte(strings.Replace(string(body), "t0ken", "evil", 1))
+	for _, resp := range []*http.Response{
+		signed(http.MethodGet, secrets, signedKey, signedKey.SigningKey, now.Add(-10*time.Minute), nil, nil),
+		signed(http.MethodGet, secrets, signedKey, signedKey.SigningKey, now.Add(10*time.Minute), nil, nil),
+		signed(http.MethodPut, secrets+"/API_TOKEN", signedKey, signedKey.SigningKey, now, body, tampered),
+		signed(http.MethodGet, secrets, signedKey, auth.HashPersonalAccessToken(rawKey.Key), now, nil, nil),
+		signed(http.MethodGet, secrets, signedKey, stored.KeyHash, now, nil, nil),
+	} {
+		apitest.ExpectStatus(t, resp, http.StatusUnauthorized)
+	}
+	otherPath := auth.SignRequest(signedKey.SigningKey, http.MethodGet, secrets+"/API_TOKEN", now.Unix(), nil)
+	header := http.Header{
+		"X-API-Key-ID":          {signedKey.ID},
+		"X-Signature-Timestamp": {strconv.FormatInt(now.Unix(), 10)},
+		"X-Signature":           {otherPath},
+	}
+	resp = srv.DoWithHeaders(http.MethodGet, secrets, "", header, nil)
+	apitest.ExpectStatus(t, resp, http.StatusUnauthorized)
+
+	// La clé signataire n'est jamais présentée, les autres clés ne signent pas
+	resp = srv.DoWithHeaders(http.MethodGet, secrets, "", http.Header{"X-API-Key": {signedKey.Key}}, nil)
+	apitest.ExpectStatus(t, resp, http.StatusUnauthorized)
+	resp = signed(http.MethodGet, secrets, rawKey, auth.HashPersonalAccessToken(rawKey.Key), now, nil, nil)
+	apitest.ExpectStatus(t, resp, http.StatusUnauthorized)
+	header.Set("X-API-Key", signedKey.Key)
+	resp = srv.DoWithHeaders(http.MethodGet, secrets+"/API_TOKEN", "", header, nil)
+	apitest.ExpectStatus(t, resp, http.StatusUnauthorized)
+}
+
+func TestAPIKeyLookupAndMetrics(t *testing.T) {
+	srv := apitest.NewServer(t)
+	ownerID := srv.Register("owner@example.com", "password123")
+	owner := srv.Login("owner@example.com", "password123")
+	org := srv.CreateOrganization("acme", ownerID)
+	otherOrg := srv.CreateOrganization("globex", ownerID)
+	project := srv.CreateProject(org.ID, "api", ownerID)
+	other := srv.CreateProject(org.ID, "billing", ownerID)
+	for _, target := range []struct{ project, env string }{{project.ID, "prod"}, {project.ID, "dev"}, {other.ID, "prod"}} {
+		resp := srv.Do(http.MethodPost, "/api/v1/organizations/"+org.ID+"/projects/"+target.project+"/environments/"+
+			target.env+"/secrets", owner, models.Secret{Name: "DB_PASSWORD", Value: "s3cret-" + target.env})
+		apitest.ExpectStatus(t, resp, http.StatusCreated)
+	}
+
+	resp := srv.Do(http.MethodPost, "/api/v1/organizations/"+org.ID+"/api-keys", owner, map[string]any{
+		"name": "ci", "project_id": project.ID, "environment": "prod", "permission": "read"})
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+	var key handlers.CreatedAPIKey
+	apitest.DecodeJSON(t, resp, &key)
+	resp = srv.Do(http.MethodPost, "/api/v1/organizations/"+org.ID+"/service-accounts", owner,
+		handlers.ServiceAccountCreation{Name: "deploy", Permission: models.APIKeyRead,
+			Resources: []models.ResourceScope{{ProjectID: project.ID, Environment: "prod"}}})
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+	var account handlers.CreatedServiceAccount
+	apitest.DecodeJSON(t, resp, &account)
+	accountToken, err := srv.AuthService.IssueServiceAccountToken(context.Background(), account.ID, account.Secret)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+
+	callers := map[string]func(path string) *http.Response{
+		"api key": func(path string) *http.Response {
+			return srv.DoWithHeaders(http.MethodGet, path, "", http.Header{"X-API-Key": {key.Key}}, nil)
+		},
+		"service account": func(path string) *http.Response {
+			return srv.Do(http.MethodGet, path, accountToken.AccessToken, nil)
+		},
+	}
+	for caller, get := range callers {
+		// Le lookup vérifie la portée sur le chemin demandé
+		resp = get("/api/v1/lookup?path=" + org.ID + "/" + project.ID + "/prod/DB_PASSWORD")
+		apitest.ExpectStatus(t, resp, http.StatusOK)
+		var result handlers.LookupResult
+		apitest.DecodeJSON(t, resp, &result)
+		if result.Value != "s3cret-prod" {
+			t.Errorf("Expected the %s to read the prod secret, got %q", caller, result.Value)
+		}
+		for _, path := range []string{
+			org.ID + "/" + project.ID + "/dev/DB_PASSWORD",
+			org.ID + "/" + other.ID + "/prod/DB_PASSWORD",
+			otherOrg.ID + "/" + project.ID + "/prod/DB_PASSWORD",
+		} {
+			resp = get("/api/v1/lookup?path=" + path)
+			apitest.ExpectStatus(t, resp, http.StatusForbidden)
+		}
+
+		// Les métriques ne comptent que les environnements couverts
+		resp = get("/api/v1/organizations/" + org.ID + "/metrics")
+		apitest.ExpectStatus(t, resp, http.StatusOK)
+		body, _ := io.ReadAll(resp.Body)
+		if !strings.Contains(string(body), `secrets_manager_org_secrets{project_id="`+project.ID+
+			`",project="api",environment="prod"} 1`) {
+			t.Errorf("Expected the %s to see the prod count, got:\n%s", caller, body)
+		}
+		if strings.Contains(string(body), `environment="dev"`) || strings.Contains(string(body), other.ID) {
+			t.Errorf("Expected the %s to see only its environment, got:\n%s", caller, body)
+		}
+		resp = get("/api/v1/organizations/" + otherOrg.ID + "/metrics")
+		apitest.ExpectStatus(t, resp, http.StatusForbidden)
+	}
+}
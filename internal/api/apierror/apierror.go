@@ -0,0 +1,210 @@
+// filepath: internal/api/apierror/apierror.go
+
+// Package apierror traduit les erreurs des services (storage, vault, auth)
+// en réponses HTTP. Le mapping est indépendant du transport : Map renvoie
+// un statut, un message et un éventuel délai de réessai, que l'API REST
+// écrit avec Write et qu'un autre serveur peut convertir à sa façon.
+package apierror
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"secrets-manager/internal/auth"
+	"secrets-manager/internal/logging"
+	"secrets-manager/internal/storage"
+	"secrets-manager/internal/vault"
+)
+
+// Délai de réessai conseillé lorsque Vault est indisponible
+const vaultRetryAfter = 5 * time.Second
+
+// ErrValidation est la catégorie des erreurs de validation des entrées
+var ErrValidation = errors.New("données invalides")
+
+// validationError porte le message destiné à l'appelant
+type validationError struct {
+	msg string
+}
+
+func (e *validationError) Error() string { return e.msg }
+
+func (e *validationError) Unwrap() error { return ErrValidation }
+
+// Validation crée une erreur de validation dont le message est renvoyé tel quel
+func Validation(msg string) error {
+	return &validationError{msg: msg}
+}
+
+// Mapping est la traduction d'une erreur pour l'appelant
+type Mapping struct {
+	Status  int
+	Message string
+	// RetryAfter est non nul si l'appelant peut réessayer plus tard
+	RetryAfter time.Duration
+}
+
+// Map traduit une erreur de service. Les erreurs inconnues donnent 500
+// avec un message vide, à remplacer par le message du handler.
+func Map(err error) Mapping {
+	var validation *validationError
+	var weakPassword *auth.PasswordPolicyError
+
+	switch {
+	case errors.As(err, &validation):
+		return Mapping{Status: http.StatusBadRequest, Message: validation.msg}
+	case errors.As(err, &weakPassword):
+		return Mapping{Status: http.StatusBadRequest, Message: weakPassword.Error()}
+	case errors.Is(err, ErrValidation):
+		return Mapping{Status: http.StatusBadRequest, Message: "Données invalides"}
+	case errors.Is(err, auth.ErrInvalidCredentials):
+		return Mapping{Status: http.StatusUnauthorized, Message: "Identifiants invalides"}
+	case errors.Is(err, auth.ErrInvalidToken), errors.Is(err, auth.ErrTokenExpired), errors.Is(err, auth.ErrTokenRevoked):
+		return Mapping{Status: http.StatusUnauthorized, Message: "Token invalide"}
+	case errors.Is(err, auth.ErrRefreshTokenReused):
+		return Mapping{Status: http.StatusUnauthorized, Message: "Token de rafraîchissement déjà utilisé : la session a été révoquée"}
+	case errors.Is(err, auth.ErrMFARequired):
+		return Mapping{Status: http.StatusUnauthorized, Message: "Authentification multifacteur requise"}
+	case errors.Is(err, auth.ErrInvalidMFACode):
+		return Mapping{Status: http.StatusUnauthorized, Message: "Code d'authentification invalide"}
+	case errors.Is(err, auth.ErrMFANotEnabled):
+		return Mapping{Status: http.StatusForbidden, Message: "Cette opération exige l'authentification multifacteur"}
+	case errors.Is(err, auth.ErrPasswordReset):
+		return Mapping{Status: http.StatusForbidden, Message: "Un nouveau mot de passe doit être choisi"}
+	case errors.Is(err, auth.ErrPasswordUnchanged):
+		return Mapping{Status: http.StatusBadRequest, Message: "Le nouveau mot de passe doit être différent de l'ancien"}
+	case errors.Is(err, auth.ErrInsufficientRole):
+		return Mapping{Status: http.StatusForbidden, Message: "Accès refusé"}
+	case errors.Is(err, auth.ErrNotMember):
+		return Mapping{Status: http.StatusNotFound, Message: "Organisation non trouvée"}
+	case errors.Is(err, auth.ErrRegistrationClosed):
+		return Mapping{Status: http.StatusForbidden, Message: "Les inscriptions sont fermées (sur invitation uniquement)"}
+	case errors.Is(err, auth.ErrRegistrationNotAllowed):
+		return Mapping{Status: http.StatusForbidden, Message: "Cette adresse email ne peut pas s'inscrire"}
+	case errors.Is(err, auth.ErrMFALocked):
+		return Mapping{Status: http.StatusTooManyRequests, Message: "Trop de codes invalides, réessayez plus tard"}
+	case errors.Is(err, vault.ErrSecretNotFound):
+		return Mapping{Status: http.StatusNotFound, Message: "Secret non trouvé"}
+	case errors.Is(err, storage.ErrInvitationNotFound):
+		return Mapping{Status: http.StatusNotFound, Message: "Invitation inconnue, expirée ou déjà utilisée"}
+	case errors.Is(err, storage.ErrTeamNotFound):
+		return Mapping{Status: http.StatusNotFound, Message: "Équipe non trouvée"}
+	case errors.Is(err, storage.ErrNotFound):
+		return Mapping{Status: http.StatusNotFound, Message: "Ressource non trouvée"}
+	case errors.Is(err, auth.ErrUserExists):
+		return Mapping{Status: http.StatusConflict, Message: "L'utilisateur existe déjà"}
+	case errors.Is(err, auth.ErrMFAEnabled):
+		return Mapping{Status: http.StatusConflict, Message: "L'authentification multifacteur est déjà activée"}
+	case errors.Is(err, storage.ErrSecretAlreadyExists):
+		return Mapping{Status: http.StatusConflict, Message: "Un secret avec ce nom existe déjà"}
+	case errors.Is(err, storage.ErrAccessAlreadyReviewed):
+		return Mapping{Status: http.StatusConflict, Message: "Cet accès a déjà été revu"}
+	case errors.Is(err, storage.ErrInvitationPending):
+		return Mapping{Status: http.StatusConflict, Message: "Une invitation est déjà en attente pour cet email"}
+	case errors.Is(err, storage.ErrTeamExists):
+		return Mapping{Status: http.StatusConflict, Message: "Une équipe avec ce nom existe déjà"}
+	case errors.Is(err, storage.ErrSPIFFEIDTaken):
+		return Mapping{Status: http.StatusConflict, Message: "Cet identifiant SPIFFE est déjà associé à un compte de service"}
+	case errors.Is(err, storage.ErrAlreadyExists):
+		return Mapping{Status: http.StatusConflict, Message: "La ressource existe déjà"}
+	case errors.Is(err, storage.ErrLocked):
+		return Mapping{Status: http.StatusLocked, Message: "La ressource est verrouillée"}
+	case errors.Is(err, storage.ErrQuotaExceeded):
+		return Mapping{Status: http.StatusPaymentRequired, Message: "Limite du plan atteinte"}
+	case errors.Is(err, vault.ErrRegionUnavailable):
+		// Erreur de configuration : réessayer ne changera rien
+		return Mapping{Status: http.StatusServiceUnavailable, Message: "Région de résidence des données indisponible"}
+	case errors.Is(err, vault.ErrUnavailable):
+		retryAfter := vaultRetryAfter
+		var hint interface{ RetryAfter() time.Duration }
+		if errors.As(err, &hint) && hint.RetryAfter() > 0 {
+			retryAfter = hint.RetryAfter()
+		}
+		return Mapping{
+			Status:     http.StatusServiceUnavailable,
+			Message:    "Stockage des secrets temporairement indisponible",
+			RetryAfter: retryAfter,
+		}
+	case errors.Is(err, vault.ErrUpstream):
+		return Mapping{Status: http.StatusBadGateway, Message: "Erreur du stockage des secrets"}
+	case errors.Is(err, context.DeadlineExceeded):
+		return Mapping{Status: http.StatusGatewayTimeout, Message: "Délai d'attente dépassé"}
+	default:
+		return Mapping{Status: http.StatusInternalServerError}
+	}
+}
+
+// Write écrit la réponse correspondant à err. fallback est le message
+// utilisé pour les erreurs inconnues ; les erreurs serveur sont journalisées.
+func Write(w http.ResponseWriter, err error, fallback string) {
+	m := Map(err)
+	if m.Message == "" {
+		m.Message = fallback
+	}
+
+	if m.Status >= http.StatusInternalServerError {
+		logging.For(logging.ComponentHTTP).Error(fallback, "status", m.Status, "error", err)
+	}
+
+	if m.RetryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(m.RetryAfter.Seconds()))))
+	}
+	http.Error(w, m.Message, m.Status)
+}
+
+// Envelope est le corps JSON des erreurs à partir de la v2 de l'API :
+// {"error": {"status": 404, "code": "not_found", "message": "..."}}
+type Envelope struct {
+	Error EnvelopeError `json:"error"`
+}
+
+// EnvelopeError décrit l'erreur ; Code est stable, Message peut évoluer
+type EnvelopeError struct {
+	Status  int    `json:"status"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// codes sont les codes stables des statuts d'erreur renvoyés par l'API
+var codes = map[int]string{
+	http.StatusBadRequest:            "invalid_request",
+	http.StatusUnauthorized:          "unauthenticated",
+	http.StatusPaymentRequired:       "quota_exceeded",
+	http.StatusForbidden:             "forbidden",
+	http.StatusNotFound:              "not_found",
+	http.StatusMethodNotAllowed:      "method_not_allowed",
+	http.StatusConflict:              "conflict",
+	http.StatusGone:                  "gone",
+	http.StatusPreconditionFailed:    "precondition_failed",
+	http.StatusRequestEntityTooLarge: "payload_too_large",
+	http.StatusUnprocessableEntity:   "unprocessable",
+	http.StatusLocked:                "locked",
+	http.StatusPreconditionRequired:  "precondition_required",
+	http.StatusTooManyRequests:       "rate_limited",
+	http.StatusInternalServerError:   "internal",
+	http.StatusNotImplemented:        "not_implemented",
+	http.StatusBadGateway:            "upstream_error",
+	http.StatusServiceUnavailable:    "unavailable",
+	http.StatusGatewayTimeout:        "timeout",
+}
+
+// Code renvoie le code stable d'un statut d'erreur. Les statuts sans code
+// dédié prennent celui de leur classe (invalid_request ou internal).
+func Code(status int) string {
+	if code, ok := codes[status]; ok {
+		return code
+	}
+	if status >= http.StatusInternalServerError {
+		return codes[http.StatusInternalServerError]
+	}
+	return codes[http.StatusBadRequest]
+}
+
+// NewEnvelope crée l'enveloppe d'une erreur
+func NewEnvelope(status int, message string) Envelope {
+	return Envelope{Error: EnvelopeError{Status: status, Code: Code(status), Message: message}}
+}
@@ -0,0 +1,76 @@
+// filepath: internal/api/apierror/apierror_test.go
+
+package apierror
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"secrets-manager/internal/auth"
+	"secrets-manager/internal/storage"
+	"secrets-manager/internal/vault"
+)
+
+func TestMap(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{"Validation", Validation("nom requis"), http.StatusBadRequest},
+		{"Invalid credentials", auth.ErrInvalidCredentials, http.StatusUnauthorized},
+		{"Expired token", auth.ErrTokenExpired, http.StatusUnauthorized},
+		{"Reused refresh token", auth.ErrRefreshTokenReused, http.StatusUnauthorized},
+		{"Invalid MFA code", auth.ErrInvalidMFACode, http.StatusUnauthorized},
+		{"MFA locked", auth.ErrMFALocked, http.StatusTooManyRequests},
+		{"MFA already enabled", auth.ErrMFAEnabled, http.StatusConflict},
+		{"Password reset required", auth.ErrPasswordReset, http.StatusForbidden},
+		{"Insufficient role", auth.ErrInsufficientRole, http.StatusForbidden},
+		{"Not a member", auth.ErrNotMember, http.StatusNotFound},
+		{"Wrapped secret not found", fmt.Errorf("%w: a/b/c/d", vault.ErrSecretNotFound), http.StatusNotFound},
+		{"Storage not found", storage.ErrUserNotFound, http.StatusNotFound},
+		{"Conflict", storage.ErrOrganizationNameExists, http.StatusConflict},
+		{"Secret conflict", storage.ErrSecretAlreadyExists, http.StatusConflict},
+		{"Pending invitation", storage.ErrInvitationPending, http.StatusConflict},
+		{"Used invitation", storage.ErrInvitationNotFound, http.StatusNotFound},
+		{"Team conflict", storage.ErrTeamExists, http.StatusConflict},
+		{"Unknown team", storage.ErrTeamNotFound, http.StatusNotFound},
+		{"Quota", storage.ErrQuotaExceeded, http.StatusPaymentRequired},
+		{"Locked", storage.ErrSecretLocked, http.StatusLocked},
+		{"Vault unavailable", fmt.Errorf("%w: sealed", vault.ErrUnavailable), http.StatusServiceUnavailable},
+		{"Region unavailable", fmt.Errorf("%w: eu", vault.ErrRegionUnavailable), http.StatusServiceUnavailable},
+		{"Vault upstream", fmt.Errorf("%w: permission denied", vault.ErrUpstream), http.StatusBadGateway},
+		{"Deadline", context.DeadlineExceeded, http.StatusGatewayTimeout},
+		{"Unknown", errors.New("boom"), http.StatusInternalServerError},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := Map(tc.err).Status; got != tc.expected {
+				t.Errorf("Expected status %d, got %d", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestWriteRetryAfter(t *testing.T) {
+	rec := httptest.NewRecorder()
+	Write(rec, fmt.Errorf("%w: sealed", vault.ErrUnavailable), "Impossible de lire le secret")
+
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected status 503, got %d", rec.Code)
+	}
+	if rec.Header().Get("Retry-After") != "5" {
+		t.Errorf("Expected Retry-After 5, got %q", rec.Header().Get("Retry-After"))
+	}
+
+	rec = httptest.NewRecorder()
+	Write(rec, errors.New("boom"), "Impossible de lire le secret")
+	if body := rec.Body.String(); body != "Impossible de lire le secret\n" {
+		t.Errorf("Expected fallback message, got %q", body)
+	}
+}
@@ -0,0 +1,766 @@
+// filepath: internal/api/apitest/apitest.go
+
+// Package apitest construit un serveur HTTP de test complet, câblé avec
+// ConfigureRoutes, des repositories en mémoire et un stockage de secrets
+// en mémoire. Il permet des tests de bout en bout sans MySQL ni Vault.
+package apitest
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"secrets-manager/internal/api"
+	"secrets-manager/internal/api/middleware"
+	"secrets-manager/internal/auth"
+	"secrets-manager/internal/captcha"
+	"secrets-manager/internal/events"
+	"secrets-manager/internal/evidence"
+	"secrets-manager/internal/geoip"
+	"secrets-manager/internal/jobs"
+	"secrets-manager/internal/logforward"
+	"secrets-manager/internal/loginalerts"
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/netguard"
+	"secrets-manager/internal/notifications"
+	"secrets-manager/internal/onboarding"
+	"secrets-manager/internal/rotation"
+	"secrets-manager/internal/storage/memory"
+	"secrets-manager/internal/vault"
+	"secrets-manager/internal/webhooks"
+)
+
+// JWTSecret est le secret de signature utilisé par le serveur de test
+const JWTSecret = "apitest-secret"
+
+// AdminToken est le token du listener d'administration du serveur de test,
+// envoyé par DoAdmin et DoAdminJSON
+const AdminToken = "apitest-admin-token"
+
+// Identifiants du client autorisé à introspecter les tokens
+const (
+	IntrospectionClientID     = "sidecar"
+	IntrospectionClientSecret = "sidecar-secret"
+)
+
+// Issuer est l'émetteur et l'audience des tokens du serveur de test
+var Issuer = auth.Issuer{Name: "apitest", Audience: "apitest-api"}
+
+// EvidenceSigningKey est la graine Ed25519 qui signe les preuves du serveur de test
+const EvidenceSigningKey = "YXBpdGVzdC1ldmlkZW5jZS1zaWduaW5nLWtleS0zMmI="
+
+// ChecksumKey est la clé HMAC des sommes de contrôle des secrets du serveur de test
+const ChecksumKey = "YXBpdGVzdC1zZWNyZXQtY2hlY2tzdW0ta2V5LTMyYnl0ZXM="
+
+// SecretRegion est la région de résidence supplémentaire du serveur de test,
+// servie par RegionStore
+const SecretRegion = "eu"
+
+// RecycleRetention est la durée de séjour dans la corbeille du serveur de test.
+// ExpireRecycleBin permet de déclencher les purges sans attendre.
+const RecycleRetention = time.Hour
+
+// Server est un serveur API de test et ses dépendances en mémoire
+type Server struct {
+	*httptest.Server
+	// Admin sert les routes du listener d'administration, authentifiées par AdminToken
+	Admin *httptest.Server
+	// MTLS sert les routes de l'API authentifiées par certificat client
+	// (voir MTLSClient)
+	MTLS *httptest.Server
+	// Router porte les routes de l'API, parcourues par les tests qui
+	// couvrent toutes les routes
+	Router *mux.Router
+
+	DB            *memory.DB
+	Users         *memory.UsersRepository
+	Organizations *memory.OrganizationsRepository
+	Secrets       *memory.SecretsRepository
+	Projects      *memory.ProjectsRepository
+	Confirmations *memory.ConfirmationsRepository
+	Devices       *memory.DeviceAuthorizationsRepository
+	Deletions     *memory.OrganizationDeletionsRepository
+	AccessReviews *memory.AccessReviewsRepository
+	Usage         *memory.UsageRepository
+	SecretReads   *memory.SecretReadsRepository
+	AdminAudit    *memory.AdminAuditRepository
+	SecretStore   *vault.MemoryStore
+	RegionStore   *vault.MemoryStore
+	VaultClusters *vault.Clusters
+	VaultService  *vault.Service
+	AuthService   *auth.Service
+	Deleter       *jobs.OrganizationDeleter
+	Evidence      *evidence.Signer
+	Checksummer   *vault.Checksummer
+
+	NotificationPreferences *memory.NotificationPreferencesRepository
+	NotificationEvents      *memory.NotificationEventsRepository
+	Webhooks                *memory.WebhooksRepository
+	PersonalAccessTokens    *memory.PersonalAccessTokensRepository
+	APIKeys                 *memory.APIKeysRepository
+	UserMFA                 *memory.UserMFARepository
+	TrustedDevices          *memory.TrustedDevicesRepository
+	RefreshTokens           *memory.RefreshTokensRepository
+	LoginEvents             *memory.LoginEventsRepository
+	LoginAlertPolicies      *memory.LoginAlertPoliciesRepository
+	SessionPolicies         *memory.SessionPoliciesRepository
+	PasswordPolicies        *memory.PasswordPoliciesRepository
+	RegistrationPolicy      *memory.RegistrationPolicyRepository
+	ReadReasonPolicies      *memory.ReadReasonPoliciesRepository
+	ProjectTemplates        *memory.ProjectTemplatesRepository
+	AuditLogs               *memory.AuditLogsRepository
+	SecretRotators          *memory.SecretRotatorsRepository
+	ScheduledSecretChanges  *memory.ScheduledSecretChangesRepository
+	MaintenanceWindows      *memory.MaintenanceWindowsRepository
+	DataKeys                *memory.DataKeysRepository
+	Transit                 *vault.MemoryTransit
+	AgeKeys                 *memory.AgeKeysRepository
+	Health                  *memory.HealthRepository
+	SettingsHistory         *memory.SettingsHistoryRepository
+	LogForwarders           *memory.LogForwardersRepository
+	BulkRotations           *memory.BulkRotationsRepository
+	Lockdowns               *memory.LockdownsRepository
+	Invitations             *memory.InvitationsRepository
+	Teams                   *memory.TeamsRepository
+	ServiceAccounts         *memory.ServiceAccountsRepository
+	CustomRoles             *memory.CustomRolesRepository
+	Impersonations          *memory.ImpersonationsRepository
+	BulkRotator             *jobs.BulkRotator
+	// Onboarding suit la prise en main ; les tests s'abonnent aux étapes
+	// franchies (les emails d'accompagnement ne sont pas envoyés)
+	Onboarding *onboarding.Tracker
+	// Outbox reçoit les emails envoyés par le serveur (invitations, confirmations d'adresse)
+	Outbox *Outbox
+	// AuditForwarder transfère les événements d'audit en tâche de fond ;
+	// comme WebhookSender, il accepte les certificats de httptest.NewTLSServer
+	AuditForwarder *logforward.Forwarder
+	// Locator situe les connexions (toutes depuis 127.0.0.1) au lieu choisi par Move
+	Locator *Locator
+	// Rotators accepte les rotateurs de test (Register)
+	Rotators *rotation.Registry
+	// WebhookSender accepte les certificats des consommateurs démarrés avec
+	// httptest.NewTLSServer
+	WebhookSender *webhooks.Sender
+	// Events diffuse les modifications qui invalident les caches HTTP
+	Events *events.Bus
+	// Switches coupe des routes ou des fonctionnalités
+	Switches *middleware.Switches
+	// AuthLimiter limite les routes d'authentification publiques : sans
+	// limite par défaut, configuré par les tests qui en ont besoin
+	AuthLimiter *middleware.AuthLimiter
+	// Captcha est le défi de l'inscription, désactivé par défaut
+	Captcha *Captcha
+
+	// clientCA signe les certificats clients acceptés par MTLS
+	clientCA    *x509.Certificate
+	clientCAKey *ecdsa.PrivateKey
+
+	t testing.TB
+}
+
+// NewServer démarre un serveur API de test, arrêté automatiquement en fin de test
+func NewServer(t testing.TB) *Server {
+	t.Helper()
+
+	db := memory.NewDB()
+	s := &Server{
+		DB:            db,
+		Users:         memory.NewUsersRepository(db),
+		Organizations: memory.NewOrganizationsRepository(db),
+		Secrets:       memory.NewSecretsRepository(db),
+		Projects:      memory.NewProjectsRepository(db),
+		Confirmations: memory.NewConfirmationsRepository(db),
+		Devices:       memory.NewDeviceAuthorizationsRepository(db),
+		Deletions:     memory.NewOrganizationDeletionsRepository(db),
+		AccessReviews: memory.NewAccessReviewsRepository(db),
+		Usage:         memory.NewUsageRepository(db),
+		SecretReads:   memory.NewSecretReadsRepository(db),
+		AdminAudit:    memory.NewAdminAuditRepository(db),
+		SecretStore:   vault.NewMemoryStore(),
+		RegionStore:   vault.NewMemoryStore(),
+		t:             t,
+
+		NotificationPreferences: memory.NewNotificationPreferencesRepository(db),
+		NotificationEvents:      memory.NewNotificationEventsRepository(db),
+		Webhooks:                memory.NewWebhooksRepository(db),
+		PersonalAccessTokens:    memory.NewPersonalAccessTokensRepository(db),
+		APIKeys:                 memory.NewAPIKeysRepository(db),
+		UserMFA:                 memory.NewUserMFARepository(db),
+		TrustedDevices:          memory.NewTrustedDevicesRepository(db),
+		RefreshTokens:           memory.NewRefreshTokensRepository(db),
+		LoginEvents:             memory.NewLoginEventsRepository(db),
+		LoginAlertPolicies:      memory.NewLoginAlertPoliciesRepository(db),
+		SessionPolicies:         memory.NewSessionPoliciesRepository(db),
+		PasswordPolicies:        memory.NewPasswordPoliciesRepository(db),
+		RegistrationPolicy:      memory.NewRegistrationPolicyRepository(db),
+		ReadReasonPolicies:      memory.NewReadReasonPoliciesRepository(db),
+		ProjectTemplates:        memory.NewProjectTemplatesRepository(db),
+		AuditLogs:               memory.NewAuditLogsRepository(db),
+		Locator:                 &Locator{},
+		SecretRotators:          memory.NewSecretRotatorsRepository(db),
+		ScheduledSecretChanges:  memory.NewScheduledSecretChangesRepository(db),
+		MaintenanceWindows:      memory.NewMaintenanceWindowsRepository(db),
+		DataKeys:                memory.NewDataKeysRepository(db),
+		Transit:                 vault.NewMemoryTransit(),
+		AgeKeys:                 memory.NewAgeKeysRepository(db),
+		Health:                  memory.NewHealthRepository(db),
+		SettingsHistory:         memory.NewSettingsHistoryRepository(db),
+		LogForwarders:           memory.NewLogForwardersRepository(db),
+		BulkRotations:           memory.NewBulkRotationsRepository(db),
+		Lockdowns:               memory.NewLockdownsRepository(db),
+		Invitations:             memory.NewInvitationsRepository(db),
+		Teams:                   memory.NewTeamsRepository(db),
+		ServiceAccounts:         memory.NewServiceAccountsRepository(db),
+		CustomRoles:             memory.NewCustomRolesRepository(db),
+		Impersonations:          memory.NewImpersonationsRepository(db),
+		Outbox:                  &Outbox{},
+		Captcha:                 &Captcha{},
+		Rotators:                rotation.NewRegistry(nil),
+		Events:                  events.NewBus(),
+	}
+	guard := netguard.Guard{Allow: netguard.Loopback}
+	outbound := guard.Transport()
+	outbound.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	s.WebhookSender = webhooks.NewSender(s.Webhooks, guard, &http.Client{
+		Timeout:   5 * time.Second,
+		Transport: outbound,
+	})
+	s.AuditForwarder = logforward.NewForwarder(s.LogForwarders, s.Organizations, guard, &http.Client{
+		Timeout:   5 * time.Second,
+		Transport: outbound,
+	})
+	s.Switches, _ = middleware.NewSwitches()
+	s.AuthLimiter = middleware.NewAuthLimiter(middleware.AuthLimits{}, nil)
+	s.VaultClusters = vault.NewClusters(vault.Cluster{Name: "primary", Store: s.SecretStore})
+	router := vault.NewRouter(map[string]vault.SecretStore{
+		models.DefaultRegion: s.VaultClusters,
+		SecretRegion:         s.RegionStore,
+	}, s.Organizations.GetOrganizationRegion)
+	s.VaultService = vault.NewService(router)
+	transit := vault.NewTransitRouter(map[string]vault.Transit{models.DefaultRegion: s.Transit},
+		s.Organizations.GetOrganizationRegion)
+	s.AuthService = auth.NewService(s.Users, s.UserMFA, s.RefreshTokens, JWTSecret, Issuer, time.Hour, 24*time.Hour)
+	s.AuthService.EnableDeviceTrust(s.TrustedDevices, 30*24*time.Hour)
+	s.AuthService.EnableServiceAccounts(s.ServiceAccounts)
+	s.AuthService.EnableImpersonation(s.Impersonations)
+	s.AuthService.ApplySessionPolicies(s.SessionPolicies)
+	s.AuthService.ApplyPasswordPolicies(models.PasswordPolicy{MinLength: 8}, s.PasswordPolicies, nil)
+	s.AuthService.RestrictRegistration(models.RegistrationPolicy{}, s.RegistrationPolicy, s.Invitations)
+	s.Onboarding = onboarding.NewTracker(memory.NewOnboardingRepository(db), s.Users, s.Projects, s.Secrets)
+	s.AuthService.ObserveLogins(loginalerts.NewMonitor(s.Locator, s.LoginEvents, s.LoginAlertPolicies, s.Users, nil))
+	signer, err := evidence.NewSigner(EvidenceSigningKey)
+	if err != nil {
+		t.Fatalf("impossible de créer le signataire des preuves: %v", err)
+	}
+	s.Evidence = signer
+	if s.Checksummer, err = vault.NewChecksummer(ChecksumKey); err != nil {
+		t.Fatalf("impossible de créer le calculateur de sommes de contrôle: %v", err)
+	}
+
+	// Les suppressions d'organisations, les rotations groupées et le
+	// transfert des journaux d'audit s'exécutent en tâche de fond, comme en
+	// production
+	s.Deleter = jobs.NewOrganizationDeleter(s.Deletions, s.Organizations, s.VaultService, RecycleRetention, time.Second)
+	rotationService := rotation.NewService(s.SecretRotators, s.Secrets, s.Projects, s.VaultService, s.Rotators,
+		s.Checksummer, nil)
+	s.BulkRotator = jobs.NewBulkRotator(s.BulkRotations, s.SecretRotators, s.Projects, s.Secrets, rotationService,
+		s.Events, time.Second)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{}, 3)
+	go func() {
+		defer func() { done <- struct{}{} }()
+		s.Deleter.Run(ctx)
+	}()
+	go func() {
+		defer func() { done <- struct{}{} }()
+		s.BulkRotator.Run(ctx)
+	}()
+	go func() {
+		defer func() { done <- struct{}{} }()
+		s.AuditForwarder.Run(ctx)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+		<-done
+		<-done
+	})
+
+	deps := &api.Dependencies{
+		VaultService:  s.VaultService,
+		AuthService:   s.AuthService,
+		Users:         s.Users,
+		Organizations: s.Organizations,
+		Secrets:       s.Secrets,
+		Projects:      s.Projects,
+		Confirmations: s.Confirmations,
+		Usage:         s.Usage,
+		AdminAudit:    s.AdminAudit,
+		SecretReads:   s.SecretReads,
+
+		DeviceAuthorizations:  s.Devices,
+		DeviceVerificationURI: "http://dashboard.test/device",
+		Invitations:           s.Invitations,
+		InvitationAcceptURI:   "http://dashboard.test/invitations/accept",
+		EmailVerificationURI:  "http://dashboard.test/email/verify",
+		Onboarding:            s.Onboarding,
+		Mailer:                s.Outbox,
+		Teams:                 s.Teams,
+		ServiceAccounts:       s.ServiceAccounts,
+		CustomRoles:           s.CustomRoles,
+		Impersonations:        s.Impersonations,
+		PersonalAccessTokens:  s.PersonalAccessTokens,
+		APIKeys:               s.APIKeys,
+		TrustedDevices:        s.TrustedDevices,
+		LoginEvents:           s.LoginEvents,
+		LoginAlertPolicies:    s.LoginAlertPolicies,
+		SessionPolicies:       s.SessionPolicies,
+		PasswordPolicies:      s.PasswordPolicies,
+		RegistrationPolicy:    s.RegistrationPolicy,
+		ReadReasonPolicies:    s.ReadReasonPolicies,
+		ProjectTemplates:      s.ProjectTemplates,
+		AuditLogs:             s.AuditLogs,
+		IntrospectionClients:  map[string]string{IntrospectionClientID: IntrospectionClientSecret},
+
+		OrganizationDeletions: s.Deletions,
+		OrganizationDeleter:   s.Deleter,
+		AccessReviews:         s.AccessReviews,
+		BulkRotations:         s.BulkRotations,
+		BulkRotator:           s.BulkRotator,
+		Lockdowns:             s.Lockdowns,
+		EvidenceSigner:        s.Evidence,
+		VaultRouter:           router,
+		VaultClusters:         s.VaultClusters,
+		Checksummer:           s.Checksummer,
+
+		NotificationPreferences: s.NotificationPreferences,
+		NotificationEvents:      s.NotificationEvents,
+		Webhooks:                s.Webhooks,
+		WebhookSender:           s.WebhookSender,
+		Events:                  s.Events,
+		Switches:                s.Switches,
+		AuthLimiter:             s.AuthLimiter,
+		Captcha:                 s.Captcha,
+		SecretRotators:          s.SecretRotators,
+		Rotators:                s.Rotators,
+		ScheduledSecretChanges:  s.ScheduledSecretChanges,
+		MaintenanceWindows:      s.MaintenanceWindows,
+		Subscriptions:           memory.NewSubscriptionsRepository(db),
+		DataKeys:                s.DataKeys,
+		Transit:                 transit,
+		AgeKeys:                 s.AgeKeys,
+		Health:                  s.Health,
+		SettingsHistory:         s.SettingsHistory,
+		LogForwarders:           s.LogForwarders,
+		AuditForwarder:          s.AuditForwarder,
+
+		ConfirmationWindow: time.Minute,
+		RecycleRetention:   RecycleRetention,
+		TrialPlanID:        "trial",
+		TrialDuration:      14 * 24 * time.Hour,
+		TrialSecretsLimit:  100,
+	}
+
+	apiRouter := mux.NewRouter()
+	api.ConfigureRoutes(apiRouter, deps)
+	s.Router = apiRouter
+	s.Server = httptest.NewServer(apiRouter)
+	t.Cleanup(s.Close)
+
+	adminRouter := mux.NewRouter()
+	api.ConfigureAdminRoutes(adminRouter, AdminToken, deps)
+	s.Admin = httptest.NewServer(adminRouter)
+	t.Cleanup(s.Admin.Close)
+
+	s.startMTLS(apiRouter)
+
+	return s
+}
+
+// Register inscrit un utilisateur via l'API et renvoie son ID
+func (s *Server) Register(email, password string) string {
+	s.t.Helper()
+
+	resp := s.Do(http.MethodPost, "/api/v1/auth/register", "", map[string]string{
+		"email":    email,
+		"password": password,
+	})
+	ExpectStatus(s.t, resp, http.StatusCreated)
+
+	user, err := s.Users.GetUserByEmail(context.Background(), email)
+	if err != nil {
+		s.t.Fatalf("utilisateur %s introuvable après inscription: %v", email, err)
+	}
+	return user.ID
+}
+
+// Login authentifie un utilisateur via l'API et renvoie son token d'accès
+func (s *Server) Login(email, password string) string {
+	s.t.Helper()
+
+	resp := s.Do(http.MethodPost, "/api/v1/auth/login", "", map[string]string{
+		"email":    email,
+		"password": password,
+	})
+	ExpectStatus(s.t, resp, http.StatusOK)
+
+	var body struct {
+		Token string `json:"token"`
+	}
+	DecodeJSON(s.t, resp, &body)
+	return body.Token
+}
+
+// CreateOrganization crée une organisation appartenant à ownerID
+func (s *Server) CreateOrganization(name, ownerID string) *models.Organization {
+	s.t.Helper()
+
+	org := &models.Organization{Name: name, OwnerID: ownerID}
+	if err := s.Organizations.CreateOrganization(context.Background(), org); err != nil {
+		s.t.Fatalf("impossible de créer l'organisation %s: %v", name, err)
+	}
+	return org
+}
+
+// CreateProject crée un projet dans une organisation
+func (s *Server) CreateProject(orgID, name, createdBy string) *models.Project {
+	s.t.Helper()
+
+	project := &models.Project{Name: name, OrganizationID: orgID, CreatedBy: createdBy}
+	if err := s.Projects.CreateProject(context.Background(), project); err != nil {
+		s.t.Fatalf("impossible de créer le projet %s: %v", name, err)
+	}
+	return project
+}
+
+// AddMember ajoute un utilisateur à une organisation avec le rôle donné
+func (s *Server) AddMember(orgID, userID, role string) {
+	s.t.Helper()
+
+	if err := s.Organizations.AddUserToOrganization(context.Background(), userID, orgID, role); err != nil {
+		s.t.Fatalf("impossible d'ajouter %s à l'organisation %s: %v", userID, orgID, err)
+	}
+}
+
+// ExpireRecycleBin rend immédiatement purgeables les organisations de la corbeille
+func (s *Server) ExpireRecycleBin() {
+	s.t.Helper()
+
+	ctx := context.Background()
+	deletions, err := s.Deletions.ListUnfinishedOrganizationDeletions(ctx)
+	if err != nil {
+		s.t.Fatalf("impossible de lister les suppressions: %v", err)
+	}
+	for _, deletion := range deletions {
+		deletion.PurgeAfter = time.Now()
+		if err := s.Deletions.UpdateOrganizationDeletion(ctx, deletion); err != nil {
+			s.t.Fatalf("impossible de modifier la suppression %s: %v", deletion.ID, err)
+		}
+	}
+	s.Deleter.Wake()
+}
+
+// Mail est un email envoyé par le serveur de test
+type Mail struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Outbox conserve les emails envoyés par le serveur de test
+type Outbox struct {
+	mu    sync.Mutex
+	mails []Mail
+}
+
+var _ notifications.Mailer = (*Outbox)(nil)
+
+// SendMail conserve l'email
+func (o *Outbox) SendMail(ctx context.Context, to, subject, body string) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.mails = append(o.mails, Mail{To: to, Subject: subject, Body: body})
+	return nil
+}
+
+// Mails renvoie les emails envoyés, du plus ancien au plus récent
+func (o *Outbox) Mails() []Mail {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return append([]Mail(nil), o.mails...)
+}
+
+// Locator situe toutes les adresses au dernier lieu passé à Move ; aucune
+// n'est située avant le premier appel
+type Locator struct {
+	mu       sync.Mutex
+	location *geoip.Location
+}
+
+var _ geoip.Locator = (*Locator)(nil)
+
+// Move situe les connexions suivantes à location (nil : non situées)
+func (l *Locator) Move(location *geoip.Location) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.location = location
+}
+
+// Locate renvoie le lieu courant, quelle que soit l'adresse
+func (l *Locator) Locate(ip string) (*geoip.Location, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.location, l.location != nil
+}
+
+// Captcha est le défi de l'inscription : aucun jusqu'au premier appel à
+// Enable
+type Captcha struct {
+	mu       sync.Mutex
+	provider captcha.Provider
+}
+
+var _ captcha.Provider = (*Captcha)(nil)
+
+// Enable exige les défis de provider aux inscriptions suivantes (nil : aucun)
+func (c *Captcha) Enable(provider captcha.Provider) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.provider = provider
+}
+
+// Challenge renvoie un défi du fournisseur courant
+func (c *Captcha) Challenge() (*captcha.Challenge, error) {
+	c.mu.Lock()
+	provider := c.provider
+	c.mu.Unlock()
+	if provider == nil {
+		return &captcha.Challenge{Provider: captcha.ProviderNone}, nil
+	}
+	return provider.Challenge()
+}
+
+// VerifyCaptcha vérifie la réponse auprès du fournisseur courant
+func (c *Captcha) VerifyCaptcha(ctx context.Context, response, remoteIP string) error {
+	c.mu.Lock()
+	provider := c.provider
+	c.mu.Unlock()
+	if provider == nil {
+		return nil
+	}
+	return provider.VerifyCaptcha(ctx, response, remoteIP)
+}
+
+// startMTLS démarre MTLS, dont les clients présentent un certificat signé
+// par une autorité de test
+func (s *Server) startMTLS(handler http.Handler) {
+	s.t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		s.t.Fatalf("impossible de générer la clé de l'autorité: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "apitest CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		s.t.Fatalf("impossible de créer l'autorité: %v", err)
+	}
+	s.clientCA, err = x509.ParseCertificate(der)
+	if err != nil {
+		s.t.Fatalf("autorité invalide: %v", err)
+	}
+	s.clientCAKey = key
+
+	// Comme server.MTLSConfig : l'autorité est vérifiée avec celles de
+	// l'organisation (voir ClientCAPEM)
+	s.MTLS = httptest.NewUnstartedServer(middleware.ClientCertificateAuth(s.AuthService)(handler))
+	s.MTLS.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	s.MTLS.StartTLS()
+	s.t.Cleanup(s.MTLS.Close)
+}
+
+// ClientCAPEM renvoie l'autorité de test (PEM), à déclarer dans la confiance
+// mTLS d'une organisation pour que ses comptes s'authentifient sur MTLS
+func (s *Server) ClientCAPEM() string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: s.clientCA.Raw}))
+}
+
+// MTLSClient renvoie un client de MTLS présentant un certificat signé par
+// l'autorité de test, de SAN URI spiffeID (sans SAN si vide)
+func (s *Server) MTLSClient(spiffeID string) *http.Client {
+	s.t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		s.t.Fatalf("impossible de générer la clé du client: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: "apitest client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	if spiffeID != "" {
+		uri, err := url.Parse(spiffeID)
+		if err != nil {
+			s.t.Fatalf("identifiant SPIFFE invalide: %v", err)
+		}
+		template.URIs = []*url.URL{uri}
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, s.clientCA, &key.PublicKey, s.clientCAKey)
+	if err != nil {
+		s.t.Fatalf("impossible de créer le certificat client: %v", err)
+	}
+
+	transport := s.MTLS.Client().Transport.(*http.Transport).Clone()
+	transport.TLSClientConfig.Certificates = []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}}
+	s.t.Cleanup(transport.CloseIdleConnections)
+	return &http.Client{Transport: transport}
+}
+
+// DoMTLS envoie une requête JSON à MTLS avec client ; body peut être nil
+func (s *Server) DoMTLS(client *http.Client, method, path string, body interface{}) *http.Response {
+	s.t.Helper()
+
+	resp, err := client.Do(s.newRequest(method, s.MTLS.URL+path, body))
+	if err != nil {
+		s.t.Fatalf("%s %s: %v", method, path, err)
+	}
+	s.t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+// DoAdmin envoie une requête au listener d'administration
+func (s *Server) DoAdmin(method, path string) *http.Response {
+	s.t.Helper()
+
+	return s.DoAdminJSON(method, path, nil)
+}
+
+// DoAdminJSON envoie une requête JSON au listener d'administration ; body peut être nil
+func (s *Server) DoAdminJSON(method, path string, body interface{}) *http.Response {
+	s.t.Helper()
+
+	return s.DoAdminWithHeaders(method, path, nil, body)
+}
+
+// DoAdminWithHeaders envoie une requête JSON au listener d'administration,
+// authentifiée par AdminToken, avec des en-têtes supplémentaires
+func (s *Server) DoAdminWithHeaders(method, path string, header http.Header, body interface{}) *http.Response {
+	s.t.Helper()
+
+	req := s.newRequest(method, s.Admin.URL+path, body)
+	req.Header.Set("Authorization", "Bearer "+AdminToken)
+	for key, values := range header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	resp, err := s.Admin.Client().Do(req)
+	if err != nil {
+		s.t.Fatalf("%s %s: %v", method, path, err)
+	}
+	s.t.Cleanup(func() { resp.Body.Close() })
+
+	return resp
+}
+
+// Do envoie une requête JSON au serveur. token peut être vide, body peut être nil.
+func (s *Server) Do(method, path, token string, body interface{}) *http.Response {
+	s.t.Helper()
+
+	return s.DoWithHeaders(method, path, token, nil, body)
+}
+
+// DoWithHeaders envoie une requête JSON avec des en-têtes supplémentaires
+func (s *Server) DoWithHeaders(method, path, token string, header http.Header, body interface{}) *http.Response {
+	s.t.Helper()
+
+	req := s.newRequest(method, s.URL+path, body)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	for key, values := range header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+
+	resp, err := s.Client().Do(req)
+	if err != nil {
+		s.t.Fatalf("%s %s: %v", method, path, err)
+	}
+	s.t.Cleanup(func() { resp.Body.Close() })
+
+	return resp
+}
+
+// newRequest prépare une requête, avec body encodé en JSON s'il n'est pas nil
+func (s *Server) newRequest(method, url string, body interface{}) *http.Request {
+	s.t.Helper()
+
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			s.t.Fatalf("impossible d'encoder le corps de la requête: %v", err)
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequest(method, url, reader)
+	if err != nil {
+		s.t.Fatalf("requête invalide: %v", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return req
+}
+
+// ExpectStatus échoue le test si la réponse n'a pas le code attendu
+func ExpectStatus(t testing.TB, resp *http.Response, expected int) {
+	t.Helper()
+
+	if resp.StatusCode != expected {
+		body, _ := io.ReadAll(resp.Body)
+		t.Fatalf("%s %s: expected status %d, got %d: %s",
+			resp.Request.Method, resp.Request.URL.Path, expected, resp.StatusCode, body)
+	}
+}
+
+// DecodeJSON décode le corps JSON d'une réponse
+func DecodeJSON(t testing.TB, resp *http.Response, v interface{}) {
+	t.Helper()
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		t.Fatalf("réponse JSON invalide: %v", err)
+	}
+}
@@ -0,0 +1,135 @@
+// filepath: internal/api/auth_limits_test.go
+
+package api_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"secrets-manager/internal/api/apitest"
+	"secrets-manager/internal/api/middleware"
+	"secrets-manager/internal/captcha"
+)
+
+// solvedCaptcha accepte la seule réponse "solved"
+type solvedCaptcha struct{}
+
+func (solvedCaptcha) VerifyCaptcha(ctx context.Context, response, remoteIP string) error {
+	if response != "solved" {
+		return errors.New("défi non résolu")
+	}
+	return nil
+}
+
+func TestAuthRateLimits(t *testing.T) {
+	srv := apitest.NewServer(t)
+	srv.Register("alice@example.com", "password123")
+	srv.Register("bob@example.com", "password123")
+	login := func(email, password string, header http.Header) *http.Response {
+		resp := srv.DoWithHeaders(http.MethodPost, "/api/v1/auth/login", "", header, map[string]string{
+			"email":    email,
+			"password": password,
+		})
+		resp.Body.Close()
+		return resp
+	}
+
+	// Plafond par email : une connexion réussie remet le compteur à zéro
+	srv.AuthLimiter.Configure(middleware.AuthLimits{PerIP: 100, PerEmail: 3, Window: time.Hour}, nil)
+	apitest.ExpectStatus(t, login("alice@example.com", "wrong", nil), http.StatusUnauthorized)
+	apitest.ExpectStatus(t, login("alice@example.com", "wrong", nil), http.StatusUnauthorized)
+	apitest.ExpectStatus(t, login("Alice@example.com", "password123", nil), http.StatusOK)
+	for i := 0; i < 3; i++ {
+		apitest.ExpectStatus(t, login("alice@example.com", "wrong", nil), http.StatusUnauthorized)
+	}
+	resp := login("alice@example.com", "password123", nil)
+	apitest.ExpectStatus(t, resp, http.StatusTooManyRequests)
+	if resp.Header.Get("Retry-After") == "" {
+		t.Error("Expected a Retry-After header")
+	}
+	apitest.ExpectStatus(t, login("bob@example.com", "password123", nil), http.StatusOK)
+
+	// Plafond par adresse IP, quel que soit l'email
+	srv.AuthLimiter.Configure(middleware.AuthLimits{PerIP: 2, Window: time.Hour}, nil)
+	apitest.ExpectStatus(t, login("alice@example.com", "password123", nil), http.StatusOK)
+	apitest.ExpectStatus(t, login("bob@example.com", "password123", nil), http.StatusOK)
+	resp = srv.Do(http.MethodPost, "/api/v1/auth/register", "", map[string]string{
+		"email":    "carol@example.com",
+		"password": "password123",
+	})
+	apitest.ExpectStatus(t, resp, http.StatusTooManyRequests)
+
+	// Délai progressif après les tentatives gratuites, sans pénaliser les
+	// autres emails
+	srv.AuthLimiter.Configure(middleware.AuthLimits{
+		Window:       time.Hour,
+		FreeAttempts: 1,
+		BaseDelay:    time.Minute,
+		MaxDelay:     time.Hour,
+	}, nil)
+	apitest.ExpectStatus(t, login("alice@example.com", "wrong", nil), http.StatusUnauthorized)
+	resp = login("alice@example.com", "password123", nil)
+	apitest.ExpectStatus(t, resp, http.StatusTooManyRequests)
+	if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "60" {
+		t.Errorf("Expected Retry-After 60, got %q", retryAfter)
+	}
+	apitest.ExpectStatus(t, login("bob@example.com", "password123", nil), http.StatusOK)
+
+	// Au-delà de CaptchaAfter, un défi résolu est exigé et dispense du délai
+	srv.AuthLimiter.Configure(middleware.AuthLimits{
+		Window:       time.Hour,
+		BaseDelay:    time.Minute,
+		CaptchaAfter: 1,
+	}, solvedCaptcha{})
+	apitest.ExpectStatus(t, login("alice@example.com", "wrong", nil), http.StatusUnauthorized)
+	apitest.ExpectStatus(t, login("alice@example.com", "password123", nil), http.StatusPreconditionRequired)
+	apitest.ExpectStatus(t, login("alice@example.com", "password123",
+		http.Header{middleware.CaptchaHeader: {"guessed"}}), http.StatusPreconditionRequired)
+	apitest.ExpectStatus(t, login("alice@example.com", "password123",
+		http.Header{middleware.CaptchaHeader: {"solved"}}), http.StatusOK)
+}
+
+func TestRegistrationCaptcha(t *testing.T) {
+	srv := apitest.NewServer(t)
+	register := func(email string, header http.Header) *http.Response {
+		resp := srv.DoWithHeaders(http.MethodPost, "/api/v1/auth/register", "", header, map[string]string{
+			"email":    email,
+			"password": "password123",
+		})
+		resp.Body.Close()
+		return resp
+	}
+	challenge := func() captcha.Challenge {
+		resp := srv.Do(http.MethodGet, "/api/v1/auth/captcha", "", nil)
+		apitest.ExpectStatus(t, resp, http.StatusOK)
+		var challenge captcha.Challenge
+		apitest.DecodeJSON(t, resp, &challenge)
+		return challenge
+	}
+
+	// Sans fournisseur configuré, l'inscription est libre
+	if provider := challenge().Provider; provider != captcha.ProviderNone {
+		t.Errorf("Expected no challenge, got %s", provider)
+	}
+	apitest.ExpectStatus(t, register("first@example.com", nil), http.StatusCreated)
+
+	pow, err := captcha.NewProofOfWork([]byte(strings.Repeat("k", 32)), 8)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	srv.Captcha.Enable(pow)
+	apitest.ExpectStatus(t, register("bot@example.com", nil), http.StatusPreconditionRequired)
+
+	issued := challenge()
+	if issued.Provider != captcha.ProviderProofOfWork || issued.Challenge == "" {
+		t.Fatalf("Expected a proof of work, got %+v", issued)
+	}
+	solved := http.Header{middleware.CaptchaHeader: {captcha.Solve(issued.Challenge, issued.Difficulty)}}
+	apitest.ExpectStatus(t, register("human@example.com", solved), http.StatusCreated)
+	// Une preuve ne sert qu'une fois
+	apitest.ExpectStatus(t, register("again@example.com", solved), http.StatusPreconditionRequired)
+}
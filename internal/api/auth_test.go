@@ -0,0 +1,69 @@
+// filepath: internal/api/auth_test.go
+
+package api_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+
+	"secrets-manager/internal/api/apitest"
+	"secrets-manager/internal/auth"
+)
+
+func TestTokensFromAnotherIssuerAreRejected(t *testing.T) {
+	srv := apitest.NewServer(t)
+	details, err := srv.AuthService.RegisterUser(t.Context(), &auth.Credentials{
+		Email:    "iss@example.com",
+		Password: "password123",
+	}, "Iss", "Test")
+	if err != nil {
+		t.Fatalf("Expected the user to be registered, got %v", err)
+	}
+
+	sign := func(overrides jwt.MapClaims) string {
+		now := time.Now()
+		claims := jwt.MapClaims{
+			"sub":  details.ID,
+			"type": "access",
+			"iss":  apitest.Issuer.Name,
+			"aud":  apitest.Issuer.Audience,
+			"exp":  now.Add(time.Hour).Unix(),
+			"nbf":  now.Unix(),
+			"iat":  now.Unix(),
+		}
+		for name, value := range overrides {
+			if value == nil {
+				delete(claims, name)
+				continue
+			}
+			claims[name] = value
+		}
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(apitest.JWTSecret))
+		if err != nil {
+			t.Fatalf("Expected the token to be signed, got %v", err)
+		}
+		return token
+	}
+
+	tests := []struct {
+		name      string
+		overrides jwt.MapClaims
+		status    int
+	}{
+		{"Valid", nil, http.StatusOK},
+		{"Other issuer", jwt.MapClaims{"iss": "staging"}, http.StatusUnauthorized},
+		{"Other audience", jwt.MapClaims{"aud": "billing-api"}, http.StatusUnauthorized},
+		{"Missing audience", jwt.MapClaims{"aud": nil}, http.StatusUnauthorized},
+		{"Missing nbf", jwt.MapClaims{"nbf": nil}, http.StatusUnauthorized},
+		{"Not yet valid", jwt.MapClaims{"nbf": time.Now().Add(time.Hour).Unix()}, http.StatusUnauthorized},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := srv.Do(http.MethodGet, "/api/v1/users/me/notification-preferences", sign(tc.overrides), nil)
+			apitest.ExpectStatus(t, resp, tc.status)
+		})
+	}
+}
@@ -0,0 +1,141 @@
+// filepath: internal/api/bulk_rotation_test.go
+
+package api_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"secrets-manager/internal/api/apitest"
+	"secrets-manager/internal/models"
+)
+
+func TestBulkRotation(t *testing.T) {
+	srv := apitest.NewServer(t)
+	srv.Rotators.Register("fake", &fakeRotator{})
+	ownerID := srv.Register("owner@example.com", "password123")
+	owner := srv.Login("owner@example.com", "password123")
+	developerID := srv.Register("dev@example.com", "password123")
+	developer := srv.Login("dev@example.com", "password123")
+	org := srv.CreateOrganization("acme", ownerID)
+	srv.AddMember(org.ID, developerID, "developer")
+	api := srv.CreateProject(org.ID, "api", ownerID)
+	web := srv.CreateProject(org.ID, "web", ownerID)
+
+	apiSecrets := "/api/v1/organizations/" + org.ID + "/projects/" + api.ID + "/environments/prod/secrets"
+	webSecrets := "/api/v1/organizations/" + org.ID + "/projects/" + web.ID + "/environments/prod/secrets"
+	for secrets, list := range map[string][]models.Secret{
+		apiSecrets: {{Name: "DB_PASSWORD", Value: "v1"}, {Name: "DB_ADMIN", Value: "admin-credentials"},
+			{Name: "API_KEY", Value: "k1"}, {Name: "API_ADMIN", Value: "admin-credentials"}},
+		webSecrets: {{Name: "TOKEN", Value: "t1"}, {Name: "TOKEN_ADMIN", Value: "admin-credentials"}},
+	} {
+		for _, secret := range list {
+			resp := srv.Do(http.MethodPost, secrets, owner, secret)
+			apitest.ExpectStatus(t, resp, http.StatusCreated)
+		}
+	}
+	for rotator, credentials := range map[string]string{
+		apiSecrets + "/DB_PASSWORD/rotator": "DB_ADMIN",
+		apiSecrets + "/API_KEY/rotator":     "API_ADMIN",
+		webSecrets + "/TOKEN/rotator":       "TOKEN_ADMIN",
+	} {
+		resp := srv.Do(http.MethodPut, rotator, owner, map[string]any{"provider": "fake",
+			"config": map[string]string{"account": "billing"}, "credentials_secret": credentials})
+		apitest.ExpectStatus(t, resp, http.StatusOK)
+	}
+	// Le fournisseur refusera les identifiants de API_KEY
+	resp := srv.Do(http.MethodPut, apiSecrets+"/API_ADMIN", owner, models.Secret{Value: "stale"})
+	apitest.ExpectStatus(t, resp, http.StatusNoContent)
+
+	bulkRotate := "/api/v1/organizations/" + org.ID + "/secrets:bulkRotate"
+	tests := []struct {
+		name   string
+		token  string
+		body   map[string]string
+		status int
+	}{
+		{"Not an admin", developer, map[string]string{"path": "api/prod/*"}, http.StatusForbidden},
+		{"Invalid pattern", owner, map[string]string{"path": "api/["}, http.StatusBadRequest},
+		{"Unknown provider", owner, map[string]string{"provider": "ldap"}, http.StatusBadRequest},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := srv.Do(http.MethodPost, bulkRotate, tc.token, tc.body)
+			apitest.ExpectStatus(t, resp, tc.status)
+		})
+	}
+
+	resp = srv.Do(http.MethodPost, bulkRotate, owner, map[string]string{"path": "api/prod/*"})
+	apitest.ExpectStatus(t, resp, http.StatusAccepted)
+	var bulk models.BulkRotation
+	apitest.DecodeJSON(t, resp, &bulk)
+	if bulk.ID == "" || bulk.TotalItems != 4 {
+		t.Fatalf("Expected a job covering the 4 secrets of api/prod, got %+v", bulk)
+	}
+
+	bulk = waitBulkRotation(t, srv, owner, org.ID, bulk.ID)
+	statuses := map[string]string{}
+	for _, item := range bulk.Items {
+		statuses[item.SecretName] = item.Status
+	}
+	expected := map[string]string{
+		"DB_PASSWORD": models.BulkItemRotated,
+		"API_KEY":     models.BulkItemFailed,
+		"DB_ADMIN":    models.BulkItemSkipped,
+		"API_ADMIN":   models.BulkItemSkipped,
+	}
+	for name, status := range expected {
+		if statuses[name] != status {
+			t.Errorf("Expected %s to be %s, got %q", name, status, statuses[name])
+		}
+	}
+	if bulk.FailedItems != 1 || bulk.PendingItems != 0 {
+		t.Errorf("Expected one failure and nothing pending, got %+v", bulk)
+	}
+
+	resp = srv.Do(http.MethodGet, apiSecrets+"/DB_PASSWORD", owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var secret models.Secret
+	apitest.DecodeJSON(t, resp, &secret)
+	if secret.Value == "v1" {
+		t.Error("Expected DB_PASSWORD to be rotated")
+	}
+	resp = srv.Do(http.MethodGet, webSecrets+"/TOKEN", owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	apitest.DecodeJSON(t, resp, &secret)
+	if secret.Value != "t1" {
+		t.Errorf("Expected TOKEN outside the filter to be kept, got %s", secret.Value)
+	}
+
+	// Le filtre par fournisseur ne retient que les secrets avec un rotateur
+	resp = srv.Do(http.MethodPost, bulkRotate, owner, map[string]string{"provider": "fake"})
+	apitest.ExpectStatus(t, resp, http.StatusAccepted)
+	apitest.DecodeJSON(t, resp, &bulk)
+	if bulk.TotalItems != 3 {
+		t.Errorf("Expected the 3 secrets with a rotator, got %d", bulk.TotalItems)
+	}
+	waitBulkRotation(t, srv, owner, org.ID, bulk.ID)
+
+	resp = srv.Do(http.MethodGet, "/api/v1/organizations/"+org.ID+"/bulk-rotations/unknown", owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusNotFound)
+}
+
+// waitBulkRotation attend la fin d'une rotation groupée et renvoie son avancement
+func waitBulkRotation(t *testing.T, srv *apitest.Server, token, orgID, id string) models.BulkRotation {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		resp := srv.Do(http.MethodGet, "/api/v1/organizations/"+orgID+"/bulk-rotations/"+id, token, nil)
+		apitest.ExpectStatus(t, resp, http.StatusOK)
+		var bulk models.BulkRotation
+		apitest.DecodeJSON(t, resp, &bulk)
+		if bulk.Finished() {
+			return bulk
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected the bulk rotation to complete, got %+v", bulk)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
@@ -0,0 +1,52 @@
+// filepath: internal/api/cache_test.go
+
+package api_test
+
+import (
+	"net/http"
+	"testing"
+
+	"secrets-manager/internal/api/apitest"
+	"secrets-manager/internal/models"
+)
+
+func TestProjectsListConditionalRequests(t *testing.T) {
+	srv := apitest.NewServer(t)
+	ownerID := srv.Register("owner@example.com", "password123")
+	token := srv.Login("owner@example.com", "password123")
+	org := srv.CreateOrganization("acme", ownerID)
+	project := srv.CreateProject(org.ID, "api", ownerID)
+
+	projects := "/api/v1/organizations/" + org.ID + "/projects"
+	resp := srv.Do(http.MethodGet, projects, token, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+
+	etag := resp.Header.Get("ETag")
+	if etag == "" || resp.Header.Get("Last-Modified") == "" {
+		t.Fatalf("Expected ETag and Last-Modified headers, got %v", resp.Header)
+	}
+	if cc := resp.Header.Get("Cache-Control"); cc != "private, max-age=30, must-revalidate" {
+		t.Errorf("Expected a private Cache-Control, got %q", cc)
+	}
+
+	// Copie à jour : 304 sans corps
+	resp = srv.DoWithHeaders(http.MethodGet, projects, token, http.Header{"If-None-Match": {etag}}, nil)
+	apitest.ExpectStatus(t, resp, http.StatusNotModified)
+
+	// Un nouveau secret change le compteur du projet : la copie est périmée
+	secrets := "/api/v1/organizations/" + org.ID + "/projects/" + project.ID + "/environments/dev/secrets"
+	resp = srv.Do(http.MethodPost, secrets, token, models.Secret{Name: "DB_PASSWORD", Value: "hunter2"})
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+
+	resp = srv.DoWithHeaders(http.MethodGet, projects, token, http.Header{"If-None-Match": {etag}}, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	if resp.Header.Get("ETag") == etag {
+		t.Errorf("Expected a new ETag after a secret was created")
+	}
+
+	// Les droits sont vérifiés même pour une requête conditionnelle
+	srv.Register("outsider@example.com", "password123")
+	outsiderToken := srv.Login("outsider@example.com", "password123")
+	resp = srv.DoWithHeaders(http.MethodGet, projects, outsiderToken, http.Header{"If-None-Match": {"*"}}, nil)
+	apitest.ExpectStatus(t, resp, http.StatusNotFound)
+}
@@ -0,0 +1,95 @@
+// filepath: internal/api/calendar_test.go
+
+package api_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"secrets-manager/internal/api/apitest"
+	"secrets-manager/internal/models"
+)
+
+func TestCalendar(t *testing.T) {
+	srv := apitest.NewServer(t)
+	ownerID := srv.Register("owner@example.com", "password123")
+	owner := srv.Login("owner@example.com", "password123")
+	memberID := srv.Register("member@example.com", "password123")
+	member := srv.Login("member@example.com", "password123")
+	srv.Register("outsider@example.com", "password123")
+	outsider := srv.Login("outsider@example.com", "password123")
+	org := srv.CreateOrganization("acme", ownerID)
+	srv.AddMember(org.ID, memberID, "member")
+	project := srv.CreateProject(org.ID, "api", ownerID)
+	ctx := context.Background()
+
+	secrets := "/api/v1/organizations/" + org.ID + "/projects/" + project.ID + "/environments/prod/secrets"
+	resp := srv.Do(http.MethodPost, secrets, owner, models.Secret{Name: "DB_PASSWORD", Value: "v1"})
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+	resp = srv.Do(http.MethodPut, secrets+"/DB_PASSWORD", owner, map[string]any{
+		"value": "v2", "effective_at": time.Now().Add(48 * time.Hour)})
+	apitest.ExpectStatus(t, resp, http.StatusAccepted)
+
+	rotator := &models.SecretRotator{OrganizationID: org.ID, ProjectID: project.ID, Environment: "prod",
+		SecretName: "API_KEY", Provider: "test", Strategy: models.RotationDual}
+	if err := srv.SecretRotators.SaveSecretRotator(ctx, rotator); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expiresAt := time.Now().Add(12 * time.Hour)
+	if err := srv.SecretRotators.SetPreviousExpiry(ctx, rotator.ID, &expiresAt); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	srv.DB.SetSubscription(&models.Subscription{ID: "sub-1", OrganizationID: org.ID, Status: "active",
+		EndDate: time.Now().Add(10 * 24 * time.Hour)})
+
+	calendar := "/api/v1/organizations/" + org.ID + "/calendar"
+	listEvents := func(t *testing.T, token, query string) []models.CalendarEvent {
+		t.Helper()
+		resp := srv.Do(http.MethodGet, calendar+query, token, nil)
+		apitest.ExpectStatus(t, resp, http.StatusOK)
+		var events []models.CalendarEvent
+		apitest.DecodeJSON(t, resp, &events)
+		return events
+	}
+
+	events := listEvents(t, owner, "")
+	if len(events) != 3 {
+		t.Fatalf("Expected 3 events, got %+v", events)
+	}
+	for i, expected := range []string{models.CalendarSecretExpiration, models.CalendarScheduledChange, models.CalendarSubscriptionRenewal} {
+		if events[i].Type != expected {
+			t.Errorf("Expected event %d to be %s, got %s", i, expected, events[i].Type)
+		}
+	}
+	if events[0].SecretName != "API_KEY_previous" {
+		t.Errorf("Expected the previous value to expire, got %s", events[0].SecretName)
+	}
+
+	// L'abonnement est réservé aux administrateurs, la période est ajustable
+	if events := listEvents(t, member, ""); len(events) != 2 {
+		t.Errorf("Expected 2 events for a member, got %+v", events)
+	}
+	if events := listEvents(t, owner, "?days=1"); len(events) != 1 {
+		t.Errorf("Expected 1 event in the next day, got %+v", events)
+	}
+	resp = srv.Do(http.MethodGet, calendar+"?days=400", owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusBadRequest)
+	resp = srv.Do(http.MethodGet, calendar, outsider, nil)
+	apitest.ExpectStatus(t, resp, http.StatusNotFound)
+
+	resp = srv.Do(http.MethodGet, calendar+".ics", owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	if contentType := resp.Header.Get("Content-Type"); !strings.HasPrefix(contentType, "text/calendar") {
+		t.Errorf("Expected a calendar, got %s", contentType)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	ics := string(body)
+	if strings.Count(ics, "BEGIN:VEVENT\r\n") != 3 || !strings.Contains(ics, "SUMMARY:Changement planifié de DB_PASSWORD (prod)\r\n") {
+		t.Errorf("Expected 3 events in the feed, got %s", ics)
+	}
+}
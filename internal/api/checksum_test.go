@@ -0,0 +1,80 @@
+// filepath: internal/api/checksum_test.go
+
+package api_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"secrets-manager/internal/api/apitest"
+	"secrets-manager/internal/models"
+)
+
+func TestSecretChecksumsForDriftDetection(t *testing.T) {
+	srv := apitest.NewServer(t)
+	ownerID := srv.Register("owner@example.com", "password123")
+	token := srv.Login("owner@example.com", "password123")
+	org := srv.CreateOrganization("acme", ownerID)
+	project := srv.CreateProject(org.ID, "api", ownerID)
+
+	secrets := "/api/v1/organizations/" + org.ID + "/projects/" + project.ID + "/environments/dev/secrets"
+	checksum := func(name string) string {
+		t.Helper()
+		resp := srv.Do(http.MethodGet, secrets+"/"+name+"/metadata", token, nil)
+		apitest.ExpectStatus(t, resp, http.StatusOK)
+		var metadata models.SecretMetadata
+		apitest.DecodeJSON(t, resp, &metadata)
+		return metadata.Checksum
+	}
+
+	resp := srv.Do(http.MethodPost, secrets, token, models.Secret{Name: "API_KEY", Value: "v1"})
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+	first := checksum("API_KEY")
+	if !strings.HasPrefix(first, "hmac-sha256:") {
+		t.Fatalf("Expected an HMAC checksum, got %q", first)
+	}
+
+	// Même valeur : même somme ; nouvelle valeur : somme différente
+	resp = srv.Do(http.MethodPut, secrets+"/API_KEY", token, models.Secret{Value: "v1", Description: "clé"})
+	apitest.ExpectStatus(t, resp, http.StatusNoContent)
+	if got := checksum("API_KEY"); got != first {
+		t.Errorf("Expected stable checksum %q, got %q", first, got)
+	}
+	resp = srv.Do(http.MethodPut, secrets+"/API_KEY", token, models.Secret{Value: "v2"})
+	apitest.ExpectStatus(t, resp, http.StatusNoContent)
+	if got := checksum("API_KEY"); got == first {
+		t.Errorf("Expected checksum to change with the value")
+	}
+
+	// Secret écrit avant l'activation des sommes : calculée à la lecture des métadonnées
+	ctx := context.Background()
+	legacy := &models.Secret{OrganizationID: org.ID, ProjectID: project.ID, Environment: "dev", Name: "LEGACY", Value: "old"}
+	if err := srv.VaultService.StoreSecret(ctx, legacy); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if err := srv.Secrets.CreateSecretMetadata(ctx, &models.SecretMetadata{
+		Name: "LEGACY", OrganizationID: org.ID, ProjectID: project.ID, Environment: "dev", Version: 1,
+	}); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+
+	resp = srv.Do(http.MethodGet, secrets+":metadata", token, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	body, _ := io.ReadAll(resp.Body)
+	if strings.Contains(string(body), `"old"`) || strings.Contains(string(body), `"v2"`) {
+		t.Errorf("Expected no secret value in metadata, got %s", body)
+	}
+	if want := srv.Checksummer.Sum(legacy); !strings.Contains(string(body), want) {
+		t.Errorf("Expected backfilled checksum %q in %s", want, body)
+	}
+	stored, _ := srv.Secrets.GetSecretMetadataByPath(ctx, org.ID, project.ID, "dev", "LEGACY")
+	if stored == nil || stored.Checksum != srv.Checksummer.Sum(legacy) {
+		t.Errorf("Expected persisted checksum, got %+v", stored)
+	}
+
+	resp = srv.Do(http.MethodGet, secrets+"/MISSING/metadata", token, nil)
+	apitest.ExpectStatus(t, resp, http.StatusNotFound)
+}
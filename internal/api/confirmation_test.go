@@ -0,0 +1,225 @@
+// filepath: internal/api/confirmation_test.go
+
+package api_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"secrets-manager/internal/api/apitest"
+	"secrets-manager/internal/api/handlers"
+	"secrets-manager/internal/jobs"
+	"secrets-manager/internal/models"
+)
+
+// confirmed rejoue une requête avec le token de confirmation donné
+func confirmed(token string) http.Header {
+	return http.Header{handlers.ConfirmationTokenHeader: []string{token}}
+}
+
+func TestOrganizationDeletionRequiresConfirmation(t *testing.T) {
+	srv := apitest.NewServer(t)
+	ownerID := srv.Register("owner@example.com", "password123")
+	adminID := srv.Register("admin@example.com", "password123")
+	org := srv.CreateOrganization("acme", ownerID)
+	srv.AddMember(org.ID, adminID, "admin")
+
+	owner := srv.Login("owner@example.com", "password123")
+	admin := srv.Login("admin@example.com", "password123")
+	path := "/api/v1/organizations/" + org.ID
+	project := srv.CreateProject(org.ID, "api", ownerID)
+	secretPath := path + "/projects/" + project.ID + "/environments/prod/secrets"
+	resp := srv.Do(http.MethodPost, secretPath, owner, models.Secret{Name: "DB", Value: "v"})
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+
+	// Un administrateur qui n'est pas propriétaire ne peut pas supprimer
+	resp = srv.Do(http.MethodDelete, path, admin, nil)
+	apitest.ExpectStatus(t, resp, http.StatusForbidden)
+
+	// Première étape : rien n'est supprimé, un token est renvoyé
+	resp = srv.Do(http.MethodDelete, path, owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusAccepted)
+	var confirmation handlers.ConfirmationResponse
+	apitest.DecodeJSON(t, resp, &confirmation)
+	if confirmation.ConfirmationToken == "" || confirmation.Action != models.ActionDeleteOrganization {
+		t.Fatalf("Unexpected confirmation: %+v", confirmation)
+	}
+	if _, err := srv.Organizations.GetOrganizationByID(context.Background(), org.ID); err != nil {
+		t.Fatalf("Expected organization to still exist, got %v", err)
+	}
+
+	// Un token inconnu est refusé
+	resp = srv.DoWithHeaders(http.MethodDelete, path, owner, confirmed("invalide"), nil)
+	apitest.ExpectStatus(t, resp, http.StatusPreconditionFailed)
+
+	resp = srv.DoWithHeaders(http.MethodDelete, path, owner, confirmed(confirmation.ConfirmationToken), nil)
+	apitest.ExpectStatus(t, resp, http.StatusAccepted)
+	var deletion models.OrganizationDeletion
+	apitest.DecodeJSON(t, resp, &deletion)
+	if resp.Header.Get("Location") != "/api/v1/organization-deletions/"+deletion.ID {
+		t.Errorf("Unexpected Location header: %s", resp.Header.Get("Location"))
+	}
+
+	// Seul l'auteur de la demande peut suivre la suppression
+	resp = srv.Do(http.MethodGet, "/api/v1/organization-deletions/"+deletion.ID, admin, nil)
+	apitest.ExpectStatus(t, resp, http.StatusNotFound)
+
+	srv.ExpireRecycleBin()
+	waitForDeletion(t, srv, owner, deletion.ID)
+	if srv.SecretStore.Versions(org.ID+"/"+project.ID+"/prod/DB") != 0 {
+		t.Error("Expected the Vault value to be deleted with the organization")
+	}
+
+	// L'organisation n'existe plus pour personne
+	resp = srv.DoWithHeaders(http.MethodDelete, path, owner, confirmed(confirmation.ConfirmationToken), nil)
+	apitest.ExpectStatus(t, resp, http.StatusNotFound)
+}
+
+// waitForDeletion attend la fin d'une suppression d'organisation exécutée en tâche de fond
+func waitForDeletion(t *testing.T, srv *apitest.Server, token, deletionID string) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		resp := srv.Do(http.MethodGet, "/api/v1/organization-deletions/"+deletionID, token, nil)
+		apitest.ExpectStatus(t, resp, http.StatusOK)
+		var deletion models.OrganizationDeletion
+		apitest.DecodeJSON(t, resp, &deletion)
+
+		switch {
+		case deletion.Status == models.DeletionCompleted:
+			if deletion.CompletedStages != deletion.TotalStages {
+				t.Errorf("Expected %d completed stages, got %d", deletion.TotalStages, deletion.CompletedStages)
+			}
+			return
+		case deletion.Status == models.DeletionFailed:
+			t.Fatalf("Expected deletion to complete, got error: %s", deletion.Error)
+		case time.Now().After(deadline):
+			t.Fatalf("Deletion still %s at stage %s", deletion.Status, deletion.Stage)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestProjectAndBulkDeletionConfirmation(t *testing.T) {
+	srv := apitest.NewServer(t)
+	adminID := srv.Register("admin@example.com", "password123")
+	org := srv.CreateOrganization("acme", adminID)
+	project := srv.CreateProject(org.ID, "api", adminID)
+	admin := srv.Login("admin@example.com", "password123")
+
+	base := "/api/v1/organizations/" + org.ID + "/projects/" + project.ID + "/environments/dev/secrets"
+	for _, name := range []string{"A", "B", "C"} {
+		resp := srv.Do(http.MethodPost, base, admin, models.Secret{Name: name, Value: "v"})
+		apitest.ExpectStatus(t, resp, http.StatusCreated)
+	}
+
+	// Un token obtenu pour une liste ne vaut pas pour une autre
+	resp := srv.Do(http.MethodPost, base+":bulkDelete", admin, handlers.BulkDeleteRequest{Names: []string{"A", "B"}})
+	apitest.ExpectStatus(t, resp, http.StatusAccepted)
+	var confirmation handlers.ConfirmationResponse
+	apitest.DecodeJSON(t, resp, &confirmation)
+
+	resp = srv.DoWithHeaders(http.MethodPost, base+":bulkDelete", admin, confirmed(confirmation.ConfirmationToken),
+		handlers.BulkDeleteRequest{Names: []string{"A", "B", "C"}})
+	apitest.ExpectStatus(t, resp, http.StatusPreconditionFailed)
+
+	resp = srv.Do(http.MethodPost, base+":bulkDelete", admin, handlers.BulkDeleteRequest{Names: []string{"B", "A"}})
+	apitest.ExpectStatus(t, resp, http.StatusAccepted)
+	apitest.DecodeJSON(t, resp, &confirmation)
+
+	resp = srv.DoWithHeaders(http.MethodPost, base+":bulkDelete", admin, confirmed(confirmation.ConfirmationToken),
+		handlers.BulkDeleteRequest{Names: []string{"A", "B"}})
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+
+	resp = srv.Do(http.MethodGet, base+"/A", admin, nil)
+	apitest.ExpectStatus(t, resp, http.StatusNotFound)
+	resp = srv.Do(http.MethodGet, base+"/C", admin, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+
+	// Suppression du projet : il part dans la corbeille et ses secrets sont masqués
+	projectPath := "/api/v1/organizations/" + org.ID + "/projects/" + project.ID
+	resp = srv.Do(http.MethodDelete, projectPath, admin, nil)
+	apitest.ExpectStatus(t, resp, http.StatusAccepted)
+	apitest.DecodeJSON(t, resp, &confirmation)
+
+	resp = srv.DoWithHeaders(http.MethodDelete, projectPath, admin, confirmed(confirmation.ConfirmationToken), nil)
+	apitest.ExpectStatus(t, resp, http.StatusAccepted)
+
+	resp = srv.Do(http.MethodGet, base+"/C", admin, nil)
+	apitest.ExpectStatus(t, resp, http.StatusNotFound)
+
+	// À la purge, valeurs Vault et métadonnées disparaissent
+	purge := jobs.PurgeSuspendedProjects(srv.Projects, srv.VaultService, 0)
+	if err := purge(context.Background()); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if srv.SecretStore.Versions(org.ID+"/"+project.ID+"/dev/C") != 0 {
+		t.Error("Expected the Vault value to be purged with the project")
+	}
+	if count, _ := srv.Secrets.GetSecretsCount(context.Background(), org.ID); count != 0 {
+		t.Errorf("Expected no remaining secret metadata, got %d", count)
+	}
+}
+
+func TestRecycleBinRestore(t *testing.T) {
+	srv := apitest.NewServer(t)
+	ownerID := srv.Register("owner@example.com", "password123")
+	org := srv.CreateOrganization("acme", ownerID)
+	project := srv.CreateProject(org.ID, "api", ownerID)
+	owner := srv.Login("owner@example.com", "password123")
+
+	orgPath := "/api/v1/organizations/" + org.ID
+	projectPath := orgPath + "/projects/" + project.ID
+	secretPath := projectPath + "/environments/prod/secrets"
+	resp := srv.Do(http.MethodPost, secretPath, owner, models.Secret{Name: "DB", Value: "v"})
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+
+	// deleteConfirmed supprime une ressource en deux étapes
+	deleteConfirmed := func(path string) {
+		resp := srv.Do(http.MethodDelete, path, owner, nil)
+		apitest.ExpectStatus(t, resp, http.StatusAccepted)
+		var confirmation handlers.ConfirmationResponse
+		apitest.DecodeJSON(t, resp, &confirmation)
+		resp = srv.DoWithHeaders(http.MethodDelete, path, owner, confirmed(confirmation.ConfirmationToken), nil)
+		apitest.ExpectStatus(t, resp, http.StatusAccepted)
+	}
+
+	// Projet supprimé puis restauré : le secret redevient lisible
+	deleteConfirmed(projectPath)
+	resp = srv.Do(http.MethodGet, secretPath+"/DB", owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusNotFound)
+
+	resp = srv.DoAdmin(http.MethodPost, "/admin/recycle-bin"+strings.TrimPrefix(projectPath, "/api/v1")+"/restore")
+	apitest.ExpectStatus(t, resp, http.StatusNoContent)
+	resp = srv.Do(http.MethodGet, secretPath+"/DB", owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+
+	// Organisation supprimée : plus accessible, mais visible dans la corbeille
+	deleteConfirmed(orgPath)
+	resp = srv.Do(http.MethodGet, secretPath+"/DB", owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusNotFound)
+
+	resp = srv.DoAdmin(http.MethodGet, "/admin/recycle-bin")
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var entries []handlers.RecycleBinEntry
+	apitest.DecodeJSON(t, resp, &entries)
+	if len(entries) != 1 || entries[0].ID != org.ID || !entries[0].Restorable {
+		t.Fatalf("Unexpected recycle bin: %+v", entries)
+	}
+	if !entries[0].PurgeAfter.After(entries[0].DeletedAt) {
+		t.Errorf("Expected purge after deletion, got %+v", entries[0])
+	}
+
+	resp = srv.DoAdmin(http.MethodPost, "/admin/recycle-bin/organizations/"+org.ID+"/restore")
+	apitest.ExpectStatus(t, resp, http.StatusNoContent)
+	resp = srv.Do(http.MethodGet, secretPath+"/DB", owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+
+	// Une organisation restaurée ne peut pas l'être deux fois
+	resp = srv.DoAdmin(http.MethodPost, "/admin/recycle-bin/organizations/"+org.ID+"/restore")
+	apitest.ExpectStatus(t, resp, http.StatusNotFound)
+}
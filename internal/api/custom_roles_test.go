@@ -0,0 +1,95 @@
+// filepath: internal/api/custom_roles_test.go
+
+package api_test
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"testing"
+
+	"secrets-manager/internal/api/apitest"
+	"secrets-manager/internal/api/handlers"
+	"secrets-manager/internal/models"
+)
+
+func TestCustomRoles(t *testing.T) {
+	srv := apitest.NewServer(t)
+	ownerID := srv.Register("owner@example.com", "password123")
+	owner := srv.Login("owner@example.com", "password123")
+	viewerID := srv.Register("viewer@example.com", "password123")
+	viewer := srv.Login("viewer@example.com", "password123")
+	org := srv.CreateOrganization("acme", ownerID)
+	srv.AddMember(org.ID, viewerID, "viewer")
+	payments := srv.CreateProject(org.ID, "payments", ownerID)
+	roles := "/api/v1/organizations/" + org.ID + "/roles"
+	assignment := "/api/v1/organizations/" + org.ID + "/members/" + viewerID + "/custom-role"
+	auditLogs := "/api/v1/organizations/" + org.ID + "/audit-logs"
+	secrets := "/api/v1/organizations/" + org.ID + "/projects/" + payments.ID + "/environments/prod/secrets"
+	auditor := handlers.CustomRoleRequest{
+		Name:        "auditor",
+		Permissions: []string{models.PermissionSecretsWrite, models.PermissionAuditRead},
+	}
+
+	// Les rôles personnalisés sont réservés au plan Enterprise et aux administrateurs
+	resp := srv.Do(http.MethodPost, roles, owner, auditor)
+	apitest.ExpectStatus(t, resp, http.StatusPaymentRequired)
+	if err := srv.Organizations.UpdateOrganizationPlan(context.Background(), org.ID, "plan-enterprise"); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	resp = srv.Do(http.MethodPost, roles, viewer, auditor)
+	apitest.ExpectStatus(t, resp, http.StatusForbidden)
+	resp = srv.Do(http.MethodPost, roles, owner, handlers.CustomRoleRequest{Name: "x", Permissions: []string{"secrets.delete"}})
+	apitest.ExpectStatus(t, resp, http.StatusBadRequest)
+	resp = srv.Do(http.MethodPost, roles, owner, auditor)
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+	var role models.CustomRole
+	apitest.DecodeJSON(t, resp, &role)
+	resp = srv.Do(http.MethodPost, roles, owner, auditor)
+	apitest.ExpectStatus(t, resp, http.StatusConflict)
+
+	// Sans rôle personnalisé, le lecteur ne modifie rien et ne lit pas le journal
+	resp = srv.Do(http.MethodPost, secrets, viewer, models.Secret{Name: "API_KEY", Value: "kX9#vQ2$mL7!pR4&wT8*"})
+	apitest.ExpectStatus(t, resp, http.StatusForbidden)
+	resp = srv.Do(http.MethodGet, auditLogs, viewer, nil)
+	apitest.ExpectStatus(t, resp, http.StatusForbidden)
+
+	resp = srv.Do(http.MethodPut, assignment, owner, handlers.CustomRoleAssignmentRequest{RoleID: "unknown"})
+	apitest.ExpectStatus(t, resp, http.StatusNotFound)
+	resp = srv.Do(http.MethodPut, assignment, owner, handlers.CustomRoleAssignmentRequest{RoleID: role.ID})
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+
+	// Ses permissions s'ajoutent à son rôle, sans conférer les autres
+	resp = srv.Do(http.MethodPost, secrets, viewer, models.Secret{Name: "API_KEY", Value: "kX9#vQ2$mL7!pR4&wT8*"})
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+	resp = srv.Do(http.MethodGet, auditLogs, viewer, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	resp = srv.Do(http.MethodPost, "/api/v1/organizations/"+org.ID+"/invitations", viewer,
+		map[string]string{"email": "new@example.com"})
+	apitest.ExpectStatus(t, resp, http.StatusForbidden)
+
+	resp = srv.Do(http.MethodGet, "/api/v1/organizations/"+org.ID+"/members/"+viewerID+"/permissions?project="+
+		payments.ID, viewer, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var permissions models.EffectivePermissions
+	apitest.DecodeJSON(t, resp, &permissions)
+	decisive := permissions.Rules[len(permissions.Rules)-1]
+	if permissions.Role != "member" || !permissions.CanWrite || decisive.Source != models.PermissionSourceCustomRole ||
+		!decisive.Decisive || decisive.CustomRoleID != role.ID ||
+		!slices.Contains(permissions.Permissions, models.PermissionAuditRead) {
+		t.Errorf("Expected the custom role to grant member, got %+v", permissions)
+	}
+
+	// Supprimer le rôle retire ses permissions
+	resp = srv.Do(http.MethodDelete, roles+"/"+role.ID, owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusNoContent)
+	resp = srv.Do(http.MethodGet, auditLogs, viewer, nil)
+	apitest.ExpectStatus(t, resp, http.StatusForbidden)
+	resp = srv.Do(http.MethodGet, roles, viewer, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var listed []models.CustomRole
+	apitest.DecodeJSON(t, resp, &listed)
+	if len(listed) != 0 {
+		t.Errorf("Expected no custom role, got %+v", listed)
+	}
+}
@@ -0,0 +1,97 @@
+// filepath: internal/api/data_keys_test.go
+
+package api_test
+
+import (
+	"encoding/base64"
+	"net/http"
+	"testing"
+
+	"secrets-manager/internal/api/apitest"
+	"secrets-manager/internal/api/handlers"
+	"secrets-manager/internal/models"
+)
+
+func TestDataKeys(t *testing.T) {
+	srv := apitest.NewServer(t)
+	ownerID := srv.Register("owner@example.com", "password123")
+	owner := srv.Login("owner@example.com", "password123")
+	memberID := srv.Register("member@example.com", "password123")
+	member := srv.Login("member@example.com", "password123")
+	org := srv.CreateOrganization("acme", ownerID)
+	srv.AddMember(org.ID, memberID, "member")
+	project := srv.CreateProject(org.ID, "infra", ownerID)
+
+	keys := "/api/v1/organizations/" + org.ID + "/projects/" + project.ID + "/data-keys"
+	resp := srv.Do(http.MethodPost, keys, member, map[string]string{"name": "tfstate"})
+	apitest.ExpectStatus(t, resp, http.StatusForbidden)
+	resp = srv.Do(http.MethodPost, keys, owner, map[string]string{"name": "tf/state"})
+	apitest.ExpectStatus(t, resp, http.StatusBadRequest)
+	resp = srv.Do(http.MethodPost, keys, owner, map[string]string{"name": "tfstate"})
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+	var key models.DataKey
+	apitest.DecodeJSON(t, resp, &key)
+	if key.ID == "" || key.TransitKey == "" || key.CreatedBy != ownerID {
+		t.Errorf("Expected a registered key, got %+v", key)
+	}
+	resp = srv.Do(http.MethodPost, keys, owner, map[string]string{"name": "tfstate"})
+	apitest.ExpectStatus(t, resp, http.StatusConflict)
+	resp = srv.Do(http.MethodPost, keys, owner, map[string]string{"name": "sops"})
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+
+	state := `{"version":4,"resources":[{"password":"hunter2"}]}`
+	resp = srv.Do(http.MethodPost, keys+"/tfstate:encrypt", member, handlers.DataKeyPayload{
+		Plaintext: base64.StdEncoding.EncodeToString([]byte(state))})
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var envelope models.DataKeyEnvelope
+	apitest.DecodeJSON(t, resp, &envelope)
+	if envelope.KeyID != key.ID || envelope.WrappedKey == "" || envelope.Ciphertext == "" {
+		t.Fatalf("Expected an envelope, got %+v", envelope)
+	}
+
+	resp = srv.Do(http.MethodPost, keys+"/tfstate:decrypt", member, envelope)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var payload handlers.DataKeyPayload
+	apitest.DecodeJSON(t, resp, &payload)
+	if decoded, _ := base64.StdEncoding.DecodeString(payload.Plaintext); string(decoded) != state {
+		t.Errorf("Expected the state back, got %q", decoded)
+	}
+
+	// Une enveloppe ne s'ouvre qu'avec sa clé et sans altération
+	resp = srv.Do(http.MethodPost, keys+"/sops:decrypt", member, envelope)
+	apitest.ExpectStatus(t, resp, http.StatusBadRequest)
+	tampered := envelope
+	raw, _ := base64.StdEncoding.DecodeString(envelope.Ciphertext)
+	raw[len(raw)-1] ^= 1
+	tampered.Ciphertext = base64.StdEncoding.EncodeToString(raw)
+	resp = srv.Do(http.MethodPost, keys+"/tfstate:decrypt", member, tampered)
+	apitest.ExpectStatus(t, resp, http.StatusBadRequest)
+
+	// Un token en lecture déchiffre mais ne chiffre pas
+	resp = srv.Do(http.MethodPost, "/api/v1/me/tokens", owner, map[string]any{
+		"name": "ci", "scopes": []string{"secrets:read"}})
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+	var token handlers.CreatedToken
+	apitest.DecodeJSON(t, resp, &token)
+	resp = srv.Do(http.MethodPost, keys+"/tfstate:decrypt", token.Token, envelope)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	resp = srv.Do(http.MethodPost, keys+"/tfstate:encrypt", token.Token, handlers.DataKeyPayload{Plaintext: ""})
+	apitest.ExpectStatus(t, resp, http.StatusForbidden)
+
+	// Chaque appel est audité, réussi ou non
+	resp = srv.Do(http.MethodGet, keys+"/tfstate/operations", member, nil)
+	apitest.ExpectStatus(t, resp, http.StatusForbidden)
+	resp = srv.Do(http.MethodGet, keys+"/tfstate/operations", owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var operations []models.DataKeyOperation
+	apitest.DecodeJSON(t, resp, &operations)
+	if len(operations) != 4 {
+		t.Fatalf("Expected 4 audited operations, got %+v", operations)
+	}
+	if operations[0].ActorID != ownerID || operations[0].Operation != models.DataKeyDecrypt || operations[0].Error != "" {
+		t.Errorf("Expected the latest decryption by the owner, got %+v", operations[0])
+	}
+	if operations[1].Error == "" || operations[3].Operation != models.DataKeyEncrypt || operations[3].Size != len(state) {
+		t.Errorf("Expected a failed decryption and the first encryption, got %+v", operations)
+	}
+}
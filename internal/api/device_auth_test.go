@@ -0,0 +1,60 @@
+// filepath: internal/api/device_auth_test.go
+
+package api_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"secrets-manager/internal/api/apitest"
+	"secrets-manager/internal/api/handlers"
+)
+
+func TestDeviceCodeFlow(t *testing.T) {
+	srv := apitest.NewServer(t)
+	srv.Register("dev@example.com", "password123")
+	token := srv.Login("dev@example.com", "password123")
+
+	resp := srv.Do(http.MethodPost, "/api/v1/auth/device/code", "", map[string]string{"client_name": "smctl"})
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var code handlers.DeviceCodeResponse
+	apitest.DecodeJSON(t, resp, &code)
+	if code.DeviceCode == "" || len(code.UserCode) != 9 || code.Interval <= 0 {
+		t.Fatalf("Expected a device code and a user code, got %+v", code)
+	}
+
+	poll := func() (int, string) {
+		resp := srv.Do(http.MethodPost, "/api/v1/auth/device/token", "", map[string]string{"device_code": code.DeviceCode})
+		var body map[string]string
+		apitest.DecodeJSON(t, resp, &body)
+		return resp.StatusCode, body["error"] + body["token"]
+	}
+
+	if status, result := poll(); status != http.StatusBadRequest || result != "authorization_pending" {
+		t.Errorf("Expected authorization_pending, got %d %s", status, result)
+	}
+	// Interroger sans respecter l'intervalle ralentit l'appareil
+	if _, result := poll(); result != "slow_down" {
+		t.Errorf("Expected slow_down, got %s", result)
+	}
+
+	// Le code peut être saisi en minuscules et sans tiret
+	userCode := strings.ToLower(strings.ReplaceAll(code.UserCode, "-", ""))
+	resp = srv.Do(http.MethodPost, "/api/v1/auth/device:approve", "", map[string]string{"user_code": userCode})
+	apitest.ExpectStatus(t, resp, http.StatusUnauthorized)
+	resp = srv.Do(http.MethodPost, "/api/v1/auth/device:approve", token, map[string]string{"user_code": userCode})
+	apitest.ExpectStatus(t, resp, http.StatusNoContent)
+
+	status, deviceToken := poll()
+	if status != http.StatusOK || deviceToken == "" {
+		t.Fatalf("Expected tokens once approved, got %d %s", status, deviceToken)
+	}
+	resp = srv.Do(http.MethodGet, "/api/v1/users/me/notification-preferences", deviceToken, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+
+	// Les tokens ne sont délivrés qu'une fois
+	if _, result := poll(); result != "expired_token" {
+		t.Errorf("Expected expired_token after the tokens were issued, got %s", result)
+	}
+}
@@ -0,0 +1,96 @@
+// filepath: internal/api/e2e_test.go
+
+package api_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"secrets-manager/internal/api/apitest"
+	"secrets-manager/internal/e2e"
+	"secrets-manager/internal/models"
+)
+
+func TestEndToEndEncryptedProject(t *testing.T) {
+	srv := apitest.NewServer(t)
+	ownerID := srv.Register("owner@example.com", "password123")
+	token := srv.Login("owner@example.com", "password123")
+	memberID := srv.Register("member@example.com", "password123")
+	memberToken := srv.Login("member@example.com", "password123")
+	org := srv.CreateOrganization("acme", ownerID)
+	srv.AddMember(org.ID, memberID, "member")
+	project := srv.CreateProject(org.ID, "api", ownerID)
+
+	keyURL := "/api/v1/organizations/" + org.ID + "/projects/" + project.ID + "/encryption-key"
+	secrets := "/api/v1/organizations/" + org.ID + "/projects/" + project.ID + "/environments/dev/secrets"
+
+	// Sans clé : mode désactivé, e2e refusé
+	resp := srv.Do(http.MethodGet, keyURL, memberToken, nil)
+	apitest.ExpectStatus(t, resp, http.StatusNotFound)
+	resp = srv.Do(http.MethodPost, secrets, token, models.Secret{Name: "A", Value: "v", E2E: true})
+	apitest.ExpectStatus(t, resp, http.StatusBadRequest)
+
+	privateKey, publicKey, err := e2e.GenerateKey()
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+
+	// Activation réservée aux administrateurs, clé validée
+	resp = srv.Do(http.MethodPut, keyURL, memberToken, map[string]string{"public_key": publicKey})
+	apitest.ExpectStatus(t, resp, http.StatusForbidden)
+	resp = srv.Do(http.MethodPut, keyURL, token, map[string]string{"public_key": "pas une clé"})
+	apitest.ExpectStatus(t, resp, http.StatusBadRequest)
+	resp = srv.Do(http.MethodPut, keyURL, token, map[string]string{"public_key": publicKey})
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+
+	resp = srv.Do(http.MethodGet, keyURL, memberToken, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var key models.ProjectEncryptionKey
+	apitest.DecodeJSON(t, resp, &key)
+	if key.PublicKey != publicKey || key.Algorithm != e2e.Algorithm || key.KeyID == "" {
+		t.Errorf("Unexpected project key: %+v", key)
+	}
+
+	// Une valeur en clair ou chiffrée pour une autre clé est refusée
+	resp = srv.Do(http.MethodPost, secrets, memberToken, models.Secret{Name: "DB_PASSWORD", Value: "hunter2"})
+	apitest.ExpectStatus(t, resp, http.StatusBadRequest)
+	_, otherKey, _ := e2e.GenerateKey()
+	foreign, _ := e2e.Seal(otherKey, "hunter2")
+	resp = srv.Do(http.MethodPost, secrets, memberToken, models.Secret{Name: "DB_PASSWORD", Value: foreign})
+	apitest.ExpectStatus(t, resp, http.StatusBadRequest)
+
+	sealed, err := e2e.Seal(publicKey, "hunter2")
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	resp = srv.Do(http.MethodPost, secrets, memberToken, models.Secret{Name: "DB_PASSWORD", Value: sealed})
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+
+	// Le serveur ne stocke que l'enveloppe chiffrée
+	stored, err := srv.SecretStore.GetSecret(context.Background(), org.ID+"/"+project.ID+"/dev/DB_PASSWORD")
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if value, _ := stored["value"].(string); strings.Contains(value, "hunter2") {
+		t.Errorf("Expected ciphertext in the store, got %q", value)
+	}
+
+	// Le secret est marqué E2E et seul le détenteur de la clé privée le lit
+	resp = srv.Do(http.MethodGet, secrets+"/DB_PASSWORD", memberToken, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var secret models.Secret
+	apitest.DecodeJSON(t, resp, &secret)
+	if !secret.E2E {
+		t.Errorf("Expected secret marked as E2E")
+	}
+	if plaintext, err := e2e.Open(privateKey, secret.Value); err != nil || plaintext != "hunter2" {
+		t.Errorf("Expected client-side decryption, got %q (%v)", plaintext, err)
+	}
+
+	metadata, err := srv.Secrets.GetSecretMetadataByPath(context.Background(), org.ID, project.ID, "dev", "DB_PASSWORD")
+	if err != nil || metadata == nil || !metadata.E2E {
+		t.Errorf("Expected E2E metadata, got %+v (%v)", metadata, err)
+	}
+}
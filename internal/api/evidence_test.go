@@ -0,0 +1,116 @@
+// filepath: internal/api/evidence_test.go
+
+package api_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"secrets-manager/internal/api/apitest"
+	"secrets-manager/internal/evidence"
+	"secrets-manager/internal/models"
+)
+
+func TestEvidenceExport(t *testing.T) {
+	srv := apitest.NewServer(t)
+	ownerID := srv.Register("owner@example.com", "password123")
+	token := srv.Login("owner@example.com", "password123")
+	memberID := srv.Register("member@example.com", "password123")
+	org := srv.CreateOrganization("acme", ownerID)
+	srv.AddMember(org.ID, memberID, "member")
+
+	resp := srv.Do(http.MethodPost, "/api/v1/organizations/"+org.ID+"/access-reviews", token, nil)
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+	resp.Body.Close()
+
+	// Réservé aux administrateurs
+	memberToken := srv.Login("member@example.com", "password123")
+	resp = srv.Do(http.MethodGet, "/api/v1/organizations/"+org.ID+"/evidence", memberToken, nil)
+	apitest.ExpectStatus(t, resp, http.StatusForbidden)
+
+	resp = srv.Do(http.MethodGet, "/api/v1/organizations/"+org.ID+"/evidence?from=2026-13-01", token, nil)
+	apitest.ExpectStatus(t, resp, http.StatusBadRequest)
+
+	resp = srv.Do(http.MethodGet, "/api/v1/evidence/public-key", token, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var key struct {
+		PublicKey string `json:"public_key"`
+	}
+	apitest.DecodeJSON(t, resp, &key)
+
+	resp = srv.Do(http.MethodGet, "/api/v1/organizations/"+org.ID+"/evidence", token, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "application/zip" {
+		t.Errorf("Expected application/zip, got %s", ct)
+	}
+	bundle, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+
+	manifest, err := evidence.Verify(bundle, key.PublicKey)
+	if err != nil {
+		t.Fatalf("Expected a valid signature but got: %v", err)
+	}
+	if manifest.OrganizationID != org.ID || manifest.GeneratedBy != ownerID {
+		t.Errorf("Unexpected manifest: %+v", manifest)
+	}
+	files := map[string]bool{}
+	for _, file := range manifest.Files {
+		files[file.Name] = true
+	}
+	for _, name := range []string{"members.json", "access_reviews.json", "api_usage.json", "admin_changes.json", "policies.json"} {
+		if !files[name] {
+			t.Errorf("Expected %s in the bundle", name)
+		}
+	}
+	if manifest.Unavailable["mfa_adoption"] == "" {
+		t.Error("Expected MFA adoption to be reported as unavailable")
+	}
+}
+
+func TestAdminAuditExport(t *testing.T) {
+	srv := apitest.NewServer(t)
+	resp := srv.DoAdmin(http.MethodGet, "/debug/buildinfo")
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+
+	resp = srv.DoAdmin(http.MethodGet, "/admin/audit/export?since=yesterday")
+	apitest.ExpectStatus(t, resp, http.StatusBadRequest)
+
+	resp = srv.DoAdmin(http.MethodGet, "/admin/audit/export")
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	bundle, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	manifest, err := evidence.Verify(bundle, srv.Evidence.PublicKey())
+	if err != nil {
+		t.Fatalf("Expected a valid signature but got: %v", err)
+	}
+	if manifest.OrganizationID != "" || len(manifest.Files) != 1 || manifest.Files[0].Name != "admin_audit.json" {
+		t.Fatalf("Unexpected manifest: %+v", manifest)
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(bundle), int64(len(bundle)))
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	file, err := archive.Open("admin_audit.json")
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	defer file.Close()
+	var entries []models.AdminAuditLog
+	if err := json.NewDecoder(file).Decode(&entries); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	// Les requêtes précédentes sont journalisées, la plus ancienne en tête
+	if len(entries) != 2 || entries[0].Path != "/debug/buildinfo" || entries[1].StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected both earlier admin requests, got %+v", entries)
+	}
+}
@@ -0,0 +1,183 @@
+// filepath: internal/api/graphql_test.go
+
+package api_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"secrets-manager/internal/api/apitest"
+	"secrets-manager/internal/api/handlers"
+	"secrets-manager/internal/models"
+)
+
+type graphQLResponse struct {
+	Data struct {
+		Organization *struct {
+			Role     string `json:"role"`
+			Projects []struct {
+				Name      string `json:"name"`
+				CreatedBy struct {
+					Email string `json:"email"`
+				} `json:"createdBy"`
+				Environments []struct {
+					Name    string `json:"name"`
+					Secrets []struct {
+						Name string `json:"name"`
+					} `json:"secrets"`
+				} `json:"environments"`
+			} `json:"projects"`
+			Usage *struct {
+				Total int `json:"total"`
+			} `json:"usage"`
+		} `json:"organization"`
+	} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+		Path    []any  `json:"path"`
+	} `json:"errors"`
+}
+
+func TestGraphQLOrganization(t *testing.T) {
+	srv := apitest.NewServer(t)
+	adminID := srv.Register("admin@example.com", "password123")
+	adminToken := srv.Login("admin@example.com", "password123")
+	viewerID := srv.Register("viewer@example.com", "password123")
+	viewerToken := srv.Login("viewer@example.com", "password123")
+	srv.Register("outsider@example.com", "password123")
+	outsiderToken := srv.Login("outsider@example.com", "password123")
+
+	org := srv.CreateOrganization("acme", adminID)
+	srv.AddMember(org.ID, viewerID, "viewer")
+	var apiProjectID string
+	for _, name := range []string{"api", "web"} {
+		project := srv.CreateProject(org.ID, name, adminID)
+		if name == "api" {
+			apiProjectID = project.ID
+		}
+		for _, env := range []string{"dev", "prod"} {
+			err := srv.Secrets.CreateSecretMetadata(context.Background(), &models.SecretMetadata{
+				Name: "DB_URL", OrganizationID: org.ID, ProjectID: project.ID, Environment: env, CreatedBy: adminID,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	query := map[string]any{
+		"query": `query Org($id: ID!) {
+			organization(id: $id) {
+				role
+				projects { name createdBy { email } environments { name secrets { name } } }
+				usage { total }
+			}
+		}`,
+		"variables": map[string]any{"id": org.ID},
+	}
+
+	resp := srv.Do(http.MethodPost, "/api/v1/graphql", adminToken, query)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var body graphQLResponse
+	apitest.DecodeJSON(t, resp, &body)
+
+	if len(body.Errors) > 0 {
+		t.Fatalf("Expected no errors for the admin, got %+v", body.Errors)
+	}
+	organization := body.Data.Organization
+	if organization == nil || organization.Role != "admin" || organization.Usage == nil {
+		t.Fatalf("Expected the organization with its usage, got %+v", organization)
+	}
+	if len(organization.Projects) != 2 {
+		t.Fatalf("Expected 2 projects, got %d", len(organization.Projects))
+	}
+	project := organization.Projects[0]
+	if project.CreatedBy.Email != "admin@example.com" {
+		t.Errorf("Expected project creator admin@example.com, got %q", project.CreatedBy.Email)
+	}
+	if len(project.Environments) != 2 || project.Environments[1].Name != "prod" ||
+		len(project.Environments[1].Secrets) != 1 || project.Environments[1].Secrets[0].Name != "DB_URL" {
+		t.Errorf("Expected dev and prod environments with their secret, got %+v", project.Environments)
+	}
+
+	// L'usage est réservé aux administrateurs : null et une erreur pour le lecteur
+	resp = srv.Do(http.MethodPost, "/api/v1/graphql", viewerToken, query)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	body = graphQLResponse{}
+	apitest.DecodeJSON(t, resp, &body)
+
+	if body.Data.Organization == nil || len(body.Data.Organization.Projects) != 2 {
+		t.Fatalf("Expected the viewer to see the projects, got %+v", body.Data.Organization)
+	}
+	if body.Data.Organization.Usage != nil {
+		t.Errorf("Expected usage to be null for a viewer, got %+v", body.Data.Organization.Usage)
+	}
+	if len(body.Errors) != 1 || len(body.Errors[0].Path) != 2 || body.Errors[0].Path[1] != "usage" {
+		t.Errorf("Expected a single error on organization.usage, got %+v", body.Errors)
+	}
+
+	// Un token restreint à l'environnement dev du projet api ne voit que lui
+	resp = srv.Do(http.MethodPost, "/api/v1/me/tokens", adminToken, map[string]any{
+		"name": "dashboard", "scopes": []string{models.ScopeMetadataRead},
+		"resources": []models.ResourceScope{{ProjectID: apiProjectID, Environment: "dev"}}})
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+	var restricted handlers.CreatedToken
+	apitest.DecodeJSON(t, resp, &restricted)
+	resp = srv.Do(http.MethodPost, "/api/v1/graphql", restricted.Token, query)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	body = graphQLResponse{}
+	apitest.DecodeJSON(t, resp, &body)
+
+	if body.Data.Organization == nil || len(body.Data.Organization.Projects) != 1 {
+		t.Fatalf("Expected the restricted token to see a single project, got %+v", body.Data.Organization)
+	}
+	project = body.Data.Organization.Projects[0]
+	if project.Name != "api" || len(project.Environments) != 1 || project.Environments[0].Name != "dev" ||
+		len(project.Environments[0].Secrets) != 1 {
+		t.Errorf("Expected only the dev environment of api, got %+v", project)
+	}
+	resp = srv.Do(http.MethodPost, "/api/v1/graphql", restricted.Token, map[string]any{
+		"query": `query Org($id: ID!) {
+			organization(id: $id) { projects { secrets(environment: "prod") { name } } }
+		}`,
+		"variables": map[string]any{"id": org.ID},
+	})
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var secrets struct {
+		Data struct {
+			Organization struct {
+				Projects []struct {
+					Secrets []struct {
+						Name string `json:"name"`
+					} `json:"secrets"`
+				} `json:"projects"`
+			} `json:"organization"`
+		} `json:"data"`
+	}
+	apitest.DecodeJSON(t, resp, &secrets)
+	if projects := secrets.Data.Organization.Projects; len(projects) != 1 || len(projects[0].Secrets) != 0 {
+		t.Errorf("Expected no prod secret for the restricted token, got %+v", projects)
+	}
+
+	// Un non-membre ne voit pas l'organisation
+	resp = srv.Do(http.MethodPost, "/api/v1/graphql", outsiderToken, query)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	body = graphQLResponse{}
+	apitest.DecodeJSON(t, resp, &body)
+
+	if body.Data.Organization != nil || len(body.Errors) != 1 {
+		t.Errorf("Expected no organization and one error for an outsider, got %+v", body)
+	}
+}
+
+func TestGraphQLRejectsInvalidQueries(t *testing.T) {
+	srv := apitest.NewServer(t)
+	srv.Register("user@example.com", "password123")
+	token := srv.Login("user@example.com", "password123")
+
+	resp := srv.Do(http.MethodPost, "/api/v1/graphql", token, map[string]any{
+		"query": `{ organizations { secrets { name } } }`,
+	})
+	apitest.ExpectStatus(t, resp, http.StatusBadRequest)
+}
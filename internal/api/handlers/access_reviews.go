@@ -0,0 +1,282 @@
+// filepath: internal/api/handlers/access_reviews.go
+
+package handlers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"secrets-manager/internal/api/apierror"
+	"secrets-manager/internal/api/middleware"
+	"secrets-manager/internal/auth"
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// Un membre sans appel à l'API depuis cette durée est signalé comme dormant
+const dormantAfter = 90 * 24 * time.Hour
+
+// AccessReviewsHandler gère les campagnes de revue des accès d'une organisation
+type AccessReviewsHandler struct {
+	reviews       storage.AccessReviewsRepository
+	organizations storage.OrganizationsRepository
+	users         storage.UsersRepository
+	usage         storage.UsageRepository
+	permissions   *auth.PermissionService
+}
+
+// NewAccessReviewsHandler crée un nouveau gestionnaire de revues des accès
+func NewAccessReviewsHandler(
+	reviews storage.AccessReviewsRepository,
+	organizations storage.OrganizationsRepository,
+	users storage.UsersRepository,
+	usage storage.UsageRepository,
+	permissions *auth.PermissionService,
+) *AccessReviewsHandler {
+	return &AccessReviewsHandler{
+		reviews:       reviews,
+		organizations: organizations,
+		users:         users,
+		usage:         usage,
+		permissions:   permissions,
+	}
+}
+
+// CreateAccessReview ouvre une campagne listant chaque membre, son rôle et
+// sa dernière activité. Réservé aux administrateurs de l'organisation.
+func (h *AccessReviewsHandler) CreateAccessReview(w http.ResponseWriter, r *http.Request) {
+	orgID := mux.Vars(r)["orgID"]
+	userID := middleware.UserIDFromContext(r.Context())
+	if !requireOrgAdmin(w, r, h.users, orgID, userID) {
+		return
+	}
+
+	members, err := h.organizations.ListOrganizationMembers(r.Context(), orgID)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de lister les membres")
+		return
+	}
+	lastActivity, err := h.usage.GetLastActivityDays(r.Context(), orgID, middleware.PrincipalUser)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de récupérer l'activité des membres")
+		return
+	}
+
+	dormantBefore := time.Now().UTC().Add(-dormantAfter).Format("2006-01-02")
+	review := &models.AccessReview{
+		OrganizationID: orgID,
+		CreatedBy:      userID,
+		Status:         models.AccessReviewOpen,
+		CreatedAt:      time.Now(),
+		Items:          make([]*models.AccessReviewItem, 0, len(members)),
+	}
+	for _, member := range members {
+		day := lastActivity[member.UserID]
+		review.Items = append(review.Items, &models.AccessReviewItem{
+			PrincipalType: middleware.PrincipalUser,
+			PrincipalID:   member.UserID,
+			Email:         member.Email,
+			Role:          member.Role,
+			LastActivity:  day,
+			Dormant:       day < dormantBefore,
+			Decision:      models.AccessDecisionPending,
+		})
+	}
+	sort.Slice(review.Items, func(i, j int) bool { return review.Items[i].Email < review.Items[j].Email })
+
+	if err := h.reviews.CreateAccessReview(r.Context(), review); err != nil {
+		apierror.Write(w, err, "Impossible de créer la revue des accès")
+		return
+	}
+	review.TotalItems = len(review.Items)
+	review.PendingItems = len(review.Items)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(review)
+}
+
+// ListAccessReviews liste les campagnes de l'organisation et leur avancement
+func (h *AccessReviewsHandler) ListAccessReviews(w http.ResponseWriter, r *http.Request) {
+	orgID := mux.Vars(r)["orgID"]
+	if !requireOrgAdmin(w, r, h.users, orgID, middleware.UserIDFromContext(r.Context())) {
+		return
+	}
+
+	reviews, err := h.reviews.ListAccessReviews(r.Context(), orgID)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de lister les revues des accès")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(reviews)
+}
+
+// GetAccessReview renvoie une campagne et la décision sur chaque accès
+func (h *AccessReviewsHandler) GetAccessReview(w http.ResponseWriter, r *http.Request) {
+	review, ok := h.loadReview(w, r)
+	if !ok {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(review)
+}
+
+// ConfirmAccess confirme l'accès d'un membre
+func (h *AccessReviewsHandler) ConfirmAccess(w http.ResponseWriter, r *http.Request) {
+	h.decide(w, r, models.AccessDecisionConfirmed)
+}
+
+// RevokeAccess révoque l'accès d'un membre en le retirant de l'organisation.
+// Le propriétaire de l'organisation ne peut pas être révoqué.
+func (h *AccessReviewsHandler) RevokeAccess(w http.ResponseWriter, r *http.Request) {
+	h.decide(w, r, models.AccessDecisionRevoked)
+}
+
+// ExportAccessReview exporte la campagne au format CSV, une ligne par accès.
+// Réservé aux membres ayant la permission exports.create.
+func (h *AccessReviewsHandler) ExportAccessReview(w http.ResponseWriter, r *http.Request) {
+	orgID := mux.Vars(r)["orgID"]
+	userID := middleware.UserIDFromContext(r.Context())
+	if !requirePermission(w, r, h.permissions, orgID, userID, models.PermissionExportsCreate) {
+		return
+	}
+	review, ok := h.getReview(w, r)
+	if !ok {
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", `attachment; filename="access-review-`+review.ID+`.csv"`)
+
+	out := csv.NewWriter(w)
+	out.Write([]string{
+		"principal_type", "principal_id", "email", "role", "last_activity",
+		"dormant", "decision", "decided_by", "decided_at",
+	})
+	rc := http.NewResponseController(w)
+	for i, item := range review.Items {
+		decidedAt := ""
+		if item.DecidedAt != nil {
+			decidedAt = item.DecidedAt.UTC().Format(time.RFC3339)
+		}
+		out.Write([]string{
+			item.PrincipalType, item.PrincipalID, item.Email, item.Role, item.LastActivity,
+			strconv.FormatBool(item.Dormant), item.Decision, item.DecidedBy, decidedAt,
+		})
+		// Envoi par blocs : l'export n'est pas construit entièrement en mémoire
+		if (i+1)%streamFlushEvery == 0 {
+			out.Flush()
+			rc.Flush()
+		}
+	}
+	out.Flush()
+}
+
+// decide enregistre une décision sur l'accès {principalID} et termine la
+// campagne lorsqu'il ne reste plus d'accès en attente
+func (h *AccessReviewsHandler) decide(w http.ResponseWriter, r *http.Request, decision string) {
+	review, ok := h.loadReview(w, r)
+	if !ok {
+		return
+	}
+
+	item := review.Item(middleware.PrincipalUser, mux.Vars(r)["principalID"])
+	if item == nil {
+		http.Error(w, "Accès non trouvé dans la revue", http.StatusNotFound)
+		return
+	}
+
+	if decision == models.AccessDecisionRevoked {
+		org, err := h.organizations.GetOrganizationByID(r.Context(), review.OrganizationID)
+		if err != nil {
+			apierror.Write(w, err, "Impossible de récupérer l'organisation")
+			return
+		}
+		if org.OwnerID == item.PrincipalID {
+			http.Error(w, "Le propriétaire de l'organisation ne peut pas être révoqué", http.StatusConflict)
+			return
+		}
+	}
+
+	// La décision est enregistrée avant le retrait : deux administrateurs ne
+	// peuvent pas décider du même accès
+	now := time.Now()
+	item.Decision = decision
+	item.DecidedBy = middleware.UserIDFromContext(r.Context())
+	item.DecidedAt = &now
+	if err := h.reviews.DecideAccessReviewItem(r.Context(), item); err != nil {
+		apierror.Write(w, err, "Impossible d'enregistrer la décision")
+		return
+	}
+
+	if decision == models.AccessDecisionRevoked {
+		err := h.users.RemoveUserFromOrganization(r.Context(), item.PrincipalID, review.OrganizationID)
+		// Un membre déjà parti n'a plus d'accès à retirer
+		if err != nil && !errors.Is(err, storage.ErrNotFound) {
+			apierror.Write(w, err, "Impossible de retirer le membre de l'organisation")
+			return
+		}
+	}
+
+	if err := h.reviews.CompleteAccessReview(r.Context(), review.ID); err != nil {
+		apierror.Write(w, err, "Impossible de terminer la revue des accès")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(item)
+}
+
+// loadReview charge la campagne {reviewID} de l'organisation {orgID} après
+// avoir vérifié que l'appelant en est administrateur
+func (h *AccessReviewsHandler) loadReview(w http.ResponseWriter, r *http.Request) (*models.AccessReview, bool) {
+	if !requireOrgAdmin(w, r, h.users, mux.Vars(r)["orgID"], middleware.UserIDFromContext(r.Context())) {
+		return nil, false
+	}
+	return h.getReview(w, r)
+}
+
+// getReview charge la campagne {reviewID} de l'organisation {orgID}
+func (h *AccessReviewsHandler) getReview(w http.ResponseWriter, r *http.Request) (*models.AccessReview, bool) {
+	vars := mux.Vars(r)
+	review, err := h.reviews.GetAccessReview(r.Context(), vars["orgID"], vars["reviewID"])
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			http.Error(w, "Revue des accès non trouvée", http.StatusNotFound)
+			return nil, false
+		}
+		apierror.Write(w, err, "Impossible de récupérer la revue des accès")
+		return nil, false
+	}
+	return review, true
+}
+
+// requireOrgAdmin vérifie que l'utilisateur administre l'organisation. Les
+// non-membres reçoivent 404, les autres membres 403 et une erreur de
+// stockage 500.
+func requireOrgAdmin(w http.ResponseWriter, r *http.Request, users storage.UsersRepository, orgID, userID string) bool {
+	role, err := users.GetUserRole(r.Context(), userID, orgID)
+	if errors.Is(err, storage.ErrNotFound) || (err == nil && role == "") {
+		http.Error(w, "Organisation non trouvée", http.StatusNotFound)
+		return false
+	}
+	if err != nil {
+		apierror.Write(w, err, "Impossible de vérifier le rôle dans l'organisation")
+		return false
+	}
+	if role != "admin" {
+		http.Error(w, "Accès refusé", http.StatusForbidden)
+		return false
+	}
+	return true
+}
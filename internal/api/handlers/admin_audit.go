@@ -0,0 +1,169 @@
+// filepath: internal/api/handlers/admin_audit.go
+
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"secrets-manager/internal/api/apierror"
+	"secrets-manager/internal/api/middleware"
+	"secrets-manager/internal/evidence"
+	"secrets-manager/internal/logging"
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// Période exportée par défaut du journal d'audit d'administration
+const defaultAdminAuditExportPeriod = 30 * 24 * time.Hour
+
+// Nom du journal dans l'archive signée
+const adminAuditFileName = "admin_audit.json"
+
+// AdminAuditHandler expose le journal d'audit des routes d'administration
+type AdminAuditHandler struct {
+	audit storage.AdminAuditRepository
+	// signer signe les exports du journal ; nil les désactive
+	signer *evidence.Signer
+}
+
+// NewAdminAuditHandler crée un nouveau gestionnaire d'audit d'administration
+func NewAdminAuditHandler(audit storage.AdminAuditRepository, signer *evidence.Signer) *AdminAuditHandler {
+	return &AdminAuditHandler{
+		audit:  audit,
+		signer: signer,
+	}
+}
+
+// ListAdminAuditLogs liste les entrées du journal.
+// Paramètres optionnels : since (RFC 3339, 7 derniers jours par défaut), limit, offset.
+func (h *AdminAuditHandler) ListAdminAuditLogs(w http.ResponseWriter, r *http.Request) {
+	since := time.Now().Add(-7 * 24 * time.Hour)
+	if v := r.URL.Query().Get("since"); v != "" {
+		parsed, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			http.Error(w, "Paramètre since invalide", http.StatusBadRequest)
+			return
+		}
+		since = parsed
+	}
+
+	limit, offset, ok := parsePagination(w, r)
+	if !ok {
+		return
+	}
+
+	entries, err := h.audit.ListAdminAuditLogs(r.Context(), since, limit, offset)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de lister le journal d'audit")
+		return
+	}
+
+	writeJSONList(w, r, entries)
+}
+
+// ExportAdminAuditLogs renvoie le journal de la période [since, until[ dans
+// une archive ZIP signée avec la clé des preuves de conformité, vérifiable
+// par un tiers avec la clé publique du serveur (smadmin verify-export).
+// Paramètres optionnels : since et until (RFC 3339, 30 derniers jours par défaut).
+func (h *AdminAuditHandler) ExportAdminAuditLogs(w http.ResponseWriter, r *http.Request) {
+	if h.signer == nil {
+		http.Error(w, "Signature des exports non configurée", http.StatusServiceUnavailable)
+		return
+	}
+
+	until := time.Now().UTC()
+	since := until.Add(-defaultAdminAuditExportPeriod)
+	var err error
+	if v := r.URL.Query().Get("since"); v != "" {
+		if since, err = time.Parse(time.RFC3339, v); err != nil {
+			http.Error(w, "Paramètre since invalide", http.StatusBadRequest)
+			return
+		}
+	}
+	if v := r.URL.Query().Get("until"); v != "" {
+		if until, err = time.Parse(time.RFC3339, v); err != nil {
+			http.Error(w, "Paramètre until invalide", http.StatusBadRequest)
+			return
+		}
+	}
+	if !since.Before(until) {
+		http.Error(w, "La période demandée est invalide", http.StatusBadRequest)
+		return
+	}
+
+	entries := []*models.AdminAuditLog{}
+	for offset := 0; ; offset += maxPageSize {
+		page, err := h.audit.ListAdminAuditLogs(r.Context(), since, maxPageSize, offset)
+		if err != nil {
+			apierror.Write(w, err, "Impossible de lister le journal d'audit")
+			return
+		}
+		for _, entry := range page {
+			if entry.Timestamp.Before(until) {
+				entries = append(entries, entry)
+			}
+		}
+		if len(page) < maxPageSize {
+			break
+		}
+	}
+	// Ordre chronologique dans l'archive
+	slices.Reverse(entries)
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		http.Error(w, "Erreur lors de l'encodage du journal d'audit", http.StatusInternalServerError)
+		return
+	}
+
+	generatedBy := ""
+	if principal, ok := middleware.PrincipalFromContext(r.Context()); ok {
+		generatedBy = principal.String()
+	}
+	manifest := evidence.Manifest{
+		From:        since.UTC(),
+		To:          until.UTC(),
+		GeneratedAt: time.Now().UTC(),
+		GeneratedBy: generatedBy,
+	}
+	filename := "admin-audit-" + since.UTC().Format("20060102T150405Z") + "-" + until.UTC().Format("20060102T150405Z") + ".zip"
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`"`)
+	if err := h.signer.WriteBundle(w, manifest, []evidence.File{{Name: adminAuditFileName, Content: data}}); err != nil {
+		logging.For(logging.ComponentHTTP).Error("export du journal d'audit interrompu", "error", err)
+	}
+}
+
+// Pagination par défaut et maximale des listes
+const (
+	defaultPageSize = 50
+	maxPageSize     = 500
+)
+
+// parsePagination lit les paramètres limit et offset de la requête.
+// En cas d'erreur, la réponse 400 est déjà écrite et ok vaut false.
+func parsePagination(w http.ResponseWriter, r *http.Request) (limit, offset int, ok bool) {
+	limit = defaultPageSize
+	if v := r.URL.Query().Get("limit"); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil || parsed <= 0 {
+			http.Error(w, "Paramètre limit invalide", http.StatusBadRequest)
+			return 0, 0, false
+		}
+		limit = min(parsed, maxPageSize)
+	}
+
+	if v := r.URL.Query().Get("offset"); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil || parsed < 0 {
+			http.Error(w, "Paramètre offset invalide", http.StatusBadRequest)
+			return 0, 0, false
+		}
+		offset = parsed
+	}
+
+	return limit, offset, true
+}
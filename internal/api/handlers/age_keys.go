@@ -0,0 +1,260 @@
+// filepath: internal/api/handlers/age_keys.go
+
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	"secrets-manager/internal/agekey"
+	"secrets-manager/internal/api/apierror"
+	"secrets-manager/internal/api/middleware"
+	"secrets-manager/internal/logging"
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+	"secrets-manager/internal/vault"
+)
+
+// Les noms des dépôts apparaissent dans les URL et les chemins Vault (pas
+// de point en tête, qui désignerait un dossier caché ou parent)
+var ageKeyRepository = regexp.MustCompile(`^[A-Za-z0-9_-][A-Za-z0-9_.-]{0,127}$`)
+
+// AgeKeysHandler gère les clés age des dépôts de code d'un projet, pour
+// sops. Chaque dépôt a sa clé active (son destinataire va dans .sops.yaml) ;
+// une rotation la remplace et conserve les précédentes, toujours servies
+// pour déchiffrer jusqu'à leur suppression. La CI récupère les identités du
+// dépôt pour sops exec-env :
+//
+//	SOPS_AGE_KEY="$(curl -fsS -H "Authorization: Bearer $TOKEN" \
+//	  .../age-keys/infra/identities)" sops exec-env secrets.enc.yaml ./deploy.sh
+type AgeKeysHandler struct {
+	keys         storage.AgeKeysRepository
+	vaultService *vault.Service
+	policy       *secretPolicy
+}
+
+// NewAgeKeysHandler crée un nouveau gestionnaire des clés age
+func NewAgeKeysHandler(
+	keys storage.AgeKeysRepository,
+	vaultService *vault.Service,
+	users storage.UsersRepository,
+	projects storage.ProjectsRepository,
+	teams storage.TeamsRepository,
+) *AgeKeysHandler {
+	return &AgeKeysHandler{
+		keys:         keys,
+		vaultService: vaultService,
+		policy:       &secretPolicy{users: users, projects: projects, teams: teams},
+	}
+}
+
+// ListAgeKeys liste les clés age du projet, ou d'un seul dépôt avec le
+// paramètre repository (destinataires uniquement)
+func (h *AgeKeysHandler) ListAgeKeys(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	orgID, projectID := vars["orgID"], vars["projectID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if err := h.policy.check(r.Context(), userID, orgID, projectID, secretRead); err != nil {
+		writePolicyError(w, err)
+		return
+	}
+
+	keys, err := h.keys.ListAgeKeys(r.Context(), orgID, projectID, r.URL.Query().Get("repository"))
+	if err != nil {
+		apierror.Write(w, err, "Impossible de lister les clés age")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(keys)
+}
+
+// CreateAgeKey génère la première clé age d'un dépôt (administrateurs de
+// l'organisation). Un dépôt qui a déjà une clé passe par RotateAgeKey.
+func (h *AgeKeysHandler) CreateAgeKey(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	orgID, projectID := vars["orgID"], vars["projectID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if err := h.policy.check(r.Context(), userID, orgID, projectID, secretAdmin); err != nil {
+		writePolicyError(w, err)
+		return
+	}
+
+	var request struct {
+		Repository string `json:"repository"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "Données invalides", http.StatusBadRequest)
+		return
+	}
+	if !ageKeyRepository.MatchString(request.Repository) {
+		apierror.Write(w, apierror.Validation("Nom de dépôt invalide (lettres, chiffres, . _ -, sans point en tête, 128 caractères au plus)"), "")
+		return
+	}
+	existing, err := h.keys.ListAgeKeys(r.Context(), orgID, projectID, request.Repository)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de lister les clés age")
+		return
+	}
+	if len(existing) > 0 {
+		apierror.Write(w, storage.ErrAgeKeysExist, "")
+		return
+	}
+
+	h.generate(w, r, orgID, projectID, request.Repository)
+}
+
+// RotateAgeKey remplace la clé active d'un dépôt par une nouvelle clé ; la
+// précédente reste servie pour déchiffrer jusqu'à sa suppression
+func (h *AgeKeysHandler) RotateAgeKey(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	orgID, projectID, repository := vars["orgID"], vars["projectID"], vars["repository"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if err := h.policy.check(r.Context(), userID, orgID, projectID, secretAdmin); err != nil {
+		writePolicyError(w, err)
+		return
+	}
+	existing, err := h.keys.ListAgeKeys(r.Context(), orgID, projectID, repository)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de lister les clés age")
+		return
+	}
+	if len(existing) == 0 {
+		apierror.Write(w, storage.ErrAgeKeyNotFound, "")
+		return
+	}
+
+	h.generate(w, r, orgID, projectID, repository)
+}
+
+// GetIdentities renvoie les identités du dépôt au format d'un fichier de
+// clés age (text/plain), clé active en tête, directement utilisable comme
+// SOPS_AGE_KEY
+func (h *AgeKeysHandler) GetIdentities(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	orgID, projectID, repository := vars["orgID"], vars["projectID"], vars["repository"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if err := h.policy.check(r.Context(), userID, orgID, projectID, secretRead); err != nil {
+		writePolicyError(w, err)
+		return
+	}
+	keys, err := h.keys.ListAgeKeys(r.Context(), orgID, projectID, repository)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de lister les clés age")
+		return
+	}
+	if len(keys) == 0 {
+		apierror.Write(w, storage.ErrAgeKeyNotFound, "")
+		return
+	}
+
+	var b strings.Builder
+	for _, key := range keys {
+		secret, err := h.vaultService.GetAgeIdentity(r.Context(), key)
+		if err != nil {
+			apierror.Write(w, err, "Impossible de récupérer les identités age")
+			return
+		}
+		identity, err := agekey.ParseIdentity(secret)
+		if err != nil {
+			logging.For(logging.ComponentHTTP).Error("identité age illisible", "age_key_id", key.ID, "error", err)
+			http.Error(w, "Impossible de récupérer les identités age", http.StatusInternalServerError)
+			return
+		}
+		b.WriteString(identity.Format(key.CreatedAt))
+	}
+
+	logging.For(logging.ComponentHTTP).Info("identités age servies",
+		"org_id", orgID, "project_id", projectID, "repository", repository, "user_id", userID)
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Write([]byte(b.String()))
+}
+
+// DeleteAgeKey supprime une clé retirée et son identité, une fois les
+// fichiers du dépôt rechiffrés avec la clé active
+func (h *AgeKeysHandler) DeleteAgeKey(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	orgID, projectID, repository := vars["orgID"], vars["projectID"], vars["repository"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if err := h.policy.check(r.Context(), userID, orgID, projectID, secretAdmin); err != nil {
+		writePolicyError(w, err)
+		return
+	}
+	keys, err := h.keys.ListAgeKeys(r.Context(), orgID, projectID, repository)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de lister les clés age")
+		return
+	}
+	var key *models.AgeKey
+	for _, candidate := range keys {
+		if candidate.ID == vars["keyID"] {
+			key = candidate
+		}
+	}
+	if key == nil {
+		apierror.Write(w, storage.ErrAgeKeyNotFound, "")
+		return
+	}
+	if key.Status == models.AgeKeyActive {
+		apierror.Write(w, apierror.Validation("La clé active ne peut pas être supprimée, effectuez d'abord une rotation"), "")
+		return
+	}
+
+	if err := h.keys.DeleteAgeKey(r.Context(), orgID, projectID, key.ID); err != nil {
+		apierror.Write(w, err, "Impossible de supprimer la clé age")
+		return
+	}
+	if err := h.vaultService.DeleteAgeIdentity(r.Context(), key); err != nil {
+		// La clé n'est plus servie ; l'identité orpheline disparaîtra avec le projet
+		logging.For(logging.ComponentHTTP).Warn("identité age non supprimée de Vault",
+			"age_key_id", key.ID, "error", err)
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// generate crée la nouvelle clé active du dépôt. L'identité est stockée dans
+// Vault avant l'enregistrement : une clé enregistrée est toujours servie.
+func (h *AgeKeysHandler) generate(w http.ResponseWriter, r *http.Request, orgID, projectID, repository string) {
+	identity, err := agekey.Generate()
+	if err != nil {
+		http.Error(w, "Impossible de générer la clé age", http.StatusInternalServerError)
+		return
+	}
+
+	key := &models.AgeKey{
+		ID:             uuid.New().String(),
+		OrganizationID: orgID,
+		ProjectID:      projectID,
+		Repository:     repository,
+		Recipient:      identity.Recipient,
+		CreatedBy:      middleware.UserIDFromContext(r.Context()),
+	}
+	if err := h.vaultService.StoreAgeIdentity(r.Context(), key, identity.Secret); err != nil {
+		apierror.Write(w, err, "Impossible de stocker l'identité age")
+		return
+	}
+	if err := h.keys.CreateAgeKey(r.Context(), key); err != nil {
+		if deleteErr := h.vaultService.DeleteAgeIdentity(r.Context(), key); deleteErr != nil {
+			logging.For(logging.ComponentHTTP).Warn("identité age orpheline dans Vault",
+				"age_key_id", key.ID, "error", deleteErr)
+		}
+		apierror.Write(w, err, "Impossible d'enregistrer la clé age")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(key)
+}
@@ -0,0 +1,190 @@
+// filepath: internal/api/handlers/api_keys.go
+
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"secrets-manager/internal/api/apierror"
+	"secrets-manager/internal/api/middleware"
+	"secrets-manager/internal/auth"
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+	"secrets-manager/internal/vault"
+)
+
+// APIKeysHandler gère les clés d'API des organisations (accès machine à
+// machine), réservées aux administrateurs
+type APIKeysHandler struct {
+	apiKeys  storage.APIKeysRepository
+	projects storage.ProjectsRepository
+	users    storage.UsersRepository
+	transit  *vault.TransitRouter
+}
+
+// NewAPIKeysHandler crée un nouveau gestionnaire de clés d'API. transit
+// enveloppe les clés de signature des clés à requêtes signées.
+func NewAPIKeysHandler(
+	apiKeys storage.APIKeysRepository,
+	projects storage.ProjectsRepository,
+	users storage.UsersRepository,
+	transit *vault.TransitRouter,
+) *APIKeysHandler {
+	return &APIKeysHandler{
+		apiKeys:  apiKeys,
+		projects: projects,
+		users:    users,
+		transit:  transit,
+	}
+}
+
+// APIKeyCreation représente une demande de clé d'API
+type APIKeyCreation struct {
+	Name string `json:"name"`
+	// ProjectID et Environment restreignent la clé ; vides, elle couvre tous
+	// les projets ou tous les environnements de l'organisation
+	ProjectID   string `json:"project_id"`
+	Environment string `json:"environment"`
+	// Permission vaut "read" ou "read_write"
+	Permission string `json:"permission"`
+	// ExpiresInDays est la durée de validité en jours (sans expiration par
+	// défaut, 365 au plus)
+	ExpiresInDays int `json:"expires_in_days"`
+	// SignedRequests exige des requêtes signées (voir auth.SignRequest) : la
+	// clé ne peut plus être présentée dans l'en-tête X-API-Key
+	SignedRequests bool `json:"signed_requests"`
+}
+
+// CreatedAPIKey est renvoyée à la création : Key et SigningKey, la clé de
+// signature des clés à requêtes signées, ne sont plus jamais affichées.
+type CreatedAPIKey struct {
+	*models.APIKey
+	Key        string `json:"key"`
+	SigningKey string `json:"signing_key,omitempty"`
+}
+
+// ListAPIKeys liste les clés de l'organisation, sans leur valeur
+func (h *APIKeysHandler) ListAPIKeys(w http.ResponseWriter, r *http.Request) {
+	orgID := mux.Vars(r)["orgID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if !requireOrgAdmin(w, r, h.users, orgID, userID) {
+		return
+	}
+
+	keys, err := h.apiKeys.ListAPIKeys(r.Context(), orgID)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de lister les clés d'API")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(keys)
+}
+
+// CreateAPIKey crée une clé d'API agissant au nom de l'administrateur connecté
+func (h *APIKeysHandler) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	orgID := mux.Vars(r)["orgID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if !requireOrgAdmin(w, r, h.users, orgID, userID) {
+		return
+	}
+
+	var creation APIKeyCreation
+	if err := json.NewDecoder(r.Body).Decode(&creation); err != nil {
+		http.Error(w, "Données invalides", http.StatusBadRequest)
+		return
+	}
+	if creation.Name == "" || len(creation.Name) > 128 {
+		apierror.Write(w, apierror.Validation("Nom de la clé requis (128 caractères au plus)"), "")
+		return
+	}
+	if creation.Permission != models.APIKeyRead && creation.Permission != models.APIKeyReadWrite {
+		apierror.Write(w, apierror.Validation("Permission invalide (read ou read_write)"), "")
+		return
+	}
+	if len(creation.Environment) > 64 {
+		apierror.Write(w, apierror.Validation("Nom d'environnement trop long (64 caractères au plus)"), "")
+		return
+	}
+	if creation.ExpiresInDays < 0 || creation.ExpiresInDays > maxTokenLifetimeDays {
+		apierror.Write(w, apierror.Validation("Durée de validité invalide (1 à 365 jours)"), "")
+		return
+	}
+	if creation.ProjectID != "" {
+		if _, err := h.projects.GetProject(r.Context(), orgID, creation.ProjectID); err != nil {
+			apierror.Write(w, err, "Impossible de vérifier le projet")
+			return
+		}
+	}
+
+	value, hash, prefix, err := auth.NewAPIKey()
+	if err != nil {
+		apierror.Write(w, err, "Impossible de générer la clé")
+		return
+	}
+	key := &models.APIKey{
+		OrganizationID: orgID,
+		ProjectID:      creation.ProjectID,
+		Environment:    creation.Environment,
+		Name:           creation.Name,
+		KeyHash:        hash,
+		Prefix:         prefix,
+		Permission:     creation.Permission,
+		SignedRequests: creation.SignedRequests,
+		CreatedBy:      userID,
+	}
+	var signingKey string
+	if creation.SignedRequests {
+		if h.transit == nil {
+			apierror.Write(w, apierror.Validation("Les requêtes signées ne sont pas disponibles"), "")
+			return
+		}
+		transit, err := h.transit.For(r.Context(), orgID)
+		if err != nil {
+			apierror.Write(w, err, "Impossible de joindre le moteur transit")
+			return
+		}
+		signingKey, key.WrappedSigningKey, err = auth.NewAPIKeySigningKey(r.Context(), transit)
+		if err != nil {
+			apierror.Write(w, err, "Impossible de générer la clé de signature")
+			return
+		}
+	}
+	if creation.ExpiresInDays > 0 {
+		expiresAt := time.Now().UTC().Add(time.Duration(creation.ExpiresInDays) * 24 * time.Hour).Truncate(time.Second)
+		key.ExpiresAt = &expiresAt
+	}
+	if err := h.apiKeys.CreateAPIKey(r.Context(), key); err != nil {
+		apierror.Write(w, err, "Impossible de créer la clé")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(CreatedAPIKey{APIKey: key, Key: value, SigningKey: signingKey})
+}
+
+// RevokeAPIKey révoque une clé de l'organisation
+func (h *APIKeysHandler) RevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	orgID := vars["orgID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if !requireOrgAdmin(w, r, h.users, orgID, userID) {
+		return
+	}
+
+	if err := h.apiKeys.DeleteAPIKey(r.Context(), orgID, vars["keyID"]); err != nil {
+		apierror.Write(w, err, "Impossible de révoquer la clé")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"secrets-manager/internal/api/apierror"
 	"secrets-manager/internal/auth"
 )
 
@@ -29,6 +30,15 @@ type UserRegistration struct {
 	LastName  string `json:"last_name"`
 }
 
+// PasswordChange représente les données pour le changement de mot de passe
+type PasswordChange struct {
+	Email       string `json:"email"`
+	Password    string `json:"password"`
+	NewPassword string `json:"new_password"`
+	// Code est un code TOTP, requis si l'authentification multifacteur est activée
+	Code string `json:"code"`
+}
+
 // Login gère la connexion d'un utilisateur
 func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 	var creds auth.Credentials
@@ -37,22 +47,28 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Authentifier l'utilisateur
+	// Authentifier l'utilisateur (le cookie d'un appareil de confiance
+	// dispense du code TOTP)
+	creds.Device = requestDevice(r, "")
 	ctx := r.Context()
-	token, refreshToken, err := h.authService.Authenticate(ctx, &creds)
+	token, _, err := h.authService.Authenticate(ctx, &creds)
 	if err != nil {
-		if err == auth.ErrInvalidCredentials {
-			http.Error(w, "Identifiants invalides", http.StatusUnauthorized)
-		} else {
-			http.Error(w, "Erreur d'authentification", http.StatusInternalServerError)
-		}
+		apierror.Write(w, err, "Erreur d'authentification")
 		return
 	}
-	// Répondre avec le token et le refresh token
 	w.Header().Set("Content-Type", "application/json")
+	// Un code TOTP est attendu sur /auth/mfa/verify avec le mfa_token
+	if token.MFARequired {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"mfa_required": true,
+			"mfa_token":    token.MFAToken,
+		})
+		return
+	}
+	// Répondre avec le token et le refresh token
 	json.NewEncoder(w).Encode(map[string]string{
 		"token":         token.Token,
-		"refresh_token": refreshToken.Token, // Assuming `Token` is the correct field for the refresh token
+		"refresh_token": token.RefreshToken,
 	})
 }
 
@@ -66,7 +82,14 @@ func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 
 	// Valider les données
 	if reg.Email == "" || reg.Password == "" {
-		http.Error(w, "Email et mot de passe requis", http.StatusBadRequest)
+		apierror.Write(w, apierror.Validation("Email et mot de passe requis"), "")
+		return
+	}
+
+	// Mode d'inscription et listes d'autorisation et de refus du serveur
+	ctx := r.Context()
+	if err := h.authService.CheckRegistration(ctx, reg.Email); err != nil {
+		apierror.Write(w, err, "Erreur d'inscription")
 		return
 	}
 
@@ -75,14 +98,9 @@ func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 		Email:    reg.Email,
 		Password: reg.Password,
 	}
-	ctx := r.Context()
 	_, err := h.authService.RegisterUser(ctx, &creds, reg.FirstName, reg.LastName)
 	if err != nil {
-		if err == auth.ErrUserExists {
-			http.Error(w, "L'utilisateur existe déjà", http.StatusConflict)
-		} else {
-			http.Error(w, "Erreur d'inscription", http.StatusInternalServerError)
-		}
+		apierror.Write(w, err, "Erreur d'inscription")
 		return
 	}
 
@@ -95,3 +113,34 @@ func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 		"message": "Utilisateur créé avec succès",
 	})
 }
+
+// ChangePassword remplace le mot de passe d'un utilisateur. La route n'exige
+// pas de session : un utilisateur qui doit changer de mot de passe ne peut
+// plus se connecter.
+func (h *AuthHandler) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	var req PasswordChange
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Données invalides", http.StatusBadRequest)
+		return
+	}
+	if req.Email == "" || req.Password == "" || req.NewPassword == "" {
+		apierror.Write(w, apierror.Validation("Email, mot de passe et nouveau mot de passe requis"), "")
+		return
+	}
+
+	creds := auth.Credentials{Email: req.Email, Password: req.Password}
+	if err := h.authService.ChangePassword(r.Context(), &creds, req.NewPassword, req.Code); err != nil {
+		apierror.Write(w, err, "Impossible de changer le mot de passe")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// JWKS publie les clés publiques de vérification des tokens (RFC 7517).
+// Les services internes la mettent en cache et la relisent lorsqu'un token
+// porte un kid inconnu, après une rotation.
+func (h *AuthHandler) JWKS(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	json.NewEncoder(w).Encode(h.authService.JWKS())
+}
@@ -0,0 +1,121 @@
+// filepath: internal/api/handlers/bulk_rotations.go
+
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"secrets-manager/internal/api/apierror"
+	"secrets-manager/internal/api/middleware"
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/rotation"
+	"secrets-manager/internal/storage"
+)
+
+// BulkRotationQueue planifie la rotation groupée des secrets d'une organisation
+type BulkRotationQueue interface {
+	Enqueue(ctx context.Context, orgID, pattern, provider, userID string) (*models.BulkRotation, error)
+}
+
+// BulkRotationRequest filtre les secrets d'une rotation groupée
+type BulkRotationRequest struct {
+	// Path est un motif sur « projet/environnement/secret » (syntaxe de
+	// path.Match, par exemple « api/prod/* »), vide pour tous les secrets
+	Path string `json:"path"`
+	// Provider restreint la rotation aux rotateurs de ce fournisseur
+	Provider string `json:"provider"`
+}
+
+// BulkRotationsHandler fait tourner tous les secrets d'une organisation qui
+// correspondent à un filtre, par exemple après une compromission présumée.
+// Réservé aux administrateurs de l'organisation.
+type BulkRotationsHandler struct {
+	rotations storage.BulkRotationsRepository
+	queue     BulkRotationQueue
+	users     storage.UsersRepository
+	providers *rotation.Registry
+}
+
+// NewBulkRotationsHandler crée un nouveau gestionnaire des rotations groupées
+func NewBulkRotationsHandler(
+	rotations storage.BulkRotationsRepository,
+	queue BulkRotationQueue,
+	users storage.UsersRepository,
+	providers *rotation.Registry,
+) *BulkRotationsHandler {
+	return &BulkRotationsHandler{
+		rotations: rotations,
+		queue:     queue,
+		users:     users,
+		providers: providers,
+	}
+}
+
+// BulkRotate planifie la rotation des secrets qui correspondent au filtre et
+// renvoie 202 avec la rotation groupée, dont l'avancement se consulte sur
+// /bulk-rotations/{rotationID}. Les secrets sans rotateur sont signalés
+// comme ignorés.
+func (h *BulkRotationsHandler) BulkRotate(w http.ResponseWriter, r *http.Request) {
+	orgID := mux.Vars(r)["orgID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if !requireOrgAdmin(w, r, h.users, orgID, userID) {
+		return
+	}
+
+	var req BulkRotationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Données invalides", http.StatusBadRequest)
+		return
+	}
+	req.Path = strings.TrimSpace(req.Path)
+	if _, err := path.Match(req.Path, ""); err != nil {
+		apierror.Write(w, apierror.Validation("Motif de chemin invalide : "+req.Path), "")
+		return
+	}
+	if _, ok := h.providers.Get(req.Provider); req.Provider != "" && !ok {
+		apierror.Write(w, apierror.Validation("Fournisseur inconnu (fournisseurs disponibles : "+
+			strings.Join(h.providers.Providers(), ", ")+")"), "")
+		return
+	}
+
+	bulk, err := h.queue.Enqueue(r.Context(), orgID, req.Path, req.Provider, userID)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de planifier la rotation groupée")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Location", "/api/v1/organizations/"+orgID+"/bulk-rotations/"+bulk.ID)
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(bulk)
+}
+
+// GetBulkRotation renvoie l'avancement d'une rotation groupée, secret par secret
+func (h *BulkRotationsHandler) GetBulkRotation(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	orgID := vars["orgID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if !requireOrgAdmin(w, r, h.users, orgID, userID) {
+		return
+	}
+
+	bulk, err := h.rotations.GetBulkRotation(r.Context(), orgID, vars["rotationID"])
+	if err != nil {
+		// storage.ErrBulkRotationNotFound donne 404
+		apierror.Write(w, err, "Impossible de récupérer la rotation groupée")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(bulk); err != nil {
+		http.Error(w, "Erreur lors de l'encodage de la rotation groupée", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,181 @@
+// filepath: internal/api/handlers/calendar.go
+
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"secrets-manager/internal/api/apierror"
+	"secrets-manager/internal/api/middleware"
+	"secrets-manager/internal/calendar"
+	"secrets-manager/internal/logging"
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// Période couverte par défaut et au plus par le calendrier, en jours
+const (
+	defaultCalendarDays = 30
+	maxCalendarDays     = 365
+)
+
+// CalendarHandler expose les échéances à venir d'une organisation : retraits
+// des valeurs précédentes des rotations doubles, changements planifiés et
+// renouvellement de l'abonnement. Le flux ICS s'utilise avec un token
+// d'accès personnel de portée metadata:read.
+type CalendarHandler struct {
+	rotators      storage.SecretRotatorsRepository
+	scheduled     storage.ScheduledSecretChangesRepository
+	subscriptions storage.SubscriptionsRepository
+	users         storage.UsersRepository
+	policy        *secretPolicy
+}
+
+// NewCalendarHandler crée un nouveau gestionnaire du calendrier
+func NewCalendarHandler(
+	rotators storage.SecretRotatorsRepository,
+	scheduled storage.ScheduledSecretChangesRepository,
+	subscriptions storage.SubscriptionsRepository,
+	users storage.UsersRepository,
+	projects storage.ProjectsRepository,
+) *CalendarHandler {
+	return &CalendarHandler{
+		rotators:      rotators,
+		scheduled:     scheduled,
+		subscriptions: subscriptions,
+		users:         users,
+		policy:        &secretPolicy{users: users, projects: projects},
+	}
+}
+
+// GetCalendar renvoie les échéances des days prochains jours (30 par défaut)
+func (h *CalendarHandler) GetCalendar(w http.ResponseWriter, r *http.Request) {
+	events, ok := h.upcoming(w, r)
+	if !ok {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(events)
+}
+
+// GetCalendarICS renvoie les mêmes échéances au format iCalendar
+func (h *CalendarHandler) GetCalendarICS(w http.ResponseWriter, r *http.Request) {
+	events, ok := h.upcoming(w, r)
+	if !ok {
+		return
+	}
+
+	orgID := mux.Vars(r)["orgID"]
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="secrets-manager-`+orgID+`.ics"`)
+	if err := calendar.WriteICS(w, "Échéances secrets-manager", events, time.Now()); err != nil {
+		logging.For(logging.ComponentHTTP).Error("écriture du calendrier interrompue",
+			"organization_id", orgID, "error", err)
+	}
+}
+
+// upcoming rassemble les échéances visibles par l'appelant, de la plus
+// proche à la plus lointaine. Les secrets des projets qu'il ne peut pas
+// lire sont omis et seuls les administrateurs voient l'abonnement.
+func (h *CalendarHandler) upcoming(w http.ResponseWriter, r *http.Request) ([]*models.CalendarEvent, bool) {
+	ctx := r.Context()
+	orgID := mux.Vars(r)["orgID"]
+	userID := middleware.UserIDFromContext(ctx)
+
+	role, err := h.users.GetUserRole(ctx, userID, orgID)
+	if err != nil || role == "" {
+		http.Error(w, "Organisation non trouvée", http.StatusNotFound)
+		return nil, false
+	}
+
+	days := defaultCalendarDays
+	if v := r.URL.Query().Get("days"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > maxCalendarDays {
+			apierror.Write(w, apierror.Validation("Paramètre days invalide (1 à 365)"), "")
+			return nil, false
+		}
+		days = n
+	}
+	until := time.Now().AddDate(0, 0, days)
+
+	// Décision de la politique par projet
+	readable := map[string]bool{}
+	canRead := func(projectID string) bool {
+		allowed, ok := readable[projectID]
+		if !ok {
+			allowed = h.policy.check(ctx, userID, orgID, projectID, secretRead) == nil
+			readable[projectID] = allowed
+		}
+		return allowed
+	}
+
+	events := []*models.CalendarEvent{}
+	rotators, err := h.rotators.ListOrganizationPreviousExpiries(ctx, orgID, until)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de lister les échéances des rotations")
+		return nil, false
+	}
+	for _, rotator := range rotators {
+		if !canRead(rotator.ProjectID) {
+			continue
+		}
+		previous := models.PreviousSecretName(rotator.SecretName)
+		events = append(events, &models.CalendarEvent{
+			UID:         fmt.Sprintf("%s-%s-%d@secrets-manager", models.CalendarSecretExpiration, rotator.ID, rotator.PreviousExpiresAt.Unix()),
+			Type:        models.CalendarSecretExpiration,
+			Summary:     fmt.Sprintf("Retrait de %s (%s)", previous, rotator.Environment),
+			At:          *rotator.PreviousExpiresAt,
+			ProjectID:   rotator.ProjectID,
+			Environment: rotator.Environment,
+			SecretName:  previous,
+		})
+	}
+
+	changes, err := h.scheduled.ListOrganizationScheduledSecretChanges(ctx, orgID, until)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de lister les changements planifiés")
+		return nil, false
+	}
+	for _, change := range changes {
+		if !canRead(change.ProjectID) {
+			continue
+		}
+		events = append(events, &models.CalendarEvent{
+			UID:         models.CalendarScheduledChange + "-" + change.ID + "@secrets-manager",
+			Type:        models.CalendarScheduledChange,
+			Summary:     fmt.Sprintf("Changement planifié de %s (%s)", change.SecretName, change.Environment),
+			At:          change.EffectiveAt,
+			ProjectID:   change.ProjectID,
+			Environment: change.Environment,
+			SecretName:  change.SecretName,
+		})
+	}
+
+	if role == "admin" && h.subscriptions != nil {
+		subscription, err := h.subscriptions.GetActiveSubscription(ctx, orgID)
+		if err != nil {
+			apierror.Write(w, err, "Impossible de récupérer l'abonnement")
+			return nil, false
+		}
+		if subscription != nil && !subscription.EndDate.After(until) {
+			events = append(events, &models.CalendarEvent{
+				UID:     models.CalendarSubscriptionRenewal + "-" + subscription.ID + "@secrets-manager",
+				Type:    models.CalendarSubscriptionRenewal,
+				Summary: "Renouvellement de l'abonnement",
+				At:      subscription.EndDate,
+			})
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool { return events[i].At.Before(events[j].At) })
+	return events, true
+}
@@ -0,0 +1,41 @@
+// filepath: internal/api/handlers/captcha.go
+
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"secrets-manager/internal/api/apierror"
+	"secrets-manager/internal/captcha"
+)
+
+// CaptchaHandler décrit le défi exigé avant l'inscription
+type CaptchaHandler struct {
+	provider captcha.Provider
+}
+
+// NewCaptchaHandler crée un nouveau gestionnaire de défis ; provider nil
+// n'en exige aucun
+func NewCaptchaHandler(provider captcha.Provider) *CaptchaHandler {
+	return &CaptchaHandler{
+		provider: provider,
+	}
+}
+
+// GetChallenge renvoie le défi à résoudre : le widget d'un CAPTCHA hébergé,
+// une preuve de travail à usage unique, ou le fournisseur "none"
+func (h *CaptchaHandler) GetChallenge(w http.ResponseWriter, r *http.Request) {
+	challenge := &captcha.Challenge{Provider: captcha.ProviderNone}
+	if h.provider != nil {
+		var err error
+		if challenge, err = h.provider.Challenge(); err != nil {
+			apierror.Write(w, err, "Impossible de créer le défi")
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(challenge)
+}
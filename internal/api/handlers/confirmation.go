@@ -0,0 +1,119 @@
+// filepath: internal/api/handlers/confirmation.go
+
+package handlers
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"secrets-manager/internal/api/apierror"
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// ConfirmationTokenHeader porte le token qui confirme une opération destructive
+const ConfirmationTokenHeader = "X-Confirmation-Token"
+
+// ConfirmationResponse est renvoyée à la première étape d'une opération destructive
+type ConfirmationResponse struct {
+	Action            string    `json:"action"`
+	ConfirmationToken string    `json:"confirmation_token"`
+	ExpiresAt         time.Time `json:"expires_at"`
+}
+
+// Confirmer impose un flux en deux étapes aux opérations destructives :
+// un premier appel sans token renvoie 202 et un token à usage unique,
+// l'opération n'est exécutée que si le même appel est rejoué avec ce token
+// dans l'en-tête X-Confirmation-Token avant son expiration.
+type Confirmer struct {
+	confirmations storage.ConfirmationsRepository
+	window        time.Duration
+}
+
+// NewConfirmer crée un gestionnaire de confirmations valables pendant window
+func NewConfirmer(confirmations storage.ConfirmationsRepository, window time.Duration) *Confirmer {
+	return &Confirmer{
+		confirmations: confirmations,
+		window:        window,
+	}
+}
+
+// confirm renvoie true si la requête porte un token valide pour cette opération
+// exacte. Sinon la réponse (202 avec un nouveau token, ou 412) est déjà écrite.
+func (c *Confirmer) confirm(w http.ResponseWriter, r *http.Request, orgID, userID, action, target string) bool {
+	token := r.Header.Get(ConfirmationTokenHeader)
+	if token == "" {
+		c.issue(w, r, orgID, userID, action, target)
+		return false
+	}
+
+	confirmation, err := c.confirmations.ConsumeConfirmation(r.Context(), hashConfirmationToken(token))
+	if errors.Is(err, storage.ErrConfirmationNotFound) {
+		http.Error(w, "Token de confirmation invalide ou expiré", http.StatusPreconditionFailed)
+		return false
+	}
+	if err != nil {
+		apierror.Write(w, err, "Impossible de vérifier la confirmation")
+		return false
+	}
+
+	// Le token ne vaut que pour l'opération, la cible et l'utilisateur d'origine
+	if confirmation.Action != action || confirmation.Target != target ||
+		confirmation.OrganizationID != orgID || confirmation.RequestedBy != userID ||
+		time.Now().After(confirmation.ExpiresAt) {
+		http.Error(w, "Token de confirmation invalide ou expiré", http.StatusPreconditionFailed)
+		return false
+	}
+
+	return true
+}
+
+// issue crée une demande de confirmation et renvoie le token à l'appelant
+func (c *Confirmer) issue(w http.ResponseWriter, r *http.Request, orgID, userID, action, target string) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		apierror.Write(w, err, "Impossible de générer le token de confirmation")
+		return
+	}
+	token := base64.RawURLEncoding.EncodeToString(raw)
+
+	confirmation := &models.DeletionConfirmation{
+		TokenHash:      hashConfirmationToken(token),
+		OrganizationID: orgID,
+		Action:         action,
+		Target:         target,
+		RequestedBy:    userID,
+		ExpiresAt:      time.Now().Add(c.window).UTC(),
+	}
+	if err := c.confirmations.CreateConfirmation(r.Context(), confirmation); err != nil {
+		apierror.Write(w, err, "Impossible d'enregistrer la demande de confirmation")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(ConfirmationResponse{
+		Action:            action,
+		ConfirmationToken: token,
+		ExpiresAt:         confirmation.ExpiresAt,
+	})
+}
+
+// hashConfirmationToken renvoie l'empreinte stockée d'un token
+func hashConfirmationToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// confirmationTarget résume une cible composite (ex: une liste de secrets)
+func confirmationTarget(parts ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,288 @@
+// filepath: internal/api/handlers/custom_roles.go
+
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"secrets-manager/internal/api/apierror"
+	"secrets-manager/internal/api/middleware"
+	"secrets-manager/internal/auth"
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// CustomRolesHandler gère les rôles personnalisés des organisations
+// Enterprise : des ensembles de permissions fines (voir models.Permissions)
+// attribués aux membres en plus de leur rôle dans l'organisation
+type CustomRolesHandler struct {
+	roles         storage.CustomRolesRepository
+	organizations storage.OrganizationsRepository
+	users         storage.UsersRepository
+}
+
+// NewCustomRolesHandler crée un nouveau gestionnaire de rôles personnalisés
+func NewCustomRolesHandler(roles storage.CustomRolesRepository, organizations storage.OrganizationsRepository,
+	users storage.UsersRepository) *CustomRolesHandler {
+	return &CustomRolesHandler{roles: roles, organizations: organizations, users: users}
+}
+
+// CustomRoleRequest crée ou remplace un rôle personnalisé
+type CustomRoleRequest struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions"`
+}
+
+// CustomRoleAssignmentRequest attribue un rôle personnalisé à un membre
+type CustomRoleAssignmentRequest struct {
+	RoleID string `json:"role_id"`
+}
+
+// ListCustomRoles liste les rôles personnalisés de l'organisation, visibles
+// de tous ses membres
+func (h *CustomRolesHandler) ListCustomRoles(w http.ResponseWriter, r *http.Request) {
+	orgID := mux.Vars(r)["orgID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if role, err := h.users.GetUserRole(r.Context(), userID, orgID); err != nil || role == "" {
+		http.Error(w, "Organisation non trouvée", http.StatusNotFound)
+		return
+	}
+
+	roles, err := h.roles.ListCustomRoles(r.Context(), orgID)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de lister les rôles")
+		return
+	}
+	writeJSONList(w, r, roles)
+}
+
+// CreateCustomRole crée un rôle personnalisé. Réservé aux administrateurs
+// d'une organisation Enterprise (402 sinon) ; 409 si le nom est déjà pris.
+func (h *CustomRolesHandler) CreateCustomRole(w http.ResponseWriter, r *http.Request) {
+	orgID := mux.Vars(r)["orgID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if !h.requireEnterpriseAdmin(w, r, orgID, userID) {
+		return
+	}
+	role, ok := decodeCustomRole(w, r)
+	if !ok {
+		return
+	}
+	role.OrganizationID = orgID
+	role.CreatedBy = userID
+	if err := h.roles.CreateCustomRole(r.Context(), role); err != nil {
+		apierror.Write(w, err, "Impossible de créer le rôle")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(role)
+}
+
+// GetCustomRole renvoie un rôle personnalisé de l'organisation
+func (h *CustomRolesHandler) GetCustomRole(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	orgID := vars["orgID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if role, err := h.users.GetUserRole(r.Context(), userID, orgID); err != nil || role == "" {
+		http.Error(w, "Organisation non trouvée", http.StatusNotFound)
+		return
+	}
+
+	role, err := h.roles.GetCustomRole(r.Context(), orgID, vars["roleID"])
+	if err != nil {
+		apierror.Write(w, err, "Impossible de récupérer le rôle")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(role); err != nil {
+		http.Error(w, "Erreur lors de l'encodage du rôle", http.StatusInternalServerError)
+	}
+}
+
+// UpdateCustomRole remplace le nom, la description et les permissions d'un
+// rôle ; ses membres en bénéficient dès la requête suivante
+func (h *CustomRolesHandler) UpdateCustomRole(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	orgID := vars["orgID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if !h.requireEnterpriseAdmin(w, r, orgID, userID) {
+		return
+	}
+	role, ok := decodeCustomRole(w, r)
+	if !ok {
+		return
+	}
+	role.ID = vars["roleID"]
+	role.OrganizationID = orgID
+	if err := h.roles.UpdateCustomRole(r.Context(), role); err != nil {
+		apierror.Write(w, err, "Impossible de modifier le rôle")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(role)
+}
+
+// DeleteCustomRole supprime un rôle ; ses membres perdent ses permissions.
+// Réservé aux administrateurs, quel que soit le plan.
+func (h *CustomRolesHandler) DeleteCustomRole(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	orgID := vars["orgID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if !requireOrgAdmin(w, r, h.users, orgID, userID) {
+		return
+	}
+
+	if err := h.roles.DeleteCustomRole(r.Context(), orgID, vars["roleID"]); err != nil {
+		apierror.Write(w, err, "Impossible de supprimer le rôle")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// AssignCustomRole attribue un rôle personnalisé à un membre, en remplaçant
+// le précédent. Réservé aux administrateurs d'une organisation Enterprise.
+func (h *CustomRolesHandler) AssignCustomRole(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	orgID := vars["orgID"]
+	memberID := vars["userID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if !h.requireEnterpriseAdmin(w, r, orgID, userID) {
+		return
+	}
+
+	var req CustomRoleAssignmentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Données invalides", http.StatusBadRequest)
+		return
+	}
+	if req.RoleID == "" {
+		apierror.Write(w, apierror.Validation("role_id requis"), "")
+		return
+	}
+	if err := checkMember(r.Context(), h.users, orgID, memberID); err != nil {
+		apierror.Write(w, err, "Impossible de vérifier le membre")
+		return
+	}
+
+	assignment := &models.CustomRoleAssignment{
+		OrganizationID: orgID,
+		UserID:         memberID,
+		RoleID:         req.RoleID,
+		AssignedBy:     userID,
+	}
+	if err := h.roles.AssignCustomRole(r.Context(), assignment); err != nil {
+		apierror.Write(w, err, "Impossible d'attribuer le rôle")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(assignment)
+}
+
+// UnassignCustomRole retire son rôle personnalisé à un membre. Réservé aux
+// administrateurs.
+func (h *CustomRolesHandler) UnassignCustomRole(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	orgID := vars["orgID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if !requireOrgAdmin(w, r, h.users, orgID, userID) {
+		return
+	}
+
+	if err := h.roles.UnassignCustomRole(r.Context(), orgID, vars["userID"]); err != nil {
+		apierror.Write(w, err, "Impossible de retirer le rôle")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// requireEnterpriseAdmin vérifie que l'utilisateur administre l'organisation
+// et que son plan autorise les rôles personnalisés (402 sinon)
+func (h *CustomRolesHandler) requireEnterpriseAdmin(w http.ResponseWriter, r *http.Request, orgID, userID string) bool {
+	if !requireOrgAdmin(w, r, h.users, orgID, userID) {
+		return false
+	}
+	planID, err := h.organizations.GetOrganizationPlan(r.Context(), orgID)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de lire le plan de l'organisation")
+		return false
+	}
+	if !slices.Contains(models.CustomRolesPlans, planID) {
+		http.Error(w, "Les rôles personnalisés nécessitent un plan Enterprise", http.StatusPaymentRequired)
+		return false
+	}
+	return true
+}
+
+// decodeCustomRole lit et valide un CustomRoleRequest
+func decodeCustomRole(w http.ResponseWriter, r *http.Request) (*models.CustomRole, bool) {
+	var req CustomRoleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Données invalides", http.StatusBadRequest)
+		return nil, false
+	}
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" || len(req.Name) > 64 {
+		apierror.Write(w, apierror.Validation("Nom du rôle requis (64 caractères au plus)"), "")
+		return nil, false
+	}
+	if len(req.Description) > 500 {
+		apierror.Write(w, apierror.Validation("Description trop longue (500 caractères au plus)"), "")
+		return nil, false
+	}
+	permissions := []string{}
+	for _, permission := range req.Permissions {
+		if !slices.Contains(models.Permissions, permission) {
+			apierror.Write(w, apierror.Validation("Permission inconnue : "+permission+
+				" (attendu : "+strings.Join(models.Permissions, ", ")+")"), "")
+			return nil, false
+		}
+		if !slices.Contains(permissions, permission) {
+			permissions = append(permissions, permission)
+		}
+	}
+	if len(permissions) == 0 {
+		apierror.Write(w, apierror.Validation("Au moins une permission requise"), "")
+		return nil, false
+	}
+	return &models.CustomRole{Name: req.Name, Description: req.Description, Permissions: permissions}, true
+}
+
+// requirePermission vérifie que l'utilisateur dispose de la permission fine
+// dans l'organisation, en tant qu'administrateur ou par son rôle
+// personnalisé. Les non-membres reçoivent 404 et les autres membres 403.
+func requirePermission(w http.ResponseWriter, r *http.Request, permissions *auth.PermissionService,
+	orgID, userID, permission string) bool {
+	allowed, err := permissions.HasPermission(r.Context(), userID, orgID, permission)
+	if errors.Is(err, auth.ErrNotMember) {
+		http.Error(w, "Organisation non trouvée", http.StatusNotFound)
+		return false
+	}
+	if err != nil {
+		apierror.Write(w, err, "Impossible de vérifier les permissions")
+		return false
+	}
+	if !allowed {
+		http.Error(w, "Accès refusé", http.StatusForbidden)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,302 @@
+// filepath: internal/api/handlers/data_keys.go
+
+package handlers
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	"secrets-manager/internal/api/apierror"
+	"secrets-manager/internal/api/middleware"
+	"secrets-manager/internal/logging"
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+	"secrets-manager/internal/vault"
+)
+
+// Taille maximale d'un contenu chiffré avec une clé de données
+const maxDataKeyPlaintext = 256 << 10
+
+// Nombre d'opérations renvoyées par l'audit d'une clé
+const dataKeyAuditLimit = 100
+
+// Les noms des clés apparaissent dans les URL
+var dataKeyName = regexp.MustCompile(`^[A-Za-z0-9_.-]{1,64}$`)
+
+// DataKeysHandler gère les clés de données des projets : chiffrement
+// d'enveloppe de petits contenus conservés ailleurs (états Terraform,
+// fichiers sops) avec le moteur transit de Vault. Chaque chiffrement et
+// déchiffrement est audité.
+type DataKeysHandler struct {
+	keys    storage.DataKeysRepository
+	transit *vault.TransitRouter
+	policy  *secretPolicy
+}
+
+// NewDataKeysHandler crée un nouveau gestionnaire des clés de données
+func NewDataKeysHandler(
+	keys storage.DataKeysRepository,
+	transit *vault.TransitRouter,
+	users storage.UsersRepository,
+	projects storage.ProjectsRepository,
+	teams storage.TeamsRepository,
+) *DataKeysHandler {
+	return &DataKeysHandler{
+		keys:    keys,
+		transit: transit,
+		policy:  &secretPolicy{users: users, projects: projects, teams: teams},
+	}
+}
+
+// DataKeyPayload est un contenu en clair, en base64
+type DataKeyPayload struct {
+	Plaintext string `json:"plaintext"`
+}
+
+// CreateDataKey enregistre une clé de données du projet et crée sa clé
+// transit (administrateurs de l'organisation)
+func (h *DataKeysHandler) CreateDataKey(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	orgID, projectID := vars["orgID"], vars["projectID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if err := h.policy.check(r.Context(), userID, orgID, projectID, secretAdmin); err != nil {
+		writePolicyError(w, err)
+		return
+	}
+
+	var request struct {
+		Name string `json:"name"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "Données invalides", http.StatusBadRequest)
+		return
+	}
+	if !dataKeyName.MatchString(request.Name) {
+		apierror.Write(w, apierror.Validation("Nom de clé invalide (lettres, chiffres, . _ -, 64 caractères au plus)"), "")
+		return
+	}
+	if _, err := h.keys.GetDataKey(r.Context(), orgID, projectID, request.Name); err == nil {
+		apierror.Write(w, storage.ErrDataKeyExists, "")
+		return
+	}
+
+	transit, err := h.transit.For(r.Context(), orgID)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de joindre le moteur transit")
+		return
+	}
+	// La clé transit est créée avant l'enregistrement : une clé enregistrée
+	// est toujours utilisable
+	id := uuid.New().String()
+	key := &models.DataKey{
+		ID:             id,
+		OrganizationID: orgID,
+		ProjectID:      projectID,
+		Name:           request.Name,
+		TransitKey:     "sm-datakey-" + id,
+		CreatedBy:      userID,
+	}
+	if err := transit.CreateTransitKey(r.Context(), key.TransitKey); err != nil {
+		apierror.Write(w, err, "Impossible de créer la clé transit")
+		return
+	}
+	if err := h.keys.CreateDataKey(r.Context(), key); err != nil {
+		apierror.Write(w, err, "Impossible d'enregistrer la clé de données")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(key)
+}
+
+// ListDataKeys liste les clés de données du projet
+func (h *DataKeysHandler) ListDataKeys(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	orgID, projectID := vars["orgID"], vars["projectID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if err := h.policy.check(r.Context(), userID, orgID, projectID, secretRead); err != nil {
+		writePolicyError(w, err)
+		return
+	}
+
+	keys, err := h.keys.ListDataKeys(r.Context(), orgID, projectID)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de lister les clés de données")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(keys)
+}
+
+// Encrypt chiffre un contenu (plaintext en base64, 256 Kio au plus) avec
+// une nouvelle clé de données et renvoie l'enveloppe
+func (h *DataKeysHandler) Encrypt(w http.ResponseWriter, r *http.Request) {
+	key, ok := h.resolve(w, r, secretWrite)
+	if !ok {
+		return
+	}
+
+	var payload DataKeyPayload
+	if err := decodeDataKeyBody(w, r, &payload); err != nil {
+		apierror.Write(w, err, "")
+		return
+	}
+	plaintext, err := base64.StdEncoding.DecodeString(payload.Plaintext)
+	if err != nil || len(plaintext) > maxDataKeyPlaintext {
+		apierror.Write(w, apierror.Validation("Contenu invalide (base64, 256 Kio au plus)"), "")
+		return
+	}
+
+	var wrapped string
+	var ciphertext []byte
+	transit, err := h.transit.For(r.Context(), key.OrganizationID)
+	if err == nil {
+		wrapped, ciphertext, err = vault.SealEnvelope(r.Context(), transit, key.TransitKey, plaintext, dataKeyContext(key))
+	}
+	if !h.audit(w, r, key, models.DataKeyEncrypt, len(plaintext), err) {
+		return
+	}
+	if err != nil {
+		apierror.Write(w, err, "Impossible de chiffrer le contenu")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(models.DataKeyEnvelope{
+		KeyID:      key.ID,
+		WrappedKey: wrapped,
+		Ciphertext: base64.StdEncoding.EncodeToString(ciphertext),
+	})
+}
+
+// Decrypt ouvre une enveloppe produite par Encrypt avec la même clé
+func (h *DataKeysHandler) Decrypt(w http.ResponseWriter, r *http.Request) {
+	key, ok := h.resolve(w, r, secretRead)
+	if !ok {
+		return
+	}
+
+	var envelope models.DataKeyEnvelope
+	if err := decodeDataKeyBody(w, r, &envelope); err != nil {
+		apierror.Write(w, err, "")
+		return
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(envelope.Ciphertext)
+	if err != nil || envelope.WrappedKey == "" {
+		apierror.Write(w, apierror.Validation("Enveloppe invalide"), "")
+		return
+	}
+	if envelope.KeyID != key.ID {
+		apierror.Write(w, apierror.Validation("L'enveloppe a été chiffrée avec une autre clé"), "")
+		return
+	}
+
+	var plaintext []byte
+	transit, err := h.transit.For(r.Context(), key.OrganizationID)
+	if err == nil {
+		plaintext, err = vault.OpenEnvelope(r.Context(), transit, key.TransitKey, envelope.WrappedKey, ciphertext, dataKeyContext(key))
+	}
+	if !h.audit(w, r, key, models.DataKeyDecrypt, len(plaintext), err) {
+		return
+	}
+	if errors.Is(err, vault.ErrInvalidEnvelope) {
+		apierror.Write(w, apierror.Validation("Enveloppe invalide ou altérée"), "")
+		return
+	}
+	if err != nil {
+		apierror.Write(w, err, "Impossible de déchiffrer le contenu")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(DataKeyPayload{Plaintext: base64.StdEncoding.EncodeToString(plaintext)})
+}
+
+// ListOperations renvoie les 100 dernières opérations d'une clé
+// (administrateurs de l'organisation)
+func (h *DataKeysHandler) ListOperations(w http.ResponseWriter, r *http.Request) {
+	key, ok := h.resolve(w, r, secretAdmin)
+	if !ok {
+		return
+	}
+
+	operations, err := h.keys.ListDataKeyOperations(r.Context(), key.ID, dataKeyAuditLimit)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de lister les opérations de la clé")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(operations)
+}
+
+// resolve vérifie l'action demandée et renvoie la clé de la route
+func (h *DataKeysHandler) resolve(w http.ResponseWriter, r *http.Request, action secretAction) (*models.DataKey, bool) {
+	vars := mux.Vars(r)
+	orgID, projectID := vars["orgID"], vars["projectID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if err := h.policy.check(r.Context(), userID, orgID, projectID, action); err != nil {
+		writePolicyError(w, err)
+		return nil, false
+	}
+	key, err := h.keys.GetDataKey(r.Context(), orgID, projectID, vars["name"])
+	if err != nil {
+		apierror.Write(w, err, "Impossible de récupérer la clé de données")
+		return nil, false
+	}
+	return key, true
+}
+
+// audit enregistre l'opération avant toute réponse : sans audit, le
+// résultat n'est pas renvoyé
+func (h *DataKeysHandler) audit(w http.ResponseWriter, r *http.Request, key *models.DataKey, operation string, size int, opErr error) bool {
+	entry := &models.DataKeyOperation{
+		DataKeyID:      key.ID,
+		OrganizationID: key.OrganizationID,
+		Operation:      operation,
+		ActorID:        middleware.UserIDFromContext(r.Context()),
+		Size:           size,
+		IPAddress:      middleware.ClientIP(r),
+		OccurredAt:     time.Now(),
+	}
+	if opErr != nil {
+		entry.Error = opErr.Error()
+		if len(entry.Error) > 255 {
+			entry.Error = strings.ToValidUTF8(entry.Error[:255], "")
+		}
+	}
+	if err := h.keys.RecordDataKeyOperation(r.Context(), entry); err != nil {
+		logging.For(logging.ComponentHTTP).Error("opération de clé de données non auditée",
+			"data_key_id", key.ID, "operation", operation, "error", err)
+		http.Error(w, "Impossible d'auditer l'opération", http.StatusInternalServerError)
+		return false
+	}
+	return true
+}
+
+// decodeDataKeyBody décode un corps JSON borné (le contenu en base64 et son enveloppe)
+func decodeDataKeyBody(w http.ResponseWriter, r *http.Request, v any) error {
+	r.Body = http.MaxBytesReader(w, r.Body, 2*maxDataKeyPlaintext)
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return apierror.Validation("Données invalides")
+	}
+	return nil
+}
+
+// dataKeyContext lie les enveloppes à leur projet et à leur clé
+func dataKeyContext(key *models.DataKey) []byte {
+	return []byte(key.OrganizationID + "/" + key.ProjectID + "/" + key.ID)
+}
@@ -0,0 +1,55 @@
+// filepath: internal/api/handlers/debug.go
+
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"runtime"
+	"runtime/debug"
+	"runtime/pprof"
+
+	"secrets-manager/internal/version"
+)
+
+// DebugHandler expose des informations d'exécution pour les opérateurs
+type DebugHandler struct{}
+
+// NewDebugHandler crée un nouveau gestionnaire de debug
+func NewDebugHandler() *DebugHandler {
+	return &DebugHandler{}
+}
+
+// Goroutines renvoie la pile complète de toutes les goroutines
+func (h *DebugHandler) Goroutines(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	if err := pprof.Lookup("goroutine").WriteTo(w, 2); err != nil {
+		http.Error(w, "Impossible de générer le dump des goroutines", http.StatusInternalServerError)
+	}
+}
+
+// BuildInfo renvoie les informations de compilation du binaire
+func (h *DebugHandler) BuildInfo(w http.ResponseWriter, r *http.Request) {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		http.Error(w, "Informations de build indisponibles", http.StatusNotFound)
+		return
+	}
+
+	settings := make(map[string]string, len(info.Settings))
+	for _, setting := range info.Settings {
+		settings[setting.Key] = setting.Value
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"version":    version.Get(),
+		"go_version": info.GoVersion,
+		"path":       info.Path,
+		"main":       info.Main,
+		"settings":   settings,
+		"goroutines": runtime.NumGoroutine(),
+		"num_cpu":    runtime.NumCPU(),
+		"gomaxprocs": runtime.GOMAXPROCS(0),
+	})
+}
@@ -0,0 +1,275 @@
+// filepath: internal/api/handlers/device_auth.go
+
+package handlers
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"secrets-manager/internal/api/apierror"
+	"secrets-manager/internal/api/middleware"
+	"secrets-manager/internal/auth"
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+const (
+	// deviceCodeTTL est la durée laissée à l'utilisateur pour approuver l'appareil
+	deviceCodeTTL = 10 * time.Minute
+	// devicePollInterval est l'intervalle minimal entre deux interrogations de l'appareil
+	devicePollInterval = 5 * time.Second
+	// userCodeAlphabet exclut les voyelles (pas de mots) et les caractères ambigus
+	userCodeAlphabet = "BCDFGHJKLMNPQRSTVWXZ"
+	userCodeLength   = 8
+)
+
+// Codes d'erreur du flux device code (RFC 8628, section 3.5)
+const (
+	deviceErrorPending      = "authorization_pending"
+	deviceErrorSlowDown     = "slow_down"
+	deviceErrorAccessDenied = "access_denied"
+	deviceErrorExpired      = "expired_token"
+	deviceErrorInvalid      = "invalid_request"
+)
+
+// DeviceCodeResponse est renvoyée à l'appareil qui demande à se connecter
+type DeviceCodeResponse struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval"`
+}
+
+// DeviceAuthHandler gère la connexion des appareils sans navigateur (flux
+// device code) : l'appareil obtient un device code et un user code, affiche
+// le user code, puis interroge le serveur jusqu'à ce que l'utilisateur
+// l'approuve depuis une session déjà authentifiée (tableau de bord, poste
+// de travail). Aucun mot de passe n'est saisi sur l'appareil.
+type DeviceAuthHandler struct {
+	authService     *auth.Service
+	devices         storage.DeviceAuthorizationsRepository
+	verificationURI string
+}
+
+// NewDeviceAuthHandler crée un nouveau gestionnaire du flux device code.
+// verificationURI est la page où l'utilisateur saisit le user code.
+func NewDeviceAuthHandler(authService *auth.Service, devices storage.DeviceAuthorizationsRepository, verificationURI string) *DeviceAuthHandler {
+	return &DeviceAuthHandler{
+		authService:     authService,
+		devices:         devices,
+		verificationURI: verificationURI,
+	}
+}
+
+// RequestCode crée une demande de connexion pour un appareil
+func (h *DeviceAuthHandler) RequestCode(w http.ResponseWriter, r *http.Request) {
+	var request struct {
+		ClientName string `json:"client_name"`
+	}
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, "Données invalides", http.StatusBadRequest)
+			return
+		}
+	}
+	if request.ClientName == "" {
+		request.ClientName = "smctl"
+	}
+	if len(request.ClientName) > 128 {
+		apierror.Write(w, apierror.Validation("Nom du client trop long"), "")
+		return
+	}
+
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		apierror.Write(w, err, "Impossible de générer le device code")
+		return
+	}
+	deviceCode := base64.RawURLEncoding.EncodeToString(raw)
+	authorization := &models.DeviceAuthorization{
+		DeviceCodeHash: hashConfirmationToken(deviceCode),
+		ClientName:     request.ClientName,
+		Status:         models.DeviceAuthorizationPending,
+		ExpiresAt:      time.Now().Add(deviceCodeTTL),
+	}
+	// Un user code déjà attribué est tiré à nouveau
+	var err error
+	for attempt := 0; attempt < 3; attempt++ {
+		authorization.UserCode = newUserCode()
+		if err = h.devices.CreateDeviceAuthorization(r.Context(), authorization); !errors.Is(err, storage.ErrAlreadyExists) {
+			break
+		}
+	}
+	if err != nil {
+		apierror.Write(w, err, "Impossible de créer la demande de connexion")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(DeviceCodeResponse{
+		DeviceCode:              deviceCode,
+		UserCode:                authorization.UserCode,
+		VerificationURI:         h.verificationURI,
+		VerificationURIComplete: h.verificationURI + "?user_code=" + url.QueryEscape(authorization.UserCode),
+		ExpiresIn:               int(deviceCodeTTL.Seconds()),
+		Interval:                int(devicePollInterval.Seconds()),
+	})
+}
+
+// PollToken renvoie les tokens de l'utilisateur une fois l'appareil
+// approuvé. Tant qu'il ne l'est pas, la réponse est 400 avec le code
+// authorization_pending (ou slow_down si l'appareil interroge trop souvent).
+func (h *DeviceAuthHandler) PollToken(w http.ResponseWriter, r *http.Request) {
+	var request struct {
+		DeviceCode string `json:"device_code"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil || request.DeviceCode == "" {
+		writeOAuthError(w, deviceErrorInvalid, "device_code requis")
+		return
+	}
+
+	ctx := r.Context()
+	now := time.Now()
+	authorization, err := h.devices.PollDeviceAuthorization(ctx, hashConfirmationToken(request.DeviceCode), now)
+	if errors.Is(err, storage.ErrDeviceCodeNotFound) {
+		writeOAuthError(w, deviceErrorExpired, "Demande inconnue ou expirée")
+		return
+	}
+	if err != nil {
+		apierror.Write(w, err, "Impossible de vérifier la demande de connexion")
+		return
+	}
+	if !now.Before(authorization.ExpiresAt) {
+		writeOAuthError(w, deviceErrorExpired, "Demande expirée")
+		return
+	}
+
+	switch authorization.Status {
+	case models.DeviceAuthorizationPending:
+		if authorization.LastPolledAt != nil && now.Sub(*authorization.LastPolledAt) < devicePollInterval {
+			writeOAuthError(w, deviceErrorSlowDown, "Interrogations trop fréquentes")
+			return
+		}
+		writeOAuthError(w, deviceErrorPending, "En attente de l'approbation de l'utilisateur")
+		return
+	case models.DeviceAuthorizationDenied:
+		h.devices.DeleteDeviceAuthorization(ctx, authorization.ID)
+		writeOAuthError(w, deviceErrorAccessDenied, "Connexion refusée par l'utilisateur")
+		return
+	}
+
+	// Les tokens ne sont délivrés qu'une fois : seule l'interrogation qui
+	// supprime la demande les reçoit
+	if err := h.devices.DeleteDeviceAuthorization(ctx, authorization.ID); err != nil {
+		if errors.Is(err, storage.ErrDeviceCodeNotFound) {
+			writeOAuthError(w, deviceErrorExpired, "Demande déjà utilisée")
+			return
+		}
+		apierror.Write(w, err, "Impossible de finaliser la connexion")
+		return
+	}
+	token, err := h.authService.IssueTokens(ctx, authorization.UserID, requestDevice(r, ""))
+	if err != nil {
+		apierror.Write(w, err, "Impossible de générer les tokens")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(map[string]string{
+		"token":         token.Token,
+		"refresh_token": token.RefreshToken,
+	})
+}
+
+// ApproveDevice connecte l'appareil affichant le user code au compte de l'utilisateur
+func (h *DeviceAuthHandler) ApproveDevice(w http.ResponseWriter, r *http.Request) {
+	h.decide(w, r, models.DeviceAuthorizationApproved)
+}
+
+// DenyDevice refuse la connexion de l'appareil affichant le user code
+func (h *DeviceAuthHandler) DenyDevice(w http.ResponseWriter, r *http.Request) {
+	h.decide(w, r, models.DeviceAuthorizationDenied)
+}
+
+func (h *DeviceAuthHandler) decide(w http.ResponseWriter, r *http.Request, status string) {
+	var request struct {
+		UserCode string `json:"user_code"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "Données invalides", http.StatusBadRequest)
+		return
+	}
+	userCode, ok := normalizeUserCode(request.UserCode)
+	if !ok {
+		apierror.Write(w, apierror.Validation("user_code invalide"), "")
+		return
+	}
+
+	userID := middleware.UserIDFromContext(r.Context())
+	err := h.devices.DecideDeviceAuthorization(r.Context(), userCode, status, userID, time.Now())
+	if errors.Is(err, storage.ErrDeviceCodeNotFound) {
+		http.Error(w, "Code inconnu, expiré ou déjà utilisé", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		apierror.Write(w, err, "Impossible d'enregistrer la décision")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// newUserCode tire un user code au format XXXX-XXXX. Les octets au-delà du
+// dernier multiple de la taille de l'alphabet sont écartés pour que chaque
+// lettre soit équiprobable.
+func newUserCode() string {
+	limit := byte(256 - 256%len(userCodeAlphabet))
+	code := make([]byte, 0, userCodeLength+1)
+	b := make([]byte, 1)
+	for len(code) < userCodeLength+1 {
+		if len(code) == userCodeLength/2 {
+			code = append(code, '-')
+			continue
+		}
+		rand.Read(b)
+		if b[0] < limit {
+			code = append(code, userCodeAlphabet[int(b[0])%len(userCodeAlphabet)])
+		}
+	}
+	return string(code)
+}
+
+// normalizeUserCode accepte un user code saisi sans tiret ou en minuscules
+func normalizeUserCode(input string) (string, bool) {
+	cleaned := strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+		return r
+	}, strings.ToUpper(input))
+	if len(cleaned) != userCodeLength || strings.Trim(cleaned, userCodeAlphabet) != "" {
+		return "", false
+	}
+	return cleaned[:userCodeLength/2] + "-" + cleaned[userCodeLength/2:], true
+}
+
+// writeOAuthError écrit une erreur au format OAuth 2.0 (RFC 6749, section 5.2)
+func writeOAuthError(w http.ResponseWriter, code, description string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusBadRequest)
+	json.NewEncoder(w).Encode(map[string]string{
+		"error":             code,
+		"error_description": description,
+	})
+}
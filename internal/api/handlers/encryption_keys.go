@@ -0,0 +1,123 @@
+// filepath: internal/api/handlers/encryption_keys.go
+
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"secrets-manager/internal/api/apierror"
+	"secrets-manager/internal/api/middleware"
+	"secrets-manager/internal/e2e"
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// EncryptionKeysHandler gère la clé publique de chiffrement de bout en bout
+// des projets. Une fois la clé enregistrée, le serveur n'accepte plus pour
+// ce projet que des valeurs chiffrées pour cette clé par le client (SDK,
+// CLI) ; la clé privée ne quitte jamais le client.
+type EncryptionKeysHandler struct {
+	projects storage.ProjectsRepository
+	policy   *secretPolicy
+}
+
+// NewEncryptionKeysHandler crée un nouveau gestionnaire des clés de projet
+func NewEncryptionKeysHandler(projects storage.ProjectsRepository, users storage.UsersRepository,
+	teams storage.TeamsRepository) *EncryptionKeysHandler {
+	return &EncryptionKeysHandler{
+		projects: projects,
+		policy:   &secretPolicy{users: users, projects: projects, teams: teams},
+	}
+}
+
+// GetEncryptionKey renvoie la clé publique du projet aux membres qui peuvent
+// lire ses secrets (404 si le mode n'est pas activé)
+func (h *EncryptionKeysHandler) GetEncryptionKey(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	orgID, projectID := vars["orgID"], vars["projectID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if err := h.policy.check(r.Context(), userID, orgID, projectID, secretRead); err != nil {
+		writePolicyError(w, err)
+		return
+	}
+	if !h.projectExists(w, r, orgID, projectID) {
+		return
+	}
+
+	key, err := h.projects.GetProjectEncryptionKey(r.Context(), projectID)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de récupérer la clé du projet")
+		return
+	}
+	if key == nil {
+		http.Error(w, "Chiffrement de bout en bout non activé", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(key); err != nil {
+		http.Error(w, "Erreur lors de l'encodage de la clé", http.StatusInternalServerError)
+	}
+}
+
+// SetEncryptionKey active le chiffrement de bout en bout du projet ou
+// remplace sa clé publique. Les secrets déjà chiffrés restent lisibles avec
+// l'ancienne clé privée (l'enveloppe porte l'identifiant de sa clé) ; les
+// écritures suivantes doivent utiliser la nouvelle clé.
+func (h *EncryptionKeysHandler) SetEncryptionKey(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	orgID, projectID := vars["orgID"], vars["projectID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if err := h.policy.check(r.Context(), userID, orgID, projectID, secretAdmin); err != nil {
+		writePolicyError(w, err)
+		return
+	}
+	if !h.projectExists(w, r, orgID, projectID) {
+		return
+	}
+
+	var request struct {
+		PublicKey string `json:"public_key"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "Données invalides", http.StatusBadRequest)
+		return
+	}
+	keyID, err := e2e.KeyID(request.PublicKey)
+	if err != nil {
+		apierror.Write(w, apierror.Validation("Clé publique X25519 invalide (base64 attendu)"), "")
+		return
+	}
+
+	key := &models.ProjectEncryptionKey{
+		ProjectID: projectID,
+		Algorithm: e2e.Algorithm,
+		PublicKey: request.PublicKey,
+		KeyID:     keyID,
+		CreatedBy: userID,
+	}
+	if err := h.projects.SetProjectEncryptionKey(r.Context(), key); err != nil {
+		apierror.Write(w, err, "Impossible d'enregistrer la clé du projet")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(key); err != nil {
+		http.Error(w, "Erreur lors de l'encodage de la clé", http.StatusInternalServerError)
+	}
+}
+
+// projectExists vérifie que le projet appartient à l'organisation
+func (h *EncryptionKeysHandler) projectExists(w http.ResponseWriter, r *http.Request, orgID, projectID string) bool {
+	if _, err := h.projects.GetProject(r.Context(), orgID, projectID); err != nil {
+		// storage.ErrProjectNotFound donne 404
+		apierror.Write(w, err, "Impossible de récupérer le projet")
+		return false
+	}
+	return true
+}
@@ -0,0 +1,27 @@
+// filepath: internal/api/handlers/events.go
+
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"secrets-manager/internal/events"
+)
+
+// EventsHandler expose le catalogue des schémas d'événements
+type EventsHandler struct{}
+
+// NewEventsHandler crée un nouveau gestionnaire du catalogue d'événements
+func NewEventsHandler() *EventsHandler {
+	return &EventsHandler{}
+}
+
+// ListSchemas renvoie le schéma de chaque type d'événement versionné livré
+// aux webhooks
+func (h *EventsHandler) ListSchemas(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(events.Catalog()); err != nil {
+		http.Error(w, "Erreur lors de l'encodage du catalogue", http.StatusInternalServerError)
+	}
+}
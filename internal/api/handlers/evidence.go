@@ -0,0 +1,248 @@
+// filepath: internal/api/handlers/evidence.go
+
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"secrets-manager/internal/api/apierror"
+	"secrets-manager/internal/api/middleware"
+	"secrets-manager/internal/auth"
+	"secrets-manager/internal/evidence"
+	"secrets-manager/internal/logging"
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// Période par défaut couverte par les preuves (période d'audit SOC 2 type II)
+const defaultEvidencePeriod = 365 * 24 * time.Hour
+
+// Preuves demandées par les auditeurs que le service ne fournit pas encore
+var unavailableEvidence = map[string]string{
+	"mfa_adoption": "l'authentification multifacteur n'est pas encore disponible",
+	"audit_log":    "les accès aux secrets ne sont pas encore journalisés individuellement ; api_usage.json détaille les appels par principal, route et jour",
+}
+
+// EvidencePolicies sont les réglages de la plateforme inclus dans les preuves
+type EvidencePolicies struct {
+	ConfirmationWindow time.Duration
+	RecycleRetention   time.Duration
+}
+
+// EvidenceHandler exporte les preuves de conformité d'une organisation
+type EvidenceHandler struct {
+	reviews       storage.AccessReviewsRepository
+	organizations storage.OrganizationsRepository
+	users         storage.UsersRepository
+	secrets       storage.SecretsRepository
+	usage         storage.UsageRepository
+	adminAudit    storage.AdminAuditRepository
+	signer        *evidence.Signer
+	policies      EvidencePolicies
+	permissions   *auth.PermissionService
+}
+
+// NewEvidenceHandler crée un nouveau gestionnaire d'export des preuves.
+// Sans signer, l'export est désactivé.
+func NewEvidenceHandler(
+	reviews storage.AccessReviewsRepository,
+	organizations storage.OrganizationsRepository,
+	users storage.UsersRepository,
+	secrets storage.SecretsRepository,
+	usage storage.UsageRepository,
+	adminAudit storage.AdminAuditRepository,
+	signer *evidence.Signer,
+	policies EvidencePolicies,
+	permissions *auth.PermissionService,
+) *EvidenceHandler {
+	return &EvidenceHandler{
+		reviews:       reviews,
+		organizations: organizations,
+		users:         users,
+		secrets:       secrets,
+		usage:         usage,
+		adminAudit:    adminAudit,
+		signer:        signer,
+		policies:      policies,
+		permissions:   permissions,
+	}
+}
+
+// GetPublicKey renvoie la clé publique de vérification des archives de preuves
+func (h *EvidenceHandler) GetPublicKey(w http.ResponseWriter, r *http.Request) {
+	if h.signer == nil {
+		http.Error(w, "Export des preuves non configuré", http.StatusServiceUnavailable)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{
+		"algorithm":  "ed25519",
+		"public_key": h.signer.PublicKey(),
+	})
+}
+
+// ExportEvidence renvoie l'archive ZIP signée des preuves de conformité de
+// l'organisation : membres, revues des accès, usage de l'API, actions
+// d'administration et réglages. Paramètres optionnels : from et to au format
+// AAAA-MM-JJ (365 derniers jours par défaut). Réservé aux membres ayant la
+// permission exports.create.
+func (h *EvidenceHandler) ExportEvidence(w http.ResponseWriter, r *http.Request) {
+	orgID := mux.Vars(r)["orgID"]
+	userID := middleware.UserIDFromContext(r.Context())
+	if !requirePermission(w, r, h.permissions, orgID, userID, models.PermissionExportsCreate) {
+		return
+	}
+	if h.signer == nil {
+		http.Error(w, "Export des preuves non configuré", http.StatusServiceUnavailable)
+		return
+	}
+
+	to := time.Now().UTC().Truncate(24 * time.Hour)
+	from := to.Add(-defaultEvidencePeriod)
+	var err error
+	if v := r.URL.Query().Get("from"); v != "" {
+		if from, err = time.Parse("2006-01-02", v); err != nil {
+			http.Error(w, "Paramètre from invalide", http.StatusBadRequest)
+			return
+		}
+	}
+	if v := r.URL.Query().Get("to"); v != "" {
+		if to, err = time.Parse("2006-01-02", v); err != nil {
+			http.Error(w, "Paramètre to invalide", http.StatusBadRequest)
+			return
+		}
+	}
+	if to.Before(from) {
+		http.Error(w, "La période demandée est invalide", http.StatusBadRequest)
+		return
+	}
+	// to est inclus : la période s'arrête à la fin de ce jour
+	end := to.Add(24 * time.Hour)
+
+	files, err := h.collect(r, orgID, from, end)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de rassembler les preuves")
+		return
+	}
+
+	manifest := evidence.Manifest{
+		OrganizationID: orgID,
+		From:           from,
+		To:             end,
+		GeneratedAt:    time.Now().UTC(),
+		GeneratedBy:    userID,
+		Unavailable:    unavailableEvidence,
+	}
+	// L'archive est écrite directement dans la réponse. Une erreur en cours
+	// d'écriture tronque l'archive, dont la signature ne se vérifie alors plus.
+	filename := "evidence-" + orgID + "-" + from.Format("2006-01-02") + "-" + to.Format("2006-01-02") + ".zip"
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`"`)
+	if err := h.signer.WriteBundle(w, manifest, files); err != nil {
+		logging.For(logging.ComponentHTTP).Error("export des preuves interrompu",
+			"organization_id", orgID, "error", err)
+	}
+}
+
+// collect rassemble les fichiers de preuves de la période [from, end[
+func (h *EvidenceHandler) collect(r *http.Request, orgID string, from, end time.Time) ([]evidence.File, error) {
+	ctx := r.Context()
+
+	members, err := h.organizations.ListOrganizationMembers(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries, err := h.reviews.ListAccessReviews(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	reviews := []*models.AccessReview{}
+	for _, summary := range summaries {
+		if summary.CreatedAt.Before(from) || !summary.CreatedAt.Before(end) {
+			continue
+		}
+		review, err := h.reviews.GetAccessReview(ctx, orgID, summary.ID)
+		if err != nil {
+			return nil, err
+		}
+		reviews = append(reviews, review)
+	}
+
+	usage, err := h.usage.GetUsageBreakdown(ctx, orgID, from, end.Add(-24*time.Hour))
+	if err != nil {
+		return nil, err
+	}
+
+	adminChanges, err := h.adminChanges(r, orgID, from, end)
+	if err != nil {
+		return nil, err
+	}
+
+	planID, err := h.organizations.GetOrganizationPlan(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	secretsCount, err := h.secrets.GetSecretsCount(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	secretsLimit, err := h.secrets.GetSecretsLimit(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	policies := map[string]interface{}{
+		"plan_id":                     planID,
+		"secrets_count":               secretsCount,
+		"secrets_limit":               secretsLimit,
+		"destructive_confirmation":    h.policies.ConfirmationWindow.String(),
+		"recycle_bin_retention":       h.policies.RecycleRetention.String(),
+		"access_review_dormant_after": dormantAfter.String(),
+	}
+
+	contents := []struct {
+		name string
+		data interface{}
+	}{
+		{"members.json", members},
+		{"access_reviews.json", reviews},
+		{"api_usage.json", usage},
+		{"admin_changes.json", adminChanges},
+		{"policies.json", policies},
+	}
+	files := make([]evidence.File, 0, len(contents))
+	for _, content := range contents {
+		data, err := json.MarshalIndent(content.data, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, evidence.File{Name: content.name, Content: data})
+	}
+	return files, nil
+}
+
+// adminChanges renvoie les actions d'administration de la plateforme de la
+// période [from, end[ portant sur l'organisation
+func (h *EvidenceHandler) adminChanges(r *http.Request, orgID string, from, end time.Time) ([]*models.AdminAuditLog, error) {
+	changes := []*models.AdminAuditLog{}
+	for offset := 0; ; offset += maxPageSize {
+		entries, err := h.adminAudit.ListAdminAuditLogs(r.Context(), from, maxPageSize, offset)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			if entry.Timestamp.Before(end) && strings.Contains(entry.Path, orgID) {
+				changes = append(changes, entry)
+			}
+		}
+		if len(entries) < maxPageSize {
+			return changes, nil
+		}
+	}
+}
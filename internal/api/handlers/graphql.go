@@ -0,0 +1,576 @@
+// filepath: internal/api/handlers/graphql.go
+
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"time"
+
+	"secrets-manager/internal/api/middleware"
+	"secrets-manager/internal/graphql"
+	"secrets-manager/internal/logging"
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// Période maximale du journal d'une organisation consultable en GraphQL
+const maxGraphQLAuditDays = 90
+
+var (
+	errGraphQLHidden    = errors.New("Accès refusé")
+	errGraphQLAdminOnly = errors.New("Accès réservé aux administrateurs de l'organisation")
+)
+
+// GraphQLHandler expose en lecture seule les organisations, projets,
+// environnements, métadonnées de secrets, journal et usage de l'utilisateur
+// connecté, pour les clients du tableau de bord.
+//
+// Autorisation : seules les organisations dont l'utilisateur est membre sont
+// visibles ; l'usage et le journal sont réservés aux administrateurs. Un
+// token restreint à des projets (ou un compte de service) ne voit que les
+// projets, environnements et secrets de ses ressources. Un
+// champ refusé vaut null et son erreur figure dans la réponse, le reste de
+// la requête est exécuté. Les utilisateurs, environnements et secrets
+// imbriqués sont chargés par lots (une requête par niveau, pas par objet).
+type GraphQLHandler struct {
+	users         storage.UsersRepository
+	organizations storage.OrganizationsRepository
+	projects      storage.ProjectsRepository
+	secrets       storage.SecretsRepository
+	usage         storage.UsageRepository
+	events        storage.NotificationEventsRepository
+	schema        *graphql.Schema
+}
+
+// NewGraphQLHandler crée le gestionnaire GraphQL et son schéma
+func NewGraphQLHandler(
+	users storage.UsersRepository,
+	organizations storage.OrganizationsRepository,
+	projects storage.ProjectsRepository,
+	secrets storage.SecretsRepository,
+	usage storage.UsageRepository,
+	events storage.NotificationEventsRepository,
+) *GraphQLHandler {
+	h := &GraphQLHandler{
+		users:         users,
+		organizations: organizations,
+		projects:      projects,
+		secrets:       secrets,
+		usage:         usage,
+		events:        events,
+	}
+	h.schema = graphql.NewSchema(h.queryType())
+	return h
+}
+
+// Query exécute une requête GraphQL. Une requête invalide (syntaxe, champ
+// ou argument inconnu) donne 400 ; les erreurs des champs sont renvoyées
+// avec 200 dans "errors".
+func (h *GraphQLHandler) Query(w http.ResponseWriter, r *http.Request) {
+	var request graphql.Request
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "Données invalides", http.StatusBadRequest)
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), graphQLLoadersKey{}, h.newLoaders())
+	response := h.schema.Execute(ctx, request)
+
+	w.Header().Set("Content-Type", "application/json")
+	if response.Data == nil {
+		w.WriteHeader(http.StatusBadRequest)
+	}
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Erreur lors de l'encodage de la réponse", http.StatusInternalServerError)
+	}
+}
+
+// graphQLLoaders regroupe les chargements d'une requête GraphQL
+type graphQLLoaders struct {
+	// roles : rôle de l'utilisateur connecté par organisation ("" s'il n'est pas membre)
+	roles *graphql.Loader[string, string]
+	users *graphql.Loader[string, *models.User]
+	// summaries : résumés des projets par organisation puis par projet
+	summaries *graphql.Loader[string, map[string]*models.ProjectSummary]
+}
+
+type graphQLLoadersKey struct{}
+
+func (h *GraphQLHandler) newLoaders() *graphQLLoaders {
+	return &graphQLLoaders{
+		roles: graphql.NewLoader(func(ctx context.Context, orgIDs []string) (map[string]string, error) {
+			userID := middleware.UserIDFromContext(ctx)
+			roles := make(map[string]string, len(orgIDs))
+			for _, orgID := range orgIDs {
+				// Une erreur signifie que l'utilisateur n'est pas membre
+				roles[orgID], _ = h.users.GetUserRole(ctx, userID, orgID)
+			}
+			return roles, nil
+		}),
+		users: graphql.NewLoader(func(ctx context.Context, ids []string) (map[string]*models.User, error) {
+			users, err := h.users.GetUsersByIDs(ctx, ids)
+			if err != nil {
+				return nil, err
+			}
+			byID := make(map[string]*models.User, len(users))
+			for _, user := range users {
+				byID[user.ID] = user
+			}
+			return byID, nil
+		}),
+		summaries: graphql.NewLoader(func(ctx context.Context, orgIDs []string) (map[string]map[string]*models.ProjectSummary, error) {
+			byOrg := make(map[string]map[string]*models.ProjectSummary, len(orgIDs))
+			for _, orgID := range orgIDs {
+				summaries, err := h.projects.ListProjectSummaries(ctx, orgID)
+				if err != nil {
+					return nil, err
+				}
+				byOrg[orgID] = make(map[string]*models.ProjectSummary, len(summaries))
+				for _, summary := range summaries {
+					byOrg[orgID][summary.ID] = summary
+				}
+			}
+			return byOrg, nil
+		}),
+	}
+}
+
+func loadersFromContext(ctx context.Context) *graphQLLoaders {
+	return ctx.Value(graphQLLoadersKey{}).(*graphQLLoaders)
+}
+
+// role renvoie le rôle de l'utilisateur connecté dans l'organisation
+func (l *graphQLLoaders) role(ctx context.Context, orgID string) (string, error) {
+	roles, err := l.roles.LoadMany(ctx, []string{orgID})
+	if err != nil {
+		return "", err
+	}
+	return roles[0], nil
+}
+
+// requireMember autorise un champ aux membres de l'organisation
+func requireMember(ctx context.Context, orgID string) error {
+	role, err := loadersFromContext(ctx).role(ctx, orgID)
+	if err != nil || role == "" {
+		return errGraphQLHidden
+	}
+	return nil
+}
+
+// requireAdmin autorise un champ aux administrateurs de l'organisation
+func requireAdmin(ctx context.Context, orgID string) error {
+	role, err := loadersFromContext(ctx).role(ctx, orgID)
+	if err != nil || role == "" {
+		return errGraphQLHidden
+	}
+	if role != "admin" {
+		return errGraphQLAdminOnly
+	}
+	return nil
+}
+
+// internalError journalise une erreur de stockage et renvoie un message
+// générique, pour ne pas exposer de détails internes aux clients
+func internalError(msg string, err error) error {
+	logging.For(logging.ComponentHTTP).Error("résolution GraphQL échouée", "error", err)
+	return errors.New(msg)
+}
+
+// property crée un champ lu sur un objet parent de type *T
+func property[T any](t graphql.Type, get func(*T) any) *graphql.Field {
+	return &graphql.Field{
+		Type: t,
+		Resolve: func(ctx context.Context, parent any, args graphql.Args) (any, error) {
+			return get(parent.(*T)), nil
+		},
+	}
+}
+
+// userField crée un champ User résolu par lot à partir de l'ID renvoyé par id
+func userField[T any](id func(*T) string) *graphql.Field {
+	return &graphql.Field{
+		Type: userType,
+		Batch: func(ctx context.Context, parents []any, args graphql.Args) ([]any, error) {
+			ids := make([]string, len(parents))
+			for i, parent := range parents {
+				ids[i] = id(parent.(*T))
+			}
+			users, err := loadersFromContext(ctx).users.LoadMany(ctx, ids)
+			if err != nil {
+				return nil, internalError("Impossible de récupérer les utilisateurs", err)
+			}
+			values := make([]any, len(users))
+			for i, user := range users {
+				values[i] = user
+			}
+			return values, nil
+		},
+	}
+}
+
+// organizationNode est une organisation visible par l'utilisateur connecté
+type organizationNode struct {
+	*models.Organization
+}
+
+// environmentNode est un environnement contenant au moins un secret
+type environmentNode struct {
+	project *models.Project
+	name    string
+}
+
+var userType = &graphql.Object{
+	Name: "User",
+	Fields: graphql.Fields{
+		"id":        property(graphql.ID, func(u *models.User) any { return u.ID }),
+		"email":     property(graphql.String, func(u *models.User) any { return u.Email }),
+		"firstName": property(graphql.String, func(u *models.User) any { return u.FirstName }),
+		"lastName":  property(graphql.String, func(u *models.User) any { return u.LastName }),
+	},
+}
+
+var memberType = &graphql.Object{
+	Name: "Member",
+	Fields: graphql.Fields{
+		"userId":    property(graphql.ID, func(m *models.OrganizationMember) any { return m.UserID }),
+		"email":     property(graphql.String, func(m *models.OrganizationMember) any { return m.Email }),
+		"firstName": property(graphql.String, func(m *models.OrganizationMember) any { return m.FirstName }),
+		"lastName":  property(graphql.String, func(m *models.OrganizationMember) any { return m.LastName }),
+		"role":      property(graphql.String, func(m *models.OrganizationMember) any { return m.Role }),
+		"joinedAt":  property(graphql.DateTime, func(m *models.OrganizationMember) any { return m.JoinedAt }),
+	},
+}
+
+var usageCountType = &graphql.Object{
+	Name: "UsageCount",
+	Fields: graphql.Fields{
+		"key":   property(graphql.String, func(c *models.UsageCount) any { return c.Key }),
+		"calls": property(graphql.Int, func(c *models.UsageCount) any { return c.Calls }),
+	},
+}
+
+var usageBreakdownType = &graphql.Object{
+	Name: "UsageBreakdown",
+	Fields: graphql.Fields{
+		"from":        property(graphql.DateTime, func(b *models.UsageBreakdown) any { return b.From }),
+		"to":          property(graphql.DateTime, func(b *models.UsageBreakdown) any { return b.To }),
+		"total":       property(graphql.Int, func(b *models.UsageBreakdown) any { return b.Total }),
+		"byPrincipal": property(graphql.ListOf(usageCountType), func(b *models.UsageBreakdown) any { return b.ByPrincipal }),
+		"byRoute":     property(graphql.ListOf(usageCountType), func(b *models.UsageBreakdown) any { return b.ByRoute }),
+		"byDay":       property(graphql.ListOf(usageCountType), func(b *models.UsageBreakdown) any { return b.ByDay }),
+	},
+}
+
+var auditEventType = &graphql.Object{
+	Name: "AuditEvent",
+	Fields: graphql.Fields{
+		"id":         property(graphql.ID, func(e *models.NotificationEvent) any { return e.ID }),
+		"type":       property(graphql.String, func(e *models.NotificationEvent) any { return e.Type }),
+		"actor":      userField(func(e *models.NotificationEvent) string { return e.ActorID }),
+		"subject":    property(graphql.String, func(e *models.NotificationEvent) any { return e.Subject }),
+		"message":    property(graphql.String, func(e *models.NotificationEvent) any { return e.Message }),
+		"occurredAt": property(graphql.DateTime, func(e *models.NotificationEvent) any { return e.OccurredAt }),
+	},
+}
+
+var secretMetadataType = &graphql.Object{
+	Name: "SecretMetadata",
+	Fields: graphql.Fields{
+		"id":          property(graphql.ID, func(s *models.SecretMetadata) any { return s.ID }),
+		"name":        property(graphql.String, func(s *models.SecretMetadata) any { return s.Name }),
+		"description": property(graphql.String, func(s *models.SecretMetadata) any { return s.Description }),
+		"environment": property(graphql.String, func(s *models.SecretMetadata) any { return s.Environment }),
+		"version":     property(graphql.Int, func(s *models.SecretMetadata) any { return s.Version }),
+		"e2e":         property(graphql.Boolean, func(s *models.SecretMetadata) any { return s.E2E }),
+		"checksum":    property(graphql.String, func(s *models.SecretMetadata) any { return s.Checksum }),
+		"lockedAt":    property(graphql.DateTime, func(s *models.SecretMetadata) any { return s.LockedAt }),
+		"lockReason":  property(graphql.String, func(s *models.SecretMetadata) any { return s.LockReason }),
+		"createdAt":   property(graphql.DateTime, func(s *models.SecretMetadata) any { return s.CreatedAt }),
+		"updatedAt":   property(graphql.DateTime, func(s *models.SecretMetadata) any { return s.UpdatedAt }),
+		"createdBy":   userField(func(s *models.SecretMetadata) string { return s.CreatedBy }),
+	},
+}
+
+// queryType construit le type racine ; les types qui lisent les
+// repositories sont construits ici
+func (h *GraphQLHandler) queryType() *graphql.Object {
+	environmentType := &graphql.Object{
+		Name: "Environment",
+		Fields: graphql.Fields{
+			"name": property(graphql.String, func(e *environmentNode) any { return e.name }),
+			"secrets": {
+				Type: graphql.ListOf(secretMetadataType),
+				Authorize: func(ctx context.Context, parent any) error {
+					return requireMember(ctx, parent.(*environmentNode).project.OrganizationID)
+				},
+				Batch: func(ctx context.Context, parents []any, args graphql.Args) ([]any, error) {
+					projects := make([]*models.Project, len(parents))
+					for i, parent := range parents {
+						projects[i] = parent.(*environmentNode).project
+					}
+					secrets, err := h.secretsByProject(ctx, projects, "")
+					if err != nil {
+						return nil, err
+					}
+					values := make([]any, len(parents))
+					for i, parent := range parents {
+						env := parent.(*environmentNode)
+						matching := []*models.SecretMetadata{}
+						for _, secret := range secrets[env.project.ID] {
+							if secret.Environment == env.name {
+								matching = append(matching, secret)
+							}
+						}
+						values[i] = matching
+					}
+					return values, nil
+				},
+			},
+		},
+	}
+
+	projectType := &graphql.Object{
+		Name: "Project",
+		Fields: graphql.Fields{
+			"id":          property(graphql.ID, func(p *models.Project) any { return p.ID }),
+			"name":        property(graphql.String, func(p *models.Project) any { return p.Name }),
+			"description": property(graphql.String, func(p *models.Project) any { return p.Description }),
+			"createdAt":   property(graphql.DateTime, func(p *models.Project) any { return p.CreatedAt }),
+			"createdBy":   userField(func(p *models.Project) string { return p.CreatedBy }),
+			"environments": {
+				Type: graphql.ListOf(environmentType),
+				Batch: func(ctx context.Context, parents []any, args graphql.Args) ([]any, error) {
+					orgIDs := make([]string, len(parents))
+					for i, parent := range parents {
+						orgIDs[i] = parent.(*models.Project).OrganizationID
+					}
+					summaries, err := loadersFromContext(ctx).summaries.LoadMany(ctx, orgIDs)
+					if err != nil {
+						return nil, internalError("Impossible de lister les environnements", err)
+					}
+					values := make([]any, len(parents))
+					for i, parent := range parents {
+						project := parent.(*models.Project)
+						environments := []*environmentNode{}
+						if summary := summaries[i][project.ID]; summary != nil {
+							for _, name := range summary.Environments {
+								if resourcesCover(ctx, project.ID, name) {
+									environments = append(environments, &environmentNode{project: project, name: name})
+								}
+							}
+						}
+						values[i] = environments
+					}
+					return values, nil
+				},
+			},
+			"secrets": {
+				Type: graphql.ListOf(secretMetadataType),
+				Args: map[string]*graphql.Argument{
+					"environment": {Type: graphql.String, Required: true},
+				},
+				Authorize: func(ctx context.Context, parent any) error {
+					return requireMember(ctx, parent.(*models.Project).OrganizationID)
+				},
+				Batch: func(ctx context.Context, parents []any, args graphql.Args) ([]any, error) {
+					projects := make([]*models.Project, len(parents))
+					for i, parent := range parents {
+						projects[i] = parent.(*models.Project)
+					}
+					secrets, err := h.secretsByProject(ctx, projects, args.String("environment"))
+					if err != nil {
+						return nil, err
+					}
+					values := make([]any, len(parents))
+					for i, project := range projects {
+						values[i] = secrets[project.ID]
+					}
+					return values, nil
+				},
+			},
+		},
+	}
+
+	organizationType := &graphql.Object{
+		Name: "Organization",
+		Fields: graphql.Fields{
+			"id":          property(graphql.ID, func(o *organizationNode) any { return o.ID }),
+			"name":        property(graphql.String, func(o *organizationNode) any { return o.Name }),
+			"description": property(graphql.String, func(o *organizationNode) any { return o.Description }),
+			"planId":      property(graphql.String, func(o *organizationNode) any { return o.PlanID }),
+			"createdAt":   property(graphql.DateTime, func(o *organizationNode) any { return o.CreatedAt }),
+			"role": {
+				Type: graphql.String,
+				Resolve: func(ctx context.Context, parent any, args graphql.Args) (any, error) {
+					return loadersFromContext(ctx).role(ctx, parent.(*organizationNode).ID)
+				},
+			},
+			"members": {
+				Type: graphql.ListOf(memberType),
+				Resolve: func(ctx context.Context, parent any, args graphql.Args) (any, error) {
+					members, err := h.organizations.ListOrganizationMembers(ctx, parent.(*organizationNode).ID)
+					if err != nil {
+						return nil, internalError("Impossible de lister les membres", err)
+					}
+					return members, nil
+				},
+			},
+			"projects": {
+				Type: graphql.ListOf(projectType),
+				Resolve: func(ctx context.Context, parent any, args graphql.Args) (any, error) {
+					projects, err := h.projects.ListOrganizationProjects(ctx, parent.(*organizationNode).ID)
+					if err != nil {
+						return nil, internalError("Impossible de lister les projets", err)
+					}
+					return slices.DeleteFunc(projects, func(project *models.Project) bool {
+						return !resourcesCover(ctx, project.ID, "")
+					}), nil
+				},
+			},
+			"usage": {
+				Type: usageBreakdownType,
+				Args: map[string]*graphql.Argument{
+					// Dates au format AAAA-MM-JJ (30 derniers jours par défaut)
+					"from": {Type: graphql.String},
+					"to":   {Type: graphql.String},
+				},
+				Authorize: func(ctx context.Context, parent any) error {
+					return requireAdmin(ctx, parent.(*organizationNode).ID)
+				},
+				Resolve: func(ctx context.Context, parent any, args graphql.Args) (any, error) {
+					to := time.Now().UTC()
+					from := to.Add(-defaultUsagePeriod)
+					var err error
+					if v := args.String("from"); v != "" {
+						if from, err = time.Parse("2006-01-02", v); err != nil {
+							return nil, errors.New("Paramètre from invalide")
+						}
+					}
+					if v := args.String("to"); v != "" {
+						if to, err = time.Parse("2006-01-02", v); err != nil {
+							return nil, errors.New("Paramètre to invalide")
+						}
+					}
+					if to.Before(from) {
+						return nil, errors.New("La période demandée est invalide")
+					}
+					breakdown, err := h.usage.GetUsageBreakdown(ctx, parent.(*organizationNode).ID, from, to)
+					if err != nil {
+						return nil, internalError("Impossible de récupérer l'usage", err)
+					}
+					return breakdown, nil
+				},
+			},
+			"audit": {
+				Type: graphql.ListOf(auditEventType),
+				Args: map[string]*graphql.Argument{
+					"days": {Type: graphql.Int, Default: 7},
+				},
+				Authorize: func(ctx context.Context, parent any) error {
+					return requireAdmin(ctx, parent.(*organizationNode).ID)
+				},
+				Resolve: func(ctx context.Context, parent any, args graphql.Args) (any, error) {
+					days := args.Int("days", 7)
+					if days < 1 || days > maxGraphQLAuditDays {
+						return nil, errors.New("days doit être compris entre 1 et 90")
+					}
+					until := time.Now()
+					events, err := h.events.ListNotificationEvents(ctx, parent.(*organizationNode).ID,
+						until.AddDate(0, 0, -days), until)
+					if err != nil {
+						return nil, internalError("Impossible de lister les événements", err)
+					}
+					return events, nil
+				},
+			},
+		},
+	}
+
+	return &graphql.Object{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"me": {
+				Type: userType,
+				Resolve: func(ctx context.Context, parent any, args graphql.Args) (any, error) {
+					user, err := h.users.GetUserByID(ctx, middleware.UserIDFromContext(ctx))
+					if err != nil {
+						return nil, internalError("Impossible de récupérer l'utilisateur", err)
+					}
+					return user, nil
+				},
+			},
+			"organizations": {
+				Type: graphql.ListOf(organizationType),
+				Resolve: func(ctx context.Context, parent any, args graphql.Args) (any, error) {
+					orgs, err := h.organizations.ListUserOrganizations(ctx, middleware.UserIDFromContext(ctx))
+					if err != nil {
+						return nil, internalError("Impossible de lister les organisations", err)
+					}
+					nodes := make([]*organizationNode, len(orgs))
+					for i, org := range orgs {
+						nodes[i] = &organizationNode{org}
+					}
+					return nodes, nil
+				},
+			},
+			"organization": {
+				Type: organizationType,
+				Args: map[string]*graphql.Argument{
+					"id": {Type: graphql.ID, Required: true},
+				},
+				Resolve: func(ctx context.Context, parent any, args graphql.Args) (any, error) {
+					orgID := args.String("id")
+					// Une organisation dont l'utilisateur n'est pas membre n'existe pas pour lui
+					if err := requireMember(ctx, orgID); err != nil {
+						return nil, errors.New("Organisation non trouvée")
+					}
+					org, err := h.organizations.GetOrganizationByID(ctx, orgID)
+					if err != nil {
+						return nil, internalError("Impossible de récupérer l'organisation", err)
+					}
+					return &organizationNode{org}, nil
+				},
+			},
+		},
+	}
+}
+
+// secretsByProject charge les secrets de plusieurs projets avec une requête
+// par organisation, et les regroupe par projet. Les secrets hors des
+// ressources de l'appelant sont écartés.
+func (h *GraphQLHandler) secretsByProject(
+	ctx context.Context,
+	projects []*models.Project,
+	env string,
+) (map[string][]*models.SecretMetadata, error) {
+	byProject := make(map[string][]*models.SecretMetadata, len(projects))
+	projectIDs := make(map[string][]string)
+	var orgIDs []string
+	for _, project := range projects {
+		if _, ok := byProject[project.ID]; ok {
+			continue
+		}
+		byProject[project.ID] = []*models.SecretMetadata{}
+		if _, ok := projectIDs[project.OrganizationID]; !ok {
+			orgIDs = append(orgIDs, project.OrganizationID)
+		}
+		projectIDs[project.OrganizationID] = append(projectIDs[project.OrganizationID], project.ID)
+	}
+
+	for _, orgID := range orgIDs {
+		secrets, err := h.secrets.ListSecretsByProjects(ctx, orgID, projectIDs[orgID], env)
+		if err != nil {
+			return nil, internalError("Impossible de lister les secrets", err)
+		}
+		for _, secret := range secrets {
+			if resourcesCover(ctx, secret.ProjectID, secret.Environment) {
+				byProject[secret.ProjectID] = append(byProject[secret.ProjectID], secret)
+			}
+		}
+	}
+	return byProject, nil
+}
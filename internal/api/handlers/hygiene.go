@@ -0,0 +1,86 @@
+// filepath: internal/api/handlers/hygiene.go
+
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"secrets-manager/internal/api/apierror"
+	"secrets-manager/internal/api/middleware"
+	"secrets-manager/internal/hygiene"
+	"secrets-manager/internal/models"
+)
+
+// assessHygiene évalue l'hygiène des secrets d'après les secrets de
+// l'organisation de même empreinte, qui révèlent les valeurs réutilisées
+// dans d'autres environnements ou projets
+func (h *SecretsHandler) assessHygiene(r *http.Request, orgID string, metadata ...*models.SecretMetadata) error {
+	duplicates, err := h.secrets.ListSecretsByValueHashes(r.Context(), orgID, hygiene.ValueHashes(metadata))
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	for _, m := range metadata {
+		m.Hygiene = hygiene.Assess(m, duplicates, now)
+	}
+	return nil
+}
+
+// GetHygieneReport renvoie le rapport d'hygiène des secrets du projet, tous
+// environnements confondus : score moyen, défauts relevés et secrets du
+// moins bon score au meilleur
+func (h *SecretsHandler) GetHygieneReport(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	orgID := vars["orgID"]
+	projectID := vars["projectID"]
+
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if err := h.policy.check(r.Context(), userID, orgID, projectID, secretRead); err != nil {
+		writePolicyError(w, err)
+		return
+	}
+
+	secrets, err := h.secrets.ListSecretsByProjects(r.Context(), orgID, []string{projectID}, "")
+	if err != nil {
+		apierror.Write(w, err, "Impossible de lister les secrets")
+		return
+	}
+	duplicates, err := h.secrets.ListSecretsByValueHashes(r.Context(), orgID, hygiene.ValueHashes(secrets))
+	if err != nil {
+		apierror.Write(w, err, "Impossible de rechercher les valeurs réutilisées")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(hygiene.Report(projectID, secrets, duplicates, time.Now())); err != nil {
+		http.Error(w, "Erreur lors de l'encodage du rapport", http.StatusInternalServerError)
+	}
+}
+
+// GetDuplicatesReport liste les valeurs partagées par plusieurs secrets de
+// l'organisation, entre environnements comme entre projets. Réservé aux
+// administrateurs : le rapport couvre tous les projets.
+func (h *SecretsHandler) GetDuplicatesReport(w http.ResponseWriter, r *http.Request) {
+	orgID := mux.Vars(r)["orgID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if !requireOrgAdmin(w, r, h.policy.users, orgID, userID) {
+		return
+	}
+
+	secrets, err := h.secrets.ListDuplicateSecretValues(r.Context(), orgID)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de rechercher les valeurs réutilisées")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(hygiene.Duplicates(orgID, secrets)); err != nil {
+		http.Error(w, "Erreur lors de l'encodage du rapport", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,195 @@
+// filepath: internal/api/handlers/impersonations.go
+
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"secrets-manager/internal/api/apierror"
+	"secrets-manager/internal/api/middleware"
+	"secrets-manager/internal/auth"
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// ImpersonationsHandler gère les usurpations d'identité par le support :
+// émission et fin sur le listener d'administration, consultation et fin par
+// l'utilisateur concerné sur l'API
+type ImpersonationsHandler struct {
+	authService    *auth.Service
+	impersonations storage.ImpersonationsRepository
+	audit          storage.AdminAuditRepository
+}
+
+// NewImpersonationsHandler crée un nouveau gestionnaire d'usurpations d'identité
+func NewImpersonationsHandler(authService *auth.Service, impersonations storage.ImpersonationsRepository,
+	audit storage.AdminAuditRepository) *ImpersonationsHandler {
+	return &ImpersonationsHandler{
+		authService:    authService,
+		impersonations: impersonations,
+		audit:          audit,
+	}
+}
+
+// ImpersonationRequest représente une demande d'usurpation d'identité
+type ImpersonationRequest struct {
+	UserID string `json:"user_id"`
+	// Reason est le motif, visible par l'utilisateur (ticket de support...)
+	Reason string `json:"reason"`
+	// ExpiresInMinutes est la durée de validité du token (15 par défaut, 60 au plus)
+	ExpiresInMinutes int `json:"expires_in_minutes"`
+}
+
+// OperatorTokenHeader porte le token d'accès de l'opérateur d'une
+// usurpation, Authorization portant le token du listener d'administration
+const OperatorTokenHeader = "X-Operator-Token"
+
+// StartImpersonation émet un token agissant au nom d'un utilisateur
+// (claim act_as). L'opérateur s'authentifie par son propre token d'accès
+// (OperatorTokenHeader) et doit être administrateur de la plateforme : 401
+// sans token valide, 403 sans le rôle. L'opérateur (son email) et le motif,
+// obligatoire, sont montrés à l'utilisateur avec les requêtes faites en son
+// nom.
+func (h *ImpersonationsHandler) StartImpersonation(w http.ResponseWriter, r *http.Request) {
+	operator, ok := h.authenticateOperator(w, r)
+	if !ok {
+		return
+	}
+
+	var request ImpersonationRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "Données invalides", http.StatusBadRequest)
+		return
+	}
+	request.Reason = strings.TrimSpace(request.Reason)
+	if request.UserID == "" {
+		apierror.Write(w, apierror.Validation("Utilisateur requis"), "")
+		return
+	}
+	if request.UserID == operator.ID {
+		apierror.Write(w, apierror.Validation("Un opérateur ne peut pas usurper sa propre identité"), "")
+		return
+	}
+	if request.Reason == "" || len(request.Reason) > 500 {
+		apierror.Write(w, apierror.Validation("Motif requis (500 caractères au plus)"), "")
+		return
+	}
+	maxMinutes := int(auth.MaxImpersonationDuration / time.Minute)
+	if request.ExpiresInMinutes < 0 || request.ExpiresInMinutes > maxMinutes {
+		apierror.Write(w, apierror.Validation("Durée de validité invalide (1 à 60 minutes)"), "")
+		return
+	}
+
+	principal := "anonymous"
+	if p, ok := middleware.PrincipalFromContext(r.Context()); ok {
+		principal = p.String()
+	}
+	impersonation := &models.Impersonation{
+		UserID:    request.UserID,
+		Operator:  operator.Email,
+		Principal: principal,
+		Reason:    request.Reason,
+	}
+	token, err := h.authService.Impersonate(r.Context(), impersonation,
+		time.Duration(request.ExpiresInMinutes)*time.Minute)
+	if errors.Is(err, auth.ErrUserNotFound) {
+		http.Error(w, "Utilisateur non trouvé", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		apierror.Write(w, err, "Impossible d'usurper l'identité de l'utilisateur")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(token)
+}
+
+// authenticateOperator authentifie l'opérateur d'une usurpation et écrit
+// la réponse d'erreur sinon
+func (h *ImpersonationsHandler) authenticateOperator(w http.ResponseWriter, r *http.Request) (*models.User, bool) {
+	token := r.Header.Get(OperatorTokenHeader)
+	if token == "" {
+		http.Error(w, "Token de l'opérateur requis", http.StatusUnauthorized)
+		return nil, false
+	}
+	operator, err := h.authService.AuthenticateOperator(r.Context(), token)
+	switch {
+	case err == nil:
+		return operator, true
+	case errors.Is(err, auth.ErrNotPlatformAdmin):
+		http.Error(w, "Réservé aux administrateurs de la plateforme", http.StatusForbidden)
+	case errors.Is(err, auth.ErrInvalidToken), errors.Is(err, auth.ErrTokenExpired),
+		errors.Is(err, auth.ErrTokenRevoked), errors.Is(err, auth.ErrMFARequired):
+		http.Error(w, "Token de l'opérateur invalide", http.StatusUnauthorized)
+	default:
+		apierror.Write(w, err, "Impossible d'authentifier l'opérateur")
+	}
+	return nil, false
+}
+
+// EndImpersonation termine une usurpation avant son expiration
+func (h *ImpersonationsHandler) EndImpersonation(w http.ResponseWriter, r *http.Request) {
+	if err := h.authService.EndImpersonation(r.Context(), mux.Vars(r)["impersonationID"], ""); err != nil {
+		apierror.Write(w, err, "Impossible de terminer l'usurpation")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListMyImpersonations liste les usurpations de l'identité de l'utilisateur
+// connecté, de la plus récente à la plus ancienne
+func (h *ImpersonationsHandler) ListMyImpersonations(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserIDFromContext(r.Context())
+	impersonations, err := h.impersonations.ListUserImpersonations(r.Context(), userID)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de lister les usurpations")
+		return
+	}
+	writeJSONList(w, r, impersonations)
+}
+
+// ListMyImpersonationRequests liste les requêtes faites par le support au
+// nom de l'utilisateur connecté pendant une usurpation.
+// Paramètres optionnels : limit, offset.
+func (h *ImpersonationsHandler) ListMyImpersonationRequests(w http.ResponseWriter, r *http.Request) {
+	impersonation, err := h.impersonations.GetImpersonation(r.Context(), mux.Vars(r)["impersonationID"])
+	if err == nil && impersonation.UserID != middleware.UserIDFromContext(r.Context()) {
+		err = storage.ErrImpersonationNotFound
+	}
+	if err != nil {
+		apierror.Write(w, err, "Impossible de lire l'usurpation")
+		return
+	}
+
+	limit, offset, ok := parsePagination(w, r)
+	if !ok {
+		return
+	}
+	entries, err := h.audit.ListImpersonationAuditLogs(r.Context(), impersonation.ID, limit, offset)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de lister les requêtes de l'usurpation")
+		return
+	}
+	writeJSONList(w, r, entries)
+}
+
+// EndMyImpersonation permet à l'utilisateur connecté de terminer une
+// usurpation de son identité
+func (h *ImpersonationsHandler) EndMyImpersonation(w http.ResponseWriter, r *http.Request) {
+	err := h.authService.EndImpersonation(r.Context(), mux.Vars(r)["impersonationID"],
+		middleware.UserIDFromContext(r.Context()))
+	if err != nil {
+		apierror.Write(w, err, "Impossible de terminer l'usurpation")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
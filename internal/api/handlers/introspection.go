@@ -0,0 +1,91 @@
+// filepath: internal/api/handlers/introspection.go
+
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"secrets-manager/internal/api/apierror"
+	"secrets-manager/internal/api/middleware"
+	"secrets-manager/internal/auth"
+	"secrets-manager/internal/storage"
+)
+
+// IntrospectionResponse décrit un token (RFC 7662, section 2.2). Seul Active
+// est renseigné pour un token invalide, expiré ou révoqué.
+type IntrospectionResponse struct {
+	Active    bool   `json:"active"`
+	Scope     string `json:"scope,omitempty"`
+	TokenType string `json:"token_type,omitempty"`
+	Sub       string `json:"sub,omitempty"`
+	Exp       int64  `json:"exp,omitempty"`
+	Iat       int64  `json:"iat,omitempty"`
+	Iss       string `json:"iss,omitempty"`
+	Aud       string `json:"aud,omitempty"`
+}
+
+// IntrospectionHandler permet aux services internes (sidecars) de vérifier
+// un token sans partager la clé de signature des JWT
+type IntrospectionHandler struct {
+	authService *auth.Service
+	tokens      storage.PersonalAccessTokensRepository
+}
+
+// NewIntrospectionHandler crée un nouveau gestionnaire d'introspection
+func NewIntrospectionHandler(authService *auth.Service, tokens storage.PersonalAccessTokensRepository) *IntrospectionHandler {
+	return &IntrospectionHandler{
+		authService: authService,
+		tokens:      tokens,
+	}
+}
+
+// Introspect décrit le token envoyé dans le formulaire (paramètre token).
+// Les tokens d'accès JWT et les tokens d'accès personnels sont acceptés ; le
+// paramètre token_type_hint est ignoré.
+func (h *IntrospectionHandler) Introspect(w http.ResponseWriter, r *http.Request) {
+	token := r.PostFormValue("token")
+	if token == "" {
+		writeOAuthError(w, "invalid_request", "token requis")
+		return
+	}
+
+	var response IntrospectionResponse
+	if auth.IsPersonalAccessToken(token) {
+		pat, err := middleware.VerifyPersonalAccessToken(r.Context(), h.tokens, token)
+		if err != nil && !errors.Is(err, storage.ErrTokenNotFound) && !errors.Is(err, middleware.ErrTokenExpired) {
+			apierror.Write(w, err, "Impossible de vérifier le token")
+			return
+		}
+		if err == nil {
+			response = IntrospectionResponse{
+				Active: true,
+				Scope:  strings.Join(pat.Scopes, " "),
+				Sub:    pat.UserID,
+				Exp:    pat.ExpiresAt.Unix(),
+				Iat:    pat.CreatedAt.Unix(),
+			}
+		}
+	} else if claims, err := h.authService.VerifyAccessToken(token); err == nil &&
+		h.authService.CheckSession(r.Context(), claims) == nil {
+		issuer := h.authService.Issuer()
+		response = IntrospectionResponse{
+			Active: true,
+			Scope:  strings.Join(claims.Scopes, " "),
+			Sub:    claims.UserID,
+			Exp:    claims.ExpiresAt.Unix(),
+			Iat:    claims.IssuedAt.Unix(),
+			Iss:    issuer.Name,
+			Aud:    issuer.Audience,
+		}
+	}
+	if response.Active {
+		response.TokenType = "Bearer"
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,343 @@
+// filepath: internal/api/handlers/invitations.go
+
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"secrets-manager/internal/api/apierror"
+	"secrets-manager/internal/api/middleware"
+	"secrets-manager/internal/auth"
+	"secrets-manager/internal/events"
+	"secrets-manager/internal/logging"
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/notifications"
+	"secrets-manager/internal/storage"
+)
+
+// InvitationsHandler gère les invitations à rejoindre une organisation.
+// Les administrateurs invitent une adresse email avec un rôle ; l'invité
+// reçoit un token par email et l'échange, sans session, contre son
+// appartenance à l'organisation.
+type InvitationsHandler struct {
+	invitations   storage.InvitationsRepository
+	organizations storage.OrganizationsRepository
+	users         storage.UsersRepository
+	authService   *auth.Service
+	mailer        notifications.Mailer
+	notifier      *notifications.Dispatcher
+	bus           *events.Bus
+	acceptURI     string
+	permissions   *auth.PermissionService
+}
+
+// NewInvitationsHandler crée un nouveau gestionnaire d'invitations.
+// acceptURI est la page du tableau de bord qui reçoit le token (paramètre
+// token) ; mailer nil désactive les invitations.
+func NewInvitationsHandler(
+	invitations storage.InvitationsRepository,
+	organizations storage.OrganizationsRepository,
+	users storage.UsersRepository,
+	authService *auth.Service,
+	mailer notifications.Mailer,
+	notifier *notifications.Dispatcher,
+	bus *events.Bus,
+	acceptURI string,
+	permissions *auth.PermissionService,
+) *InvitationsHandler {
+	return &InvitationsHandler{
+		invitations:   invitations,
+		organizations: organizations,
+		users:         users,
+		authService:   authService,
+		mailer:        mailer,
+		notifier:      notifier,
+		bus:           bus,
+		acceptURI:     acceptURI,
+		permissions:   permissions,
+	}
+}
+
+// InvitationRequest invite une adresse email
+type InvitationRequest struct {
+	Email string `json:"email"`
+	// Role est le rôle donné à l'acceptation (member par défaut)
+	Role string `json:"role"`
+}
+
+// InvitationAcceptance échange un token d'invitation. Le mot de passe et le
+// nom ne servent que si aucun compte n'existe pour l'adresse invitée.
+type InvitationAcceptance struct {
+	Token     string `json:"token"`
+	Password  string `json:"password"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+}
+
+// AcceptedInvitation décrit l'appartenance obtenue en acceptant une invitation
+type AcceptedInvitation struct {
+	OrganizationID string `json:"organization_id"`
+	UserID         string `json:"user_id"`
+	Role           string `json:"role"`
+	// AccountCreated est vrai si le compte a été créé à l'acceptation
+	AccountCreated bool `json:"account_created"`
+}
+
+// CreateInvitation invite une adresse email à rejoindre l'organisation et
+// lui envoie le token par email. Réservé aux membres ayant la permission
+// members.invite ; seuls les administrateurs invitent d'autres
+// administrateurs. 409 si l'adresse est déjà membre ou déjà invitée.
+func (h *InvitationsHandler) CreateInvitation(w http.ResponseWriter, r *http.Request) {
+	orgID := mux.Vars(r)["orgID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if !requirePermission(w, r, h.permissions, orgID, userID, models.PermissionMembersInvite) {
+		return
+	}
+
+	var req InvitationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Données invalides", http.StatusBadRequest)
+		return
+	}
+	req.Email = strings.TrimSpace(req.Email)
+	if req.Role == "" {
+		req.Role = auth.RoleMember
+	}
+	if !strings.Contains(req.Email, "@") {
+		apierror.Write(w, apierror.Validation("Adresse email invalide"), "")
+		return
+	}
+	if !memberRoles[req.Role] {
+		apierror.Write(w, apierror.Validation("role doit être admin, member ou viewer"), "")
+		return
+	}
+	if req.Role == auth.RoleAdmin {
+		if role, err := h.users.GetUserRole(r.Context(), userID, orgID); err != nil || role != auth.RoleAdmin {
+			http.Error(w, "Seuls les administrateurs invitent des administrateurs", http.StatusForbidden)
+			return
+		}
+	}
+	if h.mailer == nil {
+		http.Error(w, "L'envoi d'emails n'est pas configuré", http.StatusServiceUnavailable)
+		return
+	}
+
+	if member, err := h.isMember(r.Context(), req.Email, orgID); err != nil {
+		apierror.Write(w, err, "Impossible de vérifier les membres")
+		return
+	} else if member {
+		http.Error(w, "Cette adresse est déjà membre de l'organisation", http.StatusConflict)
+		return
+	}
+
+	org, err := h.organizations.GetOrganizationByID(r.Context(), orgID)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de récupérer l'organisation")
+		return
+	}
+	inviter, err := h.users.GetUserByID(r.Context(), userID)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de récupérer l'utilisateur")
+		return
+	}
+
+	token, hash, err := auth.NewInvitationToken()
+	if err != nil {
+		http.Error(w, "Impossible de générer l'invitation", http.StatusInternalServerError)
+		return
+	}
+	invitation := &models.Invitation{
+		OrganizationID: orgID,
+		Email:          req.Email,
+		Role:           req.Role,
+		TokenHash:      hash,
+		InvitedBy:      userID,
+		ExpiresAt:      time.Now().Add(models.DefaultInvitationValidity),
+	}
+	if err := h.invitations.CreateInvitation(r.Context(), invitation); err != nil {
+		// storage.ErrInvitationPending donne 409
+		apierror.Write(w, err, "Impossible de créer l'invitation")
+		return
+	}
+
+	subject, body := notifications.InvitationMail(org.Name, inviter.Email, invitation, h.acceptLink(token))
+	if err := h.mailer.SendMail(r.Context(), invitation.Email, subject, body); err != nil {
+		logging.For(logging.ComponentHTTP).Error("envoi d'une invitation impossible",
+			"organization", orgID, "invitation", invitation.ID, "error", err)
+		// Le token n'a été remis à personne : l'invitation est inutilisable
+		if err := h.invitations.RevokeInvitation(context.WithoutCancel(r.Context()), orgID, invitation.ID,
+			time.Now()); err != nil {
+			logging.For(logging.ComponentHTTP).Error("révocation d'une invitation non envoyée impossible",
+				"organization", orgID, "invitation", invitation.ID, "error", err)
+		}
+		http.Error(w, "Impossible d'envoyer l'invitation", http.StatusBadGateway)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(invitation)
+}
+
+// isMember indique si un compte existe pour l'adresse et appartient déjà à l'organisation
+func (h *InvitationsHandler) isMember(ctx context.Context, email, orgID string) (bool, error) {
+	user, err := h.users.GetUserByEmail(ctx, email)
+	if errors.Is(err, storage.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	role, err := h.memberRole(ctx, user.ID, orgID)
+	return role != "", err
+}
+
+// memberRole renvoie le rôle de l'utilisateur dans l'organisation, vide
+// s'il n'en est pas membre
+func (h *InvitationsHandler) memberRole(ctx context.Context, userID, orgID string) (string, error) {
+	role, err := h.users.GetUserRole(ctx, userID, orgID)
+	if errors.Is(err, storage.ErrNotFound) {
+		return "", nil
+	}
+	return role, err
+}
+
+// acceptLink renvoie le lien de la page d'acceptation portant le token
+func (h *InvitationsHandler) acceptLink(token string) string {
+	separator := "?"
+	if strings.Contains(h.acceptURI, "?") {
+		separator = "&"
+	}
+	return h.acceptURI + separator + "token=" + url.QueryEscape(token)
+}
+
+// ListInvitations liste les invitations en attente de l'organisation.
+// Réservé aux administrateurs.
+func (h *InvitationsHandler) ListInvitations(w http.ResponseWriter, r *http.Request) {
+	orgID := mux.Vars(r)["orgID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if !requireOrgAdmin(w, r, h.users, orgID, userID) {
+		return
+	}
+
+	invitations, err := h.invitations.ListPendingInvitations(r.Context(), orgID, time.Now())
+	if err != nil {
+		apierror.Write(w, err, "Impossible de lister les invitations")
+		return
+	}
+
+	writeJSONList(w, r, invitations)
+}
+
+// RevokeInvitation révoque une invitation en attente : son token n'est plus
+// accepté. Réservé aux administrateurs.
+func (h *InvitationsHandler) RevokeInvitation(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	orgID := vars["orgID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if !requireOrgAdmin(w, r, h.users, orgID, userID) {
+		return
+	}
+
+	if err := h.invitations.RevokeInvitation(r.Context(), orgID, vars["invitationID"], time.Now()); err != nil {
+		apierror.Write(w, err, "Impossible de révoquer l'invitation")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// AcceptInvitation échange un token d'invitation contre l'appartenance à
+// l'organisation avec le rôle prévu. Le token prouve la possession de
+// l'adresse invitée : le compte existant de cette adresse est rattaché à
+// l'organisation, sinon un compte est créé avec le mot de passe fourni
+// (201 au lieu de 200). La route n'exige pas de session.
+func (h *InvitationsHandler) AcceptInvitation(w http.ResponseWriter, r *http.Request) {
+	var req InvitationAcceptance
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Données invalides", http.StatusBadRequest)
+		return
+	}
+	if !strings.HasPrefix(req.Token, auth.InvitationTokenPrefix) {
+		apierror.Write(w, apierror.Validation("Token d'invitation requis"), "")
+		return
+	}
+
+	ctx := r.Context()
+	now := time.Now()
+	invitation, err := h.invitations.GetInvitationByHash(ctx, auth.HashPersonalAccessToken(req.Token))
+	if err == nil && invitation.Status(now) != models.InvitationPending {
+		err = storage.ErrInvitationNotFound
+	}
+	if err != nil {
+		apierror.Write(w, err, "Impossible de récupérer l'invitation")
+		return
+	}
+
+	accepted := AcceptedInvitation{OrganizationID: invitation.OrganizationID, Role: invitation.Role}
+	user, err := h.users.GetUserByEmail(ctx, invitation.Email)
+	switch {
+	case err == nil:
+		accepted.UserID = user.ID
+	case errors.Is(err, storage.ErrNotFound):
+		if req.Password == "" {
+			apierror.Write(w, apierror.Validation("Mot de passe requis pour créer le compte"), "")
+			return
+		}
+		details, err := h.authService.RegisterUser(ctx, &auth.Credentials{
+			Email:    invitation.Email,
+			Password: req.Password,
+		}, req.FirstName, req.LastName, invitation.OrganizationID)
+		if err != nil {
+			apierror.Write(w, err, "Erreur d'inscription")
+			return
+		}
+		accepted.UserID, accepted.AccountCreated = details.ID, true
+	default:
+		apierror.Write(w, err, "Impossible de récupérer l'utilisateur")
+		return
+	}
+
+	// Le token ne sert qu'une fois, même si deux acceptations sont simultanées
+	if err := h.invitations.AcceptInvitation(ctx, invitation.ID, accepted.UserID, now); err != nil {
+		apierror.Write(w, err, "Impossible d'accepter l'invitation")
+		return
+	}
+
+	// Un membre ajouté depuis l'invitation garde son rôle actuel
+	role, err := h.memberRole(ctx, accepted.UserID, invitation.OrganizationID)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de vérifier les membres")
+		return
+	}
+	if role == "" {
+		err := h.users.AssignUserToOrganization(ctx, accepted.UserID, invitation.OrganizationID, invitation.Role)
+		if err != nil {
+			apierror.Write(w, err, "Impossible d'ajouter le membre")
+			return
+		}
+		h.bus.Publish(events.Change{OrganizationID: invitation.OrganizationID, Resource: events.ResourceOrganization})
+		h.notifier.Notify(notifications.MemberAdded(invitation.OrganizationID, accepted.UserID, invitation.Email,
+			invitation.Role))
+	} else {
+		accepted.Role = role
+	}
+
+	status := http.StatusOK
+	if accepted.AccountCreated {
+		status = http.StatusCreated
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(accepted)
+}
@@ -0,0 +1,261 @@
+// filepath: internal/api/handlers/lockdown.go
+
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"secrets-manager/internal/api/apierror"
+	"secrets-manager/internal/api/middleware"
+	"secrets-manager/internal/auth"
+	"secrets-manager/internal/logging"
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// LockdownHandler gère le confinement d'une organisation compromise
+// (« bouton panique »). Le déclenchement et la levée sont réservés aux
+// administrateurs et exigent un code TOTP frais.
+type LockdownHandler struct {
+	lockdowns     storage.LockdownsRepository
+	organizations storage.OrganizationsRepository
+	users         storage.UsersRepository
+	tokens        storage.PersonalAccessTokensRepository
+	apiKeys       storage.APIKeysRepository
+	accounts      storage.ServiceAccountsRepository
+	reads         storage.SecretReadsRepository
+	authService   *auth.Service
+}
+
+// NewLockdownHandler crée un nouveau gestionnaire de confinement
+func NewLockdownHandler(
+	lockdowns storage.LockdownsRepository,
+	organizations storage.OrganizationsRepository,
+	users storage.UsersRepository,
+	tokens storage.PersonalAccessTokensRepository,
+	apiKeys storage.APIKeysRepository,
+	accounts storage.ServiceAccountsRepository,
+	reads storage.SecretReadsRepository,
+	authService *auth.Service,
+) *LockdownHandler {
+	return &LockdownHandler{
+		lockdowns:     lockdowns,
+		organizations: organizations,
+		users:         users,
+		tokens:        tokens,
+		apiKeys:       apiKeys,
+		accounts:      accounts,
+		reads:         reads,
+		authService:   authService,
+	}
+}
+
+// LockdownRequest déclenche un confinement
+type LockdownRequest struct {
+	// Code est un code TOTP frais de l'administrateur
+	Code   string `json:"code"`
+	Reason string `json:"reason"`
+	// ReportDays est la période du rapport d'accès (30 jours par défaut)
+	ReportDays int `json:"report_days"`
+}
+
+// StartLockdown confine l'organisation : les écritures sont gelées, les
+// clés d'API et les comptes de service de l'organisation et les tokens
+// d'accès personnels des membres supprimés, leurs sessions révoquées
+// et un nouveau mot de passe exigé, y compris de l'administrateur qui
+// déclenche le confinement. La réponse est le rapport d'accès.
+func (h *LockdownHandler) StartLockdown(w http.ResponseWriter, r *http.Request) {
+	orgID := mux.Vars(r)["orgID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if !requireOrgAdmin(w, r, h.users, orgID, userID) {
+		return
+	}
+
+	var req LockdownRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Données invalides", http.StatusBadRequest)
+		return
+	}
+	if req.ReportDays == 0 {
+		req.ReportDays = models.DefaultLockdownReportDays
+	}
+	if req.ReportDays < 1 || req.ReportDays > models.MaxLockdownReportDays {
+		apierror.Write(w, apierror.Validation("report_days doit être compris entre 1 et 90"), "")
+		return
+	}
+	if err := h.authService.StepUp(r.Context(), userID, req.Code); err != nil {
+		apierror.Write(w, err, "Impossible de vérifier le code d'authentification")
+		return
+	}
+
+	// Le gel des écritures prend effet dès l'enregistrement du confinement
+	lockdown := &models.Lockdown{
+		OrganizationID: orgID,
+		Reason:         req.Reason,
+		ReportDays:     req.ReportDays,
+		StartedBy:      userID,
+	}
+	if err := h.lockdowns.CreateLockdown(r.Context(), lockdown); err != nil {
+		// storage.ErrLockdownActive donne 409
+		apierror.Write(w, err, "Impossible de confiner l'organisation")
+		return
+	}
+
+	// La révocation se poursuit même si le client abandonne la requête
+	ctx := context.WithoutCancel(r.Context())
+	if err := h.revokeMembers(ctx, lockdown); err != nil {
+		logging.For(logging.ComponentHTTP).Error("révocation des accès incomplète",
+			"organization", orgID, "lockdown", lockdown.ID, "error", err)
+		apierror.Write(w, err, "Organisation confinée, mais la révocation des accès est incomplète")
+		return
+	}
+	logging.For(logging.ComponentHTTP).Warn("organisation confinée", "organization", orgID,
+		"lockdown", lockdown.ID, "user_id", userID, "revoked_tokens", lockdown.RevokedTokens)
+
+	report, err := h.report(ctx, lockdown)
+	if err != nil {
+		apierror.Write(w, err, "Organisation confinée, mais le rapport d'accès n'a pas pu être produit")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		http.Error(w, "Erreur lors de l'encodage du rapport", http.StatusInternalServerError)
+	}
+}
+
+// revokeMembers supprime les clés d'API et les comptes de service de
+// l'organisation et les tokens d'accès personnels des membres, révoque leurs
+// sessions et leur impose un nouveau mot de passe
+func (h *LockdownHandler) revokeMembers(ctx context.Context, lockdown *models.Lockdown) error {
+	keys, err := h.apiKeys.ListAPIKeys(ctx, lockdown.OrganizationID)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if err := h.apiKeys.DeleteAPIKey(ctx, lockdown.OrganizationID, key.ID); err != nil {
+			return err
+		}
+		lockdown.RevokedTokens++
+	}
+
+	// Supprimer un compte de service révoque ses JWT et retire son
+	// identifiant SPIFFE : ses certificats clients ne sont plus acceptés
+	accounts, err := h.accounts.ListServiceAccounts(ctx, lockdown.OrganizationID)
+	if err != nil {
+		return err
+	}
+	for _, account := range accounts {
+		if err := h.accounts.DeleteServiceAccount(ctx, lockdown.OrganizationID, account.ID); err != nil {
+			return err
+		}
+		lockdown.RevokedTokens++
+	}
+
+	members, err := h.organizations.ListOrganizationMembers(ctx, lockdown.OrganizationID)
+	if err != nil {
+		return err
+	}
+
+	for _, member := range members {
+		tokens, err := h.tokens.ListPersonalAccessTokens(ctx, member.UserID)
+		if err != nil {
+			return err
+		}
+		for _, token := range tokens {
+			if err := h.tokens.DeletePersonalAccessToken(ctx, member.UserID, token.ID); err != nil {
+				return err
+			}
+			lockdown.RevokedTokens++
+		}
+		if err := h.users.ForcePasswordReset(ctx, member.UserID, lockdown.StartedAt); err != nil {
+			return err
+		}
+		lockdown.AffectedUsers++
+	}
+	return h.lockdowns.SetLockdownRevocations(ctx, lockdown.ID, lockdown.AffectedUsers, lockdown.RevokedTokens)
+}
+
+// report récapitule les membres et les lectures de secrets des ReportDays
+// jours précédant le confinement
+func (h *LockdownHandler) report(ctx context.Context, lockdown *models.Lockdown) (*models.LockdownReport, error) {
+	since := lockdown.StartedAt.AddDate(0, 0, -lockdown.ReportDays)
+	members, err := h.organizations.ListOrganizationMembers(ctx, lockdown.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	accesses, err := h.reads.ListOrganizationSecretAccess(ctx, lockdown.OrganizationID, since)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.LockdownReport{
+		Lockdown: lockdown,
+		Since:    since,
+		Until:    lockdown.StartedAt,
+		Members:  members,
+		Accesses: accesses,
+	}, nil
+}
+
+// GetLockdown renvoie le confinement en cours et son rapport d'accès
+// (404 si l'organisation n'est pas confinée)
+func (h *LockdownHandler) GetLockdown(w http.ResponseWriter, r *http.Request) {
+	orgID := mux.Vars(r)["orgID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if !requireOrgAdmin(w, r, h.users, orgID, userID) {
+		return
+	}
+
+	lockdown, err := h.lockdowns.GetActiveLockdown(r.Context(), orgID)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de récupérer le confinement")
+		return
+	}
+	report, err := h.report(r.Context(), lockdown)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de produire le rapport d'accès")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		http.Error(w, "Erreur lors de l'encodage du rapport", http.StatusInternalServerError)
+	}
+}
+
+// LiftLockdown lève le confinement : les écritures sont de nouveau
+// acceptées. Les membres gardent l'obligation de changer de mot de passe.
+func (h *LockdownHandler) LiftLockdown(w http.ResponseWriter, r *http.Request) {
+	orgID := mux.Vars(r)["orgID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if !requireOrgAdmin(w, r, h.users, orgID, userID) {
+		return
+	}
+
+	var req MFACode
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Données invalides", http.StatusBadRequest)
+		return
+	}
+	if err := h.authService.StepUp(r.Context(), userID, req.Code); err != nil {
+		apierror.Write(w, err, "Impossible de vérifier le code d'authentification")
+		return
+	}
+
+	if err := h.lockdowns.LiftLockdown(r.Context(), orgID, userID, time.Now()); err != nil {
+		apierror.Write(w, err, "Impossible de lever le confinement")
+		return
+	}
+	logging.For(logging.ComponentHTTP).Warn("confinement levé", "organization", orgID, "user_id", userID)
+	w.WriteHeader(http.StatusNoContent)
+}
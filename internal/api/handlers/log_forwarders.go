@@ -0,0 +1,252 @@
+// filepath: internal/api/handlers/log_forwarders.go
+
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	"secrets-manager/internal/api/apierror"
+	"secrets-manager/internal/api/middleware"
+	"secrets-manager/internal/logforward"
+	"secrets-manager/internal/logging"
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// Méthodes HTTP acceptées par le filtre des destinations
+var forwardedMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE"}
+
+// LogForwardersHandler gère les destinations vers lesquelles une
+// organisation transfère ses événements d'audit (plans Business et
+// Enterprise). Toutes les routes sont réservées aux administrateurs de
+// l'organisation ; les secrets des destinations ne sont jamais renvoyés.
+type LogForwardersHandler struct {
+	forwarders    storage.LogForwardersRepository
+	organizations storage.OrganizationsRepository
+	users         storage.UsersRepository
+	forwarder     *logforward.Forwarder
+	history       storage.SettingsHistoryRepository
+}
+
+// NewLogForwardersHandler crée un nouveau gestionnaire des destinations de journaux
+func NewLogForwardersHandler(
+	forwarders storage.LogForwardersRepository,
+	organizations storage.OrganizationsRepository,
+	users storage.UsersRepository,
+	forwarder *logforward.Forwarder,
+	history storage.SettingsHistoryRepository,
+) *LogForwardersHandler {
+	return &LogForwardersHandler{
+		forwarders:    forwarders,
+		organizations: organizations,
+		users:         users,
+		forwarder:     forwarder,
+		history:       history,
+	}
+}
+
+// ForwarderTestResult est le résultat de l'envoi d'un événement de test
+type ForwarderTestResult struct {
+	Delivered bool   `json:"delivered"`
+	Error     string `json:"error,omitempty"`
+}
+
+// CreateLogForwarder enregistre une destination. Le plan de l'organisation
+// doit inclure le transfert des journaux (402 sinon).
+func (h *LogForwardersHandler) CreateLogForwarder(w http.ResponseWriter, r *http.Request) {
+	orgID := mux.Vars(r)["orgID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if !requireOrgAdmin(w, r, h.users, orgID, userID) {
+		return
+	}
+	planID, err := h.organizations.GetOrganizationPlan(r.Context(), orgID)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de lire le plan de l'organisation")
+		return
+	}
+	if !slices.Contains(models.LogForwardingPlans, planID) {
+		http.Error(w, "Le transfert des journaux d'audit nécessite un plan Business ou Enterprise",
+			http.StatusPaymentRequired)
+		return
+	}
+
+	var forwarder models.LogForwarder
+	if err := json.NewDecoder(r.Body).Decode(&forwarder); err != nil {
+		http.Error(w, "Données invalides", http.StatusBadRequest)
+		return
+	}
+	for i, method := range forwarder.Methods {
+		forwarder.Methods[i] = strings.ToUpper(method)
+		if !slices.Contains(forwardedMethods, forwarder.Methods[i]) {
+			apierror.Write(w, apierror.Validation("Méthode HTTP inconnue : "+method), "")
+			return
+		}
+	}
+	if err := validateLogForwarder(&forwarder); err != nil {
+		apierror.Write(w, err, "")
+		return
+	}
+	if h.forwarder != nil && h.forwarder.CheckDestination(r.Context(), &forwarder) != nil {
+		apierror.Write(w, apierror.Validation("La destination ne doit pas désigner une adresse interne"), "")
+		return
+	}
+	forwarder.ID = ""
+	forwarder.OrganizationID = orgID
+	forwarder.CreatedBy = userID
+	if forwarder.Methods == nil {
+		forwarder.Methods = []string{}
+	}
+	if forwarder.Projects == nil {
+		forwarder.Projects = []string{}
+	}
+
+	if err := h.forwarders.CreateLogForwarder(r.Context(), &forwarder); err != nil {
+		apierror.Write(w, err, "Impossible d'enregistrer la destination")
+		return
+	}
+	forwarder.Secret = ""
+	recordSettingsChange(r.Context(), h.history, orgID, models.SettingsLogForwarder, forwarder.ID,
+		models.SettingsCreated, nil, forwarder)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(forwarder); err != nil {
+		http.Error(w, "Erreur lors de l'encodage de la destination", http.StatusInternalServerError)
+	}
+}
+
+// validateLogForwarder vérifie la destination et les paramètres propres à son type
+func validateLogForwarder(forwarder *models.LogForwarder) error {
+	switch forwarder.Kind {
+	case models.ForwarderHTTPS:
+		if u, err := url.Parse(forwarder.Destination); err != nil || u.Scheme != "https" || u.Host == "" {
+			return apierror.Validation("La destination doit être une URL https")
+		}
+	case models.ForwarderS3:
+		if _, _, err := logforward.ParseS3Destination(forwarder.Destination); err != nil {
+			return apierror.Validation("La destination doit être de la forme s3://bucket/préfixe")
+		}
+		if forwarder.Region == "" || forwarder.AccessKeyID == "" || forwarder.Secret == "" {
+			return apierror.Validation("region, access_key_id et secret sont requis pour S3")
+		}
+		if forwarder.Endpoint != "" {
+			if u, err := url.Parse(forwarder.Endpoint); err != nil || u.Scheme != "https" || u.Host == "" {
+				return apierror.Validation("Le point d'accès S3 doit être une URL https")
+			}
+		}
+	case models.ForwarderSyslog:
+		if _, _, _, err := logforward.ParseSyslogDestination(forwarder.Destination); err != nil {
+			return apierror.Validation("La destination doit être de la forme tcp+tls://, tcp:// ou udp://hôte:port")
+		}
+	default:
+		return apierror.Validation("Type de destination invalide (https, s3 ou syslog)")
+	}
+	return nil
+}
+
+// ListLogForwarders liste les destinations de l'organisation, sans leur secret
+func (h *LogForwardersHandler) ListLogForwarders(w http.ResponseWriter, r *http.Request) {
+	orgID := mux.Vars(r)["orgID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if !requireOrgAdmin(w, r, h.users, orgID, userID) {
+		return
+	}
+
+	list, err := h.forwarders.ListLogForwarders(r.Context(), orgID)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de lister les destinations")
+		return
+	}
+	for _, forwarder := range list {
+		forwarder.Secret = ""
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(list); err != nil {
+		http.Error(w, "Erreur lors de l'encodage des destinations", http.StatusInternalServerError)
+	}
+}
+
+// DeleteLogForwarder supprime une destination
+func (h *LogForwardersHandler) DeleteLogForwarder(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	orgID, forwarderID := vars["orgID"], vars["forwarderID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if !requireOrgAdmin(w, r, h.users, orgID, userID) {
+		return
+	}
+
+	forwarder, err := h.forwarders.GetLogForwarder(r.Context(), orgID, forwarderID)
+	if err != nil {
+		// storage.ErrForwarderNotFound donne 404
+		apierror.Write(w, err, "Impossible de récupérer la destination")
+		return
+	}
+	if err := h.forwarders.DeleteLogForwarder(r.Context(), orgID, forwarderID); err != nil {
+		apierror.Write(w, err, "Impossible de supprimer la destination")
+		return
+	}
+	forwarder.Secret = ""
+	recordSettingsChange(r.Context(), h.history, orgID, models.SettingsLogForwarder, forwarderID,
+		models.SettingsDeleted, forwarder, nil)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// TestLogForwarder envoie à la destination un événement d'audit décrivant la
+// requête de test, sans appliquer ses filtres, et renvoie le résultat
+func (h *LogForwardersHandler) TestLogForwarder(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	orgID, forwarderID := vars["orgID"], vars["forwarderID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if !requireOrgAdmin(w, r, h.users, orgID, userID) {
+		return
+	}
+	if h.forwarder == nil {
+		http.Error(w, "Le transfert des journaux d'audit n'est pas activé", http.StatusServiceUnavailable)
+		return
+	}
+
+	forwarder, err := h.forwarders.GetLogForwarder(r.Context(), orgID, forwarderID)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de récupérer la destination")
+		return
+	}
+
+	principal, _ := middleware.PrincipalFromContext(r.Context())
+	event := &models.AuditEvent{
+		ID:             uuid.New().String(),
+		OrganizationID: orgID,
+		PrincipalType:  principal.Type,
+		PrincipalID:    principal.ID,
+		Method:         r.Method,
+		Route:          middleware.RouteTemplate(r),
+		Path:           r.URL.Path,
+		StatusCode:     http.StatusOK,
+		IPAddress:      middleware.ClientIP(r),
+		UserAgent:      r.UserAgent(),
+		Timestamp:      time.Now().UTC(),
+	}
+	// Le détail de l'échec reste dans les journaux du serveur : renvoyé à
+	// l'appelant, il décrirait le réseau interne (port fermé, hôte injoignable)
+	result := ForwarderTestResult{Delivered: true}
+	if err := h.forwarder.Send(r.Context(), forwarder, event); err != nil {
+		logging.For(logging.ComponentHTTP).Warn("envoi de l'événement de test échoué",
+			"organization_id", orgID, "forwarder_id", forwarder.ID, "error", err)
+		result = ForwarderTestResult{Error: "L'événement de test n'a pas pu être envoyé à la destination"}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
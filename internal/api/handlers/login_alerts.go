@@ -0,0 +1,117 @@
+// filepath: internal/api/handlers/login_alerts.go
+
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"secrets-manager/internal/api/apierror"
+	"secrets-manager/internal/api/middleware"
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// Nombre de connexions renvoyées par /me/logins
+const loginHistoryLimit = 50
+
+// LoginAlertsHandler expose l'historique des connexions de l'utilisateur
+// et la politique d'alerte de connexion des organisations
+type LoginAlertsHandler struct {
+	events   storage.LoginEventsRepository
+	policies storage.LoginAlertPoliciesRepository
+	users    storage.UsersRepository
+	history  storage.SettingsHistoryRepository
+}
+
+// NewLoginAlertsHandler crée un nouveau gestionnaire des alertes de connexion
+func NewLoginAlertsHandler(events storage.LoginEventsRepository, policies storage.LoginAlertPoliciesRepository,
+	users storage.UsersRepository, history storage.SettingsHistoryRepository) *LoginAlertsHandler {
+	return &LoginAlertsHandler{
+		events:   events,
+		policies: policies,
+		users:    users,
+		history:  history,
+	}
+}
+
+// ListLogins liste les dernières connexions de l'utilisateur, situées et
+// annotées des anomalies détectées
+func (h *LoginAlertsHandler) ListLogins(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserIDFromContext(r.Context())
+	logins, err := h.events.ListLoginEvents(r.Context(), userID, loginHistoryLimit)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de lister les connexions")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(logins)
+}
+
+// GetPolicy renvoie la politique d'alerte de connexion de l'organisation à
+// ses membres (la politique par défaut si elle n'en a pas réglé)
+func (h *LoginAlertsHandler) GetPolicy(w http.ResponseWriter, r *http.Request) {
+	orgID := mux.Vars(r)["orgID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if role, err := h.users.GetUserRole(r.Context(), userID, orgID); err != nil || role == "" {
+		http.Error(w, "Organisation non trouvée", http.StatusNotFound)
+		return
+	}
+
+	policy, err := h.policy(r, orgID)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de récupérer la politique d'alerte de connexion")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(policy)
+}
+
+// UpdatePolicy remplace la politique d'alerte de connexion de l'organisation
+func (h *LoginAlertsHandler) UpdatePolicy(w http.ResponseWriter, r *http.Request) {
+	orgID := mux.Vars(r)["orgID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if !requireOrgAdmin(w, r, h.users, orgID, userID) {
+		return
+	}
+
+	var policy models.LoginAlertPolicy
+	if err := json.NewDecoder(r.Body).Decode(&policy); err != nil {
+		http.Error(w, "Données invalides", http.StatusBadRequest)
+		return
+	}
+	before, err := h.policy(r, orgID)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de récupérer la politique d'alerte de connexion")
+		return
+	}
+
+	policy.OrganizationID = orgID
+	policy.UpdatedAt = time.Now().UTC().Truncate(time.Second)
+	if err := h.policies.SaveLoginAlertPolicy(r.Context(), &policy); err != nil {
+		apierror.Write(w, err, "Impossible d'enregistrer la politique d'alerte de connexion")
+		return
+	}
+	recordSettingsChange(r.Context(), h.history, orgID, models.SettingsLoginAlerts, orgID, models.SettingsUpdated,
+		before, &policy)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(&policy)
+}
+
+// policy renvoie la politique de l'organisation ou la politique par défaut
+func (h *LoginAlertsHandler) policy(r *http.Request, orgID string) (*models.LoginAlertPolicy, error) {
+	policy, err := h.policies.GetLoginAlertPolicy(r.Context(), orgID)
+	if errors.Is(err, storage.ErrNotFound) {
+		return models.DefaultLoginAlertPolicy(orgID), nil
+	}
+	return policy, err
+}
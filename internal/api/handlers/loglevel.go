@@ -0,0 +1,66 @@
+// filepath: internal/api/handlers/loglevel.go
+
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+
+	"secrets-manager/internal/logging"
+)
+
+// LogLevelHandler permet de consulter et modifier les niveaux de log à chaud
+type LogLevelHandler struct{}
+
+// NewLogLevelHandler crée un nouveau gestionnaire de niveaux de log
+func NewLogLevelHandler() *LogLevelHandler {
+	return &LogLevelHandler{}
+}
+
+// LogLevelUpdate représente une demande de changement de niveau
+type LogLevelUpdate struct {
+	// Component vide applique le niveau à tous les composants
+	Component string `json:"component"`
+	Level     string `json:"level"`
+}
+
+// GetLevels renvoie le niveau courant de chaque composant
+func (h *LogLevelHandler) GetLevels(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(logging.Levels())
+}
+
+// SetLevel modifie le niveau d'un composant (ou de tous)
+func (h *LogLevelHandler) SetLevel(w http.ResponseWriter, r *http.Request) {
+	var update LogLevelUpdate
+	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+		http.Error(w, "Données invalides", http.StatusBadRequest)
+		return
+	}
+
+	level, err := logging.ParseLevel(update.Level)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	components := logging.Components()
+	if update.Component != "" {
+		if !slices.Contains(components, update.Component) {
+			http.Error(w, "Composant inconnu", http.StatusBadRequest)
+			return
+		}
+		components = []string{update.Component}
+	}
+
+	for _, component := range components {
+		logging.SetLevel(component, level)
+	}
+
+	logging.For(logging.ComponentHTTP).Info("niveau de log modifié",
+		"components", components, "level", level.String())
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(logging.Levels())
+}
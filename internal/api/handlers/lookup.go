@@ -0,0 +1,178 @@
+// filepath: internal/api/handlers/lookup.go
+
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"secrets-manager/internal/api/apierror"
+	"secrets-manager/internal/api/middleware"
+	"secrets-manager/internal/events"
+	"secrets-manager/internal/models"
+)
+
+// Attente maximale d'une requête /lookup?wait=
+const maxLookupWait = 5 * time.Minute
+
+// Marge laissée au-delà de l'attente pour écrire la réponse
+const lookupWriteMargin = 15 * time.Second
+
+// LookupHandler sert la valeur d'un secret désigné par un chemin unique,
+// pour les plugins de lookup des outils de gestion de configuration
+// (Ansible, Chef). La réponse porte un index (repris dans l'ETag) : une
+// requête qui le renvoie avec wait attend la prochaine modification du
+// secret au lieu de relire en boucle.
+type LookupHandler struct {
+	secrets *SecretsHandler
+	changes *events.Watcher
+}
+
+// NewLookupHandler crée un nouveau gestionnaire de lookup ; les
+// modifications des secrets sont attendues sur bus
+func NewLookupHandler(secrets *SecretsHandler, bus *events.Bus) *LookupHandler {
+	return &LookupHandler{
+		secrets: secrets,
+		changes: events.NewWatcher(bus),
+	}
+}
+
+// LookupResult est la valeur d'un secret renvoyée par /lookup
+type LookupResult struct {
+	Path  string `json:"path"`
+	Value string `json:"value"`
+	// Index change avec la valeur ; il se repasse dans index (ou
+	// If-None-Match) pour attendre la modification suivante
+	Index string `json:"index"`
+}
+
+// Lookup renvoie le secret path=org/projet/environnement/nom (identifiants
+// de l'organisation et du projet). Paramètres :
+//   - index (ou If-None-Match) : 304 si la valeur n'a pas changé
+//   - wait (durée, 5 minutes au plus) : avec un index à jour, attend la
+//     modification suivante avant de répondre, 304 à l'échéance
+//   - format=raw : la valeur seule, en text/plain
+//
+// Les modifications sont signalées par le bus local au processus : derrière
+// plusieurs instances, l'attente peut aller jusqu'à l'échéance, l'index
+// reste exact.
+func (h *LookupHandler) Lookup(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	path := query.Get("path")
+	parts := strings.SplitN(path, "/", 4)
+	if len(parts) != 4 || slices.Contains(parts, "") {
+		apierror.Write(w, apierror.Validation("Chemin invalide (organisation/projet/environnement/nom attendu)"), "")
+		return
+	}
+	var wait time.Duration
+	if raw := query.Get("wait"); raw != "" {
+		var err error
+		wait, err = time.ParseDuration(raw)
+		if err != nil || wait <= 0 || wait > maxLookupWait {
+			apierror.Write(w, apierror.Validation("Paramètre wait invalide (durée de 5 minutes au plus)"), "")
+			return
+		}
+	}
+	format := query.Get("format")
+	if format != "" && format != "json" && format != "raw" {
+		apierror.Write(w, apierror.Validation("Paramètre format invalide (json ou raw)"), "")
+		return
+	}
+	known := query.Get("index")
+	if match := r.Header.Get("If-None-Match"); known == "" && match != "" {
+		known = strings.Trim(strings.TrimPrefix(strings.TrimSpace(match), "W/"), `"`)
+	}
+
+	// Variables de la route des secrets équivalente, reprises par l'audit des lectures
+	orgID, projectID := parts[0], parts[1]
+	r = mux.SetURLVars(r, map[string]string{
+		"orgID": orgID, "projectID": projectID, "env": parts[2], "name": parts[3],
+	})
+	userID := middleware.UserIDFromContext(r.Context())
+	// La route n'a ni projet ni environnement : les ressources du token sont
+	// vérifiées ici, sur ceux du chemin
+	if err := h.secrets.policy.checkEnvironment(r.Context(), userID, orgID, projectID, parts[2], secretRead); err != nil {
+		writePolicyError(w, err)
+		return
+	}
+	workload, err := workloadFromRequest(r)
+	if err != nil {
+		apierror.Write(w, err, "")
+		return
+	}
+	reason, err := h.secrets.readReason(r, orgID, parts[2])
+	if err != nil {
+		apierror.Write(w, err, "Impossible de vérifier les environnements protégés")
+		return
+	}
+
+	var deadline <-chan time.Time
+	if wait > 0 {
+		http.NewResponseController(w).SetWriteDeadline(time.Now().Add(wait + lookupWriteMargin))
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+		deadline = timer.C
+	}
+
+	for {
+		// S'abonner avant la lecture : une modification entre les deux n'est pas perdue
+		changed := h.changes.Next(orgID, events.ResourceSecrets)
+		secret, err := h.secrets.vaultService.GetSecret(r.Context(), orgID, projectID, parts[2], parts[3])
+		if err != nil {
+			apierror.Write(w, err, "Impossible de récupérer le secret")
+			return
+		}
+		index := h.index(secret)
+
+		w.Header().Set("ETag", `"`+index+`"`)
+		w.Header().Set("Cache-Control", "private, no-cache")
+		w.Header().Set("Vary", "Authorization")
+		if known != index {
+			h.secrets.recordReads(r, workload, []*models.Secret{secret})
+			h.secrets.auditReads(r, reason, []*models.Secret{secret})
+			writeLookupResult(w, format, &LookupResult{Path: path, Value: secret.Value, Index: index})
+			return
+		}
+		if deadline == nil {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		select {
+		case <-changed:
+		case <-deadline:
+			w.WriteHeader(http.StatusNotModified)
+			return
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+// index identifie la valeur du secret : sa somme de contrôle, ou à défaut
+// l'empreinte de son chemin et de sa valeur
+func (h *LookupHandler) index(secret *models.Secret) string {
+	if checksum := h.secrets.checksum(secret); checksum != "" {
+		return checksum
+	}
+	sum := sha256.Sum256([]byte(secret.OrganizationID + "/" + secret.ProjectID + "/" +
+		secret.Environment + "/" + secret.Name + "\x00" + secret.Value))
+	return hex.EncodeToString(sum[:16])
+}
+
+func writeLookupResult(w http.ResponseWriter, format string, result *LookupResult) {
+	if format == "raw" {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Write([]byte(result.Value))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
@@ -0,0 +1,151 @@
+// filepath: internal/api/handlers/maintenance.go
+
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"secrets-manager/internal/api/apierror"
+	"secrets-manager/internal/api/middleware"
+	"secrets-manager/internal/maintenance"
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// MaintenanceWindowsHandler gère les fenêtres de maintenance des
+// environnements de l'organisation. Toutes les routes sont réservées aux
+// administrateurs de l'organisation.
+type MaintenanceWindowsHandler struct {
+	windows storage.MaintenanceWindowsRepository
+	users   storage.UsersRepository
+	history storage.SettingsHistoryRepository
+}
+
+// NewMaintenanceWindowsHandler crée un nouveau gestionnaire des fenêtres de maintenance
+func NewMaintenanceWindowsHandler(windows storage.MaintenanceWindowsRepository, users storage.UsersRepository,
+	history storage.SettingsHistoryRepository) *MaintenanceWindowsHandler {
+	return &MaintenanceWindowsHandler{
+		windows: windows,
+		users:   users,
+		history: history,
+	}
+}
+
+// CreateWindow ajoute une fenêtre de maintenance à un environnement, qui
+// devient protégé s'il ne l'était pas
+func (h *MaintenanceWindowsHandler) CreateWindow(w http.ResponseWriter, r *http.Request) {
+	orgID := mux.Vars(r)["orgID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if !requireOrgAdmin(w, r, h.users, orgID, userID) {
+		return
+	}
+
+	var window models.MaintenanceWindow
+	if err := json.NewDecoder(r.Body).Decode(&window); err != nil {
+		http.Error(w, "Données invalides", http.StatusBadRequest)
+		return
+	}
+	window.Environment = strings.TrimSpace(window.Environment)
+	if window.Environment == "" {
+		apierror.Write(w, apierror.Validation("Un environnement est requis"), "")
+		return
+	}
+	if window.Timezone == "" {
+		window.Timezone = "UTC"
+	}
+	evaluated, err := maintenance.NewWindow(&window)
+	if err != nil {
+		apierror.Write(w, apierror.Validation("Fenêtre invalide : "+err.Error()), "")
+		return
+	}
+
+	window.OrganizationID = orgID
+	window.CreatedBy = userID
+	if err := h.windows.CreateMaintenanceWindow(r.Context(), &window); err != nil {
+		apierror.Write(w, err, "Impossible d'enregistrer la fenêtre de maintenance")
+		return
+	}
+	recordSettingsChange(r.Context(), h.history, orgID, models.SettingsMaintenanceWindow, window.ID,
+		models.SettingsCreated, nil, window)
+	setWindowStatus(&window, evaluated, time.Now())
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(window)
+}
+
+// ListWindows liste les fenêtres de maintenance de l'organisation, avec
+// leur état (ouverte) et leur prochaine ouverture
+func (h *MaintenanceWindowsHandler) ListWindows(w http.ResponseWriter, r *http.Request) {
+	orgID := mux.Vars(r)["orgID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if !requireOrgAdmin(w, r, h.users, orgID, userID) {
+		return
+	}
+
+	windows, err := h.windows.ListMaintenanceWindows(r.Context(), orgID)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de lister les fenêtres de maintenance")
+		return
+	}
+	now := time.Now()
+	for _, window := range windows {
+		if evaluated, err := maintenance.NewWindow(window); err == nil {
+			setWindowStatus(window, evaluated, now)
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(windows)
+}
+
+// DeleteWindow supprime une fenêtre de maintenance. Sans fenêtre restante,
+// l'environnement n'est plus protégé.
+func (h *MaintenanceWindowsHandler) DeleteWindow(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	orgID := vars["orgID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if !requireOrgAdmin(w, r, h.users, orgID, userID) {
+		return
+	}
+
+	windows, err := h.windows.ListMaintenanceWindows(r.Context(), orgID)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de lister les fenêtres de maintenance")
+		return
+	}
+	var deleted *models.MaintenanceWindow
+	for _, window := range windows {
+		if window.ID == vars["windowID"] {
+			deleted = window
+		}
+	}
+	if deleted == nil {
+		apierror.Write(w, storage.ErrWindowNotFound, "")
+		return
+	}
+
+	if err := h.windows.DeleteMaintenanceWindow(r.Context(), orgID, deleted.ID); err != nil {
+		apierror.Write(w, err, "Impossible de supprimer la fenêtre de maintenance")
+		return
+	}
+	recordSettingsChange(r.Context(), h.history, orgID, models.SettingsMaintenanceWindow, deleted.ID,
+		models.SettingsDeleted, deleted, nil)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// setWindowStatus renseigne l'état calculé de la fenêtre à now
+func setWindowStatus(window *models.MaintenanceWindow, evaluated *maintenance.Window, now time.Time) {
+	window.Open = evaluated.Open(now)
+	if next, ok := evaluated.NextOpening(now); ok {
+		window.NextOpening = &next
+	}
+}
@@ -0,0 +1,126 @@
+// filepath: internal/api/handlers/mfa.go
+
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"secrets-manager/internal/api/apierror"
+	"secrets-manager/internal/api/middleware"
+	"secrets-manager/internal/logging"
+)
+
+// MFAVerification est le second facteur présenté après la connexion
+type MFAVerification struct {
+	MFAToken string `json:"mfa_token"`
+	Code     string `json:"code"`
+	// RememberDevice dispense ce navigateur du code TOTP pendant la durée
+	// configurée (cookie d'appareil de confiance)
+	RememberDevice bool   `json:"remember_device"`
+	DeviceName     string `json:"device_name"`
+}
+
+// MFACode est un code TOTP de l'utilisateur connecté
+type MFACode struct {
+	Code string `json:"code"`
+}
+
+// VerifyMFA échange le mfa_token remis par Login et un code TOTP contre les
+// tokens de l'utilisateur
+func (h *AuthHandler) VerifyMFA(w http.ResponseWriter, r *http.Request) {
+	var req MFAVerification
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Données invalides", http.StatusBadRequest)
+		return
+	}
+	if req.MFAToken == "" || req.Code == "" {
+		apierror.Write(w, apierror.Validation("mfa_token et code requis"), "")
+		return
+	}
+
+	if len(req.DeviceName) > 128 {
+		apierror.Write(w, apierror.Validation("Nom de l'appareil trop long (128 caractères au plus)"), "")
+		return
+	}
+
+	token, err := h.authService.VerifyMFA(r.Context(), req.MFAToken, req.Code, requestDevice(r, ""))
+	if err != nil {
+		apierror.Write(w, err, "Erreur d'authentification")
+		return
+	}
+	if req.RememberDevice && h.authService.DeviceTrustEnabled() {
+		// La connexion aboutit même si l'appareil n'a pas pu être enregistré
+		value, device, err := h.authService.TrustDevice(r.Context(), token.UserID, requestDevice(r, req.DeviceName))
+		if err != nil {
+			logging.For(logging.ComponentHTTP).Warn("appareil de confiance non enregistré",
+				"user_id", token.UserID, "error", err)
+		} else {
+			setTrustedDeviceCookie(w, value, device.ExpiresAt)
+		}
+	}
+	writeTokens(w, token.Token, token.RefreshToken)
+}
+
+// EnrollMFA génère une clé TOTP pour l'utilisateur connecté. La clé n'est
+// exigée à la connexion qu'après ActivateMFA.
+func (h *AuthHandler) EnrollMFA(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserIDFromContext(r.Context())
+
+	enrollment, err := h.authService.EnrollMFA(r.Context(), userID)
+	if err != nil {
+		apierror.Write(w, err, "Impossible d'inscrire l'authentification multifacteur")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(enrollment)
+}
+
+// ActivateMFA active l'authentification multifacteur avec un premier code et
+// renvoie de nouveaux tokens : ceux de la session courante sont refusés
+// dès l'activation
+func (h *AuthHandler) ActivateMFA(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserIDFromContext(r.Context())
+
+	var req MFACode
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Données invalides", http.StatusBadRequest)
+		return
+	}
+
+	token, err := h.authService.ActivateMFA(r.Context(), userID, req.Code, requestDevice(r, ""))
+	if err != nil {
+		apierror.Write(w, err, "Impossible d'activer l'authentification multifacteur")
+		return
+	}
+	writeTokens(w, token.Token, token.RefreshToken)
+}
+
+// DisableMFA désactive l'authentification multifacteur après un code valide
+func (h *AuthHandler) DisableMFA(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserIDFromContext(r.Context())
+
+	var req MFACode
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Données invalides", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.authService.DisableMFA(r.Context(), userID, req.Code); err != nil {
+		apierror.Write(w, err, "Impossible de désactiver l'authentification multifacteur")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeTokens répond avec le token et le refresh token, comme Login
+func writeTokens(w http.ResponseWriter, token, refreshToken string) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{
+		"token":         token,
+		"refresh_token": refreshToken,
+	})
+}
@@ -0,0 +1,104 @@
+// filepath: internal/api/handlers/notifications.go
+
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"slices"
+
+	"secrets-manager/internal/api/apierror"
+	"secrets-manager/internal/api/middleware"
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// NotificationsHandler gère les réglages de notification de l'utilisateur connecté
+type NotificationsHandler struct {
+	preferences storage.NotificationPreferencesRepository
+}
+
+// NewNotificationsHandler crée un nouveau gestionnaire des réglages de notification
+func NewNotificationsHandler(preferences storage.NotificationPreferencesRepository) *NotificationsHandler {
+	return &NotificationsHandler{preferences: preferences}
+}
+
+// GetPreferences renvoie les canaux choisis pour chaque type d'événement
+// (réglages par défaut pour les types jamais réglés)
+func (h *NotificationsHandler) GetPreferences(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserIDFromContext(r.Context())
+
+	prefs, err := h.preferences.GetNotificationPreferences(r.Context(), userID)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de récupérer les réglages de notification")
+		return
+	}
+
+	writePreferences(w, withDefaults(userID, prefs))
+}
+
+// UpdatePreferences remplace les réglages de notification de l'utilisateur.
+// Les types d'événements absents gardent les réglages par défaut.
+func (h *NotificationsHandler) UpdatePreferences(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserIDFromContext(r.Context())
+
+	var prefs models.NotificationPreferences
+	if err := json.NewDecoder(r.Body).Decode(&prefs); err != nil {
+		http.Error(w, "Données invalides", http.StatusBadRequest)
+		return
+	}
+	prefs.UserID = userID
+
+	if prefs.SlackWebhookURL != "" {
+		if u, err := url.Parse(prefs.SlackWebhookURL); err != nil || u.Scheme != "https" || u.Host == "" {
+			apierror.Write(w, apierror.Validation("Le webhook Slack doit être une URL https"), "")
+			return
+		}
+	}
+	if prefs.Digest != "" && models.DigestPeriod(prefs.Digest) == 0 {
+		apierror.Write(w, apierror.Validation("Fréquence de résumé inconnue : "+prefs.Digest), "")
+		return
+	}
+	for eventType, channels := range prefs.Events {
+		if !slices.Contains(models.NotificationEventTypes, eventType) {
+			apierror.Write(w, apierror.Validation("Type d'événement inconnu : "+eventType), "")
+			return
+		}
+		if channels.Slack && prefs.SlackWebhookURL == "" {
+			apierror.Write(w, apierror.Validation("Un webhook Slack est requis pour les notifications Slack"), "")
+			return
+		}
+	}
+
+	if err := h.preferences.SetNotificationPreferences(r.Context(), &prefs); err != nil {
+		apierror.Write(w, err, "Impossible d'enregistrer les réglages de notification")
+		return
+	}
+
+	writePreferences(w, withDefaults(userID, &prefs))
+}
+
+// withDefaults complète les réglages enregistrés (éventuellement nil) avec
+// les réglages par défaut
+func withDefaults(userID string, prefs *models.NotificationPreferences) *models.NotificationPreferences {
+	merged := models.DefaultNotificationPreferences(userID)
+	if prefs == nil {
+		return merged
+	}
+	merged.SlackWebhookURL = prefs.SlackWebhookURL
+	merged.Digest = prefs.Digest
+	merged.LastDigestAt = prefs.LastDigestAt
+	merged.UpdatedAt = prefs.UpdatedAt
+	for eventType, channels := range prefs.Events {
+		merged.Events[eventType] = channels
+	}
+	return merged
+}
+
+func writePreferences(w http.ResponseWriter, prefs *models.NotificationPreferences) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(prefs); err != nil {
+		http.Error(w, "Erreur lors de l'encodage des réglages", http.StatusInternalServerError)
+	}
+}
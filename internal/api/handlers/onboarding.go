@@ -0,0 +1,145 @@
+// filepath: internal/api/handlers/onboarding.go
+
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"secrets-manager/internal/api/apierror"
+	"secrets-manager/internal/api/middleware"
+	"secrets-manager/internal/auth"
+	"secrets-manager/internal/logging"
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/notifications"
+	"secrets-manager/internal/onboarding"
+	"secrets-manager/internal/storage"
+)
+
+// OnboardingHandler expose l'avancement de la prise en main, lu par le
+// tableau de bord, et la confirmation de l'adresse email qui en est la
+// première étape
+type OnboardingHandler struct {
+	tracker     *onboarding.Tracker
+	authService *auth.Service
+	users       storage.UsersRepository
+	mailer      notifications.Mailer
+	verifyURI   string
+}
+
+// NewOnboardingHandler crée un nouveau gestionnaire de la prise en main.
+// verifyURI est la page du tableau de bord qui reçoit le token de
+// confirmation (paramètre token) ; mailer nil désactive la confirmation.
+func NewOnboardingHandler(
+	tracker *onboarding.Tracker,
+	authService *auth.Service,
+	users storage.UsersRepository,
+	mailer notifications.Mailer,
+	verifyURI string,
+) *OnboardingHandler {
+	return &OnboardingHandler{
+		tracker:     tracker,
+		authService: authService,
+		users:       users,
+		mailer:      mailer,
+		verifyURI:   verifyURI,
+	}
+}
+
+// EmailVerification échange un token de confirmation d'adresse
+type EmailVerification struct {
+	Token string `json:"token"`
+}
+
+// GetOnboarding renvoie l'avancement de l'appelant dans l'organisation :
+// l'état (prochaine étape ou completed) et chaque étape franchie
+func (h *OnboardingHandler) GetOnboarding(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	orgID := mux.Vars(r)["orgID"]
+	userID := middleware.UserIDFromContext(ctx)
+
+	role, err := h.users.GetUserRole(ctx, userID, orgID)
+	if err != nil || role == "" {
+		http.Error(w, "Organisation non trouvée", http.StatusNotFound)
+		return
+	}
+
+	state, err := h.tracker.State(ctx, userID, orgID)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de récupérer la prise en main")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(state)
+}
+
+// RequestEmailVerification envoie à l'appelant un lien de confirmation de
+// son adresse email, valable 24 heures
+func (h *OnboardingHandler) RequestEmailVerification(w http.ResponseWriter, r *http.Request) {
+	if h.mailer == nil {
+		http.Error(w, "L'envoi d'emails n'est pas configuré", http.StatusServiceUnavailable)
+		return
+	}
+
+	userID := middleware.UserIDFromContext(r.Context())
+	token, email, err := h.authService.EmailVerificationToken(r.Context(), userID)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de générer la confirmation")
+		return
+	}
+
+	subject := "[secrets-manager] Confirmez votre adresse email"
+	body := fmt.Sprintf("Confirmez l'adresse %s de votre compte secrets-manager avant 24 heures :\n%s\n\n"+
+		"Si vous n'êtes pas à l'origine de cette demande, ignorez cet email.\n", email, h.verifyLink(token))
+	if err := h.mailer.SendMail(r.Context(), email, subject, body); err != nil {
+		logging.For(logging.ComponentHTTP).Error("envoi d'une confirmation d'adresse impossible",
+			"user_id", userID, "error", err)
+		http.Error(w, "Impossible d'envoyer la confirmation", http.StatusBadGateway)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// VerifyEmail échange un token de confirmation d'adresse : l'étape
+// email_verified de la prise en main est franchie. Le token prouve la
+// possession de l'adresse, la route n'exige pas de session.
+func (h *OnboardingHandler) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	var req EmailVerification
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Données invalides", http.StatusBadRequest)
+		return
+	}
+	if req.Token == "" {
+		apierror.Write(w, apierror.Validation("Token de confirmation requis"), "")
+		return
+	}
+
+	userID, err := h.authService.VerifyEmail(r.Context(), req.Token)
+	if err != nil {
+		// Token expiré, révoqué ou émis pour une autre adresse : 401
+		apierror.Write(w, err, "Impossible de confirmer l'adresse")
+		return
+	}
+	if err := h.tracker.Complete(r.Context(), models.OnboardingEmailVerified, userID, userID); err != nil {
+		apierror.Write(w, err, "Impossible d'enregistrer la confirmation")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// verifyLink renvoie le lien de la page de confirmation portant le token
+func (h *OnboardingHandler) verifyLink(token string) string {
+	separator := "?"
+	if strings.Contains(h.verifyURI, "?") {
+		separator = "&"
+	}
+	return h.verifyURI + separator + "token=" + url.QueryEscape(token)
+}
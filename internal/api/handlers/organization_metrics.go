@@ -0,0 +1,212 @@
+// filepath: internal/api/handlers/organization_metrics.go
+
+package handlers
+
+import (
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"secrets-manager/internal/api/apierror"
+	"secrets-manager/internal/api/middleware"
+	"secrets-manager/internal/logging"
+	"secrets-manager/internal/metrics"
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// Quantiles publiés pour l'âge des valeurs des secrets
+var secretAgeQuantiles = []float64{0.5, 0.9, 0.99}
+
+// OrganizationMetricsHandler expose les métriques d'usage d'une organisation
+// au format Prometheus, pour que les clients les collectent dans leur propre
+// supervision (à la différence des métriques d'exploitation du listener
+// d'administration). Le scraper s'authentifie avec un token d'accès
+// personnel de portée metadata:read, une clé d'API ou un compte de service ;
+// seuls les projets dont l'appelant peut lire les secrets, et les
+// environnements que couvrent sa clé ou les ressources de son token, sont
+// comptés.
+type OrganizationMetricsHandler struct {
+	users    storage.UsersRepository
+	projects storage.ProjectsRepository
+	secrets  storage.SecretsRepository
+	reads    storage.SecretReadsRepository
+	policy   *secretPolicy
+}
+
+// NewOrganizationMetricsHandler crée un nouveau gestionnaire des métriques d'organisation
+func NewOrganizationMetricsHandler(
+	users storage.UsersRepository,
+	projects storage.ProjectsRepository,
+	secrets storage.SecretsRepository,
+	reads storage.SecretReadsRepository,
+) *OrganizationMetricsHandler {
+	return &OrganizationMetricsHandler{
+		users:    users,
+		projects: projects,
+		secrets:  secrets,
+		reads:    reads,
+		policy:   &secretPolicy{users: users, projects: projects},
+	}
+}
+
+// GetMetrics renvoie, au format texte Prometheus :
+//   - le nombre de secrets par projet et environnement
+//   - les lectures des secrets du jour et de la veille (UTC)
+//   - les quantiles de l'âge des valeurs (depuis leur dernière modification)
+func (h *OrganizationMetricsHandler) GetMetrics(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	orgID := mux.Vars(r)["orgID"]
+	userID := middleware.UserIDFromContext(ctx)
+
+	role, err := h.users.GetUserRole(ctx, userID, orgID)
+	if err != nil || role == "" {
+		http.Error(w, "Organisation non trouvée", http.StatusNotFound)
+		return
+	}
+
+	projects, err := h.projects.ListOrganizationProjects(ctx, orgID)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de lister les projets")
+		return
+	}
+	names := map[string]string{}
+	projectIDs := []string{}
+	for _, project := range projects {
+		if h.policy.check(ctx, userID, orgID, project.ID, secretRead) == nil {
+			names[project.ID] = project.Name
+			projectIDs = append(projectIDs, project.ID)
+		}
+	}
+
+	secrets := []*models.SecretMetadata{}
+	if len(projectIDs) > 0 {
+		secrets, err = h.secrets.ListSecretsByProjects(ctx, orgID, projectIDs, "")
+		if err != nil {
+			apierror.Write(w, err, "Impossible de lister les secrets")
+			return
+		}
+	}
+	now := time.Now()
+	today := now.UTC().Format("2006-01-02")
+	yesterday := now.UTC().AddDate(0, 0, -1).Format("2006-01-02")
+	reads, err := h.reads.ListOrganizationDailyReads(ctx, orgID, now.UTC().AddDate(0, 0, -1))
+	if err != nil {
+		apierror.Write(w, err, "Impossible de compter les lectures")
+		return
+	}
+
+	labelNames := []string{"project_id", "project", "environment"}
+	counts := &metrics.Family{
+		Name:       "secrets_manager_org_secrets",
+		Help:       "Nombre de secrets par projet et environnement",
+		Kind:       "gauge",
+		LabelNames: labelNames,
+	}
+	readsToday := &metrics.Family{
+		Name:       "secrets_manager_org_secret_reads_today",
+		Help:       "Lectures des valeurs des secrets depuis minuit (UTC)",
+		Kind:       "gauge",
+		LabelNames: labelNames,
+	}
+	readsYesterday := &metrics.Family{
+		Name:       "secrets_manager_org_secret_reads_yesterday",
+		Help:       "Lectures des valeurs des secrets la veille (UTC)",
+		Kind:       "gauge",
+		LabelNames: labelNames,
+	}
+	ages := &metrics.Family{
+		Name:       "secrets_manager_org_secret_age_seconds",
+		Help:       "Âge des valeurs des secrets depuis leur dernière modification",
+		Kind:       "summary",
+		LabelNames: []string{"quantile"},
+	}
+
+	key := middleware.APIKeyFromContext(ctx)
+	covered := func(projectID, env string) bool {
+		return (key == nil || key.Covers(orgID, projectID, env)) && resourcesCover(ctx, projectID, env)
+	}
+
+	perEnvironment := map[[2]string]int{}
+	values := make([]float64, 0, len(secrets))
+	for _, secret := range secrets {
+		if !covered(secret.ProjectID, secret.Environment) {
+			continue
+		}
+		perEnvironment[[2]string{secret.ProjectID, secret.Environment}]++
+		changedAt := secret.UpdatedAt
+		if changedAt.IsZero() {
+			changedAt = secret.CreatedAt
+		}
+		values = append(values, math.Max(0, now.Sub(changedAt).Seconds()))
+	}
+	keys := make([][2]string, 0, len(perEnvironment))
+	for key := range perEnvironment {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i][0] != keys[j][0] {
+			return keys[i][0] < keys[j][0]
+		}
+		return keys[i][1] < keys[j][1]
+	})
+	for _, key := range keys {
+		counts.Samples = append(counts.Samples, metrics.Sample{
+			Labels: []string{key[0], names[key[0]], key[1]},
+			Value:  float64(perEnvironment[key]),
+		})
+	}
+
+	for _, total := range reads {
+		name, ok := names[total.ProjectID]
+		if !ok || !covered(total.ProjectID, total.Environment) {
+			continue
+		}
+		sample := metrics.Sample{Labels: []string{total.ProjectID, name, total.Environment}, Value: float64(total.Reads)}
+		switch total.Day {
+		case today:
+			readsToday.Samples = append(readsToday.Samples, sample)
+		case yesterday:
+			readsYesterday.Samples = append(readsYesterday.Samples, sample)
+		}
+	}
+
+	sort.Float64s(values)
+	sum := 0.0
+	for _, value := range values {
+		sum += value
+	}
+	if len(values) > 0 {
+		for _, q := range secretAgeQuantiles {
+			ages.Samples = append(ages.Samples, metrics.Sample{
+				Labels: []string{strconv.FormatFloat(q, 'g', -1, 64)},
+				Value:  math.Round(quantile(values, q)),
+			})
+		}
+	}
+	ages.Samples = append(ages.Samples,
+		metrics.Sample{Suffix: "_sum", Value: math.Round(sum)},
+		metrics.Sample{Suffix: "_count", Value: float64(len(values))})
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	for _, family := range []*metrics.Family{counts, readsToday, readsYesterday, ages} {
+		if err := family.WriteText(w); err != nil {
+			logging.For(logging.ComponentHTTP).Warn("export des métriques de l'organisation interrompu",
+				"organization_id", orgID, "error", err)
+			return
+		}
+	}
+}
+
+// quantile renvoie le quantile q (rang le plus proche) de valeurs triées
+func quantile(sorted []float64, q float64) float64 {
+	rank := int(math.Ceil(q*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	return sorted[rank]
+}
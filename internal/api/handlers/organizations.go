@@ -0,0 +1,260 @@
+// filepath: internal/api/handlers/organizations.go
+
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"secrets-manager/internal/api/apierror"
+	"secrets-manager/internal/api/middleware"
+	"secrets-manager/internal/logging"
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/onboarding"
+	"secrets-manager/internal/storage"
+)
+
+// OrganizationDeletionQueue planifie la suppression asynchrone d'une organisation
+type OrganizationDeletionQueue interface {
+	Enqueue(ctx context.Context, orgID, userID string) (*models.OrganizationDeletion, error)
+}
+
+// TrialPolicy décrit l'abonnement d'essai accordé aux organisations
+// créées par l'API
+type TrialPolicy struct {
+	PlanID       string
+	Duration     time.Duration
+	SecretsLimit int
+}
+
+// OrganizationsHandler gère les routes liées aux organisations
+type OrganizationsHandler struct {
+	organizations storage.OrganizationsRepository
+	deletions     storage.OrganizationDeletionsRepository
+	queue         OrganizationDeletionQueue
+	users         storage.UsersRepository
+	confirmer     *Confirmer
+	trial         TrialPolicy
+	tracker       *onboarding.Tracker
+}
+
+// NewOrganizationsHandler crée un nouveau gestionnaire d'organisations.
+// tracker (optionnel) reçoit l'étape project_created franchie par le projet
+// par défaut des organisations créées.
+func NewOrganizationsHandler(
+	organizations storage.OrganizationsRepository,
+	deletions storage.OrganizationDeletionsRepository,
+	queue OrganizationDeletionQueue,
+	users storage.UsersRepository,
+	confirmer *Confirmer,
+	trial TrialPolicy,
+	tracker *onboarding.Tracker,
+) *OrganizationsHandler {
+	return &OrganizationsHandler{
+		organizations: organizations,
+		deletions:     deletions,
+		queue:         queue,
+		users:         users,
+		confirmer:     confirmer,
+		trial:         trial,
+		tracker:       tracker,
+	}
+}
+
+// OrganizationRequest est le corps de la création d'une organisation
+type OrganizationRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// CreateOrganization crée une organisation dont l'appelant est propriétaire
+// et administrateur, avec ses ressources de départ dans la même transaction :
+// l'abonnement d'essai, le projet par défaut et ses environnements (voir
+// models.OrganizationProvision). Renvoie 201 avec la provision ; un nom
+// déjà pris renvoie 409.
+func (h *OrganizationsHandler) CreateOrganization(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserIDFromContext(r.Context())
+
+	var req OrganizationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Données invalides", http.StatusBadRequest)
+		return
+	}
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		apierror.Write(w, apierror.Validation("Nom de l'organisation requis"), "")
+		return
+	}
+
+	now := time.Now()
+	provision := &models.OrganizationProvision{
+		Organization: &models.Organization{
+			Name:        req.Name,
+			Description: req.Description,
+			OwnerID:     userID,
+		},
+		// L'essai est un abonnement actif sur le plan d'essai, qui expire
+		// au bout de sa durée
+		Subscription: &models.Subscription{
+			PlanID:       h.trial.PlanID,
+			Status:       "active",
+			SecretsLimit: h.trial.SecretsLimit,
+			StartDate:    now,
+			EndDate:      now.Add(h.trial.Duration),
+		},
+		Project: &models.Project{
+			Name:      models.DefaultProjectName,
+			CreatedBy: userID,
+		},
+	}
+	for _, name := range models.DefaultEnvironments {
+		provision.Environments = append(provision.Environments, &models.Environment{Name: name})
+	}
+	if err := h.organizations.ProvisionOrganization(r.Context(), provision); err != nil {
+		apierror.Write(w, err, "Impossible de créer l'organisation")
+		return
+	}
+
+	// Le projet par défaut franchit la deuxième étape de la prise en main
+	orgID := provision.Organization.ID
+	err := h.tracker.Complete(context.WithoutCancel(r.Context()), models.OnboardingProjectCreated, orgID, userID)
+	if err != nil {
+		logging.For(logging.ComponentHTTP).Warn("échec de l'enregistrement de la prise en main",
+			"organization_id", orgID, "step", models.OnboardingProjectCreated, "error", err)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(provision)
+}
+
+// ListMembers liste les membres d'une organisation avec leur rôle.
+// Les non-membres reçoivent 404.
+func (h *OrganizationsHandler) ListMembers(w http.ResponseWriter, r *http.Request) {
+	orgID := mux.Vars(r)["orgID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if role, err := h.users.GetUserRole(r.Context(), userID, orgID); err != nil || role == "" {
+		http.Error(w, "Organisation non trouvée", http.StatusNotFound)
+		return
+	}
+
+	members, err := h.organizations.ListOrganizationMembers(r.Context(), orgID)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de lister les membres")
+		return
+	}
+
+	writeJSONList(w, r, members)
+}
+
+// Rôles acceptés par le filtre de recherche de membres
+var memberRoles = map[string]bool{"admin": true, "member": true, "viewer": true}
+
+// SearchMembers recherche les membres d'une organisation par début de nom ou
+// d'email. Paramètres optionnels : q, role (répétable), limit, offset.
+// Réservé aux administrateurs de l'organisation ; les non-membres reçoivent 404.
+func (h *OrganizationsHandler) SearchMembers(w http.ResponseWriter, r *http.Request) {
+	orgID := mux.Vars(r)["orgID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	role, err := h.users.GetUserRole(r.Context(), userID, orgID)
+	if err != nil || role == "" {
+		http.Error(w, "Organisation non trouvée", http.StatusNotFound)
+		return
+	}
+	if role != "admin" {
+		http.Error(w, "Accès refusé", http.StatusForbidden)
+		return
+	}
+
+	search := models.MemberSearch{Query: strings.TrimSpace(r.URL.Query().Get("q"))}
+	for _, role := range r.URL.Query()["role"] {
+		if !memberRoles[role] {
+			http.Error(w, "Paramètre role invalide", http.StatusBadRequest)
+			return
+		}
+		search.Roles = append(search.Roles, role)
+	}
+
+	var ok bool
+	if search.Limit, search.Offset, ok = parsePagination(w, r); !ok {
+		return
+	}
+
+	members, err := h.users.SearchOrganizationMembers(r.Context(), orgID, search)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de rechercher les membres")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(members)
+}
+
+// DeleteOrganization place une organisation dans la corbeille après
+// confirmation et renvoie 202 avec l'état de la suppression, dont la date
+// de purge définitive.
+// Seul le propriétaire peut la supprimer ; les non-membres reçoivent 404.
+func (h *OrganizationsHandler) DeleteOrganization(w http.ResponseWriter, r *http.Request) {
+	orgID := mux.Vars(r)["orgID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if role, err := h.users.GetUserRole(r.Context(), userID, orgID); err != nil || role == "" {
+		http.Error(w, "Organisation non trouvée", http.StatusNotFound)
+		return
+	}
+
+	org, err := h.organizations.GetOrganizationByID(r.Context(), orgID)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de récupérer l'organisation")
+		return
+	}
+	if org.OwnerID != userID {
+		http.Error(w, "Seul le propriétaire peut supprimer l'organisation", http.StatusForbidden)
+		return
+	}
+
+	if !h.confirmer.confirm(w, r, orgID, userID, models.ActionDeleteOrganization, orgID) {
+		return
+	}
+
+	deletion, err := h.queue.Enqueue(r.Context(), orgID, userID)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de planifier la suppression de l'organisation")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Location", "/api/v1/organization-deletions/"+deletion.ID)
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(deletion)
+}
+
+// GetOrganizationDeletion renvoie l'avancement d'une suppression d'organisation.
+// Seul l'auteur de la demande peut la consulter ; les autres reçoivent 404.
+func (h *OrganizationsHandler) GetOrganizationDeletion(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserIDFromContext(r.Context())
+
+	deletion, err := h.deletions.GetOrganizationDeletion(r.Context(), mux.Vars(r)["deletionID"])
+	if err == nil && deletion.RequestedBy != userID {
+		err = storage.ErrDeletionNotFound
+	}
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			http.Error(w, "Suppression non trouvée", http.StatusNotFound)
+			return
+		}
+		apierror.Write(w, err, "Impossible de récupérer la suppression")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(deletion)
+}
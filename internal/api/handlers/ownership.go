@@ -0,0 +1,191 @@
+// filepath: internal/api/handlers/ownership.go
+
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+
+	"github.com/gorilla/mux"
+
+	"secrets-manager/internal/api/apierror"
+	"secrets-manager/internal/api/middleware"
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+	"secrets-manager/internal/vault"
+)
+
+// SetProjectOwnership désigne les responsables du projet (un membre et/ou
+// une équipe ; des champs vides les retirent). Réservé aux administrateurs.
+func (h *ProjectsHandler) SetProjectOwnership(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	orgID := vars["orgID"]
+	projectID := vars["projectID"]
+
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if err := h.policy.check(r.Context(), userID, orgID, projectID, secretAdmin); err != nil {
+		writePolicyError(w, err)
+		return
+	}
+
+	var ownership models.Ownership
+	if err := json.NewDecoder(r.Body).Decode(&ownership); err != nil {
+		http.Error(w, "Données invalides", http.StatusBadRequest)
+		return
+	}
+	if err := checkOwnership(r.Context(), h.users, h.teams, orgID, ownership); err != nil {
+		apierror.Write(w, err, "Impossible de vérifier les responsables")
+		return
+	}
+
+	if err := h.projects.SetProjectOwnership(r.Context(), orgID, projectID, ownership); err != nil {
+		apierror.Write(w, err, "Impossible de modifier les responsables du projet")
+		return
+	}
+	project, err := h.projects.GetProject(r.Context(), orgID, projectID)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de récupérer le projet")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(project); err != nil {
+		http.Error(w, "Erreur lors de l'encodage du projet", http.StatusInternalServerError)
+	}
+}
+
+// SetSecretOwnership désigne les responsables du secret ; sans responsable,
+// le secret relève de ceux de son projet. La valeur et la version du
+// secret ne changent pas.
+func (h *SecretsHandler) SetSecretOwnership(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	orgID := vars["orgID"]
+	projectID := vars["projectID"]
+	env := vars["env"]
+	name := vars["name"]
+
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if err := h.policy.checkEnvironment(r.Context(), userID, orgID, projectID, env, secretWrite); err != nil {
+		writePolicyError(w, err)
+		return
+	}
+
+	var ownership models.Ownership
+	if err := json.NewDecoder(r.Body).Decode(&ownership); err != nil {
+		http.Error(w, "Données invalides", http.StatusBadRequest)
+		return
+	}
+	if err := checkOwnership(r.Context(), h.policy.users, h.teams, orgID, ownership); err != nil {
+		apierror.Write(w, err, "Impossible de vérifier les responsables")
+		return
+	}
+
+	metadata, err := h.secrets.GetSecretMetadataByPath(r.Context(), orgID, projectID, env, name)
+	if err == nil && metadata == nil {
+		err = vault.ErrSecretNotFound
+	}
+	if err == nil {
+		err = h.secrets.SetSecretOwnership(r.Context(), metadata.ID, ownership)
+	}
+	if err != nil {
+		apierror.Write(w, err, "Impossible de modifier les responsables du secret")
+		return
+	}
+	metadata.Ownership = ownership
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(metadata); err != nil {
+		http.Error(w, "Erreur lors de l'encodage des métadonnées", http.StatusInternalServerError)
+	}
+}
+
+// filterSecretsByOwner ne garde que les secrets dont les responsables
+// satisfont les paramètres owner et team ; un secret sans responsable est
+// jugé sur ceux de son projet
+func (h *SecretsHandler) filterSecretsByOwner(r *http.Request, orgID, projectID, userID string,
+	metadata []*models.SecretMetadata) ([]*models.SecretMetadata, error) {
+	filter, err := parseOwnershipFilter(r, h.teams, orgID, userID)
+	if err != nil || filter == nil {
+		return metadata, err
+	}
+	project, err := h.projects.GetProject(r.Context(), orgID, projectID)
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(metadata, func(m *models.SecretMetadata) bool {
+		return !filter.match(m.Ownership.Or(project.Ownership))
+	}), nil
+}
+
+// checkOwnership vérifie que les responsables désignés appartiennent à
+// l'organisation : le membre responsable et l'équipe responsable
+func checkOwnership(ctx context.Context, users storage.UsersRepository, teams storage.TeamsRepository,
+	orgID string, ownership models.Ownership) error {
+	if ownership.OwnerID != "" {
+		if err := checkMember(ctx, users, orgID, ownership.OwnerID); err != nil {
+			return err
+		}
+	}
+	if ownership.TeamID != "" {
+		_, err := teams.GetTeam(ctx, orgID, ownership.TeamID)
+		if errors.Is(err, storage.ErrTeamNotFound) {
+			return apierror.Validation("Équipe " + ownership.TeamID + " inconnue")
+		}
+		return err
+	}
+	return nil
+}
+
+// ownershipFilter retient les projets ou secrets d'un responsable
+// (paramètre owner : me ou l'identifiant d'un membre) et/ou d'une équipe
+// (paramètre team : mine pour les équipes de l'utilisateur ou l'identifiant
+// d'une équipe)
+type ownershipFilter struct {
+	ownerID string
+	// teamIDs est nil sans paramètre team, vide si l'utilisateur n'a pas d'équipe
+	teamIDs []string
+}
+
+// parseOwnershipFilter lit les paramètres owner et team ; nil sans filtre
+func parseOwnershipFilter(r *http.Request, teams storage.TeamsRepository, orgID, userID string) (*ownershipFilter, error) {
+	owner, team := r.URL.Query().Get("owner"), r.URL.Query().Get("team")
+	if owner == "" && team == "" {
+		return nil, nil
+	}
+
+	filter := &ownershipFilter{ownerID: owner}
+	if owner == "me" {
+		filter.ownerID = userID
+	}
+	switch team {
+	case "":
+	case "mine":
+		mine, err := teams.ListUserTeams(r.Context(), orgID, userID)
+		if err != nil {
+			return nil, err
+		}
+		filter.teamIDs = []string{}
+		for _, t := range mine {
+			filter.teamIDs = append(filter.teamIDs, t.ID)
+		}
+	default:
+		filter.teamIDs = []string{team}
+	}
+	return filter, nil
+}
+
+// match indique si les responsables satisfont le filtre
+func (f *ownershipFilter) match(ownership models.Ownership) bool {
+	if f.ownerID != "" && ownership.OwnerID != f.ownerID {
+		return false
+	}
+	if f.teamIDs != nil && (ownership.TeamID == "" || !slices.Contains(f.teamIDs, ownership.TeamID)) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,134 @@
+// filepath: internal/api/handlers/password_policy.go
+
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"secrets-manager/internal/api/apierror"
+	"secrets-manager/internal/api/middleware"
+	"secrets-manager/internal/auth"
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// PasswordPolicyHandler expose la politique de mot de passe des
+// organisations : longueur minimale, classes de caractères, refus des mots
+// de passe présents dans les fuites connues et des anciens mots de passe
+type PasswordPolicyHandler struct {
+	authService *auth.Service
+	policies    storage.PasswordPoliciesRepository
+	users       storage.UsersRepository
+	history     storage.SettingsHistoryRepository
+}
+
+// NewPasswordPolicyHandler crée un nouveau gestionnaire des politiques de mot de passe
+func NewPasswordPolicyHandler(authService *auth.Service, policies storage.PasswordPoliciesRepository,
+	users storage.UsersRepository, history storage.SettingsHistoryRepository) *PasswordPolicyHandler {
+	return &PasswordPolicyHandler{
+		authService: authService,
+		policies:    policies,
+		users:       users,
+		history:     history,
+	}
+}
+
+// EffectivePasswordPolicy est la politique de l'organisation accompagnée de
+// celle qui s'applique à ses membres, renforcée par la politique du serveur
+type EffectivePasswordPolicy struct {
+	*models.PasswordPolicy
+	Effective *models.PasswordPolicy `json:"effective"`
+}
+
+// GetPolicy renvoie la politique de mot de passe de l'organisation à ses
+// membres (sans exigence si elle n'en a pas réglé) et la politique effective
+func (h *PasswordPolicyHandler) GetPolicy(w http.ResponseWriter, r *http.Request) {
+	orgID := mux.Vars(r)["orgID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if role, err := h.users.GetUserRole(r.Context(), userID, orgID); err != nil || role == "" {
+		http.Error(w, "Organisation non trouvée", http.StatusNotFound)
+		return
+	}
+
+	policy, err := h.policy(r, orgID)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de récupérer la politique de mot de passe")
+		return
+	}
+	effective, err := h.authService.PasswordPolicy(r.Context(), []string{orgID})
+	if err != nil {
+		apierror.Write(w, err, "Impossible de récupérer la politique de mot de passe")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(EffectivePasswordPolicy{PasswordPolicy: policy, Effective: effective})
+}
+
+// UpdatePolicy remplace la politique de mot de passe de l'organisation. Elle
+// s'applique aux prochains mots de passe choisis par ses membres, pas à
+// ceux qu'ils utilisent déjà.
+func (h *PasswordPolicyHandler) UpdatePolicy(w http.ResponseWriter, r *http.Request) {
+	orgID := mux.Vars(r)["orgID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if !requireOrgAdmin(w, r, h.users, orgID, userID) {
+		return
+	}
+
+	var policy models.PasswordPolicy
+	if err := json.NewDecoder(r.Body).Decode(&policy); err != nil {
+		http.Error(w, "Données invalides", http.StatusBadRequest)
+		return
+	}
+	if err := validatePasswordPolicy(&policy); err != nil {
+		apierror.Write(w, err, "")
+		return
+	}
+	before, err := h.policy(r, orgID)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de récupérer la politique de mot de passe")
+		return
+	}
+
+	policy.OrganizationID = orgID
+	policy.UpdatedAt = time.Now().UTC().Truncate(time.Second)
+	if err := h.policies.SavePasswordPolicy(r.Context(), &policy); err != nil {
+		apierror.Write(w, err, "Impossible d'enregistrer la politique de mot de passe")
+		return
+	}
+	recordSettingsChange(r.Context(), h.history, orgID, models.SettingsPasswordPolicy, orgID, models.SettingsUpdated,
+		before, &policy)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(&policy)
+}
+
+// validatePasswordPolicy vérifie les bornes des réglages
+func validatePasswordPolicy(policy *models.PasswordPolicy) error {
+	if policy.MinLength < 0 || policy.MinLength > models.MaxPasswordPolicyLength {
+		return apierror.Validation(fmt.Sprintf("min_length doit être compris entre 0 et %d",
+			models.MaxPasswordPolicyLength))
+	}
+	if policy.HistorySize < 0 || policy.HistorySize > models.MaxPasswordHistory {
+		return apierror.Validation(fmt.Sprintf("history_size doit être compris entre 0 et %d",
+			models.MaxPasswordHistory))
+	}
+	return nil
+}
+
+// policy renvoie la politique de l'organisation, sans exigence par défaut
+func (h *PasswordPolicyHandler) policy(r *http.Request, orgID string) (*models.PasswordPolicy, error) {
+	policy, err := h.policies.GetPasswordPolicy(r.Context(), orgID)
+	if errors.Is(err, storage.ErrNotFound) {
+		return &models.PasswordPolicy{OrganizationID: orgID}, nil
+	}
+	return policy, err
+}
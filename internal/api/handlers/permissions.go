@@ -0,0 +1,354 @@
+// filepath: internal/api/handlers/permissions.go
+
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+
+	"github.com/gorilla/mux"
+
+	"secrets-manager/internal/api/apierror"
+	"secrets-manager/internal/api/middleware"
+	"secrets-manager/internal/auth"
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// errPermissionSecretNotFound : le secret visé par l'explication n'existe pas
+var errPermissionSecretNotFound = errors.New("secret non trouvé")
+
+// PermissionsHandler explique l'accès effectif des membres, pour
+// comprendre un refus (403) sans lire le code de la politique, et simule
+// les décisions avant d'accorder un rôle
+type PermissionsHandler struct {
+	policy          *secretPolicy
+	projects        storage.ProjectsRepository
+	teams           storage.TeamsRepository
+	secrets         storage.SecretsRepository
+	serviceAccounts storage.ServiceAccountsRepository
+}
+
+// NewPermissionsHandler crée un nouveau gestionnaire des permissions effectives
+func NewPermissionsHandler(
+	users storage.UsersRepository,
+	projects storage.ProjectsRepository,
+	teams storage.TeamsRepository,
+	roles storage.CustomRolesRepository,
+	secrets storage.SecretsRepository,
+	serviceAccounts storage.ServiceAccountsRepository,
+) *PermissionsHandler {
+	return &PermissionsHandler{
+		policy:          &secretPolicy{users: users, projects: projects, teams: teams, roles: roles},
+		projects:        projects,
+		teams:           teams,
+		secrets:         secrets,
+		serviceAccounts: serviceAccounts,
+	}
+}
+
+// PermissionSimulationRequest décrit une action hypothétique : un principal
+// (membre ou compte de service), un verbe et une ressource, éventuellement
+// après des changements qui ne sont pas encore en place
+type PermissionSimulationRequest struct {
+	// PrincipalType vaut user (par défaut) ou service_account
+	PrincipalType string `json:"principal_type"`
+	PrincipalID   string `json:"principal_id"`
+	// Action vaut read, write ou admin
+	Action      string `json:"action"`
+	ProjectID   string `json:"project_id"`
+	Environment string `json:"environment"`
+	Secret      string `json:"secret"`
+	// Role simule un autre rôle du membre dans l'organisation
+	Role string `json:"role"`
+	// JoinTeams simule l'ajout du membre à des équipes
+	JoinTeams []string `json:"join_teams"`
+	// Grants simule des rôles accordés à des équipes ; ils ne s'appliquent
+	// qu'aux équipes dont le membre fait (ou ferait) partie
+	Grants []*models.TeamGrant `json:"grants"`
+}
+
+// GetMemberPermissions renvoie l'accès effectif d'un membre à l'organisation
+// ou, avec les paramètres project, env et secret, à un projet, un
+// environnement ou un secret, et les règles qui le déterminent. Un membre
+// consulte ses propres permissions ; les administrateurs, celles de tous.
+func (h *PermissionsHandler) GetMemberPermissions(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	orgID := vars["orgID"]
+	memberID := vars["userID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	role, err := h.policy.users.GetUserRole(r.Context(), userID, orgID)
+	if err != nil || role == "" {
+		http.Error(w, "Organisation non trouvée", http.StatusNotFound)
+		return
+	}
+	if memberID != userID && role != "admin" {
+		http.Error(w, "Accès refusé", http.StatusForbidden)
+		return
+	}
+	if role, err := h.policy.users.GetUserRole(r.Context(), memberID, orgID); err != nil || role == "" {
+		http.Error(w, "Membre non trouvé", http.StatusNotFound)
+		return
+	}
+
+	query := r.URL.Query()
+	projectID, env, name := query.Get("project"), query.Get("env"), query.Get("secret")
+	if !h.checkResource(w, r, orgID, projectID, env, name) {
+		return
+	}
+
+	access, err := h.policy.access(r.Context(), memberID, orgID)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de calculer les permissions")
+		return
+	}
+	permissions, err := h.explain(r.Context(), memberID, orgID, projectID, env, name, access)
+	if errors.Is(err, errPermissionSecretNotFound) {
+		http.Error(w, "Secret non trouvé", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		apierror.Write(w, err, "Impossible de calculer les permissions")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(permissions); err != nil {
+		http.Error(w, "Erreur lors de l'encodage des permissions", http.StatusInternalServerError)
+	}
+}
+
+// SimulatePermission évalue une action hypothétique comme la politique des
+// secrets le ferait, et renvoie la décision avec ses règles. Réservé aux
+// administrateurs ; rien n'est modifié.
+func (h *PermissionsHandler) SimulatePermission(w http.ResponseWriter, r *http.Request) {
+	orgID := mux.Vars(r)["orgID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if !requireOrgAdmin(w, r, h.policy.users, orgID, userID) {
+		return
+	}
+
+	var req PermissionSimulationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Données invalides", http.StatusBadRequest)
+		return
+	}
+	if req.PrincipalType == "" {
+		req.PrincipalType = middleware.PrincipalUser
+	}
+	switch req.Action {
+	case models.PermissionActionRead, models.PermissionActionWrite, models.PermissionActionAdmin:
+	default:
+		apierror.Write(w, apierror.Validation("Action invalide (read, write ou admin)"), "")
+		return
+	}
+	if req.Role != "" && !auth.RoleAtLeast(req.Role, auth.RoleViewer) {
+		apierror.Write(w, apierror.Validation("Rôle invalide (viewer, member ou admin)"), "")
+		return
+	}
+	if !h.checkResource(w, r, orgID, req.ProjectID, req.Environment, req.Secret) {
+		return
+	}
+
+	var access *memberAccess
+	var account *models.ServiceAccount
+	var err error
+	switch req.PrincipalType {
+	case middleware.PrincipalUser:
+		access, err = h.simulatedAccess(r.Context(), orgID, &req)
+	case middleware.PrincipalServiceAccount:
+		if req.Role != "" || len(req.JoinTeams) > 0 || len(req.Grants) > 0 {
+			err = apierror.Validation("Les changements simulés ne concernent que les membres")
+			break
+		}
+		account, err = h.serviceAccount(r.Context(), orgID, req.PrincipalID)
+		if err == nil {
+			// Comme auth.ServiceAccountClaims.Role
+			access = &memberAccess{role: auth.RoleViewer}
+			if account.Permission == models.APIKeyReadWrite {
+				access.role = auth.RoleMember
+			}
+		}
+	default:
+		err = apierror.Validation("Type de principal invalide (user ou service_account)")
+	}
+	if err != nil {
+		apierror.Write(w, err, "Impossible de simuler la décision")
+		return
+	}
+
+	permissions, err := h.explain(r.Context(), req.PrincipalID, orgID, req.ProjectID, req.Environment, req.Secret,
+		access)
+	if errors.Is(err, errPermissionSecretNotFound) {
+		http.Error(w, "Secret non trouvé", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		apierror.Write(w, err, "Impossible de simuler la décision")
+		return
+	}
+	if account != nil {
+		permissions.Rules[0].Source = models.PermissionSourceServiceAccount
+		permissions.Rules[0].Detail = "Rôle " + access.role + " d'après la permission du compte de service"
+		if req.ProjectID != "" && !coversResource(account.Resources, req.ProjectID, req.Environment) {
+			permissions.Deny(&models.PermissionRule{
+				Source:      models.PermissionSourceServiceAccount,
+				ProjectID:   req.ProjectID,
+				Environment: req.Environment,
+				Detail:      "Hors des ressources du compte de service",
+			})
+			permissions.CanRead, permissions.CanWrite, permissions.CanAdmin = false, false, false
+		}
+	}
+
+	decision := &models.PermissionDecision{
+		PrincipalType: req.PrincipalType,
+		PrincipalID:   req.PrincipalID,
+		Action:        req.Action,
+		Permissions:   permissions,
+	}
+	switch req.Action {
+	case models.PermissionActionRead:
+		decision.Allowed = permissions.CanRead
+	case models.PermissionActionWrite:
+		decision.Allowed = permissions.CanWrite
+	case models.PermissionActionAdmin:
+		decision.Allowed = permissions.CanAdmin
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(decision); err != nil {
+		http.Error(w, "Erreur lors de l'encodage de la décision", http.StatusInternalServerError)
+	}
+}
+
+// checkResource valide la ressource visée : un environnement exige un
+// projet de l'organisation, un secret exige un environnement
+func (h *PermissionsHandler) checkResource(w http.ResponseWriter, r *http.Request,
+	orgID, projectID, env, name string) bool {
+	if (env != "" && projectID == "") || (name != "" && env == "") {
+		apierror.Write(w, apierror.Validation("env exige project, et secret exige env"), "")
+		return false
+	}
+	if projectID != "" {
+		if _, err := h.projects.GetProject(r.Context(), orgID, projectID); err != nil {
+			apierror.Write(w, err, "Impossible de récupérer le projet")
+			return false
+		}
+	}
+	return true
+}
+
+// explain explique l'accès au projet, à l'environnement ou au secret name ;
+// un secret verrouillé refuse les modifications
+func (h *PermissionsHandler) explain(ctx context.Context, principalID, orgID, projectID, env, name string,
+	access *memberAccess) (*models.EffectivePermissions, error) {
+	permissions, err := h.policy.explain(ctx, principalID, orgID, projectID, env, access)
+	if err != nil || name == "" {
+		return permissions, err
+	}
+
+	metadata, err := h.secrets.GetSecretMetadataByPath(ctx, orgID, projectID, env, name)
+	if err != nil {
+		return nil, err
+	}
+	if metadata == nil {
+		return nil, errPermissionSecretNotFound
+	}
+	permissions.Secret = name
+	if metadata.IsLocked() {
+		permissions.Deny(&models.PermissionRule{
+			Source:      models.PermissionSourceSecretLock,
+			ProjectID:   projectID,
+			Environment: env,
+			Detail:      "Le secret est verrouillé : aucune modification avant son déverrouillage",
+		})
+		permissions.CanWrite = false
+	}
+	return permissions, nil
+}
+
+// simulatedAccess renvoie l'accès du membre après les changements simulés
+func (h *PermissionsHandler) simulatedAccess(ctx context.Context, orgID string,
+	req *PermissionSimulationRequest) (*memberAccess, error) {
+	if err := checkMember(ctx, h.policy.users, orgID, req.PrincipalID); err != nil {
+		return nil, err
+	}
+	access, err := h.policy.access(ctx, req.PrincipalID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if req.Role != "" {
+		access.role = req.Role
+		access.hypotheticalRole = true
+	}
+	if len(req.JoinTeams) == 0 && len(req.Grants) == 0 {
+		return access, nil
+	}
+
+	teams, err := h.teams.ListUserTeams(ctx, orgID, req.PrincipalID)
+	if err != nil {
+		return nil, err
+	}
+	memberOf := req.JoinTeams
+	for _, team := range teams {
+		memberOf = append(memberOf, team.ID)
+	}
+	for _, teamID := range req.JoinTeams {
+		team, err := h.teams.GetTeam(ctx, orgID, teamID)
+		if err != nil {
+			return nil, err
+		}
+		// Les octrois de l'équipe rejointe s'appliquent, sauf si le membre
+		// en fait déjà partie
+		if !slices.ContainsFunc(teams, func(t *models.Team) bool { return t.ID == teamID }) {
+			access.hypotheticalGrants = append(access.hypotheticalGrants, team.Grants...)
+		}
+	}
+	for _, grant := range req.Grants {
+		if !auth.RoleAtLeast(grant.Role, auth.RoleViewer) {
+			return nil, apierror.Validation("Rôle invalide (viewer, member ou admin)")
+		}
+		if _, err := h.teams.GetTeam(ctx, orgID, grant.TeamID); err != nil {
+			return nil, err
+		}
+		if slices.Contains(memberOf, grant.TeamID) {
+			access.hypotheticalGrants = append(access.hypotheticalGrants, grant)
+		}
+	}
+	return access, nil
+}
+
+// serviceAccount renvoie un compte de service de l'organisation
+func (h *PermissionsHandler) serviceAccount(ctx context.Context, orgID, id string) (*models.ServiceAccount, error) {
+	account, err := h.serviceAccounts.GetServiceAccount(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if account.OrganizationID != orgID {
+		return nil, storage.ErrServiceAccountNotFound
+	}
+	return account, nil
+}
+
+// coversResource indique si les ressources d'un compte de service couvrent
+// le projet (env vide) ou l'environnement
+func coversResource(resources []models.ResourceScope, projectID, env string) bool {
+	if env == "" {
+		return models.CoversProject(resources, projectID)
+	}
+	return models.CoversEnvironment(resources, projectID, env)
+}
+
+// resourcesCover indique si les ressources du token ou du compte de service
+// de la requête couvrent le projet (env vide) ou l'environnement ; les
+// sessions ne sont pas restreintes
+func resourcesCover(ctx context.Context, projectID, env string) bool {
+	resources, ok := middleware.ResourceScopesFromContext(ctx)
+	return !ok || coversResource(resources, projectID, env)
+}
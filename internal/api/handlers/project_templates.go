@@ -0,0 +1,273 @@
+// filepath: internal/api/handlers/project_templates.go
+
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"secrets-manager/internal/api/apierror"
+	"secrets-manager/internal/api/middleware"
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// Noms acceptés pour les environnements et les secrets d'un modèle de projet
+var templateName = regexp.MustCompile(`^[A-Za-z0-9_.-]{1,64}$`)
+
+// ProjectTemplatesHandler expose le modèle de projet des organisations et
+// crée les projets selon ce modèle. Il réutilise les dépendances du
+// gestionnaire de secrets pour créer les secrets à renseigner.
+type ProjectTemplatesHandler struct {
+	*SecretsHandler
+	templates storage.ProjectTemplatesRepository
+	users     storage.UsersRepository
+	history   storage.SettingsHistoryRepository
+}
+
+// NewProjectTemplatesHandler crée un nouveau gestionnaire des modèles de projet
+func NewProjectTemplatesHandler(
+	secrets *SecretsHandler,
+	templates storage.ProjectTemplatesRepository,
+	users storage.UsersRepository,
+	history storage.SettingsHistoryRepository,
+) *ProjectTemplatesHandler {
+	return &ProjectTemplatesHandler{
+		SecretsHandler: secrets,
+		templates:      templates,
+		users:          users,
+		history:        history,
+	}
+}
+
+// ProjectRequest est le corps de la création d'un projet
+type ProjectRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// GetTemplate renvoie le modèle de projet de l'organisation à ses membres
+// (models.DefaultProjectTemplate si elle n'en a pas réglé)
+func (h *ProjectTemplatesHandler) GetTemplate(w http.ResponseWriter, r *http.Request) {
+	orgID := mux.Vars(r)["orgID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if role, err := h.users.GetUserRole(r.Context(), userID, orgID); err != nil || role == "" {
+		http.Error(w, "Organisation non trouvée", http.StatusNotFound)
+		return
+	}
+
+	template, err := h.template(r, orgID)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de récupérer le modèle de projet")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(template)
+}
+
+// UpdateTemplate remplace le modèle de projet de l'organisation. Il
+// s'applique aux projets créés ensuite ; les projets existants ne sont pas
+// modifiés.
+func (h *ProjectTemplatesHandler) UpdateTemplate(w http.ResponseWriter, r *http.Request) {
+	orgID := mux.Vars(r)["orgID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if !requireOrgAdmin(w, r, h.users, orgID, userID) {
+		return
+	}
+
+	var template models.ProjectTemplate
+	if err := json.NewDecoder(r.Body).Decode(&template); err != nil {
+		http.Error(w, "Données invalides", http.StatusBadRequest)
+		return
+	}
+	if err := normalizeProjectTemplate(&template); err != nil {
+		apierror.Write(w, err, "")
+		return
+	}
+	before, err := h.template(r, orgID)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de récupérer le modèle de projet")
+		return
+	}
+
+	template.OrganizationID = orgID
+	template.UpdatedAt = time.Now().UTC().Truncate(time.Second)
+	if err := h.templates.SaveProjectTemplate(r.Context(), &template); err != nil {
+		apierror.Write(w, err, "Impossible d'enregistrer le modèle de projet")
+		return
+	}
+	recordSettingsChange(r.Context(), h.history, orgID, models.SettingsProjectTemplate, orgID, models.SettingsUpdated,
+		before, &template)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(&template)
+}
+
+// CreateProject crée un projet selon le modèle de l'organisation : son nom
+// doit respecter la règle de nommage (400 sinon), ses environnements sont
+// créés dans la même transaction, puis les secrets à renseigner, sans
+// valeur. Réservé aux administrateurs et aux membres ; les non-membres
+// reçoivent 404. Un token restreint à des projets ne peut pas en créer.
+func (h *ProjectTemplatesHandler) CreateProject(w http.ResponseWriter, r *http.Request) {
+	orgID := mux.Vars(r)["orgID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	role, err := h.users.GetUserRole(r.Context(), userID, orgID)
+	if err != nil || role == "" {
+		http.Error(w, "Organisation non trouvée", http.StatusNotFound)
+		return
+	}
+	if role == "viewer" {
+		http.Error(w, "Accès refusé", http.StatusForbidden)
+		return
+	}
+	if _, restricted := middleware.ResourceScopesFromContext(r.Context()); restricted {
+		http.Error(w, "Un token restreint à des projets ne peut pas en créer", http.StatusForbidden)
+		return
+	}
+
+	var req ProjectRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Données invalides", http.StatusBadRequest)
+		return
+	}
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		apierror.Write(w, apierror.Validation("Nom du projet requis"), "")
+		return
+	}
+
+	template, err := h.template(r, orgID)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de récupérer le modèle de projet")
+		return
+	}
+	if !template.MatchesName(req.Name) {
+		apierror.Write(w, apierror.Validation(fmt.Sprintf("Le nom du projet doit respecter la règle de nommage %q",
+			template.NamePattern)), "")
+		return
+	}
+
+	project := &models.TemplatedProject{
+		Project: &models.Project{
+			Name:           req.Name,
+			Description:    req.Description,
+			OrganizationID: orgID,
+			CreatedBy:      userID,
+		},
+		Placeholders: []*models.SecretMetadata{},
+	}
+	for _, name := range template.Environments {
+		project.Environments = append(project.Environments, &models.Environment{Name: name})
+	}
+	if err := h.projects.CreateProjectWithEnvironments(r.Context(), project.Project, project.Environments); err != nil {
+		apierror.Write(w, err, "Impossible de créer le projet")
+		return
+	}
+
+	for _, env := range template.Environments {
+		for _, placeholder := range template.PlaceholdersFor(env) {
+			secret := &models.Secret{
+				Name:           placeholder.Name,
+				Description:    placeholder.Description,
+				OrganizationID: orgID,
+				ProjectID:      project.ID,
+				Environment:    env,
+				CreatedBy:      userID,
+			}
+			if err := h.vaultService.StoreSecret(r.Context(), secret); err != nil {
+				apierror.Write(w, err, "Impossible de créer les secrets à renseigner")
+				return
+			}
+			metadata, err := h.createMetadata(r, secret)
+			if err != nil {
+				apierror.Write(w, err, "Impossible d'enregistrer les secrets à renseigner")
+				return
+			}
+			project.Placeholders = append(project.Placeholders, metadata)
+		}
+	}
+	if len(project.Placeholders) > 0 {
+		h.checkQuota(r, orgID)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(project)
+}
+
+// normalizeProjectTemplate vérifie le modèle et retire les espaces des noms
+func normalizeProjectTemplate(template *models.ProjectTemplate) error {
+	environments := []string{}
+	for _, env := range template.Environments {
+		env = strings.TrimSpace(env)
+		if !templateName.MatchString(env) {
+			return apierror.Validation(fmt.Sprintf("Nom d'environnement invalide : %q", env))
+		}
+		if slices.Contains(environments, env) {
+			return apierror.Validation(fmt.Sprintf("Environnement en double : %s", env))
+		}
+		environments = append(environments, env)
+	}
+	if len(environments) == 0 || len(environments) > models.MaxTemplateEnvironments {
+		return apierror.Validation(fmt.Sprintf("Le modèle doit compter entre 1 et %d environnements",
+			models.MaxTemplateEnvironments))
+	}
+	template.Environments = environments
+
+	template.NamePattern = strings.TrimSpace(template.NamePattern)
+	if len(template.NamePattern) > models.MaxTemplateNamePattern {
+		return apierror.Validation(fmt.Sprintf("name_pattern ne doit pas dépasser %d caractères",
+			models.MaxTemplateNamePattern))
+	}
+	if _, err := regexp.Compile(template.NamePattern); err != nil {
+		return apierror.Validation("name_pattern n'est pas une expression régulière valide")
+	}
+
+	if len(template.Placeholders) > models.MaxTemplatePlaceholders {
+		return apierror.Validation(fmt.Sprintf("%d secrets à renseigner au plus", models.MaxTemplatePlaceholders))
+	}
+	names := make(map[string]bool)
+	for i := range template.Placeholders {
+		placeholder := &template.Placeholders[i]
+		placeholder.Name = strings.TrimSpace(placeholder.Name)
+		if !templateName.MatchString(placeholder.Name) {
+			return apierror.Validation(fmt.Sprintf("Nom de secret invalide : %q", placeholder.Name))
+		}
+		if names[placeholder.Name] {
+			return apierror.Validation(fmt.Sprintf("Secret en double : %s", placeholder.Name))
+		}
+		names[placeholder.Name] = true
+		for _, env := range placeholder.Environments {
+			if !slices.Contains(environments, env) {
+				return apierror.Validation(fmt.Sprintf("Le secret %s vise un environnement absent du modèle : %s",
+					placeholder.Name, env))
+			}
+		}
+	}
+	if template.Placeholders == nil {
+		template.Placeholders = []models.PlaceholderSecret{}
+	}
+	return nil
+}
+
+// template renvoie le modèle de l'organisation, celui par défaut si elle
+// n'en a pas réglé
+func (h *ProjectTemplatesHandler) template(r *http.Request, orgID string) (*models.ProjectTemplate, error) {
+	template, err := h.templates.GetProjectTemplate(r.Context(), orgID)
+	if errors.Is(err, storage.ErrNotFound) {
+		return models.DefaultProjectTemplate(orgID), nil
+	}
+	return template, err
+}
@@ -0,0 +1,142 @@
+// filepath: internal/api/handlers/projects.go
+
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"secrets-manager/internal/api/apierror"
+	"secrets-manager/internal/api/middleware"
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// ProjectsHandler gère les routes liées aux projets
+type ProjectsHandler struct {
+	projects  storage.ProjectsRepository
+	users     storage.UsersRepository
+	teams     storage.TeamsRepository
+	policy    *secretPolicy
+	confirmer *Confirmer
+	retention time.Duration
+}
+
+// NewProjectsHandler crée un nouveau gestionnaire de projets.
+// retention est la durée de séjour des projets supprimés dans la corbeille.
+func NewProjectsHandler(
+	projects storage.ProjectsRepository,
+	users storage.UsersRepository,
+	teams storage.TeamsRepository,
+	confirmer *Confirmer,
+	retention time.Duration,
+) *ProjectsHandler {
+	return &ProjectsHandler{
+		projects:  projects,
+		users:     users,
+		teams:     teams,
+		policy:    &secretPolicy{users: users, projects: projects, teams: teams},
+		confirmer: confirmer,
+		retention: retention,
+	}
+}
+
+// ListProjects liste les projets d'une organisation avec leur nombre de
+// secrets, leurs environnements, leur dernière activité et leurs README.
+// Les paramètres owner (me ou un membre) et team (mine ou une équipe) ne
+// gardent que les projets de ces responsables. Les non-membres reçoivent 404.
+// Un token restreint à des projets ne liste que ceux-ci.
+func (h *ProjectsHandler) ListProjects(w http.ResponseWriter, r *http.Request) {
+	orgID := mux.Vars(r)["orgID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if role, err := h.users.GetUserRole(r.Context(), userID, orgID); err != nil || role == "" {
+		http.Error(w, "Organisation non trouvée", http.StatusNotFound)
+		return
+	}
+
+	projects, err := h.projects.ListProjectSummaries(r.Context(), orgID)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de lister les projets")
+		return
+	}
+	readmes, err := h.projects.ListReadmes(r.Context(), orgID)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de lister les README")
+		return
+	}
+	attachReadmes(projects, readmes)
+
+	filter, err := parseOwnershipFilter(r, h.teams, orgID, userID)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de lister les équipes")
+		return
+	}
+	if filter != nil {
+		projects = slices.DeleteFunc(projects, func(project *models.ProjectSummary) bool {
+			return !filter.match(project.Ownership)
+		})
+	}
+	// Un token restreint à des projets ne voit que ceux-ci
+	if resources, ok := middleware.ResourceScopesFromContext(r.Context()); ok {
+		projects = slices.DeleteFunc(projects, func(project *models.ProjectSummary) bool {
+			return !models.CoversProject(resources, project.ID)
+		})
+	}
+
+	// Dernière activité connue, reprise par middleware.Cacheable
+	var lastActivity time.Time
+	for _, project := range projects {
+		if project.LastActivityAt.After(lastActivity) {
+			lastActivity = project.LastActivityAt
+		}
+	}
+	if !lastActivity.IsZero() {
+		w.Header().Set("Last-Modified", lastActivity.UTC().Format(http.TimeFormat))
+	}
+
+	writeJSONList(w, r, projects)
+}
+
+// DeleteProject place un projet dans la corbeille après confirmation. Ses
+// secrets (valeurs dans Vault et métadonnées) sont conservés jusqu'à la purge
+// et le projet peut être restauré par un administrateur de la plateforme.
+func (h *ProjectsHandler) DeleteProject(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	orgID := vars["orgID"]
+	projectID := vars["projectID"]
+
+	userID := middleware.UserIDFromContext(r.Context())
+
+	// Seuls les administrateurs de l'organisation suppriment un projet
+	if err := h.policy.check(r.Context(), userID, orgID, projectID, secretAdmin); err != nil {
+		writePolicyError(w, err)
+		return
+	}
+
+	project, err := h.projects.GetProject(r.Context(), orgID, projectID)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de récupérer le projet")
+		return
+	}
+
+	if !h.confirmer.confirm(w, r, orgID, userID, models.ActionDeleteProject, projectID) {
+		return
+	}
+
+	if err := h.projects.SuspendProject(r.Context(), orgID, projectID); err != nil {
+		apierror.Write(w, err, "Impossible de supprimer le projet")
+		return
+	}
+
+	deletedAt := time.Now()
+	project.DeletedAt = &deletedAt
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(projectRecycleBinEntry(project, h.retention))
+}
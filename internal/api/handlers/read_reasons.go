@@ -0,0 +1,223 @@
+// filepath: internal/api/handlers/read_reasons.go
+
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/gorilla/mux"
+
+	"secrets-manager/internal/api/apierror"
+	"secrets-manager/internal/api/middleware"
+	"secrets-manager/internal/auth"
+	"secrets-manager/internal/logging"
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// ReadReasonParam est le paramètre de requête qui porte le motif d'une lecture
+const ReadReasonParam = "reason"
+
+const (
+	// maxProtectedEnvironments est le nombre maximal d'environnements protégés
+	maxProtectedEnvironments = 20
+	// auditLogsLimit est le nombre d'entrées renvoyées par /audit-logs
+	auditLogsLimit = 200
+)
+
+// readReason renvoie le motif de lecture donné par le paramètre reason. Il
+// est obligatoire pour un utilisateur qui lit les secrets d'un environnement
+// protégé de l'organisation ; les autres lectures peuvent en donner un.
+func (h *SecretsHandler) readReason(r *http.Request, orgID, env string) (string, error) {
+	reason := strings.TrimSpace(r.URL.Query().Get(ReadReasonParam))
+	if len(reason) > models.MaxReadReasonLength {
+		return "", apierror.Validation("Paramètre reason trop long (500 caractères au plus)")
+	}
+	if strings.ContainsFunc(reason, unicode.IsControl) {
+		return "", apierror.Validation("Paramètre reason invalide (caractères de contrôle)")
+	}
+	principal, ok := middleware.PrincipalFromContext(r.Context())
+	if reason != "" || h.readPolicies == nil || !ok || principal.Type != middleware.PrincipalUser {
+		return reason, nil
+	}
+
+	policy, err := h.readPolicies.GetReadReasonPolicy(r.Context(), orgID)
+	if errors.Is(err, storage.ErrNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	if policy.Requires(env) {
+		return "", apierror.Validation("Environnement protégé : le motif de la lecture est requis (paramètre reason)")
+	}
+	return "", nil
+}
+
+// auditReads enregistre dans le journal d'audit la lecture motivée des
+// valeurs des secrets de l'environnement de la requête. Un échec est
+// journalisé sans faire échouer la lecture.
+func (h *SecretsHandler) auditReads(r *http.Request, reason string, secrets []*models.Secret) {
+	userID := middleware.UserIDFromContext(r.Context())
+	if h.audit == nil || reason == "" || userID == "" || len(secrets) == 0 {
+		return
+	}
+
+	vars := mux.Vars(r)
+	now := time.Now()
+	logs := make([]*models.AuditLog, 0, len(secrets))
+	for _, secret := range secrets {
+		logs = append(logs, &models.AuditLog{
+			UserID:         userID,
+			OrganizationID: vars["orgID"],
+			Action:         models.AuditActionRead,
+			ResourceType:   "secret",
+			ResourceID:     vars["projectID"] + "/" + vars["env"] + "/" + secret.Name,
+			Timestamp:      now,
+			IPAddress:      middleware.ClientIP(r),
+			UserAgent:      r.UserAgent(),
+			Reason:         reason,
+		})
+	}
+	if err := h.audit.CreateAuditLogs(context.WithoutCancel(r.Context()), logs); err != nil {
+		logging.For(logging.ComponentHTTP).Error("échec de l'audit des lectures motivées",
+			"organization_id", vars["orgID"], "user_id", userID, "error", err)
+	}
+}
+
+// ReadReasonsHandler expose les environnements protégés des organisations
+// et le journal d'audit des lectures motivées
+type ReadReasonsHandler struct {
+	policies storage.ReadReasonPoliciesRepository
+	audit    storage.AuditLogsRepository
+	users    storage.UsersRepository
+	history  storage.SettingsHistoryRepository
+	// permissions autorise la lecture du journal d'audit (audit.read)
+	permissions *auth.PermissionService
+}
+
+// NewReadReasonsHandler crée un nouveau gestionnaire des motifs de lecture
+func NewReadReasonsHandler(policies storage.ReadReasonPoliciesRepository, audit storage.AuditLogsRepository,
+	users storage.UsersRepository, history storage.SettingsHistoryRepository,
+	permissions *auth.PermissionService) *ReadReasonsHandler {
+	return &ReadReasonsHandler{
+		policies:    policies,
+		audit:       audit,
+		users:       users,
+		history:     history,
+		permissions: permissions,
+	}
+}
+
+// GetPolicy renvoie les environnements protégés de l'organisation à ses membres
+func (h *ReadReasonsHandler) GetPolicy(w http.ResponseWriter, r *http.Request) {
+	orgID := mux.Vars(r)["orgID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if role, err := h.users.GetUserRole(r.Context(), userID, orgID); err != nil || role == "" {
+		http.Error(w, "Organisation non trouvée", http.StatusNotFound)
+		return
+	}
+
+	policy, err := h.policy(r, orgID)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de récupérer les environnements protégés")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(policy)
+}
+
+// UpdatePolicy remplace les environnements protégés de l'organisation
+// (liste vide : aucun motif n'est exigé)
+func (h *ReadReasonsHandler) UpdatePolicy(w http.ResponseWriter, r *http.Request) {
+	orgID := mux.Vars(r)["orgID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if !requireOrgAdmin(w, r, h.users, orgID, userID) {
+		return
+	}
+
+	var policy models.ReadReasonPolicy
+	if err := json.NewDecoder(r.Body).Decode(&policy); err != nil {
+		http.Error(w, "Données invalides", http.StatusBadRequest)
+		return
+	}
+	environments := []string{}
+	for _, env := range policy.Environments {
+		env = strings.TrimSpace(env)
+		if env == "" {
+			apierror.Write(w, apierror.Validation("Nom d'environnement requis"), "")
+			return
+		}
+		if !slices.Contains(environments, env) {
+			environments = append(environments, env)
+		}
+	}
+	if len(environments) > maxProtectedEnvironments {
+		apierror.Write(w, apierror.Validation("20 environnements protégés au plus"), "")
+		return
+	}
+	before, err := h.policy(r, orgID)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de récupérer les environnements protégés")
+		return
+	}
+
+	slices.Sort(environments)
+	policy.OrganizationID = orgID
+	policy.Environments = environments
+	policy.UpdatedAt = time.Now().UTC().Truncate(time.Second)
+	if err := h.policies.SaveReadReasonPolicy(r.Context(), &policy); err != nil {
+		apierror.Write(w, err, "Impossible d'enregistrer les environnements protégés")
+		return
+	}
+	recordSettingsChange(r.Context(), h.history, orgID, models.SettingsReadReasons, orgID, models.SettingsUpdated,
+		before, &policy)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(&policy)
+}
+
+// ListAuditLogs liste aux membres ayant la permission audit.read les
+// dernières entrées du journal d'audit de l'organisation sur la période
+// (paramètre days, 30 jours par défaut)
+func (h *ReadReasonsHandler) ListAuditLogs(w http.ResponseWriter, r *http.Request) {
+	orgID := mux.Vars(r)["orgID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if !requirePermission(w, r, h.permissions, orgID, userID, models.PermissionAuditRead) {
+		return
+	}
+	since, err := readsSince(r)
+	if err != nil {
+		apierror.Write(w, err, "")
+		return
+	}
+
+	logs, err := h.audit.ListAuditLogs(r.Context(), orgID, since, auditLogsLimit)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de lister le journal d'audit")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(logs)
+}
+
+// policy renvoie la politique de l'organisation, sans environnement protégé par défaut
+func (h *ReadReasonsHandler) policy(r *http.Request, orgID string) (*models.ReadReasonPolicy, error) {
+	policy, err := h.policies.GetReadReasonPolicy(r.Context(), orgID)
+	if errors.Is(err, storage.ErrNotFound) {
+		return &models.ReadReasonPolicy{OrganizationID: orgID, Environments: []string{}}, nil
+	}
+	return policy, err
+}
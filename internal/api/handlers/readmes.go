@@ -0,0 +1,148 @@
+// filepath: internal/api/handlers/readmes.go
+
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"secrets-manager/internal/api/apierror"
+	"secrets-manager/internal/api/middleware"
+	"secrets-manager/internal/models"
+)
+
+// ReadmeRequest remplace le contenu d'un README
+type ReadmeRequest struct {
+	Content string `json:"content"`
+}
+
+// GetReadme renvoie le README du projet ou, sur la route d'un
+// environnement, celui de l'environnement. 404 s'il n'existe pas.
+func (h *ProjectsHandler) GetReadme(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	orgID := vars["orgID"]
+	projectID := vars["projectID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if err := h.policy.checkEnvironment(r.Context(), userID, orgID, projectID, vars["env"], secretRead); err != nil {
+		writePolicyError(w, err)
+		return
+	}
+	if _, err := h.projects.GetProject(r.Context(), orgID, projectID); err != nil {
+		apierror.Write(w, err, "Impossible de récupérer le projet")
+		return
+	}
+
+	readme, err := h.projects.GetReadme(r.Context(), projectID, vars["env"])
+	if err != nil {
+		apierror.Write(w, err, "Impossible de récupérer le README")
+		return
+	}
+	if readme == nil {
+		http.Error(w, "README non trouvé", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(readme)
+}
+
+// SetReadme crée ou remplace le README du projet ou d'un environnement.
+// Réservé aux membres qui peuvent modifier les secrets.
+func (h *ProjectsHandler) SetReadme(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	orgID := vars["orgID"]
+	projectID := vars["projectID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if err := h.policy.checkEnvironment(r.Context(), userID, orgID, projectID, vars["env"], secretWrite); err != nil {
+		writePolicyError(w, err)
+		return
+	}
+	if _, err := h.projects.GetProject(r.Context(), orgID, projectID); err != nil {
+		apierror.Write(w, err, "Impossible de récupérer le projet")
+		return
+	}
+
+	var req ReadmeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Données invalides", http.StatusBadRequest)
+		return
+	}
+	if req.Content == "" {
+		apierror.Write(w, apierror.Validation("content est requis (DELETE supprime le README)"), "")
+		return
+	}
+	if len(req.Content) > models.MaxReadmeLength {
+		apierror.Write(w, apierror.Validation(
+			fmt.Sprintf("content ne doit pas dépasser %d octets", models.MaxReadmeLength)), "")
+		return
+	}
+
+	readme := &models.Readme{
+		OrganizationID: orgID,
+		ProjectID:      projectID,
+		Environment:    vars["env"],
+		Content:        req.Content,
+		UpdatedBy:      userID,
+		UpdatedAt:      time.Now(),
+	}
+	if err := h.projects.SetReadme(r.Context(), readme); err != nil {
+		apierror.Write(w, err, "Impossible d'enregistrer le README")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(readme)
+}
+
+// DeleteReadme supprime le README du projet ou d'un environnement
+func (h *ProjectsHandler) DeleteReadme(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	orgID := vars["orgID"]
+	projectID := vars["projectID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if err := h.policy.checkEnvironment(r.Context(), userID, orgID, projectID, vars["env"], secretWrite); err != nil {
+		writePolicyError(w, err)
+		return
+	}
+	if _, err := h.projects.GetProject(r.Context(), orgID, projectID); err != nil {
+		apierror.Write(w, err, "Impossible de récupérer le projet")
+		return
+	}
+
+	if err := h.projects.DeleteReadme(r.Context(), projectID, vars["env"]); err != nil {
+		apierror.Write(w, err, "Impossible de supprimer le README")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// attachReadmes joint aux projets listés leur README et ceux de leurs
+// environnements ; une modification de README compte comme une activité
+func attachReadmes(projects []*models.ProjectSummary, readmes []*models.Readme) {
+	byProject := make(map[string]*models.ProjectSummary, len(projects))
+	for _, project := range projects {
+		byProject[project.ID] = project
+	}
+	for _, readme := range readmes {
+		project, ok := byProject[readme.ProjectID]
+		if !ok {
+			continue
+		}
+		project.Touch(readme.UpdatedAt)
+		if readme.Environment == "" {
+			project.Readme = readme
+			continue
+		}
+		if project.EnvironmentReadmes == nil {
+			project.EnvironmentReadmes = make(map[string]*models.Readme)
+		}
+		project.EnvironmentReadmes[readme.Environment] = readme
+	}
+}
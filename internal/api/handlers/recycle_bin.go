@@ -0,0 +1,149 @@
+// filepath: internal/api/handlers/recycle_bin.go
+
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"secrets-manager/internal/api/apierror"
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// Types d'éléments de la corbeille
+const (
+	RecycleBinOrganization = "organization"
+	RecycleBinProject      = "project"
+)
+
+// RecycleBinEntry décrit une organisation ou un projet de la corbeille
+type RecycleBinEntry struct {
+	Type           string    `json:"type"`
+	ID             string    `json:"id"`
+	OrganizationID string    `json:"organization_id"`
+	Name           string    `json:"name"`
+	DeletedAt      time.Time `json:"deleted_at"`
+	PurgeAfter     time.Time `json:"purge_after"`
+	// Restorable est faux dès que la purge définitive a commencé
+	Restorable bool `json:"restorable"`
+}
+
+// OrganizationRestorer sort une organisation de la corbeille avant sa purge
+type OrganizationRestorer interface {
+	Restore(ctx context.Context, orgID string) (*models.OrganizationDeletion, error)
+}
+
+// RecycleBinHandler expose la corbeille aux administrateurs de la plateforme
+type RecycleBinHandler struct {
+	organizations storage.OrganizationsRepository
+	deletions     storage.OrganizationDeletionsRepository
+	restorer      OrganizationRestorer
+	projects      storage.ProjectsRepository
+	retention     time.Duration
+}
+
+// NewRecycleBinHandler crée un nouveau gestionnaire de corbeille.
+// retention est la durée de séjour des projets dans la corbeille.
+func NewRecycleBinHandler(
+	organizations storage.OrganizationsRepository,
+	deletions storage.OrganizationDeletionsRepository,
+	restorer OrganizationRestorer,
+	projects storage.ProjectsRepository,
+	retention time.Duration,
+) *RecycleBinHandler {
+	return &RecycleBinHandler{
+		organizations: organizations,
+		deletions:     deletions,
+		restorer:      restorer,
+		projects:      projects,
+		retention:     retention,
+	}
+}
+
+// ListRecycleBin liste les organisations puis les projets de la corbeille
+func (h *RecycleBinHandler) ListRecycleBin(w http.ResponseWriter, r *http.Request) {
+	orgs, err := h.organizations.ListSuspendedOrganizations(r.Context())
+	if err != nil {
+		apierror.Write(w, err, "Impossible de lister les organisations supprimées")
+		return
+	}
+	unfinished, err := h.deletions.ListUnfinishedOrganizationDeletions(r.Context())
+	if err != nil {
+		apierror.Write(w, err, "Impossible de lister les suppressions en cours")
+		return
+	}
+	deletions := make(map[string]*models.OrganizationDeletion, len(unfinished))
+	for _, deletion := range unfinished {
+		deletions[deletion.OrganizationID] = deletion
+	}
+
+	projects, err := h.projects.ListSuspendedProjects(r.Context(), time.Now())
+	if err != nil {
+		apierror.Write(w, err, "Impossible de lister les projets supprimés")
+		return
+	}
+
+	entries := make([]RecycleBinEntry, 0, len(orgs)+len(projects))
+	for _, org := range orgs {
+		entry := RecycleBinEntry{
+			Type:           RecycleBinOrganization,
+			ID:             org.ID,
+			OrganizationID: org.ID,
+			Name:           org.Name,
+			DeletedAt:      *org.DeletedAt,
+		}
+		if deletion, ok := deletions[org.ID]; ok {
+			entry.PurgeAfter = deletion.PurgeAfter
+			entry.Restorable = deletion.Restorable()
+		}
+		entries = append(entries, entry)
+	}
+	for _, project := range projects {
+		entries = append(entries, projectRecycleBinEntry(project, h.retention))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(entries); err != nil {
+		http.Error(w, "Erreur lors de l'encodage de la corbeille", http.StatusInternalServerError)
+	}
+}
+
+// RestoreOrganization restaure une organisation, ses membres, projets et
+// secrets, tant que sa purge n'a pas commencé
+func (h *RecycleBinHandler) RestoreOrganization(w http.ResponseWriter, r *http.Request) {
+	if _, err := h.restorer.Restore(r.Context(), mux.Vars(r)["orgID"]); err != nil {
+		apierror.Write(w, err, "Impossible de restaurer l'organisation")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RestoreProject restaure un projet et ses secrets
+func (h *RecycleBinHandler) RestoreProject(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	if err := h.projects.RestoreProject(r.Context(), vars["orgID"], vars["projectID"]); err != nil {
+		apierror.Write(w, err, "Impossible de restaurer le projet")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// projectRecycleBinEntry décrit un projet suspendu
+func projectRecycleBinEntry(project *models.Project, retention time.Duration) RecycleBinEntry {
+	return RecycleBinEntry{
+		Type:           RecycleBinProject,
+		ID:             project.ID,
+		OrganizationID: project.OrganizationID,
+		Name:           project.Name,
+		DeletedAt:      *project.DeletedAt,
+		PurgeAfter:     project.DeletedAt.Add(retention),
+		Restorable:     true,
+	}
+}
@@ -0,0 +1,123 @@
+// filepath: internal/api/handlers/registration_policy.go
+
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"secrets-manager/internal/api/apierror"
+	"secrets-manager/internal/auth"
+	"secrets-manager/internal/logging"
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// RegistrationPolicyHandler règle depuis le listener d'administration qui
+// peut s'inscrire librement sur le serveur
+type RegistrationPolicyHandler struct {
+	authService *auth.Service
+	policies    storage.RegistrationPolicyRepository
+}
+
+// NewRegistrationPolicyHandler crée un nouveau gestionnaire de la politique d'inscription
+func NewRegistrationPolicyHandler(authService *auth.Service,
+	policies storage.RegistrationPolicyRepository) *RegistrationPolicyHandler {
+	return &RegistrationPolicyHandler{
+		authService: authService,
+		policies:    policies,
+	}
+}
+
+// GetPolicy renvoie la politique d'inscription en vigueur
+func (h *RegistrationPolicyHandler) GetPolicy(w http.ResponseWriter, r *http.Request) {
+	policy, err := h.authService.RegistrationPolicy(r.Context())
+	if err != nil {
+		apierror.Write(w, err, "Impossible de récupérer la politique d'inscription")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(policy)
+}
+
+// UpdatePolicy remplace la politique d'inscription de la configuration.
+// Les comptes existants et les invitations ne sont pas concernés.
+func (h *RegistrationPolicyHandler) UpdatePolicy(w http.ResponseWriter, r *http.Request) {
+	var policy models.RegistrationPolicy
+	if err := json.NewDecoder(r.Body).Decode(&policy); err != nil {
+		http.Error(w, "Données invalides", http.StatusBadRequest)
+		return
+	}
+	if err := normalizeRegistrationPolicy(&policy); err != nil {
+		apierror.Write(w, err, "")
+		return
+	}
+	if err := h.policies.SaveRegistrationPolicy(r.Context(), &policy); err != nil {
+		apierror.Write(w, err, "Impossible d'enregistrer la politique d'inscription")
+		return
+	}
+
+	logging.For(logging.ComponentHTTP).Warn("politique d'inscription modifiée", "mode", policy.Mode,
+		"allow_list", len(policy.AllowList), "deny_list", len(policy.DenyList))
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(&policy)
+}
+
+// ResetPolicy supprime la politique enregistrée : celle de la configuration
+// (REGISTRATION_*) s'applique de nouveau
+func (h *RegistrationPolicyHandler) ResetPolicy(w http.ResponseWriter, r *http.Request) {
+	if err := h.policies.DeleteRegistrationPolicy(r.Context()); err != nil {
+		apierror.Write(w, err, "Impossible de supprimer la politique d'inscription")
+		return
+	}
+
+	logging.For(logging.ComponentHTTP).Warn("politique d'inscription de la configuration rétablie")
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// normalizeRegistrationPolicy vérifie le mode et met les listes en
+// minuscules, sans doublon
+func normalizeRegistrationPolicy(policy *models.RegistrationPolicy) error {
+	if !models.ValidRegistrationMode(policy.Mode) {
+		return apierror.Validation("mode doit valoir open, restricted ou closed")
+	}
+	for _, list := range []*[]string{&policy.AllowList, &policy.DenyList} {
+		if len(*list) > models.MaxRegistrationListSize {
+			return apierror.Validation(fmt.Sprintf("Une liste contient %d entrées au plus",
+				models.MaxRegistrationListSize))
+		}
+		entries := []string{}
+		seen := make(map[string]bool)
+		for _, entry := range *list {
+			entry = strings.ToLower(strings.TrimSpace(entry))
+			if !validRegistrationEntry(entry) {
+				return apierror.Validation(fmt.Sprintf("Entrée invalide %q (domaine ou adresse email attendu)", entry))
+			}
+			if !seen[entry] {
+				seen[entry] = true
+				entries = append(entries, entry)
+			}
+		}
+		*list = entries
+	}
+	return nil
+}
+
+// validRegistrationEntry accepte un domaine (example.com) ou une adresse
+// (bob@example.com)
+func validRegistrationEntry(entry string) bool {
+	local, domain, isEmail := strings.Cut(entry, "@")
+	if isEmail && (local == "" || strings.Contains(domain, "@")) {
+		return false
+	}
+	if !isEmail {
+		domain = entry
+	}
+	return len(entry) <= 255 && strings.Contains(domain, ".") && !strings.HasPrefix(domain, ".") &&
+		!strings.HasSuffix(domain, ".") && !strings.ContainsAny(domain, " \t/:")
+}
@@ -0,0 +1,127 @@
+// filepath: internal/api/handlers/residency.go
+
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"secrets-manager/internal/api/apierror"
+	"secrets-manager/internal/api/middleware"
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// RegionCatalog liste les régions dont le backend de secrets est configuré
+type RegionCatalog interface {
+	Regions() []string
+	HasRegion(region string) bool
+}
+
+// Residency décrit la région où sont stockés les secrets d'une organisation
+type Residency struct {
+	OrganizationID   string   `json:"organization_id"`
+	Region           string   `json:"region"`
+	AvailableRegions []string `json:"available_regions"`
+}
+
+// ResidencyHandler expose la région de résidence des données d'une organisation
+type ResidencyHandler struct {
+	organizations storage.OrganizationsRepository
+	users         storage.UsersRepository
+	regions       RegionCatalog
+	history       storage.SettingsHistoryRepository
+}
+
+// NewResidencyHandler crée un nouveau gestionnaire de résidence des données
+func NewResidencyHandler(organizations storage.OrganizationsRepository, users storage.UsersRepository,
+	regions RegionCatalog, history storage.SettingsHistoryRepository) *ResidencyHandler {
+	return &ResidencyHandler{
+		organizations: organizations,
+		users:         users,
+		regions:       regions,
+		history:       history,
+	}
+}
+
+// GetResidency renvoie la région de résidence de l'organisation à ses membres
+func (h *ResidencyHandler) GetResidency(w http.ResponseWriter, r *http.Request) {
+	orgID := mux.Vars(r)["orgID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if role, err := h.users.GetUserRole(r.Context(), userID, orgID); err != nil || role == "" {
+		http.Error(w, "Organisation non trouvée", http.StatusNotFound)
+		return
+	}
+
+	h.writeResidency(w, r, orgID)
+}
+
+// UpdateResidency change la région de résidence de l'organisation. Les
+// secrets ne sont pas migrés d'un backend à l'autre : le changement n'est
+// possible que tant que l'organisation n'a aucun secret.
+func (h *ResidencyHandler) UpdateResidency(w http.ResponseWriter, r *http.Request) {
+	orgID := mux.Vars(r)["orgID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if !requireOrgAdmin(w, r, h.users, orgID, userID) {
+		return
+	}
+
+	var update struct {
+		Region string `json:"region"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+		http.Error(w, "Données invalides", http.StatusBadRequest)
+		return
+	}
+	if !h.regions.HasRegion(update.Region) {
+		http.Error(w, "Région inconnue", http.StatusBadRequest)
+		return
+	}
+
+	current, err := h.organizations.GetOrganizationRegion(r.Context(), orgID)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de récupérer la région de l'organisation")
+		return
+	}
+	if current != update.Region {
+		count, err := h.organizations.CountOrganizationSecrets(r.Context(), orgID)
+		if err != nil {
+			apierror.Write(w, err, "Impossible de compter les secrets de l'organisation")
+			return
+		}
+		if count > 0 {
+			http.Error(w, "La région ne peut plus changer : l'organisation contient des secrets", http.StatusConflict)
+			return
+		}
+		if err := h.organizations.UpdateOrganizationRegion(r.Context(), orgID, update.Region); err != nil {
+			apierror.Write(w, err, "Impossible de changer la région de l'organisation")
+			return
+		}
+		recordSettingsChange(r.Context(), h.history, orgID, models.SettingsResidency, orgID, models.SettingsUpdated,
+			map[string]string{"region": current}, map[string]string{"region": update.Region})
+	}
+
+	h.writeResidency(w, r, orgID)
+}
+
+// writeResidency écrit la résidence actuelle de l'organisation
+func (h *ResidencyHandler) writeResidency(w http.ResponseWriter, r *http.Request, orgID string) {
+	region, err := h.organizations.GetOrganizationRegion(r.Context(), orgID)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de récupérer la région de l'organisation")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(Residency{
+		OrganizationID:   orgID,
+		Region:           region,
+		AvailableRegions: h.regions.Regions(),
+	}); err != nil {
+		http.Error(w, "Erreur lors de l'encodage de la résidence", http.StatusInternalServerError)
+	}
+}
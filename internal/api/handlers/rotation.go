@@ -0,0 +1,187 @@
+// filepath: internal/api/handlers/rotation.go
+
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"secrets-manager/internal/api/apierror"
+	"secrets-manager/internal/api/middleware"
+	"secrets-manager/internal/events"
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/notifications"
+	"secrets-manager/internal/rotation"
+	"secrets-manager/internal/storage"
+)
+
+// Exigences vérifiées avant la rotation d'un secret
+const (
+	rotationCheckPermission  = "write_permission"
+	rotationCheckUnlocked    = "unlocked"
+	rotationCheckCredentials = "provider_credentials"
+)
+
+// RotationHandler fait tourner les secrets critiques : simulation de la
+// rotation et rotation gérée chez un fournisseur externe. Il réutilise les
+// dépendances et les règles d'écriture du gestionnaire de secrets.
+type RotationHandler struct {
+	*SecretsHandler
+	rotators  storage.SecretRotatorsRepository
+	providers *rotation.Registry
+	service   *rotation.Service
+	// webhooks fournit les webhooks notifiés ; nil les ignore
+	webhooks storage.WebhooksRepository
+}
+
+// NewRotationHandler crée un nouveau gestionnaire de rotation
+func NewRotationHandler(
+	secrets *SecretsHandler,
+	rotators storage.SecretRotatorsRepository,
+	providers *rotation.Registry,
+	webhooks storage.WebhooksRepository,
+) *RotationHandler {
+	service := rotation.NewService(rotators, secrets.secrets, secrets.projects, secrets.vaultService,
+		providers, secrets.checksummer, secrets.notifier)
+	return &RotationHandler{
+		SecretsHandler: secrets,
+		rotators:       rotators,
+		providers:      providers,
+		service:        service,
+		webhooks:       webhooks,
+	}
+}
+
+// DryRun simule la rotation d'un secret : consommateurs sur la période
+// (paramètre days) et date de la dernière lecture, exigences à satisfaire
+// avant de remplacer la valeur, et webhooks qui recevront l'événement.
+// Rien n'est modifié et aucune valeur n'est renvoyée.
+func (h *RotationHandler) DryRun(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	orgID := vars["orgID"]
+	projectID := vars["projectID"]
+	env := vars["env"]
+	name := vars["name"]
+
+	userID := middleware.UserIDFromContext(r.Context())
+	if err := h.policy.checkEnvironment(r.Context(), userID, orgID, projectID, env, secretRead); err != nil {
+		writePolicyError(w, err)
+		return
+	}
+	since, err := readsSince(r)
+	if err != nil {
+		apierror.Write(w, err, "")
+		return
+	}
+
+	metadata, err := h.secrets.GetSecretMetadataByPath(r.Context(), orgID, projectID, env, name)
+	if err == nil && metadata == nil {
+		// Secret antérieur aux métadonnées : seul le coffre connaît sa version
+		var secret *models.Secret
+		if secret, err = h.vaultService.GetSecret(r.Context(), orgID, projectID, env, name); err == nil {
+			metadata = &models.SecretMetadata{Version: secret.Version}
+		}
+	}
+	if err != nil {
+		apierror.Write(w, err, "Impossible de récupérer le secret")
+		return
+	}
+
+	plan := &models.RotationPlan{
+		OrganizationID: orgID,
+		ProjectID:      projectID,
+		Environment:    env,
+		SecretName:     name,
+		Version:        metadata.Version,
+		Since:          since,
+		Consumers:      []*models.SecretConsumer{},
+		Requirements:   []*models.RotationRequirement{},
+		Webhooks:       []*models.RotationWebhook{},
+	}
+
+	if h.reads != nil {
+		plan.Consumers, err = h.reads.ListSecretConsumers(r.Context(), orgID, projectID, env, name, since)
+		if err != nil {
+			apierror.Write(w, err, "Impossible de lister les consommateurs du secret")
+			return
+		}
+	}
+	for _, consumer := range plan.Consumers {
+		if plan.LastReadAt == nil || consumer.LastReadAt.After(*plan.LastReadAt) {
+			lastReadAt := consumer.LastReadAt
+			plan.LastReadAt = &lastReadAt
+		}
+	}
+
+	permission := &models.RotationRequirement{Check: rotationCheckPermission, Satisfied: true,
+		Detail: "Vous pouvez modifier la valeur du secret"}
+	if err := h.policy.checkEnvironment(r.Context(), userID, orgID, projectID, env, secretWrite); err != nil {
+		permission.Satisfied = false
+		permission.Detail = "La rotation doit être effectuée par un membre ou un administrateur"
+	}
+	unlocked := &models.RotationRequirement{Check: rotationCheckUnlocked, Satisfied: true,
+		Detail: "Le secret n'est pas verrouillé"}
+	if metadata.IsLocked() {
+		unlocked.Satisfied = false
+		unlocked.Detail = "Le secret doit être déverrouillé par un administrateur"
+		if metadata.LockReason != "" {
+			unlocked.Detail += " (" + metadata.LockReason + ")"
+		}
+	}
+	plan.Requirements = append(plan.Requirements, permission, unlocked)
+
+	// Rotation gérée : les identifiants du fournisseur doivent être lisibles
+	rotator, err := h.rotators.GetSecretRotator(r.Context(), orgID, projectID, env, name)
+	switch {
+	case err == nil:
+		plan.Provider = rotator.Provider
+		credentials := &models.RotationRequirement{Check: rotationCheckCredentials, Satisfied: true,
+			Detail: "Identifiants du fournisseur lus dans " + rotator.CredentialsSecret}
+		if _, err := h.vaultService.GetSecret(r.Context(), orgID, projectID, env, rotator.CredentialsSecret); err != nil {
+			credentials.Satisfied = false
+			credentials.Detail = "Le secret lié " + rotator.CredentialsSecret + " est introuvable"
+		}
+		plan.Requirements = append(plan.Requirements, credentials)
+	case !errors.Is(err, storage.ErrRotatorNotFound):
+		apierror.Write(w, err, "Impossible de récupérer le rotateur du secret")
+		return
+	}
+
+	plan.Ready = true
+	for _, requirement := range plan.Requirements {
+		plan.Ready = plan.Ready && requirement.Satisfied
+	}
+
+	key, err := h.projects.GetProjectEncryptionKey(r.Context(), projectID)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de récupérer la clé du projet")
+		return
+	}
+	if key != nil {
+		plan.EncryptionKeyID = key.KeyID
+	}
+
+	// Seules les modifications en production sont publiées (voir notifyChange)
+	if h.webhooks != nil && notifications.IsProduction(env) {
+		webhooks, err := h.webhooks.ListWebhooks(r.Context(), orgID)
+		if err != nil {
+			apierror.Write(w, err, "Impossible de lister les webhooks")
+			return
+		}
+		for _, webhook := range webhooks {
+			if webhook.Subscribes(events.SecretUpdatedV1) {
+				plan.Webhooks = append(plan.Webhooks, &models.RotationWebhook{
+					ID:    webhook.ID,
+					URL:   webhook.URL,
+					Event: events.SecretUpdatedV1,
+				})
+			}
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(plan)
+}
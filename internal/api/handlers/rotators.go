@@ -0,0 +1,242 @@
+// filepath: internal/api/handlers/rotators.go
+
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"secrets-manager/internal/api/apierror"
+	"secrets-manager/internal/api/middleware"
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/rotation"
+	"secrets-manager/internal/vault"
+)
+
+// SecretRotatorRequest configure la rotation gérée d'un secret
+type SecretRotatorRequest struct {
+	Provider          string            `json:"provider"`
+	Config            map[string]string `json:"config"`
+	CredentialsSecret string            `json:"credentials_secret"`
+
+	// Strategy vaut "replace" (défaut) ou "dual" ; OverlapSeconds est la
+	// durée du chevauchement d'une rotation double (24 heures par défaut)
+	Strategy       string `json:"strategy"`
+	OverlapSeconds int64  `json:"overlap_seconds"`
+}
+
+const (
+	// defaultRotationOverlap est le chevauchement par défaut d'une rotation double
+	defaultRotationOverlap = 24 * time.Hour
+	// maxRotationOverlap borne la durée de vie des anciens identifiants
+	maxRotationOverlap = 30 * 24 * time.Hour
+)
+
+// GetRotator renvoie le rotateur du secret (404 s'il n'en a pas)
+func (h *RotationHandler) GetRotator(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	orgID := vars["orgID"]
+	projectID := vars["projectID"]
+
+	userID := middleware.UserIDFromContext(r.Context())
+	if err := h.policy.checkEnvironment(r.Context(), userID, orgID, projectID, vars["env"], secretRead); err != nil {
+		writePolicyError(w, err)
+		return
+	}
+
+	rotator, err := h.rotators.GetSecretRotator(r.Context(), orgID, projectID, vars["env"], vars["name"])
+	if err != nil {
+		apierror.Write(w, err, "Impossible de récupérer le rotateur du secret")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(rotator)
+}
+
+// SetRotator configure la rotation gérée du secret. Réservé aux
+// administrateurs : le rotateur agit avec les identifiants du secret lié.
+func (h *RotationHandler) SetRotator(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	orgID := vars["orgID"]
+	projectID := vars["projectID"]
+	env := vars["env"]
+	name := vars["name"]
+
+	userID := middleware.UserIDFromContext(r.Context())
+	if err := h.policy.checkEnvironment(r.Context(), userID, orgID, projectID, env, secretAdmin); err != nil {
+		writePolicyError(w, err)
+		return
+	}
+
+	var req SecretRotatorRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Données invalides", http.StatusBadRequest)
+		return
+	}
+	provider, ok := h.providers.Get(req.Provider)
+	if !ok {
+		apierror.Write(w, apierror.Validation("Fournisseur inconnu (fournisseurs disponibles : "+
+			strings.Join(h.providers.Providers(), ", ")+")"), "")
+		return
+	}
+	if err := provider.Validate(req.Config); err != nil {
+		apierror.Write(w, apierror.Validation("Configuration invalide : "+err.Error()), "")
+		return
+	}
+	if err := validateRotationStrategy(&req); err != nil {
+		apierror.Write(w, err, "")
+		return
+	}
+	req.CredentialsSecret = strings.TrimSpace(req.CredentialsSecret)
+	if req.CredentialsSecret == "" || req.CredentialsSecret == name {
+		apierror.Write(w, apierror.Validation("Un secret lié distinct contenant les identifiants du fournisseur est requis"), "")
+		return
+	}
+
+	if _, err := h.vaultService.GetSecret(r.Context(), orgID, projectID, env, name); err != nil {
+		apierror.Write(w, err, "Impossible de récupérer le secret")
+		return
+	}
+	if err := h.checkLinkedSecret(r.Context(), orgID, projectID, env, req.CredentialsSecret); err != nil {
+		apierror.Write(w, err, "Impossible de récupérer le secret lié")
+		return
+	}
+	if err := h.service.CheckManagedEncryption(r.Context(), projectID); err != nil {
+		writeRotationError(w, err, "Impossible de configurer la rotation")
+		return
+	}
+
+	rotator := &models.SecretRotator{
+		OrganizationID:    orgID,
+		ProjectID:         projectID,
+		Environment:       env,
+		SecretName:        name,
+		Provider:          req.Provider,
+		Config:            req.Config,
+		CredentialsSecret: req.CredentialsSecret,
+		CreatedBy:         userID,
+		Strategy:          req.Strategy,
+		OverlapSeconds:    req.OverlapSeconds,
+	}
+	if err := h.rotators.SaveSecretRotator(r.Context(), rotator); err != nil {
+		apierror.Write(w, err, "Impossible d'enregistrer le rotateur")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(rotator)
+}
+
+// DeleteRotator supprime le rotateur du secret ; la valeur n'est pas
+// modifiée mais une valeur précédente encore en service est retirée
+func (h *RotationHandler) DeleteRotator(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	orgID := vars["orgID"]
+	projectID := vars["projectID"]
+
+	userID := middleware.UserIDFromContext(r.Context())
+	if err := h.policy.checkEnvironment(r.Context(), userID, orgID, projectID, vars["env"], secretAdmin); err != nil {
+		writePolicyError(w, err)
+		return
+	}
+
+	rotator, err := h.rotators.GetSecretRotator(r.Context(), orgID, projectID, vars["env"], vars["name"])
+	if err != nil {
+		apierror.Write(w, err, "Impossible de récupérer le rotateur du secret")
+		return
+	}
+	// Sans rotateur, plus rien ne retirerait la valeur précédente
+	if rotator.PreviousExpiresAt != nil {
+		if err := h.service.Retire(r.Context(), rotator); err != nil {
+			writeRotationError(w, err, "Impossible de retirer la valeur précédente")
+			return
+		}
+	}
+	if err := h.rotators.DeleteSecretRotator(r.Context(), orgID, projectID, vars["env"], vars["name"]); err != nil {
+		apierror.Write(w, err, "Impossible de supprimer le rotateur")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Rotate fait tourner les identifiants du secret chez son fournisseur (voir
+// rotation.Service) et renvoie le rotateur avec le résultat. Un échec de
+// révocation n'annule pas la rotation ; il est signalé dans last_error.
+func (h *RotationHandler) Rotate(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	orgID := vars["orgID"]
+	projectID := vars["projectID"]
+
+	userID := middleware.UserIDFromContext(r.Context())
+	if err := h.policy.checkEnvironment(r.Context(), userID, orgID, projectID, vars["env"], secretWrite); err != nil {
+		writePolicyError(w, err)
+		return
+	}
+
+	rotator, err := h.rotators.GetSecretRotator(r.Context(), orgID, projectID, vars["env"], vars["name"])
+	if err != nil {
+		apierror.Write(w, err, "Impossible de récupérer le rotateur du secret")
+		return
+	}
+	if err := h.service.Rotate(r.Context(), rotator, userID); err != nil {
+		writeRotationError(w, err, "Impossible de faire tourner le secret")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(rotator)
+}
+
+// validateRotationStrategy vérifie la stratégie demandée et complète le
+// chevauchement d'une rotation double
+func validateRotationStrategy(req *SecretRotatorRequest) error {
+	switch req.Strategy {
+	case "", models.RotationReplace:
+		req.Strategy = models.RotationReplace
+		req.OverlapSeconds = 0
+		return nil
+	case models.RotationDual:
+	default:
+		return apierror.Validation("Stratégie de rotation inconnue (replace ou dual)")
+	}
+
+	if req.OverlapSeconds == 0 {
+		req.OverlapSeconds = int64(defaultRotationOverlap / time.Second)
+	}
+	overlap := time.Duration(req.OverlapSeconds) * time.Second
+	if overlap < time.Minute || overlap > maxRotationOverlap {
+		return apierror.Validation("Chevauchement invalide (d'une minute à 30 jours)")
+	}
+	return nil
+}
+
+// writeRotationError écrit 502 pour un refus du fournisseur, 400 pour une
+// configuration qui empêche la rotation, la réponse habituelle sinon
+func writeRotationError(w http.ResponseWriter, err error, fallback string) {
+	if errors.Is(err, rotation.ErrUpstream) {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	if errors.Is(err, rotation.ErrInvalid) {
+		apierror.Write(w, apierror.Validation(err.Error()), "")
+		return
+	}
+	apierror.Write(w, err, fallback)
+}
+
+// checkLinkedSecret vérifie que le secret lié existe dans l'environnement
+func (h *RotationHandler) checkLinkedSecret(ctx context.Context, orgID, projectID, env, name string) error {
+	_, err := h.vaultService.GetSecret(ctx, orgID, projectID, env, name)
+	if errors.Is(err, vault.ErrSecretNotFound) {
+		return apierror.Validation("Le secret lié " + name + " est introuvable")
+	}
+	return err
+}
@@ -0,0 +1,132 @@
+// filepath: internal/api/handlers/scheduled_secrets.go
+
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"secrets-manager/internal/api/apierror"
+	"secrets-manager/internal/api/middleware"
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// maxScheduleHorizon borne la date d'effet d'un changement planifié
+const maxScheduleHorizon = 365 * 24 * time.Hour
+
+// SecretUpdateRequest est le corps de la modification d'un secret. Avec
+// EffectiveAt, la nouvelle valeur est planifiée : les lectures servent la
+// valeur actuelle jusqu'à cette date.
+type SecretUpdateRequest struct {
+	models.Secret
+	EffectiveAt *time.Time `json:"effective_at,omitempty"`
+}
+
+// scheduleUpdate met la nouvelle valeur du secret en attente jusqu'à
+// effectiveAt et répond 202 avec le changement planifié. Un changement déjà
+// planifié pour le secret est remplacé.
+func (h *SecretsHandler) scheduleUpdate(w http.ResponseWriter, r *http.Request, secret *models.Secret, effectiveAt time.Time) {
+	now := time.Now()
+	if !effectiveAt.After(now) {
+		apierror.Write(w, apierror.Validation("effective_at doit être dans le futur"), "")
+		return
+	}
+	if effectiveAt.Sub(now) > maxScheduleHorizon {
+		apierror.Write(w, apierror.Validation("effective_at ne peut pas dépasser un an"), "")
+		return
+	}
+
+	if err := h.vaultService.StoreScheduledValue(r.Context(), secret); err != nil {
+		apierror.Write(w, err, "Impossible de planifier le changement du secret")
+		return
+	}
+	change := &models.ScheduledSecretChange{
+		OrganizationID: secret.OrganizationID,
+		ProjectID:      secret.ProjectID,
+		Environment:    secret.Environment,
+		SecretName:     secret.Name,
+		EffectiveAt:    effectiveAt.UTC(),
+		CreatedBy:      secret.CreatedBy,
+	}
+	if err := h.scheduled.SaveScheduledSecretChange(r.Context(), change); err != nil {
+		apierror.Write(w, err, "Impossible de planifier le changement du secret")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(change)
+}
+
+// GetScheduledChange renvoie le changement planifié du secret, sans sa valeur
+func (h *SecretsHandler) GetScheduledChange(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	orgID := vars["orgID"]
+	projectID := vars["projectID"]
+
+	userID := middleware.UserIDFromContext(r.Context())
+	if err := h.policy.checkEnvironment(r.Context(), userID, orgID, projectID, vars["env"], secretRead); err != nil {
+		writePolicyError(w, err)
+		return
+	}
+
+	change, err := h.scheduled.GetScheduledSecretChange(r.Context(), orgID, projectID, vars["env"], vars["name"])
+	if err != nil {
+		apierror.Write(w, err, "Impossible de récupérer le changement planifié")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(change)
+}
+
+// CancelScheduledChange annule le changement planifié du secret
+func (h *SecretsHandler) CancelScheduledChange(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	orgID := vars["orgID"]
+	projectID := vars["projectID"]
+
+	userID := middleware.UserIDFromContext(r.Context())
+	if err := h.policy.checkEnvironment(r.Context(), userID, orgID, projectID, vars["env"], secretWrite); err != nil {
+		writePolicyError(w, err)
+		return
+	}
+
+	change, err := h.scheduled.GetScheduledSecretChange(r.Context(), orgID, projectID, vars["env"], vars["name"])
+	if err == nil {
+		err = h.cancelScheduled(r, change)
+	}
+	if err != nil {
+		apierror.Write(w, err, "Impossible d'annuler le changement planifié")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// cancelScheduledByPath annule le changement planifié d'un secret supprimé,
+// pour qu'il ne s'applique pas à un secret recréé sous le même nom
+func (h *SecretsHandler) cancelScheduledByPath(r *http.Request, orgID, projectID, env, name string) error {
+	change, err := h.scheduled.GetScheduledSecretChange(r.Context(), orgID, projectID, env, name)
+	if errors.Is(err, storage.ErrScheduleNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return h.cancelScheduled(r, change)
+}
+
+// cancelScheduled supprime le changement planifié puis sa valeur en attente
+func (h *SecretsHandler) cancelScheduled(r *http.Request, change *models.ScheduledSecretChange) error {
+	if err := h.scheduled.DeleteScheduledSecretChange(r.Context(), change.ID); err != nil {
+		return err
+	}
+	return h.vaultService.DeleteScheduledValue(r.Context(),
+		change.OrganizationID, change.ProjectID, change.Environment, change.SecretName)
+}
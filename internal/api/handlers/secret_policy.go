@@ -0,0 +1,256 @@
+// filepath: internal/api/handlers/secret_policy.go
+
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+
+	"secrets-manager/internal/api/middleware"
+	"secrets-manager/internal/auth"
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// Actions possibles sur un secret
+type secretAction int
+
+const (
+	secretRead secretAction = iota
+	secretWrite
+	// secretAdmin couvre le verrouillage et le déverrouillage
+	secretAdmin
+)
+
+var (
+	// errSecretHidden : l'appelant ne doit pas apprendre l'existence du secret (404)
+	errSecretHidden = errors.New("secret masqué")
+	// errSecretForbidden : l'appelant voit le secret mais ne peut pas le modifier (403)
+	errSecretForbidden = errors.New("action interdite sur le secret")
+)
+
+// secretPolicy décide de l'accès aux secrets d'une organisation.
+//
+// Un appelant qui ne peut pas lire les secrets de l'organisation reçoit
+// toujours 404, que le secret existe ou non, pour ne pas révéler son
+// existence. Un membre qui peut lire mais pas modifier reçoit 403, et les
+// administrateurs obtiennent la vraie distinction entre 403 et 404.
+// Les secrets d'un projet placé dans la corbeille sont masqués pour tous.
+// Le rôle d'un membre sur un projet est le plus élevé de son rôle dans
+// l'organisation, des rôles accordés à ses équipes et de celui que confèrent
+// les permissions secrets.read et secrets.write de son rôle personnalisé.
+type secretPolicy struct {
+	users    storage.UsersRepository
+	projects storage.ProjectsRepository
+	// teams fournit les octrois des équipes ; nil les ignore
+	teams storage.TeamsRepository
+	// roles fournit les rôles personnalisés des membres ; nil les ignore
+	roles storage.CustomRolesRepository
+}
+
+// check renvoie nil si l'utilisateur peut effectuer l'action sur le projet,
+// errSecretHidden ou errSecretForbidden sinon. Seuls les octrois d'équipe
+// sur le projet entier s'appliquent.
+func (p *secretPolicy) check(ctx context.Context, userID, orgID, projectID string, action secretAction) error {
+	return p.checkEnvironment(ctx, userID, orgID, projectID, "", action)
+}
+
+// checkEnvironment est check pour les secrets d'un environnement : les
+// octrois d'équipe sur cet environnement s'appliquent aussi
+func (p *secretPolicy) checkEnvironment(ctx context.Context, userID, orgID, projectID, env string, action secretAction) error {
+	role, err := p.users.GetUserRole(ctx, userID, orgID)
+	if err != nil || role == "" {
+		// Non-membre (ou organisation inexistante) : rien ne doit transparaître
+		return errSecretHidden
+	}
+	// Un compte de service ou un token restreint ne voit que les projets de
+	// ses ressources, et dans un environnement, que ceux qu'elles couvrent
+	if resources, ok := middleware.ResourceScopesFromContext(ctx); ok && !coversResource(resources, projectID, env) {
+		return errSecretHidden
+	}
+
+	if suspended, err := p.projects.IsProjectSuspended(ctx, orgID, projectID); err != nil || suspended {
+		return errSecretHidden
+	}
+
+	if p.teams != nil && role != auth.RoleAdmin {
+		grants, err := p.teams.ListUserGrants(ctx, orgID, userID)
+		if err != nil {
+			return errSecretHidden
+		}
+		role = auth.InheritedRole(role, grants, projectID, env)
+	}
+	if p.roles != nil && role != auth.RoleAdmin {
+		custom, err := p.roles.GetMemberCustomRole(ctx, orgID, userID)
+		if err != nil && !errors.Is(err, storage.ErrNotFound) {
+			return errSecretHidden
+		}
+		role = auth.HigherRole(role, auth.CustomRoleSecretsRole(custom))
+	}
+
+	switch role {
+	case "admin":
+		return nil
+	case "member":
+		if action == secretAdmin {
+			return errSecretForbidden
+		}
+		return nil
+	case "viewer":
+		if action == secretRead {
+			return nil
+		}
+		return errSecretForbidden
+	default:
+		return errSecretHidden
+	}
+}
+
+// writePolicyError écrit la réponse correspondant à un refus de la politique
+func writePolicyError(w http.ResponseWriter, err error) {
+	if errors.Is(err, errSecretForbidden) {
+		http.Error(w, "Accès refusé", http.StatusForbidden)
+		return
+	}
+	http.Error(w, "Secret non trouvé", http.StatusNotFound)
+}
+
+// memberAccess réunit ce qui détermine le rôle d'un membre : son rôle dans
+// l'organisation, les octrois de ses équipes et son rôle personnalisé. Une
+// simulation y ajoute des changements hypothétiques.
+type memberAccess struct {
+	role   string
+	grants []*models.TeamGrant
+	// customRole est le rôle personnalisé du membre, nil s'il n'en a pas
+	customRole *models.CustomRole
+	// hypotheticalRole signale un rôle dans l'organisation simulé
+	hypotheticalRole bool
+	// hypotheticalGrants sont des octrois simulés, pas encore accordés
+	hypotheticalGrants []*models.TeamGrant
+}
+
+// access renvoie le rôle du membre dans l'organisation, les octrois de ses
+// équipes et son rôle personnalisé
+func (p *secretPolicy) access(ctx context.Context, userID, orgID string) (*memberAccess, error) {
+	role, err := p.users.GetUserRole(ctx, userID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	access := &memberAccess{role: role}
+	if p.teams != nil {
+		if access.grants, err = p.teams.ListUserGrants(ctx, orgID, userID); err != nil {
+			return nil, err
+		}
+	}
+	if p.roles != nil {
+		access.customRole, err = p.roles.GetMemberCustomRole(ctx, orgID, userID)
+		if err != nil && !errors.Is(err, storage.ErrNotFound) {
+			return nil, err
+		}
+	}
+	return access, nil
+}
+
+// explain détaille les règles qui déterminent l'accès d'un membre au
+// projet, ou à l'un de ses environnements, comme check les applique. Sans
+// projet, seul le rôle dans l'organisation s'applique.
+func (p *secretPolicy) explain(ctx context.Context, userID, orgID, projectID, env string,
+	access *memberAccess) (*models.EffectivePermissions, error) {
+	decisive := &models.PermissionRule{
+		Source:       models.PermissionSourceOrganization,
+		Effect:       models.PermissionAllow,
+		Role:         access.role,
+		Hypothetical: access.hypotheticalRole,
+		Detail:       "Rôle " + access.role + " dans l'organisation",
+	}
+	permissions := &models.EffectivePermissions{
+		UserID:         userID,
+		OrganizationID: orgID,
+		ProjectID:      projectID,
+		Environment:    env,
+		Role:           access.role,
+		Rules:          []*models.PermissionRule{decisive},
+	}
+
+	grants := append(slices.Clone(access.grants), access.hypotheticalGrants...)
+	if projectID != "" && len(grants) > 0 && p.teams != nil {
+		teams, err := p.teams.ListTeams(ctx, orgID)
+		if err != nil {
+			return nil, err
+		}
+		names := make(map[string]string, len(teams))
+		for _, team := range teams {
+			names[team.ID] = team.Name
+		}
+		for i, grant := range grants {
+			if !grant.Covers(projectID, env) {
+				continue
+			}
+			rule := &models.PermissionRule{
+				Source:       models.PermissionSourceTeam,
+				Effect:       models.PermissionAllow,
+				Role:         grant.Role,
+				TeamID:       grant.TeamID,
+				TeamName:     names[grant.TeamID],
+				ProjectID:    grant.ProjectID,
+				Environment:  grant.Environment,
+				Hypothetical: i >= len(access.grants),
+				Detail:       "Rôle " + grant.Role + " accordé à l'équipe " + names[grant.TeamID] + " sur le projet",
+			}
+			if grant.Environment != "" {
+				rule.Detail = "Rôle " + grant.Role + " accordé à l'équipe " + names[grant.TeamID] +
+					" sur l'environnement " + grant.Environment
+			}
+			permissions.Rules = append(permissions.Rules, rule)
+			// Comme auth.InheritedRole : le premier rôle le plus élevé l'emporte
+			if auth.RoleAtLeast(grant.Role, permissions.Role) && grant.Role != permissions.Role {
+				permissions.Role = grant.Role
+				decisive = rule
+			}
+		}
+	}
+	if role := auth.CustomRoleSecretsRole(access.customRole); role != "" && projectID != "" {
+		rule := &models.PermissionRule{
+			Source:       models.PermissionSourceCustomRole,
+			Effect:       models.PermissionAllow,
+			Role:         role,
+			CustomRoleID: access.customRole.ID,
+			Detail:       "Rôle " + role + " conféré par le rôle personnalisé " + access.customRole.Name,
+		}
+		permissions.Rules = append(permissions.Rules, rule)
+		if auth.HigherRole(permissions.Role, role) != permissions.Role {
+			permissions.Role = role
+			decisive = rule
+		}
+	}
+	decisive.Decisive = true
+
+	permissions.Permissions = []string{}
+	if access.role == auth.RoleAdmin {
+		permissions.Permissions = slices.Clone(models.Permissions)
+	} else if access.customRole != nil {
+		permissions.Permissions = slices.Clone(access.customRole.Permissions)
+	}
+
+	permissions.CanRead = auth.RoleAtLeast(permissions.Role, auth.RoleViewer)
+	permissions.CanWrite = auth.RoleAtLeast(permissions.Role, auth.RoleMember)
+	permissions.CanAdmin = auth.RoleAtLeast(permissions.Role, auth.RoleAdmin)
+
+	if projectID != "" {
+		suspended, err := p.projects.IsProjectSuspended(ctx, orgID, projectID)
+		if err != nil {
+			return nil, err
+		}
+		if suspended {
+			permissions.Deny(&models.PermissionRule{
+				Source:    models.PermissionSourceRecycleBin,
+				ProjectID: projectID,
+				Detail:    "Le projet est dans la corbeille : ses secrets sont masqués",
+			})
+			permissions.CanRead, permissions.CanWrite, permissions.CanAdmin = false, false, false
+		}
+	}
+	return permissions, nil
+}
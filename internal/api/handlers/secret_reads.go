@@ -0,0 +1,169 @@
+// filepath: internal/api/handlers/secret_reads.go
+
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"secrets-manager/internal/api/apierror"
+	"secrets-manager/internal/api/middleware"
+	"secrets-manager/internal/logging"
+	"secrets-manager/internal/models"
+)
+
+// WorkloadHeader identifie la charge de travail (service, pod, pipeline) qui
+// lit la valeur d'un secret
+const WorkloadHeader = "X-Workload"
+
+// Longueur maximale d'un identifiant de charge de travail
+const maxWorkloadLength = 128
+
+// workloadFromRequest renvoie la charge de travail déclarée par l'en-tête
+// X-Workload ("" s'il est absent). Seuls les caractères ASCII imprimables
+// sont acceptés.
+func workloadFromRequest(r *http.Request) (string, error) {
+	workload := strings.TrimSpace(r.Header.Get(WorkloadHeader))
+	if len(workload) > maxWorkloadLength {
+		return "", apierror.Validation("En-tête X-Workload trop long (128 caractères au plus)")
+	}
+	for i := 0; i < len(workload); i++ {
+		if workload[i] < 0x20 || workload[i] > 0x7e {
+			return "", apierror.Validation("En-tête X-Workload invalide (ASCII imprimable attendu)")
+		}
+	}
+	return workload, nil
+}
+
+// recordReads comptabilise la lecture des valeurs des secrets de
+// l'environnement de la requête par sa charge de travail et son principal.
+// Un échec est journalisé sans faire échouer la lecture.
+func (h *SecretsHandler) recordReads(r *http.Request, workload string, secrets []*models.Secret) {
+	principal, ok := middleware.PrincipalFromContext(r.Context())
+	if h.reads == nil || !ok || len(secrets) == 0 {
+		return
+	}
+
+	vars := mux.Vars(r)
+	now := time.Now()
+	reads := make([]*models.SecretRead, 0, len(secrets))
+	for _, secret := range secrets {
+		reads = append(reads, &models.SecretRead{
+			OrganizationID: vars["orgID"],
+			ProjectID:      vars["projectID"],
+			Environment:    vars["env"],
+			SecretName:     secret.Name,
+			Workload:       workload,
+			PrincipalType:  principal.Type,
+			PrincipalID:    principal.ID,
+			Timestamp:      now,
+		})
+	}
+	if err := h.reads.RecordSecretReads(context.WithoutCancel(r.Context()), reads); err != nil {
+		logging.For(logging.ComponentHTTP).Warn("échec de l'enregistrement des lectures de secrets",
+			"organization_id", reads[0].OrganizationID, "workload", workload, "error", err)
+	}
+}
+
+const (
+	// defaultReadsPeriodDays est la période couverte par défaut par les consommateurs
+	defaultReadsPeriodDays = 30
+	// maxReadsPeriodDays est la période maximale (rétention des compteurs)
+	maxReadsPeriodDays = 90
+)
+
+// readsSince renvoie le premier jour (UTC) de la période demandée par le
+// paramètre days : 30 derniers jours par défaut, aujourd'hui compris
+func readsSince(r *http.Request) (time.Time, error) {
+	days := defaultReadsPeriodDays
+	if v := r.URL.Query().Get("days"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > maxReadsPeriodDays {
+			return time.Time{}, apierror.Validation("Paramètre days invalide (1 à 90)")
+		}
+		days = n
+	}
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	return today.AddDate(0, 0, -(days - 1)), nil
+}
+
+// ListConsumers liste les charges de travail et principaux qui ont lu le
+// secret pendant la période (paramètre days), du plus récent au plus ancien.
+// Une charge de travail vide regroupe les lectures sans en-tête X-Workload.
+func (h *SecretsHandler) ListConsumers(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	orgID := vars["orgID"]
+	projectID := vars["projectID"]
+
+	userID := middleware.UserIDFromContext(r.Context())
+	if err := h.policy.checkEnvironment(r.Context(), userID, orgID, projectID, vars["env"], secretRead); err != nil {
+		writePolicyError(w, err)
+		return
+	}
+	since, err := readsSince(r)
+	if err != nil {
+		apierror.Write(w, err, "")
+		return
+	}
+
+	consumers := []*models.SecretConsumer{}
+	if h.reads != nil {
+		consumers, err = h.reads.ListSecretConsumers(r.Context(), orgID, projectID, vars["env"], vars["name"], since)
+		if err != nil {
+			apierror.Write(w, err, "Impossible de lister les consommateurs du secret")
+			return
+		}
+	}
+
+	writeJSONList(w, r, consumers)
+}
+
+// GetDependencyGraph renvoie le graphe des dépendances du projet : quelles
+// charges de travail ont lu quels secrets pendant la période (paramètre days)
+func (h *SecretsHandler) GetDependencyGraph(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	orgID := vars["orgID"]
+	projectID := vars["projectID"]
+
+	userID := middleware.UserIDFromContext(r.Context())
+	if err := h.policy.check(r.Context(), userID, orgID, projectID, secretRead); err != nil {
+		writePolicyError(w, err)
+		return
+	}
+	since, err := readsSince(r)
+	if err != nil {
+		apierror.Write(w, err, "")
+		return
+	}
+
+	graph := &models.DependencyGraph{
+		OrganizationID: orgID,
+		ProjectID:      projectID,
+		Since:          since,
+		Workloads:      []string{},
+		Dependencies:   []*models.SecretDependency{},
+	}
+	if h.reads != nil {
+		graph.Dependencies, err = h.reads.ListProjectDependencies(r.Context(), orgID, projectID, since)
+		if err != nil {
+			apierror.Write(w, err, "Impossible de calculer les dépendances du projet")
+			return
+		}
+	}
+	for _, dependency := range graph.Dependencies {
+		if dependency.Workload != "" && !slices.Contains(graph.Workloads, dependency.Workload) {
+			graph.Workloads = append(graph.Workloads, dependency.Workload)
+		}
+	}
+	slices.Sort(graph.Workloads)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(graph)
+}
@@ -4,23 +4,76 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/gorilla/mux"
 
+	"secrets-manager/internal/api/apierror"
+	"secrets-manager/internal/api/middleware"
+	"secrets-manager/internal/e2e"
+	"secrets-manager/internal/hygiene"
+	"secrets-manager/internal/logging"
 	"secrets-manager/internal/models"
+	"secrets-manager/internal/notifications"
+	"secrets-manager/internal/storage"
 	"secrets-manager/internal/vault"
 )
 
 // SecretsHandler gère les routes liées aux secrets
 type SecretsHandler struct {
 	vaultService *vault.Service
+	secrets      storage.SecretsRepository
+	projects     storage.ProjectsRepository
+	teams        storage.TeamsRepository
+	policy       *secretPolicy
+	confirmer    *Confirmer
+	// checksummer calcule les sommes de contrôle des valeurs ; nil les désactive
+	checksummer *vault.Checksummer
+	// notifier prévient les membres des changements en production ; nil les ignore
+	notifier *notifications.Dispatcher
+	// reads comptabilise les lectures par charge de travail ; nil les ignore
+	reads storage.SecretReadsRepository
+	// scheduled contient les changements de valeur planifiés
+	scheduled storage.ScheduledSecretChangesRepository
+	// readPolicies désigne les environnements dont la lecture exige un motif ; nil n'en exige aucun
+	readPolicies storage.ReadReasonPoliciesRepository
+	// audit enregistre les lectures motivées ; nil les ignore
+	audit storage.AuditLogsRepository
 }
 
 // NewSecretsHandler crée un nouveau gestionnaire de secrets
-func NewSecretsHandler(vaultService *vault.Service) *SecretsHandler {
+func NewSecretsHandler(
+	vaultService *vault.Service,
+	users storage.UsersRepository,
+	secrets storage.SecretsRepository,
+	projects storage.ProjectsRepository,
+	teams storage.TeamsRepository,
+	roles storage.CustomRolesRepository,
+	confirmer *Confirmer,
+	checksummer *vault.Checksummer,
+	notifier *notifications.Dispatcher,
+	reads storage.SecretReadsRepository,
+	scheduled storage.ScheduledSecretChangesRepository,
+	readPolicies storage.ReadReasonPoliciesRepository,
+	audit storage.AuditLogsRepository,
+) *SecretsHandler {
 	return &SecretsHandler{
 		vaultService: vaultService,
+		secrets:      secrets,
+		projects:     projects,
+		teams:        teams,
+		policy:       &secretPolicy{users: users, projects: projects, teams: teams, roles: roles},
+		confirmer:    confirmer,
+		checksummer:  checksummer,
+		notifier:     notifier,
+		reads:        reads,
+		scheduled:    scheduled,
+		readPolicies: readPolicies,
+		audit:        audit,
 	}
 }
 
@@ -33,19 +86,33 @@ func (h *SecretsHandler) GetSecret(w http.ResponseWriter, r *http.Request) {
 	name := vars["name"]
 
 	// Extraire l'ID utilisateur depuis le contexte (mis par middleware auth)
-	//userID := r.Context().Value("userID").(string)
+	userID := middleware.UserIDFromContext(r.Context())
 
 	// Vérifier si l'utilisateur a accès à ce secret
-	// TODO: implémenter la vérification des permissions
+	if err := h.policy.checkEnvironment(r.Context(), userID, orgID, projectID, env, secretRead); err != nil {
+		writePolicyError(w, err)
+		return
+	}
+	workload, err := workloadFromRequest(r)
+	if err != nil {
+		apierror.Write(w, err, "")
+		return
+	}
+	reason, err := h.readReason(r, orgID, env)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de vérifier les environnements protégés")
+		return
+	}
 
 	secret, err := h.vaultService.GetSecret(r.Context(), orgID, projectID, env, name)
 	if err != nil {
-		http.Error(w, "Secret non trouvé", http.StatusNotFound)
+		apierror.Write(w, err, "Impossible de récupérer le secret")
 		return
 	}
 
 	// Audit de l'accès au secret
-	// TODO: journaliser l'accès au secret
+	h.recordReads(r, workload, []*models.Secret{secret})
+	h.auditReads(r, reason, []*models.Secret{secret})
 
 	w.Header().Set("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(secret); err != nil {
@@ -55,27 +122,310 @@ func (h *SecretsHandler) GetSecret(w http.ResponseWriter, r *http.Request) {
 
 // CreateSecret crée un nouveau secret
 func (h *SecretsHandler) CreateSecret(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
 	var secret models.Secret
 	if err := json.NewDecoder(r.Body).Decode(&secret); err != nil {
 		http.Error(w, "Données invalides", http.StatusBadRequest)
 		return
 	}
 
+	// Le chemin fait foi : le corps ne peut pas viser une autre organisation
+	secret.OrganizationID = vars["orgID"]
+	secret.ProjectID = vars["projectID"]
+	secret.Environment = vars["env"]
+
 	// Extraire l'ID utilisateur depuis le contexte (mis par middleware auth)
-	userID := r.Context().Value("userID").(string)
-	secret.CreatedBy = userID
+	secret.CreatedBy = middleware.UserIDFromContext(r.Context())
 
 	// Vérifier si l'utilisateur a le droit de créer un secret dans ce projet
-	// TODO: implémenter la vérification des permissions
+	if err := h.policy.checkEnvironment(r.Context(), secret.CreatedBy, secret.OrganizationID, secret.ProjectID,
+		secret.Environment, secretWrite); err != nil {
+		writePolicyError(w, err)
+		return
+	}
+
+	// Un secret existant verrouillé ne peut pas être écrasé
+	metadata, err := h.secrets.GetSecretMetadataByPath(r.Context(),
+		secret.OrganizationID, secret.ProjectID, secret.Environment, secret.Name)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de créer le secret")
+		return
+	}
+	if metadata != nil && metadata.IsLocked() {
+		apierror.Write(w, storage.ErrSecretLocked, "")
+		return
+	}
+
+	if err := h.checkEncryption(r, &secret); err != nil {
+		apierror.Write(w, err, "Impossible de créer le secret")
+		return
+	}
 
 	if err := h.vaultService.StoreSecret(r.Context(), &secret); err != nil {
-		http.Error(w, "Impossible de créer le secret", http.StatusInternalServerError)
+		apierror.Write(w, err, "Impossible de créer le secret")
+		return
+	}
+
+	// Une création simultanée du même chemin est refusée par l'index unique
+	// (storage.ErrSecretAlreadyExists, 409)
+	if err := h.saveMetadata(r, metadata, &secret); err != nil {
+		apierror.Write(w, err, "Impossible d'enregistrer les métadonnées du secret")
 		return
 	}
 
+	if metadata == nil {
+		h.notifyChange(r, nil, secret.OrganizationID, secret.ProjectID, secret.Environment,
+			notifications.SecretCreated, secret.CreatedBy, secret.Name)
+		h.checkQuota(r, secret.OrganizationID)
+	} else {
+		h.notifyChange(r, metadata, secret.OrganizationID, secret.ProjectID, secret.Environment,
+			notifications.SecretUpdated, secret.CreatedBy, secret.Name)
+	}
+
 	w.WriteHeader(http.StatusCreated)
 }
 
+// UpdateSecret remplace la valeur et la description d'un secret existant.
+// Avec effective_at, le remplacement est planifié (voir scheduleUpdate).
+func (h *SecretsHandler) UpdateSecret(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	orgID := vars["orgID"]
+	projectID := vars["projectID"]
+	env := vars["env"]
+	name := vars["name"]
+
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if err := h.policy.checkEnvironment(r.Context(), userID, orgID, projectID, env, secretWrite); err != nil {
+		writePolicyError(w, err)
+		return
+	}
+
+	var update SecretUpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+		http.Error(w, "Données invalides", http.StatusBadRequest)
+		return
+	}
+
+	secret, err := h.vaultService.GetSecret(r.Context(), orgID, projectID, env, name)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de récupérer le secret")
+		return
+	}
+
+	metadata, err := h.unlockedMetadata(r, orgID, projectID, env, name)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de mettre à jour le secret")
+		return
+	}
+
+	secret.Value = update.Value
+	secret.Description = update.Description
+	secret.E2E = update.E2E
+	secret.CreatedBy = userID
+
+	if err := h.checkEncryption(r, secret); err != nil {
+		apierror.Write(w, err, "Impossible de mettre à jour le secret")
+		return
+	}
+
+	if update.EffectiveAt != nil {
+		h.scheduleUpdate(w, r, secret, *update.EffectiveAt)
+		return
+	}
+
+	if err := h.vaultService.StoreSecret(r.Context(), secret); err != nil {
+		apierror.Write(w, err, "Impossible de mettre à jour le secret")
+		return
+	}
+
+	if err := h.saveMetadata(r, metadata, secret); err != nil {
+		apierror.Write(w, err, "Impossible d'enregistrer les métadonnées du secret")
+		return
+	}
+
+	h.notifyChange(r, metadata, orgID, projectID, env, notifications.SecretUpdated, userID, name)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DiffSecretVersions compare deux versions d'un secret (paramètres from et to).
+// Seuls les noms des champs modifiés sont renvoyés, jamais les valeurs.
+func (h *SecretsHandler) DiffSecretVersions(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	orgID := vars["orgID"]
+	projectID := vars["projectID"]
+	env := vars["env"]
+	name := vars["name"]
+
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if err := h.policy.checkEnvironment(r.Context(), userID, orgID, projectID, env, secretRead); err != nil {
+		writePolicyError(w, err)
+		return
+	}
+
+	from, err := strconv.Atoi(r.URL.Query().Get("from"))
+	if err != nil || from < 1 {
+		apierror.Write(w, apierror.Validation("Paramètre from invalide"), "")
+		return
+	}
+	to, err := strconv.Atoi(r.URL.Query().Get("to"))
+	if err != nil || to < 1 {
+		apierror.Write(w, apierror.Validation("Paramètre to invalide"), "")
+		return
+	}
+
+	diff, err := h.vaultService.DiffSecretVersions(r.Context(), orgID, projectID, env, name, from, to)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de comparer les versions du secret")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(diff); err != nil {
+		http.Error(w, "Erreur lors de l'encodage de la comparaison", http.StatusInternalServerError)
+	}
+}
+
+// PreviewSecret renvoie un aperçu masqué de la valeur d'un secret : les
+// premiers et derniers caractères (paramètre chars, 4 par défaut, 8 au plus)
+// et la longueur, un quart de la valeur au plus étant révélé. L'aperçu ne
+// compte pas comme une lecture de la valeur : il n'exige pas de motif et
+// n'est pas audité comme tel.
+func (h *SecretsHandler) PreviewSecret(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	orgID := vars["orgID"]
+	projectID := vars["projectID"]
+	env := vars["env"]
+	name := vars["name"]
+
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if err := h.policy.checkEnvironment(r.Context(), userID, orgID, projectID, env, secretRead); err != nil {
+		writePolicyError(w, err)
+		return
+	}
+
+	chars := models.DefaultPreviewChars
+	if v := r.URL.Query().Get("chars"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 || n > models.MaxPreviewChars {
+			apierror.Write(w, apierror.Validation("Paramètre chars invalide (0 à 8)"), "")
+			return
+		}
+		chars = n
+	}
+
+	secret, err := h.vaultService.GetSecret(r.Context(), orgID, projectID, env, name)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de récupérer le secret")
+		return
+	}
+	if secret.E2E {
+		apierror.Write(w, apierror.Validation("Aperçu indisponible pour un secret chiffré de bout en bout"), "")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	if err := json.NewEncoder(w).Encode(models.NewSecretPreview(secret.Name, secret.Value, chars)); err != nil {
+		http.Error(w, "Erreur lors de l'encodage de l'aperçu", http.StatusInternalServerError)
+	}
+}
+
+// GetSecretMetadata renvoie les métadonnées d'un secret, avec la somme de
+// contrôle et l'hygiène de sa valeur, sans jamais renvoyer la valeur
+func (h *SecretsHandler) GetSecretMetadata(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	orgID := vars["orgID"]
+	projectID := vars["projectID"]
+	env := vars["env"]
+	name := vars["name"]
+
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if err := h.policy.checkEnvironment(r.Context(), userID, orgID, projectID, env, secretRead); err != nil {
+		writePolicyError(w, err)
+		return
+	}
+
+	metadata, err := h.secrets.GetSecretMetadataByPath(r.Context(), orgID, projectID, env, name)
+	if err == nil && metadata == nil {
+		err = vault.ErrSecretNotFound
+	}
+	if err == nil {
+		err = h.refreshChecksum(r, metadata)
+	}
+	if err == nil {
+		err = h.assessHygiene(r, orgID, metadata)
+	}
+	if err != nil {
+		apierror.Write(w, err, "Impossible de récupérer les métadonnées du secret")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(metadata); err != nil {
+		http.Error(w, "Erreur lors de l'encodage des métadonnées", http.StatusInternalServerError)
+	}
+}
+
+// ListSecretsMetadata liste les métadonnées des secrets d'un environnement
+// avec leurs sommes de contrôle et leur hygiène. Les agents et Terraform les comparent aux
+// sommes connues pour détecter une dérive sans télécharger les valeurs.
+// Les paramètres owner (me ou un membre) et team (mine ou une équipe) ne
+// gardent que les secrets de ces responsables, ou ceux de leur projet.
+func (h *SecretsHandler) ListSecretsMetadata(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	orgID := vars["orgID"]
+	projectID := vars["projectID"]
+	env := vars["env"]
+
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if err := h.policy.checkEnvironment(r.Context(), userID, orgID, projectID, env, secretRead); err != nil {
+		writePolicyError(w, err)
+		return
+	}
+
+	metadata, err := h.secrets.ListProjectSecrets(r.Context(), orgID, projectID, env)
+	if err == nil {
+		metadata, err = h.filterSecretsByOwner(r, orgID, projectID, userID, metadata)
+	}
+	if err != nil {
+		apierror.Write(w, err, "Impossible de lister les secrets")
+		return
+	}
+
+	degraded := false
+	for _, m := range metadata {
+		err := h.refreshChecksum(r, m)
+		if errors.Is(err, vault.ErrUnavailable) {
+			// Mode dégradé : la somme reste absente faute de pouvoir lire la valeur
+			degraded = true
+			continue
+		}
+		if errors.Is(err, vault.ErrSecretNotFound) {
+			continue
+		}
+		if err != nil {
+			apierror.Write(w, err, "Impossible de calculer les sommes de contrôle")
+			return
+		}
+	}
+	if degraded {
+		w.Header().Set("Warning", `199 - "mode dégradé : sommes de contrôle incomplètes"`)
+	}
+	if err := h.assessHygiene(r, orgID, metadata...); err != nil {
+		apierror.Write(w, err, "Impossible d'évaluer l'hygiène des secrets")
+		return
+	}
+
+	writeJSONList(w, r, metadata)
+}
+
 // ListSecrets liste tous les secrets d'un projet
 func (h *SecretsHandler) ListSecrets(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
@@ -83,14 +433,33 @@ func (h *SecretsHandler) ListSecrets(w http.ResponseWriter, r *http.Request) {
 	projectID := vars["projectID"]
 	env := vars["env"]
 
-	// TODO: vérifier les permissions
+	userID := middleware.UserIDFromContext(r.Context())
 
-	secrets, err := h.vaultService.ListProjectSecrets(r.Context(), orgID, projectID, env)
+	if err := h.policy.checkEnvironment(r.Context(), userID, orgID, projectID, env, secretRead); err != nil {
+		writePolicyError(w, err)
+		return
+	}
+	workload, err := workloadFromRequest(r)
 	if err != nil {
-		http.Error(w, "Impossible de lister les secrets", http.StatusInternalServerError)
+		apierror.Write(w, err, "")
+		return
+	}
+	reason, err := h.readReason(r, orgID, env)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de vérifier les environnements protégés")
 		return
 	}
 
+	secrets, err := h.vaultService.ListProjectSecrets(r.Context(), orgID, projectID, env)
+	if errors.Is(err, vault.ErrDegraded) {
+		w.Header().Set("Warning", `199 - "mode dégradé : valeurs des secrets indisponibles"`)
+	} else if err != nil {
+		apierror.Write(w, err, "Impossible de lister les secrets")
+		return
+	}
+	h.recordReads(r, workload, secrets)
+	h.auditReads(r, reason, secrets)
+
 	w.Header().Set("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(secrets); err != nil {
 		http.Error(w, "Erreur lors de l'encodage des secrets", http.StatusInternalServerError)
@@ -105,12 +474,324 @@ func (h *SecretsHandler) DeleteSecret(w http.ResponseWriter, r *http.Request) {
 	env := vars["env"]
 	name := vars["name"]
 
-	// TODO: vérifier les permissions
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if err := h.policy.checkEnvironment(r.Context(), userID, orgID, projectID, env, secretWrite); err != nil {
+		writePolicyError(w, err)
+		return
+	}
+
+	// La suppression Vault est idempotente : vérifier l'existence pour renvoyer un vrai 404
+	if _, err := h.vaultService.GetSecret(r.Context(), orgID, projectID, env, name); err != nil {
+		apierror.Write(w, err, "Impossible de récupérer le secret")
+		return
+	}
+
+	metadata, err := h.unlockedMetadata(r, orgID, projectID, env, name)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de supprimer le secret")
+		return
+	}
+
+	if err := h.cancelScheduledByPath(r, orgID, projectID, env, name); err != nil {
+		apierror.Write(w, err, "Impossible d'annuler le changement planifié du secret")
+		return
+	}
 
 	if err := h.vaultService.DeleteSecret(r.Context(), orgID, projectID, env, name); err != nil {
-		http.Error(w, "Impossible de supprimer le secret", http.StatusInternalServerError)
+		apierror.Write(w, err, "Impossible de supprimer le secret")
 		return
 	}
 
+	if err := h.secrets.DeleteSecretMetadataByPath(r.Context(), orgID, projectID, env, name); err != nil {
+		apierror.Write(w, err, "Impossible de supprimer les métadonnées du secret")
+		return
+	}
+
+	h.notifyChange(r, metadata, orgID, projectID, env, notifications.SecretDeleted, userID, name)
+
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// BulkDeleteRequest liste les secrets d'un environnement à supprimer
+type BulkDeleteRequest struct {
+	Names []string `json:"names"`
+}
+
+// BulkDeleteSecrets supprime plusieurs secrets d'un environnement après
+// confirmation. Le token n'est valable que pour la même liste de noms.
+func (h *SecretsHandler) BulkDeleteSecrets(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	orgID := vars["orgID"]
+	projectID := vars["projectID"]
+	env := vars["env"]
+
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if err := h.policy.checkEnvironment(r.Context(), userID, orgID, projectID, env, secretWrite); err != nil {
+		writePolicyError(w, err)
+		return
+	}
+
+	var req BulkDeleteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Données invalides", http.StatusBadRequest)
+		return
+	}
+	names := slices.Compact(slices.Sorted(slices.Values(req.Names)))
+	if len(names) == 0 || names[0] == "" {
+		apierror.Write(w, apierror.Validation("Au moins un nom de secret est requis"), "")
+		return
+	}
+
+	// Refuser dès la demande si un des secrets est verrouillé
+	for _, name := range names {
+		if _, err := h.unlockedMetadata(r, orgID, projectID, env, name); err != nil {
+			apierror.Write(w, err, "Impossible de vérifier les secrets à supprimer")
+			return
+		}
+	}
+
+	target := confirmationTarget(append([]string{projectID, env}, names...)...)
+	if !h.confirmer.confirm(w, r, orgID, userID, models.ActionBulkDeleteSecrets, target) {
+		return
+	}
+
+	deleted := make([]string, 0, len(names))
+	for _, name := range names {
+		if err := h.cancelScheduledByPath(r, orgID, projectID, env, name); err != nil {
+			apierror.Write(w, err, "Impossible d'annuler le changement planifié du secret "+name)
+			return
+		}
+		if err := h.vaultService.DeleteSecret(r.Context(), orgID, projectID, env, name); err != nil {
+			apierror.Write(w, err, "Impossible de supprimer le secret "+name)
+			return
+		}
+		if err := h.secrets.DeleteSecretMetadataByPath(r.Context(), orgID, projectID, env, name); err != nil {
+			apierror.Write(w, err, "Impossible de supprimer les métadonnées du secret "+name)
+			return
+		}
+		deleted = append(deleted, name)
+	}
+
+	h.notifyChange(r, nil, orgID, projectID, env, notifications.SecretDeleted, userID, deleted...)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string][]string{"deleted": deleted})
+}
+
+// SecretLockRequest porte la raison d'un verrouillage ou déverrouillage
+type SecretLockRequest struct {
+	Reason string `json:"reason"`
+}
+
+// LockSecret verrouille un secret : plus aucune modification ni suppression
+// jusqu'à son déverrouillage par un administrateur.
+func (h *SecretsHandler) LockSecret(w http.ResponseWriter, r *http.Request) {
+	h.setLock(w, r, true)
+}
+
+// UnlockSecret retire le verrou d'un secret
+func (h *SecretsHandler) UnlockSecret(w http.ResponseWriter, r *http.Request) {
+	h.setLock(w, r, false)
+}
+
+func (h *SecretsHandler) setLock(w http.ResponseWriter, r *http.Request, lock bool) {
+	vars := mux.Vars(r)
+	orgID := vars["orgID"]
+	projectID := vars["projectID"]
+	env := vars["env"]
+	name := vars["name"]
+
+	userID := middleware.UserIDFromContext(r.Context())
+
+	// Seuls les administrateurs de l'organisation verrouillent et déverrouillent
+	if err := h.policy.checkEnvironment(r.Context(), userID, orgID, projectID, env, secretAdmin); err != nil {
+		writePolicyError(w, err)
+		return
+	}
+
+	var req SecretLockRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Données invalides", http.StatusBadRequest)
+		return
+	}
+	if strings.TrimSpace(req.Reason) == "" {
+		apierror.Write(w, apierror.Validation("Une raison est requise"), "")
+		return
+	}
+
+	secret, err := h.vaultService.GetSecret(r.Context(), orgID, projectID, env, name)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de récupérer le secret")
+		return
+	}
+
+	metadata, err := h.secrets.GetSecretMetadataByPath(r.Context(), orgID, projectID, env, name)
+	if err == nil && metadata == nil {
+		// Secret antérieur aux métadonnées : les créer avant de le verrouiller
+		metadata, err = h.createMetadata(r, secret)
+	}
+	if err != nil {
+		apierror.Write(w, err, "Impossible de récupérer les métadonnées du secret")
+		return
+	}
+
+	if lock {
+		err = h.secrets.LockSecret(r.Context(), metadata.ID, userID, req.Reason)
+	} else {
+		err = h.secrets.UnlockSecret(r.Context(), metadata.ID)
+	}
+	if err != nil {
+		apierror.Write(w, err, "Impossible de modifier le verrou du secret")
+		return
+	}
+
+	logging.For(logging.ComponentHTTP).Info("verrou du secret modifié",
+		"organization_id", orgID, "project_id", projectID, "environment", env, "secret", name,
+		"locked", lock, "user_id", userID, "reason", req.Reason)
+
+	metadata, err = h.secrets.GetSecretMetadata(r.Context(), metadata.ID)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de récupérer les métadonnées du secret")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(metadata); err != nil {
+		http.Error(w, "Erreur lors de l'encodage des métadonnées", http.StatusInternalServerError)
+	}
+}
+
+// unlockedMetadata renvoie les métadonnées du secret (éventuellement nil)
+// ou storage.ErrSecretLocked si le secret est verrouillé.
+func (h *SecretsHandler) unlockedMetadata(r *http.Request, orgID, projectID, env, name string) (*models.SecretMetadata, error) {
+	metadata, err := h.secrets.GetSecretMetadataByPath(r.Context(), orgID, projectID, env, name)
+	if err != nil {
+		return nil, err
+	}
+	if metadata != nil && metadata.IsLocked() {
+		return nil, storage.ErrSecretLocked
+	}
+	return metadata, nil
+}
+
+// checkEncryption impose le chiffrement de bout en bout aux secrets d'un
+// projet qui a enregistré une clé publique : la valeur doit être une
+// enveloppe chiffrée pour la clé actuelle du projet, et le secret est alors
+// marqué E2E. Le serveur ne vérifie que la forme de l'enveloppe, il ne peut
+// pas la déchiffrer.
+func (h *SecretsHandler) checkEncryption(r *http.Request, secret *models.Secret) error {
+	key, err := h.projects.GetProjectEncryptionKey(r.Context(), secret.ProjectID)
+	if err != nil {
+		return err
+	}
+	if key == nil {
+		if secret.E2E {
+			return apierror.Validation("Le projet n'a pas de clé de chiffrement de bout en bout")
+		}
+		return nil
+	}
+
+	envelope, err := e2e.ParseEnvelope(secret.Value)
+	if err != nil {
+		return apierror.Validation("Le projet exige des valeurs chiffrées de bout en bout")
+	}
+	if envelope.KeyID != key.KeyID {
+		return apierror.Validation("La valeur n'est pas chiffrée pour la clé actuelle du projet")
+	}
+	secret.E2E = true
+	return nil
+}
+
+// checksum renvoie la somme de contrôle de la valeur du secret, vide si
+// les sommes de contrôle sont désactivées
+func (h *SecretsHandler) checksum(secret *models.Secret) string {
+	if h.checksummer == nil {
+		return ""
+	}
+	return h.checksummer.Sum(secret)
+}
+
+// refreshChecksum calcule et enregistre la somme de contrôle d'un secret
+// écrit avant leur activation ou avec une ancienne clé
+func (h *SecretsHandler) refreshChecksum(r *http.Request, metadata *models.SecretMetadata) error {
+	if h.checksummer == nil {
+		metadata.Checksum = ""
+		return nil
+	}
+	if h.checksummer.Current(metadata.Checksum) {
+		return nil
+	}
+
+	secret, err := h.vaultService.GetSecret(r.Context(),
+		metadata.OrganizationID, metadata.ProjectID, metadata.Environment, metadata.Name)
+	if err != nil {
+		return err
+	}
+	metadata.Checksum = h.checksummer.Sum(secret)
+	return h.secrets.SetSecretChecksum(r.Context(), metadata.ID, metadata.Checksum)
+}
+
+// notifyChange prévient les responsables d'un changement de secrets en
+// production : ceux du secret (metadata, nil pour plusieurs secrets ou une
+// création), sinon ceux du projet
+func (h *SecretsHandler) notifyChange(r *http.Request, metadata *models.SecretMetadata,
+	orgID, projectID, env, action, userID string, names ...string) {
+	if notifications.IsProduction(env) {
+		event := notifications.SecretsChanged(orgID, projectID, env, action, userID, names...)
+		event.Owner = notifications.SecretOwners(r.Context(), h.projects, orgID, projectID, metadata)
+		h.notifier.Notify(event)
+	}
+}
+
+// checkQuota alerte les administrateurs lorsque la création d'un secret
+// franchit un seuil de la limite du plan. L'alerte est facultative : une
+// erreur de lecture du compteur est ignorée.
+func (h *SecretsHandler) checkQuota(r *http.Request, orgID string) {
+	count, err := h.secrets.GetSecretsCount(r.Context(), orgID)
+	if err != nil {
+		return
+	}
+	limit, err := h.secrets.GetSecretsLimit(r.Context(), orgID)
+	if err != nil {
+		return
+	}
+	if event, ok := notifications.QuotaAlert(orgID, count, limit); ok {
+		h.notifier.Notify(event)
+	}
+}
+
+// saveMetadata crée les métadonnées d'un secret ou incrémente leur version
+func (h *SecretsHandler) saveMetadata(r *http.Request, metadata *models.SecretMetadata, secret *models.Secret) error {
+	if metadata == nil {
+		_, err := h.createMetadata(r, secret)
+		return err
+	}
+
+	metadata.Description = secret.Description
+	metadata.E2E = secret.E2E
+	metadata.Checksum = h.checksum(secret)
+	hygiene.Measure(metadata, secret, h.checksummer)
+	metadata.Version++
+	return h.secrets.UpdateSecretMetadata(r.Context(), metadata)
+}
+
+func (h *SecretsHandler) createMetadata(r *http.Request, secret *models.Secret) (*models.SecretMetadata, error) {
+	metadata := &models.SecretMetadata{
+		Name:           secret.Name,
+		Description:    secret.Description,
+		OrganizationID: secret.OrganizationID,
+		ProjectID:      secret.ProjectID,
+		Environment:    secret.Environment,
+		CreatedBy:      secret.CreatedBy,
+		Version:        1,
+		E2E:            secret.E2E,
+		Checksum:       h.checksum(secret),
+	}
+	hygiene.Measure(metadata, secret, h.checksummer)
+	if err := h.secrets.CreateSecretMetadata(r.Context(), metadata); err != nil {
+		return nil, err
+	}
+	return metadata, nil
+}
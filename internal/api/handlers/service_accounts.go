@@ -0,0 +1,406 @@
+// filepath: internal/api/handlers/service_accounts.go
+
+package handlers
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"secrets-manager/internal/api/apierror"
+	"secrets-manager/internal/api/middleware"
+	"secrets-manager/internal/auth"
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// Limite du nombre de ressources d'un compte de service
+const maxServiceAccountResources = 50
+
+// Message d'erreur des identifiants SPIFFE refusés
+const invalidSPIFFEID = "Identifiant SPIFFE invalide (spiffe://domaine/chemin, 512 caractères au plus)"
+
+// ServiceAccountsHandler gère les comptes de service des organisations et
+// leur confiance mTLS, réservés aux administrateurs, et l'échange de leur
+// secret contre un token
+type ServiceAccountsHandler struct {
+	authService *auth.Service
+	accounts    storage.ServiceAccountsRepository
+	projects    storage.ProjectsRepository
+	users       storage.UsersRepository
+	history     storage.SettingsHistoryRepository
+}
+
+// NewServiceAccountsHandler crée un nouveau gestionnaire de comptes de service
+func NewServiceAccountsHandler(authService *auth.Service, accounts storage.ServiceAccountsRepository,
+	projects storage.ProjectsRepository, users storage.UsersRepository,
+	history storage.SettingsHistoryRepository) *ServiceAccountsHandler {
+	return &ServiceAccountsHandler{
+		authService: authService,
+		accounts:    accounts,
+		projects:    projects,
+		users:       users,
+		history:     history,
+	}
+}
+
+// ServiceAccountCreation représente une demande de compte de service
+type ServiceAccountCreation struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// Permission vaut "read" ou "read_write"
+	Permission string `json:"permission"`
+	// Resources liste les projets accessibles, chacun restreint ou non à un
+	// environnement ; au moins une est requise
+	Resources []models.ResourceScope `json:"resources"`
+	// SPIFFEID authentifie aussi le compte par certificat client (optionnel)
+	SPIFFEID string `json:"spiffe_id"`
+}
+
+// ServiceAccountTrustUpdate remplace la confiance mTLS d'une organisation
+type ServiceAccountTrustUpdate struct {
+	// TrustDomain est le domaine de confiance SPIFFE de ses comptes
+	TrustDomain string `json:"trust_domain"`
+	// CABundle contient les autorités (PEM) qui signent leurs certificats
+	CABundle string `json:"ca_bundle"`
+}
+
+// SPIFFEIDUpdate associe un identifiant SPIFFE à un compte de service ;
+// vide, il le retire
+type SPIFFEIDUpdate struct {
+	SPIFFEID string `json:"spiffe_id"`
+}
+
+// CreatedServiceAccount est renvoyé à la création : Secret n'est plus
+// jamais affiché
+type CreatedServiceAccount struct {
+	*models.ServiceAccount
+	Secret string `json:"secret"`
+}
+
+// ListServiceAccounts liste les comptes de service de l'organisation, sans leur secret
+func (h *ServiceAccountsHandler) ListServiceAccounts(w http.ResponseWriter, r *http.Request) {
+	orgID := mux.Vars(r)["orgID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if !requireOrgAdmin(w, r, h.users, orgID, userID) {
+		return
+	}
+
+	accounts, err := h.accounts.ListServiceAccounts(r.Context(), orgID)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de lister les comptes de service")
+		return
+	}
+	writeJSONList(w, r, accounts)
+}
+
+// CreateServiceAccount crée un compte de service et renvoie, une seule
+// fois, son secret
+func (h *ServiceAccountsHandler) CreateServiceAccount(w http.ResponseWriter, r *http.Request) {
+	orgID := mux.Vars(r)["orgID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if !requireOrgAdmin(w, r, h.users, orgID, userID) {
+		return
+	}
+
+	var creation ServiceAccountCreation
+	if err := json.NewDecoder(r.Body).Decode(&creation); err != nil {
+		http.Error(w, "Données invalides", http.StatusBadRequest)
+		return
+	}
+	creation.Name = strings.TrimSpace(creation.Name)
+	if creation.Name == "" || len(creation.Name) > 128 {
+		apierror.Write(w, apierror.Validation("Nom du compte requis (128 caractères au plus)"), "")
+		return
+	}
+	if creation.Permission != models.APIKeyRead && creation.Permission != models.APIKeyReadWrite {
+		apierror.Write(w, apierror.Validation("Permission invalide (read ou read_write)"), "")
+		return
+	}
+	creation.SPIFFEID = strings.TrimSpace(creation.SPIFFEID)
+	if creation.SPIFFEID != "" {
+		if err := h.checkSPIFFEID(r, orgID, creation.SPIFFEID); err != nil {
+			apierror.Write(w, err, "Impossible de vérifier l'identifiant SPIFFE")
+			return
+		}
+	}
+	if len(creation.Resources) == 0 || len(creation.Resources) > maxServiceAccountResources {
+		apierror.Write(w, apierror.Validation("Ressources requises (50 au plus)"), "")
+		return
+	}
+	for _, resource := range creation.Resources {
+		if len(resource.Environment) > 64 {
+			apierror.Write(w, apierror.Validation("Nom d'environnement trop long (64 caractères au plus)"), "")
+			return
+		}
+		_, err := h.projects.GetProject(r.Context(), orgID, resource.ProjectID)
+		if errors.Is(err, storage.ErrNotFound) {
+			apierror.Write(w, apierror.Validation("Projet inconnu : "+resource.ProjectID), "")
+			return
+		}
+		if err != nil {
+			apierror.Write(w, err, "Impossible de vérifier le projet")
+			return
+		}
+	}
+
+	secret, hash, prefix, err := auth.NewServiceAccountSecret()
+	if err != nil {
+		apierror.Write(w, err, "Impossible de générer le secret")
+		return
+	}
+	account := &models.ServiceAccount{
+		OrganizationID: orgID,
+		Name:           creation.Name,
+		Description:    creation.Description,
+		SecretHash:     hash,
+		SecretPrefix:   prefix,
+		Permission:     creation.Permission,
+		Resources:      creation.Resources,
+		SPIFFEID:       creation.SPIFFEID,
+		CreatedBy:      userID,
+	}
+	if err := h.accounts.CreateServiceAccount(r.Context(), account); err != nil {
+		apierror.Write(w, err, "Impossible de créer le compte de service")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(CreatedServiceAccount{ServiceAccount: account, Secret: secret})
+}
+
+// UpdateSPIFFEID associe au compte de service l'identifiant SPIFFE des
+// certificats clients qui l'authentifient sur le listener mTLS, ou le retire.
+// L'identifiant doit appartenir au domaine de confiance de l'organisation et
+// ne peut être associé qu'à un seul de ses comptes (409).
+func (h *ServiceAccountsHandler) UpdateSPIFFEID(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	orgID := vars["orgID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if !requireOrgAdmin(w, r, h.users, orgID, userID) {
+		return
+	}
+
+	var update SPIFFEIDUpdate
+	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+		http.Error(w, "Données invalides", http.StatusBadRequest)
+		return
+	}
+	update.SPIFFEID = strings.TrimSpace(update.SPIFFEID)
+	if update.SPIFFEID != "" {
+		if err := h.checkSPIFFEID(r, orgID, update.SPIFFEID); err != nil {
+			apierror.Write(w, err, "Impossible de vérifier l'identifiant SPIFFE")
+			return
+		}
+	}
+
+	err := h.accounts.SetServiceAccountSPIFFEID(r.Context(), orgID, vars["accountID"], update.SPIFFEID)
+	if err != nil {
+		apierror.Write(w, err, "Impossible d'associer l'identifiant SPIFFE")
+		return
+	}
+	account, err := h.accounts.GetServiceAccount(r.Context(), vars["accountID"])
+	if err != nil {
+		apierror.Write(w, err, "Impossible de récupérer le compte de service")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(account)
+}
+
+// checkSPIFFEID vérifie qu'un identifiant SPIFFE est valide et appartient
+// au domaine de confiance déclaré par l'organisation
+func (h *ServiceAccountsHandler) checkSPIFFEID(r *http.Request, orgID, spiffeID string) error {
+	if !models.ValidSPIFFEID(spiffeID) {
+		return apierror.Validation(invalidSPIFFEID)
+	}
+	trust, err := h.accounts.GetServiceAccountTrust(r.Context(), orgID)
+	if errors.Is(err, storage.ErrNotFound) {
+		return apierror.Validation("Déclarez le domaine de confiance mTLS de l'organisation avant ses identifiants SPIFFE")
+	}
+	if err != nil {
+		return err
+	}
+	if !trust.Contains(spiffeID) {
+		return apierror.Validation("L'identifiant SPIFFE doit appartenir au domaine de confiance de l'organisation (" +
+			trust.TrustDomain + ")")
+	}
+	return nil
+}
+
+// GetTrust renvoie la confiance mTLS de l'organisation (404 si elle n'en a
+// pas déclaré)
+func (h *ServiceAccountsHandler) GetTrust(w http.ResponseWriter, r *http.Request) {
+	orgID := mux.Vars(r)["orgID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if !requireOrgAdmin(w, r, h.users, orgID, userID) {
+		return
+	}
+
+	trust, err := h.accounts.GetServiceAccountTrust(r.Context(), orgID)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de récupérer la confiance mTLS")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(trust)
+}
+
+// UpdateTrust remplace la confiance mTLS de l'organisation : le domaine de
+// confiance des identifiants SPIFFE de ses comptes et les autorités qui
+// signent leurs certificats clients. Un domaine déjà déclaré par une autre
+// organisation est refusé (409). Les comptes dont l'identifiant sort du
+// nouveau domaine ne s'authentifient plus par certificat.
+func (h *ServiceAccountsHandler) UpdateTrust(w http.ResponseWriter, r *http.Request) {
+	orgID := mux.Vars(r)["orgID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if !requireOrgAdmin(w, r, h.users, orgID, userID) {
+		return
+	}
+
+	var update ServiceAccountTrustUpdate
+	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+		http.Error(w, "Données invalides", http.StatusBadRequest)
+		return
+	}
+	update.TrustDomain = strings.TrimSpace(update.TrustDomain)
+	if !models.ValidTrustDomain(update.TrustDomain) {
+		apierror.Write(w, apierror.Validation(fmt.Sprintf(
+			"Domaine de confiance invalide (minuscules, chiffres, « . », « - » et « _ », %d caractères au plus)",
+			models.MaxTrustDomainLength)), "")
+		return
+	}
+	if err := validateCABundle(update.CABundle); err != nil {
+		apierror.Write(w, err, "")
+		return
+	}
+	before, err := h.accounts.GetServiceAccountTrust(r.Context(), orgID)
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		apierror.Write(w, err, "Impossible de récupérer la confiance mTLS")
+		return
+	}
+
+	trust := &models.ServiceAccountTrust{
+		OrganizationID: orgID,
+		TrustDomain:    update.TrustDomain,
+		CABundle:       update.CABundle,
+		UpdatedBy:      userID,
+		UpdatedAt:      time.Now().UTC().Truncate(time.Second),
+	}
+	if err := h.accounts.SaveServiceAccountTrust(r.Context(), trust); err != nil {
+		apierror.Write(w, err, "Impossible d'enregistrer la confiance mTLS")
+		return
+	}
+	recordSettingsChange(r.Context(), h.history, orgID, models.SettingsServiceAccountTrust, orgID,
+		models.SettingsUpdated, before, trust)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(trust)
+}
+
+// validateCABundle vérifie que bundle ne contient que des certificats PEM
+// d'autorités, au moins un
+func validateCABundle(bundle string) error {
+	if len(bundle) > models.MaxCABundleLength {
+		return apierror.Validation(fmt.Sprintf("ca_bundle trop volumineux (%d octets au plus)",
+			models.MaxCABundleLength))
+	}
+	rest, count := []byte(bundle), 0
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			return apierror.Validation("ca_bundle ne doit contenir que des certificats")
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil || !cert.IsCA {
+			return apierror.Validation("ca_bundle contient un certificat d'autorité invalide")
+		}
+		count++
+	}
+	if count == 0 || strings.TrimSpace(string(rest)) != "" {
+		return apierror.Validation("ca_bundle doit contenir au moins un certificat d'autorité (PEM)")
+	}
+	return nil
+}
+
+// DeleteServiceAccount supprime un compte de service de l'organisation :
+// ses tokens sont refusés dès la requête suivante
+func (h *ServiceAccountsHandler) DeleteServiceAccount(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	orgID := vars["orgID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if !requireOrgAdmin(w, r, h.users, orgID, userID) {
+		return
+	}
+
+	if err := h.accounts.DeleteServiceAccount(r.Context(), orgID, vars["accountID"]); err != nil {
+		apierror.Write(w, err, "Impossible de supprimer le compte de service")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// IssueToken échange les identifiants d'un compte de service contre un
+// token d'accès (grant_type=client_credentials, RFC 6749, section 4.4).
+// Les identifiants sont lus dans l'en-tête HTTP Basic ou, à défaut, dans
+// les champs client_id et client_secret du formulaire.
+func (h *ServiceAccountsHandler) IssueToken(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeOAuthError(w, "invalid_request", "Formulaire invalide")
+		return
+	}
+	if r.PostForm.Get("grant_type") != "client_credentials" {
+		writeOAuthError(w, "unsupported_grant_type", "Seul client_credentials est accepté")
+		return
+	}
+	id, secret, ok := r.BasicAuth()
+	if !ok {
+		id, secret = r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+	}
+	if id == "" || secret == "" {
+		writeOAuthError(w, "invalid_request", "client_id et client_secret requis")
+		return
+	}
+
+	token, err := h.authService.IssueServiceAccountToken(r.Context(), id, secret)
+	if errors.Is(err, auth.ErrInvalidCredentials) {
+		w.Header().Set("WWW-Authenticate", `Basic realm="secrets-manager"`)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(map[string]string{
+			"error":             "invalid_client",
+			"error_description": "Identifiants du compte de service invalides",
+		})
+		return
+	}
+	if err != nil {
+		apierror.Write(w, err, "Impossible d'émettre le token")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(token)
+}
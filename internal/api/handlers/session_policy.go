@@ -0,0 +1,126 @@
+// filepath: internal/api/handlers/session_policy.go
+
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"secrets-manager/internal/api/apierror"
+	"secrets-manager/internal/api/middleware"
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// SessionPolicyHandler expose la politique de session des organisations :
+// durée maximale des sessions, délai d'inactivité et nombre de sessions
+// simultanées de leurs membres
+type SessionPolicyHandler struct {
+	policies storage.SessionPoliciesRepository
+	users    storage.UsersRepository
+	history  storage.SettingsHistoryRepository
+}
+
+// NewSessionPolicyHandler crée un nouveau gestionnaire des politiques de session
+func NewSessionPolicyHandler(policies storage.SessionPoliciesRepository, users storage.UsersRepository,
+	history storage.SettingsHistoryRepository) *SessionPolicyHandler {
+	return &SessionPolicyHandler{
+		policies: policies,
+		users:    users,
+		history:  history,
+	}
+}
+
+// GetPolicy renvoie la politique de session de l'organisation à ses membres
+// (sans limite si elle n'en a pas réglé)
+func (h *SessionPolicyHandler) GetPolicy(w http.ResponseWriter, r *http.Request) {
+	orgID := mux.Vars(r)["orgID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if role, err := h.users.GetUserRole(r.Context(), userID, orgID); err != nil || role == "" {
+		http.Error(w, "Organisation non trouvée", http.StatusNotFound)
+		return
+	}
+
+	policy, err := h.policy(r, orgID)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de récupérer la politique de session")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(policy)
+}
+
+// UpdatePolicy remplace la politique de session de l'organisation. Elle
+// s'applique aux prochains tokens émis pour ses membres, y compris aux
+// rafraîchissements des sessions ouvertes.
+func (h *SessionPolicyHandler) UpdatePolicy(w http.ResponseWriter, r *http.Request) {
+	orgID := mux.Vars(r)["orgID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if !requireOrgAdmin(w, r, h.users, orgID, userID) {
+		return
+	}
+
+	var policy models.SessionPolicy
+	if err := json.NewDecoder(r.Body).Decode(&policy); err != nil {
+		http.Error(w, "Données invalides", http.StatusBadRequest)
+		return
+	}
+	if err := validateSessionPolicy(&policy); err != nil {
+		apierror.Write(w, err, "")
+		return
+	}
+	before, err := h.policy(r, orgID)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de récupérer la politique de session")
+		return
+	}
+
+	policy.OrganizationID = orgID
+	policy.UpdatedAt = time.Now().UTC().Truncate(time.Second)
+	if err := h.policies.SaveSessionPolicy(r.Context(), &policy); err != nil {
+		apierror.Write(w, err, "Impossible d'enregistrer la politique de session")
+		return
+	}
+	recordSettingsChange(r.Context(), h.history, orgID, models.SettingsSessionPolicy, orgID, models.SettingsUpdated,
+		before, &policy)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(&policy)
+}
+
+// validateSessionPolicy vérifie les bornes des réglages (0 : sans limite)
+func validateSessionPolicy(policy *models.SessionPolicy) error {
+	if policy.MaxSessionHours < 0 || policy.MaxSessionHours > models.MaxSessionPolicyHours {
+		return apierror.Validation(fmt.Sprintf("max_session_hours doit être compris entre 0 et %d",
+			models.MaxSessionPolicyHours))
+	}
+	if policy.IdleTimeoutMinutes < 0 || (policy.IdleTimeoutMinutes > 0 && policy.IdleTimeoutMinutes < models.MinIdleTimeoutMinutes) {
+		return apierror.Validation(fmt.Sprintf("idle_timeout_minutes doit valoir 0 ou au moins %d",
+			models.MinIdleTimeoutMinutes))
+	}
+	if policy.MaxSessionHours > 0 && policy.IdleTimeout() > policy.MaxLifetime() {
+		return apierror.Validation("idle_timeout_minutes ne peut pas dépasser max_session_hours")
+	}
+	if policy.MaxSessions < 0 || policy.MaxSessions > models.MaxSessionPolicySessions {
+		return apierror.Validation(fmt.Sprintf("max_sessions doit être compris entre 0 et %d",
+			models.MaxSessionPolicySessions))
+	}
+	return nil
+}
+
+// policy renvoie la politique de l'organisation, sans limite par défaut
+func (h *SessionPolicyHandler) policy(r *http.Request, orgID string) (*models.SessionPolicy, error) {
+	policy, err := h.policies.GetSessionPolicy(r.Context(), orgID)
+	if errors.Is(err, storage.ErrNotFound) {
+		return &models.SessionPolicy{OrganizationID: orgID}, nil
+	}
+	return policy, err
+}
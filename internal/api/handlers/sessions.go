@@ -0,0 +1,101 @@
+// filepath: internal/api/handlers/sessions.go
+
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"secrets-manager/internal/api/apierror"
+	"secrets-manager/internal/api/middleware"
+)
+
+// RefreshRequest porte le token de rafraîchissement d'une session
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// Refresh échange un token de rafraîchissement contre de nouveaux tokens de
+// la même session. Le token présenté ne sert qu'une fois : le réutiliser
+// révoque la session.
+func (h *AuthHandler) Refresh(w http.ResponseWriter, r *http.Request) {
+	var req RefreshRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Données invalides", http.StatusBadRequest)
+		return
+	}
+	if req.RefreshToken == "" {
+		apierror.Write(w, apierror.Validation("refresh_token requis"), "")
+		return
+	}
+
+	token, err := h.authService.RefreshToken(r.Context(), req.RefreshToken, requestDevice(r, ""))
+	if err != nil {
+		apierror.Write(w, err, "Impossible de rafraîchir la session")
+		return
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	writeTokens(w, token.Token, token.RefreshToken)
+}
+
+// Logout ferme la session du token de rafraîchissement : ses tokens d'accès
+// sont refusés dès la requête suivante
+func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	var req RefreshRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Données invalides", http.StatusBadRequest)
+		return
+	}
+	if req.RefreshToken == "" {
+		apierror.Write(w, apierror.Validation("refresh_token requis"), "")
+		return
+	}
+
+	if err := h.authService.Logout(r.Context(), req.RefreshToken); err != nil {
+		apierror.Write(w, err, "Impossible de fermer la session")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListSessions liste les sessions actives de l'utilisateur connecté, avec
+// l'appareil de chacune ; la session de la requête est marquée current
+func (h *AuthHandler) ListSessions(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserIDFromContext(r.Context())
+	sessions, err := h.authService.Sessions(r.Context(), userID, middleware.SessionIDFromContext(r.Context()))
+	if err != nil {
+		apierror.Write(w, err, "Impossible de lister les sessions")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(sessions)
+}
+
+// RevokeSession révoque une session de l'utilisateur connecté
+func (h *AuthHandler) RevokeSession(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserIDFromContext(r.Context())
+	if err := h.authService.RevokeSession(r.Context(), userID, mux.Vars(r)["sessionID"]); err != nil {
+		apierror.Write(w, err, "Impossible de révoquer la session")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RevokeOtherSessions révoque toutes les sessions de l'utilisateur connecté
+// sauf celle de la requête
+func (h *AuthHandler) RevokeOtherSessions(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserIDFromContext(r.Context())
+	revoked, err := h.authService.RevokeOtherSessions(r.Context(), userID,
+		middleware.SessionIDFromContext(r.Context()))
+	if err != nil {
+		apierror.Write(w, err, "Impossible de révoquer les sessions")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]int64{"revoked": revoked})
+}
@@ -0,0 +1,85 @@
+// filepath: internal/api/handlers/settings_history.go
+
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"secrets-manager/internal/api/apierror"
+	"secrets-manager/internal/api/middleware"
+	"secrets-manager/internal/logging"
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// Éléments de configuration filtrables dans l'historique
+var settingsResources = map[string]bool{
+	models.SettingsResidency:         true,
+	models.SettingsMaintenanceWindow: true,
+	models.SettingsWebhook:           true,
+	models.SettingsSubscription:      true,
+	models.SettingsLogForwarder:      true,
+}
+
+// SettingsHistoryHandler expose l'historique de la configuration d'une
+// organisation aux administrateurs, pour la conformité et le diagnostic
+type SettingsHistoryHandler struct {
+	history storage.SettingsHistoryRepository
+	users   storage.UsersRepository
+}
+
+// NewSettingsHistoryHandler crée un nouveau gestionnaire de l'historique de configuration
+func NewSettingsHistoryHandler(history storage.SettingsHistoryRepository, users storage.UsersRepository) *SettingsHistoryHandler {
+	return &SettingsHistoryHandler{
+		history: history,
+		users:   users,
+	}
+}
+
+// ListSettingsChanges liste les modifications de la configuration, de la plus
+// récente à la plus ancienne. Paramètres optionnels : resource, limit, offset.
+func (h *SettingsHistoryHandler) ListSettingsChanges(w http.ResponseWriter, r *http.Request) {
+	orgID := mux.Vars(r)["orgID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if !requireOrgAdmin(w, r, h.users, orgID, userID) {
+		return
+	}
+	resource := r.URL.Query().Get("resource")
+	if resource != "" && !settingsResources[resource] {
+		apierror.Write(w, apierror.Validation("Élément de configuration inconnu : "+resource), "")
+		return
+	}
+	limit, offset, ok := parsePagination(w, r)
+	if !ok {
+		return
+	}
+
+	changes, err := h.history.ListSettingsChanges(r.Context(), orgID, resource, limit, offset)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de lister l'historique de configuration")
+		return
+	}
+
+	writeJSONList(w, r, changes)
+}
+
+// recordSettingsChange historise une modification déjà appliquée de la
+// configuration de l'organisation. Un échec est journalisé sans faire échouer
+// la requête.
+func recordSettingsChange(ctx context.Context, history storage.SettingsHistoryRepository,
+	orgID, resource, resourceID, action string, before, after any) {
+	logger := logging.For(logging.ComponentHTTP)
+	change, err := models.NewSettingsChange(orgID, resource, resourceID, action,
+		middleware.UserIDFromContext(ctx), before, after)
+	if err == nil {
+		err = history.RecordSettingsChange(context.WithoutCancel(ctx), change)
+	}
+	if err != nil {
+		logger.Error("modification de la configuration non historisée",
+			"organization_id", orgID, "resource", resource, "resource_id", resourceID, "error", err)
+	}
+}
@@ -0,0 +1,174 @@
+// filepath: internal/api/handlers/status.go
+
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"time"
+
+	"secrets-manager/internal/api/apierror"
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// États publiés par la page de statut
+const (
+	StatusOperational = "operational"
+	StatusDegraded    = "degraded"
+	StatusOutage      = "outage"
+)
+
+// Jours d'historique publiés par composant
+const statusHistoryDays = 90
+
+// Durée d'affichage des incidents résolus
+const resolvedIncidentsWindow = 7 * 24 * time.Hour
+
+// Durée pendant laquelle la page de statut est mise en cache
+const statusMaxAge = 30 * time.Second
+
+// StatusHandler expose les données d'une page de statut publique : état
+// courant et disponibilité des composants, incidents en cours et récents
+type StatusHandler struct {
+	health storage.HealthRepository
+}
+
+// NewStatusHandler crée un nouveau gestionnaire de la page de statut
+func NewStatusHandler(health storage.HealthRepository) *StatusHandler {
+	return &StatusHandler{
+		health: health,
+	}
+}
+
+// Status est la réponse de GET /status
+type Status struct {
+	Status          string                   `json:"status"`
+	UpdatedAt       time.Time                `json:"updated_at"`
+	Components      []*StatusComponent       `json:"components"`
+	Incidents       []*models.HealthIncident `json:"incidents"`
+	RecentIncidents []*models.HealthIncident `json:"recent_incidents"`
+}
+
+// StatusComponent est l'état et la disponibilité d'un composant. Les
+// disponibilités sont des pourcentages de vérifications réussies, calculés
+// par jour (UTC) ; nil si aucune vérification n'a eu lieu sur la période.
+type StatusComponent struct {
+	Name      string       `json:"name"`
+	Status    string       `json:"status"`
+	Uptime1d  *float64     `json:"uptime_1d"`
+	Uptime7d  *float64     `json:"uptime_7d"`
+	Uptime30d *float64     `json:"uptime_30d"`
+	Uptime90d *float64     `json:"uptime_90d"`
+	History   []*StatusDay `json:"history"`
+}
+
+// StatusDay est la disponibilité d'un composant pendant un jour
+type StatusDay struct {
+	Day    string   `json:"day"` // AAAA-MM-JJ
+	Uptime *float64 `json:"uptime"`
+}
+
+// GetStatus renvoie l'état des composants sur les 90 derniers jours et les
+// incidents (sans authentification, pour une page de statut publique)
+func (h *StatusHandler) GetStatus(w http.ResponseWriter, r *http.Request) {
+	now := time.Now().UTC()
+	first := now.AddDate(0, 0, -(statusHistoryDays - 1))
+
+	days, err := h.health.ListHealthCheckDays(r.Context(), first)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de lire l'historique de santé")
+		return
+	}
+	incidents, err := h.health.ListHealthIncidents(r.Context(), now.Add(-resolvedIncidentsWindow))
+	if err != nil {
+		apierror.Write(w, err, "Impossible de lire les incidents")
+		return
+	}
+
+	status := &Status{
+		Status:          StatusOperational,
+		UpdatedAt:       now,
+		Components:      []*StatusComponent{},
+		Incidents:       []*models.HealthIncident{},
+		RecentIncidents: []*models.HealthIncident{},
+	}
+	// Position de chaque jour dans l'historique, du plus ancien à aujourd'hui
+	dayIndex := make(map[string]int, statusHistoryDays)
+	for i := 0; i < statusHistoryDays; i++ {
+		dayIndex[first.AddDate(0, 0, i).Format("2006-01-02")] = i
+	}
+	components := map[string]*StatusComponent{}
+	component := func(name string) *StatusComponent {
+		if c, ok := components[name]; ok {
+			return c
+		}
+		c := &StatusComponent{Name: name, Status: StatusOperational, History: make([]*StatusDay, statusHistoryDays)}
+		for day, i := range dayIndex {
+			c.History[i] = &StatusDay{Day: day}
+		}
+		components[name] = c
+		status.Components = append(status.Components, c)
+		return c
+	}
+
+	// Disponibilité de chaque jour, puis de chaque période jusqu'à aujourd'hui
+	byComponent := map[string][]*models.HealthCheckDay{}
+	for _, day := range days {
+		byComponent[day.Component] = append(byComponent[day.Component], day)
+	}
+	for name, aggregates := range byComponent {
+		c := component(name)
+		var checks, failures [statusHistoryDays]int64
+		for _, aggregate := range aggregates {
+			i, ok := dayIndex[aggregate.Day]
+			if !ok || aggregate.Checks == 0 {
+				continue
+			}
+			checks[i], failures[i] = aggregate.Checks, aggregate.Failures
+			c.History[i].Uptime = uptime(aggregate.Checks, aggregate.Failures)
+		}
+		c.Uptime1d = windowUptime(checks[:], failures[:], 1)
+		c.Uptime7d = windowUptime(checks[:], failures[:], 7)
+		c.Uptime30d = windowUptime(checks[:], failures[:], 30)
+		c.Uptime90d = windowUptime(checks[:], failures[:], statusHistoryDays)
+	}
+
+	for _, incident := range incidents {
+		if incident.ResolvedAt != nil {
+			status.RecentIncidents = append(status.RecentIncidents, incident)
+			continue
+		}
+		status.Incidents = append(status.Incidents, incident)
+		component(incident.Component).Status = StatusOutage
+		status.Status = StatusDegraded
+	}
+	sort.Slice(status.Components, func(i, j int) bool { return status.Components[i].Name < status.Components[j].Name })
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(statusMaxAge.Seconds())))
+	json.NewEncoder(w).Encode(status)
+}
+
+// windowUptime renvoie la disponibilité des n derniers jours de l'historique
+func windowUptime(checks, failures []int64, n int) *float64 {
+	var total, failed int64
+	for i := len(checks) - n; i < len(checks); i++ {
+		total += checks[i]
+		failed += failures[i]
+	}
+	return uptime(total, failed)
+}
+
+// uptime renvoie le pourcentage de vérifications réussies, arrondi au
+// millième, ou nil sans vérification
+func uptime(checks, failures int64) *float64 {
+	if checks == 0 {
+		return nil
+	}
+	percent := math.Round(float64(checks-failures)/float64(checks)*100*1000) / 1000
+	return &percent
+}
@@ -0,0 +1,51 @@
+// filepath: internal/api/handlers/stream.go
+
+package handlers
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"secrets-manager/internal/logging"
+)
+
+// streamFlushEvery est le nombre d'éléments ou de lignes envoyés entre deux
+// vidages d'une réponse en streaming
+const streamFlushEvery = 100
+
+// writeJSONList écrit une liste JSON élément par élément au lieu d'encoder
+// la réponse complète en mémoire, et la vide régulièrement (transfert
+// chunked). Une liste vide donne []. Une fois l'envoi commencé, une erreur
+// ne peut plus changer le statut : elle est journalisée et la réponse
+// tronquée.
+func writeJSONList[T any](w http.ResponseWriter, r *http.Request, items []T) {
+	w.Header().Set("Content-Type", "application/json")
+
+	rc := http.NewResponseController(w)
+	encoder := json.NewEncoder(w)
+	err := func() error {
+		if _, err := io.WriteString(w, "["); err != nil {
+			return err
+		}
+		for i, item := range items {
+			if i > 0 {
+				if _, err := io.WriteString(w, ","); err != nil {
+					return err
+				}
+			}
+			if err := encoder.Encode(item); err != nil {
+				return err
+			}
+			if (i+1)%streamFlushEvery == 0 {
+				rc.Flush()
+			}
+		}
+		_, err := io.WriteString(w, "]\n")
+		return err
+	}()
+	if err != nil {
+		logging.For(logging.ComponentHTTP).Warn("réponse en streaming interrompue",
+			"path", r.URL.Path, "error", err)
+	}
+}
@@ -0,0 +1,69 @@
+// filepath: internal/api/handlers/switches.go
+
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"secrets-manager/internal/api/middleware"
+	"secrets-manager/internal/logging"
+)
+
+// SwitchesHandler permet de couper et rétablir à chaud des routes ou des
+// fonctionnalités (incident, maintenance)
+type SwitchesHandler struct {
+	switches *middleware.Switches
+}
+
+// NewSwitchesHandler crée un nouveau gestionnaire d'interrupteurs
+func NewSwitchesHandler(switches *middleware.Switches) *SwitchesHandler {
+	return &SwitchesHandler{
+		switches: switches,
+	}
+}
+
+// ListSwitches renvoie les interrupteurs actifs et les fonctionnalités connues
+func (h *SwitchesHandler) ListSwitches(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"switches": h.switches.List(),
+		"features": middleware.Features,
+	})
+}
+
+// DisableSwitch coupe une route ou une fonctionnalité
+func (h *SwitchesHandler) DisableSwitch(w http.ResponseWriter, r *http.Request) {
+	var sw middleware.Switch
+	if err := json.NewDecoder(r.Body).Decode(&sw); err != nil {
+		http.Error(w, "Données invalides", http.StatusBadRequest)
+		return
+	}
+	if sw.Reason == "" {
+		http.Error(w, "La raison de la coupure est requise", http.StatusBadRequest)
+		return
+	}
+	if err := h.switches.Disable(sw); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	logging.For(logging.ComponentHTTP).Warn("interrupteur activé", "switch", sw.Name, "reason", sw.Reason)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(h.switches.List())
+}
+
+// EnableSwitch rétablit la route ou la fonctionnalité donnée par le paramètre name
+func (h *SwitchesHandler) EnableSwitch(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("name")
+	if !h.switches.Enable(name) {
+		http.Error(w, "Interrupteur non trouvé", http.StatusNotFound)
+		return
+	}
+
+	logging.For(logging.ComponentHTTP).Warn("interrupteur désactivé", "switch", name)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(h.switches.List())
+}
@@ -0,0 +1,298 @@
+// filepath: internal/api/handlers/teams.go
+
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"secrets-manager/internal/api/apierror"
+	"secrets-manager/internal/api/middleware"
+	"secrets-manager/internal/auth"
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// TeamsHandler gère les équipes d'une organisation. Les équipes sont
+// responsables de projets et de secrets (voir models.Ownership), reçoivent
+// les notifications de leurs changements en production et transmettent à
+// leurs membres les rôles qui leur sont accordés sur les projets (voir
+// models.TeamGrant).
+type TeamsHandler struct {
+	teams    storage.TeamsRepository
+	projects storage.ProjectsRepository
+	users    storage.UsersRepository
+}
+
+// NewTeamsHandler crée un nouveau gestionnaire d'équipes
+func NewTeamsHandler(teams storage.TeamsRepository, projects storage.ProjectsRepository,
+	users storage.UsersRepository) *TeamsHandler {
+	return &TeamsHandler{teams: teams, projects: projects, users: users}
+}
+
+// TeamRequest crée une équipe
+type TeamRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// TeamGrantRequest accorde un rôle à une équipe
+type TeamGrantRequest struct {
+	// Role vaut viewer, member ou admin
+	Role string `json:"role"`
+}
+
+// ListTeams liste les équipes de l'organisation, sans leurs membres
+func (h *TeamsHandler) ListTeams(w http.ResponseWriter, r *http.Request) {
+	orgID := mux.Vars(r)["orgID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if role, err := h.users.GetUserRole(r.Context(), userID, orgID); err != nil || role == "" {
+		http.Error(w, "Organisation non trouvée", http.StatusNotFound)
+		return
+	}
+
+	teams, err := h.teams.ListTeams(r.Context(), orgID)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de lister les équipes")
+		return
+	}
+	writeJSONList(w, r, teams)
+}
+
+// ListMyTeams liste les équipes de l'organisation dont l'utilisateur connecté est membre
+func (h *TeamsHandler) ListMyTeams(w http.ResponseWriter, r *http.Request) {
+	orgID := mux.Vars(r)["orgID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if role, err := h.users.GetUserRole(r.Context(), userID, orgID); err != nil || role == "" {
+		http.Error(w, "Organisation non trouvée", http.StatusNotFound)
+		return
+	}
+
+	teams, err := h.teams.ListUserTeams(r.Context(), orgID, userID)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de lister les équipes")
+		return
+	}
+	writeJSONList(w, r, teams)
+}
+
+// CreateTeam crée une équipe sans membre. Réservé aux administrateurs ; 409
+// si le nom est déjà pris dans l'organisation.
+func (h *TeamsHandler) CreateTeam(w http.ResponseWriter, r *http.Request) {
+	orgID := mux.Vars(r)["orgID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if !requireOrgAdmin(w, r, h.users, orgID, userID) {
+		return
+	}
+
+	var req TeamRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Données invalides", http.StatusBadRequest)
+		return
+	}
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		apierror.Write(w, apierror.Validation("Nom de l'équipe requis"), "")
+		return
+	}
+
+	team := &models.Team{
+		OrganizationID: orgID,
+		Name:           req.Name,
+		Description:    req.Description,
+		CreatedBy:      userID,
+	}
+	if err := h.teams.CreateTeam(r.Context(), team); err != nil {
+		apierror.Write(w, err, "Impossible de créer l'équipe")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(team)
+}
+
+// GetTeam renvoie une équipe avec ses membres et ses octrois
+func (h *TeamsHandler) GetTeam(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	orgID := vars["orgID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if role, err := h.users.GetUserRole(r.Context(), userID, orgID); err != nil || role == "" {
+		http.Error(w, "Organisation non trouvée", http.StatusNotFound)
+		return
+	}
+
+	team, err := h.teams.GetTeam(r.Context(), orgID, vars["teamID"])
+	if err != nil {
+		apierror.Write(w, err, "Impossible de récupérer l'équipe")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(team); err != nil {
+		http.Error(w, "Erreur lors de l'encodage de l'équipe", http.StatusInternalServerError)
+	}
+}
+
+// DeleteTeam supprime une équipe ; ses projets et secrets n'ont plus
+// d'équipe responsable. Réservé aux administrateurs.
+func (h *TeamsHandler) DeleteTeam(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	orgID := vars["orgID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if !requireOrgAdmin(w, r, h.users, orgID, userID) {
+		return
+	}
+
+	if err := h.teams.DeleteTeam(r.Context(), orgID, vars["teamID"]); err != nil {
+		apierror.Write(w, err, "Impossible de supprimer l'équipe")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// AddTeamMember ajoute un membre de l'organisation à une équipe. Réservé
+// aux administrateurs ; sans effet si l'utilisateur en fait déjà partie.
+func (h *TeamsHandler) AddTeamMember(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	orgID := vars["orgID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if !requireOrgAdmin(w, r, h.users, orgID, userID) {
+		return
+	}
+
+	team, err := h.teams.GetTeam(r.Context(), orgID, vars["teamID"])
+	if err != nil {
+		apierror.Write(w, err, "Impossible de récupérer l'équipe")
+		return
+	}
+	if err := checkMember(r.Context(), h.users, orgID, vars["userID"]); err != nil {
+		apierror.Write(w, err, "Impossible de vérifier les membres")
+		return
+	}
+
+	member := &models.TeamMember{TeamID: team.ID, UserID: vars["userID"], AddedBy: userID}
+	if err := h.teams.AddTeamMember(r.Context(), member); err != nil {
+		apierror.Write(w, err, "Impossible d'ajouter le membre")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RemoveTeamMember retire un membre d'une équipe. Réservé aux administrateurs.
+func (h *TeamsHandler) RemoveTeamMember(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	orgID := vars["orgID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if !requireOrgAdmin(w, r, h.users, orgID, userID) {
+		return
+	}
+
+	team, err := h.teams.GetTeam(r.Context(), orgID, vars["teamID"])
+	if err != nil {
+		apierror.Write(w, err, "Impossible de récupérer l'équipe")
+		return
+	}
+	if err := h.teams.RemoveTeamMember(r.Context(), team.ID, vars["userID"]); err != nil {
+		apierror.Write(w, err, "Impossible de retirer le membre")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// SetTeamGrant accorde un rôle à l'équipe sur un projet, ou sur l'un de
+// ses environnements pour la route .../environments/{env}/role, en
+// remplaçant l'octroi existant. Réservé aux administrateurs.
+func (h *TeamsHandler) SetTeamGrant(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	orgID := vars["orgID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if !requireOrgAdmin(w, r, h.users, orgID, userID) {
+		return
+	}
+
+	var req TeamGrantRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Données invalides", http.StatusBadRequest)
+		return
+	}
+	if !auth.RoleAtLeast(req.Role, auth.RoleViewer) {
+		apierror.Write(w, apierror.Validation("Rôle invalide (viewer, member ou admin)"), "")
+		return
+	}
+	if len(vars["env"]) > 64 {
+		apierror.Write(w, apierror.Validation("Nom d'environnement trop long (64 caractères au plus)"), "")
+		return
+	}
+
+	team, err := h.teams.GetTeam(r.Context(), orgID, vars["teamID"])
+	if err != nil {
+		apierror.Write(w, err, "Impossible de récupérer l'équipe")
+		return
+	}
+	if _, err := h.projects.GetProject(r.Context(), orgID, vars["projectID"]); err != nil {
+		apierror.Write(w, err, "Impossible de récupérer le projet")
+		return
+	}
+
+	grant := &models.TeamGrant{
+		TeamID:      team.ID,
+		ProjectID:   vars["projectID"],
+		Environment: vars["env"],
+		Role:        req.Role,
+		GrantedBy:   userID,
+	}
+	if err := h.teams.SetTeamGrant(r.Context(), grant); err != nil {
+		apierror.Write(w, err, "Impossible d'accorder le rôle")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(grant)
+}
+
+// RemoveTeamGrant retire le rôle accordé à l'équipe sur un projet ou un
+// environnement. Réservé aux administrateurs.
+func (h *TeamsHandler) RemoveTeamGrant(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	orgID := vars["orgID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if !requireOrgAdmin(w, r, h.users, orgID, userID) {
+		return
+	}
+
+	team, err := h.teams.GetTeam(r.Context(), orgID, vars["teamID"])
+	if err != nil {
+		apierror.Write(w, err, "Impossible de récupérer l'équipe")
+		return
+	}
+	if err := h.teams.RemoveTeamGrant(r.Context(), team.ID, vars["projectID"], vars["env"]); err != nil {
+		apierror.Write(w, err, "Impossible de retirer le rôle")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// checkMember renvoie une erreur de validation si l'utilisateur n'est pas
+// membre de l'organisation
+func checkMember(ctx context.Context, users storage.UsersRepository, orgID, userID string) error {
+	role, err := users.GetUserRole(ctx, userID, orgID)
+	if errors.Is(err, storage.ErrNotFound) || (err == nil && role == "") {
+		return apierror.Validation("L'utilisateur " + userID + " n'est pas membre de l'organisation")
+	}
+	return err
+}
@@ -0,0 +1,151 @@
+// filepath: internal/api/handlers/tokens.go
+
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"secrets-manager/internal/api/apierror"
+	"secrets-manager/internal/api/middleware"
+	"secrets-manager/internal/auth"
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+const (
+	// defaultTokenLifetimeDays est la durée de validité d'un token sans expiration demandée
+	defaultTokenLifetimeDays = 30
+	// maxTokenLifetimeDays est la durée de validité maximale d'un token
+	maxTokenLifetimeDays = 365
+	// maxTokenResources est le nombre maximal de projets d'un token
+	maxTokenResources = 50
+)
+
+// TokensHandler gère les tokens d'accès personnels de l'utilisateur connecté
+type TokensHandler struct {
+	tokens storage.PersonalAccessTokensRepository
+}
+
+// NewTokensHandler crée un nouveau gestionnaire de tokens d'accès personnels
+func NewTokensHandler(tokens storage.PersonalAccessTokensRepository) *TokensHandler {
+	return &TokensHandler{
+		tokens: tokens,
+	}
+}
+
+// TokenCreation représente une demande de token d'accès personnel
+type TokenCreation struct {
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+	// ExpiresInDays est la durée de validité en jours (30 par défaut, 365 au plus)
+	ExpiresInDays int `json:"expires_in_days"`
+	// Resources restreint le token à des projets, chacun restreint ou non à
+	// un environnement ; vide, le token couvre tous les projets
+	Resources []models.ResourceScope `json:"resources,omitempty"`
+}
+
+// CreatedToken est renvoyé à la création : Token n'est plus jamais affiché
+type CreatedToken struct {
+	*models.PersonalAccessToken
+	Token string `json:"token"`
+}
+
+// ListTokens liste les tokens de l'utilisateur, sans leur valeur
+func (h *TokensHandler) ListTokens(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserIDFromContext(r.Context())
+	tokens, err := h.tokens.ListPersonalAccessTokens(r.Context(), userID)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de lister les tokens")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(tokens)
+}
+
+// CreateToken crée un token d'accès personnel avec les portées demandées,
+// éventuellement restreint à des projets. Les droits de l'utilisateur
+// s'appliquent toujours : un projet auquel il n'a pas accès reste invisible.
+func (h *TokensHandler) CreateToken(w http.ResponseWriter, r *http.Request) {
+	var creation TokenCreation
+	if err := json.NewDecoder(r.Body).Decode(&creation); err != nil {
+		http.Error(w, "Données invalides", http.StatusBadRequest)
+		return
+	}
+	if creation.Name == "" || len(creation.Name) > 128 {
+		apierror.Write(w, apierror.Validation("Nom du token requis (128 caractères au plus)"), "")
+		return
+	}
+	if len(creation.Scopes) == 0 {
+		apierror.Write(w, apierror.Validation("Au moins une portée est requise"), "")
+		return
+	}
+	for _, scope := range creation.Scopes {
+		if !slices.Contains(models.TokenScopes, scope) {
+			apierror.Write(w, apierror.Validation("Portée inconnue : "+scope), "")
+			return
+		}
+	}
+	if creation.ExpiresInDays == 0 {
+		creation.ExpiresInDays = defaultTokenLifetimeDays
+	}
+	if creation.ExpiresInDays < 0 || creation.ExpiresInDays > maxTokenLifetimeDays {
+		apierror.Write(w, apierror.Validation("Durée de validité invalide (1 à 365 jours)"), "")
+		return
+	}
+	if len(creation.Resources) > maxTokenResources {
+		apierror.Write(w, apierror.Validation("Trop de projets (50 au plus)"), "")
+		return
+	}
+	for _, resource := range creation.Resources {
+		if resource.ProjectID == "" || len(resource.ProjectID) > 36 {
+			apierror.Write(w, apierror.Validation("Identifiant de projet invalide"), "")
+			return
+		}
+		if len(resource.Environment) > 64 {
+			apierror.Write(w, apierror.Validation("Nom d'environnement trop long (64 caractères au plus)"), "")
+			return
+		}
+	}
+
+	value, hash, prefix, err := auth.NewPersonalAccessToken()
+	if err != nil {
+		apierror.Write(w, err, "Impossible de générer le token")
+		return
+	}
+	slices.Sort(creation.Scopes)
+	token := &models.PersonalAccessToken{
+		UserID:    middleware.UserIDFromContext(r.Context()),
+		Name:      creation.Name,
+		TokenHash: hash,
+		Prefix:    prefix,
+		Scopes:    slices.Compact(creation.Scopes),
+		Resources: creation.Resources,
+		ExpiresAt: time.Now().UTC().Add(time.Duration(creation.ExpiresInDays) * 24 * time.Hour).Truncate(time.Second),
+	}
+	if err := h.tokens.CreatePersonalAccessToken(r.Context(), token); err != nil {
+		apierror.Write(w, err, "Impossible de créer le token")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(CreatedToken{PersonalAccessToken: token, Token: value})
+}
+
+// RevokeToken révoque un token de l'utilisateur
+func (h *TokensHandler) RevokeToken(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserIDFromContext(r.Context())
+	if err := h.tokens.DeletePersonalAccessToken(r.Context(), userID, mux.Vars(r)["tokenID"]); err != nil {
+		apierror.Write(w, err, "Impossible de révoquer le token")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
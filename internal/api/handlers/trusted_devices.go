@@ -0,0 +1,103 @@
+// filepath: internal/api/handlers/trusted_devices.go
+
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"secrets-manager/internal/api/apierror"
+	"secrets-manager/internal/api/middleware"
+	"secrets-manager/internal/auth"
+	"secrets-manager/internal/storage"
+)
+
+// trustedDeviceCookie porte le jeton d'un appareil de confiance
+const trustedDeviceCookie = "sm_trusted_device"
+
+// Longueur maximale du User-Agent enregistré avec un appareil
+const maxUserAgentLength = 512
+
+// TrustedDevicesHandler gère les appareils de confiance de l'utilisateur
+// connecté, dispensés de code TOTP à la connexion
+type TrustedDevicesHandler struct {
+	devices storage.TrustedDevicesRepository
+}
+
+// NewTrustedDevicesHandler crée un nouveau gestionnaire d'appareils de confiance
+func NewTrustedDevicesHandler(devices storage.TrustedDevicesRepository) *TrustedDevicesHandler {
+	return &TrustedDevicesHandler{
+		devices: devices,
+	}
+}
+
+// ListDevices liste les appareils de confiance de l'utilisateur
+func (h *TrustedDevicesHandler) ListDevices(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserIDFromContext(r.Context())
+	devices, err := h.devices.ListTrustedDevices(r.Context(), userID)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de lister les appareils")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(devices)
+}
+
+// RevokeDevice révoque la confiance accordée à un appareil : le prochain
+// login depuis cet appareil exigera un code TOTP
+func (h *TrustedDevicesHandler) RevokeDevice(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserIDFromContext(r.Context())
+	if err := h.devices.DeleteTrustedDevice(r.Context(), userID, mux.Vars(r)["deviceID"]); err != nil {
+		apierror.Write(w, err, "Impossible de révoquer l'appareil")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RevokeAllDevices révoque tous les appareils de confiance de l'utilisateur
+func (h *TrustedDevicesHandler) RevokeAllDevices(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserIDFromContext(r.Context())
+	if _, err := h.devices.DeleteUserTrustedDevices(r.Context(), userID); err != nil {
+		apierror.Write(w, err, "Impossible de révoquer les appareils")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// requestDevice décrit l'appareil à l'origine de la requête
+func requestDevice(r *http.Request, name string) *auth.Device {
+	userAgent := r.UserAgent()
+	if len(userAgent) > maxUserAgentLength {
+		userAgent = userAgent[:maxUserAgentLength]
+	}
+	device := &auth.Device{
+		Fingerprint: auth.DeviceFingerprint(r.UserAgent()),
+		UserAgent:   userAgent,
+		IPAddress:   middleware.ClientIP(r),
+		Name:        name,
+	}
+	if cookie, err := r.Cookie(trustedDeviceCookie); err == nil {
+		device.Token = cookie.Value
+	}
+	return device
+}
+
+// setTrustedDeviceCookie remet au navigateur le jeton de son appareil de
+// confiance, inaccessible aux scripts et limité à l'API
+func setTrustedDeviceCookie(w http.ResponseWriter, value string, expiresAt time.Time) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     trustedDeviceCookie,
+		Value:    value,
+		Path:     "/api",
+		Expires:  expiresAt,
+		Secure:   true,
+		HttpOnly: true,
+		SameSite: http.SameSiteStrictMode,
+	})
+}
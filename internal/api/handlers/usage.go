@@ -0,0 +1,75 @@
+// filepath: internal/api/handlers/usage.go
+
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"secrets-manager/internal/api/apierror"
+	"secrets-manager/internal/api/middleware"
+	"secrets-manager/internal/storage"
+)
+
+// Période par défaut du détail d'usage
+const defaultUsagePeriod = 30 * 24 * time.Hour
+
+// UsageHandler gère les routes liées à l'usage de l'API
+type UsageHandler struct {
+	usage storage.UsageRepository
+	users storage.UsersRepository
+}
+
+// NewUsageHandler crée un nouveau gestionnaire d'usage
+func NewUsageHandler(usage storage.UsageRepository, users storage.UsersRepository) *UsageHandler {
+	return &UsageHandler{
+		usage: usage,
+		users: users,
+	}
+}
+
+// GetBreakdown renvoie les appels API d'une organisation par principal, route et jour.
+// Paramètres optionnels : from et to au format AAAA-MM-JJ (30 derniers jours par défaut).
+func (h *UsageHandler) GetBreakdown(w http.ResponseWriter, r *http.Request) {
+	orgID := mux.Vars(r)["orgID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	// Seuls les administrateurs de l'organisation peuvent consulter l'usage
+	if !requireOrgAdmin(w, r, h.users, orgID, userID) {
+		return
+	}
+
+	var err error
+	to := time.Now().UTC()
+	from := to.Add(-defaultUsagePeriod)
+	if v := r.URL.Query().Get("from"); v != "" {
+		if from, err = time.Parse("2006-01-02", v); err != nil {
+			http.Error(w, "Paramètre from invalide", http.StatusBadRequest)
+			return
+		}
+	}
+	if v := r.URL.Query().Get("to"); v != "" {
+		if to, err = time.Parse("2006-01-02", v); err != nil {
+			http.Error(w, "Paramètre to invalide", http.StatusBadRequest)
+			return
+		}
+	}
+	if to.Before(from) {
+		http.Error(w, "La période demandée est invalide", http.StatusBadRequest)
+		return
+	}
+
+	breakdown, err := h.usage.GetUsageBreakdown(r.Context(), orgID, from, to)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de récupérer l'usage")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(breakdown); err != nil {
+		http.Error(w, "Erreur lors de l'encodage de l'usage", http.StatusInternalServerError)
+	}
+}
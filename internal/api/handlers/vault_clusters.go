@@ -0,0 +1,45 @@
+// filepath: internal/api/handlers/vault_clusters.go
+
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"secrets-manager/internal/vault"
+)
+
+// ClusterMonitor vérifie et rapporte la santé des clusters Vault
+type ClusterMonitor interface {
+	Check(ctx context.Context) error
+	Statuses() []vault.ClusterStatus
+}
+
+// VaultClustersHandler expose la santé et la réplication des clusters Vault
+type VaultClustersHandler struct {
+	clusters ClusterMonitor
+}
+
+// NewVaultClustersHandler crée un nouveau gestionnaire d'état des clusters Vault
+func NewVaultClustersHandler(clusters ClusterMonitor) *VaultClustersHandler {
+	return &VaultClustersHandler{
+		clusters: clusters,
+	}
+}
+
+// ListClusters renvoie l'état de chaque cluster lors de la dernière
+// vérification. refresh=true relance la vérification avant de répondre.
+func (h *VaultClustersHandler) ListClusters(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Query().Get("refresh") == "true" {
+		if err := h.clusters.Check(r.Context()); err != nil {
+			http.Error(w, "Impossible de vérifier les clusters Vault", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(h.clusters.Statuses()); err != nil {
+		http.Error(w, "Erreur lors de l'encodage de l'état des clusters", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,28 @@
+// filepath: internal/api/handlers/version.go
+
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"secrets-manager/internal/version"
+)
+
+// VersionHandler expose la version du serveur
+type VersionHandler struct {
+	backends []string
+}
+
+// NewVersionHandler crée un nouveau gestionnaire de version
+func NewVersionHandler(backends []string) *VersionHandler {
+	return &VersionHandler{
+		backends: backends,
+	}
+}
+
+// GetVersion renvoie la version, le commit, la date de build et les backends actifs
+func (h *VersionHandler) GetVersion(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(version.Get(h.backends...))
+}
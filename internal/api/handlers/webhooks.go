@@ -0,0 +1,237 @@
+// filepath: internal/api/handlers/webhooks.go
+
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"secrets-manager/internal/api/apierror"
+	"secrets-manager/internal/api/middleware"
+	"secrets-manager/internal/events"
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+	"secrets-manager/internal/webhooks"
+)
+
+// WebhooksHandler gère les webhooks d'une organisation et leurs outils de
+// diagnostic (événement de test, historique des livraisons, rejeu). Toutes
+// les routes sont réservées aux administrateurs de l'organisation.
+type WebhooksHandler struct {
+	webhooks storage.WebhooksRepository
+	users    storage.UsersRepository
+	sender   *webhooks.Sender
+	history  storage.SettingsHistoryRepository
+}
+
+// NewWebhooksHandler crée un nouveau gestionnaire des webhooks
+func NewWebhooksHandler(webhooksRepo storage.WebhooksRepository, users storage.UsersRepository,
+	sender *webhooks.Sender, history storage.SettingsHistoryRepository) *WebhooksHandler {
+	return &WebhooksHandler{
+		webhooks: webhooksRepo,
+		users:    users,
+		sender:   sender,
+		history:  history,
+	}
+}
+
+// CreateWebhook enregistre un webhook. Son secret de signature n'est
+// renvoyé que dans cette réponse.
+func (h *WebhooksHandler) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	orgID := mux.Vars(r)["orgID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if !requireOrgAdmin(w, r, h.users, orgID, userID) {
+		return
+	}
+
+	var request struct {
+		URL    string   `json:"url"`
+		Events []string `json:"events"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "Données invalides", http.StatusBadRequest)
+		return
+	}
+	if err := h.sender.CheckURL(r.Context(), request.URL); errors.Is(err, webhooks.ErrInvalidURL) {
+		apierror.Write(w, apierror.Validation("L'URL du webhook doit être une URL https"), "")
+		return
+	} else if err != nil {
+		apierror.Write(w, apierror.Validation("L'URL du webhook ne doit pas désigner une adresse interne"), "")
+		return
+	}
+	for _, eventType := range request.Events {
+		if _, ok := events.Lookup(eventType); !ok {
+			apierror.Write(w, apierror.Validation("Type d'événement inconnu : "+eventType), "")
+			return
+		}
+	}
+
+	secret, err := webhooks.NewSecret()
+	if err != nil {
+		http.Error(w, "Impossible de générer le secret du webhook", http.StatusInternalServerError)
+		return
+	}
+	webhook := &models.Webhook{
+		OrganizationID: orgID,
+		URL:            request.URL,
+		Secret:         secret,
+		Events:         request.Events,
+		CreatedBy:      userID,
+	}
+	if webhook.Events == nil {
+		webhook.Events = []string{}
+	}
+	if err := h.webhooks.CreateWebhook(r.Context(), webhook); err != nil {
+		apierror.Write(w, err, "Impossible d'enregistrer le webhook")
+		return
+	}
+	// Le secret de signature n'apparaît pas dans l'historique
+	recorded := *webhook
+	recorded.Secret = ""
+	recordSettingsChange(r.Context(), h.history, orgID, models.SettingsWebhook, webhook.ID,
+		models.SettingsCreated, nil, recorded)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(webhook); err != nil {
+		http.Error(w, "Erreur lors de l'encodage du webhook", http.StatusInternalServerError)
+	}
+}
+
+// ListWebhooks liste les webhooks de l'organisation, sans leur secret
+func (h *WebhooksHandler) ListWebhooks(w http.ResponseWriter, r *http.Request) {
+	orgID := mux.Vars(r)["orgID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if !requireOrgAdmin(w, r, h.users, orgID, userID) {
+		return
+	}
+
+	list, err := h.webhooks.ListWebhooks(r.Context(), orgID)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de lister les webhooks")
+		return
+	}
+	for _, webhook := range list {
+		webhook.Secret = ""
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(list); err != nil {
+		http.Error(w, "Erreur lors de l'encodage des webhooks", http.StatusInternalServerError)
+	}
+}
+
+// DeleteWebhook supprime un webhook et l'historique de ses livraisons
+func (h *WebhooksHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	orgID, webhookID := vars["orgID"], vars["webhookID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if !requireOrgAdmin(w, r, h.users, orgID, userID) {
+		return
+	}
+
+	webhook, err := h.webhooks.GetWebhook(r.Context(), orgID, webhookID)
+	if err != nil {
+		// storage.ErrWebhookNotFound donne 404
+		apierror.Write(w, err, "Impossible de récupérer le webhook")
+		return
+	}
+	if err := h.webhooks.DeleteWebhook(r.Context(), orgID, webhookID); err != nil {
+		apierror.Write(w, err, "Impossible de supprimer le webhook")
+		return
+	}
+	webhook.Secret = ""
+	recordSettingsChange(r.Context(), h.history, orgID, models.SettingsWebhook, webhookID,
+		models.SettingsDeleted, webhook, nil)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// TestWebhook envoie au webhook un événement webhook.ping.v1 signé et renvoie la
+// livraison (statut et réponse du consommateur)
+func (h *WebhooksHandler) TestWebhook(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	orgID, webhookID := vars["orgID"], vars["webhookID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if !requireOrgAdmin(w, r, h.users, orgID, userID) {
+		return
+	}
+
+	webhook, err := h.webhooks.GetWebhook(r.Context(), orgID, webhookID)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de récupérer le webhook")
+		return
+	}
+
+	delivery, err := h.sender.Test(r.Context(), webhook)
+	if err != nil {
+		apierror.Write(w, err, "Impossible d'enregistrer la livraison")
+		return
+	}
+	writeDelivery(w, delivery)
+}
+
+// ListDeliveries liste les livraisons d'un webhook, de la plus récente à la
+// plus ancienne. Paramètres optionnels : limit, offset.
+func (h *WebhooksHandler) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	orgID, webhookID := vars["orgID"], vars["webhookID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if !requireOrgAdmin(w, r, h.users, orgID, userID) {
+		return
+	}
+	limit, offset, ok := parsePagination(w, r)
+	if !ok {
+		return
+	}
+
+	if _, err := h.webhooks.GetWebhook(r.Context(), orgID, webhookID); err != nil {
+		apierror.Write(w, err, "Impossible de récupérer le webhook")
+		return
+	}
+	deliveries, err := h.webhooks.ListWebhookDeliveries(r.Context(), orgID, webhookID, limit, offset)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de lister les livraisons")
+		return
+	}
+
+	writeJSONList(w, r, deliveries)
+}
+
+// Redeliver renvoie le corps d'une livraison précédente, avec une nouvelle
+// signature, et renvoie la nouvelle livraison
+func (h *WebhooksHandler) Redeliver(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	orgID, deliveryID := vars["orgID"], vars["deliveryID"]
+	userID := middleware.UserIDFromContext(r.Context())
+
+	if !requireOrgAdmin(w, r, h.users, orgID, userID) {
+		return
+	}
+
+	previous, err := h.webhooks.GetWebhookDelivery(r.Context(), orgID, deliveryID)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de récupérer la livraison")
+		return
+	}
+	delivery, err := h.sender.Redeliver(r.Context(), previous)
+	if err != nil {
+		apierror.Write(w, err, "Impossible de rejouer la livraison")
+		return
+	}
+	writeDelivery(w, delivery)
+}
+
+func writeDelivery(w http.ResponseWriter, delivery *models.WebhookDelivery) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(delivery); err != nil {
+		http.Error(w, "Erreur lors de l'encodage de la livraison", http.StatusInternalServerError)
+	}
+}
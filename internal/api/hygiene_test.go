@@ -0,0 +1,124 @@
+// filepath: internal/api/hygiene_test.go
+
+package api_test
+
+import (
+	"net/http"
+	"slices"
+	"testing"
+
+	"secrets-manager/internal/api/apitest"
+	"secrets-manager/internal/models"
+)
+
+func TestSecretHygiene(t *testing.T) {
+	srv := apitest.NewServer(t)
+	ownerID := srv.Register("owner@example.com", "password123")
+	owner := srv.Login("owner@example.com", "password123")
+	org := srv.CreateOrganization("acme", ownerID)
+	project := srv.CreateProject(org.ID, "api", ownerID)
+	base := "/api/v1/organizations/" + org.ID + "/projects/" + project.ID
+
+	shared := "kX9#vQ2$mL7!pR4&wT8*zN3@"
+	for _, secret := range []struct{ env, name, value string }{
+		{"prod", "DB_PASSWORD", shared},
+		{"staging", "DB_PASSWORD", shared},
+		{"prod", "API_TOKEN", "changeme"},
+	} {
+		resp := srv.Do(http.MethodPost, base+"/environments/"+secret.env+"/secrets", owner,
+			models.Secret{Name: secret.name, Value: secret.value})
+		apitest.ExpectStatus(t, resp, http.StatusCreated)
+	}
+
+	// L'hygiène accompagne les métadonnées, sans la valeur ni son empreinte
+	resp := srv.Do(http.MethodGet, base+"/environments/prod/secrets/DB_PASSWORD/metadata", owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var metadata models.SecretMetadata
+	apitest.DecodeJSON(t, resp, &metadata)
+	if metadata.Hygiene == nil || !slices.Equal(metadata.Hygiene.ReusedIn, []string{"staging"}) ||
+		!slices.Equal(metadata.Hygiene.Issues, []string{models.HygieneReused}) || metadata.ValueHash != "" {
+		t.Errorf("Expected the prod password to be flagged as reused in staging, got %+v", metadata.Hygiene)
+	}
+
+	resp = srv.Do(http.MethodGet, base+"/environments/prod/secrets:metadata", owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var listed []models.SecretMetadata
+	apitest.DecodeJSON(t, resp, &listed)
+	for _, m := range listed {
+		if m.Hygiene == nil {
+			t.Errorf("Expected hygiene for %s", m.Name)
+		}
+	}
+
+	// Une nouvelle valeur en staging met fin à la réutilisation
+	resp = srv.Do(http.MethodPut, base+"/environments/staging/secrets/DB_PASSWORD", owner,
+		models.Secret{Value: "Zq4!rW8#nB2$kM6&yH1*pL5@"})
+	apitest.ExpectStatus(t, resp, http.StatusNoContent)
+
+	resp = srv.Do(http.MethodGet, base+"/hygiene", owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var report models.HygieneReport
+	apitest.DecodeJSON(t, resp, &report)
+	if report.Assessed != 3 || report.Issues[models.HygieneReused] != 0 || report.Issues[models.HygieneShort] != 1 {
+		t.Fatalf("Expected three assessed secrets with a single short one, got %+v", report)
+	}
+	if worst := report.Secrets[0]; worst.Name != "API_TOKEN" || worst.Hygiene.Score != 45 {
+		t.Errorf("Expected API_TOKEN to be listed first, got %+v", worst)
+	}
+}
+
+func TestDuplicateSecretValues(t *testing.T) {
+	srv := apitest.NewServer(t)
+	ownerID := srv.Register("owner@example.com", "password123")
+	owner := srv.Login("owner@example.com", "password123")
+	memberID := srv.Register("member@example.com", "password123")
+	member := srv.Login("member@example.com", "password123")
+	org := srv.CreateOrganization("acme", ownerID)
+	srv.AddMember(org.ID, memberID, "member")
+	api := srv.CreateProject(org.ID, "api", ownerID)
+	worker := srv.CreateProject(org.ID, "worker", ownerID)
+	path := func(projectID, env string) string {
+		return "/api/v1/organizations/" + org.ID + "/projects/" + projectID + "/environments/" + env + "/secrets"
+	}
+
+	shared := "kX9#vQ2$mL7!pR4&wT8*zN3@"
+	for _, secret := range []struct{ projectID, env, name, value string }{
+		{api.ID, "prod", "DB_PASSWORD", shared},
+		{worker.ID, "prod", "DB_PASSWORD", shared},
+		{worker.ID, "dev", "DB_PASSWORD", shared},
+		{api.ID, "prod", "API_TOKEN", "Zq4!rW8#nB2$kM6&yH1*pL5@"},
+	} {
+		resp := srv.Do(http.MethodPost, path(secret.projectID, secret.env), owner,
+			models.Secret{Name: secret.name, Value: secret.value})
+		apitest.ExpectStatus(t, resp, http.StatusCreated)
+	}
+
+	resp := srv.Do(http.MethodGet, path(api.ID, "prod")+"/DB_PASSWORD/metadata", owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var metadata models.SecretMetadata
+	apitest.DecodeJSON(t, resp, &metadata)
+	if metadata.Hygiene == nil || !slices.Equal(metadata.Hygiene.SharedWith, []string{worker.ID}) ||
+		!slices.Equal(metadata.Hygiene.Issues, []string{models.HygieneShared}) {
+		t.Errorf("Expected the api password to be shared with the worker project, got %+v", metadata.Hygiene)
+	}
+
+	resp = srv.Do(http.MethodGet, "/api/v1/organizations/"+org.ID+"/projects/"+worker.ID+"/hygiene", owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var report models.HygieneReport
+	apitest.DecodeJSON(t, resp, &report)
+	if report.Issues[models.HygieneShared] != 2 || report.Issues[models.HygieneReused] != 2 {
+		t.Errorf("Expected both worker secrets to be reused and shared, got %+v", report.Issues)
+	}
+
+	// Le rapport de l'organisation couvre tous les projets : réservé aux administrateurs
+	duplicates := "/api/v1/organizations/" + org.ID + "/hygiene/duplicates"
+	resp = srv.Do(http.MethodGet, duplicates, member, nil)
+	apitest.ExpectStatus(t, resp, http.StatusForbidden)
+	resp = srv.Do(http.MethodGet, duplicates, owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var duplicated models.DuplicatesReport
+	apitest.DecodeJSON(t, resp, &duplicated)
+	if duplicated.Secrets != 3 || len(duplicated.Duplicates) != 1 || duplicated.Duplicates[0].Projects != 2 {
+		t.Errorf("Expected the password to be listed once across both projects, got %+v", duplicated)
+	}
+}
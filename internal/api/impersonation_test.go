@@ -0,0 +1,171 @@
+// filepath: internal/api/impersonation_test.go
+
+package api_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"secrets-manager/internal/api/apitest"
+	"secrets-manager/internal/api/handlers"
+	"secrets-manager/internal/auth"
+	"secrets-manager/internal/models"
+)
+
+func TestImpersonation(t *testing.T) {
+	srv := apitest.NewServer(t)
+	userID := srv.Register("alice@example.com", "password123")
+	session := srv.Login("alice@example.com", "password123")
+	org := srv.CreateOrganization("acme", userID)
+	project := srv.CreateProject(org.ID, "payments", userID)
+	secrets := "/api/v1/organizations/" + org.ID + "/projects/" + project.ID + "/environments/prod/secrets"
+
+	// L'opérateur s'authentifie et doit être administrateur de la plateforme
+	supportID := srv.Register("support@example.com", "password123")
+	support, err := srv.Users.GetUserByID(context.Background(), supportID)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	support.Role = auth.PlatformAdminRole
+	if err := srv.Users.UpdateUser(context.Background(), support); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	operator := http.Header{handlers.OperatorTokenHeader: {srv.Login("support@example.com", "password123")}}
+	impersonate := func(header http.Header, request map[string]any) *http.Response {
+		t.Helper()
+		return srv.DoAdminWithHeaders(http.MethodPost, "/admin/impersonations", header, request)
+	}
+	request := map[string]any{"user_id": userID, "reason": "ticket #4521"}
+
+	// Sans le token du listener d'administration
+	req, err := http.NewRequest(http.MethodPost, srv.Admin.URL+"/admin/impersonations", nil)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	req.Header.Set(handlers.OperatorTokenHeader, operator.Get(handlers.OperatorTokenHeader))
+	resp, err := srv.Admin.Client().Do(req)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	resp.Body.Close()
+	apitest.ExpectStatus(t, resp, http.StatusUnauthorized)
+
+	// Sans opérateur authentifié, ou avec un opérateur qui n'est pas administrateur
+	apitest.ExpectStatus(t, impersonate(nil, request), http.StatusUnauthorized)
+	apitest.ExpectStatus(t, impersonate(http.Header{handlers.OperatorTokenHeader: {"invalid"}}, request),
+		http.StatusUnauthorized)
+	apitest.ExpectStatus(t, impersonate(http.Header{handlers.OperatorTokenHeader: {session}}, request),
+		http.StatusForbidden)
+
+	// Motif obligatoire, durée bornée, pas d'usurpation de soi-même
+	for _, request := range []map[string]any{
+		{"user_id": userID},
+		{"user_id": userID, "reason": "ticket #4521", "expires_in_minutes": 120},
+		{"user_id": supportID, "reason": "ticket #4521"},
+	} {
+		apitest.ExpectStatus(t, impersonate(operator, request), http.StatusBadRequest)
+	}
+	resp = impersonate(operator, map[string]any{"user_id": "unknown", "reason": "ticket #4521"})
+	apitest.ExpectStatus(t, resp, http.StatusNotFound)
+
+	// L'opérateur vient de son identité, pas du corps de la requête
+	resp = impersonate(operator, map[string]any{
+		"user_id": userID, "operator": "someone-else", "reason": "ticket #4521", "expires_in_minutes": 10,
+	})
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+	var started auth.ImpersonationToken
+	apitest.DecodeJSON(t, resp, &started)
+	if started.Token == "" || started.Impersonation == nil || started.Impersonation.UserID != userID {
+		t.Fatalf("Expected an impersonation token, got %+v", started)
+	}
+	if lifetime := time.Until(started.ExpiresAt); lifetime > 10*time.Minute || lifetime < 9*time.Minute {
+		t.Errorf("Expected a 10 minutes token, got %v", lifetime)
+	}
+	claims, err := srv.AuthService.VerifyAccessToken(started.Token)
+	if err != nil || claims.UserID != userID || claims.ImpersonationID != started.Impersonation.ID {
+		t.Errorf("Expected an act_as claim, got %+v (%v)", claims, err)
+	}
+
+	// Le support agit au nom de l'utilisateur, sauf sur ses tokens et ses
+	// usurpations
+	resp = srv.Do(http.MethodPost, secrets, started.Token, models.Secret{Name: "API_KEY", Value: "kX9#vQ2$mL7!pR4&wT8*"})
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+	resp = srv.Do(http.MethodGet, "/api/v1/organizations/"+org.ID+"/projects", started.Token, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	resp = srv.Do(http.MethodPost, "/api/v1/me/tokens", started.Token, map[string]any{
+		"name": "persist", "scopes": []string{models.ScopeSecretsRead},
+	})
+	apitest.ExpectStatus(t, resp, http.StatusForbidden)
+	resp = srv.Do(http.MethodGet, "/api/v1/me/impersonations", started.Token, nil)
+	apitest.ExpectStatus(t, resp, http.StatusForbidden)
+
+	// L'utilisateur voit l'usurpation et chaque requête faite en son nom
+	resp = srv.Do(http.MethodGet, "/api/v1/me/impersonations", session, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var impersonations []models.Impersonation
+	apitest.DecodeJSON(t, resp, &impersonations)
+	if len(impersonations) != 1 || impersonations[0].Operator != "support@example.com" ||
+		impersonations[0].Reason != "ticket #4521" || impersonations[0].Principal != "admin:token" {
+		t.Fatalf("Expected the impersonation, got %+v", impersonations)
+	}
+	requestsPath := "/api/v1/me/impersonations/" + started.Impersonation.ID + "/requests"
+	resp = srv.Do(http.MethodGet, requestsPath, session, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var requests []models.AdminAuditLog
+	apitest.DecodeJSON(t, resp, &requests)
+	if len(requests) != 4 {
+		t.Fatalf("Expected 4 audited requests, got %+v", requests)
+	}
+	for _, entry := range requests {
+		if entry.ImpersonationID != started.Impersonation.ID || entry.Principal != "user:"+userID {
+			t.Errorf("Expected a flagged entry, got %+v", entry)
+		}
+	}
+	if requests[3].Method != http.MethodPost || requests[3].StatusCode != http.StatusCreated ||
+		requests[3].RequestBody == "" {
+		t.Errorf("Expected the secret creation first, got %+v", requests[3])
+	}
+
+	// Le journal d'administration signale les mêmes requêtes
+	resp = srv.DoAdmin(http.MethodGet, "/admin/audit")
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var audit []models.AdminAuditLog
+	apitest.DecodeJSON(t, resp, &audit)
+	flagged := 0
+	for _, entry := range audit {
+		if entry.ImpersonationID == started.Impersonation.ID {
+			flagged++
+		}
+	}
+	if flagged != 4 {
+		t.Errorf("Expected 4 flagged entries in the admin audit log, got %d", flagged)
+	}
+
+	// Les requêtes d'une autre usurpation ne sont pas visibles
+	srv.Register("bob@example.com", "password123")
+	bob := srv.Login("bob@example.com", "password123")
+	resp = srv.Do(http.MethodGet, requestsPath, bob, nil)
+	apitest.ExpectStatus(t, resp, http.StatusNotFound)
+	resp = srv.Do(http.MethodDelete, "/api/v1/me/impersonations/"+started.Impersonation.ID, bob, nil)
+	apitest.ExpectStatus(t, resp, http.StatusNotFound)
+
+	// L'utilisateur termine l'usurpation : le token est refusé
+	resp = srv.Do(http.MethodDelete, "/api/v1/me/impersonations/"+started.Impersonation.ID, session, nil)
+	apitest.ExpectStatus(t, resp, http.StatusNoContent)
+	resp = srv.Do(http.MethodGet, "/api/v1/organizations/"+org.ID+"/projects", started.Token, nil)
+	apitest.ExpectStatus(t, resp, http.StatusUnauthorized)
+	resp = srv.DoAdmin(http.MethodDelete, "/admin/impersonations/"+started.Impersonation.ID)
+	apitest.ExpectStatus(t, resp, http.StatusNotFound)
+
+	// L'administrateur peut aussi y mettre fin
+	resp = impersonate(operator, map[string]any{"user_id": userID, "reason": "ticket #4522"})
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+	var second auth.ImpersonationToken
+	apitest.DecodeJSON(t, resp, &second)
+	resp = srv.DoAdmin(http.MethodDelete, "/admin/impersonations/"+second.Impersonation.ID)
+	apitest.ExpectStatus(t, resp, http.StatusNoContent)
+	resp = srv.Do(http.MethodGet, "/api/v1/organizations/"+org.ID+"/projects", second.Token, nil)
+	apitest.ExpectStatus(t, resp, http.StatusUnauthorized)
+}
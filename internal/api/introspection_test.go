@@ -0,0 +1,67 @@
+// filepath: internal/api/introspection_test.go
+
+package api_test
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"secrets-manager/internal/api/apitest"
+	"secrets-manager/internal/api/handlers"
+)
+
+func TestTokenIntrospection(t *testing.T) {
+	srv := apitest.NewServer(t)
+	srv.Register("sidecar@example.com", "password123")
+	session := srv.Login("sidecar@example.com", "password123")
+
+	introspect := func(secret, token string) *http.Response {
+		form := url.Values{"token": {token}}
+		req, err := http.NewRequest(http.MethodPost, srv.URL+"/api/v1/auth/introspect", strings.NewReader(form.Encode()))
+		if err != nil {
+			t.Fatalf("Expected a valid request, got %v", err)
+		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.SetBasicAuth(apitest.IntrospectionClientID, secret)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("Expected a response, got %v", err)
+		}
+		return resp
+	}
+	describe := func(token string) handlers.IntrospectionResponse {
+		resp := introspect(apitest.IntrospectionClientSecret, token)
+		apitest.ExpectStatus(t, resp, http.StatusOK)
+		var body handlers.IntrospectionResponse
+		apitest.DecodeJSON(t, resp, &body)
+		return body
+	}
+
+	resp := introspect("wrong-secret", session)
+	apitest.ExpectStatus(t, resp, http.StatusUnauthorized)
+
+	body := describe(session)
+	if !body.Active || body.Sub == "" || body.Exp == 0 || body.Iss != apitest.Issuer.Name ||
+		!strings.Contains(body.Scope, "secrets:write") {
+		t.Errorf("Expected an active session token, got %+v", body)
+	}
+
+	resp = srv.Do(http.MethodPost, "/api/v1/me/tokens", session, map[string]any{
+		"name":   "sidecar",
+		"scopes": []string{"secrets:read"},
+	})
+	var created handlers.CreatedToken
+	apitest.DecodeJSON(t, resp, &created)
+	if body := describe(created.Token); !body.Active || body.Scope != "secrets:read" || body.Sub != created.UserID {
+		t.Errorf("Expected an active personal access token, got %+v", body)
+	}
+
+	srv.Do(http.MethodDelete, "/api/v1/me/tokens/"+created.ID, session, nil)
+	for _, token := range []string{created.Token, "not-a-token", session + "x"} {
+		if body := describe(token); body.Active || body.Sub != "" {
+			t.Errorf("Expected an inactive token, got %+v", body)
+		}
+	}
+}
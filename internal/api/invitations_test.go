@@ -0,0 +1,104 @@
+// filepath: internal/api/invitations_test.go
+
+package api_test
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"secrets-manager/internal/api/apitest"
+	"secrets-manager/internal/api/handlers"
+	"secrets-manager/internal/models"
+)
+
+var invitationToken = regexp.MustCompile(`sminv_[A-Za-z0-9_-]+`)
+
+func TestInvitations(t *testing.T) {
+	srv := apitest.NewServer(t)
+	ownerID := srv.Register("owner@example.com", "password123")
+	owner := srv.Login("owner@example.com", "password123")
+	memberID := srv.Register("member@example.com", "password123")
+	member := srv.Login("member@example.com", "password123")
+	existingID := srv.Register("existing@example.com", "password123")
+	org := srv.CreateOrganization("acme", ownerID)
+	srv.AddMember(org.ID, memberID, "member")
+	invitations := "/api/v1/organizations/" + org.ID + "/invitations"
+
+	// Le token n'est remis que par email
+	invite := func(email, role string) (*models.Invitation, string) {
+		t.Helper()
+		resp := srv.Do(http.MethodPost, invitations, owner, map[string]string{"email": email, "role": role})
+		apitest.ExpectStatus(t, resp, http.StatusCreated)
+		var invitation models.Invitation
+		apitest.DecodeJSON(t, resp, &invitation)
+		mails := srv.Outbox.Mails()
+		mail := mails[len(mails)-1]
+		token := invitationToken.FindString(mail.Body)
+		if mail.To != email || token == "" {
+			t.Fatalf("Expected an invitation email with a token to %s, got %+v", email, mail)
+		}
+		return &invitation, token
+	}
+
+	resp := srv.Do(http.MethodPost, invitations, member, map[string]string{"email": "new@example.com"})
+	apitest.ExpectStatus(t, resp, http.StatusForbidden)
+	resp = srv.Do(http.MethodPost, invitations, owner, map[string]string{"email": "new@example.com", "role": "owner"})
+	apitest.ExpectStatus(t, resp, http.StatusBadRequest)
+	resp = srv.Do(http.MethodPost, invitations, owner, map[string]string{"email": "member@example.com"})
+	apitest.ExpectStatus(t, resp, http.StatusConflict)
+
+	invitation, token := invite("new@example.com", "viewer")
+	if invitation.Role != "viewer" || invitation.InvitedBy != ownerID {
+		t.Errorf("Expected a viewer invitation from the owner, got %+v", invitation)
+	}
+	resp = srv.Do(http.MethodPost, invitations, owner, map[string]string{"email": "new@example.com"})
+	apitest.ExpectStatus(t, resp, http.StatusConflict)
+
+	// Nouveau compte : le mot de passe est exigé, le token ne sert qu'une fois
+	accept := "/api/v1/invitations/accept"
+	resp = srv.Do(http.MethodPost, accept, "", map[string]string{"token": token})
+	apitest.ExpectStatus(t, resp, http.StatusBadRequest)
+	resp = srv.Do(http.MethodPost, accept, "", map[string]string{"token": token, "password": "password123"})
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+	var accepted handlers.AcceptedInvitation
+	apitest.DecodeJSON(t, resp, &accepted)
+	if !accepted.AccountCreated || accepted.OrganizationID != org.ID || accepted.Role != "viewer" {
+		t.Errorf("Expected a new viewer account, got %+v", accepted)
+	}
+	if role, _ := srv.Users.GetUserRole(context.Background(), accepted.UserID, org.ID); role != "viewer" {
+		t.Errorf("Expected the new account to be a viewer, got %q", role)
+	}
+	srv.Login("new@example.com", "password123")
+	resp = srv.Do(http.MethodPost, accept, "", map[string]string{"token": token, "password": "password123"})
+	apitest.ExpectStatus(t, resp, http.StatusNotFound)
+
+	// Compte existant : rattaché sans mot de passe ; une invitation révoquée est refusée
+	revoked, token := invite("existing@example.com", "member")
+	resp = srv.Do(http.MethodGet, invitations, owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var pending []models.Invitation
+	apitest.DecodeJSON(t, resp, &pending)
+	if len(pending) != 1 || pending[0].ID != revoked.ID {
+		t.Fatalf("Expected only the existing account's invitation to be pending, got %+v", pending)
+	}
+	resp = srv.Do(http.MethodDelete, invitations+"/"+revoked.ID, owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusNoContent)
+	resp = srv.Do(http.MethodPost, accept, "", map[string]string{"token": token})
+	apitest.ExpectStatus(t, resp, http.StatusNotFound)
+
+	_, token = invite("existing@example.com", "member")
+	resp = srv.Do(http.MethodPost, accept, "", map[string]string{"token": token})
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	apitest.DecodeJSON(t, resp, &accepted)
+	if accepted.AccountCreated || accepted.UserID != existingID || accepted.Role != "member" {
+		t.Errorf("Expected the existing account to join as member, got %+v", accepted)
+	}
+	resp = srv.Do(http.MethodGet, invitations, owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	apitest.DecodeJSON(t, resp, &pending)
+	if len(pending) != 0 {
+		t.Errorf("Expected no pending invitation, got %+v", pending)
+	}
+}
@@ -0,0 +1,208 @@
+// filepath: internal/api/jwks_test.go
+
+package api_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+
+	"secrets-manager/internal/api/apitest"
+	"secrets-manager/internal/auth"
+	"secrets-manager/internal/vault"
+)
+
+func TestJWKSAndAsymmetricSigning(t *testing.T) {
+	srv := apitest.NewServer(t)
+	srv.Register("sidecar@example.com", "password123")
+	legacy := srv.Login("sidecar@example.com", "password123")
+
+	signingKey := func(id string, private interface{}) *auth.SigningKey {
+		der, err := x509.MarshalPKCS8PrivateKey(private)
+		if err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+		key, err := auth.ParseSigningKey(id, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+		if err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+		return key
+	}
+	jwks := func() auth.JWKS {
+		resp, err := http.Get(srv.URL + "/.well-known/jwks.json")
+		if err != nil {
+			t.Fatalf("Expected a response, got %v", err)
+		}
+		defer resp.Body.Close()
+		apitest.ExpectStatus(t, resp, http.StatusOK)
+		var body auth.JWKS
+		apitest.DecodeJSON(t, resp, &body)
+		return body
+	}
+	profile := func(token string) int {
+		resp := srv.Do(http.MethodGet, "/api/v1/me/tokens", token, nil)
+		return resp.StatusCode
+	}
+
+	if keys := jwks().Keys; len(keys) != 0 {
+		t.Errorf("Expected no public key with HS256, got %+v", keys)
+	}
+
+	// EdDSA : le kid désigne la clé publiée qui vérifie le token
+	_, edPrivate, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if err := srv.AuthService.EnableSigningKeys([]*auth.SigningKey{signingKey("2026-07", edPrivate)}, true); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	signed := srv.Login("sidecar@example.com", "password123")
+	published := jwks().Keys
+	if len(published) != 1 || published[0].KeyType != "OKP" || published[0].Algorithm != "EdDSA" {
+		t.Fatalf("Expected the Ed25519 key, got %+v", published)
+	}
+	token, err := jwt.Parse(signed, func(token *jwt.Token) (interface{}, error) {
+		x, err := base64.RawURLEncoding.DecodeString(published[0].X)
+		return ed25519.PublicKey(x), err
+	})
+	if err != nil || !token.Valid || token.Header["kid"] != "2026-07" || token.Method.Alg() != "EdDSA" {
+		t.Errorf("Expected a token verified with the JWKS, got %v (%v)", token, err)
+	}
+	if profile(signed) != http.StatusOK || profile(legacy) != http.StatusOK {
+		t.Error("Expected both EdDSA and legacy HS256 tokens to be accepted")
+	}
+
+	// Rotation vers RS256 : les tokens de l'ancienne clé restent valides,
+	// ceux en HS256 sont refusés sans repli
+	rsaPrivate, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	keys := []*auth.SigningKey{signingKey("2026-10", rsaPrivate), signingKey("2026-07", edPrivate)}
+	if err := srv.AuthService.EnableSigningKeys(keys, false); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	rotated := srv.Login("sidecar@example.com", "password123")
+	if published := jwks().Keys; len(published) != 2 || published[0].KeyID != "2026-10" || published[0].KeyType != "RSA" {
+		t.Errorf("Expected both keys, the RSA one first, got %+v", published)
+	}
+	if profile(rotated) != http.StatusOK || profile(signed) != http.StatusOK {
+		t.Error("Expected tokens of both keys to be accepted")
+	}
+	if status := profile(legacy); status != http.StatusUnauthorized {
+		t.Errorf("Expected HS256 tokens to be rejected, got %d", status)
+	}
+
+	if _, err := auth.ParseSigningKey("weak", []byte("not a key")); err == nil {
+		t.Error("Expected an invalid PEM key to be rejected")
+	}
+}
+
+func TestSigningKeyRotation(t *testing.T) {
+	srv := apitest.NewServer(t)
+	srv.Register("sidecar@example.com", "password123")
+	legacy := srv.Login("sidecar@example.com", "password123")
+	profile := func(token string) int {
+		resp := srv.Do(http.MethodGet, "/api/v1/me/tokens", token, nil)
+		return resp.StatusCode
+	}
+	kid := func(signed string) interface{} {
+		token, _, err := jwt.NewParser().ParseUnverified(signed, jwt.MapClaims{})
+		if err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+		return token.Header["kid"]
+	}
+
+	// Secrets HMAC identifiés par un kid, lus dans un fichier ou dans Vault
+	path := filepath.Join(t.TempDir(), "jwt-2026-07")
+	if err := os.WriteFile(path, []byte(strings.Repeat("a", 32)+"\n"), 0o600); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	old, err := auth.LoadSigningKey(t.Context(), "2026-07", path, nil)
+	if err != nil || old.Algorithm() != "HS256" {
+		t.Fatalf("Expected an HS256 key, got %v (%v)", old, err)
+	}
+	store := vault.NewMemoryStore()
+	store.WriteSecret(t.Context(), "jwt/signing", map[string]interface{}{"2026-10": strings.Repeat("b", 32)})
+	current, err := auth.LoadSigningKey(t.Context(), "2026-10", "vault:jwt/signing#2026-10", store)
+	if err != nil || current.Algorithm() != "HS256" {
+		t.Fatalf("Expected an HS256 key from Vault, got %v (%v)", current, err)
+	}
+	for source, reader := range map[string]auth.SecretReader{
+		"vault:jwt/signing#missing": store,
+		"vault:jwt/signing#2026-10": nil,
+		path + ".missing":           nil,
+	} {
+		if _, err := auth.LoadSigningKey(t.Context(), "bad", source, reader); err == nil {
+			t.Errorf("Expected %s to be rejected", source)
+		}
+	}
+	if _, err := auth.NewSecretKey("short", []byte("too short")); err == nil {
+		t.Error("Expected a short secret to be rejected")
+	}
+
+	if err := srv.AuthService.EnableSigningKeys([]*auth.SigningKey{old}, true); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	signedByOld := srv.Login("sidecar@example.com", "password123")
+	if kid(signedByOld) != "2026-07" {
+		t.Errorf("Expected the 2026-07 kid, got %v", kid(signedByOld))
+	}
+
+	// Rotation : la nouvelle clé signe, les tokens de l'ancienne restent
+	// valides jusqu'à son retrait, et les secrets ne sont jamais publiés
+	old.RetiresAt = time.Now().Add(time.Hour)
+	if err := srv.AuthService.EnableSigningKeys([]*auth.SigningKey{current, old}, false); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	signedByCurrent := srv.Login("sidecar@example.com", "password123")
+	if kid(signedByCurrent) != "2026-10" {
+		t.Errorf("Expected the 2026-10 kid, got %v", kid(signedByCurrent))
+	}
+	if profile(signedByCurrent) != http.StatusOK || profile(signedByOld) != http.StatusOK {
+		t.Error("Expected tokens of both keys to be accepted")
+	}
+	if status := profile(legacy); status != http.StatusUnauthorized {
+		t.Errorf("Expected tokens without kid to be rejected, got %d", status)
+	}
+	if keys := srv.AuthService.JWKS().Keys; len(keys) != 0 {
+		t.Errorf("Expected HMAC secrets not to be published, got %+v", keys)
+	}
+
+	// Un token dont le kid désigne une clé d'un autre algorithme est refusé
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS512, jwt.MapClaims{"sub": "x"})
+	forged.Header["kid"] = "2026-10"
+	signed, _ := forged.SignedString([]byte(strings.Repeat("b", 32)))
+	if _, err := srv.AuthService.VerifyAccessToken(signed); err == nil {
+		t.Error("Expected a token with a mismatched algorithm to be rejected")
+	}
+
+	// Clé retirée : ses tokens sont refusés ; elle ne signe plus
+	old.RetiresAt = time.Now().Add(-time.Second)
+	if status := profile(signedByOld); status != http.StatusUnauthorized {
+		t.Errorf("Expected tokens of a retired key to be rejected, got %d", status)
+	}
+	if profile(signedByCurrent) != http.StatusOK {
+		t.Error("Expected tokens of the current key to be accepted")
+	}
+	if err := srv.AuthService.EnableSigningKeys([]*auth.SigningKey{old}, false); err == nil {
+		t.Error("Expected retired keys only to be rejected")
+	}
+	current.RetiresAt = time.Now().Add(-time.Second)
+	resp := srv.Do(http.MethodPost, "/api/v1/auth/login", "", map[string]string{
+		"email": "sidecar@example.com", "password": "password123",
+	})
+	apitest.ExpectStatus(t, resp, http.StatusInternalServerError)
+}
@@ -0,0 +1,156 @@
+// filepath: internal/api/lockdown_test.go
+
+package api_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"secrets-manager/internal/api/apitest"
+	"secrets-manager/internal/api/handlers"
+	"secrets-manager/internal/auth"
+	"secrets-manager/internal/models"
+)
+
+func TestLockdown(t *testing.T) {
+	srv := apitest.NewServer(t)
+	ownerID := srv.Register("owner@example.com", "password123")
+	owner := srv.Login("owner@example.com", "password123")
+	memberID := srv.Register("member@example.com", "password123")
+	member := srv.Login("member@example.com", "password123")
+	org := srv.CreateOrganization("acme", ownerID)
+	srv.AddMember(org.ID, memberID, "member")
+	project := srv.CreateProject(org.ID, "api", ownerID)
+	secrets := "/api/v1/organizations/" + org.ID + "/projects/" + project.ID + "/environments/prod/secrets"
+	lockdown := "/api/v1/organizations/" + org.ID + "/lockdown"
+
+	resp := srv.Do(http.MethodPost, "/api/v1/me/tokens", member, map[string]any{
+		"name": "ci", "scopes": []string{"secrets:read"}})
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+	var pat handlers.CreatedToken
+	apitest.DecodeJSON(t, resp, &pat)
+	resp = srv.Do(http.MethodPut, "/api/v1/organizations/"+org.ID+"/service-account-trust", owner,
+		handlers.ServiceAccountTrustUpdate{TrustDomain: "acme.internal", CABundle: srv.ClientCAPEM()})
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	resp = srv.Do(http.MethodPost, "/api/v1/organizations/"+org.ID+"/service-accounts", owner,
+		handlers.ServiceAccountCreation{Name: "deploy", Permission: models.APIKeyRead,
+			Resources: []models.ResourceScope{{ProjectID: project.ID}}, SPIFFEID: "spiffe://acme.internal/deploy"})
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+	var account handlers.CreatedServiceAccount
+	apitest.DecodeJSON(t, resp, &account)
+	accountToken, err := srv.AuthService.IssueServiceAccountToken(context.Background(), account.ID, account.Secret)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	workload := srv.MTLSClient("spiffe://acme.internal/deploy")
+	resp = srv.DoMTLS(workload, http.MethodGet, secrets, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	err = srv.SecretReads.RecordSecretReads(context.Background(), []*models.SecretRead{{
+		OrganizationID: org.ID, ProjectID: project.ID, Environment: "prod", SecretName: "DB_PASSWORD",
+		PrincipalType: "user", PrincipalID: memberID, Timestamp: time.Now().AddDate(0, 0, -2),
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Le déclenchement exige un administrateur ayant activé l'authentification multifacteur
+	resp = srv.Do(http.MethodPost, lockdown, owner, map[string]any{"code": "123456"})
+	apitest.ExpectStatus(t, resp, http.StatusForbidden)
+	resp = srv.Do(http.MethodPost, "/api/v1/auth/mfa/enroll", owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+	var enrollment auth.MFAEnrollment
+	apitest.DecodeJSON(t, resp, &enrollment)
+	// Chaque code est frais : le dernier pas accepté est oublié
+	code := func() string {
+		mfa, err := srv.UserMFA.GetUserMFA(context.Background(), ownerID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		mfa.LastStep = 0
+		srv.UserMFA.SaveUserMFA(context.Background(), mfa)
+		code, _ := auth.TOTPCode(enrollment.Secret, time.Now())
+		return code
+	}
+	resp = srv.Do(http.MethodPost, "/api/v1/auth/mfa/activate", owner, map[string]string{"code": code()})
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var tokens map[string]string
+	apitest.DecodeJSON(t, resp, &tokens)
+	owner = tokens["token"]
+
+	resp = srv.Do(http.MethodPost, lockdown, member, map[string]any{"code": "123456"})
+	apitest.ExpectStatus(t, resp, http.StatusForbidden)
+	resp = srv.Do(http.MethodPost, lockdown, owner, map[string]any{"code": "abcdef"})
+	apitest.ExpectStatus(t, resp, http.StatusUnauthorized)
+	resp = srv.Do(http.MethodPost, lockdown, owner, map[string]any{"code": code(), "reason": "fuite", "report_days": 7})
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+	var report models.LockdownReport
+	apitest.DecodeJSON(t, resp, &report)
+	if report.Lockdown.AffectedUsers != 2 || report.Lockdown.RevokedTokens != 2 || len(report.Members) != 2 {
+		t.Errorf("Expected both members, the token and the service account to be revoked, got %+v", report.Lockdown)
+	}
+	if len(report.Accesses) != 1 || report.Accesses[0].PrincipalID != memberID || report.Accesses[0].Reads != 1 {
+		t.Errorf("Expected the member's read in the access report, got %+v", report.Accesses)
+	}
+
+	// Sessions et tokens révoqués, nouveau mot de passe exigé
+	for _, token := range []string{owner, member, pat.Token, accountToken.AccessToken} {
+		resp = srv.Do(http.MethodGet, secrets, token, nil)
+		apitest.ExpectStatus(t, resp, http.StatusUnauthorized)
+	}
+	// Le compte de service est supprimé avec son identifiant SPIFFE
+	resp = srv.DoMTLS(workload, http.MethodGet, secrets, nil)
+	apitest.ExpectStatus(t, resp, http.StatusUnauthorized)
+	// Un compte de service qui s'authentifie encore est refusé, même en lecture
+	late := &models.ServiceAccount{OrganizationID: org.ID, Name: "late", Permission: models.APIKeyRead,
+		Resources: []models.ResourceScope{{ProjectID: project.ID}}, SPIFFEID: "spiffe://acme.internal/late"}
+	if err := srv.ServiceAccounts.CreateServiceAccount(context.Background(), late); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	resp = srv.DoMTLS(srv.MTLSClient("spiffe://acme.internal/late"), http.MethodGet, secrets, nil)
+	apitest.ExpectStatus(t, resp, http.StatusLocked)
+	resp = srv.Do(http.MethodPost, "/api/v1/auth/login", "", map[string]string{
+		"email": "member@example.com", "password": "password123"})
+	apitest.ExpectStatus(t, resp, http.StatusForbidden)
+
+	change := map[string]string{"email": "member@example.com", "password": "password123", "new_password": "password123"}
+	resp = srv.Do(http.MethodPost, "/api/v1/auth/password:change", "", change)
+	apitest.ExpectStatus(t, resp, http.StatusBadRequest)
+	change["new_password"] = "rotated456"
+	resp = srv.Do(http.MethodPost, "/api/v1/auth/password:change", "", change)
+	apitest.ExpectStatus(t, resp, http.StatusNoContent)
+	// Les tokens émis pendant la seconde de la révocation sont refusés
+	time.Sleep(time.Until(report.Lockdown.StartedAt.Truncate(time.Second).Add(time.Second)))
+	member = srv.Login("member@example.com", "rotated456")
+
+	// Les lectures restent possibles, les écritures sont gelées
+	resp = srv.Do(http.MethodGet, secrets, member, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	resp = srv.Do(http.MethodPost, secrets, member, models.Secret{Name: "DB_PASSWORD", Value: "s3cret"})
+	apitest.ExpectStatus(t, resp, http.StatusLocked)
+
+	// L'administrateur se reconnecte avec un code pour lever le confinement
+	resp = srv.Do(http.MethodPost, "/api/v1/auth/password:change", "", map[string]string{
+		"email": "owner@example.com", "password": "password123", "new_password": "rotated456", "code": code()})
+	apitest.ExpectStatus(t, resp, http.StatusNoContent)
+	resp = srv.Do(http.MethodPost, "/api/v1/auth/login", "", map[string]string{
+		"email": "owner@example.com", "password": "rotated456"})
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var login map[string]any
+	apitest.DecodeJSON(t, resp, &login)
+	resp = srv.Do(http.MethodPost, "/api/v1/auth/mfa/verify", "", map[string]any{
+		"mfa_token": login["mfa_token"], "code": code()})
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	apitest.DecodeJSON(t, resp, &tokens)
+	owner = tokens["token"]
+
+	resp = srv.Do(http.MethodGet, lockdown, owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	resp = srv.Do(http.MethodPost, lockdown+":lift", owner, map[string]string{"code": code()})
+	apitest.ExpectStatus(t, resp, http.StatusNoContent)
+	resp = srv.Do(http.MethodGet, lockdown, owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusNotFound)
+	resp = srv.Do(http.MethodPost, secrets, member, models.Secret{Name: "DB_PASSWORD", Value: "s3cret"})
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+}
@@ -0,0 +1,171 @@
+// filepath: internal/api/log_forwarders_test.go
+
+package api_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"secrets-manager/internal/api/apitest"
+	"secrets-manager/internal/api/handlers"
+	"secrets-manager/internal/models"
+)
+
+func TestLogForwarders(t *testing.T) {
+	srv := apitest.NewServer(t)
+	ownerID := srv.Register("owner@example.com", "password123")
+	owner := srv.Login("owner@example.com", "password123")
+	org := srv.CreateOrganization("acme", ownerID)
+	project := srv.CreateProject(org.ID, "api", ownerID)
+
+	// Point de réception HTTPS du SIEM
+	received := make(chan models.AuditEvent, 16)
+	authorizations := make(chan string, 16)
+	siem := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event models.AuditEvent
+		json.NewDecoder(r.Body).Decode(&event)
+		authorizations <- r.Header.Get("Authorization")
+		received <- event
+	}))
+	defer siem.Close()
+
+	// Serveur syslog TCP
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	messages := make(chan string, 16)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			line, _ := bufio.NewReader(conn).ReadString('\n')
+			conn.Close()
+			messages <- line
+		}
+	}()
+
+	base := "/api/v1/organizations/" + org.ID + "/log-forwarders"
+	https := map[string]any{"kind": "https", "destination": siem.URL + "/audit", "secret": "siem-token",
+		"methods": []string{"post"}, "projects": []string{project.ID}}
+
+	// Réservé aux plans Business et Enterprise
+	resp := srv.Do(http.MethodPost, base, owner, https)
+	apitest.ExpectStatus(t, resp, http.StatusPaymentRequired)
+	if err := srv.Organizations.UpdateOrganizationPlan(context.Background(), org.ID, "plan-business"); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, invalid := range []map[string]any{
+		{"kind": "kafka", "destination": "kafka://broker:9092"},
+		{"kind": "https", "destination": "http://siem.example.com"},
+		{"kind": "s3", "destination": "s3://audit-logs/acme"},
+		{"kind": "syslog", "destination": "udp://siem.example.com"},
+		{"kind": "https", "destination": siem.URL, "methods": []string{"TRACE"}},
+		// Adresses internes : réseaux privés, métadonnées des clouds
+		{"kind": "syslog", "destination": "tcp://10.0.0.5:514"},
+		{"kind": "syslog", "destination": "udp://[fd00::1]:514"},
+		{"kind": "https", "destination": "https://169.254.169.254/latest/meta-data/"},
+		{"kind": "s3", "destination": "s3://audit-logs/acme", "region": "eu-west-3", "access_key_id": "AKIA",
+			"secret": "s3cret", "endpoint": "https://192.168.1.10:9000"},
+	} {
+		resp = srv.Do(http.MethodPost, base, owner, invalid)
+		apitest.ExpectStatus(t, resp, http.StatusBadRequest)
+	}
+
+	resp = srv.Do(http.MethodPost, base, owner, https)
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+	var forwarder models.LogForwarder
+	apitest.DecodeJSON(t, resp, &forwarder)
+	if forwarder.Secret != "" || forwarder.Methods[0] != http.MethodPost {
+		t.Errorf("Expected a normalized forwarder without its secret, got %+v", forwarder)
+	}
+	resp = srv.Do(http.MethodPost, base, owner, map[string]any{"kind": "syslog",
+		"destination": "tcp://" + listener.Addr().String(), "failures_only": true})
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+
+	// Une écriture sur le projet est transférée au SIEM, pas au syslog
+	secrets := "/api/v1/organizations/" + org.ID + "/projects/" + project.ID + "/environments/prod/secrets"
+	resp = srv.Do(http.MethodPost, secrets, owner, models.Secret{Name: "DB_PASSWORD", Value: "s3cret"})
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+	select {
+	case event := <-received:
+		if event.Method != http.MethodPost || event.ProjectID != project.ID || event.PrincipalID != ownerID ||
+			event.StatusCode != http.StatusCreated || !strings.HasSuffix(event.Route, "/environments/{env}/secrets") {
+			t.Errorf("Expected the secret creation event, got %+v", event)
+		}
+		if authorization := <-authorizations; authorization != "Bearer siem-token" {
+			t.Errorf("Expected the bearer token, got %q", authorization)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the event to be forwarded over HTTPS")
+	}
+
+	// Une lecture en échec n'est transférée qu'au syslog
+	resp = srv.Do(http.MethodGet, secrets+"/MISSING", owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusNotFound)
+	select {
+	case message := <-messages:
+		if !strings.Contains(message, "<108>1 ") || !strings.Contains(message, `"status_code":404`) {
+			t.Errorf("Expected an RFC 5424 warning with the event, got %q", message)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the failure to be forwarded over syslog")
+	}
+	select {
+	case event := <-received:
+		t.Errorf("Expected the read to be filtered out of HTTPS, got %+v", event)
+	default:
+	}
+
+	// La liste ne renvoie pas les secrets ; l'événement de test ignore les filtres
+	resp = srv.Do(http.MethodGet, base, owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var list []models.LogForwarder
+	apitest.DecodeJSON(t, resp, &list)
+	if len(list) != 2 || list[0].Secret != "" {
+		t.Errorf("Expected two forwarders without secrets, got %+v", list)
+	}
+	resp = srv.Do(http.MethodPost, base+"/"+forwarder.ID+":test", owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var result handlers.ForwarderTestResult
+	apitest.DecodeJSON(t, resp, &result)
+	if !result.Delivered {
+		t.Errorf("Expected the test event to be delivered, got %+v", result)
+	}
+	<-received
+
+	resp = srv.Do(http.MethodDelete, base+"/"+forwarder.ID, owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusNoContent)
+	resp = srv.Do(http.MethodDelete, base+"/"+forwarder.ID, owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusNotFound)
+
+	// L'échec d'un envoi de test ne décrit pas le réseau (port fermé)
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	closed.Close()
+	resp = srv.Do(http.MethodPost, base, owner, map[string]any{"kind": "syslog",
+		"destination": "tcp://" + closed.Addr().String()})
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+	apitest.DecodeJSON(t, resp, &forwarder)
+	resp = srv.Do(http.MethodPost, base+"/"+forwarder.ID+":test", owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	result = handlers.ForwarderTestResult{}
+	apitest.DecodeJSON(t, resp, &result)
+	if result.Delivered || result.Error == "" || strings.Contains(result.Error, "refused") ||
+		strings.Contains(result.Error, closed.Addr().String()) {
+		t.Errorf("Expected a generic failure, got %+v", result)
+	}
+}
@@ -0,0 +1,98 @@
+// filepath: internal/api/login_alerts_test.go
+
+package api_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"secrets-manager/internal/api/apitest"
+	"secrets-manager/internal/auth"
+	"secrets-manager/internal/geoip"
+	"secrets-manager/internal/models"
+)
+
+func TestLoginAlerts(t *testing.T) {
+	srv := apitest.NewServer(t)
+	ownerID := srv.Register("owner@example.com", "password123")
+	session := srv.Login("owner@example.com", "password123")
+	memberID := srv.Register("member@example.com", "password123")
+	member := srv.Login("member@example.com", "password123")
+	org := srv.CreateOrganization("acme", ownerID)
+	srv.AddMember(org.ID, memberID, "member")
+	policyPath := "/api/v1/organizations/" + org.ID + "/login-alerts"
+
+	resp := srv.Do(http.MethodPost, "/api/v1/auth/mfa/enroll", session, nil)
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+	var enrollment auth.MFAEnrollment
+	apitest.DecodeJSON(t, resp, &enrollment)
+	code := func() string {
+		mfa, err := srv.UserMFA.GetUserMFA(context.Background(), ownerID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		mfa.LastStep = 0
+		srv.UserMFA.SaveUserMFA(context.Background(), mfa)
+		code, _ := auth.TOTPCode(enrollment.Secret, time.Now())
+		return code
+	}
+	resp = srv.Do(http.MethodPost, "/api/v1/auth/mfa/activate", session, map[string]string{"code": code()})
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var tokens map[string]string
+	apitest.DecodeJSON(t, resp, &tokens)
+	owner := tokens["token"]
+
+	// Politique par défaut : alertes sans code supplémentaire
+	resp = srv.Do(http.MethodGet, policyPath, member, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var policy models.LoginAlertPolicy
+	apitest.DecodeJSON(t, resp, &policy)
+	if !policy.NotifyNewLocation || !policy.NotifyImpossibleTravel || policy.StepUp {
+		t.Errorf("Expected the default policy, got %+v", policy)
+	}
+	update := map[string]bool{"notify_new_location": true, "notify_impossible_travel": true, "step_up": true}
+	resp = srv.Do(http.MethodPut, policyPath, member, update)
+	apitest.ExpectStatus(t, resp, http.StatusForbidden)
+	resp = srv.Do(http.MethodPut, policyPath, owner, update)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+
+	// Connexion depuis Paris avec un appareil de confiance mémorisé
+	srv.Locator.Move(&geoip.Location{Country: "FR", City: "Paris", Latitude: 48.8566, Longitude: 2.3522})
+	browser := http.Header{"User-Agent": {"Firefox/131.0"}}
+	credentials := map[string]string{"email": "owner@example.com", "password": "password123"}
+	login := func(header http.Header) map[string]any {
+		resp := srv.DoWithHeaders(http.MethodPost, "/api/v1/auth/login", "", header, credentials)
+		apitest.ExpectStatus(t, resp, http.StatusOK)
+		var body map[string]any
+		apitest.DecodeJSON(t, resp, &body)
+		return body
+	}
+	challenge := login(browser)
+	resp = srv.DoWithHeaders(http.MethodPost, "/api/v1/auth/mfa/verify", "", browser, map[string]any{
+		"mfa_token": challenge["mfa_token"], "code": code(), "remember_device": true})
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	trusted := browser.Clone()
+	for _, c := range resp.Cookies() {
+		trusted.Set("Cookie", c.Name+"="+c.Value)
+	}
+	if body := login(trusted); body["token"] == nil {
+		t.Fatalf("Expected tokens from the trusted device, got %+v", body)
+	}
+
+	// Aussitôt après à New York : le code est exigé malgré l'appareil de confiance
+	srv.Locator.Move(&geoip.Location{Country: "US", City: "New York", Latitude: 40.7128, Longitude: -74.0060})
+	if body := login(trusted); body["mfa_required"] != true {
+		t.Errorf("Expected an MFA challenge after an impossible travel, got %+v", body)
+	}
+
+	resp = srv.Do(http.MethodGet, "/api/v1/me/logins", owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var logins []models.LoginEvent
+	apitest.DecodeJSON(t, resp, &logins)
+	if len(logins) != 4 || logins[0].Anomaly != models.LoginImpossibleTravel || !logins[0].StepUp ||
+		logins[0].City != "New York" || logins[1].Anomaly != "" {
+		t.Errorf("Expected the impossible travel at the top of the history, got %+v", logins)
+	}
+}
@@ -0,0 +1,97 @@
+// filepath: internal/api/lookup_test.go
+
+package api_test
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"secrets-manager/internal/api/apitest"
+	"secrets-manager/internal/api/handlers"
+	"secrets-manager/internal/models"
+)
+
+func TestLookup(t *testing.T) {
+	srv := apitest.NewServer(t)
+	ownerID := srv.Register("owner@example.com", "password123")
+	owner := srv.Login("owner@example.com", "password123")
+	org := srv.CreateOrganization("acme", ownerID)
+	project := srv.CreateProject(org.ID, "api", ownerID)
+
+	secrets := "/api/v1/organizations/" + org.ID + "/projects/" + project.ID + "/environments/prod/secrets"
+	resp := srv.Do(http.MethodPost, secrets, owner, models.Secret{Name: "DB_PASSWORD", Value: "v1"})
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+
+	path := org.ID + "/" + project.ID + "/prod/DB_PASSWORD"
+	lookup := "/api/v1/lookup?path=" + url.QueryEscape(path)
+	resp = srv.Do(http.MethodGet, lookup, owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	etag := resp.Header.Get("ETag")
+	var result handlers.LookupResult
+	apitest.DecodeJSON(t, resp, &result)
+	if result.Value != "v1" || result.Path != path || etag != `"`+result.Index+`"` {
+		t.Fatalf("Expected v1 with its index, got %+v (ETag %s)", result, etag)
+	}
+
+	resp = srv.DoWithHeaders(http.MethodGet, lookup, owner, http.Header{"If-None-Match": {etag}}, nil)
+	apitest.ExpectStatus(t, resp, http.StatusNotModified)
+	resp = srv.Do(http.MethodGet, lookup+"&index="+url.QueryEscape(result.Index)+"&wait=50ms", owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusNotModified)
+
+	// L'attente longue se termine à la modification du secret
+	done := make(chan *http.Response, 1)
+	go func() {
+		done <- srv.Do(http.MethodGet, lookup+"&index="+url.QueryEscape(result.Index)+"&wait=10s&format=raw", owner, nil)
+	}()
+	time.Sleep(100 * time.Millisecond)
+	resp = srv.Do(http.MethodPut, secrets+"/DB_PASSWORD", owner, map[string]string{"value": "v2"})
+	apitest.ExpectStatus(t, resp, http.StatusNoContent)
+	select {
+	case resp = <-done:
+		apitest.ExpectStatus(t, resp, http.StatusOK)
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if string(body) != "v2" {
+			t.Errorf("Expected the new raw value, got %q", body)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the long poll to return after the change")
+	}
+
+	for _, query := range []string{"?path=" + org.ID + "/" + project.ID + "/prod", "?path=" + url.QueryEscape(path) + "&wait=1h"} {
+		resp = srv.Do(http.MethodGet, "/api/v1/lookup"+query, owner, nil)
+		apitest.ExpectStatus(t, resp, http.StatusBadRequest)
+	}
+
+	// Un token de lecture des métadonnées ne suffit pas
+	for scope, expected := range map[string]int{models.ScopeMetadataRead: http.StatusForbidden, models.ScopeSecretsRead: http.StatusOK} {
+		resp = srv.Do(http.MethodPost, "/api/v1/me/tokens", owner, map[string]any{
+			"name": "ansible-" + scope, "scopes": []string{scope}})
+		apitest.ExpectStatus(t, resp, http.StatusCreated)
+		var token handlers.CreatedToken
+		apitest.DecodeJSON(t, resp, &token)
+		resp = srv.Do(http.MethodGet, lookup, token.Token, nil)
+		apitest.ExpectStatus(t, resp, expected)
+	}
+
+	// Un token restreint à l'environnement dev ne lit pas prod par le lookup
+	resp = srv.Do(http.MethodPost, "/api/v1/me/tokens", owner, map[string]any{
+		"name": "ansible-dev", "scopes": []string{models.ScopeSecretsRead},
+		"resources": []models.ResourceScope{{ProjectID: project.ID, Environment: "dev"}}})
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+	var devToken handlers.CreatedToken
+	apitest.DecodeJSON(t, resp, &devToken)
+	resp = srv.Do(http.MethodGet, secrets+"/DB_PASSWORD", devToken.Token, nil)
+	apitest.ExpectStatus(t, resp, http.StatusForbidden)
+	resp = srv.Do(http.MethodGet, lookup, devToken.Token, nil)
+	apitest.ExpectStatus(t, resp, http.StatusNotFound)
+	devSecrets := "/api/v1/organizations/" + org.ID + "/projects/" + project.ID + "/environments/dev/secrets"
+	resp = srv.Do(http.MethodPost, devSecrets, owner, models.Secret{Name: "DB_PASSWORD", Value: "dev"})
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+	resp = srv.Do(http.MethodGet, "/api/v1/lookup?path="+url.QueryEscape(org.ID+"/"+project.ID+"/dev/DB_PASSWORD"),
+		devToken.Token, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+}
@@ -0,0 +1,96 @@
+// filepath: internal/api/maintenance_gate_test.go
+
+package api_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"secrets-manager/internal/api/apitest"
+	"secrets-manager/internal/jobs"
+	"secrets-manager/internal/maintenance"
+	"secrets-manager/internal/models"
+)
+
+func TestMaintenanceWindows(t *testing.T) {
+	srv := apitest.NewServer(t)
+	ownerID := srv.Register("owner@example.com", "password123")
+	owner := srv.Login("owner@example.com", "password123")
+	memberID := srv.Register("member@example.com", "password123")
+	member := srv.Login("member@example.com", "password123")
+	org := srv.CreateOrganization("acme", ownerID)
+	srv.AddMember(org.ID, memberID, "member")
+	project := srv.CreateProject(org.ID, "api", ownerID)
+
+	windows := "/api/v1/organizations/" + org.ID + "/maintenance-windows"
+	resp := srv.Do(http.MethodPost, windows, owner, models.MaintenanceWindow{Environment: "prod", Schedule: "0 25 * * *", DurationMinutes: 60})
+	apitest.ExpectStatus(t, resp, http.StatusBadRequest)
+	resp = srv.Do(http.MethodPost, windows, owner, models.MaintenanceWindow{Environment: "prod", Schedule: "0 2 * * *", DurationMinutes: 0})
+	apitest.ExpectStatus(t, resp, http.StatusBadRequest)
+
+	// Une fenêtre d'une minute, douze heures plus tard : fermée maintenant
+	closedAt := time.Now().UTC().Add(12 * time.Hour)
+	closed := models.MaintenanceWindow{Environment: "prod", Schedule: fmt.Sprintf("0 %d * * *", closedAt.Hour()), DurationMinutes: 1}
+	resp = srv.Do(http.MethodPost, windows, member, closed)
+	apitest.ExpectStatus(t, resp, http.StatusForbidden)
+	resp = srv.Do(http.MethodPost, windows, owner, closed)
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+	var created models.MaintenanceWindow
+	apitest.DecodeJSON(t, resp, &created)
+	if created.ID == "" || created.Timezone != "UTC" || created.Open || created.NextOpening == nil {
+		t.Errorf("Expected a closed UTC window with a next opening, got %+v", created)
+	}
+
+	// Le changement planifié échu attend l'ouverture d'une fenêtre
+	secrets := "/api/v1/organizations/" + org.ID + "/projects/" + project.ID + "/environments/prod/secrets"
+	resp = srv.Do(http.MethodPost, secrets, owner, models.Secret{Name: "DB_PASSWORD", Value: "v1"})
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+	resp = srv.Do(http.MethodPut, secrets+"/DB_PASSWORD", owner, map[string]any{
+		"value": "v2", "effective_at": time.Now().Add(time.Hour)})
+	apitest.ExpectStatus(t, resp, http.StatusAccepted)
+	var change models.ScheduledSecretChange
+	apitest.DecodeJSON(t, resp, &change)
+	change.EffectiveAt = time.Now().Add(-time.Second)
+	if err := srv.ScheduledSecretChanges.SaveScheduledSecretChange(context.Background(), &change); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	promoter := jobs.NewSecretPromoter(srv.ScheduledSecretChanges, srv.Secrets, srv.Projects, srv.VaultService,
+		srv.Checksummer, nil, srv.Events, maintenance.NewGate(srv.MaintenanceWindows))
+	expectValue := func(t *testing.T, expected string) {
+		t.Helper()
+		if err := promoter.PromoteDue(context.Background()); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		resp := srv.Do(http.MethodGet, secrets+"/DB_PASSWORD", owner, nil)
+		apitest.ExpectStatus(t, resp, http.StatusOK)
+		var secret models.Secret
+		apitest.DecodeJSON(t, resp, &secret)
+		if secret.Value != expected {
+			t.Errorf("Expected %s, got %s", expected, secret.Value)
+		}
+	}
+	expectValue(t, "v1")
+
+	// Une fenêtre toujours ouverte débloque la promotion
+	resp = srv.Do(http.MethodPost, windows, owner, models.MaintenanceWindow{Environment: "prod", Schedule: "* * * * *", DurationMinutes: 60})
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+	resp = srv.Do(http.MethodGet, windows, owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var listed []models.MaintenanceWindow
+	apitest.DecodeJSON(t, resp, &listed)
+	if len(listed) != 2 {
+		t.Fatalf("Expected 2 windows, got %d", len(listed))
+	}
+	expectValue(t, "v2")
+
+	resp = srv.Do(http.MethodDelete, windows+"/"+created.ID, member, nil)
+	apitest.ExpectStatus(t, resp, http.StatusForbidden)
+	resp = srv.Do(http.MethodDelete, windows+"/"+created.ID, owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusNoContent)
+	resp = srv.Do(http.MethodDelete, windows+"/"+created.ID, owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusNotFound)
+}
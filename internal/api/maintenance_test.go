@@ -0,0 +1,60 @@
+// filepath: internal/api/maintenance_test.go
+
+package api_test
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"secrets-manager/internal/api/apitest"
+	"secrets-manager/internal/api/middleware"
+)
+
+func TestKillSwitches(t *testing.T) {
+	srv := apitest.NewServer(t)
+	ownerID := srv.Register("owner@example.com", "password123")
+	token := srv.Login("owner@example.com", "password123")
+	org := srv.CreateOrganization("acme", ownerID)
+
+	evidence := "/api/v1/organizations/" + org.ID + "/evidence"
+	projects := "/api/v2/organizations/" + org.ID + "/projects"
+
+	if err := srv.Switches.Disable(middleware.Switch{Name: middleware.FeatureExports, Reason: "incident en cours"}); err != nil {
+		t.Fatalf("Failed to disable exports: %v", err)
+	}
+	resp := srv.Do(http.MethodGet, evidence, token, nil)
+	apitest.ExpectStatus(t, resp, http.StatusServiceUnavailable)
+	var payload struct {
+		Error  struct{ Code string }
+		Switch middleware.Switch
+	}
+	apitest.DecodeJSON(t, resp, &payload)
+	if payload.Error.Code != "maintenance" || payload.Switch.Reason != "incident en cours" {
+		t.Errorf("Expected the switch to be explained, got %+v", payload)
+	}
+
+	// Une route coupée sans préfixe de version l'est dans toutes les versions
+	if err := srv.Switches.Disable(middleware.Switch{Name: "get /organizations/{orgID}/projects", Reason: "migration"}); err != nil {
+		t.Fatalf("Failed to disable the projects list: %v", err)
+	}
+	resp = srv.Do(http.MethodGet, projects, token, nil)
+	apitest.ExpectStatus(t, resp, http.StatusServiceUnavailable)
+
+	resp = srv.DoAdmin(http.MethodGet, "/admin/switches")
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var list struct{ Switches []middleware.Switch }
+	apitest.DecodeJSON(t, resp, &list)
+	if len(list.Switches) != 2 {
+		t.Errorf("Expected 2 active switches, got %d", len(list.Switches))
+	}
+
+	resp = srv.DoAdmin(http.MethodDelete, "/admin/switches?name="+url.QueryEscape("GET /organizations/{orgID}/projects"))
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	resp = srv.Do(http.MethodGet, projects, token, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+
+	// Les autres routes ne sont pas concernées
+	resp = srv.Do(http.MethodGet, "/api/v1/evidence/public-key", token, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+}
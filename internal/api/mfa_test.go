@@ -0,0 +1,117 @@
+// filepath: internal/api/mfa_test.go
+
+package api_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"secrets-manager/internal/api/apitest"
+	"secrets-manager/internal/auth"
+)
+
+func TestMFA(t *testing.T) {
+	srv := apitest.NewServer(t)
+	userID := srv.Register("mfa@example.com", "password123")
+	session := srv.Login("mfa@example.com", "password123")
+	protected := "/api/v1/users/me/notification-preferences"
+
+	resp := srv.Do(http.MethodPost, "/api/v1/auth/mfa/enroll", session, nil)
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+	var enrollment auth.MFAEnrollment
+	apitest.DecodeJSON(t, resp, &enrollment)
+	if enrollment.Secret == "" || !strings.HasPrefix(enrollment.ProvisioningURI, "otpauth://totp/") ||
+		!strings.Contains(enrollment.ProvisioningURI, "secret="+enrollment.Secret) {
+		t.Fatalf("Expected a TOTP secret and its provisioning URI, got %+v", enrollment)
+	}
+
+	code := func() string {
+		code, err := auth.TOTPCode(enrollment.Secret, time.Now())
+		if err != nil {
+			t.Fatal(err)
+		}
+		return code
+	}
+	// Un code ne sert qu'une fois : oublier le dernier pas accepté permet de
+	// réutiliser le pas courant sans attendre le suivant
+	forgetStep := func() {
+		mfa, err := srv.UserMFA.GetUserMFA(context.Background(), userID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		mfa.LastStep = 0
+		srv.UserMFA.SaveUserMFA(context.Background(), mfa)
+	}
+	var tokens map[string]string
+
+	// L'inscription n'est exigée qu'après un premier code valide
+	resp = srv.Do(http.MethodPost, "/api/v1/auth/mfa/activate", session, map[string]string{"code": "abcdef"})
+	apitest.ExpectStatus(t, resp, http.StatusUnauthorized)
+	resp = srv.Do(http.MethodGet, protected, session, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	resp = srv.Do(http.MethodPost, "/api/v1/auth/mfa/activate", session, map[string]string{"code": code()})
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	apitest.DecodeJSON(t, resp, &tokens)
+
+	// Les tokens émis sans code TOTP sont refusés
+	resp = srv.Do(http.MethodGet, protected, session, nil)
+	apitest.ExpectStatus(t, resp, http.StatusUnauthorized)
+	resp = srv.Do(http.MethodGet, protected, tokens["token"], nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	resp = srv.Do(http.MethodPost, "/api/v1/auth/mfa/enroll", tokens["token"], nil)
+	apitest.ExpectStatus(t, resp, http.StatusConflict)
+
+	// La connexion renvoie un token à échanger avec un code
+	resp = srv.Do(http.MethodPost, "/api/v1/auth/login", "", map[string]string{
+		"email": "mfa@example.com", "password": "password123"})
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var login struct {
+		Token       string `json:"token"`
+		MFARequired bool   `json:"mfa_required"`
+		MFAToken    string `json:"mfa_token"`
+	}
+	apitest.DecodeJSON(t, resp, &login)
+	if login.Token != "" || !login.MFARequired || login.MFAToken == "" {
+		t.Fatalf("Expected an MFA challenge instead of tokens, got %+v", login)
+	}
+	resp = srv.Do(http.MethodGet, protected, login.MFAToken, nil)
+	apitest.ExpectStatus(t, resp, http.StatusUnauthorized)
+
+	forgetStep()
+	current := code()
+	verify := map[string]string{"mfa_token": login.MFAToken, "code": current}
+	resp = srv.Do(http.MethodPost, "/api/v1/auth/mfa/verify", "", verify)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	apitest.DecodeJSON(t, resp, &tokens)
+	resp = srv.Do(http.MethodGet, protected, tokens["token"], nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+
+	// Un code déjà utilisé est refusé, et les codes refusés verrouillent la vérification
+	resp = srv.Do(http.MethodPost, "/api/v1/auth/mfa/verify", "", verify)
+	apitest.ExpectStatus(t, resp, http.StatusUnauthorized)
+	for i := 0; i < 3; i++ {
+		resp = srv.Do(http.MethodPost, "/api/v1/auth/mfa/verify", "",
+			map[string]string{"mfa_token": login.MFAToken, "code": "abcdef"})
+		apitest.ExpectStatus(t, resp, http.StatusUnauthorized)
+	}
+	resp = srv.Do(http.MethodPost, "/api/v1/auth/mfa/verify", "",
+		map[string]string{"mfa_token": login.MFAToken, "code": "abcdef"})
+	apitest.ExpectStatus(t, resp, http.StatusTooManyRequests)
+	forgetStep()
+	resp = srv.Do(http.MethodPost, "/api/v1/auth/mfa/verify", "",
+		map[string]string{"mfa_token": login.MFAToken, "code": code()})
+	apitest.ExpectStatus(t, resp, http.StatusTooManyRequests)
+
+	// La désactivation exige un code valide
+	if err := srv.UserMFA.SetMFAFailures(context.Background(), userID, 0, nil); err != nil {
+		t.Fatal(err)
+	}
+	forgetStep()
+	resp = srv.Do(http.MethodPost, "/api/v1/auth/mfa/disable", tokens["token"], map[string]string{"code": code()})
+	apitest.ExpectStatus(t, resp, http.StatusNoContent)
+	resp = srv.Do(http.MethodGet, protected, session, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+}
@@ -0,0 +1,159 @@
+// filepath: internal/api/middleware/admin_audit.go
+
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"secrets-manager/internal/logging"
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// Taille maximale du corps de requête conservé dans l'audit
+const maxAuditedBodySize = 64 << 10
+
+// Fragments de clés JSON dont la valeur est masquée dans l'audit
+var sensitiveKeyFragments = []string{"password", "secret", "token", "value", "key", "credential"}
+
+// AdminAudit est un middleware qui enregistre chaque requête d'administration
+// (principal, corps expurgé, code de réponse) dans le journal d'audit dédié.
+// Les chemins listés dans skip (ex: /metrics) ne sont pas audités.
+func AdminAudit(audit storage.AdminAuditRepository, skip ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			for _, path := range skip {
+				if r.URL.Path == path {
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+			serveAudited(audit, next, w, r, "")
+		})
+	}
+}
+
+// ImpersonationAudit est un middleware qui inscrit au journal d'audit
+// d'administration chaque requête de l'API faite sous une usurpation
+// d'identité, signalée par son usurpation. Il doit être placé après
+// l'authentification.
+func ImpersonationAudit(audit storage.AdminAuditRepository) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			impersonationID := ImpersonationFromContext(r.Context())
+			if impersonationID == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			serveAudited(audit, next, w, r, impersonationID)
+		})
+	}
+}
+
+// serveAudited sert la requête puis l'enregistre dans le journal d'audit
+// d'administration
+func serveAudited(audit storage.AdminAuditRepository, next http.Handler, w http.ResponseWriter, r *http.Request,
+	impersonationID string) {
+	// Lire le début du corps puis le restituer intact au handler
+	var body []byte
+	if r.Body != nil {
+		body, _ = io.ReadAll(io.LimitReader(r.Body, maxAuditedBodySize))
+		r.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), r.Body))
+	}
+
+	recorder := newStatusRecorder(w)
+	start := time.Now()
+
+	next.ServeHTTP(recorder, r)
+
+	principal := "anonymous"
+	if p, ok := PrincipalFromContext(r.Context()); ok {
+		principal = p.String()
+	}
+
+	entry := &models.AdminAuditLog{
+		Principal:       principal,
+		Method:          r.Method,
+		Route:           RouteTemplate(r),
+		Path:            r.URL.Path,
+		RequestBody:     SanitizeBody(body),
+		StatusCode:      recorder.status,
+		DurationMS:      time.Since(start).Milliseconds(),
+		IPAddress:       ClientIP(r),
+		UserAgent:       r.UserAgent(),
+		Timestamp:       start.UTC(),
+		ImpersonationID: impersonationID,
+	}
+	if err := audit.CreateAdminAuditLog(context.WithoutCancel(r.Context()), entry); err != nil {
+		logging.For(logging.ComponentHTTP).Error("échec de l'audit d'administration",
+			"path", r.URL.Path, "error", err)
+	}
+}
+
+// SanitizeBody renvoie le corps JSON avec les valeurs sensibles masquées.
+// Un corps non JSON n'est pas conservé, seule sa taille est indiquée.
+func SanitizeBody(body []byte) string {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return ""
+	}
+
+	var payload interface{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return fmt.Sprintf("[corps non JSON, %d octets]", len(body))
+	}
+
+	sanitized, err := json.Marshal(redact(payload))
+	if err != nil {
+		return fmt.Sprintf("[corps illisible, %d octets]", len(body))
+	}
+	return string(sanitized)
+}
+
+// redact masque récursivement les valeurs des clés sensibles
+func redact(v interface{}) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for key, inner := range value {
+			if isSensitiveKey(key) {
+				value[key] = "[REDACTED]"
+				continue
+			}
+			value[key] = redact(inner)
+		}
+		return value
+	case []interface{}:
+		for i, inner := range value {
+			value[i] = redact(inner)
+		}
+		return value
+	default:
+		return v
+	}
+}
+
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, fragment := range sensitiveKeyFragments {
+		if strings.Contains(key, fragment) {
+			return true
+		}
+	}
+	return false
+}
+
+// ClientIP renvoie l'adresse IP de l'appelant
+func ClientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,57 @@
+// filepath: internal/api/middleware/admin_audit_test.go
+
+package middleware
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSanitizeBody(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		contains []string
+		excludes []string
+	}{
+		{
+			name:     "Empty body",
+			body:     "",
+			contains: []string{},
+		},
+		{
+			name:     "Sensitive keys are redacted",
+			body:     `{"component":"http","password":"hunter2","nested":{"api_key":"abc","level":"debug"}}`,
+			contains: []string{`"component":"http"`, `"level":"debug"`, `[REDACTED]`},
+			excludes: []string{"hunter2", "abc"},
+		},
+		{
+			name:     "Arrays are walked",
+			body:     `[{"token":"t0k3n"},{"name":"ok"}]`,
+			contains: []string{`"name":"ok"`},
+			excludes: []string{"t0k3n"},
+		},
+		{
+			name:     "Non JSON body is not kept",
+			body:     "password=hunter2",
+			contains: []string{"non JSON"},
+			excludes: []string{"hunter2"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			sanitized := SanitizeBody([]byte(tc.body))
+			for _, expected := range tc.contains {
+				if !strings.Contains(sanitized, expected) {
+					t.Errorf("Expected %q in %q", expected, sanitized)
+				}
+			}
+			for _, unexpected := range tc.excludes {
+				if strings.Contains(sanitized, unexpected) {
+					t.Errorf("Did not expect %q in %q", unexpected, sanitized)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,200 @@
+// filepath: internal/api/middleware/api_keys.go
+
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"secrets-manager/internal/auth"
+	"secrets-manager/internal/logging"
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+	"secrets-manager/internal/vault"
+)
+
+// APIKeyHeader est l'en-tête qui porte les clés d'API
+const APIKeyHeader = "X-API-Key"
+
+// En-têtes des requêtes signées par une clé d'API : l'identifiant de la clé,
+// l'horodatage en secondes Unix et la signature (voir auth.SignRequest)
+const (
+	APIKeyIDHeader           = "X-API-Key-ID"
+	SignatureTimestampHeader = "X-Signature-Timestamp"
+	SignatureHeader          = "X-Signature"
+)
+
+// Taille maximale du corps d'une requête signée, lu en entier pour vérifier
+// sa signature
+const maxSignedBodyBytes = 1 << 20
+
+// Routes accessibles avec une clé d'API ou un compte de service : les
+// secrets d'un environnement, le lookup et les métriques de l'organisation
+const (
+	apiKeyRoutes    = "/organizations/{orgID}/projects/{projectID}/environments/{env}/secrets"
+	lookupRoute     = "/lookup"
+	orgMetricsRoute = "/organizations/{orgID}/metrics"
+)
+
+var (
+	// ErrAPIKeyExpired indique qu'une clé d'API a expiré
+	ErrAPIKeyExpired = errors.New("clé d'API expirée")
+	// ErrSignatureRequired indique qu'une clé à requêtes signées a été
+	// présentée dans l'en-tête X-API-Key
+	ErrSignatureRequired = errors.New("cette clé d'API exige des requêtes signées")
+	// ErrInvalidSignature indique une signature absente, fausse, périmée ou
+	// déjà présentée
+	ErrInvalidSignature = errors.New("signature de la requête invalide")
+
+	errSignedBodyTooLarge = errors.New("corps de la requête signée trop volumineux")
+)
+
+// VerifyAPIKey renvoie la clé d'API valide correspondant à raw et enregistre
+// son utilisation. Les clés à requêtes signées sont refusées
+// (ErrSignatureRequired).
+func VerifyAPIKey(ctx context.Context, apiKeys storage.APIKeysRepository, raw string) (*models.APIKey, error) {
+	if !strings.HasPrefix(raw, auth.APIKeyPrefix) {
+		return nil, storage.ErrAPIKeyNotFound
+	}
+	key, err := apiKeys.GetAPIKeyByHash(ctx, auth.HashPersonalAccessToken(raw))
+	if err != nil {
+		return nil, err
+	}
+	if key.SignedRequests {
+		return nil, ErrSignatureRequired
+	}
+	return useAPIKey(ctx, apiKeys, key)
+}
+
+// verifySignedRequest renvoie la clé d'API valide qui a signé la requête et
+// enregistre son utilisation. L'horodatage doit être à moins de
+// auth.SignatureMaxSkew de l'horloge du serveur et la signature ne doit pas
+// avoir déjà été présentée, à aucune instance (voir
+// ClaimRequestSignature). La clé de signature est déchiffrée par le moteur
+// transit de l'organisation de la clé. Le corps est restitué à la suite.
+func verifySignedRequest(
+	r *http.Request,
+	apiKeys storage.APIKeysRepository,
+	transits *vault.TransitRouter,
+) (*models.APIKey, error) {
+	keyID := r.Header.Get(APIKeyIDHeader)
+	signature := r.Header.Get(SignatureHeader)
+	timestamp, err := strconv.ParseInt(r.Header.Get(SignatureTimestampHeader), 10, 64)
+	if err != nil || signature == "" {
+		return nil, ErrInvalidSignature
+	}
+	now := time.Now()
+	signedAt := time.Unix(timestamp, 0)
+	if signedAt.Before(now.Add(-auth.SignatureMaxSkew)) || signedAt.After(now.Add(auth.SignatureMaxSkew)) {
+		return nil, ErrInvalidSignature
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxSignedBodyBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(body) > maxSignedBodyBytes {
+		return nil, errSignedBodyTooLarge
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	key, err := apiKeys.GetAPIKey(r.Context(), keyID)
+	if err != nil {
+		return nil, err
+	}
+	if !key.SignedRequests || key.WrappedSigningKey == "" {
+		return nil, ErrInvalidSignature
+	}
+	transit, err := transits.For(r.Context(), key.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	signingKey, err := auth.UnwrapAPIKeySigningKey(r.Context(), transit, key.WrappedSigningKey)
+	if err != nil {
+		return nil, err
+	}
+	if !auth.VerifyRequestSignature(signingKey, signature, r.Method, r.RequestURI, timestamp, body) {
+		return nil, ErrInvalidSignature
+	}
+	err = apiKeys.ClaimRequestSignature(r.Context(), key.ID, signature, signedAt.Add(auth.SignatureMaxSkew))
+	if errors.Is(err, storage.ErrSignatureReplayed) {
+		return nil, ErrInvalidSignature
+	}
+	if err != nil {
+		return nil, err
+	}
+	return useAPIKey(r.Context(), apiKeys, key)
+}
+
+// useAPIKey refuse une clé expirée et enregistre son utilisation au plus
+// une fois par tokenTouchInterval
+func useAPIKey(ctx context.Context, apiKeys storage.APIKeysRepository, key *models.APIKey) (*models.APIKey, error) {
+	now := time.Now()
+	if key.ExpiresAt != nil && !now.Before(*key.ExpiresAt) {
+		return nil, ErrAPIKeyExpired
+	}
+
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= tokenTouchInterval {
+		if err := apiKeys.TouchAPIKey(ctx, key.ID, now); err != nil {
+			logging.For(logging.ComponentHTTP).Warn("date d'utilisation de la clé d'API non enregistrée",
+				"api_key_id", key.ID, "error", err)
+		}
+	}
+	return key, nil
+}
+
+// machineTarget renvoie l'organisation, le projet et l'environnement visés
+// par une requête sur une route accessible aux clés d'API et aux comptes de
+// service ; false hors de ces routes. Le lookup les lit dans son paramètre
+// path, les métriques ne visent que l'organisation (projet vide).
+func machineTarget(r *http.Request) (orgID, projectID, env string, ok bool) {
+	template := versionPrefix.ReplaceAllString(RouteTemplate(r), "")
+	vars := mux.Vars(r)
+	switch {
+	case strings.HasPrefix(template, apiKeyRoutes):
+		return vars["orgID"], vars["projectID"], vars["env"], true
+	case template == lookupRoute:
+		parts := strings.SplitN(r.URL.Query().Get("path"), "/", 4)
+		if len(parts) != 4 || parts[1] == "" {
+			return "", "", "", true
+		}
+		return parts[0], parts[1], parts[2], true
+	case template == orgMetricsRoute:
+		return vars["orgID"], "", "", true
+	}
+	return "", "", "", false
+}
+
+// RestrictAPIKeys refuse (403) les requêtes authentifiées par une clé d'API
+// hors des routes des secrets, du lookup et des métriques, ou visant une
+// organisation, un projet ou un environnement hors de la portée de la clé.
+// Les métriques ne comptent que les environnements couverts par la clé. Les
+// portées de sa permission sont vérifiées par RequireTokenScopes.
+func RestrictAPIKeys(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := APIKeyFromContext(r.Context())
+		if key == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		orgID, projectID, env, ok := machineTarget(r)
+		if !ok {
+			http.Error(w, "Route non accessible avec une clé d'API", http.StatusForbidden)
+			return
+		}
+		if orgID == "" || key.OrganizationID != orgID || (projectID != "" && !key.Covers(orgID, projectID, env)) {
+			http.Error(w, "Hors de la portée de la clé d'API", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
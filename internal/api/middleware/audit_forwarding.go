@@ -0,0 +1,55 @@
+// filepath: internal/api/middleware/audit_forwarding.go
+
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"secrets-manager/internal/models"
+)
+
+// AuditSink reçoit les événements d'audit des organisations sans attendre
+// leur transfert (voir logforward.Forwarder)
+type AuditSink interface {
+	Forward(event models.AuditEvent)
+}
+
+// AuditForwarding est un middleware qui publie chaque requête authentifiée
+// sur une route d'organisation (principal, route, code de réponse) comme
+// événement d'audit de cette organisation. Il doit être placé après
+// l'authentification.
+func AuditForwarding(sink AuditSink) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			recorder := newStatusRecorder(w)
+			start := time.Now()
+
+			next.ServeHTTP(recorder, r)
+
+			vars := mux.Vars(r)
+			principal, ok := PrincipalFromContext(r.Context())
+			if vars["orgID"] == "" || !ok {
+				return
+			}
+
+			sink.Forward(models.AuditEvent{
+				OrganizationID:  vars["orgID"],
+				ProjectID:       vars["projectID"],
+				PrincipalType:   principal.Type,
+				PrincipalID:     principal.ID,
+				Method:          r.Method,
+				Route:           RouteTemplate(r),
+				Path:            r.URL.Path,
+				StatusCode:      recorder.status,
+				IPAddress:       ClientIP(r),
+				UserAgent:       r.UserAgent(),
+				Reason:          r.URL.Query().Get("reason"),
+				ImpersonationID: ImpersonationFromContext(r.Context()),
+				Timestamp:       start.UTC(),
+			})
+		})
+	}
+}
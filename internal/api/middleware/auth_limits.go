@@ -0,0 +1,263 @@
+// filepath: internal/api/middleware/auth_limits.go
+
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"secrets-manager/internal/captcha"
+	"secrets-manager/internal/logging"
+	"secrets-manager/internal/metrics"
+)
+
+// CaptchaHeader porte la réponse au défi (CAPTCHA, preuve de travail...)
+// exigé après CaptchaAfter tentatives
+const CaptchaHeader = "X-Captcha-Token"
+
+// Taille maximale du corps lu pour y trouver l'email
+const maxAuthBodyBytes = 64 << 10
+
+var authLimitedTotal = metrics.NewCounter("http_auth_limited_total",
+	"Nombre de tentatives d'authentification refusées par la limitation", "route", "reason")
+
+// AuthLimits configure la limitation des routes d'authentification
+// publiques. Une valeur nulle désactive la limite correspondante.
+type AuthLimits struct {
+	// PerIP et PerEmail sont les tentatives permises par fenêtre, par adresse
+	// IP et par email (429 au-delà, jusqu'à la fin de la fenêtre)
+	PerIP    int
+	PerEmail int
+	Window   time.Duration
+	// Après FreeAttempts tentatives, chacune doit attendre BaseDelay après
+	// la précédente, délai doublé à chaque tentative jusqu'à MaxDelay
+	FreeAttempts int
+	BaseDelay    time.Duration
+	MaxDelay     time.Duration
+	// CaptchaAfter est le nombre de tentatives après lequel un défi résolu
+	// est exigé (428 sans lui), s'il y a un vérificateur ; le défi dispense
+	// du délai, pas des plafonds
+	CaptchaAfter int
+}
+
+// CaptchaVerifier vérifie la réponse à un défi présentée dans CaptchaHeader
+type CaptchaVerifier interface {
+	VerifyCaptcha(ctx context.Context, response, remoteIP string) error
+}
+
+// RequireCaptcha exige sur chaque requête la réponse à un défi, dans
+// CaptchaHeader : 428 si elle est absente ou refusée, 503 si le fournisseur
+// ne répond pas. Un AuthLimiter placé après lui ne la vérifie pas une
+// seconde fois (les preuves de travail sont à usage unique). verifier nil
+// n'exige rien.
+func RequireCaptcha(verifier CaptchaVerifier) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if verifier == nil {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			err := verifier.VerifyCaptcha(r.Context(), r.Header.Get(CaptchaHeader), ClientIP(r))
+			if errors.Is(err, captcha.ErrInvalid) {
+				authLimitedTotal.Inc(RouteTemplate(r), "captcha")
+				http.Error(w, "Défi requis : présentez sa réponse dans l'en-tête "+CaptchaHeader,
+					http.StatusPreconditionRequired)
+				return
+			}
+			if err != nil {
+				logging.For(logging.ComponentHTTP).Error("vérification du défi impossible", "error", err)
+				http.Error(w, "Vérification du défi indisponible", http.StatusServiceUnavailable)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), captchaKey, true)))
+		})
+	}
+}
+
+// AuthLimiter limite les tentatives sur les routes d'authentification
+// publiques (connexion, inscription, rafraîchissement...), par adresse IP et
+// par email, indépendamment des autres limites. Les compteurs sont en
+// mémoire, propres à chaque instance.
+type AuthLimiter struct {
+	mu        sync.Mutex
+	limits    AuthLimits
+	captcha   CaptchaVerifier
+	attempts  map[string]*authAttempts
+	lastSweep time.Time
+}
+
+// authAttempts compte les tentatives d'une clé (IP ou email) dans la fenêtre
+type authAttempts struct {
+	count int
+	since time.Time
+	last  time.Time
+}
+
+// NewAuthLimiter crée un limiteur ; captcha nil n'exige jamais de défi
+func NewAuthLimiter(limits AuthLimits, captcha CaptchaVerifier) *AuthLimiter {
+	return &AuthLimiter{
+		limits:   limits,
+		captcha:  captcha,
+		attempts: make(map[string]*authAttempts),
+	}
+}
+
+// Configure remplace les limites et le vérificateur de défis, et remet les
+// compteurs à zéro
+func (l *AuthLimiter) Configure(limits AuthLimits, captcha CaptchaVerifier) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.limits = limits
+	l.captcha = captcha
+	l.attempts = make(map[string]*authAttempts)
+}
+
+// Limit est le middleware des routes limitées. Chaque tentative compte pour
+// l'adresse IP et, si le corps JSON en porte un, pour l'email ; une réponse
+// 2xx remet le compteur de l'email à zéro. Le délai progressif et le défi
+// suivent le compteur de l'email, à défaut celui de l'adresse IP. Un
+// limiteur nil ne limite rien.
+func (l *AuthLimiter) Limit(next http.Handler) http.Handler {
+	if l == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys := []string{"ip:" + ClientIP(r)}
+		emailKey := ""
+		if email := requestEmail(r); email != "" {
+			emailKey = "email:" + email
+			keys = append(keys, emailKey)
+		}
+		route := RouteTemplate(r)
+
+		l.mu.Lock()
+		captcha := l.captcha
+		l.mu.Unlock()
+		solved, _ := r.Context().Value(captchaKey).(bool)
+		if response := r.Header.Get(CaptchaHeader); !solved && response != "" && captcha != nil {
+			solved = captcha.VerifyCaptcha(r.Context(), response, ClientIP(r)) == nil
+		}
+
+		status, reason, retryAfter := l.allow(keys, solved, time.Now())
+		if status != 0 {
+			authLimitedTotal.Inc(route, reason)
+			if retryAfter > 0 {
+				w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			}
+			if status == http.StatusPreconditionRequired {
+				http.Error(w, "Défi requis : présentez sa réponse dans l'en-tête "+CaptchaHeader, status)
+				return
+			}
+			logging.For(logging.ComponentHTTP).Warn("tentatives d'authentification limitées",
+				"route", route, "reason", reason, "ip", ClientIP(r))
+			http.Error(w, "Trop de tentatives, réessayez plus tard", status)
+			return
+		}
+
+		recorder := newStatusRecorder(w)
+		next.ServeHTTP(recorder, r)
+		if emailKey != "" && recorder.status >= 200 && recorder.status < 300 {
+			l.mu.Lock()
+			delete(l.attempts, emailKey)
+			l.mu.Unlock()
+		}
+	})
+}
+
+// allow enregistre la tentative si elle est permise ; sinon il renvoie le
+// statut du refus, sa raison (métrique) et le délai avant de réessayer
+func (l *AuthLimiter) allow(keys []string, solved bool, now time.Time) (int, string, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sweep(now)
+	entries := make([]*authAttempts, len(keys))
+	for i, key := range keys {
+		entry := l.attempts[key]
+		if entry == nil || (l.limits.Window > 0 && now.Sub(entry.since) >= l.limits.Window) {
+			entry = &authAttempts{since: now}
+		}
+		entries[i] = entry
+
+		limit := l.limits.PerIP
+		reason := "ip"
+		if strings.HasPrefix(key, "email:") {
+			limit, reason = l.limits.PerEmail, "email"
+		}
+		if limit > 0 && entry.count >= limit {
+			return http.StatusTooManyRequests, reason, entry.since.Add(l.limits.Window).Sub(now)
+		}
+	}
+
+	// Le délai et le défi suivent les tentatives sur l'email (à défaut,
+	// celles de l'adresse IP) : les autres utilisateurs de la même adresse
+	// IP n'attendent pas, seul le plafond PerIP les concerne
+	pacing := entries[len(entries)-1]
+	count, last := pacing.count, pacing.last
+	if l.captcha != nil && l.limits.CaptchaAfter > 0 && count >= l.limits.CaptchaAfter && !solved {
+		return http.StatusPreconditionRequired, "captcha", 0
+	}
+	if !solved && l.limits.BaseDelay > 0 && count >= l.limits.FreeAttempts && count > 0 {
+		delay := l.limits.BaseDelay << min(count-l.limits.FreeAttempts, 30)
+		if l.limits.MaxDelay > 0 && (delay > l.limits.MaxDelay || delay <= 0) {
+			delay = l.limits.MaxDelay
+		}
+		if wait := last.Add(delay).Sub(now); wait > 0 {
+			return http.StatusTooManyRequests, "delay", wait
+		}
+	}
+
+	for i, key := range keys {
+		entries[i].count++
+		entries[i].last = now
+		l.attempts[key] = entries[i]
+	}
+	return 0, "", 0
+}
+
+// sweep oublie les compteurs dont la fenêtre est écoulée, au plus une fois
+// par fenêtre
+func (l *AuthLimiter) sweep(now time.Time) {
+	if l.limits.Window <= 0 || now.Sub(l.lastSweep) < l.limits.Window {
+		return
+	}
+	for key, entry := range l.attempts {
+		if now.Sub(entry.since) >= l.limits.Window {
+			delete(l.attempts, key)
+		}
+	}
+	l.lastSweep = now
+}
+
+// requestEmail renvoie l'email (en minuscules) du corps JSON de la requête,
+// dont le corps reste lisible par le handler ; "" s'il n'en porte pas
+func requestEmail(r *http.Request) string {
+	if r.Body == nil {
+		return ""
+	}
+	original := r.Body
+	body, err := io.ReadAll(io.LimitReader(original, maxAuthBodyBytes))
+	r.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(body), original), original}
+	if err != nil {
+		return ""
+	}
+	var payload struct {
+		Email string `json:"email"`
+	}
+	if json.Unmarshal(body, &payload) != nil {
+		return ""
+	}
+	return strings.ToLower(strings.TrimSpace(payload.Email))
+}
@@ -0,0 +1,172 @@
+// filepath: internal/api/middleware/cache.go
+
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"secrets-manager/internal/events"
+)
+
+// CacheValidators mémorise, par organisation et ressource, la date de la
+// dernière modification publiée sur le bus. Une ressource qui n'a pas été
+// modifiée depuis le démarrage du processus a pour date ce démarrage.
+type CacheValidators struct {
+	mu       sync.RWMutex
+	started  time.Time
+	modified map[string]time.Time
+}
+
+// NewCacheValidators crée les validateurs et les abonne aux modifications
+// du bus ; bus nil ne laisse que la date de démarrage et celles fournies
+// par les handlers
+func NewCacheValidators(bus *events.Bus) *CacheValidators {
+	v := &CacheValidators{
+		started:  time.Now(),
+		modified: make(map[string]time.Time),
+	}
+	if bus != nil {
+		bus.Subscribe(v.invalidate)
+	}
+	return v
+}
+
+func (v *CacheValidators) invalidate(change events.Change) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	key := change.OrganizationID + "/" + change.Resource
+	if change.At.After(v.modified[key]) {
+		v.modified[key] = change.At
+	}
+}
+
+// LastModified renvoie la date de dernière modification connue d'une ressource
+func (v *CacheValidators) LastModified(orgID, resource string) time.Time {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	if at, ok := v.modified[orgID+"/"+resource]; ok {
+		return at
+	}
+	return v.started
+}
+
+// Cacheable ajoute aux réponses 200 d'une route GET les en-têtes de cache
+// (Cache-Control, ETag, Last-Modified) et répond 304 aux requêtes
+// conditionnelles dont la copie est à jour.
+//
+// L'ETag est l'empreinte SHA-256 du corps : If-None-Match est toujours
+// exact. Last-Modified est la plus récente des dates fournies par le handler
+// (en-tête Last-Modified) et par le bus pour la ressource de l'organisation
+// {orgID} ; le bus étant local au processus, les clients servis par
+// plusieurs instances doivent privilégier If-None-Match. Les réponses sont
+// privées (Vary: Authorization) : elles dépendent des droits de l'appelant,
+// qui sont vérifiés à chaque requête, y compris celles qui reçoivent 304.
+func Cacheable(validators *CacheValidators, resource string, maxAge time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet && r.Method != http.MethodHead {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			rec := &bufferedResponse{header: make(http.Header), status: http.StatusOK}
+			next.ServeHTTP(rec, r)
+
+			for key, values := range rec.header {
+				w.Header()[key] = values
+			}
+			if rec.status != http.StatusOK {
+				w.WriteHeader(rec.status)
+				w.Write(rec.body.Bytes())
+				return
+			}
+
+			sum := sha256.Sum256(rec.body.Bytes())
+			etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+			lastModified := validators.LastModified(mux.Vars(r)["orgID"], resource)
+			if handlerModified, err := http.ParseTime(rec.header.Get("Last-Modified")); err == nil &&
+				handlerModified.After(lastModified) {
+				lastModified = handlerModified
+			}
+			lastModified = lastModified.UTC().Truncate(time.Second)
+
+			header := w.Header()
+			header.Set("Cache-Control", fmt.Sprintf("private, max-age=%d, must-revalidate", int(maxAge.Seconds())))
+			header.Add("Vary", "Authorization")
+			header.Set("ETag", etag)
+			header.Set("Last-Modified", lastModified.Format(http.TimeFormat))
+
+			if notModified(r, etag, lastModified) {
+				header.Del("Content-Type")
+				header.Del("Content-Length")
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			if r.Method != http.MethodHead {
+				w.Write(rec.body.Bytes())
+			}
+		})
+	}
+}
+
+// notModified applique les règles des requêtes conditionnelles : If-None-Match,
+// s'il est présent, l'emporte sur If-Modified-Since
+func notModified(r *http.Request, etag string, lastModified time.Time) bool {
+	if match := r.Header.Get("If-None-Match"); match != "" {
+		for _, candidate := range strings.Split(match, ",") {
+			candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+			if candidate == "*" || candidate == etag {
+				return true
+			}
+		}
+		return false
+	}
+	if since, err := http.ParseTime(r.Header.Get("If-Modified-Since")); err == nil {
+		return !lastModified.After(since)
+	}
+	return false
+}
+
+// Invalidates publie sur le bus, après une réponse 2xx, la modification de
+// chaque ressource pour l'organisation {orgID} de la route
+func Invalidates(bus *events.Bus, resources ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rec := newStatusRecorder(w)
+			next.ServeHTTP(rec, r)
+
+			orgID := mux.Vars(r)["orgID"]
+			if rec.status < 200 || rec.status >= 300 || orgID == "" {
+				return
+			}
+			for _, resource := range resources {
+				bus.Publish(events.Change{OrganizationID: orgID, Resource: resource})
+			}
+		})
+	}
+}
+
+// bufferedResponse conserve la réponse d'un handler pour calculer son ETag
+type bufferedResponse struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (b *bufferedResponse) Header() http.Header { return b.header }
+
+func (b *bufferedResponse) WriteHeader(status int) { b.status = status }
+
+func (b *bufferedResponse) Write(p []byte) (int, error) { return b.body.Write(p) }
@@ -0,0 +1,51 @@
+// filepath: internal/api/middleware/cache_test.go
+
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"secrets-manager/internal/events"
+)
+
+func TestCacheableLastModifiedFollowsBus(t *testing.T) {
+	bus := events.NewBus()
+	validators := NewCacheValidators(bus)
+
+	router := mux.NewRouter()
+	router.Handle("/organizations/{orgID}/projects", Cacheable(validators, events.ResourceProjects, time.Minute)(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`[]`))
+		})))
+
+	get := func(since string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/organizations/org-1/projects", nil)
+		if since != "" {
+			req.Header.Set("If-Modified-Since", since)
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	lastModified := get("").Header().Get("Last-Modified")
+	if rec := get(lastModified); rec.Code != http.StatusNotModified {
+		t.Errorf("Expected 304 for an up-to-date copy, got %d", rec.Code)
+	}
+
+	// Une modification d'une autre organisation ne change rien
+	bus.Publish(events.Change{OrganizationID: "org-2", Resource: events.ResourceProjects, At: time.Now().Add(time.Hour)})
+	if rec := get(lastModified); rec.Code != http.StatusNotModified {
+		t.Errorf("Expected 304 after a change in another organization, got %d", rec.Code)
+	}
+
+	bus.Publish(events.Change{OrganizationID: "org-1", Resource: events.ResourceProjects, At: time.Now().Add(time.Hour)})
+	if rec := get(lastModified); rec.Code != http.StatusOK {
+		t.Errorf("Expected 200 after a change published on the bus, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,234 @@
+// filepath: internal/api/middleware/compress.go
+
+package middleware
+
+import (
+	"compress/flate"
+	"compress/gzip"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// minCompressSize est la taille en dessous de laquelle une réponse est
+// envoyée sans compression : le gain ne couvre pas le coût
+const minCompressSize = 1024
+
+var (
+	gzipWriters = sync.Pool{New: func() any {
+		w, _ := gzip.NewWriterLevel(io.Discard, gzip.DefaultCompression)
+		return w
+	}}
+	flateWriters = sync.Pool{New: func() any {
+		w, _ := flate.NewWriter(io.Discard, flate.DefaultCompression)
+		return w
+	}}
+)
+
+// Compress compresse les réponses en gzip ou deflate selon l'en-tête
+// Accept-Encoding de la requête. Seuls les contenus textuels (JSON, CSV,
+// texte) d'au moins minCompressSize octets sont compressés ; les réponses
+// en streaming restent en streaming (Flush vide le compresseur).
+//
+// À réserver aux listes et exports volumineux : les réponses contenant des
+// valeurs de secrets ne sont pas compressées (attaques de type BREACH).
+func Compress(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept-Encoding")
+
+		encoding := negotiateEncoding(r.Header.Get("Accept-Encoding"))
+		if encoding == "" || r.Method == http.MethodHead {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		cw := &compressWriter{ResponseWriter: w, encoding: encoding, status: http.StatusOK}
+		defer cw.Close()
+		next.ServeHTTP(cw, r)
+	})
+}
+
+// negotiateEncoding choisit gzip ou deflate (gzip à poids égal) selon les
+// poids q de Accept-Encoding ; "" si aucun n'est accepté
+func negotiateEncoding(header string) string {
+	best, bestQ := "", 0.0
+	for _, part := range strings.Split(header, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if name == "*" {
+			name = "gzip"
+		}
+		if (name != "gzip" && name != "deflate") || q <= 0 {
+			continue
+		}
+		if q > bestQ || (q == bestQ && name == "gzip") {
+			best, bestQ = name, q
+		}
+	}
+	return best
+}
+
+// compressible indique si un type de contenu gagne à être compressé
+func compressible(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return strings.HasPrefix(mediaType, "text/") ||
+		mediaType == "application/json" ||
+		mediaType == "application/x-ndjson" ||
+		strings.HasSuffix(mediaType, "+json")
+}
+
+// compressWriter retient les premiers octets de la réponse jusqu'à savoir
+// si elle mérite d'être compressée, puis écrit soit en clair, soit à
+// travers le compresseur
+type compressWriter struct {
+	http.ResponseWriter
+	encoding string
+	status   int
+
+	wroteHeader bool // WriteHeader a été appelé par le handler
+	decided     bool
+	buffer      []byte
+	compressor  interface {
+		io.WriteCloser
+		Flush() error
+		Reset(io.Writer)
+	}
+}
+
+func (c *compressWriter) WriteHeader(status int) {
+	if c.wroteHeader {
+		return
+	}
+	c.wroteHeader = true
+	c.status = status
+
+	// Réponses sans corps ou déjà encodées : rien à compresser
+	if status < http.StatusOK || status == http.StatusNoContent || status == http.StatusNotModified ||
+		c.Header().Get("Content-Encoding") != "" || !compressible(c.Header().Get("Content-Type")) {
+		c.decide(false)
+	}
+}
+
+func (c *compressWriter) Write(p []byte) (int, error) {
+	if !c.wroteHeader {
+		if c.Header().Get("Content-Type") == "" {
+			c.Header().Set("Content-Type", http.DetectContentType(p))
+		}
+		c.WriteHeader(http.StatusOK)
+	}
+	if c.decided {
+		if c.compressor != nil {
+			return c.compressor.Write(p)
+		}
+		return c.ResponseWriter.Write(p)
+	}
+
+	c.buffer = append(c.buffer, p...)
+	if len(c.buffer) >= minCompressSize {
+		if err := c.decide(true); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// decide envoie les en-têtes et les octets retenus, compressés ou non
+func (c *compressWriter) decide(compress bool) error {
+	if c.decided {
+		return nil
+	}
+	c.decided = true
+
+	if compress {
+		header := c.Header()
+		header.Set("Content-Encoding", c.encoding)
+		header.Del("Content-Length")
+		// La représentation compressée n'est plus identique octet par octet
+		if etag := header.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+			header.Set("ETag", "W/"+etag)
+		}
+		if c.encoding == "gzip" {
+			gz := gzipWriters.Get().(*gzip.Writer)
+			gz.Reset(c.ResponseWriter)
+			c.compressor = gz
+		} else {
+			fl := flateWriters.Get().(*flate.Writer)
+			fl.Reset(c.ResponseWriter)
+			c.compressor = fl
+		}
+	}
+	c.ResponseWriter.WriteHeader(c.status)
+
+	buffered := c.buffer
+	c.buffer = nil
+	if len(buffered) == 0 {
+		return nil
+	}
+	var err error
+	if c.compressor != nil {
+		_, err = c.compressor.Write(buffered)
+	} else {
+		_, err = c.ResponseWriter.Write(buffered)
+	}
+	return err
+}
+
+// Flush envoie ce qui a déjà été écrit : une réponse en streaming est
+// compressée dès son premier vidage
+func (c *compressWriter) Flush() {
+	if !c.wroteHeader {
+		c.WriteHeader(http.StatusOK)
+	}
+	if !c.decided {
+		c.decide(true)
+	}
+	if c.compressor != nil {
+		c.compressor.Flush()
+	}
+	if flusher, ok := c.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Close termine le flux compressé ou envoie en clair une réponse trop courte
+func (c *compressWriter) Close() error {
+	if !c.wroteHeader {
+		// Le handler n'a rien écrit : laisser net/http envoyer 200 sans corps
+		return nil
+	}
+	if !c.decided {
+		return c.decide(false)
+	}
+	if c.compressor == nil {
+		return nil
+	}
+
+	err := c.compressor.Close()
+	switch compressor := c.compressor.(type) {
+	case *gzip.Writer:
+		gzipWriters.Put(compressor)
+	case *flate.Writer:
+		flateWriters.Put(compressor)
+	}
+	c.compressor = nil
+	return err
+}
+
+// Unwrap permet à http.ResponseController d'accéder au writer d'origine
+func (c *compressWriter) Unwrap() http.ResponseWriter {
+	return c.ResponseWriter
+}
@@ -0,0 +1,97 @@
+// filepath: internal/api/middleware/compress_test.go
+
+package middleware
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNegotiateEncoding(t *testing.T) {
+	tests := []struct {
+		header   string
+		expected string
+	}{
+		{"", ""},
+		{"gzip, deflate, br", "gzip"},
+		{"deflate", "deflate"},
+		{"gzip;q=0.5, deflate", "deflate"},
+		{"gzip;q=0, identity", ""},
+		{"*", "gzip"},
+		{"br", ""},
+	}
+	for _, tt := range tests {
+		if got := negotiateEncoding(tt.header); got != tt.expected {
+			t.Errorf("Expected %q for %q, got %q", tt.expected, tt.header, got)
+		}
+	}
+}
+
+func TestCompressStreamsLargeResponses(t *testing.T) {
+	large := strings.Repeat(`{"name":"DB_PASSWORD"},`, 500)
+	handler := Compress(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", `"abc"`)
+		// Écriture en plusieurs morceaux avec vidage, comme une liste en streaming
+		io.WriteString(w, large[:100])
+		w.(http.Flusher).Flush()
+		io.WriteString(w, large[100:])
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Header().Get("Content-Encoding") != "gzip" {
+		t.Fatalf("Expected a gzip response, got headers %v", rec.Header())
+	}
+	if etag := rec.Header().Get("ETag"); etag != `W/"abc"` {
+		t.Errorf("Expected a weak ETag, got %q", etag)
+	}
+	reader, err := gzip.NewReader(rec.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(body) != large {
+		t.Errorf("Expected the decompressed body to match the original")
+	}
+}
+
+func TestCompressSkipsSmallAndBinaryResponses(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+	}{
+		{"small json", "application/json", `{"ok":true}`},
+		{"zip archive", "application/zip", strings.Repeat("PK", 2000)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := Compress(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", tt.contentType)
+				io.WriteString(w, tt.body)
+			}))
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("Accept-Encoding", "gzip, deflate")
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Header().Get("Content-Encoding") != "" {
+				t.Errorf("Expected no compression, got %q", rec.Header().Get("Content-Encoding"))
+			}
+			if rec.Body.String() != tt.body {
+				t.Errorf("Expected the body unchanged")
+			}
+		})
+	}
+}
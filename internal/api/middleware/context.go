@@ -0,0 +1,161 @@
+// filepath: internal/api/middleware/context.go
+
+package middleware
+
+import (
+	"context"
+
+	"secrets-manager/internal/auth"
+	"secrets-manager/internal/models"
+)
+
+type contextKey string
+
+const (
+	userIDKey    contextKey = "userID"
+	principalKey contextKey = "principal"
+	scopesKey    contextKey = "scopes"
+	rolesKey     contextKey = "roles"
+	apiKeyKey    contextKey = "apiKey"
+	sessionKey   contextKey = "session"
+	accountKey   contextKey = "serviceAccount"
+	resourcesKey contextKey = "resources"
+	captchaKey   contextKey = "captcha"
+	// certificateKey porte le compte de service authentifié par certificat client
+	certificateKey contextKey = "clientCertificate"
+	// impersonationKey porte l'usurpation d'identité du token (claim act_as)
+	impersonationKey contextKey = "impersonation"
+)
+
+// Types de principal authentifié
+const (
+	PrincipalUser   = "user"
+	PrincipalAdmin  = "admin"
+	PrincipalClient = "client"
+	PrincipalAPIKey = "api_key"
+	// PrincipalServiceAccount est un compte de service authentifié par son token
+	PrincipalServiceAccount = "service_account"
+)
+
+// Principal identifie l'appelant authentifié d'une requête
+type Principal struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+// String renvoie la forme "type:id" du principal
+func (p Principal) String() string {
+	return p.Type + ":" + p.ID
+}
+
+// WithUserID ajoute l'ID d'un utilisateur authentifié au contexte
+func WithUserID(ctx context.Context, userID string) context.Context {
+	ctx = context.WithValue(ctx, userIDKey, userID)
+	return WithPrincipal(ctx, Principal{Type: PrincipalUser, ID: userID})
+}
+
+// UserIDFromContext renvoie l'ID de l'utilisateur authentifié ("" si absent)
+func UserIDFromContext(ctx context.Context) string {
+	userID, _ := ctx.Value(userIDKey).(string)
+	return userID
+}
+
+// WithSessionID ajoute au contexte la session du token d'accès (claim sid)
+func WithSessionID(ctx context.Context, sessionID string) context.Context {
+	return context.WithValue(ctx, sessionKey, sessionID)
+}
+
+// SessionIDFromContext renvoie la session du token d'accès de la requête
+// ("" pour un token d'accès personnel, une clé d'API ou un token sans session)
+func SessionIDFromContext(ctx context.Context) string {
+	sessionID, _ := ctx.Value(sessionKey).(string)
+	return sessionID
+}
+
+// WithImpersonation signale que la requête est faite par le support au nom
+// de l'utilisateur, sous l'usurpation impersonationID
+func WithImpersonation(ctx context.Context, impersonationID string) context.Context {
+	return context.WithValue(ctx, impersonationKey, impersonationID)
+}
+
+// ImpersonationFromContext renvoie l'usurpation d'identité de la requête
+// ("" si l'utilisateur agit lui-même)
+func ImpersonationFromContext(ctx context.Context) string {
+	impersonationID, _ := ctx.Value(impersonationKey).(string)
+	return impersonationID
+}
+
+// WithPrincipal ajoute le principal authentifié au contexte
+func WithPrincipal(ctx context.Context, principal Principal) context.Context {
+	return context.WithValue(ctx, principalKey, principal)
+}
+
+// PrincipalFromContext renvoie le principal authentifié de la requête
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	principal, ok := ctx.Value(principalKey).(Principal)
+	return principal, ok
+}
+
+// WithTokenScopes restreint la requête aux portées d'un token d'accès personnel
+func WithTokenScopes(ctx context.Context, scopes []string) context.Context {
+	return context.WithValue(ctx, scopesKey, scopes)
+}
+
+// TokenScopesFromContext renvoie les portées du token d'accès personnel de la
+// requête ; false pour une session, qui a toutes les portées
+func TokenScopesFromContext(ctx context.Context) ([]string, bool) {
+	scopes, ok := ctx.Value(scopesKey).([]string)
+	return scopes, ok
+}
+
+// WithAPIKey authentifie la requête par une clé d'API : elle agit au nom de
+// son créateur, avec les portées de sa permission, et le principal est la clé
+func WithAPIKey(ctx context.Context, key *models.APIKey) context.Context {
+	ctx = WithTokenScopes(WithUserID(ctx, key.CreatedBy), key.Scopes())
+	ctx = WithPrincipal(ctx, Principal{Type: PrincipalAPIKey, ID: key.ID})
+	return context.WithValue(ctx, apiKeyKey, key)
+}
+
+// WithTokenResources restreint la requête aux projets d'un token d'accès
+// personnel ; resources vide ne la restreint pas
+func WithTokenResources(ctx context.Context, resources []models.ResourceScope) context.Context {
+	if len(resources) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, resourcesKey, resources)
+}
+
+// APIKeyFromContext renvoie la clé d'API de la requête (nil pour une session
+// ou un token d'accès personnel)
+func APIKeyFromContext(ctx context.Context) *models.APIKey {
+	key, _ := ctx.Value(apiKeyKey).(*models.APIKey)
+	return key
+}
+
+// WithServiceAccount authentifie la requête par le token d'un compte de
+// service : le compte est l'utilisateur de la requête, avec les portées et
+// les ressources de son token
+func WithServiceAccount(ctx context.Context, claims *auth.ServiceAccountClaims) context.Context {
+	ctx = WithTokenScopes(WithUserID(ctx, claims.ServiceAccountID), claims.Scopes)
+	ctx = WithPrincipal(ctx, Principal{Type: PrincipalServiceAccount, ID: claims.ServiceAccountID})
+	return context.WithValue(ctx, accountKey, claims)
+}
+
+// ServiceAccountFromContext renvoie les claims du compte de service de la
+// requête (nil pour tout autre appelant)
+func ServiceAccountFromContext(ctx context.Context) *auth.ServiceAccountClaims {
+	claims, _ := ctx.Value(accountKey).(*auth.ServiceAccountClaims)
+	return claims
+}
+
+// ResourceScopesFromContext renvoie les projets et environnements auxquels
+// la requête est restreinte ; false si elle n'est pas restreinte (seuls les
+// comptes de service et les tokens d'accès personnels limités à des projets
+// le sont)
+func ResourceScopesFromContext(ctx context.Context) ([]models.ResourceScope, bool) {
+	if claims := ServiceAccountFromContext(ctx); claims != nil {
+		return claims.Resources, true
+	}
+	resources, ok := ctx.Value(resourcesKey).([]models.ResourceScope)
+	return resources, ok
+}
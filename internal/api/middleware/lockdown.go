@@ -0,0 +1,53 @@
+// filepath: internal/api/middleware/lockdown.go
+
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"secrets-manager/internal/logging"
+	"secrets-manager/internal/storage"
+)
+
+// Routes qui restent accessibles en écriture pendant un confinement
+const lockdownRoutes = "/organizations/{orgID}/lockdown"
+
+// LockdownFreeze refuse (423) les écritures dans une organisation confinée
+// après une compromission. Les lectures restent possibles pour l'enquête,
+// ainsi que la levée du confinement. Les comptes de service de
+// l'organisation, supprimés par le confinement, sont refusés sur toutes les
+// routes : un token ou un certificat encore en vol ne lit plus rien.
+// lockdowns nil désactive la vérification.
+func LockdownFreeze(lockdowns storage.LockdownsRepository) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			orgID := mux.Vars(r)["orgID"]
+			template := versionPrefix.ReplaceAllString(RouteTemplate(r), "")
+			frozen := !IsReadOnlyRoute(r.Method, template) && !strings.HasPrefix(template, lockdownRoutes)
+			if account := ServiceAccountFromContext(r.Context()); account != nil {
+				orgID, frozen = account.OrganizationID, true
+			}
+			if lockdowns == nil || orgID == "" || !frozen {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			_, err := lockdowns.GetActiveLockdown(r.Context(), orgID)
+			if err == nil {
+				http.Error(w, "Organisation confinée : les modifications sont suspendues", http.StatusLocked)
+				return
+			}
+			if !errors.Is(err, storage.ErrLockdownNotFound) {
+				logging.For(logging.ComponentHTTP).Error("confinement de l'organisation non vérifié",
+					"organization", orgID, "error", err)
+				http.Error(w, "Impossible de vérifier le confinement de l'organisation", http.StatusInternalServerError)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
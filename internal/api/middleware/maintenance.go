@@ -0,0 +1,223 @@
+// filepath: internal/api/middleware/maintenance.go
+
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"secrets-manager/internal/api/apierror"
+)
+
+// Fonctionnalités pouvant être coupées par un interrupteur (voir Feature)
+const (
+	// FeatureExports couvre les exports des revues d'accès et des preuves
+	FeatureExports = "exports"
+	// FeatureWebhooks couvre les événements de test et les rejeux de livraisons
+	FeatureWebhooks = "webhooks"
+	// FeatureGraphQL couvre l'endpoint GraphQL du tableau de bord
+	FeatureGraphQL = "graphql"
+)
+
+// Features sont les fonctionnalités connues des interrupteurs
+var Features = []string{FeatureExports, FeatureWebhooks, FeatureGraphQL}
+
+// ErrInvalidSwitch est renvoyée pour un interrupteur qui ne désigne ni une
+// fonctionnalité connue ni une route
+var ErrInvalidSwitch = errors.New("interrupteur invalide : fonctionnalité inconnue ou route mal formée")
+
+// Préfixe de version des modèles de routes (/api/v1, /api/v2)
+var versionPrefix = regexp.MustCompile(`^/api/v[0-9]+`)
+
+// Switch désactive une fonctionnalité ou une route. Name est une
+// fonctionnalité (ex: exports) ou un modèle de route, précédé ou non d'une
+// méthode, avec ou sans préfixe de version : "/organizations/{orgID}/evidence"
+// coupe la route dans toutes les versions de l'API, "POST /api/v1/auth/register"
+// seulement l'inscription en v1.
+type Switch struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+	// Until est la fin prévue de la coupure (Retry-After) ; nil si elle est inconnue
+	Until    *time.Time `json:"until,omitempty"`
+	Disabled time.Time  `json:"disabled_at"`
+}
+
+// Switches contient les interrupteurs actifs. La lecture, faite à chaque
+// requête, ne prend pas de verrou. Les interrupteurs sont propres au
+// processus : une modification par l'API d'administration doit être
+// appliquée à chaque instance.
+type Switches struct {
+	mu     sync.Mutex // sérialise les modifications
+	active atomic.Pointer[map[string]Switch]
+}
+
+// NewSwitches crée les interrupteurs avec ceux donnés actifs (configuration)
+func NewSwitches(initial ...Switch) (*Switches, error) {
+	s := &Switches{}
+	s.active.Store(&map[string]Switch{})
+	for _, sw := range initial {
+		if err := s.Disable(sw); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// normalizeSwitchName valide le nom d'un interrupteur et uniformise la méthode
+func normalizeSwitchName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if slices.Contains(Features, name) {
+		return name, nil
+	}
+	method, path, ok := strings.Cut(name, " ")
+	if !ok {
+		method, path = "", name
+	}
+	path = strings.TrimSpace(path)
+	if !strings.HasPrefix(path, "/") || strings.ContainsAny(path, " ?") {
+		return "", ErrInvalidSwitch
+	}
+	if method != "" {
+		return strings.ToUpper(method) + " " + path, nil
+	}
+	return path, nil
+}
+
+// Disable active un interrupteur, ou remplace celui de même nom. La date
+// de coupure est celle de l'appel.
+func (s *Switches) Disable(sw Switch) error {
+	name, err := normalizeSwitchName(sw.Name)
+	if err != nil {
+		return err
+	}
+	sw.Name = name
+	sw.Disabled = time.Now().UTC()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	active := make(map[string]Switch, len(*s.active.Load())+1)
+	for k, v := range *s.active.Load() {
+		active[k] = v
+	}
+	active[name] = sw
+	s.active.Store(&active)
+	return nil
+}
+
+// Enable retire un interrupteur ; false s'il n'était pas actif
+func (s *Switches) Enable(name string) bool {
+	name, err := normalizeSwitchName(name)
+	if err != nil {
+		return false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := (*s.active.Load())[name]; !ok {
+		return false
+	}
+	active := make(map[string]Switch, len(*s.active.Load()))
+	for k, v := range *s.active.Load() {
+		if k != name {
+			active[k] = v
+		}
+	}
+	s.active.Store(&active)
+	return true
+}
+
+// List renvoie les interrupteurs actifs triés par nom
+func (s *Switches) List() []Switch {
+	switches := []Switch{}
+	for _, sw := range *s.active.Load() {
+		switches = append(switches, sw)
+	}
+	sort.Slice(switches, func(i, j int) bool { return switches[i].Name < switches[j].Name })
+	return switches
+}
+
+// lookup renvoie le premier interrupteur actif parmi les noms donnés
+func (s *Switches) lookup(names ...string) (Switch, bool) {
+	active := *s.active.Load()
+	if len(active) == 0 {
+		return Switch{}, false
+	}
+	for _, name := range names {
+		if sw, ok := active[name]; ok {
+			return sw, true
+		}
+	}
+	return Switch{}, false
+}
+
+// Maintenance répond 503 aux requêtes dont la route est coupée par un
+// interrupteur. switches nil désactive la vérification.
+func Maintenance(switches *Switches) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if switches == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+			template := RouteTemplate(r)
+			unversioned := versionPrefix.ReplaceAllString(template, "")
+			if sw, ok := switches.lookup(template, r.Method+" "+template,
+				unversioned, r.Method+" "+unversioned); ok {
+				writeSwitched(w, sw)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// Feature répond 503 aux requêtes lorsque la fonctionnalité est coupée.
+// switches nil désactive la vérification.
+func Feature(switches *Switches, feature string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if switches != nil {
+				if sw, ok := switches.lookup(feature); ok {
+					writeSwitched(w, sw)
+					return
+				}
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// writeSwitched explique la coupure à l'appelant
+func writeSwitched(w http.ResponseWriter, sw Switch) {
+	if sw.Until != nil {
+		if wait := time.Until(*sw.Until); wait > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusServiceUnavailable)
+	json.NewEncoder(w).Encode(switchedResponse{
+		Envelope: apierror.Envelope{Error: apierror.EnvelopeError{
+			Status:  http.StatusServiceUnavailable,
+			Code:    "maintenance",
+			Message: "Fonctionnalité temporairement désactivée",
+		}},
+		Switch: sw,
+	})
+}
+
+// switchedResponse est l'enveloppe d'erreur complétée par l'interrupteur en cause
+type switchedResponse struct {
+	apierror.Envelope
+	Switch Switch `json:"switch"`
+}
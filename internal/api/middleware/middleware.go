@@ -3,25 +3,29 @@
 package middleware
 
 import (
-	"context"
-	"log"
+	"crypto/subtle"
+	"errors"
 	"net/http"
 	"runtime/debug"
 	"strings"
 	"time"
 
 	"secrets-manager/internal/auth"
+	"secrets-manager/internal/logging"
+	"secrets-manager/internal/storage"
+	"secrets-manager/internal/vault"
 )
 
 // Logger est un middleware pour journaliser les requêtes
 func Logger(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := logging.For(logging.ComponentHTTP)
 		start := time.Now()
-		log.Printf("Started %s %s", r.Method, r.URL.Path)
+		logger.Debug("started", "method", r.Method, "path", r.URL.Path)
 
 		next.ServeHTTP(w, r)
 
-		log.Printf("Completed %s %s in %v", r.Method, r.URL.Path, time.Since(start))
+		logger.Info("completed", "method", r.Method, "path", r.URL.Path, "duration", time.Since(start))
 	})
 }
 
@@ -30,7 +34,8 @@ func Recover(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		defer func() {
 			if err := recover(); err != nil {
-				log.Printf("Panic recovered: %v\n%s", err, debug.Stack())
+				logging.For(logging.ComponentHTTP).Error("panic recovered",
+					"error", err, "stack", string(debug.Stack()))
 				http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 			}
 		}()
@@ -39,12 +44,92 @@ func Recover(next http.Handler) http.Handler {
 	})
 }
 
-// JWTAuth est un middleware pour l'authentification JWT
-func JWTAuth(authService *auth.Service) func(http.Handler) http.Handler {
+// JWTAuth est un middleware pour l'authentification JWT. Il accepte aussi
+// les tokens d'accès personnels (préfixe smpat_), dont les portées et les
+// projets sont ajoutés au contexte ; tokens nil les refuse. Les tokens des
+// comptes de service ajoutent au contexte leurs portées et leurs ressources
+// (voir ResourceScopesFromContext). Les tokens d'usurpation d'identité
+// ajoutent au contexte leur usurpation (voir ImpersonationFromContext). Les
+// clés d'API sont présentées dans l'en-tête X-API-Key à la place de l'en-tête
+// Authorization ; apiKeys nil les refuse. Les clés à requêtes signées ne
+// sont jamais présentées : leurs requêtes portent l'identifiant de la clé,
+// un horodatage et une signature HMAC (voir APIKeyIDHeader), vérifiée avec
+// la clé de signature que déchiffre transits ; transits nil les refuse. Sur
+// le listener mTLS, le compte de service du certificat client (voir
+// ClientCertificateAuth) tient lieu de token.
+func JWTAuth(
+	authService *auth.Service,
+	tokens storage.PersonalAccessTokensRepository,
+	apiKeys storage.APIKeysRepository,
+	transits *vault.TransitRouter,
+) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			// Extraire le token de l'en-tête Authorization
 			authHeader := r.Header.Get("Authorization")
+
+			// Clé d'API (accès machine à machine), présentée ou signataire
+			rawKey := r.Header.Get(APIKeyHeader)
+			keyID := r.Header.Get(APIKeyIDHeader)
+
+			// Compte de service authentifié par certificat client
+			if account := certificateAccount(r.Context()); account != nil {
+				if authHeader != "" || rawKey != "" || keyID != "" {
+					http.Error(w, "Un seul mode d'authentification par requête", http.StatusUnauthorized)
+					return
+				}
+				next.ServeHTTP(w, r.WithContext(WithServiceAccount(r.Context(), account)))
+				return
+			}
+			if keyID != "" {
+				if authHeader != "" || rawKey != "" {
+					http.Error(w, "Un seul mode d'authentification par requête", http.StatusUnauthorized)
+					return
+				}
+				if apiKeys == nil || transits == nil {
+					http.Error(w, "Signature de la requête invalide", http.StatusUnauthorized)
+					return
+				}
+				key, err := verifySignedRequest(r, apiKeys, transits)
+				if errors.Is(err, errSignedBodyTooLarge) {
+					http.Error(w, "Corps de la requête signée trop volumineux", http.StatusRequestEntityTooLarge)
+					return
+				}
+				if errors.Is(err, ErrInvalidSignature) || errors.Is(err, ErrAPIKeyExpired) ||
+					errors.Is(err, storage.ErrNotFound) {
+					http.Error(w, "Signature de la requête invalide", http.StatusUnauthorized)
+					return
+				}
+				if err != nil {
+					logging.For(logging.ComponentHTTP).Error("signature de la requête non vérifiée",
+						"api_key", keyID, "error", err)
+					http.Error(w, "Impossible de vérifier la signature de la requête", http.StatusInternalServerError)
+					return
+				}
+				next.ServeHTTP(w, r.WithContext(WithAPIKey(r.Context(), key)))
+				return
+			}
+			if rawKey != "" {
+				if authHeader != "" {
+					http.Error(w, "Un seul mode d'authentification par requête", http.StatusUnauthorized)
+					return
+				}
+				if apiKeys == nil {
+					http.Error(w, "Clé d'API invalide", http.StatusUnauthorized)
+					return
+				}
+				key, err := VerifyAPIKey(r.Context(), apiKeys, rawKey)
+				if errors.Is(err, ErrSignatureRequired) {
+					http.Error(w, "Cette clé d'API exige des requêtes signées", http.StatusUnauthorized)
+					return
+				}
+				if err != nil {
+					http.Error(w, "Clé d'API invalide", http.StatusUnauthorized)
+					return
+				}
+				next.ServeHTTP(w, r.WithContext(WithAPIKey(r.Context(), key)))
+				return
+			}
 			if authHeader == "" {
 				http.Error(w, "Autorisation requise", http.StatusUnauthorized)
 				return
@@ -57,15 +142,110 @@ func JWTAuth(authService *auth.Service) func(http.Handler) http.Handler {
 				return
 			}
 
+			// Token d'accès personnel
+			if auth.IsPersonalAccessToken(tokenParts[1]) && tokens != nil {
+				token, err := VerifyPersonalAccessToken(r.Context(), tokens, tokenParts[1])
+				if err != nil {
+					http.Error(w, "Token invalide", http.StatusUnauthorized)
+					return
+				}
+				ctx := WithTokenScopes(WithUserID(r.Context(), token.UserID), token.Scopes)
+				ctx = WithTokenResources(ctx, token.Resources)
+				next.ServeHTTP(w, r.WithContext(ctx))
+				return
+			}
+
 			// Vérifier le token
-			userID, err := authService.VerifyToken(tokenParts[1])
+			claims, err := authService.VerifyAccessToken(tokenParts[1])
+			if errors.Is(err, auth.ErrInvalidToken) {
+				// Token d'un compte de service
+				if account, err := authService.VerifyServiceAccountToken(tokenParts[1]); err == nil {
+					serveServiceAccount(w, r, next, authService, account)
+					return
+				}
+			}
 			if err != nil {
 				http.Error(w, "Token invalide", http.StatusUnauthorized)
 				return
 			}
+			// Le token ne doit pas avoir été révoqué, et un utilisateur inscrit
+			// doit avoir présenté un code TOTP
+			if err := authService.CheckSession(r.Context(), claims); err != nil {
+				if errors.Is(err, auth.ErrMFARequired) {
+					http.Error(w, "Authentification multifacteur requise", http.StatusUnauthorized)
+					return
+				}
+				if errors.Is(err, auth.ErrTokenRevoked) || errors.Is(err, auth.ErrInvalidToken) {
+					http.Error(w, "Token invalide", http.StatusUnauthorized)
+					return
+				}
+				http.Error(w, "Impossible de vérifier la session", http.StatusInternalServerError)
+				return
+			}
+
+			// Ajouter l'ID utilisateur, sa session et ses rôles embarqués au contexte
+			ctx := WithUserID(r.Context(), claims.UserID)
+			if claims.SessionID != "" {
+				ctx = WithSessionID(ctx, claims.SessionID)
+			}
+			if claims.Roles != nil {
+				ctx = WithRoleClaims(ctx, claims.Roles, claims.IssuedAt)
+			}
+			if claims.ImpersonationID != "" {
+				ctx = WithImpersonation(ctx, claims.ImpersonationID)
+			}
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// serveServiceAccount poursuit la requête d'un compte de service si le
+// compte existe toujours
+func serveServiceAccount(w http.ResponseWriter, r *http.Request, next http.Handler, authService *auth.Service,
+	account *auth.ServiceAccountClaims) {
+	if err := authService.CheckServiceAccount(r.Context(), account); err != nil {
+		if errors.Is(err, auth.ErrTokenRevoked) || errors.Is(err, auth.ErrInvalidToken) {
+			http.Error(w, "Token invalide", http.StatusUnauthorized)
+			return
+		}
+		http.Error(w, "Impossible de vérifier le compte de service", http.StatusInternalServerError)
+		return
+	}
+	next.ServeHTTP(w, r.WithContext(WithServiceAccount(r.Context(), account)))
+}
+
+// ClientCredentials est un middleware exigeant l'authentification HTTP Basic
+// d'un service client (identifiant et secret). Aucun client ne désactive la
+// route : toutes les requêtes sont refusées.
+func ClientCredentials(clients map[string]string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id, secret, ok := r.BasicAuth()
+			expected, known := clients[id]
+			if !ok || !known || subtle.ConstantTimeCompare([]byte(secret), []byte(expected)) != 1 {
+				w.Header().Set("WWW-Authenticate", `Basic realm="secrets-manager"`)
+				http.Error(w, "Client non authentifié", http.StatusUnauthorized)
+				return
+			}
+
+			ctx := WithPrincipal(r.Context(), Principal{Type: PrincipalClient, ID: id})
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// StaticToken est un middleware exigeant un Bearer token statique. Un token
+// vide refuse toutes les requêtes.
+func StaticToken(token string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			provided, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if token == "" || !ok || subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+				http.Error(w, "Autorisation requise", http.StatusUnauthorized)
+				return
+			}
 
-			// Ajouter l'ID utilisateur au contexte
-			ctx := context.WithValue(r.Context(), "userID", userID)
+			ctx := WithPrincipal(r.Context(), Principal{Type: PrincipalAdmin, ID: "token"})
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
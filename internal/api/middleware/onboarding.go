@@ -0,0 +1,35 @@
+// filepath: internal/api/middleware/onboarding.go
+
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"secrets-manager/internal/logging"
+	"secrets-manager/internal/onboarding"
+)
+
+// CompletesOnboarding signale au tracker, après une réponse 201, l'étape
+// de prise en main franchie par l'utilisateur dans l'organisation {orgID}
+// de la route. Un échec est journalisé sans modifier la réponse.
+func CompletesOnboarding(tracker *onboarding.Tracker, step string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rec := newStatusRecorder(w)
+			next.ServeHTTP(rec, r)
+
+			orgID := mux.Vars(r)["orgID"]
+			if rec.status != http.StatusCreated || orgID == "" {
+				return
+			}
+			userID := UserIDFromContext(r.Context())
+			if err := tracker.Complete(context.WithoutCancel(r.Context()), step, orgID, userID); err != nil {
+				logging.For(logging.ComponentHTTP).Warn("échec de l'enregistrement de la prise en main",
+					"organization_id", orgID, "step", step, "error", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+// filepath: internal/api/middleware/rbac.go
+
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"secrets-manager/internal/api/apierror"
+	"secrets-manager/internal/auth"
+)
+
+// RequireRole réserve les modifications aux membres ayant au moins le rôle
+// role dans l'organisation de la route (403 sinon) ; les lectures restent
+// ouvertes à tous ses membres, lecteurs (viewer) compris. Les non-membres
+// reçoivent 404 pour ne pas révéler l'existence de l'organisation. Sur les
+// routes d'un projet ou d'un environnement, les rôles accordés aux équipes
+// de l'utilisateur s'ajoutent à son rôle. Les clés d'API, déjà restreintes
+// par RestrictAPIKeys, ne sont pas concernées.
+func RequireRole(permissions *auth.PermissionService, role string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			vars := mux.Vars(r)
+			orgID := vars["orgID"]
+			if orgID == "" || APIKeyFromContext(r.Context()) != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			required := auth.RoleViewer
+			if !IsReadOnlyRoute(r.Method, RouteTemplate(r)) {
+				required = role
+			}
+			err := permissions.AuthorizeResource(r.Context(), UserIDFromContext(r.Context()), orgID,
+				vars["projectID"], vars["env"], required)
+			if err != nil {
+				apierror.Write(w, err, "Impossible de vérifier le rôle dans l'organisation")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
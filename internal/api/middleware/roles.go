@@ -0,0 +1,81 @@
+// filepath: internal/api/middleware/roles.go
+
+package middleware
+
+import (
+	"context"
+	"time"
+
+	"secrets-manager/internal/events"
+	"secrets-manager/internal/storage"
+)
+
+// Durée pendant laquelle les rôles embarqués dans un token d'accès sont
+// utilisés sans être relus en base
+const claimedRolesMaxAge = 5 * time.Minute
+
+// roleClaims sont les rôles embarqués dans le token d'accès de la requête
+type roleClaims struct {
+	roles    map[string]string
+	issuedAt time.Time
+}
+
+// WithRoleClaims ajoute au contexte les rôles embarqués dans le token
+// d'accès de l'utilisateur, émis à issuedAt
+func WithRoleClaims(ctx context.Context, roles map[string]string, issuedAt time.Time) context.Context {
+	return context.WithValue(ctx, rolesKey, roleClaims{roles: roles, issuedAt: issuedAt})
+}
+
+// ClaimedRoles lit les rôles des utilisateurs dans le token d'accès de la
+// requête plutôt qu'en base. Un rôle embarqué n'est utilisé que si le token a
+// moins de claimedRolesMaxAge et que les membres de l'organisation n'ont pas
+// été modifiés depuis son émission (modifications publiées sur le bus des
+// validateurs, donc locales au processus). Dans tous les autres cas (token
+// d'accès personnel, organisation absente du token, claim omis), le rôle est
+// lu en base : un ajout est visible immédiatement, un retrait au plus tard
+// après claimedRolesMaxAge.
+type ClaimedRoles struct {
+	storage.UsersRepository
+	validators *CacheValidators
+}
+
+// NewClaimedRoles enveloppe le repository des utilisateurs
+func NewClaimedRoles(users storage.UsersRepository, validators *CacheValidators) *ClaimedRoles {
+	return &ClaimedRoles{
+		UsersRepository: users,
+		validators:      validators,
+	}
+}
+
+// GetUserRole renvoie le rôle embarqué dans le token s'il est encore fiable,
+// sinon celui enregistré en base
+func (c *ClaimedRoles) GetUserRole(ctx context.Context, userID, orgID string) (string, error) {
+	// Un compte de service n'est pas membre : son rôle découle de son token
+	if account := ServiceAccountFromContext(ctx); account != nil && account.ServiceAccountID == userID {
+		if role := account.Role(orgID); role != "" {
+			return role, nil
+		}
+		return "", storage.ErrUserNotFound
+	}
+	if role, ok := c.claimedRole(ctx, userID, orgID); ok {
+		return role, nil
+	}
+	return c.UsersRepository.GetUserRole(ctx, userID, orgID)
+}
+
+func (c *ClaimedRoles) claimedRole(ctx context.Context, userID, orgID string) (string, bool) {
+	claims, ok := ctx.Value(rolesKey).(roleClaims)
+	if !ok || UserIDFromContext(ctx) != userID || time.Since(claims.issuedAt) >= claimedRolesMaxAge {
+		return "", false
+	}
+	role, ok := claims.roles[orgID]
+	if !ok {
+		return "", false
+	}
+	// iat est à la seconde : un token émis dans la même seconde qu'une
+	// modification est considéré comme antérieur
+	if !claims.issuedAt.After(c.validators.LastModified(orgID, events.ResourceOrganization)) {
+		return "", false
+	}
+	return role, true
+}
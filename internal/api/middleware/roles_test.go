@@ -0,0 +1,47 @@
+// filepath: internal/api/middleware/roles_test.go
+
+package middleware
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"secrets-manager/internal/events"
+	"secrets-manager/internal/storage/memory"
+)
+
+func TestClaimedRolesFallBackToStorage(t *testing.T) {
+	db := memory.NewDB()
+	users := memory.NewUsersRepository(db)
+	bus := events.NewBus()
+	validators := NewCacheValidators(bus)
+	validators.started = time.Now().Add(-time.Hour)
+	roles := NewClaimedRoles(users, validators)
+
+	issuedAt := time.Now().Add(-time.Minute)
+	ctx := WithRoleClaims(WithUserID(context.Background(), "user-1"), map[string]string{"org-1": "admin"}, issuedAt)
+
+	// Le rôle embarqué évite la lecture en base
+	if role, err := roles.GetUserRole(ctx, "user-1", "org-1"); err != nil || role != "admin" {
+		t.Errorf("Expected the claimed role, got %q (%v)", role, err)
+	}
+	// Une organisation absente du token, un autre utilisateur ou un token
+	// trop ancien sont lus en base
+	if _, err := roles.GetUserRole(ctx, "user-1", "org-2"); err == nil {
+		t.Error("Expected an unclaimed organization to be read from storage")
+	}
+	if _, err := roles.GetUserRole(ctx, "user-2", "org-1"); err == nil {
+		t.Error("Expected another user's role to be read from storage")
+	}
+	stale := WithRoleClaims(ctx, map[string]string{"org-1": "admin"}, time.Now().Add(-claimedRolesMaxAge))
+	if _, err := roles.GetUserRole(stale, "user-1", "org-1"); err == nil {
+		t.Error("Expected an old token's roles to be read from storage")
+	}
+
+	// Une modification des membres de l'organisation invalide les rôles embarqués
+	bus.Publish(events.Change{OrganizationID: "org-1", Resource: events.ResourceOrganization})
+	if _, err := roles.GetUserRole(ctx, "user-1", "org-1"); err == nil {
+		t.Error("Expected the claimed role to be ignored after a membership change")
+	}
+}
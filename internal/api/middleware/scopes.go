@@ -0,0 +1,157 @@
+// filepath: internal/api/middleware/scopes.go
+
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"secrets-manager/internal/auth"
+	"secrets-manager/internal/logging"
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// Intervalle minimal entre deux enregistrements de la dernière utilisation d'un token
+const tokenTouchInterval = time.Minute
+
+// ErrTokenExpired indique qu'un token d'accès personnel a expiré
+var ErrTokenExpired = errors.New("token d'accès personnel expiré")
+
+// Routes réservées aux sessions : un token d'accès personnel ou
+// d'usurpation d'identité ne peut ni créer d'autres tokens, ni approuver la
+// connexion d'un appareil, ni modifier l'authentification multifacteur, les
+// appareils de confiance, les sessions ou les usurpations
+var sessionOnlyRoutes = []string{"/me/tokens", "/auth/device:", "/auth/mfa/", "/me/devices", "/auth/sessions",
+	"/me/impersonations"}
+
+// Routes des secrets qui n'exposent pas de valeur
+var metadataSuffixes = []string{"/metadata", ":metadata", "/consumers", "/rotate:dry-run", "/rotator", "/scheduled"}
+
+// Routes POST en lecture seule
+var readOnlyPostRoutes = []string{
+	"/graphql",
+	"/organizations/{orgID}/projects/{projectID}/environments/{env}/secrets/{name}/rotate:dry-run",
+	"/organizations/{orgID}/projects/{projectID}/data-keys/{name}:decrypt",
+}
+
+// Routes hors secrets qui manipulent des contenus en clair, soumises aux
+// mêmes portées que les valeurs des secrets
+var valueRoutes = []string{
+	"/organizations/{orgID}/projects/{projectID}/data-keys/{name}:encrypt",
+	"/organizations/{orgID}/projects/{projectID}/data-keys/{name}:decrypt",
+	"/organizations/{orgID}/projects/{projectID}/age-keys/{repository}/identities",
+	"/lookup",
+}
+
+// VerifyPersonalAccessToken renvoie le token d'accès personnel valide
+// correspondant à raw et enregistre son utilisation
+func VerifyPersonalAccessToken(ctx context.Context, tokens storage.PersonalAccessTokensRepository, raw string) (*models.PersonalAccessToken, error) {
+	token, err := tokens.GetPersonalAccessTokenByHash(ctx, auth.HashPersonalAccessToken(raw))
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if !now.Before(token.ExpiresAt) {
+		return nil, ErrTokenExpired
+	}
+
+	if token.LastUsedAt == nil || now.Sub(*token.LastUsedAt) >= tokenTouchInterval {
+		if err := tokens.TouchPersonalAccessToken(ctx, token.ID, now); err != nil {
+			logging.For(logging.ComponentHTTP).Warn("date d'utilisation du token non enregistrée",
+				"token_id", token.ID, "error", err)
+		}
+	}
+	return token, nil
+}
+
+// RequiredScope renvoie la portée nécessaire à une requête, d'après sa
+// méthode et le modèle de sa route : les routes des secrets (hors
+// métadonnées, consommateurs, simulation de rotation, rotateur et changement
+// planifié), le chiffrement avec les clés de données, les identités age et
+// le lookup demandent secrets:read ou secrets:write, les autres
+// metadata:read ou metadata:write
+func RequiredScope(r *http.Request) string {
+	template := versionPrefix.ReplaceAllString(RouteTemplate(r), "")
+	read := IsReadOnlyRoute(r.Method, template)
+	values := strings.Contains(template, "/secrets") && !slices.ContainsFunc(metadataSuffixes, func(suffix string) bool {
+		return strings.HasSuffix(template, suffix)
+	}) || slices.Contains(valueRoutes, template)
+
+	switch {
+	case values && read:
+		return models.ScopeSecretsRead
+	case values:
+		return models.ScopeSecretsWrite
+	case read:
+		return models.ScopeMetadataRead
+	default:
+		return models.ScopeMetadataWrite
+	}
+}
+
+// IsReadOnlyRoute indique si une requête de cette méthode sur la route
+// (modèle avec ou sans préfixe de version) ne modifie rien : GET, HEAD et
+// les routes POST en lecture seule
+func IsReadOnlyRoute(method, template string) bool {
+	template = versionPrefix.ReplaceAllString(template, "")
+	return method == http.MethodGet || method == http.MethodHead || slices.Contains(readOnlyPostRoutes, template)
+}
+
+// RequireTokenScopes refuse (403) les requêtes authentifiées par un token
+// d'accès personnel qui n'a pas la portée nécessaire, qui visent une route
+// réservée aux sessions, ou un projet ou un environnement hors des projets
+// du token, ainsi que celles d'une usurpation d'identité sur une route
+// réservée aux sessions. Les sessions ne sont pas restreintes.
+func RequireTokenScopes(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		template := versionPrefix.ReplaceAllString(RouteTemplate(r), "")
+		sessionOnly := slices.ContainsFunc(sessionOnlyRoutes, func(prefix string) bool {
+			return strings.HasPrefix(template, prefix)
+		})
+		if sessionOnly && ImpersonationFromContext(r.Context()) != "" {
+			http.Error(w, "Route non accessible pendant une usurpation d'identité", http.StatusForbidden)
+			return
+		}
+
+		scopes, ok := TokenScopesFromContext(r.Context())
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if sessionOnly {
+			http.Error(w, "Route non accessible avec un token d'accès personnel", http.StatusForbidden)
+			return
+		}
+		if scope := RequiredScope(r); !slices.Contains(scopes, scope) {
+			http.Error(w, "Portée du token insuffisante : "+scope+" requise", http.StatusForbidden)
+			return
+		}
+		if resources, ok := r.Context().Value(resourcesKey).([]models.ResourceScope); ok &&
+			!coversRoute(resources, mux.Vars(r)) {
+			http.Error(w, "Projet hors de la portée du token", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// coversRoute indique si les projets d'un token couvrent le projet (et
+// l'environnement) de la route ; les routes hors projet ne sont pas
+// restreintes (la liste des projets est filtrée par son gestionnaire)
+func coversRoute(resources []models.ResourceScope, vars map[string]string) bool {
+	projectID, ok := vars["projectID"]
+	if !ok {
+		return true
+	}
+	if env, ok := vars["env"]; ok {
+		return models.CoversEnvironment(resources, projectID, env)
+	}
+	return models.CoversProject(resources, projectID)
+}
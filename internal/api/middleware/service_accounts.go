@@ -0,0 +1,76 @@
+// filepath: internal/api/middleware/service_accounts.go
+
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"secrets-manager/internal/auth"
+	"secrets-manager/internal/logging"
+	"secrets-manager/internal/models"
+)
+
+// ClientCertificateAuth authentifie les requêtes du listener mTLS par le
+// certificat client de leur connexion : il doit être signé par une autorité
+// de l'organisation propriétaire du domaine de confiance de son identifiant
+// SPIFFE, associé à l'un de ses comptes de service (401 sinon). JWTAuth
+// accepte ensuite ces requêtes comme celles d'un token du compte, sans
+// en-tête d'authentification.
+func ClientCertificateAuth(authService *auth.Service) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.TLS == nil || len(r.TLS.PeerCertificates) == 0 {
+				http.Error(w, "Certificat client requis", http.StatusUnauthorized)
+				return
+			}
+			account, err := authService.AuthenticateClientCertificate(r.Context(), r.TLS.PeerCertificates)
+			if errors.Is(err, auth.ErrInvalidCredentials) {
+				http.Error(w, "Certificat client non associé à un compte de service", http.StatusUnauthorized)
+				return
+			}
+			if err != nil {
+				logging.For(logging.ComponentHTTP).Error("authentification par certificat client impossible",
+					"error", err)
+				http.Error(w, "Impossible de vérifier le certificat client", http.StatusInternalServerError)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), certificateKey, account)))
+		})
+	}
+}
+
+// certificateAccount renvoie le compte de service authentifié par
+// certificat client (nil hors du listener mTLS)
+func certificateAccount(ctx context.Context) *auth.ServiceAccountClaims {
+	account, _ := ctx.Value(certificateKey).(*auth.ServiceAccountClaims)
+	return account
+}
+
+// RestrictServiceAccounts refuse (403) les requêtes d'un compte de service
+// hors des routes des secrets, du lookup et des métriques, ou visant une
+// organisation, un projet ou un environnement hors des ressources de son
+// token. Comme pour les clés
+// d'API, les portées de sa permission sont vérifiées par RequireTokenScopes.
+func RestrictServiceAccounts(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		account := ServiceAccountFromContext(r.Context())
+		if account == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		orgID, projectID, env, ok := machineTarget(r)
+		if !ok {
+			http.Error(w, "Route non accessible avec un compte de service", http.StatusForbidden)
+			return
+		}
+		if orgID == "" || orgID != account.OrganizationID ||
+			(projectID != "" && !models.CoversEnvironment(account.Resources, projectID, env)) {
+			http.Error(w, "Hors de la portée du compte de service", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
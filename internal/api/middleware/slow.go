@@ -0,0 +1,61 @@
+// filepath: internal/api/middleware/slow.go
+
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"secrets-manager/internal/logging"
+	"secrets-manager/internal/metrics"
+)
+
+var slowRequestsTotal = metrics.NewCounter("http_slow_requests_total",
+	"Nombre de requêtes HTTP ayant dépassé le seuil de latence", "route")
+
+// Routes dont les requêtes avec le paramètre wait attendent une modification
+// (attente longue) : leur durée ne traduit pas une lenteur
+var longPollRoutes = []string{"/lookup"}
+
+// SlowRequests est un middleware qui journalise et comptabilise les requêtes
+// dépassant le seuil de latence. Il ajoute aussi la route et l'organisation
+// aux attributs de log du contexte, repris par les logs SQL et Vault.
+// Un seuil nul désactive la détection.
+func SlowRequests(threshold time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			route := r.Method + " " + RouteTemplate(r)
+			orgID := mux.Vars(r)["orgID"]
+
+			ctx := logging.WithAttrs(r.Context(),
+				slog.String("route", route),
+				slog.String("organization_id", orgID))
+
+			recorder := newStatusRecorder(w)
+			start := time.Now()
+
+			next.ServeHTTP(recorder, r.WithContext(ctx))
+
+			duration := time.Since(start)
+			if threshold <= 0 || duration < threshold {
+				return
+			}
+			if r.URL.Query().Has("wait") &&
+				slices.Contains(longPollRoutes, versionPrefix.ReplaceAllString(RouteTemplate(r), "")) {
+				return
+			}
+
+			slowRequestsTotal.Inc(route)
+			logging.For(logging.ComponentHTTP).Warn("requête lente",
+				"route", route,
+				"organization_id", orgID,
+				"status", recorder.status,
+				"duration", duration,
+				"threshold", threshold)
+		})
+	}
+}
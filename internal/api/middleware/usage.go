@@ -0,0 +1,55 @@
+// filepath: internal/api/middleware/usage.go
+
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"secrets-manager/internal/logging"
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// UsageTracking est un middleware qui attribue chaque appel API sur une
+// route d'organisation au principal authentifié. Il doit être placé après
+// l'authentification.
+func UsageTracking(usage storage.UsageRepository) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r)
+
+			orgID := mux.Vars(r)["orgID"]
+			principal, ok := PrincipalFromContext(r.Context())
+			if orgID == "" || !ok {
+				return
+			}
+
+			call := &models.APICall{
+				OrganizationID: orgID,
+				PrincipalType:  principal.Type,
+				PrincipalID:    principal.ID,
+				Route:          r.Method + " " + RouteTemplate(r),
+				Timestamp:      time.Now(),
+			}
+			if err := usage.RecordAPICall(context.WithoutCancel(r.Context()), call); err != nil {
+				logging.For(logging.ComponentHTTP).Warn("échec de l'enregistrement de l'usage",
+					"organization_id", orgID, "error", err)
+			}
+		})
+	}
+}
+
+// RouteTemplate renvoie le modèle de la route correspondant à la requête
+// (ex: /api/v1/organizations/{orgID}/...), ou le chemin brut à défaut
+func RouteTemplate(r *http.Request) string {
+	if route := mux.CurrentRoute(r); route != nil {
+		if template, err := route.GetPathTemplate(); err == nil {
+			return template
+		}
+	}
+	return r.URL.Path
+}
@@ -0,0 +1,69 @@
+// filepath: internal/api/notifications_test.go
+
+package api_test
+
+import (
+	"net/http"
+	"testing"
+
+	"secrets-manager/internal/api/apitest"
+	"secrets-manager/internal/models"
+)
+
+func TestNotificationPreferences(t *testing.T) {
+	srv := apitest.NewServer(t)
+	srv.Register("owner@example.com", "password123")
+	token := srv.Login("owner@example.com", "password123")
+
+	url := "/api/v1/users/me/notification-preferences"
+
+	// Par défaut : tous les événements par email
+	resp := srv.Do(http.MethodGet, url, token, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var prefs models.NotificationPreferences
+	apitest.DecodeJSON(t, resp, &prefs)
+	for _, eventType := range models.NotificationEventTypes {
+		if channels := prefs.Events[eventType]; !channels.Email || channels.Slack {
+			t.Errorf("Expected email only by default for %s, got %+v", eventType, channels)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		prefs    models.NotificationPreferences
+		expected int
+	}{
+		{"unknown event", models.NotificationPreferences{
+			Events: map[string]models.NotificationChannels{"weather": {Email: true}},
+		}, http.StatusBadRequest},
+		{"slack without webhook", models.NotificationPreferences{
+			Events: map[string]models.NotificationChannels{models.NotificationQuotaAlert: {Slack: true}},
+		}, http.StatusBadRequest},
+		{"plain http webhook", models.NotificationPreferences{
+			SlackWebhookURL: "http://hooks.example.com/x",
+		}, http.StatusBadRequest},
+		{"valid", models.NotificationPreferences{
+			SlackWebhookURL: "https://hooks.slack.com/services/T/B/X",
+			Events: map[string]models.NotificationChannels{
+				models.NotificationSecretChangedProduction: {Slack: true},
+			},
+		}, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := srv.Do(http.MethodPut, url, token, tt.prefs)
+			apitest.ExpectStatus(t, resp, tt.expected)
+		})
+	}
+
+	resp = srv.Do(http.MethodGet, url, token, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	apitest.DecodeJSON(t, resp, &prefs)
+	if channels := prefs.Events[models.NotificationSecretChangedProduction]; channels.Email || !channels.Slack {
+		t.Errorf("Expected Slack only for production changes, got %+v", channels)
+	}
+	if channels := prefs.Events[models.NotificationQuotaAlert]; !channels.Email {
+		t.Errorf("Expected default channels for unset events, got %+v", channels)
+	}
+}
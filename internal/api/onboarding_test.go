@@ -0,0 +1,106 @@
+// filepath: internal/api/onboarding_test.go
+
+package api_test
+
+import (
+	"net/http"
+	"regexp"
+	"sync"
+	"testing"
+
+	"secrets-manager/internal/api/apitest"
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/onboarding"
+)
+
+var verificationToken = regexp.MustCompile(`token=([A-Za-z0-9_.-]+)`)
+
+func TestOnboarding(t *testing.T) {
+	srv := apitest.NewServer(t)
+	ownerID := srv.Register("owner@example.com", "password123")
+	owner := srv.Login("owner@example.com", "password123")
+	memberID := srv.Register("member@example.com", "password123")
+	member := srv.Login("member@example.com", "password123")
+	srv.Register("outsider@example.com", "password123")
+	outsider := srv.Login("outsider@example.com", "password123")
+	org := srv.CreateOrganization("acme", ownerID)
+	srv.AddMember(org.ID, memberID, "member")
+	path := "/api/v1/organizations/" + org.ID + "/onboarding"
+
+	var mu sync.Mutex
+	var published []onboarding.Event
+	srv.Onboarding.Subscribe(func(event onboarding.Event) {
+		mu.Lock()
+		defer mu.Unlock()
+		published = append(published, event)
+	})
+	state := func(token string) *models.Onboarding {
+		t.Helper()
+		resp := srv.Do(http.MethodGet, path, token, nil)
+		apitest.ExpectStatus(t, resp, http.StatusOK)
+		var onboarding models.Onboarding
+		apitest.DecodeJSON(t, resp, &onboarding)
+		return &onboarding
+	}
+
+	// Aucune étape franchie : l'adresse email est la première proposée
+	got := state(owner)
+	if got.State != models.OnboardingEmailVerified || len(got.Steps) != len(models.OnboardingSteps) ||
+		got.Steps[0].Completed || got.CompletedAt != nil {
+		t.Fatalf("Expected a fresh onboarding, got %+v", got)
+	}
+	apitest.ExpectStatus(t, srv.Do(http.MethodGet, path, outsider, nil), http.StatusNotFound)
+
+	// Confirmation de l'adresse par le lien reçu par email
+	resp := srv.Do(http.MethodPost, "/api/v1/me/email-verification", owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusAccepted)
+	mails := srv.Outbox.Mails()
+	match := verificationToken.FindStringSubmatch(mails[len(mails)-1].Body)
+	if mails[len(mails)-1].To != "owner@example.com" || match == nil {
+		t.Fatalf("Expected a verification link sent to the owner, got %+v", mails[len(mails)-1])
+	}
+	verify := func(token string) *http.Response {
+		return srv.Do(http.MethodPost, "/api/v1/auth/email:verify", "", map[string]string{"token": token})
+	}
+	apitest.ExpectStatus(t, verify(owner), http.StatusUnauthorized)
+	apitest.ExpectStatus(t, verify(match[1]), http.StatusNoContent)
+	apitest.ExpectStatus(t, verify(match[1]), http.StatusNoContent)
+	if got = state(owner); got.State != models.OnboardingProjectCreated || got.Steps[0].CompletedBy != ownerID {
+		t.Fatalf("Expected the email to be verified, got %+v", got)
+	}
+
+	// Les étapes d'organisation sont partagées, l'adresse reste propre à chacun
+	project := srv.CreateProject(org.ID, "api", ownerID)
+	resp = srv.Do(http.MethodPost, "/api/v1/organizations/"+org.ID+"/projects/"+project.ID+"/environments/dev/secrets",
+		member, models.Secret{Name: "API_KEY", Value: "abc123"})
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+	got = state(member)
+	if got.State != models.OnboardingEmailVerified || !got.Steps[1].Completed || !got.Steps[2].Completed ||
+		got.Steps[2].CompletedBy != memberID {
+		t.Fatalf("Expected shared organization steps, got %+v", got)
+	}
+
+	resp = srv.Do(http.MethodPost, "/api/v1/organizations/"+org.ID+"/invitations", owner,
+		map[string]string{"email": "carol@example.com", "role": "member"})
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+	if got = state(owner); got.State != models.OnboardingCompleted || got.CompletedAt == nil {
+		t.Fatalf("Expected a completed onboarding, got %+v", got)
+	}
+
+	// Chaque étape n'est publiée qu'une fois ; le projet créé avant d'être
+	// suivi est rattrapé sans être publié
+	mu.Lock()
+	defer mu.Unlock()
+	steps := []string{models.OnboardingEmailVerified, models.OnboardingSecretStored, models.OnboardingTeammateInvited}
+	if len(published) != len(steps) {
+		t.Fatalf("Expected %d published steps, got %+v", len(steps), published)
+	}
+	for i, step := range steps {
+		if published[i].Step != step || published[i].OrganizationID != org.ID {
+			t.Errorf("Expected step %s for %s, got %+v", step, org.ID, published[i])
+		}
+	}
+	if last := published[len(published)-1]; last.UserID != ownerID || last.Onboarding.State != models.OnboardingCompleted {
+		t.Errorf("Expected the last step to complete the owner's onboarding, got %+v", last)
+	}
+}
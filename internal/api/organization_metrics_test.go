@@ -0,0 +1,65 @@
+// filepath: internal/api/organization_metrics_test.go
+
+package api_test
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"secrets-manager/internal/api/apitest"
+	"secrets-manager/internal/api/handlers"
+	"secrets-manager/internal/models"
+)
+
+func TestOrganizationMetrics(t *testing.T) {
+	srv := apitest.NewServer(t)
+	ownerID := srv.Register("owner@example.com", "password123")
+	owner := srv.Login("owner@example.com", "password123")
+	srv.Register("outsider@example.com", "password123")
+	outsider := srv.Login("outsider@example.com", "password123")
+	org := srv.CreateOrganization("acme", ownerID)
+	project := srv.CreateProject(org.ID, "api", ownerID)
+
+	secrets := "/api/v1/organizations/" + org.ID + "/projects/" + project.ID + "/environments/prod/secrets"
+	for _, name := range []string{"DB_PASSWORD", "API_KEY"} {
+		resp := srv.Do(http.MethodPost, secrets, owner, models.Secret{Name: name, Value: "v1"})
+		apitest.ExpectStatus(t, resp, http.StatusCreated)
+	}
+	resp := srv.Do(http.MethodGet, secrets+"/DB_PASSWORD", owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	resp.Body.Close()
+
+	// Le scraper utilise un token de lecture des métadonnées
+	resp = srv.Do(http.MethodPost, "/api/v1/me/tokens", owner, map[string]any{
+		"name": "prometheus", "scopes": []string{models.ScopeMetadataRead}})
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+	var token handlers.CreatedToken
+	apitest.DecodeJSON(t, resp, &token)
+
+	metrics := "/api/v1/organizations/" + org.ID + "/metrics"
+	resp = srv.Do(http.MethodGet, metrics, token.Token, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	if contentType := resp.Header.Get("Content-Type"); !strings.HasPrefix(contentType, "text/plain") {
+		t.Errorf("Expected the Prometheus text format, got %s", contentType)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	labels := `{project_id="` + project.ID + `",project="api",environment="prod"}`
+	for _, expected := range []string{
+		"# TYPE secrets_manager_org_secrets gauge\n",
+		"secrets_manager_org_secrets" + labels + " 2\n",
+		"secrets_manager_org_secret_reads_today" + labels + " 1\n",
+		"# TYPE secrets_manager_org_secret_age_seconds summary\n",
+		`secrets_manager_org_secret_age_seconds{quantile="0.5"} `,
+		"secrets_manager_org_secret_age_seconds_count 2\n",
+	} {
+		if !strings.Contains(string(body), expected) {
+			t.Errorf("Expected the metrics to contain %q, got:\n%s", expected, body)
+		}
+	}
+
+	resp = srv.Do(http.MethodGet, metrics, outsider, nil)
+	apitest.ExpectStatus(t, resp, http.StatusNotFound)
+}
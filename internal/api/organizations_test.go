@@ -0,0 +1,78 @@
+// filepath: internal/api/organizations_test.go
+
+package api_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"secrets-manager/internal/api/apitest"
+	"secrets-manager/internal/models"
+)
+
+func TestCreateOrganization(t *testing.T) {
+	srv := apitest.NewServer(t)
+	userID := srv.Register("alice@example.com", "password123")
+	token := srv.Login("alice@example.com", "password123")
+	ctx := context.Background()
+
+	resp := srv.Do(http.MethodPost, "/api/v1/organizations", token, map[string]string{"name": " acme "})
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+	var provision models.OrganizationProvision
+	apitest.DecodeJSON(t, resp, &provision)
+
+	org := provision.Organization
+	if org == nil || org.ID == "" || org.Name != "acme" || org.OwnerID != userID || org.PlanID != "trial" {
+		t.Fatalf("Expected an organization owned by the caller, got %+v", org)
+	}
+	if role, err := srv.Users.GetUserRole(ctx, userID, org.ID); err != nil || role != "admin" {
+		t.Errorf("Expected the creator to be admin, got %q (%v)", role, err)
+	}
+
+	// Abonnement d'essai actif, dont la limite s'applique aux secrets
+	subscription := provision.Subscription
+	if subscription == nil || subscription.OrganizationID != org.ID || subscription.Status != "active" ||
+		subscription.EndDate.Sub(subscription.StartDate) != 14*24*time.Hour {
+		t.Fatalf("Expected a 14 days trial subscription, got %+v", subscription)
+	}
+	if limit, err := srv.Secrets.GetSecretsLimit(ctx, org.ID); err != nil || limit != 100 {
+		t.Errorf("Expected a trial limit of 100 secrets, got %d (%v)", limit, err)
+	}
+
+	// Projet par défaut et ses environnements
+	projects, err := srv.Projects.ListOrganizationProjects(ctx, org.ID)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if len(projects) != 1 || projects[0].ID != provision.Project.ID || projects[0].Name != models.DefaultProjectName {
+		t.Fatalf("Expected the default project, got %+v", projects)
+	}
+	if len(provision.Environments) != len(models.DefaultEnvironments) {
+		t.Fatalf("Expected %d environments, got %+v", len(models.DefaultEnvironments), provision.Environments)
+	}
+	for i, env := range provision.Environments {
+		if env.Name != models.DefaultEnvironments[i] || env.ProjectID != provision.Project.ID {
+			t.Errorf("Expected environment %s of the default project, got %+v", models.DefaultEnvironments[i], env)
+		}
+	}
+
+	// Le projet par défaut compte comme la création du premier projet
+	state, err := srv.Onboarding.State(ctx, userID, org.ID)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if !state.Steps[1].Completed || state.Steps[1].CompletedBy != userID {
+		t.Errorf("Expected the project_created step to be completed, got %+v", state)
+	}
+
+	// Nom déjà pris ou absent
+	resp = srv.Do(http.MethodPost, "/api/v1/organizations", token, map[string]string{"name": "acme"})
+	apitest.ExpectStatus(t, resp, http.StatusConflict)
+	resp = srv.Do(http.MethodPost, "/api/v1/organizations", token, map[string]string{"name": " "})
+	apitest.ExpectStatus(t, resp, http.StatusBadRequest)
+	if orgs, _ := srv.Users.GetUserOrganizations(ctx, userID); len(orgs) != 1 {
+		t.Errorf("Expected a single organization, got %d", len(orgs))
+	}
+}
@@ -0,0 +1,122 @@
+// filepath: internal/api/password_policy_test.go
+
+package api_test
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"secrets-manager/internal/api/apitest"
+	"secrets-manager/internal/api/handlers"
+	"secrets-manager/internal/auth"
+	"secrets-manager/internal/models"
+)
+
+func TestPasswordPolicy(t *testing.T) {
+	srv := apitest.NewServer(t)
+	ownerID := srv.Register("owner@example.com", "password123")
+	owner := srv.Login("owner@example.com", "password123")
+	memberID := srv.Register("member@example.com", "password123")
+	member := srv.Login("member@example.com", "password123")
+	org := srv.CreateOrganization("acme", ownerID)
+	srv.AddMember(org.ID, memberID, "member")
+	path := "/api/v1/organizations/" + org.ID + "/password-policy"
+
+	// Sans politique d'organisation, seule celle du serveur s'applique
+	resp := srv.Do(http.MethodGet, path, member, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var policy handlers.EffectivePasswordPolicy
+	apitest.DecodeJSON(t, resp, &policy)
+	if policy.MinLength != 0 || policy.Effective == nil || policy.Effective.MinLength != 8 {
+		t.Fatalf("Expected the server policy only, got %+v", policy)
+	}
+
+	// Seul un administrateur modifie la politique, dans les bornes
+	strict := models.PasswordPolicy{MinLength: 12, RequireUppercase: true, RequireDigit: true, RequireSymbol: true,
+		HistorySize: 2}
+	resp = srv.Do(http.MethodPut, path, member, strict)
+	apitest.ExpectStatus(t, resp, http.StatusForbidden)
+	resp = srv.Do(http.MethodPut, path, owner, models.PasswordPolicy{MinLength: 500})
+	apitest.ExpectStatus(t, resp, http.StatusBadRequest)
+	resp = srv.Do(http.MethodPut, path, owner, models.PasswordPolicy{HistorySize: -1})
+	apitest.ExpectStatus(t, resp, http.StatusBadRequest)
+	resp = srv.Do(http.MethodPut, path, owner, strict)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	resp = srv.Do(http.MethodGet, path, member, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	apitest.DecodeJSON(t, resp, &policy)
+	if policy.MinLength != 12 || !policy.RequireSymbol || policy.Effective.MinLength != 12 ||
+		policy.Effective.HistorySize != 2 {
+		t.Fatalf("Expected the organization policy, got %+v", policy)
+	}
+
+	// Les exigences non respectées sont toutes signalées
+	change := func(current, next string) *http.Response {
+		return srv.Do(http.MethodPost, "/api/v1/auth/password:change", "", map[string]string{
+			"email": "member@example.com", "password": current, "new_password": next,
+		})
+	}
+	resp = change("password123", "weakpass")
+	apitest.ExpectStatus(t, resp, http.StatusBadRequest)
+	body, _ := io.ReadAll(resp.Body)
+	for _, reason := range []string{"12 caractères", "une majuscule", "un chiffre", "un symbole"} {
+		if !strings.Contains(string(body), reason) {
+			t.Errorf("Expected %q in the error, got %q", reason, body)
+		}
+	}
+
+	// Les anciens mots de passe ne peuvent pas être réutilisés
+	resp = change("password123", "Rotated#2026a")
+	apitest.ExpectStatus(t, resp, http.StatusNoContent)
+	resp = change("Rotated#2026a", "Rotated#2026b")
+	apitest.ExpectStatus(t, resp, http.StatusNoContent)
+	resp = change("Rotated#2026b", "Rotated#2026a")
+	apitest.ExpectStatus(t, resp, http.StatusBadRequest)
+	if body, _ := io.ReadAll(resp.Body); !strings.Contains(string(body), "différent des 2 précédents") {
+		t.Errorf("Expected a reuse error, got %q", body)
+	}
+
+	// Les mots de passe présents dans les fuites connues sont refusés ; seul
+	// le préfixe de l'empreinte est transmis
+	sum := sha1.Sum([]byte("Breached#2026"))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	var prefixes []string
+	hibp := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		prefix := strings.TrimPrefix(r.URL.Path, "/range/")
+		prefixes = append(prefixes, prefix)
+		if prefix == hash[:5] {
+			fmt.Fprintf(w, "%s:0\r\n%s:42\r\n", strings.Repeat("0", 35), hash[5:])
+		}
+	}))
+	defer hibp.Close()
+	srv.AuthService.ApplyPasswordPolicies(models.PasswordPolicy{MinLength: 8}, srv.PasswordPolicies,
+		auth.NewHIBPChecker(hibp.URL))
+	strict.RejectBreached = true
+	resp = srv.Do(http.MethodPut, path, owner, strict)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+
+	resp = change("Rotated#2026b", "Breached#2026")
+	apitest.ExpectStatus(t, resp, http.StatusBadRequest)
+	if body, _ := io.ReadAll(resp.Body); !strings.Contains(string(body), "fuites de données connues") {
+		t.Errorf("Expected a breach error, got %q", body)
+	}
+	resp = change("Rotated#2026b", "Unbreached#2026")
+	apitest.ExpectStatus(t, resp, http.StatusNoContent)
+	if len(prefixes) != 2 || prefixes[0] != hash[:5] {
+		t.Errorf("Expected only hash prefixes to be sent, got %v", prefixes)
+	}
+	srv.Login("member@example.com", "Unbreached#2026")
+
+	// La politique ne s'applique pas aux autres utilisateurs
+	srv.Register("other@example.com", "password123")
+	resp = srv.Do(http.MethodPost, "/api/v1/auth/password:change", "", map[string]string{
+		"email": "other@example.com", "password": "password123", "new_password": "password456",
+	})
+	apitest.ExpectStatus(t, resp, http.StatusNoContent)
+}
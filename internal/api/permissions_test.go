@@ -0,0 +1,142 @@
+// filepath: internal/api/permissions_test.go
+
+package api_test
+
+import (
+	"net/http"
+	"testing"
+
+	"secrets-manager/internal/api/apitest"
+	"secrets-manager/internal/api/handlers"
+	"secrets-manager/internal/models"
+)
+
+func TestEffectivePermissions(t *testing.T) {
+	srv := apitest.NewServer(t)
+	ownerID := srv.Register("owner@example.com", "password123")
+	owner := srv.Login("owner@example.com", "password123")
+	viewerID := srv.Register("viewer@example.com", "password123")
+	viewer := srv.Login("viewer@example.com", "password123")
+	otherID := srv.Register("other@example.com", "password123")
+	org := srv.CreateOrganization("acme", ownerID)
+	srv.AddMember(org.ID, viewerID, "viewer")
+	srv.AddMember(org.ID, otherID, "viewer")
+	payments := srv.CreateProject(org.ID, "payments", ownerID)
+	teams := "/api/v1/organizations/" + org.ID + "/teams"
+	permissions := "/api/v1/organizations/" + org.ID + "/members/" + viewerID + "/permissions"
+
+	resp := srv.Do(http.MethodPost, teams, owner, map[string]string{"name": "paiements"})
+	var team models.Team
+	apitest.DecodeJSON(t, resp, &team)
+	srv.Do(http.MethodPut, teams+"/"+team.ID+"/members/"+viewerID, owner, nil)
+	resp = srv.Do(http.MethodPut, teams+"/"+team.ID+"/projects/"+payments.ID+"/environments/prod/role", owner,
+		map[string]string{"role": "member"})
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	resp = srv.Do(http.MethodPost, "/api/v1/organizations/"+org.ID+"/projects/"+payments.ID+"/environments/prod/secrets",
+		owner, models.Secret{Name: "API_KEY", Value: "kX9#vQ2$mL7!pR4&wT8*"})
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+
+	explain := func(token, query string) models.EffectivePermissions {
+		t.Helper()
+		resp := srv.Do(http.MethodGet, permissions+query, token, nil)
+		apitest.ExpectStatus(t, resp, http.StatusOK)
+		var body models.EffectivePermissions
+		apitest.DecodeJSON(t, resp, &body)
+		return body
+	}
+
+	// Un membre ne consulte que ses propres permissions
+	resp = srv.Do(http.MethodGet, "/api/v1/organizations/"+org.ID+"/members/"+otherID+"/permissions", viewer, nil)
+	apitest.ExpectStatus(t, resp, http.StatusForbidden)
+	resp = srv.Do(http.MethodGet, permissions+"?env=prod", viewer, nil)
+	apitest.ExpectStatus(t, resp, http.StatusBadRequest)
+
+	body := explain(viewer, "?project="+payments.ID+"&env=staging")
+	if body.Role != "viewer" || body.CanWrite || len(body.Rules) != 1 || !body.Rules[0].Decisive {
+		t.Errorf("Expected the organization role only, got %+v", body)
+	}
+	body = explain(owner, "?project="+payments.ID+"&env=prod")
+	if body.Role != "member" || !body.CanWrite || body.CanAdmin || len(body.Rules) != 2 ||
+		body.Rules[0].Decisive || !body.Rules[1].Decisive || body.Rules[1].TeamName != "paiements" {
+		t.Errorf("Expected the team grant to decide, got %+v", body)
+	}
+
+	// Un secret verrouillé n'est plus modifiable
+	resp = srv.Do(http.MethodPost, "/api/v1/organizations/"+org.ID+"/projects/"+payments.ID+"/environments/prod/secrets/API_KEY/lock",
+		owner, map[string]string{"reason": "gel des déploiements"})
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	body = explain(viewer, "?project="+payments.ID+"&env=prod&secret=API_KEY")
+	if body.CanWrite || !body.CanRead || body.Rules[len(body.Rules)-1].Source != models.PermissionSourceSecretLock {
+		t.Errorf("Expected the lock to deny writes, got %+v", body)
+	}
+}
+
+func TestPermissionSimulation(t *testing.T) {
+	srv := apitest.NewServer(t)
+	ownerID := srv.Register("owner@example.com", "password123")
+	owner := srv.Login("owner@example.com", "password123")
+	viewerID := srv.Register("viewer@example.com", "password123")
+	viewer := srv.Login("viewer@example.com", "password123")
+	org := srv.CreateOrganization("acme", ownerID)
+	srv.AddMember(org.ID, viewerID, "viewer")
+	payments := srv.CreateProject(org.ID, "payments", ownerID)
+	search := srv.CreateProject(org.ID, "search", ownerID)
+	simulate := "/api/v1/organizations/" + org.ID + "/permissions:simulate"
+
+	resp := srv.Do(http.MethodPost, "/api/v1/organizations/"+org.ID+"/teams", owner, map[string]string{"name": "paiements"})
+	var team models.Team
+	apitest.DecodeJSON(t, resp, &team)
+
+	decide := func(req handlers.PermissionSimulationRequest) models.PermissionDecision {
+		t.Helper()
+		resp := srv.Do(http.MethodPost, simulate, owner, req)
+		apitest.ExpectStatus(t, resp, http.StatusOK)
+		var decision models.PermissionDecision
+		apitest.DecodeJSON(t, resp, &decision)
+		return decision
+	}
+
+	write := handlers.PermissionSimulationRequest{
+		PrincipalID: viewerID, Action: "write", ProjectID: payments.ID, Environment: "prod",
+	}
+	resp = srv.Do(http.MethodPost, simulate, viewer, write)
+	apitest.ExpectStatus(t, resp, http.StatusForbidden)
+	resp = srv.Do(http.MethodPost, simulate, owner, handlers.PermissionSimulationRequest{PrincipalID: viewerID, Action: "delete"})
+	apitest.ExpectStatus(t, resp, http.StatusBadRequest)
+
+	if decision := decide(write); decision.Allowed {
+		t.Errorf("Expected a viewer not to write, got %+v", decision)
+	}
+
+	// Rejoindre l'équipe à laquelle on accorderait member sur prod
+	write.JoinTeams = []string{team.ID}
+	write.Grants = []*models.TeamGrant{{TeamID: team.ID, ProjectID: payments.ID, Environment: "prod", Role: "member"}}
+	decision := decide(write)
+	rules := decision.Permissions.Rules
+	if !decision.Allowed || len(rules) != 2 || !rules[1].Decisive || !rules[1].Hypothetical {
+		t.Errorf("Expected the hypothetical grant to allow writes, got %+v", decision)
+	}
+	// Rien n'a été accordé
+	resp = srv.Do(http.MethodPost, "/api/v1/organizations/"+org.ID+"/projects/"+payments.ID+"/environments/prod/secrets",
+		viewer, models.Secret{Name: "API_KEY", Value: "kX9#vQ2$mL7!pR4&wT8*"})
+	apitest.ExpectStatus(t, resp, http.StatusForbidden)
+
+	// Un compte de service est limité à ses ressources
+	resp = srv.Do(http.MethodPost, "/api/v1/organizations/"+org.ID+"/service-accounts", owner, handlers.ServiceAccountCreation{
+		Name: "deploy", Permission: models.APIKeyRead, Resources: []models.ResourceScope{{ProjectID: payments.ID}},
+	})
+	var account handlers.CreatedServiceAccount
+	apitest.DecodeJSON(t, resp, &account)
+	read := handlers.PermissionSimulationRequest{
+		PrincipalType: "service_account", PrincipalID: account.ID, Action: "read", ProjectID: payments.ID, Environment: "prod",
+	}
+	if decision := decide(read); !decision.Allowed {
+		t.Errorf("Expected the service account to read its project, got %+v", decision)
+	}
+	read.ProjectID = search.ID
+	decision = decide(read)
+	rules = decision.Permissions.Rules
+	if decision.Allowed || rules[len(rules)-1].Source != models.PermissionSourceServiceAccount {
+		t.Errorf("Expected the service account scope to deny, got %+v", decision)
+	}
+}
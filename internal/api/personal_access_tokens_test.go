@@ -0,0 +1,120 @@
+// filepath: internal/api/personal_access_tokens_test.go
+
+package api_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"secrets-manager/internal/api/apitest"
+	"secrets-manager/internal/api/handlers"
+	"secrets-manager/internal/models"
+)
+
+func TestPersonalAccessTokens(t *testing.T) {
+	srv := apitest.NewServer(t)
+	srv.Register("script@example.com", "password123")
+	session := srv.Login("script@example.com", "password123")
+
+	resp := srv.Do(http.MethodPost, "/api/v1/me/tokens", session, map[string]any{
+		"name":   "ci",
+		"scopes": []string{"metadata:read"},
+	})
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+	var created handlers.CreatedToken
+	apitest.DecodeJSON(t, resp, &created)
+	if !strings.HasPrefix(created.Token, "smpat_") || created.ID == "" {
+		t.Fatalf("Expected a personal access token, got %+v", created)
+	}
+
+	// La portée metadata:read autorise les lectures hors secrets
+	resp = srv.Do(http.MethodGet, "/api/v1/users/me/notification-preferences", created.Token, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	resp = srv.Do(http.MethodPut, "/api/v1/users/me/notification-preferences", created.Token, map[string]any{})
+	apitest.ExpectStatus(t, resp, http.StatusForbidden)
+
+	// Un token ne gère pas les tokens
+	resp = srv.Do(http.MethodGet, "/api/v1/me/tokens", created.Token, nil)
+	apitest.ExpectStatus(t, resp, http.StatusForbidden)
+
+	var tokens []map[string]any
+	resp = srv.Do(http.MethodGet, "/api/v1/me/tokens", session, nil)
+	apitest.DecodeJSON(t, resp, &tokens)
+	if len(tokens) != 1 || tokens[0]["token_hash"] != nil || tokens[0]["last_used_at"] == nil {
+		t.Errorf("Expected one listed token without its hash, got %v", tokens)
+	}
+
+	resp = srv.Do(http.MethodDelete, "/api/v1/me/tokens/"+created.ID, session, nil)
+	apitest.ExpectStatus(t, resp, http.StatusNoContent)
+	resp = srv.Do(http.MethodGet, "/api/v1/users/me/notification-preferences", created.Token, nil)
+	apitest.ExpectStatus(t, resp, http.StatusUnauthorized)
+}
+
+func TestPersonalAccessTokenValidation(t *testing.T) {
+	srv := apitest.NewServer(t)
+	srv.Register("script@example.com", "password123")
+	session := srv.Login("script@example.com", "password123")
+
+	for _, body := range []map[string]any{
+		{"name": "", "scopes": []string{"secrets:read"}},
+		{"name": "ci", "scopes": []string{"admin"}},
+		{"name": "ci", "scopes": []string{"secrets:read"}, "expires_in_days": 400},
+	} {
+		resp := srv.Do(http.MethodPost, "/api/v1/me/tokens", session, body)
+		apitest.ExpectStatus(t, resp, http.StatusBadRequest)
+	}
+}
+
+func TestPersonalAccessTokenResources(t *testing.T) {
+	srv := apitest.NewServer(t)
+	userID := srv.Register("script@example.com", "password123")
+	session := srv.Login("script@example.com", "password123")
+	org := srv.CreateOrganization("acme", userID)
+	payments := srv.CreateProject(org.ID, "payments", userID)
+	billing := srv.CreateProject(org.ID, "billing", userID)
+	projects := "/api/v1/organizations/" + org.ID + "/projects"
+	secrets := func(projectID, env string) string {
+		return projects + "/" + projectID + "/environments/" + env + "/secrets"
+	}
+	for _, path := range []string{secrets(payments.ID, "prod"), secrets(payments.ID, "dev"), secrets(billing.ID, "prod")} {
+		resp := srv.Do(http.MethodPost, path, session, models.Secret{Name: "API_KEY", Value: "kX9#vQ2$mL7!pR4&wT8*"})
+		apitest.ExpectStatus(t, resp, http.StatusCreated)
+	}
+
+	resp := srv.Do(http.MethodPost, "/api/v1/me/tokens", session, handlers.TokenCreation{
+		Name:      "deploy",
+		Scopes:    []string{models.ScopeSecretsRead, models.ScopeMetadataRead},
+		Resources: []models.ResourceScope{{ProjectID: payments.ID, Environment: "prod"}},
+	})
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+	var created handlers.CreatedToken
+	apitest.DecodeJSON(t, resp, &created)
+	if len(created.Resources) != 1 || created.Resources[0].ProjectID != payments.ID {
+		t.Fatalf("Expected a token restricted to payments, got %+v", created.PersonalAccessToken)
+	}
+
+	// Le token ne lit que l'environnement de son projet
+	resp = srv.Do(http.MethodGet, secrets(payments.ID, "prod")+"/API_KEY", created.Token, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	for _, path := range []string{secrets(payments.ID, "dev"), secrets(billing.ID, "prod")} {
+		resp = srv.Do(http.MethodGet, path+"/API_KEY", created.Token, nil)
+		apitest.ExpectStatus(t, resp, http.StatusForbidden)
+	}
+
+	// et ne liste que son projet
+	resp = srv.Do(http.MethodGet, projects, created.Token, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var listed []models.ProjectSummary
+	apitest.DecodeJSON(t, resp, &listed)
+	if len(listed) != 1 || listed[0].ID != payments.ID {
+		t.Errorf("Expected only the payments project, got %+v", listed)
+	}
+
+	resp = srv.Do(http.MethodPost, "/api/v1/me/tokens", session, handlers.TokenCreation{
+		Name:      "deploy",
+		Scopes:    []string{models.ScopeSecretsRead},
+		Resources: []models.ResourceScope{{Environment: "prod"}},
+	})
+	apitest.ExpectStatus(t, resp, http.StatusBadRequest)
+}
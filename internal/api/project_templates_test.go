@@ -0,0 +1,115 @@
+// filepath: internal/api/project_templates_test.go
+
+package api_test
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"testing"
+
+	"secrets-manager/internal/api/apitest"
+	"secrets-manager/internal/models"
+)
+
+func TestProjectTemplates(t *testing.T) {
+	srv := apitest.NewServer(t)
+	ownerID := srv.Register("owner@example.com", "password123")
+	owner := srv.Login("owner@example.com", "password123")
+	viewerID := srv.Register("viewer@example.com", "password123")
+	viewer := srv.Login("viewer@example.com", "password123")
+	org := srv.CreateOrganization("acme", ownerID)
+	srv.AddMember(org.ID, viewerID, "viewer")
+	templatePath := "/api/v1/organizations/" + org.ID + "/project-template"
+	projectsPath := "/api/v1/organizations/" + org.ID + "/projects"
+
+	createProject := func(token, name string, expected int) *models.TemplatedProject {
+		t.Helper()
+		resp := srv.Do(http.MethodPost, projectsPath, token, map[string]string{"name": name})
+		apitest.ExpectStatus(t, resp, expected)
+		if expected != http.StatusCreated {
+			return nil
+		}
+		var project models.TemplatedProject
+		apitest.DecodeJSON(t, resp, &project)
+		return &project
+	}
+	environments := func(project *models.TemplatedProject) []string {
+		names := []string{}
+		for _, env := range project.Environments {
+			if env.ProjectID != project.ID {
+				t.Errorf("Expected environment %s to belong to %s, got %s", env.Name, project.ID, env.ProjectID)
+			}
+			names = append(names, env.Name)
+		}
+		return names
+	}
+
+	// Sans modèle réglé : environnements par défaut, nom libre
+	resp := srv.Do(http.MethodGet, templatePath, viewer, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var template models.ProjectTemplate
+	apitest.DecodeJSON(t, resp, &template)
+	if !slices.Equal(template.Environments, models.DefaultEnvironments) || template.NamePattern != "" {
+		t.Fatalf("Expected the default template, got %+v", template)
+	}
+	project := createProject(owner, "Legacy API", http.StatusCreated)
+	if got := environments(project); !slices.Equal(got, models.DefaultEnvironments) || len(project.Placeholders) != 0 {
+		t.Fatalf("Expected the default environments only, got %v and %+v", got, project.Placeholders)
+	}
+	createProject(viewer, "readonly", http.StatusForbidden)
+
+	// Modèles refusés
+	for _, invalid := range []models.ProjectTemplate{
+		{Environments: []string{}},
+		{Environments: []string{"dev", "dev"}},
+		{Environments: []string{"dev/us"}},
+		{Environments: []string{"dev"}, NamePattern: "[a-z"},
+		{Environments: []string{"dev"}, Placeholders: []models.PlaceholderSecret{
+			{Name: "API_KEY", Environments: []string{"prod"}},
+		}},
+	} {
+		apitest.ExpectStatus(t, srv.Do(http.MethodPut, templatePath, owner, invalid), http.StatusBadRequest)
+	}
+	apitest.ExpectStatus(t, srv.Do(http.MethodPut, templatePath, viewer, template), http.StatusForbidden)
+
+	resp = srv.Do(http.MethodPut, templatePath, owner, models.ProjectTemplate{
+		Environments: []string{" dev", "prod"},
+		NamePattern:  "[a-z][a-z0-9-]*",
+		Placeholders: []models.PlaceholderSecret{
+			{Name: "DATABASE_URL", Description: "Base principale"},
+			{Name: "STRIPE_KEY", Environments: []string{"prod"}},
+		},
+	})
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+
+	// Le modèle s'applique aux projets créés ensuite
+	createProject(owner, "Billing", http.StatusBadRequest)
+	project = createProject(owner, "billing", http.StatusCreated)
+	if got := environments(project); !slices.Equal(got, []string{"dev", "prod"}) {
+		t.Fatalf("Expected the template environments, got %v", got)
+	}
+	var placeholders []string
+	for _, metadata := range project.Placeholders {
+		placeholders = append(placeholders, metadata.Environment+"/"+metadata.Name)
+	}
+	if !slices.Equal(placeholders, []string{"dev/DATABASE_URL", "prod/DATABASE_URL", "prod/STRIPE_KEY"}) {
+		t.Fatalf("Expected the template placeholders, got %v", placeholders)
+	}
+	resp = srv.Do(http.MethodGet, projectsPath+"/"+project.ID+"/environments/prod/secrets/STRIPE_KEY", owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var secret models.Secret
+	apitest.DecodeJSON(t, resp, &secret)
+	if secret.Value != "" {
+		t.Errorf("Expected an empty placeholder, got %q", secret.Value)
+	}
+
+	// La création du projet compte dans la prise en main
+	state, err := srv.Onboarding.State(context.Background(), ownerID, org.ID)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if !state.Steps[1].Completed || state.Steps[1].CompletedBy != ownerID {
+		t.Errorf("Expected the project_created step to be completed, got %+v", state)
+	}
+}
@@ -0,0 +1,68 @@
+// filepath: internal/api/read_reasons_test.go
+
+package api_test
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"secrets-manager/internal/api/apitest"
+	"secrets-manager/internal/models"
+)
+
+func TestReadReasons(t *testing.T) {
+	srv := apitest.NewServer(t)
+	ownerID := srv.Register("owner@example.com", "password123")
+	owner := srv.Login("owner@example.com", "password123")
+	memberID := srv.Register("member@example.com", "password123")
+	member := srv.Login("member@example.com", "password123")
+	org := srv.CreateOrganization("acme", ownerID)
+	srv.AddMember(org.ID, memberID, "member")
+	project := srv.CreateProject(org.ID, "api", ownerID)
+	base := "/api/v1/organizations/" + org.ID + "/projects/" + project.ID + "/environments/"
+	policyPath := "/api/v1/organizations/" + org.ID + "/read-reasons"
+
+	for _, env := range []string{"prod", "dev"} {
+		resp := srv.Do(http.MethodPost, base+env+"/secrets", owner, models.Secret{Name: "DB_PASSWORD", Value: "s3cret"})
+		apitest.ExpectStatus(t, resp, http.StatusCreated)
+	}
+
+	// Réservé aux administrateurs
+	resp := srv.Do(http.MethodPut, policyPath, member, map[string]any{"environments": []string{"prod"}})
+	apitest.ExpectStatus(t, resp, http.StatusForbidden)
+	resp = srv.Do(http.MethodPut, policyPath, owner, map[string]any{"environments": []string{"prod", " "}})
+	apitest.ExpectStatus(t, resp, http.StatusBadRequest)
+	resp = srv.Do(http.MethodPut, policyPath, owner, map[string]any{"environments": []string{"prod"}})
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	resp = srv.Do(http.MethodGet, policyPath, member, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var policy models.ReadReasonPolicy
+	apitest.DecodeJSON(t, resp, &policy)
+	if !policy.Requires("prod") || policy.Requires("dev") {
+		t.Errorf("Expected only prod to be protected, got %+v", policy)
+	}
+
+	// Un motif est exigé en production, pas ailleurs
+	resp = srv.Do(http.MethodGet, base+"prod/secrets/DB_PASSWORD", member, nil)
+	apitest.ExpectStatus(t, resp, http.StatusBadRequest)
+	resp = srv.Do(http.MethodGet, base+"prod/secrets", member, nil)
+	apitest.ExpectStatus(t, resp, http.StatusBadRequest)
+	resp = srv.Do(http.MethodGet, base+"dev/secrets/DB_PASSWORD", member, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	reason := url.Values{"reason": {"INC-42 : rotation manuelle"}}.Encode()
+	resp = srv.Do(http.MethodGet, base+"prod/secrets/DB_PASSWORD?"+reason, member, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+
+	// Les lectures motivées sont auditées
+	resp = srv.Do(http.MethodGet, "/api/v1/organizations/"+org.ID+"/audit-logs", member, nil)
+	apitest.ExpectStatus(t, resp, http.StatusForbidden)
+	resp = srv.Do(http.MethodGet, "/api/v1/organizations/"+org.ID+"/audit-logs", owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var logs []models.AuditLog
+	apitest.DecodeJSON(t, resp, &logs)
+	if len(logs) != 1 || logs[0].UserID != memberID || logs[0].Reason != "INC-42 : rotation manuelle" ||
+		logs[0].ResourceID != project.ID+"/prod/DB_PASSWORD" {
+		t.Errorf("Expected the justified read in the audit log, got %+v", logs)
+	}
+}
@@ -0,0 +1,71 @@
+// filepath: internal/api/readmes_test.go
+
+package api_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"secrets-manager/internal/api/apitest"
+	"secrets-manager/internal/models"
+)
+
+func TestReadmes(t *testing.T) {
+	srv := apitest.NewServer(t)
+	ownerID := srv.Register("owner@example.com", "password123")
+	owner := srv.Login("owner@example.com", "password123")
+	viewerID := srv.Register("viewer@example.com", "password123")
+	viewer := srv.Login("viewer@example.com", "password123")
+	org := srv.CreateOrganization("acme", ownerID)
+	srv.AddMember(org.ID, viewerID, "viewer")
+	project := srv.CreateProject(org.ID, "api", ownerID)
+	projectReadme := "/api/v1/organizations/" + org.ID + "/projects/" + project.ID + "/readme"
+	prodReadme := "/api/v1/organizations/" + org.ID + "/projects/" + project.ID + "/environments/prod/readme"
+
+	resp := srv.Do(http.MethodGet, projectReadme, owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusNotFound)
+
+	// Les lecteurs consultent les README sans pouvoir les modifier
+	resp = srv.Do(http.MethodPut, projectReadme, viewer, map[string]string{"content": "# API"})
+	apitest.ExpectStatus(t, resp, http.StatusForbidden)
+	resp = srv.Do(http.MethodPut, projectReadme, owner, map[string]string{"content": ""})
+	apitest.ExpectStatus(t, resp, http.StatusBadRequest)
+	resp = srv.Do(http.MethodPut, projectReadme, owner,
+		map[string]string{"content": strings.Repeat("a", models.MaxReadmeLength+1)})
+	apitest.ExpectStatus(t, resp, http.StatusBadRequest)
+
+	resp = srv.Do(http.MethodPut, projectReadme, owner, map[string]string{"content": "# API\n\nResponsable : équipe paiements"})
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	resp = srv.Do(http.MethodPut, prodReadme, owner, map[string]string{"content": "## Rotation\n\nVia le rotator Postgres"})
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+
+	resp = srv.Do(http.MethodGet, prodReadme, viewer, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var readme models.Readme
+	apitest.DecodeJSON(t, resp, &readme)
+	if readme.Environment != "prod" || readme.UpdatedBy != ownerID || !strings.HasPrefix(readme.Content, "## Rotation") {
+		t.Errorf("Expected the prod runbook written by the owner, got %+v", readme)
+	}
+
+	// La liste des projets porte les README
+	resp = srv.Do(http.MethodGet, "/api/v1/organizations/"+org.ID+"/projects", viewer, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var projects []models.ProjectSummary
+	apitest.DecodeJSON(t, resp, &projects)
+	if len(projects) != 1 || projects[0].Readme == nil || projects[0].EnvironmentReadmes["prod"] == nil {
+		t.Fatalf("Expected the project listing to include both READMEs, got %+v", projects)
+	}
+
+	resp = srv.Do(http.MethodDelete, prodReadme, owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusNoContent)
+	resp = srv.Do(http.MethodGet, prodReadme, owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusNotFound)
+
+	// Un projet d'une autre organisation reste invisible
+	otherID := srv.Register("other@example.com", "password123")
+	other := srv.Login("other@example.com", "password123")
+	otherOrg := srv.CreateOrganization("globex", otherID)
+	resp = srv.Do(http.MethodGet, "/api/v1/organizations/"+otherOrg.ID+"/projects/"+project.ID+"/readme", other, nil)
+	apitest.ExpectStatus(t, resp, http.StatusNotFound)
+}
@@ -0,0 +1,92 @@
+// filepath: internal/api/registration_policy_test.go
+
+package api_test
+
+import (
+	"net/http"
+	"testing"
+
+	"secrets-manager/internal/api/apitest"
+	"secrets-manager/internal/models"
+)
+
+func TestRegistrationPolicy(t *testing.T) {
+	srv := apitest.NewServer(t)
+	ownerID := srv.Register("owner@example.com", "password123")
+	owner := srv.Login("owner@example.com", "password123")
+	org := srv.CreateOrganization("acme", ownerID)
+	register := func(email string) *http.Response {
+		return srv.Do(http.MethodPost, "/api/v1/auth/register", "", map[string]string{
+			"email": email, "password": "password123",
+		})
+	}
+
+	// Inscription libre par défaut
+	resp := srv.DoAdmin(http.MethodGet, "/admin/registration-policy")
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var policy models.RegistrationPolicy
+	apitest.DecodeJSON(t, resp, &policy)
+	if policy.Mode != models.RegistrationOpen || len(policy.AllowList) != 0 || len(policy.DenyList) != 0 {
+		t.Fatalf("Expected open registration, got %+v", policy)
+	}
+
+	for _, invalid := range []models.RegistrationPolicy{
+		{Mode: "invite"},
+		{Mode: models.RegistrationRestricted, AllowList: []string{"not a domain"}},
+		{Mode: models.RegistrationOpen, DenyList: []string{"@example.com"}},
+	} {
+		resp = srv.DoAdminJSON(http.MethodPut, "/admin/registration-policy", invalid)
+		apitest.ExpectStatus(t, resp, http.StatusBadRequest)
+	}
+
+	// La liste de refus s'applique aussi aux sous-domaines
+	resp = srv.DoAdminJSON(http.MethodPut, "/admin/registration-policy", models.RegistrationPolicy{
+		Mode: models.RegistrationOpen, DenyList: []string{"Spam.test", "eve@example.com"},
+	})
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	apitest.DecodeJSON(t, resp, &policy)
+	if len(policy.DenyList) != 2 || policy.DenyList[0] != "spam.test" {
+		t.Errorf("Expected a normalized deny list, got %+v", policy)
+	}
+	for _, email := range []string{"bot@spam.test", "bot@mail.spam.test", "EVE@example.com"} {
+		apitest.ExpectStatus(t, register(email), http.StatusForbidden)
+	}
+	apitest.ExpectStatus(t, register("alice@example.com"), http.StatusCreated)
+
+	// Mode restreint : domaines autorisés et adresses invitées uniquement
+	resp = srv.DoAdminJSON(http.MethodPut, "/admin/registration-policy", models.RegistrationPolicy{
+		Mode: models.RegistrationRestricted, AllowList: []string{"corp.test"}, DenyList: []string{"intern.corp.test"},
+	})
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	apitest.ExpectStatus(t, register("bob@example.com"), http.StatusForbidden)
+	apitest.ExpectStatus(t, register("carol@corp.test"), http.StatusCreated)
+	apitest.ExpectStatus(t, register("dave@intern.corp.test"), http.StatusForbidden)
+
+	resp = srv.Do(http.MethodPost, "/api/v1/organizations/"+org.ID+"/invitations", owner,
+		map[string]string{"email": "bob@example.com", "role": "member"})
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+	apitest.ExpectStatus(t, register("bob@example.com"), http.StatusCreated)
+
+	// Inscriptions fermées : seules les invitations créent des comptes
+	resp = srv.DoAdminJSON(http.MethodPut, "/admin/registration-policy", models.RegistrationPolicy{
+		Mode: models.RegistrationClosed, AllowList: []string{"corp.test"},
+	})
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	apitest.ExpectStatus(t, register("frank@corp.test"), http.StatusForbidden)
+
+	resp = srv.Do(http.MethodPost, "/api/v1/organizations/"+org.ID+"/invitations", owner,
+		map[string]string{"email": "grace@example.com", "role": "member"})
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+	mails := srv.Outbox.Mails()
+	token := invitationToken.FindString(mails[len(mails)-1].Body)
+	resp = srv.Do(http.MethodPost, "/api/v1/invitations/accept", "", map[string]string{
+		"token": token, "password": "password123",
+	})
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+	srv.Login("grace@example.com", "password123")
+
+	// La politique de la configuration s'applique de nouveau
+	resp = srv.DoAdmin(http.MethodDelete, "/admin/registration-policy")
+	apitest.ExpectStatus(t, resp, http.StatusNoContent)
+	apitest.ExpectStatus(t, register("frank@corp.test"), http.StatusCreated)
+}
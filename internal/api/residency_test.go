@@ -0,0 +1,66 @@
+// filepath: internal/api/residency_test.go
+
+package api_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"secrets-manager/internal/api/apitest"
+	"secrets-manager/internal/api/handlers"
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/vault"
+)
+
+func TestDataResidency(t *testing.T) {
+	srv := apitest.NewServer(t)
+	ownerID := srv.Register("owner@example.com", "password123")
+	token := srv.Login("owner@example.com", "password123")
+	memberID := srv.Register("member@example.com", "password123")
+	memberToken := srv.Login("member@example.com", "password123")
+	org := srv.CreateOrganization("acme", ownerID)
+	srv.AddMember(org.ID, memberID, "member")
+	project := srv.CreateProject(org.ID, "api", ownerID)
+
+	residency := "/api/v1/organizations/" + org.ID + "/residency"
+
+	resp := srv.Do(http.MethodGet, residency, memberToken, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var current handlers.Residency
+	apitest.DecodeJSON(t, resp, &current)
+	if current.Region != models.DefaultRegion || len(current.AvailableRegions) != 2 {
+		t.Errorf("Expected default region with 2 available regions, got %+v", current)
+	}
+
+	// Réservé aux administrateurs, et limité aux régions configurées
+	resp = srv.Do(http.MethodPut, residency, memberToken, map[string]string{"region": apitest.SecretRegion})
+	apitest.ExpectStatus(t, resp, http.StatusForbidden)
+	resp = srv.Do(http.MethodPut, residency, token, map[string]string{"region": "mars"})
+	apitest.ExpectStatus(t, resp, http.StatusBadRequest)
+
+	resp = srv.Do(http.MethodPut, residency, token, map[string]string{"region": apitest.SecretRegion})
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	apitest.DecodeJSON(t, resp, &current)
+	if current.Region != apitest.SecretRegion {
+		t.Errorf("Expected region %s, got %s", apitest.SecretRegion, current.Region)
+	}
+
+	// Les secrets ne sont écrits que dans le backend de la région
+	resp = srv.Do(http.MethodPost, "/api/v1/organizations/"+org.ID+"/projects/"+project.ID+"/environments/dev/secrets",
+		token, models.Secret{Name: "API_KEY", Value: "v"})
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+
+	path := org.ID + "/" + project.ID + "/dev/API_KEY"
+	if _, err := srv.RegionStore.GetSecret(context.Background(), path); err != nil {
+		t.Errorf("Expected secret in the regional backend, got %v", err)
+	}
+	if _, err := srv.SecretStore.GetSecret(context.Background(), path); !errors.Is(err, vault.ErrSecretNotFound) {
+		t.Errorf("Expected no secret in the default backend, got %v", err)
+	}
+
+	// Une organisation qui contient des secrets ne change plus de région
+	resp = srv.Do(http.MethodPut, residency, token, map[string]string{"region": models.DefaultRegion})
+	apitest.ExpectStatus(t, resp, http.StatusConflict)
+}
@@ -0,0 +1,293 @@
+// filepath: internal/api/rotation_test.go
+
+package api_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"secrets-manager/internal/api/apitest"
+	"secrets-manager/internal/events"
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/rotation"
+)
+
+func TestRotationDryRun(t *testing.T) {
+	srv := apitest.NewServer(t)
+	ownerID := srv.Register("owner@example.com", "password123")
+	owner := srv.Login("owner@example.com", "password123")
+	viewerID := srv.Register("viewer@example.com", "password123")
+	viewer := srv.Login("viewer@example.com", "password123")
+	org := srv.CreateOrganization("acme", ownerID)
+	srv.AddMember(org.ID, viewerID, "viewer")
+	project := srv.CreateProject(org.ID, "api", ownerID)
+
+	for _, webhook := range []*models.Webhook{
+		{OrganizationID: org.ID, URL: "https://hooks.example.com/all"},
+		{OrganizationID: org.ID, URL: "https://hooks.example.com/updates", Events: []string{events.SecretUpdatedV1}},
+		{OrganizationID: org.ID, URL: "https://hooks.example.com/deletes", Events: []string{events.SecretDeletedV1}},
+	} {
+		if err := srv.Webhooks.CreateWebhook(context.Background(), webhook); err != nil {
+			t.Fatalf("Expected webhook to be created, got %v", err)
+		}
+	}
+
+	secrets := "/api/v1/organizations/" + org.ID + "/projects/" + project.ID + "/environments/prod/secrets"
+	resp := srv.Do(http.MethodPost, secrets, owner, models.Secret{Name: "DB_PASSWORD", Value: "v1"})
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+	header := http.Header{}
+	header.Set("X-Workload", "billing")
+	resp = srv.DoWithHeaders(http.MethodGet, secrets+"/DB_PASSWORD", owner, header, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+
+	resp = srv.Do(http.MethodPost, secrets+"/DB_PASSWORD/rotate:dry-run", owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var plan models.RotationPlan
+	apitest.DecodeJSON(t, resp, &plan)
+	if !plan.Ready || plan.Version != 1 {
+		t.Errorf("Expected a ready plan for version 1, got ready=%v version=%d", plan.Ready, plan.Version)
+	}
+	if len(plan.Consumers) != 1 || plan.Consumers[0].Workload != "billing" || plan.LastReadAt == nil {
+		t.Errorf("Expected billing as the only consumer, got %+v", plan.Consumers)
+	}
+	if len(plan.Webhooks) != 2 {
+		t.Errorf("Expected 2 webhooks subscribed to %s, got %d", events.SecretUpdatedV1, len(plan.Webhooks))
+	}
+
+	// Un lecteur voit le plan mais ne peut pas effectuer la rotation d'un secret verrouillé
+	resp = srv.Do(http.MethodPost, secrets+"/DB_PASSWORD/lock", owner, map[string]string{"reason": "audit"})
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	resp = srv.Do(http.MethodPost, secrets+"/DB_PASSWORD/rotate:dry-run", viewer, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	plan = models.RotationPlan{}
+	apitest.DecodeJSON(t, resp, &plan)
+	if plan.Ready {
+		t.Error("Expected plan not to be ready")
+	}
+	for _, requirement := range plan.Requirements {
+		if requirement.Satisfied {
+			t.Errorf("Expected requirement %s to be unsatisfied", requirement.Check)
+		}
+	}
+
+	resp = srv.Do(http.MethodPost, secrets+"/MISSING/rotate:dry-run", owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusNotFound)
+
+	// Hors production, aucun webhook n'est notifié
+	staging := "/api/v1/organizations/" + org.ID + "/projects/" + project.ID + "/environments/staging/secrets"
+	resp = srv.Do(http.MethodPost, staging, owner, models.Secret{Name: "DB_PASSWORD", Value: "v1"})
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+	resp = srv.Do(http.MethodPost, staging+"/DB_PASSWORD/rotate:dry-run", owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	plan = models.RotationPlan{}
+	apitest.DecodeJSON(t, resp, &plan)
+	if len(plan.Webhooks) != 0 {
+		t.Errorf("Expected no webhooks outside production, got %d", len(plan.Webhooks))
+	}
+}
+
+// fakeRotator émet des valeurs numérotées et note les valeurs révoquées
+type fakeRotator struct {
+	issued  int
+	revoked []string
+}
+
+func (f *fakeRotator) Validate(config map[string]string) error {
+	if config["account"] == "" {
+		return errors.New("paramètre account requis")
+	}
+	return nil
+}
+
+func (f *fakeRotator) Issue(ctx context.Context, req rotation.Request) (string, error) {
+	if req.Credentials != "admin-credentials" {
+		return "", rotation.ErrUpstream
+	}
+	f.issued++
+	return "rotated-" + strconv.Itoa(f.issued), nil
+}
+
+func (f *fakeRotator) Revoke(ctx context.Context, req rotation.Request) error {
+	f.revoked = append(f.revoked, req.Current)
+	return nil
+}
+
+func TestManagedRotation(t *testing.T) {
+	srv := apitest.NewServer(t)
+	fake := &fakeRotator{}
+	srv.Rotators.Register("fake", fake)
+	ownerID := srv.Register("owner@example.com", "password123")
+	owner := srv.Login("owner@example.com", "password123")
+	viewerID := srv.Register("viewer@example.com", "password123")
+	viewer := srv.Login("viewer@example.com", "password123")
+	org := srv.CreateOrganization("acme", ownerID)
+	srv.AddMember(org.ID, viewerID, "viewer")
+	project := srv.CreateProject(org.ID, "api", ownerID)
+
+	secrets := "/api/v1/organizations/" + org.ID + "/projects/" + project.ID + "/environments/prod/secrets"
+	for _, secret := range []models.Secret{{Name: "DB_PASSWORD", Value: "v1"}, {Name: "DB_ADMIN", Value: "admin-credentials"}} {
+		resp := srv.Do(http.MethodPost, secrets, owner, secret)
+		apitest.ExpectStatus(t, resp, http.StatusCreated)
+	}
+	rotator := secrets + "/DB_PASSWORD/rotator"
+
+	resp := srv.Do(http.MethodPost, secrets+"/DB_PASSWORD/rotate", owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusNotFound)
+
+	tests := []struct {
+		name   string
+		token  string
+		body   map[string]any
+		status int
+	}{
+		{"Unknown provider", owner, map[string]any{"provider": "ldap", "credentials_secret": "DB_ADMIN"}, http.StatusBadRequest},
+		{"Invalid config", owner, map[string]any{"provider": "fake", "credentials_secret": "DB_ADMIN"}, http.StatusBadRequest},
+		{"Missing linked secret", owner, map[string]any{"provider": "fake", "config": map[string]string{"account": "billing"},
+			"credentials_secret": "MISSING"}, http.StatusBadRequest},
+		{"Viewer", viewer, map[string]any{"provider": "fake", "config": map[string]string{"account": "billing"},
+			"credentials_secret": "DB_ADMIN"}, http.StatusForbidden},
+		{"Valid", owner, map[string]any{"provider": "fake", "config": map[string]string{"account": "billing"},
+			"credentials_secret": "DB_ADMIN"}, http.StatusOK},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := srv.Do(http.MethodPut, rotator, tc.token, tc.body)
+			apitest.ExpectStatus(t, resp, tc.status)
+		})
+	}
+
+	resp = srv.Do(http.MethodPost, secrets+"/DB_PASSWORD/rotate:dry-run", owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var plan models.RotationPlan
+	apitest.DecodeJSON(t, resp, &plan)
+	if plan.Provider != "fake" || !plan.Ready || len(plan.Requirements) != 3 {
+		t.Errorf("Expected a ready plan with provider credentials, got %+v", plan)
+	}
+
+	resp = srv.Do(http.MethodPost, secrets+"/DB_PASSWORD/rotate", viewer, nil)
+	apitest.ExpectStatus(t, resp, http.StatusForbidden)
+	resp = srv.Do(http.MethodPost, secrets+"/DB_PASSWORD/rotate", owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var rotated models.SecretRotator
+	apitest.DecodeJSON(t, resp, &rotated)
+	if rotated.LastRotatedAt == nil || rotated.LastError != "" {
+		t.Errorf("Expected a successful rotation, got %+v", rotated)
+	}
+
+	resp = srv.Do(http.MethodGet, secrets+"/DB_PASSWORD", owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var secret models.Secret
+	apitest.DecodeJSON(t, resp, &secret)
+	if secret.Value != "rotated-1" {
+		t.Errorf("Expected rotated-1, got %s", secret.Value)
+	}
+	if len(fake.revoked) != 1 || fake.revoked[0] != "v1" {
+		t.Errorf("Expected v1 to be revoked, got %v", fake.revoked)
+	}
+
+	// Le fournisseur refuse des identifiants modifiés : la valeur est conservée
+	resp = srv.Do(http.MethodPut, secrets+"/DB_ADMIN", owner, models.Secret{Value: "stale"})
+	apitest.ExpectStatus(t, resp, http.StatusNoContent)
+	resp = srv.Do(http.MethodPost, secrets+"/DB_PASSWORD/rotate", owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusBadGateway)
+	resp = srv.Do(http.MethodGet, rotator, viewer, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	apitest.DecodeJSON(t, resp, &rotated)
+	if rotated.LastError == "" {
+		t.Error("Expected the failed rotation to be recorded")
+	}
+
+	resp = srv.Do(http.MethodDelete, rotator, owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusNoContent)
+	resp = srv.Do(http.MethodGet, rotator, owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusNotFound)
+}
+
+func TestDualRotation(t *testing.T) {
+	srv := apitest.NewServer(t)
+	fake := &fakeRotator{}
+	srv.Rotators.Register("fake", fake)
+	ownerID := srv.Register("owner@example.com", "password123")
+	owner := srv.Login("owner@example.com", "password123")
+	org := srv.CreateOrganization("acme", ownerID)
+	project := srv.CreateProject(org.ID, "api", ownerID)
+
+	secrets := "/api/v1/organizations/" + org.ID + "/projects/" + project.ID + "/environments/prod/secrets"
+	for _, secret := range []models.Secret{{Name: "DB_PASSWORD", Value: "v1"}, {Name: "DB_ADMIN", Value: "admin-credentials"}} {
+		resp := srv.Do(http.MethodPost, secrets, owner, secret)
+		apitest.ExpectStatus(t, resp, http.StatusCreated)
+	}
+	rotator := secrets + "/DB_PASSWORD/rotator"
+	body := map[string]any{"provider": "fake", "config": map[string]string{"account": "billing"},
+		"credentials_secret": "DB_ADMIN", "strategy": "blue-green"}
+
+	resp := srv.Do(http.MethodPut, rotator, owner, body)
+	apitest.ExpectStatus(t, resp, http.StatusBadRequest)
+	body["strategy"] = models.RotationDual
+	body["overlap_seconds"] = 10
+	resp = srv.Do(http.MethodPut, rotator, owner, body)
+	apitest.ExpectStatus(t, resp, http.StatusBadRequest)
+	delete(body, "overlap_seconds")
+	resp = srv.Do(http.MethodPut, rotator, owner, body)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var configured models.SecretRotator
+	apitest.DecodeJSON(t, resp, &configured)
+	if configured.OverlapSeconds != 24*3600 {
+		t.Errorf("Expected a default overlap of 24 hours, got %d", configured.OverlapSeconds)
+	}
+
+	// Les deux valeurs restent en service pendant le chevauchement
+	resp = srv.Do(http.MethodPost, secrets+"/DB_PASSWORD/rotate", owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var rotated models.SecretRotator
+	apitest.DecodeJSON(t, resp, &rotated)
+	if rotated.PreviousExpiresAt == nil || rotated.LastError != "" {
+		t.Errorf("Expected the previous value to be scheduled for retirement, got %+v", rotated)
+	}
+	expected := map[string]string{"DB_PASSWORD": "rotated-1", "DB_PASSWORD_previous": "v1"}
+	for name, value := range expected {
+		resp = srv.Do(http.MethodGet, secrets+"/"+name, owner, nil)
+		apitest.ExpectStatus(t, resp, http.StatusOK)
+		var secret models.Secret
+		apitest.DecodeJSON(t, resp, &secret)
+		if secret.Value != value {
+			t.Errorf("Expected %s to be %s, got %s", name, value, secret.Value)
+		}
+	}
+	if len(fake.revoked) != 0 {
+		t.Errorf("Expected nothing to be revoked during the overlap, got %v", fake.revoked)
+	}
+
+	// Une nouvelle rotation retire d'abord la valeur précédente
+	resp = srv.Do(http.MethodPost, secrets+"/DB_PASSWORD/rotate", owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	if len(fake.revoked) != 1 || fake.revoked[0] != "v1" {
+		t.Errorf("Expected v1 to be revoked, got %v", fake.revoked)
+	}
+
+	// À la fin du chevauchement, le job retire rotated-1
+	past := time.Now().Add(-time.Minute)
+	if err := srv.SecretRotators.SetPreviousExpiry(context.Background(), rotated.ID, &past); err != nil {
+		t.Fatalf("Expected expiry to be set, got %v", err)
+	}
+	retirer := rotation.NewRetirer(srv.SecretRotators, srv.Secrets, srv.VaultService, srv.Rotators, nil)
+	if err := retirer.RetireExpired(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(fake.revoked) != 2 || fake.revoked[1] != "rotated-1" {
+		t.Errorf("Expected rotated-1 to be revoked, got %v", fake.revoked)
+	}
+	resp = srv.Do(http.MethodGet, secrets+"/DB_PASSWORD_previous", owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusNotFound)
+	resp = srv.Do(http.MethodGet, rotator, owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var retired models.SecretRotator
+	apitest.DecodeJSON(t, resp, &retired)
+	if retired.PreviousExpiresAt != nil {
+		t.Errorf("Expected no previous value left, got %v", retired.PreviousExpiresAt)
+	}
+}
@@ -1,47 +1,744 @@
 // filepath: d:\go\src\secrets-manager\internal\api\routes.go
-// filepath: d:\go\src\secrets-manager\internal\api\routes.go
 package api
 
 import (
+	"net/http"
+	"time"
+
 	"github.com/gorilla/mux"
 
 	"secrets-manager/internal/api/handlers"
 	"secrets-manager/internal/api/middleware"
+	"secrets-manager/internal/api/versioning"
 	"secrets-manager/internal/auth"
+	"secrets-manager/internal/captcha"
+	"secrets-manager/internal/events"
+	"secrets-manager/internal/evidence"
+	"secrets-manager/internal/jobs"
+	"secrets-manager/internal/logforward"
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/notifications"
+	"secrets-manager/internal/onboarding"
+	"secrets-manager/internal/rotation"
+	"secrets-manager/internal/storage"
 	"secrets-manager/internal/vault"
+	"secrets-manager/internal/webhooks"
 )
 
+// Dependencies regroupe les services et repositories utilisés par les routes
+type Dependencies struct {
+	VaultService  *vault.Service
+	AuthService   *auth.Service
+	Users         storage.UsersRepository
+	Organizations storage.OrganizationsRepository
+	Secrets       storage.SecretsRepository
+	Projects      storage.ProjectsRepository
+	Confirmations storage.ConfirmationsRepository
+	Usage         storage.UsageRepository
+	AdminAudit    storage.AdminAuditRepository
+
+	// SecretReads comptabilise les lectures des secrets par charge de travail (en-tête X-Workload)
+	SecretReads storage.SecretReadsRepository
+
+	// SecretRotators configure la rotation gérée des secrets, exécutée par
+	// les rotateurs des fournisseurs de Rotators
+	SecretRotators storage.SecretRotatorsRepository
+	Rotators       *rotation.Registry
+
+	// ScheduledSecretChanges contient les changements de valeur planifiés,
+	// promus par jobs.SecretPromoter
+	ScheduledSecretChanges storage.ScheduledSecretChangesRepository
+	// MaintenanceWindows limite les modifications automatiques des
+	// environnements protégés à leurs fenêtres de maintenance
+	MaintenanceWindows storage.MaintenanceWindowsRepository
+	// Subscriptions donne la date de renouvellement de l'abonnement ; nil
+	// l'omet du calendrier
+	Subscriptions storage.SubscriptionsRepository
+	// DataKeys contient les clés de données des projets, dont le chiffrement
+	// d'enveloppe passe par le moteur transit de Transit
+	DataKeys storage.DataKeysRepository
+	Transit  *vault.TransitRouter
+	// AgeKeys contient les clés age (sops) des dépôts, dont les identités
+	// sont stockées dans Vault
+	AgeKeys storage.AgeKeysRepository
+	// SettingsHistory contient les versions de la configuration des organisations
+	SettingsHistory storage.SettingsHistoryRepository
+	// Health contient l'historique de santé des composants publié par /status
+	Health storage.HealthRepository
+	// LogForwarders contient les destinations des journaux d'audit des
+	// organisations, servies par AuditForwarder
+	LogForwarders  storage.LogForwardersRepository
+	AuditForwarder *logforward.Forwarder
+
+	// DeviceAuthorizations contient les demandes de connexion de la CLI (flux device code)
+	DeviceAuthorizations storage.DeviceAuthorizationsRepository
+	// PersonalAccessTokens contient les tokens d'accès personnels des utilisateurs
+	PersonalAccessTokens storage.PersonalAccessTokensRepository
+	// TrustedDevices contient les appareils de confiance des utilisateurs,
+	// dispensés de code TOTP à la connexion
+	TrustedDevices storage.TrustedDevicesRepository
+	// APIKeys contient les clés d'API des organisations (en-tête X-API-Key)
+	APIKeys storage.APIKeysRepository
+	// LoginEvents contient les connexions des utilisateurs, situées par loginalerts
+	LoginEvents storage.LoginEventsRepository
+	// LoginAlertPolicies contient les politiques d'alerte de connexion des organisations
+	LoginAlertPolicies storage.LoginAlertPoliciesRepository
+	// ReadReasonPolicies désigne les environnements protégés, dont la
+	// lecture des secrets exige un motif
+	ReadReasonPolicies storage.ReadReasonPoliciesRepository
+	// ProjectTemplates contient les modèles appliqués aux projets créés dans les organisations
+	ProjectTemplates storage.ProjectTemplatesRepository
+	// AuditLogs contient le journal d'audit des lectures motivées
+	AuditLogs storage.AuditLogsRepository
+	// SessionPolicies contient les politiques de session des organisations,
+	// appliquées par le service d'authentification
+	SessionPolicies storage.SessionPoliciesRepository
+	// PasswordPolicies contient les politiques de mot de passe des
+	// organisations et l'historique des mots de passe, appliqués par le
+	// service d'authentification
+	PasswordPolicies storage.PasswordPoliciesRepository
+	// RegistrationPolicy contient la politique d'inscription réglée depuis le
+	// listener d'administration (nil désactive ces routes)
+	RegistrationPolicy storage.RegistrationPolicyRepository
+	// Invitations contient les invitations à rejoindre les organisations,
+	// envoyées par Mailer (nil désactive les invitations)
+	Invitations storage.InvitationsRepository
+	Mailer      notifications.Mailer
+	// Onboarding suit la prise en main des utilisateurs ; nil désactive
+	// ses routes et la confirmation des adresses email
+	Onboarding *onboarding.Tracker
+	// Teams contient les équipes des organisations, responsables de projets et de secrets
+	Teams storage.TeamsRepository
+	// ServiceAccounts contient les comptes de service des organisations
+	ServiceAccounts storage.ServiceAccountsRepository
+	// CustomRoles contient les rôles personnalisés des organisations et leurs attributions
+	CustomRoles storage.CustomRolesRepository
+	// Impersonations contient les usurpations d'identité par le support ;
+	// nil désactive leurs routes
+	Impersonations storage.ImpersonationsRepository
+	// IntrospectionClients associe chaque service autorisé à introspecter des tokens à son secret
+	IntrospectionClients map[string]string
+
+	// OrganizationDeletions suit les suppressions d'organisations exécutées par OrganizationDeleter
+	OrganizationDeletions storage.OrganizationDeletionsRepository
+	OrganizationDeleter   *jobs.OrganizationDeleter
+	AccessReviews         storage.AccessReviewsRepository
+	// BulkRotations suit les rotations groupées exécutées par BulkRotator
+	BulkRotations storage.BulkRotationsRepository
+	BulkRotator   *jobs.BulkRotator
+	// Lockdowns contient les confinements des organisations compromises
+	Lockdowns storage.LockdownsRepository
+
+	// EvidenceSigner signe les archives de preuves ; nil désactive leur export
+	EvidenceSigner *evidence.Signer
+	// VaultRouter envoie les secrets vers le backend de la région de chaque organisation
+	VaultRouter *vault.Router
+	// VaultClusters suit la santé du cluster primaire et de ses réplicas
+	VaultClusters *vault.Clusters
+	// Checksummer calcule les sommes de contrôle des secrets ; nil les désactive
+	Checksummer *vault.Checksummer
+	// NotificationPreferences contient les réglages de notification des utilisateurs
+	NotificationPreferences storage.NotificationPreferencesRepository
+	// Notifier distribue les notifications ; nil les désactive
+	Notifier *notifications.Dispatcher
+	// NotificationEvents est le journal des événements des organisations
+	NotificationEvents storage.NotificationEventsRepository
+	// Webhooks contient les webhooks des organisations et leurs livraisons
+	Webhooks storage.WebhooksRepository
+	// WebhookSender livre les événements de test et les rejeux
+	WebhookSender *webhooks.Sender
+	// Events diffuse les modifications des ressources pour invalider les
+	// caches HTTP ; nil ne laisse que les dates fournies par les handlers
+	Events *events.Bus
+	// Switches coupe à chaud des routes ou des fonctionnalités ; nil désactive la vérification
+	Switches *middleware.Switches
+	// AuthLimiter limite les tentatives sur les routes d'authentification
+	// publiques ; nil ne les limite pas
+	AuthLimiter *middleware.AuthLimiter
+	// Captcha est le défi exigé à l'inscription et, au-delà de quelques
+	// tentatives, par AuthLimiter ; nil n'en exige aucun
+	Captcha captcha.Provider
+	// V1Deprecation annonce le retrait de /api/v1 ; nil tant qu'il n'est pas planifié
+	V1Deprecation *versioning.Deprecation
+
+	// SlowRequestThreshold est le seuil de journalisation des requêtes lentes
+	SlowRequestThreshold time.Duration
+	// ConfirmationWindow est la durée de validité des tokens de confirmation
+	ConfirmationWindow time.Duration
+	// RecycleRetention est la durée de séjour dans la corbeille avant purge
+	RecycleRetention time.Duration
+	// DeviceVerificationURI est la page où l'utilisateur approuve un appareil
+	DeviceVerificationURI string
+	// InvitationAcceptURI est la page où l'invité accepte une invitation
+	InvitationAcceptURI string
+	// EmailVerificationURI est la page où l'utilisateur confirme son adresse email
+	EmailVerificationURI string
+	// TrialPlanID, TrialDuration et TrialSecretsLimit décrivent l'abonnement
+	// d'essai des organisations créées par l'API
+	TrialPlanID       string
+	TrialDuration     time.Duration
+	TrialSecretsLimit int
+}
+
+// Durée pendant laquelle les clients réutilisent une réponse de métadonnées
+// sans la revalider
+const metadataMaxAge = 30 * time.Second
+
 // ConfigureRoutes configure les routes de l'API
-func ConfigureRoutes(
-	router *mux.Router,
-	vaultService *vault.Service,
-	authService *auth.Service,
-) {
+func ConfigureRoutes(router *mux.Router, deps *Dependencies) {
 	// Middleware pour toutes les routes
 	router.Use(middleware.Logger)
 	router.Use(middleware.Recover)
+	router.Use(middleware.SlowRequests(deps.SlowRequestThreshold))
+	router.Use(middleware.Maintenance(deps.Switches))
+
+	// Les modifications publiées sur le bus invalident le cache HTTP et les
+	// rôles embarqués dans les tokens d'accès
+	validators := middleware.NewCacheValidators(deps.Events)
+	users := middleware.NewClaimedRoles(deps.Users, validators)
+	permissions := auth.NewPermissionService(users, deps.Teams, deps.CustomRoles)
 
 	// Gestionnaires
-	secretsHandler := handlers.NewSecretsHandler(vaultService)
-	authHandler := handlers.NewAuthHandler(authService)
+	confirmer := handlers.NewConfirmer(deps.Confirmations, deps.ConfirmationWindow)
+	secretsHandler := handlers.NewSecretsHandler(deps.VaultService, users, deps.Secrets, deps.Projects, deps.Teams,
+		deps.CustomRoles, confirmer, deps.Checksummer, deps.Notifier, deps.SecretReads, deps.ScheduledSecretChanges,
+		deps.ReadReasonPolicies, deps.AuditLogs)
+	organizationsHandler := handlers.NewOrganizationsHandler(deps.Organizations, deps.OrganizationDeletions,
+		deps.OrganizationDeleter, users, confirmer, handlers.TrialPolicy{
+			PlanID:       deps.TrialPlanID,
+			Duration:     deps.TrialDuration,
+			SecretsLimit: deps.TrialSecretsLimit,
+		}, deps.Onboarding)
+	projectsHandler := handlers.NewProjectsHandler(deps.Projects, users, deps.Teams, confirmer, deps.RecycleRetention)
+	teamsHandler := handlers.NewTeamsHandler(deps.Teams, deps.Projects, users)
+	permissionsHandler := handlers.NewPermissionsHandler(users, deps.Projects, deps.Teams, deps.CustomRoles,
+		deps.Secrets, deps.ServiceAccounts)
+	customRolesHandler := handlers.NewCustomRolesHandler(deps.CustomRoles, deps.Organizations, users)
+	authHandler := handlers.NewAuthHandler(deps.AuthService)
+	captchaHandler := handlers.NewCaptchaHandler(deps.Captcha)
+	deviceAuthHandler := handlers.NewDeviceAuthHandler(deps.AuthService, deps.DeviceAuthorizations,
+		deps.DeviceVerificationURI)
+	tokensHandler := handlers.NewTokensHandler(deps.PersonalAccessTokens)
+	trustedDevicesHandler := handlers.NewTrustedDevicesHandler(deps.TrustedDevices)
+	apiKeysHandler := handlers.NewAPIKeysHandler(deps.APIKeys, deps.Projects, users, deps.Transit)
+	serviceAccountsHandler := handlers.NewServiceAccountsHandler(deps.AuthService, deps.ServiceAccounts, deps.Projects, users,
+		deps.SettingsHistory)
+	loginAlertsHandler := handlers.NewLoginAlertsHandler(deps.LoginEvents, deps.LoginAlertPolicies, users,
+		deps.SettingsHistory)
+	sessionPolicyHandler := handlers.NewSessionPolicyHandler(deps.SessionPolicies, users, deps.SettingsHistory)
+	passwordPolicyHandler := handlers.NewPasswordPolicyHandler(deps.AuthService, deps.PasswordPolicies, users,
+		deps.SettingsHistory)
+	readReasonsHandler := handlers.NewReadReasonsHandler(deps.ReadReasonPolicies, deps.AuditLogs, users,
+		deps.SettingsHistory, permissions)
+	projectTemplatesHandler := handlers.NewProjectTemplatesHandler(secretsHandler, deps.ProjectTemplates, users,
+		deps.SettingsHistory)
+	invitationsHandler := handlers.NewInvitationsHandler(deps.Invitations, deps.Organizations, users,
+		deps.AuthService, deps.Mailer, deps.Notifier, deps.Events, deps.InvitationAcceptURI, permissions)
+	onboardingHandler := handlers.NewOnboardingHandler(deps.Onboarding, deps.AuthService, users, deps.Mailer,
+		deps.EmailVerificationURI)
+	introspectionHandler := handlers.NewIntrospectionHandler(deps.AuthService, deps.PersonalAccessTokens)
+	usageHandler := handlers.NewUsageHandler(deps.Usage, users)
+	accessReviewsHandler := handlers.NewAccessReviewsHandler(deps.AccessReviews, deps.Organizations, users, deps.Usage,
+		permissions)
+	evidenceHandler := handlers.NewEvidenceHandler(deps.AccessReviews, deps.Organizations, users, deps.Secrets,
+		deps.Usage, deps.AdminAudit, deps.EvidenceSigner, handlers.EvidencePolicies{
+			ConfirmationWindow: deps.ConfirmationWindow,
+			RecycleRetention:   deps.RecycleRetention,
+		}, permissions)
+	residencyHandler := handlers.NewResidencyHandler(deps.Organizations, users, deps.VaultRouter, deps.SettingsHistory)
+	encryptionKeysHandler := handlers.NewEncryptionKeysHandler(deps.Projects, users, deps.Teams)
+	notificationsHandler := handlers.NewNotificationsHandler(deps.NotificationPreferences)
+	rotationHandler := handlers.NewRotationHandler(secretsHandler, deps.SecretRotators, deps.Rotators, deps.Webhooks)
+	bulkRotationsHandler := handlers.NewBulkRotationsHandler(deps.BulkRotations, deps.BulkRotator, users, deps.Rotators)
+	lockdownHandler := handlers.NewLockdownHandler(deps.Lockdowns, deps.Organizations, users, deps.PersonalAccessTokens,
+		deps.APIKeys, deps.ServiceAccounts, deps.SecretReads, deps.AuthService)
+	webhooksHandler := handlers.NewWebhooksHandler(deps.Webhooks, users, deps.WebhookSender, deps.SettingsHistory)
+	maintenanceHandler := handlers.NewMaintenanceWindowsHandler(deps.MaintenanceWindows, users, deps.SettingsHistory)
+	settingsHistoryHandler := handlers.NewSettingsHistoryHandler(deps.SettingsHistory, users)
+	dataKeysHandler := handlers.NewDataKeysHandler(deps.DataKeys, deps.Transit, users, deps.Projects, deps.Teams)
+	organizationMetricsHandler := handlers.NewOrganizationMetricsHandler(users, deps.Projects, deps.Secrets,
+		deps.SecretReads)
+	lookupHandler := handlers.NewLookupHandler(secretsHandler, deps.Events)
+	ageKeysHandler := handlers.NewAgeKeysHandler(deps.AgeKeys, deps.VaultService, users, deps.Projects, deps.Teams)
+	calendarHandler := handlers.NewCalendarHandler(deps.SecretRotators, deps.ScheduledSecretChanges, deps.Subscriptions,
+		users, deps.Projects)
+	eventsHandler := handlers.NewEventsHandler()
+	graphQLHandler := handlers.NewGraphQLHandler(users, deps.Organizations, deps.Projects, deps.Secrets,
+		deps.Usage, deps.NotificationEvents)
+	versionHandler := handlers.NewVersionHandler([]string{"mysql", "vault"})
+	statusHandler := handlers.NewStatusHandler(deps.Health)
+	logForwardersHandler := handlers.NewLogForwardersHandler(deps.LogForwarders, deps.Organizations, users,
+		deps.AuditForwarder, deps.SettingsHistory)
+
+	// Cache HTTP des métadonnées, invalidé par les modifications publiées sur le bus.
+	// Les listes et exports volumineux sont compressés (jamais les valeurs des secrets).
+	cacheable := func(resource string, h http.HandlerFunc) http.Handler {
+		return middleware.Compress(middleware.Cacheable(validators, resource, metadataMaxAge)(h))
+	}
+	compressed := func(h http.HandlerFunc) http.Handler {
+		return middleware.Compress(h)
+	}
+	invalidates := func(h http.HandlerFunc, resources ...string) http.Handler {
+		return middleware.Invalidates(deps.Events, resources...)(h)
+	}
+	// Étapes de la prise en main franchies par une création réussie
+	onboards := func(step string, h http.Handler) http.Handler {
+		return middleware.CompletesOnboarding(deps.Onboarding, step)(h)
+	}
+	// Fonctionnalités pouvant être coupées pendant un incident
+	feature := func(name string, h http.Handler) http.Handler {
+		return middleware.Feature(deps.Switches, name)(h)
+	}
+
+	// Chaque route est servie par toutes les versions de l'API (/api/v1,
+	// /api/v2) sauf restriction par versioning.Since ou versioning.Until.
+	// À partir de la v2, les erreurs sont renvoyées dans une enveloppe JSON.
+	publicRouter := versioning.NewRouter()
+	apiRouter := versioning.NewRouter()
+	for _, v := range versioning.Versions {
+		public := router.PathPrefix(v.Prefix()).Subrouter()
+		protected := router.PathPrefix(v.Prefix()).Subrouter()
+		if v >= versioning.V2 {
+			public.Use(versioning.ErrorEnvelope)
+			protected.Use(versioning.ErrorEnvelope)
+		}
+		publicRouter.Mount(v, public)
+		apiRouter.Mount(v, protected)
+	}
+	if deps.V1Deprecation != nil {
+		publicRouter.Deprecate(versioning.V1, *deps.V1Deprecation)
+		apiRouter.Deprecate(versioning.V1, *deps.V1Deprecation)
+	}
+
+	// Clés publiques de vérification des tokens (JWKS), hors versions de l'API
+	router.HandleFunc("/.well-known/jwks.json", authHandler.JWKS).Methods("GET")
+
+	// Version du serveur (non protégée, utilisée par la CLI pour vérifier la compatibilité)
+	publicRouter.HandleFunc("/version", versionHandler.GetVersion).Methods("GET")
 
-	// Routes d'authentification (non protégées)
-	router.HandleFunc("/api/v1/auth/login", authHandler.Login).Methods("POST")
-	router.HandleFunc("/api/v1/auth/register", authHandler.Register).Methods("POST")
+	// Données de la page de statut publique : disponibilité des composants et incidents
+	publicRouter.HandleFunc("/status", statusHandler.GetStatus).Methods("GET")
+
+	// Routes d'authentification (non protégées), limitées par adresse IP et
+	// par email
+	limited := func(h http.HandlerFunc) http.Handler { return deps.AuthLimiter.Limit(h) }
+	publicRouter.Handle("/auth/login", limited(authHandler.Login)).Methods("POST")
+	publicRouter.Handle("/auth/register",
+		middleware.RequireCaptcha(deps.Captcha)(limited(authHandler.Register))).Methods("POST")
+	publicRouter.Handle("/auth/mfa/verify", limited(authHandler.VerifyMFA)).Methods("POST")
+	publicRouter.Handle("/auth/refresh", limited(authHandler.Refresh)).Methods("POST")
+	publicRouter.HandleFunc("/auth/logout", authHandler.Logout).Methods("POST")
+	publicRouter.Handle("/auth/password:change", limited(authHandler.ChangePassword)).Methods("POST")
+
+	// Défi (CAPTCHA ou preuve de travail) à résoudre avant l'inscription
+	publicRouter.HandleFunc("/auth/captcha", captchaHandler.GetChallenge).Methods("GET")
+
+	// Connexion des appareils sans navigateur (CLI) : demande de code et
+	// interrogation, puis approbation depuis une session authentifiée
+	publicRouter.HandleFunc("/auth/device/code", deviceAuthHandler.RequestCode).Methods("POST")
+	publicRouter.HandleFunc("/auth/device/token", deviceAuthHandler.PollToken).Methods("POST")
+
+	// Échange du secret d'un compte de service contre un token d'accès
+	// (client_credentials)
+	publicRouter.Handle("/auth/token", limited(serviceAccountsHandler.IssueToken)).Methods("POST")
+
+	// Acceptation d'une invitation avec le token reçu par email : rattache
+	// le compte de l'adresse invitée ou le crée
+	publicRouter.Handle("/invitations/accept", limited(invitationsHandler.AcceptInvitation)).Methods("POST")
+
+	// Confirmation de l'adresse email avec le token reçu par email
+	if deps.Onboarding != nil {
+		publicRouter.Handle("/auth/email:verify", limited(onboardingHandler.VerifyEmail)).Methods("POST")
+	}
+
+	// Introspection des tokens (RFC 7662) par les services internes,
+	// authentifiés par leurs identifiants de client
+	publicRouter.Handle("/auth/introspect", middleware.ClientCredentials(deps.IntrospectionClients)(
+		http.HandlerFunc(introspectionHandler.Introspect))).Methods("POST")
 
 	// Routes API protégées
-	apiRouter := router.PathPrefix("/api/v1").Subrouter()
-	apiRouter.Use(middleware.JWTAuth(authService))
+	apiRouter.Use(middleware.JWTAuth(deps.AuthService, deps.PersonalAccessTokens, deps.APIKeys,
+		deps.Transit))
+	apiRouter.Use(middleware.ImpersonationAudit(deps.AdminAudit))
+	apiRouter.Use(middleware.RestrictAPIKeys)
+	apiRouter.Use(middleware.RestrictServiceAccounts)
+	apiRouter.Use(middleware.RequireTokenScopes)
+	apiRouter.Use(middleware.UsageTracking(deps.Usage))
+	apiRouter.Use(middleware.AuditForwarding(deps.AuditForwarder))
+	apiRouter.Use(middleware.LockdownFreeze(deps.Lockdowns))
+
+	// Approbation ou refus d'un appareil par l'utilisateur connecté
+	apiRouter.HandleFunc("/auth/device:approve", deviceAuthHandler.ApproveDevice).Methods("POST")
+	apiRouter.HandleFunc("/auth/device:deny", deviceAuthHandler.DenyDevice).Methods("POST")
+
+	// Inscription, activation et désactivation de l'authentification
+	// multifacteur (TOTP) de l'utilisateur connecté
+	apiRouter.HandleFunc("/auth/mfa/enroll", authHandler.EnrollMFA).Methods("POST")
+	apiRouter.HandleFunc("/auth/mfa/activate", authHandler.ActivateMFA).Methods("POST")
+	apiRouter.HandleFunc("/auth/mfa/disable", authHandler.DisableMFA).Methods("POST")
+
+	// Sessions actives de l'utilisateur connecté (une par connexion), gérables
+	// uniquement depuis une session
+	apiRouter.HandleFunc("/auth/sessions", authHandler.ListSessions).Methods("GET")
+	apiRouter.HandleFunc("/auth/sessions", authHandler.RevokeOtherSessions).Methods("DELETE")
+	apiRouter.HandleFunc("/auth/sessions/{sessionID}", authHandler.RevokeSession).Methods("DELETE")
+
+	// Appareils de confiance de l'utilisateur connecté (remember_device lors
+	// de /auth/mfa/verify), gérables uniquement depuis une session
+	apiRouter.HandleFunc("/me/devices", trustedDevicesHandler.ListDevices).Methods("GET")
+	apiRouter.HandleFunc("/me/devices", trustedDevicesHandler.RevokeAllDevices).Methods("DELETE")
+	apiRouter.HandleFunc("/me/devices/{deviceID}", trustedDevicesHandler.RevokeDevice).Methods("DELETE")
+
+	// Dernières connexions de l'utilisateur connecté et politique d'alerte
+	// des connexions inhabituelles de l'organisation
+	apiRouter.HandleFunc("/me/logins", loginAlertsHandler.ListLogins).Methods("GET")
+	apiRouter.HandleFunc("/organizations/{orgID}/login-alerts", loginAlertsHandler.GetPolicy).Methods("GET")
+	apiRouter.HandleFunc("/organizations/{orgID}/login-alerts", loginAlertsHandler.UpdatePolicy).Methods("PUT")
+	apiRouter.HandleFunc("/organizations/{orgID}/session-policy", sessionPolicyHandler.GetPolicy).Methods("GET")
+	apiRouter.HandleFunc("/organizations/{orgID}/session-policy", sessionPolicyHandler.UpdatePolicy).Methods("PUT")
+
+	// Politique de mot de passe des membres de l'organisation (longueur,
+	// classes de caractères, fuites connues, réutilisation)
+	apiRouter.HandleFunc("/organizations/{orgID}/password-policy", passwordPolicyHandler.GetPolicy).Methods("GET")
+	apiRouter.HandleFunc("/organizations/{orgID}/password-policy", passwordPolicyHandler.UpdatePolicy).Methods("PUT")
+
+	// Tokens d'accès personnels de l'utilisateur connecté (scripts agissant en
+	// son nom), gérables uniquement depuis une session
+	apiRouter.HandleFunc("/me/tokens", tokensHandler.ListTokens).Methods("GET")
+	apiRouter.HandleFunc("/me/tokens", tokensHandler.CreateToken).Methods("POST")
+	apiRouter.HandleFunc("/me/tokens/{tokenID}", tokensHandler.RevokeToken).Methods("DELETE")
+
+	// Usurpations de l'identité de l'utilisateur connecté par le support et
+	// requêtes faites en son nom, consultables et terminables uniquement
+	// depuis une session
+	if deps.Impersonations != nil {
+		impersonationsHandler := handlers.NewImpersonationsHandler(deps.AuthService, deps.Impersonations, deps.AdminAudit)
+		apiRouter.HandleFunc("/me/impersonations", impersonationsHandler.ListMyImpersonations).Methods("GET")
+		apiRouter.HandleFunc("/me/impersonations/{impersonationID}/requests",
+			impersonationsHandler.ListMyImpersonationRequests).Methods("GET")
+		apiRouter.HandleFunc("/me/impersonations/{impersonationID}",
+			impersonationsHandler.EndMyImpersonation).Methods("DELETE")
+	}
+
+	// Clés d'API de l'organisation (pipelines CI), restreintes aux secrets
+	// d'un projet et d'un environnement
+	apiRouter.HandleFunc("/organizations/{orgID}/api-keys", apiKeysHandler.ListAPIKeys).Methods("GET")
+	apiRouter.HandleFunc("/organizations/{orgID}/api-keys", apiKeysHandler.CreateAPIKey).Methods("POST")
+	apiRouter.HandleFunc("/organizations/{orgID}/api-keys/{keyID}", apiKeysHandler.RevokeAPIKey).Methods("DELETE")
 
-	// Routes pour les secrets
-	apiRouter.HandleFunc("/organizations/{orgID}/projects/{projectID}/environments/{env}/secrets",
+	// Comptes de service de l'organisation : leurs tokens portent leur propre
+	// identité et les projets et environnements auxquels ils ont accès. Un
+	// identifiant SPIFFE, dans le domaine de confiance de l'organisation, les
+	// authentifie aussi par certificat client sur le listener mTLS.
+	apiRouter.HandleFunc("/organizations/{orgID}/service-accounts", serviceAccountsHandler.ListServiceAccounts).Methods("GET")
+	apiRouter.HandleFunc("/organizations/{orgID}/service-accounts", serviceAccountsHandler.CreateServiceAccount).Methods("POST")
+	apiRouter.HandleFunc("/organizations/{orgID}/service-accounts/{accountID}", serviceAccountsHandler.DeleteServiceAccount).Methods("DELETE")
+	apiRouter.HandleFunc("/organizations/{orgID}/service-accounts/{accountID}/spiffe-id",
+		serviceAccountsHandler.UpdateSPIFFEID).Methods("PUT")
+	apiRouter.HandleFunc("/organizations/{orgID}/service-account-trust", serviceAccountsHandler.GetTrust).Methods("GET")
+	apiRouter.HandleFunc("/organizations/{orgID}/service-account-trust", serviceAccountsHandler.UpdateTrust).Methods("PUT")
+
+	// Routes pour les secrets : les lecteurs (viewer) les consultent, les
+	// modifications sont réservées aux membres
+	secretsRouter := apiRouter.PathPrefix("/organizations/{orgID}/projects/{projectID}/environments/{env}")
+	secretsRouter.Use(middleware.RequireRole(permissions, auth.RoleMember))
+	secretsRouter.HandleFunc("/secrets",
 		secretsHandler.ListSecrets).Methods("GET")
-	apiRouter.HandleFunc("/organizations/{orgID}/projects/{projectID}/environments/{env}/secrets",
-		secretsHandler.CreateSecret).Methods("POST")
-	apiRouter.HandleFunc("/organizations/{orgID}/projects/{projectID}/environments/{env}/secrets/{name}",
+	secretsRouter.Handle("/secrets", onboards(models.OnboardingSecretStored,
+		invalidates(secretsHandler.CreateSecret, events.ResourceSecrets, events.ResourceProjects))).Methods("POST")
+	secretsRouter.Handle("/secrets:metadata",
+		cacheable(events.ResourceSecrets, secretsHandler.ListSecretsMetadata)).Methods("GET")
+	secretsRouter.Handle("/secrets:bulkDelete",
+		invalidates(secretsHandler.BulkDeleteSecrets, events.ResourceSecrets, events.ResourceProjects)).Methods("POST")
+	secretsRouter.HandleFunc("/secrets/{name}",
 		secretsHandler.GetSecret).Methods("GET")
-	apiRouter.HandleFunc("/organizations/{orgID}/projects/{projectID}/environments/{env}/secrets/{name}",
-		secretsHandler.DeleteSecret).Methods("DELETE")
+	secretsRouter.Handle("/secrets/{name}",
+		invalidates(secretsHandler.UpdateSecret, events.ResourceSecrets, events.ResourceProjects)).Methods("PUT")
+	secretsRouter.HandleFunc("/secrets/{name}/metadata",
+		secretsHandler.GetSecretMetadata).Methods("GET")
+	secretsRouter.HandleFunc("/secrets/{name}/preview",
+		secretsHandler.PreviewSecret).Methods("GET")
+	secretsRouter.Handle("/secrets/{name}",
+		invalidates(secretsHandler.DeleteSecret, events.ResourceSecrets, events.ResourceProjects)).Methods("DELETE")
+	secretsRouter.HandleFunc("/secrets/{name}/versions:diff",
+		secretsHandler.DiffSecretVersions).Methods("GET")
+	secretsRouter.Handle("/secrets/{name}/consumers",
+		compressed(secretsHandler.ListConsumers)).Methods("GET")
+	secretsRouter.HandleFunc("/secrets/{name}/scheduled",
+		secretsHandler.GetScheduledChange).Methods("GET")
+	secretsRouter.HandleFunc("/secrets/{name}/scheduled",
+		secretsHandler.CancelScheduledChange).Methods("DELETE")
+	secretsRouter.HandleFunc("/secrets/{name}/rotate:dry-run",
+		rotationHandler.DryRun).Methods("POST")
+	secretsRouter.Handle("/secrets/{name}/rotate",
+		invalidates(rotationHandler.Rotate, events.ResourceSecrets, events.ResourceProjects)).Methods("POST")
+	secretsRouter.HandleFunc("/secrets/{name}/rotator",
+		rotationHandler.GetRotator).Methods("GET")
+	secretsRouter.HandleFunc("/secrets/{name}/rotator",
+		rotationHandler.SetRotator).Methods("PUT")
+	secretsRouter.HandleFunc("/secrets/{name}/rotator",
+		rotationHandler.DeleteRotator).Methods("DELETE")
+	secretsRouter.Handle("/secrets/{name}/ownership",
+		invalidates(secretsHandler.SetSecretOwnership, events.ResourceSecrets)).Methods("PUT")
+	secretsRouter.Handle("/secrets/{name}/lock",
+		invalidates(secretsHandler.LockSecret, events.ResourceSecrets)).Methods("POST")
+	secretsRouter.Handle("/secrets/{name}/unlock",
+		invalidates(secretsHandler.UnlockSecret, events.ResourceSecrets)).Methods("POST")
+	// README markdown de l'environnement, renvoyé avec la liste des projets
+	secretsRouter.HandleFunc("/readme", projectsHandler.GetReadme).Methods("GET")
+	secretsRouter.Handle("/readme",
+		invalidates(projectsHandler.SetReadme, events.ResourceProjects)).Methods("PUT")
+	secretsRouter.Handle("/readme",
+		invalidates(projectsHandler.DeleteReadme, events.ResourceProjects)).Methods("DELETE")
+
+	// Rotation groupée des secrets de l'organisation, exécutée en tâche de fond
+	apiRouter.HandleFunc("/organizations/{orgID}/secrets:bulkRotate",
+		bulkRotationsHandler.BulkRotate).Methods("POST")
+	apiRouter.HandleFunc("/organizations/{orgID}/bulk-rotations/{rotationID}",
+		bulkRotationsHandler.GetBulkRotation).Methods("GET")
+
+	// Confinement de l'organisation après une compromission : révocation des
+	// accès, gel des écritures et rapport d'accès
+	apiRouter.HandleFunc("/organizations/{orgID}/lockdown", lockdownHandler.StartLockdown).Methods("POST")
+	apiRouter.HandleFunc("/organizations/{orgID}/lockdown", lockdownHandler.GetLockdown).Methods("GET")
+	apiRouter.HandleFunc("/organizations/{orgID}/lockdown:lift", lockdownHandler.LiftLockdown).Methods("POST")
+
+	// Hygiène des valeurs des secrets du projet (longueur, entropie, réutilisation, âge)
+	// et valeurs partagées entre les secrets de l'organisation
+	apiRouter.HandleFunc("/organizations/{orgID}/projects/{projectID}/hygiene",
+		secretsHandler.GetHygieneReport).Methods("GET")
+	apiRouter.HandleFunc("/organizations/{orgID}/hygiene/duplicates",
+		secretsHandler.GetDuplicatesReport).Methods("GET")
+
+	// Charges de travail qui lisent les secrets du projet (en-tête X-Workload)
+	apiRouter.Handle("/organizations/{orgID}/projects/{projectID}/dependencies",
+		compressed(secretsHandler.GetDependencyGraph)).Methods("GET")
+
+	// Chiffrement de bout en bout : clé publique du projet
+	apiRouter.HandleFunc("/organizations/{orgID}/projects/{projectID}/encryption-key",
+		encryptionKeysHandler.GetEncryptionKey).Methods("GET")
+	apiRouter.HandleFunc("/organizations/{orgID}/projects/{projectID}/encryption-key",
+		encryptionKeysHandler.SetEncryptionKey).Methods("PUT")
+
+	// Clés de données : chiffrement d'enveloppe de petits contenus (états
+	// Terraform, fichiers sops), audité à chaque appel
+	apiRouter.HandleFunc("/organizations/{orgID}/projects/{projectID}/data-keys",
+		dataKeysHandler.ListDataKeys).Methods("GET")
+	apiRouter.HandleFunc("/organizations/{orgID}/projects/{projectID}/data-keys",
+		dataKeysHandler.CreateDataKey).Methods("POST")
+	apiRouter.HandleFunc("/organizations/{orgID}/projects/{projectID}/data-keys/{name}:encrypt",
+		dataKeysHandler.Encrypt).Methods("POST")
+	apiRouter.HandleFunc("/organizations/{orgID}/projects/{projectID}/data-keys/{name}:decrypt",
+		dataKeysHandler.Decrypt).Methods("POST")
+	apiRouter.Handle("/organizations/{orgID}/projects/{projectID}/data-keys/{name}/operations",
+		compressed(dataKeysHandler.ListOperations)).Methods("GET")
+
+	// Lookup des outils de gestion de configuration (Ansible, Chef) : un
+	// secret par chemin, avec attente longue des modifications
+	apiRouter.HandleFunc("/lookup", lookupHandler.Lookup).Methods("GET")
+
+	// Clés age (sops) des dépôts de code : destinataires, rotation et
+	// identités servies à sops exec-env
+	apiRouter.HandleFunc("/organizations/{orgID}/projects/{projectID}/age-keys",
+		ageKeysHandler.ListAgeKeys).Methods("GET")
+	apiRouter.HandleFunc("/organizations/{orgID}/projects/{projectID}/age-keys",
+		ageKeysHandler.CreateAgeKey).Methods("POST")
+	apiRouter.HandleFunc("/organizations/{orgID}/projects/{projectID}/age-keys/{repository}:rotate",
+		ageKeysHandler.RotateAgeKey).Methods("POST")
+	apiRouter.HandleFunc("/organizations/{orgID}/projects/{projectID}/age-keys/{repository}/identities",
+		ageKeysHandler.GetIdentities).Methods("GET")
+	apiRouter.HandleFunc("/organizations/{orgID}/projects/{projectID}/age-keys/{repository}/{keyID}",
+		ageKeysHandler.DeleteAgeKey).Methods("DELETE")
+
+	// Réglages de notification de l'utilisateur connecté
+	apiRouter.HandleFunc("/users/me/notification-preferences", notificationsHandler.GetPreferences).Methods("GET")
+	apiRouter.HandleFunc("/users/me/notification-preferences", notificationsHandler.UpdatePreferences).Methods("PUT")
+
+	// Fenêtres de maintenance des environnements protégés
+	apiRouter.HandleFunc("/organizations/{orgID}/maintenance-windows",
+		maintenanceHandler.ListWindows).Methods("GET")
+	apiRouter.HandleFunc("/organizations/{orgID}/maintenance-windows",
+		maintenanceHandler.CreateWindow).Methods("POST")
+	apiRouter.HandleFunc("/organizations/{orgID}/maintenance-windows/{windowID}",
+		maintenanceHandler.DeleteWindow).Methods("DELETE")
+
+	// Calendrier des échéances à venir (JSON et flux iCalendar)
+	apiRouter.Handle("/organizations/{orgID}/calendar", compressed(calendarHandler.GetCalendar)).Methods("GET")
+	apiRouter.Handle("/organizations/{orgID}/calendar.ics", compressed(calendarHandler.GetCalendarICS)).Methods("GET")
+
+	// Métriques d'usage de l'organisation au format Prometheus, collectées
+	// par la supervision des clients
+	apiRouter.Handle("/organizations/{orgID}/metrics", compressed(organizationMetricsHandler.GetMetrics)).Methods("GET")
+
+	// Webhooks de l'organisation : événement de test, historique et rejeu des livraisons
+	apiRouter.HandleFunc("/organizations/{orgID}/webhooks", webhooksHandler.ListWebhooks).Methods("GET")
+	apiRouter.HandleFunc("/organizations/{orgID}/webhooks", webhooksHandler.CreateWebhook).Methods("POST")
+	apiRouter.Handle("/organizations/{orgID}/webhooks/{webhookID}:test",
+		feature(middleware.FeatureWebhooks, http.HandlerFunc(webhooksHandler.TestWebhook))).Methods("POST")
+	apiRouter.Handle("/organizations/{orgID}/webhooks/{webhookID}/deliveries",
+		compressed(webhooksHandler.ListDeliveries)).Methods("GET")
+	apiRouter.HandleFunc("/organizations/{orgID}/webhooks/{webhookID}", webhooksHandler.DeleteWebhook).Methods("DELETE")
+	apiRouter.Handle("/organizations/{orgID}/deliveries/{deliveryID}:redeliver",
+		feature(middleware.FeatureWebhooks, http.HandlerFunc(webhooksHandler.Redeliver))).Methods("POST")
+	apiRouter.Handle("/events/schemas", cacheable("", eventsHandler.ListSchemas)).Methods("GET")
+
+	// GraphQL (lecture seule) pour les clients du tableau de bord
+	apiRouter.Handle("/graphql", feature(middleware.FeatureGraphQL, compressed(graphQLHandler.Query))).Methods("POST")
+
+	// Usage de l'API par principal, route et jour
+	apiRouter.Handle("/organizations/{orgID}/usage/breakdown",
+		compressed(usageHandler.GetBreakdown)).Methods("GET")
+
+	// Création d'une organisation avec ses ressources de départ
+	apiRouter.HandleFunc("/organizations", organizationsHandler.CreateOrganization).Methods("POST")
+
+	// Listes des membres et des projets d'une organisation
+	apiRouter.Handle("/organizations/{orgID}/members",
+		cacheable(events.ResourceOrganization, organizationsHandler.ListMembers)).Methods("GET")
+	apiRouter.Handle("/organizations/{orgID}/members:search",
+		compressed(organizationsHandler.SearchMembers)).Methods("GET")
+	// Accès effectif d'un membre et règles qui le déterminent (?project=&env=&secret=)
+	apiRouter.HandleFunc("/organizations/{orgID}/members/{userID}/permissions",
+		permissionsHandler.GetMemberPermissions).Methods("GET")
+	// Simulation d'une décision (principal, action, ressource), avant
+	// d'accorder un rôle
+	apiRouter.HandleFunc("/organizations/{orgID}/permissions:simulate",
+		permissionsHandler.SimulatePermission).Methods("POST")
+	apiRouter.Handle("/organizations/{orgID}/projects",
+		cacheable(events.ResourceProjects, projectsHandler.ListProjects)).Methods("GET")
+	// Création d'un projet selon le modèle de l'organisation (environnements,
+	// règle de nommage, secrets à renseigner)
+	apiRouter.Handle("/organizations/{orgID}/projects", onboards(models.OnboardingProjectCreated,
+		invalidates(projectTemplatesHandler.CreateProject, events.ResourceProjects, events.ResourceSecrets))).
+		Methods("POST")
+	apiRouter.HandleFunc("/organizations/{orgID}/project-template",
+		projectTemplatesHandler.GetTemplate).Methods("GET")
+	apiRouter.HandleFunc("/organizations/{orgID}/project-template",
+		projectTemplatesHandler.UpdateTemplate).Methods("PUT")
+
+	// Équipes de l'organisation et leurs membres (gérés par les
+	// administrateurs) ; une équipe supprimée n'est plus responsable de rien
+	apiRouter.HandleFunc("/organizations/{orgID}/teams", teamsHandler.ListTeams).Methods("GET")
+	apiRouter.HandleFunc("/organizations/{orgID}/teams", teamsHandler.CreateTeam).Methods("POST")
+	apiRouter.HandleFunc("/organizations/{orgID}/teams:mine", teamsHandler.ListMyTeams).Methods("GET")
+	apiRouter.HandleFunc("/organizations/{orgID}/teams/{teamID}", teamsHandler.GetTeam).Methods("GET")
+	apiRouter.Handle("/organizations/{orgID}/teams/{teamID}",
+		invalidates(teamsHandler.DeleteTeam, events.ResourceProjects, events.ResourceSecrets)).Methods("DELETE")
+	apiRouter.HandleFunc("/organizations/{orgID}/teams/{teamID}/members/{userID}",
+		teamsHandler.AddTeamMember).Methods("PUT")
+	apiRouter.HandleFunc("/organizations/{orgID}/teams/{teamID}/members/{userID}",
+		teamsHandler.RemoveTeamMember).Methods("DELETE")
+
+	// Rôles accordés aux équipes sur un projet ou l'un de ses environnements :
+	// leurs membres en héritent s'ils dépassent leur rôle dans l'organisation
+	apiRouter.Handle("/organizations/{orgID}/teams/{teamID}/projects/{projectID}/role",
+		invalidates(teamsHandler.SetTeamGrant, events.ResourceProjects, events.ResourceSecrets)).Methods("PUT")
+	apiRouter.Handle("/organizations/{orgID}/teams/{teamID}/projects/{projectID}/role",
+		invalidates(teamsHandler.RemoveTeamGrant, events.ResourceProjects, events.ResourceSecrets)).Methods("DELETE")
+	apiRouter.Handle("/organizations/{orgID}/teams/{teamID}/projects/{projectID}/environments/{env}/role",
+		invalidates(teamsHandler.SetTeamGrant, events.ResourceProjects, events.ResourceSecrets)).Methods("PUT")
+	apiRouter.Handle("/organizations/{orgID}/teams/{teamID}/projects/{projectID}/environments/{env}/role",
+		invalidates(teamsHandler.RemoveTeamGrant, events.ResourceProjects, events.ResourceSecrets)).Methods("DELETE")
+
+	// Rôles personnalisés (plan Enterprise) : permissions fines attribuées à
+	// un membre en plus de son rôle dans l'organisation
+	apiRouter.HandleFunc("/organizations/{orgID}/roles", customRolesHandler.ListCustomRoles).Methods("GET")
+	apiRouter.HandleFunc("/organizations/{orgID}/roles", customRolesHandler.CreateCustomRole).Methods("POST")
+	apiRouter.HandleFunc("/organizations/{orgID}/roles/{roleID}", customRolesHandler.GetCustomRole).Methods("GET")
+	apiRouter.Handle("/organizations/{orgID}/roles/{roleID}",
+		invalidates(customRolesHandler.UpdateCustomRole, events.ResourceProjects, events.ResourceSecrets)).Methods("PUT")
+	apiRouter.Handle("/organizations/{orgID}/roles/{roleID}",
+		invalidates(customRolesHandler.DeleteCustomRole, events.ResourceProjects, events.ResourceSecrets)).Methods("DELETE")
+	apiRouter.Handle("/organizations/{orgID}/members/{userID}/custom-role",
+		invalidates(customRolesHandler.AssignCustomRole, events.ResourceProjects, events.ResourceSecrets)).Methods("PUT")
+	apiRouter.Handle("/organizations/{orgID}/members/{userID}/custom-role",
+		invalidates(customRolesHandler.UnassignCustomRole, events.ResourceProjects,
+			events.ResourceSecrets)).Methods("DELETE")
+
+	// Responsables (membre et/ou équipe) du projet ; ceux des secrets sont
+	// sous /environments/{env}/secrets/{name}/ownership
+	apiRouter.Handle("/organizations/{orgID}/projects/{projectID}/ownership",
+		invalidates(projectsHandler.SetProjectOwnership, events.ResourceProjects)).Methods("PUT")
+
+	// Invitations en attente de l'organisation (administrateurs)
+	apiRouter.HandleFunc("/organizations/{orgID}/invitations",
+		invitationsHandler.ListInvitations).Methods("GET")
+	apiRouter.Handle("/organizations/{orgID}/invitations", onboards(models.OnboardingTeammateInvited,
+		http.HandlerFunc(invitationsHandler.CreateInvitation))).Methods("POST")
+	apiRouter.HandleFunc("/organizations/{orgID}/invitations/{invitationID}",
+		invitationsHandler.RevokeInvitation).Methods("DELETE")
+
+	// Prise en main : avancement de l'utilisateur connecté dans
+	// l'organisation (lu par le tableau de bord) et envoi du lien de
+	// confirmation de son adresse email
+	if deps.Onboarding != nil {
+		apiRouter.HandleFunc("/organizations/{orgID}/onboarding", onboardingHandler.GetOnboarding).Methods("GET")
+		apiRouter.HandleFunc("/me/email-verification", onboardingHandler.RequestEmailVerification).Methods("POST")
+	}
+
+	// Campagnes de revue des accès (administrateurs de l'organisation)
+	apiRouter.HandleFunc("/organizations/{orgID}/access-reviews",
+		accessReviewsHandler.CreateAccessReview).Methods("POST")
+	apiRouter.Handle("/organizations/{orgID}/access-reviews",
+		compressed(accessReviewsHandler.ListAccessReviews)).Methods("GET")
+	apiRouter.Handle("/organizations/{orgID}/access-reviews/{reviewID}",
+		compressed(accessReviewsHandler.GetAccessReview)).Methods("GET")
+	apiRouter.Handle("/organizations/{orgID}/access-reviews/{reviewID}/export",
+		feature(middleware.FeatureExports, compressed(accessReviewsHandler.ExportAccessReview))).Methods("GET")
+	apiRouter.HandleFunc("/organizations/{orgID}/access-reviews/{reviewID}/items/{principalID}/confirm",
+		accessReviewsHandler.ConfirmAccess).Methods("POST")
+	apiRouter.Handle("/organizations/{orgID}/access-reviews/{reviewID}/items/{principalID}/revoke",
+		invalidates(accessReviewsHandler.RevokeAccess, events.ResourceOrganization)).Methods("POST")
+
+	// Preuves de conformité (archive ZIP signée) et clé de vérification
+	apiRouter.Handle("/organizations/{orgID}/evidence",
+		feature(middleware.FeatureExports, http.HandlerFunc(evidenceHandler.ExportEvidence))).Methods("GET")
+	apiRouter.HandleFunc("/evidence/public-key", evidenceHandler.GetPublicKey).Methods("GET")
+
+	// Historique des modifications de la configuration de l'organisation
+	apiRouter.Handle("/organizations/{orgID}/settings-history",
+		compressed(settingsHistoryHandler.ListSettingsChanges)).Methods("GET")
+
+	// Environnements protégés, dont la lecture des secrets exige un motif
+	// (paramètre reason), et journal d'audit des lectures motivées
+	apiRouter.HandleFunc("/organizations/{orgID}/read-reasons", readReasonsHandler.GetPolicy).Methods("GET")
+	apiRouter.HandleFunc("/organizations/{orgID}/read-reasons", readReasonsHandler.UpdatePolicy).Methods("PUT")
+	apiRouter.Handle("/organizations/{orgID}/audit-logs",
+		compressed(readReasonsHandler.ListAuditLogs)).Methods("GET")
+
+	// Transfert des journaux d'audit de l'organisation vers ses destinations (HTTPS, S3, syslog)
+	apiRouter.HandleFunc("/organizations/{orgID}/log-forwarders",
+		logForwardersHandler.ListLogForwarders).Methods("GET")
+	apiRouter.HandleFunc("/organizations/{orgID}/log-forwarders",
+		logForwardersHandler.CreateLogForwarder).Methods("POST")
+	apiRouter.HandleFunc("/organizations/{orgID}/log-forwarders/{forwarderID}:test",
+		logForwardersHandler.TestLogForwarder).Methods("POST")
+	apiRouter.HandleFunc("/organizations/{orgID}/log-forwarders/{forwarderID}",
+		logForwardersHandler.DeleteLogForwarder).Methods("DELETE")
+
+	// Région de résidence des secrets de l'organisation
+	apiRouter.Handle("/organizations/{orgID}/residency",
+		cacheable(events.ResourceOrganization, residencyHandler.GetResidency)).Methods("GET")
+	apiRouter.Handle("/organizations/{orgID}/residency",
+		invalidates(residencyHandler.UpdateResidency, events.ResourceOrganization)).Methods("PUT")
+
+	// Opérations destructives (confirmation en deux étapes)
+	apiRouter.Handle("/organizations/{orgID}",
+		invalidates(organizationsHandler.DeleteOrganization, events.ResourceOrganization)).Methods("DELETE")
+	apiRouter.Handle("/organizations/{orgID}/projects/{projectID}",
+		invalidates(projectsHandler.DeleteProject, events.ResourceProjects, events.ResourceSecrets)).Methods("DELETE")
+
+	// README markdown du projet (procédure de rotation, responsables),
+	// renvoyés avec la liste des projets ; ceux des environnements sont
+	// sous /environments/{env}/readme
+	apiRouter.HandleFunc("/organizations/{orgID}/projects/{projectID}/readme",
+		projectsHandler.GetReadme).Methods("GET")
+	apiRouter.Handle("/organizations/{orgID}/projects/{projectID}/readme",
+		invalidates(projectsHandler.SetReadme, events.ResourceProjects)).Methods("PUT")
+	apiRouter.Handle("/organizations/{orgID}/projects/{projectID}/readme",
+		invalidates(projectsHandler.DeleteReadme, events.ResourceProjects)).Methods("DELETE")
+	apiRouter.HandleFunc("/organization-deletions/{deletionID}",
+		organizationsHandler.GetOrganizationDeletion).Methods("GET")
 
 	// Routes pour projets, organisations, etc.
 	// ...
@@ -0,0 +1,335 @@
+// filepath: internal/api/routes_test.go
+
+package api_test
+
+import (
+	"net/http"
+	"testing"
+
+	"secrets-manager/internal/api/apierror"
+	"secrets-manager/internal/api/apitest"
+	"secrets-manager/internal/models"
+)
+
+func TestAuthFlow(t *testing.T) {
+	srv := apitest.NewServer(t)
+
+	srv.Register("alice@example.com", "password123")
+
+	// Un second enregistrement avec le même email est refusé
+	resp := srv.Do(http.MethodPost, "/api/v1/auth/register", "", map[string]string{
+		"email":    "alice@example.com",
+		"password": "other",
+	})
+	apitest.ExpectStatus(t, resp, http.StatusConflict)
+
+	// Mauvais mot de passe
+	resp = srv.Do(http.MethodPost, "/api/v1/auth/login", "", map[string]string{
+		"email":    "alice@example.com",
+		"password": "wrong",
+	})
+	apitest.ExpectStatus(t, resp, http.StatusUnauthorized)
+
+	token := srv.Login("alice@example.com", "password123")
+	if token == "" {
+		t.Fatal("Expected a token, got empty string")
+	}
+}
+
+func TestProtectedRoutesRequireToken(t *testing.T) {
+	srv := apitest.NewServer(t)
+
+	resp := srv.Do(http.MethodGet, "/api/v1/organizations/o/projects/p/environments/dev/secrets", "", nil)
+	apitest.ExpectStatus(t, resp, http.StatusUnauthorized)
+
+	resp = srv.Do(http.MethodGet, "/api/v1/organizations/o/projects/p/environments/dev/secrets", "invalid", nil)
+	apitest.ExpectStatus(t, resp, http.StatusUnauthorized)
+}
+
+func TestSecretCRUD(t *testing.T) {
+	srv := apitest.NewServer(t)
+	userID := srv.Register("bob@example.com", "password123")
+	token := srv.Login("bob@example.com", "password123")
+	org := srv.CreateOrganization("acme", userID)
+
+	base := "/api/v1/organizations/" + org.ID + "/projects/proj1/environments/dev/secrets"
+
+	resp := srv.Do(http.MethodPost, base, token, models.Secret{
+		Name:           "API_KEY",
+		Value:          "abc123",
+		OrganizationID: org.ID,
+		ProjectID:      "proj1",
+		Environment:    "dev",
+	})
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+
+	resp = srv.Do(http.MethodGet, base+"/API_KEY", token, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var secret models.Secret
+	apitest.DecodeJSON(t, resp, &secret)
+	if secret.Value != "abc123" {
+		t.Errorf("Expected value abc123, got %s", secret.Value)
+	}
+	if secret.CreatedBy != userID {
+		t.Errorf("Expected created_by %s, got %s", userID, secret.CreatedBy)
+	}
+
+	resp = srv.Do(http.MethodGet, base, token, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var secrets []models.Secret
+	apitest.DecodeJSON(t, resp, &secrets)
+	if len(secrets) != 1 {
+		t.Errorf("Expected 1 secret, got %d", len(secrets))
+	}
+
+	resp = srv.Do(http.MethodDelete, base+"/API_KEY", token, nil)
+	apitest.ExpectStatus(t, resp, http.StatusNoContent)
+
+	resp = srv.Do(http.MethodGet, base+"/API_KEY", token, nil)
+	apitest.ExpectStatus(t, resp, http.StatusNotFound)
+}
+
+func TestSecretExistenceHiding(t *testing.T) {
+	srv := apitest.NewServer(t)
+	ownerID := srv.Register("owner@example.com", "password123")
+	viewerID := srv.Register("viewer@example.com", "password123")
+	srv.Register("outsider@example.com", "password123")
+	org := srv.CreateOrganization("acme", ownerID)
+	srv.AddMember(org.ID, viewerID, "viewer")
+
+	owner := srv.Login("owner@example.com", "password123")
+	viewer := srv.Login("viewer@example.com", "password123")
+	outsider := srv.Login("outsider@example.com", "password123")
+
+	base := "/api/v1/organizations/" + org.ID + "/projects/proj1/environments/dev/secrets"
+	resp := srv.Do(http.MethodPost, base, owner, models.Secret{Name: "API_KEY", Value: "abc123"})
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		token  string
+		body   interface{}
+		status int
+	}{
+		{"Outsider get existing", http.MethodGet, base + "/API_KEY", outsider, nil, http.StatusNotFound},
+		{"Outsider get missing", http.MethodGet, base + "/MISSING", outsider, nil, http.StatusNotFound},
+		{"Outsider update existing", http.MethodPut, base + "/API_KEY", outsider, models.Secret{Value: "x"}, http.StatusNotFound},
+		{"Outsider delete existing", http.MethodDelete, base + "/API_KEY", outsider, nil, http.StatusNotFound},
+		{"Viewer get existing", http.MethodGet, base + "/API_KEY", viewer, nil, http.StatusOK},
+		{"Viewer update existing", http.MethodPut, base + "/API_KEY", viewer, models.Secret{Value: "x"}, http.StatusForbidden},
+		{"Viewer delete existing", http.MethodDelete, base + "/API_KEY", viewer, nil, http.StatusForbidden},
+		{"Viewer create", http.MethodPost, base, viewer, models.Secret{Name: "NEW_KEY", Value: "x"}, http.StatusForbidden},
+		{"Outsider create", http.MethodPost, base, outsider, models.Secret{Name: "NEW_KEY", Value: "x"}, http.StatusNotFound},
+		{"Admin update missing", http.MethodPut, base + "/MISSING", owner, models.Secret{Value: "x"}, http.StatusNotFound},
+		{"Admin delete missing", http.MethodDelete, base + "/MISSING", owner, nil, http.StatusNotFound},
+		{"Admin update existing", http.MethodPut, base + "/API_KEY", owner, models.Secret{Value: "def456"}, http.StatusNoContent},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := srv.Do(tc.method, tc.path, tc.token, tc.body)
+			apitest.ExpectStatus(t, resp, tc.status)
+		})
+	}
+}
+
+func TestSecretVersionsDiff(t *testing.T) {
+	srv := apitest.NewServer(t)
+	userID := srv.Register("carol@example.com", "password123")
+	token := srv.Login("carol@example.com", "password123")
+	org := srv.CreateOrganization("acme", userID)
+
+	base := "/api/v1/organizations/" + org.ID + "/projects/proj1/environments/dev/secrets"
+	resp := srv.Do(http.MethodPost, base, token, models.Secret{Name: "DB", Value: `{"password":"a"}`})
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+	resp = srv.Do(http.MethodPut, base+"/DB", token, models.Secret{Value: `{"password":"b"}`})
+	apitest.ExpectStatus(t, resp, http.StatusNoContent)
+
+	resp = srv.Do(http.MethodGet, base+"/DB/versions:diff?from=1&to=2", token, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var diff models.SecretVersionDiff
+	apitest.DecodeJSON(t, resp, &diff)
+	if !diff.Changed || len(diff.Fields) != 1 || diff.Fields[0].Field != "password" {
+		t.Errorf("Unexpected diff: %+v", diff)
+	}
+
+	resp = srv.Do(http.MethodGet, base+"/DB/versions:diff?from=1", token, nil)
+	apitest.ExpectStatus(t, resp, http.StatusBadRequest)
+}
+
+func TestSecretLocking(t *testing.T) {
+	srv := apitest.NewServer(t)
+	adminID := srv.Register("admin@example.com", "password123")
+	memberID := srv.Register("member@example.com", "password123")
+	org := srv.CreateOrganization("acme", adminID)
+	srv.AddMember(org.ID, memberID, "member")
+
+	admin := srv.Login("admin@example.com", "password123")
+	member := srv.Login("member@example.com", "password123")
+
+	base := "/api/v1/organizations/" + org.ID + "/projects/proj1/environments/prod/secrets"
+	resp := srv.Do(http.MethodPost, base, admin, models.Secret{Name: "DB_PASSWORD", Value: "s3cr3t"})
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+
+	resp = srv.Do(http.MethodPost, base+"/DB_PASSWORD/lock", member, map[string]string{"reason": "prod"})
+	apitest.ExpectStatus(t, resp, http.StatusForbidden)
+
+	resp = srv.Do(http.MethodPost, base+"/DB_PASSWORD/lock", admin, map[string]string{"reason": ""})
+	apitest.ExpectStatus(t, resp, http.StatusBadRequest)
+
+	resp = srv.Do(http.MethodPost, base+"/DB_PASSWORD/lock", admin, map[string]string{"reason": "identifiants de production"})
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var metadata models.SecretMetadata
+	apitest.DecodeJSON(t, resp, &metadata)
+	if !metadata.IsLocked() || metadata.LockedBy != adminID {
+		t.Errorf("Expected secret locked by %s, got %+v", adminID, metadata)
+	}
+
+	// Ni modification, ni écrasement, ni suppression tant que le verrou est posé
+	resp = srv.Do(http.MethodPut, base+"/DB_PASSWORD", member, models.Secret{Value: "oops"})
+	apitest.ExpectStatus(t, resp, http.StatusLocked)
+	resp = srv.Do(http.MethodPost, base, admin, models.Secret{Name: "DB_PASSWORD", Value: "oops"})
+	apitest.ExpectStatus(t, resp, http.StatusLocked)
+	resp = srv.Do(http.MethodDelete, base+"/DB_PASSWORD", admin, nil)
+	apitest.ExpectStatus(t, resp, http.StatusLocked)
+
+	resp = srv.Do(http.MethodPost, base+"/DB_PASSWORD/unlock", admin, map[string]string{"reason": "rotation planifiée"})
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+
+	resp = srv.Do(http.MethodDelete, base+"/DB_PASSWORD", member, nil)
+	apitest.ExpectStatus(t, resp, http.StatusNoContent)
+}
+
+func TestListMembersAndProjects(t *testing.T) {
+	srv := apitest.NewServer(t)
+	ownerID := srv.Register("owner@example.com", "password123")
+	token := srv.Login("owner@example.com", "password123")
+	memberID := srv.Register("member@example.com", "password123")
+	srv.Register("outsider@example.com", "password123")
+	outsiderToken := srv.Login("outsider@example.com", "password123")
+
+	org := srv.CreateOrganization("acme", ownerID)
+	srv.AddMember(org.ID, memberID, "viewer")
+	api := srv.CreateProject(org.ID, "api", ownerID)
+	srv.CreateProject(org.ID, "web", ownerID)
+
+	for _, env := range []string{"dev", "prod"} {
+		resp := srv.Do(http.MethodPost, "/api/v1/organizations/"+org.ID+"/projects/"+api.ID+"/environments/"+env+"/secrets",
+			token, models.Secret{Name: "API_KEY", Value: "v"})
+		apitest.ExpectStatus(t, resp, http.StatusCreated)
+	}
+
+	resp := srv.Do(http.MethodGet, "/api/v1/organizations/"+org.ID+"/members", token, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var members []models.OrganizationMember
+	apitest.DecodeJSON(t, resp, &members)
+	roles := map[string]string{}
+	for _, member := range members {
+		roles[member.Email] = member.Role
+	}
+	if len(members) != 2 || roles["member@example.com"] != "viewer" || roles["owner@example.com"] == "" {
+		t.Errorf("Unexpected members: %+v", members)
+	}
+
+	resp = srv.Do(http.MethodGet, "/api/v1/organizations/"+org.ID+"/projects", token, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var projects []models.ProjectSummary
+	apitest.DecodeJSON(t, resp, &projects)
+	if len(projects) != 2 {
+		t.Fatalf("Expected 2 projects, got %d", len(projects))
+	}
+	if projects[0].Name != "api" || projects[0].SecretsCount != 2 || len(projects[0].Environments) != 2 ||
+		projects[0].Environments[0] != "dev" || projects[0].Environments[1] != "prod" {
+		t.Errorf("Unexpected summary for api: %+v", projects[0])
+	}
+	if !projects[0].LastActivityAt.After(projects[0].UpdatedAt) {
+		t.Errorf("Expected last activity after project update, got %v", projects[0].LastActivityAt)
+	}
+	if projects[1].SecretsCount != 0 || len(projects[1].Environments) != 0 || !projects[1].LastActivityAt.Equal(projects[1].UpdatedAt) {
+		t.Errorf("Unexpected summary for web: %+v", projects[1])
+	}
+
+	// Les non-membres ne voient pas l'organisation
+	resp = srv.Do(http.MethodGet, "/api/v1/organizations/"+org.ID+"/members", outsiderToken, nil)
+	apitest.ExpectStatus(t, resp, http.StatusNotFound)
+	resp = srv.Do(http.MethodGet, "/api/v1/organizations/"+org.ID+"/projects", outsiderToken, nil)
+	apitest.ExpectStatus(t, resp, http.StatusNotFound)
+}
+
+func TestSearchMembers(t *testing.T) {
+	srv := apitest.NewServer(t)
+	ownerID := srv.Register("owner@example.com", "password123")
+	token := srv.Login("owner@example.com", "password123")
+	org := srv.CreateOrganization("acme", ownerID)
+	for _, email := range []string{"alice@example.com", "albert@example.com", "bob@example.com"} {
+		srv.AddMember(org.ID, srv.Register(email, "password123"), "viewer")
+	}
+
+	search := func(query string) []models.OrganizationMember {
+		t.Helper()
+		resp := srv.Do(http.MethodGet, "/api/v1/organizations/"+org.ID+"/members:search?"+query, token, nil)
+		apitest.ExpectStatus(t, resp, http.StatusOK)
+		var members []models.OrganizationMember
+		apitest.DecodeJSON(t, resp, &members)
+		return members
+	}
+
+	tests := []struct {
+		query    string
+		expected int
+	}{
+		{"q=AL", 2},
+		{"q=al&limit=1", 1},
+		{"q=al&limit=1&offset=1", 1},
+		{"role=viewer", 3},
+		{"role=admin&role=member", 1},
+		{"q=zed", 0},
+	}
+	for _, tc := range tests {
+		if members := search(tc.query); len(members) != tc.expected {
+			t.Errorf("Expected %d members for %q, got %d", tc.expected, tc.query, len(members))
+		}
+	}
+
+	resp := srv.Do(http.MethodGet, "/api/v1/organizations/"+org.ID+"/members:search?role=owner", token, nil)
+	apitest.ExpectStatus(t, resp, http.StatusBadRequest)
+
+	// Les membres non administrateurs ne peuvent pas rechercher
+	viewerToken := srv.Login("bob@example.com", "password123")
+	resp = srv.Do(http.MethodGet, "/api/v1/organizations/"+org.ID+"/members:search?q=a", viewerToken, nil)
+	apitest.ExpectStatus(t, resp, http.StatusForbidden)
+}
+
+func TestAPIv2ErrorEnvelope(t *testing.T) {
+	srv := apitest.NewServer(t)
+
+	// Même route, sans token : texte brut en v1, enveloppe JSON en v2
+	path := "/organizations/o/projects/p/environments/dev/secrets"
+	resp := srv.Do(http.MethodGet, "/api/v1"+path, "", nil)
+	apitest.ExpectStatus(t, resp, http.StatusUnauthorized)
+	if ct := resp.Header.Get("Content-Type"); ct != "text/plain; charset=utf-8" {
+		t.Errorf("Expected a plain text error in v1, got %q", ct)
+	}
+
+	resp = srv.Do(http.MethodGet, "/api/v2"+path, "", nil)
+	apitest.ExpectStatus(t, resp, http.StatusUnauthorized)
+	var envelope apierror.Envelope
+	apitest.DecodeJSON(t, resp, &envelope)
+	if envelope.Error.Code != "unauthenticated" || envelope.Error.Message == "" {
+		t.Errorf("Expected an unauthenticated error envelope, got %+v", envelope.Error)
+	}
+
+	// Les routes publiques sont aussi servies en v2
+	srv.Register("carol@example.com", "password123")
+	resp = srv.Do(http.MethodPost, "/api/v2/auth/login", "", map[string]string{
+		"email":    "carol@example.com",
+		"password": "wrong",
+	})
+	apitest.ExpectStatus(t, resp, http.StatusUnauthorized)
+	apitest.DecodeJSON(t, resp, &envelope)
+	if envelope.Error.Status != http.StatusUnauthorized {
+		t.Errorf("Expected status 401 in the envelope, got %d", envelope.Error.Status)
+	}
+}
@@ -0,0 +1,105 @@
+// filepath: internal/api/scheduled_secrets_test.go
+
+package api_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"secrets-manager/internal/api/apitest"
+	"secrets-manager/internal/events"
+	"secrets-manager/internal/jobs"
+	"secrets-manager/internal/models"
+)
+
+func TestScheduledSecretChange(t *testing.T) {
+	srv := apitest.NewServer(t)
+	ownerID := srv.Register("owner@example.com", "password123")
+	owner := srv.Login("owner@example.com", "password123")
+	org := srv.CreateOrganization("acme", ownerID)
+	project := srv.CreateProject(org.ID, "api", ownerID)
+
+	secrets := "/api/v1/organizations/" + org.ID + "/projects/" + project.ID + "/environments/prod/secrets"
+	resp := srv.Do(http.MethodPost, secrets, owner, models.Secret{Name: "DB_PASSWORD", Value: "v1"})
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+
+	expectValue := func(t *testing.T, expected string) {
+		t.Helper()
+		resp := srv.Do(http.MethodGet, secrets+"/DB_PASSWORD", owner, nil)
+		apitest.ExpectStatus(t, resp, http.StatusOK)
+		var secret models.Secret
+		apitest.DecodeJSON(t, resp, &secret)
+		if secret.Value != expected {
+			t.Errorf("Expected %s, got %s", expected, secret.Value)
+		}
+	}
+
+	resp = srv.Do(http.MethodPut, secrets+"/DB_PASSWORD", owner, map[string]any{
+		"value": "v2", "effective_at": time.Now().Add(-time.Minute)})
+	apitest.ExpectStatus(t, resp, http.StatusBadRequest)
+
+	effectiveAt := time.Now().Add(time.Hour)
+	resp = srv.Do(http.MethodPut, secrets+"/DB_PASSWORD", owner, map[string]any{
+		"value": "v2", "effective_at": effectiveAt})
+	apitest.ExpectStatus(t, resp, http.StatusAccepted)
+	var change models.ScheduledSecretChange
+	apitest.DecodeJSON(t, resp, &change)
+	if !change.EffectiveAt.Equal(effectiveAt) || change.CreatedBy != ownerID {
+		t.Errorf("Expected a change effective at %v by the owner, got %+v", effectiveAt, change)
+	}
+
+	// La valeur actuelle est servie jusqu'à la date d'effet
+	expectValue(t, "v1")
+	resp = srv.Do(http.MethodGet, secrets+"/DB_PASSWORD/scheduled", owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	resp = srv.Do(http.MethodGet, secrets, owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var listed []models.Secret
+	apitest.DecodeJSON(t, resp, &listed)
+	if len(listed) != 1 || listed[0].Value != "v1" {
+		t.Errorf("Expected only the current value to be listed, got %+v", listed)
+	}
+
+	promoter := jobs.NewSecretPromoter(srv.ScheduledSecretChanges, srv.Secrets, srv.Projects, srv.VaultService,
+		srv.Checksummer, nil, srv.Events, nil)
+	if err := promoter.PromoteDue(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expectValue(t, "v1")
+
+	// Date d'effet atteinte : la valeur est promue et la modification publiée
+	var published []events.Change
+	srv.Events.Subscribe(func(c events.Change) { published = append(published, c) })
+	change.EffectiveAt = time.Now().Add(-time.Second)
+	if err := srv.ScheduledSecretChanges.SaveScheduledSecretChange(context.Background(), &change); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := promoter.PromoteDue(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expectValue(t, "v2")
+	if len(published) != 2 || published[0].OrganizationID != org.ID {
+		t.Errorf("Expected secrets and projects changes to be published, got %+v", published)
+	}
+	resp = srv.Do(http.MethodGet, secrets+"/DB_PASSWORD/metadata", owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var metadata models.SecretMetadata
+	apitest.DecodeJSON(t, resp, &metadata)
+	if metadata.Version != 2 {
+		t.Errorf("Expected version 2, got %d", metadata.Version)
+	}
+	resp = srv.Do(http.MethodGet, secrets+"/DB_PASSWORD/scheduled", owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusNotFound)
+
+	// Un changement annulé n'est jamais appliqué
+	resp = srv.Do(http.MethodPut, secrets+"/DB_PASSWORD", owner, map[string]any{
+		"value": "v3", "effective_at": time.Now().Add(time.Hour)})
+	apitest.ExpectStatus(t, resp, http.StatusAccepted)
+	resp = srv.Do(http.MethodDelete, secrets+"/DB_PASSWORD/scheduled", owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusNoContent)
+	resp = srv.Do(http.MethodDelete, secrets+"/DB_PASSWORD/scheduled", owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusNotFound)
+	expectValue(t, "v2")
+}
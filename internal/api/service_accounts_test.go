@@ -0,0 +1,277 @@
+// filepath: internal/api/service_accounts_test.go
+
+package api_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"secrets-manager/internal/api/apitest"
+	"secrets-manager/internal/api/handlers"
+	"secrets-manager/internal/auth"
+	"secrets-manager/internal/models"
+)
+
+func TestServiceAccounts(t *testing.T) {
+	srv := apitest.NewServer(t)
+	ownerID := srv.Register("owner@example.com", "password123")
+	owner := srv.Login("owner@example.com", "password123")
+	memberID := srv.Register("member@example.com", "password123")
+	member := srv.Login("member@example.com", "password123")
+	org := srv.CreateOrganization("acme", ownerID)
+	srv.AddMember(org.ID, memberID, "member")
+	payments := srv.CreateProject(org.ID, "payments", ownerID)
+	search := srv.CreateProject(org.ID, "search", ownerID)
+	accounts := "/api/v1/organizations/" + org.ID + "/service-accounts"
+	secrets := func(projectID, env string) string {
+		return "/api/v1/organizations/" + org.ID + "/projects/" + projectID + "/environments/" + env + "/secrets"
+	}
+	for _, path := range []string{secrets(payments.ID, "prod"), secrets(payments.ID, "staging"), secrets(search.ID, "prod")} {
+		resp := srv.Do(http.MethodPost, path, owner, models.Secret{Name: "API_KEY", Value: "kX9#vQ2$mL7!pR4&wT8*"})
+		apitest.ExpectStatus(t, resp, http.StatusCreated)
+	}
+
+	// Seuls les administrateurs créent des comptes, sur des projets existants
+	creation := handlers.ServiceAccountCreation{
+		Name:       "deploy",
+		Permission: models.APIKeyRead,
+		Resources:  []models.ResourceScope{{ProjectID: payments.ID, Environment: "prod"}},
+	}
+	resp := srv.Do(http.MethodPost, accounts, member, creation)
+	apitest.ExpectStatus(t, resp, http.StatusForbidden)
+	resp = srv.Do(http.MethodPost, accounts, owner, handlers.ServiceAccountCreation{
+		Name: "deploy", Permission: models.APIKeyRead, Resources: []models.ResourceScope{{ProjectID: "unknown"}},
+	})
+	apitest.ExpectStatus(t, resp, http.StatusBadRequest)
+	resp = srv.Do(http.MethodPost, accounts, owner, creation)
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+	var created handlers.CreatedServiceAccount
+	apitest.DecodeJSON(t, resp, &created)
+	if !strings.HasPrefix(created.Secret, auth.ServiceAccountSecretPrefix) || !strings.HasPrefix(created.Secret, created.SecretPrefix) {
+		t.Errorf("Expected a service account secret, got %+v", created)
+	}
+
+	issue := func(form url.Values) *http.Response {
+		req, err := http.NewRequest(http.MethodPost, srv.URL+"/api/v1/auth/token", strings.NewReader(form.Encode()))
+		if err != nil {
+			t.Fatalf("Expected a valid request, got %v", err)
+		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("Expected a response, got %v", err)
+		}
+		t.Cleanup(func() { resp.Body.Close() })
+		return resp
+	}
+	resp = issue(url.Values{"grant_type": {"password"}, "client_id": {created.ID}, "client_secret": {created.Secret}})
+	apitest.ExpectStatus(t, resp, http.StatusBadRequest)
+	resp = issue(url.Values{"grant_type": {"client_credentials"}, "client_id": {created.ID}, "client_secret": {"smsa_wrong"}})
+	apitest.ExpectStatus(t, resp, http.StatusUnauthorized)
+	resp = issue(url.Values{"grant_type": {"client_credentials"}, "client_id": {created.ID}, "client_secret": {created.Secret}})
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var token auth.ServiceAccountToken
+	apitest.DecodeJSON(t, resp, &token)
+	if token.TokenType != "Bearer" || token.ExpiresIn <= 0 || token.Scope != "secrets:read metadata:read" {
+		t.Errorf("Expected a read-only bearer token, got %+v", token)
+	}
+
+	// Le token ne donne accès qu'aux secrets de ses ressources, en lecture
+	resp = srv.Do(http.MethodGet, secrets(payments.ID, "prod")+"/API_KEY", token.AccessToken, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	resp = srv.Do(http.MethodGet, secrets(payments.ID, "staging")+"/API_KEY", token.AccessToken, nil)
+	apitest.ExpectStatus(t, resp, http.StatusForbidden)
+	resp = srv.Do(http.MethodGet, secrets(search.ID, "prod")+"/API_KEY", token.AccessToken, nil)
+	apitest.ExpectStatus(t, resp, http.StatusForbidden)
+	resp = srv.Do(http.MethodPut, secrets(payments.ID, "prod")+"/API_KEY", token.AccessToken,
+		models.Secret{Value: "pQ7!zR2#xW9$kL4&"})
+	apitest.ExpectStatus(t, resp, http.StatusForbidden)
+	resp = srv.Do(http.MethodGet, "/api/v1/organizations/"+org.ID+"/projects", token.AccessToken, nil)
+	apitest.ExpectStatus(t, resp, http.StatusForbidden)
+
+	// Supprimer le compte révoque ses tokens
+	resp = srv.Do(http.MethodGet, accounts, owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var listed []models.ServiceAccount
+	apitest.DecodeJSON(t, resp, &listed)
+	if len(listed) != 1 || listed[0].ID != created.ID || listed[0].LastUsedAt == nil {
+		t.Errorf("Expected the used service account, got %+v", listed)
+	}
+	resp = srv.Do(http.MethodDelete, accounts+"/"+created.ID, owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusNoContent)
+	resp = srv.Do(http.MethodGet, secrets(payments.ID, "prod")+"/API_KEY", token.AccessToken, nil)
+	apitest.ExpectStatus(t, resp, http.StatusUnauthorized)
+}
+
+func TestServiceAccountClientCertificates(t *testing.T) {
+	srv := apitest.NewServer(t)
+	ownerID := srv.Register("owner@example.com", "password123")
+	owner := srv.Login("owner@example.com", "password123")
+	memberID := srv.Register("member@example.com", "password123")
+	member := srv.Login("member@example.com", "password123")
+	org := srv.CreateOrganization("acme", ownerID)
+	srv.AddMember(org.ID, memberID, "member")
+	payments := srv.CreateProject(org.ID, "payments", ownerID)
+	accounts := "/api/v1/organizations/" + org.ID + "/service-accounts"
+	secrets := "/api/v1/organizations/" + org.ID + "/projects/" + payments.ID + "/environments/prod/secrets"
+	trustPath := "/api/v1/organizations/" + org.ID + "/service-account-trust"
+	resp := srv.Do(http.MethodPost, secrets, owner, models.Secret{Name: "API_KEY", Value: "kX9#vQ2$mL7!pR4&wT8*"})
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+
+	// Sans domaine de confiance, aucun identifiant SPIFFE n'est accepté
+	creation := handlers.ServiceAccountCreation{
+		Name:       "deploy",
+		Permission: models.APIKeyRead,
+		Resources:  []models.ResourceScope{{ProjectID: payments.ID, Environment: "prod"}},
+		SPIFFEID:   "spiffe://acme.internal/deploy",
+	}
+	resp = srv.Do(http.MethodPost, accounts, owner, creation)
+	apitest.ExpectStatus(t, resp, http.StatusBadRequest)
+	resp = srv.Do(http.MethodGet, trustPath, owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusNotFound)
+
+	// Les administrateurs déclarent le domaine et les autorités de l'organisation
+	trust := handlers.ServiceAccountTrustUpdate{TrustDomain: "acme.internal", CABundle: srv.ClientCAPEM()}
+	resp = srv.Do(http.MethodPut, trustPath, member, trust)
+	apitest.ExpectStatus(t, resp, http.StatusForbidden)
+	for _, invalid := range []handlers.ServiceAccountTrustUpdate{
+		{TrustDomain: "Acme.internal", CABundle: trust.CABundle},
+		{TrustDomain: "acme.internal/x", CABundle: trust.CABundle},
+		{TrustDomain: "acme.internal"},
+		{TrustDomain: "acme.internal", CABundle: "not a certificate"},
+		{TrustDomain: "acme.internal", CABundle: trust.CABundle + "garbage"},
+	} {
+		resp = srv.Do(http.MethodPut, trustPath, owner, invalid)
+		apitest.ExpectStatus(t, resp, http.StatusBadRequest)
+	}
+	resp = srv.Do(http.MethodPut, trustPath, owner, trust)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	resp = srv.Do(http.MethodGet, trustPath, owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var stored models.ServiceAccountTrust
+	apitest.DecodeJSON(t, resp, &stored)
+	if stored.TrustDomain != "acme.internal" || stored.CABundle != trust.CABundle || stored.UpdatedBy != ownerID {
+		t.Errorf("Expected the declared trust, got %+v", stored)
+	}
+
+	// Identifiant SPIFFE à la création, valide, dans le domaine et propre à un compte
+	resp = srv.Do(http.MethodPost, accounts, owner, creation)
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+	var created handlers.CreatedServiceAccount
+	apitest.DecodeJSON(t, resp, &created)
+	if created.SPIFFEID != creation.SPIFFEID {
+		t.Errorf("Expected the SPIFFE ID to be stored, got %+v", created.ServiceAccount)
+	}
+	creation.Name = "deploy-2"
+	resp = srv.Do(http.MethodPost, accounts, owner, creation)
+	apitest.ExpectStatus(t, resp, http.StatusConflict)
+	for _, invalid := range []string{"https://acme.internal/deploy", "spiffe://acme.internal", "spiffe://Acme/deploy",
+		"spiffe://acme.internal/deploy?x=1", "spiffe://acme.internal:8443/deploy", "spiffe://globex.internal/deploy"} {
+		creation.SPIFFEID = invalid
+		resp = srv.Do(http.MethodPost, accounts, owner, creation)
+		apitest.ExpectStatus(t, resp, http.StatusBadRequest)
+	}
+
+	// Le certificat client authentifie le compte, dans les limites de ses ressources
+	client := srv.MTLSClient("spiffe://acme.internal/deploy")
+	resp = srv.DoMTLS(client, http.MethodGet, secrets+"/API_KEY", nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var secret models.Secret
+	apitest.DecodeJSON(t, resp, &secret)
+	if secret.Value != "kX9#vQ2$mL7!pR4&wT8*" {
+		t.Errorf("Expected the secret value, got %q", secret.Value)
+	}
+	resp = srv.DoMTLS(client, http.MethodPut, secrets+"/API_KEY", models.Secret{Value: "pQ7!zR2#xW9$kL4&"})
+	apitest.ExpectStatus(t, resp, http.StatusForbidden)
+	resp = srv.DoMTLS(client, http.MethodGet, "/api/v1/organizations/"+org.ID+"/projects", nil)
+	apitest.ExpectStatus(t, resp, http.StatusForbidden)
+
+	// Certificat sans identifiant SPIFFE ou d'un identifiant inconnu
+	resp = srv.DoMTLS(srv.MTLSClient(""), http.MethodGet, secrets+"/API_KEY", nil)
+	apitest.ExpectStatus(t, resp, http.StatusUnauthorized)
+	resp = srv.DoMTLS(srv.MTLSClient("spiffe://acme.internal/other"), http.MethodGet, secrets+"/API_KEY", nil)
+	apitest.ExpectStatus(t, resp, http.StatusUnauthorized)
+
+	// Sans certificat client, la poignée de main échoue
+	if resp, err := srv.MTLS.Client().Get(srv.MTLS.URL + secrets + "/API_KEY"); err == nil {
+		resp.Body.Close()
+		t.Errorf("Expected the TLS handshake to fail without a client certificate")
+	}
+
+	// Les administrateurs changent ou retirent l'identifiant : l'ancien est refusé
+	spiffePath := accounts + "/" + created.ID + "/spiffe-id"
+	resp = srv.Do(http.MethodPut, spiffePath, member, handlers.SPIFFEIDUpdate{SPIFFEID: "spiffe://acme.internal/ci"})
+	apitest.ExpectStatus(t, resp, http.StatusForbidden)
+	resp = srv.Do(http.MethodPut, spiffePath, owner, handlers.SPIFFEIDUpdate{SPIFFEID: "spiffe://acme.internal/ci"})
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	resp = srv.DoMTLS(client, http.MethodGet, secrets+"/API_KEY", nil)
+	apitest.ExpectStatus(t, resp, http.StatusUnauthorized)
+	resp = srv.DoMTLS(srv.MTLSClient("spiffe://acme.internal/ci"), http.MethodGet, secrets+"/API_KEY", nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	resp = srv.Do(http.MethodPut, accounts+"/unknown/spiffe-id", owner, handlers.SPIFFEIDUpdate{})
+	apitest.ExpectStatus(t, resp, http.StatusNotFound)
+	resp = srv.Do(http.MethodPut, spiffePath, owner, handlers.SPIFFEIDUpdate{})
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	resp = srv.DoMTLS(srv.MTLSClient("spiffe://acme.internal/ci"), http.MethodGet, secrets+"/API_KEY", nil)
+	apitest.ExpectStatus(t, resp, http.StatusUnauthorized)
+
+	// Un domaine n'appartient qu'à une organisation, dont les autorités
+	// signent seules les certificats de ses comptes
+	otherID := srv.Register("other@example.com", "password123")
+	other := srv.Login("other@example.com", "password123")
+	globex := srv.CreateOrganization("globex", otherID)
+	billing := srv.CreateProject(globex.ID, "billing", otherID)
+	globexTrust := "/api/v1/organizations/" + globex.ID + "/service-account-trust"
+	resp = srv.Do(http.MethodPut, globexTrust, other, trust)
+	apitest.ExpectStatus(t, resp, http.StatusConflict)
+	resp = srv.Do(http.MethodPut, globexTrust, other,
+		handlers.ServiceAccountTrustUpdate{TrustDomain: "globex.internal", CABundle: newCAPEM(t)})
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	resp = srv.Do(http.MethodPost, "/api/v1/organizations/"+globex.ID+"/service-accounts", other,
+		handlers.ServiceAccountCreation{Name: "deploy", Permission: models.APIKeyRead,
+			Resources: []models.ResourceScope{{ProjectID: billing.ID}}, SPIFFEID: "spiffe://globex.internal/deploy"})
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+	billingSecrets := "/api/v1/organizations/" + globex.ID + "/projects/" + billing.ID + "/environments/prod/secrets"
+	globexClient := srv.MTLSClient("spiffe://globex.internal/deploy")
+	resp = srv.DoMTLS(globexClient, http.MethodGet, billingSecrets, nil)
+	apitest.ExpectStatus(t, resp, http.StatusUnauthorized)
+	resp = srv.Do(http.MethodPut, globexTrust, other,
+		handlers.ServiceAccountTrustUpdate{TrustDomain: "globex.internal", CABundle: srv.ClientCAPEM()})
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	resp = srv.DoMTLS(globexClient, http.MethodGet, billingSecrets, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+}
+
+// newCAPEM renvoie une autorité (PEM) autre que celle de apitest
+func newCAPEM(t *testing.T) string {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(2),
+		Subject:               pkix.Name{CommonName: "other CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
@@ -0,0 +1,77 @@
+// filepath: internal/api/session_policy_test.go
+
+package api_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"secrets-manager/internal/api/apitest"
+	"secrets-manager/internal/models"
+)
+
+func TestSessionPolicy(t *testing.T) {
+	srv := apitest.NewServer(t)
+	ownerID := srv.Register("owner@example.com", "password123")
+	owner := srv.Login("owner@example.com", "password123")
+	memberID := srv.Register("member@example.com", "password123")
+	member := srv.Login("member@example.com", "password123")
+	org := srv.CreateOrganization("acme", ownerID)
+	srv.AddMember(org.ID, memberID, "member")
+	policyPath := "/api/v1/organizations/" + org.ID + "/session-policy"
+	login := func() map[string]string {
+		resp := srv.Do(http.MethodPost, "/api/v1/auth/login", "", map[string]string{
+			"email": "member@example.com", "password": "password123"})
+		apitest.ExpectStatus(t, resp, http.StatusOK)
+		var tokens map[string]string
+		apitest.DecodeJSON(t, resp, &tokens)
+		return tokens
+	}
+
+	// Sans politique réglée, aucune limite ne s'applique
+	resp := srv.Do(http.MethodGet, policyPath, member, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var policy models.SessionPolicy
+	apitest.DecodeJSON(t, resp, &policy)
+	if policy.MaxSessionHours != 0 || policy.IdleTimeoutMinutes != 0 || policy.MaxSessions != 0 {
+		t.Errorf("Expected no limits by default, got %+v", policy)
+	}
+
+	// Réservée aux administrateurs, dans les bornes
+	settings := map[string]int{"max_session_hours": 8, "idle_timeout_minutes": 30, "max_sessions": 1}
+	resp = srv.Do(http.MethodPut, policyPath, member, settings)
+	apitest.ExpectStatus(t, resp, http.StatusForbidden)
+	resp = srv.Do(http.MethodPut, policyPath, owner, map[string]int{"idle_timeout_minutes": 1})
+	apitest.ExpectStatus(t, resp, http.StatusBadRequest)
+	resp = srv.Do(http.MethodPut, policyPath, owner, map[string]int{"max_session_hours": 1, "idle_timeout_minutes": 90})
+	apitest.ExpectStatus(t, resp, http.StatusBadRequest)
+	resp = srv.Do(http.MethodPut, policyPath, owner, settings)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+
+	// Le délai d'inactivité borne la validité du token de rafraîchissement
+	first := login()
+	active, err := srv.RefreshTokens.ListActiveRefreshTokens(context.Background(), memberID, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// La session ouverte avant la politique est révoquée par max_sessions
+	if len(active) != 1 || time.Until(active[0].ExpiresAt) > 30*time.Minute {
+		t.Errorf("Expected a single session expiring within the idle timeout, got %+v", active)
+	}
+
+	// Une nouvelle connexion révoque la plus ancienne au-delà de max_sessions
+	second := login()
+	resp = srv.Do(http.MethodPost, "/api/v1/auth/refresh", "", map[string]string{"refresh_token": first["refresh_token"]})
+	apitest.ExpectStatus(t, resp, http.StatusUnauthorized)
+	resp = srv.Do(http.MethodGet, "/api/v1/auth/sessions", second["token"], nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var sessions []models.Session
+	apitest.DecodeJSON(t, resp, &sessions)
+	if len(sessions) != 1 || !sessions[0].Current {
+		t.Errorf("Expected only the latest session, got %+v", sessions)
+	}
+	resp = srv.Do(http.MethodPost, "/api/v1/auth/refresh", "", map[string]string{"refresh_token": second["refresh_token"]})
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+}
@@ -0,0 +1,99 @@
+// filepath: internal/api/sessions_test.go
+
+package api_test
+
+import (
+	"net/http"
+	"testing"
+
+	"secrets-manager/internal/api/apitest"
+	"secrets-manager/internal/models"
+)
+
+func TestSessions(t *testing.T) {
+	srv := apitest.NewServer(t)
+	srv.Register("sessions@example.com", "password123")
+	protected := "/api/v1/users/me/notification-preferences"
+	credentials := map[string]string{"email": "sessions@example.com", "password": "password123"}
+	login := func(userAgent string) map[string]string {
+		resp := srv.DoWithHeaders(http.MethodPost, "/api/v1/auth/login", "",
+			http.Header{"User-Agent": {userAgent}}, credentials)
+		apitest.ExpectStatus(t, resp, http.StatusOK)
+		var tokens map[string]string
+		apitest.DecodeJSON(t, resp, &tokens)
+		return tokens
+	}
+	refresh := func(refreshToken string, expected int) map[string]string {
+		resp := srv.Do(http.MethodPost, "/api/v1/auth/refresh", "", map[string]string{"refresh_token": refreshToken})
+		apitest.ExpectStatus(t, resp, expected)
+		var tokens map[string]string
+		if expected == http.StatusOK {
+			apitest.DecodeJSON(t, resp, &tokens)
+		}
+		return tokens
+	}
+
+	// Chaque rafraîchissement remplace le token de rafraîchissement
+	laptop := login("Firefox/131.0")
+	rotated := refresh(laptop["refresh_token"], http.StatusOK)
+	if rotated["refresh_token"] == laptop["refresh_token"] {
+		t.Fatalf("Expected a new refresh token on refresh")
+	}
+	resp := srv.Do(http.MethodGet, protected, rotated["token"], nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+
+	// Réutiliser un token remplacé révoque toute la session
+	refresh(laptop["refresh_token"], http.StatusUnauthorized)
+	for _, token := range []string{laptop["token"], rotated["token"]} {
+		resp = srv.Do(http.MethodGet, protected, token, nil)
+		apitest.ExpectStatus(t, resp, http.StatusUnauthorized)
+	}
+	refresh(rotated["refresh_token"], http.StatusUnauthorized)
+
+	// Les sessions actives décrivent l'appareil de chaque connexion
+	phone := login("Safari/17.0")
+	cli := login("secrets-cli/1.4")
+	resp = srv.Do(http.MethodGet, "/api/v1/auth/sessions", phone["token"], nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var sessions []models.Session
+	apitest.DecodeJSON(t, resp, &sessions)
+	if len(sessions) != 2 {
+		t.Fatalf("Expected the phone and CLI sessions, got %+v", sessions)
+	}
+	var cliSession string
+	for _, session := range sessions {
+		if session.Current != (session.UserAgent == "Safari/17.0") {
+			t.Errorf("Expected only the phone session to be current, got %+v", session)
+		}
+		if session.UserAgent == "secrets-cli/1.4" {
+			cliSession = session.ID
+		}
+	}
+
+	resp = srv.Do(http.MethodDelete, "/api/v1/auth/sessions/"+cliSession, phone["token"], nil)
+	apitest.ExpectStatus(t, resp, http.StatusNoContent)
+	resp = srv.Do(http.MethodGet, protected, cli["token"], nil)
+	apitest.ExpectStatus(t, resp, http.StatusUnauthorized)
+	refresh(cli["refresh_token"], http.StatusUnauthorized)
+	resp = srv.Do(http.MethodDelete, "/api/v1/auth/sessions/"+cliSession, phone["token"], nil)
+	apitest.ExpectStatus(t, resp, http.StatusNotFound)
+
+	// Révoquer les autres sessions conserve celle de la requête
+	tablet := login("Chrome/129.0")
+	resp = srv.Do(http.MethodDelete, "/api/v1/auth/sessions", phone["token"], nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	resp = srv.Do(http.MethodGet, protected, tablet["token"], nil)
+	apitest.ExpectStatus(t, resp, http.StatusUnauthorized)
+	resp = srv.Do(http.MethodGet, protected, phone["token"], nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+
+	// La déconnexion ferme la session, et peut être répétée
+	for i := 0; i < 2; i++ {
+		resp = srv.Do(http.MethodPost, "/api/v1/auth/logout", "", map[string]string{"refresh_token": phone["refresh_token"]})
+		apitest.ExpectStatus(t, resp, http.StatusNoContent)
+	}
+	resp = srv.Do(http.MethodGet, protected, phone["token"], nil)
+	apitest.ExpectStatus(t, resp, http.StatusUnauthorized)
+	resp = srv.Do(http.MethodPost, "/api/v1/auth/logout", "", map[string]string{"refresh_token": "smrt_unknown"})
+	apitest.ExpectStatus(t, resp, http.StatusUnauthorized)
+}
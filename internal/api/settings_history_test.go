@@ -0,0 +1,74 @@
+// filepath: internal/api/settings_history_test.go
+
+package api_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"secrets-manager/internal/api/apitest"
+	"secrets-manager/internal/models"
+)
+
+func TestSettingsHistory(t *testing.T) {
+	srv := apitest.NewServer(t)
+	ownerID := srv.Register("owner@example.com", "password123")
+	owner := srv.Login("owner@example.com", "password123")
+	memberID := srv.Register("member@example.com", "password123")
+	member := srv.Login("member@example.com", "password123")
+	org := srv.CreateOrganization("acme", ownerID)
+	srv.AddMember(org.ID, memberID, "member")
+
+	base := "/api/v1/organizations/" + org.ID
+	resp := srv.Do(http.MethodPut, base+"/residency", owner, map[string]string{"region": apitest.SecretRegion})
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	resp = srv.Do(http.MethodPost, base+"/maintenance-windows", owner,
+		models.MaintenanceWindow{Environment: "prod", Schedule: "0 2 * * *", DurationMinutes: 60})
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+	resp = srv.Do(http.MethodPost, base+"/webhooks", owner, map[string]any{"url": "https://hooks.example.com/sm"})
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+	var webhook models.Webhook
+	apitest.DecodeJSON(t, resp, &webhook)
+	resp = srv.Do(http.MethodDelete, base+"/webhooks/"+webhook.ID, owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusNoContent)
+
+	// Réservé aux administrateurs
+	resp = srv.Do(http.MethodGet, base+"/settings-history", member, nil)
+	apitest.ExpectStatus(t, resp, http.StatusForbidden)
+
+	resp = srv.Do(http.MethodGet, base+"/settings-history", owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var changes []models.SettingsChange
+	apitest.DecodeJSON(t, resp, &changes)
+	if len(changes) != 4 {
+		t.Fatalf("Expected 4 changes, got %d", len(changes))
+	}
+	for i, expected := range []string{models.SettingsWebhook, models.SettingsWebhook, models.SettingsMaintenanceWindow, models.SettingsResidency} {
+		if changes[i].Resource != expected || changes[i].Version != int64(4-i) || changes[i].ChangedBy != ownerID {
+			t.Errorf("Expected version %d on %s by the owner, got %+v", 4-i, expected, changes[i])
+		}
+	}
+	residency := changes[3]
+	if residency.Action != models.SettingsUpdated || !strings.Contains(string(residency.Before), models.DefaultRegion) ||
+		!strings.Contains(string(residency.After), apitest.SecretRegion) {
+		t.Errorf("Expected the region before and after, got %s -> %s", residency.Before, residency.After)
+	}
+	deleted := changes[0]
+	if deleted.Action != models.SettingsDeleted || deleted.ResourceID != webhook.ID || string(deleted.After) != "null" {
+		t.Errorf("Expected the webhook deletion, got %+v", deleted)
+	}
+	// Le secret de signature n'est jamais historisé
+	if strings.Contains(string(changes[1].After), webhook.Secret) || strings.Contains(string(deleted.Before), webhook.Secret) {
+		t.Error("Expected the webhook secret to be left out of the history")
+	}
+
+	resp = srv.Do(http.MethodGet, base+"/settings-history?resource=maintenance_window", owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	apitest.DecodeJSON(t, resp, &changes)
+	if len(changes) != 1 || changes[0].Action != models.SettingsCreated || string(changes[0].Before) != "null" {
+		t.Errorf("Expected the window creation only, got %+v", changes)
+	}
+	resp = srv.Do(http.MethodGet, base+"/settings-history?resource=plans", owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusBadRequest)
+}
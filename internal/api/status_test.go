@@ -0,0 +1,73 @@
+// filepath: internal/api/status_test.go
+
+package api_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"secrets-manager/internal/api/apitest"
+	"secrets-manager/internal/api/handlers"
+	"secrets-manager/internal/jobs"
+	"secrets-manager/internal/models"
+)
+
+func TestStatus(t *testing.T) {
+	srv := apitest.NewServer(t)
+
+	var vaultErr error
+	monitor := jobs.NewHealthMonitor(srv.Health,
+		jobs.HealthProbe{Component: models.ComponentDatabase, Check: func(ctx context.Context) error { return nil }},
+		jobs.HealthProbe{Component: models.ComponentVault, Check: func(ctx context.Context) error { return vaultErr }},
+	)
+	getStatus := func(t *testing.T) handlers.Status {
+		t.Helper()
+		// Page publique : aucune authentification
+		resp := srv.Do(http.MethodGet, "/api/v1/status", "", nil)
+		apitest.ExpectStatus(t, resp, http.StatusOK)
+		var status handlers.Status
+		apitest.DecodeJSON(t, resp, &status)
+		return status
+	}
+
+	// Trois vérifications dont deux échecs de Vault : un seul incident
+	for _, err := range []error{nil, errors.New("sealed"), errors.New("sealed")} {
+		vaultErr = err
+		if err := monitor.Check(context.Background()); err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+	}
+	status := getStatus(t)
+	if status.Status != handlers.StatusDegraded || len(status.Incidents) != 1 || status.Incidents[0].Component != models.ComponentVault {
+		t.Fatalf("Expected one vault incident, got %+v", status)
+	}
+	if len(status.Components) != 2 {
+		t.Fatalf("Expected 2 components, got %d", len(status.Components))
+	}
+	database, vault := status.Components[0], status.Components[1]
+	if database.Status != handlers.StatusOperational || database.Uptime1d == nil || *database.Uptime1d != 100 {
+		t.Errorf("Expected the database fully available, got %+v", database)
+	}
+	if vault.Status != handlers.StatusOutage || vault.Uptime90d == nil || *vault.Uptime90d != 33.333 {
+		t.Errorf("Expected vault down with 33.333%% uptime, got %+v", vault)
+	}
+	if len(vault.History) != 90 || vault.History[89].Uptime == nil || vault.History[0].Uptime != nil {
+		t.Errorf("Expected 90 days of history ending today, got %d days", len(vault.History))
+	}
+
+	// Le rétablissement résout l'incident
+	vaultErr = nil
+	if err := monitor.Check(context.Background()); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	status = getStatus(t)
+	if status.Status != handlers.StatusOperational || len(status.Incidents) != 0 ||
+		len(status.RecentIncidents) != 1 || status.RecentIncidents[0].ResolvedAt == nil {
+		t.Errorf("Expected the incident resolved, got %+v", status)
+	}
+	if *status.Components[1].Uptime7d != 50 {
+		t.Errorf("Expected 50%% uptime for vault, got %v", *status.Components[1].Uptime7d)
+	}
+}
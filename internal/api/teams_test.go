@@ -0,0 +1,188 @@
+// filepath: internal/api/teams_test.go
+
+package api_test
+
+import (
+	"net/http"
+	"testing"
+
+	"secrets-manager/internal/api/apitest"
+	"secrets-manager/internal/models"
+)
+
+func TestTeamsAndOwnership(t *testing.T) {
+	srv := apitest.NewServer(t)
+	ownerID := srv.Register("owner@example.com", "password123")
+	owner := srv.Login("owner@example.com", "password123")
+	memberID := srv.Register("member@example.com", "password123")
+	member := srv.Login("member@example.com", "password123")
+	outsiderID := srv.Register("outsider@example.com", "password123")
+	org := srv.CreateOrganization("acme", ownerID)
+	srv.AddMember(org.ID, memberID, "member")
+	payments := srv.CreateProject(org.ID, "payments", ownerID)
+	srv.CreateProject(org.ID, "search", ownerID)
+	teams := "/api/v1/organizations/" + org.ID + "/teams"
+
+	// Seuls les administrateurs gèrent les équipes
+	resp := srv.Do(http.MethodPost, teams, member, map[string]string{"name": "paiements"})
+	apitest.ExpectStatus(t, resp, http.StatusForbidden)
+	resp = srv.Do(http.MethodPost, teams, owner, map[string]string{"name": " "})
+	apitest.ExpectStatus(t, resp, http.StatusBadRequest)
+	resp = srv.Do(http.MethodPost, teams, owner, map[string]string{"name": "paiements"})
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+	var team models.Team
+	apitest.DecodeJSON(t, resp, &team)
+	resp = srv.Do(http.MethodPost, teams, owner, map[string]string{"name": "Paiements"})
+	apitest.ExpectStatus(t, resp, http.StatusConflict)
+
+	resp = srv.Do(http.MethodPut, teams+"/"+team.ID+"/members/"+outsiderID, owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusBadRequest)
+	resp = srv.Do(http.MethodPut, teams+"/"+team.ID+"/members/"+memberID, owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusNoContent)
+	resp = srv.Do(http.MethodGet, teams+"/"+team.ID, member, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	apitest.DecodeJSON(t, resp, &team)
+	if len(team.Members) != 1 || team.Members[0].UserID != memberID || team.Members[0].AddedBy != ownerID {
+		t.Errorf("Expected the member added by the owner, got %+v", team.Members)
+	}
+	resp = srv.Do(http.MethodGet, teams+":mine", member, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var mine []models.Team
+	apitest.DecodeJSON(t, resp, &mine)
+	if len(mine) != 1 || mine[0].ID != team.ID {
+		t.Errorf("Expected the member to belong to the team, got %+v", mine)
+	}
+
+	// Le projet payments revient à l'équipe ; un de ses secrets à l'administrateur
+	ownership := "/api/v1/organizations/" + org.ID + "/projects/" + payments.ID + "/ownership"
+	resp = srv.Do(http.MethodPut, ownership, member, models.Ownership{TeamID: team.ID})
+	apitest.ExpectStatus(t, resp, http.StatusForbidden)
+	resp = srv.Do(http.MethodPut, ownership, owner, models.Ownership{TeamID: "unknown"})
+	apitest.ExpectStatus(t, resp, http.StatusBadRequest)
+	resp = srv.Do(http.MethodPut, ownership, owner, models.Ownership{TeamID: team.ID})
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+
+	secrets := "/api/v1/organizations/" + org.ID + "/projects/" + payments.ID + "/environments/prod/secrets"
+	for _, name := range []string{"STRIPE_KEY", "DB_PASSWORD"} {
+		resp = srv.Do(http.MethodPost, secrets, owner, models.Secret{Name: name, Value: "kX9#vQ2$mL7!pR4&wT8*"})
+		apitest.ExpectStatus(t, resp, http.StatusCreated)
+	}
+	resp = srv.Do(http.MethodPut, secrets+"/DB_PASSWORD/ownership", member, models.Ownership{OwnerID: outsiderID})
+	apitest.ExpectStatus(t, resp, http.StatusBadRequest)
+	resp = srv.Do(http.MethodPut, secrets+"/DB_PASSWORD/ownership", member, models.Ownership{OwnerID: ownerID})
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+
+	// Filtres : les projets et secrets de mon équipe, ou les miens
+	resp = srv.Do(http.MethodGet, "/api/v1/organizations/"+org.ID+"/projects?team=mine", member, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var projects []models.ProjectSummary
+	apitest.DecodeJSON(t, resp, &projects)
+	if len(projects) != 1 || projects[0].ID != payments.ID || projects[0].TeamID != team.ID {
+		t.Errorf("Expected only the team's project, got %+v", projects)
+	}
+
+	expectSecrets := func(token, query string, expected ...string) {
+		t.Helper()
+		resp := srv.Do(http.MethodGet, secrets+":metadata?"+query, token, nil)
+		apitest.ExpectStatus(t, resp, http.StatusOK)
+		var metadata []models.SecretMetadata
+		apitest.DecodeJSON(t, resp, &metadata)
+		names := []string{}
+		for _, m := range metadata {
+			names = append(names, m.Name)
+		}
+		if len(names) != len(expected) {
+			t.Errorf("Expected %v for %q, got %v", expected, query, names)
+			return
+		}
+		for i := range expected {
+			if names[i] != expected[i] {
+				t.Errorf("Expected %v for %q, got %v", expected, query, names)
+				return
+			}
+		}
+	}
+	// Un secret sans responsable relève de ceux de son projet
+	expectSecrets(member, "team=mine", "STRIPE_KEY")
+	expectSecrets(owner, "owner=me", "DB_PASSWORD")
+	expectSecrets(owner, "team=mine")
+
+	// Une équipe supprimée n'est plus responsable du projet
+	resp = srv.Do(http.MethodDelete, teams+"/"+team.ID, owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusNoContent)
+	resp = srv.Do(http.MethodGet, "/api/v1/organizations/"+org.ID+"/projects?team="+team.ID, owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	apitest.DecodeJSON(t, resp, &projects)
+	if len(projects) != 0 {
+		t.Errorf("Expected no project owned by the deleted team, got %+v", projects)
+	}
+}
+
+func TestTeamRoleInheritance(t *testing.T) {
+	srv := apitest.NewServer(t)
+	ownerID := srv.Register("owner@example.com", "password123")
+	owner := srv.Login("owner@example.com", "password123")
+	viewerID := srv.Register("viewer@example.com", "password123")
+	viewer := srv.Login("viewer@example.com", "password123")
+	org := srv.CreateOrganization("acme", ownerID)
+	srv.AddMember(org.ID, viewerID, "viewer")
+	payments := srv.CreateProject(org.ID, "payments", ownerID)
+	search := srv.CreateProject(org.ID, "search", ownerID)
+	teams := "/api/v1/organizations/" + org.ID + "/teams"
+	secrets := func(projectID, env string) string {
+		return "/api/v1/organizations/" + org.ID + "/projects/" + projectID + "/environments/" + env + "/secrets"
+	}
+	write := func(projectID, env string) *http.Response {
+		return srv.Do(http.MethodPost, secrets(projectID, env), viewer, models.Secret{Name: "API_KEY", Value: "kX9#vQ2$mL7!pR4&wT8*"})
+	}
+
+	resp := srv.Do(http.MethodPost, teams, owner, map[string]string{"name": "paiements"})
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+	var team models.Team
+	apitest.DecodeJSON(t, resp, &team)
+	resp = srv.Do(http.MethodPut, teams+"/"+team.ID+"/members/"+viewerID, owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusNoContent)
+
+	// Sans octroi, le lecteur ne modifie rien
+	apitest.ExpectStatus(t, write(payments.ID, "prod"), http.StatusForbidden)
+
+	// L'équipe devient membre de l'environnement prod de payments
+	prodRole := teams + "/" + team.ID + "/projects/" + payments.ID + "/environments/prod/role"
+	resp = srv.Do(http.MethodPut, prodRole, viewer, map[string]string{"role": "member"})
+	apitest.ExpectStatus(t, resp, http.StatusForbidden)
+	resp = srv.Do(http.MethodPut, prodRole, owner, map[string]string{"role": "owner"})
+	apitest.ExpectStatus(t, resp, http.StatusBadRequest)
+	resp = srv.Do(http.MethodPut, teams+"/"+team.ID+"/projects/unknown/role", owner, map[string]string{"role": "member"})
+	apitest.ExpectStatus(t, resp, http.StatusNotFound)
+	resp = srv.Do(http.MethodPut, prodRole, owner, map[string]string{"role": "member"})
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+
+	apitest.ExpectStatus(t, write(payments.ID, "prod"), http.StatusCreated)
+	apitest.ExpectStatus(t, write(payments.ID, "staging"), http.StatusForbidden)
+	apitest.ExpectStatus(t, write(search.ID, "prod"), http.StatusForbidden)
+
+	// Un octroi sur le projet entier couvre tous ses environnements ; les
+	// droits d'administration restent à l'organisation
+	resp = srv.Do(http.MethodPut, teams+"/"+team.ID+"/projects/"+search.ID+"/role", owner, map[string]string{"role": "member"})
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	apitest.ExpectStatus(t, write(search.ID, "staging"), http.StatusCreated)
+	resp = srv.Do(http.MethodPost, secrets(search.ID, "staging")+"/API_KEY/lock", viewer, nil)
+	apitest.ExpectStatus(t, resp, http.StatusForbidden)
+
+	resp = srv.Do(http.MethodGet, teams+"/"+team.ID, viewer, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	apitest.DecodeJSON(t, resp, &team)
+	if len(team.Grants) != 2 {
+		t.Errorf("Expected two grants, got %+v", team.Grants)
+	}
+
+	// Retirer l'octroi, ou le membre de l'équipe, retire le rôle hérité
+	resp = srv.Do(http.MethodDelete, prodRole, owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusNoContent)
+	resp = srv.Do(http.MethodPut, secrets(payments.ID, "prod")+"/API_KEY", viewer, models.Secret{Value: "pQ7!zR2#xW9$kL4&"})
+	apitest.ExpectStatus(t, resp, http.StatusForbidden)
+	resp = srv.Do(http.MethodDelete, teams+"/"+team.ID+"/members/"+viewerID, owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusNoContent)
+	resp = srv.Do(http.MethodPut, secrets(search.ID, "staging")+"/API_KEY", viewer, models.Secret{Value: "pQ7!zR2#xW9$kL4&"})
+	apitest.ExpectStatus(t, resp, http.StatusForbidden)
+}
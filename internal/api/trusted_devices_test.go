@@ -0,0 +1,98 @@
+// filepath: internal/api/trusted_devices_test.go
+
+package api_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"secrets-manager/internal/api/apitest"
+	"secrets-manager/internal/auth"
+	"secrets-manager/internal/models"
+)
+
+func TestTrustedDevices(t *testing.T) {
+	srv := apitest.NewServer(t)
+	userID := srv.Register("device@example.com", "password123")
+	session := srv.Login("device@example.com", "password123")
+
+	resp := srv.Do(http.MethodPost, "/api/v1/auth/mfa/enroll", session, nil)
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+	var enrollment auth.MFAEnrollment
+	apitest.DecodeJSON(t, resp, &enrollment)
+	code := func() string {
+		mfa, err := srv.UserMFA.GetUserMFA(context.Background(), userID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		mfa.LastStep = 0
+		srv.UserMFA.SaveUserMFA(context.Background(), mfa)
+		code, _ := auth.TOTPCode(enrollment.Secret, time.Now())
+		return code
+	}
+	resp = srv.Do(http.MethodPost, "/api/v1/auth/mfa/activate", session, map[string]string{"code": code()})
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var tokens map[string]string
+	apitest.DecodeJSON(t, resp, &tokens)
+
+	browser := http.Header{"User-Agent": {"Firefox/131.0"}}
+	credentials := map[string]string{"email": "device@example.com", "password": "password123"}
+	login := func(header http.Header) map[string]any {
+		resp := srv.DoWithHeaders(http.MethodPost, "/api/v1/auth/login", "", header, credentials)
+		apitest.ExpectStatus(t, resp, http.StatusOK)
+		var body map[string]any
+		apitest.DecodeJSON(t, resp, &body)
+		return body
+	}
+
+	// Le code est demandé, puis l'appareil est mémorisé
+	challenge := login(browser)
+	resp = srv.DoWithHeaders(http.MethodPost, "/api/v1/auth/mfa/verify", "", browser, map[string]any{
+		"mfa_token": challenge["mfa_token"], "code": code(), "remember_device": true, "device_name": "laptop"})
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var cookie *http.Cookie
+	for _, c := range resp.Cookies() {
+		if c.Name == "sm_trusted_device" {
+			cookie = c
+		}
+	}
+	if cookie == nil || !cookie.HttpOnly || !cookie.Secure {
+		t.Fatalf("Expected a secure trusted device cookie, got %+v", resp.Cookies())
+	}
+
+	// Depuis le même navigateur, le code n'est plus demandé
+	trusted := browser.Clone()
+	trusted.Set("Cookie", cookie.Name+"="+cookie.Value)
+	if body := login(trusted); body["token"] == nil || body["mfa_required"] != nil {
+		t.Fatalf("Expected tokens without MFA challenge from the trusted device, got %+v", body)
+	} else {
+		resp = srv.Do(http.MethodGet, "/api/v1/me/devices", body["token"].(string), nil)
+		apitest.ExpectStatus(t, resp, http.StatusOK)
+	}
+
+	// Un cookie copié vers un autre navigateur n'est pas reconnu
+	copied := trusted.Clone()
+	copied.Set("User-Agent", "curl/8.0")
+	if body := login(copied); body["mfa_required"] != true {
+		t.Errorf("Expected an MFA challenge from another browser, got %+v", body)
+	}
+
+	resp = srv.Do(http.MethodGet, "/api/v1/me/devices", tokens["token"], nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var devices []models.TrustedDevice
+	apitest.DecodeJSON(t, resp, &devices)
+	if len(devices) != 1 || devices[0].Name != "laptop" || devices[0].UserAgent != "Firefox/131.0" || devices[0].LastUsedAt == nil {
+		t.Fatalf("Expected the used laptop device, got %+v", devices)
+	}
+
+	// Révoquer la confiance rétablit le code
+	resp = srv.Do(http.MethodDelete, "/api/v1/me/devices/"+devices[0].ID, tokens["token"], nil)
+	apitest.ExpectStatus(t, resp, http.StatusNoContent)
+	if body := login(trusted); body["mfa_required"] != true {
+		t.Errorf("Expected an MFA challenge once the device is revoked, got %+v", body)
+	}
+	resp = srv.Do(http.MethodDelete, "/api/v1/me/devices/"+devices[0].ID, tokens["token"], nil)
+	apitest.ExpectStatus(t, resp, http.StatusNotFound)
+}
@@ -0,0 +1,54 @@
+// filepath: internal/api/usage_test.go
+
+package api_test
+
+import (
+	"net/http"
+	"testing"
+
+	"secrets-manager/internal/api/apitest"
+	"secrets-manager/internal/models"
+)
+
+func TestUsageBreakdown(t *testing.T) {
+	srv := apitest.NewServer(t)
+	adminID := srv.Register("admin@example.com", "password123")
+	adminToken := srv.Login("admin@example.com", "password123")
+	memberID := srv.Register("member@example.com", "password123")
+	memberToken := srv.Login("member@example.com", "password123")
+
+	org := srv.CreateOrganization("acme", adminID)
+	srv.AddMember(org.ID, memberID, "member")
+
+	secrets := "/api/v1/organizations/" + org.ID + "/projects/p/environments/dev/secrets"
+	srv.Do(http.MethodGet, secrets, memberToken, nil)
+	srv.Do(http.MethodGet, secrets, memberToken, nil)
+	srv.Do(http.MethodGet, secrets, adminToken, nil)
+
+	// Les membres non administrateurs ne voient pas l'usage
+	resp := srv.Do(http.MethodGet, "/api/v1/organizations/"+org.ID+"/usage/breakdown", memberToken, nil)
+	apitest.ExpectStatus(t, resp, http.StatusForbidden)
+
+	resp = srv.Do(http.MethodGet, "/api/v1/organizations/"+org.ID+"/usage/breakdown", adminToken, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+
+	var breakdown models.UsageBreakdown
+	apitest.DecodeJSON(t, resp, &breakdown)
+
+	// 3 lectures + la tentative refusée du membre
+	if breakdown.Total != 4 {
+		t.Errorf("Expected 4 calls, got %d", breakdown.Total)
+	}
+	if len(breakdown.ByPrincipal) != 2 || breakdown.ByPrincipal[0].Key != "user:"+memberID {
+		t.Errorf("Expected member to be the top principal, got %+v", breakdown.ByPrincipal)
+	}
+	if len(breakdown.ByDay) != 1 {
+		t.Errorf("Expected a single day, got %+v", breakdown.ByDay)
+	}
+
+	// Les non-membres ne voient pas l'organisation
+	srv.Register("outsider@example.com", "password123")
+	outsiderToken := srv.Login("outsider@example.com", "password123")
+	resp = srv.Do(http.MethodGet, "/api/v1/organizations/"+org.ID+"/usage/breakdown", outsiderToken, nil)
+	apitest.ExpectStatus(t, resp, http.StatusNotFound)
+}
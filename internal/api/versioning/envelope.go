@@ -0,0 +1,90 @@
+// filepath: internal/api/versioning/envelope.go
+
+package versioning
+
+import (
+	"encoding/json"
+	"mime"
+	"net/http"
+	"strings"
+
+	"secrets-manager/internal/api/apierror"
+)
+
+// ErrorEnvelope convertit les erreurs en texte brut (http.Error,
+// apierror.Write) en enveloppe JSON apierror.Envelope. Les handlers restent
+// communs à toutes les versions ; les réponses d'erreur déjà en JSON et les
+// réponses réussies sont transmises telles quelles.
+func ErrorEnvelope(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ew := &envelopeWriter{ResponseWriter: w}
+		next.ServeHTTP(ew, r)
+		ew.finish()
+	})
+}
+
+// envelopeWriter retient le corps des erreurs en texte brut pour le
+// réécrire en JSON une fois le handler terminé
+type envelopeWriter struct {
+	http.ResponseWriter
+	wroteHeader bool
+	capturing   bool
+	status      int
+	message     strings.Builder
+}
+
+func (e *envelopeWriter) WriteHeader(status int) {
+	if e.wroteHeader {
+		return
+	}
+	e.wroteHeader = true
+
+	header := e.Header()
+	mediaType, _, _ := mime.ParseMediaType(header.Get("Content-Type"))
+	if status >= http.StatusBadRequest && mediaType == "text/plain" && header.Get("Content-Encoding") == "" {
+		e.capturing = true
+		e.status = status
+		return
+	}
+	e.ResponseWriter.WriteHeader(status)
+}
+
+func (e *envelopeWriter) Write(p []byte) (int, error) {
+	if !e.wroteHeader {
+		e.WriteHeader(http.StatusOK)
+	}
+	if e.capturing {
+		return e.message.Write(p)
+	}
+	return e.ResponseWriter.Write(p)
+}
+
+// Flush n'a pas d'effet sur une erreur en cours de capture
+func (e *envelopeWriter) Flush() {
+	if e.capturing {
+		return
+	}
+	if !e.wroteHeader {
+		e.WriteHeader(http.StatusOK)
+	}
+	if flusher, ok := e.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// finish écrit l'enveloppe de l'erreur capturée
+func (e *envelopeWriter) finish() {
+	if !e.capturing {
+		return
+	}
+	header := e.Header()
+	header.Set("Content-Type", "application/json")
+	header.Del("Content-Length")
+	e.ResponseWriter.WriteHeader(e.status)
+	json.NewEncoder(e.ResponseWriter).Encode(apierror.NewEnvelope(e.status, strings.TrimSpace(e.message.String())))
+}
+
+// Unwrap permet à http.ResponseController d'accéder au writer d'origine
+func (e *envelopeWriter) Unwrap() http.ResponseWriter {
+	return e.ResponseWriter
+}
@@ -0,0 +1,214 @@
+// filepath: internal/api/versioning/versioning.go
+
+// Package versioning sert les routes de l'API sous plusieurs versions
+// (/api/v1, /api/v2) et annonce leur retrait. Chaque route est déclarée une
+// seule fois pour toutes les versions qui la servent : Since et Until la
+// restreignent à un intervalle de versions, Deprecated annonce sa date de
+// retrait avec les en-têtes Deprecation (RFC 9745), Sunset (RFC 8594) et Link.
+package versioning
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// Version est une version majeure de l'API
+type Version int
+
+const (
+	V1 Version = 1
+	// V2 renvoie les erreurs dans une enveloppe JSON (voir ErrorEnvelope)
+	V2 Version = 2
+)
+
+// Versions sont les versions servies, de la plus ancienne à la plus récente
+var Versions = []Version{V1, V2}
+
+func (v Version) String() string {
+	return "v" + strconv.Itoa(int(v))
+}
+
+// Prefix renvoie le préfixe des chemins de la version (ex: /api/v2)
+func (v Version) Prefix() string {
+	return "/api/" + v.String()
+}
+
+// Deprecation annonce le retrait d'une version ou d'une route
+type Deprecation struct {
+	// Since est la date à partir de laquelle l'utilisation est déconseillée
+	Since time.Time
+	// Sunset est la date de retrait, zéro tant qu'elle n'est pas fixée.
+	// À partir de cette date, les requêtes reçoivent 410 Gone.
+	Sunset time.Time
+	// Successor est l'URL de la remplaçante. Pour une version dépréciée,
+	// vide désigne le même chemin dans la dernière version qui le sert.
+	Successor string
+}
+
+// apply écrit les en-têtes d'annonce et indique si la date de retrait est passée
+func (d Deprecation) apply(w http.ResponseWriter, successor string, now time.Time) (gone bool) {
+	header := w.Header()
+	header.Set("Deprecation", "@"+strconv.FormatInt(d.Since.Unix(), 10))
+	if !d.Sunset.IsZero() {
+		header.Set("Sunset", d.Sunset.UTC().Format(http.TimeFormat))
+	}
+	if d.Successor != "" {
+		successor = d.Successor
+	}
+	if successor != "" {
+		header.Set("Link", "<"+successor+`>; rel="successor-version"`)
+	}
+	return !d.Sunset.IsZero() && !now.Before(d.Sunset)
+}
+
+// Option restreint ou annonce le retrait d'une route
+type Option func(*route)
+
+type route struct {
+	since       Version
+	until       Version
+	deprecation *Deprecation
+}
+
+// serves indique si la route existe dans la version v
+func (r *route) serves(v Version) bool {
+	return v >= r.since && (r.until == 0 || v <= r.until)
+}
+
+// Since réserve la route aux versions à partir de v (changement incompatible
+// introduit par v)
+func Since(v Version) Option {
+	return func(r *route) { r.since = v }
+}
+
+// Until réserve la route aux versions jusqu'à v incluse (route retirée
+// dans la version suivante)
+func Until(v Version) Option {
+	return func(r *route) { r.until = v }
+}
+
+// Deprecated annonce le retrait de la route dans toutes ses versions
+func Deprecated(d Deprecation) Option {
+	return func(r *route) { r.deprecation = &d }
+}
+
+// Router enregistre les routes sur le sous-routeur de chaque version
+type Router struct {
+	routers      map[Version]*mux.Router
+	deprecations map[Version]*Deprecation
+	now          func() time.Time
+}
+
+// NewRouter crée un routeur sans version ; Mount ajoute les versions servies
+func NewRouter() *Router {
+	return &Router{
+		routers:      make(map[Version]*mux.Router),
+		deprecations: make(map[Version]*Deprecation),
+		now:          time.Now,
+	}
+}
+
+// Mount sert la version v avec router, un sous-routeur de préfixe v.Prefix()
+func (r *Router) Mount(v Version, router *mux.Router) {
+	r.routers[v] = router
+}
+
+// Deprecate annonce le retrait de toutes les routes de la version v, y
+// compris celles déjà enregistrées
+func (r *Router) Deprecate(v Version, d Deprecation) {
+	r.deprecations[v] = &d
+}
+
+// Use ajoute des middlewares à toutes les versions
+func (r *Router) Use(middlewares ...mux.MiddlewareFunc) {
+	for _, v := range Versions {
+		if router, ok := r.routers[v]; ok {
+			router.Use(middlewares...)
+		}
+	}
+}
+
+// PathPrefix renvoie un routeur servant les routes de préfixe prefix dans
+// chaque version montée, auxquelles Use ajoute des middlewares propres. Les
+// routes du sous-routeur sont enregistrées sans le préfixe ; les
+// dépréciations de versions restent partagées.
+func (r *Router) PathPrefix(prefix string) *Router {
+	sub := &Router{
+		routers:      make(map[Version]*mux.Router),
+		deprecations: r.deprecations,
+		now:          r.now,
+	}
+	for v, router := range r.routers {
+		sub.routers[v] = router.PathPrefix(prefix).Subrouter()
+	}
+	return sub
+}
+
+// Routes sont les routes d'un même chemin dans les différentes versions
+type Routes []*mux.Route
+
+// Methods restreint les routes aux méthodes HTTP données
+func (rs Routes) Methods(methods ...string) Routes {
+	for _, route := range rs {
+		route.Methods(methods...)
+	}
+	return rs
+}
+
+// HandleFunc enregistre f pour path dans les versions qui servent la route
+func (r *Router) HandleFunc(path string, f func(http.ResponseWriter, *http.Request), opts ...Option) Routes {
+	return r.Handle(path, http.HandlerFunc(f), opts...)
+}
+
+// Handle enregistre handler pour path dans les versions qui servent la route
+func (r *Router) Handle(path string, handler http.Handler, opts ...Option) Routes {
+	rt := &route{since: V1}
+	for _, opt := range opts {
+		opt(rt)
+	}
+
+	var routes Routes
+	for i, v := range Versions {
+		router, ok := r.routers[v]
+		if !ok || !rt.serves(v) {
+			continue
+		}
+		// Dernière version qui sert aussi la route (Link d'une version dépréciée)
+		var successor Version
+		for _, next := range Versions[i+1:] {
+			if _, mounted := r.routers[next]; mounted && rt.serves(next) {
+				successor = next
+			}
+		}
+		routes = append(routes, router.Handle(path, r.announce(v, successor, rt.deprecation, handler)))
+	}
+	return routes
+}
+
+// announce ajoute au handler les en-têtes de retrait de la version et de la
+// route ; la dépréciation de la route, plus précise, l'emporte
+func (r *Router) announce(v, successor Version, deprecation *Deprecation, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		now := r.now()
+		gone := false
+		if d := r.deprecations[v]; d != nil {
+			link := ""
+			if successor != 0 {
+				link = successor.Prefix() + strings.TrimPrefix(req.URL.Path, v.Prefix())
+			}
+			gone = d.apply(w, link, now)
+		}
+		if deprecation != nil {
+			gone = deprecation.apply(w, "", now) || gone
+		}
+		if gone {
+			http.Error(w, "Cette route de l'API a été retirée", http.StatusGone)
+			return
+		}
+		next.ServeHTTP(w, req)
+	})
+}
@@ -0,0 +1,128 @@
+// filepath: internal/api/versioning/versioning_test.go
+
+package versioning
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+func newTestRouter() (*mux.Router, *Router) {
+	root := mux.NewRouter()
+	router := NewRouter()
+	for _, v := range Versions {
+		router.Mount(v, root.PathPrefix(v.Prefix()).Subrouter())
+	}
+	return root, router
+}
+
+func serve(root http.Handler, path string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	root.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	return rec
+}
+
+func TestVersionGates(t *testing.T) {
+	root, router := newTestRouter()
+	ok := func(w http.ResponseWriter, r *http.Request) {}
+	router.HandleFunc("/legacy", ok, Until(V1)).Methods("GET")
+	router.HandleFunc("/preview", ok, Since(V2)).Methods("GET")
+	router.HandleFunc("/stable", ok).Methods("GET")
+
+	cases := map[string]int{
+		"/api/v1/legacy":  http.StatusOK,
+		"/api/v2/legacy":  http.StatusNotFound,
+		"/api/v1/preview": http.StatusNotFound,
+		"/api/v2/preview": http.StatusOK,
+		"/api/v1/stable":  http.StatusOK,
+		"/api/v2/stable":  http.StatusOK,
+	}
+	for path, expected := range cases {
+		if rec := serve(root, path); rec.Code != expected {
+			t.Errorf("Expected %d for %s, got %d", expected, path, rec.Code)
+		}
+	}
+}
+
+func TestDeprecatedVersion(t *testing.T) {
+	root, router := newTestRouter()
+	since := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	router.Deprecate(V1, Deprecation{Since: since, Sunset: since.AddDate(1, 0, 0)})
+	router.HandleFunc("/items/{id}", func(w http.ResponseWriter, r *http.Request) {}).Methods("GET")
+
+	router.now = func() time.Time { return since.AddDate(0, 6, 0) }
+	rec := serve(root, "/api/v1/items/42")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200 before the sunset, got %d", rec.Code)
+	}
+	if got := rec.Header().Get("Deprecation"); got != "@1767225600" {
+		t.Errorf("Expected Deprecation @1767225600, got %q", got)
+	}
+	if got := rec.Header().Get("Sunset"); got != "Fri, 01 Jan 2027 00:00:00 GMT" {
+		t.Errorf("Expected Sunset date, got %q", got)
+	}
+	if got := rec.Header().Get("Link"); got != `</api/v2/items/42>; rel="successor-version"` {
+		t.Errorf("Expected a successor-version link to v2, got %q", got)
+	}
+	if rec := serve(root, "/api/v2/items/42"); rec.Header().Get("Deprecation") != "" {
+		t.Errorf("Expected no Deprecation header on v2")
+	}
+
+	router.now = func() time.Time { return since.AddDate(1, 0, 0) }
+	if rec := serve(root, "/api/v1/items/42"); rec.Code != http.StatusGone {
+		t.Errorf("Expected 410 after the sunset, got %d", rec.Code)
+	}
+}
+
+func TestErrorEnvelope(t *testing.T) {
+	handler := ErrorEnvelope(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "5")
+		http.Error(w, "Stockage indisponible", http.StatusServiceUnavailable)
+	}))
+	rec := serve(handler, "/")
+
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected 503, got %d", rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Expected application/json, got %q", ct)
+	}
+	if rec.Header().Get("Retry-After") != "5" {
+		t.Errorf("Expected Retry-After to be kept")
+	}
+	expected := `{"error":{"status":503,"code":"unavailable","message":"Stockage indisponible"}}` + "\n"
+	if rec.Body.String() != expected {
+		t.Errorf("Expected %s, got %s", expected, rec.Body.String())
+	}
+}
+
+func TestPathPrefixMiddlewares(t *testing.T) {
+	root, router := newTestRouter()
+	ok := func(w http.ResponseWriter, r *http.Request) {}
+	forbidden := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		})
+	}
+	router.HandleFunc("/open", ok).Methods("GET")
+	restricted := router.PathPrefix("/restricted")
+	restricted.Use(forbidden)
+	restricted.HandleFunc("/items/{id}", ok).Methods("GET")
+
+	cases := map[string]int{
+		"/api/v1/open":               http.StatusOK,
+		"/api/v2/open":               http.StatusOK,
+		"/api/v1/restricted/items/1": http.StatusForbidden,
+		"/api/v2/restricted/items/1": http.StatusForbidden,
+		"/api/v2/restricted/other":   http.StatusNotFound,
+	}
+	for path, expected := range cases {
+		if rec := serve(root, path); rec.Code != expected {
+			t.Errorf("Expected %d for %s, got %d", expected, path, rec.Code)
+		}
+	}
+}
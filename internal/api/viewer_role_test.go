@@ -0,0 +1,105 @@
+// filepath: internal/api/viewer_role_test.go
+
+package api_test
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+
+	"secrets-manager/internal/api/apitest"
+	"secrets-manager/internal/api/middleware"
+	"secrets-manager/internal/models"
+)
+
+// Variables des modèles de route ({name} ou {name:regexp})
+var routeVariable = regexp.MustCompile(`\{([^}:]+)(:[^}]+)?\}`)
+
+// TestViewerCannotMutate parcourt toutes les routes d'une organisation qui
+// modifient quelque chose (y compris les opérations en masse et celles des
+// administrateurs) : un lecteur reçoit 403 et un non-membre 404, sans que
+// rien ne change. Une nouvelle route est couverte dès son ajout.
+func TestViewerCannotMutate(t *testing.T) {
+	srv := apitest.NewServer(t)
+	ownerID := srv.Register("owner@example.com", "password123")
+	owner := srv.Login("owner@example.com", "password123")
+	viewerID := srv.Register("viewer@example.com", "password123")
+	viewer := srv.Login("viewer@example.com", "password123")
+	srv.Register("outsider@example.com", "password123")
+	outsider := srv.Login("outsider@example.com", "password123")
+	org := srv.CreateOrganization("acme", ownerID)
+	srv.AddMember(org.ID, viewerID, "viewer")
+	project := srv.CreateProject(org.ID, "payments", ownerID)
+	secret := "/api/v1/organizations/" + org.ID + "/projects/" + project.ID + "/environments/prod/secrets/API_KEY"
+	resp := srv.Do(http.MethodPost, "/api/v1/organizations/"+org.ID+"/projects/"+project.ID+"/environments/prod/secrets",
+		owner, models.Secret{Name: "API_KEY", Value: "kX9#vQ2$mL7!pR4&wT8*"})
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+
+	values := map[string]string{
+		"orgID":     org.ID,
+		"projectID": project.ID,
+		"env":       "prod",
+		"name":      "API_KEY",
+		"userID":    ownerID,
+	}
+	var routes int
+	err := srv.Router.Walk(func(route *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
+		template, err := route.GetPathTemplate()
+		if err != nil || !strings.Contains(template, "{orgID}") {
+			return nil
+		}
+		methods, err := route.GetMethods()
+		if err != nil {
+			return nil
+		}
+		path := routeVariable.ReplaceAllStringFunc(template, func(variable string) string {
+			if value, ok := values[routeVariable.FindStringSubmatch(variable)[1]]; ok {
+				return value
+			}
+			return "unknown"
+		})
+
+		for _, method := range methods {
+			if method == http.MethodOptions || middleware.IsReadOnlyRoute(method, template) {
+				continue
+			}
+			routes++
+			for _, tc := range []struct {
+				caller string
+				token  string
+				status int
+			}{
+				{"viewer", viewer, http.StatusForbidden},
+				{"outsider", outsider, http.StatusNotFound},
+			} {
+				resp := srv.Do(method, path, tc.token, map[string]any{})
+				resp.Body.Close()
+				if resp.StatusCode != tc.status {
+					t.Errorf("Expected %d for %s on %s %s, got %d", tc.status, tc.caller, method, template,
+						resp.StatusCode)
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if routes == 0 {
+		t.Fatal("Expected mutating organization routes")
+	}
+
+	// Rien n'a été modifié ni supprimé
+	resp = srv.Do(http.MethodGet, secret, owner, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var read models.Secret
+	apitest.DecodeJSON(t, resp, &read)
+	if read.Value != "kX9#vQ2$mL7!pR4&wT8*" {
+		t.Errorf("Expected the secret to be untouched, got %+v", read)
+	}
+	resp = srv.Do(http.MethodGet, "/api/v1/organizations/"+org.ID+"/members", viewer, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+}
@@ -0,0 +1,137 @@
+// filepath: internal/api/webhooks_test.go
+
+package api_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"secrets-manager/internal/api/apitest"
+	"secrets-manager/internal/events"
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/webhooks"
+)
+
+func TestWebhookTestAndRedeliver(t *testing.T) {
+	srv := apitest.NewServer(t)
+	ownerID := srv.Register("owner@example.com", "password123")
+	memberID := srv.Register("member@example.com", "password123")
+	ownerToken := srv.Login("owner@example.com", "password123")
+	memberToken := srv.Login("member@example.com", "password123")
+	org := srv.CreateOrganization("acme", ownerID)
+	srv.AddMember(org.ID, memberID, "member")
+
+	// Le consommateur échoue au premier appel puis accepte les suivants
+	var secret atomic.Value
+	var calls atomic.Int32
+	consumer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		signature := r.Header.Get(webhooks.HeaderSignature)
+		ts, _ := strconv.ParseInt(strings.TrimPrefix(strings.Split(signature, ",")[0], "t="), 10, 64)
+		if signature != webhooks.Sign(secret.Load().(string), time.Unix(ts, 0), body) {
+			http.Error(w, "bad signature", http.StatusUnauthorized)
+			return
+		}
+		if calls.Add(1) == 1 {
+			http.Error(w, "consumer crashed", http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer consumer.Close()
+
+	base := "/api/v1/organizations/" + org.ID
+
+	resp := srv.Do(http.MethodPost, base+"/webhooks", ownerToken, map[string]any{"url": "http://example.com/hook"})
+	apitest.ExpectStatus(t, resp, http.StatusBadRequest)
+	resp = srv.Do(http.MethodPost, base+"/webhooks", memberToken, map[string]any{"url": consumer.URL})
+	apitest.ExpectStatus(t, resp, http.StatusForbidden)
+
+	// Adresses internes refusées (réseaux privés, métadonnées des clouds)
+	for _, internal := range []string{
+		"https://10.0.0.1/hook",
+		"https://169.254.169.254/latest/meta-data/",
+		"https://[fd00:ec2::254]/",
+		"https://[::ffff:192.168.1.1]/hook",
+	} {
+		resp = srv.Do(http.MethodPost, base+"/webhooks", ownerToken, map[string]any{"url": internal})
+		apitest.ExpectStatus(t, resp, http.StatusBadRequest)
+	}
+
+	resp = srv.Do(http.MethodPost, base+"/webhooks", ownerToken, map[string]any{"url": consumer.URL})
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+	var webhook models.Webhook
+	apitest.DecodeJSON(t, resp, &webhook)
+	if !strings.HasPrefix(webhook.Secret, "whsec_") {
+		t.Fatalf("Expected the signing secret in the creation response, got %q", webhook.Secret)
+	}
+	secret.Store(webhook.Secret)
+
+	// Le secret n'est plus renvoyé ensuite
+	resp = srv.Do(http.MethodGet, base+"/webhooks", ownerToken, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var list []models.Webhook
+	apitest.DecodeJSON(t, resp, &list)
+	if len(list) != 1 || list[0].Secret != "" {
+		t.Errorf("Expected one webhook without its secret, got %+v", list)
+	}
+
+	resp = srv.Do(http.MethodPost, base+"/webhooks/"+webhook.ID+":test", ownerToken, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var failed models.WebhookDelivery
+	apitest.DecodeJSON(t, resp, &failed)
+	if failed.StatusCode != http.StatusInternalServerError || !strings.Contains(failed.ResponseBody, "consumer crashed") {
+		t.Errorf("Expected the consumer's 500 response to be recorded, got %+v", failed)
+	}
+	if failed.EventType != events.WebhookPingV1 {
+		t.Errorf("Expected a ping event, got %s", failed.EventType)
+	}
+
+	resp = srv.Do(http.MethodPost, base+"/deliveries/"+failed.ID+":redeliver", ownerToken, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var redelivered models.WebhookDelivery
+	apitest.DecodeJSON(t, resp, &redelivered)
+	if redelivered.StatusCode != http.StatusOK || redelivered.RedeliveryOf != failed.ID {
+		t.Errorf("Expected a successful redelivery of %s, got %+v", failed.ID, redelivered)
+	}
+	if redelivered.Payload != failed.Payload {
+		t.Errorf("Expected the same payload to be redelivered")
+	}
+
+	resp = srv.Do(http.MethodGet, base+"/webhooks/"+webhook.ID+"/deliveries", ownerToken, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var deliveries []models.WebhookDelivery
+	apitest.DecodeJSON(t, resp, &deliveries)
+	if len(deliveries) != 2 || deliveries[0].ID != redelivered.ID {
+		t.Errorf("Expected two deliveries, most recent first, got %+v", deliveries)
+	}
+
+	resp = srv.Do(http.MethodPost, base+"/deliveries/unknown:redeliver", ownerToken, nil)
+	apitest.ExpectStatus(t, resp, http.StatusNotFound)
+}
+
+func TestEventSchemasCatalog(t *testing.T) {
+	srv := apitest.NewServer(t)
+	srv.Register("owner@example.com", "password123")
+	token := srv.Login("owner@example.com", "password123")
+
+	resp := srv.Do(http.MethodGet, "/api/v1/events/schemas", token, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var schemas []events.Schema
+	apitest.DecodeJSON(t, resp, &schemas)
+
+	if len(schemas) != len(events.Types()) {
+		t.Fatalf("Expected %d schemas, got %d", len(events.Types()), len(schemas))
+	}
+	for _, schema := range schemas {
+		if schema.Type == events.SecretCreatedV1 && (schema.Name != "secret.created" || schema.Version != 1) {
+			t.Errorf("Expected secret.created version 1, got %+v", schema)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+// filepath: internal/api/workloads_test.go
+
+package api_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"secrets-manager/internal/api/apitest"
+	"secrets-manager/internal/models"
+)
+
+func TestSecretReadsWithWorkloadHeader(t *testing.T) {
+	srv := apitest.NewServer(t)
+	ownerID := srv.Register("owner@example.com", "password123")
+	token := srv.Login("owner@example.com", "password123")
+	org := srv.CreateOrganization("acme", ownerID)
+	project := srv.CreateProject(org.ID, "api", ownerID)
+
+	secrets := "/api/v1/organizations/" + org.ID + "/projects/" + project.ID + "/environments/prod/secrets"
+	resp := srv.Do(http.MethodPost, secrets, token, models.Secret{Name: "DB_PASSWORD", Value: "v1"})
+	apitest.ExpectStatus(t, resp, http.StatusCreated)
+
+	tests := []struct {
+		name     string
+		path     string
+		workload string
+		status   int
+	}{
+		{"Get without workload", secrets + "/DB_PASSWORD", "", http.StatusOK},
+		{"Get with workload", secrets + "/DB_PASSWORD", "billing-api/pod-7f9c", http.StatusOK},
+		{"List with workload", secrets, "github:acme/api#42", http.StatusOK},
+		{"Too long", secrets + "/DB_PASSWORD", strings.Repeat("w", 129), http.StatusBadRequest},
+		{"Non-ASCII", secrets, "facturation-é", http.StatusBadRequest},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			header := http.Header{}
+			if tc.workload != "" {
+				header.Set("X-Workload", tc.workload)
+			}
+			resp := srv.DoWithHeaders(http.MethodGet, tc.path, token, header, nil)
+			apitest.ExpectStatus(t, resp, tc.status)
+		})
+	}
+}
+
+func TestSecretConsumersAndDependencyGraph(t *testing.T) {
+	srv := apitest.NewServer(t)
+	ownerID := srv.Register("owner@example.com", "password123")
+	token := srv.Login("owner@example.com", "password123")
+	org := srv.CreateOrganization("acme", ownerID)
+	project := srv.CreateProject(org.ID, "api", ownerID)
+
+	secrets := "/api/v1/organizations/" + org.ID + "/projects/" + project.ID + "/environments/prod/secrets"
+	for _, name := range []string{"DB_PASSWORD", "STRIPE_KEY"} {
+		resp := srv.Do(http.MethodPost, secrets, token, models.Secret{Name: name, Value: "v1"})
+		apitest.ExpectStatus(t, resp, http.StatusCreated)
+	}
+	read := func(path, workload string) {
+		t.Helper()
+		header := http.Header{}
+		if workload != "" {
+			header.Set("X-Workload", workload)
+		}
+		resp := srv.DoWithHeaders(http.MethodGet, path, token, header, nil)
+		apitest.ExpectStatus(t, resp, http.StatusOK)
+	}
+	read(secrets+"/DB_PASSWORD", "billing")
+	read(secrets+"/DB_PASSWORD", "billing")
+	read(secrets, "reporting")
+	read(secrets+"/DB_PASSWORD", "")
+
+	resp := srv.Do(http.MethodGet, secrets+"/DB_PASSWORD/consumers", token, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var consumers []models.SecretConsumer
+	apitest.DecodeJSON(t, resp, &consumers)
+	reads := map[string]int64{}
+	for _, consumer := range consumers {
+		reads[consumer.Workload] += consumer.Reads
+	}
+	if len(reads) != 3 || reads["billing"] != 2 || reads["reporting"] != 1 || reads[""] != 1 {
+		t.Errorf("Expected billing, reporting and an undeclared consumer, got %v", reads)
+	}
+
+	resp = srv.Do(http.MethodGet, "/api/v1/organizations/"+org.ID+"/projects/"+project.ID+"/dependencies?days=7", token, nil)
+	apitest.ExpectStatus(t, resp, http.StatusOK)
+	var graph models.DependencyGraph
+	apitest.DecodeJSON(t, resp, &graph)
+	if strings.Join(graph.Workloads, ",") != "billing,reporting" {
+		t.Errorf("Expected billing and reporting workloads, got %v", graph.Workloads)
+	}
+	// DB_PASSWORD : billing, reporting et lecture non attribuée ; STRIPE_KEY : reporting
+	if len(graph.Dependencies) != 4 {
+		t.Errorf("Expected 4 dependencies, got %d", len(graph.Dependencies))
+	}
+
+	resp = srv.Do(http.MethodGet, "/api/v1/organizations/"+org.ID+"/projects/"+project.ID+"/dependencies?days=365", token, nil)
+	apitest.ExpectStatus(t, resp, http.StatusBadRequest)
+}
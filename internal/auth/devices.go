@@ -0,0 +1,116 @@
+// filepath: internal/auth/devices.go
+
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// trustedDevicePrefix distingue les cookies des appareils de confiance
+const trustedDevicePrefix = "smdt_"
+
+// Device identifie l'appareil d'une connexion
+type Device struct {
+	// Token est le cookie remis par TrustDevice ("" s'il n'en présente pas)
+	Token string
+	// Fingerprint est l'empreinte du navigateur (voir DeviceFingerprint)
+	Fingerprint string
+	UserAgent   string
+	IPAddress   string
+	// Name est le nom choisi par l'utilisateur pour reconnaître l'appareil
+	Name string
+}
+
+// DeviceFingerprint renvoie l'empreinte d'un navigateur : un cookie
+// d'appareil de confiance copié vers un autre navigateur n'est pas reconnu
+func DeviceFingerprint(userAgent string) string {
+	sum := sha256.Sum256([]byte(userAgent))
+	return hex.EncodeToString(sum[:])
+}
+
+// EnableDeviceTrust permet aux utilisateurs de ne plus présenter de code
+// TOTP depuis un appareil de confiance pendant period. Sans appel (ou avec
+// une durée nulle), un code est exigé à chaque connexion.
+func (s *Service) EnableDeviceTrust(devices storage.TrustedDevicesRepository, period time.Duration) {
+	s.devices = devices
+	s.trustPeriod = period
+}
+
+// LoginObserver est prévenu des connexions par mot de passe réussies
+// (voir loginalerts)
+type LoginObserver interface {
+	// ObserveLogin renvoie vrai si un code TOTP doit être exigé, même
+	// depuis un appareil de confiance
+	ObserveLogin(ctx context.Context, userID string, device *Device) bool
+}
+
+// ObserveLogins transmet chaque connexion par mot de passe à observer
+func (s *Service) ObserveLogins(observer LoginObserver) {
+	s.logins = observer
+}
+
+// DeviceTrustEnabled indique si les appareils de confiance sont acceptés
+func (s *Service) DeviceTrustEnabled() bool {
+	return s.devices != nil && s.trustPeriod > 0
+}
+
+// TrustDevice enregistre l'appareil d'un utilisateur qui vient de présenter
+// un code TOTP et renvoie le cookie à lui remettre, valable jusqu'à
+// l'expiration de l'appareil
+func (s *Service) TrustDevice(ctx context.Context, userID string, device *Device) (string, *models.TrustedDevice, error) {
+	if !s.DeviceTrustEnabled() {
+		return "", nil, errors.New("appareils de confiance désactivés")
+	}
+	token, hash, _, err := newToken(trustedDevicePrefix)
+	if err != nil {
+		return "", nil, err
+	}
+
+	trusted := &models.TrustedDevice{
+		UserID:      userID,
+		Name:        device.Name,
+		TokenHash:   hash,
+		Fingerprint: device.Fingerprint,
+		UserAgent:   device.UserAgent,
+		IPAddress:   device.IPAddress,
+		ExpiresAt:   time.Now().Add(s.trustPeriod).Truncate(time.Second),
+	}
+	if err := s.devices.CreateTrustedDevice(ctx, trusted); err != nil {
+		return "", nil, err
+	}
+	return token, trusted, nil
+}
+
+// deviceTrusted indique si la connexion provient d'un appareil de confiance
+// de l'utilisateur, et enregistre alors son utilisation. Un cookie expiré,
+// présenté par un autre navigateur ou antérieur à une révocation des
+// sessions (confinement) n'est pas reconnu.
+func (s *Service) deviceTrusted(ctx context.Context, user *models.User, device *Device) (bool, error) {
+	if !s.DeviceTrustEnabled() || device == nil || device.Token == "" {
+		return false, nil
+	}
+	trusted, err := s.devices.GetTrustedDeviceByHash(ctx, HashPersonalAccessToken(device.Token))
+	if errors.Is(err, storage.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	now := time.Now()
+	if trusted.UserID != user.ID || trusted.Fingerprint != device.Fingerprint ||
+		!now.Before(trusted.ExpiresAt) || revoked(user, trusted.CreatedAt) {
+		return false, nil
+	}
+	if err := s.devices.TouchTrustedDevice(ctx, trusted.ID, now); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,71 @@
+// filepath: internal/auth/email_verification.go
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+
+	"secrets-manager/internal/storage"
+)
+
+const (
+	// emailVerificationTokenType est le type du token envoyé par email pour
+	// confirmer l'adresse d'un utilisateur
+	emailVerificationTokenType = "email_verification"
+	emailVerificationExpiry    = 24 * time.Hour
+)
+
+// EmailVerificationToken génère le token qui confirme l'adresse actuelle
+// de l'utilisateur, à lui envoyer par email. Il renvoie aussi l'adresse.
+func (s *Service) EmailVerificationToken(ctx context.Context, userID string) (string, string, error) {
+	user, err := s.users.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return "", "", ErrUserNotFound
+		}
+		return "", "", err
+	}
+
+	token, _, err := s.generateToken(user.ID, emailVerificationTokenType, emailVerificationExpiry,
+		jwt.MapClaims{"email": user.Email})
+	if err != nil {
+		return "", "", err
+	}
+	return token, user.Email, nil
+}
+
+// VerifyEmail vérifie un token de confirmation d'adresse et renvoie
+// l'utilisateur dont l'adresse est confirmée. Le token n'est plus accepté
+// si l'adresse a changé depuis son envoi ou si les tokens de l'utilisateur
+// ont été révoqués.
+func (s *Service) VerifyEmail(ctx context.Context, token string) (string, error) {
+	claims, err := s.parseToken(token)
+	if err != nil {
+		return "", err
+	}
+	if tokenType, ok := claims["type"].(string); !ok || tokenType != emailVerificationTokenType {
+		return "", ErrInvalidToken
+	}
+	userID, ok := claims["sub"].(string)
+	if !ok {
+		return "", ErrInvalidToken
+	}
+	user, err := s.users.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return "", ErrInvalidToken
+		}
+		return "", err
+	}
+	if email, ok := claims["email"].(string); !ok || email != user.Email {
+		return "", ErrInvalidToken
+	}
+	if iat, ok := claims["iat"].(float64); !ok || revoked(user, time.Unix(int64(iat), 0)) {
+		return "", ErrTokenRevoked
+	}
+	return user.ID, nil
+}
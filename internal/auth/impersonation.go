@@ -0,0 +1,165 @@
+// filepath: internal/auth/impersonation.go
+
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// Durées des usurpations d'identité : par défaut et au plus
+const (
+	DefaultImpersonationDuration = 15 * time.Minute
+	MaxImpersonationDuration     = time.Hour
+)
+
+// PlatformAdminRole est le rôle plateforme (models.User.Role) des membres
+// du support autorisés à usurper une identité
+const PlatformAdminRole = "admin"
+
+var (
+	// ErrImpersonationDisabled indique que les usurpations d'identité ne
+	// sont pas activées sur ce serveur
+	ErrImpersonationDisabled = errors.New("usurpation d'identité non activée")
+	// ErrNotPlatformAdmin indique que l'opérateur n'est pas administrateur
+	// de la plateforme
+	ErrNotPlatformAdmin = errors.New("l'opérateur n'est pas administrateur de la plateforme")
+)
+
+// ImpersonationToken est le token remis à l'administrateur qui usurpe
+// l'identité d'un utilisateur. Aucun token de rafraîchissement n'est émis :
+// l'usurpation prend fin à l'expiration du token.
+type ImpersonationToken struct {
+	Token         string                `json:"token"`
+	ExpiresAt     time.Time             `json:"expires_at"`
+	Impersonation *models.Impersonation `json:"impersonation"`
+}
+
+// EnableImpersonation accepte les usurpations d'identité par le support.
+// Sans appel, leur émission et leurs tokens sont refusés.
+func (s *Service) EnableImpersonation(impersonations storage.ImpersonationsRepository) {
+	s.impersonations = impersonations
+}
+
+// Impersonate enregistre l'usurpation de l'identité d'un utilisateur et émet
+// son token d'accès : il porte l'utilisateur (sub), ses rôles et le claim
+// act_as (l'usurpation), et expire au plus tard après
+// MaxImpersonationDuration. impersonation porte l'utilisateur, l'opérateur,
+// l'appelant et le motif ; duration nulle vaut DefaultImpersonationDuration.
+func (s *Service) Impersonate(ctx context.Context, impersonation *models.Impersonation,
+	duration time.Duration) (*ImpersonationToken, error) {
+	if s.impersonations == nil {
+		return nil, ErrImpersonationDisabled
+	}
+	if duration <= 0 {
+		duration = DefaultImpersonationDuration
+	}
+	duration = min(duration, MaxImpersonationDuration)
+
+	if _, err := s.users.GetUserByID(ctx, impersonation.UserID); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	roles, err := s.users.GetUserRoles(ctx, impersonation.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	impersonation.CreatedAt = now.Truncate(time.Second)
+	impersonation.ExpiresAt = now.Add(duration).Truncate(time.Second)
+	impersonation.EndedAt = nil
+	if err := s.impersonations.CreateImpersonation(ctx, impersonation); err != nil {
+		return nil, err
+	}
+
+	extra := jwt.MapClaims{
+		"act_as": impersonation.ID,
+		"scope":  strings.Join(models.TokenScopes, " "),
+	}
+	if encoded, err := json.Marshal(roles); err == nil && len(encoded) <= maxRolesClaimSize {
+		extra["orgs"] = roles
+	}
+	token, expiresAt, err := s.generateToken(impersonation.UserID, "access", impersonation.ExpiresAt.Sub(now), extra)
+	if err != nil {
+		return nil, err
+	}
+	return &ImpersonationToken{Token: token, ExpiresAt: expiresAt, Impersonation: impersonation}, nil
+}
+
+// AuthenticateOperator authentifie l'opérateur d'une usurpation par son
+// propre token d'accès : la session doit être valide (voir CheckSession) et
+// ne pas être elle-même une usurpation. ErrNotPlatformAdmin si l'utilisateur
+// n'a pas le rôle plateforme PlatformAdminRole.
+func (s *Service) AuthenticateOperator(ctx context.Context, token string) (*models.User, error) {
+	claims, err := s.VerifyAccessToken(token)
+	if err != nil {
+		return nil, err
+	}
+	if claims.ImpersonationID != "" {
+		return nil, ErrInvalidToken
+	}
+	if err := s.CheckSession(ctx, claims); err != nil {
+		return nil, err
+	}
+	user, err := s.users.GetUserByID(ctx, claims.UserID)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, ErrInvalidToken
+		}
+		return nil, err
+	}
+	if user.Role != PlatformAdminRole {
+		return nil, ErrNotPlatformAdmin
+	}
+	return user, nil
+}
+
+// EndImpersonation termine une usurpation : ses tokens ne sont plus acceptés.
+// userID non vide restreint aux usurpations du compte de cet utilisateur.
+func (s *Service) EndImpersonation(ctx context.Context, id, userID string) error {
+	if s.impersonations == nil {
+		return ErrImpersonationDisabled
+	}
+	impersonation, err := s.impersonations.GetImpersonation(ctx, id)
+	if err != nil {
+		return err
+	}
+	if userID != "" && impersonation.UserID != userID {
+		return storage.ErrImpersonationNotFound
+	}
+	return s.impersonations.EndImpersonation(ctx, id, time.Now())
+}
+
+// checkImpersonation vérifie que l'usurpation d'un token (claim act_as) est
+// toujours en cours et concerne l'utilisateur du token. L'administrateur
+// s'étant authentifié, le code TOTP de l'utilisateur n'est pas exigé.
+func (s *Service) checkImpersonation(ctx context.Context, claims *Claims) error {
+	if s.impersonations == nil {
+		return ErrInvalidToken
+	}
+	impersonation, err := s.impersonations.GetImpersonation(ctx, claims.ImpersonationID)
+	if errors.Is(err, storage.ErrNotFound) {
+		return ErrInvalidToken
+	}
+	if err != nil {
+		return err
+	}
+	if impersonation.UserID != claims.UserID {
+		return ErrInvalidToken
+	}
+	if !impersonation.Active(time.Now()) {
+		return ErrTokenRevoked
+	}
+	return nil
+}
@@ -0,0 +1,258 @@
+// filepath: internal/auth/mfa.go
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+const (
+	// mfaPendingTokenType est le type du token remis à la connexion d'un
+	// utilisateur inscrit, échangé contre les tokens avec un code TOTP
+	mfaPendingTokenType = "mfa_pending"
+	mfaPendingExpiry    = 5 * time.Minute
+
+	// Au-delà de mfaMaxFailures codes refusés, la vérification est
+	// verrouillée pendant mfaLockout
+	mfaMaxFailures = 5
+	mfaLockout     = 15 * time.Minute
+)
+
+// mfaMethods est le claim amr (RFC 8176) des tokens émis après un code TOTP
+var mfaMethods = []string{"mfa", "otp"}
+
+// MFAEnrollment est la clé TOTP à inscrire dans l'application d'authentification
+type MFAEnrollment struct {
+	Secret string `json:"secret"`
+	// ProvisioningURI est l'URI otpauth:// à afficher en QR code
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+// EnrollMFA génère une nouvelle clé TOTP pour l'utilisateur. Elle n'est
+// exigée à la connexion qu'après ActivateMFA ; une inscription non activée
+// est remplacée.
+func (s *Service) EnrollMFA(ctx context.Context, userID string) (*MFAEnrollment, error) {
+	user, err := s.users.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	enabled, err := s.mfaEnabled(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if enabled {
+		return nil, ErrMFAEnabled
+	}
+
+	secret, err := NewTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.mfa.SaveUserMFA(ctx, &models.UserMFA{UserID: userID, Secret: secret}); err != nil {
+		return nil, err
+	}
+
+	return &MFAEnrollment{
+		Secret:          secret,
+		ProvisioningURI: TOTPProvisioningURI(secret, s.issuer.Name, user.Email),
+	}, nil
+}
+
+// ActivateMFA active l'authentification multifacteur avec un premier code
+// de la clé inscrite et renvoie des tokens qui la portent : les tokens de
+// la session courante ne sont plus acceptés.
+func (s *Service) ActivateMFA(ctx context.Context, userID, code string, device *Device) (*TokenResponse, error) {
+	mfa, err := s.mfa.GetUserMFA(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if mfa.Enabled() {
+		return nil, ErrMFAEnabled
+	}
+	step, err := s.checkMFACode(ctx, mfa, code)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	mfa.EnabledAt = &now
+	mfa.LastStep = step
+	mfa.FailedAttempts = 0
+	mfa.LockedUntil = nil
+	if err := s.mfa.SaveUserMFA(ctx, mfa); err != nil {
+		return nil, err
+	}
+	return s.mfaTokens(ctx, userID, device)
+}
+
+// VerifyMFA échange le token remis à la connexion et un code TOTP contre
+// les tokens d'une nouvelle session de l'utilisateur
+func (s *Service) VerifyMFA(ctx context.Context, mfaToken, code string, device *Device) (*TokenResponse, error) {
+	claims, err := s.parseToken(mfaToken)
+	if err != nil {
+		return nil, err
+	}
+	if tokenType, ok := claims["type"].(string); !ok || tokenType != mfaPendingTokenType {
+		return nil, ErrInvalidToken
+	}
+	userID, ok := claims["sub"].(string)
+	if !ok {
+		return nil, ErrInvalidToken
+	}
+	user, err := s.users.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, ErrInvalidToken
+		}
+		return nil, err
+	}
+	if iat, ok := claims["iat"].(float64); !ok || revoked(user, time.Unix(int64(iat), 0)) {
+		return nil, ErrTokenRevoked
+	}
+
+	mfa, err := s.mfa.GetUserMFA(ctx, userID)
+	if errors.Is(err, storage.ErrNotFound) {
+		// Désactivée depuis la connexion
+		return nil, ErrInvalidToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !mfa.Enabled() {
+		return nil, ErrInvalidToken
+	}
+	if _, err := s.checkMFACode(ctx, mfa, code); err != nil {
+		return nil, err
+	}
+	return s.mfaTokens(ctx, userID, device)
+}
+
+// DisableMFA désactive l'authentification multifacteur après un code valide.
+// Les appareils de confiance sont oubliés : une nouvelle activation exigera
+// de nouveau un code sur chacun.
+func (s *Service) DisableMFA(ctx context.Context, userID, code string) error {
+	mfa, err := s.mfa.GetUserMFA(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if mfa.Enabled() {
+		if _, err := s.checkMFACode(ctx, mfa, code); err != nil {
+			return err
+		}
+	}
+	if err := s.mfa.DeleteUserMFA(ctx, userID); err != nil {
+		return err
+	}
+	if s.devices != nil {
+		if _, err := s.devices.DeleteUserTrustedDevices(ctx, userID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// StepUp vérifie un code TOTP frais avant une opération sensible, même
+// si la session a déjà été ouverte avec un code. L'utilisateur doit avoir
+// activé l'authentification multifacteur (ErrMFANotEnabled sinon).
+func (s *Service) StepUp(ctx context.Context, userID, code string) error {
+	mfa, err := s.mfa.GetUserMFA(ctx, userID)
+	if errors.Is(err, storage.ErrNotFound) {
+		return ErrMFANotEnabled
+	}
+	if err != nil {
+		return err
+	}
+	if !mfa.Enabled() {
+		return ErrMFANotEnabled
+	}
+	_, err = s.checkMFACode(ctx, mfa, code)
+	return err
+}
+
+// RequireMFA renvoie ErrMFARequired si le token n'a pas été émis après un
+// code TOTP alors que l'utilisateur a activé l'authentification multifacteur
+func (s *Service) RequireMFA(ctx context.Context, claims *Claims) error {
+	if claims.MFA {
+		return nil
+	}
+	enabled, err := s.mfaEnabled(ctx, claims.UserID)
+	if err != nil {
+		return err
+	}
+	if enabled {
+		return ErrMFARequired
+	}
+	return nil
+}
+
+// mfaEnabled indique si l'utilisateur a activé l'authentification multifacteur
+func (s *Service) mfaEnabled(ctx context.Context, userID string) (bool, error) {
+	if s.mfa == nil {
+		return false, nil
+	}
+	mfa, err := s.mfa.GetUserMFA(ctx, userID)
+	if errors.Is(err, storage.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return mfa.Enabled(), nil
+}
+
+// checkMFACode vérifie un code et renvoie son pas de temps. Un code ne sert
+// qu'une fois ; les codes refusés sont comptés et verrouillent la
+// vérification au-delà de mfaMaxFailures.
+func (s *Service) checkMFACode(ctx context.Context, mfa *models.UserMFA, code string) (int64, error) {
+	now := time.Now()
+	if mfa.LockedUntil != nil && now.Before(*mfa.LockedUntil) {
+		return 0, ErrMFALocked
+	}
+
+	step, ok := verifyTOTP(mfa.Secret, code, now, mfa.LastStep)
+	if ok {
+		accepted, err := s.mfa.AcceptMFAStep(ctx, mfa.UserID, step)
+		if err != nil {
+			return 0, err
+		}
+		if accepted {
+			return step, nil
+		}
+	}
+
+	failures, lockedUntil := mfa.FailedAttempts+1, (*time.Time)(nil)
+	if failures >= mfaMaxFailures {
+		until := now.Add(mfaLockout)
+		failures, lockedUntil = 0, &until
+	}
+	if err := s.mfa.SetMFAFailures(ctx, mfa.UserID, failures, lockedUntil); err != nil {
+		return 0, err
+	}
+	if lockedUntil != nil {
+		return 0, ErrMFALocked
+	}
+	return 0, ErrInvalidMFACode
+}
+
+// mfaTokens génère les tokens d'une nouvelle session d'un utilisateur qui
+// vient de présenter un code
+func (s *Service) mfaTokens(ctx context.Context, userID string, device *Device) (*TokenResponse, error) {
+	return s.generateTokenPair(ctx, newSession(userID, true, device))
+}
+
+// hasMFAClaim indique si le token porte le claim amr d'un code TOTP
+func hasMFAClaim(claims jwt.MapClaims) bool {
+	methods, ok := claims["amr"].([]interface{})
+	return ok && slices.Contains(methods, interface{}("mfa"))
+}
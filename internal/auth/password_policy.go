@@ -0,0 +1,212 @@
+// filepath: internal/auth/password_policy.go
+
+package auth
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"secrets-manager/internal/logging"
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// HIBPURL est l'API Pwned Passwords de Have I Been Pwned
+const HIBPURL = "https://api.pwnedpasswords.com"
+
+// Délai maximal d'une recherche dans les fuites connues
+const breachCheckTimeout = 5 * time.Second
+
+// ErrWeakPassword indique un mot de passe refusé par la politique (voir
+// PasswordPolicyError pour le détail)
+var ErrWeakPassword = errors.New("mot de passe refusé par la politique")
+
+// PasswordPolicyError liste les exigences de la politique que le mot de
+// passe ne respecte pas
+type PasswordPolicyError struct {
+	Reasons []string
+}
+
+func (e *PasswordPolicyError) Error() string {
+	return "Mot de passe refusé : " + strings.Join(e.Reasons, ", ")
+}
+
+func (e *PasswordPolicyError) Unwrap() error { return ErrWeakPassword }
+
+// BreachChecker indique si un mot de passe figure dans les fuites connues
+type BreachChecker interface {
+	Breached(ctx context.Context, password string) (bool, error)
+}
+
+// HIBPChecker interroge l'API Pwned Passwords par k-anonymat : seuls les 5
+// premiers caractères de l'empreinte SHA-1 du mot de passe sont transmis,
+// la comparaison des suffixes renvoyés est locale
+type HIBPChecker struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewHIBPChecker crée le vérificateur ; baseURL vide prend HIBPURL
+func NewHIBPChecker(baseURL string) *HIBPChecker {
+	if baseURL == "" {
+		baseURL = HIBPURL
+	}
+	return &HIBPChecker{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		client:  &http.Client{Timeout: breachCheckTimeout},
+	}
+}
+
+// Breached recherche l'empreinte du mot de passe parmi celles de son préfixe
+func (c *HIBPChecker) Breached(ctx context.Context, password string) (bool, error) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := hash[:5], hash[5:]
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/range/"+prefix, nil)
+	if err != nil {
+		return false, err
+	}
+	// Le remplissage masque le nombre de suffixes réels du préfixe
+	req.Header.Set("Add-Padding", "true")
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("recherche dans les fuites connues: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("recherche dans les fuites connues: statut %d", resp.StatusCode)
+	}
+
+	// Une ligne par empreinte : "SUFFIXE:OCCURRENCES" (0 pour le remplissage)
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		candidate, count, _ := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		if strings.EqualFold(candidate, suffix) {
+			return count != "0", nil
+		}
+	}
+	return false, scanner.Err()
+}
+
+// ApplyPasswordPolicies applique aux nouveaux mots de passe la politique du
+// serveur base et celles des organisations (policies, qui conserve aussi
+// l'historique des mots de passe). breaches nil désactive la recherche dans
+// les fuites connues. Sans appel, tout mot de passe est accepté.
+func (s *Service) ApplyPasswordPolicies(base models.PasswordPolicy, policies storage.PasswordPoliciesRepository,
+	breaches BreachChecker) {
+	s.basePasswordPolicy = base
+	s.passwordPolicies = policies
+	s.breaches = breaches
+}
+
+// PasswordPolicy renvoie la politique applicable aux membres des
+// organisations orgIDs : la plus stricte de celle du serveur et des leurs
+func (s *Service) PasswordPolicy(ctx context.Context, orgIDs []string) (*models.PasswordPolicy, error) {
+	policies := []*models.PasswordPolicy{&s.basePasswordPolicy}
+	if s.passwordPolicies != nil {
+		for _, orgID := range orgIDs {
+			policy, err := s.passwordPolicies.GetPasswordPolicy(ctx, orgID)
+			if errors.Is(err, storage.ErrNotFound) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			policies = append(policies, policy)
+		}
+	}
+	return models.StrictestPasswordPolicy(policies), nil
+}
+
+// checkPassword vérifie un nouveau mot de passe contre la politique des
+// organisations orgIDs et, pour un utilisateur existant (userID non vide),
+// contre ses anciens mots de passe. Toutes les exigences non respectées
+// sont renvoyées ensemble (PasswordPolicyError).
+func (s *Service) checkPassword(ctx context.Context, userID, password string, orgIDs []string) error {
+	policy, err := s.PasswordPolicy(ctx, orgIDs)
+	if err != nil {
+		return err
+	}
+
+	var reasons []string
+	if length := utf8.RuneCountInString(password); length < policy.MinLength {
+		reasons = append(reasons, fmt.Sprintf("%d caractères au moins", policy.MinLength))
+	}
+	var upper, lower, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		case !unicode.IsLetter(r):
+			symbol = true
+		}
+	}
+	for _, class := range []struct {
+		required, present bool
+		reason            string
+	}{
+		{policy.RequireUppercase, upper, "une majuscule"},
+		{policy.RequireLowercase, lower, "une minuscule"},
+		{policy.RequireDigit, digit, "un chiffre"},
+		{policy.RequireSymbol, symbol, "un symbole"},
+	} {
+		if class.required && !class.present {
+			reasons = append(reasons, class.reason)
+		}
+	}
+
+	if userID != "" && policy.HistorySize > 0 && s.passwordPolicies != nil {
+		hashes, err := s.passwordPolicies.ListPasswordHistory(ctx, userID, policy.HistorySize)
+		if err != nil {
+			return err
+		}
+		for _, hash := range hashes {
+			if bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil {
+				reasons = append(reasons, fmt.Sprintf("différent des %d précédents", policy.HistorySize))
+				break
+			}
+		}
+	}
+
+	// Seul un mot de passe conforme au reste est recherché dans les fuites.
+	// Une panne de l'API n'empêche ni l'inscription ni le changement.
+	if len(reasons) == 0 && policy.RejectBreached && s.breaches != nil {
+		breached, err := s.breaches.Breached(ctx, password)
+		if err != nil {
+			logging.For(logging.ComponentHTTP).Warn("recherche dans les fuites connues impossible", "error", err)
+		}
+		if breached {
+			reasons = append(reasons, "absent des fuites de données connues")
+		}
+	}
+
+	if len(reasons) > 0 {
+		return &PasswordPolicyError{Reasons: reasons}
+	}
+	return nil
+}
+
+// recordPasswordChange conserve l'empreinte du mot de passe remplacé pour
+// en interdire la réutilisation
+func (s *Service) recordPasswordChange(ctx context.Context, userID, replacedHash string) error {
+	if s.passwordPolicies == nil {
+		return nil
+	}
+	return s.passwordPolicies.AddPasswordHistory(ctx, userID, replacedHash, time.Now())
+}
@@ -0,0 +1,176 @@
+// filepath: internal/auth/permissions.go
+
+package auth
+
+import (
+	"context"
+	"errors"
+
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// Rôles d'un membre dans une organisation, du plus au moins privilégié
+const (
+	RoleAdmin  = "admin"
+	RoleMember = "member"
+	RoleViewer = "viewer"
+)
+
+var (
+	// ErrNotMember indique que l'utilisateur n'est pas membre de l'organisation
+	ErrNotMember = errors.New("l'utilisateur n'est pas membre de l'organisation")
+	// ErrInsufficientRole indique que le rôle de l'utilisateur ne permet pas l'opération
+	ErrInsufficientRole = errors.New("rôle insuffisant dans l'organisation")
+)
+
+// roleRanks ordonne les rôles : un rôle accorde les droits des rôles de rang inférieur
+var roleRanks = map[string]int{
+	RoleViewer: 1,
+	RoleMember: 2,
+	RoleAdmin:  3,
+}
+
+// RoleAtLeast indique si role accorde au moins les droits de required. Un
+// rôle inconnu n'accorde aucun droit.
+func RoleAtLeast(role, required string) bool {
+	rank, ok := roleRanks[role]
+	return ok && rank >= roleRanks[required]
+}
+
+// InheritedRole renvoie le rôle effectif sur un projet, ou l'un de ses
+// environnements : le plus élevé de role, le rôle dans l'organisation, et
+// des rôles des octrois d'équipe qui s'y appliquent (voir TeamGrant.Covers)
+func InheritedRole(role string, grants []*models.TeamGrant, projectID, env string) string {
+	for _, grant := range grants {
+		if grant.Covers(projectID, env) && roleRanks[grant.Role] > roleRanks[role] {
+			role = grant.Role
+		}
+	}
+	return role
+}
+
+// HigherRole renvoie le plus élevé de deux rôles
+func HigherRole(role, other string) string {
+	if roleRanks[other] > roleRanks[role] {
+		return other
+	}
+	return role
+}
+
+// CustomRoleSecretsRole renvoie le rôle équivalent, sur les secrets, aux
+// permissions d'un rôle personnalisé : member avec secrets.write, viewer
+// avec secrets.read, aucun sinon (ou sans rôle personnalisé)
+func CustomRoleSecretsRole(custom *models.CustomRole) string {
+	switch {
+	case custom.Allows(models.PermissionSecretsWrite):
+		return RoleMember
+	case custom.Allows(models.PermissionSecretsRead):
+		return RoleViewer
+	default:
+		return ""
+	}
+}
+
+// PermissionService résout le rôle effectif des utilisateurs dans les
+// organisations, d'après leur appartenance (user_organizations), et sur
+// les projets, d'après les octrois de leurs équipes et leur rôle personnalisé
+type PermissionService struct {
+	users storage.UsersRepository
+	// teams fournit les octrois des équipes ; nil les ignore
+	teams storage.TeamsRepository
+	// roles fournit les rôles personnalisés des membres ; nil les ignore
+	roles storage.CustomRolesRepository
+}
+
+// NewPermissionService crée un nouveau service de permissions
+func NewPermissionService(users storage.UsersRepository, teams storage.TeamsRepository,
+	roles storage.CustomRolesRepository) *PermissionService {
+	return &PermissionService{users: users, teams: teams, roles: roles}
+}
+
+// EffectiveRole renvoie le rôle de l'utilisateur dans l'organisation
+// (ErrNotMember s'il n'en est pas membre ou si elle n'existe pas)
+func (p *PermissionService) EffectiveRole(ctx context.Context, userID, orgID string) (string, error) {
+	role, err := p.users.GetUserRole(ctx, userID, orgID)
+	if errors.Is(err, storage.ErrNotFound) {
+		return "", ErrNotMember
+	}
+	if err != nil {
+		return "", err
+	}
+	if _, ok := roleRanks[role]; !ok {
+		return "", ErrNotMember
+	}
+	return role, nil
+}
+
+// Authorize vérifie que l'utilisateur a au moins le rôle required dans
+// l'organisation (ErrNotMember ou ErrInsufficientRole sinon)
+func (p *PermissionService) Authorize(ctx context.Context, userID, orgID, required string) error {
+	role, err := p.EffectiveRole(ctx, userID, orgID)
+	if err != nil {
+		return err
+	}
+	if !RoleAtLeast(role, required) {
+		return ErrInsufficientRole
+	}
+	return nil
+}
+
+// EffectiveResourceRole renvoie le rôle de l'utilisateur sur un projet, ou
+// l'un de ses environnements (env vide : le projet entier), hérité de ses
+// équipes ou de son rôle personnalisé s'il dépasse son rôle dans
+// l'organisation (ErrNotMember s'il n'en est pas membre)
+func (p *PermissionService) EffectiveResourceRole(ctx context.Context, userID, orgID, projectID, env string) (string, error) {
+	role, err := p.EffectiveRole(ctx, userID, orgID)
+	if err != nil || projectID == "" || role == RoleAdmin {
+		return role, err
+	}
+	if p.teams != nil {
+		grants, err := p.teams.ListUserGrants(ctx, orgID, userID)
+		if err != nil {
+			return "", err
+		}
+		role = InheritedRole(role, grants, projectID, env)
+	}
+	if p.roles != nil {
+		custom, err := p.roles.GetMemberCustomRole(ctx, orgID, userID)
+		if err != nil && !errors.Is(err, storage.ErrNotFound) {
+			return "", err
+		}
+		role = HigherRole(role, CustomRoleSecretsRole(custom))
+	}
+	return role, nil
+}
+
+// HasPermission indique si l'utilisateur dispose de la permission fine dans
+// l'organisation : les administrateurs les ont toutes, les autres membres
+// celles de leur rôle personnalisé (ErrNotMember s'il n'en est pas membre)
+func (p *PermissionService) HasPermission(ctx context.Context, userID, orgID, permission string) (bool, error) {
+	role, err := p.EffectiveRole(ctx, userID, orgID)
+	if err != nil || role == RoleAdmin || p.roles == nil {
+		return role == RoleAdmin, err
+	}
+	custom, err := p.roles.GetMemberCustomRole(ctx, orgID, userID)
+	if errors.Is(err, storage.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return custom.Allows(permission), nil
+}
+
+// AuthorizeResource vérifie que l'utilisateur a au moins le rôle required
+// sur le projet ou l'environnement (ErrNotMember ou ErrInsufficientRole sinon)
+func (p *PermissionService) AuthorizeResource(ctx context.Context, userID, orgID, projectID, env, required string) error {
+	role, err := p.EffectiveResourceRole(ctx, userID, orgID, projectID, env)
+	if err != nil {
+		return err
+	}
+	if !RoleAtLeast(role, required) {
+		return ErrInsufficientRole
+	}
+	return nil
+}
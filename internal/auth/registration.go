@@ -0,0 +1,81 @@
+// filepath: internal/auth/registration.go
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// Erreurs de la politique d'inscription
+var (
+	ErrRegistrationClosed     = errors.New("les inscriptions sont fermées")
+	ErrRegistrationNotAllowed = errors.New("cette adresse ne peut pas s'inscrire")
+)
+
+// RestrictRegistration encadre l'inscription libre : defaults s'applique
+// tant qu'aucune politique n'est enregistrée dans policies, invitations
+// indique les adresses invitées (mode restricted). Sans appel, toute
+// adresse peut s'inscrire.
+func (s *Service) RestrictRegistration(defaults models.RegistrationPolicy,
+	policies storage.RegistrationPolicyRepository, invitations storage.InvitationsRepository) {
+	s.registration = defaults
+	s.registrationPolicies = policies
+	s.invitations = invitations
+}
+
+// RegistrationPolicy renvoie la politique d'inscription en vigueur :
+// l'enregistrée, à défaut celle de la configuration
+func (s *Service) RegistrationPolicy(ctx context.Context) (*models.RegistrationPolicy, error) {
+	if s.registrationPolicies != nil {
+		policy, err := s.registrationPolicies.GetRegistrationPolicy(ctx)
+		if err == nil {
+			return policy, nil
+		}
+		if !errors.Is(err, storage.ErrNotFound) {
+			return nil, err
+		}
+	}
+
+	policy := s.registration
+	if policy.Mode == "" {
+		policy.Mode = models.RegistrationOpen
+	}
+	policy.AllowList = append([]string{}, policy.AllowList...)
+	policy.DenyList = append([]string{}, policy.DenyList...)
+	return &policy, nil
+}
+
+// CheckRegistration vérifie que l'adresse peut créer un compte par
+// inscription libre. Les invitations acceptées n'y sont pas soumises.
+func (s *Service) CheckRegistration(ctx context.Context, email string) error {
+	policy, err := s.RegistrationPolicy(ctx)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case policy.Mode == models.RegistrationClosed:
+		return ErrRegistrationClosed
+	case policy.Denied(email):
+		return ErrRegistrationNotAllowed
+	case policy.Mode == models.RegistrationOpen, policy.Allowed(email):
+		return nil
+	}
+
+	// Mode restricted : seules les adresses invitées peuvent encore s'inscrire
+	if s.invitations != nil {
+		invited, err := s.invitations.HasPendingInvitation(ctx, email, time.Now())
+		if err != nil {
+			return err
+		}
+		if invited {
+			return nil
+		}
+	}
+	return ErrRegistrationNotAllowed
+}
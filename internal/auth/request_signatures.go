@@ -0,0 +1,67 @@
+// filepath: internal/auth/request_signatures.go
+
+package auth
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"time"
+
+	"secrets-manager/internal/vault"
+)
+
+// SignatureMaxSkew est l'écart toléré entre l'horodatage d'une requête
+// signée et l'horloge du serveur, dans les deux sens
+const SignatureMaxSkew = 5 * time.Minute
+
+// APIKeySigningTransitKey est la clé transit qui enveloppe les clés de
+// signature des clés d'API
+const APIKeySigningTransitKey = "sm-api-key-signing"
+
+// NewAPIKeySigningKey génère la clé de signature HMAC d'une clé d'API, une
+// clé de données de 256 bits indépendante de la clé elle-même. Elle est
+// renvoyée en clair (hexadécimal), pour le client, et enveloppée par la clé
+// transit APIKeySigningTransitKey, seule forme conservée : lire la base ne
+// suffit pas à signer des requêtes.
+func NewAPIKeySigningKey(ctx context.Context, transit vault.Transit) (signingKey, wrapped string, err error) {
+	if err := transit.CreateTransitKey(ctx, APIKeySigningTransitKey); err != nil {
+		return "", "", err
+	}
+	plaintext, wrapped, err := transit.GenerateDataKey(ctx, APIKeySigningTransitKey)
+	if err != nil {
+		return "", "", err
+	}
+	return hex.EncodeToString(plaintext), wrapped, nil
+}
+
+// UnwrapAPIKeySigningKey renvoie en clair une clé de signature enveloppée
+// par NewAPIKeySigningKey
+func UnwrapAPIKeySigningKey(ctx context.Context, transit vault.Transit, wrapped string) (string, error) {
+	plaintext, err := transit.DecryptDataKey(ctx, APIKeySigningTransitKey, wrapped)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(plaintext), nil
+}
+
+// SignRequest renvoie la signature (HMAC-SHA256 hexadécimal) d'une requête
+// avec la clé de signature signingKey. La chaîne signée est, séparés par
+// des retours à la ligne : la méthode, le chemin avec sa requête (RequestURI),
+// l'horodatage en secondes Unix et l'empreinte SHA-256 hexadécimale du corps.
+func SignRequest(signingKey, method, requestURI string, timestamp int64, body []byte) string {
+	bodySum := sha256.Sum256(body)
+	mac := hmac.New(sha256.New, []byte(signingKey))
+	mac.Write([]byte(method + "\n" + requestURI + "\n" + strconv.FormatInt(timestamp, 10) + "\n" +
+		hex.EncodeToString(bodySum[:])))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyRequestSignature indique si signature est celle de la requête, en
+// temps constant
+func VerifyRequestSignature(signingKey, signature, method, requestURI string, timestamp int64, body []byte) bool {
+	expected := SignRequest(signingKey, method, requestURI, timestamp, body)
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
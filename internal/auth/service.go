@@ -4,14 +4,16 @@ package auth
 
 import (
 	"context"
-	"database/sql"
+	"encoding/json"
 	"errors"
-	"fmt"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v4"
-	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
+
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
 )
 
 // Erreurs du service d'authentification
@@ -21,20 +23,71 @@ var (
 	ErrInvalidToken       = errors.New("token invalide")
 	ErrUserNotFound       = errors.New("utilisateur non trouvé")
 	ErrTokenExpired       = errors.New("token expiré")
+	ErrMFARequired        = errors.New("authentification multifacteur requise")
+	ErrInvalidMFACode     = errors.New("code d'authentification invalide")
+	ErrMFAEnabled         = errors.New("l'authentification multifacteur est déjà activée")
+	ErrMFALocked          = errors.New("trop de codes invalides, réessayez plus tard")
+	ErrMFANotEnabled      = errors.New("l'authentification multifacteur n'est pas activée")
+	ErrTokenRevoked       = errors.New("token révoqué")
+	ErrPasswordReset      = errors.New("un nouveau mot de passe doit être choisi")
+	ErrPasswordUnchanged  = errors.New("le nouveau mot de passe doit être différent de l'ancien")
+	ErrRefreshTokenReused = errors.New("token de rafraîchissement déjà utilisé, session révoquée")
 )
 
+// Taille maximale (JSON) des rôles embarqués dans un token d'accès : au-delà,
+// le claim est omis et les rôles sont lus en base à chaque requête
+const maxRolesClaimSize = 2048
+
 // Service fournit des fonctionnalités d'authentification
 type Service struct {
-	db          *sql.DB
+	users       storage.UsersRepository
+	mfa         storage.UserMFARepository
+	sessions    storage.RefreshTokensRepository
+	devices     storage.TrustedDevicesRepository
+	trustPeriod time.Duration
+	logins      LoginObserver
+	policies    storage.SessionPoliciesRepository
 	jwtSecret   string
 	jwtExpiry   time.Duration
 	refreshTime time.Duration
+	issuer      Issuer
+	// serviceAccounts authentifie les comptes de service ; nil les refuse
+	serviceAccounts storage.ServiceAccountsRepository
+	// signingKeys signent les tokens (la première) et les vérifient ; vide,
+	// le secret partagé jwtSecret est utilisé (HS256)
+	signingKeys []*SigningKey
+	// acceptHS256 accepte encore les tokens HS256 malgré les signingKeys
+	acceptHS256 bool
+	// impersonations enregistre les usurpations d'identité ; nil les refuse
+	impersonations storage.ImpersonationsRepository
+	// basePasswordPolicy est la politique de mot de passe du serveur,
+	// renforcée par celles des organisations (passwordPolicies) ; breaches
+	// recherche les mots de passe dans les fuites connues
+	basePasswordPolicy models.PasswordPolicy
+	passwordPolicies   storage.PasswordPoliciesRepository
+	breaches           BreachChecker
+	// registration est la politique d'inscription de la configuration,
+	// remplacée par celle de registrationPolicies si elle est réglée ;
+	// invitations signale les adresses invitées
+	registration         models.RegistrationPolicy
+	registrationPolicies storage.RegistrationPolicyRepository
+	invitations          storage.InvitationsRepository
+}
+
+// Issuer identifie l'émetteur des tokens (claim iss) et le service auquel
+// ils sont destinés (claim aud). Les deux sont vérifiés à chaque lecture pour
+// qu'un token émis pour un autre environnement ou service soit refusé.
+type Issuer struct {
+	Name     string
+	Audience string
 }
 
 // Credentials représente les identifiants d'un utilisateur
 type Credentials struct {
 	Email    string `json:"email"`
 	Password string `json:"password"`
+	// Device dispense du code TOTP s'il s'agit d'un appareil de confiance
+	Device *Device `json:"-"`
 }
 
 // TokenResponse représente la réponse avec le token JWT
@@ -43,6 +96,10 @@ type TokenResponse struct {
 	RefreshToken string    `json:"refresh_token,omitempty"`
 	ExpiresAt    time.Time `json:"expires_at"`
 	UserID       string    `json:"user_id"`
+	// MFARequired indique que le mot de passe est valide mais qu'un code
+	// TOTP doit être présenté avec MFAToken (Token est alors vide)
+	MFARequired bool   `json:"mfa_required,omitempty"`
+	MFAToken    string `json:"mfa_token,omitempty"`
 }
 
 // UserDetails représente les informations renvoyées lors de l'authentification
@@ -54,66 +111,126 @@ type UserDetails struct {
 	Role      string `json:"role"`
 }
 
+// Claims contient les informations portées par un token d'accès
+type Claims struct {
+	UserID string
+	// Roles associe à chaque organisation le rôle de l'utilisateur lors de
+	// l'émission du token ; nil si le claim a été omis (trop d'organisations)
+	Roles map[string]string
+	// Scopes sont les portées du token
+	Scopes []string
+	// MFA indique que l'utilisateur a présenté un code TOTP (claim amr)
+	MFA bool
+	// SessionID est la session du token (claim sid), révocable avec
+	// RevokeSession ; vide pour un token émis avant la persistance des sessions
+	SessionID string
+	// ImpersonationID est l'usurpation d'identité du token (claim act_as) ;
+	// vide si l'utilisateur s'est authentifié lui-même
+	ImpersonationID string
+	IssuedAt        time.Time
+	ExpiresAt       time.Time
+}
+
 // NewService crée un nouveau service d'authentification
-func NewService(db *sql.DB, jwtSecret string, jwtExpiry, refreshTime time.Duration) *Service {
+func NewService(users storage.UsersRepository, mfa storage.UserMFARepository, sessions storage.RefreshTokensRepository,
+	jwtSecret string, issuer Issuer, jwtExpiry, refreshTime time.Duration) *Service {
 	return &Service{
-		db:          db,
+		users:       users,
+		mfa:         mfa,
+		sessions:    sessions,
 		jwtSecret:   jwtSecret,
 		jwtExpiry:   jwtExpiry,
 		refreshTime: refreshTime,
+		issuer:      issuer,
 	}
 }
 
+// Issuer renvoie l'émetteur et l'audience des tokens du service
+func (s *Service) Issuer() Issuer {
+	return s.issuer
+}
+
 // Authenticate vérifie les identifiants d'un utilisateur et génère un token JWT
 func (s *Service) Authenticate(ctx context.Context, creds *Credentials) (*TokenResponse, *UserDetails, error) {
-	var hashedPassword, userID, firstName, lastName, role string
-
-	query := "SELECT id, hashed_password, first_name, last_name, role FROM users WHERE email = ?"
-	err := s.db.QueryRowContext(ctx, query, creds.Email).Scan(&userID, &hashedPassword, &firstName, &lastName, &role)
+	user, err := s.users.GetUserByEmail(ctx, creds.Email)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
+		if errors.Is(err, storage.ErrNotFound) {
 			return nil, nil, ErrInvalidCredentials
 		}
 		return nil, nil, err
 	}
 
 	// Vérifier le mot de passe
-	err = bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(creds.Password))
+	err = bcrypt.CompareHashAndPassword([]byte(user.HashedPassword), []byte(creds.Password))
 	if err != nil {
 		return nil, nil, ErrInvalidCredentials
 	}
+	// Après un confinement, le mot de passe a pu être compromis
+	if user.PasswordResetRequired {
+		return nil, nil, ErrPasswordReset
+	}
 
-	// Générer le token JWT et le token de rafraîchissement
-	token, refreshToken, expiresAt, err := s.generateTokenPair(userID)
+	// Une connexion inhabituelle peut exiger un code même depuis un appareil de confiance
+	stepUp := s.logins != nil && s.logins.ObserveLogin(ctx, user.ID, creds.Device)
+
+	details := &UserDetails{
+		ID:        user.ID,
+		Email:     user.Email,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		Role:      user.Role,
+	}
+
+	// Avec l'authentification multifacteur, les tokens ne sont émis
+	// qu'après le code TOTP (voir VerifyMFA), sauf depuis un appareil de confiance
+	enabled, err := s.mfaEnabled(ctx, user.ID)
 	if err != nil {
 		return nil, nil, err
 	}
+	if enabled && !stepUp {
+		trusted, err := s.deviceTrusted(ctx, user, creds.Device)
+		if err != nil {
+			return nil, nil, err
+		}
+		if trusted {
+			tokens, err := s.mfaTokens(ctx, user.ID, creds.Device)
+			return tokens, details, err
+		}
+	}
+	if enabled {
+		mfaToken, expiresAt, err := s.generateToken(user.ID, mfaPendingTokenType, mfaPendingExpiry, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+		return &TokenResponse{
+			ExpiresAt:   expiresAt,
+			UserID:      user.ID,
+			MFARequired: true,
+			MFAToken:    mfaToken,
+		}, details, nil
+	}
 
-	return &TokenResponse{
-		Token:        token,
-		RefreshToken: refreshToken,
-		ExpiresAt:    expiresAt,
-		UserID:       userID,
-	}, &UserDetails{
-		ID:        userID,
-		Email:     creds.Email,
-		FirstName: firstName,
-		LastName:  lastName,
-		Role:      role,
-	}, nil
-}
-
-// RegisterUser enregistre un nouvel utilisateur
-func (s *Service) RegisterUser(ctx context.Context, creds *Credentials, firstName, lastName string) (*UserDetails, error) {
-	// Vérifier si l'utilisateur existe déjà
-	var exists bool
-	err := s.db.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM users WHERE email = ?)", creds.Email).Scan(&exists)
+	// Générer le token JWT et le token de rafraîchissement d'une nouvelle session
+	tokens, err := s.generateTokenPair(ctx, newSession(user.ID, false, creds.Device))
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	if exists {
+	return tokens, details, nil
+}
+
+// RegisterUser enregistre un nouvel utilisateur. Son mot de passe doit
+// respecter la politique du serveur et celle des organisations orgIDs qu'il
+// rejoint (invitation).
+func (s *Service) RegisterUser(ctx context.Context, creds *Credentials, firstName, lastName string,
+	orgIDs ...string) (*UserDetails, error) {
+	// Un email déjà inscrit est signalé avant la politique de mot de passe,
+	// qu'il est inutile de respecter pour ce compte
+	if _, err := s.users.GetUserByEmail(ctx, creds.Email); err == nil {
 		return nil, ErrUserExists
 	}
+	if err := s.checkPassword(ctx, "", creds.Password, orgIDs); err != nil {
+		return nil, err
+	}
 
 	// Hasher le mot de passe
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(creds.Password), bcrypt.DefaultCost)
@@ -121,54 +238,102 @@ func (s *Service) RegisterUser(ctx context.Context, creds *Credentials, firstNam
 		return nil, err
 	}
 
-	// Insérer le nouvel utilisateur
-	userID := uuid.New().String()
-	_, err = s.db.ExecContext(ctx,
-		"INSERT INTO users (id, email, hashed_password, first_name, last_name, role, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, NOW(), NOW())",
-		userID, creds.Email, hashedPassword, firstName, lastName, "user",
-	)
-	if err != nil {
+	// Insérer le nouvel utilisateur (le repository vérifie l'unicité de l'email)
+	user := &models.User{
+		Email:          creds.Email,
+		HashedPassword: string(hashedPassword),
+		FirstName:      firstName,
+		LastName:       lastName,
+		Role:           "user",
+	}
+	if err := s.users.CreateUser(ctx, user); err != nil {
+		if errors.Is(err, storage.ErrAlreadyExists) {
+			return nil, ErrUserExists
+		}
 		return nil, err
 	}
 
 	return &UserDetails{
-		ID:        userID,
-		Email:     creds.Email,
-		FirstName: firstName,
-		LastName:  lastName,
-		Role:      "user",
+		ID:        user.ID,
+		Email:     user.Email,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		Role:      user.Role,
 	}, nil
 }
 
-// VerifyToken vérifie la validité d'un token JWT
-func (s *Service) VerifyToken(tokenString string) (string, error) {
-	claims, err := s.parseToken(tokenString)
+// ChangePassword remplace le mot de passe d'un utilisateur identifié par
+// son mot de passe actuel et, s'il l'a activée, un code TOTP. C'est aussi
+// le moyen de lever l'obligation de changer de mot de passe. Le nouveau mot
+// de passe doit respecter la politique de ses organisations ; l'ancien est
+// conservé dans l'historique.
+func (s *Service) ChangePassword(ctx context.Context, creds *Credentials, newPassword, code string) error {
+	user, err := s.users.GetUserByEmail(ctx, creds.Email)
 	if err != nil {
-		return "", err
+		if errors.Is(err, storage.ErrNotFound) {
+			return ErrInvalidCredentials
+		}
+		return err
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.HashedPassword), []byte(creds.Password)); err != nil {
+		return ErrInvalidCredentials
+	}
+	if newPassword == creds.Password {
+		return ErrPasswordUnchanged
 	}
 
-	// Vérifier que c'est un token d'accès
-	if tokenType, ok := claims["type"].(string); !ok || tokenType != "access" {
-		return "", ErrInvalidToken
+	if s.mfa != nil {
+		mfa, err := s.mfa.GetUserMFA(ctx, user.ID)
+		if err != nil && !errors.Is(err, storage.ErrNotFound) {
+			return err
+		}
+		if err == nil && mfa.Enabled() {
+			if _, err := s.checkMFACode(ctx, mfa, code); err != nil {
+				return err
+			}
+		}
 	}
 
-	userID, ok := claims["sub"].(string)
-	if !ok {
-		return "", ErrInvalidToken
+	roles, err := s.users.GetUserRoles(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	orgIDs := make([]string, 0, len(roles))
+	for orgID := range roles {
+		orgIDs = append(orgIDs, orgID)
+	}
+	if err := s.checkPassword(ctx, user.ID, newPassword, orgIDs); err != nil {
+		return err
 	}
 
-	return userID, nil
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	if err := s.users.UpdatePassword(ctx, user.ID, string(hashedPassword)); err != nil {
+		return err
+	}
+	return s.recordPasswordChange(ctx, user.ID, user.HashedPassword)
 }
 
-// RefreshToken rafraîchit un token JWT expiré
-func (s *Service) RefreshToken(ctx context.Context, refreshToken string) (*TokenResponse, error) {
-	claims, err := s.parseToken(refreshToken)
+// VerifyToken vérifie la validité d'un token JWT
+func (s *Service) VerifyToken(tokenString string) (string, error) {
+	claims, err := s.VerifyAccessToken(tokenString)
+	if err != nil {
+		return "", err
+	}
+	return claims.UserID, nil
+}
+
+// VerifyAccessToken vérifie un token d'accès et renvoie ses claims
+func (s *Service) VerifyAccessToken(tokenString string) (*Claims, error) {
+	claims, err := s.parseToken(tokenString)
 	if err != nil {
 		return nil, err
 	}
 
-	// Vérifier que c'est un token de rafraîchissement
-	if tokenType, ok := claims["type"].(string); !ok || tokenType != "refresh" {
+	// Vérifier que c'est un token d'accès
+	if tokenType, ok := claims["type"].(string); !ok || tokenType != "access" {
 		return nil, ErrInvalidToken
 	}
 
@@ -177,33 +342,106 @@ func (s *Service) RefreshToken(ctx context.Context, refreshToken string) (*Token
 		return nil, ErrInvalidToken
 	}
 
-	// Générer de nouveaux tokens
-	token, newRefreshToken, expiresAt, err := s.generateTokenPair(userID)
+	result := &Claims{UserID: userID}
+	if iat, ok := claims["iat"].(float64); ok {
+		result.IssuedAt = time.Unix(int64(iat), 0)
+	}
+	if exp, ok := claims["exp"].(float64); ok {
+		result.ExpiresAt = time.Unix(int64(exp), 0)
+	}
+	if scope, ok := claims["scope"].(string); ok {
+		result.Scopes = strings.Fields(scope)
+	}
+	result.MFA = hasMFAClaim(claims)
+	result.SessionID, _ = claims["sid"].(string)
+	result.ImpersonationID, _ = claims["act_as"].(string)
+	if orgs, ok := claims["orgs"].(map[string]interface{}); ok {
+		result.Roles = make(map[string]string, len(orgs))
+		for orgID, role := range orgs {
+			if role, ok := role.(string); ok {
+				result.Roles[orgID] = role
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// CheckSession vérifie, à chaque requête, qu'un token d'accès valide n'a
+// pas été révoqué (confinement de l'organisation, session révoquée ou
+// déconnectée, usurpation d'identité terminée) et qu'il a été émis après
+// un code TOTP si l'utilisateur a activé l'authentification multifacteur
+func (s *Service) CheckSession(ctx context.Context, claims *Claims) error {
+	user, err := s.users.GetUserByID(ctx, claims.UserID)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return ErrInvalidToken
+		}
+		return err
+	}
+	if revoked(user, claims.IssuedAt) {
+		return ErrTokenRevoked
+	}
+	if claims.SessionID != "" {
+		sessionRevoked, err := s.sessions.SessionRevoked(ctx, claims.SessionID)
+		if err != nil {
+			return err
+		}
+		if sessionRevoked {
+			return ErrTokenRevoked
+		}
+	}
+	if claims.ImpersonationID != "" {
+		return s.checkImpersonation(ctx, claims)
+	}
+	return s.RequireMFA(ctx, claims)
+}
+
+// revoked indique si un token émis à issuedAt a été révoqué. La date
+// d'émission d'un JWT est à la seconde : un token émis pendant la seconde
+// de la révocation est refusé.
+func revoked(user *models.User, issuedAt time.Time) bool {
+	return user.TokensNotBefore != nil && !issuedAt.After(user.TokensNotBefore.Truncate(time.Second))
+}
+
+// IssueTokens génère les tokens d'un utilisateur authentifié par un autre
+// moyen que son mot de passe (approbation d'un appareil). L'approbation
+// ayant été donnée depuis une session complète, les tokens d'un utilisateur
+// inscrit à l'authentification multifacteur la portent.
+func (s *Service) IssueTokens(ctx context.Context, userID string, device *Device) (*TokenResponse, error) {
+	if _, err := s.users.GetUserByID(ctx, userID); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	mfa, err := s.mfaEnabled(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
 
-	return &TokenResponse{
-		Token:        token,
-		RefreshToken: newRefreshToken,
-		ExpiresAt:    expiresAt,
-		UserID:       userID,
-	}, nil
+	return s.generateTokenPair(ctx, newSession(userID, mfa, device))
 }
 
 // generateToken génère un nouveau token JWT
-func (s *Service) generateToken(userID, tokenType string, expiry time.Duration) (string, time.Time, error) {
-	expiresAt := time.Now().Add(expiry)
+func (s *Service) generateToken(userID, tokenType string, expiry time.Duration, extra jwt.MapClaims) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(expiry)
 
 	claims := jwt.MapClaims{
 		"sub":  userID,
 		"type": tokenType,
+		"iss":  s.issuer.Name,
+		"aud":  s.issuer.Audience,
 		"exp":  expiresAt.Unix(),
-		"iat":  time.Now().Unix(),
+		"nbf":  now.Unix(),
+		"iat":  now.Unix(),
+	}
+	for name, value := range extra {
+		claims[name] = value
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	signedToken, err := token.SignedString([]byte(s.jwtSecret))
+	signedToken, err := s.signToken(claims)
 	if err != nil {
 		return "", time.Time{}, err
 	}
@@ -212,28 +450,71 @@ func (s *Service) generateToken(userID, tokenType string, expiry time.Duration)
 }
 
 // generateTokenPair génère un token d'accès et un token de rafraîchissement
-func (s *Service) generateTokenPair(userID string) (string, string, time.Time, error) {
-	accessToken, expiresAt, err := s.generateToken(userID, "access", s.jwtExpiry)
+// de la session de refresh, enregistré avant d'être remis. Le token d'accès
+// embarque la session (claim sid), les rôles de l'utilisateur dans ses
+// organisations (claim orgs) et ses portées (claim scope) ; les rôles sont
+// relus à chaque rafraîchissement. Après un code TOTP, le token d'accès porte
+// le claim amr et la session le conserve aux rafraîchissements. Les durées
+// de validité sont bornées par la politique de session des organisations.
+func (s *Service) generateTokenPair(ctx context.Context, refresh *models.RefreshToken) (*TokenResponse, error) {
+	roles, err := s.users.GetUserRoles(ctx, refresh.UserID)
 	if err != nil {
-		return "", "", time.Time{}, err
+		return nil, err
 	}
 
-	refreshToken, _, err := s.generateToken(userID, "refresh", s.refreshTime)
+	policy, err := s.sessionPolicy(ctx, roles)
 	if err != nil {
-		return "", "", time.Time{}, err
+		return nil, err
 	}
 
-	return accessToken, refreshToken, expiresAt, nil
+	refreshToken, hash, _, err := newToken(RefreshTokenPrefix)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	opened := refresh.SessionID == ""
+	start := refresh.SessionStartedAt
+	if opened {
+		start = now
+	}
+	accessExpiry, refreshExpiry := s.sessionExpiries(policy, start, now)
+	refresh.TokenHash = hash
+	refresh.ExpiresAt = now.Add(refreshExpiry).Truncate(time.Second)
+	if err := s.sessions.CreateRefreshToken(ctx, refresh); err != nil {
+		return nil, err
+	}
+	if opened {
+		if err := s.limitSessions(ctx, policy, refresh.UserID, refresh.SessionID); err != nil {
+			return nil, err
+		}
+	}
+
+	extra := jwt.MapClaims{
+		"sid":   refresh.SessionID,
+		"scope": strings.Join(models.TokenScopes, " "),
+	}
+	if encoded, err := json.Marshal(roles); err == nil && len(encoded) <= maxRolesClaimSize {
+		extra["orgs"] = roles
+	}
+	if refresh.MFA {
+		extra["amr"] = mfaMethods
+	}
+	accessToken, expiresAt, err := s.generateToken(refresh.UserID, "access", accessExpiry, extra)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TokenResponse{
+		Token:        accessToken,
+		RefreshToken: refreshToken,
+		ExpiresAt:    expiresAt,
+		UserID:       refresh.UserID,
+	}, nil
 }
 
 // parseToken parse un token JWT et vérifie sa validité
 func (s *Service) parseToken(tokenString string) (jwt.MapClaims, error) {
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("méthode de signature inattendue: %v", token.Header["alg"])
-		}
-		return []byte(s.jwtSecret), nil
-	})
+	token, err := jwt.Parse(tokenString, s.verificationKey)
 
 	if err != nil {
 		if errors.Is(err, jwt.ErrTokenExpired) {
@@ -251,5 +532,17 @@ func (s *Service) parseToken(tokenString string) (jwt.MapClaims, error) {
 		return nil, ErrInvalidToken
 	}
 
+	// exp et nbf sont vérifiés par jwt.Parse lorsqu'ils sont présents : ils
+	// sont ici obligatoires, comme l'émetteur et l'audience
+	if _, ok := claims["exp"]; !ok {
+		return nil, ErrInvalidToken
+	}
+	if _, ok := claims["nbf"]; !ok {
+		return nil, ErrInvalidToken
+	}
+	if !claims.VerifyIssuer(s.issuer.Name, true) || !claims.VerifyAudience(s.issuer.Audience, true) {
+		return nil, ErrInvalidToken
+	}
+
 	return claims, nil
 }
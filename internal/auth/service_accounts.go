@@ -0,0 +1,233 @@
+// filepath: internal/auth/service_accounts.go
+
+package auth
+
+import (
+	"context"
+	"crypto/subtle"
+	"crypto/x509"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// Intervalle minimal entre deux enregistrements de la date d'utilisation
+// d'un compte authentifié par certificat client
+const certificateTouchInterval = time.Minute
+
+// serviceAccountTokenType est le type des tokens émis aux comptes de
+// service, refusés là où un token d'accès d'utilisateur est attendu
+const serviceAccountTokenType = "service_account"
+
+// ServiceAccountClaims contient les informations portées par le token d'un
+// compte de service
+type ServiceAccountClaims struct {
+	ServiceAccountID string
+	OrganizationID   string
+	// Scopes sont les portées du token, d'après la permission du compte
+	Scopes []string
+	// Resources sont les projets et environnements accessibles
+	Resources []models.ResourceScope
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+}
+
+// Role renvoie le rôle du compte dans l'organisation, d'après ses portées :
+// member s'il peut modifier les secrets, viewer sinon, aucun hors de son
+// organisation
+func (c *ServiceAccountClaims) Role(orgID string) string {
+	switch {
+	case orgID != c.OrganizationID:
+		return ""
+	case slices.Contains(c.Scopes, models.ScopeSecretsWrite):
+		return RoleMember
+	default:
+		return RoleViewer
+	}
+}
+
+// ServiceAccountToken est la réponse à l'échange client_credentials (RFC
+// 6749, section 5.1). Aucun token de rafraîchissement n'est émis : le
+// compte présente de nouveau son secret.
+type ServiceAccountToken struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+	Scope       string `json:"scope"`
+}
+
+// EnableServiceAccounts accepte l'authentification des comptes de service.
+// Sans appel, l'échange de leur secret est refusé.
+func (s *Service) EnableServiceAccounts(accounts storage.ServiceAccountsRepository) {
+	s.serviceAccounts = accounts
+}
+
+// IssueServiceAccountToken échange l'identifiant et le secret d'un compte de
+// service contre un token d'accès portant ses portées et ses ressources
+// (ErrInvalidCredentials si le compte est inconnu ou le secret invalide)
+func (s *Service) IssueServiceAccountToken(ctx context.Context, id, secret string) (*ServiceAccountToken, error) {
+	if s.serviceAccounts == nil || !strings.HasPrefix(secret, ServiceAccountSecretPrefix) {
+		return nil, ErrInvalidCredentials
+	}
+	account, err := s.serviceAccounts.GetServiceAccount(ctx, id)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, err
+	}
+	if subtle.ConstantTimeCompare([]byte(HashPersonalAccessToken(secret)), []byte(account.SecretHash)) != 1 {
+		return nil, ErrInvalidCredentials
+	}
+
+	now := time.Now()
+	if err := s.serviceAccounts.TouchServiceAccount(ctx, account.ID, now); err != nil {
+		return nil, err
+	}
+
+	scope := strings.Join(account.Scopes(), " ")
+	token, expiresAt, err := s.generateToken(account.ID, serviceAccountTokenType, s.jwtExpiry, jwt.MapClaims{
+		"org":       account.OrganizationID,
+		"scope":     scope,
+		"resources": account.Resources,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &ServiceAccountToken{
+		AccessToken: token,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(expiresAt.Sub(now).Seconds()),
+		Scope:       scope,
+	}, nil
+}
+
+// VerifyServiceAccountToken vérifie le token d'un compte de service et
+// renvoie ses claims
+func (s *Service) VerifyServiceAccountToken(tokenString string) (*ServiceAccountClaims, error) {
+	claims, err := s.parseToken(tokenString)
+	if err != nil {
+		return nil, err
+	}
+	if tokenType, ok := claims["type"].(string); !ok || tokenType != serviceAccountTokenType {
+		return nil, ErrInvalidToken
+	}
+
+	result := &ServiceAccountClaims{}
+	var ok bool
+	if result.ServiceAccountID, ok = claims["sub"].(string); !ok {
+		return nil, ErrInvalidToken
+	}
+	if result.OrganizationID, ok = claims["org"].(string); !ok {
+		return nil, ErrInvalidToken
+	}
+	if scope, ok := claims["scope"].(string); ok {
+		result.Scopes = strings.Fields(scope)
+	}
+	resources, _ := claims["resources"].([]interface{})
+	for _, resource := range resources {
+		fields, ok := resource.(map[string]interface{})
+		if !ok {
+			return nil, ErrInvalidToken
+		}
+		projectID, _ := fields["project_id"].(string)
+		env, _ := fields["environment"].(string)
+		result.Resources = append(result.Resources, models.ResourceScope{ProjectID: projectID, Environment: env})
+	}
+	if iat, ok := claims["iat"].(float64); ok {
+		result.IssuedAt = time.Unix(int64(iat), 0)
+	}
+	if exp, ok := claims["exp"].(float64); ok {
+		result.ExpiresAt = time.Unix(int64(exp), 0)
+	}
+	return result, nil
+}
+
+// CheckServiceAccount vérifie, à chaque requête, que le compte de service
+// du token existe toujours : supprimer le compte révoque ses tokens
+func (s *Service) CheckServiceAccount(ctx context.Context, claims *ServiceAccountClaims) error {
+	if s.serviceAccounts == nil {
+		return ErrInvalidToken
+	}
+	account, err := s.serviceAccounts.GetServiceAccount(ctx, claims.ServiceAccountID)
+	if errors.Is(err, storage.ErrNotFound) {
+		return ErrTokenRevoked
+	}
+	if err != nil {
+		return err
+	}
+	if account.OrganizationID != claims.OrganizationID {
+		return ErrInvalidToken
+	}
+	return nil
+}
+
+// AuthenticateClientCertificate authentifie un compte de service par les
+// certificats présentés lors d'une connexion mTLS (le certificat client puis
+// ses intermédiaires). Son unique SAN URI spiffe:// désigne le domaine de
+// confiance d'une organisation : le certificat doit être signé par une
+// autorité de cette organisation et l'identifiant associé à l'un de ses
+// comptes (ErrInvalidCredentials sinon). Les claims renvoyées portent les
+// portées et les ressources du compte et expirent avec le certificat.
+func (s *Service) AuthenticateClientCertificate(ctx context.Context,
+	certs []*x509.Certificate) (*ServiceAccountClaims, error) {
+	// Un SVID X.509 porte exactement un SAN URI, l'identifiant SPIFFE
+	if s.serviceAccounts == nil || len(certs) == 0 || len(certs[0].URIs) != 1 ||
+		!models.ValidSPIFFEID(certs[0].URIs[0].String()) {
+		return nil, ErrInvalidCredentials
+	}
+	cert, spiffeID := certs[0], certs[0].URIs[0].String()
+
+	trust, err := s.serviceAccounts.GetServiceAccountTrustByDomain(ctx, models.SPIFFETrustDomain(spiffeID))
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, err
+	}
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM([]byte(trust.CABundle)) {
+		return nil, ErrInvalidCredentials
+	}
+	intermediates := x509.NewCertPool()
+	for _, intermediate := range certs[1:] {
+		intermediates.AddCert(intermediate)
+	}
+	_, err = cert.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	if err != nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	account, err := s.serviceAccounts.GetServiceAccountBySPIFFEID(ctx, trust.OrganizationID, spiffeID)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if account.LastUsedAt == nil || now.Sub(*account.LastUsedAt) >= certificateTouchInterval {
+		if err := s.serviceAccounts.TouchServiceAccount(ctx, account.ID, now); err != nil {
+			return nil, err
+		}
+	}
+	return &ServiceAccountClaims{
+		ServiceAccountID: account.ID,
+		OrganizationID:   account.OrganizationID,
+		Scopes:           account.Scopes(),
+		Resources:        account.Resources,
+		IssuedAt:         cert.NotBefore,
+		ExpiresAt:        cert.NotAfter,
+	}, nil
+}
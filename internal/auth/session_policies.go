@@ -0,0 +1,96 @@
+// filepath: internal/auth/session_policies.go
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// ApplySessionPolicies applique aux sessions des membres la politique de
+// session de leurs organisations. Sans appel, seule la configuration globale
+// des tokens s'applique.
+func (s *Service) ApplySessionPolicies(policies storage.SessionPoliciesRepository) {
+	s.policies = policies
+}
+
+// sessionPolicy renvoie la politique la plus stricte des organisations d'un
+// utilisateur (roles, par ID d'organisation) ; une politique vide n'impose
+// aucune limite
+func (s *Service) sessionPolicy(ctx context.Context, roles map[string]string) (*models.SessionPolicy, error) {
+	if s.policies == nil {
+		return &models.SessionPolicy{}, nil
+	}
+	policies := make([]*models.SessionPolicy, 0, len(roles))
+	for orgID := range roles {
+		policy, err := s.policies.GetSessionPolicy(ctx, orgID)
+		if errors.Is(err, storage.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, policy)
+	}
+	return models.StrictestSessionPolicy(policies), nil
+}
+
+// sessionExpired indique si la session du token a dépassé la durée maximale
+// ou le délai d'inactivité de la politique. La politique est relue à chaque
+// rafraîchissement : un durcissement s'applique aux sessions ouvertes.
+func sessionExpired(policy *models.SessionPolicy, token *models.RefreshToken, now time.Time) bool {
+	if lifetime := policy.MaxLifetime(); lifetime > 0 && !now.Before(token.SessionStartedAt.Add(lifetime)) {
+		return true
+	}
+	idle := policy.IdleTimeout()
+	return idle > 0 && !now.Before(token.CreatedAt.Add(idle))
+}
+
+// sessionExpiries renvoie les durées de validité des tokens d'une session
+// ouverte à start, bornées par la politique : le token de rafraîchissement
+// expire au plus tard à la fin de la session ou après le délai d'inactivité
+func (s *Service) sessionExpiries(policy *models.SessionPolicy, start, now time.Time) (access, refresh time.Duration) {
+	access, refresh = s.jwtExpiry, s.refreshTime
+	if idle := policy.IdleTimeout(); idle > 0 {
+		access, refresh = min(access, idle), min(refresh, idle)
+	}
+	if lifetime := policy.MaxLifetime(); lifetime > 0 {
+		remaining := start.Add(lifetime).Sub(now)
+		access, refresh = min(access, remaining), min(refresh, remaining)
+	}
+	return access, refresh
+}
+
+// limitSessions révoque les sessions les plus anciennes de l'utilisateur
+// au-delà du nombre de sessions simultanées de la politique, la session
+// current comprise dans le compte
+func (s *Service) limitSessions(ctx context.Context, policy *models.SessionPolicy, userID, current string) error {
+	if policy.MaxSessions == 0 {
+		return nil
+	}
+	now := time.Now()
+	// Les sessions sont triées de la plus récente à la plus ancienne
+	tokens, err := s.sessions.ListActiveRefreshTokens(ctx, userID, now)
+	if err != nil {
+		return err
+	}
+	kept := 1
+	for _, token := range tokens {
+		if token.SessionID == current {
+			continue
+		}
+		if kept < policy.MaxSessions {
+			kept++
+			continue
+		}
+		err := s.sessions.RevokeSession(ctx, userID, token.SessionID, now)
+		if err != nil && !errors.Is(err, storage.ErrSessionNotFound) {
+			return err
+		}
+	}
+	return nil
+}
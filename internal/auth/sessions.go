@@ -0,0 +1,179 @@
+// filepath: internal/auth/sessions.go
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// newSession prépare le premier token de rafraîchissement d'une session
+// ouverte depuis device (nil : appareil inconnu)
+func newSession(userID string, mfa bool, device *Device) *models.RefreshToken {
+	session := &models.RefreshToken{UserID: userID, MFA: mfa}
+	if device != nil {
+		session.UserAgent = device.UserAgent
+		session.IPAddress = device.IPAddress
+	}
+	return session
+}
+
+// RefreshToken échange un token de rafraîchissement contre de nouveaux
+// tokens de la même session. Le token présenté est remplacé : le présenter
+// de nouveau (token volé et déjà utilisé par son détenteur légitime, ou
+// l'inverse) révoque toute la session et renvoie ErrRefreshTokenReused.
+func (s *Service) RefreshToken(ctx context.Context, refreshToken string, device *Device) (*TokenResponse, error) {
+	stored, err := s.sessions.GetRefreshTokenByHash(ctx, HashPersonalAccessToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, ErrInvalidToken
+		}
+		return nil, err
+	}
+	if stored.RevokedAt != nil {
+		return nil, ErrTokenRevoked
+	}
+	now := time.Now()
+	if stored.RotatedAt != nil {
+		return nil, s.revokeReusedSession(ctx, stored, now)
+	}
+	if !now.Before(stored.ExpiresAt) {
+		return nil, ErrTokenExpired
+	}
+
+	user, err := s.users.GetUserByID(ctx, stored.UserID)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, ErrInvalidToken
+		}
+		return nil, err
+	}
+	if revoked(user, stored.CreatedAt.Truncate(time.Second)) {
+		return nil, ErrTokenRevoked
+	}
+
+	// Une session ouverte avant l'activation de l'authentification
+	// multifacteur ne permet pas de la contourner
+	if !stored.MFA {
+		enabled, err := s.mfaEnabled(ctx, stored.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if enabled {
+			return nil, ErrMFARequired
+		}
+	}
+
+	if s.policies != nil {
+		roles, err := s.users.GetUserRoles(ctx, stored.UserID)
+		if err != nil {
+			return nil, err
+		}
+		policy, err := s.sessionPolicy(ctx, roles)
+		if err != nil {
+			return nil, err
+		}
+		if sessionExpired(policy, stored, now) {
+			return nil, ErrTokenExpired
+		}
+	}
+
+	// Deux rafraîchissements concurrents : un seul remplace le token
+	rotated, err := s.sessions.RotateRefreshToken(ctx, stored.ID, now)
+	if err != nil {
+		return nil, err
+	}
+	if !rotated {
+		return nil, s.revokeReusedSession(ctx, stored, now)
+	}
+
+	// Les rôles embarqués sont relus en base ; l'adresse IP est celle du
+	// dernier rafraîchissement
+	next := &models.RefreshToken{
+		SessionID:        stored.SessionID,
+		UserID:           stored.UserID,
+		MFA:              stored.MFA,
+		UserAgent:        stored.UserAgent,
+		IPAddress:        stored.IPAddress,
+		SessionStartedAt: stored.SessionStartedAt,
+	}
+	if device != nil && device.IPAddress != "" {
+		next.IPAddress = device.IPAddress
+	}
+	return s.generateTokenPair(ctx, next)
+}
+
+// revokeReusedSession révoque la session d'un token remplacé présenté de nouveau
+func (s *Service) revokeReusedSession(ctx context.Context, reused *models.RefreshToken, now time.Time) error {
+	err := s.sessions.RevokeSession(ctx, reused.UserID, reused.SessionID, now)
+	if err != nil && !errors.Is(err, storage.ErrSessionNotFound) {
+		return err
+	}
+	return ErrRefreshTokenReused
+}
+
+// Logout révoque la session d'un token de rafraîchissement : ses tokens
+// d'accès sont refusés dès la requête suivante. Une session déjà révoquée
+// n'est pas une erreur.
+func (s *Service) Logout(ctx context.Context, refreshToken string) error {
+	stored, err := s.sessions.GetRefreshTokenByHash(ctx, HashPersonalAccessToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return ErrInvalidToken
+		}
+		return err
+	}
+	err = s.sessions.RevokeSession(ctx, stored.UserID, stored.SessionID, time.Now())
+	if err != nil && !errors.Is(err, storage.ErrSessionNotFound) {
+		return err
+	}
+	return nil
+}
+
+// Sessions liste les sessions actives de l'utilisateur. Les sessions
+// invalidées sans être révoquées (confinement, authentification
+// multifacteur activée depuis) sont omises ; current est marquée.
+func (s *Service) Sessions(ctx context.Context, userID, current string) ([]*models.Session, error) {
+	user, err := s.users.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	mfa, err := s.mfaEnabled(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	tokens, err := s.sessions.ListActiveRefreshTokens(ctx, userID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := []*models.Session{}
+	for _, token := range tokens {
+		if revoked(user, token.CreatedAt.Truncate(time.Second)) || (mfa && !token.MFA) {
+			continue
+		}
+		session := token.Session()
+		session.Current = session.ID == current
+		sessions = append(sessions, session)
+	}
+	return sessions, nil
+}
+
+// RevokeSession révoque une session de l'utilisateur (storage.ErrSessionNotFound
+// si elle n'existe pas ou est déjà révoquée)
+func (s *Service) RevokeSession(ctx context.Context, userID, sessionID string) error {
+	return s.sessions.RevokeSession(ctx, userID, sessionID, time.Now())
+}
+
+// RevokeOtherSessions révoque toutes les sessions de l'utilisateur sauf
+// current et renvoie le nombre de tokens révoqués
+func (s *Service) RevokeOtherSessions(ctx context.Context, userID, current string) (int64, error) {
+	return s.sessions.RevokeUserSessions(ctx, userID, current, time.Now())
+}
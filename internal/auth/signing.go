@@ -0,0 +1,276 @@
+// filepath: internal/auth/signing.go
+
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// Taille minimale des clés RSA de signature des tokens
+const minRSAKeyBits = 2048
+
+// Taille minimale d'un secret HMAC de signature des tokens (RFC 7518, 3.2)
+const minSecretKeyBytes = 32
+
+// Préfixe d'une source de clé de signature lue dans Vault
+// ("vault:jwt/signing#2026-10")
+const vaultKeySource = "vault:"
+
+// SigningKey est une clé de signature des tokens, identifiée par son kid :
+// RS256 pour une clé RSA, EdDSA pour une clé Ed25519, HS256 pour un secret
+// partagé. Les services internes vérifient les tokens d'une clé
+// asymétrique avec sa clé publique, publiée dans le JWKS, sans connaître de
+// secret.
+type SigningKey struct {
+	ID string
+	// RetiresAt est la date à partir de laquelle la clé ne signe plus et
+	// les tokens qu'elle a signés sont refusés ; zéro, elle n'expire pas
+	RetiresAt time.Time
+	method    jwt.SigningMethod
+	private   crypto.Signer
+	secret    []byte
+}
+
+// SecretReader lit un secret KV (vault.Client)
+type SecretReader interface {
+	GetSecret(ctx context.Context, path string) (map[string]interface{}, error)
+}
+
+// ParseSigningKey lit une clé privée PEM : PKCS#8 (RSA ou Ed25519) ou
+// PKCS#1 (RSA)
+func ParseSigningKey(id string, data []byte) (*SigningKey, error) {
+	if id == "" {
+		return nil, errors.New("identifiant (kid) de la clé de signature requis")
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("clé de signature %s : PEM invalide", id)
+	}
+
+	var parsed interface{}
+	var err error
+	if block.Type == "RSA PRIVATE KEY" {
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	} else {
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("clé de signature %s invalide: %w", id, err)
+	}
+
+	switch private := parsed.(type) {
+	case *rsa.PrivateKey:
+		if private.N.BitLen() < minRSAKeyBits {
+			return nil, fmt.Errorf("clé de signature %s trop courte (%d bits, %d au moins)", id,
+				private.N.BitLen(), minRSAKeyBits)
+		}
+		return &SigningKey{ID: id, method: jwt.SigningMethodRS256, private: private}, nil
+	case ed25519.PrivateKey:
+		return &SigningKey{ID: id, method: jwt.SigningMethodEdDSA, private: private}, nil
+	default:
+		return nil, fmt.Errorf("clé de signature %s : type %T non pris en charge (RSA ou Ed25519)", id, parsed)
+	}
+}
+
+// NewSecretKey crée une clé HMAC (HS256) : contrairement à JWT_SECRET, elle
+// porte un kid et peut donc être remplacée sans invalider les tokens
+// qu'elle a signés
+func NewSecretKey(id string, secret []byte) (*SigningKey, error) {
+	if id == "" {
+		return nil, errors.New("identifiant (kid) de la clé de signature requis")
+	}
+	if len(secret) < minSecretKeyBytes {
+		return nil, fmt.Errorf("secret de signature %s trop court (%d octets, %d au moins)", id, len(secret),
+			minSecretKeyBytes)
+	}
+	return &SigningKey{ID: id, method: jwt.SigningMethodHS256, secret: secret}, nil
+}
+
+// LoadSigningKey lit la clé kid depuis source : un fichier, ou un champ d'un
+// secret Vault ("vault:chemin#champ", champ "key" par défaut) lu avec
+// secrets. Une clé PEM est asymétrique, tout autre contenu est un secret HMAC.
+func LoadSigningKey(ctx context.Context, id, source string, secrets SecretReader) (*SigningKey, error) {
+	var data []byte
+	if path, ok := strings.CutPrefix(source, vaultKeySource); ok {
+		path, field, _ := strings.Cut(path, "#")
+		if field == "" {
+			field = "key"
+		}
+		if secrets == nil {
+			return nil, fmt.Errorf("clé de signature %s : Vault non configuré", id)
+		}
+		secret, err := secrets.GetSecret(ctx, path)
+		if err != nil {
+			return nil, fmt.Errorf("clé de signature %s : %w", id, err)
+		}
+		value, ok := secret[field].(string)
+		if !ok || value == "" {
+			return nil, fmt.Errorf("clé de signature %s : champ %q absent de %s", id, field, path)
+		}
+		data = []byte(value)
+	} else {
+		var err error
+		if data, err = os.ReadFile(source); err != nil {
+			return nil, fmt.Errorf("clé de signature %s : %w", id, err)
+		}
+	}
+
+	data = bytes.TrimSpace(data)
+	if bytes.HasPrefix(data, []byte("-----BEGIN")) {
+		return ParseSigningKey(id, data)
+	}
+	return NewSecretKey(id, data)
+}
+
+// Retired indique si la clé est retirée à la date now
+func (k *SigningKey) Retired(now time.Time) bool {
+	return !k.RetiresAt.IsZero() && !now.Before(k.RetiresAt)
+}
+
+// Algorithm renvoie l'algorithme de signature (claim alg) de la clé
+func (k *SigningKey) Algorithm() string {
+	return k.method.Alg()
+}
+
+// JWK décrit la clé publique d'une clé de signature (RFC 7517 ; RFC 8037
+// pour Ed25519)
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	// N et E sont le module et l'exposant d'une clé RSA
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// Curve et X décrivent une clé Ed25519 (kty OKP)
+	Curve string `json:"crv,omitempty"`
+	X     string `json:"x,omitempty"`
+}
+
+// JWKS est l'ensemble des clés publiques de vérification des tokens
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWK renvoie la clé publique de la clé de signature (vide pour un secret
+// HMAC, qui n'est jamais publié)
+func (k *SigningKey) JWK() JWK {
+	if k.private == nil {
+		return JWK{}
+	}
+	jwk := JWK{KeyID: k.ID, Use: "sig", Algorithm: k.Algorithm()}
+	switch public := k.private.Public().(type) {
+	case *rsa.PublicKey:
+		jwk.KeyType = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+	case ed25519.PublicKey:
+		jwk.KeyType = "OKP"
+		jwk.Curve = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(public)
+	}
+	return jwk
+}
+
+// EnableSigningKeys signe les tokens avec la première clé non retirée (kid
+// dans l'en-tête) au lieu du secret partagé JWT_SECRET. Les tokens signés
+// par les autres clés restent acceptés, et leurs clés publiques publiées,
+// jusqu'à leur date de retrait : une rotation ajoute la nouvelle clé en tête
+// et fixe le retrait de l'ancienne après l'expiration des tokens qu'elle a
+// signés. acceptHS256 accepte encore les tokens signés avec JWT_SECRET (sans
+// kid), le temps que ceux émis avant le changement expirent.
+func (s *Service) EnableSigningKeys(keys []*SigningKey, acceptHS256 bool) error {
+	if len(keys) == 0 {
+		return errors.New("au moins une clé de signature requise")
+	}
+	seen := make(map[string]bool, len(keys))
+	active := false
+	for _, key := range keys {
+		if seen[key.ID] {
+			return fmt.Errorf("clé de signature %s en double", key.ID)
+		}
+		seen[key.ID] = true
+		active = active || !key.Retired(time.Now())
+	}
+	if !active {
+		return errors.New("toutes les clés de signature sont retirées")
+	}
+	s.signingKeys = keys
+	s.acceptHS256 = acceptHS256
+	return nil
+}
+
+// JWKS renvoie les clés publiques de vérification des tokens, sans les clés
+// retirées ; vide tant que les tokens sont signés avec un secret partagé
+func (s *Service) JWKS() JWKS {
+	jwks := JWKS{Keys: make([]JWK, 0, len(s.signingKeys))}
+	now := time.Now()
+	for _, key := range s.signingKeys {
+		if key.private != nil && !key.Retired(now) {
+			jwks.Keys = append(jwks.Keys, key.JWK())
+		}
+	}
+	return jwks
+}
+
+// signToken signe les claims avec la clé de signature courante ou, sans
+// clé de signature, avec le secret partagé JWT_SECRET
+func (s *Service) signToken(claims jwt.MapClaims) (string, error) {
+	if len(s.signingKeys) == 0 {
+		return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(s.jwtSecret))
+	}
+	now := time.Now()
+	for _, key := range s.signingKeys {
+		if key.Retired(now) {
+			continue
+		}
+		token := jwt.NewWithClaims(key.method, claims)
+		token.Header["kid"] = key.ID
+		if key.private == nil {
+			return token.SignedString(key.secret)
+		}
+		return token.SignedString(key.private)
+	}
+	return "", errors.New("toutes les clés de signature sont retirées")
+}
+
+// verificationKey renvoie la clé qui vérifie la signature du token, d'après
+// son algorithme et son kid. Un token sans kid est signé avec JWT_SECRET ;
+// celui d'une clé retirée est refusé.
+func (s *Service) verificationKey(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	if _, ok := token.Method.(*jwt.SigningMethodHMAC); ok && kid == "" {
+		if len(s.signingKeys) > 0 && !s.acceptHS256 {
+			return nil, fmt.Errorf("méthode de signature refusée: %v", token.Header["alg"])
+		}
+		return []byte(s.jwtSecret), nil
+	}
+	for _, key := range s.signingKeys {
+		if key.ID != kid || key.Algorithm() != token.Method.Alg() {
+			continue
+		}
+		if key.Retired(time.Now()) {
+			return nil, fmt.Errorf("clé de signature retirée: %q", kid)
+		}
+		if key.private == nil {
+			return key.secret, nil
+		}
+		return key.private.Public(), nil
+	}
+	return nil, fmt.Errorf("clé de signature inconnue: %q (%v)", kid, token.Header["alg"])
+}
@@ -0,0 +1,77 @@
+// filepath: internal/auth/tokens.go
+
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+)
+
+// PersonalAccessTokenPrefix distingue les tokens d'accès personnels des JWT
+// (et permet aux scanners de secrets de les reconnaître)
+const PersonalAccessTokenPrefix = "smpat_"
+
+// APIKeyPrefix distingue les clés d'API (en-tête X-API-Key)
+const APIKeyPrefix = "smak_"
+
+// RefreshTokenPrefix distingue les tokens de rafraîchissement
+const RefreshTokenPrefix = "smrt_"
+
+// ServiceAccountSecretPrefix distingue les secrets des comptes de service
+const ServiceAccountSecretPrefix = "smsa_"
+
+// InvitationTokenPrefix distingue les tokens d'invitation envoyés par email
+const InvitationTokenPrefix = "sminv_"
+
+// Nombre de caractères aléatoires du token conservés en clair pour l'identifier
+const tokenDisplayLength = 6
+
+// NewPersonalAccessToken génère un token d'accès personnel et renvoie le
+// token, à remettre une seule fois à l'utilisateur, son empreinte et son
+// préfixe d'affichage
+func NewPersonalAccessToken() (token, hash, prefix string, err error) {
+	return newToken(PersonalAccessTokenPrefix)
+}
+
+// NewAPIKey génère une clé d'API et renvoie la clé, à remettre une seule
+// fois à l'administrateur, son empreinte et son préfixe d'affichage
+func NewAPIKey() (key, hash, prefix string, err error) {
+	return newToken(APIKeyPrefix)
+}
+
+// NewServiceAccountSecret génère le secret d'un compte de service et
+// renvoie le secret, à remettre une seule fois à l'administrateur, son
+// empreinte et son préfixe d'affichage
+func NewServiceAccountSecret() (secret, hash, prefix string, err error) {
+	return newToken(ServiceAccountSecretPrefix)
+}
+
+// NewInvitationToken génère le token d'une invitation, envoyé une seule
+// fois par email, et son empreinte
+func NewInvitationToken() (token, hash string, err error) {
+	token, hash, _, err = newToken(InvitationTokenPrefix)
+	return token, hash, err
+}
+
+func newToken(kind string) (token, hash, prefix string, err error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", "", "", err
+	}
+	token = kind + base64.RawURLEncoding.EncodeToString(raw)
+	return token, HashPersonalAccessToken(token), token[:len(kind)+tokenDisplayLength], nil
+}
+
+// IsPersonalAccessToken indique si un Bearer token est un token d'accès personnel
+func IsPersonalAccessToken(token string) bool {
+	return strings.HasPrefix(token, PersonalAccessTokenPrefix)
+}
+
+// HashPersonalAccessToken renvoie l'empreinte conservée d'un token ou d'une clé d'API
+func HashPersonalAccessToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
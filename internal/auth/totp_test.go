@@ -0,0 +1,52 @@
+// filepath: internal/auth/totp_test.go
+
+package auth
+
+import (
+	"testing"
+	"time"
+)
+
+// Vecteurs de test de la RFC 6238 (annexe B, SHA1) tronqués à 6 chiffres
+func TestTOTPCode(t *testing.T) {
+	secret := totpEncoding.EncodeToString([]byte("12345678901234567890"))
+	tests := []struct {
+		at       int64
+		expected string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+
+	for _, tt := range tests {
+		code, err := TOTPCode(secret, time.Unix(tt.at, 0))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if code != tt.expected {
+			t.Errorf("Expected %s at %d, got %s", tt.expected, tt.at, code)
+		}
+	}
+}
+
+func TestVerifyTOTPRejectsReplay(t *testing.T) {
+	secret, err := NewTOTPSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	code, _ := TOTPCode(secret, now)
+
+	step, ok := verifyTOTP(secret, code, now, 0)
+	if !ok || step != now.Unix()/totpPeriod {
+		t.Fatalf("Expected the current code to be accepted, got %d %v", step, ok)
+	}
+	if _, ok := verifyTOTP(secret, code, now, step); ok {
+		t.Errorf("Expected a used code to be rejected")
+	}
+	if _, ok := verifyTOTP(secret, code, now.Add(3*totpPeriod*time.Second), 0); ok {
+		t.Errorf("Expected an outdated code to be rejected")
+	}
+}
@@ -0,0 +1,76 @@
+// filepath: internal/calendar/ics.go
+
+// Package calendar produit le flux iCalendar (RFC 5545) des échéances d'une
+// organisation, auquel les équipes s'abonnent depuis leur agenda.
+package calendar
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"secrets-manager/internal/models"
+)
+
+// Longueur maximale d'une ligne iCalendar, en octets (hors CRLF)
+const maxLineLength = 75
+
+// Format des dates iCalendar en UTC
+const icsTime = "20060102T150405Z"
+
+var textEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+// WriteICS écrit le calendrier name contenant events. now date la
+// génération (DTSTAMP) de chaque événement.
+func WriteICS(w io.Writer, name string, events []*models.CalendarEvent, now time.Time) error {
+	bw := bufio.NewWriter(w)
+	line := func(property, value string) {
+		writeFolded(bw, property+":"+value)
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", "-//secrets-manager//calendar//FR")
+	line("CALSCALE", "GREGORIAN")
+	line("METHOD", "PUBLISH")
+	line("X-WR-CALNAME", escapeText(name))
+	for _, event := range events {
+		line("BEGIN", "VEVENT")
+		line("UID", event.UID)
+		line("DTSTAMP", now.UTC().Format(icsTime))
+		line("DTSTART", event.At.UTC().Format(icsTime))
+		line("SUMMARY", escapeText(event.Summary))
+		line("CATEGORIES", escapeText(event.Type))
+		if event.ProjectID != "" {
+			line("DESCRIPTION", escapeText("Projet "+event.ProjectID+", environnement "+event.Environment))
+		}
+		line("END", "VEVENT")
+	}
+	line("END", "VCALENDAR")
+	return bw.Flush()
+}
+
+func escapeText(text string) string {
+	return textEscaper.Replace(text)
+}
+
+// writeFolded écrit une ligne terminée par CRLF, repliée tous les 75
+// octets sans couper de caractère UTF-8 : chaque suite commence par un espace
+func writeFolded(w *bufio.Writer, line string) {
+	limit := maxLineLength
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		w.WriteString(line[:cut])
+		w.WriteString("\r\n ")
+		line = line[cut:]
+		// L'espace de continuation compte dans la longueur de la ligne
+		limit = maxLineLength - 1
+	}
+	w.WriteString(line)
+	w.WriteString("\r\n")
+}
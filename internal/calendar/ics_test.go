@@ -0,0 +1,49 @@
+// filepath: internal/calendar/ics_test.go
+
+package calendar
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"secrets-manager/internal/models"
+)
+
+func TestWriteICS(t *testing.T) {
+	at := time.Date(2026, 10, 20, 22, 0, 0, 0, time.FixedZone("CEST", 2*3600))
+	events := []*models.CalendarEvent{{
+		UID:         "scheduled_change-1@secrets-manager",
+		Type:        models.CalendarScheduledChange,
+		Summary:     "Changement planifié de DB_PASSWORD; " + strings.Repeat("é", 60),
+		At:          at,
+		ProjectID:   "p1",
+		Environment: "prod",
+	}}
+
+	var buf bytes.Buffer
+	if err := WriteICS(&buf, "Échéances", events, at); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	ics := buf.String()
+
+	if !strings.HasPrefix(ics, "BEGIN:VCALENDAR\r\n") || !strings.HasSuffix(ics, "END:VCALENDAR\r\n") {
+		t.Errorf("Expected a calendar, got %q", ics)
+	}
+	if !strings.Contains(ics, "DTSTART:20261020T200000Z\r\n") {
+		t.Errorf("Expected the start in UTC, got %q", ics)
+	}
+	if !strings.Contains(ics, `DB_PASSWORD\; `) {
+		t.Errorf("Expected the semicolon to be escaped, got %q", ics)
+	}
+	for _, line := range strings.Split(strings.TrimSuffix(ics, "\r\n"), "\r\n") {
+		if len(line) > maxLineLength {
+			t.Errorf("Expected lines of at most %d octets, got %d", maxLineLength, len(line))
+		}
+	}
+	unfolded := strings.ReplaceAll(ics, "\r\n ", "")
+	if !strings.Contains(unfolded, strings.Repeat("é", 60)+"\r\n") {
+		t.Errorf("Expected the folded summary to unfold intact, got %q", unfolded)
+	}
+}
@@ -0,0 +1,151 @@
+// filepath: internal/captcha/captcha.go
+
+// Package captcha vérifie que l'appelant d'une route publique (inscription,
+// tentatives répétées de connexion) n'est pas un automate : CAPTCHA hébergé
+// (hCaptcha, Cloudflare Turnstile) ou preuve de travail calculée par le
+// client. Le fournisseur est choisi par déploiement ; la réponse au défi est
+// présentée dans l'en-tête X-Captcha-Token.
+package captcha
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Fournisseurs pris en charge ; ProviderNone n'exige aucun défi
+const (
+	ProviderNone        = "none"
+	ProviderHCaptcha    = "hcaptcha"
+	ProviderTurnstile   = "turnstile"
+	ProviderProofOfWork = "pow"
+)
+
+// Points de vérification des CAPTCHA hébergés
+const (
+	hCaptchaVerifyURL  = "https://api.hcaptcha.com/siteverify"
+	turnstileVerifyURL = "https://challenges.cloudflare.com/turnstile/v0/siteverify"
+)
+
+// Délai maximal d'une vérification auprès du fournisseur
+const verifyTimeout = 5 * time.Second
+
+// ErrInvalid indique une réponse absente, invalide, expirée ou déjà utilisée
+var ErrInvalid = errors.New("défi non résolu")
+
+// Challenge décrit au client le défi à résoudre avant l'inscription
+type Challenge struct {
+	Provider string `json:"provider"`
+	// SiteKey est la clé publique du widget d'un CAPTCHA hébergé
+	SiteKey string `json:"site_key,omitempty"`
+	// Challenge et Difficulty décrivent une preuve de travail : trouver un
+	// compteur tel que SHA-256("challenge:compteur") commence par Difficulty
+	// bits nuls, puis présenter "challenge:compteur"
+	Challenge  string     `json:"challenge,omitempty"`
+	Difficulty int        `json:"difficulty,omitempty"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+}
+
+// Provider émet et vérifie les défis
+type Provider interface {
+	// Challenge renvoie un défi à résoudre
+	Challenge() (*Challenge, error)
+	// VerifyCaptcha vérifie la réponse au défi (ErrInvalid si elle est refusée)
+	VerifyCaptcha(ctx context.Context, response, remoteIP string) error
+}
+
+// New crée le fournisseur configuré : secret est la clé secrète d'un
+// CAPTCHA hébergé ou la clé HMAC des preuves de travail
+func New(provider, siteKey, secret, verifyURL string, difficulty int) (Provider, error) {
+	if provider == ProviderProofOfWork {
+		pow, err := NewProofOfWork([]byte(secret), difficulty)
+		if err != nil {
+			return nil, err
+		}
+		return pow, nil
+	}
+	verifier, err := NewSiteVerify(provider, siteKey, secret, verifyURL)
+	if err != nil {
+		return nil, err
+	}
+	return verifier, nil
+}
+
+// SiteVerify vérifie les réponses d'un CAPTCHA hébergé (hCaptcha, Turnstile)
+// auprès de son point de vérification, qui partagent le même protocole
+type SiteVerify struct {
+	provider  string
+	siteKey   string
+	secret    string
+	verifyURL string
+	client    *http.Client
+}
+
+// NewSiteVerify crée le vérificateur d'un CAPTCHA hébergé. verifyURL vide
+// prend le point de vérification du fournisseur.
+func NewSiteVerify(provider, siteKey, secret, verifyURL string) (*SiteVerify, error) {
+	defaultURL := map[string]string{ProviderHCaptcha: hCaptchaVerifyURL, ProviderTurnstile: turnstileVerifyURL}
+	if _, ok := defaultURL[provider]; !ok {
+		return nil, fmt.Errorf("fournisseur de CAPTCHA inconnu: %q", provider)
+	}
+	if verifyURL == "" {
+		verifyURL = defaultURL[provider]
+	}
+	if secret == "" {
+		return nil, fmt.Errorf("secret du CAPTCHA %s requis", provider)
+	}
+	return &SiteVerify{
+		provider:  provider,
+		siteKey:   siteKey,
+		secret:    secret,
+		verifyURL: verifyURL,
+		client:    &http.Client{Timeout: verifyTimeout},
+	}, nil
+}
+
+// Challenge renvoie le fournisseur et la clé du widget à afficher
+func (v *SiteVerify) Challenge() (*Challenge, error) {
+	return &Challenge{Provider: v.provider, SiteKey: v.siteKey}, nil
+}
+
+// VerifyCaptcha transmet la réponse du widget au fournisseur
+func (v *SiteVerify) VerifyCaptcha(ctx context.Context, response, remoteIP string) error {
+	if response == "" {
+		return ErrInvalid
+	}
+	form := url.Values{"secret": {v.secret}, "response": {response}}
+	if remoteIP != "" {
+		form.Set("remoteip", remoteIP)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, v.verifyURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("vérification du CAPTCHA %s: %w", v.provider, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("vérification du CAPTCHA %s: statut %d", v.provider, resp.StatusCode)
+	}
+
+	var result struct {
+		Success    bool     `json:"success"`
+		ErrorCodes []string `json:"error-codes"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("vérification du CAPTCHA %s: %w", v.provider, err)
+	}
+	if !result.Success {
+		return fmt.Errorf("%w (%s)", ErrInvalid, strings.Join(result.ErrorCodes, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+// filepath: internal/captcha/captcha_test.go
+
+package captcha
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestProofOfWork(t *testing.T) {
+	pow, err := NewProofOfWork([]byte(strings.Repeat("k", 32)), 8)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	challenge, err := pow.Challenge()
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if challenge.Provider != ProviderProofOfWork || challenge.Difficulty != 8 || challenge.ExpiresAt == nil {
+		t.Fatalf("Unexpected challenge: %+v", challenge)
+	}
+	ctx := context.Background()
+
+	response := Solve(challenge.Challenge, challenge.Difficulty)
+	if err := pow.VerifyCaptcha(ctx, response, ""); err != nil {
+		t.Fatalf("Expected the solved challenge to be accepted, got %v", err)
+	}
+	if err := pow.VerifyCaptcha(ctx, response, ""); !errors.Is(err, ErrInvalid) {
+		t.Errorf("Expected a replayed challenge to be rejected, got %v", err)
+	}
+
+	other, _ := pow.Challenge()
+	forged := strings.Replace(other.Challenge, ".", "0.", 1)
+	for _, response := range []string{
+		"",
+		other.Challenge,
+		Solve(forged, 8),
+		other.Challenge + ":x",
+	} {
+		if err := pow.VerifyCaptcha(ctx, response, ""); !errors.Is(err, ErrInvalid) {
+			t.Errorf("Expected %q to be rejected, got %v", response, err)
+		}
+	}
+
+	if _, err := NewProofOfWork([]byte("short"), 8); err == nil {
+		t.Error("Expected a short key to be rejected")
+	}
+}
+
+func TestSiteVerify(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.PostFormValue("secret") != "s3cret" || r.PostFormValue("remoteip") != "203.0.113.7" {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		if r.PostFormValue("response") == "human" {
+			w.Write([]byte(`{"success": true}`))
+			return
+		}
+		w.Write([]byte(`{"success": false, "error-codes": ["invalid-input-response"]}`))
+	}))
+	defer server.Close()
+
+	verifier, err := New(ProviderTurnstile, "site", "s3cret", server.URL, 0)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	ctx := context.Background()
+	if err := verifier.VerifyCaptcha(ctx, "human", "203.0.113.7"); err != nil {
+		t.Errorf("Expected the response to be accepted, got %v", err)
+	}
+	if err := verifier.VerifyCaptcha(ctx, "bot", "203.0.113.7"); !errors.Is(err, ErrInvalid) {
+		t.Errorf("Expected the response to be rejected, got %v", err)
+	}
+	if _, err := New("recaptcha", "site", "s3cret", "", 0); err == nil {
+		t.Error("Expected an unknown provider to be rejected")
+	}
+}
@@ -4,25 +4,145 @@ package config
 
 import (
 	"fmt"
+	"net"
 	"os"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
+
+	"secrets-manager/internal/models"
 )
 
 // Config contient toutes les configurations de l'application
 type Config struct {
 	Server   ServerConfig
+	Admin    AdminConfig
+	MTLS     MTLSConfig
 	Database DatabaseConfig
 	Vault    VaultConfig
 	JWT      JWTConfig
+	SMTP     SMTPConfig
+	Log      LogConfig
+	Evidence EvidenceConfig
+	Checksum ChecksumConfig
+	GeoIP    GeoIPConfig
+	// Replication configure la réplication des métadonnées vers la région de secours
+	Replication ReplicationConfig
+	// AuthRateLimit limite les tentatives sur les routes d'authentification publiques
+	AuthRateLimit AuthRateLimitConfig
+	// Captcha configure le défi exigé à l'inscription
+	Captcha CaptchaConfig
+	// PasswordPolicy est la politique de mot de passe du serveur
+	PasswordPolicy PasswordPolicyConfig
+	// Registration encadre l'inscription libre (POST /auth/register)
+	Registration RegistrationConfig
+	// Trial est l'abonnement d'essai des organisations créées par l'API
+	Trial TrialConfig
+	// Preflight active les vérifications des dépendances au démarrage
+	Preflight bool
 }
 
 // ServerConfig contient la configuration du serveur HTTP
 type ServerConfig struct {
+	// Address accepte un hôte ("0.0.0.0"), un couple "hôte:port"
+	// ou un socket unix ("unix:/run/secrets-manager.sock")
 	Address string
 	Port    int
+	// ConfirmationWindow est la durée de validité des tokens de confirmation
+	// des opérations destructives
+	ConfirmationWindow time.Duration
+	// RecycleRetention est la durée pendant laquelle une organisation ou un
+	// projet supprimé reste restaurable avant sa purge définitive
+	RecycleRetention time.Duration
+	// UsageFlushInterval est l'intervalle d'écriture des appels API accumulés en mémoire
+	UsageFlushInterval time.Duration
+	// SlowRequestThreshold est la latence au-delà de laquelle une requête
+	// est journalisée comme lente (0 désactive la détection)
+	SlowRequestThreshold time.Duration
+	// V1DeprecatedSince est la date de dépréciation de /api/v1 (zéro si
+	// elle n'est pas dépréciée) et V1Sunset sa date de retrait (optionnelle)
+	V1DeprecatedSince time.Time
+	V1Sunset          time.Time
+	// DeviceVerificationURI est la page du tableau de bord où l'utilisateur
+	// saisit le code affiché par la CLI (flux device code)
+	DeviceVerificationURI string
+	// InvitationAcceptURI est la page du tableau de bord où l'invité accepte
+	// une invitation (lien envoyé par email, avec le paramètre token)
+	InvitationAcceptURI string
+	// EmailVerificationURI est la page du tableau de bord où l'utilisateur
+	// confirme son adresse email (lien envoyé par email, avec le paramètre token)
+	EmailVerificationURI string
+	// KillSwitches sont les routes ou fonctionnalités coupées au démarrage
+	KillSwitches []KillSwitch
+}
+
+// KillSwitch coupe une route ou une fonctionnalité (voir middleware.Switch)
+type KillSwitch struct {
+	Name   string
+	Reason string
+}
+
+// ListenAddress renvoie le réseau et l'adresse d'écoute du serveur.
+// Le port n'est ajouté que si Address ne contient pas déjà un port.
+func (c ServerConfig) ListenAddress() (network, address string) {
+	if path, ok := strings.CutPrefix(c.Address, "unix:"); ok {
+		return "unix", path
+	}
+	if strings.HasPrefix(c.Address, "/") {
+		return "unix", c.Address
+	}
+	if _, _, err := net.SplitHostPort(c.Address); err == nil {
+		return "tcp", c.Address
+	}
+	return "tcp", net.JoinHostPort(c.Address, strconv.Itoa(c.Port))
+}
+
+// AdminConfig contient la configuration du listener d'administration
+// (pprof, dumps de goroutines, informations de build)
+type AdminConfig struct {
+	// Address vide désactive le listener d'administration
+	Address string
+	// Token doit être présenté en Bearer sur chaque requête ; vide, le
+	// listener n'est pas démarré
+	Token string
+	// AuditRetention est la durée de conservation du journal d'audit d'administration
+	AuditRetention time.Duration
+}
+
+// Enabled indique si le listener d'administration doit être démarré : il
+// faut une adresse et un token, faute de quoi tout processus local aurait
+// les droits d'administration de la plateforme
+func (c AdminConfig) Enabled() bool {
+	return c.Address != "" && c.Token != ""
+}
+
+// ListenAddress renvoie le réseau et l'adresse d'écoute du listener d'administration
+func (c AdminConfig) ListenAddress() (network, address string) {
+	return ServerConfig{Address: c.Address}.ListenAddress()
+}
+
+// MTLSConfig contient la configuration du listener TLS dédié aux comptes
+// de service authentifiés par certificat client (SAN SPIFFE). Les autorités
+// qui signent ces certificats sont déclarées par chaque organisation.
+type MTLSConfig struct {
+	// Address vide désactive le listener mTLS
+	Address string
+	// CertFile et KeyFile sont le certificat et la clé du serveur (PEM)
+	CertFile string
+	KeyFile  string
+}
+
+// Enabled indique si le listener mTLS doit être démarré
+func (c MTLSConfig) Enabled() bool {
+	return c.Address != ""
+}
+
+// ListenAddress renvoie le réseau et l'adresse d'écoute du listener mTLS
+func (c MTLSConfig) ListenAddress() (network, address string) {
+	return ServerConfig{Address: c.Address}.ListenAddress()
 }
 
 // DatabaseConfig contient la configuration de la base de données
@@ -32,18 +152,219 @@ type DatabaseConfig struct {
 	User     string
 	Password string
 	DBName   string
+	// AutoMigrate applique les migrations en attente au démarrage
+	AutoMigrate bool
+	// SlowQueryThreshold est la latence au-delà de laquelle une requête SQL
+	// est journalisée comme lente (0 désactive la détection)
+	SlowQueryThreshold time.Duration
 }
 
 // VaultConfig contient la configuration de Vault
 type VaultConfig struct {
 	Address string
 	Token   string
+	// Timeout borne chaque appel à Vault
+	Timeout time.Duration
+	// BreakerThreshold est le nombre d'échecs consécutifs qui ouvre le disjoncteur
+	BreakerThreshold int
+	// BreakerCooldown est la durée pendant laquelle Vault n'est plus sollicité
+	BreakerCooldown time.Duration
+	// Regions liste les backends Vault des régions de résidence, en plus du
+	// backend principal (région "default")
+	Regions []VaultCluster
+	// ClusterName nomme le cluster primaire du backend principal
+	ClusterName string
+	// Replicas liste les réplicas de performance du backend principal,
+	// utilisés pour les lectures
+	Replicas []VaultCluster
+	// HealthInterval est la période de vérification de la santé des clusters
+	HealthInterval time.Duration
+}
+
+// VaultCluster est un cluster Vault supplémentaire (région ou réplica)
+type VaultCluster struct {
+	Name    string
+	Address string
+	Token   string
 }
 
 // JWTConfig contient la configuration JWT
 type JWTConfig struct {
-	Secret     string
-	Expiration time.Duration
+	Secret            string
+	Expiration        time.Duration
+	RefreshExpiration time.Duration
+
+	// Issuer (claim iss) et Audience (claim aud) distinguent les tokens de
+	// chaque environnement : un token émis pour un autre est refusé
+	Issuer   string
+	Audience string
+
+	// IntrospectionClients associe l'identifiant de chaque service autorisé à
+	// introspecter des tokens à son secret
+	IntrospectionClients map[string]string
+
+	// TrustedDeviceDuration est la durée pendant laquelle un appareil de
+	// confiance est dispensé de code TOTP ; 0 exige un code à chaque connexion
+	TrustedDeviceDuration time.Duration
+
+	// SigningKeys sont les clés (RSA, Ed25519 ou secrets HMAC) qui signent
+	// les tokens, la première non retirée signant les nouveaux ; vide, les
+	// tokens sont signés avec Secret (HS256, sans kid)
+	SigningKeys []JWTSigningKey
+	// AcceptHS256 accepte encore, avec SigningKeys, les tokens signés avec
+	// Secret, le temps que ceux émis avant le changement expirent
+	AcceptHS256 bool
+}
+
+// JWTSigningKey est une clé de signature des tokens
+type JWTSigningKey struct {
+	// ID est publié dans l'en-tête des tokens (kid) et dans le JWKS
+	ID string
+	// Source est le fichier de la clé (PEM, ou secret HMAC brut) ou son
+	// emplacement dans Vault ("vault:chemin#champ")
+	Source string
+	// RetiresAt est la date de retrait de la clé (JWT_KEY_RETIREMENTS) ;
+	// zéro, elle n'expire pas
+	RetiresAt time.Time
+}
+
+// SMTPConfig contient la configuration de l'envoi d'emails
+type SMTPConfig struct {
+	// Address est le serveur SMTP "hôte:port" ; vide désactive l'envoi
+	Address string
+	// From est l'expéditeur des notifications
+	From string
+}
+
+// Enabled indique si un serveur SMTP est configuré
+func (c SMTPConfig) Enabled() bool {
+	return c.Address != ""
+}
+
+// EvidenceConfig contient la configuration de l'export des preuves de conformité
+type EvidenceConfig struct {
+	// SigningKey est la graine Ed25519 (32 octets en base64) qui signe les
+	// archives de preuves ; vide désactive l'export
+	SigningKey string
+}
+
+// Enabled indique si l'export des preuves est configuré
+func (c EvidenceConfig) Enabled() bool {
+	return c.SigningKey != ""
+}
+
+// GeoIPConfig contient la configuration de la localisation des connexions
+type GeoIPConfig struct {
+	// Database est le chemin de la base CSV des réseaux (voir geoip) ;
+	// vide désactive la détection des connexions inhabituelles
+	Database string
+}
+
+// Enabled indique si les connexions sont situées
+func (c GeoIPConfig) Enabled() bool {
+	return c.Database != ""
+}
+
+// ChecksumConfig contient la configuration des sommes de contrôle des secrets
+type ChecksumConfig struct {
+	// Key est la clé HMAC (au moins 32 octets en base64) des sommes de
+	// contrôle ; vide les désactive. Elle doit être identique sur tous les
+	// serveurs et ne pas changer, sous peine de signaler une dérive partout.
+	Key string
+}
+
+// Enabled indique si les sommes de contrôle sont configurées
+func (c ChecksumConfig) Enabled() bool {
+	return c.Key != ""
+}
+
+// ReplicationConfig contient la configuration de la réplication des
+// métadonnées vers la base MySQL de la région de secours
+type ReplicationConfig struct {
+	// Standby est la base de secours ; un hôte vide désactive la réplication
+	Standby DatabaseConfig
+	// Interval est la période d'envoi des changements à la base de secours
+	Interval time.Duration
+	// Retention est la durée de conservation des changements sur le primaire
+	Retention time.Duration
+}
+
+// Enabled indique si une base de secours est configurée
+func (c ReplicationConfig) Enabled() bool {
+	return c.Standby.Host != ""
+}
+
+// AuthRateLimitConfig contient la limitation des tentatives de connexion,
+// d'inscription et de rafraîchissement (voir middleware.AuthLimits) ; une
+// valeur nulle désactive la limite correspondante
+type AuthRateLimitConfig struct {
+	PerIP        int
+	PerEmail     int
+	Window       time.Duration
+	FreeAttempts int
+	BaseDelay    time.Duration
+	MaxDelay     time.Duration
+	CaptchaAfter int
+}
+
+// CaptchaConfig contient la configuration du défi exigé à l'inscription et
+// après plusieurs tentatives d'authentification (voir captcha)
+type CaptchaConfig struct {
+	// Provider est none (aucun défi), hcaptcha, turnstile ou pow
+	Provider string
+	// SiteKey est la clé publique du widget d'un CAPTCHA hébergé
+	SiteKey string
+	// Secret est la clé secrète du CAPTCHA hébergé, ou la clé HMAC (32
+	// octets au moins) qui signe les défis de preuve de travail, partagée
+	// par toutes les instances
+	Secret string
+	// VerifyURL remplace le point de vérification du fournisseur (proxy)
+	VerifyURL string
+	// Difficulty est le nombre de bits nuls exigés par la preuve de travail
+	Difficulty int
+}
+
+// Enabled indique si un défi est exigé
+func (c CaptchaConfig) Enabled() bool {
+	return c.Provider != "" && c.Provider != "none"
+}
+
+// PasswordPolicyConfig contient la politique de mot de passe appliquée à
+// tous les utilisateurs, que les organisations peuvent renforcer
+type PasswordPolicyConfig struct {
+	MinLength int
+	// RejectBreached refuse les mots de passe présents dans les fuites connues
+	RejectBreached bool
+	// BreachAPIURL est l'API Pwned Passwords interrogée (proxy ou miroir) ;
+	// vide, la recherche est désactivée, même pour les organisations
+	BreachAPIURL string
+}
+
+// RegistrationConfig contient la politique d'inscription appliquée tant
+// qu'aucune n'a été réglée depuis le listener d'administration
+type RegistrationConfig struct {
+	// Mode est open, restricted (adresses invitées et liste d'autorisation)
+	// ou closed (invitations uniquement)
+	Mode string
+	// AllowList et DenyList contiennent des domaines ou des adresses
+	AllowList []string
+	DenyList  []string
+}
+
+// TrialConfig contient l'abonnement d'essai accordé à chaque organisation
+// créée par POST /organizations
+type TrialConfig struct {
+	// PlanID est le plan de l'essai
+	PlanID string
+	// Duration est la durée de l'essai, après laquelle l'abonnement expire
+	Duration     time.Duration
+	SecretsLimit int
+}
+
+// LogConfig contient la configuration des logs
+type LogConfig struct {
+	// Level est le niveau initial de tous les composants (debug, info, warn, error)
+	Level string
 }
 
 // Load charge la configuration depuis les variables d'environnement
@@ -60,6 +381,56 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("SERVER_PORT invalide: %w", err)
 	}
 	config.Server.Port = port
+	config.Server.SlowRequestThreshold, err = getDuration("SLOW_REQUEST_THRESHOLD", "1s")
+	if err != nil {
+		return nil, err
+	}
+	config.Server.ConfirmationWindow, err = getDuration("CONFIRMATION_WINDOW", "15m")
+	if err != nil {
+		return nil, err
+	}
+	config.Server.RecycleRetention, err = getDuration("RECYCLE_BIN_RETENTION", "720h")
+	if err != nil {
+		return nil, err
+	}
+	config.Server.UsageFlushInterval, err = getDuration("USAGE_FLUSH_INTERVAL", "10s")
+	if err != nil {
+		return nil, err
+	}
+	config.Server.V1DeprecatedSince, err = getDate("API_V1_DEPRECATED_SINCE")
+	if err != nil {
+		return nil, err
+	}
+	config.Server.V1Sunset, err = getDate("API_V1_SUNSET")
+	if err != nil {
+		return nil, err
+	}
+	if !config.Server.V1Sunset.IsZero() && config.Server.V1DeprecatedSince.IsZero() {
+		return nil, fmt.Errorf("API_V1_SUNSET nécessite API_V1_DEPRECATED_SINCE")
+	}
+	config.Server.DeviceVerificationURI = getEnv("DEVICE_VERIFICATION_URI", "http://localhost:3000/device")
+	config.Server.InvitationAcceptURI = getEnv("INVITATION_ACCEPT_URI", "http://localhost:3000/invitations/accept")
+	config.Server.EmailVerificationURI = getEnv("EMAIL_VERIFICATION_URI", "http://localhost:3000/email/verify")
+	config.Server.KillSwitches, err = parseKillSwitches("KILL_SWITCHES")
+	if err != nil {
+		return nil, err
+	}
+
+	// Configuration du listener d'administration
+	config.Admin.Address = getEnv("ADMIN_ADDRESS", "127.0.0.1:9090")
+	config.Admin.Token = getEnv("ADMIN_TOKEN", "")
+	config.Admin.AuditRetention, err = getDuration("ADMIN_AUDIT_RETENTION", "8760h")
+	if err != nil {
+		return nil, err
+	}
+
+	// Configuration du listener mTLS des comptes de service
+	config.MTLS.Address = getEnv("MTLS_ADDRESS", "")
+	config.MTLS.CertFile = getEnv("MTLS_CERT_FILE", "")
+	config.MTLS.KeyFile = getEnv("MTLS_KEY_FILE", "")
+	if config.MTLS.Enabled() && (config.MTLS.CertFile == "" || config.MTLS.KeyFile == "") {
+		return nil, fmt.Errorf("MTLS_ADDRESS nécessite MTLS_CERT_FILE et MTLS_KEY_FILE")
+	}
 
 	// Configuration de la base de données
 	config.Database.Host = getEnv("DB_HOST", "localhost")
@@ -71,10 +442,63 @@ func Load() (*Config, error) {
 	config.Database.User = getEnv("DB_USER", "root")
 	config.Database.Password = getEnv("DB_PASSWORD", "")
 	config.Database.DBName = getEnv("DB_NAME", "secrets_manager")
+	autoMigrate, err := strconv.ParseBool(getEnv("DB_AUTO_MIGRATE", "false"))
+	if err != nil {
+		return nil, fmt.Errorf("DB_AUTO_MIGRATE invalide: %w", err)
+	}
+	config.Database.AutoMigrate = autoMigrate
+	config.Database.SlowQueryThreshold, err = getDuration("SLOW_QUERY_THRESHOLD", "200ms")
+	if err != nil {
+		return nil, err
+	}
+
+	// Réplication des métadonnées (optionnelle), vers une base de même schéma
+	config.Replication.Standby = config.Database
+	config.Replication.Standby.Host = getEnv("DR_DB_HOST", "")
+	config.Replication.Standby.Port, err = strconv.Atoi(getEnv("DR_DB_PORT", strconv.Itoa(config.Database.Port)))
+	if err != nil {
+		return nil, fmt.Errorf("DR_DB_PORT invalide: %w", err)
+	}
+	config.Replication.Standby.User = getEnv("DR_DB_USER", config.Database.User)
+	config.Replication.Standby.Password = getEnv("DR_DB_PASSWORD", config.Database.Password)
+	config.Replication.Standby.DBName = getEnv("DR_DB_NAME", config.Database.DBName)
+	config.Replication.Interval, err = getDuration("DR_REPLICATION_INTERVAL", "5s")
+	if err != nil {
+		return nil, err
+	}
+	config.Replication.Retention, err = getDuration("METADATA_CHANGES_RETENTION", "168h")
+	if err != nil {
+		return nil, err
+	}
 
 	// Configuration de Vault
 	config.Vault.Address = getEnv("VAULT_ADDR", "http://localhost:8200")
 	config.Vault.Token = getEnv("VAULT_TOKEN", "")
+	config.Vault.Timeout, err = getDuration("VAULT_TIMEOUT", "10s")
+	if err != nil {
+		return nil, err
+	}
+	config.Vault.BreakerThreshold, err = strconv.Atoi(getEnv("VAULT_BREAKER_THRESHOLD", "5"))
+	if err != nil {
+		return nil, fmt.Errorf("VAULT_BREAKER_THRESHOLD invalide: %w", err)
+	}
+	config.Vault.BreakerCooldown, err = getDuration("VAULT_BREAKER_COOLDOWN", "30s")
+	if err != nil {
+		return nil, err
+	}
+	config.Vault.Regions, err = parseVaultClusters("VAULT_REGIONS", config.Vault.Token)
+	if err != nil {
+		return nil, err
+	}
+	config.Vault.ClusterName = getEnv("VAULT_CLUSTER_NAME", "primary")
+	config.Vault.Replicas, err = parseVaultClusters("VAULT_REPLICAS", config.Vault.Token)
+	if err != nil {
+		return nil, err
+	}
+	config.Vault.HealthInterval, err = getDuration("VAULT_HEALTH_INTERVAL", "15s")
+	if err != nil {
+		return nil, err
+	}
 
 	// Configuration JWT
 	config.JWT.Secret = getEnv("JWT_SECRET", "votre_secret_jwt_très_sécurisé")
@@ -83,6 +507,124 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("JWT_EXPIRATION_HOURS invalide: %w", err)
 	}
 	config.JWT.Expiration = time.Duration(jwtExp) * time.Hour
+	refreshExp, err := strconv.Atoi(getEnv("JWT_REFRESH_EXPIRATION_HOURS", "168"))
+	if err != nil {
+		return nil, fmt.Errorf("JWT_REFRESH_EXPIRATION_HOURS invalide: %w", err)
+	}
+	config.JWT.RefreshExpiration = time.Duration(refreshExp) * time.Hour
+	config.JWT.Issuer = getEnv("JWT_ISSUER", "secrets-manager")
+	config.JWT.Audience = getEnv("JWT_AUDIENCE", "secrets-manager-api")
+	config.JWT.IntrospectionClients, err = parseClientCredentials("INTROSPECTION_CLIENTS")
+	if err != nil {
+		return nil, err
+	}
+	trustedDeviceDays, err := strconv.Atoi(getEnv("TRUSTED_DEVICE_DAYS", "30"))
+	if err != nil {
+		return nil, fmt.Errorf("TRUSTED_DEVICE_DAYS invalide: %w", err)
+	}
+	config.JWT.TrustedDeviceDuration = time.Duration(trustedDeviceDays) * 24 * time.Hour
+	config.JWT.SigningKeys, err = parseSigningKeys("JWT_SIGNING_KEYS", "JWT_KEY_RETIREMENTS")
+	if err != nil {
+		return nil, err
+	}
+	config.JWT.AcceptHS256, err = strconv.ParseBool(getEnv("JWT_ACCEPT_HS256", "true"))
+	if err != nil {
+		return nil, fmt.Errorf("JWT_ACCEPT_HS256 invalide: %w", err)
+	}
+
+	// Limitation des routes d'authentification publiques
+	config.AuthRateLimit.PerIP, err = getInt("AUTH_RATE_LIMIT_PER_IP", "30")
+	if err != nil {
+		return nil, err
+	}
+	config.AuthRateLimit.PerEmail, err = getInt("AUTH_RATE_LIMIT_PER_EMAIL", "10")
+	if err != nil {
+		return nil, err
+	}
+	config.AuthRateLimit.Window, err = getDuration("AUTH_RATE_LIMIT_WINDOW", "15m")
+	if err != nil {
+		return nil, err
+	}
+	config.AuthRateLimit.FreeAttempts, err = getInt("AUTH_RATE_LIMIT_FREE_ATTEMPTS", "3")
+	if err != nil {
+		return nil, err
+	}
+	config.AuthRateLimit.BaseDelay, err = getDuration("AUTH_RATE_LIMIT_BASE_DELAY", "1s")
+	if err != nil {
+		return nil, err
+	}
+	config.AuthRateLimit.MaxDelay, err = getDuration("AUTH_RATE_LIMIT_MAX_DELAY", "1m")
+	if err != nil {
+		return nil, err
+	}
+	config.AuthRateLimit.CaptchaAfter, err = getInt("AUTH_CAPTCHA_AFTER", "5")
+	if err != nil {
+		return nil, err
+	}
+
+	// Défi à l'inscription (optionnel)
+	config.Captcha.Provider = getEnv("CAPTCHA_PROVIDER", "none")
+	config.Captcha.SiteKey = getEnv("CAPTCHA_SITE_KEY", "")
+	config.Captcha.Secret = getEnv("CAPTCHA_SECRET", "")
+	config.Captcha.VerifyURL = getEnv("CAPTCHA_VERIFY_URL", "")
+	config.Captcha.Difficulty, err = getInt("CAPTCHA_POW_DIFFICULTY", "20")
+	if err != nil {
+		return nil, err
+	}
+
+	// Politique de mot de passe du serveur
+	config.PasswordPolicy.MinLength, err = getInt("PASSWORD_MIN_LENGTH", "8")
+	if err != nil {
+		return nil, err
+	}
+	config.PasswordPolicy.RejectBreached, err = strconv.ParseBool(getEnv("PASSWORD_REJECT_BREACHED", "false"))
+	if err != nil {
+		return nil, fmt.Errorf("PASSWORD_REJECT_BREACHED invalide: %w", err)
+	}
+	config.PasswordPolicy.BreachAPIURL = getEnv("PASSWORD_BREACH_API_URL", "https://api.pwnedpasswords.com")
+
+	// Politique d'inscription
+	config.Registration.Mode = getEnv("REGISTRATION_MODE", models.RegistrationOpen)
+	if !models.ValidRegistrationMode(config.Registration.Mode) {
+		return nil, fmt.Errorf("REGISTRATION_MODE invalide: %q (attendu open, restricted ou closed)",
+			config.Registration.Mode)
+	}
+	config.Registration.AllowList = getList("REGISTRATION_ALLOW_LIST")
+	config.Registration.DenyList = getList("REGISTRATION_DENY_LIST")
+
+	// Abonnement d'essai des nouvelles organisations
+	config.Trial.PlanID = getEnv("TRIAL_PLAN_ID", "trial")
+	trialDays, err := getInt("TRIAL_DAYS", "14")
+	if err != nil {
+		return nil, err
+	}
+	config.Trial.Duration = time.Duration(trialDays) * 24 * time.Hour
+	config.Trial.SecretsLimit, err = getInt("TRIAL_SECRETS_LIMIT", "100")
+	if err != nil {
+		return nil, err
+	}
+
+	// Configuration des logs
+	config.Log.Level = getEnv("LOG_LEVEL", "info")
+
+	// Configuration SMTP (optionnelle)
+	config.SMTP.Address = getEnv("SMTP_ADDRESS", "")
+	config.SMTP.From = getEnv("SMTP_FROM", "secrets-manager@localhost")
+
+	// Signature des preuves de conformité (optionnelle)
+	config.Evidence.SigningKey = getEnv("EVIDENCE_SIGNING_KEY", "")
+
+	// Sommes de contrôle des secrets pour la détection de dérive (optionnelles)
+	config.Checksum.Key = getEnv("SECRET_CHECKSUM_KEY", "")
+
+	// Localisation des connexions (optionnelle)
+	config.GeoIP.Database = getEnv("GEOIP_DATABASE", "")
+
+	preflight, err := strconv.ParseBool(getEnv("PREFLIGHT_CHECKS", "true"))
+	if err != nil {
+		return nil, fmt.Errorf("PREFLIGHT_CHECKS invalide: %w", err)
+	}
+	config.Preflight = preflight
 
 	return config, nil
 }
@@ -94,3 +636,163 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+// getInt récupère un entier positif ou nul depuis l'environnement
+func getInt(key, defaultValue string) (int, error) {
+	n, err := strconv.Atoi(getEnv(key, defaultValue))
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("%s invalide: %q", key, getEnv(key, defaultValue))
+	}
+	return n, nil
+}
+
+// getDuration récupère une durée (ex: "500ms", "2s") depuis l'environnement
+func getDuration(key, defaultValue string) (time.Duration, error) {
+	d, err := time.ParseDuration(getEnv(key, defaultValue))
+	if err != nil {
+		return 0, fmt.Errorf("%s invalide: %w", key, err)
+	}
+	return d, nil
+}
+
+// getDate lit une date au format AAAA-MM-JJ (UTC) ; zéro si la variable est vide
+func getDate(key string) (time.Time, error) {
+	value := getEnv(key, "")
+	if value == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s invalide: %w", key, err)
+	}
+	return t, nil
+}
+
+// getList lit une liste de valeurs séparées par des virgules depuis la
+// variable key ("example.com,bob@example.org")
+func getList(key string) []string {
+	list := []string{}
+	for _, entry := range strings.Split(getEnv(key, ""), ",") {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			list = append(list, entry)
+		}
+	}
+	return list
+}
+
+// parseKillSwitches lit une liste d'interrupteurs séparés par des points-virgules
+// ("exports=incident en cours;POST /auth/register=inscriptions fermées")
+// depuis la variable key
+func parseKillSwitches(key string) ([]KillSwitch, error) {
+	var switches []KillSwitch
+	for _, entry := range strings.Split(getEnv(key, ""), ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, reason, ok := strings.Cut(entry, "=")
+		name, reason = strings.TrimSpace(name), strings.TrimSpace(reason)
+		if !ok || name == "" || reason == "" {
+			return nil, fmt.Errorf("%s invalide: %q (attendu nom=raison)", key, entry)
+		}
+		switches = append(switches, KillSwitch{Name: name, Reason: reason})
+	}
+	return switches, nil
+}
+
+// parseClientCredentials lit une liste d'identifiants de clients séparés par
+// des virgules ("sidecar:secret1,gateway:secret2") depuis la variable key
+func parseClientCredentials(key string) (map[string]string, error) {
+	clients := make(map[string]string)
+	for _, entry := range strings.Split(getEnv(key, ""), ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		id, secret, ok := strings.Cut(entry, ":")
+		if !ok || id == "" || secret == "" {
+			return nil, fmt.Errorf("%s invalide: %q (attendu id:secret)", key, id)
+		}
+		if _, exists := clients[id]; exists {
+			return nil, fmt.Errorf("%s invalide: client %q en double", key, id)
+		}
+		clients[id] = secret
+	}
+	return clients, nil
+}
+
+// parseSigningKeys lit une liste de clés de signature
+// ("2026-10=vault:jwt/signing#2026-10,2026-07=/etc/secrets-manager/jwt-2026-07.pem")
+// depuis la variable key, la clé courante en tête, et leurs dates de retrait
+// ("2026-07=2026-11-30" ou au format RFC 3339) depuis la variable retirements
+func parseSigningKeys(key, retirements string) ([]JWTSigningKey, error) {
+	var keys []JWTSigningKey
+	seen := make(map[string]bool)
+	for _, entry := range strings.Split(getEnv(key, ""), ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		id, source, ok := strings.Cut(entry, "=")
+		id, source = strings.TrimSpace(id), strings.TrimSpace(source)
+		if !ok || id == "" || source == "" {
+			return nil, fmt.Errorf("%s invalide: %q (attendu kid=source)", key, entry)
+		}
+		if seen[id] {
+			return nil, fmt.Errorf("%s invalide: kid %q en double", key, id)
+		}
+		seen[id] = true
+		keys = append(keys, JWTSigningKey{ID: id, Source: source})
+	}
+
+	for _, entry := range strings.Split(getEnv(retirements, ""), ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		id, date, ok := strings.Cut(entry, "=")
+		id, date = strings.TrimSpace(id), strings.TrimSpace(date)
+		retiresAt, err := time.Parse(time.RFC3339, date)
+		if err != nil {
+			retiresAt, err = time.Parse("2006-01-02", date)
+		}
+		if !ok || err != nil {
+			return nil, fmt.Errorf("%s invalide: %q (attendu kid=date)", retirements, entry)
+		}
+		i := slices.IndexFunc(keys, func(k JWTSigningKey) bool { return k.ID == id })
+		if i < 0 {
+			return nil, fmt.Errorf("%s invalide: kid %q absent de %s", retirements, id, key)
+		}
+		keys[i].RetiresAt = retiresAt
+	}
+	return keys, nil
+}
+
+// parseVaultClusters lit une liste de clusters ("eu=https://vault-eu:8200,us=...")
+// depuis la variable key. Le token d'un cluster est lu dans
+// VAULT_TOKEN_<NOM>, à défaut defaultToken.
+func parseVaultClusters(key, defaultToken string) ([]VaultCluster, error) {
+	var clusters []VaultCluster
+	seen := make(map[string]bool)
+	for _, entry := range strings.Split(getEnv(key, ""), ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, address, ok := strings.Cut(entry, "=")
+		name, address = strings.TrimSpace(name), strings.TrimSpace(address)
+		if !ok || name == "" || address == "" {
+			return nil, fmt.Errorf("%s invalide: %q (attendu nom=adresse)", key, entry)
+		}
+		if name == models.DefaultRegion || seen[name] {
+			return nil, fmt.Errorf("%s invalide: nom %q dupliqué ou réservé", key, name)
+		}
+		seen[name] = true
+		clusters = append(clusters, VaultCluster{
+			Name:    name,
+			Address: address,
+			Token:   getEnv("VAULT_TOKEN_"+strings.ToUpper(name), defaultToken),
+		})
+	}
+	return clusters, nil
+}
@@ -0,0 +1,207 @@
+// filepath: internal/e2e/e2e.go
+
+// Package e2e implémente le chiffrement de bout en bout des secrets. Le
+// client chiffre chaque valeur pour la clé publique X25519 du projet ;
+// le serveur ne stocke que l'enveloppe chiffrée et ne détient jamais la clé
+// privée, il ne peut donc pas lire ces secrets. Seal et Open sont utilisés
+// côté client (SDK, CLI) ; le serveur se contente de ParseEnvelope pour
+// refuser une valeur qui ne serait pas chiffrée pour la clé du projet.
+//
+// Chiffrement : une clé X25519 éphémère par valeur, secret partagé dérivé
+// par HKDF-SHA256 (sel : clé éphémère puis clé du destinataire), puis
+// AES-256-GCM.
+package e2e
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Algorithm identifie le schéma de chiffrement des enveloppes
+const Algorithm = "X25519-HKDF-SHA256-AES256GCM"
+
+// Version est la version du format d'enveloppe
+const Version = 1
+
+var (
+	// ErrInvalidKey indique une clé X25519 mal formée
+	ErrInvalidKey = errors.New("clé de chiffrement de bout en bout invalide")
+	// ErrInvalidEnvelope indique une valeur qui n'est pas une enveloppe chiffrée valide
+	ErrInvalidEnvelope = errors.New("enveloppe chiffrée invalide")
+)
+
+// Envelope est une valeur chiffrée pour la clé publique d'un projet,
+// sérialisée en JSON dans la valeur du secret
+type Envelope struct {
+	Version   int    `json:"v"`
+	Algorithm string `json:"alg"`
+	// KeyID est l'empreinte de la clé publique du destinataire
+	KeyID string `json:"kid"`
+	// EphemeralKey est la clé publique X25519 éphémère (base64)
+	EphemeralKey string `json:"epk"`
+	Nonce        string `json:"nonce"`
+	Ciphertext   string `json:"ct"`
+}
+
+// GenerateKey crée une paire de clés X25519, encodées en base64
+func GenerateKey() (privateKey, publicKey string, err error) {
+	key, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return "", "", err
+	}
+	return base64.StdEncoding.EncodeToString(key.Bytes()),
+		base64.StdEncoding.EncodeToString(key.PublicKey().Bytes()), nil
+}
+
+// KeyID renvoie l'empreinte d'une clé publique (16 premiers octets du SHA-256, en hexadécimal)
+func KeyID(publicKey string) (string, error) {
+	key, err := parsePublicKey(publicKey)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(key.Bytes())
+	return hex.EncodeToString(sum[:16]), nil
+}
+
+// Seal chiffre plaintext pour la clé publique publicKey et renvoie l'enveloppe JSON
+func Seal(publicKey, plaintext string) (string, error) {
+	recipient, err := parsePublicKey(publicKey)
+	if err != nil {
+		return "", err
+	}
+	kid, err := KeyID(publicKey)
+	if err != nil {
+		return "", err
+	}
+
+	ephemeral, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return "", err
+	}
+	shared, err := ephemeral.ECDH(recipient)
+	if err != nil {
+		return "", err
+	}
+	aead, err := newAEAD(shared, ephemeral.PublicKey().Bytes(), recipient.Bytes())
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	envelope := Envelope{
+		Version:      Version,
+		Algorithm:    Algorithm,
+		KeyID:        kid,
+		EphemeralKey: base64.StdEncoding.EncodeToString(ephemeral.PublicKey().Bytes()),
+		Nonce:        base64.StdEncoding.EncodeToString(nonce),
+		Ciphertext:   base64.StdEncoding.EncodeToString(aead.Seal(nil, nonce, []byte(plaintext), []byte(kid))),
+	}
+	data, err := json.Marshal(envelope)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// Open déchiffre une enveloppe avec la clé privée privateKey
+func Open(privateKey, value string) (string, error) {
+	raw, err := base64.StdEncoding.DecodeString(privateKey)
+	if err != nil {
+		return "", ErrInvalidKey
+	}
+	key, err := ecdh.X25519().NewPrivateKey(raw)
+	if err != nil {
+		return "", ErrInvalidKey
+	}
+
+	envelope, err := ParseEnvelope(value)
+	if err != nil {
+		return "", err
+	}
+	ephemeral, err := parsePublicKey(envelope.EphemeralKey)
+	if err != nil {
+		return "", ErrInvalidEnvelope
+	}
+	shared, err := key.ECDH(ephemeral)
+	if err != nil {
+		return "", ErrInvalidEnvelope
+	}
+	aead, err := newAEAD(shared, ephemeral.Bytes(), key.PublicKey().Bytes())
+	if err != nil {
+		return "", err
+	}
+
+	nonce, _ := base64.StdEncoding.DecodeString(envelope.Nonce)
+	ciphertext, _ := base64.StdEncoding.DecodeString(envelope.Ciphertext)
+	plaintext, err := aead.Open(nil, nonce, ciphertext, []byte(envelope.KeyID))
+	if err != nil {
+		return "", fmt.Errorf("%w: déchiffrement impossible", ErrInvalidEnvelope)
+	}
+	return string(plaintext), nil
+}
+
+// ParseEnvelope vérifie la forme d'une enveloppe sans la déchiffrer
+func ParseEnvelope(value string) (*Envelope, error) {
+	var envelope Envelope
+	if err := json.Unmarshal([]byte(value), &envelope); err != nil {
+		return nil, ErrInvalidEnvelope
+	}
+	if envelope.Version != Version || envelope.Algorithm != Algorithm || envelope.KeyID == "" {
+		return nil, fmt.Errorf("%w: version ou algorithme non pris en charge", ErrInvalidEnvelope)
+	}
+	if _, err := parsePublicKey(envelope.EphemeralKey); err != nil {
+		return nil, fmt.Errorf("%w: clé éphémère", ErrInvalidEnvelope)
+	}
+	if nonce, err := base64.StdEncoding.DecodeString(envelope.Nonce); err != nil || len(nonce) != 12 {
+		return nil, fmt.Errorf("%w: nonce", ErrInvalidEnvelope)
+	}
+	// Le texte chiffré contient au moins l'étiquette d'authentification GCM
+	if ciphertext, err := base64.StdEncoding.DecodeString(envelope.Ciphertext); err != nil || len(ciphertext) < 16 {
+		return nil, fmt.Errorf("%w: texte chiffré", ErrInvalidEnvelope)
+	}
+	return &envelope, nil
+}
+
+// ValidatePublicKey vérifie qu'une clé publique X25519 est bien formée
+func ValidatePublicKey(publicKey string) error {
+	_, err := parsePublicKey(publicKey)
+	return err
+}
+
+func parsePublicKey(publicKey string) (*ecdh.PublicKey, error) {
+	raw, err := base64.StdEncoding.DecodeString(publicKey)
+	if err != nil {
+		return nil, ErrInvalidKey
+	}
+	key, err := ecdh.X25519().NewPublicKey(raw)
+	if err != nil {
+		return nil, ErrInvalidKey
+	}
+	return key, nil
+}
+
+// newAEAD dérive la clé AES-256-GCM du secret partagé
+func newAEAD(shared, ephemeralKey, recipientKey []byte) (cipher.AEAD, error) {
+	salt := append(append([]byte{}, ephemeralKey...), recipientKey...)
+	key, err := hkdf.Key(sha256.New, shared, salt, Algorithm, 32)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,46 @@
+// filepath: internal/e2e/e2e_test.go
+
+package e2e
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSealOpen(t *testing.T) {
+	privateKey, publicKey, err := GenerateKey()
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+
+	sealed, err := Seal(publicKey, "postgres://user:pass@db")
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	envelope, err := ParseEnvelope(sealed)
+	if err != nil {
+		t.Fatalf("Expected a valid envelope but got: %v", err)
+	}
+	if kid, _ := KeyID(publicKey); envelope.KeyID != kid {
+		t.Errorf("Expected key id %s, got %s", kid, envelope.KeyID)
+	}
+
+	plaintext, err := Open(privateKey, sealed)
+	if err != nil || plaintext != "postgres://user:pass@db" {
+		t.Errorf("Expected decrypted value, got %q (%v)", plaintext, err)
+	}
+
+	// Une autre clé privée ne déchiffre pas la valeur
+	otherKey, _, _ := GenerateKey()
+	if _, err := Open(otherKey, sealed); !errors.Is(err, ErrInvalidEnvelope) {
+		t.Errorf("Expected ErrInvalidEnvelope, got %v", err)
+	}
+}
+
+func TestParseEnvelopeRejectsPlaintext(t *testing.T) {
+	for _, value := range []string{"", "plaintext", `{"v":1,"alg":"none","kid":"k"}`} {
+		if _, err := ParseEnvelope(value); !errors.Is(err, ErrInvalidEnvelope) {
+			t.Errorf("Expected ErrInvalidEnvelope for %q, got %v", value, err)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+// filepath: internal/events/bus.go
+
+package events
+
+import (
+	"sync"
+	"time"
+)
+
+// Ressources dont les modifications sont publiées sur le Bus
+const (
+	// ResourceOrganization couvre les réglages (région, plan) et les membres
+	ResourceOrganization = "organization"
+	// ResourceProjects couvre la liste des projets et leurs compteurs
+	ResourceProjects = "projects"
+	// ResourceSecrets couvre les métadonnées des secrets
+	ResourceSecrets = "secrets"
+)
+
+// Change signale la modification d'une ressource d'une organisation
+type Change struct {
+	OrganizationID string
+	Resource       string
+	At             time.Time
+}
+
+// Bus diffuse les modifications aux abonnés (invalidation des caches). La
+// diffusion est synchrone et locale au processus : les abonnés doivent
+// rendre la main rapidement.
+type Bus struct {
+	mu          sync.RWMutex
+	subscribers []func(Change)
+}
+
+// NewBus crée un bus sans abonné
+func NewBus() *Bus {
+	return &Bus{}
+}
+
+// Subscribe abonne fn à toutes les modifications publiées ensuite
+func (b *Bus) Subscribe(fn func(Change)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.subscribers = append(b.subscribers, fn)
+}
+
+// Publish diffuse une modification. Un Bus nil ignore les modifications.
+func (b *Bus) Publish(change Change) {
+	if b == nil {
+		return
+	}
+	if change.At.IsZero() {
+		change.At = time.Now()
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for _, fn := range b.subscribers {
+		fn(change)
+	}
+}
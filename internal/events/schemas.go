@@ -0,0 +1,201 @@
+// filepath: internal/events/schemas.go
+
+// Package events définit les types d'événements versionnés livrés aux
+// consommateurs (webhooks) et le catalogue de leurs schémas.
+//
+// Garanties de compatibilité : le contenu (data) d'un type versionné
+// (secret.created.v1) ne perd jamais de champ et aucun champ ne change de
+// type ; seuls des champs peuvent être ajoutés. Un changement incompatible
+// crée une nouvelle version (secret.created.v2) publiée à côté de
+// l'ancienne. Le test TestSchemasAreBackwardCompatible compare le catalogue
+// à testdata/schemas.golden.json.
+package events
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Types d'événements versionnés
+const (
+	SecretCreatedV1   = "secret.created.v1"
+	SecretUpdatedV1   = "secret.updated.v1"
+	SecretDeletedV1   = "secret.deleted.v1"
+	MemberAddedV1     = "member.added.v1"
+	QuotaAlertV1      = "quota.alert.v1"
+	WebhookPingV1     = "webhook.ping.v1"
+	LoginSuspiciousV1 = "login.suspicious.v1"
+
+	OnboardingStepCompletedV1 = "onboarding.step_completed.v1"
+)
+
+// SecretChanged est le contenu des événements secret.*.v1
+type SecretChanged struct {
+	ProjectID   string   `json:"project_id" desc:"Projet des secrets"`
+	Environment string   `json:"environment" desc:"Environnement des secrets"`
+	Names       []string `json:"names" desc:"Noms des secrets (jamais leurs valeurs)"`
+	ActorID     string   `json:"actor_id" desc:"Utilisateur à l'origine du changement"`
+}
+
+// MemberAdded est le contenu de member.added.v1
+type MemberAdded struct {
+	UserID string `json:"user_id" desc:"Nouveau membre"`
+	Email  string `json:"email" desc:"Email du nouveau membre"`
+	Role   string `json:"role" desc:"Rôle dans l'organisation (admin, member, viewer)"`
+}
+
+// QuotaAlert est le contenu de quota.alert.v1
+type QuotaAlert struct {
+	Count   int `json:"count" desc:"Nombre de secrets de l'organisation"`
+	Limit   int `json:"limit" desc:"Nombre de secrets autorisés par le plan"`
+	Percent int `json:"percent" desc:"Pourcentage de la limite atteint (80 puis 100)"`
+}
+
+// WebhookPing est le contenu de webhook.ping.v1
+type WebhookPing struct {
+	WebhookID string `json:"webhook_id" desc:"Webhook testé"`
+	Message   string `json:"message" desc:"Texte libre"`
+}
+
+// SuspiciousLogin est le contenu de login.suspicious.v1
+type SuspiciousLogin struct {
+	UserID     string    `json:"user_id" desc:"Membre qui s'est connecté"`
+	Email      string    `json:"email" desc:"Email du membre"`
+	Anomaly    string    `json:"anomaly" desc:"Anomalie détectée (new_location, impossible_travel)"`
+	IPAddress  string    `json:"ip_address" desc:"Adresse IP de la connexion"`
+	Country    string    `json:"country" desc:"Pays de la connexion (ISO 3166-1 alpha-2)"`
+	City       string    `json:"city,omitempty" desc:"Ville de la connexion"`
+	OccurredAt time.Time `json:"occurred_at" desc:"Date de la connexion"`
+}
+
+// OnboardingStepCompleted est le contenu de onboarding.step_completed.v1
+type OnboardingStepCompleted struct {
+	Step        string    `json:"step" desc:"Étape franchie (voir GET /organizations/{orgID}/onboarding)"`
+	NextStep    string    `json:"next_step" desc:"Prochaine étape de l'auteur, completed à la fin"`
+	CompletedBy string    `json:"completed_by" desc:"Membre qui a franchi l'étape"`
+	CompletedAt time.Time `json:"completed_at" desc:"Date de l'étape"`
+}
+
+// Field décrit un champ du contenu d'un événement
+type Field struct {
+	Name string `json:"name"`
+	// Type est string, integer, boolean, datetime ou array<...>
+	Type        string `json:"type"`
+	Required    bool   `json:"required"`
+	Description string `json:"description"`
+}
+
+// Schema décrit un type d'événement versionné
+type Schema struct {
+	Type        string  `json:"type"`
+	Name        string  `json:"name"`
+	Version     int     `json:"version"`
+	Description string  `json:"description"`
+	Fields      []Field `json:"fields"`
+}
+
+// registry associe chaque type versionné à la structure de son contenu
+var registry = []struct {
+	eventType   string
+	description string
+	data        any
+}{
+	{SecretCreatedV1, "Secrets créés dans un environnement de production", SecretChanged{}},
+	{SecretUpdatedV1, "Secrets modifiés dans un environnement de production", SecretChanged{}},
+	{SecretDeletedV1, "Secrets supprimés d'un environnement de production", SecretChanged{}},
+	{MemberAddedV1, "Nouveau membre dans l'organisation", MemberAdded{}},
+	{QuotaAlertV1, "Nombre de secrets proche de la limite du plan", QuotaAlert{}},
+	{WebhookPingV1, "Événement de test envoyé à la demande d'un administrateur", WebhookPing{}},
+	{LoginSuspiciousV1, "Connexion d'un membre depuis un nouveau lieu ou trop loin de la précédente", SuspiciousLogin{}},
+	{OnboardingStepCompletedV1, "Étape de la prise en main franchie par un membre (emails d'accompagnement)",
+		OnboardingStepCompleted{}},
+}
+
+// Catalog renvoie les schémas de tous les types d'événements, dans l'ordre du registre
+func Catalog() []Schema {
+	schemas := make([]Schema, 0, len(registry))
+	for _, entry := range registry {
+		schemas = append(schemas, newSchema(entry.eventType, entry.description, entry.data))
+	}
+	return schemas
+}
+
+// Lookup renvoie le schéma d'un type d'événement versionné
+func Lookup(eventType string) (Schema, bool) {
+	for _, entry := range registry {
+		if entry.eventType == eventType {
+			return newSchema(entry.eventType, entry.description, entry.data), true
+		}
+	}
+	return Schema{}, false
+}
+
+// Types liste les types d'événements versionnés
+func Types() []string {
+	types := make([]string, 0, len(registry))
+	for _, entry := range registry {
+		types = append(types, entry.eventType)
+	}
+	return types
+}
+
+// newSchema construit le schéma d'un type à partir des champs JSON de la
+// structure de son contenu, ce qui garantit que le catalogue décrit
+// exactement ce qui est livré
+func newSchema(eventType, description string, data any) Schema {
+	name, version := splitType(eventType)
+	schema := Schema{
+		Type:        eventType,
+		Name:        name,
+		Version:     version,
+		Description: description,
+	}
+
+	t := reflect.TypeOf(data)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if tag == "" || tag == "-" {
+			continue
+		}
+		schema.Fields = append(schema.Fields, Field{
+			Name:        tag,
+			Type:        fieldType(f.Type),
+			Required:    !strings.Contains(opts, "omitempty"),
+			Description: f.Tag.Get("desc"),
+		})
+	}
+	return schema
+}
+
+// splitType sépare "secret.created.v1" en "secret.created" et 1
+func splitType(eventType string) (string, int) {
+	i := strings.LastIndex(eventType, ".v")
+	if i < 0 {
+		return eventType, 0
+	}
+	var version int
+	fmt.Sscanf(eventType[i+2:], "%d", &version)
+	return eventType[:i], version
+}
+
+func fieldType(t reflect.Type) string {
+	if t == reflect.TypeOf(time.Time{}) {
+		return "datetime"
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		return "integer"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Slice:
+		return "array<" + fieldType(t.Elem()) + ">"
+	case reflect.Pointer:
+		return fieldType(t.Elem())
+	}
+	return "object"
+}
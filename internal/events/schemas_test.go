@@ -0,0 +1,80 @@
+// filepath: internal/events/schemas_test.go
+
+package events_test
+
+import (
+	"encoding/json"
+	"os"
+	"regexp"
+	"testing"
+
+	"secrets-manager/internal/events"
+)
+
+// TestSchemasAreBackwardCompatible vérifie que les schémas publiés
+// (testdata/schemas.golden.json) restent compatibles : aucun type retiré,
+// aucun champ retiré, retypé ou devenu facultatif. Un nouveau type ou un
+// nouveau champ doit être ajouté au fichier lors de sa publication.
+func TestSchemasAreBackwardCompatible(t *testing.T) {
+	data, err := os.ReadFile("testdata/schemas.golden.json")
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	var published []events.Schema
+	if err := json.Unmarshal(data, &published); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+
+	known := map[string]bool{}
+	for _, golden := range published {
+		known[golden.Type] = true
+
+		current, ok := events.Lookup(golden.Type)
+		if !ok {
+			t.Errorf("Expected published event type %s to remain available", golden.Type)
+			continue
+		}
+		fields := map[string]events.Field{}
+		for _, field := range current.Fields {
+			fields[field.Name] = field
+		}
+		for _, field := range golden.Fields {
+			got, ok := fields[field.Name]
+			switch {
+			case !ok:
+				t.Errorf("Expected field %s of %s to remain available", field.Name, golden.Type)
+			case got.Type != field.Type:
+				t.Errorf("Expected field %s of %s to keep type %s, got %s", field.Name, golden.Type, field.Type, got.Type)
+			case field.Required && !got.Required:
+				t.Errorf("Expected field %s of %s to remain required", field.Name, golden.Type)
+			}
+		}
+		if len(current.Fields) != len(golden.Fields) {
+			t.Errorf("Expected new fields of %s to be added to testdata/schemas.golden.json", golden.Type)
+		}
+	}
+
+	for _, eventType := range events.Types() {
+		if !known[eventType] {
+			t.Errorf("Expected new event type %s to be added to testdata/schemas.golden.json", eventType)
+		}
+	}
+}
+
+func TestEventTypesAreVersioned(t *testing.T) {
+	pattern := regexp.MustCompile(`^[a-z]+(\.[a-z_]+)+\.v[1-9][0-9]*$`)
+
+	for _, schema := range events.Catalog() {
+		if !pattern.MatchString(schema.Type) || schema.Version < 1 {
+			t.Errorf("Expected a versioned event type, got %s (version %d)", schema.Type, schema.Version)
+		}
+		if len(schema.Fields) == 0 {
+			t.Errorf("Expected %s to describe its fields", schema.Type)
+		}
+		for _, field := range schema.Fields {
+			if field.Description == "" {
+				t.Errorf("Expected field %s of %s to be documented", field.Name, schema.Type)
+			}
+		}
+	}
+}
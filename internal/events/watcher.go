@@ -0,0 +1,51 @@
+// filepath: internal/events/watcher.go
+
+package events
+
+import (
+	"sync"
+)
+
+// Watcher permet d'attendre la prochaine modification d'une ressource
+// publiée sur le bus (requêtes en attente longue). Comme le bus, il est
+// local au processus.
+type Watcher struct {
+	mu      sync.Mutex
+	waiting map[string]chan struct{}
+}
+
+// NewWatcher crée un Watcher abonné au bus ; bus nil ne signale aucune modification
+func NewWatcher(bus *Bus) *Watcher {
+	w := &Watcher{waiting: make(map[string]chan struct{})}
+	if bus != nil {
+		bus.Subscribe(w.notify)
+	}
+	return w
+}
+
+// Next renvoie un canal fermé à la prochaine modification de la ressource
+// de l'organisation. Il doit être obtenu avant de lire l'état courant pour
+// ne manquer aucune modification.
+func (w *Watcher) Next(orgID, resource string) <-chan struct{} {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	key := orgID + "/" + resource
+	ch, ok := w.waiting[key]
+	if !ok {
+		ch = make(chan struct{})
+		w.waiting[key] = ch
+	}
+	return ch
+}
+
+func (w *Watcher) notify(change Change) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	key := change.OrganizationID + "/" + change.Resource
+	if ch, ok := w.waiting[key]; ok {
+		close(ch)
+		delete(w.waiting, key)
+	}
+}
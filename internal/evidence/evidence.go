@@ -0,0 +1,168 @@
+// filepath: internal/evidence/evidence.go
+
+// Package evidence assemble les preuves de conformité (SOC 2, ISO 27001)
+// d'une organisation, ou le journal d'audit d'administration de la
+// plateforme, en une archive ZIP signée. L'archive contient un
+// manifest.json listant l'empreinte SHA-256 de chaque fichier et
+// manifest.sig, la signature Ed25519 du manifeste : vérifier la signature
+// avec la clé publique du serveur garantit l'intégrité de toute l'archive.
+package evidence
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Noms réservés dans l'archive
+const (
+	ManifestName  = "manifest.json"
+	SignatureName = "manifest.sig"
+)
+
+// ErrInvalidSignature indique une archive modifiée ou signée par une autre clé
+var ErrInvalidSignature = errors.New("signature de l'archive de preuves invalide")
+
+// File est un fichier de preuves de l'archive
+type File struct {
+	Name    string
+	Content []byte
+}
+
+// Manifest décrit l'archive de preuves
+type Manifest struct {
+	// OrganizationID est vide pour le journal d'administration de la plateforme
+	OrganizationID string    `json:"organization_id,omitempty"`
+	From           time.Time `json:"from"`
+	To             time.Time `json:"to"`
+	GeneratedAt    time.Time `json:"generated_at"`
+	GeneratedBy    string    `json:"generated_by"`
+	// PublicKey est la clé publique Ed25519 (base64) ayant signé le manifeste
+	PublicKey string         `json:"public_key"`
+	Files     []ManifestFile `json:"files"`
+	// Unavailable liste les preuves demandées que le service ne peut pas
+	// encore fournir, avec leur raison
+	Unavailable map[string]string `json:"unavailable,omitempty"`
+}
+
+// ManifestFile est l'empreinte d'un fichier de l'archive
+type ManifestFile struct {
+	Name   string `json:"name"`
+	Size   int    `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// Signer signe les archives de preuves
+type Signer struct {
+	key ed25519.PrivateKey
+}
+
+// NewSigner crée un signataire à partir d'une graine Ed25519 de 32 octets
+// encodée en base64
+func NewSigner(seed string) (*Signer, error) {
+	raw, err := base64.StdEncoding.DecodeString(seed)
+	if err != nil {
+		return nil, fmt.Errorf("clé de signature des preuves invalide: %w", err)
+	}
+	if len(raw) != ed25519.SeedSize {
+		return nil, fmt.Errorf("clé de signature des preuves invalide: %d octets, %d attendus", len(raw), ed25519.SeedSize)
+	}
+	return &Signer{key: ed25519.NewKeyFromSeed(raw)}, nil
+}
+
+// PublicKey renvoie la clé publique de vérification, encodée en base64
+func (s *Signer) PublicKey() string {
+	return base64.StdEncoding.EncodeToString(s.key.Public().(ed25519.PublicKey))
+}
+
+// WriteBundle écrit l'archive signée contenant files dans w. Les empreintes
+// des fichiers et la clé publique sont ajoutées au manifeste.
+func (s *Signer) WriteBundle(w io.Writer, manifest Manifest, files []File) error {
+	manifest.PublicKey = s.PublicKey()
+	manifest.Files = make([]ManifestFile, 0, len(files))
+	for _, file := range files {
+		sum := sha256.Sum256(file.Content)
+		manifest.Files = append(manifest.Files, ManifestFile{
+			Name:   file.Name,
+			Size:   len(file.Content),
+			SHA256: hex.EncodeToString(sum[:]),
+		})
+	}
+
+	manifestJSON, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	signature := base64.StdEncoding.EncodeToString(ed25519.Sign(s.key, manifestJSON))
+
+	archive := zip.NewWriter(w)
+	files = append(files, File{Name: ManifestName, Content: manifestJSON}, File{Name: SignatureName, Content: []byte(signature)})
+	for _, file := range files {
+		entry, err := archive.CreateHeader(&zip.FileHeader{
+			Name:     file.Name,
+			Method:   zip.Deflate,
+			Modified: manifest.GeneratedAt,
+		})
+		if err != nil {
+			return err
+		}
+		if _, err := entry.Write(file.Content); err != nil {
+			return err
+		}
+	}
+	return archive.Close()
+}
+
+// Verify vérifie la signature d'une archive avec la clé publique (base64)
+// et l'empreinte de chacun de ses fichiers, puis renvoie son manifeste
+func Verify(bundle []byte, publicKey string) (*Manifest, error) {
+	key, err := base64.StdEncoding.DecodeString(publicKey)
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("clé publique invalide")
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(bundle), int64(len(bundle)))
+	if err != nil {
+		return nil, err
+	}
+	contents := make(map[string][]byte, len(archive.File))
+	for _, file := range archive.File {
+		rc, err := file.Open()
+		if err != nil {
+			return nil, err
+		}
+		content, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+		contents[file.Name] = content
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(string(contents[SignatureName]))
+	if err != nil || !ed25519.Verify(key, contents[ManifestName], signature) {
+		return nil, ErrInvalidSignature
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(contents[ManifestName], &manifest); err != nil {
+		return nil, err
+	}
+	for _, file := range manifest.Files {
+		content, ok := contents[file.Name]
+		sum := sha256.Sum256(content)
+		if !ok || hex.EncodeToString(sum[:]) != file.SHA256 {
+			return nil, fmt.Errorf("%w: %s modifié", ErrInvalidSignature, file.Name)
+		}
+	}
+
+	return &manifest, nil
+}
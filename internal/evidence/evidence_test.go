@@ -0,0 +1,77 @@
+// filepath: internal/evidence/evidence_test.go
+
+package evidence
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestBundleSignature(t *testing.T) {
+	seed := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{7}, ed25519.SeedSize))
+	signer, err := NewSigner(seed)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+
+	var buf bytes.Buffer
+	manifest := Manifest{OrganizationID: "org1", GeneratedAt: time.Now()}
+	files := []File{{Name: "members.json", Content: []byte(`[{"role":"admin"}]`)}}
+	if err := signer.WriteBundle(&buf, manifest, files); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+
+	verified, err := Verify(buf.Bytes(), signer.PublicKey())
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if len(verified.Files) != 1 || verified.Files[0].Name != "members.json" {
+		t.Errorf("Unexpected manifest files: %+v", verified.Files)
+	}
+
+	// Une autre clé ne valide pas l'archive
+	other, _ := NewSigner(base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{8}, ed25519.SeedSize)))
+	if _, err := Verify(buf.Bytes(), other.PublicKey()); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Expected ErrInvalidSignature with another key, got %v", err)
+	}
+
+	// Un fichier modifié est détecté par son empreinte
+	tampered := rewriteBundle(t, buf.Bytes(), "members.json", []byte(`[{"role":"viewer"}]`))
+	if _, err := Verify(tampered, signer.PublicKey()); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Expected ErrInvalidSignature for a tampered file, got %v", err)
+	}
+
+	if _, err := NewSigner("trop-court"); err == nil {
+		t.Error("Expected error for an invalid seed")
+	}
+}
+
+// rewriteBundle recopie l'archive en remplaçant le contenu d'un fichier
+func rewriteBundle(t *testing.T, bundle []byte, name string, content []byte) []byte {
+	t.Helper()
+
+	archive, err := zip.NewReader(bytes.NewReader(bundle), int64(len(bundle)))
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	var out bytes.Buffer
+	writer := zip.NewWriter(&out)
+	for _, file := range archive.File {
+		rc, _ := file.Open()
+		data, _ := io.ReadAll(rc)
+		rc.Close()
+		if file.Name == name {
+			data = content
+		}
+		entry, _ := writer.Create(file.Name)
+		entry.Write(data)
+	}
+	writer.Close()
+	return out.Bytes()
+}
@@ -0,0 +1,164 @@
+// filepath: internal/geoip/geoip.go
+
+// Package geoip situe les adresses IP des connexions à partir d'une base
+// CSV de réseaux (export des bases GeoLite2 City ou équivalent), chargée en
+// mémoire au démarrage. Chaque ligne associe un réseau CIDR à un pays, une
+// ville et des coordonnées :
+//
+//	network,country,city,latitude,longitude
+//	81.2.69.0/24,GB,London,51.5142,-0.0931
+package geoip
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/netip"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Rayon moyen de la Terre en kilomètres
+const earthRadiusKm = 6371.0
+
+// Location est la position d'une adresse IP
+type Location struct {
+	// Country est le code ISO 3166-1 alpha-2 du pays
+	Country   string  `json:"country"`
+	City      string  `json:"city,omitempty"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// Locator situe une adresse IP
+type Locator interface {
+	// Locate renvoie la position de l'adresse, false si elle est inconnue
+	// (adresse privée, réseau absent de la base)
+	Locate(ip string) (*Location, bool)
+}
+
+// Database est une base de réseaux en mémoire
+type Database struct {
+	// networks est trié du préfixe le plus long au plus court : le premier
+	// réseau qui contient l'adresse est le plus précis
+	networks []network
+}
+
+type network struct {
+	prefix   netip.Prefix
+	location Location
+}
+
+var _ Locator = (*Database)(nil)
+
+// Load charge une base CSV depuis un fichier
+func Load(path string) (*Database, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return Parse(f)
+}
+
+// Parse lit une base CSV. La ligne d'en-tête est facultative.
+func Parse(r io.Reader) (*Database, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 5
+	reader.TrimLeadingSpace = true
+
+	db := &Database{}
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if line == 1 && record[0] == "network" {
+			continue
+		}
+
+		n, err := parseNetwork(record)
+		if err != nil {
+			return nil, fmt.Errorf("ligne %d : %w", line, err)
+		}
+		db.networks = append(db.networks, n)
+	}
+
+	sort.SliceStable(db.networks, func(i, j int) bool {
+		return db.networks[i].prefix.Bits() > db.networks[j].prefix.Bits()
+	})
+	return db, nil
+}
+
+func parseNetwork(record []string) (network, error) {
+	prefix, err := netip.ParsePrefix(record[0])
+	if err != nil {
+		return network{}, fmt.Errorf("réseau invalide %q", record[0])
+	}
+	latitude, err := strconv.ParseFloat(record[3], 64)
+	if err != nil || latitude < -90 || latitude > 90 {
+		return network{}, fmt.Errorf("latitude invalide %q", record[3])
+	}
+	longitude, err := strconv.ParseFloat(record[4], 64)
+	if err != nil || longitude < -180 || longitude > 180 {
+		return network{}, fmt.Errorf("longitude invalide %q", record[4])
+	}
+	if record[1] == "" {
+		return network{}, errors.New("pays requis")
+	}
+
+	return network{
+		prefix: prefix.Masked(),
+		location: Location{
+			Country:   strings.ToUpper(record[1]),
+			City:      record[2],
+			Latitude:  latitude,
+			Longitude: longitude,
+		},
+	}, nil
+}
+
+// Len renvoie le nombre de réseaux de la base
+func (d *Database) Len() int {
+	return len(d.networks)
+}
+
+// Locate renvoie la position du réseau le plus précis contenant l'adresse
+func (d *Database) Locate(ip string) (*Location, bool) {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return nil, false
+	}
+	addr = addr.Unmap()
+
+	for _, n := range d.networks {
+		if n.prefix.Contains(addr) {
+			location := n.location
+			return &location, true
+		}
+	}
+	return nil, false
+}
+
+// Distance renvoie la distance à vol d'oiseau entre deux positions, en
+// kilomètres (formule de haversine)
+func Distance(a, b *Location) float64 {
+	lat1, lat2 := radians(a.Latitude), radians(b.Latitude)
+	dLat := lat2 - lat1
+	dLon := radians(b.Longitude - a.Longitude)
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+func radians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
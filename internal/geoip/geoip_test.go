@@ -0,0 +1,60 @@
+// filepath: internal/geoip/geoip_test.go
+
+package geoip
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+const testDatabase = `network,country,city,latitude,longitude
+81.2.0.0/16,GB,,51.4964,-0.1224
+81.2.69.0/24,gb,London,51.5142,-0.0931
+2001:db8::/32,FR,Paris,48.8566,2.3522
+`
+
+func TestLocate(t *testing.T) {
+	db, err := Parse(strings.NewReader(testDatabase))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		ip      string
+		city    string
+		located bool
+	}{
+		{"81.2.69.160", "London", true},
+		{"81.2.1.1", "", true},
+		{"::ffff:81.2.69.160", "London", true},
+		{"2001:db8::1", "Paris", true},
+		{"10.0.0.1", "", false},
+		{"invalid", "", false},
+	}
+	for _, tt := range tests {
+		location, ok := db.Locate(tt.ip)
+		if ok != tt.located || (ok && location.City != tt.city) {
+			t.Errorf("Expected %s to be located in %q (%v), got %+v", tt.ip, tt.city, tt.located, location)
+		}
+	}
+	if location, _ := db.Locate("81.2.69.160"); location.Country != "GB" {
+		t.Errorf("Expected the country code to be normalized, got %q", location.Country)
+	}
+
+	if _, err := Parse(strings.NewReader("81.2.69.0/24,GB,London,151,0\n")); err == nil {
+		t.Errorf("Expected an invalid latitude to be rejected")
+	}
+}
+
+func TestDistance(t *testing.T) {
+	paris := &Location{Latitude: 48.8566, Longitude: 2.3522}
+	london := &Location{Latitude: 51.5074, Longitude: -0.1278}
+
+	if d := Distance(paris, london); math.Abs(d-344) > 5 {
+		t.Errorf("Expected about 344 km between Paris and London, got %.0f", d)
+	}
+	if d := Distance(paris, paris); d != 0 {
+		t.Errorf("Expected no distance, got %f", d)
+	}
+}
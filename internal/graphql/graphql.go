@@ -0,0 +1,588 @@
+// filepath: internal/graphql/graphql.go
+
+// Package graphql exécute des requêtes GraphQL sur un schéma déclaré en Go.
+//
+// Seul le sous-ensemble utile aux clients du tableau de bord est pris en
+// charge : requêtes (pas de mutations ni d'abonnements), variables, alias,
+// arguments et sélections imbriquées, champ __typename. Les fragments, les
+// directives et l'introspection ne le sont pas.
+//
+// L'exécution se fait niveau par niveau : un champ est résolu en une fois
+// pour tous les objets parents du même niveau (Field.Batch), ce qui évite
+// les requêtes N+1 lorsque la requête imbrique des listes. Loader complète
+// ce regroupement par un cache le temps d'une requête.
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// DefaultMaxDepth est la profondeur maximale de sélection acceptée
+const DefaultMaxDepth = 10
+
+// Type est le type d'un champ : *Scalar, *Object ou *List
+type Type interface {
+	String() string
+}
+
+// Scalar est un type feuille
+type Scalar struct {
+	Name      string
+	serialize func(v any) (any, error)
+}
+
+func (s *Scalar) String() string { return s.Name }
+
+// Object est un type composé de champs
+type Object struct {
+	Name   string
+	Fields Fields
+}
+
+func (o *Object) String() string { return o.Name }
+
+// List est une liste d'éléments d'un même type
+type List struct {
+	OfType Type
+}
+
+func (l *List) String() string { return "[" + l.OfType.String() + "]" }
+
+// ListOf renvoie le type liste de t
+func ListOf(t Type) *List {
+	return &List{OfType: t}
+}
+
+// Fields associe les noms des champs d'un objet à leur définition
+type Fields map[string]*Field
+
+// Field est un champ d'objet. Resolve résout le champ pour un parent ;
+// Batch, s'il est défini, le résout pour tous les parents d'un même niveau
+// et doit renvoyer une valeur par parent, dans le même ordre. Authorize,
+// s'il est défini, est appelé pour chaque parent : une erreur remplace la
+// valeur du champ par null et est ajoutée aux erreurs de la réponse.
+type Field struct {
+	Type      Type
+	Args      map[string]*Argument
+	Resolve   func(ctx context.Context, parent any, args Args) (any, error)
+	Batch     func(ctx context.Context, parents []any, args Args) ([]any, error)
+	Authorize func(ctx context.Context, parent any) error
+}
+
+// Argument décrit un argument de champ
+type Argument struct {
+	Type     *Scalar
+	Required bool
+	Default  any
+}
+
+// Args contient les arguments d'un champ, convertis vers le type Go de
+// leur scalaire (string, int, bool) ; un argument absent vaut nil
+type Args map[string]any
+
+// String renvoie un argument String ou ID ("" s'il est absent)
+func (a Args) String(name string) string {
+	s, _ := a[name].(string)
+	return s
+}
+
+// Int renvoie un argument Int (def s'il est absent)
+func (a Args) Int(name string, def int) int {
+	if n, ok := a[name].(int); ok {
+		return n
+	}
+	return def
+}
+
+// Scalaires pris en charge
+var (
+	String = &Scalar{Name: "String", serialize: serializeString}
+	ID     = &Scalar{Name: "ID", serialize: serializeString}
+	Int    = &Scalar{Name: "Int", serialize: serializeInt}
+	// Boolean accepte bool
+	Boolean = &Scalar{Name: "Boolean", serialize: func(v any) (any, error) {
+		b, ok := v.(bool)
+		if !ok {
+			return nil, fmt.Errorf("booléen attendu, %T reçu", v)
+		}
+		return b, nil
+	}}
+	// DateTime est une date RFC 3339 en UTC
+	DateTime = &Scalar{Name: "DateTime", serialize: func(v any) (any, error) {
+		switch t := v.(type) {
+		case time.Time:
+			return t.UTC().Format(time.RFC3339), nil
+		case *time.Time:
+			return t.UTC().Format(time.RFC3339), nil
+		}
+		return nil, fmt.Errorf("date attendue, %T reçu", v)
+	}}
+)
+
+func serializeString(v any) (any, error) {
+	if s, ok := v.(string); ok {
+		return s, nil
+	}
+	if s, ok := v.(fmt.Stringer); ok {
+		return s.String(), nil
+	}
+	return nil, fmt.Errorf("chaîne attendue, %T reçu", v)
+}
+
+func serializeInt(v any) (any, error) {
+	switch n := v.(type) {
+	case int:
+		return n, nil
+	case int32:
+		return n, nil
+	case int64:
+		return n, nil
+	}
+	return nil, fmt.Errorf("entier attendu, %T reçu", v)
+}
+
+// Schema est un schéma exécutable
+type Schema struct {
+	query    *Object
+	maxDepth int
+}
+
+// NewSchema crée un schéma dont query est le type racine
+func NewSchema(query *Object) *Schema {
+	return &Schema{query: query, maxDepth: DefaultMaxDepth}
+}
+
+// Request est une requête GraphQL reçue en JSON
+type Request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName,omitempty"`
+	Variables     map[string]any `json:"variables,omitempty"`
+}
+
+// Response est la réponse GraphQL. Data vaut nil si la requête est
+// invalide (syntaxe, champ inconnu...) et n'a pas été exécutée.
+type Response struct {
+	Data   any      `json:"data"`
+	Errors []*Error `json:"errors,omitempty"`
+}
+
+// Error est une erreur de requête ou d'exécution d'un champ (Path)
+type Error struct {
+	Message string `json:"message"`
+	Path    []any  `json:"path,omitempty"`
+}
+
+func (e *Error) Error() string { return e.Message }
+
+// Execute analyse, valide et exécute une requête
+func (s *Schema) Execute(ctx context.Context, req Request) *Response {
+	operations, err := parse(req.Query)
+	if err != nil {
+		return &Response{Errors: []*Error{{Message: err.Error()}}}
+	}
+
+	op, err := selectOperation(operations, req.OperationName)
+	if err != nil {
+		return &Response{Errors: []*Error{{Message: err.Error()}}}
+	}
+	if errs := s.validate(s.query, op.selections, 1); len(errs) > 0 {
+		return &Response{Errors: errs}
+	}
+
+	variables := make(map[string]any, len(op.variables))
+	for _, variable := range op.variables {
+		if value, ok := req.Variables[variable.name]; ok {
+			variables[variable.name] = value
+		} else if variable.hasDefault {
+			variables[variable.name] = variable.defaultValue
+		}
+	}
+
+	e := &executor{variables: variables}
+	results := e.executeSelections(ctx, s.query, []any{nil}, op.selections, [][]any{nil})
+	return &Response{Data: results[0], Errors: e.errors}
+}
+
+func selectOperation(operations []*operation, name string) (*operation, error) {
+	if name == "" {
+		if len(operations) > 1 {
+			return nil, errors.New("operationName est requis lorsque la requête contient plusieurs opérations")
+		}
+		return operations[0], nil
+	}
+	for _, op := range operations {
+		if op.name == name {
+			return op, nil
+		}
+	}
+	return nil, fmt.Errorf("opération %q inconnue", name)
+}
+
+// validate vérifie les champs, les arguments, les sous-sélections et la profondeur
+func (s *Schema) validate(obj *Object, selections []*selection, depth int) []*Error {
+	if depth > s.maxDepth {
+		return []*Error{{Message: fmt.Sprintf("profondeur maximale de %d niveaux dépassée", s.maxDepth)}}
+	}
+
+	var errs []*Error
+	for _, sel := range selections {
+		if sel.name == "__typename" {
+			continue
+		}
+		field, ok := obj.Fields[sel.name]
+		if !ok {
+			errs = append(errs, &Error{Message: fmt.Sprintf("champ %s inconnu sur %s", sel.name, obj.Name)})
+			continue
+		}
+		for name := range sel.args {
+			if _, ok := field.Args[name]; !ok {
+				errs = append(errs, &Error{Message: fmt.Sprintf("argument %s inconnu sur %s.%s", name, obj.Name, sel.name)})
+			}
+		}
+
+		child := unwrap(field.Type)
+		switch child := child.(type) {
+		case *Object:
+			if len(sel.selections) == 0 {
+				errs = append(errs, &Error{Message: fmt.Sprintf("sélection requise sur %s.%s (%s)", obj.Name, sel.name, field.Type)})
+				continue
+			}
+			errs = append(errs, s.validate(child, sel.selections, depth+1)...)
+		case *Scalar:
+			if len(sel.selections) > 0 {
+				errs = append(errs, &Error{Message: fmt.Sprintf("%s.%s (%s) n'a pas de sous-champs", obj.Name, sel.name, field.Type)})
+			}
+		}
+	}
+	return errs
+}
+
+// unwrap renvoie le type des éléments d'une liste (éventuellement imbriquée)
+func unwrap(t Type) Type {
+	for {
+		list, ok := t.(*List)
+		if !ok {
+			return t
+		}
+		t = list.OfType
+	}
+}
+
+// executor exécute une opération validée et accumule les erreurs des champs
+type executor struct {
+	variables map[string]any
+	errors    []*Error
+}
+
+func (e *executor) fail(path []any, err error) {
+	e.errors = append(e.errors, &Error{Message: err.Error(), Path: path})
+}
+
+// executeSelections résout les champs sélectionnés pour tous les parents
+// d'un même niveau et renvoie un objet de réponse par parent
+func (e *executor) executeSelections(
+	ctx context.Context,
+	obj *Object,
+	parents []any,
+	selections []*selection,
+	paths [][]any,
+) []any {
+	results := make([]*orderedMap, len(parents))
+	for i := range results {
+		results[i] = &orderedMap{}
+	}
+
+	for _, sel := range selections {
+		key := sel.key()
+		if sel.name == "__typename" {
+			for _, result := range results {
+				result.set(key, obj.Name)
+			}
+			continue
+		}
+		field := obj.Fields[sel.name]
+
+		fieldPaths := make([][]any, len(parents))
+		for i := range parents {
+			fieldPaths[i] = appendPath(paths[i], key)
+			results[i].set(key, nil)
+		}
+
+		args, err := e.coerceArgs(field, sel)
+		if err != nil {
+			for i := range parents {
+				e.fail(fieldPaths[i], err)
+			}
+			continue
+		}
+
+		// Autorisation, parent par parent
+		var allowed []int
+		for i, parent := range parents {
+			if field.Authorize != nil {
+				if err := field.Authorize(ctx, parent); err != nil {
+					e.fail(fieldPaths[i], err)
+					continue
+				}
+			}
+			allowed = append(allowed, i)
+		}
+		if len(allowed) == 0 {
+			continue
+		}
+
+		resolved, ok := e.resolve(ctx, field, parents, allowed, args, fieldPaths)
+		values := make([]any, 0, len(allowed))
+		valuePaths := make([][]any, 0, len(allowed))
+		var indexes []int
+		for j, i := range allowed {
+			if ok[j] {
+				values = append(values, resolved[j])
+				valuePaths = append(valuePaths, fieldPaths[i])
+				indexes = append(indexes, i)
+			}
+		}
+
+		completed := e.completeValues(ctx, field.Type, values, sel.selections, valuePaths)
+		for j, i := range indexes {
+			results[i].set(key, completed[j])
+		}
+	}
+
+	out := make([]any, len(results))
+	for i, result := range results {
+		out[i] = result
+	}
+	return out
+}
+
+// resolve appelle Batch une fois ou Resolve pour chaque parent autorisé.
+// ok indique, pour chaque parent, si la résolution a réussi.
+func (e *executor) resolve(
+	ctx context.Context,
+	field *Field,
+	parents []any,
+	allowed []int,
+	args Args,
+	paths [][]any,
+) (values []any, ok []bool) {
+	values = make([]any, len(allowed))
+	ok = make([]bool, len(allowed))
+
+	if field.Batch != nil {
+		batch := make([]any, len(allowed))
+		for j, i := range allowed {
+			batch[j] = parents[i]
+		}
+		resolved, err := field.Batch(ctx, batch, args)
+		if err == nil && len(resolved) != len(batch) {
+			err = fmt.Errorf("résolution groupée : %d valeurs pour %d parents", len(resolved), len(batch))
+		}
+		if err != nil {
+			for _, i := range allowed {
+				e.fail(paths[i], err)
+			}
+			return values, ok
+		}
+		for j := range resolved {
+			values[j], ok[j] = resolved[j], true
+		}
+		return values, ok
+	}
+
+	for j, i := range allowed {
+		value, err := field.Resolve(ctx, parents[i], args)
+		if err != nil {
+			e.fail(paths[i], err)
+			continue
+		}
+		values[j], ok[j] = value, true
+	}
+	return values, ok
+}
+
+// completeValues convertit les valeurs résolues d'un même champ selon son
+// type. Les objets de toutes les valeurs sont résolus ensemble.
+func (e *executor) completeValues(ctx context.Context, t Type, values []any, selections []*selection, paths [][]any) []any {
+	completed := make([]any, len(values))
+
+	switch t := t.(type) {
+	case *Scalar:
+		for i, value := range values {
+			if isNull(value) {
+				continue
+			}
+			serialized, err := t.serialize(value)
+			if err != nil {
+				e.fail(paths[i], err)
+				continue
+			}
+			completed[i] = serialized
+		}
+
+	case *Object:
+		var objects []any
+		var objectPaths [][]any
+		var indexes []int
+		for i, value := range values {
+			if !isNull(value) {
+				objects = append(objects, value)
+				objectPaths = append(objectPaths, paths[i])
+				indexes = append(indexes, i)
+			}
+		}
+		if len(objects) == 0 {
+			return completed
+		}
+		results := e.executeSelections(ctx, t, objects, selections, objectPaths)
+		for j, i := range indexes {
+			completed[i] = results[j]
+		}
+
+	case *List:
+		// Les éléments de toutes les listes sont complétés ensemble
+		var items []any
+		var itemPaths [][]any
+		bounds := make([][2]int, len(values))
+		for i, value := range values {
+			bounds[i] = [2]int{-1, -1}
+			if isNull(value) {
+				continue
+			}
+			rv := reflect.ValueOf(value)
+			if rv.Kind() != reflect.Slice {
+				e.fail(paths[i], fmt.Errorf("liste attendue, %T reçu", value))
+				continue
+			}
+			start := len(items)
+			for j := 0; j < rv.Len(); j++ {
+				items = append(items, rv.Index(j).Interface())
+				itemPaths = append(itemPaths, appendPath(paths[i], j))
+			}
+			bounds[i] = [2]int{start, len(items)}
+		}
+		completedItems := e.completeValues(ctx, t.OfType, items, selections, itemPaths)
+		for i, b := range bounds {
+			if b[0] >= 0 {
+				completed[i] = completedItems[b[0]:b[1]:b[1]]
+			}
+		}
+	}
+	return completed
+}
+
+// coerceArgs remplace les variables et convertit les arguments du champ
+func (e *executor) coerceArgs(field *Field, sel *selection) (Args, error) {
+	args := make(Args, len(field.Args))
+	for name, arg := range field.Args {
+		raw, present := sel.args[name]
+		if ref, ok := raw.(variableRef); ok {
+			raw, present = e.variables[string(ref)]
+		}
+		if !present || raw == nil {
+			if arg.Required {
+				return nil, fmt.Errorf("argument %s requis", name)
+			}
+			if arg.Default != nil {
+				args[name] = arg.Default
+			}
+			continue
+		}
+		value, err := coerceInput(arg.Type, raw)
+		if err != nil {
+			return nil, fmt.Errorf("argument %s : %w", name, err)
+		}
+		args[name] = value
+	}
+	return args, nil
+}
+
+// coerceInput convertit une valeur littérale ou une variable JSON
+func coerceInput(t *Scalar, raw any) (any, error) {
+	switch t {
+	case String, ID:
+		switch v := raw.(type) {
+		case string:
+			return v, nil
+		case int64:
+			if t == ID {
+				return fmt.Sprint(v), nil
+			}
+		}
+	case Int:
+		switch v := raw.(type) {
+		case int64:
+			return int(v), nil
+		case float64:
+			if v == float64(int(v)) {
+				return int(v), nil
+			}
+		}
+	case Boolean:
+		if v, ok := raw.(bool); ok {
+			return v, nil
+		}
+	}
+	return nil, fmt.Errorf("%s attendu", t.Name)
+}
+
+func appendPath(path []any, element any) []any {
+	extended := make([]any, len(path), len(path)+1)
+	copy(extended, path)
+	return append(extended, element)
+}
+
+// isNull indique si une valeur résolue est nulle (nil ou pointeur nil)
+func isNull(v any) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	return (rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface) && rv.IsNil()
+}
+
+// orderedMap est un objet de réponse dont les champs gardent l'ordre de la requête
+type orderedMap struct {
+	keys   []string
+	values map[string]any
+}
+
+func (m *orderedMap) set(key string, value any) {
+	if m.values == nil {
+		m.values = make(map[string]any)
+	}
+	if _, ok := m.values[key]; !ok {
+		m.keys = append(m.keys, key)
+	}
+	m.values[key] = value
+}
+
+// MarshalJSON encode les champs dans l'ordre de la requête
+func (m *orderedMap) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, key := range m.keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		k, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		v, err := json.Marshal(m.values[key])
+		if err != nil {
+			return nil, err
+		}
+		b.Write(k)
+		b.WriteByte(':')
+		b.Write(v)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
@@ -0,0 +1,190 @@
+// filepath: internal/graphql/graphql_test.go
+
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type testAuthor struct {
+	ID   string
+	Name string
+}
+
+type testPost struct {
+	Title    string
+	AuthorID string
+	Secret   string
+}
+
+// testSchema : posts → author (résolu par lot), secret (refusé pour "private")
+func testSchema(batches *int) *Schema {
+	author := &Object{
+		Name: "Author",
+		Fields: Fields{
+			"id": {Type: ID, Resolve: func(ctx context.Context, parent any, args Args) (any, error) {
+				return parent.(*testAuthor).ID, nil
+			}},
+			"name": {Type: String, Resolve: func(ctx context.Context, parent any, args Args) (any, error) {
+				return parent.(*testAuthor).Name, nil
+			}},
+		},
+	}
+	post := &Object{
+		Name: "Post",
+		Fields: Fields{
+			"title": {Type: String, Resolve: func(ctx context.Context, parent any, args Args) (any, error) {
+				return parent.(*testPost).Title, nil
+			}},
+			"author": {Type: author, Batch: func(ctx context.Context, parents []any, args Args) ([]any, error) {
+				*batches++
+				authors := make([]any, len(parents))
+				for i, parent := range parents {
+					id := parent.(*testPost).AuthorID
+					authors[i] = &testAuthor{ID: id, Name: strings.ToUpper(id)}
+				}
+				return authors, nil
+			}},
+			"secret": {
+				Type: String,
+				Authorize: func(ctx context.Context, parent any) error {
+					if parent.(*testPost).Title == "private" {
+						return errors.New("Accès refusé")
+					}
+					return nil
+				},
+				Resolve: func(ctx context.Context, parent any, args Args) (any, error) {
+					return parent.(*testPost).Secret, nil
+				},
+			},
+		},
+	}
+	return NewSchema(&Object{
+		Name: "Query",
+		Fields: Fields{
+			"posts": {
+				Type: ListOf(post),
+				Args: map[string]*Argument{"first": {Type: Int, Default: 10}},
+				Resolve: func(ctx context.Context, parent any, args Args) (any, error) {
+					posts := []*testPost{
+						{Title: "public", AuthorID: "a", Secret: "s1"},
+						{Title: "private", AuthorID: "b", Secret: "s2"},
+						{Title: "other", AuthorID: "a", Secret: "s3"},
+					}
+					if n := args.Int("first", 10); n < len(posts) {
+						posts = posts[:n]
+					}
+					return posts, nil
+				},
+			},
+		},
+	})
+}
+
+func execute(t *testing.T, schema *Schema, req Request) (string, *Response) {
+	t.Helper()
+	resp := schema.Execute(context.Background(), req)
+	data, err := json.Marshal(resp.Data)
+	if err != nil {
+		t.Fatalf("impossible d'encoder la réponse: %v", err)
+	}
+	return string(data), resp
+}
+
+func TestExecuteBatchesNestedFields(t *testing.T) {
+	batches := 0
+	data, resp := execute(t, testSchema(&batches), Request{
+		Query:     `query Posts($n: Int) { posts(first: $n) { title writer: author { name } } }`,
+		Variables: map[string]any{"n": float64(3)},
+	})
+
+	if len(resp.Errors) > 0 {
+		t.Fatalf("Expected no errors, got %v", resp.Errors[0])
+	}
+	expected := `{"posts":[{"title":"public","writer":{"name":"A"}},` +
+		`{"title":"private","writer":{"name":"B"}},{"title":"other","writer":{"name":"A"}}]}`
+	if data != expected {
+		t.Errorf("Expected %s, got %s", expected, data)
+	}
+	// Un seul appel pour les 3 auteurs
+	if batches != 1 {
+		t.Errorf("Expected 1 batch call, got %d", batches)
+	}
+}
+
+func TestExecuteFieldAuthorization(t *testing.T) {
+	batches := 0
+	data, resp := execute(t, testSchema(&batches), Request{Query: `{ posts(first: 2) { title secret } }`})
+
+	expected := `{"posts":[{"title":"public","secret":"s1"},{"title":"private","secret":null}]}`
+	if data != expected {
+		t.Errorf("Expected %s, got %s", expected, data)
+	}
+	if len(resp.Errors) != 1 {
+		t.Fatalf("Expected 1 error, got %d", len(resp.Errors))
+	}
+	path, _ := json.Marshal(resp.Errors[0].Path)
+	if string(path) != `["posts",1,"secret"]` {
+		t.Errorf("Expected error path [posts 1 secret], got %s", path)
+	}
+}
+
+func TestExecuteRejectsInvalidQueries(t *testing.T) {
+	batches := 0
+	schema := testSchema(&batches)
+
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{"syntax", `{ posts { title }`},
+		{"unknown field", `{ posts { body } }`},
+		{"unknown argument", `{ posts(last: 1) { title } }`},
+		{"missing selection", `{ posts }`},
+		{"selection on scalar", `{ posts { title { length } } }`},
+		{"mutation", `mutation { posts { title } }`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := schema.Execute(context.Background(), Request{Query: tt.query})
+			if resp.Data != nil {
+				t.Errorf("Expected no data for an invalid query, got %v", resp.Data)
+			}
+			if len(resp.Errors) == 0 {
+				t.Errorf("Expected an error for %q", tt.query)
+			}
+		})
+	}
+}
+
+func TestLoaderCachesKeys(t *testing.T) {
+	var fetched [][]string
+	loader := NewLoader(func(ctx context.Context, keys []string) (map[string]int, error) {
+		fetched = append(fetched, keys)
+		values := make(map[string]int, len(keys))
+		for _, key := range keys {
+			values[key] = len(key)
+		}
+		return values, nil
+	})
+
+	ctx := context.Background()
+	if _, err := loader.LoadMany(ctx, []string{"a", "bb", "a"}); err != nil {
+		t.Fatal(err)
+	}
+	values, err := loader.LoadMany(ctx, []string{"bb", "ccc"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(values) != 2 || values[0] != 2 || values[1] != 3 {
+		t.Errorf("Expected [2 3], got %v", values)
+	}
+	if len(fetched) != 2 || len(fetched[0]) != 2 || len(fetched[1]) != 1 {
+		t.Errorf("Expected fetches [[a bb] [ccc]], got %v", fetched)
+	}
+}
@@ -0,0 +1,56 @@
+// filepath: internal/graphql/loader.go
+
+package graphql
+
+import (
+	"context"
+	"sync"
+)
+
+// Loader regroupe et met en cache, le temps d'une requête, le chargement
+// d'objets par clé. Les résolveurs groupés (Field.Batch) appellent LoadMany
+// avec les clés de tous leurs parents : seules les clés absentes du cache
+// sont demandées à fetch, en un seul appel.
+type Loader[K comparable, V any] struct {
+	fetch func(ctx context.Context, keys []K) (map[K]V, error)
+
+	mu    sync.Mutex
+	cache map[K]V
+}
+
+// NewLoader crée un loader. fetch renvoie les valeurs trouvées ; une clé
+// absente du résultat donne la valeur zéro de V.
+func NewLoader[K comparable, V any](fetch func(ctx context.Context, keys []K) (map[K]V, error)) *Loader[K, V] {
+	return &Loader[K, V]{fetch: fetch, cache: make(map[K]V)}
+}
+
+// LoadMany renvoie les valeurs des clés, dans l'ordre des clés
+func (l *Loader[K, V]) LoadMany(ctx context.Context, keys []K) ([]V, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var missing []K
+	seen := make(map[K]bool)
+	for _, key := range keys {
+		if _, ok := l.cache[key]; !ok && !seen[key] {
+			missing = append(missing, key)
+			seen[key] = true
+		}
+	}
+
+	if len(missing) > 0 {
+		found, err := l.fetch(ctx, missing)
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range missing {
+			l.cache[key] = found[key]
+		}
+	}
+
+	values := make([]V, len(keys))
+	for i, key := range keys {
+		values[i] = l.cache[key]
+	}
+	return values, nil
+}
@@ -0,0 +1,486 @@
+// filepath: internal/graphql/parser.go
+
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// operation est une requête analysée
+type operation struct {
+	name       string
+	variables  []*variableDefinition
+	selections []*selection
+}
+
+// variableDefinition déclare une variable d'opération ($days: Int = 7)
+type variableDefinition struct {
+	name         string
+	defaultValue any
+	hasDefault   bool
+}
+
+// selection est un champ demandé, avec son alias, ses arguments et sa sous-sélection
+type selection struct {
+	alias      string
+	name       string
+	args       map[string]any
+	selections []*selection
+}
+
+// key renvoie le nom du champ dans la réponse
+func (s *selection) key() string {
+	if s.alias != "" {
+		return s.alias
+	}
+	return s.name
+}
+
+// variableRef est une référence à une variable dans une valeur d'argument
+type variableRef string
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenPunct
+	tokenName
+	tokenInt
+	tokenFloat
+	tokenString
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	pos   int
+}
+
+// parser analyse le sous-ensemble du langage GraphQL pris en charge :
+// requêtes (query) avec variables, alias, arguments et sélections
+// imbriquées. Les fragments, directives et mutations sont refusés.
+type parser struct {
+	src string
+	pos int
+	tok token
+}
+
+func parse(src string) ([]*operation, error) {
+	p := &parser{src: src}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+
+	var operations []*operation
+	for p.tok.kind != tokenEOF {
+		op, err := p.parseOperation()
+		if err != nil {
+			return nil, err
+		}
+		operations = append(operations, op)
+	}
+	if len(operations) == 0 {
+		return nil, fmt.Errorf("requête vide")
+	}
+	return operations, nil
+}
+
+func (p *parser) parseOperation() (*operation, error) {
+	op := &operation{}
+	if p.tok.kind == tokenName {
+		switch p.tok.value {
+		case "query":
+		case "mutation", "subscription":
+			return nil, p.errorf("opération %s non prise en charge", p.tok.value)
+		case "fragment":
+			return nil, p.errorf("fragments non pris en charge")
+		default:
+			return nil, p.errorf("opération inattendue %q", p.tok.value)
+		}
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		if p.tok.kind == tokenName {
+			op.name = p.tok.value
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+		}
+		if p.isPunct("(") {
+			variables, err := p.parseVariableDefinitions()
+			if err != nil {
+				return nil, err
+			}
+			op.variables = variables
+		}
+	}
+
+	selections, err := p.parseSelectionSet()
+	if err != nil {
+		return nil, err
+	}
+	op.selections = selections
+	return op, nil
+}
+
+func (p *parser) parseVariableDefinitions() ([]*variableDefinition, error) {
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	var variables []*variableDefinition
+	for !p.isPunct(")") {
+		if err := p.expect("$"); err != nil {
+			return nil, err
+		}
+		name, err := p.expectName()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		if err := p.skipType(); err != nil {
+			return nil, err
+		}
+		variable := &variableDefinition{name: name}
+		if p.isPunct("=") {
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+			if variable.defaultValue, err = p.parseValue(true); err != nil {
+				return nil, err
+			}
+			variable.hasDefault = true
+		}
+		variables = append(variables, variable)
+	}
+	return variables, p.next()
+}
+
+// skipType lit un type de variable (Int, [ID!]!...) ; les types des
+// variables ne sont pas vérifiés, les arguments le sont à l'exécution
+func (p *parser) skipType() error {
+	if p.isPunct("[") {
+		if err := p.next(); err != nil {
+			return err
+		}
+		if err := p.skipType(); err != nil {
+			return err
+		}
+		if err := p.expect("]"); err != nil {
+			return err
+		}
+	} else if _, err := p.expectName(); err != nil {
+		return err
+	}
+	if p.isPunct("!") {
+		return p.next()
+	}
+	return nil
+}
+
+func (p *parser) parseSelectionSet() ([]*selection, error) {
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	var selections []*selection
+	for !p.isPunct("}") {
+		if p.isPunct("...") {
+			return nil, p.errorf("fragments non pris en charge")
+		}
+		sel, err := p.parseField()
+		if err != nil {
+			return nil, err
+		}
+		selections = append(selections, sel)
+	}
+	if len(selections) == 0 {
+		return nil, p.errorf("sélection vide")
+	}
+	return selections, p.next()
+}
+
+func (p *parser) parseField() (*selection, error) {
+	name, err := p.expectName()
+	if err != nil {
+		return nil, err
+	}
+	sel := &selection{name: name}
+	if p.isPunct(":") {
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		sel.alias = name
+		if sel.name, err = p.expectName(); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.isPunct("(") {
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		sel.args = make(map[string]any)
+		for !p.isPunct(")") {
+			argName, err := p.expectName()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(":"); err != nil {
+				return nil, err
+			}
+			if sel.args[argName], err = p.parseValue(false); err != nil {
+				return nil, err
+			}
+		}
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.isPunct("@") {
+		return nil, p.errorf("directives non prises en charge")
+	}
+	if p.isPunct("{") {
+		if sel.selections, err = p.parseSelectionSet(); err != nil {
+			return nil, err
+		}
+	}
+	return sel, nil
+}
+
+// parseValue lit une valeur d'argument ; constant interdit les variables
+// (valeurs par défaut)
+func (p *parser) parseValue(constant bool) (any, error) {
+	tok := p.tok
+	switch {
+	case tok.kind == tokenPunct && tok.value == "$":
+		if constant {
+			return nil, p.errorf("variable interdite dans une valeur constante")
+		}
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		name, err := p.expectName()
+		return variableRef(name), err
+	case tok.kind == tokenInt:
+		n, err := strconv.ParseInt(tok.value, 10, 64)
+		if err != nil {
+			return nil, p.errorf("entier invalide %s", tok.value)
+		}
+		return n, p.next()
+	case tok.kind == tokenFloat:
+		f, err := strconv.ParseFloat(tok.value, 64)
+		if err != nil {
+			return nil, p.errorf("nombre invalide %s", tok.value)
+		}
+		return f, p.next()
+	case tok.kind == tokenString:
+		return tok.value, p.next()
+	case tok.kind == tokenName:
+		var value any
+		switch tok.value {
+		case "true":
+			value = true
+		case "false":
+			value = false
+		case "null":
+			value = nil
+		default:
+			// Valeur d'énumération, transmise comme une chaîne
+			value = tok.value
+		}
+		return value, p.next()
+	case tok.kind == tokenPunct && tok.value == "[":
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		list := []any{}
+		for !p.isPunct("]") {
+			item, err := p.parseValue(constant)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, item)
+		}
+		return list, p.next()
+	case tok.kind == tokenPunct && tok.value == "{":
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		object := map[string]any{}
+		for !p.isPunct("}") {
+			name, err := p.expectName()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(":"); err != nil {
+				return nil, err
+			}
+			if object[name], err = p.parseValue(constant); err != nil {
+				return nil, err
+			}
+		}
+		return object, p.next()
+	}
+	return nil, p.errorf("valeur attendue")
+}
+
+func (p *parser) isPunct(value string) bool {
+	return p.tok.kind == tokenPunct && p.tok.value == value
+}
+
+func (p *parser) expect(value string) error {
+	if !p.isPunct(value) {
+		return p.errorf("%q attendu", value)
+	}
+	return p.next()
+}
+
+func (p *parser) expectName() (string, error) {
+	if p.tok.kind != tokenName {
+		return "", p.errorf("nom attendu")
+	}
+	name := p.tok.value
+	return name, p.next()
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	return fmt.Errorf("syntaxe (position %d) : %s", p.tok.pos, fmt.Sprintf(format, args...))
+}
+
+// next lit le jeton suivant
+func (p *parser) next() error {
+	// Blancs, virgules et commentaires sont ignorés
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		if c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == ',' {
+			p.pos++
+		} else if c == '#' {
+			for p.pos < len(p.src) && p.src[p.pos] != '\n' {
+				p.pos++
+			}
+		} else if strings.HasPrefix(p.src[p.pos:], "\uFEFF") {
+			p.pos += len("\uFEFF")
+		} else {
+			break
+		}
+	}
+
+	start := p.pos
+	if p.pos >= len(p.src) {
+		p.tok = token{kind: tokenEOF, pos: start}
+		return nil
+	}
+
+	c := p.src[p.pos]
+	switch {
+	case strings.HasPrefix(p.src[p.pos:], "..."):
+		p.pos += 3
+		p.tok = token{kind: tokenPunct, value: "...", pos: start}
+	case strings.ContainsRune("{}()[]:!$=@", rune(c)):
+		p.pos++
+		p.tok = token{kind: tokenPunct, value: string(c), pos: start}
+	case c == '_' || isLetter(c):
+		for p.pos < len(p.src) && (p.src[p.pos] == '_' || isLetter(p.src[p.pos]) || isDigit(p.src[p.pos])) {
+			p.pos++
+		}
+		p.tok = token{kind: tokenName, value: p.src[start:p.pos], pos: start}
+	case c == '-' || isDigit(c):
+		return p.lexNumber(start)
+	case c == '"':
+		return p.lexString(start)
+	default:
+		r, _ := utf8.DecodeRuneInString(p.src[p.pos:])
+		return fmt.Errorf("syntaxe (position %d) : caractère inattendu %q", start, r)
+	}
+	return nil
+}
+
+func (p *parser) lexNumber(start int) error {
+	kind := tokenInt
+	if p.src[p.pos] == '-' {
+		p.pos++
+	}
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		switch {
+		case isDigit(c):
+		case c == '.' || c == 'e' || c == 'E' || ((c == '+' || c == '-') && kind == tokenFloat):
+			kind = tokenFloat
+		default:
+			p.tok = token{kind: kind, value: p.src[start:p.pos], pos: start}
+			return nil
+		}
+		p.pos++
+	}
+	p.tok = token{kind: kind, value: p.src[start:p.pos], pos: start}
+	return nil
+}
+
+func (p *parser) lexString(start int) error {
+	if strings.HasPrefix(p.src[p.pos:], `"""`) {
+		return fmt.Errorf("syntaxe (position %d) : chaînes multilignes non prises en charge", start)
+	}
+	p.pos++
+
+	var b strings.Builder
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		switch {
+		case c == '"':
+			p.pos++
+			p.tok = token{kind: tokenString, value: b.String(), pos: start}
+			return nil
+		case c == '\n' || c == '\r':
+			return fmt.Errorf("syntaxe (position %d) : chaîne non terminée", start)
+		case c == '\\':
+			if p.pos+1 >= len(p.src) {
+				return fmt.Errorf("syntaxe (position %d) : chaîne non terminée", start)
+			}
+			escape := p.src[p.pos+1]
+			p.pos += 2
+			switch escape {
+			case '"', '\\', '/':
+				b.WriteByte(escape)
+			case 'b':
+				b.WriteByte('\b')
+			case 'f':
+				b.WriteByte('\f')
+			case 'n':
+				b.WriteByte('\n')
+			case 'r':
+				b.WriteByte('\r')
+			case 't':
+				b.WriteByte('\t')
+			case 'u':
+				if p.pos+4 > len(p.src) {
+					return fmt.Errorf("syntaxe (position %d) : échappement unicode invalide", start)
+				}
+				code, err := strconv.ParseUint(p.src[p.pos:p.pos+4], 16, 32)
+				if err != nil {
+					return fmt.Errorf("syntaxe (position %d) : échappement unicode invalide", start)
+				}
+				b.WriteRune(rune(code))
+				p.pos += 4
+			default:
+				return fmt.Errorf("syntaxe (position %d) : échappement invalide \\%c", start, escape)
+			}
+		default:
+			b.WriteByte(c)
+			p.pos++
+		}
+	}
+	return fmt.Errorf("syntaxe (position %d) : chaîne non terminée", start)
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
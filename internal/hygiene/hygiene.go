@@ -0,0 +1,247 @@
+// filepath: internal/hygiene/hygiene.go
+
+// Package hygiene évalue la qualité des valeurs des secrets : longueur,
+// entropie, réutilisation de la même valeur dans plusieurs environnements
+// ou projets et âge. Les mesures sont prises à l'écriture (Measure) et conservées
+// dans les métadonnées ; l'évaluation (Assess, Report) n'a donc jamais
+// besoin de relire les valeurs dans Vault.
+package hygiene
+
+import (
+	"math"
+	"slices"
+	"sort"
+	"time"
+	"unicode/utf8"
+
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/vault"
+)
+
+// Seuils des défauts d'hygiène
+const (
+	// MinLength est la longueur en dessous de laquelle une valeur est trop courte
+	MinLength = 16
+	// MinEntropyBits est l'entropie en dessous de laquelle une valeur est
+	// trop prévisible (une valeur aléatoire de 16 caractères hexadécimaux l'atteint)
+	MinEntropyBits = 60
+	// MaxAge est l'âge au-delà duquel une valeur devrait être renouvelée
+	MaxAge = 90 * 24 * time.Hour
+)
+
+// penalties retire des points au score pour chaque défaut
+var penalties = map[string]int{
+	models.HygieneShort:      25,
+	models.HygieneLowEntropy: 30,
+	models.HygieneReused:     30,
+	models.HygieneShared:     20,
+	models.HygieneStale:      15,
+}
+
+// Measure enregistre dans les métadonnées les mesures de la valeur du
+// secret. La valeur d'un secret chiffré de bout en bout n'est pas mesurée ;
+// sans checksummer, les réutilisations ne sont pas détectées. L'empreinte
+// dépend de l'organisation : les réutilisations ne sont détectées qu'entre
+// ses propres projets.
+func Measure(metadata *models.SecretMetadata, secret *models.Secret, checksummer *vault.Checksummer) {
+	metadata.ValueHash, metadata.ValueLength, metadata.ValueEntropy = "", 0, 0
+	if secret.E2E {
+		return
+	}
+	metadata.ValueLength = utf8.RuneCountInString(secret.Value)
+	metadata.ValueEntropy = EntropyBits(secret.Value)
+	if checksummer != nil {
+		metadata.ValueHash = checksummer.Fingerprint(secret.OrganizationID, secret.Value)
+	}
+}
+
+// EntropyBits renvoie l'entropie de Shannon de la valeur en bits : la
+// fréquence de chaque caractère dans la valeur estime sa probabilité
+func EntropyBits(value string) float64 {
+	counts := make(map[rune]int)
+	length := 0
+	for _, r := range value {
+		counts[r]++
+		length++
+	}
+
+	perRune := 0.0
+	for _, count := range counts {
+		p := float64(count) / float64(length)
+		perRune -= p * math.Log2(p)
+	}
+	return math.Round(perRune*float64(length)*10) / 10
+}
+
+// Assess évalue un secret à la date now. duplicates contient les secrets de
+// l'organisation, tous projets confondus, susceptibles de partager sa valeur
+// (ceux de même empreinte sont retenus). Renvoie nil si la valeur n'a pas
+// été mesurée.
+func Assess(metadata *models.SecretMetadata, duplicates []*models.SecretMetadata, now time.Time) *models.SecretHygiene {
+	if metadata.ValueLength == 0 {
+		return nil
+	}
+
+	hygiene := &models.SecretHygiene{
+		Length:      metadata.ValueLength,
+		EntropyBits: metadata.ValueEntropy,
+		AgeDays:     int(now.Sub(metadata.UpdatedAt) / (24 * time.Hour)),
+		Issues:      []string{},
+	}
+	if metadata.ValueHash != "" {
+		for _, other := range duplicates {
+			if other.ValueHash != metadata.ValueHash {
+				continue
+			}
+			switch {
+			case other.ProjectID != metadata.ProjectID:
+				if !slices.Contains(hygiene.SharedWith, other.ProjectID) {
+					hygiene.SharedWith = append(hygiene.SharedWith, other.ProjectID)
+				}
+			case other.Environment != metadata.Environment:
+				if !slices.Contains(hygiene.ReusedIn, other.Environment) {
+					hygiene.ReusedIn = append(hygiene.ReusedIn, other.Environment)
+				}
+			}
+		}
+		sort.Strings(hygiene.ReusedIn)
+		sort.Strings(hygiene.SharedWith)
+	}
+
+	if hygiene.Length < MinLength {
+		hygiene.Issues = append(hygiene.Issues, models.HygieneShort)
+	}
+	if hygiene.EntropyBits < MinEntropyBits {
+		hygiene.Issues = append(hygiene.Issues, models.HygieneLowEntropy)
+	}
+	if len(hygiene.ReusedIn) > 0 {
+		hygiene.Issues = append(hygiene.Issues, models.HygieneReused)
+	}
+	if len(hygiene.SharedWith) > 0 {
+		hygiene.Issues = append(hygiene.Issues, models.HygieneShared)
+	}
+	if now.Sub(metadata.UpdatedAt) > MaxAge {
+		hygiene.Issues = append(hygiene.Issues, models.HygieneStale)
+	}
+
+	hygiene.Score = 100
+	for _, issue := range hygiene.Issues {
+		hygiene.Score -= penalties[issue]
+	}
+	hygiene.Score = max(hygiene.Score, 0)
+	return hygiene
+}
+
+// Report agrège l'hygiène des secrets d'un projet (tous environnements) à la
+// date now ; duplicates est passé à Assess
+func Report(projectID string, secrets, duplicates []*models.SecretMetadata, now time.Time) *models.HygieneReport {
+	report := &models.HygieneReport{
+		ProjectID: projectID,
+		Score:     100,
+		Issues:    map[string]int{},
+		Secrets:   []*models.HygieneReportEntry{},
+	}
+
+	total := 0
+	for _, metadata := range secrets {
+		hygiene := Assess(metadata, duplicates, now)
+		if hygiene == nil {
+			report.Unassessed++
+			continue
+		}
+		report.Assessed++
+		total += hygiene.Score
+		for _, issue := range hygiene.Issues {
+			report.Issues[issue]++
+		}
+		report.Secrets = append(report.Secrets, &models.HygieneReportEntry{
+			Environment: metadata.Environment,
+			Name:        metadata.Name,
+			Hygiene:     hygiene,
+		})
+	}
+	if report.Assessed > 0 {
+		report.Score = total / report.Assessed
+	}
+
+	sort.SliceStable(report.Secrets, func(i, j int) bool {
+		a, b := report.Secrets[i], report.Secrets[j]
+		if a.Hygiene.Score != b.Hygiene.Score {
+			return a.Hygiene.Score < b.Hygiene.Score
+		}
+		if a.Environment != b.Environment {
+			return a.Environment < b.Environment
+		}
+		return a.Name < b.Name
+	})
+	return report
+}
+
+// ValueHashes renvoie les empreintes distinctes des valeurs des secrets
+func ValueHashes(secrets []*models.SecretMetadata) []string {
+	hashes := []string{}
+	for _, metadata := range secrets {
+		if metadata.ValueHash != "" && !slices.Contains(hashes, metadata.ValueHash) {
+			hashes = append(hashes, metadata.ValueHash)
+		}
+	}
+	return hashes
+}
+
+// Duplicates regroupe par empreinte les secrets d'une organisation qui
+// partagent leur valeur avec un autre secret, dans l'ordre reçu au sein de
+// chaque groupe. Les groupes couvrant le plus de projets, puis de secrets, viennent
+// en premier.
+func Duplicates(orgID string, secrets []*models.SecretMetadata) *models.DuplicatesReport {
+	report := &models.DuplicatesReport{OrganizationID: orgID, Duplicates: []*models.DuplicateValue{}}
+
+	groups := make(map[string]*models.DuplicateValue)
+	projects := make(map[string]map[string]bool)
+	for _, metadata := range secrets {
+		if metadata.ValueHash == "" {
+			continue
+		}
+		group, ok := groups[metadata.ValueHash]
+		if !ok {
+			group = &models.DuplicateValue{}
+			groups[metadata.ValueHash] = group
+			projects[metadata.ValueHash] = make(map[string]bool)
+			report.Duplicates = append(report.Duplicates, group)
+		}
+		group.Secrets = append(group.Secrets, models.SecretLocation{
+			ProjectID:   metadata.ProjectID,
+			Environment: metadata.Environment,
+			Name:        metadata.Name,
+		})
+		projects[metadata.ValueHash][metadata.ProjectID] = true
+	}
+
+	report.Duplicates = slices.DeleteFunc(report.Duplicates, func(group *models.DuplicateValue) bool {
+		return len(group.Secrets) < 2
+	})
+	for hash, group := range groups {
+		group.Projects = len(projects[hash])
+	}
+	for _, group := range report.Duplicates {
+		report.Secrets += len(group.Secrets)
+	}
+
+	sort.SliceStable(report.Duplicates, func(i, j int) bool {
+		a, b := report.Duplicates[i], report.Duplicates[j]
+		if a.Projects != b.Projects {
+			return a.Projects > b.Projects
+		}
+		if len(a.Secrets) != len(b.Secrets) {
+			return len(a.Secrets) > len(b.Secrets)
+		}
+		first, other := a.Secrets[0], b.Secrets[0]
+		if first.ProjectID != other.ProjectID {
+			return first.ProjectID < other.ProjectID
+		}
+		if first.Environment != other.Environment {
+			return first.Environment < other.Environment
+		}
+		return first.Name < other.Name
+	})
+	return report
+}
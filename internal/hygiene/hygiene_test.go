@@ -0,0 +1,93 @@
+// filepath: internal/hygiene/hygiene_test.go
+
+package hygiene
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"secrets-manager/internal/models"
+)
+
+func TestEntropyBits(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected float64
+	}{
+		{"", 0},
+		{"aaaaaaaa", 0},
+		{"abab", 4},
+		{"0123456789abcdef", 64},
+	}
+
+	for _, tt := range tests {
+		if got := EntropyBits(tt.value); got != tt.expected {
+			t.Errorf("Expected %v bits for %q, got %v", tt.expected, tt.value, got)
+		}
+	}
+}
+
+func TestAssess(t *testing.T) {
+	now := time.Now()
+	strong := &models.Secret{OrganizationID: "org", Value: "kX9#vQ2$mL7!pR4&wT8*zN3@"}
+	weak := &models.Secret{OrganizationID: "org", Value: "password"}
+
+	measured := func(project, env string, secret *models.Secret, age time.Duration) *models.SecretMetadata {
+		metadata := &models.SecretMetadata{ProjectID: project, Environment: env, Name: "KEY", UpdatedAt: now.Add(-age)}
+		Measure(metadata, secret, nil)
+		// Sans checksummer, l'empreinte est simulée par la valeur elle-même
+		metadata.ValueHash = secret.Value
+		return metadata
+	}
+	prod := measured("api", "prod", strong, 0)
+	staging := measured("api", "staging", strong, 0)
+	dev := measured("api", "dev", weak, 100*24*time.Hour)
+	project := []*models.SecretMetadata{prod, staging, dev}
+	worker := measured("worker", "prod", strong, 0)
+	duplicates := append(slices.Clone(project), worker)
+
+	hygiene := Assess(dev, duplicates, now)
+	expected := []string{models.HygieneShort, models.HygieneLowEntropy, models.HygieneStale}
+	if !slices.Equal(hygiene.Issues, expected) || hygiene.Score != 30 || hygiene.AgeDays != 100 {
+		t.Errorf("Expected a short, predictable and stale value scoring 30, got %+v", hygiene)
+	}
+	hygiene = Assess(prod, duplicates, now)
+	if !slices.Equal(hygiene.Issues, []string{models.HygieneReused, models.HygieneShared}) ||
+		!slices.Equal(hygiene.ReusedIn, []string{"staging"}) || !slices.Equal(hygiene.SharedWith, []string{"worker"}) {
+		t.Errorf("Expected the prod value to be reused in staging and shared with worker, got %+v", hygiene)
+	}
+	if Assess(&models.SecretMetadata{E2E: true}, duplicates, now) != nil {
+		t.Errorf("Expected no assessment for an unmeasured value")
+	}
+
+	report := Report("api", append(project, &models.SecretMetadata{}), duplicates, now)
+	if report.Assessed != 3 || report.Unassessed != 1 || report.Score != (30+50+50)/3 ||
+		report.Issues[models.HygieneReused] != 2 || report.Secrets[0].Environment != "dev" {
+		t.Errorf("Expected the report to aggregate the assessed secrets worst first, got %+v", report)
+	}
+}
+
+func TestDuplicates(t *testing.T) {
+	secret := func(hash, project, env string) *models.SecretMetadata {
+		return &models.SecretMetadata{ValueHash: hash, ProjectID: project, Environment: env, Name: "KEY"}
+	}
+	report := Duplicates("org", []*models.SecretMetadata{
+		secret("a", "api", "dev"),
+		secret("a", "api", "prod"),
+		secret("b", "api", "prod"),
+		secret("b", "worker", "prod"),
+		secret("c", "api", "qa"),
+		secret("", "api", "e2e"),
+	})
+
+	if report.Secrets != 4 || len(report.Duplicates) != 2 {
+		t.Fatalf("Expected two groups of shared values, got %+v", report)
+	}
+	if group := report.Duplicates[0]; group.Projects != 2 || group.Secrets[1].ProjectID != "worker" {
+		t.Errorf("Expected the value shared across projects to come first, got %+v", group)
+	}
+	if group := report.Duplicates[1]; group.Projects != 1 || group.Secrets[0].Environment != "dev" {
+		t.Errorf("Expected the value reused across environments to come second, got %+v", group)
+	}
+}
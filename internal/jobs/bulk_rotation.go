@@ -0,0 +1,261 @@
+// filepath: internal/jobs/bulk_rotation.go
+
+package jobs
+
+import (
+	"context"
+	"errors"
+	"path"
+	"sort"
+	"time"
+
+	"secrets-manager/internal/events"
+	"secrets-manager/internal/logging"
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/rotation"
+	"secrets-manager/internal/storage"
+	"secrets-manager/internal/vault"
+)
+
+// BulkRotator fait tourner en tâche de fond les secrets d'une rotation
+// groupée, l'un après l'autre. Le résultat de chaque secret est enregistré
+// dès sa rotation : une rotation groupée interrompue (redémarrage, Vault
+// indisponible) reprend aux secrets encore en attente.
+type BulkRotator struct {
+	rotations storage.BulkRotationsRepository
+	rotators  storage.SecretRotatorsRepository
+	projects  storage.ProjectsRepository
+	secrets   storage.SecretsRepository
+	service   *rotation.Service
+	// bus invalide les caches HTTP des secrets ; nil l'ignore
+	bus      *events.Bus
+	interval time.Duration
+	wake     chan struct{}
+}
+
+// NewBulkRotator crée l'exécuteur des rotations groupées. interval est le
+// délai entre deux examens des rotations inachevées.
+func NewBulkRotator(
+	rotations storage.BulkRotationsRepository,
+	rotators storage.SecretRotatorsRepository,
+	projects storage.ProjectsRepository,
+	secrets storage.SecretsRepository,
+	service *rotation.Service,
+	bus *events.Bus,
+	interval time.Duration,
+) *BulkRotator {
+	return &BulkRotator{
+		rotations: rotations,
+		rotators:  rotators,
+		projects:  projects,
+		secrets:   secrets,
+		service:   service,
+		bus:       bus,
+		interval:  interval,
+		wake:      make(chan struct{}, 1),
+	}
+}
+
+// Enqueue fige la liste des secrets de l'organisation qui correspondent au
+// filtre et planifie leur rotation au nom de userID. pattern est un motif
+// path.Match sur « projet/environnement/secret » (vide pour tous), provider
+// restreint la rotation à un fournisseur. Sans filtre de fournisseur, les
+// secrets sans rotateur sont listés comme ignorés.
+func (b *BulkRotator) Enqueue(ctx context.Context, orgID, pattern, provider, userID string) (*models.BulkRotation, error) {
+	projects, err := b.projects.ListOrganizationProjects(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	projectNames := make(map[string]string, len(projects))
+	projectIDs := make([]string, 0, len(projects))
+	for _, project := range projects {
+		projectNames[project.ID] = project.Name
+		projectIDs = append(projectIDs, project.ID)
+	}
+	matches := func(projectID, env, name string) bool {
+		projectName, ok := projectNames[projectID]
+		if !ok {
+			// Projet dans la corbeille
+			return false
+		}
+		if pattern == "" {
+			return true
+		}
+		matched, _ := path.Match(pattern, projectName+"/"+env+"/"+name)
+		return matched
+	}
+
+	rotators, err := b.rotators.ListOrganizationSecretRotators(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	items := []*models.BulkRotationItem{}
+	managed := make(map[string]bool)
+	for _, rotator := range rotators {
+		key := rotator.ProjectID + "/" + rotator.Environment + "/"
+		managed[key+rotator.SecretName] = true
+		// La valeur précédente d'une rotation double est gérée par son rotateur
+		managed[key+models.PreviousSecretName(rotator.SecretName)] = true
+
+		if provider != "" && rotator.Provider != provider {
+			continue
+		}
+		if matches(rotator.ProjectID, rotator.Environment, rotator.SecretName) {
+			items = append(items, &models.BulkRotationItem{
+				ProjectID:   rotator.ProjectID,
+				Environment: rotator.Environment,
+				SecretName:  rotator.SecretName,
+				RotatorID:   rotator.ID,
+				Status:      models.BulkItemPending,
+			})
+		}
+	}
+
+	if provider == "" && len(projectIDs) > 0 {
+		secrets, err := b.secrets.ListSecretsByProjects(ctx, orgID, projectIDs, "")
+		if err != nil {
+			return nil, err
+		}
+		for _, secret := range secrets {
+			if managed[secret.ProjectID+"/"+secret.Environment+"/"+secret.Name] ||
+				!matches(secret.ProjectID, secret.Environment, secret.Name) {
+				continue
+			}
+			items = append(items, &models.BulkRotationItem{
+				ProjectID:   secret.ProjectID,
+				Environment: secret.Environment,
+				SecretName:  secret.Name,
+				Status:      models.BulkItemSkipped,
+				Error:       storage.ErrRotatorNotFound.Error(),
+			})
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		x, y := items[i], items[j]
+		if x.ProjectID != y.ProjectID {
+			return x.ProjectID < y.ProjectID
+		}
+		if x.Environment != y.Environment {
+			return x.Environment < y.Environment
+		}
+		return x.SecretName < y.SecretName
+	})
+
+	bulk := &models.BulkRotation{
+		OrganizationID: orgID,
+		Path:           pattern,
+		Provider:       provider,
+		RequestedBy:    userID,
+		Status:         models.BulkRotationPending,
+		Items:          items,
+	}
+	if err := b.rotations.CreateBulkRotation(ctx, bulk); err != nil {
+		return nil, err
+	}
+
+	b.Wake()
+	return b.rotations.GetBulkRotation(ctx, orgID, bulk.ID)
+}
+
+// Wake déclenche un examen immédiat des rotations groupées inachevées
+func (b *BulkRotator) Wake() {
+	select {
+	case b.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run traite les rotations groupées jusqu'à l'annulation du contexte. Les
+// rotations inachevées sont examinées au démarrage puis toutes les interval.
+func (b *BulkRotator) Run(ctx context.Context) {
+	ticker := time.NewTicker(b.interval)
+	defer ticker.Stop()
+
+	for {
+		RunOnce(ctx, "bulk_rotations", b.processUnfinished)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-b.wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// processUnfinished exécute, l'une après l'autre, les rotations groupées
+// en attente ou interrompues
+func (b *BulkRotator) processUnfinished(ctx context.Context) error {
+	unfinished, err := b.rotations.ListUnfinishedBulkRotations(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, bulk := range unfinished {
+		if err := b.process(ctx, bulk); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// process fait tourner les secrets encore en attente. Une indisponibilité
+// de Vault interrompt la rotation groupée, reprise au prochain examen ; un
+// autre échec est enregistré sur le secret concerné.
+func (b *BulkRotator) process(ctx context.Context, bulk *models.BulkRotation) error {
+	logger := logging.For(logging.ComponentJobs)
+
+	bulk, err := b.rotations.GetBulkRotation(ctx, bulk.OrganizationID, bulk.ID)
+	if err != nil {
+		return err
+	}
+	if bulk.Status == models.BulkRotationPending {
+		if err := b.rotations.SetBulkRotationStatus(ctx, bulk.ID, models.BulkRotationRunning, nil); err != nil {
+			return err
+		}
+	}
+
+	for _, item := range bulk.Items {
+		if item.Status != models.BulkItemPending {
+			continue
+		}
+
+		err := b.rotate(ctx, bulk, item)
+		if err != nil && (ctx.Err() != nil || errors.Is(err, vault.ErrUnavailable)) {
+			return err
+		}
+		now := time.Now()
+		item.CompletedAt = &now
+		item.Status = models.BulkItemRotated
+		item.Error = ""
+		if err != nil {
+			item.Status = models.BulkItemFailed
+			item.Error = err.Error()
+			logger.Warn("échec de la rotation d'un secret",
+				"bulk_rotation", bulk.ID, "organization", bulk.OrganizationID, "secret", item.SecretName, "error", err)
+		}
+		if err := b.rotations.UpdateBulkRotationItem(ctx, item); err != nil {
+			return err
+		}
+	}
+
+	now := time.Now()
+	logger.Info("rotation groupée terminée", "bulk_rotation", bulk.ID, "organization", bulk.OrganizationID)
+	return b.rotations.SetBulkRotationStatus(ctx, bulk.ID, models.BulkRotationCompleted, &now)
+}
+
+// rotate fait tourner un secret avec son rotateur actuel
+func (b *BulkRotator) rotate(ctx context.Context, bulk *models.BulkRotation, item *models.BulkRotationItem) error {
+	rotator, err := b.rotators.GetSecretRotator(ctx, bulk.OrganizationID, item.ProjectID, item.Environment, item.SecretName)
+	if err != nil {
+		return err
+	}
+	if err := b.service.Rotate(ctx, rotator, bulk.RequestedBy); err != nil {
+		return err
+	}
+
+	for _, resource := range []string{events.ResourceSecrets, events.ResourceProjects} {
+		b.bus.Publish(events.Change{OrganizationID: bulk.OrganizationID, Resource: resource})
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+// filepath: internal/jobs/health.go
+
+package jobs
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"secrets-manager/internal/logging"
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// Délai accordé à la vérification de chaque composant
+const healthCheckTimeout = 5 * time.Second
+
+// HealthProbe vérifie un composant ; une erreur le signale indisponible
+type HealthProbe struct {
+	Component string
+	Check     func(ctx context.Context) error
+}
+
+// HealthMonitor vérifie périodiquement les composants du service et en
+// conserve l'historique pour la page de statut : chaque vérification est
+// comptée dans son jour, un échec ouvre un incident et la vérification
+// réussie suivante le résout. Plusieurs instances peuvent vérifier les mêmes
+// composants, un seul incident est ouvert par panne.
+type HealthMonitor struct {
+	health storage.HealthRepository
+	probes []HealthProbe
+}
+
+// NewHealthMonitor crée la tâche de vérification des composants
+func NewHealthMonitor(health storage.HealthRepository, probes ...HealthProbe) *HealthMonitor {
+	return &HealthMonitor{
+		health: health,
+		probes: probes,
+	}
+}
+
+// Check vérifie chaque composant et enregistre le résultat. L'enregistrement
+// passe par la base : si elle est indisponible, seul son propre incident
+// manque à l'historique.
+func (m *HealthMonitor) Check(ctx context.Context) error {
+	logger := logging.For(logging.ComponentJobs)
+
+	var errs []error
+	for _, probe := range m.probes {
+		checkCtx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+		checkErr := probe.Check(checkCtx)
+		cancel()
+		now := time.Now()
+
+		if err := m.health.RecordHealthCheck(ctx, probe.Component, now, checkErr == nil); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if checkErr == nil {
+			resolved, err := m.health.ResolveHealthIncidents(ctx, probe.Component, now)
+			if err != nil {
+				errs = append(errs, err)
+			} else if resolved > 0 {
+				logger.Info("composant rétabli", "component", probe.Component)
+			}
+			continue
+		}
+
+		err := m.health.OpenHealthIncident(ctx, &models.HealthIncident{Component: probe.Component, StartedAt: now})
+		switch {
+		case err == nil:
+			logger.Warn("composant indisponible, incident ouvert", "component", probe.Component, "error", checkErr)
+		case !errors.Is(err, storage.ErrIncidentOpen):
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,252 @@
+// filepath: internal/jobs/organization_deletion.go
+
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"secrets-manager/internal/logging"
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+	"secrets-manager/internal/vault"
+)
+
+// OrganizationDeleter supprime les organisations en tâche de fond, étape par
+// étape. Une organisation supprimée passe d'abord retention dans la corbeille,
+// où elle peut être restaurée, puis elle est purgée. L'avancement est
+// enregistré après chaque étape : une purge interrompue (redémarrage, Vault
+// indisponible) reprend là où elle s'était arrêtée.
+type OrganizationDeleter struct {
+	deletions     storage.OrganizationDeletionsRepository
+	organizations storage.OrganizationsRepository
+	vaultService  *vault.Service
+	retention     time.Duration
+	interval      time.Duration
+	wake          chan struct{}
+
+	// mu sérialise le démarrage d'une purge et la restauration
+	mu sync.Mutex
+}
+
+// NewOrganizationDeleter crée l'exécuteur des suppressions d'organisations.
+// retention est la durée de séjour dans la corbeille, interval le délai entre
+// deux examens des suppressions inachevées.
+func NewOrganizationDeleter(
+	deletions storage.OrganizationDeletionsRepository,
+	organizations storage.OrganizationsRepository,
+	vaultService *vault.Service,
+	retention time.Duration,
+	interval time.Duration,
+) *OrganizationDeleter {
+	return &OrganizationDeleter{
+		deletions:     deletions,
+		organizations: organizations,
+		vaultService:  vaultService,
+		retention:     retention,
+		interval:      interval,
+		wake:          make(chan struct{}, 1),
+	}
+}
+
+// Enqueue place une organisation dans la corbeille et planifie sa purge.
+// Si une suppression est déjà en cours pour cette organisation, elle est
+// renvoyée telle quelle.
+func (d *OrganizationDeleter) Enqueue(ctx context.Context, orgID, userID string) (*models.OrganizationDeletion, error) {
+	unfinished, err := d.deletions.ListUnfinishedOrganizationDeletions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, deletion := range unfinished {
+		if deletion.OrganizationID == orgID {
+			return deletion, nil
+		}
+	}
+
+	deletion := &models.OrganizationDeletion{
+		OrganizationID: orgID,
+		RequestedBy:    userID,
+		Status:         models.DeletionPending,
+		Stage:          models.OrganizationDeletionStages[0],
+		TotalStages:    len(models.OrganizationDeletionStages),
+		PurgeAfter:     time.Now().Add(d.retention),
+	}
+	if err := d.organizations.SuspendOrganization(ctx, orgID); err != nil {
+		return nil, err
+	}
+	if err := d.deletions.CreateOrganizationDeletion(ctx, deletion); err != nil {
+		return nil, err
+	}
+
+	d.Wake()
+	return deletion, nil
+}
+
+// Restore sort une organisation de la corbeille tant que sa purge n'a pas
+// commencé. Renvoie storage.ErrDeletionNotFound s'il n'y a rien à restaurer.
+func (d *OrganizationDeleter) Restore(ctx context.Context, orgID string) (*models.OrganizationDeletion, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	unfinished, err := d.deletions.ListUnfinishedOrganizationDeletions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, deletion := range unfinished {
+		if deletion.OrganizationID != orgID || !deletion.Restorable() {
+			continue
+		}
+
+		if err := d.organizations.RestoreOrganization(ctx, orgID); err != nil {
+			return nil, err
+		}
+		now := time.Now()
+		deletion.Status = models.DeletionRestored
+		deletion.CompletedAt = &now
+		if err := d.deletions.UpdateOrganizationDeletion(ctx, deletion); err != nil {
+			return nil, err
+		}
+		return deletion, nil
+	}
+
+	return nil, storage.ErrDeletionNotFound
+}
+
+// Wake déclenche un examen immédiat des suppressions inachevées
+func (d *OrganizationDeleter) Wake() {
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run traite les suppressions jusqu'à l'annulation du contexte. Les
+// suppressions échues sont examinées au démarrage puis toutes les interval.
+func (d *OrganizationDeleter) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		RunOnce(ctx, "organization_deletions", d.processUnfinished)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-d.wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// processUnfinished exécute, l'une après l'autre, les suppressions échues
+// en attente ou interrompues
+func (d *OrganizationDeleter) processUnfinished(ctx context.Context) error {
+	unfinished, err := d.deletions.ListUnfinishedOrganizationDeletions(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, deletion := range unfinished {
+		if now.Before(deletion.PurgeAfter) {
+			continue
+		}
+		if err := d.process(ctx, deletion.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// process exécute les étapes restantes d'une suppression. Une indisponibilité
+// de Vault laisse la suppression en cours pour la prochaine reprise ; toute
+// autre erreur la marque en échec.
+func (d *OrganizationDeleter) process(ctx context.Context, id string) error {
+	logger := logging.For(logging.ComponentJobs)
+
+	deletion, err := d.start(ctx, id)
+	if err != nil || deletion == nil {
+		return err
+	}
+
+	for i := deletion.CompletedStages; i < len(models.OrganizationDeletionStages); i++ {
+		stage := models.OrganizationDeletionStages[i]
+		deletion.Stage = stage
+
+		if err := d.runStage(ctx, deletion, stage); err != nil {
+			deletion.Error = fmt.Sprintf("étape %s: %v", stage, err)
+			if !errors.Is(err, vault.ErrUnavailable) {
+				deletion.Status = models.DeletionFailed
+			}
+			logger.Warn("échec de la suppression d'organisation",
+				"deletion", deletion.ID, "organization", deletion.OrganizationID, "stage", stage, "error", err)
+			return d.deletions.UpdateOrganizationDeletion(ctx, deletion)
+		}
+
+		deletion.CompletedStages = i + 1
+		if err := d.deletions.UpdateOrganizationDeletion(ctx, deletion); err != nil {
+			return err
+		}
+	}
+
+	now := time.Now()
+	deletion.Status = models.DeletionCompleted
+	deletion.CompletedAt = &now
+	logger.Info("organisation supprimée",
+		"deletion", deletion.ID, "organization", deletion.OrganizationID, "secrets", deletion.SecretsDeleted)
+	return d.deletions.UpdateOrganizationDeletion(ctx, deletion)
+}
+
+// start fait passer une suppression à l'état en cours, sauf si elle a été
+// restaurée entre-temps (renvoie alors nil)
+func (d *OrganizationDeleter) start(ctx context.Context, id string) (*models.OrganizationDeletion, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	deletion, err := d.deletions.GetOrganizationDeletion(ctx, id)
+	if err != nil || deletion.Finished() {
+		return nil, err
+	}
+
+	deletion.Status = models.DeletionRunning
+	deletion.Error = ""
+	if err := d.deletions.UpdateOrganizationDeletion(ctx, deletion); err != nil {
+		return nil, err
+	}
+	return deletion, nil
+}
+
+// runStage exécute une étape de suppression
+func (d *OrganizationDeleter) runStage(ctx context.Context, deletion *models.OrganizationDeletion, stage string) error {
+	if stage == models.DeletionStageVaultSecrets {
+		deleted, err := d.vaultService.DeleteOrganizationSecrets(ctx, deletion.OrganizationID)
+		deletion.SecretsDeleted += deleted
+		return err
+	}
+	return d.organizations.DeleteOrganizationStage(ctx, deletion.OrganizationID, stage)
+}
+
+// PurgeSuspendedProjects renvoie la tâche qui purge définitivement les projets
+// restés plus de retention dans la corbeille : valeurs Vault d'abord, puis
+// métadonnées, environnements et projet.
+func PurgeSuspendedProjects(projects storage.ProjectsRepository, vaultService *vault.Service, retention time.Duration) Func {
+	return func(ctx context.Context) error {
+		expired, err := projects.ListSuspendedProjects(ctx, time.Now().Add(-retention))
+		if err != nil {
+			return err
+		}
+
+		for _, project := range expired {
+			if _, err := vaultService.DeleteProjectSecrets(ctx, project.OrganizationID, project.ID); err != nil {
+				return err
+			}
+			if err := projects.DeleteProject(ctx, project.OrganizationID, project.ID); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
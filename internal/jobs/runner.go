@@ -0,0 +1,85 @@
+// filepath: internal/jobs/runner.go
+
+// Package jobs exécute les tâches périodiques du serveur
+// (rétention, réconciliation, rotations planifiées...).
+package jobs
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"secrets-manager/internal/logging"
+	"secrets-manager/internal/metrics"
+)
+
+var runsTotal = metrics.NewCounter("job_runs_total",
+	"Nombre d'exécutions des tâches périodiques", "job", "result")
+
+// Func est le corps d'une tâche périodique
+type Func func(ctx context.Context) error
+
+type job struct {
+	name     string
+	interval time.Duration
+	run      Func
+}
+
+// Runner exécute des tâches à intervalle régulier jusqu'à l'annulation du contexte
+type Runner struct {
+	jobs []job
+	wg   sync.WaitGroup
+}
+
+// NewRunner crée un exécuteur de tâches vide
+func NewRunner() *Runner {
+	return &Runner{}
+}
+
+// Every enregistre une tâche exécutée toutes les interval (à appeler avant Start)
+func (r *Runner) Every(name string, interval time.Duration, run Func) {
+	r.jobs = append(r.jobs, job{name: name, interval: interval, run: run})
+}
+
+// Start lance toutes les tâches enregistrées. Chaque tâche est exécutée une
+// première fois après un intervalle complet, jamais en parallèle d'elle-même.
+func (r *Runner) Start(ctx context.Context) {
+	for _, j := range r.jobs {
+		r.wg.Add(1)
+		go func(j job) {
+			defer r.wg.Done()
+
+			ticker := time.NewTicker(j.interval)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					RunOnce(ctx, j.name, j.run)
+				}
+			}
+		}(j)
+	}
+}
+
+// Wait attend la fin de toutes les tâches après l'annulation du contexte
+func (r *Runner) Wait() {
+	r.wg.Wait()
+}
+
+// RunOnce exécute une tâche en journalisant et comptabilisant son résultat
+func RunOnce(ctx context.Context, name string, run Func) {
+	logger := logging.For(logging.ComponentJobs)
+	start := time.Now()
+
+	if err := run(ctx); err != nil {
+		runsTotal.Inc(name, "error")
+		logger.Error("échec de la tâche", "job", name, "duration", time.Since(start), "error", err)
+		return
+	}
+
+	runsTotal.Inc(name, "success")
+	logger.Debug("tâche terminée", "job", name, "duration", time.Since(start))
+}
@@ -0,0 +1,165 @@
+// filepath: internal/jobs/scheduled_secrets.go
+
+package jobs
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"secrets-manager/internal/events"
+	"secrets-manager/internal/hygiene"
+	"secrets-manager/internal/logging"
+	"secrets-manager/internal/maintenance"
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/notifications"
+	"secrets-manager/internal/storage"
+	"secrets-manager/internal/vault"
+)
+
+// SecretPromoter applique les changements de valeur planifiés arrivés à
+// échéance : la valeur en attente remplace la valeur lue en une seule
+// écriture Vault, puis la version des métadonnées est incrémentée et le
+// changement publié comme une modification faite par son auteur.
+type SecretPromoter struct {
+	scheduled storage.ScheduledSecretChangesRepository
+	secrets   storage.SecretsRepository
+	// projects désigne les responsables des secrets qui n'en ont pas ; nil
+	// notifie tous les membres
+	projects     storage.ProjectsRepository
+	vaultService *vault.Service
+	// checksummer calcule la somme de contrôle de la nouvelle valeur ; nil les désactive
+	checksummer *vault.Checksummer
+	// notifier prévient les membres des changements en production ; nil les ignore
+	notifier *notifications.Dispatcher
+	// bus invalide les caches HTTP des secrets ; nil l'ignore
+	bus *events.Bus
+	// gate limite les promotions aux fenêtres de maintenance ; nil les autorise toujours
+	gate *maintenance.Gate
+}
+
+// NewSecretPromoter crée la tâche de promotion des valeurs planifiées
+func NewSecretPromoter(
+	scheduled storage.ScheduledSecretChangesRepository,
+	secrets storage.SecretsRepository,
+	projects storage.ProjectsRepository,
+	vaultService *vault.Service,
+	checksummer *vault.Checksummer,
+	notifier *notifications.Dispatcher,
+	bus *events.Bus,
+	gate *maintenance.Gate,
+) *SecretPromoter {
+	return &SecretPromoter{
+		scheduled:    scheduled,
+		secrets:      secrets,
+		projects:     projects,
+		vaultService: vaultService,
+		checksummer:  checksummer,
+		notifier:     notifier,
+		bus:          bus,
+		gate:         gate,
+	}
+}
+
+// PromoteDue promeut les changements arrivés à échéance. Un changement en
+// échec (Vault indisponible, secret verrouillé) est retenté au passage
+// suivant sans bloquer les autres. Dans un environnement protégé, les
+// changements attendent la prochaine fenêtre de maintenance.
+func (p *SecretPromoter) PromoteDue(ctx context.Context) error {
+	now := time.Now()
+	due, err := p.scheduled.ListDueScheduledSecretChanges(ctx, now)
+	if err != nil {
+		return err
+	}
+
+	logger := logging.For(logging.ComponentJobs)
+	for _, change := range due {
+		allowed, err := p.gate.Allowed(ctx, change.OrganizationID, change.Environment, now)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			continue
+		}
+		if err := p.promote(ctx, change, now); err != nil {
+			logger.Warn("promotion d'une valeur planifiée impossible",
+				"change_id", change.ID, "organization_id", change.OrganizationID, "error", err)
+		}
+	}
+	return nil
+}
+
+func (p *SecretPromoter) promote(ctx context.Context, change *models.ScheduledSecretChange, now time.Time) error {
+	// Le changement a pu être annulé ou replanifié depuis la liste
+	change, err := p.scheduled.GetScheduledSecretChange(ctx,
+		change.OrganizationID, change.ProjectID, change.Environment, change.SecretName)
+	if errors.Is(err, storage.ErrScheduleNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if change.EffectiveAt.After(now) {
+		return nil
+	}
+
+	metadata, err := p.secrets.GetSecretMetadataByPath(ctx,
+		change.OrganizationID, change.ProjectID, change.Environment, change.SecretName)
+	if err != nil {
+		return err
+	}
+	staged, err := p.vaultService.GetScheduledValue(ctx,
+		change.OrganizationID, change.ProjectID, change.Environment, change.SecretName)
+	if err != nil && !errors.Is(err, vault.ErrSecretNotFound) {
+		return err
+	}
+	if metadata == nil || staged == nil {
+		// Secret supprimé depuis la planification : le changement est abandonné
+		logging.For(logging.ComponentJobs).Info("valeur planifiée abandonnée, secret supprimé",
+			"change_id", change.ID, "organization_id", change.OrganizationID, "secret", change.SecretName)
+		return p.discard(ctx, change)
+	}
+	if metadata.IsLocked() {
+		return storage.ErrSecretLocked
+	}
+
+	staged.CreatedBy = change.CreatedBy
+	if err := p.vaultService.StoreSecret(ctx, staged); err != nil {
+		return err
+	}
+	metadata.Description = staged.Description
+	metadata.E2E = staged.E2E
+	metadata.Checksum = ""
+	if p.checksummer != nil {
+		metadata.Checksum = p.checksummer.Sum(staged)
+	}
+	hygiene.Measure(metadata, staged, p.checksummer)
+	metadata.Version++
+	if err := p.secrets.UpdateSecretMetadata(ctx, metadata); err != nil {
+		return err
+	}
+	if err := p.discard(ctx, change); err != nil {
+		return err
+	}
+
+	for _, resource := range []string{events.ResourceSecrets, events.ResourceProjects} {
+		p.bus.Publish(events.Change{OrganizationID: change.OrganizationID, Resource: resource})
+	}
+	if notifications.IsProduction(change.Environment) {
+		event := notifications.SecretsChanged(change.OrganizationID, change.ProjectID, change.Environment,
+			notifications.SecretUpdated, change.CreatedBy, change.SecretName)
+		event.Owner = notifications.SecretOwners(ctx, p.projects, change.OrganizationID, change.ProjectID, metadata)
+		p.notifier.Notify(event)
+	}
+	return nil
+}
+
+// discard supprime le changement planifié puis sa valeur en attente
+func (p *SecretPromoter) discard(ctx context.Context, change *models.ScheduledSecretChange) error {
+	err := p.scheduled.DeleteScheduledSecretChange(ctx, change.ID)
+	if err != nil && !errors.Is(err, storage.ErrScheduleNotFound) {
+		return err
+	}
+	return p.vaultService.DeleteScheduledValue(ctx,
+		change.OrganizationID, change.ProjectID, change.Environment, change.SecretName)
+}
@@ -0,0 +1,81 @@
+// filepath: internal/jobs/secret_counts.go
+
+package jobs
+
+import (
+	"context"
+	"errors"
+
+	"secrets-manager/internal/logging"
+	"secrets-manager/internal/metrics"
+	"secrets-manager/internal/storage"
+	"secrets-manager/internal/vault"
+)
+
+// Sources comparées aux métadonnées de secrets lors de la réconciliation
+const (
+	driftSourceUsage = "usage_statistics"
+	driftSourceVault = "vault"
+)
+
+var (
+	secretCountDrift = metrics.NewGauge("secret_count_drift_organizations",
+		"Organisations dont le nombre de secrets diverge des métadonnées lors de la dernière réconciliation", "source")
+	secretCountCorrections = metrics.NewCounter("secret_count_corrections_total",
+		"Compteurs de secrets corrigés par la réconciliation")
+)
+
+// ReconcileSecretCounts renvoie la tâche qui compare, pour chaque organisation,
+// le compteur de usage_statistics au nombre réel de métadonnées de secrets et
+// le corrige en cas d'écart. Le nombre de secrets présents dans Vault est aussi
+// comparé aux métadonnées ; un écart est signalé mais jamais corrigé
+// automatiquement car il demande une analyse (valeur orpheline ou perdue).
+func ReconcileSecretCounts(secrets storage.SecretsRepository, vaultService *vault.Service) Func {
+	return func(ctx context.Context) error {
+		logger := logging.For(logging.ComponentJobs)
+
+		counts, err := secrets.ListSecretCounts(ctx)
+		if err != nil {
+			return err
+		}
+
+		usageDrift, vaultDrift := 0, 0
+		checkVault := vaultService != nil
+		for _, count := range counts {
+			if count.Recorded != count.Actual {
+				usageDrift++
+				logger.Warn("compteur de secrets divergent, correction",
+					"organization_id", count.OrganizationID, "recorded", count.Recorded, "actual", count.Actual)
+				if err := secrets.SetSecretsCount(ctx, count.OrganizationID, count.Actual); err != nil {
+					return err
+				}
+				secretCountCorrections.Inc()
+			}
+
+			if !checkVault {
+				continue
+			}
+			stored, err := vaultService.CountOrganizationSecrets(ctx, count.OrganizationID)
+			if err != nil {
+				if errors.Is(err, vault.ErrUnavailable) {
+					// Ne pas insister pendant une panne : les compteurs restent réconciliés
+					logger.Warn("Vault indisponible, comparaison avec Vault ignorée", "error", err)
+					checkVault = false
+					continue
+				}
+				return err
+			}
+			if stored != count.Actual {
+				vaultDrift++
+				logger.Error("secrets Vault et métadonnées divergents",
+					"organization_id", count.OrganizationID, "vault", stored, "metadata", count.Actual)
+			}
+		}
+
+		secretCountDrift.Set(float64(usageDrift), driftSourceUsage)
+		if checkVault {
+			secretCountDrift.Set(float64(vaultDrift), driftSourceVault)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,64 @@
+// filepath: internal/jobs/secret_counts_test.go
+
+package jobs
+
+import (
+	"context"
+	"testing"
+
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage/memory"
+	"secrets-manager/internal/vault"
+	"secrets-manager/internal/vault/vaulttest"
+)
+
+func TestReconcileSecretCounts(t *testing.T) {
+	ctx := context.Background()
+	db := memory.NewDB()
+	organizations := memory.NewOrganizationsRepository(db)
+	secrets := memory.NewSecretsRepository(db)
+	h := vaulttest.New(t)
+
+	org := &models.Organization{Name: "acme", OwnerID: "owner"}
+	if err := organizations.CreateOrganization(ctx, org); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	for _, name := range []string{"A", "B"} {
+		metadata := &models.SecretMetadata{Name: name, OrganizationID: org.ID, ProjectID: "p", Environment: "dev"}
+		if err := secrets.CreateSecretMetadata(ctx, metadata); err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+	}
+	// Une seule des deux valeurs est présente dans Vault
+	h.Seed(&models.Secret{Name: "A", Value: "1", OrganizationID: org.ID, ProjectID: "p", Environment: "dev"})
+
+	// Un chemin de code a oublié de décrémenter le compteur
+	if err := secrets.SetSecretsCount(ctx, org.ID, 5); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+
+	if err := ReconcileSecretCounts(secrets, h.Service)(ctx); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if count, _ := secrets.GetSecretsCount(ctx, org.ID); count != 2 {
+		t.Errorf("Expected count to be corrected to 2, got %d", count)
+	}
+	if drift := secretCountDrift.Value(driftSourceUsage); drift != 1 {
+		t.Errorf("Expected 1 organization with a usage drift, got %v", drift)
+	}
+	if drift := secretCountDrift.Value(driftSourceVault); drift != 1 {
+		t.Errorf("Expected 1 organization with a Vault drift, got %v", drift)
+	}
+
+	// Vault indisponible : les compteurs sont tout de même réconciliés
+	h.Store.FailOn("list", vault.ErrUnavailable)
+	if err := secrets.SetSecretsCount(ctx, org.ID, 0); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if err := ReconcileSecretCounts(secrets, h.Service)(ctx); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if count, _ := secrets.GetSecretsCount(ctx, org.ID); count != 2 {
+		t.Errorf("Expected count to be corrected to 2, got %d", count)
+	}
+}
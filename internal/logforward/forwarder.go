@@ -0,0 +1,229 @@
+// filepath: internal/logforward/forwarder.go
+
+// Package logforward transfère les événements d'audit de chaque organisation
+// vers les destinations choisies par ses administrateurs, pour alimenter
+// leur propre SIEM :
+//   - https : l'événement en JSON dans le corps d'un POST (token Bearer optionnel)
+//   - s3 : un objet JSON par événement, envoyé par une requête signée AWS
+//     Signature V4 (AWS ou stockage compatible)
+//   - syslog : un message RFC 5424 dont le contenu est l'événement en JSON,
+//     sur TCP (avec ou sans TLS) ou UDP
+//
+// Les événements sont transférés en tâche de fond ; un échec est journalisé
+// et comptabilisé mais pas rejoué. Seules les organisations dont le plan
+// inclut le transfert (models.LogForwardingPlans) sont servies.
+package logforward
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+
+	"secrets-manager/internal/logging"
+	"secrets-manager/internal/metrics"
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/netguard"
+	"secrets-manager/internal/storage"
+)
+
+// queueSize est le nombre d'événements en attente au-delà duquel les
+// nouveaux événements sont abandonnés
+const queueSize = 1024
+
+// sendTimeout borne chaque envoi à une destination
+const sendTimeout = 10 * time.Second
+
+var (
+	logger = logging.For(logging.ComponentJobs)
+
+	forwardsTotal = metrics.NewCounter("audit_log_forwards_total",
+		"Événements d'audit transférés par type de destination et résultat", "kind", "result")
+	forwardsDropped = metrics.NewCounter("audit_log_forwards_dropped_total",
+		"Événements d'audit abandonnés faute de place dans la file de transfert")
+)
+
+// Forwarder transfère les événements d'audit aux destinations de leur organisation
+type Forwarder struct {
+	forwarders    storage.LogForwardersRepository
+	organizations storage.OrganizationsRepository
+	guard         netguard.Guard
+	http          *http.Client
+	queue         chan models.AuditEvent
+}
+
+// NewForwarder crée le transfert des journaux d'audit. guard refuse les
+// destinations internes, à l'enregistrement (CheckDestination) et à chaque
+// connexion, syslog compris. client nil utilise un client protégé par guard
+// avec un délai de 10 secondes ; un client fourni doit l'être aussi
+// (guard.Transport). Les redirections ne sont jamais suivies.
+func NewForwarder(forwarders storage.LogForwardersRepository, organizations storage.OrganizationsRepository,
+	guard netguard.Guard, client *http.Client) *Forwarder {
+	if client == nil {
+		client = guard.Client(sendTimeout)
+	}
+	noRedirect := *client
+	noRedirect.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	return &Forwarder{
+		forwarders:    forwarders,
+		organizations: organizations,
+		guard:         guard,
+		http:          &noRedirect,
+		queue:         make(chan models.AuditEvent, queueSize),
+	}
+}
+
+// CheckDestination renvoie netguard.ErrForbiddenDestination si l'hôte de la
+// destination (ou du point d'accès S3) désigne une adresse interne
+func (f *Forwarder) CheckDestination(ctx context.Context, forwarder *models.LogForwarder) error {
+	var host string
+	switch forwarder.Kind {
+	case models.ForwarderHTTPS:
+		if u, err := url.Parse(forwarder.Destination); err == nil {
+			host = u.Hostname()
+		}
+	case models.ForwarderS3:
+		if u, err := url.Parse(forwarder.Endpoint); err == nil {
+			host = u.Hostname()
+		}
+	case models.ForwarderSyslog:
+		if _, address, _, err := ParseSyslogDestination(forwarder.Destination); err == nil {
+			host, _, _ = net.SplitHostPort(address)
+		}
+	}
+	if host == "" {
+		return nil
+	}
+	return f.guard.CheckHost(ctx, host)
+}
+
+// Forward publie un événement sans attendre son transfert. Un Forwarder nil
+// ignore les événements ; si la file est pleine, l'événement est perdu.
+func (f *Forwarder) Forward(event models.AuditEvent) {
+	if f == nil {
+		return
+	}
+	if event.ID == "" {
+		event.ID = uuid.New().String()
+	}
+
+	select {
+	case f.queue <- event:
+	default:
+		forwardsDropped.Inc()
+		logger.Warn("file de transfert des journaux d'audit pleine, événement abandonné",
+			"organization_id", event.OrganizationID)
+	}
+}
+
+// Run transfère les événements publiés jusqu'à l'annulation du contexte
+func (f *Forwarder) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event := <-f.queue:
+			if err := f.Deliver(ctx, event); err != nil {
+				logger.Error("transfert d'un événement d'audit impossible",
+					"organization_id", event.OrganizationID, "error", err)
+			}
+		}
+	}
+}
+
+// Deliver envoie l'événement à chaque destination de l'organisation dont il
+// passe les filtres. Un échec d'envoi est journalisé et n'empêche pas les
+// autres envois ; l'erreur renvoyée ne concerne que la lecture de la configuration.
+func (f *Forwarder) Deliver(ctx context.Context, event models.AuditEvent) error {
+	forwarders, err := f.forwarders.ListLogForwarders(ctx, event.OrganizationID)
+	if err != nil || len(forwarders) == 0 {
+		return err
+	}
+	// Une organisation passée à un plan sans transfert garde sa configuration
+	// mais n'est plus servie
+	planID, err := f.organizations.GetOrganizationPlan(ctx, event.OrganizationID)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(models.LogForwardingPlans, planID) {
+		return nil
+	}
+
+	for _, forwarder := range forwarders {
+		if !forwarder.Matches(&event) {
+			continue
+		}
+		if err := f.Send(ctx, forwarder, &event); err != nil {
+			logger.Warn("transfert d'un événement d'audit échoué",
+				"organization_id", event.OrganizationID, "forwarder_id", forwarder.ID, "kind", forwarder.Kind,
+				"error", err)
+		}
+	}
+	return nil
+}
+
+// Send envoie un événement à une destination, sans appliquer ses filtres
+func (f *Forwarder) Send(ctx context.Context, forwarder *models.LogForwarder, event *models.AuditEvent) error {
+	ctx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+
+	body, err := json.Marshal(event)
+	if err == nil {
+		switch forwarder.Kind {
+		case models.ForwarderHTTPS:
+			err = f.post(ctx, forwarder, body)
+		case models.ForwarderS3:
+			err = f.putObject(ctx, forwarder, event, body)
+		case models.ForwarderSyslog:
+			err = sendSyslog(ctx, f.guard, forwarder, event, body)
+		default:
+			err = fmt.Errorf("type de destination inconnu : %s", forwarder.Kind)
+		}
+	}
+
+	result := "ok"
+	if err != nil {
+		result = "error"
+	}
+	forwardsTotal.Inc(forwarder.Kind, result)
+	return err
+}
+
+// post envoie l'événement au point de réception HTTPS
+func (f *Forwarder) post(ctx context.Context, forwarder *models.LogForwarder, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, forwarder.Destination, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if forwarder.Secret != "" {
+		req.Header.Set("Authorization", "Bearer "+forwarder.Secret)
+	}
+	return f.do(req)
+}
+
+// do exécute la requête et exige une réponse 2xx
+func (f *Forwarder) do(req *http.Request) error {
+	req.Header.Set("User-Agent", "secrets-manager-audit")
+	resp, err := f.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("statut %d", resp.StatusCode)
+	}
+	return nil
+}
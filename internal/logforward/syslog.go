@@ -0,0 +1,94 @@
+// filepath: internal/logforward/syslog.go
+
+package logforward
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/netguard"
+)
+
+// Priorités syslog (facility 13, « log audit ») : information pour une
+// requête réussie, avertissement pour une requête refusée ou en échec
+const (
+	syslogPriorityInfo    = 13*8 + 6
+	syslogPriorityWarning = 13*8 + 4
+)
+
+// Format des horodatages (RFC 5424 : six décimales au plus)
+const syslogTimestamp = "2006-01-02T15:04:05.000000Z07:00"
+
+// Nom d'hôte annoncé dans les messages syslog
+var syslogHostname = func() string {
+	if hostname, err := os.Hostname(); err == nil && hostname != "" {
+		return hostname
+	}
+	return "-"
+}()
+
+// ParseSyslogDestination décompose une destination tcp+tls://, tcp:// ou
+// udp://hôte:port en réseau et adresse
+func ParseSyslogDestination(destination string) (network, address string, useTLS bool, err error) {
+	u, err := url.Parse(destination)
+	if err == nil && u.Port() != "" && u.Hostname() != "" {
+		switch u.Scheme {
+		case "tcp+tls":
+			return "tcp", u.Host, true, nil
+		case "tcp", "udp":
+			return u.Scheme, u.Host, false, nil
+		}
+	}
+	return "", "", false, fmt.Errorf("destination syslog invalide (tcp+tls://, tcp:// ou udp://hôte:port attendu)")
+}
+
+// sendSyslog envoie l'événement dans un message RFC 5424, par une connexion
+// que guard refuse vers les adresses internes. Sur TCP, le message est
+// préfixé de sa longueur (RFC 6587, octet counting).
+func sendSyslog(ctx context.Context, guard netguard.Guard, forwarder *models.LogForwarder, event *models.AuditEvent,
+	body []byte) error {
+	network, address, useTLS, err := ParseSyslogDestination(forwarder.Destination)
+	if err != nil {
+		return err
+	}
+
+	var conn net.Conn
+	dialer := guard.Dialer(sendTimeout)
+	if useTLS {
+		tlsDialer := &tls.Dialer{NetDialer: dialer, Config: &tls.Config{MinVersion: tls.VersionTLS12}}
+		conn, err = tlsDialer.DialContext(ctx, network, address)
+	} else {
+		conn, err = dialer.DialContext(ctx, network, address)
+	}
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	message := formatSyslog(event, body)
+	if network == "tcp" {
+		message = strconv.Itoa(len(message)) + " " + message
+	}
+	_, err = conn.Write([]byte(message))
+	return err
+}
+
+// formatSyslog construit le message :
+// <PRI>1 HORODATAGE HÔTE secrets-manager - audit - CORPS
+func formatSyslog(event *models.AuditEvent, body []byte) string {
+	priority := syslogPriorityInfo
+	if event.StatusCode >= 400 {
+		priority = syslogPriorityWarning
+	}
+	return fmt.Sprintf("<%d>1 %s %s secrets-manager - audit - %s", priority,
+		event.Timestamp.UTC().Format(syslogTimestamp), syslogHostname, body)
+}
@@ -0,0 +1,36 @@
+// filepath: internal/logging/context.go
+
+package logging
+
+import (
+	"context"
+	"log/slog"
+)
+
+type contextKey struct{}
+
+// WithAttrs ajoute des attributs de log (route, organisation...) au contexte.
+// Les couches basses (Vault, SQL) les reprennent dans leurs propres logs.
+func WithAttrs(ctx context.Context, attrs ...slog.Attr) context.Context {
+	existing := AttrsFromContext(ctx)
+	merged := make([]slog.Attr, 0, len(existing)+len(attrs))
+	merged = append(merged, existing...)
+	merged = append(merged, attrs...)
+	return context.WithValue(ctx, contextKey{}, merged)
+}
+
+// AttrsFromContext renvoie les attributs de log portés par le contexte
+func AttrsFromContext(ctx context.Context) []slog.Attr {
+	attrs, _ := ctx.Value(contextKey{}).([]slog.Attr)
+	return attrs
+}
+
+// ArgsFromContext renvoie les attributs du contexte sous forme d'arguments slog
+func ArgsFromContext(ctx context.Context) []any {
+	attrs := AttrsFromContext(ctx)
+	args := make([]any, len(attrs))
+	for i, attr := range attrs {
+		args[i] = attr
+	}
+	return args
+}
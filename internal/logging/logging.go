@@ -0,0 +1,120 @@
+// filepath: internal/logging/logging.go
+
+// Package logging fournit des loggers structurés par composant
+// dont le niveau peut être modifié à chaud.
+package logging
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Composants connus de l'application
+const (
+	ComponentHTTP    = "http"
+	ComponentVault   = "vault"
+	ComponentStorage = "storage"
+	ComponentJobs    = "jobs"
+)
+
+var (
+	mu           sync.Mutex
+	defaultLevel = slog.LevelInfo
+	components   = map[string]*component{}
+)
+
+type component struct {
+	level  *slog.LevelVar
+	logger *slog.Logger
+}
+
+// Init définit le niveau initial de tous les composants
+func Init(level slog.Level) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	defaultLevel = level
+	for _, c := range components {
+		c.level.Set(level)
+	}
+}
+
+// For renvoie le logger d'un composant, créé au premier appel
+func For(name string) *slog.Logger {
+	mu.Lock()
+	defer mu.Unlock()
+
+	return get(name).logger
+}
+
+// SetLevel modifie le niveau d'un composant à chaud
+func SetLevel(name string, level slog.Level) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	get(name).level.Set(level)
+}
+
+// Levels renvoie le niveau courant de chaque composant
+func Levels() map[string]string {
+	mu.Lock()
+	defer mu.Unlock()
+
+	levels := make(map[string]string, len(components))
+	for name, c := range components {
+		levels[name] = strings.ToLower(c.level.Level().String())
+	}
+	return levels
+}
+
+// Components renvoie la liste triée des composants connus
+func Components() []string {
+	mu.Lock()
+	defer mu.Unlock()
+
+	names := make([]string, 0, len(components))
+	for name := range components {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ParseLevel convertit un niveau textuel (debug, info, warn, error)
+func ParseLevel(s string) (slog.Level, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(s)); err != nil {
+		return 0, fmt.Errorf("niveau de log invalide: %s", s)
+	}
+	return level, nil
+}
+
+// get doit être appelée avec mu verrouillé
+func get(name string) *component {
+	if c, ok := components[name]; ok {
+		return c
+	}
+
+	level := &slog.LevelVar{}
+	level.Set(defaultLevel)
+	handler := slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level})
+
+	c := &component{
+		level:  level,
+		logger: slog.New(handler).With("component", name),
+	}
+	components[name] = c
+	return c
+}
+
+func init() {
+	// Déclarer les composants connus pour qu'ils soient réglables
+	// avant d'avoir journalisé quoi que ce soit
+	for _, name := range []string{ComponentHTTP, ComponentVault, ComponentStorage, ComponentJobs} {
+		get(name)
+	}
+}
@@ -0,0 +1,166 @@
+// filepath: internal/loginalerts/monitor.go
+
+// Package loginalerts surveille les connexions par mot de passe : chacune
+// est située d'après son adresse IP et comparée aux précédentes de
+// l'utilisateur. Un déplacement impossible ou une première connexion depuis
+// une ville est notifié aux organisations de l'utilisateur selon leur
+// politique, qui peut aussi exiger un code TOTP supplémentaire.
+package loginalerts
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"secrets-manager/internal/auth"
+	"secrets-manager/internal/geoip"
+	"secrets-manager/internal/logging"
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/notifications"
+	"secrets-manager/internal/storage"
+)
+
+const (
+	// Au-delà de maxTravelSpeed (km/h, plus rapide qu'un avion de ligne),
+	// le déplacement depuis la connexion précédente est jugé impossible
+	maxTravelSpeed = 1000.0
+	// En deçà de minTravelDistance (km), l'imprécision de la base et les
+	// adresses des opérateurs mobiles ne permettent pas de conclure
+	minTravelDistance = 500.0
+)
+
+var logger = logging.For(logging.ComponentHTTP)
+
+// Monitor analyse les connexions et déclenche les alertes
+type Monitor struct {
+	locator  geoip.Locator
+	events   storage.LoginEventsRepository
+	policies storage.LoginAlertPoliciesRepository
+	users    storage.UsersRepository
+	// notifier prévient les membres des connexions inhabituelles ; nil les ignore
+	notifier *notifications.Dispatcher
+}
+
+var _ auth.LoginObserver = (*Monitor)(nil)
+
+// NewMonitor crée le moniteur de connexions. Avec un locator nil, les
+// connexions sont enregistrées sans être situées ni analysées.
+func NewMonitor(
+	locator geoip.Locator,
+	events storage.LoginEventsRepository,
+	policies storage.LoginAlertPoliciesRepository,
+	users storage.UsersRepository,
+	notifier *notifications.Dispatcher,
+) *Monitor {
+	return &Monitor{
+		locator:  locator,
+		events:   events,
+		policies: policies,
+		users:    users,
+		notifier: notifier,
+	}
+}
+
+// ObserveLogin enregistre la connexion de l'utilisateur et renvoie vrai si
+// une de ses organisations exige un code TOTP pour l'anomalie détectée.
+// Une erreur est journalisée sans bloquer la connexion.
+func (m *Monitor) ObserveLogin(ctx context.Context, userID string, device *auth.Device) bool {
+	event := &models.LoginEvent{
+		UserID:     userID,
+		OccurredAt: time.Now().UTC().Truncate(time.Second),
+	}
+	if device != nil {
+		event.IPAddress = device.IPAddress
+		event.UserAgent = device.UserAgent
+	}
+	if m.locator != nil {
+		if location, ok := m.locator.Locate(event.IPAddress); ok {
+			event.Country = location.Country
+			event.City = location.City
+			event.Latitude = location.Latitude
+			event.Longitude = location.Longitude
+		}
+	}
+
+	if event.Located() {
+		anomaly, err := m.detect(ctx, event)
+		if err != nil {
+			logger.Error("analyse de la connexion impossible", "user_id", userID, "error", err)
+		}
+		event.Anomaly = anomaly
+	}
+	if event.Anomaly != "" {
+		event.StepUp = m.alert(ctx, event)
+	}
+
+	if err := m.events.RecordLoginEvent(ctx, event); err != nil {
+		logger.Error("enregistrement de la connexion impossible", "user_id", userID, "error", err)
+	}
+	return event.StepUp
+}
+
+// detect compare une connexion située aux précédentes de l'utilisateur.
+// La première connexion située ne sert que de référence.
+func (m *Monitor) detect(ctx context.Context, event *models.LoginEvent) (string, error) {
+	previous, err := m.events.LastLocatedLoginEvent(ctx, event.UserID)
+	if errors.Is(err, storage.ErrNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	distance := geoip.Distance(
+		&geoip.Location{Latitude: previous.Latitude, Longitude: previous.Longitude},
+		&geoip.Location{Latitude: event.Latitude, Longitude: event.Longitude},
+	)
+	if distance > minTravelDistance {
+		hours := event.OccurredAt.Sub(previous.OccurredAt).Hours()
+		if hours <= 0 || distance/hours > maxTravelSpeed {
+			return models.LoginImpossibleTravel, nil
+		}
+	}
+
+	known, err := m.events.LoginLocationKnown(ctx, event.UserID, event.Country, event.City)
+	if err != nil {
+		return "", err
+	}
+	if !known {
+		return models.LoginNewLocation, nil
+	}
+	return "", nil
+}
+
+// alert notifie l'anomalie aux organisations de l'utilisateur dont la
+// politique le demande, et indique si l'une d'elles exige un code TOTP
+func (m *Monitor) alert(ctx context.Context, event *models.LoginEvent) bool {
+	user, err := m.users.GetUserByID(ctx, event.UserID)
+	if err != nil {
+		logger.Error("alerte de connexion impossible", "user_id", event.UserID, "error", err)
+		return false
+	}
+	organizations, err := m.users.GetUserOrganizations(ctx, event.UserID)
+	if err != nil {
+		logger.Error("alerte de connexion impossible", "user_id", event.UserID, "error", err)
+		return false
+	}
+
+	stepUp := false
+	for _, org := range organizations {
+		policy, err := m.policies.GetLoginAlertPolicy(ctx, org.ID)
+		if errors.Is(err, storage.ErrNotFound) {
+			policy = models.DefaultLoginAlertPolicy(org.ID)
+		} else if err != nil {
+			logger.Error("politique d'alerte de connexion illisible",
+				"organization_id", org.ID, "error", err)
+			continue
+		}
+		if policy.Notifies(event.Anomaly) {
+			m.notifier.Notify(notifications.SuspiciousLogin(org.ID, user.Email, event))
+		}
+		stepUp = stepUp || policy.StepUp
+	}
+	logger.Warn("connexion inhabituelle", "user_id", event.UserID, "anomaly", event.Anomaly,
+		"country", event.Country, "city", event.City, "step_up", stepUp)
+	return stepUp
+}
@@ -0,0 +1,82 @@
+// filepath: internal/loginalerts/monitor_test.go
+
+package loginalerts
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"secrets-manager/internal/auth"
+	"secrets-manager/internal/geoip"
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage/memory"
+)
+
+const testDatabase = `network,country,city,latitude,longitude
+192.0.2.0/24,FR,Paris,48.8566,2.3522
+198.51.100.0/24,FR,Lyon,45.7640,4.8357
+203.0.113.0/24,US,New York,40.7128,-74.0060
+`
+
+func TestObserveLogin(t *testing.T) {
+	ctx := context.Background()
+	db := memory.NewDB()
+	users := memory.NewUsersRepository(db)
+	organizations := memory.NewOrganizationsRepository(db)
+	events := memory.NewLoginEventsRepository(db)
+	policies := memory.NewLoginAlertPoliciesRepository(db)
+
+	user := &models.User{Email: "dev@example.com"}
+	if err := users.CreateUser(ctx, user); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	org := &models.Organization{Name: "acme", OwnerID: user.ID}
+	if err := organizations.CreateOrganization(ctx, org); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	locator, err := geoip.Parse(strings.NewReader(testDatabase))
+	if err != nil {
+		t.Fatal(err)
+	}
+	monitor := NewMonitor(locator, events, policies, users, nil)
+
+	tests := []struct {
+		ip      string
+		anomaly string
+	}{
+		// Adresse non située : enregistrée sans analyse
+		{"10.0.0.1", ""},
+		// Première connexion située : référence
+		{"192.0.2.10", ""},
+		{"192.0.2.11", ""},
+		// Lyon est trop proche de Paris pour conclure à un déplacement impossible
+		{"198.51.100.1", models.LoginNewLocation},
+		{"203.0.113.1", models.LoginImpossibleTravel},
+	}
+	for _, tt := range tests {
+		if monitor.ObserveLogin(ctx, user.ID, &auth.Device{IPAddress: tt.ip}) {
+			t.Errorf("Expected no step-up for %s without a policy", tt.ip)
+		}
+		last, err := events.ListLoginEvents(ctx, user.ID, 1)
+		if err != nil || len(last) != 1 {
+			t.Fatalf("Expected the login to be recorded, got %v %v", last, err)
+		}
+		if last[0].Anomaly != tt.anomaly {
+			t.Errorf("Expected anomaly %q for %s, got %q", tt.anomaly, tt.ip, last[0].Anomaly)
+		}
+	}
+
+	// La politique de l'organisation peut exiger un code pour toute anomalie
+	policy := models.DefaultLoginAlertPolicy(org.ID)
+	policy.StepUp = true
+	if err := policies.SaveLoginAlertPolicy(ctx, policy); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if !monitor.ObserveLogin(ctx, user.ID, &auth.Device{IPAddress: "192.0.2.12"}) {
+		t.Errorf("Expected a step-up after travelling back to Paris")
+	}
+	if monitor.ObserveLogin(ctx, user.ID, &auth.Device{IPAddress: "192.0.2.13"}) {
+		t.Errorf("Expected no step-up without an anomaly")
+	}
+}
@@ -0,0 +1,91 @@
+// filepath: internal/maintenance/gate.go
+
+package maintenance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// Durée maximale d'une fenêtre de maintenance
+const MaxDuration = 24 * time.Hour
+
+// Window est une fenêtre de maintenance prête à être évaluée
+type Window struct {
+	schedule *Schedule
+	duration time.Duration
+	location *time.Location
+}
+
+// NewWindow valide une fenêtre de maintenance. Un fuseau vide vaut UTC.
+func NewWindow(window *models.MaintenanceWindow) (*Window, error) {
+	schedule, err := ParseSchedule(window.Schedule)
+	if err != nil {
+		return nil, err
+	}
+	duration := time.Duration(window.DurationMinutes) * time.Minute
+	if duration < time.Minute || duration > MaxDuration {
+		return nil, errors.New("durée invalide (d'une minute à 24 heures)")
+	}
+	location := time.UTC
+	if window.Timezone != "" {
+		if location, err = time.LoadLocation(window.Timezone); err != nil {
+			return nil, fmt.Errorf("fuseau horaire inconnu : %s", window.Timezone)
+		}
+	}
+	return &Window{schedule: schedule, duration: duration, location: location}, nil
+}
+
+// Open indique si la fenêtre est ouverte à t : une occurrence a eu lieu
+// depuis moins de sa durée
+func (w *Window) Open(t time.Time) bool {
+	start, ok := w.schedule.Next(t.In(w.location).Add(-w.duration))
+	return ok && !start.After(t)
+}
+
+// NextOpening renvoie la prochaine ouverture de la fenêtre après t
+func (w *Window) NextOpening(t time.Time) (time.Time, bool) {
+	return w.schedule.Next(t.In(w.location))
+}
+
+// Gate autorise les modifications automatiques d'un environnement protégé
+// pendant ses fenêtres de maintenance. Un Gate nil autorise tout.
+type Gate struct {
+	windows storage.MaintenanceWindowsRepository
+}
+
+// NewGate crée le contrôle des fenêtres de maintenance
+func NewGate(windows storage.MaintenanceWindowsRepository) *Gate {
+	return &Gate{windows: windows}
+}
+
+// Allowed indique si une modification automatique de l'environnement est
+// permise à t : l'environnement n'a pas de fenêtre ou l'une est ouverte.
+// Une fenêtre invalide est ignorée.
+func (g *Gate) Allowed(ctx context.Context, orgID, env string, t time.Time) (bool, error) {
+	if g == nil {
+		return true, nil
+	}
+	windows, err := g.windows.ListEnvironmentMaintenanceWindows(ctx, orgID, env)
+	if err != nil {
+		return false, err
+	}
+
+	protected := false
+	for _, window := range windows {
+		w, err := NewWindow(window)
+		if err != nil {
+			continue
+		}
+		protected = true
+		if w.Open(t) {
+			return true, nil
+		}
+	}
+	return !protected, nil
+}
@@ -0,0 +1,173 @@
+// filepath: internal/maintenance/schedule.go
+
+// Package maintenance limite les modifications automatiques (changements
+// planifiés, retrait des valeurs précédentes des rotations) aux fenêtres de
+// maintenance définies par les administrateurs pour un environnement. Un
+// environnement sans fenêtre n'est pas protégé.
+package maintenance
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Horizon de recherche de la prochaine ouverture (un 29 février peut se faire attendre)
+const searchHorizon = 5 * 366 * 24 * time.Hour
+
+// Schedule est une expression cron à cinq champs (minute, heure, jour du
+// mois, mois, jour de la semaine). Chaque champ accepte *, une valeur, un
+// intervalle a-b, un pas /n et des listes séparées par des virgules.
+type Schedule struct {
+	minutes  uint64
+	hours    uint64
+	days     uint64
+	months   uint64
+	weekdays uint64
+	// Comme cron, si le jour du mois et le jour de la semaine sont tous deux
+	// restreints, l'un ou l'autre suffit
+	anyDay     bool
+	anyWeekday bool
+}
+
+// field décrit les bornes d'un champ cron
+type field struct {
+	name     string
+	min, max int
+}
+
+var fields = []field{
+	{"minute", 0, 59},
+	{"heure", 0, 23},
+	{"jour du mois", 1, 31},
+	{"mois", 1, 12},
+	{"jour de la semaine", 0, 7},
+}
+
+// ParseSchedule analyse une expression cron à cinq champs
+func ParseSchedule(expr string) (*Schedule, error) {
+	parts := strings.Fields(expr)
+	if len(parts) != len(fields) {
+		return nil, errors.New("expression cron à cinq champs attendue (minute heure jour mois jour-de-semaine)")
+	}
+
+	sets := make([]uint64, len(fields))
+	for i, part := range parts {
+		set, err := parseField(part, fields[i])
+		if err != nil {
+			return nil, err
+		}
+		sets[i] = set
+	}
+
+	// 7 désigne aussi le dimanche
+	if sets[4]&(1<<7) != 0 {
+		sets[4] |= 1
+	}
+	return &Schedule{
+		minutes:    sets[0],
+		hours:      sets[1],
+		days:       sets[2],
+		months:     sets[3],
+		weekdays:   sets[4],
+		anyDay:     parts[2] == "*",
+		anyWeekday: parts[4] == "*",
+	}, nil
+}
+
+func parseField(expr string, f field) (uint64, error) {
+	var set uint64
+	for _, item := range strings.Split(expr, ",") {
+		rangeExpr, stepExpr, hasStep := strings.Cut(item, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepExpr)
+			if err != nil || n < 1 {
+				return 0, fmt.Errorf("pas invalide pour le champ %s : %q", f.name, item)
+			}
+			step = n
+		}
+
+		low, high := f.min, f.max
+		switch {
+		case rangeExpr == "*":
+		case strings.Contains(rangeExpr, "-"):
+			from, to, _ := strings.Cut(rangeExpr, "-")
+			var err error
+			if low, err = parseValue(from, f); err != nil {
+				return 0, err
+			}
+			if high, err = parseValue(to, f); err != nil {
+				return 0, err
+			}
+			if low > high {
+				return 0, fmt.Errorf("intervalle invalide pour le champ %s : %q", f.name, item)
+			}
+		default:
+			value, err := parseValue(rangeExpr, f)
+			if err != nil {
+				return 0, err
+			}
+			low = value
+			if !hasStep {
+				high = value
+			}
+		}
+
+		for v := low; v <= high; v += step {
+			set |= 1 << v
+		}
+	}
+	return set, nil
+}
+
+func parseValue(expr string, f field) (int, error) {
+	value, err := strconv.Atoi(expr)
+	if err != nil || value < f.min || value > f.max {
+		return 0, fmt.Errorf("valeur invalide pour le champ %s : %q (de %d à %d)", f.name, expr, f.min, f.max)
+	}
+	return value, nil
+}
+
+// dayMatches indique si le jour de t correspond au jour du mois, au mois et
+// au jour de la semaine de l'expression
+func (s *Schedule) dayMatches(t time.Time) bool {
+	if s.months&(1<<int(t.Month())) == 0 {
+		return false
+	}
+	day := s.days&(1<<t.Day()) != 0
+	weekday := s.weekdays&(1<<int(t.Weekday())) != 0
+	switch {
+	case s.anyDay && s.anyWeekday:
+		return true
+	case s.anyDay:
+		return weekday
+	case s.anyWeekday:
+		return day
+	default:
+		return day || weekday
+	}
+}
+
+// Next renvoie la première minute correspondant à l'expression strictement
+// après after, dans le fuseau de after. ok est faux si aucune ne survient
+// dans les cinq prochaines années.
+func (s *Schedule) Next(after time.Time) (next time.Time, ok bool) {
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(searchHorizon)
+	for t.Before(limit) {
+		switch {
+		case !s.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+		case s.hours&(1<<t.Hour()) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+		case s.minutes&(1<<t.Minute()) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,73 @@
+// filepath: internal/maintenance/schedule_test.go
+
+package maintenance
+
+import (
+	"testing"
+	"time"
+
+	"secrets-manager/internal/models"
+)
+
+func TestParseScheduleErrors(t *testing.T) {
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "5-1 * * * *", "*/0 * * * *", "a * * * *"} {
+		if _, err := ParseSchedule(expr); err == nil {
+			t.Errorf("Expected an error for %q", expr)
+		}
+	}
+}
+
+func TestScheduleNext(t *testing.T) {
+	// Le jeudi 1er octobre 2026 à 10h30 UTC
+	after := time.Date(2026, 10, 1, 10, 30, 0, 0, time.UTC)
+	cases := []struct {
+		expr     string
+		expected time.Time
+	}{
+		{"* * * * *", time.Date(2026, 10, 1, 10, 31, 0, 0, time.UTC)},
+		{"0 2 * * *", time.Date(2026, 10, 2, 2, 0, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2026, 10, 1, 10, 45, 0, 0, time.UTC)},
+		{"0 22 * * 6,7", time.Date(2026, 10, 3, 22, 0, 0, 0, time.UTC)},
+		{"0 3 15 * 1", time.Date(2026, 10, 5, 3, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, c := range cases {
+		schedule, err := ParseSchedule(c.expr)
+		if err != nil {
+			t.Fatalf("Expected %q to parse, got %v", c.expr, err)
+		}
+		next, ok := schedule.Next(after)
+		if !ok || !next.Equal(c.expected) {
+			t.Errorf("Expected %q to open at %v, got %v", c.expr, c.expected, next)
+		}
+	}
+
+	schedule, _ := ParseSchedule("0 0 31 2 *")
+	if _, ok := schedule.Next(after); ok {
+		t.Errorf("Expected no opening on February 31st")
+	}
+}
+
+func TestWindowOpen(t *testing.T) {
+	window, err := NewWindow(&models.MaintenanceWindow{Schedule: "0 22 * * *", DurationMinutes: 120, Timezone: "Europe/Paris"})
+	if err != nil {
+		t.Fatalf("Expected a valid window, got %v", err)
+	}
+	paris, _ := time.LoadLocation("Europe/Paris")
+	if !window.Open(time.Date(2026, 10, 2, 23, 30, 0, 0, paris)) {
+		t.Errorf("Expected the window to be open at 23:30")
+	}
+	if window.Open(time.Date(2026, 10, 3, 0, 0, 0, 0, paris)) {
+		t.Errorf("Expected the window to be closed at midnight")
+	}
+
+	for _, invalid := range []models.MaintenanceWindow{
+		{Schedule: "0 22 * * *", DurationMinutes: 0},
+		{Schedule: "0 22 * * *", DurationMinutes: 25 * 60},
+		{Schedule: "0 22 * * *", DurationMinutes: 60, Timezone: "Mars/Olympus"},
+	} {
+		if _, err := NewWindow(&invalid); err == nil {
+			t.Errorf("Expected an error for %+v", invalid)
+		}
+	}
+}
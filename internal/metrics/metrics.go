@@ -0,0 +1,243 @@
+// filepath: internal/metrics/metrics.go
+
+// Package metrics fournit des compteurs et jauges étiquetés, exposés au
+// format texte Prometheus sur le listener d'administration.
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var (
+	registryMu sync.Mutex
+	registry   = map[string]*vec{}
+)
+
+// vec est une famille de séries partageant un nom et des noms d'étiquettes
+type vec struct {
+	name       string
+	help       string
+	kind       string // counter ou gauge
+	labelNames []string
+
+	mu     sync.Mutex
+	values map[string]float64
+	labels map[string][]string
+}
+
+// CounterVec est un compteur monotone étiqueté
+type CounterVec struct{ v *vec }
+
+// GaugeVec est une jauge étiquetée
+type GaugeVec struct{ v *vec }
+
+// NewCounter déclare un compteur (ou renvoie celui déjà déclaré sous ce nom)
+func NewCounter(name, help string, labelNames ...string) *CounterVec {
+	return &CounterVec{v: register(name, help, "counter", labelNames)}
+}
+
+// NewGauge déclare une jauge (ou renvoie celle déjà déclarée sous ce nom)
+func NewGauge(name, help string, labelNames ...string) *GaugeVec {
+	return &GaugeVec{v: register(name, help, "gauge", labelNames)}
+}
+
+// Inc incrémente le compteur pour les valeurs d'étiquettes données
+func (c *CounterVec) Inc(labelValues ...string) {
+	c.v.add(1, labelValues)
+}
+
+// Add ajoute delta (positif) au compteur
+func (c *CounterVec) Add(delta float64, labelValues ...string) {
+	if delta < 0 {
+		return
+	}
+	c.v.add(delta, labelValues)
+}
+
+// Value renvoie la valeur courante du compteur
+func (c *CounterVec) Value(labelValues ...string) float64 {
+	return c.v.get(labelValues)
+}
+
+// Set fixe la valeur de la jauge
+func (g *GaugeVec) Set(value float64, labelValues ...string) {
+	g.v.set(value, labelValues)
+}
+
+// Add ajoute delta (éventuellement négatif) à la jauge
+func (g *GaugeVec) Add(delta float64, labelValues ...string) {
+	g.v.add(delta, labelValues)
+}
+
+// Value renvoie la valeur courante de la jauge
+func (g *GaugeVec) Value(labelValues ...string) float64 {
+	return g.v.get(labelValues)
+}
+
+func register(name, help, kind string, labelNames []string) *vec {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if existing, ok := registry[name]; ok {
+		return existing
+	}
+
+	v := &vec{
+		name:       name,
+		help:       help,
+		kind:       kind,
+		labelNames: labelNames,
+		values:     map[string]float64{},
+		labels:     map[string][]string{},
+	}
+	registry[name] = v
+	return v
+}
+
+func (v *vec) key(labelValues []string) string {
+	if len(labelValues) != len(v.labelNames) {
+		panic(fmt.Sprintf("metrics: %s attend %d étiquettes, %d fournies", v.name, len(v.labelNames), len(labelValues)))
+	}
+	return strings.Join(labelValues, "\xff")
+}
+
+func (v *vec) add(delta float64, labelValues []string) {
+	key := v.key(labelValues)
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if _, ok := v.labels[key]; !ok {
+		v.labels[key] = append([]string(nil), labelValues...)
+	}
+	v.values[key] += delta
+}
+
+func (v *vec) set(value float64, labelValues []string) {
+	key := v.key(labelValues)
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if _, ok := v.labels[key]; !ok {
+		v.labels[key] = append([]string(nil), labelValues...)
+	}
+	v.values[key] = value
+}
+
+func (v *vec) get(labelValues []string) float64 {
+	key := v.key(labelValues)
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	return v.values[key]
+}
+
+// WriteText écrit toutes les métriques au format texte Prometheus
+func WriteText(w io.Writer) error {
+	registryMu.Lock()
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	vecs := make([]*vec, 0, len(names))
+	sort.Strings(names)
+	for _, name := range names {
+		vecs = append(vecs, registry[name])
+	}
+	registryMu.Unlock()
+
+	for _, v := range vecs {
+		if err := v.writeText(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v *vec) writeText(w io.Writer) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if _, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", v.name, v.help, v.name, v.kind); err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(v.values))
+	for key := range v.values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if _, err := fmt.Fprintf(w, "%s%s %g\n", v.name, formatLabels(v.labelNames, v.labels[key]), v.values[key]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Family est une famille de séries calculées à la demande, hors du registre
+// (métriques propres à une organisation, par exemple)
+type Family struct {
+	Name       string
+	Help       string
+	Kind       string // counter, gauge ou summary
+	LabelNames []string
+	Samples    []Sample
+}
+
+// Sample est une série d'une Family
+type Sample struct {
+	// Suffix complète le nom de la famille (_sum et _count des résumés) ;
+	// une série suffixée n'a pas d'étiquettes
+	Suffix string
+	Labels []string
+	Value  float64
+}
+
+// WriteText écrit la famille au format texte Prometheus
+func (f *Family) WriteText(w io.Writer) error {
+	if _, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", f.Name, f.Help, f.Name, f.Kind); err != nil {
+		return err
+	}
+	for _, sample := range f.Samples {
+		labels := ""
+		if sample.Suffix == "" {
+			labels = formatLabels(f.LabelNames, sample.Labels)
+		}
+		if _, err := fmt.Fprintf(w, "%s%s%s %g\n", f.Name, sample.Suffix, labels, sample.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// formatLabels formate les étiquettes d'une série ({a="x",b="y"})
+func formatLabels(names, values []string) string {
+	if len(names) == 0 {
+		return ""
+	}
+
+	pairs := make([]string, len(names))
+	for i, name := range names {
+		pairs[i] = fmt.Sprintf("%s=%q", name, values[i])
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+// Handler expose les métriques au format texte Prometheus
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		if err := WriteText(w); err != nil {
+			http.Error(w, "Erreur lors de l'export des métriques", http.StatusInternalServerError)
+		}
+	})
+}
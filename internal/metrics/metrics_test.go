@@ -0,0 +1,64 @@
+// filepath: internal/metrics/metrics_test.go
+
+package metrics
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWriteText(t *testing.T) {
+	counter := NewCounter("test_requests_total", "Requêtes de test", "route")
+	counter.Inc("GET /a")
+	counter.Inc("GET /a")
+	counter.Add(3, "GET /b")
+
+	gauge := NewGauge("test_state", "État de test")
+	gauge.Set(2)
+
+	var out strings.Builder
+	if err := WriteText(&out); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+
+	for _, expected := range []string{
+		"# TYPE test_requests_total counter",
+		`test_requests_total{route="GET /a"} 2`,
+		`test_requests_total{route="GET /b"} 3`,
+		"# TYPE test_state gauge",
+		"test_state 2",
+	} {
+		if !strings.Contains(out.String(), expected) {
+			t.Errorf("Expected output to contain %q, got:\n%s", expected, out.String())
+		}
+	}
+
+	again := NewCounter("test_requests_total", "Requêtes de test", "route")
+	if again.Value("GET /a") != 2 {
+		t.Errorf("Expected re-registration to reuse the existing counter")
+	}
+}
+
+func TestFamilyWriteText(t *testing.T) {
+	family := &Family{
+		Name:       "test_age_seconds",
+		Help:       "Âge de test",
+		Kind:       "summary",
+		LabelNames: []string{"quantile"},
+		Samples: []Sample{
+			{Labels: []string{"0.5"}, Value: 10},
+			{Suffix: "_sum", Value: 30},
+			{Suffix: "_count", Value: 2},
+		},
+	}
+
+	var out strings.Builder
+	if err := family.WriteText(&out); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	expected := "# HELP test_age_seconds Âge de test\n# TYPE test_age_seconds summary\n" +
+		"test_age_seconds{quantile=\"0.5\"} 10\ntest_age_seconds_sum 30\ntest_age_seconds_count 2\n"
+	if out.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, out.String())
+	}
+}
@@ -0,0 +1,62 @@
+// filepath: internal/models/access_review.go
+
+package models
+
+import (
+	"time"
+)
+
+// États d'une campagne de revue des accès
+const (
+	AccessReviewOpen      = "open"
+	AccessReviewCompleted = "completed"
+)
+
+// Décisions sur un accès revu
+const (
+	AccessDecisionPending   = "pending"
+	AccessDecisionConfirmed = "confirmed"
+	AccessDecisionRevoked   = "revoked"
+)
+
+// AccessReview est une campagne de revue des accès d'une organisation. Elle
+// fige la liste des principaux et de leurs rôles à sa création ; la campagne
+// est terminée lorsque chaque accès a été confirmé ou révoqué.
+type AccessReview struct {
+	ID             string     `json:"id" db:"id"`
+	OrganizationID string     `json:"organization_id" db:"organization_id"`
+	CreatedBy      string     `json:"created_by" db:"created_by"`
+	Status         string     `json:"status" db:"status"`
+	TotalItems     int        `json:"total_items" db:"-"`
+	PendingItems   int        `json:"pending_items" db:"-"`
+	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+	CompletedAt    *time.Time `json:"completed_at,omitempty" db:"completed_at"`
+	// Items n'est renseigné que lors de la lecture d'une campagne
+	Items []*AccessReviewItem `json:"items,omitempty" db:"-"`
+}
+
+// AccessReviewItem est l'accès d'un principal soumis à la revue
+type AccessReviewItem struct {
+	ReviewID      string `json:"review_id" db:"review_id"`
+	PrincipalType string `json:"principal_type" db:"principal_type"`
+	PrincipalID   string `json:"principal_id" db:"principal_id"`
+	Email         string `json:"email" db:"email"`
+	Role          string `json:"role" db:"role"`
+	// LastActivity est le dernier jour (AAAA-MM-JJ) d'appel à l'API, vide si aucun
+	LastActivity string `json:"last_activity,omitempty" db:"last_activity"`
+	// Dormant signale un principal sans activité récente
+	Dormant   bool       `json:"dormant" db:"dormant"`
+	Decision  string     `json:"decision" db:"decision"`
+	DecidedBy string     `json:"decided_by,omitempty" db:"decided_by"`
+	DecidedAt *time.Time `json:"decided_at,omitempty" db:"decided_at"`
+}
+
+// Item renvoie l'accès d'un principal, ou nil s'il ne fait pas partie de la revue
+func (r *AccessReview) Item(principalType, principalID string) *AccessReviewItem {
+	for _, item := range r.Items {
+		if item.PrincipalType == principalType && item.PrincipalID == principalID {
+			return item
+		}
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+// filepath: internal/models/age_key.go
+
+package models
+
+import (
+	"time"
+)
+
+// États des clés age
+const (
+	// AgeKeyActive est la clé avec laquelle les fichiers du dépôt sont chiffrés
+	AgeKeyActive = "active"
+	// AgeKeyRetired est une clé remplacée par une rotation, encore servie
+	// pour déchiffrer les fichiers qui n'ont pas été rechiffrés
+	AgeKeyRetired = "retired"
+)
+
+// AgeKey est une clé age (sops) d'un dépôt de code, rattachée à un projet.
+// Seul le destinataire (clé publique) est conservé en base ; l'identité est
+// stockée dans Vault.
+type AgeKey struct {
+	ID             string     `json:"id" db:"id"`
+	OrganizationID string     `json:"organization_id" db:"organization_id"`
+	ProjectID      string     `json:"project_id" db:"project_id"`
+	Repository     string     `json:"repository" db:"repository"`
+	Recipient      string     `json:"recipient" db:"recipient"`
+	Status         string     `json:"status" db:"status"`
+	CreatedBy      string     `json:"created_by" db:"created_by"`
+	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+	RetiredAt      *time.Time `json:"retired_at,omitempty" db:"retired_at"`
+}
@@ -0,0 +1,60 @@
+// filepath: internal/models/api_key.go
+
+package models
+
+import (
+	"time"
+)
+
+// Permissions des clés d'API
+const (
+	// APIKeyRead permet de lire les secrets de la portée de la clé
+	APIKeyRead = "read"
+	// APIKeyReadWrite permet aussi de les créer, modifier et supprimer
+	APIKeyReadWrite = "read_write"
+)
+
+// APIKey est une clé d'accès machine à machine (pipelines CI) restreinte aux
+// secrets d'une organisation, et éventuellement d'un projet et d'un
+// environnement. Elle agit avec les droits de l'administrateur qui l'a
+// créée, dans les limites de sa portée. Seule l'empreinte de la clé est
+// conservée ; Prefix, ses premiers caractères, permet de la reconnaître.
+// Une clé à requêtes signées (SignedRequests) n'est jamais présentée : chaque
+// requête porte une signature HMAC avec une clé de signature propre, conservée
+// enveloppée par Vault (voir auth.NewAPIKeySigningKey).
+type APIKey struct {
+	ID             string `json:"id" db:"id"`
+	OrganizationID string `json:"organization_id" db:"organization_id"`
+	// ProjectID et Environment vides donnent accès à tous les projets ou
+	// à tous les environnements
+	ProjectID   string `json:"project_id,omitempty" db:"project_id"`
+	Environment string `json:"environment,omitempty" db:"environment"`
+	Name        string `json:"name" db:"name"`
+	KeyHash     string `json:"-" db:"key_hash"`
+	Prefix      string `json:"prefix" db:"prefix"`
+	Permission  string `json:"permission" db:"permission"`
+	// SignedRequests refuse la clé présentée dans l'en-tête X-API-Key
+	SignedRequests bool `json:"signed_requests" db:"signed_requests"`
+	// WrappedSigningKey est la clé de signature enveloppée par le moteur
+	// transit (vide sans requêtes signées)
+	WrappedSigningKey string     `json:"-" db:"wrapped_signing_key"`
+	CreatedBy         string     `json:"created_by" db:"created_by"`
+	ExpiresAt         *time.Time `json:"expires_at,omitempty" db:"expires_at"`
+	LastUsedAt        *time.Time `json:"last_used_at,omitempty" db:"last_used_at"`
+	CreatedAt         time.Time  `json:"created_at" db:"created_at"`
+}
+
+// Scopes renvoie les portées équivalentes à la permission de la clé
+func (k *APIKey) Scopes() []string {
+	if k.Permission == APIKeyReadWrite {
+		return []string{ScopeSecretsRead, ScopeSecretsWrite, ScopeMetadataRead}
+	}
+	return []string{ScopeSecretsRead, ScopeMetadataRead}
+}
+
+// Covers indique si la portée de la clé couvre l'environnement d'un projet
+func (k *APIKey) Covers(orgID, projectID, env string) bool {
+	return k.OrganizationID == orgID &&
+		(k.ProjectID == "" || k.ProjectID == projectID) &&
+		(k.Environment == "" || k.Environment == env)
+}
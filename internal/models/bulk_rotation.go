@@ -0,0 +1,64 @@
+// filepath: internal/models/bulk_rotation.go
+
+package models
+
+import (
+	"time"
+)
+
+// États d'une rotation groupée
+const (
+	BulkRotationPending   = "pending"
+	BulkRotationRunning   = "running"
+	BulkRotationCompleted = "completed"
+)
+
+// États d'un secret dans une rotation groupée
+const (
+	BulkItemPending = "pending"
+	BulkItemRotated = "rotated"
+	BulkItemFailed  = "failed"
+	// BulkItemSkipped : le secret correspond au filtre mais n'a pas de rotateur
+	BulkItemSkipped = "skipped"
+)
+
+// BulkRotation fait tourner en tâche de fond tous les secrets d'une
+// organisation qui correspondent à un filtre, par exemple après une
+// compromission présumée. La liste des secrets est figée à la création ;
+// une rotation interrompue reprend aux secrets encore en attente.
+type BulkRotation struct {
+	ID             string `json:"id" db:"id"`
+	OrganizationID string `json:"organization_id" db:"organization_id"`
+	// Path est un motif path.Match sur « projet/environnement/secret », vide pour tous
+	Path string `json:"path,omitempty" db:"path"`
+	// Provider restreint la rotation aux rotateurs de ce fournisseur
+	Provider     string     `json:"provider,omitempty" db:"provider"`
+	RequestedBy  string     `json:"requested_by" db:"requested_by"`
+	Status       string     `json:"status" db:"status"`
+	TotalItems   int        `json:"total_items" db:"-"`
+	PendingItems int        `json:"pending_items" db:"-"`
+	FailedItems  int        `json:"failed_items" db:"-"`
+	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
+	CompletedAt  *time.Time `json:"completed_at,omitempty" db:"completed_at"`
+	// Items n'est renseigné que lors de la lecture d'une rotation
+	Items []*BulkRotationItem `json:"items,omitempty" db:"-"`
+}
+
+// BulkRotationItem est l'avancement d'un secret dans une rotation groupée
+type BulkRotationItem struct {
+	ID          string `json:"id" db:"id"`
+	RotationID  string `json:"rotation_id" db:"rotation_id"`
+	ProjectID   string `json:"project_id" db:"project_id"`
+	Environment string `json:"environment" db:"environment"`
+	SecretName  string `json:"secret_name" db:"secret_name"`
+	// RotatorID est vide pour un secret ignoré
+	RotatorID   string     `json:"rotator_id,omitempty" db:"rotator_id"`
+	Status      string     `json:"status" db:"status"`
+	Error       string     `json:"error,omitempty" db:"error"`
+	CompletedAt *time.Time `json:"completed_at,omitempty" db:"completed_at"`
+}
+
+// Finished indique si la rotation groupée est terminée
+func (r *BulkRotation) Finished() bool {
+	return r.Status == BulkRotationCompleted
+}
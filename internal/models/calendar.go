@@ -0,0 +1,30 @@
+// filepath: internal/models/calendar.go
+
+package models
+
+import (
+	"time"
+)
+
+// Types des échéances du calendrier d'une organisation
+const (
+	// CalendarSecretExpiration : retrait de la valeur précédente d'une rotation double
+	CalendarSecretExpiration = "secret_expiration"
+	// CalendarScheduledChange : changement de valeur planifié d'un secret
+	CalendarScheduledChange = "scheduled_change"
+	// CalendarSubscriptionRenewal : renouvellement de l'abonnement
+	CalendarSubscriptionRenewal = "subscription_renewal"
+)
+
+// CalendarEvent est une échéance à venir de l'organisation. Les champs du
+// secret sont vides pour le renouvellement de l'abonnement.
+type CalendarEvent struct {
+	// UID est stable d'une requête à l'autre, pour les clients de calendrier
+	UID         string    `json:"uid"`
+	Type        string    `json:"type"`
+	Summary     string    `json:"summary"`
+	At          time.Time `json:"at"`
+	ProjectID   string    `json:"project_id,omitempty"`
+	Environment string    `json:"environment,omitempty"`
+	SecretName  string    `json:"secret_name,omitempty"`
+}
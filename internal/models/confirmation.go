@@ -0,0 +1,28 @@
+// filepath: internal/models/confirmation.go
+
+package models
+
+import (
+	"time"
+)
+
+// Opérations destructives soumises à confirmation
+const (
+	ActionDeleteOrganization = "delete_organization"
+	ActionDeleteProject      = "delete_project"
+	ActionBulkDeleteSecrets  = "bulk_delete_secrets"
+)
+
+// DeletionConfirmation est une demande d'opération destructive en attente.
+// Seule l'empreinte du token est conservée ; le token n'est remis qu'une
+// fois au demandeur et n'est utilisable qu'une fois, avant ExpiresAt.
+type DeletionConfirmation struct {
+	ID             string    `json:"id" db:"id"`
+	TokenHash      string    `json:"-" db:"token_hash"`
+	OrganizationID string    `json:"organization_id" db:"organization_id"`
+	Action         string    `json:"action" db:"action"`
+	Target         string    `json:"target" db:"target"` // empreinte de la cible exacte de l'opération
+	RequestedBy    string    `json:"requested_by" db:"requested_by"`
+	ExpiresAt      time.Time `json:"expires_at" db:"expires_at"`
+	CreatedAt      time.Time `json:"created_at" db:"created_at"`
+}
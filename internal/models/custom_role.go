@@ -0,0 +1,65 @@
+// filepath: internal/models/custom_role.go
+
+package models
+
+import (
+	"slices"
+	"time"
+)
+
+// Permissions fines composant les rôles personnalisés
+const (
+	PermissionSecretsRead   = "secrets.read"
+	PermissionSecretsWrite  = "secrets.write"
+	PermissionMembersInvite = "members.invite"
+	PermissionAuditRead     = "audit.read"
+	PermissionExportsCreate = "exports.create"
+)
+
+// Permissions liste les permissions reconnues dans un rôle personnalisé
+var Permissions = []string{
+	PermissionSecretsRead,
+	PermissionSecretsWrite,
+	PermissionMembersInvite,
+	PermissionAuditRead,
+	PermissionExportsCreate,
+}
+
+// CustomRolesPlans liste les plans autorisant les rôles personnalisés
+var CustomRolesPlans = []string{"plan-enterprise"}
+
+// CustomRole est un rôle défini par une organisation, composé de
+// permissions fines. Attribué à un membre, il s'ajoute à son rôle dans
+// l'organisation (admin, member ou viewer) sans jamais le restreindre.
+type CustomRole struct {
+	ID             string    `json:"id" db:"id"`
+	OrganizationID string    `json:"organization_id" db:"organization_id"`
+	Name           string    `json:"name" db:"name"`
+	Description    string    `json:"description" db:"description"`
+	Permissions    []string  `json:"permissions" db:"permissions"`
+	CreatedBy      string    `json:"created_by" db:"created_by"`
+	CreatedAt      time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// Allows indique si le rôle accorde la permission. secrets.write implique
+// secrets.read.
+func (r *CustomRole) Allows(permission string) bool {
+	if r == nil {
+		return false
+	}
+	if permission == PermissionSecretsRead && slices.Contains(r.Permissions, PermissionSecretsWrite) {
+		return true
+	}
+	return slices.Contains(r.Permissions, permission)
+}
+
+// CustomRoleAssignment attribue un rôle personnalisé à un membre ; un
+// membre en a au plus un par organisation
+type CustomRoleAssignment struct {
+	OrganizationID string    `json:"organization_id" db:"organization_id"`
+	UserID         string    `json:"user_id" db:"user_id"`
+	RoleID         string    `json:"role_id" db:"role_id"`
+	AssignedBy     string    `json:"assigned_by" db:"assigned_by"`
+	AssignedAt     time.Time `json:"assigned_at" db:"assigned_at"`
+}
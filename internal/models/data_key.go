@@ -0,0 +1,51 @@
+// filepath: internal/models/data_key.go
+
+package models
+
+import (
+	"time"
+)
+
+// DataKey est une clé de chiffrement d'enveloppe d'un projet, pour de petits
+// contenus conservés hors du gestionnaire (états Terraform, fichiers sops).
+// Elle désigne une clé du moteur transit de Vault, qui ne le quitte jamais :
+// chaque chiffrement utilise une nouvelle clé de données enveloppée par elle.
+type DataKey struct {
+	ID             string    `json:"id" db:"id"`
+	OrganizationID string    `json:"organization_id" db:"organization_id"`
+	ProjectID      string    `json:"project_id" db:"project_id"`
+	Name           string    `json:"name" db:"name"`
+	TransitKey     string    `json:"transit_key" db:"transit_key"`
+	CreatedBy      string    `json:"created_by" db:"created_by"`
+	CreatedAt      time.Time `json:"created_at" db:"created_at"`
+}
+
+// DataKeyEnvelope est un contenu chiffré avec une clé de données : la clé
+// de données enveloppée par transit et le chiffré AES-256-GCM (nonce en
+// tête), en base64
+type DataKeyEnvelope struct {
+	KeyID      string `json:"key_id"`
+	WrappedKey string `json:"wrapped_key"`
+	Ciphertext string `json:"ciphertext"`
+}
+
+// Opérations des clés de données
+const (
+	DataKeyEncrypt = "encrypt"
+	DataKeyDecrypt = "decrypt"
+)
+
+// DataKeyOperation est l'audit d'un appel de chiffrement ou de déchiffrement.
+// Le contenu n'est jamais conservé, seule sa taille l'est.
+type DataKeyOperation struct {
+	ID             string    `json:"id" db:"id"`
+	DataKeyID      string    `json:"data_key_id" db:"data_key_id"`
+	OrganizationID string    `json:"organization_id" db:"organization_id"`
+	Operation      string    `json:"operation" db:"operation"`
+	ActorID        string    `json:"actor_id" db:"actor_id"`
+	Size           int       `json:"size" db:"size"`
+	IPAddress      string    `json:"ip_address" db:"ip_address"`
+	OccurredAt     time.Time `json:"occurred_at" db:"occurred_at"`
+	// Error est vide si l'opération a réussi
+	Error string `json:"error,omitempty" db:"error"`
+}
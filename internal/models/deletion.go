@@ -0,0 +1,68 @@
+// filepath: internal/models/deletion.go
+
+package models
+
+import (
+	"time"
+)
+
+// États d'une suppression d'organisation
+const (
+	DeletionPending   = "pending"
+	DeletionRunning   = "running"
+	DeletionCompleted = "completed"
+	DeletionFailed    = "failed"
+	// DeletionRestored : l'organisation a été restaurée depuis la corbeille avant sa purge
+	DeletionRestored = "restored"
+)
+
+// Étapes d'une suppression d'organisation, dans l'ordre d'exécution.
+// Les appartenances sont retirées en premier pour couper tout accès
+// pendant le reste de la suppression.
+const (
+	DeletionStageMemberships    = "memberships"
+	DeletionStageVaultSecrets   = "vault_secrets"
+	DeletionStageSecretMetadata = "secret_metadata"
+	DeletionStageProjects       = "projects"
+	DeletionStageSubscriptions  = "subscriptions"
+	DeletionStageOrganization   = "organization"
+)
+
+// OrganizationDeletionStages liste les étapes dans l'ordre d'exécution
+var OrganizationDeletionStages = []string{
+	DeletionStageMemberships,
+	DeletionStageVaultSecrets,
+	DeletionStageSecretMetadata,
+	DeletionStageProjects,
+	DeletionStageSubscriptions,
+	DeletionStageOrganization,
+}
+
+// OrganizationDeletion suit la suppression asynchrone d'une organisation.
+// L'organisation reste dans la corbeille jusqu'à PurgeAfter, puis chaque
+// étape est exécutée ; une suppression interrompue reprend à l'étape Stage.
+type OrganizationDeletion struct {
+	ID              string     `json:"id" db:"id"`
+	OrganizationID  string     `json:"organization_id" db:"organization_id"`
+	RequestedBy     string     `json:"requested_by" db:"requested_by"`
+	Status          string     `json:"status" db:"status"`
+	Stage           string     `json:"stage" db:"stage"`
+	CompletedStages int        `json:"completed_stages" db:"completed_stages"`
+	TotalStages     int        `json:"total_stages" db:"-"`
+	SecretsDeleted  int        `json:"secrets_deleted" db:"secrets_deleted"`
+	PurgeAfter      time.Time  `json:"purge_after" db:"purge_after"`
+	Error           string     `json:"error,omitempty" db:"error"`
+	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at" db:"updated_at"`
+	CompletedAt     *time.Time `json:"completed_at,omitempty" db:"completed_at"`
+}
+
+// Finished indique si la suppression est terminée, avec succès ou non
+func (d *OrganizationDeletion) Finished() bool {
+	return d.Status == DeletionCompleted || d.Status == DeletionFailed || d.Status == DeletionRestored
+}
+
+// Restorable indique si l'organisation peut encore être restaurée (purge non commencée)
+func (d *OrganizationDeletion) Restorable() bool {
+	return d.Status == DeletionPending && d.CompletedStages == 0
+}
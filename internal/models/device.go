@@ -0,0 +1,30 @@
+// filepath: internal/models/device.go
+
+package models
+
+import (
+	"time"
+)
+
+// États d'une autorisation d'appareil
+const (
+	DeviceAuthorizationPending  = "pending"
+	DeviceAuthorizationApproved = "approved"
+	DeviceAuthorizationDenied   = "denied"
+)
+
+// DeviceAuthorization est une demande de connexion d'un appareil sans
+// navigateur (flux device code, RFC 8628). L'appareil interroge le serveur
+// avec le device code, dont seule l'empreinte est conservée, pendant que
+// l'utilisateur approuve le user code depuis une session déjà authentifiée.
+type DeviceAuthorization struct {
+	ID             string     `json:"id" db:"id"`
+	DeviceCodeHash string     `json:"-" db:"device_code_hash"`
+	UserCode       string     `json:"user_code" db:"user_code"`
+	ClientName     string     `json:"client_name" db:"client_name"`
+	Status         string     `json:"status" db:"status"`
+	UserID         string     `json:"user_id,omitempty" db:"user_id"` // utilisateur ayant approuvé ou refusé
+	LastPolledAt   *time.Time `json:"last_polled_at,omitempty" db:"last_polled_at"`
+	ExpiresAt      time.Time  `json:"expires_at" db:"expires_at"`
+	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+}
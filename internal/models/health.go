@@ -0,0 +1,32 @@
+// filepath: internal/models/health.go
+
+package models
+
+import (
+	"time"
+)
+
+// Composants dont la santé est suivie pour la page de statut ; chaque région
+// de résidence des données ajoute son composant vault-<région>
+const (
+	ComponentDatabase = "database"
+	ComponentVault    = "vault"
+)
+
+// HealthCheckDay agrège les vérifications de santé d'un composant pendant un
+// jour (UTC), toutes instances du serveur confondues
+type HealthCheckDay struct {
+	Component string `json:"component" db:"component"`
+	Day       string `json:"day" db:"day"` // AAAA-MM-JJ
+	Checks    int64  `json:"checks" db:"checks"`
+	Failures  int64  `json:"failures" db:"failures"`
+}
+
+// HealthIncident est une indisponibilité d'un composant, ouverte au premier
+// échec de vérification et résolue à la première vérification réussie
+type HealthIncident struct {
+	ID         string     `json:"id" db:"id"`
+	Component  string     `json:"component" db:"component"`
+	StartedAt  time.Time  `json:"started_at" db:"started_at"`
+	ResolvedAt *time.Time `json:"resolved_at,omitempty" db:"resolved_at"`
+}
@@ -0,0 +1,77 @@
+// filepath: internal/models/hygiene.go
+
+package models
+
+// Défauts d'hygiène d'une valeur de secret
+const (
+	HygieneShort      = "short"
+	HygieneLowEntropy = "low_entropy"
+	HygieneReused     = "reused"
+	HygieneStale      = "stale"
+	// HygieneShared signale une valeur utilisée dans d'autres projets de l'organisation
+	HygieneShared = "shared"
+)
+
+// SecretHygiene évalue la valeur d'un secret sur 100 : longueur, entropie,
+// réutilisation dans d'autres environnements ou projets et âge. Seules des
+// mesures sont exposées, jamais la valeur ni son empreinte.
+type SecretHygiene struct {
+	Score  int `json:"score"`
+	Length int `json:"length"`
+	// EntropyBits est l'entropie de Shannon de la valeur, en bits
+	EntropyBits float64 `json:"entropy_bits"`
+	// AgeDays est le nombre de jours depuis la dernière écriture de la valeur
+	AgeDays int `json:"age_days"`
+	// ReusedIn liste les autres environnements du projet où la même valeur est utilisée
+	ReusedIn []string `json:"reused_in,omitempty"`
+	// SharedWith liste les autres projets de l'organisation où la même valeur est utilisée
+	SharedWith []string `json:"shared_with,omitempty"`
+	// Issues liste les défauts relevés (Hygiene*)
+	Issues []string `json:"issues"`
+}
+
+// HygieneReportEntry est l'évaluation d'un secret dans un rapport d'hygiène
+type HygieneReportEntry struct {
+	Environment string         `json:"environment"`
+	Name        string         `json:"name"`
+	Hygiene     *SecretHygiene `json:"hygiene"`
+}
+
+// HygieneReport agrège l'hygiène des secrets d'un projet, tous
+// environnements confondus. Les secrets sans mesure (écrits avant leur
+// calcul ou chiffrés de bout en bout) ne sont que comptés.
+type HygieneReport struct {
+	ProjectID string `json:"project_id"`
+	// Score est la moyenne des scores des secrets évalués (100 sans secret évalué)
+	Score      int `json:"score"`
+	Assessed   int `json:"assessed"`
+	Unassessed int `json:"unassessed"`
+	// Issues compte les secrets présentant chaque défaut
+	Issues map[string]int `json:"issues"`
+	// Secrets liste les secrets évalués, du moins bon score au meilleur
+	Secrets []*HygieneReportEntry `json:"secrets"`
+}
+
+// SecretLocation désigne un secret par son chemin
+type SecretLocation struct {
+	ProjectID   string `json:"project_id"`
+	Environment string `json:"environment"`
+	Name        string `json:"name"`
+}
+
+// DuplicateValue regroupe les secrets d'une organisation qui partagent la
+// même valeur, sans la révéler
+type DuplicateValue struct {
+	// Projects compte les projets distincts du groupe
+	Projects int              `json:"projects"`
+	Secrets  []SecretLocation `json:"secrets"`
+}
+
+// DuplicatesReport liste les valeurs partagées par plusieurs secrets d'une
+// organisation, des plus répandues aux moins répandues
+type DuplicatesReport struct {
+	OrganizationID string `json:"organization_id"`
+	// Secrets compte les secrets concernés, tous groupes confondus
+	Secrets    int               `json:"secrets"`
+	Duplicates []*DuplicateValue `json:"duplicates"`
+}
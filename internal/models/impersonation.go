@@ -0,0 +1,29 @@
+// filepath: internal/models/impersonation.go
+
+package models
+
+import "time"
+
+// Impersonation est une usurpation d'identité par le support : un
+// administrateur de la plateforme agit au nom d'un utilisateur, avec un
+// token de courte durée (claim act_as). Chaque requête ainsi faite est
+// inscrite au journal d'audit d'administration, et l'utilisateur voit les
+// usurpations de son compte et leurs requêtes.
+type Impersonation struct {
+	ID     string `json:"id" db:"id"`
+	UserID string `json:"user_id" db:"user_id"`
+	// Operator nomme le membre du support qui agit (email, identifiant)
+	Operator string `json:"operator" db:"operator"`
+	// Principal est l'appelant de la route d'administration ("admin:token")
+	Principal string    `json:"principal" db:"principal"`
+	Reason    string    `json:"reason" db:"reason"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	ExpiresAt time.Time `json:"expires_at" db:"expires_at"`
+	// EndedAt est la date de fin anticipée (par l'administrateur ou l'utilisateur)
+	EndedAt *time.Time `json:"ended_at,omitempty" db:"ended_at"`
+}
+
+// Active indique si l'usurpation est encore en cours à cet instant
+func (i *Impersonation) Active(now time.Time) bool {
+	return i.EndedAt == nil && now.Before(i.ExpiresAt)
+}
@@ -0,0 +1,49 @@
+// filepath: internal/models/invitation.go
+
+package models
+
+import (
+	"time"
+)
+
+// DefaultInvitationValidity est la durée de validité d'une invitation
+const DefaultInvitationValidity = 7 * 24 * time.Hour
+
+// États d'une invitation
+const (
+	InvitationPending  = "pending"
+	InvitationAccepted = "accepted"
+	InvitationRevoked  = "revoked"
+	InvitationExpired  = "expired"
+)
+
+// Invitation invite une adresse email à rejoindre une organisation avec un
+// rôle. Seule l'empreinte du token envoyé par email est conservée ; le
+// token n'est utilisable qu'une fois, avant ExpiresAt.
+type Invitation struct {
+	ID             string     `json:"id" db:"id"`
+	OrganizationID string     `json:"organization_id" db:"organization_id"`
+	Email          string     `json:"email" db:"email"`
+	Role           string     `json:"role" db:"role"`
+	TokenHash      string     `json:"-" db:"token_hash"`
+	InvitedBy      string     `json:"invited_by" db:"invited_by"`
+	ExpiresAt      time.Time  `json:"expires_at" db:"expires_at"`
+	AcceptedAt     *time.Time `json:"accepted_at,omitempty" db:"accepted_at"`
+	AcceptedBy     string     `json:"accepted_by,omitempty" db:"accepted_by"`
+	RevokedAt      *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+}
+
+// Status renvoie l'état de l'invitation à la date now
+func (i *Invitation) Status(now time.Time) string {
+	switch {
+	case i.AcceptedAt != nil:
+		return InvitationAccepted
+	case i.RevokedAt != nil:
+		return InvitationRevoked
+	case !now.Before(i.ExpiresAt):
+		return InvitationExpired
+	default:
+		return InvitationPending
+	}
+}
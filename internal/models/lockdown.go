@@ -0,0 +1,50 @@
+// filepath: internal/models/lockdown.go
+
+package models
+
+import (
+	"time"
+)
+
+// Période par défaut et maximale (jours) du rapport d'accès d'un confinement
+const (
+	DefaultLockdownReportDays = 30
+	MaxLockdownReportDays     = 90
+)
+
+// Lockdown est le mode de réponse à une compromission d'une organisation
+// (« bouton panique »). À son déclenchement, les tokens d'accès personnels
+// des membres sont révoqués, leurs sessions invalidées et un nouveau mot de
+// passe exigé ; les écritures dans l'organisation sont refusées jusqu'à la
+// levée du confinement.
+type Lockdown struct {
+	ID             string `json:"id" db:"id"`
+	OrganizationID string `json:"organization_id" db:"organization_id"`
+	Reason         string `json:"reason,omitempty" db:"reason"`
+	// ReportDays est la période couverte par le rapport d'accès, jusqu'au déclenchement
+	ReportDays int `json:"report_days" db:"report_days"`
+	// AffectedUsers et RevokedTokens comptent les membres dont les sessions
+	// ont été révoquées et les tokens d'accès personnels, clés d'API et
+	// comptes de service supprimés
+	AffectedUsers int        `json:"affected_users" db:"affected_users"`
+	RevokedTokens int        `json:"revoked_tokens" db:"revoked_tokens"`
+	StartedBy     string     `json:"started_by" db:"started_by"`
+	StartedAt     time.Time  `json:"started_at" db:"started_at"`
+	LiftedBy      string     `json:"lifted_by,omitempty" db:"lifted_by"`
+	LiftedAt      *time.Time `json:"lifted_at,omitempty" db:"lifted_at"`
+}
+
+// Active indique si les écritures de l'organisation sont gelées
+func (l *Lockdown) Active() bool {
+	return l.LiftedAt == nil
+}
+
+// LockdownReport récapitule les accès aux secrets de l'organisation pendant
+// les jours précédant un confinement, pour évaluer l'étendue de la compromission
+type LockdownReport struct {
+	Lockdown *Lockdown             `json:"lockdown"`
+	Since    time.Time             `json:"since"`
+	Until    time.Time             `json:"until"`
+	Members  []*OrganizationMember `json:"members"`
+	Accesses []*SecretAccess       `json:"accesses"`
+}
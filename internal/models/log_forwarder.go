@@ -0,0 +1,80 @@
+// filepath: internal/models/log_forwarder.go
+
+package models
+
+import (
+	"slices"
+	"time"
+)
+
+// Types de destinations des journaux d'audit d'une organisation
+const (
+	ForwarderHTTPS  = "https"
+	ForwarderS3     = "s3"
+	ForwarderSyslog = "syslog"
+)
+
+// LogForwardingPlans sont les plans qui incluent le transfert des journaux d'audit
+var LogForwardingPlans = []string{"plan-business", "plan-enterprise"}
+
+// LogForwarder transfère les événements d'audit d'une organisation vers une
+// destination choisie par ses administrateurs (SIEM, stockage d'archives)
+type LogForwarder struct {
+	ID             string `json:"id" db:"id"`
+	OrganizationID string `json:"organization_id" db:"organization_id"`
+	Kind           string `json:"kind" db:"kind"`
+	// Destination dépend du type : URL https ; s3://bucket/préfixe ;
+	// tcp+tls://hôte:port, tcp://hôte:port ou udp://hôte:port pour syslog
+	Destination string `json:"destination" db:"destination"`
+	// Region et Endpoint (stockage compatible S3) ne servent qu'au type s3
+	Region   string `json:"region,omitempty" db:"region"`
+	Endpoint string `json:"endpoint,omitempty" db:"endpoint"`
+	// AccessKeyID identifie la clé d'accès S3 dont Secret est la clé secrète ;
+	// pour https, Secret est envoyé en token Bearer. Secret n'est jamais renvoyé.
+	AccessKeyID string `json:"access_key_id,omitempty" db:"access_key_id"`
+	Secret      string `json:"secret,omitempty" db:"secret"`
+	// Methods restreint le transfert aux requêtes de ces méthodes HTTP
+	// (POST, DELETE...), toutes si vide
+	Methods []string `json:"methods" db:"methods"`
+	// Projects restreint le transfert aux requêtes sur ces projets, toutes
+	// (y compris hors projet) si vide
+	Projects []string `json:"projects" db:"projects"`
+	// FailuresOnly ne transfère que les requêtes refusées ou en échec (statut 4xx ou 5xx)
+	FailuresOnly bool      `json:"failures_only" db:"failures_only"`
+	CreatedBy    string    `json:"created_by" db:"created_by"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+}
+
+// Matches indique si l'événement passe les filtres de la destination
+func (f *LogForwarder) Matches(event *AuditEvent) bool {
+	if len(f.Methods) > 0 && !slices.Contains(f.Methods, event.Method) {
+		return false
+	}
+	if len(f.Projects) > 0 && !slices.Contains(f.Projects, event.ProjectID) {
+		return false
+	}
+	return !f.FailuresOnly || event.StatusCode >= 400
+}
+
+// AuditEvent est une requête de l'API sur une organisation, transférée aux
+// destinations de journaux de cette organisation
+type AuditEvent struct {
+	ID             string `json:"id"`
+	OrganizationID string `json:"organization_id"`
+	ProjectID      string `json:"project_id,omitempty"`
+	PrincipalType  string `json:"principal_type"`
+	PrincipalID    string `json:"principal_id"`
+	Method         string `json:"method"`
+	// Route est le modèle de la route (ex: /api/v1/organizations/{orgID}/...)
+	Route      string `json:"route"`
+	Path       string `json:"path"`
+	StatusCode int    `json:"status_code"`
+	IPAddress  string `json:"ip_address"`
+	UserAgent  string `json:"user_agent"`
+	// Reason est le motif de lecture donné par l'appelant (paramètre reason)
+	Reason string `json:"reason,omitempty"`
+	// ImpersonationID signale une requête faite par le support au nom de
+	// l'utilisateur (voir Impersonation)
+	ImpersonationID string    `json:"impersonation_id,omitempty"`
+	Timestamp       time.Time `json:"timestamp"`
+}
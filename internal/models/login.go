@@ -0,0 +1,75 @@
+// filepath: internal/models/login.go
+
+package models
+
+import (
+	"time"
+)
+
+// Anomalies détectées à la connexion
+const (
+	// LoginNewLocation : première connexion de l'utilisateur depuis cette ville
+	LoginNewLocation = "new_location"
+	// LoginImpossibleTravel : la distance depuis la connexion précédente n'a
+	// pas pu être parcourue dans le temps écoulé
+	LoginImpossibleTravel = "impossible_travel"
+)
+
+// LoginEvent est une connexion par mot de passe réussie, située d'après
+// l'adresse IP (Country vide si elle n'a pas pu l'être)
+type LoginEvent struct {
+	ID        string  `json:"id" db:"id"`
+	UserID    string  `json:"user_id" db:"user_id"`
+	IPAddress string  `json:"ip_address" db:"ip_address"`
+	UserAgent string  `json:"user_agent" db:"user_agent"`
+	Country   string  `json:"country,omitempty" db:"country"`
+	City      string  `json:"city,omitempty" db:"city"`
+	Latitude  float64 `json:"latitude,omitempty" db:"latitude"`
+	Longitude float64 `json:"longitude,omitempty" db:"longitude"`
+	// Anomaly est LoginNewLocation, LoginImpossibleTravel ou vide
+	Anomaly string `json:"anomaly,omitempty" db:"anomaly"`
+	// StepUp indique qu'un code TOTP a été exigé à cause de l'anomalie
+	StepUp     bool      `json:"step_up" db:"step_up"`
+	OccurredAt time.Time `json:"occurred_at" db:"occurred_at"`
+}
+
+// Located indique si la connexion a pu être située
+func (e *LoginEvent) Located() bool {
+	return e.Country != ""
+}
+
+// LoginAlertPolicy règle, pour une organisation, les alertes envoyées
+// lorsqu'un de ses membres se connecte de façon inhabituelle
+type LoginAlertPolicy struct {
+	OrganizationID string `json:"organization_id" db:"organization_id"`
+	// NotifyNewLocation et NotifyImpossibleTravel notifient le membre et les
+	// administrateurs de l'organisation (événement suspicious_login)
+	NotifyNewLocation      bool `json:"notify_new_location" db:"notify_new_location"`
+	NotifyImpossibleTravel bool `json:"notify_impossible_travel" db:"notify_impossible_travel"`
+	// StepUp exige un code TOTP lors d'une connexion inhabituelle, même
+	// depuis un appareil de confiance (membres ayant activé
+	// l'authentification multifacteur)
+	StepUp    bool      `json:"step_up" db:"step_up"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// DefaultLoginAlertPolicy renvoie la politique d'une organisation qui n'a
+// rien réglé : les deux alertes sont notifiées, sans code supplémentaire
+func DefaultLoginAlertPolicy(orgID string) *LoginAlertPolicy {
+	return &LoginAlertPolicy{
+		OrganizationID:         orgID,
+		NotifyNewLocation:      true,
+		NotifyImpossibleTravel: true,
+	}
+}
+
+// Notifies indique si la politique notifie une anomalie
+func (p *LoginAlertPolicy) Notifies(anomaly string) bool {
+	switch anomaly {
+	case LoginNewLocation:
+		return p.NotifyNewLocation
+	case LoginImpossibleTravel:
+		return p.NotifyImpossibleTravel
+	}
+	return false
+}
@@ -0,0 +1,25 @@
+// filepath: internal/models/maintenance.go
+
+package models
+
+import "time"
+
+// MaintenanceWindow est une fenêtre de maintenance d'un environnement de
+// l'organisation (tous projets confondus) : elle s'ouvre à chaque
+// occurrence de Schedule, une expression cron évaluée dans Timezone, pour
+// DurationMinutes. Un environnement qui a des fenêtres est protégé : les
+// modifications automatiques n'y sont appliquées que fenêtre ouverte.
+type MaintenanceWindow struct {
+	ID              string    `json:"id" db:"id"`
+	OrganizationID  string    `json:"organization_id" db:"organization_id"`
+	Environment     string    `json:"environment" db:"environment"`
+	Schedule        string    `json:"schedule" db:"schedule"`
+	DurationMinutes int       `json:"duration_minutes" db:"duration_minutes"`
+	Timezone        string    `json:"timezone" db:"timezone"`
+	CreatedBy       string    `json:"created_by" db:"created_by"`
+	CreatedAt       time.Time `json:"created_at" db:"created_at"`
+
+	// État calculé à la lecture
+	Open        bool       `json:"open" db:"-"`
+	NextOpening *time.Time `json:"next_opening,omitempty" db:"-"`
+}
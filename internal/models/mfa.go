@@ -0,0 +1,28 @@
+// filepath: internal/models/mfa.go
+
+package models
+
+import (
+	"time"
+)
+
+// UserMFA est l'authentification multifacteur (TOTP) d'un utilisateur. Elle
+// n'est exigée à la connexion qu'une fois activée par un premier code valide.
+type UserMFA struct {
+	UserID string `json:"user_id" db:"user_id"`
+	// Secret est la clé TOTP partagée, encodée en base32
+	Secret string `json:"-" db:"secret"`
+	// EnabledAt est nil tant que l'inscription n'est pas confirmée
+	EnabledAt *time.Time `json:"enabled_at,omitempty" db:"enabled_at"`
+	// LastStep est le dernier pas de temps accepté : un code ne sert qu'une fois
+	LastStep int64 `json:"-" db:"last_step"`
+	// FailedAttempts compte les codes refusés depuis le dernier code accepté
+	FailedAttempts int        `json:"-" db:"failed_attempts"`
+	LockedUntil    *time.Time `json:"locked_until,omitempty" db:"locked_until"`
+	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+}
+
+// Enabled indique si l'authentification multifacteur est exigée à la connexion
+func (m *UserMFA) Enabled() bool {
+	return m.EnabledAt != nil
+}
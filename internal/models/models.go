@@ -16,6 +16,11 @@ type User struct {
 	Role           string    `json:"role" db:"role"`
 	CreatedAt      time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt      time.Time `json:"updated_at" db:"updated_at"`
+	// PasswordResetRequired refuse la connexion tant que l'utilisateur n'a
+	// pas choisi un nouveau mot de passe (mode incident)
+	PasswordResetRequired bool `json:"password_reset_required" db:"password_reset_required"`
+	// TokensNotBefore révoque les tokens émis avant cette date ; nil si aucun
+	TokensNotBefore *time.Time `json:"-" db:"tokens_not_before"`
 }
 
 // Organization représente une organisation utilisatrice du service
@@ -27,8 +32,14 @@ type Organization struct {
 	CreatedAt   time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
 	OwnerID     string    `json:"owner_id" db:"owner_id"`
+	// DeletedAt est renseigné tant que l'organisation est dans la corbeille
+	DeletedAt *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
 }
 
+// DefaultRegion est la région de résidence des organisations qui n'en ont pas
+// choisi : leurs secrets sont stockés dans le backend Vault principal
+const DefaultRegion = "default"
+
 // Project représente un projet contenant des secrets
 type Project struct {
 	ID             string    `json:"id" db:"id"`
@@ -38,6 +49,10 @@ type Project struct {
 	CreatedAt      time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt      time.Time `json:"updated_at" db:"updated_at"`
 	CreatedBy      string    `json:"created_by" db:"created_by"`
+	// DeletedAt est renseigné tant que le projet est dans la corbeille
+	DeletedAt *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
+	// Ownership désigne les responsables du projet et, par défaut, de ses secrets
+	Ownership
 }
 
 // Environment représente un environnement (dev, staging, prod, etc.)
@@ -63,6 +78,21 @@ type Secret struct {
 	CreatedAt      time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt      time.Time `json:"updated_at" db:"updated_at"`
 	Version        int       `json:"version" db:"version"`
+	// E2E indique une valeur chiffrée par le client pour la clé publique du
+	// projet (enveloppe e2e) : le serveur ne peut pas la déchiffrer
+	E2E bool `json:"e2e,omitempty" db:"-"`
+}
+
+// ProjectEncryptionKey est la clé publique X25519 avec laquelle les clients
+// chiffrent les secrets d'un projet en mode de bout en bout
+type ProjectEncryptionKey struct {
+	ProjectID string `json:"project_id"`
+	Algorithm string `json:"algorithm"`
+	PublicKey string `json:"public_key"`
+	// KeyID est l'empreinte de la clé, reprise dans chaque enveloppe
+	KeyID     string    `json:"key_id"`
+	CreatedBy string    `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
 }
 
 // Subscription représente un abonnement au service
@@ -100,6 +130,47 @@ type UserOrganization struct {
 	UpdatedAt      time.Time `json:"updated_at" db:"updated_at"`
 }
 
+// OrganizationMember représente un membre d'une organisation avec son profil
+type OrganizationMember struct {
+	UserID    string    `json:"user_id" db:"user_id"`
+	Email     string    `json:"email" db:"email"`
+	FirstName string    `json:"first_name" db:"first_name"`
+	LastName  string    `json:"last_name" db:"last_name"`
+	Role      string    `json:"role" db:"role"`
+	JoinedAt  time.Time `json:"joined_at" db:"created_at"`
+}
+
+// MemberSearch filtre la recherche de membres d'une organisation
+type MemberSearch struct {
+	// Query est un début de nom, de prénom ou d'email (insensible à la casse)
+	Query string
+	// Roles restreint la recherche à ces rôles (tous si vide)
+	Roles  []string
+	Limit  int
+	Offset int
+}
+
+// ProjectSummary représente un projet accompagné de ses compteurs
+type ProjectSummary struct {
+	Project
+	SecretsCount int `json:"secrets_count" db:"secrets_count"`
+	// Environments liste, triés, les environnements contenant au moins un secret
+	Environments []string `json:"environments" db:"environments"`
+	// LastActivityAt est la dernière modification du projet ou de l'un de ses secrets
+	LastActivityAt time.Time `json:"last_activity_at" db:"last_activity_at"`
+	// Readme est le README du projet, nil s'il n'en a pas
+	Readme *Readme `json:"readme,omitempty" db:"-"`
+	// EnvironmentReadmes contient les README des environnements, par nom
+	EnvironmentReadmes map[string]*Readme `json:"environment_readmes,omitempty" db:"-"`
+}
+
+// Touch avance LastActivityAt jusqu'à at s'il est plus récent
+func (s *ProjectSummary) Touch(at time.Time) {
+	if at.After(s.LastActivityAt) {
+		s.LastActivityAt = at
+	}
+}
+
 // AuditLog représente une entrée du journal d'audit
 type AuditLog struct {
 	ID             string    `json:"id" db:"id"`
@@ -111,4 +182,25 @@ type AuditLog struct {
 	Timestamp      time.Time `json:"timestamp" db:"timestamp"`
 	IPAddress      string    `json:"ip_address" db:"ip_address"`
 	UserAgent      string    `json:"user_agent" db:"user_agent"`
+	// Reason est le motif donné pour une lecture dans un environnement protégé
+	Reason string `json:"reason,omitempty" db:"reason"`
+}
+
+// AdminAuditLog représente une requête sur une route d'administration,
+// conservée plus longtemps que le journal d'audit standard
+type AdminAuditLog struct {
+	ID          string    `json:"id" db:"id"`
+	Principal   string    `json:"principal" db:"principal"` // type:id de l'appelant
+	Method      string    `json:"method" db:"method"`
+	Route       string    `json:"route" db:"route"`
+	Path        string    `json:"path" db:"path"`
+	RequestBody string    `json:"request_body" db:"request_body"` // corps expurgé des valeurs sensibles
+	StatusCode  int       `json:"status_code" db:"status_code"`
+	DurationMS  int64     `json:"duration_ms" db:"duration_ms"`
+	IPAddress   string    `json:"ip_address" db:"ip_address"`
+	UserAgent   string    `json:"user_agent" db:"user_agent"`
+	Timestamp   time.Time `json:"timestamp" db:"timestamp"`
+	// ImpersonationID signale une requête de l'API faite par le support au
+	// nom d'un utilisateur (voir Impersonation)
+	ImpersonationID string `json:"impersonation_id,omitempty" db:"impersonation_id"`
 }
@@ -0,0 +1,113 @@
+// filepath: internal/models/notification.go
+
+package models
+
+import (
+	"time"
+)
+
+// Types d'événements notifiés aux membres d'une organisation
+const (
+	// NotificationSecretChangedProduction : secret créé, modifié ou supprimé
+	// dans un environnement de production
+	NotificationSecretChangedProduction = "secret_changed_production"
+	// NotificationMemberAdded : nouveau membre dans l'organisation
+	NotificationMemberAdded = "member_added"
+	// NotificationQuotaAlert : nombre de secrets proche de la limite du plan
+	NotificationQuotaAlert = "quota_alert"
+	// NotificationSuspiciousLogin : connexion d'un membre depuis un nouveau
+	// lieu ou trop loin de sa connexion précédente
+	NotificationSuspiciousLogin = "suspicious_login"
+	// NotificationOnboarding : étape de la prise en main franchie, envoyée
+	// à son seul auteur (emails d'accompagnement)
+	NotificationOnboarding = "onboarding"
+)
+
+// NotificationEventTypes liste les types d'événements notifiables
+var NotificationEventTypes = []string{
+	NotificationSecretChangedProduction,
+	NotificationMemberAdded,
+	NotificationQuotaAlert,
+	NotificationSuspiciousLogin,
+	NotificationOnboarding,
+}
+
+// Fréquences des résumés de notification
+const (
+	DigestDaily  = "daily"
+	DigestWeekly = "weekly"
+)
+
+// DigestPeriod renvoie la période couverte par un résumé (0 si la fréquence est inconnue)
+func DigestPeriod(digest string) time.Duration {
+	switch digest {
+	case DigestDaily:
+		return 24 * time.Hour
+	case DigestWeekly:
+		return 7 * 24 * time.Hour
+	}
+	return 0
+}
+
+// NotificationChannels indique les canaux par lesquels un événement est reçu
+type NotificationChannels struct {
+	Email bool `json:"email"`
+	Slack bool `json:"slack"`
+}
+
+// NotificationPreferences sont les réglages de notification d'un utilisateur
+type NotificationPreferences struct {
+	UserID string `json:"user_id" db:"user_id"`
+	// SlackWebhookURL est le webhook entrant Slack de l'utilisateur ;
+	// vide, aucune notification Slack n'est envoyée
+	SlackWebhookURL string `json:"slack_webhook_url,omitempty" db:"slack_webhook_url"`
+	// Events associe chaque type d'événement à ses canaux
+	Events map[string]NotificationChannels `json:"events" db:"-"`
+	// Digest remplace les notifications par événement par un résumé
+	// (DigestDaily, DigestWeekly) envoyé par email ; vide, chaque événement
+	// est notifié dès qu'il survient
+	Digest string `json:"digest,omitempty" db:"digest"`
+	// LastDigestAt est la date du dernier résumé envoyé
+	LastDigestAt *time.Time `json:"last_digest_at,omitempty" db:"last_digest_at"`
+	UpdatedAt    time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// NotificationEvent est un événement notifié, conservé pour les résumés
+type NotificationEvent struct {
+	ID             string    `json:"id" db:"id"`
+	OrganizationID string    `json:"organization_id" db:"organization_id"`
+	Type           string    `json:"type" db:"type"`
+	ActorID        string    `json:"actor_id,omitempty" db:"actor_id"`
+	Subject        string    `json:"subject" db:"subject"`
+	Message        string    `json:"message" db:"message"`
+	OccurredAt     time.Time `json:"occurred_at" db:"occurred_at"`
+	// Schema est le type versionné livré aux webhooks (voir le package
+	// events) et Data le contenu correspondant ; ils ne sont pas conservés
+	Schema string `json:"schema,omitempty" db:"-"`
+	Data   any    `json:"data,omitempty" db:"-"`
+	// Owner limite l'envoi immédiat aux responsables de la ressource
+	// concernée ; vide, tous les membres concernés sont notifiés
+	Owner Ownership `json:"-" db:"-"`
+}
+
+// DefaultNotificationPreferences renvoie les réglages d'un utilisateur qui
+// n'a rien choisi : tous les événements par email, rien sur Slack
+func DefaultNotificationPreferences(userID string) *NotificationPreferences {
+	prefs := &NotificationPreferences{
+		UserID: userID,
+		Events: make(map[string]NotificationChannels, len(NotificationEventTypes)),
+	}
+	for _, eventType := range NotificationEventTypes {
+		prefs.Events[eventType] = NotificationChannels{Email: true}
+	}
+	return prefs
+}
+
+// Channels renvoie les canaux choisis pour un type d'événement. Un type
+// absent des réglages (ajouté depuis) suit les réglages par défaut.
+func (p *NotificationPreferences) Channels(eventType string) NotificationChannels {
+	if channels, ok := p.Events[eventType]; ok {
+		return channels
+	}
+	return NotificationChannels{Email: true}
+}
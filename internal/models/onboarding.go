@@ -0,0 +1,109 @@
+// filepath: internal/models/onboarding.go
+
+package models
+
+import (
+	"time"
+)
+
+// Étapes de la prise en main, dans l'ordre proposé par le tableau de bord
+const (
+	// OnboardingEmailVerified : l'utilisateur a confirmé son adresse email.
+	// Seule étape propre à l'utilisateur, les autres sont partagées par les
+	// membres de l'organisation.
+	OnboardingEmailVerified = "email_verified"
+	// OnboardingProjectCreated : un premier projet existe dans l'organisation
+	OnboardingProjectCreated = "project_created"
+	// OnboardingSecretStored : un premier secret a été enregistré
+	OnboardingSecretStored = "secret_stored"
+	// OnboardingTeammateInvited : un premier membre a été invité
+	OnboardingTeammateInvited = "teammate_invited"
+	// OnboardingCompleted est l'état atteint une fois toutes les étapes franchies
+	OnboardingCompleted = "completed"
+)
+
+// OnboardingSteps liste les étapes de la prise en main dans l'ordre
+var OnboardingSteps = []string{
+	OnboardingEmailVerified,
+	OnboardingProjectCreated,
+	OnboardingSecretStored,
+	OnboardingTeammateInvited,
+}
+
+// OnboardingUserStep indique si l'étape est propre à l'utilisateur (sinon,
+// elle est franchie pour toute l'organisation)
+func OnboardingUserStep(step string) bool {
+	return step == OnboardingEmailVerified
+}
+
+// OnboardingStep est une étape franchie. SubjectID est l'utilisateur pour
+// les étapes qui lui sont propres, l'organisation pour les autres.
+type OnboardingStep struct {
+	SubjectID   string    `json:"-" db:"subject_id"`
+	Step        string    `json:"step" db:"step"`
+	CompletedBy string    `json:"completed_by,omitempty" db:"completed_by"`
+	CompletedAt time.Time `json:"completed_at" db:"completed_at"`
+}
+
+// OnboardingStepStatus décrit une étape de la prise en main, franchie ou non
+type OnboardingStepStatus struct {
+	Step        string     `json:"step"`
+	Completed   bool       `json:"completed"`
+	CompletedBy string     `json:"completed_by,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+// Onboarding est l'avancement de la prise en main d'un utilisateur dans une
+// organisation. State est la première étape non franchie, ou
+// OnboardingCompleted : les étapes peuvent être franchies dans le désordre,
+// le tableau de bord propose toujours la première qui reste.
+type Onboarding struct {
+	UserID         string                 `json:"user_id"`
+	OrganizationID string                 `json:"organization_id"`
+	State          string                 `json:"state"`
+	Steps          []OnboardingStepStatus `json:"steps"`
+	// CompletedAt est la date de la dernière étape, une fois toutes franchies
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+// NewOnboarding calcule l'avancement d'un utilisateur dans une organisation
+// à partir des étapes franchies par l'un et par l'autre
+func NewOnboarding(userID, orgID string, completed []*OnboardingStep) *Onboarding {
+	done := make(map[string]*OnboardingStep, len(completed))
+	for _, step := range completed {
+		subject := orgID
+		if OnboardingUserStep(step.Step) {
+			subject = userID
+		}
+		if step.SubjectID == subject {
+			done[step.Step] = step
+		}
+	}
+
+	onboarding := &Onboarding{
+		UserID:         userID,
+		OrganizationID: orgID,
+		State:          OnboardingCompleted,
+		Steps:          make([]OnboardingStepStatus, 0, len(OnboardingSteps)),
+	}
+	var last time.Time
+	for _, name := range OnboardingSteps {
+		status := OnboardingStepStatus{Step: name}
+		if step, ok := done[name]; ok {
+			completedAt := step.CompletedAt
+			status.Completed = true
+			status.CompletedBy = step.CompletedBy
+			status.CompletedAt = &completedAt
+			if completedAt.After(last) {
+				last = completedAt
+			}
+		} else if onboarding.State == OnboardingCompleted {
+			onboarding.State = name
+		}
+		onboarding.Steps = append(onboarding.Steps, status)
+	}
+	if onboarding.State == OnboardingCompleted {
+		onboarding.CompletedAt = &last
+	}
+	return onboarding
+}
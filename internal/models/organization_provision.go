@@ -0,0 +1,20 @@
+// filepath: internal/models/organization_provision.go
+
+package models
+
+// Ressources de départ créées avec chaque organisation par l'API
+const DefaultProjectName = "default"
+
+// DefaultEnvironments sont les environnements du projet de départ
+var DefaultEnvironments = []string{"dev", "staging", "prod"}
+
+// OrganizationProvision regroupe une organisation et ses ressources de
+// départ, créées dans une même transaction : l'appartenance admin du
+// propriétaire, l'abonnement d'essai, le projet par défaut et ses
+// environnements
+type OrganizationProvision struct {
+	Organization *Organization  `json:"organization"`
+	Subscription *Subscription  `json:"subscription"`
+	Project      *Project       `json:"project"`
+	Environments []*Environment `json:"environments"`
+}
@@ -0,0 +1,54 @@
+// filepath: internal/models/password_policy.go
+
+package models
+
+import (
+	"time"
+)
+
+// Bornes des réglages d'une politique de mot de passe
+const (
+	MaxPasswordPolicyLength = 128
+	// MaxPasswordHistory est aussi le nombre d'anciens mots de passe conservés
+	// par utilisateur
+	MaxPasswordHistory = 24
+)
+
+// PasswordPolicy encadre les mots de passe des membres d'une organisation,
+// choisis à l'inscription (invitation) ou lors d'un changement. Elle
+// s'ajoute à la politique du serveur (PASSWORD_*) : chaque exigence est la
+// plus stricte des deux.
+type PasswordPolicy struct {
+	OrganizationID string `json:"organization_id,omitempty" db:"organization_id"`
+	// MinLength est le nombre minimal de caractères
+	MinLength int `json:"min_length" db:"min_length"`
+	// Classes de caractères exigées ; un symbole est tout caractère qui n'est
+	// ni une lettre ni un chiffre
+	RequireUppercase bool `json:"require_uppercase" db:"require_uppercase"`
+	RequireLowercase bool `json:"require_lowercase" db:"require_lowercase"`
+	RequireDigit     bool `json:"require_digit" db:"require_digit"`
+	RequireSymbol    bool `json:"require_symbol" db:"require_symbol"`
+	// RejectBreached refuse les mots de passe présents dans les fuites
+	// connues (Have I Been Pwned, par k-anonymat)
+	RejectBreached bool `json:"reject_breached" db:"reject_breached"`
+	// HistorySize est le nombre d'anciens mots de passe qui ne peuvent pas
+	// être réutilisés
+	HistorySize int       `json:"history_size" db:"history_size"`
+	UpdatedAt   time.Time `json:"updated_at,omitempty" db:"updated_at"`
+}
+
+// StrictestPasswordPolicy combine des politiques (celle du serveur et celles
+// des organisations d'un utilisateur) : chaque exigence est la plus stricte
+func StrictestPasswordPolicy(policies []*PasswordPolicy) *PasswordPolicy {
+	strictest := &PasswordPolicy{}
+	for _, policy := range policies {
+		strictest.MinLength = max(strictest.MinLength, policy.MinLength)
+		strictest.RequireUppercase = strictest.RequireUppercase || policy.RequireUppercase
+		strictest.RequireLowercase = strictest.RequireLowercase || policy.RequireLowercase
+		strictest.RequireDigit = strictest.RequireDigit || policy.RequireDigit
+		strictest.RequireSymbol = strictest.RequireSymbol || policy.RequireSymbol
+		strictest.RejectBreached = strictest.RejectBreached || policy.RejectBreached
+		strictest.HistorySize = max(strictest.HistorySize, policy.HistorySize)
+	}
+	return strictest
+}
@@ -0,0 +1,92 @@
+// filepath: internal/models/permission.go
+
+package models
+
+// Origines des règles qui déterminent l'accès d'un membre
+const (
+	// PermissionSourceOrganization est le rôle du membre dans l'organisation
+	PermissionSourceOrganization = "organization_role"
+	// PermissionSourceTeam est un rôle accordé à l'une de ses équipes (voir TeamGrant)
+	PermissionSourceTeam = "team_grant"
+	// PermissionSourceRecycleBin masque les secrets d'un projet dans la corbeille
+	PermissionSourceRecycleBin = "recycle_bin"
+	// PermissionSourceSecretLock refuse les modifications d'un secret verrouillé
+	PermissionSourceSecretLock = "secret_lock"
+	// PermissionSourceServiceAccount restreint un compte de service à ses
+	// ressources (voir ServiceAccount.Resources)
+	PermissionSourceServiceAccount = "service_account_scope"
+	// PermissionSourceCustomRole est le rôle personnalisé du membre (voir CustomRole)
+	PermissionSourceCustomRole = "custom_role"
+)
+
+// Effets d'une règle
+const (
+	PermissionAllow = "allow"
+	PermissionDeny  = "deny"
+)
+
+// EffectivePermissions explique l'accès effectif d'un membre à une
+// organisation, un projet, un environnement ou un secret : les actions
+// permises et les règles qui les déterminent
+type EffectivePermissions struct {
+	UserID         string `json:"user_id"`
+	OrganizationID string `json:"organization_id"`
+	ProjectID      string `json:"project_id,omitempty"`
+	Environment    string `json:"environment,omitempty"`
+	Secret         string `json:"secret,omitempty"`
+	// Role est le rôle effectif : le plus élevé des règles allow applicables
+	Role     string `json:"role"`
+	CanRead  bool   `json:"can_read"`
+	CanWrite bool   `json:"can_write"`
+	CanAdmin bool   `json:"can_admin"`
+	// Permissions liste les permissions fines du membre dans l'organisation
+	// (voir CustomRole) ; les administrateurs les ont toutes
+	Permissions []string `json:"permissions"`
+	// Rules liste toutes les règles applicables, y compris celles sans effet
+	Rules []*PermissionRule `json:"rules"`
+}
+
+// PermissionRule est une règle applicable à l'accès d'un membre
+type PermissionRule struct {
+	Source string `json:"source"`
+	Effect string `json:"effect"`
+	// Role est le rôle accordé par une règle allow
+	Role     string `json:"role,omitempty"`
+	TeamID   string `json:"team_id,omitempty"`
+	TeamName string `json:"team_name,omitempty"`
+	// CustomRoleID désigne le rôle personnalisé d'une règle custom_role
+	CustomRoleID string `json:"custom_role_id,omitempty"`
+	ProjectID    string `json:"project_id,omitempty"`
+	Environment  string `json:"environment,omitempty"`
+	// Decisive signale la règle qui donne le rôle effectif, ou qui refuse
+	// l'accès
+	Decisive bool `json:"decisive"`
+	// Hypothetical signale une règle simulée, qui n'est pas encore en place
+	Hypothetical bool   `json:"hypothetical,omitempty"`
+	Detail       string `json:"detail"`
+}
+
+// Deny ajoute une règle qui refuse l'accès ; l'appelant retire les actions
+// qu'elle refuse
+func (p *EffectivePermissions) Deny(rule *PermissionRule) {
+	rule.Effect = PermissionDeny
+	rule.Decisive = true
+	p.Rules = append(p.Rules, rule)
+}
+
+// Actions évaluées par une simulation
+const (
+	PermissionActionRead  = "read"
+	PermissionActionWrite = "write"
+	PermissionActionAdmin = "admin"
+)
+
+// PermissionDecision est le résultat d'une simulation : l'action est-elle
+// permise au principal, et selon quelles règles
+type PermissionDecision struct {
+	PrincipalType string                `json:"principal_type"`
+	PrincipalID   string                `json:"principal_id"`
+	Action        string                `json:"action"`
+	Allowed       bool                  `json:"allowed"`
+	Permissions   *EffectivePermissions `json:"permissions"`
+}
@@ -0,0 +1,79 @@
+// filepath: internal/models/project_template.go
+
+package models
+
+import (
+	"regexp"
+	"slices"
+	"time"
+)
+
+// Bornes du modèle de projet d'une organisation
+const (
+	MaxTemplateEnvironments = 20
+	MaxTemplatePlaceholders = 50
+	MaxTemplateNamePattern  = 200
+)
+
+// ProjectTemplate est le modèle appliqué à chaque projet créé dans une
+// organisation : les environnements créés avec lui, la règle de nommage de
+// son nom et les secrets à renseigner créés dans ses environnements
+type ProjectTemplate struct {
+	OrganizationID string   `json:"organization_id" db:"organization_id"`
+	Environments   []string `json:"environments" db:"environments"`
+	// NamePattern est l'expression régulière que le nom entier des projets
+	// doit respecter ; vide, le nom est libre
+	NamePattern  string              `json:"name_pattern" db:"name_pattern"`
+	Placeholders []PlaceholderSecret `json:"placeholders" db:"placeholders"`
+	UpdatedAt    time.Time           `json:"updated_at" db:"updated_at"`
+}
+
+// PlaceholderSecret est un secret créé sans valeur avec chaque projet, que
+// ses membres doivent renseigner
+type PlaceholderSecret struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// Environments limite le secret à ces environnements du modèle ; vide,
+	// il est créé dans chacun
+	Environments []string `json:"environments,omitempty"`
+}
+
+// DefaultProjectTemplate renvoie le modèle des organisations qui n'en ont
+// pas réglé : les environnements DefaultEnvironments, sans règle de nommage
+// ni secret à renseigner
+func DefaultProjectTemplate(orgID string) *ProjectTemplate {
+	return &ProjectTemplate{
+		OrganizationID: orgID,
+		Environments:   slices.Clone(DefaultEnvironments),
+		Placeholders:   []PlaceholderSecret{},
+	}
+}
+
+// MatchesName indique si name respecte la règle de nommage du modèle. Une
+// règle invalide, refusée à l'enregistrement, ne laisse passer aucun nom.
+func (t *ProjectTemplate) MatchesName(name string) bool {
+	if t.NamePattern == "" {
+		return true
+	}
+	pattern, err := regexp.Compile("^(?:" + t.NamePattern + ")$")
+	return err == nil && pattern.MatchString(name)
+}
+
+// PlaceholdersFor renvoie les secrets à renseigner de l'environnement env
+func (t *ProjectTemplate) PlaceholdersFor(env string) []PlaceholderSecret {
+	var placeholders []PlaceholderSecret
+	for _, placeholder := range t.Placeholders {
+		if len(placeholder.Environments) == 0 || slices.Contains(placeholder.Environments, env) {
+			placeholders = append(placeholders, placeholder)
+		}
+	}
+	return placeholders
+}
+
+// TemplatedProject est un projet créé selon le modèle de son organisation,
+// avec ses environnements et ses secrets à renseigner
+type TemplatedProject struct {
+	*Project
+	Environments []*Environment    `json:"environments"`
+	Placeholders []*SecretMetadata `json:"placeholders"`
+}
@@ -0,0 +1,30 @@
+// filepath: internal/models/read_reason.go
+
+package models
+
+import (
+	"slices"
+	"time"
+)
+
+// MaxReadReasonLength est la longueur maximale du motif d'une lecture
+const MaxReadReasonLength = 500
+
+// AuditActionRead est l'action des entrées du journal d'audit qui
+// enregistrent la lecture motivée de la valeur d'un secret
+const AuditActionRead = "read"
+
+// ReadReasonPolicy désigne les environnements protégés d'une organisation :
+// un utilisateur qui lit la valeur d'un de leurs secrets doit en donner le
+// motif, enregistré dans le journal d'audit (processus de gestion des
+// changements). Les clés d'API, limitées à un environnement, n'en donnent pas.
+type ReadReasonPolicy struct {
+	OrganizationID string    `json:"organization_id" db:"organization_id"`
+	Environments   []string  `json:"environments" db:"environments"`
+	UpdatedAt      time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// Requires indique si la lecture des secrets de env exige un motif
+func (p *ReadReasonPolicy) Requires(env string) bool {
+	return slices.Contains(p.Environments, env)
+}
@@ -0,0 +1,23 @@
+// filepath: internal/models/readme.go
+
+package models
+
+import (
+	"time"
+)
+
+// MaxReadmeLength est la taille maximale d'un README, en octets
+const MaxReadmeLength = 64 * 1024
+
+// Readme documente un projet ou l'un de ses environnements en markdown :
+// procédure de rotation, responsables, contacts. Il accompagne les secrets
+// pour que le contexte reste à côté des valeurs.
+type Readme struct {
+	OrganizationID string `json:"organization_id" db:"organization_id"`
+	ProjectID      string `json:"project_id" db:"project_id"`
+	// Environment est vide pour le README du projet
+	Environment string    `json:"environment,omitempty" db:"environment"`
+	Content     string    `json:"content" db:"content"`
+	UpdatedBy   string    `json:"updated_by" db:"updated_by"`
+	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+}
@@ -0,0 +1,57 @@
+// filepath: internal/models/refresh_token.go
+
+package models
+
+import (
+	"time"
+)
+
+// RefreshToken est un token de rafraîchissement, dont seule l'empreinte est
+// conservée. Chaque rafraîchissement le remplace par un nouveau token de la
+// même session (SessionID, l'ID du premier token de la session) : présenter
+// de nouveau un token remplacé révoque toute la session.
+type RefreshToken struct {
+	ID        string `json:"id" db:"id"`
+	SessionID string `json:"session_id" db:"session_id"`
+	UserID    string `json:"user_id" db:"user_id"`
+	TokenHash string `json:"-" db:"token_hash"`
+	// MFA indique que la session a été ouverte avec un code TOTP
+	MFA       bool   `json:"mfa" db:"mfa"`
+	UserAgent string `json:"user_agent" db:"user_agent"`
+	IPAddress string `json:"ip_address" db:"ip_address"`
+	// SessionStartedAt est la date de connexion de la session
+	SessionStartedAt time.Time `json:"session_started_at" db:"session_started_at"`
+	ExpiresAt        time.Time `json:"expires_at" db:"expires_at"`
+	// RotatedAt est la date à laquelle le token a été remplacé
+	RotatedAt *time.Time `json:"rotated_at,omitempty" db:"rotated_at"`
+	// RevokedAt est la date de révocation de la session (déconnexion,
+	// révocation par l'utilisateur ou réutilisation d'un token remplacé)
+	RevokedAt *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+}
+
+// Session est une session active d'un utilisateur, décrite par son token de
+// rafraîchissement courant
+type Session struct {
+	ID        string    `json:"id"`
+	UserAgent string    `json:"user_agent"`
+	IPAddress string    `json:"ip_address"`
+	StartedAt time.Time `json:"started_at"`
+	// RefreshedAt est la date du dernier rafraîchissement (ou de la connexion)
+	RefreshedAt time.Time `json:"refreshed_at"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	// Current désigne la session de la requête
+	Current bool `json:"current"`
+}
+
+// Session renvoie la session dont le token est le token courant
+func (t *RefreshToken) Session() *Session {
+	return &Session{
+		ID:          t.SessionID,
+		UserAgent:   t.UserAgent,
+		IPAddress:   t.IPAddress,
+		StartedAt:   t.SessionStartedAt,
+		RefreshedAt: t.CreatedAt,
+		ExpiresAt:   t.ExpiresAt,
+	}
+}
@@ -0,0 +1,78 @@
+// filepath: internal/models/registration_policy.go
+
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// Modes d'inscription (POST /auth/register)
+const (
+	// RegistrationOpen accepte toute adresse absente de la liste de refus
+	RegistrationOpen = "open"
+	// RegistrationRestricted n'accepte que les adresses invitées dans une
+	// organisation et celles de la liste d'autorisation
+	RegistrationRestricted = "restricted"
+	// RegistrationClosed refuse toute inscription ; les invitations restent
+	// le seul moyen de créer un compte
+	RegistrationClosed = "closed"
+)
+
+// Nombre maximal d'entrées d'une liste d'autorisation ou de refus
+const MaxRegistrationListSize = 500
+
+// RegistrationPolicy encadre l'inscription libre sur le serveur. Les listes
+// contiennent des domaines (example.com, qui couvre aussi ses
+// sous-domaines) ou des adresses complètes ; la liste de refus l'emporte sur
+// la liste d'autorisation et sur les invitations.
+type RegistrationPolicy struct {
+	Mode      string    `json:"mode" db:"mode"`
+	AllowList []string  `json:"allow_list" db:"allow_list"`
+	DenyList  []string  `json:"deny_list" db:"deny_list"`
+	UpdatedAt time.Time `json:"updated_at,omitempty" db:"updated_at"`
+}
+
+// ValidRegistrationMode indique si mode est un mode d'inscription connu
+func ValidRegistrationMode(mode string) bool {
+	switch mode {
+	case RegistrationOpen, RegistrationRestricted, RegistrationClosed:
+		return true
+	default:
+		return false
+	}
+}
+
+// Allowed indique si l'adresse figure dans la liste d'autorisation
+func (p *RegistrationPolicy) Allowed(email string) bool {
+	return matchesRegistrationList(p.AllowList, email)
+}
+
+// Denied indique si l'adresse figure dans la liste de refus
+func (p *RegistrationPolicy) Denied(email string) bool {
+	return matchesRegistrationList(p.DenyList, email)
+}
+
+// matchesRegistrationList compare une adresse aux entrées d'une liste, sans
+// tenir compte de la casse
+func matchesRegistrationList(list []string, email string) bool {
+	email = strings.ToLower(strings.TrimSpace(email))
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return false
+	}
+	domain := email[at+1:]
+	for _, entry := range list {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		switch {
+		case entry == "":
+		case strings.Contains(entry, "@"):
+			if entry == email {
+				return true
+			}
+		case domain == entry || strings.HasSuffix(domain, "."+entry):
+			return true
+		}
+	}
+	return false
+}
@@ -33,6 +33,40 @@ type SecretMetadata struct {
 	CreatedAt      time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt      time.Time `json:"updated_at" db:"updated_at"`
 	Version        int       `json:"version" db:"version"`
+
+	// Verrou posé par un administrateur : aucune modification ni suppression
+	// n'est acceptée tant que LockedAt est défini
+	LockedBy   string     `json:"locked_by,omitempty" db:"locked_by"`
+	LockedAt   *time.Time `json:"locked_at,omitempty" db:"locked_at"`
+	LockReason string     `json:"lock_reason,omitempty" db:"lock_reason"`
+
+	// E2E indique que la valeur est chiffrée de bout en bout par le client
+	E2E bool `json:"e2e" db:"e2e"`
+
+	// Checksum est la somme de contrôle déterministe de la valeur
+	// (détection de dérive sans lecture de la valeur)
+	Checksum string `json:"checksum,omitempty" db:"checksum"`
+
+	// Mesures de la valeur prises à l'écriture (voir le package hygiene) :
+	// l'hygiène est évaluée sans relire les valeurs. ValueHash est
+	// l'empreinte salée par organisation qui révèle les valeurs réutilisées ;
+	// ValueLength est nul pour les secrets écrits avant les mesures et pour
+	// les secrets chiffrés de bout en bout.
+	ValueHash    string  `json:"-" db:"value_hash"`
+	ValueLength  int     `json:"-" db:"value_length"`
+	ValueEntropy float64 `json:"-" db:"value_entropy"`
+
+	// Hygiene est l'évaluation de la valeur, calculée à la lecture des
+	// métadonnées (nil si la valeur n'a pas été mesurée)
+	Hygiene *SecretHygiene `json:"hygiene,omitempty" db:"-"`
+
+	// Ownership désigne les responsables du secret ; vide, ceux du projet s'appliquent
+	Ownership
+}
+
+// IsLocked indique si le secret est verrouillé
+func (m *SecretMetadata) IsLocked() bool {
+	return m.LockedAt != nil
 }
 
 // ToMetadata convertit un Secret en SecretMetadata (sans la valeur)
@@ -50,3 +84,167 @@ func (s *SecretData) ToMetadata() *SecretMetadata {
 		Version:        s.Version,
 	}
 }
+
+// Types de modification d'un champ entre deux versions
+const (
+	FieldAdded   = "added"
+	FieldRemoved = "removed"
+	FieldChanged = "changed"
+)
+
+// SecretFieldChange décrit la modification d'un champ d'un secret structuré.
+// Les valeurs ne sont jamais incluses, seul le nom du champ l'est.
+type SecretFieldChange struct {
+	Field  string `json:"field"`
+	Change string `json:"change"`
+}
+
+// SecretVersionDiff compare deux versions d'un secret
+type SecretVersionDiff struct {
+	Name        string `json:"name"`
+	FromVersion int    `json:"from_version"`
+	ToVersion   int    `json:"to_version"`
+	// Structured indique que les deux valeurs sont des objets JSON
+	Structured bool `json:"structured"`
+	// Changed indique si la valeur a été modifiée
+	Changed bool `json:"changed"`
+	// DescriptionChanged indique si la description a été modifiée
+	DescriptionChanged bool                 `json:"description_changed"`
+	Fields             []*SecretFieldChange `json:"fields,omitempty"`
+}
+
+// RotationPlan décrit les effets de la rotation d'un secret sans
+// l'effectuer : qui le lit, ce que la rotation exige et quels webhooks
+// seront notifiés
+type RotationPlan struct {
+	OrganizationID string    `json:"organization_id"`
+	ProjectID      string    `json:"project_id"`
+	Environment    string    `json:"environment"`
+	SecretName     string    `json:"secret_name"`
+	Version        int       `json:"version"`
+	Since          time.Time `json:"since"`
+	// LastReadAt est la lecture la plus récente de la période, nil sans lecture
+	LastReadAt   *time.Time             `json:"last_read_at"`
+	Consumers    []*SecretConsumer      `json:"consumers"`
+	Requirements []*RotationRequirement `json:"requirements"`
+	// Ready indique que toutes les exigences sont satisfaites
+	Ready bool `json:"ready"`
+	// Provider est le fournisseur de la rotation gérée, vide sans rotateur
+	Provider string `json:"provider,omitempty"`
+	// EncryptionKeyID est la clé du projet pour laquelle la nouvelle valeur
+	// doit être chiffrée, vide si le projet accepte les valeurs en clair
+	EncryptionKeyID string             `json:"encryption_key_id,omitempty"`
+	Webhooks        []*RotationWebhook `json:"webhooks"`
+}
+
+// RotationRequirement est une condition à remplir avant la rotation
+type RotationRequirement struct {
+	Check     string `json:"check"`
+	Satisfied bool   `json:"satisfied"`
+	Detail    string `json:"detail"`
+}
+
+// RotationWebhook est un webhook qui recevra l'événement de la rotation
+type RotationWebhook struct {
+	ID    string `json:"id"`
+	URL   string `json:"url"`
+	Event string `json:"event"`
+}
+
+// SecretRotator configure la rotation gérée d'un secret chez un
+// fournisseur externe (voir le package rotation). Les identifiants qui
+// autorisent la rotation sont lus dans le secret CredentialsSecret du même
+// environnement.
+type SecretRotator struct {
+	ID                string            `json:"id" db:"id"`
+	OrganizationID    string            `json:"organization_id" db:"organization_id"`
+	ProjectID         string            `json:"project_id" db:"project_id"`
+	Environment       string            `json:"environment" db:"environment"`
+	SecretName        string            `json:"secret_name" db:"secret_name"`
+	Provider          string            `json:"provider" db:"provider"`
+	Config            map[string]string `json:"config" db:"config"`
+	CredentialsSecret string            `json:"credentials_secret" db:"credentials_secret"`
+	CreatedBy         string            `json:"created_by" db:"created_by"`
+	CreatedAt         time.Time         `json:"created_at" db:"created_at"`
+	UpdatedAt         time.Time         `json:"updated_at" db:"updated_at"`
+
+	// Résultat de la dernière rotation : LastError est vide si elle a réussi
+	LastRotatedAt *time.Time `json:"last_rotated_at,omitempty" db:"last_rotated_at"`
+	LastError     string     `json:"last_error,omitempty" db:"last_error"`
+
+	// Strategy vaut RotationReplace ou RotationDual. En rotation double,
+	// l'ancienne valeur reste lisible sous {name}_previous pendant
+	// OverlapSeconds avant d'être retirée et ses identifiants révoqués.
+	Strategy       string `json:"strategy" db:"strategy"`
+	OverlapSeconds int64  `json:"overlap_seconds,omitempty" db:"overlap_seconds"`
+	// PreviousExpiresAt est la date de retrait de {name}_previous, nil s'il n'existe pas
+	PreviousExpiresAt *time.Time `json:"previous_expires_at,omitempty" db:"previous_expires_at"`
+}
+
+// Stratégies de rotation
+const (
+	// RotationReplace remplace la valeur et révoque aussitôt l'ancienne
+	RotationReplace = "replace"
+	// RotationDual garde l'ancienne valeur sous {name}_previous pendant le chevauchement
+	RotationDual = "dual"
+)
+
+// PreviousSecretSuffix est ajouté au nom d'un secret pour conserver sa
+// valeur précédente pendant une rotation double
+const PreviousSecretSuffix = "_previous"
+
+// PreviousSecretName renvoie le nom du secret qui conserve la valeur
+// précédente de name
+func PreviousSecretName(name string) string {
+	return name + PreviousSecretSuffix
+}
+
+// ScheduledSecretChange est une nouvelle valeur de secret en attente : elle
+// remplace la valeur actuelle à EffectiveAt. La valeur est conservée dans
+// Vault, à l'écart des secrets lus, jamais en base.
+type ScheduledSecretChange struct {
+	ID             string    `json:"id" db:"id"`
+	OrganizationID string    `json:"organization_id" db:"organization_id"`
+	ProjectID      string    `json:"project_id" db:"project_id"`
+	Environment    string    `json:"environment" db:"environment"`
+	SecretName     string    `json:"secret_name" db:"secret_name"`
+	EffectiveAt    time.Time `json:"effective_at" db:"effective_at"`
+	CreatedBy      string    `json:"created_by" db:"created_by"`
+	CreatedAt      time.Time `json:"created_at" db:"created_at"`
+}
+
+// Bornes du nombre de caractères révélés à chaque extrémité par un aperçu
+const (
+	DefaultPreviewChars = 4
+	MaxPreviewChars     = 8
+)
+
+// SecretPreview est l'aperçu masqué d'une valeur : quelques caractères de
+// début et de fin et sa longueur, pour reconnaître un identifiant sans le
+// divulguer
+type SecretPreview struct {
+	Name   string `json:"name"`
+	Prefix string `json:"prefix"`
+	Suffix string `json:"suffix"`
+	// Length est la longueur de la valeur en caractères
+	Length int `json:"length"`
+	// Masked est la valeur affichable, partie masquée remplacée par des *
+	Masked string `json:"masked"`
+}
+
+// NewSecretPreview masque value en révélant au plus chars caractères à
+// chaque extrémité. Un quart de la valeur au plus est révélé au total (un
+// huitième de chaque côté) : une valeur courte est entièrement masquée.
+func NewSecretPreview(name, value string, chars int) *SecretPreview {
+	runes := []rune(value)
+	shown := min(chars, len(runes)/8)
+	preview := &SecretPreview{
+		Name:   name,
+		Prefix: string(runes[:shown]),
+		Suffix: string(runes[len(runes)-shown:]),
+		Length: len(runes),
+	}
+	// Le masque a une largeur fixe pour ne pas répéter la longueur
+	preview.Masked = preview.Prefix + "********" + preview.Suffix
+	return preview
+}
@@ -0,0 +1,135 @@
+// filepath: internal/models/service_account.go
+
+package models
+
+import (
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+// MaxSPIFFEIDLength est la longueur maximale d'un identifiant SPIFFE associé
+// à un compte de service
+const MaxSPIFFEIDLength = 512
+
+// MaxTrustDomainLength est la longueur maximale d'un domaine de confiance SPIFFE
+const MaxTrustDomainLength = 255
+
+// MaxCABundleLength est la taille maximale (PEM) des autorités d'une organisation
+const MaxCABundleLength = 64 << 10
+
+// ServiceAccount est un compte machine d'une organisation. Contrairement à
+// une clé d'API, il n'agit pas au nom de son créateur : il échange son
+// secret (flux client_credentials) contre des JWT qui portent sa propre
+// identité, sa permission et les projets et environnements auxquels il a
+// accès. Seule l'empreinte du secret est conservée ; SecretPrefix, ses
+// premiers caractères, permet de le reconnaître.
+type ServiceAccount struct {
+	ID             string `json:"id" db:"id"`
+	OrganizationID string `json:"organization_id" db:"organization_id"`
+	Name           string `json:"name" db:"name"`
+	Description    string `json:"description" db:"description"`
+	SecretHash     string `json:"-" db:"secret_hash"`
+	SecretPrefix   string `json:"secret_prefix" db:"secret_prefix"`
+	// Permission vaut APIKeyRead ou APIKeyReadWrite
+	Permission string `json:"permission" db:"permission"`
+	// Resources liste les projets (et environnements) accessibles
+	Resources []ResourceScope `json:"resources" db:"resources"`
+	// SPIFFEID authentifie le compte par certificat client sur le listener
+	// mTLS (SAN URI spiffe://...), dans le domaine de confiance de son
+	// organisation ; vide, seul son secret l'authentifie
+	SPIFFEID   string     `json:"spiffe_id,omitempty" db:"spiffe_id"`
+	CreatedBy  string     `json:"created_by" db:"created_by"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty" db:"last_used_at"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+}
+
+// ValidSPIFFEID indique si id est un identifiant SPIFFE de charge de travail :
+// spiffe://<domaine de confiance>/<chemin>, sans port, requête ni fragment
+func ValidSPIFFEID(id string) bool {
+	if len(id) > MaxSPIFFEIDLength {
+		return false
+	}
+	u, err := url.Parse(id)
+	if err != nil || u.Scheme != "spiffe" || u.User != nil || u.Port() != "" {
+		return false
+	}
+	if u.Host == "" || u.Host != strings.ToLower(u.Host) {
+		return false
+	}
+	return len(u.Path) > 1 && u.RawQuery == "" && u.Fragment == "" && !u.ForceQuery &&
+		!strings.Contains(u.Path, "//") && !strings.HasSuffix(u.Path, "/")
+}
+
+// ValidTrustDomain indique si domain est un domaine de confiance SPIFFE :
+// minuscules, chiffres, points, tirets et soulignés
+func ValidTrustDomain(domain string) bool {
+	if domain == "" || len(domain) > MaxTrustDomainLength {
+		return false
+	}
+	for _, c := range domain {
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') && c != '.' && c != '-' && c != '_' {
+			return false
+		}
+	}
+	return true
+}
+
+// SPIFFETrustDomain renvoie le domaine de confiance d'un identifiant SPIFFE
+// valide (vide sinon)
+func SPIFFETrustDomain(id string) string {
+	if !ValidSPIFFEID(id) {
+		return ""
+	}
+	u, _ := url.Parse(id)
+	return u.Host
+}
+
+// ServiceAccountTrust est la confiance mTLS d'une organisation : ses comptes
+// de service s'authentifient par des certificats clients signés par l'une
+// des autorités de CABundle (PEM) et dont l'identifiant SPIFFE appartient à
+// TrustDomain. Un domaine de confiance n'appartient qu'à une organisation.
+type ServiceAccountTrust struct {
+	OrganizationID string    `json:"organization_id" db:"organization_id"`
+	TrustDomain    string    `json:"trust_domain" db:"trust_domain"`
+	CABundle       string    `json:"ca_bundle" db:"ca_bundle"`
+	UpdatedBy      string    `json:"updated_by" db:"updated_by"`
+	UpdatedAt      time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// Contains indique si l'identifiant SPIFFE appartient au domaine de confiance
+func (t *ServiceAccountTrust) Contains(spiffeID string) bool {
+	return t.TrustDomain != "" && SPIFFETrustDomain(spiffeID) == t.TrustDomain
+}
+
+// ResourceScope donne accès aux secrets d'un projet, dans un environnement
+// ou dans tous (Environment vide)
+type ResourceScope struct {
+	ProjectID   string `json:"project_id"`
+	Environment string `json:"environment,omitempty"`
+}
+
+// Scopes renvoie les portées des tokens du compte, équivalentes à sa permission
+func (a *ServiceAccount) Scopes() []string {
+	if a.Permission == APIKeyReadWrite {
+		return []string{ScopeSecretsRead, ScopeSecretsWrite, ScopeMetadataRead}
+	}
+	return []string{ScopeSecretsRead, ScopeMetadataRead}
+}
+
+// CoversProject indique si l'une des portées donne accès au projet, dans au
+// moins un environnement
+func CoversProject(resources []ResourceScope, projectID string) bool {
+	return slices.ContainsFunc(resources, func(scope ResourceScope) bool {
+		return scope.ProjectID == projectID
+	})
+}
+
+// CoversEnvironment indique si l'une des portées donne accès à
+// l'environnement du projet
+func CoversEnvironment(resources []ResourceScope, projectID, env string) bool {
+	return slices.ContainsFunc(resources, func(scope ResourceScope) bool {
+		return scope.ProjectID == projectID && (scope.Environment == "" || scope.Environment == env)
+	})
+}
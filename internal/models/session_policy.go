@@ -0,0 +1,61 @@
+// filepath: internal/models/session_policy.go
+
+package models
+
+import (
+	"time"
+)
+
+// Bornes des réglages d'une politique de session
+const (
+	MaxSessionPolicyHours    = 24 * 90
+	MinIdleTimeoutMinutes    = 5
+	MaxSessionPolicySessions = 100
+)
+
+// SessionPolicy limite les sessions des membres d'une organisation. Un
+// réglage à 0 n'impose aucune limite : la configuration globale des tokens
+// (JWT_EXPIRATION_HOURS, JWT_REFRESH_EXPIRATION_HOURS) s'applique.
+type SessionPolicy struct {
+	OrganizationID string `json:"organization_id" db:"organization_id"`
+	// MaxSessionHours est la durée maximale d'une session depuis la
+	// connexion, rafraîchissements compris
+	MaxSessionHours int `json:"max_session_hours" db:"max_session_hours"`
+	// IdleTimeoutMinutes ferme une session qui n'a pas été rafraîchie
+	// pendant ce délai
+	IdleTimeoutMinutes int `json:"idle_timeout_minutes" db:"idle_timeout_minutes"`
+	// MaxSessions est le nombre de sessions simultanées d'un membre : une
+	// nouvelle connexion révoque les plus anciennes
+	MaxSessions int       `json:"max_sessions" db:"max_sessions"`
+	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// MaxLifetime renvoie la durée maximale d'une session (0 sans limite)
+func (p *SessionPolicy) MaxLifetime() time.Duration {
+	return time.Duration(p.MaxSessionHours) * time.Hour
+}
+
+// IdleTimeout renvoie le délai d'inactivité d'une session (0 sans limite)
+func (p *SessionPolicy) IdleTimeout() time.Duration {
+	return time.Duration(p.IdleTimeoutMinutes) * time.Minute
+}
+
+// StrictestSessionPolicy combine les politiques des organisations d'un
+// utilisateur : chaque limite est la plus stricte de celles qui sont réglées
+func StrictestSessionPolicy(policies []*SessionPolicy) *SessionPolicy {
+	strictest := &SessionPolicy{}
+	for _, policy := range policies {
+		strictest.MaxSessionHours = minLimit(strictest.MaxSessionHours, policy.MaxSessionHours)
+		strictest.IdleTimeoutMinutes = minLimit(strictest.IdleTimeoutMinutes, policy.IdleTimeoutMinutes)
+		strictest.MaxSessions = minLimit(strictest.MaxSessions, policy.MaxSessions)
+	}
+	return strictest
+}
+
+// minLimit renvoie la plus petite de deux limites, 0 signifiant « sans limite »
+func minLimit(a, b int) int {
+	if a == 0 || (b != 0 && b < a) {
+		return b
+	}
+	return a
+}
@@ -0,0 +1,74 @@
+// filepath: internal/models/settings_change.go
+
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Éléments de la configuration d'une organisation dont les modifications
+// sont historisées
+const (
+	SettingsResidency           = "residency"
+	SettingsMaintenanceWindow   = "maintenance_window"
+	SettingsWebhook             = "webhook"
+	SettingsSubscription        = "subscription"
+	SettingsLogForwarder        = "log_forwarder"
+	SettingsLoginAlerts         = "login_alerts"
+	SettingsSessionPolicy       = "session_policy"
+	SettingsPasswordPolicy      = "password_policy"
+	SettingsReadReasons         = "read_reasons"
+	SettingsProjectTemplate     = "project_template"
+	SettingsServiceAccountTrust = "service_account_trust"
+)
+
+// Actions historisées
+const (
+	SettingsCreated = "created"
+	SettingsUpdated = "updated"
+	SettingsDeleted = "deleted"
+)
+
+// SettingsChange est une version de la configuration d'une organisation :
+// l'élément modifié, son état avant et après la modification, son auteur
+type SettingsChange struct {
+	ID             string `json:"id" db:"id"`
+	OrganizationID string `json:"organization_id" db:"organization_id"`
+	// Version numérote les modifications de l'organisation à partir de 1
+	Version    int64  `json:"version" db:"version"`
+	Resource   string `json:"resource" db:"resource"`
+	ResourceID string `json:"resource_id" db:"resource_id"`
+	Action     string `json:"action" db:"action"`
+	// ChangedBy est vide pour une modification faite par le système
+	// (facturation, outils d'exploitation)
+	ChangedBy string `json:"changed_by" db:"changed_by"`
+	// Before est null à la création, After à la suppression
+	Before    json.RawMessage `json:"before" db:"before_state"`
+	After     json.RawMessage `json:"after" db:"after_state"`
+	CreatedAt time.Time       `json:"created_at" db:"created_at"`
+}
+
+// NewSettingsChange prépare l'historisation d'une modification ; before et
+// after (nil si absents) sont conservés au format JSON de l'API
+func NewSettingsChange(orgID, resource, resourceID, action, changedBy string, before, after any) (*SettingsChange, error) {
+	change := &SettingsChange{
+		OrganizationID: orgID,
+		Resource:       resource,
+		ResourceID:     resourceID,
+		Action:         action,
+		ChangedBy:      changedBy,
+	}
+	var err error
+	if before != nil {
+		if change.Before, err = json.Marshal(before); err != nil {
+			return nil, err
+		}
+	}
+	if after != nil {
+		if change.After, err = json.Marshal(after); err != nil {
+			return nil, err
+		}
+	}
+	return change, nil
+}
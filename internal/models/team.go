@@ -0,0 +1,84 @@
+// filepath: internal/models/team.go
+
+package models
+
+import (
+	"time"
+)
+
+// Team est une équipe de membres d'une organisation. Les équipes possèdent
+// des projets et des secrets (voir Ownership) et reçoivent leurs
+// notifications.
+type Team struct {
+	ID             string    `json:"id" db:"id"`
+	OrganizationID string    `json:"organization_id" db:"organization_id"`
+	Name           string    `json:"name" db:"name"`
+	Description    string    `json:"description" db:"description"`
+	CreatedBy      string    `json:"created_by" db:"created_by"`
+	CreatedAt      time.Time `json:"created_at" db:"created_at"`
+	// Members et Grants ne sont renseignés que lors de la lecture d'une équipe
+	Members []*TeamMember `json:"members,omitempty" db:"-"`
+	Grants  []*TeamGrant  `json:"grants,omitempty" db:"-"`
+}
+
+// TeamMember est l'appartenance d'un membre de l'organisation à une équipe
+type TeamMember struct {
+	TeamID  string    `json:"team_id" db:"team_id"`
+	UserID  string    `json:"user_id" db:"user_id"`
+	AddedBy string    `json:"added_by" db:"added_by"`
+	AddedAt time.Time `json:"added_at" db:"added_at"`
+}
+
+// TeamGrant accorde aux membres d'une équipe un rôle sur un projet, dans un
+// environnement ou dans tous (Environment vide). Le rôle effectif d'un
+// membre est le plus élevé de son rôle dans l'organisation et des rôles
+// accordés à ses équipes.
+type TeamGrant struct {
+	TeamID      string    `json:"team_id" db:"team_id"`
+	ProjectID   string    `json:"project_id" db:"project_id"`
+	Environment string    `json:"environment,omitempty" db:"environment"`
+	Role        string    `json:"role" db:"role"`
+	GrantedBy   string    `json:"granted_by" db:"granted_by"`
+	GrantedAt   time.Time `json:"granted_at" db:"granted_at"`
+}
+
+// Covers indique si l'octroi s'applique à l'environnement du projet. Sans
+// environnement (env vide), seuls les octrois sur tout le projet s'appliquent.
+func (g *TeamGrant) Covers(projectID, env string) bool {
+	return g.ProjectID == projectID && (g.Environment == "" || g.Environment == env)
+}
+
+// Ownership désigne les responsables d'un projet ou d'un secret : un membre
+// (OwnerID) et/ou une équipe (TeamID). Un secret sans responsable relève de
+// ceux de son projet (voir Or).
+type Ownership struct {
+	OwnerID string `json:"owner_id,omitempty" db:"owner_id"`
+	TeamID  string `json:"team_id,omitempty" db:"team_id"`
+}
+
+// IsZero indique qu'aucun responsable n'est désigné
+func (o Ownership) IsZero() bool {
+	return o.OwnerID == "" && o.TeamID == ""
+}
+
+// Or renvoie les responsables, ou fallback si aucun n'est désigné
+func (o Ownership) Or(fallback Ownership) Ownership {
+	if o.IsZero() {
+		return fallback
+	}
+	return o
+}
+
+// OwnedBy indique si userID est le responsable désigné ou appartient à
+// l'une des équipes teamIDs responsables
+func (o Ownership) OwnedBy(userID string, teamIDs []string) bool {
+	if o.OwnerID != "" && o.OwnerID == userID {
+		return true
+	}
+	for _, id := range teamIDs {
+		if o.TeamID != "" && o.TeamID == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,43 @@
+// filepath: internal/models/token.go
+
+package models
+
+import (
+	"time"
+)
+
+// Portées des tokens d'accès personnels. Une session (JWT) a toutes les
+// portées ; les droits de l'utilisateur dans l'organisation s'appliquent
+// dans tous les cas.
+const (
+	// ScopeSecretsRead permet de lire les valeurs des secrets
+	ScopeSecretsRead = "secrets:read"
+	// ScopeSecretsWrite permet de créer, modifier, supprimer et verrouiller des secrets
+	ScopeSecretsWrite = "secrets:write"
+	// ScopeMetadataRead permet de lire tout le reste (organisations, projets,
+	// membres, métadonnées des secrets, usage...)
+	ScopeMetadataRead = "metadata:read"
+	// ScopeMetadataWrite permet toutes les autres modifications
+	ScopeMetadataWrite = "metadata:write"
+)
+
+// TokenScopes liste les portées connues
+var TokenScopes = []string{ScopeSecretsRead, ScopeSecretsWrite, ScopeMetadataRead, ScopeMetadataWrite}
+
+// PersonalAccessToken est un token d'accès personnel permettant à un script
+// d'agir au nom d'un utilisateur. Seule l'empreinte du token est conservée ;
+// Prefix, ses premiers caractères, permet de le reconnaître. Resources
+// restreint le token à des projets, voire à des environnements ; vide, le
+// token couvre tous les projets de l'utilisateur.
+type PersonalAccessToken struct {
+	ID         string          `json:"id" db:"id"`
+	UserID     string          `json:"user_id" db:"user_id"`
+	Name       string          `json:"name" db:"name"`
+	TokenHash  string          `json:"-" db:"token_hash"`
+	Prefix     string          `json:"prefix" db:"prefix"`
+	Scopes     []string        `json:"scopes" db:"scopes"`
+	Resources  []ResourceScope `json:"resources,omitempty" db:"resources"`
+	ExpiresAt  time.Time       `json:"expires_at" db:"expires_at"`
+	LastUsedAt *time.Time      `json:"last_used_at,omitempty" db:"last_used_at"`
+	CreatedAt  time.Time       `json:"created_at" db:"created_at"`
+}
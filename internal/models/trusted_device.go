@@ -0,0 +1,24 @@
+// filepath: internal/models/trusted_device.go
+
+package models
+
+import (
+	"time"
+)
+
+// TrustedDevice est un appareil sur lequel l'utilisateur a demandé, après un
+// code TOTP, à ne plus en présenter jusqu'à ExpiresAt. L'appareil est
+// reconnu par un cookie dont seule l'empreinte est conservée, et par
+// l'empreinte de son navigateur (Fingerprint).
+type TrustedDevice struct {
+	ID          string     `json:"id" db:"id"`
+	UserID      string     `json:"user_id" db:"user_id"`
+	Name        string     `json:"name" db:"name"`
+	TokenHash   string     `json:"-" db:"token_hash"`
+	Fingerprint string     `json:"-" db:"fingerprint"`
+	UserAgent   string     `json:"user_agent" db:"user_agent"`
+	IPAddress   string     `json:"ip_address" db:"ip_address"`
+	ExpiresAt   time.Time  `json:"expires_at" db:"expires_at"`
+	LastUsedAt  *time.Time `json:"last_used_at,omitempty" db:"last_used_at"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+}
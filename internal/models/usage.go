@@ -0,0 +1,126 @@
+// filepath: internal/models/usage.go
+
+package models
+
+import (
+	"time"
+)
+
+// APICall représente un appel API attribué à un principal
+type APICall struct {
+	OrganizationID string    `json:"organization_id" db:"organization_id"`
+	PrincipalType  string    `json:"principal_type" db:"principal_type"` // user, api_key, ...
+	PrincipalID    string    `json:"principal_id" db:"principal_id"`
+	Route          string    `json:"route" db:"route"` // méthode + modèle de route
+	Timestamp      time.Time `json:"timestamp" db:"-"`
+}
+
+// APICallCount est un nombre d'appels accumulés pour une même organisation,
+// un même jour, un même principal et une même route
+type APICallCount struct {
+	OrganizationID string `json:"organization_id" db:"organization_id"`
+	Day            string `json:"day" db:"day"` // AAAA-MM-JJ, UTC
+	PrincipalType  string `json:"principal_type" db:"principal_type"`
+	PrincipalID    string `json:"principal_id" db:"principal_id"`
+	Route          string `json:"route" db:"route"`
+	Calls          int64  `json:"calls" db:"calls"`
+}
+
+// Count renvoie le décompte d'un appel unique
+func (c *APICall) Count() *APICallCount {
+	return &APICallCount{
+		OrganizationID: c.OrganizationID,
+		Day:            c.Timestamp.UTC().Format("2006-01-02"),
+		PrincipalType:  c.PrincipalType,
+		PrincipalID:    c.PrincipalID,
+		Route:          c.Route,
+		Calls:          1,
+	}
+}
+
+// UsageCount est le nombre d'appels pour une clé de regroupement
+type UsageCount struct {
+	Key   string `json:"key"`
+	Calls int64  `json:"calls"`
+}
+
+// UsageBreakdown détaille les appels API d'une organisation sur une période
+type UsageBreakdown struct {
+	OrganizationID string        `json:"organization_id"`
+	From           time.Time     `json:"from"`
+	To             time.Time     `json:"to"`
+	Total          int64         `json:"total"`
+	ByPrincipal    []*UsageCount `json:"by_principal"`
+	ByRoute        []*UsageCount `json:"by_route"`
+	ByDay          []*UsageCount `json:"by_day"`
+}
+
+// SecretCount compare, pour une organisation, le compteur de secrets enregistré
+// dans usage_statistics au nombre réel de métadonnées de secrets
+type SecretCount struct {
+	OrganizationID string `json:"organization_id"`
+	Recorded       int    `json:"recorded"`
+	Actual         int    `json:"actual"`
+}
+
+// SecretRead représente une lecture de la valeur d'un secret, attribuée au
+// principal et à la charge de travail (en-tête X-Workload) qui l'a effectuée
+type SecretRead struct {
+	OrganizationID string    `json:"organization_id" db:"organization_id"`
+	ProjectID      string    `json:"project_id" db:"project_id"`
+	Environment    string    `json:"environment" db:"environment"`
+	SecretName     string    `json:"secret_name" db:"secret_name"`
+	Workload       string    `json:"workload" db:"workload"` // vide si non déclarée
+	PrincipalType  string    `json:"principal_type" db:"principal_type"`
+	PrincipalID    string    `json:"principal_id" db:"principal_id"`
+	Timestamp      time.Time `json:"timestamp" db:"-"`
+}
+
+// SecretConsumer résume les lectures d'un secret par une charge de travail
+// et un principal sur une période
+type SecretConsumer struct {
+	Workload      string    `json:"workload"`
+	PrincipalType string    `json:"principal_type"`
+	PrincipalID   string    `json:"principal_id"`
+	Reads         int64     `json:"reads"`
+	LastReadAt    time.Time `json:"last_read_at"`
+}
+
+// SecretDependency relie un secret à une charge de travail qui l'a lu
+type SecretDependency struct {
+	Environment string    `json:"environment"`
+	SecretName  string    `json:"secret_name"`
+	Workload    string    `json:"workload"`
+	Reads       int64     `json:"reads"`
+	LastReadAt  time.Time `json:"last_read_at"`
+}
+
+// SecretAccess résume les lectures d'un secret par un principal sur une période
+type SecretAccess struct {
+	PrincipalType string    `json:"principal_type"`
+	PrincipalID   string    `json:"principal_id"`
+	ProjectID     string    `json:"project_id"`
+	Environment   string    `json:"environment"`
+	SecretName    string    `json:"secret_name"`
+	Reads         int64     `json:"reads"`
+	LastReadAt    time.Time `json:"last_read_at"`
+}
+
+// DailySecretReads est le nombre de lectures des secrets d'un environnement
+// d'un projet pendant un jour (UTC)
+type DailySecretReads struct {
+	ProjectID   string `json:"project_id"`
+	Environment string `json:"environment"`
+	Day         string `json:"day"` // AAAA-MM-JJ
+	Reads       int64  `json:"reads"`
+}
+
+// DependencyGraph résume quelles charges de travail ont lu quels secrets
+// d'un projet depuis Since
+type DependencyGraph struct {
+	OrganizationID string              `json:"organization_id"`
+	ProjectID      string              `json:"project_id"`
+	Since          time.Time           `json:"since"`
+	Workloads      []string            `json:"workloads"`
+	Dependencies   []*SecretDependency `json:"dependencies"`
+}
@@ -0,0 +1,52 @@
+// filepath: internal/models/webhook.go
+
+package models
+
+import (
+	"slices"
+	"time"
+)
+
+// Webhook est un point de réception HTTPS d'une organisation. Chaque
+// livraison est signée avec Secret (HMAC-SHA256).
+type Webhook struct {
+	ID             string `json:"id" db:"id"`
+	OrganizationID string `json:"organization_id" db:"organization_id"`
+	URL            string `json:"url" db:"url"`
+	// Secret n'est renvoyé qu'à la création du webhook
+	Secret string `json:"secret,omitempty" db:"secret"`
+	// Events restreint les livraisons à ces types d'événements versionnés
+	// (secret.created.v1...), tous si vide
+	Events    []string  `json:"events" db:"events"`
+	CreatedBy string    `json:"created_by" db:"created_by"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// Subscribes indique si le webhook reçoit les événements de ce type
+func (w *Webhook) Subscribes(eventType string) bool {
+	return len(w.Events) == 0 || slices.Contains(w.Events, eventType)
+}
+
+// WebhookDelivery est une tentative de livraison d'un événement à un webhook
+type WebhookDelivery struct {
+	ID             string `json:"id" db:"id"`
+	WebhookID      string `json:"webhook_id" db:"webhook_id"`
+	OrganizationID string `json:"organization_id" db:"organization_id"`
+	EventType      string `json:"event_type" db:"event_type"`
+	// Payload est le corps JSON envoyé
+	Payload string `json:"payload" db:"payload"`
+	// StatusCode vaut 0 si aucune réponse n'a été reçue (voir Error)
+	StatusCode int `json:"status_code" db:"status_code"`
+	// ResponseBody est le début de la réponse du consommateur
+	ResponseBody string `json:"response_body" db:"response_body"`
+	Error        string `json:"error,omitempty" db:"error"`
+	DurationMS   int64  `json:"duration_ms" db:"duration_ms"`
+	// RedeliveryOf est la livraison rejouée par celle-ci
+	RedeliveryOf string    `json:"redelivery_of,omitempty" db:"redelivery_of"`
+	DeliveredAt  time.Time `json:"delivered_at" db:"delivered_at"`
+}
+
+// Succeeded indique si le consommateur a accepté la livraison (statut 2xx)
+func (d *WebhookDelivery) Succeeded() bool {
+	return d.StatusCode >= 200 && d.StatusCode < 300
+}
@@ -0,0 +1,118 @@
+// filepath: internal/netguard/netguard.go
+
+// Package netguard protège les connexions sortantes vers des destinations
+// choisies par les organisations (webhooks, rotateurs, transferts de
+// journaux) : les adresses internes (boucle locale, réseaux privés,
+// link-local dont les services de métadonnées des clouds...) sont refusées.
+//
+// La vérification a lieu à la connexion, sur l'adresse effectivement
+// contactée après résolution DNS : elle couvre les redirections et les
+// noms qui changent d'adresse entre la validation et l'envoi (DNS
+// rebinding). CheckHost permet en plus de refuser la destination dès son
+// enregistrement.
+package netguard
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+)
+
+// ErrForbiddenDestination indique une destination interne
+var ErrForbiddenDestination = errors.New("destination interdite : adresse interne")
+
+// Préfixes internes ou réservés que netip ne classe pas comme privés
+var reserved = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+	netip.MustParsePrefix("64:ff9b:1::/48"),
+}
+
+// Guard refuse les destinations internes. Sa valeur zéro les refuse toutes ;
+// Allow exempte des préfixes (serveurs de test sur la boucle locale).
+type Guard struct {
+	Allow []netip.Prefix
+}
+
+// Check renvoie ErrForbiddenDestination si ip est une adresse interne
+func (g Guard) Check(ip netip.Addr) error {
+	ip = ip.Unmap()
+	for _, prefix := range g.Allow {
+		if prefix.Contains(ip) {
+			return nil
+		}
+	}
+	if !ip.IsValid() || ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return ErrForbiddenDestination
+	}
+	for _, prefix := range reserved {
+		if prefix.Contains(ip) {
+			return ErrForbiddenDestination
+		}
+	}
+	return nil
+}
+
+// CheckHost résout host et renvoie ErrForbiddenDestination si l'une de ses
+// adresses est interne. Un nom qui ne se résout pas encore est accepté :
+// Dialer vérifiera l'adresse obtenue à la connexion.
+func (g Guard) CheckHost(ctx context.Context, host string) error {
+	if ip, err := netip.ParseAddr(host); err == nil {
+		return g.Check(ip)
+	}
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return nil
+	}
+	for _, addr := range addrs {
+		if err := g.Check(addr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Dialer renvoie un net.Dialer qui refuse de se connecter aux adresses
+// internes
+func (g Guard) Dialer(timeout time.Duration) *net.Dialer {
+	return &net.Dialer{
+		Timeout: timeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return ErrForbiddenDestination
+			}
+			return g.Check(addrPort.Addr())
+		},
+	}
+}
+
+// Transport renvoie un transport HTTP dont les connexions passent par
+// Dialer. Le proxy de l'environnement est ignoré : il contournerait la
+// vérification.
+func (g Guard) Transport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = g.Dialer(30 * time.Second).DialContext
+	return transport
+}
+
+// Client renvoie un client HTTP protégé par Transport
+func (g Guard) Client(timeout time.Duration) *http.Client {
+	return &http.Client{Timeout: timeout, Transport: g.Transport()}
+}
+
+// Loopback exempte la boucle locale (serveurs de test)
+var Loopback = []netip.Prefix{
+	netip.MustParsePrefix("127.0.0.0/8"),
+	netip.MustParsePrefix("::1/128"),
+}
@@ -0,0 +1,66 @@
+// filepath: internal/netguard/netguard_test.go
+
+package netguard
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+)
+
+func TestCheck(t *testing.T) {
+	for addr, forbidden := range map[string]bool{
+		"127.0.0.1":        true,
+		"::1":              true,
+		"10.1.2.3":         true,
+		"172.16.0.1":       true,
+		"192.168.1.1":      true,
+		"169.254.169.254":  true,
+		"fd00:ec2::254":    true,
+		"fe80::1":          true,
+		"100.100.100.200":  true,
+		"0.0.0.0":          true,
+		"::ffff:127.0.0.1": true,
+		"64:ff9b::a00:1":   true,
+		"93.184.216.34":    false,
+		"2606:4700::1111":  false,
+	} {
+		err := Guard{}.Check(netip.MustParseAddr(addr))
+		if forbidden != errors.Is(err, ErrForbiddenDestination) {
+			t.Errorf("Expected %s forbidden=%v, got %v", addr, forbidden, err)
+		}
+	}
+
+	if err := (Guard{Allow: Loopback}).Check(netip.MustParseAddr("127.0.0.1")); err != nil {
+		t.Errorf("Expected the allowed loopback to pass, got %v", err)
+	}
+}
+
+func TestCheckHost(t *testing.T) {
+	if err := (Guard{}).CheckHost(context.Background(), "localhost"); !errors.Is(err, ErrForbiddenDestination) {
+		t.Errorf("Expected localhost to be forbidden, got %v", err)
+	}
+}
+
+func TestClientRefusesInternalConnections(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("internal"))
+	}))
+	defer server.Close()
+
+	// Vérifié à la connexion, quel que soit le nom présenté
+	_, err := Guard{}.Client(time.Second).Get(server.URL)
+	if !errors.Is(err, ErrForbiddenDestination) {
+		t.Fatalf("Expected the connection to be refused, got %v", err)
+	}
+
+	resp, err := Guard{Allow: Loopback}.Client(time.Second).Get(server.URL)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	resp.Body.Close()
+}
@@ -0,0 +1,211 @@
+// filepath: internal/notifications/digest.go
+
+package notifications
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"secrets-manager/internal/metrics"
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+var digestsSent = metrics.NewCounter("notification_digests_sent_total",
+	"Résumés de notification envoyés par fréquence et résultat", "digest", "result")
+
+// Digests envoie par email les résumés quotidiens ou hebdomadaires aux
+// utilisateurs qui les ont choisis à la place des notifications par
+// événement. Chaque résumé couvre, pour chaque organisation de
+// l'utilisateur, les événements conservés par le Dispatcher, les nouveaux
+// membres et les projets de la corbeille purgés avant le prochain résumé.
+type Digests struct {
+	preferences      storage.NotificationPreferencesRepository
+	events           storage.NotificationEventsRepository
+	users            storage.UsersRepository
+	organizations    storage.OrganizationsRepository
+	projects         storage.ProjectsRepository
+	mailer           Mailer
+	recycleRetention time.Duration
+}
+
+// NewDigests crée la tâche des résumés. recycleRetention est la durée de
+// conservation des projets dans la corbeille ; mailer nil désactive les résumés.
+func NewDigests(
+	preferences storage.NotificationPreferencesRepository,
+	events storage.NotificationEventsRepository,
+	users storage.UsersRepository,
+	organizations storage.OrganizationsRepository,
+	projects storage.ProjectsRepository,
+	mailer Mailer,
+	recycleRetention time.Duration,
+) *Digests {
+	return &Digests{
+		preferences:      preferences,
+		events:           events,
+		users:            users,
+		organizations:    organizations,
+		projects:         projects,
+		mailer:           mailer,
+		recycleRetention: recycleRetention,
+	}
+}
+
+// Send envoie les résumés arrivés à échéance : une période après le
+// précédent résumé (ou après le choix de la fréquence). Un résumé vide n'est
+// pas envoyé mais compte comme envoyé. Un échec pour un utilisateur
+// n'empêche pas les autres envois.
+func (d *Digests) Send(ctx context.Context) error {
+	if d.mailer == nil {
+		return nil
+	}
+
+	subscribers, err := d.preferences.ListDigestSubscribers(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, prefs := range subscribers {
+		period := models.DigestPeriod(prefs.Digest)
+		if period == 0 {
+			continue
+		}
+		since := prefs.UpdatedAt
+		if prefs.LastDigestAt != nil {
+			since = *prefs.LastDigestAt
+		}
+		if now.Sub(since) < period {
+			continue
+		}
+
+		if err := d.send(ctx, prefs, since, now, period); err != nil {
+			digestsSent.Inc(prefs.Digest, "error")
+			logger.Warn("envoi d'un résumé de notification impossible",
+				"user_id", prefs.UserID, "digest", prefs.Digest, "error", err)
+			continue
+		}
+		digestsSent.Inc(prefs.Digest, "ok")
+	}
+	return nil
+}
+
+// send construit et envoie le résumé d'un utilisateur pour [since, now)
+func (d *Digests) send(ctx context.Context, prefs *models.NotificationPreferences, since, now time.Time, period time.Duration) error {
+	user, err := d.users.GetUserByID(ctx, prefs.UserID)
+	if err != nil {
+		return err
+	}
+	organizations, err := d.users.GetUserOrganizations(ctx, prefs.UserID)
+	if err != nil {
+		return err
+	}
+
+	var body strings.Builder
+	for _, org := range organizations {
+		section, err := d.organizationSection(ctx, prefs, org, since, now, period)
+		if err != nil {
+			return err
+		}
+		body.WriteString(section)
+	}
+
+	if body.Len() > 0 {
+		subject := fmt.Sprintf("[secrets-manager] Résumé du %s", now.Format("02/01/2006"))
+		if err := d.mailer.SendMail(ctx, user.Email, subject, body.String()); err != nil {
+			return err
+		}
+	}
+	return d.preferences.MarkDigestSent(ctx, prefs.UserID, now)
+}
+
+// organizationSection renvoie la partie du résumé consacrée à une
+// organisation (vide s'il n'y a rien à signaler)
+func (d *Digests) organizationSection(
+	ctx context.Context,
+	prefs *models.NotificationPreferences,
+	org *models.Organization,
+	since, now time.Time,
+	period time.Duration,
+) (string, error) {
+	members, err := d.organizations.ListOrganizationMembers(ctx, org.ID)
+	if err != nil {
+		return "", err
+	}
+	var self *models.OrganizationMember
+	for _, member := range members {
+		if member.UserID == prefs.UserID {
+			self = member
+		}
+	}
+	if self == nil {
+		return "", nil
+	}
+
+	var changes []string
+	events, err := d.events.ListNotificationEvents(ctx, org.ID, since, now)
+	if err != nil {
+		return "", err
+	}
+	for _, event := range events {
+		// Les nouveaux membres sont lus dans la liste des membres
+		if event.Type == models.NotificationMemberAdded || !isRecipient(*event, self) || !subscribed(prefs, event.Type) {
+			continue
+		}
+		changes = append(changes, fmt.Sprintf("%s %s", event.OccurredAt.Format("02/01 15:04"),
+			strings.TrimPrefix(event.Subject, "[secrets-manager] ")))
+	}
+
+	var newMembers []string
+	if self.Role == "admin" && subscribed(prefs, models.NotificationMemberAdded) {
+		for _, member := range members {
+			if member.UserID != prefs.UserID && !member.JoinedAt.Before(since) {
+				newMembers = append(newMembers, fmt.Sprintf("%s (%s)", member.Email, member.Role))
+			}
+		}
+	}
+
+	var expirations []string
+	suspended, err := d.projects.ListSuspendedProjects(ctx, now.Add(period-d.recycleRetention))
+	if err != nil {
+		return "", err
+	}
+	for _, project := range suspended {
+		if project.OrganizationID != org.ID || project.DeletedAt == nil {
+			continue
+		}
+		expirations = append(expirations, fmt.Sprintf("projet %s, purgé le %s", project.Name,
+			project.DeletedAt.Add(d.recycleRetention).Format("02/01/2006 15:04")))
+	}
+
+	if len(changes) == 0 && len(newMembers) == 0 && len(expirations) == 0 {
+		return "", nil
+	}
+
+	var section strings.Builder
+	fmt.Fprintf(&section, "Organisation %s\n", org.Name)
+	writeDigestList(&section, "Changements", changes)
+	writeDigestList(&section, "Nouveaux membres", newMembers)
+	writeDigestList(&section, "Expirations à venir", expirations)
+	section.WriteString("\n")
+	return section.String(), nil
+}
+
+// subscribed indique si l'utilisateur suit un type d'événement, quel que
+// soit le canal choisi
+func subscribed(prefs *models.NotificationPreferences, eventType string) bool {
+	channels := prefs.Channels(eventType)
+	return channels.Email || channels.Slack
+}
+
+func writeDigestList(b *strings.Builder, title string, items []string) {
+	if len(items) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "  %s :\n", title)
+	for _, item := range items {
+		fmt.Fprintf(b, "    - %s\n", item)
+	}
+}
@@ -0,0 +1,83 @@
+// filepath: internal/notifications/digest_test.go
+
+package notifications_test
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/notifications"
+	"secrets-manager/internal/storage/memory"
+)
+
+func TestDigestReplacesPerEventNotifications(t *testing.T) {
+	ctx := context.Background()
+	db := memory.NewDB()
+	users := memory.NewUsersRepository(db)
+	organizations := memory.NewOrganizationsRepository(db)
+	projects := memory.NewProjectsRepository(db)
+	preferences := memory.NewNotificationPreferencesRepository(db)
+	events := memory.NewNotificationEventsRepository(db)
+
+	ids := map[string]string{}
+	for _, email := range []string{"owner@example.com", "dev@example.com"} {
+		user := &models.User{Email: email}
+		if err := users.CreateUser(ctx, user); err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+		ids[email] = user.ID
+	}
+	org := &models.Organization{Name: "acme", OwnerID: ids["owner@example.com"]}
+	if err := organizations.CreateOrganization(ctx, org); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+
+	// Le propriétaire reçoit un résumé quotidien, le dernier date d'hier
+	owner := models.DefaultNotificationPreferences(ids["owner@example.com"])
+	owner.Digest = models.DigestDaily
+	if err := preferences.SetNotificationPreferences(ctx, owner); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if err := preferences.MarkDigestSent(ctx, owner.UserID, time.Now().Add(-25*time.Hour)); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+
+	if err := organizations.AddUserToOrganization(ctx, ids["dev@example.com"], org.ID, "member"); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+
+	out := &outbox{}
+	dispatcher := notifications.NewDispatcher(organizations, preferences, events, out, out, nil, nil)
+	event := notifications.SecretsChanged(org.ID, "p", "prod", notifications.SecretUpdated, ids["dev@example.com"], "API_KEY")
+	if err := dispatcher.Deliver(ctx, event); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if len(out.emails) != 0 {
+		t.Errorf("Expected no per-event email for a digest subscriber, got %v", out.emails)
+	}
+
+	digests := notifications.NewDigests(preferences, events, users, organizations, projects, out, 720*time.Hour)
+	if err := digests.Send(ctx); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if !slices.Equal(out.emails, []string{"owner@example.com"}) {
+		t.Fatalf("Expected one digest for the owner, got %v", out.emails)
+	}
+	for _, expected := range []string{"acme", "API_KEY", "dev@example.com (member)"} {
+		if !strings.Contains(out.bodies[0], expected) {
+			t.Errorf("Expected the digest to mention %q, got:\n%s", expected, out.bodies[0])
+		}
+	}
+
+	// Le résumé suivant n'est dû que dans une période
+	if err := digests.Send(ctx); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if len(out.emails) != 1 {
+		t.Errorf("Expected no second digest before the next period, got %v", out.emails)
+	}
+}
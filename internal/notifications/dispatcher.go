@@ -0,0 +1,390 @@
+// filepath: internal/notifications/dispatcher.go
+
+// Package notifications prévient les membres d'une organisation des
+// événements qui les concernent (secret modifié en production, nouveau
+// membre, quota presque atteint, connexion inhabituelle), par email ou Slack selon les réglages de
+// chacun. Les handlers publient les événements sans attendre ; le
+// Dispatcher les distribue en tâche de fond et les conserve pour les
+// résumés quotidiens ou hebdomadaires (voir Digests).
+package notifications
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"secrets-manager/internal/events"
+	"secrets-manager/internal/logging"
+	"secrets-manager/internal/metrics"
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// queueSize est le nombre d'événements en attente au-delà duquel les
+// nouveaux événements sont abandonnés
+const queueSize = 256
+
+var (
+	logger = logging.For(logging.ComponentJobs)
+
+	notificationsSent = metrics.NewCounter("notifications_sent_total",
+		"Notifications envoyées par type d'événement, canal et résultat", "event", "channel", "result")
+	notificationsDropped = metrics.NewCounter("notifications_dropped_total",
+		"Événements abandonnés faute de place dans la file de notification", "event")
+)
+
+// Event est un événement à notifier aux membres d'une organisation (Type
+// est un des models.Notification*). Son auteur (ActorID) n'en est pas notifié.
+type Event = models.NotificationEvent
+
+// Mailer envoie un email en texte brut
+type Mailer interface {
+	SendMail(ctx context.Context, to, subject, body string) error
+}
+
+// SlackPoster publie un message sur un webhook entrant Slack
+type SlackPoster interface {
+	PostSlack(ctx context.Context, webhookURL, text string) error
+}
+
+// WebhookPublisher livre un événement aux webhooks de son organisation
+type WebhookPublisher interface {
+	Publish(ctx context.Context, event Event) error
+}
+
+// Dispatcher distribue les événements aux membres concernés en respectant
+// leurs réglages de notification
+type Dispatcher struct {
+	organizations storage.OrganizationsRepository
+	preferences   storage.NotificationPreferencesRepository
+	events        storage.NotificationEventsRepository
+	mailer        Mailer
+	slack         SlackPoster
+	webhooks      WebhookPublisher
+	// teams résout les équipes responsables des événements (Event.Owner)
+	teams storage.TeamsRepository
+	queue chan Event
+}
+
+// NewDispatcher crée le distributeur de notifications. mailer, slack ou
+// webhooks nil désactive le canal correspondant ; teams nil ignore les
+// équipes responsables, seul le membre responsable est alors retenu.
+func NewDispatcher(
+	organizations storage.OrganizationsRepository,
+	preferences storage.NotificationPreferencesRepository,
+	events storage.NotificationEventsRepository,
+	mailer Mailer,
+	slack SlackPoster,
+	webhooks WebhookPublisher,
+	teams storage.TeamsRepository,
+) *Dispatcher {
+	return &Dispatcher{
+		organizations: organizations,
+		preferences:   preferences,
+		events:        events,
+		mailer:        mailer,
+		slack:         slack,
+		webhooks:      webhooks,
+		teams:         teams,
+		queue:         make(chan Event, queueSize),
+	}
+}
+
+// Notify publie un événement sans attendre sa distribution. Un Dispatcher
+// nil ignore les événements ; si la file est pleine, l'événement est perdu.
+func (d *Dispatcher) Notify(event Event) {
+	if d == nil {
+		return
+	}
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now()
+	}
+
+	select {
+	case d.queue <- event:
+	default:
+		notificationsDropped.Inc(event.Type)
+		logger.Warn("file de notification pleine, événement abandonné",
+			"event", event.Type, "organization_id", event.OrganizationID)
+	}
+}
+
+// Run distribue les événements publiés jusqu'à l'annulation du contexte
+func (d *Dispatcher) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event := <-d.queue:
+			if err := d.Deliver(ctx, event); err != nil {
+				logger.Error("distribution d'une notification impossible",
+					"event", event.Type, "organization_id", event.OrganizationID, "error", err)
+			}
+		}
+	}
+}
+
+// Deliver enregistre l'événement pour les résumés, le livre aux webhooks de
+// l'organisation puis l'envoie à chaque destinataire par les canaux qu'il a
+// choisis ; les destinataires abonnés à un résumé le recevront avec celui-ci.
+// Un événement qui désigne des responsables (Owner) n'est envoyé qu'à eux,
+// sauf si aucun n'est membre de l'organisation. Un échec d'envoi à un
+// destinataire n'empêche pas les autres envois.
+func (d *Dispatcher) Deliver(ctx context.Context, event Event) error {
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now()
+	}
+	if err := d.events.RecordNotificationEvent(ctx, &event); err != nil {
+		return err
+	}
+	if d.webhooks != nil {
+		if err := d.webhooks.Publish(ctx, event); err != nil {
+			logger.Error("livraison aux webhooks impossible",
+				"event", event.Type, "organization_id", event.OrganizationID, "error", err)
+		}
+	}
+
+	members, err := d.organizations.ListOrganizationMembers(ctx, event.OrganizationID)
+	if err != nil {
+		return err
+	}
+	owners, err := d.owners(ctx, event, members)
+	if err != nil {
+		return err
+	}
+
+	for _, member := range members {
+		if !isRecipient(event, member) {
+			continue
+		}
+		if owners != nil && !owners[member.UserID] {
+			continue
+		}
+
+		prefs, err := d.preferences.GetNotificationPreferences(ctx, member.UserID)
+		if err != nil {
+			return err
+		}
+		if prefs == nil {
+			prefs = models.DefaultNotificationPreferences(member.UserID)
+		}
+		if prefs.Digest != "" {
+			continue
+		}
+		channels := prefs.Channels(event.Type)
+
+		if channels.Email && d.mailer != nil && member.Email != "" {
+			d.send(event, "email", d.mailer.SendMail(ctx, member.Email, event.Subject, event.Message))
+		}
+		if channels.Slack && d.slack != nil && prefs.SlackWebhookURL != "" {
+			d.send(event, "slack", d.slack.PostSlack(ctx, prefs.SlackWebhookURL, event.Subject+"\n"+event.Message))
+		}
+	}
+	return nil
+}
+
+// owners renvoie les responsables de l'événement parmi les membres de
+// l'organisation : le membre désigné et les membres de l'équipe désignée.
+// Renvoie nil si l'événement n'en désigne pas ou si aucun n'est membre,
+// pour que l'événement ne soit pas perdu.
+func (d *Dispatcher) owners(ctx context.Context, event Event, members []*models.OrganizationMember) (map[string]bool, error) {
+	if event.Owner.IsZero() {
+		return nil, nil
+	}
+
+	candidates := make(map[string]bool)
+	if event.Owner.OwnerID != "" {
+		candidates[event.Owner.OwnerID] = true
+	}
+	if event.Owner.TeamID != "" && d.teams != nil {
+		team, err := d.teams.GetTeam(ctx, event.OrganizationID, event.Owner.TeamID)
+		if err != nil && !errors.Is(err, storage.ErrNotFound) {
+			return nil, err
+		}
+		if team != nil {
+			for _, member := range team.Members {
+				candidates[member.UserID] = true
+			}
+		}
+	}
+
+	owners := make(map[string]bool)
+	for _, member := range members {
+		if candidates[member.UserID] {
+			owners[member.UserID] = true
+		}
+	}
+	if len(owners) == 0 {
+		return nil, nil
+	}
+	return owners, nil
+}
+
+// send comptabilise le résultat d'un envoi
+func (d *Dispatcher) send(event Event, channel string, err error) {
+	if err != nil {
+		notificationsSent.Inc(event.Type, channel, "error")
+		logger.Warn("envoi d'une notification impossible",
+			"event", event.Type, "channel", channel, "organization_id", event.OrganizationID, "error", err)
+		return
+	}
+	notificationsSent.Inc(event.Type, channel, "ok")
+}
+
+// isRecipient indique si un membre est concerné par l'événement : les
+// alertes de quota et les nouveaux membres ne sont envoyés qu'aux
+// administrateurs, l'auteur de l'événement n'est jamais notifié, sauf
+// d'une connexion inhabituelle à son compte (envoyée aussi aux administrateurs)
+// et des étapes de sa prise en main (envoyées à lui seul)
+func isRecipient(event Event, member *models.OrganizationMember) bool {
+	switch event.Type {
+	case models.NotificationSuspiciousLogin:
+		return member.UserID == event.ActorID || member.Role == "admin"
+	case models.NotificationOnboarding:
+		return member.UserID == event.ActorID
+	}
+	if member.UserID == event.ActorID {
+		return false
+	}
+	switch event.Type {
+	case models.NotificationQuotaAlert, models.NotificationMemberAdded:
+		return member.Role == "admin"
+	default:
+		return true
+	}
+}
+
+// Actions sur les secrets décrites par SecretsChanged
+const (
+	SecretCreated = "créé"
+	SecretUpdated = "modifié"
+	SecretDeleted = "supprimé"
+)
+
+// secretSchemas associe chaque action au type d'événement versionné livré aux webhooks
+var secretSchemas = map[string]string{
+	SecretCreated: events.SecretCreatedV1,
+	SecretUpdated: events.SecretUpdatedV1,
+	SecretDeleted: events.SecretDeletedV1,
+}
+
+// IsProduction indique si un environnement est un environnement de production
+func IsProduction(env string) bool {
+	switch strings.ToLower(env) {
+	case "prod", "production", "prd":
+		return true
+	}
+	return false
+}
+
+// SecretsChanged décrit la création, la modification ou la suppression
+// (action : SecretCreated, SecretUpdated, SecretDeleted) de secrets d'un
+// environnement. Les valeurs ne sont jamais incluses.
+func SecretsChanged(orgID, projectID, env, action, actorID string, names ...string) Event {
+	return Event{
+		Type:           models.NotificationSecretChangedProduction,
+		OrganizationID: orgID,
+		ActorID:        actorID,
+		Subject: fmt.Sprintf("[secrets-manager] Changement en %s : %s (%s)",
+			env, strings.Join(names, ", "), action),
+		Message: fmt.Sprintf("Action : %s\nSecrets : %s\nProjet : %s\nEnvironnement : %s\nAuteur : %s\n",
+			action, strings.Join(names, ", "), projectID, env, actorID),
+		Schema: secretSchemas[action],
+		Data: events.SecretChanged{
+			ProjectID:   projectID,
+			Environment: env,
+			Names:       names,
+			ActorID:     actorID,
+		},
+	}
+}
+
+// SecretOwners renvoie les responsables d'un changement de secrets : ceux
+// du secret (metadata, éventuellement nil), sinon ceux de son projet. Une
+// erreur de lecture du projet est ignorée : l'événement, sans responsable,
+// va alors à tous les membres.
+func SecretOwners(ctx context.Context, projects storage.ProjectsRepository, orgID, projectID string, metadata *models.SecretMetadata) models.Ownership {
+	if metadata != nil && !metadata.Ownership.IsZero() {
+		return metadata.Ownership
+	}
+	if projects == nil {
+		return models.Ownership{}
+	}
+	project, err := projects.GetProject(ctx, orgID, projectID)
+	if err != nil {
+		return models.Ownership{}
+	}
+	return project.Ownership
+}
+
+// MemberAdded décrit l'arrivée d'un membre dans l'organisation, publiée à
+// l'acceptation d'une invitation
+func MemberAdded(orgID, userID, email, role string) Event {
+	return Event{
+		Type:           models.NotificationMemberAdded,
+		OrganizationID: orgID,
+		ActorID:        userID,
+		Subject:        fmt.Sprintf("[secrets-manager] Nouveau membre : %s", email),
+		Message:        fmt.Sprintf("%s a rejoint l'organisation avec le rôle %s.\n", email, role),
+		Schema:         events.MemberAddedV1,
+		Data:           events.MemberAdded{UserID: userID, Email: email, Role: role},
+	}
+}
+
+// QuotaAlert renvoie l'alerte à émettre lorsque le nombre de secrets d'une
+// organisation vient d'atteindre 80 % puis 100 % de la limite de son plan.
+// Le second résultat est faux si aucun seuil n'est franchi.
+func QuotaAlert(orgID string, count, limit int) (Event, bool) {
+	if limit <= 0 {
+		return Event{}, false
+	}
+	warning := (limit*80 + 99) / 100
+	if count != warning && count != limit {
+		return Event{}, false
+	}
+	return Event{
+		Type:           models.NotificationQuotaAlert,
+		OrganizationID: orgID,
+		Subject:        fmt.Sprintf("[secrets-manager] %d secrets sur %d autorisés", count, limit),
+		Message: fmt.Sprintf("L'organisation utilise %d secrets sur les %d de son plan (%d %%).\n",
+			count, limit, count*100/limit),
+		Schema: events.QuotaAlertV1,
+		Data:   events.QuotaAlert{Count: count, Limit: limit, Percent: count * 100 / limit},
+	}, true
+}
+
+// loginAnomalies décrit les anomalies de connexion dans les notifications
+var loginAnomalies = map[string]string{
+	models.LoginNewLocation:      "première connexion depuis ce lieu",
+	models.LoginImpossibleTravel: "déplacement impossible depuis la connexion précédente",
+}
+
+// SuspiciousLogin décrit la connexion inhabituelle d'un membre (anomalie
+// détectée dans login). Le membre et les administrateurs sont notifiés.
+func SuspiciousLogin(orgID, email string, login *models.LoginEvent) Event {
+	place := login.Country
+	if login.City != "" {
+		place = login.City + ", " + login.Country
+	}
+	return Event{
+		Type:           models.NotificationSuspiciousLogin,
+		OrganizationID: orgID,
+		ActorID:        login.UserID,
+		Subject:        fmt.Sprintf("[secrets-manager] Connexion inhabituelle de %s (%s)", email, place),
+		Message: fmt.Sprintf("Compte : %s\nAnomalie : %s\nLieu : %s\nAdresse IP : %s\nDate : %s\n"+
+			"Si vous n'êtes pas à l'origine de cette connexion, changez votre mot de passe et prévenez un administrateur.\n",
+			email, loginAnomalies[login.Anomaly], place, login.IPAddress, login.OccurredAt.UTC().Format(time.RFC3339)),
+		Schema: events.LoginSuspiciousV1,
+		Data: events.SuspiciousLogin{
+			UserID:     login.UserID,
+			Email:      email,
+			Anomaly:    login.Anomaly,
+			IPAddress:  login.IPAddress,
+			Country:    login.Country,
+			City:       login.City,
+			OccurredAt: login.OccurredAt,
+		},
+	}
+}
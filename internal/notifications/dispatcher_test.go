@@ -0,0 +1,158 @@
+// filepath: internal/notifications/dispatcher_test.go
+
+package notifications_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/notifications"
+	"secrets-manager/internal/storage/memory"
+)
+
+// outbox enregistre les emails et messages Slack envoyés
+type outbox struct {
+	emails []string
+	bodies []string
+	slack  []string
+}
+
+func (o *outbox) SendMail(ctx context.Context, to, subject, body string) error {
+	o.emails = append(o.emails, to)
+	o.bodies = append(o.bodies, body)
+	return nil
+}
+
+func (o *outbox) PostSlack(ctx context.Context, webhookURL, text string) error {
+	o.slack = append(o.slack, webhookURL)
+	return nil
+}
+
+func TestDispatcherRespectsPreferences(t *testing.T) {
+	ctx := context.Background()
+	db := memory.NewDB()
+	users := memory.NewUsersRepository(db)
+	organizations := memory.NewOrganizationsRepository(db)
+	preferences := memory.NewNotificationPreferencesRepository(db)
+
+	ids := map[string]string{}
+	for _, email := range []string{"owner@example.com", "dev@example.com", "ops@example.com", "quiet@example.com"} {
+		user := &models.User{Email: email}
+		if err := users.CreateUser(ctx, user); err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+		ids[email] = user.ID
+	}
+	org := &models.Organization{Name: "acme", OwnerID: ids["owner@example.com"]}
+	if err := organizations.CreateOrganization(ctx, org); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	for _, email := range []string{"dev@example.com", "ops@example.com", "quiet@example.com"} {
+		if err := organizations.AddUserToOrganization(ctx, ids[email], org.ID, "member"); err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+	}
+
+	// ops : Slack au lieu de l'email ; quiet : aucun changement en production
+	ops := models.DefaultNotificationPreferences(ids["ops@example.com"])
+	ops.SlackWebhookURL = "https://hooks.slack.com/services/T/B/X"
+	ops.Events[models.NotificationSecretChangedProduction] = models.NotificationChannels{Slack: true}
+	quiet := models.DefaultNotificationPreferences(ids["quiet@example.com"])
+	quiet.Events[models.NotificationSecretChangedProduction] = models.NotificationChannels{}
+	for _, prefs := range []*models.NotificationPreferences{ops, quiet} {
+		if err := preferences.SetNotificationPreferences(ctx, prefs); err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+	}
+
+	out := &outbox{}
+	teams := memory.NewTeamsRepository(db)
+	dispatcher := notifications.NewDispatcher(organizations, preferences,
+		memory.NewNotificationEventsRepository(db), out, out, nil, teams)
+
+	// L'auteur (dev) n'est pas notifié de son propre changement
+	event := notifications.SecretsChanged(org.ID, "p", "prod", notifications.SecretUpdated, ids["dev@example.com"], "API_KEY")
+	if err := dispatcher.Deliver(ctx, event); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if !slices.Equal(out.emails, []string{"owner@example.com"}) {
+		t.Errorf("Expected one email to the owner, got %v", out.emails)
+	}
+	if !slices.Equal(out.slack, []string{ops.SlackWebhookURL}) {
+		t.Errorf("Expected one Slack message for ops, got %v", out.slack)
+	}
+
+	// Un changement d'un secret possédé par une équipe ne va qu'à ses membres
+	team := &models.Team{OrganizationID: org.ID, Name: "ops"}
+	if err := teams.CreateTeam(ctx, team); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if err := teams.AddTeamMember(ctx, &models.TeamMember{TeamID: team.ID, UserID: ids["ops@example.com"]}); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	out.emails, out.slack = nil, nil
+	event.Owner = models.Ownership{TeamID: team.ID}
+	if err := dispatcher.Deliver(ctx, event); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if len(out.emails) != 0 || !slices.Equal(out.slack, []string{ops.SlackWebhookURL}) {
+		t.Errorf("Expected the change for the ops team only, got %v %v", out.emails, out.slack)
+	}
+
+	// Sans responsable membre de l'organisation, tous les membres concernés sont notifiés
+	out.emails, out.slack = nil, nil
+	event.Owner = models.Ownership{OwnerID: "former-member"}
+	if err := dispatcher.Deliver(ctx, event); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if !slices.Equal(out.emails, []string{"owner@example.com"}) || len(out.slack) != 1 {
+		t.Errorf("Expected the change for every member, got %v %v", out.emails, out.slack)
+	}
+
+	// Les alertes de quota ne vont qu'aux administrateurs
+	out.emails, out.slack = nil, nil
+	alert, ok := notifications.QuotaAlert(org.ID, 8, 10)
+	if !ok {
+		t.Fatalf("Expected a quota alert at 80%%")
+	}
+	if err := dispatcher.Deliver(ctx, alert); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if !slices.Equal(out.emails, []string{"owner@example.com"}) || len(out.slack) != 0 {
+		t.Errorf("Expected the quota alert for admins only, got %v %v", out.emails, out.slack)
+	}
+
+	// Une connexion inhabituelle est notifiée au membre concerné et aux administrateurs
+	out.emails, out.slack = nil, nil
+	login := &models.LoginEvent{UserID: ids["dev@example.com"], IPAddress: "81.2.69.1", Country: "GB",
+		City: "London", Anomaly: models.LoginNewLocation}
+	if err := dispatcher.Deliver(ctx, notifications.SuspiciousLogin(org.ID, "dev@example.com", login)); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	slices.Sort(out.emails)
+	if !slices.Equal(out.emails, []string{"dev@example.com", "owner@example.com"}) {
+		t.Errorf("Expected the suspicious login for the member and admins, got %v", out.emails)
+	}
+}
+
+func TestQuotaAlertThresholds(t *testing.T) {
+	tests := []struct {
+		count, limit int
+		expected     bool
+	}{
+		{7, 10, false},
+		{8, 10, true},
+		{9, 10, false},
+		{10, 10, true},
+		{4, 5, true},
+		{1, 0, false},
+	}
+
+	for _, tt := range tests {
+		if _, ok := notifications.QuotaAlert("org", tt.count, tt.limit); ok != tt.expected {
+			t.Errorf("Expected alert=%v for %d/%d, got %v", tt.expected, tt.count, tt.limit, ok)
+		}
+	}
+}
@@ -0,0 +1,24 @@
+// filepath: internal/notifications/invitations.go
+
+package notifications
+
+import (
+	"fmt"
+	"time"
+
+	"secrets-manager/internal/models"
+)
+
+// InvitationMail renvoie le sujet et le corps de l'email d'invitation à
+// rejoindre l'organisation orgName. link contient le token : l'email est
+// le seul endroit où il apparaît.
+func InvitationMail(orgName, inviterEmail string, invitation *models.Invitation, link string) (subject, body string) {
+	subject = fmt.Sprintf("[secrets-manager] Invitation à rejoindre %s", orgName)
+	body = fmt.Sprintf("%s vous invite à rejoindre l'organisation %s avec le rôle %s.\n\n"+
+		"Acceptez l'invitation avant le %s :\n%s\n\n"+
+		"Si vous avez déjà un compte avec cette adresse, l'organisation y sera ajoutée ; "+
+		"sinon, le compte sera créé à l'acceptation.\n"+
+		"Si vous n'attendiez pas cette invitation, ignorez cet email.\n",
+		inviterEmail, orgName, invitation.Role, invitation.ExpiresAt.UTC().Format(time.RFC3339), link)
+	return subject, body
+}
@@ -0,0 +1,57 @@
+// filepath: internal/notifications/onboarding.go
+
+package notifications
+
+import (
+	"fmt"
+	"time"
+
+	"secrets-manager/internal/events"
+	"secrets-manager/internal/models"
+)
+
+// onboardingSteps décrit les étapes de la prise en main dans les emails
+var onboardingSteps = map[string]string{
+	models.OnboardingEmailVerified:   "adresse email confirmée",
+	models.OnboardingProjectCreated:  "premier projet créé",
+	models.OnboardingSecretStored:    "premier secret enregistré",
+	models.OnboardingTeammateInvited: "premier membre invité",
+}
+
+// onboardingHints propose la prochaine étape à la fin des emails
+var onboardingHints = map[string]string{
+	models.OnboardingEmailVerified: "Confirmez votre adresse email depuis le lien reçu à l'inscription " +
+		"(POST /api/v1/me/email-verification pour en recevoir un nouveau).",
+	models.OnboardingProjectCreated: "Créez un premier projet pour regrouper les secrets d'une application.",
+	models.OnboardingSecretStored: "Enregistrez un premier secret dans un environnement du projet, " +
+		"puis lisez-le depuis la CLI ou l'API.",
+	models.OnboardingTeammateInvited: "Invitez un membre de votre équipe dans l'organisation.",
+	models.OnboardingCompleted: "La prise en main est terminée : consultez la documentation pour la rotation " +
+		"des secrets et les webhooks.",
+}
+
+// OnboardingStepCompleted décrit une étape de la prise en main franchie
+// par actorID, et la prochaine étape qui lui est proposée (next, ou
+// models.OnboardingCompleted). Seul l'auteur est notifié.
+func OnboardingStepCompleted(orgID, actorID, step, next string, completedAt time.Time) Event {
+	subject := fmt.Sprintf("[secrets-manager] Prise en main : %s", onboardingSteps[step])
+	if next == models.OnboardingCompleted {
+		subject = "[secrets-manager] Prise en main terminée"
+	}
+	return Event{
+		Type:           models.NotificationOnboarding,
+		OrganizationID: orgID,
+		ActorID:        actorID,
+		Subject:        subject,
+		Message: fmt.Sprintf("Étape franchie : %s.\n\nProchaine étape : %s\n",
+			onboardingSteps[step], onboardingHints[next]),
+		OccurredAt: completedAt,
+		Schema:     events.OnboardingStepCompletedV1,
+		Data: events.OnboardingStepCompleted{
+			Step:        step,
+			NextStep:    next,
+			CompletedBy: actorID,
+			CompletedAt: completedAt,
+		},
+	}
+}
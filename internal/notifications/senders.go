@@ -0,0 +1,79 @@
+// filepath: internal/notifications/senders.go
+
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/smtp"
+	"time"
+)
+
+// SMTPMailer envoie les emails par un relais SMTP sans authentification
+type SMTPMailer struct {
+	address string
+	from    string
+}
+
+var _ Mailer = (*SMTPMailer)(nil)
+
+// NewSMTPMailer crée un expéditeur pour le relais address ("hôte:port")
+func NewSMTPMailer(address, from string) *SMTPMailer {
+	return &SMTPMailer{address: address, from: from}
+}
+
+// SendMail envoie un email en texte brut UTF-8
+func (m *SMTPMailer) SendMail(ctx context.Context, to, subject, body string) error {
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", m.from)
+	fmt.Fprintf(&msg, "To: %s\r\n", to)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	msg.WriteString("Content-Transfer-Encoding: 8bit\r\n\r\n")
+	msg.WriteString(body)
+
+	return smtp.SendMail(m.address, nil, m.from, []string{to}, msg.Bytes())
+}
+
+// SlackClient publie les messages sur les webhooks entrants Slack
+type SlackClient struct {
+	http *http.Client
+}
+
+var _ SlackPoster = (*SlackClient)(nil)
+
+// NewSlackClient crée un client Slack
+func NewSlackClient() *SlackClient {
+	return &SlackClient{http: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// PostSlack publie text sur le webhook
+func (c *SlackClient) PostSlack(ctx context.Context, webhookURL, text string) error {
+	payload, err := json.Marshal(map[string]string{"text": text})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook Slack : statut %d", resp.StatusCode)
+	}
+	return nil
+}
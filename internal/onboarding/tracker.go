@@ -0,0 +1,238 @@
+// filepath: internal/onboarding/tracker.go
+
+// Package onboarding suit la prise en main des nouveaux utilisateurs :
+// adresse email confirmée, premier projet, premier secret, premier membre
+// invité (voir models.OnboardingSteps). Les handlers signalent les étapes
+// franchies au Tracker, qui les enregistre une seule fois et publie un
+// Event à ses abonnés, à l'origine des emails d'accompagnement.
+package onboarding
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"secrets-manager/internal/logging"
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/notifications"
+	"secrets-manager/internal/storage"
+)
+
+var logger = logging.For(logging.ComponentHTTP)
+
+// Event signale une étape franchie pour la première fois
+type Event struct {
+	Step string
+	// UserID est l'auteur de l'étape
+	UserID string
+	// OrganizationID est l'organisation concernée ; une adresse confirmée
+	// est publiée pour chaque organisation de l'utilisateur, une seule fois
+	// sans organisation s'il n'en a pas encore
+	OrganizationID string
+	// Onboarding est l'avancement de l'auteur dans l'organisation après
+	// l'étape (nil sans organisation)
+	Onboarding  *models.Onboarding
+	CompletedAt time.Time
+}
+
+// Tracker enregistre les étapes de la prise en main et calcule
+// l'avancement de chaque utilisateur dans ses organisations
+type Tracker struct {
+	steps    storage.OnboardingRepository
+	users    storage.UsersRepository
+	projects storage.ProjectsRepository
+	secrets  storage.SecretsRepository
+
+	mu          sync.RWMutex
+	subscribers []func(Event)
+}
+
+// NewTracker crée le suivi de la prise en main. projects et secrets
+// servent à rattraper les étapes franchies avant sa mise en service.
+func NewTracker(
+	steps storage.OnboardingRepository,
+	users storage.UsersRepository,
+	projects storage.ProjectsRepository,
+	secrets storage.SecretsRepository,
+) *Tracker {
+	return &Tracker{
+		steps:    steps,
+		users:    users,
+		projects: projects,
+		secrets:  secrets,
+	}
+}
+
+// Subscribe abonne fn aux étapes franchies ensuite. Comme pour events.Bus,
+// la diffusion est synchrone : les abonnés doivent rendre la main rapidement.
+func (t *Tracker) Subscribe(fn func(Event)) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.subscribers = append(t.subscribers, fn)
+}
+
+// Complete enregistre l'étape franchie par actorID. subjectID est
+// l'utilisateur pour une étape qui lui est propre, l'organisation sinon.
+// Une étape déjà franchie n'est ni modifiée ni publiée de nouveau. Un
+// Tracker nil ignore les étapes.
+func (t *Tracker) Complete(ctx context.Context, step, subjectID, actorID string) error {
+	if t == nil {
+		return nil
+	}
+	return t.complete(ctx, &models.OnboardingStep{SubjectID: subjectID, Step: step, CompletedBy: actorID})
+}
+
+// State renvoie l'avancement de l'utilisateur dans l'organisation. Les
+// étapes d'organisation franchies avant la mise en service du suivi (un
+// projet ou un secret existe déjà) sont enregistrées au passage, sans être
+// publiées : elles ne donnent pas lieu à un email d'accompagnement.
+func (t *Tracker) State(ctx context.Context, userID, orgID string) (*models.Onboarding, error) {
+	onboarding, err := t.state(ctx, userID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if onboarding.State == models.OnboardingCompleted {
+		return onboarding, nil
+	}
+
+	reconciled, err := t.reconcile(ctx, onboarding)
+	if err != nil || !reconciled {
+		return onboarding, err
+	}
+	return t.state(ctx, userID, orgID)
+}
+
+func (t *Tracker) state(ctx context.Context, userID, orgID string) (*models.Onboarding, error) {
+	steps, err := t.steps.ListOnboardingSteps(ctx, userID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	return models.NewOnboarding(userID, orgID, steps), nil
+}
+
+// reconcile enregistre, sans les publier, le premier projet et le premier
+// secret existants de l'organisation s'ils manquent à l'avancement
+func (t *Tracker) reconcile(ctx context.Context, onboarding *models.Onboarding) (bool, error) {
+	missing := make(map[string]bool)
+	for _, status := range onboarding.Steps {
+		missing[status.Step] = !status.Completed
+	}
+	if !missing[models.OnboardingProjectCreated] && !missing[models.OnboardingSecretStored] {
+		return false, nil
+	}
+
+	projects, err := t.projects.ListOrganizationProjects(ctx, onboarding.OrganizationID)
+	if err != nil || len(projects) == 0 {
+		return false, err
+	}
+	reconciled := false
+	if missing[models.OnboardingProjectCreated] {
+		first := projects[0]
+		for _, project := range projects {
+			if project.CreatedAt.Before(first.CreatedAt) {
+				first = project
+			}
+		}
+		if _, err := t.steps.CompleteOnboardingStep(ctx, &models.OnboardingStep{
+			SubjectID:   onboarding.OrganizationID,
+			Step:        models.OnboardingProjectCreated,
+			CompletedBy: first.CreatedBy,
+			CompletedAt: first.CreatedAt,
+		}); err != nil {
+			return false, err
+		}
+		reconciled = true
+	}
+	if missing[models.OnboardingSecretStored] {
+		projectIDs := make([]string, 0, len(projects))
+		for _, project := range projects {
+			projectIDs = append(projectIDs, project.ID)
+		}
+		secrets, err := t.secrets.ListSecretsByProjects(ctx, onboarding.OrganizationID, projectIDs, "")
+		if err != nil || len(secrets) == 0 {
+			return reconciled, err
+		}
+		first := secrets[0]
+		for _, secret := range secrets {
+			if secret.CreatedAt.Before(first.CreatedAt) {
+				first = secret
+			}
+		}
+		if _, err := t.steps.CompleteOnboardingStep(ctx, &models.OnboardingStep{
+			SubjectID:   onboarding.OrganizationID,
+			Step:        models.OnboardingSecretStored,
+			CompletedBy: first.CreatedBy,
+			CompletedAt: first.CreatedAt,
+		}); err != nil {
+			return reconciled, err
+		}
+		reconciled = true
+	}
+	return reconciled, nil
+}
+
+// complete enregistre l'étape et la publie si elle vient d'être franchie
+func (t *Tracker) complete(ctx context.Context, step *models.OnboardingStep) error {
+	completed, err := t.steps.CompleteOnboardingStep(ctx, step)
+	if err != nil || !completed {
+		return err
+	}
+
+	orgIDs := []string{step.SubjectID}
+	if models.OnboardingUserStep(step.Step) {
+		organizations, err := t.users.GetUserOrganizations(ctx, step.SubjectID)
+		if err != nil {
+			return err
+		}
+		orgIDs = orgIDs[:0]
+		for _, org := range organizations {
+			orgIDs = append(orgIDs, org.ID)
+		}
+	}
+
+	logger.Info("étape de prise en main franchie", "step", step.Step, "subject_id", step.SubjectID,
+		"user_id", step.CompletedBy)
+	if len(orgIDs) == 0 {
+		t.publish(Event{Step: step.Step, UserID: step.CompletedBy, CompletedAt: step.CompletedAt})
+		return nil
+	}
+	for _, orgID := range orgIDs {
+		onboarding, err := t.state(ctx, step.CompletedBy, orgID)
+		if err != nil {
+			return err
+		}
+		t.publish(Event{
+			Step:           step.Step,
+			UserID:         step.CompletedBy,
+			OrganizationID: orgID,
+			Onboarding:     onboarding,
+			CompletedAt:    step.CompletedAt,
+		})
+	}
+	return nil
+}
+
+func (t *Tracker) publish(event Event) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	for _, fn := range t.subscribers {
+		fn(event)
+	}
+}
+
+// Notify renvoie un abonné qui transmet les étapes franchies au
+// distributeur de notifications : leur auteur reçoit un email
+// d'accompagnement (selon ses réglages) et les webhooks de l'organisation
+// l'événement onboarding.step_completed.v1. Les adresses confirmées sans
+// organisation ne sont pas notifiées.
+func Notify(notifier *notifications.Dispatcher) func(Event) {
+	return func(event Event) {
+		if event.Onboarding == nil {
+			return
+		}
+		notifier.Notify(notifications.OnboardingStepCompleted(event.OrganizationID, event.UserID, event.Step,
+			event.Onboarding.State, event.CompletedAt))
+	}
+}
@@ -0,0 +1,122 @@
+// filepath: internal/preflight/checks.go
+
+package preflight
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"fmt"
+	"net"
+	"strings"
+
+	"secrets-manager/internal/config"
+	mysqldb "secrets-manager/internal/storage/mysql"
+	"secrets-manager/internal/vault"
+)
+
+// Secret JWT livré dans la configuration par défaut, refusé au démarrage
+const defaultJWTSecret = "votre_secret_jwt_très_sécurisé"
+
+// Longueur minimale du secret de signature HS256
+const minJWTSecretLength = 32
+
+// SchemaVersion vérifie que la base est joignable et à jour des migrations
+func SchemaVersion(db *sql.DB) Check {
+	return Check{
+		Name: "Schéma MySQL",
+		Hint: "appliquer les migrations avec DB_AUTO_MIGRATE=true ou vérifier DB_HOST, DB_USER et DB_PASSWORD",
+		Run: func(ctx context.Context) error {
+			if err := db.PingContext(ctx); err != nil {
+				return fmt.Errorf("base injoignable: %w", err)
+			}
+
+			current, err := mysqldb.CurrentSchemaVersion(ctx, db)
+			if err != nil {
+				return err
+			}
+			latest, err := mysqldb.LatestSchemaVersion()
+			if err != nil {
+				return err
+			}
+
+			switch {
+			case current < latest:
+				return fmt.Errorf("version %d, %d attendue : migrations en attente", current, latest)
+			case current > latest:
+				return fmt.Errorf("version %d plus récente que ce binaire (%d)", current, latest)
+			}
+			return nil
+		},
+	}
+}
+
+// VaultHealth vérifie que Vault est joignable et descellé
+func VaultHealth(client *vault.Client) Check {
+	return Check{
+		Name: "Vault joignable et descellé",
+		Hint: "vérifier VAULT_ADDR et desceller Vault (vault operator unseal)",
+		Run:  client.Health,
+	}
+}
+
+// VaultKVMount vérifie que le point de montage KV v2 des secrets existe
+func VaultKVMount(client *vault.Client, mount string) Check {
+	return Check{
+		Name: fmt.Sprintf("Point de montage KV v2 %q", mount),
+		Hint: fmt.Sprintf("activer le moteur : vault secrets enable -path=%s kv-v2, et vérifier les droits de VAULT_TOKEN", mount),
+		Run: func(ctx context.Context) error {
+			return client.CheckKVMount(ctx, mount)
+		},
+	}
+}
+
+// JWTSecret vérifie que la clé de signature des tokens est utilisable. Avec
+// des clés asymétriques qui refusent HS256, JWT_SECRET n'est plus utilisé.
+func JWTSecret(cfg config.JWTConfig) Check {
+	return Check{
+		Name: "Clé de signature JWT",
+		Hint: fmt.Sprintf("définir JWT_SECRET avec au moins %d caractères aléatoires (ex: openssl rand -base64 48)", minJWTSecretLength),
+		Run: func(ctx context.Context) error {
+			switch {
+			case len(cfg.SigningKeys) > 0 && !cfg.AcceptHS256:
+				return nil
+			case cfg.Secret == "":
+				return fmt.Errorf("JWT_SECRET est vide")
+			case cfg.Secret == defaultJWTSecret:
+				return fmt.Errorf("JWT_SECRET a sa valeur par défaut")
+			case len(cfg.Secret) < minJWTSecretLength:
+				return fmt.Errorf("JWT_SECRET trop court (%d caractères)", len(cfg.Secret))
+			}
+			return nil
+		},
+	}
+}
+
+// SMTP vérifie que le serveur SMTP répond avec une bannière 220
+func SMTP(cfg config.SMTPConfig) Check {
+	return Check{
+		Name: fmt.Sprintf("Serveur SMTP %s", cfg.Address),
+		Hint: "vérifier SMTP_ADDRESS (hôte:port) ou le laisser vide pour désactiver l'envoi d'emails",
+		Run: func(ctx context.Context) error {
+			var dialer net.Dialer
+			conn, err := dialer.DialContext(ctx, "tcp", cfg.Address)
+			if err != nil {
+				return err
+			}
+			defer conn.Close()
+
+			if deadline, ok := ctx.Deadline(); ok {
+				conn.SetReadDeadline(deadline)
+			}
+			banner, err := bufio.NewReader(conn).ReadString('\n')
+			if err != nil {
+				return fmt.Errorf("pas de bannière: %w", err)
+			}
+			if !strings.HasPrefix(banner, "220") {
+				return fmt.Errorf("bannière inattendue: %s", strings.TrimSpace(banner))
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,69 @@
+// filepath: internal/preflight/preflight.go
+
+// Package preflight vérifie les dépendances du serveur au démarrage et
+// produit un rapport lisible avec une piste de correction pour chaque échec.
+package preflight
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Délai maximal accordé à chaque vérification
+const checkTimeout = 5 * time.Second
+
+// Check est une vérification de démarrage
+type Check struct {
+	// Name décrit la dépendance vérifiée
+	Name string
+	// Run renvoie une erreur si la dépendance n'est pas utilisable
+	Run func(ctx context.Context) error
+	// Hint indique comment corriger le problème
+	Hint string
+}
+
+// Result est le résultat d'une vérification
+type Result struct {
+	Check    Check
+	Err      error
+	Duration time.Duration
+}
+
+// Run exécute les vérifications dans l'ordre, écrit le rapport dans w
+// et renvoie true si toutes ont réussi.
+func Run(ctx context.Context, w io.Writer, checks ...Check) bool {
+	results := make([]Result, 0, len(checks))
+	for _, check := range checks {
+		checkCtx, cancel := context.WithTimeout(ctx, checkTimeout)
+		start := time.Now()
+		err := check.Run(checkCtx)
+		cancel()
+
+		results = append(results, Result{Check: check, Err: err, Duration: time.Since(start)})
+	}
+
+	return Report(w, results)
+}
+
+// Report écrit le rapport des résultats et renvoie true si tous ont réussi
+func Report(w io.Writer, results []Result) bool {
+	ok := true
+
+	fmt.Fprintln(w, "Vérifications de démarrage :")
+	for _, result := range results {
+		if result.Err == nil {
+			fmt.Fprintf(w, "  [OK]    %s (%s)\n", result.Check.Name, result.Duration.Round(time.Millisecond))
+			continue
+		}
+
+		ok = false
+		fmt.Fprintf(w, "  [ECHEC] %s : %v\n", result.Check.Name, result.Err)
+		if result.Check.Hint != "" {
+			fmt.Fprintf(w, "          -> %s\n", result.Check.Hint)
+		}
+	}
+
+	return ok
+}
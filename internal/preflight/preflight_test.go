@@ -0,0 +1,52 @@
+// filepath: internal/preflight/preflight_test.go
+
+package preflight
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"secrets-manager/internal/config"
+)
+
+func TestJWTSecret(t *testing.T) {
+	tests := []struct {
+		name    string
+		secret  string
+		wantErr bool
+	}{
+		{"Empty secret", "", true},
+		{"Default secret", defaultJWTSecret, true},
+		{"Short secret", "trop-court", true},
+		{"Valid secret", strings.Repeat("k", minJWTSecretLength), false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := JWTSecret(config.JWTConfig{Secret: tc.secret}).Run(t.Context())
+			if (err != nil) != tc.wantErr {
+				t.Errorf("Expected error: %v, got: %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestRunReport(t *testing.T) {
+	var out bytes.Buffer
+	ok := Run(t.Context(), &out,
+		JWTSecret(config.JWTConfig{Secret: strings.Repeat("k", minJWTSecretLength)}),
+		JWTSecret(config.JWTConfig{Secret: ""}),
+	)
+
+	if ok {
+		t.Error("Expected report to fail")
+	}
+	report := out.String()
+	if !strings.Contains(report, "[OK]") || !strings.Contains(report, "[ECHEC]") {
+		t.Errorf("Expected both OK and ECHEC lines, got:\n%s", report)
+	}
+	if !strings.Contains(report, "-> définir JWT_SECRET") {
+		t.Errorf("Expected remediation hint, got:\n%s", report)
+	}
+}
@@ -0,0 +1,210 @@
+// filepath: internal/rotation/aws.go
+
+package rotation
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	awsIAMEndpoint = "https://iam.amazonaws.com"
+	// IAM est un service global signé pour us-east-1
+	awsIAMRegion  = "us-east-1"
+	awsIAMVersion = "2010-05-08"
+)
+
+// AWSAccessKey est la valeur d'un secret qui contient une clé d'accès AWS,
+// ainsi que celle du secret lié qui autorise la rotation
+type AWSAccessKey struct {
+	AccessKeyID     string `json:"access_key_id"`
+	SecretAccessKey string `json:"secret_access_key"`
+	// SessionToken accompagne les identifiants temporaires (STS)
+	SessionToken string `json:"session_token,omitempty"`
+}
+
+// AWSIAM fait tourner la clé d'accès d'un utilisateur IAM : une nouvelle
+// clé est créée puis l'ancienne est supprimée. IAM limite chaque
+// utilisateur à deux clés, l'utilisateur ne doit donc en avoir qu'une.
+//
+// Configuration : user_name (obligatoire). Les appels signés partent
+// toujours vers l'API IAM : son adresse n'est pas configurable.
+type AWSIAM struct {
+	http *http.Client
+	// endpoint et now sont remplacés par les tests
+	endpoint string
+	now      func() time.Time
+}
+
+// Validate vérifie la configuration
+func (a *AWSIAM) Validate(config map[string]string) error {
+	if _, ok := config["endpoint"]; ok {
+		return errors.New("endpoint n'est pas configurable : les appels partent vers l'API IAM d'AWS")
+	}
+	return requireConfig(config, "user_name")
+}
+
+// Issue crée une nouvelle clé d'accès pour l'utilisateur
+func (a *AWSIAM) Issue(ctx context.Context, req Request) (string, error) {
+	var result struct {
+		AccessKey struct {
+			AccessKeyID     string `xml:"AccessKeyId"`
+			SecretAccessKey string `xml:"SecretAccessKey"`
+		} `xml:"CreateAccessKeyResult>AccessKey"`
+	}
+	err := a.call(ctx, req, url.Values{
+		"Action":   {"CreateAccessKey"},
+		"UserName": {req.Config["user_name"]},
+	}, &result)
+	if err != nil {
+		return "", err
+	}
+
+	value, err := json.Marshal(AWSAccessKey{
+		AccessKeyID:     result.AccessKey.AccessKeyID,
+		SecretAccessKey: result.AccessKey.SecretAccessKey,
+	})
+	return string(value), err
+}
+
+// Revoke supprime l'ancienne clé d'accès de l'utilisateur
+func (a *AWSIAM) Revoke(ctx context.Context, req Request) error {
+	var current AWSAccessKey
+	if req.Current == "" || json.Unmarshal([]byte(req.Current), &current) != nil || current.AccessKeyID == "" {
+		// Valeur initiale saisie à la main : aucune clé connue à supprimer
+		return nil
+	}
+	return a.call(ctx, req, url.Values{
+		"Action":      {"DeleteAccessKey"},
+		"UserName":    {req.Config["user_name"]},
+		"AccessKeyId": {current.AccessKeyID},
+	}, nil)
+}
+
+// call appelle une action de l'API IAM, signée avec les identifiants du
+// secret lié, et décode sa réponse XML dans result
+func (a *AWSIAM) call(ctx context.Context, req Request, params url.Values, result any) error {
+	var credentials AWSAccessKey
+	if err := json.Unmarshal([]byte(req.Credentials), &credentials); err != nil || credentials.AccessKeyID == "" {
+		return fmt.Errorf("%w : identifiants AWS invalides dans le secret lié", ErrUpstream)
+	}
+
+	params.Set("Version", awsIAMVersion)
+	body := params.Encode()
+	endpoint := awsIAMEndpoint
+	if a.endpoint != "" {
+		endpoint = a.endpoint
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint+"/", strings.NewReader(body))
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+	now := time.Now
+	if a.now != nil {
+		now = a.now
+	}
+	signV4(httpReq, []byte(body), credentials, awsIAMRegion, "iam", now())
+
+	resp, err := a.http.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("%w : %v", ErrUpstream, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		var failure struct {
+			Code    string `xml:"Error>Code"`
+			Message string `xml:"Error>Message"`
+		}
+		if xml.Unmarshal(data, &failure) == nil && failure.Code != "" {
+			return fmt.Errorf("%w : %s : %s", ErrUpstream, failure.Code, failure.Message)
+		}
+		return fmt.Errorf("%w : statut %d", ErrUpstream, resp.StatusCode)
+	}
+	if result == nil {
+		return nil
+	}
+	return xml.Unmarshal(data, result)
+}
+
+// signV4 signe une requête AWS (Signature Version 4) avec les en-têtes
+// host, content-type et x-amz-date
+func signV4(req *http.Request, body []byte, credentials AWSAccessKey, region, service string, now time.Time) {
+	amzDate := now.UTC().Format("20060102T150405Z")
+	day := amzDate[:8]
+	req.Header.Set("X-Amz-Date", amzDate)
+	if credentials.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", credentials.SessionToken)
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		lower := strings.ToLower(name)
+		if lower == "content-type" || strings.HasPrefix(lower, "x-amz-") {
+			headers[lower] = strings.TrimSpace(strings.Join(values, ","))
+		}
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		strings.ReplaceAll(req.URL.Query().Encode(), "+", "%20"),
+		canonicalHeaders.String(),
+		signedHeaders,
+		sha256Hex(body),
+	}, "\n")
+
+	scope := day + "/" + region + "/" + service + "/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + sha256Hex([]byte(canonicalRequest))
+
+	key := hmacSHA256([]byte("AWS4"+credentials.SecretAccessKey), day)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, service)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		credentials.AccessKeyID, scope, signedHeaders, signature))
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
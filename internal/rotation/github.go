@@ -0,0 +1,133 @@
+// filepath: internal/rotation/github.go
+
+package rotation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const githubAPI = "https://api.github.com"
+
+// GitHubApp remplace le token d'installation d'une application GitHub :
+// un nouveau token est demandé avec la clé privée de l'application (secret
+// lié, au format PEM) puis l'ancien est révoqué. Les tokens d'installation
+// expirent au bout d'une heure, la rotation doit donc être fréquente.
+//
+// Configuration : app_id et installation_id (obligatoires), api_url
+// (https://api.github.com par défaut ; GitHub Enterprise Cloud : une URL
+// https d'un hôte en .ghe.com).
+type GitHubApp struct {
+	http *http.Client
+	// apiURL remplace l'API par défaut dans les tests
+	apiURL string
+}
+
+// Validate vérifie la configuration
+func (g *GitHubApp) Validate(config map[string]string) error {
+	if err := requireConfig(config, "app_id", "installation_id"); err != nil {
+		return err
+	}
+	_, err := g.baseURL(config)
+	return err
+}
+
+// Issue demande un nouveau token d'installation
+func (g *GitHubApp) Issue(ctx context.Context, req Request) (string, error) {
+	key, err := jwt.ParseRSAPrivateKeyFromPEM([]byte(req.Credentials))
+	if err != nil {
+		return "", fmt.Errorf("%w : clé privée de l'application GitHub invalide dans le secret lié", ErrUpstream)
+	}
+	// Marge d'une minute pour les horloges décalées, 10 minutes au plus
+	now := time.Now()
+	appToken, err := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.RegisteredClaims{
+		Issuer:    req.Config["app_id"],
+		IssuedAt:  jwt.NewNumericDate(now.Add(-time.Minute)),
+		ExpiresAt: jwt.NewNumericDate(now.Add(9 * time.Minute)),
+	}).SignedString(key)
+	if err != nil {
+		return "", err
+	}
+
+	path := "/app/installations/" + req.Config["installation_id"] + "/access_tokens"
+	resp, err := g.do(ctx, req, http.MethodPost, path, appToken)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return "", upstreamError(resp)
+	}
+
+	var result struct {
+		Token string `json:"token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil || result.Token == "" {
+		return "", fmt.Errorf("%w : réponse de GitHub invalide", ErrUpstream)
+	}
+	return result.Token, nil
+}
+
+// Revoke révoque l'ancien token d'installation. Un token déjà expiré (401)
+// n'est pas une erreur.
+func (g *GitHubApp) Revoke(ctx context.Context, req Request) error {
+	if req.Current == "" {
+		return nil
+	}
+	resp, err := g.do(ctx, req, http.MethodDelete, "/installation/token", req.Current)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusUnauthorized {
+		return upstreamError(resp)
+	}
+	return nil
+}
+
+func (g *GitHubApp) do(ctx context.Context, req Request, method, path, token string) (*http.Response, error) {
+	base, err := g.baseURL(req.Config)
+	if err != nil {
+		return nil, err
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, method, base+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Accept", "application/vnd.github+json")
+	httpReq.Header.Set("Authorization", "Bearer "+token)
+	httpReq.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+
+	resp, err := g.http.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("%w : %v", ErrUpstream, err)
+	}
+	return resp, nil
+}
+
+// baseURL renvoie l'URL de l'API GitHub de la configuration, vérifiée à
+// chaque appel : le JWT de l'application et les tokens ne partent que vers
+// api.github.com ou un hôte GitHub Enterprise Cloud, en https
+func (g *GitHubApp) baseURL(config map[string]string) (string, error) {
+	apiURL := strings.TrimSpace(config["api_url"])
+	if apiURL == "" {
+		if g.apiURL != "" {
+			return g.apiURL, nil
+		}
+		return githubAPI, nil
+	}
+	u, err := url.Parse(apiURL)
+	if err != nil || u.Scheme != "https" || u.User != nil || u.Port() != "" ||
+		(u.Hostname() != "api.github.com" && !strings.HasSuffix(u.Hostname(), ".ghe.com")) {
+		return "", errors.New("api_url doit être une URL https de api.github.com ou d'un hôte en .ghe.com")
+	}
+	return strings.TrimSuffix(apiURL, "/"), nil
+}
@@ -0,0 +1,116 @@
+// filepath: internal/rotation/retirer.go
+
+package rotation
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"secrets-manager/internal/logging"
+	"secrets-manager/internal/maintenance"
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+	"secrets-manager/internal/vault"
+)
+
+// Retirer retire la valeur précédente des rotations doubles : les
+// identifiants de {name}_previous sont révoqués chez le fournisseur puis le
+// secret est supprimé
+type Retirer struct {
+	rotators     storage.SecretRotatorsRepository
+	secrets      storage.SecretsRepository
+	vaultService *vault.Service
+	providers    *Registry
+	// gate limite les retraits automatiques aux fenêtres de maintenance ; nil les autorise toujours
+	gate *maintenance.Gate
+}
+
+// NewRetirer crée le gestionnaire de retrait des valeurs précédentes
+func NewRetirer(
+	rotators storage.SecretRotatorsRepository,
+	secrets storage.SecretsRepository,
+	vaultService *vault.Service,
+	providers *Registry,
+	gate *maintenance.Gate,
+) *Retirer {
+	return &Retirer{
+		rotators:     rotators,
+		secrets:      secrets,
+		vaultService: vaultService,
+		providers:    providers,
+		gate:         gate,
+	}
+}
+
+// RetireExpired retire les valeurs précédentes dont le chevauchement est
+// terminé. Un échec est consigné dans le rotateur et retenté au passage
+// suivant, sans empêcher les autres retraits. Dans un environnement protégé,
+// le retrait attend la prochaine fenêtre de maintenance.
+func (r *Retirer) RetireExpired(ctx context.Context) error {
+	now := time.Now()
+	expired, err := r.rotators.ListExpiredPreviousValues(ctx, now)
+	if err != nil {
+		return err
+	}
+
+	logger := logging.For(logging.ComponentJobs)
+	for _, rotator := range expired {
+		allowed, err := r.gate.Allowed(ctx, rotator.OrganizationID, rotator.Environment, now)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			continue
+		}
+		if err := r.Retire(ctx, rotator); err != nil {
+			logger.Warn("retrait d'une valeur précédente impossible",
+				"rotator_id", rotator.ID, "organization_id", rotator.OrganizationID, "error", err)
+			if err := r.rotators.RecordSecretRotation(ctx, rotator.ID, time.Now(),
+				"valeur précédente non retirée : "+err.Error()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Retire révoque les identifiants de la valeur précédente du rotateur puis
+// supprime {name}_previous. Une valeur précédente déjà supprimée n'est pas
+// une erreur.
+func (r *Retirer) Retire(ctx context.Context, rotator *models.SecretRotator) error {
+	name := models.PreviousSecretName(rotator.SecretName)
+	previous, err := r.vaultService.GetSecret(ctx, rotator.OrganizationID, rotator.ProjectID, rotator.Environment, name)
+	switch {
+	case errors.Is(err, vault.ErrSecretNotFound):
+		previous = nil
+	case err != nil:
+		return err
+	}
+
+	if previous != nil {
+		provider, ok := r.providers.Get(rotator.Provider)
+		if !ok {
+			return errors.New("fournisseur inconnu : " + rotator.Provider)
+		}
+		credentials, err := r.vaultService.GetSecret(ctx, rotator.OrganizationID, rotator.ProjectID,
+			rotator.Environment, rotator.CredentialsSecret)
+		if err != nil {
+			return err
+		}
+		err = provider.Revoke(ctx, Request{Current: previous.Value, Credentials: credentials.Value, Config: rotator.Config})
+		if err != nil {
+			return err
+		}
+		if err := r.vaultService.DeleteSecret(ctx, rotator.OrganizationID, rotator.ProjectID, rotator.Environment, name); err != nil {
+			return err
+		}
+	}
+
+	err = r.secrets.DeleteSecretMetadataByPath(ctx, rotator.OrganizationID, rotator.ProjectID, rotator.Environment, name)
+	if err != nil {
+		return err
+	}
+	rotator.PreviousExpiresAt = nil
+	return r.rotators.SetPreviousExpiry(ctx, rotator.ID, nil)
+}
@@ -0,0 +1,131 @@
+// filepath: internal/rotation/rotation.go
+
+// Package rotation fait tourner les identifiants d'un fournisseur externe
+// (clés d'accès AWS IAM, tokens d'une application GitHub, mots de passe du
+// moteur de bases de données de Vault) pour le compte d'un secret.
+//
+// Une rotation se déroule en deux temps : Issue crée de nouveaux
+// identifiants chez le fournisseur, puis, une fois la nouvelle valeur
+// enregistrée dans le secret, Revoke révoque les anciens. Un échec
+// d'enregistrement laisse ainsi les consommateurs sur des identifiants
+// encore valides. Les identifiants qui autorisent la rotation sont lus
+// dans un secret lié du même environnement.
+package rotation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"secrets-manager/internal/netguard"
+)
+
+// Fournisseurs intégrés
+const (
+	ProviderAWSIAM        = "aws_iam"
+	ProviderGitHubApp     = "github_app"
+	ProviderVaultDatabase = "vault_database"
+)
+
+// ErrUpstream indique un refus ou une panne du fournisseur
+var ErrUpstream = errors.New("le fournisseur a refusé la rotation")
+
+// Request décrit une rotation
+type Request struct {
+	// Current est la valeur actuelle du secret, vide à la première rotation
+	Current string
+	// Credentials est la valeur du secret lié qui autorise la rotation
+	Credentials string
+	// Config est la configuration du rotateur (voir Validate)
+	Config map[string]string
+}
+
+// Rotator fait tourner les identifiants d'un fournisseur
+type Rotator interface {
+	// Validate vérifie la configuration du rotateur ; le message d'erreur
+	// est destiné à l'utilisateur
+	Validate(config map[string]string) error
+
+	// Issue crée de nouveaux identifiants et renvoie la nouvelle valeur du secret
+	Issue(ctx context.Context, req Request) (string, error)
+
+	// Revoke révoque les identifiants de req.Current, remplacés par la
+	// valeur renvoyée par Issue
+	Revoke(ctx context.Context, req Request) error
+}
+
+// Registry associe chaque fournisseur à son rotateur
+type Registry struct {
+	rotators map[string]Rotator
+}
+
+// NewRegistry crée un registre des fournisseurs intégrés. client nil
+// utilise un client avec un délai de 30 secondes qui refuse les
+// destinations internes (voir netguard) ; un client fourni doit faire de
+// même. Les redirections ne sont jamais suivies : elles emporteraient les
+// identifiants vers un autre hôte.
+func NewRegistry(client *http.Client) *Registry {
+	if client == nil {
+		client = netguard.Guard{}.Client(30 * time.Second)
+	}
+	noRedirect := *client
+	noRedirect.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	client = &noRedirect
+	r := &Registry{rotators: make(map[string]Rotator)}
+	r.Register(ProviderAWSIAM, &AWSIAM{http: client})
+	r.Register(ProviderGitHubApp, &GitHubApp{http: client})
+	r.Register(ProviderVaultDatabase, &VaultDatabase{http: client})
+	return r
+}
+
+// Register ajoute ou remplace le rotateur d'un fournisseur
+func (r *Registry) Register(provider string, rotator Rotator) {
+	r.rotators[provider] = rotator
+}
+
+// Get renvoie le rotateur d'un fournisseur
+func (r *Registry) Get(provider string) (Rotator, bool) {
+	rotator, ok := r.rotators[provider]
+	return rotator, ok
+}
+
+// Providers liste les fournisseurs enregistrés par ordre alphabétique
+func (r *Registry) Providers() []string {
+	providers := make([]string, 0, len(r.rotators))
+	for provider := range r.rotators {
+		providers = append(providers, provider)
+	}
+	sort.Strings(providers)
+	return providers
+}
+
+// requireConfig vérifie la présence des clés de configuration obligatoires
+func requireConfig(config map[string]string, keys ...string) error {
+	for _, key := range keys {
+		if strings.TrimSpace(config[key]) == "" {
+			return fmt.Errorf("paramètre %s requis", key)
+		}
+	}
+	return nil
+}
+
+// configOr renvoie la valeur d'une clé de configuration ou sa valeur par défaut
+func configOr(config map[string]string, key, fallback string) string {
+	if v := strings.TrimSpace(config[key]); v != "" {
+		return v
+	}
+	return fallback
+}
+
+// upstreamError construit l'erreur d'une réponse inattendue du fournisseur
+func upstreamError(resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	return fmt.Errorf("%w : statut %d : %s", ErrUpstream, resp.StatusCode, strings.TrimSpace(string(body)))
+}
@@ -0,0 +1,179 @@
+// filepath: internal/rotation/rotation_test.go
+
+package rotation
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Exemple de la documentation AWS (Signature Version 4, IAM ListUsers)
+func TestSignV4(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodGet, "https://iam.amazonaws.com/?Action=ListUsers&Version=2010-05-08", nil)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+	credentials := AWSAccessKey{AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY"}
+
+	signV4(req, nil, credentials, "us-east-1", "iam", time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC))
+
+	expected := "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/iam/aws4_request, " +
+		"SignedHeaders=content-type;host;x-amz-date, " +
+		"Signature=5d672d79c15b13162d9279b0855cfba6789a8edb4c82c400e06b5924a6f2b5d7"
+	if got := req.Header.Get("Authorization"); got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+}
+
+func TestAWSIAMRotation(t *testing.T) {
+	var actions []string
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKIAADMIN/") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		r.ParseForm()
+		actions = append(actions, r.PostForm.Get("Action")+":"+r.PostForm.Get("AccessKeyId"))
+		if r.PostForm.Get("Action") == "CreateAccessKey" {
+			w.Write([]byte(`<CreateAccessKeyResponse><CreateAccessKeyResult><AccessKey>
+				<AccessKeyId>AKIANEW</AccessKeyId><SecretAccessKey>new-secret</SecretAccessKey>
+				</AccessKey></CreateAccessKeyResult></CreateAccessKeyResponse>`))
+		}
+	}))
+	defer upstream.Close()
+
+	rotator, _ := NewRegistry(upstream.Client()).Get(ProviderAWSIAM)
+	rotator.(*AWSIAM).endpoint = upstream.URL
+	req := Request{
+		Current:     `{"access_key_id":"AKIAOLD","secret_access_key":"old-secret"}`,
+		Credentials: `{"access_key_id":"AKIAADMIN","secret_access_key":"admin-secret"}`,
+		Config:      map[string]string{"user_name": "billing"},
+	}
+	// Les identifiants signés ne partent jamais vers une adresse choisie
+	override := map[string]string{"user_name": "billing", "endpoint": "http://127.0.0.1:9090"}
+	if err := rotator.Validate(override); err == nil {
+		t.Error("Expected the endpoint override to be rejected")
+	}
+	value, err := rotator.Issue(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	var key AWSAccessKey
+	if err := json.Unmarshal([]byte(value), &key); err != nil || key.AccessKeyID != "AKIANEW" {
+		t.Errorf("Expected the new access key, got %s", value)
+	}
+	if err := rotator.Revoke(context.Background(), req); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if strings.Join(actions, ",") != "CreateAccessKey:,DeleteAccessKey:AKIAOLD" {
+		t.Errorf("Expected the old key to be deleted after the new one was created, got %v", actions)
+	}
+
+	// Le client par défaut refuse les adresses internes
+	guarded, _ := NewRegistry(nil).Get(ProviderAWSIAM)
+	guarded.(*AWSIAM).endpoint = upstream.URL
+	if _, err := guarded.Issue(context.Background(), req); err == nil || len(actions) != 2 {
+		t.Errorf("Expected the loopback endpoint to be refused, got %v after %v", err, actions)
+	}
+}
+
+func TestGitHubAppRotation(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pemKey := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(privateKey)})
+
+	revoked := ""
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/app/installations/42/access_tokens":
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"token":"ghs_new"}`))
+		case r.Method == http.MethodDelete && r.URL.Path == "/installation/token":
+			revoked = strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer upstream.Close()
+
+	rotator, _ := NewRegistry(upstream.Client()).Get(ProviderGitHubApp)
+	rotator.(*GitHubApp).apiURL = upstream.URL
+	req := Request{
+		Current:     "ghs_old",
+		Credentials: string(pemKey),
+		Config:      map[string]string{"app_id": "7", "installation_id": "42"},
+	}
+	if err := rotator.Validate(req.Config); err != nil {
+		t.Fatalf("Expected valid configuration, got %v", err)
+	}
+	for apiURL, valid := range map[string]bool{
+		"https://api.acme.ghe.com":  true,
+		"https://api.github.com/":   true,
+		"http://api.github.com":     false,
+		"https://127.0.0.1:9090":    false,
+		"https://github.internal":   false,
+		"https://api.github.com:81": false,
+	} {
+		config := map[string]string{"app_id": "7", "installation_id": "42", "api_url": apiURL}
+		if err := rotator.Validate(config); (err == nil) != valid {
+			t.Errorf("Expected api_url %s valid=%v, got %v", apiURL, valid, err)
+		}
+	}
+	// Une configuration enregistrée avant la vérification est refusée à l'appel
+	stale := Request{Credentials: string(pemKey),
+		Config: map[string]string{"app_id": "7", "installation_id": "42", "api_url": "http://127.0.0.1:9090"}}
+	if _, err := rotator.Issue(context.Background(), stale); err == nil {
+		t.Error("Expected a stale api_url to be refused")
+	}
+	if token, err := rotator.Issue(context.Background(), req); err != nil || token != "ghs_new" {
+		t.Errorf("Expected ghs_new, got %q (%v)", token, err)
+	}
+	if err := rotator.Revoke(context.Background(), req); err != nil || revoked != "ghs_old" {
+		t.Errorf("Expected ghs_old to be revoked, got %q (%v)", revoked, err)
+	}
+}
+
+func TestVaultDatabaseRotation(t *testing.T) {
+	rotated := false
+	upstream := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "s.rotator" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/database/rotate-role/billing":
+			rotated = true
+			w.WriteHeader(http.StatusNoContent)
+		case "/v1/database/static-creds/billing":
+			w.Write([]byte(`{"data":{"username":"billing","password":"new-password"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer upstream.Close()
+
+	rotator, _ := NewRegistry(upstream.Client()).Get(ProviderVaultDatabase)
+	if err := rotator.Validate(map[string]string{"address": upstream.URL}); err == nil {
+		t.Error("Expected missing role to be rejected")
+	}
+	if err := rotator.Validate(map[string]string{"address": "http://127.0.0.1:9090", "role": "billing"}); err == nil {
+		t.Error("Expected a plain http address to be rejected")
+	}
+	password, err := rotator.Issue(context.Background(), Request{
+		Credentials: "s.rotator",
+		Config:      map[string]string{"address": upstream.URL, "role": "billing"},
+	})
+	if err != nil || password != "new-password" || !rotated {
+		t.Errorf("Expected the role to be rotated, got %q (%v)", password, err)
+	}
+}
@@ -0,0 +1,249 @@
+// filepath: internal/rotation/service.go
+
+package rotation
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"secrets-manager/internal/hygiene"
+	"secrets-manager/internal/logging"
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/notifications"
+	"secrets-manager/internal/storage"
+	"secrets-manager/internal/vault"
+)
+
+// ErrInvalid indique une configuration qui empêche la rotation (fournisseur
+// inconnu, secret lié absent, projet chiffré de bout en bout) ; le message
+// de l'erreur est destiné à l'utilisateur
+var ErrInvalid = errors.New("rotation impossible")
+
+type invalidError struct {
+	msg string
+}
+
+func (e *invalidError) Error() string { return e.msg }
+
+func (e *invalidError) Unwrap() error { return ErrInvalid }
+
+// Service exécute la rotation gérée d'un secret, demandée par un
+// utilisateur (route /rotate) ou par une rotation groupée
+type Service struct {
+	rotators     storage.SecretRotatorsRepository
+	secrets      storage.SecretsRepository
+	projects     storage.ProjectsRepository
+	vaultService *vault.Service
+	providers    *Registry
+	retirer      *Retirer
+	// checksummer calcule la somme de contrôle de la nouvelle valeur ; nil les désactive
+	checksummer *vault.Checksummer
+	// notifier prévient les membres des changements en production ; nil les ignore
+	notifier *notifications.Dispatcher
+}
+
+// NewService crée le service de rotation gérée
+func NewService(
+	rotators storage.SecretRotatorsRepository,
+	secrets storage.SecretsRepository,
+	projects storage.ProjectsRepository,
+	vaultService *vault.Service,
+	providers *Registry,
+	checksummer *vault.Checksummer,
+	notifier *notifications.Dispatcher,
+) *Service {
+	return &Service{
+		rotators:     rotators,
+		secrets:      secrets,
+		projects:     projects,
+		vaultService: vaultService,
+		providers:    providers,
+		// Les retraits demandés explicitement ne dépendent pas des fenêtres de maintenance
+		retirer:     NewRetirer(rotators, secrets, vaultService, providers, nil),
+		checksummer: checksummer,
+		notifier:    notifier,
+	}
+}
+
+// Rotate fait tourner les identifiants du secret chez son fournisseur : de
+// nouveaux identifiants sont créés, enregistrés comme nouvelle version du
+// secret par actorID, puis les anciens sont révoqués. Un échec de
+// révocation n'annule pas la rotation ; il est signalé dans LastError.
+//
+// En rotation double, l'ancienne valeur est copiée dans {name}_previous et
+// ses identifiants ne sont révoqués qu'à la fin du chevauchement (voir
+// Retirer). Une valeur précédente encore en service est retirée avant la
+// nouvelle rotation.
+//
+// Le rotateur est mis à jour avec le résultat. Les erreurs du fournisseur
+// enveloppent ErrUpstream, celles de configuration ErrInvalid.
+func (s *Service) Rotate(ctx context.Context, rotator *models.SecretRotator, actorID string) error {
+	orgID, projectID, env, name := rotator.OrganizationID, rotator.ProjectID, rotator.Environment, rotator.SecretName
+
+	provider, ok := s.providers.Get(rotator.Provider)
+	if !ok {
+		return &invalidError{msg: "Fournisseur inconnu : " + rotator.Provider}
+	}
+	secret, err := s.vaultService.GetSecret(ctx, orgID, projectID, env, name)
+	if err != nil {
+		return err
+	}
+	metadata, err := s.unlockedMetadata(ctx, orgID, projectID, env, name)
+	if err != nil {
+		return err
+	}
+	if err := s.CheckManagedEncryption(ctx, projectID); err != nil {
+		return err
+	}
+	if rotator.PreviousExpiresAt != nil {
+		if err := s.retirer.Retire(ctx, rotator); err != nil {
+			s.record(ctx, rotator, "valeur précédente non retirée : "+err.Error())
+			return err
+		}
+	}
+	credentials, err := s.vaultService.GetSecret(ctx, orgID, projectID, env, rotator.CredentialsSecret)
+	if errors.Is(err, vault.ErrSecretNotFound) {
+		return &invalidError{msg: "Le secret lié " + rotator.CredentialsSecret + " est introuvable"}
+	}
+	if err != nil {
+		return err
+	}
+
+	req := Request{Current: secret.Value, Credentials: credentials.Value, Config: rotator.Config}
+	value, err := provider.Issue(ctx, req)
+	if err != nil {
+		s.record(ctx, rotator, err.Error())
+		return err
+	}
+
+	names := []string{name}
+	if rotator.Strategy == models.RotationDual {
+		if err := s.storePrevious(ctx, secret); err != nil {
+			// Les anciens identifiants restent valides : rien n'est révoqué
+			s.record(ctx, rotator, "valeur précédente non enregistrée : "+err.Error())
+			return err
+		}
+		names = append(names, models.PreviousSecretName(name))
+	}
+
+	secret.Value = value
+	secret.E2E = false
+	secret.CreatedBy = actorID
+	if err := s.vaultService.StoreSecret(ctx, secret); err != nil {
+		// Les anciens identifiants restent valides : rien n'est révoqué
+		s.record(ctx, rotator, "nouvelle valeur non enregistrée : "+err.Error())
+		return err
+	}
+	if err := s.saveMetadata(ctx, metadata, secret); err != nil {
+		return err
+	}
+	if notifications.IsProduction(env) {
+		event := notifications.SecretsChanged(orgID, projectID, env, notifications.SecretUpdated, actorID, names...)
+		event.Owner = notifications.SecretOwners(ctx, s.projects, orgID, projectID, metadata)
+		s.notifier.Notify(event)
+	}
+
+	lastError := ""
+	if rotator.Strategy == models.RotationDual {
+		expiresAt := time.Now().Add(time.Duration(rotator.OverlapSeconds) * time.Second)
+		if err := s.rotators.SetPreviousExpiry(ctx, rotator.ID, &expiresAt); err != nil {
+			lastError = "date de retrait de la valeur précédente non enregistrée : " + err.Error()
+		}
+		rotator.PreviousExpiresAt = &expiresAt
+	} else if err := provider.Revoke(ctx, req); err != nil {
+		lastError = "anciens identifiants non révoqués : " + err.Error()
+	}
+	s.record(ctx, rotator, lastError)
+	return nil
+}
+
+// Retire retire la valeur précédente encore en service d'une rotation
+// double, sans attendre la fin du chevauchement
+func (s *Service) Retire(ctx context.Context, rotator *models.SecretRotator) error {
+	return s.retirer.Retire(ctx, rotator)
+}
+
+// CheckManagedEncryption refuse la rotation gérée dans un projet chiffré de
+// bout en bout : le serveur ne peut pas chiffrer la nouvelle valeur
+func (s *Service) CheckManagedEncryption(ctx context.Context, projectID string) error {
+	key, err := s.projects.GetProjectEncryptionKey(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	if key != nil {
+		return &invalidError{msg: "La rotation gérée est impossible dans un projet chiffré de bout en bout"}
+	}
+	return nil
+}
+
+// storePrevious copie la valeur actuelle du secret dans {name}_previous
+func (s *Service) storePrevious(ctx context.Context, secret *models.Secret) error {
+	previous := *secret
+	previous.Name = models.PreviousSecretName(secret.Name)
+	previous.Description = "Valeur précédente de " + secret.Name + " (rotation double)"
+
+	metadata, err := s.unlockedMetadata(ctx, previous.OrganizationID, previous.ProjectID, previous.Environment, previous.Name)
+	if err != nil {
+		return err
+	}
+	if err := s.vaultService.StoreSecret(ctx, &previous); err != nil {
+		return err
+	}
+	return s.saveMetadata(ctx, metadata, &previous)
+}
+
+// unlockedMetadata renvoie les métadonnées du secret (nil pour un secret
+// antérieur aux métadonnées), storage.ErrSecretLocked s'il est verrouillé
+func (s *Service) unlockedMetadata(ctx context.Context, orgID, projectID, env, name string) (*models.SecretMetadata, error) {
+	metadata, err := s.secrets.GetSecretMetadataByPath(ctx, orgID, projectID, env, name)
+	if err != nil {
+		return nil, err
+	}
+	if metadata != nil && metadata.IsLocked() {
+		return nil, storage.ErrSecretLocked
+	}
+	return metadata, nil
+}
+
+// saveMetadata crée les métadonnées d'un secret ou incrémente leur version
+func (s *Service) saveMetadata(ctx context.Context, metadata *models.SecretMetadata, secret *models.Secret) error {
+	checksum := ""
+	if s.checksummer != nil {
+		checksum = s.checksummer.Sum(secret)
+	}
+	if metadata == nil {
+		metadata = &models.SecretMetadata{
+			Name:           secret.Name,
+			Description:    secret.Description,
+			OrganizationID: secret.OrganizationID,
+			ProjectID:      secret.ProjectID,
+			Environment:    secret.Environment,
+			CreatedBy:      secret.CreatedBy,
+			Version:        1,
+			E2E:            secret.E2E,
+			Checksum:       checksum,
+		}
+		hygiene.Measure(metadata, secret, s.checksummer)
+		return s.secrets.CreateSecretMetadata(ctx, metadata)
+	}
+
+	metadata.Description = secret.Description
+	metadata.E2E = secret.E2E
+	metadata.Checksum = checksum
+	hygiene.Measure(metadata, secret, s.checksummer)
+	metadata.Version++
+	return s.secrets.UpdateSecretMetadata(ctx, metadata)
+}
+
+// record enregistre le résultat d'une rotation dans le rotateur. Un échec
+// est journalisé sans changer le résultat de la rotation.
+func (s *Service) record(ctx context.Context, rotator *models.SecretRotator, lastError string) {
+	now := time.Now()
+	rotator.LastRotatedAt = &now
+	rotator.LastError = lastError
+	if err := s.rotators.RecordSecretRotation(context.WithoutCancel(ctx), rotator.ID, now, lastError); err != nil {
+		logging.For(logging.ComponentJobs).Warn("résultat de la rotation non enregistré",
+			"rotator_id", rotator.ID, "error", err)
+	}
+}
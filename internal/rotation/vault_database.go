@@ -0,0 +1,85 @@
+// filepath: internal/rotation/vault_database.go
+
+package rotation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	vault "github.com/hashicorp/vault/api"
+)
+
+// VaultDatabase fait tourner le mot de passe d'un rôle statique du moteur
+// de bases de données de Vault : Vault change le mot de passe dans la base
+// puis le nouveau est lu. L'ancien mot de passe est invalidé par Vault,
+// Revoke n'a rien à faire. Le secret lié contient un token Vault autorisé
+// sur rotate-role et static-creds.
+//
+// Configuration : address et role (obligatoires), mount ("database" par
+// défaut), namespace.
+type VaultDatabase struct {
+	http *http.Client
+}
+
+// Validate vérifie la configuration
+func (v *VaultDatabase) Validate(config map[string]string) error {
+	if err := requireConfig(config, "address", "role"); err != nil {
+		return err
+	}
+	return validateVaultAddress(config["address"])
+}
+
+// Issue déclenche la rotation du rôle statique et renvoie le nouveau mot de passe
+func (v *VaultDatabase) Issue(ctx context.Context, req Request) (string, error) {
+	if err := validateVaultAddress(req.Config["address"]); err != nil {
+		return "", err
+	}
+	cfg := vault.DefaultConfig()
+	cfg.Address = req.Config["address"]
+	cfg.HttpClient = v.http
+	client, err := vault.NewClient(cfg)
+	if err != nil {
+		return "", err
+	}
+	client.SetToken(strings.TrimSpace(req.Credentials))
+	if namespace := req.Config["namespace"]; namespace != "" {
+		client.SetNamespace(namespace)
+	}
+
+	mount := strings.Trim(configOr(req.Config, "mount", "database"), "/")
+	role := req.Config["role"]
+	if _, err := client.Logical().WriteWithContext(ctx, mount+"/rotate-role/"+role, nil); err != nil {
+		return "", fmt.Errorf("%w : %v", ErrUpstream, err)
+	}
+	secret, err := client.Logical().ReadWithContext(ctx, mount+"/static-creds/"+role)
+	if err != nil {
+		return "", fmt.Errorf("%w : %v", ErrUpstream, err)
+	}
+	if secret == nil {
+		return "", fmt.Errorf("%w : rôle statique %s introuvable", ErrUpstream, role)
+	}
+	password, ok := secret.Data["password"].(string)
+	if !ok || password == "" {
+		return "", fmt.Errorf("%w : mot de passe absent de la réponse de Vault", ErrUpstream)
+	}
+	return password, nil
+}
+
+// Revoke ne fait rien : Vault a déjà invalidé l'ancien mot de passe
+func (v *VaultDatabase) Revoke(ctx context.Context, req Request) error {
+	return nil
+}
+
+// validateVaultAddress exige une adresse https : le token du secret lié ne
+// part jamais en clair
+func validateVaultAddress(address string) error {
+	u, err := url.Parse(address)
+	if err != nil || u.Scheme != "https" || u.Hostname() == "" || u.User != nil {
+		return errors.New("address doit être une URL https")
+	}
+	return nil
+}
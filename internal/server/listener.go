@@ -0,0 +1,117 @@
+// filepath: internal/server/listener.go
+
+package server
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+
+	"secrets-manager/internal/config"
+)
+
+// Premier descripteur de fichier transmis par systemd (SD_LISTEN_FDS_START)
+const systemdListenFDStart = 3
+
+// Listen crée le listener du serveur HTTP.
+// Si le processus a été lancé par systemd avec activation par socket
+// (LISTEN_PID/LISTEN_FDS), le socket hérité est utilisé ; sinon un
+// listener TCP ou unix est créé à partir de la configuration.
+func Listen(cfg config.ServerConfig) (net.Listener, error) {
+	ln, err := systemdListener()
+	if err != nil {
+		return nil, err
+	}
+	if ln != nil {
+		return ln, nil
+	}
+
+	network, address := cfg.ListenAddress()
+	return listen(network, address)
+}
+
+// ListenAdmin crée le listener dédié aux endpoints d'administration.
+// Il n'utilise jamais l'activation systemd, réservée au serveur API.
+func ListenAdmin(cfg config.AdminConfig) (net.Listener, error) {
+	network, address := cfg.ListenAddress()
+	return listen(network, address)
+}
+
+// ListenMTLS crée le listener TLS dédié aux comptes de service : seuls les
+// clients présentant un certificat terminent la poignée de main. Chaque
+// organisation déclare ses propres autorités : le certificat est vérifié
+// ensuite, avec celles du domaine de confiance de son identifiant SPIFFE.
+func ListenMTLS(cfg config.MTLSConfig) (net.Listener, error) {
+	cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("certificat du listener mTLS invalide: %w", err)
+	}
+
+	network, address := cfg.ListenAddress()
+	ln, err := listen(network, address)
+	if err != nil {
+		return nil, err
+	}
+	return tls.NewListener(ln, MTLSConfig(cert)), nil
+}
+
+// MTLSConfig renvoie la configuration TLS du listener mTLS : certificat
+// client obligatoire, vérifié par auth.AuthenticateClientCertificate avec
+// les autorités de l'organisation, TLS 1.2 au moins
+func MTLSConfig(cert tls.Certificate) *tls.Config {
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientAuth:   tls.RequireAnyClientCert,
+		MinVersion:   tls.VersionTLS12,
+	}
+}
+
+// listen ouvre un listener TCP ou unix
+func listen(network, address string) (net.Listener, error) {
+	if network == "unix" {
+		// Supprimer un éventuel socket laissé par une exécution précédente
+		if err := os.Remove(address); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("impossible de supprimer le socket existant %s: %w", address, err)
+		}
+	}
+
+	ln, err := net.Listen(network, address)
+	if err != nil {
+		return nil, fmt.Errorf("impossible d'écouter sur %s %s: %w", network, address, err)
+	}
+
+	return ln, nil
+}
+
+// systemdListener renvoie le socket transmis par systemd, ou nil si le
+// processus n'a pas été activé par socket
+func systemdListener() (net.Listener, error) {
+	pid, err := strconv.Atoi(os.Getenv("LISTEN_PID"))
+	if err != nil || pid != os.Getpid() {
+		return nil, nil
+	}
+
+	fds, err := strconv.Atoi(os.Getenv("LISTEN_FDS"))
+	if err != nil || fds < 1 {
+		return nil, nil
+	}
+
+	// Éviter que les processus enfants n'héritent des variables
+	os.Unsetenv("LISTEN_PID")
+	os.Unsetenv("LISTEN_FDS")
+	os.Unsetenv("LISTEN_FDNAMES")
+
+	// Seul le premier socket est utilisé par le serveur API
+	file := os.NewFile(uintptr(systemdListenFDStart), "LISTEN_FD_3")
+	defer file.Close()
+
+	ln, err := net.FileListener(file)
+	if err != nil {
+		return nil, fmt.Errorf("socket systemd invalide: %w", err)
+	}
+
+	return ln, nil
+}
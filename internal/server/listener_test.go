@@ -0,0 +1,63 @@
+// filepath: internal/server/listener_test.go
+
+package server
+
+import (
+	"path/filepath"
+	"testing"
+
+	"secrets-manager/internal/config"
+)
+
+func TestListen(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      config.ServerConfig
+		network     string
+		shouldError bool
+	}{
+		{
+			name:    "Host and port",
+			config:  config.ServerConfig{Address: "127.0.0.1", Port: 0},
+			network: "tcp",
+		},
+		{
+			name:    "host:port address",
+			config:  config.ServerConfig{Address: "127.0.0.1:0", Port: 8080},
+			network: "tcp",
+		},
+		{
+			name:    "Unix socket",
+			config:  config.ServerConfig{Address: "unix:" + filepath.Join(t.TempDir(), "api.sock")},
+			network: "unix",
+		},
+		{
+			name:        "Invalid port",
+			config:      config.ServerConfig{Address: "127.0.0.1", Port: -1},
+			shouldError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ln, err := Listen(tc.config)
+
+			if tc.shouldError {
+				if err == nil {
+					ln.Close()
+					t.Errorf("Expected error but got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+			defer ln.Close()
+
+			if ln.Addr().Network() != tc.network {
+				t.Errorf("Expected network %s, got %s", tc.network, ln.Addr().Network())
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+// filepath: internal/storage/errors.go
+
+package storage
+
+import "errors"
+
+// Catégories d'erreurs communes à toutes les implémentations de repositories.
+// Les erreurs spécifiques ci-dessous les enveloppent, ce qui permet de tester
+// la catégorie avec errors.Is sans connaître l'erreur exacte.
+var (
+	ErrNotFound      = errors.New("ressource non trouvée")
+	ErrAlreadyExists = errors.New("la ressource existe déjà")
+	ErrQuotaExceeded = errors.New("limite du plan atteinte")
+	ErrLocked        = errors.New("la ressource est verrouillée")
+)
+
+// Erreurs spécifiques des repositories
+var (
+	ErrUserNotFound               = kindError("utilisateur non trouvé", ErrNotFound)
+	ErrEmailAlreadyExists         = kindError("cet email est déjà utilisé", ErrAlreadyExists)
+	ErrOrganizationNotFound       = kindError("organisation non trouvée", ErrNotFound)
+	ErrOrganizationNameExists     = kindError("une organisation avec ce nom existe déjà", ErrAlreadyExists)
+	ErrSecretLocked               = kindError("le secret est verrouillé", ErrLocked)
+	ErrSecretAlreadyExists        = kindError("un secret avec ce nom existe déjà", ErrAlreadyExists)
+	ErrProjectNotFound            = kindError("projet non trouvé", ErrNotFound)
+	ErrConfirmationNotFound       = kindError("confirmation inconnue ou déjà utilisée", ErrNotFound)
+	ErrDeletionNotFound           = kindError("suppression non trouvée", ErrNotFound)
+	ErrAccessReviewNotFound       = kindError("revue des accès non trouvée", ErrNotFound)
+	ErrAccessAlreadyReviewed      = kindError("cet accès a déjà été revu", ErrAlreadyExists)
+	ErrWebhookNotFound            = kindError("webhook non trouvé", ErrNotFound)
+	ErrDeliveryNotFound           = kindError("livraison non trouvée", ErrNotFound)
+	ErrDeviceCodeNotFound         = kindError("autorisation d'appareil inconnue ou expirée", ErrNotFound)
+	ErrTokenNotFound              = kindError("token d'accès non trouvé", ErrNotFound)
+	ErrRotatorNotFound            = kindError("aucun rotateur configuré pour ce secret", ErrNotFound)
+	ErrScheduleNotFound           = kindError("aucun changement planifié pour ce secret", ErrNotFound)
+	ErrWindowNotFound             = kindError("fenêtre de maintenance non trouvée", ErrNotFound)
+	ErrDataKeyNotFound            = kindError("clé de données non trouvée", ErrNotFound)
+	ErrDataKeyExists              = kindError("une clé de données avec ce nom existe déjà", ErrAlreadyExists)
+	ErrAgeKeyNotFound             = kindError("clé age non trouvée", ErrNotFound)
+	ErrAgeKeysExist               = kindError("ce dépôt a déjà une clé age, utilisez la rotation", ErrAlreadyExists)
+	ErrIncidentOpen               = kindError("un incident est déjà en cours pour ce composant", ErrAlreadyExists)
+	ErrForwarderNotFound          = kindError("destination des journaux non trouvée", ErrNotFound)
+	ErrBulkRotationNotFound       = kindError("rotation groupée non trouvée", ErrNotFound)
+	ErrMFANotFound                = kindError("authentification multifacteur non configurée", ErrNotFound)
+	ErrLockdownNotFound           = kindError("aucun confinement en cours pour cette organisation", ErrNotFound)
+	ErrLockdownActive             = kindError("un confinement est déjà en cours pour cette organisation", ErrAlreadyExists)
+	ErrAPIKeyNotFound             = kindError("clé d'API non trouvée", ErrNotFound)
+	ErrSignatureReplayed          = kindError("signature de requête déjà présentée", ErrAlreadyExists)
+	ErrDeviceNotFound             = kindError("appareil de confiance non trouvé", ErrNotFound)
+	ErrLoginEventNotFound         = kindError("aucune connexion située", ErrNotFound)
+	ErrLoginPolicyNotFound        = kindError("aucune politique d'alerte de connexion", ErrNotFound)
+	ErrRefreshTokenNotFound       = kindError("token de rafraîchissement non trouvé", ErrNotFound)
+	ErrSessionNotFound            = kindError("session non trouvée", ErrNotFound)
+	ErrSessionPolicyNotFound      = kindError("aucune politique de session", ErrNotFound)
+	ErrPasswordPolicyNotFound     = kindError("aucune politique de mot de passe", ErrNotFound)
+	ErrRegistrationPolicyNotFound = kindError("aucune politique d'inscription", ErrNotFound)
+	ErrReadPolicyNotFound         = kindError("aucun environnement protégé", ErrNotFound)
+	ErrProjectTemplateNotFound    = kindError("aucun modèle de projet", ErrNotFound)
+	ErrInvitationNotFound         = kindError("invitation inconnue, expirée ou déjà utilisée", ErrNotFound)
+	ErrInvitationPending          = kindError("une invitation est déjà en attente pour cet email", ErrAlreadyExists)
+	ErrTeamNotFound               = kindError("équipe non trouvée", ErrNotFound)
+	ErrTeamExists                 = kindError("une équipe avec ce nom existe déjà", ErrAlreadyExists)
+	ErrServiceAccountNotFound     = kindError("compte de service non trouvé", ErrNotFound)
+	ErrSPIFFEIDTaken              = kindError("identifiant SPIFFE déjà associé à un compte de service de l'organisation", ErrAlreadyExists)
+	ErrTrustDomainNotFound        = kindError("aucun domaine de confiance mTLS", ErrNotFound)
+	ErrTrustDomainTaken           = kindError("ce domaine de confiance appartient à une autre organisation", ErrAlreadyExists)
+	ErrCustomRoleNotFound         = kindError("rôle personnalisé non trouvé", ErrNotFound)
+	ErrCustomRoleExists           = kindError("un rôle avec ce nom existe déjà", ErrAlreadyExists)
+	ErrImpersonationNotFound      = kindError("usurpation d'identité non trouvée ou terminée", ErrNotFound)
+)
+
+// categorizedError est une erreur avec son propre message appartenant à une catégorie
+type categorizedError struct {
+	msg  string
+	kind error
+}
+
+func (e *categorizedError) Error() string { return e.msg }
+
+func (e *categorizedError) Unwrap() error { return e.kind }
+
+// kindError crée une erreur de la catégorie kind
+func kindError(msg string, kind error) error {
+	return &categorizedError{msg: msg, kind: kind}
+}
@@ -0,0 +1,133 @@
+// filepath: internal/storage/memory/access_reviews_repository.go
+
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// AccessReviewsRepository est l'implémentation en mémoire de storage.AccessReviewsRepository
+type AccessReviewsRepository struct {
+	db *DB
+}
+
+var _ storage.AccessReviewsRepository = (*AccessReviewsRepository)(nil)
+
+// NewAccessReviewsRepository crée un nouveau repository de revues des accès en mémoire
+func NewAccessReviewsRepository(db *DB) *AccessReviewsRepository {
+	return &AccessReviewsRepository{db: db}
+}
+
+// CreateAccessReview enregistre une campagne et ses accès
+func (r *AccessReviewsRepository) CreateAccessReview(ctx context.Context, review *models.AccessReview) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if review.ID == "" {
+		review.ID = uuid.New().String()
+	}
+	for _, item := range review.Items {
+		item.ReviewID = review.ID
+	}
+
+	r.db.accessReviews[review.ID] = copyAccessReview(review, true)
+	return nil
+}
+
+// GetAccessReview récupère une campagne et ses accès
+func (r *AccessReviewsRepository) GetAccessReview(ctx context.Context, orgID, id string) (*models.AccessReview, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	review, ok := r.db.accessReviews[id]
+	if !ok || review.OrganizationID != orgID {
+		return nil, storage.ErrAccessReviewNotFound
+	}
+	return copyAccessReview(review, true), nil
+}
+
+// ListAccessReviews liste les campagnes d'une organisation avec leur avancement
+func (r *AccessReviewsRepository) ListAccessReviews(ctx context.Context, orgID string) ([]*models.AccessReview, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	reviews := []*models.AccessReview{}
+	for _, review := range r.db.accessReviews {
+		if review.OrganizationID == orgID {
+			reviews = append(reviews, copyAccessReview(review, false))
+		}
+	}
+	sort.Slice(reviews, func(i, j int) bool { return reviews[i].CreatedAt.After(reviews[j].CreatedAt) })
+
+	return reviews, nil
+}
+
+// DecideAccessReviewItem enregistre la décision sur un accès encore en attente
+func (r *AccessReviewsRepository) DecideAccessReviewItem(ctx context.Context, item *models.AccessReviewItem) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	review, ok := r.db.accessReviews[item.ReviewID]
+	if !ok {
+		return storage.ErrAccessAlreadyReviewed
+	}
+	existing := review.Item(item.PrincipalType, item.PrincipalID)
+	if existing == nil || existing.Decision != models.AccessDecisionPending {
+		return storage.ErrAccessAlreadyReviewed
+	}
+
+	existing.Decision = item.Decision
+	existing.DecidedBy = item.DecidedBy
+	existing.DecidedAt = item.DecidedAt
+	return nil
+}
+
+// CompleteAccessReview termine la campagne s'il ne reste aucun accès en attente
+func (r *AccessReviewsRepository) CompleteAccessReview(ctx context.Context, id string) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	review, ok := r.db.accessReviews[id]
+	if !ok || review.Status != models.AccessReviewOpen {
+		return nil
+	}
+	for _, item := range review.Items {
+		if item.Decision == models.AccessDecisionPending {
+			return nil
+		}
+	}
+
+	now := time.Now()
+	review.Status = models.AccessReviewCompleted
+	review.CompletedAt = &now
+	return nil
+}
+
+// copyAccessReview copie une campagne et calcule son avancement ; les accès
+// ne sont copiés que si withItems est vrai
+func copyAccessReview(review *models.AccessReview, withItems bool) *models.AccessReview {
+	copied := *review
+	copied.Items = nil
+	copied.TotalItems = len(review.Items)
+	copied.PendingItems = 0
+	for _, item := range review.Items {
+		if item.Decision == models.AccessDecisionPending {
+			copied.PendingItems++
+		}
+		if withItems {
+			itemCopy := *item
+			copied.Items = append(copied.Items, &itemCopy)
+		}
+	}
+	if withItems && copied.Items == nil {
+		copied.Items = []*models.AccessReviewItem{}
+	}
+	return &copied
+}
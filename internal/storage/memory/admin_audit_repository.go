@@ -0,0 +1,104 @@
+// filepath: internal/storage/memory/admin_audit_repository.go
+
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// AdminAuditRepository est l'implémentation en mémoire de storage.AdminAuditRepository
+type AdminAuditRepository struct {
+	db *DB
+}
+
+var _ storage.AdminAuditRepository = (*AdminAuditRepository)(nil)
+
+// NewAdminAuditRepository crée un nouveau repository d'audit d'administration en mémoire
+func NewAdminAuditRepository(db *DB) *AdminAuditRepository {
+	return &AdminAuditRepository{db: db}
+}
+
+// CreateAdminAuditLog enregistre une entrée du journal
+func (r *AdminAuditRepository) CreateAdminAuditLog(ctx context.Context, entry *models.AdminAuditLog) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if entry.ID == "" {
+		entry.ID = uuid.New().String()
+	}
+	copied := *entry
+	r.db.adminAuditLogs = append(r.db.adminAuditLogs, &copied)
+	return nil
+}
+
+// ListAdminAuditLogs liste les entrées depuis since, de la plus récente à la plus ancienne
+func (r *AdminAuditRepository) ListAdminAuditLogs(
+	ctx context.Context,
+	since time.Time,
+	limit, offset int,
+) ([]*models.AdminAuditLog, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	entries := []*models.AdminAuditLog{}
+	for _, entry := range r.db.adminAuditLogs {
+		if !entry.Timestamp.Before(since) {
+			copied := *entry
+			entries = append(entries, &copied)
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Timestamp.After(entries[j].Timestamp)
+	})
+
+	return paginate(entries, limit, offset), nil
+}
+
+// ListImpersonationAuditLogs liste les requêtes faites sous une usurpation
+// d'identité, de la plus récente à la plus ancienne
+func (r *AdminAuditRepository) ListImpersonationAuditLogs(
+	ctx context.Context,
+	impersonationID string,
+	limit, offset int,
+) ([]*models.AdminAuditLog, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	entries := []*models.AdminAuditLog{}
+	for _, entry := range r.db.adminAuditLogs {
+		if entry.ImpersonationID == impersonationID {
+			copied := *entry
+			entries = append(entries, &copied)
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Timestamp.After(entries[j].Timestamp)
+	})
+
+	return paginate(entries, limit, offset), nil
+}
+
+// PurgeAdminAuditLogs supprime les entrées antérieures à before
+func (r *AdminAuditRepository) PurgeAdminAuditLogs(ctx context.Context, before time.Time) (int64, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	kept := r.db.adminAuditLogs[:0]
+	var purged int64
+	for _, entry := range r.db.adminAuditLogs {
+		if entry.Timestamp.Before(before) {
+			purged++
+			continue
+		}
+		kept = append(kept, entry)
+	}
+	r.db.adminAuditLogs = kept
+	return purged, nil
+}
@@ -0,0 +1,86 @@
+// filepath: internal/storage/memory/age_keys_repository.go
+
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// AgeKeysRepository est l'implémentation en mémoire de storage.AgeKeysRepository
+type AgeKeysRepository struct {
+	db *DB
+}
+
+var _ storage.AgeKeysRepository = (*AgeKeysRepository)(nil)
+
+// NewAgeKeysRepository crée un nouveau repository de clés age en mémoire
+func NewAgeKeysRepository(db *DB) *AgeKeysRepository {
+	return &AgeKeysRepository{db: db}
+}
+
+// CreateAgeKey enregistre la nouvelle clé active d'un dépôt et retire la précédente
+func (r *AgeKeysRepository) CreateAgeKey(ctx context.Context, key *models.AgeKey) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if key.ID == "" {
+		key.ID = uuid.New().String()
+	}
+	key.Status = models.AgeKeyActive
+	key.CreatedAt = time.Now()
+	key.RetiredAt = nil
+
+	for _, existing := range r.db.ageKeys {
+		if existing.OrganizationID == key.OrganizationID && existing.ProjectID == key.ProjectID &&
+			existing.Repository == key.Repository && existing.Status == models.AgeKeyActive {
+			retiredAt := key.CreatedAt
+			existing.Status = models.AgeKeyRetired
+			existing.RetiredAt = &retiredAt
+		}
+	}
+	copied := *key
+	r.db.ageKeys[key.ID] = &copied
+	return nil
+}
+
+// ListAgeKeys liste les clés d'un dépôt du projet, de la plus récente à la plus ancienne
+func (r *AgeKeysRepository) ListAgeKeys(ctx context.Context, orgID, projectID, repository string) ([]*models.AgeKey, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	keys := []*models.AgeKey{}
+	for _, key := range r.db.ageKeys {
+		if key.OrganizationID == orgID && key.ProjectID == projectID &&
+			(repository == "" || key.Repository == repository) {
+			copied := *key
+			keys = append(keys, &copied)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].CreatedAt.Equal(keys[j].CreatedAt) {
+			return keys[i].Status == models.AgeKeyActive
+		}
+		return keys[i].CreatedAt.After(keys[j].CreatedAt)
+	})
+	return keys, nil
+}
+
+// DeleteAgeKey supprime une clé du projet
+func (r *AgeKeysRepository) DeleteAgeKey(ctx context.Context, orgID, projectID, id string) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	key, ok := r.db.ageKeys[id]
+	if !ok || key.OrganizationID != orgID || key.ProjectID != projectID {
+		return storage.ErrAgeKeyNotFound
+	}
+	delete(r.db.ageKeys, id)
+	return nil
+}
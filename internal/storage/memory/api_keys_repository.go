@@ -0,0 +1,145 @@
+// filepath: internal/storage/memory/api_keys_repository.go
+
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// APIKeysRepository est l'implémentation en mémoire de storage.APIKeysRepository
+type APIKeysRepository struct {
+	db *DB
+}
+
+var _ storage.APIKeysRepository = (*APIKeysRepository)(nil)
+
+// NewAPIKeysRepository crée un nouveau repository de clés d'API en mémoire
+func NewAPIKeysRepository(db *DB) *APIKeysRepository {
+	return &APIKeysRepository{db: db}
+}
+
+// CreateAPIKey enregistre une clé
+func (r *APIKeysRepository) CreateAPIKey(ctx context.Context, key *models.APIKey) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if key.ID == "" {
+		key.ID = uuid.New().String()
+	}
+	key.CreatedAt = time.Now()
+	r.db.apiKeys[key.ID] = copyAPIKey(key)
+	return nil
+}
+
+// GetAPIKeyByHash récupère la clé correspondant à l'empreinte
+func (r *APIKeysRepository) GetAPIKeyByHash(ctx context.Context, keyHash string) (*models.APIKey, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	for _, key := range r.db.apiKeys {
+		if key.KeyHash == keyHash {
+			return copyAPIKey(key), nil
+		}
+	}
+	return nil, storage.ErrAPIKeyNotFound
+}
+
+// GetAPIKey récupère une clé par son identifiant
+func (r *APIKeysRepository) GetAPIKey(ctx context.Context, id string) (*models.APIKey, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	key, ok := r.db.apiKeys[id]
+	if !ok {
+		return nil, storage.ErrAPIKeyNotFound
+	}
+	return copyAPIKey(key), nil
+}
+
+// ListAPIKeys liste les clés de l'organisation, de la plus récente à la plus ancienne
+func (r *APIKeysRepository) ListAPIKeys(ctx context.Context, orgID string) ([]*models.APIKey, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	keys := []*models.APIKey{}
+	for _, key := range r.db.apiKeys {
+		if key.OrganizationID == orgID {
+			keys = append(keys, copyAPIKey(key))
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].CreatedAt.After(keys[j].CreatedAt) })
+	return keys, nil
+}
+
+// DeleteAPIKey révoque une clé de l'organisation
+func (r *APIKeysRepository) DeleteAPIKey(ctx context.Context, orgID, id string) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	key, ok := r.db.apiKeys[id]
+	if !ok || key.OrganizationID != orgID {
+		return storage.ErrAPIKeyNotFound
+	}
+	delete(r.db.apiKeys, id)
+	return nil
+}
+
+// TouchAPIKey enregistre la date de dernière utilisation
+func (r *APIKeysRepository) TouchAPIKey(ctx context.Context, id string, at time.Time) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if key, ok := r.db.apiKeys[id]; ok {
+		key.LastUsedAt = &at
+	}
+	return nil
+}
+
+// ClaimRequestSignature retient la signature d'une requête jusqu'à expiresAt
+func (r *APIKeysRepository) ClaimRequestSignature(ctx context.Context, keyID, signature string,
+	expiresAt time.Time) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	claim := keyID + ":" + signature
+	if until, ok := r.db.requestSignatures[claim]; ok && time.Now().Before(until) {
+		return storage.ErrSignatureReplayed
+	}
+	r.db.requestSignatures[claim] = expiresAt
+	return nil
+}
+
+// PurgeExpiredRequestSignatures oublie les signatures expirées
+func (r *APIKeysRepository) PurgeExpiredRequestSignatures(ctx context.Context, now time.Time) (int64, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	var purged int64
+	for claim, until := range r.db.requestSignatures {
+		if !now.Before(until) {
+			delete(r.db.requestSignatures, claim)
+			purged++
+		}
+	}
+	return purged, nil
+}
+
+func copyAPIKey(key *models.APIKey) *models.APIKey {
+	copied := *key
+	if key.ExpiresAt != nil {
+		expiresAt := *key.ExpiresAt
+		copied.ExpiresAt = &expiresAt
+	}
+	if key.LastUsedAt != nil {
+		lastUsedAt := *key.LastUsedAt
+		copied.LastUsedAt = &lastUsedAt
+	}
+	return &copied
+}
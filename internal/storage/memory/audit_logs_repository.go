@@ -0,0 +1,57 @@
+// filepath: internal/storage/memory/audit_logs_repository.go
+
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// AuditLogsRepository est l'implémentation en mémoire de storage.AuditLogsRepository
+type AuditLogsRepository struct {
+	db *DB
+}
+
+var _ storage.AuditLogsRepository = (*AuditLogsRepository)(nil)
+
+// NewAuditLogsRepository crée un nouveau repository du journal d'audit en mémoire
+func NewAuditLogsRepository(db *DB) *AuditLogsRepository {
+	return &AuditLogsRepository{db: db}
+}
+
+// CreateAuditLogs enregistre un lot d'entrées
+func (r *AuditLogsRepository) CreateAuditLogs(ctx context.Context, logs []*models.AuditLog) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	for _, log := range logs {
+		if log.ID == "" {
+			log.ID = uuid.New().String()
+		}
+		copied := *log
+		r.db.auditLogs = append(r.db.auditLogs, &copied)
+	}
+	return nil
+}
+
+// ListAuditLogs liste les entrées de l'organisation depuis since, de la plus récente à la plus ancienne
+func (r *AuditLogsRepository) ListAuditLogs(ctx context.Context, orgID string, since time.Time, limit int) ([]*models.AuditLog, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	// Les entrées sont enregistrées dans l'ordre chronologique
+	logs := []*models.AuditLog{}
+	for i := len(r.db.auditLogs) - 1; i >= 0 && len(logs) < limit; i-- {
+		log := r.db.auditLogs[i]
+		if log.OrganizationID == orgID && !log.Timestamp.Before(since) {
+			copied := *log
+			logs = append(logs, &copied)
+		}
+	}
+	return logs, nil
+}
@@ -0,0 +1,131 @@
+// filepath: internal/storage/memory/bulk_rotations_repository.go
+
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// BulkRotationsRepository est l'implémentation en mémoire de storage.BulkRotationsRepository
+type BulkRotationsRepository struct {
+	db *DB
+}
+
+var _ storage.BulkRotationsRepository = (*BulkRotationsRepository)(nil)
+
+// NewBulkRotationsRepository crée un nouveau repository de rotations groupées en mémoire
+func NewBulkRotationsRepository(db *DB) *BulkRotationsRepository {
+	return &BulkRotationsRepository{db: db}
+}
+
+// CreateBulkRotation enregistre une rotation groupée et ses secrets
+func (r *BulkRotationsRepository) CreateBulkRotation(ctx context.Context, rotation *models.BulkRotation) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if rotation.ID == "" {
+		rotation.ID = uuid.New().String()
+	}
+	rotation.CreatedAt = time.Now()
+	for _, item := range rotation.Items {
+		if item.ID == "" {
+			item.ID = uuid.New().String()
+		}
+		item.RotationID = rotation.ID
+	}
+
+	r.db.bulkRotations[rotation.ID] = copyBulkRotation(rotation, true)
+	return nil
+}
+
+// GetBulkRotation récupère une rotation groupée et ses secrets
+func (r *BulkRotationsRepository) GetBulkRotation(ctx context.Context, orgID, id string) (*models.BulkRotation, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	rotation, ok := r.db.bulkRotations[id]
+	if !ok || rotation.OrganizationID != orgID {
+		return nil, storage.ErrBulkRotationNotFound
+	}
+	return copyBulkRotation(rotation, true), nil
+}
+
+// ListUnfinishedBulkRotations liste les rotations groupées en attente ou interrompues
+func (r *BulkRotationsRepository) ListUnfinishedBulkRotations(ctx context.Context) ([]*models.BulkRotation, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	rotations := []*models.BulkRotation{}
+	for _, rotation := range r.db.bulkRotations {
+		if !rotation.Finished() {
+			rotations = append(rotations, copyBulkRotation(rotation, false))
+		}
+	}
+	sort.Slice(rotations, func(i, j int) bool { return rotations[i].CreatedAt.Before(rotations[j].CreatedAt) })
+
+	return rotations, nil
+}
+
+// UpdateBulkRotationItem enregistre le résultat de la rotation d'un secret
+func (r *BulkRotationsRepository) UpdateBulkRotationItem(ctx context.Context, item *models.BulkRotationItem) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	rotation, ok := r.db.bulkRotations[item.RotationID]
+	if !ok {
+		return nil
+	}
+	for _, existing := range rotation.Items {
+		if existing.ID == item.ID {
+			existing.Status = item.Status
+			existing.Error = item.Error
+			existing.CompletedAt = item.CompletedAt
+		}
+	}
+	return nil
+}
+
+// SetBulkRotationStatus enregistre l'état d'une rotation groupée
+func (r *BulkRotationsRepository) SetBulkRotationStatus(ctx context.Context, id, status string, completedAt *time.Time) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if rotation, ok := r.db.bulkRotations[id]; ok {
+		rotation.Status = status
+		rotation.CompletedAt = completedAt
+	}
+	return nil
+}
+
+// copyBulkRotation copie une rotation groupée et calcule son avancement ;
+// les secrets ne sont copiés que si withItems est vrai
+func copyBulkRotation(rotation *models.BulkRotation, withItems bool) *models.BulkRotation {
+	copied := *rotation
+	copied.Items = nil
+	copied.TotalItems = len(rotation.Items)
+	copied.PendingItems = 0
+	copied.FailedItems = 0
+	for _, item := range rotation.Items {
+		switch item.Status {
+		case models.BulkItemPending:
+			copied.PendingItems++
+		case models.BulkItemFailed:
+			copied.FailedItems++
+		}
+		if withItems {
+			itemCopy := *item
+			copied.Items = append(copied.Items, &itemCopy)
+		}
+	}
+	if withItems && copied.Items == nil {
+		copied.Items = []*models.BulkRotationItem{}
+	}
+	return &copied
+}
@@ -0,0 +1,70 @@
+// filepath: internal/storage/memory/confirmations_repository.go
+
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// ConfirmationsRepository est l'implémentation en mémoire de storage.ConfirmationsRepository
+type ConfirmationsRepository struct {
+	db *DB
+}
+
+var _ storage.ConfirmationsRepository = (*ConfirmationsRepository)(nil)
+
+// NewConfirmationsRepository crée un nouveau repository de confirmations en mémoire
+func NewConfirmationsRepository(db *DB) *ConfirmationsRepository {
+	return &ConfirmationsRepository{db: db}
+}
+
+// CreateConfirmation enregistre une demande de confirmation
+func (r *ConfirmationsRepository) CreateConfirmation(ctx context.Context, confirmation *models.DeletionConfirmation) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if confirmation.ID == "" {
+		confirmation.ID = uuid.New().String()
+	}
+	confirmation.CreatedAt = time.Now()
+
+	copied := *confirmation
+	r.db.confirmations[confirmation.TokenHash] = &copied
+	return nil
+}
+
+// ConsumeConfirmation renvoie et supprime la confirmation correspondant à l'empreinte
+func (r *ConfirmationsRepository) ConsumeConfirmation(ctx context.Context, tokenHash string) (*models.DeletionConfirmation, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	confirmation, ok := r.db.confirmations[tokenHash]
+	if !ok {
+		return nil, storage.ErrConfirmationNotFound
+	}
+	delete(r.db.confirmations, tokenHash)
+
+	copied := *confirmation
+	return &copied, nil
+}
+
+// PurgeExpiredConfirmations supprime les confirmations expirées
+func (r *ConfirmationsRepository) PurgeExpiredConfirmations(ctx context.Context, now time.Time) (int64, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	var purged int64
+	for key, confirmation := range r.db.confirmations {
+		if confirmation.ExpiresAt.Before(now) {
+			delete(r.db.confirmations, key)
+			purged++
+		}
+	}
+	return purged, nil
+}
@@ -0,0 +1,170 @@
+// filepath: internal/storage/memory/custom_roles_repository.go
+
+package memory
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// CustomRolesRepository est l'implémentation en mémoire de storage.CustomRolesRepository
+type CustomRolesRepository struct {
+	db *DB
+}
+
+var _ storage.CustomRolesRepository = (*CustomRolesRepository)(nil)
+
+// NewCustomRolesRepository crée un nouveau repository de rôles personnalisés en mémoire
+func NewCustomRolesRepository(db *DB) *CustomRolesRepository {
+	return &CustomRolesRepository{db: db}
+}
+
+// CreateCustomRole enregistre un rôle personnalisé
+func (r *CustomRolesRepository) CreateCustomRole(ctx context.Context, role *models.CustomRole) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if r.nameTaken(role) {
+		return storage.ErrCustomRoleExists
+	}
+	if role.ID == "" {
+		role.ID = uuid.New().String()
+	}
+	role.CreatedAt = time.Now()
+	role.UpdatedAt = role.CreatedAt
+	r.db.customRoles[role.ID] = copyCustomRole(role)
+	return nil
+}
+
+// GetCustomRole récupère un rôle de l'organisation
+func (r *CustomRolesRepository) GetCustomRole(ctx context.Context, orgID, id string) (*models.CustomRole, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	role, ok := r.db.customRoles[id]
+	if !ok || role.OrganizationID != orgID {
+		return nil, storage.ErrCustomRoleNotFound
+	}
+	return copyCustomRole(role), nil
+}
+
+// ListCustomRoles liste les rôles de l'organisation par nom
+func (r *CustomRolesRepository) ListCustomRoles(ctx context.Context, orgID string) ([]*models.CustomRole, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	roles := []*models.CustomRole{}
+	for _, role := range r.db.customRoles {
+		if role.OrganizationID == orgID {
+			roles = append(roles, copyCustomRole(role))
+		}
+	}
+	sort.Slice(roles, func(i, j int) bool { return roles[i].Name < roles[j].Name })
+	return roles, nil
+}
+
+// UpdateCustomRole remplace le nom, la description et les permissions d'un rôle
+func (r *CustomRolesRepository) UpdateCustomRole(ctx context.Context, role *models.CustomRole) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	existing, ok := r.db.customRoles[role.ID]
+	if !ok || existing.OrganizationID != role.OrganizationID {
+		return storage.ErrCustomRoleNotFound
+	}
+	if r.nameTaken(role) {
+		return storage.ErrCustomRoleExists
+	}
+	role.CreatedBy = existing.CreatedBy
+	role.CreatedAt = existing.CreatedAt
+	role.UpdatedAt = time.Now()
+	r.db.customRoles[role.ID] = copyCustomRole(role)
+	return nil
+}
+
+// DeleteCustomRole supprime un rôle et ses attributions
+func (r *CustomRolesRepository) DeleteCustomRole(ctx context.Context, orgID, id string) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	role, ok := r.db.customRoles[id]
+	if !ok || role.OrganizationID != orgID {
+		return storage.ErrCustomRoleNotFound
+	}
+	delete(r.db.customRoles, id)
+	for userID, assignment := range r.db.customRoleAssignments[orgID] {
+		if assignment.RoleID == id {
+			delete(r.db.customRoleAssignments[orgID], userID)
+		}
+	}
+	return nil
+}
+
+// AssignCustomRole attribue un rôle à un membre
+func (r *CustomRolesRepository) AssignCustomRole(ctx context.Context, assignment *models.CustomRoleAssignment) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	role, ok := r.db.customRoles[assignment.RoleID]
+	if !ok || role.OrganizationID != assignment.OrganizationID {
+		return storage.ErrCustomRoleNotFound
+	}
+	assignment.AssignedAt = time.Now()
+	if r.db.customRoleAssignments[assignment.OrganizationID] == nil {
+		r.db.customRoleAssignments[assignment.OrganizationID] = make(map[string]*models.CustomRoleAssignment)
+	}
+	copied := *assignment
+	r.db.customRoleAssignments[assignment.OrganizationID][assignment.UserID] = &copied
+	return nil
+}
+
+// UnassignCustomRole retire son rôle personnalisé à un membre
+func (r *CustomRolesRepository) UnassignCustomRole(ctx context.Context, orgID, userID string) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	delete(r.db.customRoleAssignments[orgID], userID)
+	return nil
+}
+
+// GetMemberCustomRole renvoie le rôle personnalisé d'un membre
+func (r *CustomRolesRepository) GetMemberCustomRole(ctx context.Context, orgID, userID string) (*models.CustomRole, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	assignment, ok := r.db.customRoleAssignments[orgID][userID]
+	if !ok {
+		return nil, storage.ErrCustomRoleNotFound
+	}
+	role, ok := r.db.customRoles[assignment.RoleID]
+	if !ok {
+		return nil, storage.ErrCustomRoleNotFound
+	}
+	return copyCustomRole(role), nil
+}
+
+// nameTaken indique si un autre rôle de l'organisation porte déjà ce nom ;
+// comme la collation MySQL, la comparaison ignore la casse
+func (r *CustomRolesRepository) nameTaken(role *models.CustomRole) bool {
+	for _, existing := range r.db.customRoles {
+		if existing.ID != role.ID && existing.OrganizationID == role.OrganizationID &&
+			strings.EqualFold(existing.Name, role.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+func copyCustomRole(role *models.CustomRole) *models.CustomRole {
+	copied := *role
+	copied.Permissions = slices.Clone(role.Permissions)
+	return &copied
+}
@@ -0,0 +1,101 @@
+// filepath: internal/storage/memory/data_keys_repository.go
+
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// DataKeysRepository est l'implémentation en mémoire de storage.DataKeysRepository
+type DataKeysRepository struct {
+	db *DB
+}
+
+var _ storage.DataKeysRepository = (*DataKeysRepository)(nil)
+
+// NewDataKeysRepository crée un nouveau repository de clés de données en mémoire
+func NewDataKeysRepository(db *DB) *DataKeysRepository {
+	return &DataKeysRepository{db: db}
+}
+
+// CreateDataKey enregistre une clé de données
+func (r *DataKeysRepository) CreateDataKey(ctx context.Context, key *models.DataKey) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	path := rotatorKey(key.OrganizationID, key.ProjectID, "", key.Name)
+	if _, ok := r.db.dataKeys[path]; ok {
+		return storage.ErrDataKeyExists
+	}
+	if key.ID == "" {
+		key.ID = uuid.New().String()
+	}
+	key.CreatedAt = time.Now()
+	copied := *key
+	r.db.dataKeys[path] = &copied
+	return nil
+}
+
+// GetDataKey renvoie une clé du projet par son nom
+func (r *DataKeysRepository) GetDataKey(ctx context.Context, orgID, projectID, name string) (*models.DataKey, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	key, ok := r.db.dataKeys[rotatorKey(orgID, projectID, "", name)]
+	if !ok {
+		return nil, storage.ErrDataKeyNotFound
+	}
+	copied := *key
+	return &copied, nil
+}
+
+// ListDataKeys liste les clés du projet par nom
+func (r *DataKeysRepository) ListDataKeys(ctx context.Context, orgID, projectID string) ([]*models.DataKey, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	keys := []*models.DataKey{}
+	for _, key := range r.db.dataKeys {
+		if key.OrganizationID == orgID && key.ProjectID == projectID {
+			copied := *key
+			keys = append(keys, &copied)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].Name < keys[j].Name })
+	return keys, nil
+}
+
+// RecordDataKeyOperation enregistre un chiffrement ou un déchiffrement
+func (r *DataKeysRepository) RecordDataKeyOperation(ctx context.Context, operation *models.DataKeyOperation) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if operation.ID == "" {
+		operation.ID = uuid.New().String()
+	}
+	copied := *operation
+	r.db.dataKeyOperations = append(r.db.dataKeyOperations, &copied)
+	return nil
+}
+
+// ListDataKeyOperations liste les dernières opérations d'une clé, de la plus récente à la plus ancienne
+func (r *DataKeysRepository) ListDataKeyOperations(ctx context.Context, dataKeyID string, limit int) ([]*models.DataKeyOperation, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	operations := []*models.DataKeyOperation{}
+	for i := len(r.db.dataKeyOperations) - 1; i >= 0 && len(operations) < limit; i-- {
+		if operation := r.db.dataKeyOperations[i]; operation.DataKeyID == dataKeyID {
+			copied := *operation
+			operations = append(operations, &copied)
+		}
+	}
+	return operations, nil
+}
@@ -0,0 +1,174 @@
+// filepath: internal/storage/memory/db.go
+
+// Package memory implémente les repositories de stockage en mémoire.
+// Il reproduit le comportement des repositories MySQL pour les tests
+// et le développement local sans base de données.
+package memory
+
+import (
+	"sync"
+	"time"
+
+	"secrets-manager/internal/models"
+)
+
+// DB contient les tables partagées entre les repositories en mémoire
+type DB struct {
+	mu sync.RWMutex
+
+	users             map[string]*models.User
+	organizations     map[string]*models.Organization
+	userOrganizations map[string]*models.UserOrganization
+	projects          map[string]*models.Project
+	environments      map[string]*models.Environment
+	secrets           map[string]*models.SecretMetadata
+	secretCounts      map[string]int
+	secretLimits      map[string]int
+	apiCalls          []*models.APICallCount
+	secretReads       []*models.SecretRead
+	adminAuditLogs    []*models.AdminAuditLog
+	confirmations     map[string]*models.DeletionConfirmation
+
+	organizationDeletions map[string]*models.OrganizationDeletion
+	accessReviews         map[string]*models.AccessReview
+	// organizationRegions contient les régions de résidence choisies
+	organizationRegions map[string]string
+	projectKeys         map[string]*models.ProjectEncryptionKey
+	// readmes contient les README par projet, puis par environnement ("" pour le projet)
+	readmes map[string]map[string]*models.Readme
+
+	notificationPreferences map[string]*models.NotificationPreferences
+	notificationEvents      []*models.NotificationEvent
+	webhooks                map[string]*models.Webhook
+	webhookDeliveries       []*models.WebhookDelivery
+	deviceAuthorizations    map[string]*models.DeviceAuthorization
+	personalAccessTokens    map[string]*models.PersonalAccessToken
+	apiKeys                 map[string]*models.APIKey
+	requestSignatures       map[string]time.Time
+	secretRotators          map[string]*models.SecretRotator
+	scheduledSecretChanges  map[string]*models.ScheduledSecretChange
+	maintenanceWindows      map[string]*models.MaintenanceWindow
+	dataKeys                map[string]*models.DataKey
+	dataKeyOperations       []*models.DataKeyOperation
+	ageKeys                 map[string]*models.AgeKey
+	healthCheckDays         map[string]*models.HealthCheckDay
+	healthIncidents         map[string]*models.HealthIncident
+	settingsChanges         []*models.SettingsChange
+	logForwarders           map[string]*models.LogForwarder
+	bulkRotations           map[string]*models.BulkRotation
+	userMFA                 map[string]*models.UserMFA
+	trustedDevices          map[string]*models.TrustedDevice
+	refreshTokens           map[string]*models.RefreshToken
+	sessionPolicies         map[string]*models.SessionPolicy
+	passwordPolicies        map[string]*models.PasswordPolicy
+	readReasonPolicies      map[string]*models.ReadReasonPolicy
+	auditLogs               []*models.AuditLog
+	loginEvents             []*models.LoginEvent
+	loginAlertPolicies      map[string]*models.LoginAlertPolicy
+	lockdowns               map[string]*models.Lockdown
+	invitations             map[string]*models.Invitation
+	teams                   map[string]*models.Team
+	serviceAccounts         map[string]*models.ServiceAccount
+	serviceAccountTrusts    map[string]*models.ServiceAccountTrust
+	customRoles             map[string]*models.CustomRole
+	impersonations          map[string]*models.Impersonation
+	// teamMembers contient les appartenances, par équipe puis par utilisateur
+	teamMembers map[string]map[string]*models.TeamMember
+	// teamGrants contient les octrois, par équipe
+	teamGrants map[string][]*models.TeamGrant
+	// passwordHistory contient les empreintes des mots de passe remplacés,
+	// par utilisateur, de la plus récente à la plus ancienne
+	passwordHistory map[string][]string
+	// customRoleAssignments contient les rôles personnalisés attribués, par
+	// organisation puis par utilisateur
+	customRoleAssignments map[string]map[string]*models.CustomRoleAssignment
+	// subscriptions contient l'abonnement actif de chaque organisation
+	subscriptions map[string]*models.Subscription
+	// registrationPolicy est la politique d'inscription enregistrée (nil si
+	// la configuration s'applique)
+	registrationPolicy *models.RegistrationPolicy
+	// onboardingSteps contient les étapes franchies, par sujet puis par étape
+	onboardingSteps map[string]map[string]*models.OnboardingStep
+	// projectTemplates contient les modèles de projet, par organisation
+	projectTemplates map[string]*models.ProjectTemplate
+}
+
+// NewDB crée une base en mémoire vide
+func NewDB() *DB {
+	return &DB{
+		users:             make(map[string]*models.User),
+		organizations:     make(map[string]*models.Organization),
+		userOrganizations: make(map[string]*models.UserOrganization),
+		projects:          make(map[string]*models.Project),
+		environments:      make(map[string]*models.Environment),
+		secrets:           make(map[string]*models.SecretMetadata),
+		secretCounts:      make(map[string]int),
+		secretLimits:      make(map[string]int),
+		confirmations:     make(map[string]*models.DeletionConfirmation),
+
+		organizationDeletions: make(map[string]*models.OrganizationDeletion),
+		accessReviews:         make(map[string]*models.AccessReview),
+		organizationRegions:   make(map[string]string),
+		projectKeys:           make(map[string]*models.ProjectEncryptionKey),
+		readmes:               make(map[string]map[string]*models.Readme),
+
+		notificationPreferences: make(map[string]*models.NotificationPreferences),
+		webhooks:                make(map[string]*models.Webhook),
+		deviceAuthorizations:    make(map[string]*models.DeviceAuthorization),
+		personalAccessTokens:    make(map[string]*models.PersonalAccessToken),
+		apiKeys:                 make(map[string]*models.APIKey),
+		requestSignatures:       make(map[string]time.Time),
+		secretRotators:          make(map[string]*models.SecretRotator),
+		scheduledSecretChanges:  make(map[string]*models.ScheduledSecretChange),
+		maintenanceWindows:      make(map[string]*models.MaintenanceWindow),
+		dataKeys:                make(map[string]*models.DataKey),
+		ageKeys:                 make(map[string]*models.AgeKey),
+		healthCheckDays:         make(map[string]*models.HealthCheckDay),
+		healthIncidents:         make(map[string]*models.HealthIncident),
+		logForwarders:           make(map[string]*models.LogForwarder),
+		bulkRotations:           make(map[string]*models.BulkRotation),
+		userMFA:                 make(map[string]*models.UserMFA),
+		trustedDevices:          make(map[string]*models.TrustedDevice),
+		refreshTokens:           make(map[string]*models.RefreshToken),
+		sessionPolicies:         make(map[string]*models.SessionPolicy),
+		passwordPolicies:        make(map[string]*models.PasswordPolicy),
+		passwordHistory:         make(map[string][]string),
+		readReasonPolicies:      make(map[string]*models.ReadReasonPolicy),
+		loginAlertPolicies:      make(map[string]*models.LoginAlertPolicy),
+		lockdowns:               make(map[string]*models.Lockdown),
+		invitations:             make(map[string]*models.Invitation),
+		teams:                   make(map[string]*models.Team),
+		serviceAccounts:         make(map[string]*models.ServiceAccount),
+		serviceAccountTrusts:    make(map[string]*models.ServiceAccountTrust),
+		customRoles:             make(map[string]*models.CustomRole),
+		impersonations:          make(map[string]*models.Impersonation),
+		teamMembers:             make(map[string]map[string]*models.TeamMember),
+		teamGrants:              make(map[string][]*models.TeamGrant),
+		customRoleAssignments:   make(map[string]map[string]*models.CustomRoleAssignment),
+		subscriptions:           make(map[string]*models.Subscription),
+		onboardingSteps:         make(map[string]map[string]*models.OnboardingStep),
+		projectTemplates:        make(map[string]*models.ProjectTemplate),
+	}
+}
+
+// SetSecretsLimit définit la limite de secrets de l'abonnement actif d'une organisation
+func (db *DB) SetSecretsLimit(orgID string, limit int) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	db.secretLimits[orgID] = limit
+}
+
+// SetSubscription définit l'abonnement actif d'une organisation
+func (db *DB) SetSubscription(subscription *models.Subscription) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	copied := *subscription
+	db.subscriptions[subscription.OrganizationID] = &copied
+}
+
+// membershipKey construit la clé d'une appartenance utilisateur/organisation
+func membershipKey(userID, orgID string) string {
+	return userID + "/" + orgID
+}
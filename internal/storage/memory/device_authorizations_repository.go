@@ -0,0 +1,104 @@
+// filepath: internal/storage/memory/device_authorizations_repository.go
+
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// DeviceAuthorizationsRepository est l'implémentation en mémoire de storage.DeviceAuthorizationsRepository
+type DeviceAuthorizationsRepository struct {
+	db *DB
+}
+
+var _ storage.DeviceAuthorizationsRepository = (*DeviceAuthorizationsRepository)(nil)
+
+// NewDeviceAuthorizationsRepository crée un nouveau repository d'autorisations d'appareils en mémoire
+func NewDeviceAuthorizationsRepository(db *DB) *DeviceAuthorizationsRepository {
+	return &DeviceAuthorizationsRepository{db: db}
+}
+
+// CreateDeviceAuthorization enregistre une demande de connexion d'un appareil
+func (r *DeviceAuthorizationsRepository) CreateDeviceAuthorization(ctx context.Context, authorization *models.DeviceAuthorization) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	for _, existing := range r.db.deviceAuthorizations {
+		if existing.UserCode == authorization.UserCode || existing.DeviceCodeHash == authorization.DeviceCodeHash {
+			return storage.ErrAlreadyExists
+		}
+	}
+	if authorization.ID == "" {
+		authorization.ID = uuid.New().String()
+	}
+	authorization.CreatedAt = time.Now()
+
+	copied := *authorization
+	r.db.deviceAuthorizations[authorization.ID] = &copied
+	return nil
+}
+
+// PollDeviceAuthorization renvoie la demande et enregistre la date d'interrogation
+func (r *DeviceAuthorizationsRepository) PollDeviceAuthorization(ctx context.Context, deviceCodeHash string, now time.Time) (*models.DeviceAuthorization, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	for _, authorization := range r.db.deviceAuthorizations {
+		if authorization.DeviceCodeHash == deviceCodeHash {
+			copied := *authorization
+			polledAt := now
+			authorization.LastPolledAt = &polledAt
+			return &copied, nil
+		}
+	}
+	return nil, storage.ErrDeviceCodeNotFound
+}
+
+// DecideDeviceAuthorization approuve ou refuse une demande en attente non expirée
+func (r *DeviceAuthorizationsRepository) DecideDeviceAuthorization(ctx context.Context, userCode, status, userID string, now time.Time) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	for _, authorization := range r.db.deviceAuthorizations {
+		if authorization.UserCode == userCode && authorization.Status == models.DeviceAuthorizationPending &&
+			authorization.ExpiresAt.After(now) {
+			authorization.Status = status
+			authorization.UserID = userID
+			return nil
+		}
+	}
+	return storage.ErrDeviceCodeNotFound
+}
+
+// DeleteDeviceAuthorization supprime une demande
+func (r *DeviceAuthorizationsRepository) DeleteDeviceAuthorization(ctx context.Context, id string) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if _, ok := r.db.deviceAuthorizations[id]; !ok {
+		return storage.ErrDeviceCodeNotFound
+	}
+	delete(r.db.deviceAuthorizations, id)
+	return nil
+}
+
+// PurgeExpiredDeviceAuthorizations supprime les demandes expirées
+func (r *DeviceAuthorizationsRepository) PurgeExpiredDeviceAuthorizations(ctx context.Context, now time.Time) (int64, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	var purged int64
+	for id, authorization := range r.db.deviceAuthorizations {
+		if authorization.ExpiresAt.Before(now) {
+			delete(r.db.deviceAuthorizations, id)
+			purged++
+		}
+	}
+	return purged, nil
+}
@@ -0,0 +1,139 @@
+// filepath: internal/storage/memory/health_repository.go
+
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// HealthRepository est l'implémentation en mémoire de storage.HealthRepository
+type HealthRepository struct {
+	db *DB
+}
+
+var _ storage.HealthRepository = (*HealthRepository)(nil)
+
+// NewHealthRepository crée un nouveau repository de l'historique de santé en mémoire
+func NewHealthRepository(db *DB) *HealthRepository {
+	return &HealthRepository{db: db}
+}
+
+// RecordHealthCheck comptabilise une vérification d'un composant dans son jour (UTC)
+func (r *HealthRepository) RecordHealthCheck(ctx context.Context, component string, at time.Time, healthy bool) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	day := at.UTC().Format("2006-01-02")
+	key := component + "\x00" + day
+	aggregate, ok := r.db.healthCheckDays[key]
+	if !ok {
+		aggregate = &models.HealthCheckDay{Component: component, Day: day}
+		r.db.healthCheckDays[key] = aggregate
+	}
+	aggregate.Checks++
+	if !healthy {
+		aggregate.Failures++
+	}
+	return nil
+}
+
+// ListHealthCheckDays liste les agrégats journaliers depuis le jour de since
+func (r *HealthRepository) ListHealthCheckDays(ctx context.Context, since time.Time) ([]*models.HealthCheckDay, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	first := since.UTC().Format("2006-01-02")
+	days := []*models.HealthCheckDay{}
+	for _, aggregate := range r.db.healthCheckDays {
+		if aggregate.Day >= first {
+			copied := *aggregate
+			days = append(days, &copied)
+		}
+	}
+	sort.Slice(days, func(i, j int) bool {
+		if days[i].Component != days[j].Component {
+			return days[i].Component < days[j].Component
+		}
+		return days[i].Day < days[j].Day
+	})
+	return days, nil
+}
+
+// PurgeHealthChecks supprime les agrégats des jours antérieurs à before
+func (r *HealthRepository) PurgeHealthChecks(ctx context.Context, before time.Time) (int64, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	limit := before.UTC().Format("2006-01-02")
+	var purged int64
+	for key, aggregate := range r.db.healthCheckDays {
+		if aggregate.Day < limit {
+			delete(r.db.healthCheckDays, key)
+			purged++
+		}
+	}
+	return purged, nil
+}
+
+// OpenHealthIncident ouvre un incident, sauf si le composant en a déjà un en cours
+func (r *HealthRepository) OpenHealthIncident(ctx context.Context, incident *models.HealthIncident) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	for _, existing := range r.db.healthIncidents {
+		if existing.Component == incident.Component && existing.ResolvedAt == nil {
+			return storage.ErrIncidentOpen
+		}
+	}
+	if incident.ID == "" {
+		incident.ID = uuid.New().String()
+	}
+	incident.ResolvedAt = nil
+
+	copied := *incident
+	r.db.healthIncidents[incident.ID] = &copied
+	return nil
+}
+
+// ResolveHealthIncidents résout les incidents en cours du composant
+func (r *HealthRepository) ResolveHealthIncidents(ctx context.Context, component string, at time.Time) (int64, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	var resolved int64
+	for _, incident := range r.db.healthIncidents {
+		if incident.Component == component && incident.ResolvedAt == nil {
+			resolvedAt := at
+			incident.ResolvedAt = &resolvedAt
+			resolved++
+		}
+	}
+	return resolved, nil
+}
+
+// ListHealthIncidents liste les incidents en cours et ceux résolus depuis since
+func (r *HealthRepository) ListHealthIncidents(ctx context.Context, since time.Time) ([]*models.HealthIncident, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	incidents := []*models.HealthIncident{}
+	for _, incident := range r.db.healthIncidents {
+		if incident.ResolvedAt == nil || !incident.ResolvedAt.Before(since) {
+			copied := *incident
+			if incident.ResolvedAt != nil {
+				resolvedAt := *incident.ResolvedAt
+				copied.ResolvedAt = &resolvedAt
+			}
+			incidents = append(incidents, &copied)
+		}
+	}
+	sort.Slice(incidents, func(i, j int) bool { return incidents[i].StartedAt.After(incidents[j].StartedAt) })
+	return incidents, nil
+}
@@ -0,0 +1,84 @@
+// filepath: internal/storage/memory/impersonations_repository.go
+
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// ImpersonationsRepository est l'implémentation en mémoire de storage.ImpersonationsRepository
+type ImpersonationsRepository struct {
+	db *DB
+}
+
+var _ storage.ImpersonationsRepository = (*ImpersonationsRepository)(nil)
+
+// NewImpersonationsRepository crée un nouveau repository d'usurpations d'identité en mémoire
+func NewImpersonationsRepository(db *DB) *ImpersonationsRepository {
+	return &ImpersonationsRepository{db: db}
+}
+
+// CreateImpersonation enregistre une usurpation
+func (r *ImpersonationsRepository) CreateImpersonation(ctx context.Context, impersonation *models.Impersonation) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if impersonation.ID == "" {
+		impersonation.ID = uuid.New().String()
+	}
+	copied := *impersonation
+	r.db.impersonations[impersonation.ID] = &copied
+	return nil
+}
+
+// GetImpersonation récupère une usurpation
+func (r *ImpersonationsRepository) GetImpersonation(ctx context.Context, id string) (*models.Impersonation, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	impersonation, ok := r.db.impersonations[id]
+	if !ok {
+		return nil, storage.ErrImpersonationNotFound
+	}
+	copied := *impersonation
+	return &copied, nil
+}
+
+// ListUserImpersonations liste les usurpations du compte d'un utilisateur,
+// de la plus récente à la plus ancienne
+func (r *ImpersonationsRepository) ListUserImpersonations(ctx context.Context, userID string) ([]*models.Impersonation, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	impersonations := []*models.Impersonation{}
+	for _, impersonation := range r.db.impersonations {
+		if impersonation.UserID == userID {
+			copied := *impersonation
+			impersonations = append(impersonations, &copied)
+		}
+	}
+	sort.Slice(impersonations, func(i, j int) bool {
+		return impersonations[i].CreatedAt.After(impersonations[j].CreatedAt)
+	})
+	return impersonations, nil
+}
+
+// EndImpersonation termine une usurpation en cours
+func (r *ImpersonationsRepository) EndImpersonation(ctx context.Context, id string, at time.Time) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	impersonation, ok := r.db.impersonations[id]
+	if !ok || impersonation.EndedAt != nil {
+		return storage.ErrImpersonationNotFound
+	}
+	impersonation.EndedAt = &at
+	return nil
+}
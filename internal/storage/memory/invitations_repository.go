@@ -0,0 +1,131 @@
+// filepath: internal/storage/memory/invitations_repository.go
+
+package memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// InvitationsRepository est l'implémentation en mémoire de storage.InvitationsRepository
+type InvitationsRepository struct {
+	db *DB
+}
+
+var _ storage.InvitationsRepository = (*InvitationsRepository)(nil)
+
+// NewInvitationsRepository crée un nouveau repository d'invitations en mémoire
+func NewInvitationsRepository(db *DB) *InvitationsRepository {
+	return &InvitationsRepository{db: db}
+}
+
+// CreateInvitation enregistre une invitation
+func (r *InvitationsRepository) CreateInvitation(ctx context.Context, invitation *models.Invitation) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	now := time.Now()
+	for _, existing := range r.db.invitations {
+		// Comme la collation MySQL, la comparaison des emails ignore la casse
+		if existing.OrganizationID == invitation.OrganizationID &&
+			strings.EqualFold(existing.Email, invitation.Email) &&
+			existing.Status(now) == models.InvitationPending {
+			return storage.ErrInvitationPending
+		}
+	}
+
+	if invitation.ID == "" {
+		invitation.ID = uuid.New().String()
+	}
+	invitation.CreatedAt = now
+	r.db.invitations[invitation.ID] = copyInvitation(invitation)
+	return nil
+}
+
+// GetInvitationByHash récupère l'invitation correspondant à l'empreinte
+func (r *InvitationsRepository) GetInvitationByHash(ctx context.Context, tokenHash string) (*models.Invitation, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	for _, invitation := range r.db.invitations {
+		if invitation.TokenHash == tokenHash {
+			return copyInvitation(invitation), nil
+		}
+	}
+	return nil, storage.ErrInvitationNotFound
+}
+
+// ListPendingInvitations liste les invitations en attente de l'organisation
+func (r *InvitationsRepository) ListPendingInvitations(ctx context.Context, orgID string, now time.Time) ([]*models.Invitation, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	invitations := []*models.Invitation{}
+	for _, invitation := range r.db.invitations {
+		if invitation.OrganizationID == orgID && invitation.Status(now) == models.InvitationPending {
+			invitations = append(invitations, copyInvitation(invitation))
+		}
+	}
+	sort.Slice(invitations, func(i, j int) bool { return invitations[i].CreatedAt.After(invitations[j].CreatedAt) })
+	return invitations, nil
+}
+
+// RevokeInvitation révoque une invitation en attente de l'organisation
+func (r *InvitationsRepository) RevokeInvitation(ctx context.Context, orgID, id string, now time.Time) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	invitation, ok := r.db.invitations[id]
+	if !ok || invitation.OrganizationID != orgID || invitation.Status(now) != models.InvitationPending {
+		return storage.ErrInvitationNotFound
+	}
+	invitation.RevokedAt = &now
+	return nil
+}
+
+// AcceptInvitation marque une invitation en attente comme acceptée
+func (r *InvitationsRepository) AcceptInvitation(ctx context.Context, id, userID string, now time.Time) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	invitation, ok := r.db.invitations[id]
+	if !ok || invitation.Status(now) != models.InvitationPending {
+		return storage.ErrInvitationNotFound
+	}
+	invitation.AcceptedAt = &now
+	invitation.AcceptedBy = userID
+	return nil
+}
+
+// HasPendingInvitation indique si l'adresse est invitée dans une organisation
+func (r *InvitationsRepository) HasPendingInvitation(ctx context.Context, email string, now time.Time) (bool, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	for _, invitation := range r.db.invitations {
+		if strings.EqualFold(invitation.Email, email) && invitation.Status(now) == models.InvitationPending {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func copyInvitation(invitation *models.Invitation) *models.Invitation {
+	copied := *invitation
+	if invitation.AcceptedAt != nil {
+		acceptedAt := *invitation.AcceptedAt
+		copied.AcceptedAt = &acceptedAt
+	}
+	if invitation.RevokedAt != nil {
+		revokedAt := *invitation.RevokedAt
+		copied.RevokedAt = &revokedAt
+	}
+	return &copied
+}
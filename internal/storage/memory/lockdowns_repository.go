@@ -0,0 +1,95 @@
+// filepath: internal/storage/memory/lockdowns_repository.go
+
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// LockdownsRepository est l'implémentation en mémoire de storage.LockdownsRepository
+type LockdownsRepository struct {
+	db *DB
+}
+
+var _ storage.LockdownsRepository = (*LockdownsRepository)(nil)
+
+// NewLockdownsRepository crée un nouveau repository de confinements en mémoire
+func NewLockdownsRepository(db *DB) *LockdownsRepository {
+	return &LockdownsRepository{db: db}
+}
+
+// CreateLockdown enregistre un confinement s'il n'y en a pas déjà un en cours
+func (r *LockdownsRepository) CreateLockdown(ctx context.Context, lockdown *models.Lockdown) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if r.activeLocked(lockdown.OrganizationID) != nil {
+		return storage.ErrLockdownActive
+	}
+	if lockdown.ID == "" {
+		lockdown.ID = uuid.New().String()
+	}
+	if lockdown.StartedAt.IsZero() {
+		lockdown.StartedAt = time.Now()
+	}
+	copied := *lockdown
+	r.db.lockdowns[lockdown.ID] = &copied
+	return nil
+}
+
+// GetActiveLockdown renvoie le confinement en cours de l'organisation
+func (r *LockdownsRepository) GetActiveLockdown(ctx context.Context, orgID string) (*models.Lockdown, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	lockdown := r.activeLocked(orgID)
+	if lockdown == nil {
+		return nil, storage.ErrLockdownNotFound
+	}
+	copied := *lockdown
+	return &copied, nil
+}
+
+// SetLockdownRevocations enregistre le nombre de membres et de tokens révoqués
+func (r *LockdownsRepository) SetLockdownRevocations(ctx context.Context, id string, affectedUsers, revokedTokens int) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	lockdown, ok := r.db.lockdowns[id]
+	if !ok {
+		return storage.ErrLockdownNotFound
+	}
+	lockdown.AffectedUsers = affectedUsers
+	lockdown.RevokedTokens = revokedTokens
+	return nil
+}
+
+// LiftLockdown lève le confinement en cours de l'organisation
+func (r *LockdownsRepository) LiftLockdown(ctx context.Context, orgID, liftedBy string, at time.Time) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	lockdown := r.activeLocked(orgID)
+	if lockdown == nil {
+		return storage.ErrLockdownNotFound
+	}
+	lockdown.LiftedBy = liftedBy
+	lockdown.LiftedAt = &at
+	return nil
+}
+
+// activeLocked renvoie le confinement en cours ; le verrou doit être détenu
+func (r *LockdownsRepository) activeLocked(orgID string) *models.Lockdown {
+	for _, lockdown := range r.db.lockdowns {
+		if lockdown.OrganizationID == orgID && lockdown.Active() {
+			return lockdown
+		}
+	}
+	return nil
+}
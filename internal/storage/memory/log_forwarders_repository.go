@@ -0,0 +1,87 @@
+// filepath: internal/storage/memory/log_forwarders_repository.go
+
+package memory
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// LogForwardersRepository est l'implémentation en mémoire de storage.LogForwardersRepository
+type LogForwardersRepository struct {
+	db *DB
+}
+
+var _ storage.LogForwardersRepository = (*LogForwardersRepository)(nil)
+
+// NewLogForwardersRepository crée un nouveau repository des destinations de journaux en mémoire
+func NewLogForwardersRepository(db *DB) *LogForwardersRepository {
+	return &LogForwardersRepository{db: db}
+}
+
+// CreateLogForwarder enregistre une destination
+func (r *LogForwardersRepository) CreateLogForwarder(ctx context.Context, forwarder *models.LogForwarder) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if forwarder.ID == "" {
+		forwarder.ID = uuid.New().String()
+	}
+	forwarder.CreatedAt = time.Now()
+	r.db.logForwarders[forwarder.ID] = copyLogForwarder(forwarder)
+	return nil
+}
+
+// GetLogForwarder récupère une destination de l'organisation
+func (r *LogForwardersRepository) GetLogForwarder(ctx context.Context, orgID, id string) (*models.LogForwarder, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	forwarder, ok := r.db.logForwarders[id]
+	if !ok || forwarder.OrganizationID != orgID {
+		return nil, storage.ErrForwarderNotFound
+	}
+	return copyLogForwarder(forwarder), nil
+}
+
+// ListLogForwarders liste les destinations de l'organisation par date de création
+func (r *LogForwardersRepository) ListLogForwarders(ctx context.Context, orgID string) ([]*models.LogForwarder, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	forwarders := []*models.LogForwarder{}
+	for _, forwarder := range r.db.logForwarders {
+		if forwarder.OrganizationID == orgID {
+			forwarders = append(forwarders, copyLogForwarder(forwarder))
+		}
+	}
+	sort.Slice(forwarders, func(i, j int) bool { return forwarders[i].CreatedAt.Before(forwarders[j].CreatedAt) })
+	return forwarders, nil
+}
+
+// DeleteLogForwarder supprime une destination
+func (r *LogForwardersRepository) DeleteLogForwarder(ctx context.Context, orgID, id string) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	forwarder, ok := r.db.logForwarders[id]
+	if !ok || forwarder.OrganizationID != orgID {
+		return storage.ErrForwarderNotFound
+	}
+	delete(r.db.logForwarders, id)
+	return nil
+}
+
+func copyLogForwarder(forwarder *models.LogForwarder) *models.LogForwarder {
+	copied := *forwarder
+	copied.Methods = slices.Clone(forwarder.Methods)
+	copied.Projects = slices.Clone(forwarder.Projects)
+	return &copied
+}
@@ -0,0 +1,47 @@
+// filepath: internal/storage/memory/login_alert_policies_repository.go
+
+package memory
+
+import (
+	"context"
+	"time"
+
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// LoginAlertPoliciesRepository est l'implémentation en mémoire de storage.LoginAlertPoliciesRepository
+type LoginAlertPoliciesRepository struct {
+	db *DB
+}
+
+var _ storage.LoginAlertPoliciesRepository = (*LoginAlertPoliciesRepository)(nil)
+
+// NewLoginAlertPoliciesRepository crée un nouveau repository de politiques d'alerte en mémoire
+func NewLoginAlertPoliciesRepository(db *DB) *LoginAlertPoliciesRepository {
+	return &LoginAlertPoliciesRepository{db: db}
+}
+
+// GetLoginAlertPolicy renvoie la politique de l'organisation
+func (r *LoginAlertPoliciesRepository) GetLoginAlertPolicy(ctx context.Context, orgID string) (*models.LoginAlertPolicy, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	policy, ok := r.db.loginAlertPolicies[orgID]
+	if !ok {
+		return nil, storage.ErrLoginPolicyNotFound
+	}
+	copied := *policy
+	return &copied, nil
+}
+
+// SaveLoginAlertPolicy crée ou remplace la politique de l'organisation
+func (r *LoginAlertPoliciesRepository) SaveLoginAlertPolicy(ctx context.Context, policy *models.LoginAlertPolicy) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	policy.UpdatedAt = time.Now()
+	copied := *policy
+	r.db.loginAlertPolicies[policy.OrganizationID] = &copied
+	return nil
+}
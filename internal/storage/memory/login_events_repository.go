@@ -0,0 +1,81 @@
+// filepath: internal/storage/memory/login_events_repository.go
+
+package memory
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// LoginEventsRepository est l'implémentation en mémoire de storage.LoginEventsRepository
+type LoginEventsRepository struct {
+	db *DB
+}
+
+var _ storage.LoginEventsRepository = (*LoginEventsRepository)(nil)
+
+// NewLoginEventsRepository crée un nouveau repository de connexions en mémoire
+func NewLoginEventsRepository(db *DB) *LoginEventsRepository {
+	return &LoginEventsRepository{db: db}
+}
+
+// RecordLoginEvent enregistre une connexion
+func (r *LoginEventsRepository) RecordLoginEvent(ctx context.Context, event *models.LoginEvent) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if event.ID == "" {
+		event.ID = uuid.New().String()
+	}
+	copied := *event
+	r.db.loginEvents = append(r.db.loginEvents, &copied)
+	return nil
+}
+
+// LastLocatedLoginEvent renvoie la dernière connexion située de l'utilisateur
+func (r *LoginEventsRepository) LastLocatedLoginEvent(ctx context.Context, userID string) (*models.LoginEvent, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	// Les connexions sont enregistrées dans l'ordre chronologique
+	for i := len(r.db.loginEvents) - 1; i >= 0; i-- {
+		event := r.db.loginEvents[i]
+		if event.UserID == userID && event.Located() {
+			copied := *event
+			return &copied, nil
+		}
+	}
+	return nil, storage.ErrLoginEventNotFound
+}
+
+// LoginLocationKnown indique si l'utilisateur s'est déjà connecté depuis cette ville
+func (r *LoginEventsRepository) LoginLocationKnown(ctx context.Context, userID, country, city string) (bool, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	for _, event := range r.db.loginEvents {
+		if event.UserID == userID && event.Country == country && event.City == city {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// ListLoginEvents liste les dernières connexions de l'utilisateur
+func (r *LoginEventsRepository) ListLoginEvents(ctx context.Context, userID string, limit int) ([]*models.LoginEvent, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	events := []*models.LoginEvent{}
+	for i := len(r.db.loginEvents) - 1; i >= 0 && len(events) < limit; i-- {
+		if event := r.db.loginEvents[i]; event.UserID == userID {
+			copied := *event
+			events = append(events, &copied)
+		}
+	}
+	return events, nil
+}
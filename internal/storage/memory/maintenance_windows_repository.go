@@ -0,0 +1,83 @@
+// filepath: internal/storage/memory/maintenance_windows_repository.go
+
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// MaintenanceWindowsRepository est l'implémentation en mémoire de storage.MaintenanceWindowsRepository
+type MaintenanceWindowsRepository struct {
+	db *DB
+}
+
+var _ storage.MaintenanceWindowsRepository = (*MaintenanceWindowsRepository)(nil)
+
+// NewMaintenanceWindowsRepository crée un nouveau repository de fenêtres de maintenance en mémoire
+func NewMaintenanceWindowsRepository(db *DB) *MaintenanceWindowsRepository {
+	return &MaintenanceWindowsRepository{db: db}
+}
+
+// CreateMaintenanceWindow enregistre une fenêtre de maintenance
+func (r *MaintenanceWindowsRepository) CreateMaintenanceWindow(ctx context.Context, window *models.MaintenanceWindow) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if window.ID == "" {
+		window.ID = uuid.New().String()
+	}
+	window.CreatedAt = time.Now()
+	copied := *window
+	r.db.maintenanceWindows[window.ID] = &copied
+	return nil
+}
+
+// ListMaintenanceWindows liste les fenêtres de l'organisation par environnement
+func (r *MaintenanceWindowsRepository) ListMaintenanceWindows(ctx context.Context, orgID string) ([]*models.MaintenanceWindow, error) {
+	return r.list(orgID, func(window *models.MaintenanceWindow) bool { return true }), nil
+}
+
+// ListEnvironmentMaintenanceWindows liste les fenêtres d'un environnement de l'organisation
+func (r *MaintenanceWindowsRepository) ListEnvironmentMaintenanceWindows(ctx context.Context, orgID, env string) ([]*models.MaintenanceWindow, error) {
+	return r.list(orgID, func(window *models.MaintenanceWindow) bool { return window.Environment == env }), nil
+}
+
+// DeleteMaintenanceWindow supprime une fenêtre de maintenance
+func (r *MaintenanceWindowsRepository) DeleteMaintenanceWindow(ctx context.Context, orgID, id string) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	window, ok := r.db.maintenanceWindows[id]
+	if !ok || window.OrganizationID != orgID {
+		return storage.ErrWindowNotFound
+	}
+	delete(r.db.maintenanceWindows, id)
+	return nil
+}
+
+func (r *MaintenanceWindowsRepository) list(orgID string, keep func(*models.MaintenanceWindow) bool) []*models.MaintenanceWindow {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	windows := []*models.MaintenanceWindow{}
+	for _, window := range r.db.maintenanceWindows {
+		if window.OrganizationID == orgID && keep(window) {
+			copied := *window
+			windows = append(windows, &copied)
+		}
+	}
+	sort.Slice(windows, func(i, j int) bool {
+		if windows[i].Environment != windows[j].Environment {
+			return windows[i].Environment < windows[j].Environment
+		}
+		return windows[i].CreatedAt.Before(windows[j].CreatedAt)
+	})
+	return windows
+}
@@ -0,0 +1,75 @@
+// filepath: internal/storage/memory/notification_events_repository.go
+
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// NotificationEventsRepository est l'implémentation en mémoire de storage.NotificationEventsRepository
+type NotificationEventsRepository struct {
+	db *DB
+}
+
+var _ storage.NotificationEventsRepository = (*NotificationEventsRepository)(nil)
+
+// NewNotificationEventsRepository crée un nouveau repository d'événements notifiés en mémoire
+func NewNotificationEventsRepository(db *DB) *NotificationEventsRepository {
+	return &NotificationEventsRepository{db: db}
+}
+
+// RecordNotificationEvent enregistre un événement
+func (r *NotificationEventsRepository) RecordNotificationEvent(ctx context.Context, event *models.NotificationEvent) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if event.ID == "" {
+		event.ID = uuid.New().String()
+	}
+	copied := *event
+	r.db.notificationEvents = append(r.db.notificationEvents, &copied)
+	return nil
+}
+
+// ListNotificationEvents liste les événements d'une organisation sur une période
+func (r *NotificationEventsRepository) ListNotificationEvents(ctx context.Context, orgID string, since, until time.Time) ([]*models.NotificationEvent, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	events := []*models.NotificationEvent{}
+	for _, event := range r.db.notificationEvents {
+		if event.OrganizationID == orgID && !event.OccurredAt.Before(since) && event.OccurredAt.Before(until) {
+			copied := *event
+			events = append(events, &copied)
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].OccurredAt.Before(events[j].OccurredAt)
+	})
+	return events, nil
+}
+
+// PurgeNotificationEvents supprime les événements survenus avant before
+func (r *NotificationEventsRepository) PurgeNotificationEvents(ctx context.Context, before time.Time) (int64, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	kept := r.db.notificationEvents[:0]
+	var purged int64
+	for _, event := range r.db.notificationEvents {
+		if event.OccurredAt.Before(before) {
+			purged++
+			continue
+		}
+		kept = append(kept, event)
+	}
+	r.db.notificationEvents = kept
+	return purged, nil
+}
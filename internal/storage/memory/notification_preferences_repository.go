@@ -0,0 +1,84 @@
+// filepath: internal/storage/memory/notification_preferences_repository.go
+
+package memory
+
+import (
+	"context"
+	"maps"
+	"time"
+
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// NotificationPreferencesRepository est l'implémentation en mémoire de storage.NotificationPreferencesRepository
+type NotificationPreferencesRepository struct {
+	db *DB
+}
+
+var _ storage.NotificationPreferencesRepository = (*NotificationPreferencesRepository)(nil)
+
+// NewNotificationPreferencesRepository crée un nouveau repository de réglages de notification en mémoire
+func NewNotificationPreferencesRepository(db *DB) *NotificationPreferencesRepository {
+	return &NotificationPreferencesRepository{db: db}
+}
+
+// GetNotificationPreferences récupère les réglages d'un utilisateur
+func (r *NotificationPreferencesRepository) GetNotificationPreferences(ctx context.Context, userID string) (*models.NotificationPreferences, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	prefs, ok := r.db.notificationPreferences[userID]
+	if !ok {
+		return nil, nil
+	}
+	return copyNotificationPreferences(prefs), nil
+}
+
+// SetNotificationPreferences remplace les réglages d'un utilisateur
+func (r *NotificationPreferencesRepository) SetNotificationPreferences(ctx context.Context, prefs *models.NotificationPreferences) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	prefs.UpdatedAt = time.Now()
+	if existing, ok := r.db.notificationPreferences[prefs.UserID]; ok {
+		prefs.LastDigestAt = existing.LastDigestAt
+	}
+	r.db.notificationPreferences[prefs.UserID] = copyNotificationPreferences(prefs)
+	return nil
+}
+
+// ListDigestSubscribers liste les réglages des utilisateurs qui reçoivent des résumés
+func (r *NotificationPreferencesRepository) ListDigestSubscribers(ctx context.Context) ([]*models.NotificationPreferences, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	subscribers := []*models.NotificationPreferences{}
+	for _, prefs := range r.db.notificationPreferences {
+		if prefs.Digest != "" {
+			subscribers = append(subscribers, copyNotificationPreferences(prefs))
+		}
+	}
+	return subscribers, nil
+}
+
+// MarkDigestSent enregistre la date du dernier résumé envoyé à un utilisateur
+func (r *NotificationPreferencesRepository) MarkDigestSent(ctx context.Context, userID string, at time.Time) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if prefs, ok := r.db.notificationPreferences[userID]; ok {
+		prefs.LastDigestAt = &at
+	}
+	return nil
+}
+
+func copyNotificationPreferences(prefs *models.NotificationPreferences) *models.NotificationPreferences {
+	copied := *prefs
+	copied.Events = maps.Clone(prefs.Events)
+	if prefs.LastDigestAt != nil {
+		lastDigestAt := *prefs.LastDigestAt
+		copied.LastDigestAt = &lastDigestAt
+	}
+	return &copied
+}
@@ -0,0 +1,61 @@
+// filepath: internal/storage/memory/onboarding_repository.go
+
+package memory
+
+import (
+	"context"
+	"time"
+
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// OnboardingRepository est l'implémentation en mémoire de storage.OnboardingRepository
+type OnboardingRepository struct {
+	db *DB
+}
+
+var _ storage.OnboardingRepository = (*OnboardingRepository)(nil)
+
+// NewOnboardingRepository crée un nouveau repository de prise en main en mémoire
+func NewOnboardingRepository(db *DB) *OnboardingRepository {
+	return &OnboardingRepository{db: db}
+}
+
+// CompleteOnboardingStep enregistre une étape franchie et renvoie vrai si
+// elle ne l'était pas encore
+func (r *OnboardingRepository) CompleteOnboardingStep(ctx context.Context, step *models.OnboardingStep) (bool, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	steps := r.db.onboardingSteps[step.SubjectID]
+	if steps == nil {
+		steps = make(map[string]*models.OnboardingStep)
+		r.db.onboardingSteps[step.SubjectID] = steps
+	}
+	if _, ok := steps[step.Step]; ok {
+		return false, nil
+	}
+
+	if step.CompletedAt.IsZero() {
+		step.CompletedAt = time.Now()
+	}
+	copied := *step
+	steps[step.Step] = &copied
+	return true, nil
+}
+
+// ListOnboardingSteps liste les étapes franchies par les sujets
+func (r *OnboardingRepository) ListOnboardingSteps(ctx context.Context, subjectIDs ...string) ([]*models.OnboardingStep, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	var result []*models.OnboardingStep
+	for _, subjectID := range subjectIDs {
+		for _, step := range r.db.onboardingSteps[subjectID] {
+			copied := *step
+			result = append(result, &copied)
+		}
+	}
+	return result, nil
+}
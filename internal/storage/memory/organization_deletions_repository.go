@@ -0,0 +1,95 @@
+// filepath: internal/storage/memory/organization_deletions_repository.go
+
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// OrganizationDeletionsRepository est l'implémentation en mémoire de storage.OrganizationDeletionsRepository
+type OrganizationDeletionsRepository struct {
+	db *DB
+}
+
+var _ storage.OrganizationDeletionsRepository = (*OrganizationDeletionsRepository)(nil)
+
+// NewOrganizationDeletionsRepository crée un nouveau repository de suppressions en mémoire
+func NewOrganizationDeletionsRepository(db *DB) *OrganizationDeletionsRepository {
+	return &OrganizationDeletionsRepository{db: db}
+}
+
+// CreateOrganizationDeletion enregistre une nouvelle suppression en attente
+func (r *OrganizationDeletionsRepository) CreateOrganizationDeletion(ctx context.Context, deletion *models.OrganizationDeletion) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if deletion.ID == "" {
+		deletion.ID = uuid.New().String()
+	}
+	now := time.Now()
+	deletion.CreatedAt = now
+	deletion.UpdatedAt = now
+
+	copied := *deletion
+	r.db.organizationDeletions[deletion.ID] = &copied
+	return nil
+}
+
+// GetOrganizationDeletion récupère une suppression par son ID
+func (r *OrganizationDeletionsRepository) GetOrganizationDeletion(ctx context.Context, id string) (*models.OrganizationDeletion, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	deletion, ok := r.db.organizationDeletions[id]
+	if !ok {
+		return nil, storage.ErrDeletionNotFound
+	}
+	copied := *deletion
+	copied.TotalStages = len(models.OrganizationDeletionStages)
+	return &copied, nil
+}
+
+// UpdateOrganizationDeletion enregistre l'avancement d'une suppression
+func (r *OrganizationDeletionsRepository) UpdateOrganizationDeletion(ctx context.Context, deletion *models.OrganizationDeletion) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	existing, ok := r.db.organizationDeletions[deletion.ID]
+	if !ok {
+		return nil
+	}
+	existing.Status = deletion.Status
+	existing.Stage = deletion.Stage
+	existing.CompletedStages = deletion.CompletedStages
+	existing.SecretsDeleted = deletion.SecretsDeleted
+	existing.PurgeAfter = deletion.PurgeAfter
+	existing.Error = deletion.Error
+	existing.CompletedAt = deletion.CompletedAt
+	existing.UpdatedAt = time.Now()
+	return nil
+}
+
+// ListUnfinishedOrganizationDeletions liste les suppressions en attente ou interrompues
+func (r *OrganizationDeletionsRepository) ListUnfinishedOrganizationDeletions(ctx context.Context) ([]*models.OrganizationDeletion, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	deletions := []*models.OrganizationDeletion{}
+	for _, deletion := range r.db.organizationDeletions {
+		if !deletion.Finished() {
+			copied := *deletion
+			copied.TotalStages = len(models.OrganizationDeletionStages)
+			deletions = append(deletions, &copied)
+		}
+	}
+	sort.Slice(deletions, func(i, j int) bool { return deletions[i].CreatedAt.Before(deletions[j].CreatedAt) })
+
+	return deletions, nil
+}
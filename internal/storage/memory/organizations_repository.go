@@ -0,0 +1,493 @@
+// filepath: internal/storage/memory/organizations_repository.go
+
+package memory
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// OrganizationsRepository est l'implémentation en mémoire de storage.OrganizationsRepository
+type OrganizationsRepository struct {
+	db *DB
+}
+
+var _ storage.OrganizationsRepository = (*OrganizationsRepository)(nil)
+
+// NewOrganizationsRepository crée un nouveau repository en mémoire pour les organisations
+func NewOrganizationsRepository(db *DB) *OrganizationsRepository {
+	return &OrganizationsRepository{db: db}
+}
+
+// CreateOrganization crée une organisation et ajoute son propriétaire comme admin
+func (r *OrganizationsRepository) CreateOrganization(ctx context.Context, org *models.Organization) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	for _, existing := range r.db.organizations {
+		if existing.Name == org.Name {
+			return storage.ErrOrganizationNameExists
+		}
+	}
+
+	if org.ID == "" {
+		org.ID = uuid.New().String()
+	}
+	now := time.Now()
+	if org.CreatedAt.IsZero() {
+		org.CreatedAt = now
+	}
+	if org.UpdatedAt.IsZero() {
+		org.UpdatedAt = now
+	}
+
+	copied := *org
+	r.db.organizations[org.ID] = &copied
+	r.db.userOrganizations[membershipKey(org.OwnerID, org.ID)] = &models.UserOrganization{
+		UserID:         org.OwnerID,
+		OrganizationID: org.ID,
+		Role:           "admin",
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	return nil
+}
+
+// GetOrganizationByID récupère une organisation par son ID
+func (r *OrganizationsRepository) GetOrganizationByID(ctx context.Context, id string) (*models.Organization, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	org, ok := r.db.organizations[id]
+	if !ok {
+		return nil, storage.ErrOrganizationNotFound
+	}
+	copied := *org
+	return &copied, nil
+}
+
+// ListUserOrganizations liste les organisations d'un utilisateur, triées par nom
+func (r *OrganizationsRepository) ListUserOrganizations(ctx context.Context, userID string) ([]*models.Organization, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	var orgs []*models.Organization
+	for _, membership := range r.db.userOrganizations {
+		if membership.UserID != userID {
+			continue
+		}
+		if org, ok := r.db.organizations[membership.OrganizationID]; ok {
+			copied := *org
+			orgs = append(orgs, &copied)
+		}
+	}
+	sort.Slice(orgs, func(i, j int) bool { return orgs[i].Name < orgs[j].Name })
+
+	return orgs, nil
+}
+
+// UpdateOrganization met à jour le nom et la description d'une organisation
+func (r *OrganizationsRepository) UpdateOrganization(ctx context.Context, org *models.Organization) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	for id, existing := range r.db.organizations {
+		if id != org.ID && existing.Name == org.Name {
+			return storage.ErrOrganizationNameExists
+		}
+	}
+
+	existing, ok := r.db.organizations[org.ID]
+	if !ok {
+		return storage.ErrOrganizationNotFound
+	}
+	existing.Name = org.Name
+	existing.Description = org.Description
+	existing.UpdatedAt = time.Now()
+	return nil
+}
+
+// DeleteOrganization supprime une organisation et ses données associées
+func (r *OrganizationsRepository) DeleteOrganization(ctx context.Context, id string) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if _, ok := r.db.organizations[id]; !ok {
+		return storage.ErrOrganizationNotFound
+	}
+
+	for key, membership := range r.db.userOrganizations {
+		if membership.OrganizationID == id {
+			delete(r.db.userOrganizations, key)
+		}
+	}
+	for key, project := range r.db.projects {
+		if project.OrganizationID == id {
+			delete(r.db.projects, key)
+		}
+	}
+	for key, secret := range r.db.secrets {
+		if secret.OrganizationID == id {
+			delete(r.db.secrets, key)
+		}
+	}
+	delete(r.db.secretCounts, id)
+	delete(r.db.secretLimits, id)
+	delete(r.db.organizations, id)
+	return nil
+}
+
+// ListOrganizationUsers liste les membres d'une organisation
+func (r *OrganizationsRepository) ListOrganizationUsers(ctx context.Context, orgID string) ([]*models.UserOrganization, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	var members []*models.UserOrganization
+	for _, membership := range r.db.userOrganizations {
+		if membership.OrganizationID == orgID {
+			copied := *membership
+			members = append(members, &copied)
+		}
+	}
+	sort.Slice(members, func(i, j int) bool { return members[i].UserID < members[j].UserID })
+
+	return members, nil
+}
+
+// ListOrganizationMembers liste les membres d'une organisation avec leur profil
+func (r *OrganizationsRepository) ListOrganizationMembers(ctx context.Context, orgID string) ([]*models.OrganizationMember, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	members := []*models.OrganizationMember{}
+	for _, membership := range r.db.userOrganizations {
+		if membership.OrganizationID != orgID {
+			continue
+		}
+		user, ok := r.db.users[membership.UserID]
+		if !ok {
+			continue
+		}
+		members = append(members, &models.OrganizationMember{
+			UserID:    user.ID,
+			Email:     user.Email,
+			FirstName: user.FirstName,
+			LastName:  user.LastName,
+			Role:      membership.Role,
+			JoinedAt:  membership.CreatedAt,
+		})
+	}
+	sort.Slice(members, func(i, j int) bool {
+		if members[i].LastName != members[j].LastName {
+			return members[i].LastName < members[j].LastName
+		}
+		if members[i].FirstName != members[j].FirstName {
+			return members[i].FirstName < members[j].FirstName
+		}
+		return members[i].UserID < members[j].UserID
+	})
+
+	return members, nil
+}
+
+// AddUserToOrganization ajoute un utilisateur à une organisation ou met à jour son rôle
+func (r *OrganizationsRepository) AddUserToOrganization(ctx context.Context, userID, orgID, role string) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	now := time.Now()
+	key := membershipKey(userID, orgID)
+	if existing, ok := r.db.userOrganizations[key]; ok {
+		existing.Role = role
+		existing.UpdatedAt = now
+		return nil
+	}
+
+	r.db.userOrganizations[key] = &models.UserOrganization{
+		UserID:         userID,
+		OrganizationID: orgID,
+		Role:           role,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	return nil
+}
+
+// RemoveUserFromOrganization retire un utilisateur (hors propriétaire) d'une organisation
+func (r *OrganizationsRepository) RemoveUserFromOrganization(ctx context.Context, userID, orgID string) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if org, ok := r.db.organizations[orgID]; ok && org.OwnerID == userID {
+		return errors.New("impossible de retirer le propriétaire de l'organisation")
+	}
+
+	key := membershipKey(userID, orgID)
+	if _, ok := r.db.userOrganizations[key]; !ok {
+		return errors.New("l'utilisateur n'appartient pas à cette organisation")
+	}
+	delete(r.db.userOrganizations, key)
+	return nil
+}
+
+// ChangeOrganizationOwner change le propriétaire d'une organisation
+func (r *OrganizationsRepository) ChangeOrganizationOwner(ctx context.Context, orgID, newOwnerID string) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	membership, ok := r.db.userOrganizations[membershipKey(newOwnerID, orgID)]
+	if !ok {
+		return errors.New("le nouvel utilisateur n'appartient pas à cette organisation")
+	}
+
+	org, ok := r.db.organizations[orgID]
+	if !ok {
+		return storage.ErrOrganizationNotFound
+	}
+
+	now := time.Now()
+	org.OwnerID = newOwnerID
+	org.UpdatedAt = now
+	membership.Role = "admin"
+	membership.UpdatedAt = now
+	return nil
+}
+
+// UpdateOrganizationPlan met à jour le plan d'une organisation
+func (r *OrganizationsRepository) UpdateOrganizationPlan(ctx context.Context, orgID, planID string) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	org, ok := r.db.organizations[orgID]
+	if !ok {
+		return storage.ErrOrganizationNotFound
+	}
+	org.PlanID = planID
+	org.UpdatedAt = time.Now()
+	return nil
+}
+
+// GetOrganizationPlan récupère le plan actuel d'une organisation
+func (r *OrganizationsRepository) GetOrganizationPlan(ctx context.Context, orgID string) (string, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	org, ok := r.db.organizations[orgID]
+	if !ok {
+		return "", storage.ErrOrganizationNotFound
+	}
+	return org.PlanID, nil
+}
+
+// GetOrganizationRegion récupère la région de résidence d'une organisation
+func (r *OrganizationsRepository) GetOrganizationRegion(ctx context.Context, orgID string) (string, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	if _, ok := r.db.organizations[orgID]; !ok {
+		return "", storage.ErrOrganizationNotFound
+	}
+	if region, ok := r.db.organizationRegions[orgID]; ok {
+		return region, nil
+	}
+	return models.DefaultRegion, nil
+}
+
+// UpdateOrganizationRegion change la région de résidence d'une organisation
+func (r *OrganizationsRepository) UpdateOrganizationRegion(ctx context.Context, orgID, region string) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	org, ok := r.db.organizations[orgID]
+	if !ok {
+		return storage.ErrOrganizationNotFound
+	}
+	r.db.organizationRegions[orgID] = region
+	org.UpdatedAt = time.Now()
+	return nil
+}
+
+// CountOrganizationSecrets compte le nombre de secrets d'une organisation
+func (r *OrganizationsRepository) CountOrganizationSecrets(ctx context.Context, orgID string) (int, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	count := 0
+	for _, secret := range r.db.secrets {
+		if secret.OrganizationID == orgID {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// DeleteOrganizationStage supprime les données d'une étape de suppression
+func (r *OrganizationsRepository) DeleteOrganizationStage(ctx context.Context, orgID, stage string) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	switch stage {
+	case models.DeletionStageMemberships:
+		for key, membership := range r.db.userOrganizations {
+			if membership.OrganizationID == orgID {
+				delete(r.db.userOrganizations, key)
+			}
+		}
+		for id, team := range r.db.teams {
+			if team.OrganizationID == orgID {
+				delete(r.db.teams, id)
+				delete(r.db.teamMembers, id)
+				delete(r.db.teamGrants, id)
+			}
+		}
+		for id, account := range r.db.serviceAccounts {
+			if account.OrganizationID == orgID {
+				delete(r.db.serviceAccounts, id)
+			}
+		}
+		delete(r.db.serviceAccountTrusts, orgID)
+		for id, role := range r.db.customRoles {
+			if role.OrganizationID == orgID {
+				delete(r.db.customRoles, id)
+			}
+		}
+		delete(r.db.customRoleAssignments, orgID)
+	case models.DeletionStageSecretMetadata:
+		for key, secret := range r.db.secrets {
+			if secret.OrganizationID == orgID {
+				delete(r.db.secrets, key)
+			}
+		}
+	case models.DeletionStageProjects:
+		for key, project := range r.db.projects {
+			if project.OrganizationID == orgID {
+				delete(r.db.projects, key)
+				delete(r.db.projectKeys, key)
+				delete(r.db.readmes, key)
+				deleteEnvironments(r.db, key)
+			}
+		}
+	case models.DeletionStageSubscriptions:
+		delete(r.db.secretCounts, orgID)
+		delete(r.db.secretLimits, orgID)
+		delete(r.db.subscriptions, orgID)
+	case models.DeletionStageOrganization:
+		for id, webhook := range r.db.webhooks {
+			if webhook.OrganizationID == orgID {
+				delete(r.db.webhooks, id)
+			}
+		}
+		r.db.webhookDeliveries = slices.DeleteFunc(r.db.webhookDeliveries, func(delivery *models.WebhookDelivery) bool {
+			return delivery.OrganizationID == orgID
+		})
+		r.db.notificationEvents = slices.DeleteFunc(r.db.notificationEvents, func(event *models.NotificationEvent) bool {
+			return event.OrganizationID == orgID
+		})
+		delete(r.db.organizations, orgID)
+	}
+	return nil
+}
+
+// SuspendOrganization place l'organisation dans la corbeille
+func (r *OrganizationsRepository) SuspendOrganization(ctx context.Context, orgID string) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	org, ok := r.db.organizations[orgID]
+	if !ok || org.DeletedAt != nil {
+		return storage.ErrOrganizationNotFound
+	}
+	now := time.Now()
+	org.DeletedAt = &now
+	return nil
+}
+
+// RestoreOrganization sort l'organisation de la corbeille
+func (r *OrganizationsRepository) RestoreOrganization(ctx context.Context, orgID string) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	org, ok := r.db.organizations[orgID]
+	if !ok || org.DeletedAt == nil {
+		return storage.ErrOrganizationNotFound
+	}
+	org.DeletedAt = nil
+	return nil
+}
+
+// ListSuspendedOrganizations liste les organisations de la corbeille
+func (r *OrganizationsRepository) ListSuspendedOrganizations(ctx context.Context) ([]*models.Organization, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	orgs := []*models.Organization{}
+	for _, org := range r.db.organizations {
+		if org.DeletedAt != nil {
+			copied := *org
+			orgs = append(orgs, &copied)
+		}
+	}
+	sort.Slice(orgs, func(i, j int) bool { return orgs[i].DeletedAt.Before(*orgs[j].DeletedAt) })
+
+	return orgs, nil
+}
+
+// ProvisionOrganization crée une organisation et ses ressources de départ ;
+// le verrou de la base tient lieu de transaction
+func (r *OrganizationsRepository) ProvisionOrganization(ctx context.Context, provision *models.OrganizationProvision) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	org := provision.Organization
+	for _, existing := range r.db.organizations {
+		if existing.Name == org.Name {
+			return storage.ErrOrganizationNameExists
+		}
+	}
+
+	now := time.Now()
+	org.ID = uuid.New().String()
+	org.CreatedAt, org.UpdatedAt = now, now
+	org.PlanID = provision.Subscription.PlanID
+	copiedOrg := *org
+	r.db.organizations[org.ID] = &copiedOrg
+	r.db.userOrganizations[membershipKey(org.OwnerID, org.ID)] = &models.UserOrganization{
+		UserID:         org.OwnerID,
+		OrganizationID: org.ID,
+		Role:           "admin",
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+
+	subscription := provision.Subscription
+	subscription.ID = uuid.New().String()
+	subscription.OrganizationID = org.ID
+	subscription.CreatedAt, subscription.UpdatedAt = now, now
+	copiedSubscription := *subscription
+	r.db.subscriptions[org.ID] = &copiedSubscription
+	r.db.secretLimits[org.ID] = subscription.SecretsLimit
+
+	project := provision.Project
+	project.ID = uuid.New().String()
+	project.OrganizationID = org.ID
+	project.CreatedAt, project.UpdatedAt = now, now
+	copiedProject := *project
+	r.db.projects[project.ID] = &copiedProject
+
+	for _, env := range provision.Environments {
+		env.ID = uuid.New().String()
+		env.ProjectID = project.ID
+		env.CreatedAt, env.UpdatedAt = now, now
+		copiedEnv := *env
+		r.db.environments[env.ID] = &copiedEnv
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+// filepath: internal/storage/memory/password_policies_repository.go
+
+package memory
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// PasswordPoliciesRepository est l'implémentation en mémoire de storage.PasswordPoliciesRepository
+type PasswordPoliciesRepository struct {
+	db *DB
+}
+
+var _ storage.PasswordPoliciesRepository = (*PasswordPoliciesRepository)(nil)
+
+// NewPasswordPoliciesRepository crée un nouveau repository de politiques de mot de passe en mémoire
+func NewPasswordPoliciesRepository(db *DB) *PasswordPoliciesRepository {
+	return &PasswordPoliciesRepository{db: db}
+}
+
+// GetPasswordPolicy renvoie la politique de l'organisation
+func (r *PasswordPoliciesRepository) GetPasswordPolicy(ctx context.Context, orgID string) (*models.PasswordPolicy, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	policy, ok := r.db.passwordPolicies[orgID]
+	if !ok {
+		return nil, storage.ErrPasswordPolicyNotFound
+	}
+	copied := *policy
+	return &copied, nil
+}
+
+// SavePasswordPolicy crée ou remplace la politique de l'organisation
+func (r *PasswordPoliciesRepository) SavePasswordPolicy(ctx context.Context, policy *models.PasswordPolicy) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	policy.UpdatedAt = time.Now()
+	copied := *policy
+	r.db.passwordPolicies[policy.OrganizationID] = &copied
+	return nil
+}
+
+// AddPasswordHistory enregistre l'empreinte d'un mot de passe remplacé
+func (r *PasswordPoliciesRepository) AddPasswordHistory(ctx context.Context, userID, hashedPassword string,
+	at time.Time) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	history := append([]string{hashedPassword}, r.db.passwordHistory[userID]...)
+	r.db.passwordHistory[userID] = history[:min(len(history), models.MaxPasswordHistory)]
+	return nil
+}
+
+// ListPasswordHistory renvoie les empreintes des derniers mots de passe remplacés
+func (r *PasswordPoliciesRepository) ListPasswordHistory(ctx context.Context, userID string, limit int) ([]string, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	history := r.db.passwordHistory[userID]
+	return slices.Clone(history[:min(len(history), limit)]), nil
+}
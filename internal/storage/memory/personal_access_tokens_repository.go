@@ -0,0 +1,103 @@
+// filepath: internal/storage/memory/personal_access_tokens_repository.go
+
+package memory
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// PersonalAccessTokensRepository est l'implémentation en mémoire de storage.PersonalAccessTokensRepository
+type PersonalAccessTokensRepository struct {
+	db *DB
+}
+
+var _ storage.PersonalAccessTokensRepository = (*PersonalAccessTokensRepository)(nil)
+
+// NewPersonalAccessTokensRepository crée un nouveau repository de tokens d'accès personnels en mémoire
+func NewPersonalAccessTokensRepository(db *DB) *PersonalAccessTokensRepository {
+	return &PersonalAccessTokensRepository{db: db}
+}
+
+// CreatePersonalAccessToken enregistre un token
+func (r *PersonalAccessTokensRepository) CreatePersonalAccessToken(ctx context.Context, token *models.PersonalAccessToken) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if token.ID == "" {
+		token.ID = uuid.New().String()
+	}
+	token.CreatedAt = time.Now()
+	r.db.personalAccessTokens[token.ID] = copyPersonalAccessToken(token)
+	return nil
+}
+
+// GetPersonalAccessTokenByHash récupère le token correspondant à l'empreinte
+func (r *PersonalAccessTokensRepository) GetPersonalAccessTokenByHash(ctx context.Context, tokenHash string) (*models.PersonalAccessToken, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	for _, token := range r.db.personalAccessTokens {
+		if token.TokenHash == tokenHash {
+			return copyPersonalAccessToken(token), nil
+		}
+	}
+	return nil, storage.ErrTokenNotFound
+}
+
+// ListPersonalAccessTokens liste les tokens de l'utilisateur, du plus récent au plus ancien
+func (r *PersonalAccessTokensRepository) ListPersonalAccessTokens(ctx context.Context, userID string) ([]*models.PersonalAccessToken, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	tokens := []*models.PersonalAccessToken{}
+	for _, token := range r.db.personalAccessTokens {
+		if token.UserID == userID {
+			tokens = append(tokens, copyPersonalAccessToken(token))
+		}
+	}
+	sort.Slice(tokens, func(i, j int) bool { return tokens[i].CreatedAt.After(tokens[j].CreatedAt) })
+	return tokens, nil
+}
+
+// DeletePersonalAccessToken révoque un token de l'utilisateur
+func (r *PersonalAccessTokensRepository) DeletePersonalAccessToken(ctx context.Context, userID, id string) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	token, ok := r.db.personalAccessTokens[id]
+	if !ok || token.UserID != userID {
+		return storage.ErrTokenNotFound
+	}
+	delete(r.db.personalAccessTokens, id)
+	return nil
+}
+
+// TouchPersonalAccessToken enregistre la date de dernière utilisation
+func (r *PersonalAccessTokensRepository) TouchPersonalAccessToken(ctx context.Context, id string, at time.Time) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if token, ok := r.db.personalAccessTokens[id]; ok {
+		token.LastUsedAt = &at
+	}
+	return nil
+}
+
+func copyPersonalAccessToken(token *models.PersonalAccessToken) *models.PersonalAccessToken {
+	copied := *token
+	copied.Scopes = slices.Clone(token.Scopes)
+	copied.Resources = slices.Clone(token.Resources)
+	if token.LastUsedAt != nil {
+		lastUsedAt := *token.LastUsedAt
+		copied.LastUsedAt = &lastUsedAt
+	}
+	return &copied
+}
@@ -0,0 +1,57 @@
+// filepath: internal/storage/memory/project_templates_repository.go
+
+package memory
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// ProjectTemplatesRepository est l'implémentation en mémoire de storage.ProjectTemplatesRepository
+type ProjectTemplatesRepository struct {
+	db *DB
+}
+
+var _ storage.ProjectTemplatesRepository = (*ProjectTemplatesRepository)(nil)
+
+// NewProjectTemplatesRepository crée un nouveau repository de modèles de projet en mémoire
+func NewProjectTemplatesRepository(db *DB) *ProjectTemplatesRepository {
+	return &ProjectTemplatesRepository{db: db}
+}
+
+// GetProjectTemplate renvoie le modèle de l'organisation
+func (r *ProjectTemplatesRepository) GetProjectTemplate(ctx context.Context, orgID string) (*models.ProjectTemplate, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	template, ok := r.db.projectTemplates[orgID]
+	if !ok {
+		return nil, storage.ErrProjectTemplateNotFound
+	}
+	return copyProjectTemplate(template), nil
+}
+
+// SaveProjectTemplate crée ou remplace le modèle de l'organisation
+func (r *ProjectTemplatesRepository) SaveProjectTemplate(ctx context.Context, template *models.ProjectTemplate) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	template.UpdatedAt = time.Now()
+	r.db.projectTemplates[template.OrganizationID] = copyProjectTemplate(template)
+	return nil
+}
+
+func copyProjectTemplate(template *models.ProjectTemplate) *models.ProjectTemplate {
+	copied := *template
+	copied.Environments = slices.Clone(template.Environments)
+	copied.Placeholders = make([]models.PlaceholderSecret, len(template.Placeholders))
+	for i, placeholder := range template.Placeholders {
+		placeholder.Environments = slices.Clone(placeholder.Environments)
+		copied.Placeholders[i] = placeholder
+	}
+	return &copied
+}
@@ -0,0 +1,319 @@
+// filepath: internal/storage/memory/projects_repository.go
+
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// ProjectsRepository est l'implémentation en mémoire de storage.ProjectsRepository
+type ProjectsRepository struct {
+	db *DB
+}
+
+var _ storage.ProjectsRepository = (*ProjectsRepository)(nil)
+
+// NewProjectsRepository crée un nouveau repository en mémoire pour les projets
+func NewProjectsRepository(db *DB) *ProjectsRepository {
+	return &ProjectsRepository{db: db}
+}
+
+// CreateProject crée un projet
+func (r *ProjectsRepository) CreateProject(ctx context.Context, project *models.Project) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if project.ID == "" {
+		project.ID = uuid.New().String()
+	}
+	now := time.Now()
+	project.CreatedAt = now
+	project.UpdatedAt = now
+
+	copied := *project
+	r.db.projects[project.ID] = &copied
+	return nil
+}
+
+// CreateProjectWithEnvironments crée un projet et ses environnements
+func (r *ProjectsRepository) CreateProjectWithEnvironments(ctx context.Context, project *models.Project,
+	environments []*models.Environment) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if project.ID == "" {
+		project.ID = uuid.New().String()
+	}
+	now := time.Now()
+	project.CreatedAt, project.UpdatedAt = now, now
+	copied := *project
+	r.db.projects[project.ID] = &copied
+
+	for _, env := range environments {
+		env.ID = uuid.New().String()
+		env.ProjectID = project.ID
+		env.CreatedAt, env.UpdatedAt = now, now
+		copiedEnv := *env
+		r.db.environments[env.ID] = &copiedEnv
+	}
+	return nil
+}
+
+// GetProject récupère un projet d'une organisation (hors corbeille)
+func (r *ProjectsRepository) GetProject(ctx context.Context, orgID, projectID string) (*models.Project, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	project, ok := r.db.projects[projectID]
+	if !ok || project.OrganizationID != orgID || project.DeletedAt != nil {
+		return nil, storage.ErrProjectNotFound
+	}
+	copied := *project
+	return &copied, nil
+}
+
+// ListOrganizationProjects liste les projets d'une organisation (hors corbeille)
+func (r *ProjectsRepository) ListOrganizationProjects(ctx context.Context, orgID string) ([]*models.Project, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	projects := []*models.Project{}
+	for _, project := range r.db.projects {
+		if project.OrganizationID == orgID && project.DeletedAt == nil {
+			copied := *project
+			projects = append(projects, &copied)
+		}
+	}
+	sort.Slice(projects, func(i, j int) bool { return projects[i].Name < projects[j].Name })
+
+	return projects, nil
+}
+
+// ListProjectSummaries liste les projets d'une organisation (hors corbeille)
+// avec leurs compteurs et leur dernière activité
+func (r *ProjectsRepository) ListProjectSummaries(ctx context.Context, orgID string) ([]*models.ProjectSummary, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	byID := make(map[string]*models.ProjectSummary)
+	environments := make(map[string]map[string]bool)
+	summaries := []*models.ProjectSummary{}
+	for _, project := range r.db.projects {
+		if project.OrganizationID == orgID && project.DeletedAt == nil {
+			summary := &models.ProjectSummary{Project: *project, Environments: []string{}}
+			summary.Touch(project.UpdatedAt)
+			byID[project.ID] = summary
+			environments[project.ID] = make(map[string]bool)
+			summaries = append(summaries, summary)
+		}
+	}
+	for _, secret := range r.db.secrets {
+		if summary, ok := byID[secret.ProjectID]; ok && secret.OrganizationID == orgID {
+			summary.SecretsCount++
+			summary.Touch(secret.UpdatedAt)
+			environments[secret.ProjectID][secret.Environment] = true
+		}
+	}
+	for id, summary := range byID {
+		for env := range environments[id] {
+			summary.Environments = append(summary.Environments, env)
+		}
+		sort.Strings(summary.Environments)
+	}
+	sort.Slice(summaries, func(i, j int) bool { return summaries[i].Name < summaries[j].Name })
+
+	return summaries, nil
+}
+
+// DeleteProject supprime le projet et les métadonnées de ses secrets
+func (r *ProjectsRepository) DeleteProject(ctx context.Context, orgID, projectID string) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	for key, secret := range r.db.secrets {
+		if secret.OrganizationID == orgID && secret.ProjectID == projectID {
+			delete(r.db.secrets, key)
+			if r.db.secretCounts[orgID] > 0 {
+				r.db.secretCounts[orgID]--
+			}
+		}
+	}
+	if project, ok := r.db.projects[projectID]; ok && project.OrganizationID == orgID {
+		delete(r.db.projects, projectID)
+		delete(r.db.projectKeys, projectID)
+		delete(r.db.readmes, projectID)
+		deleteEnvironments(r.db, projectID)
+	}
+	return nil
+}
+
+// deleteEnvironments supprime les environnements d'un projet (verrou tenu)
+func deleteEnvironments(db *DB, projectID string) {
+	for id, env := range db.environments {
+		if env.ProjectID == projectID {
+			delete(db.environments, id)
+		}
+	}
+}
+
+// SuspendProject place le projet dans la corbeille
+func (r *ProjectsRepository) SuspendProject(ctx context.Context, orgID, projectID string) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	project, ok := r.db.projects[projectID]
+	if !ok || project.OrganizationID != orgID || project.DeletedAt != nil {
+		return storage.ErrProjectNotFound
+	}
+	now := time.Now()
+	project.DeletedAt = &now
+	return nil
+}
+
+// RestoreProject sort le projet de la corbeille
+func (r *ProjectsRepository) RestoreProject(ctx context.Context, orgID, projectID string) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	project, ok := r.db.projects[projectID]
+	if !ok || project.OrganizationID != orgID || project.DeletedAt == nil {
+		return storage.ErrProjectNotFound
+	}
+	project.DeletedAt = nil
+	return nil
+}
+
+// IsProjectSuspended indique si le projet est dans la corbeille
+func (r *ProjectsRepository) IsProjectSuspended(ctx context.Context, orgID, projectID string) (bool, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	project, ok := r.db.projects[projectID]
+	return ok && project.OrganizationID == orgID && project.DeletedAt != nil, nil
+}
+
+// ListSuspendedProjects liste les projets placés dans la corbeille avant before
+func (r *ProjectsRepository) ListSuspendedProjects(ctx context.Context, before time.Time) ([]*models.Project, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	projects := []*models.Project{}
+	for _, project := range r.db.projects {
+		if project.DeletedAt != nil && project.DeletedAt.Before(before) {
+			copied := *project
+			projects = append(projects, &copied)
+		}
+	}
+	sort.Slice(projects, func(i, j int) bool { return projects[i].DeletedAt.Before(*projects[j].DeletedAt) })
+
+	return projects, nil
+}
+
+// GetProjectEncryptionKey récupère la clé publique de chiffrement de bout en bout d'un projet
+func (r *ProjectsRepository) GetProjectEncryptionKey(ctx context.Context, projectID string) (*models.ProjectEncryptionKey, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	key, ok := r.db.projectKeys[projectID]
+	if !ok {
+		return nil, nil
+	}
+	copied := *key
+	return &copied, nil
+}
+
+// SetProjectEncryptionKey enregistre ou remplace la clé publique d'un projet
+func (r *ProjectsRepository) SetProjectEncryptionKey(ctx context.Context, key *models.ProjectEncryptionKey) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if key.CreatedAt.IsZero() {
+		key.CreatedAt = time.Now()
+	}
+	copied := *key
+	r.db.projectKeys[key.ProjectID] = &copied
+	return nil
+}
+
+// GetReadme récupère le README d'un projet (env vide) ou d'un environnement
+func (r *ProjectsRepository) GetReadme(ctx context.Context, projectID, env string) (*models.Readme, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	readme, ok := r.db.readmes[projectID][env]
+	if !ok {
+		return nil, nil
+	}
+	copied := *readme
+	return &copied, nil
+}
+
+// ListReadmes liste les README des projets d'une organisation
+func (r *ProjectsRepository) ListReadmes(ctx context.Context, orgID string) ([]*models.Readme, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	readmes := []*models.Readme{}
+	for _, environments := range r.db.readmes {
+		for _, readme := range environments {
+			if readme.OrganizationID == orgID {
+				copied := *readme
+				readmes = append(readmes, &copied)
+			}
+		}
+	}
+	sort.Slice(readmes, func(i, j int) bool {
+		if readmes[i].ProjectID != readmes[j].ProjectID {
+			return readmes[i].ProjectID < readmes[j].ProjectID
+		}
+		return readmes[i].Environment < readmes[j].Environment
+	})
+
+	return readmes, nil
+}
+
+// SetReadme enregistre ou remplace un README
+func (r *ProjectsRepository) SetReadme(ctx context.Context, readme *models.Readme) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if readme.UpdatedAt.IsZero() {
+		readme.UpdatedAt = time.Now()
+	}
+	if r.db.readmes[readme.ProjectID] == nil {
+		r.db.readmes[readme.ProjectID] = make(map[string]*models.Readme)
+	}
+	copied := *readme
+	r.db.readmes[readme.ProjectID][readme.Environment] = &copied
+	return nil
+}
+
+// DeleteReadme supprime un README ; sans effet s'il n'existe pas
+func (r *ProjectsRepository) DeleteReadme(ctx context.Context, projectID, env string) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	delete(r.db.readmes[projectID], env)
+	return nil
+}
+
+// SetProjectOwnership désigne les responsables d'un projet
+func (r *ProjectsRepository) SetProjectOwnership(ctx context.Context, orgID, projectID string, ownership models.Ownership) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	project, ok := r.db.projects[projectID]
+	if !ok || project.OrganizationID != orgID || project.DeletedAt != nil {
+		return storage.ErrProjectNotFound
+	}
+	project.Ownership = ownership
+	project.UpdatedAt = time.Now()
+	return nil
+}
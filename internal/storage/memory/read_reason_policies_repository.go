@@ -0,0 +1,50 @@
+// filepath: internal/storage/memory/read_reason_policies_repository.go
+
+package memory
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// ReadReasonPoliciesRepository est l'implémentation en mémoire de storage.ReadReasonPoliciesRepository
+type ReadReasonPoliciesRepository struct {
+	db *DB
+}
+
+var _ storage.ReadReasonPoliciesRepository = (*ReadReasonPoliciesRepository)(nil)
+
+// NewReadReasonPoliciesRepository crée un nouveau repository d'environnements protégés en mémoire
+func NewReadReasonPoliciesRepository(db *DB) *ReadReasonPoliciesRepository {
+	return &ReadReasonPoliciesRepository{db: db}
+}
+
+// GetReadReasonPolicy renvoie la politique de l'organisation
+func (r *ReadReasonPoliciesRepository) GetReadReasonPolicy(ctx context.Context, orgID string) (*models.ReadReasonPolicy, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	policy, ok := r.db.readReasonPolicies[orgID]
+	if !ok {
+		return nil, storage.ErrReadPolicyNotFound
+	}
+	copied := *policy
+	copied.Environments = slices.Clone(policy.Environments)
+	return &copied, nil
+}
+
+// SaveReadReasonPolicy crée ou remplace la politique de l'organisation
+func (r *ReadReasonPoliciesRepository) SaveReadReasonPolicy(ctx context.Context, policy *models.ReadReasonPolicy) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	policy.UpdatedAt = time.Now()
+	copied := *policy
+	copied.Environments = slices.Clone(policy.Environments)
+	r.db.readReasonPolicies[policy.OrganizationID] = &copied
+	return nil
+}
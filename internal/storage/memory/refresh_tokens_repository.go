@@ -0,0 +1,146 @@
+// filepath: internal/storage/memory/refresh_tokens_repository.go
+
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// RefreshTokensRepository est l'implémentation en mémoire de storage.RefreshTokensRepository
+type RefreshTokensRepository struct {
+	db *DB
+}
+
+var _ storage.RefreshTokensRepository = (*RefreshTokensRepository)(nil)
+
+// NewRefreshTokensRepository crée un nouveau repository de tokens de rafraîchissement en mémoire
+func NewRefreshTokensRepository(db *DB) *RefreshTokensRepository {
+	return &RefreshTokensRepository{db: db}
+}
+
+// CreateRefreshToken enregistre un token, en ouvrant une session s'il n'en a pas
+func (r *RefreshTokensRepository) CreateRefreshToken(ctx context.Context, token *models.RefreshToken) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if token.ID == "" {
+		token.ID = uuid.New().String()
+	}
+	token.CreatedAt = time.Now()
+	if token.SessionID == "" {
+		token.SessionID = token.ID
+		token.SessionStartedAt = token.CreatedAt
+	}
+	r.db.refreshTokens[token.ID] = copyRefreshToken(token)
+	return nil
+}
+
+// GetRefreshTokenByHash récupère le token correspondant à l'empreinte
+func (r *RefreshTokensRepository) GetRefreshTokenByHash(ctx context.Context, tokenHash string) (*models.RefreshToken, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	for _, token := range r.db.refreshTokens {
+		if token.TokenHash == tokenHash {
+			return copyRefreshToken(token), nil
+		}
+	}
+	return nil, storage.ErrRefreshTokenNotFound
+}
+
+// RotateRefreshToken marque le token comme remplacé s'il ne l'était pas déjà
+func (r *RefreshTokensRepository) RotateRefreshToken(ctx context.Context, id string, at time.Time) (bool, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	token, ok := r.db.refreshTokens[id]
+	if !ok || token.RotatedAt != nil || token.RevokedAt != nil {
+		return false, nil
+	}
+	token.RotatedAt = &at
+	return true, nil
+}
+
+// ListActiveRefreshTokens liste le token courant de chaque session active de l'utilisateur
+func (r *RefreshTokensRepository) ListActiveRefreshTokens(ctx context.Context, userID string, now time.Time) ([]*models.RefreshToken, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	tokens := []*models.RefreshToken{}
+	for _, token := range r.db.refreshTokens {
+		if token.UserID == userID && token.RotatedAt == nil && token.RevokedAt == nil && now.Before(token.ExpiresAt) {
+			tokens = append(tokens, copyRefreshToken(token))
+		}
+	}
+	sort.Slice(tokens, func(i, j int) bool { return tokens[i].SessionStartedAt.After(tokens[j].SessionStartedAt) })
+	return tokens, nil
+}
+
+// RevokeSession révoque une session de l'utilisateur
+func (r *RefreshTokensRepository) RevokeSession(ctx context.Context, userID, sessionID string, at time.Time) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if r.revoke(func(token *models.RefreshToken) bool {
+		return token.UserID == userID && token.SessionID == sessionID
+	}, at) == 0 {
+		return storage.ErrSessionNotFound
+	}
+	return nil
+}
+
+// RevokeUserSessions révoque les sessions de l'utilisateur, sauf exceptSessionID
+func (r *RefreshTokensRepository) RevokeUserSessions(ctx context.Context, userID, exceptSessionID string, at time.Time) (int64, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	return r.revoke(func(token *models.RefreshToken) bool {
+		return token.UserID == userID && token.SessionID != exceptSessionID
+	}, at), nil
+}
+
+// SessionRevoked indique si une session a été révoquée
+func (r *RefreshTokensRepository) SessionRevoked(ctx context.Context, sessionID string) (bool, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	for _, token := range r.db.refreshTokens {
+		if token.SessionID == sessionID && token.RevokedAt != nil {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// revoke révoque les tokens non révoqués sélectionnés et renvoie leur nombre
+func (r *RefreshTokensRepository) revoke(match func(*models.RefreshToken) bool, at time.Time) int64 {
+	var revoked int64
+	for _, token := range r.db.refreshTokens {
+		if token.RevokedAt == nil && match(token) {
+			revokedAt := at
+			token.RevokedAt = &revokedAt
+			revoked++
+		}
+	}
+	return revoked
+}
+
+func copyRefreshToken(token *models.RefreshToken) *models.RefreshToken {
+	copied := *token
+	if token.RotatedAt != nil {
+		rotatedAt := *token.RotatedAt
+		copied.RotatedAt = &rotatedAt
+	}
+	if token.RevokedAt != nil {
+		revokedAt := *token.RevokedAt
+		copied.RevokedAt = &revokedAt
+	}
+	return &copied
+}
@@ -0,0 +1,61 @@
+// filepath: internal/storage/memory/registration_policy_repository.go
+
+package memory
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// RegistrationPolicyRepository est l'implémentation en mémoire de storage.RegistrationPolicyRepository
+type RegistrationPolicyRepository struct {
+	db *DB
+}
+
+var _ storage.RegistrationPolicyRepository = (*RegistrationPolicyRepository)(nil)
+
+// NewRegistrationPolicyRepository crée un nouveau repository de politique d'inscription en mémoire
+func NewRegistrationPolicyRepository(db *DB) *RegistrationPolicyRepository {
+	return &RegistrationPolicyRepository{db: db}
+}
+
+// GetRegistrationPolicy renvoie la politique enregistrée
+func (r *RegistrationPolicyRepository) GetRegistrationPolicy(ctx context.Context) (*models.RegistrationPolicy, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	if r.db.registrationPolicy == nil {
+		return nil, storage.ErrRegistrationPolicyNotFound
+	}
+	return copyRegistrationPolicy(r.db.registrationPolicy), nil
+}
+
+// SaveRegistrationPolicy crée ou remplace la politique
+func (r *RegistrationPolicyRepository) SaveRegistrationPolicy(ctx context.Context, policy *models.RegistrationPolicy) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	policy.UpdatedAt = time.Now()
+	r.db.registrationPolicy = copyRegistrationPolicy(policy)
+	return nil
+}
+
+// DeleteRegistrationPolicy supprime la politique enregistrée
+func (r *RegistrationPolicyRepository) DeleteRegistrationPolicy(ctx context.Context) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	r.db.registrationPolicy = nil
+	return nil
+}
+
+func copyRegistrationPolicy(policy *models.RegistrationPolicy) *models.RegistrationPolicy {
+	copied := *policy
+	copied.AllowList = slices.Clone(policy.AllowList)
+	copied.DenyList = slices.Clone(policy.DenyList)
+	return &copied
+}
@@ -0,0 +1,103 @@
+// filepath: internal/storage/memory/scheduled_secret_changes_repository.go
+
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// ScheduledSecretChangesRepository est l'implémentation en mémoire de storage.ScheduledSecretChangesRepository
+type ScheduledSecretChangesRepository struct {
+	db *DB
+}
+
+var _ storage.ScheduledSecretChangesRepository = (*ScheduledSecretChangesRepository)(nil)
+
+// NewScheduledSecretChangesRepository crée un nouveau repository de changements planifiés en mémoire
+func NewScheduledSecretChangesRepository(db *DB) *ScheduledSecretChangesRepository {
+	return &ScheduledSecretChangesRepository{db: db}
+}
+
+// SaveScheduledSecretChange crée ou remplace le changement planifié d'un
+// secret. Un changement remplacé garde son identifiant.
+func (r *ScheduledSecretChangesRepository) SaveScheduledSecretChange(ctx context.Context, change *models.ScheduledSecretChange) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	key := rotatorKey(change.OrganizationID, change.ProjectID, change.Environment, change.SecretName)
+	if existing, ok := r.db.scheduledSecretChanges[key]; ok {
+		change.ID = existing.ID
+	} else if change.ID == "" {
+		change.ID = uuid.New().String()
+	}
+	change.CreatedAt = time.Now()
+	copied := *change
+	r.db.scheduledSecretChanges[key] = &copied
+	return nil
+}
+
+// GetScheduledSecretChange renvoie le changement planifié d'un secret
+func (r *ScheduledSecretChangesRepository) GetScheduledSecretChange(ctx context.Context, orgID, projectID, env, name string) (*models.ScheduledSecretChange, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	change, ok := r.db.scheduledSecretChanges[rotatorKey(orgID, projectID, env, name)]
+	if !ok {
+		return nil, storage.ErrScheduleNotFound
+	}
+	copied := *change
+	return &copied, nil
+}
+
+// DeleteScheduledSecretChange supprime un changement planifié
+func (r *ScheduledSecretChangesRepository) DeleteScheduledSecretChange(ctx context.Context, id string) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	for key, change := range r.db.scheduledSecretChanges {
+		if change.ID == id {
+			delete(r.db.scheduledSecretChanges, key)
+			return nil
+		}
+	}
+	return storage.ErrScheduleNotFound
+}
+
+// ListDueScheduledSecretChanges liste les changements à appliquer, du plus
+// ancien au plus récent
+func (r *ScheduledSecretChangesRepository) ListDueScheduledSecretChanges(ctx context.Context, before time.Time) ([]*models.ScheduledSecretChange, error) {
+	return r.listChanges("", before), nil
+}
+
+// ListOrganizationScheduledSecretChanges liste les changements de
+// l'organisation à appliquer avant before, du plus ancien au plus récent
+func (r *ScheduledSecretChangesRepository) ListOrganizationScheduledSecretChanges(ctx context.Context, orgID string, before time.Time) ([]*models.ScheduledSecretChange, error) {
+	return r.listChanges(orgID, before), nil
+}
+
+// listChanges liste les changements à appliquer avant before, de toutes
+// les organisations si orgID est vide
+func (r *ScheduledSecretChangesRepository) listChanges(orgID string, before time.Time) []*models.ScheduledSecretChange {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	changes := []*models.ScheduledSecretChange{}
+	for _, change := range r.db.scheduledSecretChanges {
+		if orgID != "" && change.OrganizationID != orgID {
+			continue
+		}
+		if !change.EffectiveAt.After(before) {
+			copied := *change
+			changes = append(changes, &copied)
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].EffectiveAt.Before(changes[j].EffectiveAt) })
+	return changes
+}
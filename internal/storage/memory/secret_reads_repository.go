@@ -0,0 +1,225 @@
+// filepath: internal/storage/memory/secret_reads_repository.go
+
+package memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// SecretReadsRepository est l'implémentation en mémoire de storage.SecretReadsRepository
+type SecretReadsRepository struct {
+	db *DB
+}
+
+var _ storage.SecretReadsRepository = (*SecretReadsRepository)(nil)
+
+// NewSecretReadsRepository crée un nouveau repository de lectures en mémoire
+func NewSecretReadsRepository(db *DB) *SecretReadsRepository {
+	return &SecretReadsRepository{db: db}
+}
+
+// RecordSecretReads comptabilise un lot de lectures
+func (r *SecretReadsRepository) RecordSecretReads(ctx context.Context, reads []*models.SecretRead) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	for _, read := range reads {
+		copied := *read
+		r.db.secretReads = append(r.db.secretReads, &copied)
+	}
+	return nil
+}
+
+// ListSecretConsumers résume les lectures d'un secret par charge de travail et principal
+func (r *SecretReadsRepository) ListSecretConsumers(
+	ctx context.Context,
+	orgID, projectID, env, name string,
+	since time.Time,
+) ([]*models.SecretConsumer, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	byKey := make(map[string]*models.SecretConsumer)
+	consumers := []*models.SecretConsumer{}
+	for _, read := range r.readsSince(since) {
+		if read.OrganizationID != orgID || read.ProjectID != projectID || read.Environment != env || read.SecretName != name {
+			continue
+		}
+		key := read.Workload + "\x00" + read.PrincipalType + "\x00" + read.PrincipalID
+		consumer, ok := byKey[key]
+		if !ok {
+			consumer = &models.SecretConsumer{
+				Workload:      read.Workload,
+				PrincipalType: read.PrincipalType,
+				PrincipalID:   read.PrincipalID,
+			}
+			byKey[key] = consumer
+			consumers = append(consumers, consumer)
+		}
+		consumer.Reads++
+		if read.Timestamp.After(consumer.LastReadAt) {
+			consumer.LastReadAt = read.Timestamp
+		}
+	}
+
+	sort.Slice(consumers, func(i, j int) bool { return consumers[i].LastReadAt.After(consumers[j].LastReadAt) })
+	return consumers, nil
+}
+
+// ListProjectDependencies résume les lectures des secrets d'un projet par
+// environnement, secret et charge de travail
+func (r *SecretReadsRepository) ListProjectDependencies(
+	ctx context.Context,
+	orgID, projectID string,
+	since time.Time,
+) ([]*models.SecretDependency, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	byKey := make(map[string]*models.SecretDependency)
+	dependencies := []*models.SecretDependency{}
+	for _, read := range r.readsSince(since) {
+		if read.OrganizationID != orgID || read.ProjectID != projectID {
+			continue
+		}
+		key := read.Environment + "\x00" + read.SecretName + "\x00" + read.Workload
+		dependency, ok := byKey[key]
+		if !ok {
+			dependency = &models.SecretDependency{
+				Environment: read.Environment,
+				SecretName:  read.SecretName,
+				Workload:    read.Workload,
+			}
+			byKey[key] = dependency
+			dependencies = append(dependencies, dependency)
+		}
+		dependency.Reads++
+		if read.Timestamp.After(dependency.LastReadAt) {
+			dependency.LastReadAt = read.Timestamp
+		}
+	}
+
+	sort.Slice(dependencies, func(i, j int) bool {
+		a, b := dependencies[i], dependencies[j]
+		if a.Environment != b.Environment {
+			return a.Environment < b.Environment
+		}
+		if a.SecretName != b.SecretName {
+			return a.SecretName < b.SecretName
+		}
+		return a.Workload < b.Workload
+	})
+	return dependencies, nil
+}
+
+// ListOrganizationDailyReads totalise les lectures des secrets de
+// l'organisation par projet, environnement et jour
+func (r *SecretReadsRepository) ListOrganizationDailyReads(
+	ctx context.Context,
+	orgID string,
+	since time.Time,
+) ([]*models.DailySecretReads, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	byKey := make(map[string]*models.DailySecretReads)
+	totals := []*models.DailySecretReads{}
+	for _, read := range r.readsSince(since) {
+		if read.OrganizationID != orgID {
+			continue
+		}
+		day := read.Timestamp.UTC().Format("2006-01-02")
+		key := read.ProjectID + "\x00" + read.Environment + "\x00" + day
+		total, ok := byKey[key]
+		if !ok {
+			total = &models.DailySecretReads{ProjectID: read.ProjectID, Environment: read.Environment, Day: day}
+			byKey[key] = total
+			totals = append(totals, total)
+		}
+		total.Reads++
+	}
+
+	sort.Slice(totals, func(i, j int) bool {
+		a, b := totals[i], totals[j]
+		if a.ProjectID != b.ProjectID {
+			return a.ProjectID < b.ProjectID
+		}
+		if a.Environment != b.Environment {
+			return a.Environment < b.Environment
+		}
+		return a.Day < b.Day
+	})
+	return totals, nil
+}
+
+// ListOrganizationSecretAccess résume les lectures des secrets de
+// l'organisation par principal et secret
+func (r *SecretReadsRepository) ListOrganizationSecretAccess(
+	ctx context.Context,
+	orgID string,
+	since time.Time,
+) ([]*models.SecretAccess, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	byKey := make(map[string]*models.SecretAccess)
+	accesses := []*models.SecretAccess{}
+	for _, read := range r.readsSince(since) {
+		if read.OrganizationID != orgID {
+			continue
+		}
+		key := strings.Join([]string{read.PrincipalType, read.PrincipalID, read.ProjectID, read.Environment, read.SecretName}, "\x00")
+		access, ok := byKey[key]
+		if !ok {
+			access = &models.SecretAccess{
+				PrincipalType: read.PrincipalType,
+				PrincipalID:   read.PrincipalID,
+				ProjectID:     read.ProjectID,
+				Environment:   read.Environment,
+				SecretName:    read.SecretName,
+			}
+			byKey[key] = access
+			accesses = append(accesses, access)
+		}
+		access.Reads++
+		if read.Timestamp.After(access.LastReadAt) {
+			access.LastReadAt = read.Timestamp
+		}
+	}
+
+	sort.Slice(accesses, func(i, j int) bool {
+		a, b := accesses[i], accesses[j]
+		if a.PrincipalType != b.PrincipalType {
+			return a.PrincipalType < b.PrincipalType
+		}
+		if a.PrincipalID != b.PrincipalID {
+			return a.PrincipalID < b.PrincipalID
+		}
+		if a.ProjectID != b.ProjectID {
+			return a.ProjectID < b.ProjectID
+		}
+		if a.Environment != b.Environment {
+			return a.Environment < b.Environment
+		}
+		return a.SecretName < b.SecretName
+	})
+	return accesses, nil
+}
+
+// readsSince renvoie les lectures effectuées depuis le jour de since (UTC)
+func (r *SecretReadsRepository) readsSince(since time.Time) []*models.SecretRead {
+	day := since.UTC().Format("2006-01-02")
+	reads := []*models.SecretRead{}
+	for _, read := range r.db.secretReads {
+		if read.Timestamp.UTC().Format("2006-01-02") >= day {
+			reads = append(reads, read)
+		}
+	}
+	return reads
+}
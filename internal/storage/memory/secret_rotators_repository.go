@@ -0,0 +1,177 @@
+// filepath: internal/storage/memory/secret_rotators_repository.go
+
+package memory
+
+import (
+	"context"
+	"maps"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// SecretRotatorsRepository est l'implémentation en mémoire de storage.SecretRotatorsRepository
+type SecretRotatorsRepository struct {
+	db *DB
+}
+
+var _ storage.SecretRotatorsRepository = (*SecretRotatorsRepository)(nil)
+
+// NewSecretRotatorsRepository crée un nouveau repository de rotateurs en mémoire
+func NewSecretRotatorsRepository(db *DB) *SecretRotatorsRepository {
+	return &SecretRotatorsRepository{db: db}
+}
+
+// SaveSecretRotator crée ou remplace le rotateur d'un secret. Le résultat
+// de la dernière rotation est conservé.
+func (r *SecretRotatorsRepository) SaveSecretRotator(ctx context.Context, rotator *models.SecretRotator) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	key := rotatorKey(rotator.OrganizationID, rotator.ProjectID, rotator.Environment, rotator.SecretName)
+	now := time.Now()
+	if existing, ok := r.db.secretRotators[key]; ok {
+		rotator.ID = existing.ID
+		rotator.CreatedBy = existing.CreatedBy
+		rotator.CreatedAt = existing.CreatedAt
+		rotator.LastRotatedAt = existing.LastRotatedAt
+		rotator.LastError = existing.LastError
+		rotator.PreviousExpiresAt = existing.PreviousExpiresAt
+	} else {
+		if rotator.ID == "" {
+			rotator.ID = uuid.New().String()
+		}
+		rotator.CreatedAt = now
+	}
+	rotator.UpdatedAt = now
+	r.db.secretRotators[key] = copySecretRotator(rotator)
+	return nil
+}
+
+// GetSecretRotator renvoie le rotateur d'un secret
+func (r *SecretRotatorsRepository) GetSecretRotator(ctx context.Context, orgID, projectID, env, name string) (*models.SecretRotator, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	rotator, ok := r.db.secretRotators[rotatorKey(orgID, projectID, env, name)]
+	if !ok {
+		return nil, storage.ErrRotatorNotFound
+	}
+	return copySecretRotator(rotator), nil
+}
+
+// DeleteSecretRotator supprime le rotateur d'un secret
+func (r *SecretRotatorsRepository) DeleteSecretRotator(ctx context.Context, orgID, projectID, env, name string) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	key := rotatorKey(orgID, projectID, env, name)
+	if _, ok := r.db.secretRotators[key]; !ok {
+		return storage.ErrRotatorNotFound
+	}
+	delete(r.db.secretRotators, key)
+	return nil
+}
+
+// RecordSecretRotation enregistre le résultat d'une rotation
+func (r *SecretRotatorsRepository) RecordSecretRotation(ctx context.Context, id string, at time.Time, lastError string) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	for _, rotator := range r.db.secretRotators {
+		if rotator.ID == id {
+			rotator.LastRotatedAt = &at
+			rotator.LastError = lastError
+		}
+	}
+	return nil
+}
+
+// SetPreviousExpiry enregistre la date de retrait de la valeur précédente
+func (r *SecretRotatorsRepository) SetPreviousExpiry(ctx context.Context, id string, expiresAt *time.Time) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	for _, rotator := range r.db.secretRotators {
+		if rotator.ID == id {
+			rotator.PreviousExpiresAt = nil
+			if expiresAt != nil {
+				at := *expiresAt
+				rotator.PreviousExpiresAt = &at
+			}
+		}
+	}
+	return nil
+}
+
+// ListExpiredPreviousValues liste les rotateurs dont la valeur précédente
+// doit être retirée, de la plus ancienne échéance à la plus récente
+func (r *SecretRotatorsRepository) ListExpiredPreviousValues(ctx context.Context, before time.Time) ([]*models.SecretRotator, error) {
+	return r.listPreviousExpiries("", before), nil
+}
+
+// ListOrganizationPreviousExpiries liste les rotateurs de l'organisation
+// dont la valeur précédente sera retirée avant before
+func (r *SecretRotatorsRepository) ListOrganizationPreviousExpiries(ctx context.Context, orgID string, before time.Time) ([]*models.SecretRotator, error) {
+	return r.listPreviousExpiries(orgID, before), nil
+}
+
+// ListOrganizationSecretRotators liste les rotateurs des secrets de l'organisation
+func (r *SecretRotatorsRepository) ListOrganizationSecretRotators(ctx context.Context, orgID string) ([]*models.SecretRotator, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	rotators := []*models.SecretRotator{}
+	for _, rotator := range r.db.secretRotators {
+		if rotator.OrganizationID == orgID {
+			rotators = append(rotators, copySecretRotator(rotator))
+		}
+	}
+	sort.Slice(rotators, func(i, j int) bool {
+		return rotatorKey(rotators[i].OrganizationID, rotators[i].ProjectID, rotators[i].Environment, rotators[i].SecretName) <
+			rotatorKey(rotators[j].OrganizationID, rotators[j].ProjectID, rotators[j].Environment, rotators[j].SecretName)
+	})
+	return rotators, nil
+}
+
+// listPreviousExpiries liste les valeurs précédentes à retirer avant
+// before, de toutes les organisations si orgID est vide
+func (r *SecretRotatorsRepository) listPreviousExpiries(orgID string, before time.Time) []*models.SecretRotator {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	rotators := []*models.SecretRotator{}
+	for _, rotator := range r.db.secretRotators {
+		if orgID != "" && rotator.OrganizationID != orgID {
+			continue
+		}
+		if rotator.PreviousExpiresAt != nil && !rotator.PreviousExpiresAt.After(before) {
+			rotators = append(rotators, copySecretRotator(rotator))
+		}
+	}
+	sort.Slice(rotators, func(i, j int) bool { return rotators[i].PreviousExpiresAt.Before(*rotators[j].PreviousExpiresAt) })
+	return rotators
+}
+
+func rotatorKey(orgID, projectID, env, name string) string {
+	return strings.Join([]string{orgID, projectID, env, name}, "/")
+}
+
+func copySecretRotator(rotator *models.SecretRotator) *models.SecretRotator {
+	copied := *rotator
+	copied.Config = maps.Clone(rotator.Config)
+	if rotator.LastRotatedAt != nil {
+		at := *rotator.LastRotatedAt
+		copied.LastRotatedAt = &at
+	}
+	if rotator.PreviousExpiresAt != nil {
+		at := *rotator.PreviousExpiresAt
+		copied.PreviousExpiresAt = &at
+	}
+	return &copied
+}
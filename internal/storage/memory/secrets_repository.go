@@ -0,0 +1,350 @@
+// filepath: internal/storage/memory/secrets_repository.go
+
+package memory
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// SecretsRepository est l'implémentation en mémoire de storage.SecretsRepository
+type SecretsRepository struct {
+	db *DB
+}
+
+var _ storage.SecretsRepository = (*SecretsRepository)(nil)
+
+// NewSecretsRepository crée un nouveau repository en mémoire pour les secrets
+func NewSecretsRepository(db *DB) *SecretsRepository {
+	return &SecretsRepository{db: db}
+}
+
+// CreateSecretMetadata crée les métadonnées d'un secret
+func (r *SecretsRepository) CreateSecretMetadata(ctx context.Context, metadata *models.SecretMetadata) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	// Équivalent de l'index unique (organisation, projet, environnement, nom)
+	for _, existing := range r.db.secrets {
+		if existing.OrganizationID == metadata.OrganizationID && existing.ProjectID == metadata.ProjectID &&
+			existing.Environment == metadata.Environment && existing.Name == metadata.Name {
+			return storage.ErrSecretAlreadyExists
+		}
+	}
+
+	if metadata.ID == "" {
+		metadata.ID = uuid.New().String()
+	}
+	now := time.Now()
+	metadata.CreatedAt = now
+	metadata.UpdatedAt = now
+
+	copied := *metadata
+	r.db.secrets[metadata.ID] = &copied
+	r.db.secretCounts[metadata.OrganizationID]++
+	return nil
+}
+
+// GetSecretMetadata récupère les métadonnées d'un secret par son ID
+func (r *SecretsRepository) GetSecretMetadata(ctx context.Context, id string) (*models.SecretMetadata, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	metadata, ok := r.db.secrets[id]
+	if !ok {
+		return nil, nil
+	}
+	copied := *metadata
+	return &copied, nil
+}
+
+// GetSecretMetadataByPath récupère les métadonnées d'un secret par son chemin complet
+func (r *SecretsRepository) GetSecretMetadataByPath(
+	ctx context.Context,
+	orgID, projectID, env, name string,
+) (*models.SecretMetadata, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	for _, metadata := range r.db.secrets {
+		if metadata.OrganizationID == orgID && metadata.ProjectID == projectID &&
+			metadata.Environment == env && metadata.Name == name {
+			copied := *metadata
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+// ListProjectSecrets liste tous les secrets d'un projet et environnement
+func (r *SecretsRepository) ListProjectSecrets(
+	ctx context.Context,
+	orgID, projectID, env string,
+) ([]*models.SecretMetadata, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	var secrets []*models.SecretMetadata
+	for _, metadata := range r.db.secrets {
+		if metadata.OrganizationID == orgID && metadata.ProjectID == projectID && metadata.Environment == env {
+			copied := *metadata
+			secrets = append(secrets, &copied)
+		}
+	}
+	sort.Slice(secrets, func(i, j int) bool { return secrets[i].Name < secrets[j].Name })
+
+	return secrets, nil
+}
+
+// ListSecretsByProjects liste les secrets de plusieurs projets d'une
+// organisation, dans un environnement ou dans tous (env vide)
+func (r *SecretsRepository) ListSecretsByProjects(
+	ctx context.Context,
+	orgID string,
+	projectIDs []string,
+	env string,
+) ([]*models.SecretMetadata, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	secrets := []*models.SecretMetadata{}
+	for _, metadata := range r.db.secrets {
+		if metadata.OrganizationID != orgID || !slices.Contains(projectIDs, metadata.ProjectID) {
+			continue
+		}
+		if env != "" && metadata.Environment != env {
+			continue
+		}
+		copied := *metadata
+		secrets = append(secrets, &copied)
+	}
+	sort.Slice(secrets, func(i, j int) bool {
+		a, b := secrets[i], secrets[j]
+		if a.ProjectID != b.ProjectID {
+			return a.ProjectID < b.ProjectID
+		}
+		if a.Environment != b.Environment {
+			return a.Environment < b.Environment
+		}
+		return a.Name < b.Name
+	})
+
+	return secrets, nil
+}
+
+// ListSecretsByValueHashes liste les secrets d'une organisation, tous
+// projets confondus, dont l'empreinte de la valeur figure parmi hashes
+func (r *SecretsRepository) ListSecretsByValueHashes(
+	ctx context.Context,
+	orgID string,
+	hashes []string,
+) ([]*models.SecretMetadata, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	secrets := []*models.SecretMetadata{}
+	for _, metadata := range r.db.secrets {
+		if metadata.OrganizationID == orgID && metadata.ValueHash != "" && slices.Contains(hashes, metadata.ValueHash) {
+			copied := *metadata
+			secrets = append(secrets, &copied)
+		}
+	}
+	sortByValueHash(secrets)
+
+	return secrets, nil
+}
+
+// ListDuplicateSecretValues liste les secrets d'une organisation dont la
+// valeur est partagée avec au moins un autre secret, groupés par empreinte
+func (r *SecretsRepository) ListDuplicateSecretValues(ctx context.Context, orgID string) ([]*models.SecretMetadata, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	counts := make(map[string]int)
+	for _, metadata := range r.db.secrets {
+		if metadata.OrganizationID == orgID && metadata.ValueHash != "" {
+			counts[metadata.ValueHash]++
+		}
+	}
+
+	secrets := []*models.SecretMetadata{}
+	for _, metadata := range r.db.secrets {
+		if metadata.OrganizationID == orgID && counts[metadata.ValueHash] > 1 {
+			copied := *metadata
+			secrets = append(secrets, &copied)
+		}
+	}
+	sortByValueHash(secrets)
+
+	return secrets, nil
+}
+
+// sortByValueHash trie les secrets par empreinte, puis par chemin
+func sortByValueHash(secrets []*models.SecretMetadata) {
+	sort.Slice(secrets, func(i, j int) bool {
+		a, b := secrets[i], secrets[j]
+		if a.ValueHash != b.ValueHash {
+			return a.ValueHash < b.ValueHash
+		}
+		if a.ProjectID != b.ProjectID {
+			return a.ProjectID < b.ProjectID
+		}
+		if a.Environment != b.Environment {
+			return a.Environment < b.Environment
+		}
+		return a.Name < b.Name
+	})
+}
+
+// UpdateSecretMetadata met à jour les métadonnées d'un secret
+func (r *SecretsRepository) UpdateSecretMetadata(ctx context.Context, metadata *models.SecretMetadata) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	existing, ok := r.db.secrets[metadata.ID]
+	if !ok {
+		return nil
+	}
+	existing.Name = metadata.Name
+	existing.Description = metadata.Description
+	existing.Version = metadata.Version
+	existing.E2E = metadata.E2E
+	existing.Checksum = metadata.Checksum
+	existing.ValueHash = metadata.ValueHash
+	existing.ValueLength = metadata.ValueLength
+	existing.ValueEntropy = metadata.ValueEntropy
+	existing.UpdatedAt = time.Now()
+	return nil
+}
+
+// SetSecretChecksum enregistre la somme de contrôle d'un secret
+func (r *SecretsRepository) SetSecretChecksum(ctx context.Context, id, checksum string) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if existing, ok := r.db.secrets[id]; ok {
+		existing.Checksum = checksum
+	}
+	return nil
+}
+
+// SetSecretOwnership désigne les responsables d'un secret
+func (r *SecretsRepository) SetSecretOwnership(ctx context.Context, id string, ownership models.Ownership) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if existing, ok := r.db.secrets[id]; ok {
+		existing.Ownership = ownership
+	}
+	return nil
+}
+
+// DeleteSecretMetadata supprime les métadonnées d'un secret
+func (r *SecretsRepository) DeleteSecretMetadata(ctx context.Context, id string, orgID string) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	delete(r.db.secrets, id)
+	if r.db.secretCounts[orgID] > 0 {
+		r.db.secretCounts[orgID]--
+	}
+	return nil
+}
+
+// DeleteSecretMetadataByPath supprime les métadonnées d'un secret par son chemin
+func (r *SecretsRepository) DeleteSecretMetadataByPath(
+	ctx context.Context,
+	orgID, projectID, env, name string,
+) error {
+	metadata, err := r.GetSecretMetadataByPath(ctx, orgID, projectID, env, name)
+	if err != nil || metadata == nil {
+		return err
+	}
+	return r.DeleteSecretMetadata(ctx, metadata.ID, orgID)
+}
+
+// LockSecret verrouille un secret en conservant l'auteur et la raison
+func (r *SecretsRepository) LockSecret(ctx context.Context, id, userID, reason string) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	existing, ok := r.db.secrets[id]
+	if !ok {
+		return nil
+	}
+	now := time.Now()
+	existing.LockedBy = userID
+	existing.LockedAt = &now
+	existing.LockReason = reason
+	return nil
+}
+
+// UnlockSecret retire le verrou d'un secret
+func (r *SecretsRepository) UnlockSecret(ctx context.Context, id string) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	existing, ok := r.db.secrets[id]
+	if !ok {
+		return nil
+	}
+	existing.LockedBy = ""
+	existing.LockedAt = nil
+	existing.LockReason = ""
+	return nil
+}
+
+// GetSecretsCount obtient le nombre de secrets pour une organisation
+func (r *SecretsRepository) GetSecretsCount(ctx context.Context, orgID string) (int, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	return r.db.secretCounts[orgID], nil
+}
+
+// ListSecretCounts compare les compteurs enregistrés aux métadonnées de chaque organisation
+func (r *SecretsRepository) ListSecretCounts(ctx context.Context) ([]*models.SecretCount, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	actual := make(map[string]int)
+	for _, secret := range r.db.secrets {
+		actual[secret.OrganizationID]++
+	}
+
+	counts := []*models.SecretCount{}
+	for orgID := range r.db.organizations {
+		counts = append(counts, &models.SecretCount{
+			OrganizationID: orgID,
+			Recorded:       r.db.secretCounts[orgID],
+			Actual:         actual[orgID],
+		})
+	}
+	sort.Slice(counts, func(i, j int) bool { return counts[i].OrganizationID < counts[j].OrganizationID })
+
+	return counts, nil
+}
+
+// SetSecretsCount corrige le compteur de secrets d'une organisation
+func (r *SecretsRepository) SetSecretsCount(ctx context.Context, orgID string, count int) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	r.db.secretCounts[orgID] = count
+	return nil
+}
+
+// GetSecretsLimit obtient la limite de secrets pour une organisation
+func (r *SecretsRepository) GetSecretsLimit(ctx context.Context, orgID string) (int, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	return r.db.secretLimits[orgID], nil
+}
@@ -0,0 +1,194 @@
+// filepath: internal/storage/memory/service_accounts_repository.go
+
+package memory
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// ServiceAccountsRepository est l'implémentation en mémoire de storage.ServiceAccountsRepository
+type ServiceAccountsRepository struct {
+	db *DB
+}
+
+var _ storage.ServiceAccountsRepository = (*ServiceAccountsRepository)(nil)
+
+// NewServiceAccountsRepository crée un nouveau repository de comptes de service en mémoire
+func NewServiceAccountsRepository(db *DB) *ServiceAccountsRepository {
+	return &ServiceAccountsRepository{db: db}
+}
+
+// CreateServiceAccount enregistre un compte de service
+func (r *ServiceAccountsRepository) CreateServiceAccount(ctx context.Context, account *models.ServiceAccount) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if r.spiffeIDTaken(account.OrganizationID, account.SPIFFEID, account.ID) {
+		return storage.ErrSPIFFEIDTaken
+	}
+	if account.ID == "" {
+		account.ID = uuid.New().String()
+	}
+	account.CreatedAt = time.Now()
+	r.db.serviceAccounts[account.ID] = copyServiceAccount(account)
+	return nil
+}
+
+// GetServiceAccount récupère un compte de service
+func (r *ServiceAccountsRepository) GetServiceAccount(ctx context.Context, id string) (*models.ServiceAccount, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	account, ok := r.db.serviceAccounts[id]
+	if !ok {
+		return nil, storage.ErrServiceAccountNotFound
+	}
+	return copyServiceAccount(account), nil
+}
+
+// GetServiceAccountBySPIFFEID récupère le compte de l'organisation associé à
+// l'identifiant SPIFFE
+func (r *ServiceAccountsRepository) GetServiceAccountBySPIFFEID(ctx context.Context,
+	orgID, spiffeID string) (*models.ServiceAccount, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	for _, account := range r.db.serviceAccounts {
+		if spiffeID != "" && account.OrganizationID == orgID && account.SPIFFEID == spiffeID {
+			return copyServiceAccount(account), nil
+		}
+	}
+	return nil, storage.ErrServiceAccountNotFound
+}
+
+// SetServiceAccountSPIFFEID associe un identifiant SPIFFE à un compte de l'organisation
+func (r *ServiceAccountsRepository) SetServiceAccountSPIFFEID(ctx context.Context, orgID, id, spiffeID string) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	account, ok := r.db.serviceAccounts[id]
+	if !ok || account.OrganizationID != orgID {
+		return storage.ErrServiceAccountNotFound
+	}
+	if r.spiffeIDTaken(orgID, spiffeID, id) {
+		return storage.ErrSPIFFEIDTaken
+	}
+	account.SPIFFEID = spiffeID
+	return nil
+}
+
+// spiffeIDTaken indique si spiffeID est associé à un autre compte de
+// l'organisation que id
+func (r *ServiceAccountsRepository) spiffeIDTaken(orgID, spiffeID, id string) bool {
+	if spiffeID == "" {
+		return false
+	}
+	for _, account := range r.db.serviceAccounts {
+		if account.OrganizationID == orgID && account.SPIFFEID == spiffeID && account.ID != id {
+			return true
+		}
+	}
+	return false
+}
+
+// ListServiceAccounts liste les comptes de l'organisation, du plus récent au plus ancien
+func (r *ServiceAccountsRepository) ListServiceAccounts(ctx context.Context, orgID string) ([]*models.ServiceAccount, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	accounts := []*models.ServiceAccount{}
+	for _, account := range r.db.serviceAccounts {
+		if account.OrganizationID == orgID {
+			accounts = append(accounts, copyServiceAccount(account))
+		}
+	}
+	sort.Slice(accounts, func(i, j int) bool { return accounts[i].CreatedAt.After(accounts[j].CreatedAt) })
+	return accounts, nil
+}
+
+// DeleteServiceAccount supprime un compte de l'organisation
+func (r *ServiceAccountsRepository) DeleteServiceAccount(ctx context.Context, orgID, id string) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	account, ok := r.db.serviceAccounts[id]
+	if !ok || account.OrganizationID != orgID {
+		return storage.ErrServiceAccountNotFound
+	}
+	delete(r.db.serviceAccounts, id)
+	return nil
+}
+
+// TouchServiceAccount enregistre la date de dernière utilisation
+func (r *ServiceAccountsRepository) TouchServiceAccount(ctx context.Context, id string, at time.Time) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if account, ok := r.db.serviceAccounts[id]; ok {
+		account.LastUsedAt = &at
+	}
+	return nil
+}
+
+// GetServiceAccountTrust récupère la confiance mTLS de l'organisation
+func (r *ServiceAccountsRepository) GetServiceAccountTrust(ctx context.Context,
+	orgID string) (*models.ServiceAccountTrust, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	trust, ok := r.db.serviceAccountTrusts[orgID]
+	if !ok {
+		return nil, storage.ErrTrustDomainNotFound
+	}
+	copied := *trust
+	return &copied, nil
+}
+
+// GetServiceAccountTrustByDomain récupère la confiance mTLS du domaine de confiance
+func (r *ServiceAccountsRepository) GetServiceAccountTrustByDomain(ctx context.Context,
+	trustDomain string) (*models.ServiceAccountTrust, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	for _, trust := range r.db.serviceAccountTrusts {
+		if trust.TrustDomain == trustDomain {
+			copied := *trust
+			return &copied, nil
+		}
+	}
+	return nil, storage.ErrTrustDomainNotFound
+}
+
+// SaveServiceAccountTrust crée ou remplace la confiance mTLS de l'organisation
+func (r *ServiceAccountsRepository) SaveServiceAccountTrust(ctx context.Context,
+	trust *models.ServiceAccountTrust) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	for orgID, existing := range r.db.serviceAccountTrusts {
+		if existing.TrustDomain == trust.TrustDomain && orgID != trust.OrganizationID {
+			return storage.ErrTrustDomainTaken
+		}
+	}
+	copied := *trust
+	r.db.serviceAccountTrusts[trust.OrganizationID] = &copied
+	return nil
+}
+
+func copyServiceAccount(account *models.ServiceAccount) *models.ServiceAccount {
+	copied := *account
+	copied.Resources = slices.Clone(account.Resources)
+	if account.LastUsedAt != nil {
+		lastUsedAt := *account.LastUsedAt
+		copied.LastUsedAt = &lastUsedAt
+	}
+	return &copied
+}
@@ -0,0 +1,47 @@
+// filepath: internal/storage/memory/session_policies_repository.go
+
+package memory
+
+import (
+	"context"
+	"time"
+
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// SessionPoliciesRepository est l'implémentation en mémoire de storage.SessionPoliciesRepository
+type SessionPoliciesRepository struct {
+	db *DB
+}
+
+var _ storage.SessionPoliciesRepository = (*SessionPoliciesRepository)(nil)
+
+// NewSessionPoliciesRepository crée un nouveau repository de politiques de session en mémoire
+func NewSessionPoliciesRepository(db *DB) *SessionPoliciesRepository {
+	return &SessionPoliciesRepository{db: db}
+}
+
+// GetSessionPolicy renvoie la politique de l'organisation
+func (r *SessionPoliciesRepository) GetSessionPolicy(ctx context.Context, orgID string) (*models.SessionPolicy, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	policy, ok := r.db.sessionPolicies[orgID]
+	if !ok {
+		return nil, storage.ErrSessionPolicyNotFound
+	}
+	copied := *policy
+	return &copied, nil
+}
+
+// SaveSessionPolicy crée ou remplace la politique de l'organisation
+func (r *SessionPoliciesRepository) SaveSessionPolicy(ctx context.Context, policy *models.SessionPolicy) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	policy.UpdatedAt = time.Now()
+	copied := *policy
+	r.db.sessionPolicies[policy.OrganizationID] = &copied
+	return nil
+}
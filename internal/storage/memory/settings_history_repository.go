@@ -0,0 +1,68 @@
+// filepath: internal/storage/memory/settings_history_repository.go
+
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// SettingsHistoryRepository est l'implémentation en mémoire de storage.SettingsHistoryRepository
+type SettingsHistoryRepository struct {
+	db *DB
+}
+
+var _ storage.SettingsHistoryRepository = (*SettingsHistoryRepository)(nil)
+
+// NewSettingsHistoryRepository crée un nouveau repository de l'historique de configuration en mémoire
+func NewSettingsHistoryRepository(db *DB) *SettingsHistoryRepository {
+	return &SettingsHistoryRepository{db: db}
+}
+
+// RecordSettingsChange enregistre une modification sous la version suivante
+func (r *SettingsHistoryRepository) RecordSettingsChange(ctx context.Context, change *models.SettingsChange) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if change.ID == "" {
+		change.ID = uuid.New().String()
+	}
+	change.Version = 1
+	for _, existing := range r.db.settingsChanges {
+		if existing.OrganizationID == change.OrganizationID && existing.Version >= change.Version {
+			change.Version = existing.Version + 1
+		}
+	}
+	change.CreatedAt = time.Now()
+
+	copied := *change
+	r.db.settingsChanges = append(r.db.settingsChanges, &copied)
+	return nil
+}
+
+// ListSettingsChanges liste les modifications de l'organisation, de la plus récente à la plus ancienne
+func (r *SettingsHistoryRepository) ListSettingsChanges(
+	ctx context.Context,
+	orgID, resource string,
+	limit, offset int,
+) ([]*models.SettingsChange, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	// Les modifications sont enregistrées dans l'ordre des versions
+	changes := []*models.SettingsChange{}
+	for i := len(r.db.settingsChanges) - 1; i >= 0; i-- {
+		change := r.db.settingsChanges[i]
+		if change.OrganizationID != orgID || (resource != "" && change.Resource != resource) {
+			continue
+		}
+		copied := *change
+		changes = append(changes, &copied)
+	}
+	return paginate(changes, limit, offset), nil
+}
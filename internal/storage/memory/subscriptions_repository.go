@@ -0,0 +1,37 @@
+// filepath: internal/storage/memory/subscriptions_repository.go
+
+package memory
+
+import (
+	"context"
+	"time"
+
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// SubscriptionsRepository est l'implémentation en mémoire de storage.SubscriptionsRepository
+type SubscriptionsRepository struct {
+	db *DB
+}
+
+var _ storage.SubscriptionsRepository = (*SubscriptionsRepository)(nil)
+
+// NewSubscriptionsRepository crée un nouveau repository d'abonnements en mémoire
+func NewSubscriptionsRepository(db *DB) *SubscriptionsRepository {
+	return &SubscriptionsRepository{db: db}
+}
+
+// GetActiveSubscription renvoie l'abonnement actif de l'organisation, nil
+// s'il n'en a pas ou s'il est échu
+func (r *SubscriptionsRepository) GetActiveSubscription(ctx context.Context, orgID string) (*models.Subscription, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	subscription, ok := r.db.subscriptions[orgID]
+	if !ok || subscription.Status != "active" || !subscription.EndDate.After(time.Now()) {
+		return nil, nil
+	}
+	copied := *subscription
+	return &copied, nil
+}
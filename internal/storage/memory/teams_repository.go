@@ -0,0 +1,232 @@
+// filepath: internal/storage/memory/teams_repository.go
+
+package memory
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// TeamsRepository est l'implémentation en mémoire de storage.TeamsRepository
+type TeamsRepository struct {
+	db *DB
+}
+
+var _ storage.TeamsRepository = (*TeamsRepository)(nil)
+
+// NewTeamsRepository crée un nouveau repository d'équipes en mémoire
+func NewTeamsRepository(db *DB) *TeamsRepository {
+	return &TeamsRepository{db: db}
+}
+
+// CreateTeam enregistre une équipe
+func (r *TeamsRepository) CreateTeam(ctx context.Context, team *models.Team) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	for _, existing := range r.db.teams {
+		// Comme la collation MySQL, la comparaison des noms ignore la casse
+		if existing.OrganizationID == team.OrganizationID && strings.EqualFold(existing.Name, team.Name) {
+			return storage.ErrTeamExists
+		}
+	}
+
+	if team.ID == "" {
+		team.ID = uuid.New().String()
+	}
+	team.CreatedAt = time.Now()
+	copied := *team
+	copied.Members = nil
+	r.db.teams[team.ID] = &copied
+	return nil
+}
+
+// GetTeam récupère une équipe de l'organisation avec ses membres
+func (r *TeamsRepository) GetTeam(ctx context.Context, orgID, id string) (*models.Team, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	team, ok := r.db.teams[id]
+	if !ok || team.OrganizationID != orgID {
+		return nil, storage.ErrTeamNotFound
+	}
+
+	copied := *team
+	copied.Members = []*models.TeamMember{}
+	for _, member := range r.db.teamMembers[id] {
+		member := *member
+		copied.Members = append(copied.Members, &member)
+	}
+	sort.Slice(copied.Members, func(i, j int) bool {
+		return copied.Members[i].AddedAt.Before(copied.Members[j].AddedAt)
+	})
+	copied.Grants = []*models.TeamGrant{}
+	for _, grant := range r.db.teamGrants[id] {
+		grant := *grant
+		copied.Grants = append(copied.Grants, &grant)
+	}
+	sortGrants(copied.Grants)
+	return &copied, nil
+}
+
+// ListTeams liste les équipes de l'organisation par nom
+func (r *TeamsRepository) ListTeams(ctx context.Context, orgID string) ([]*models.Team, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	return r.listTeams(func(team *models.Team) bool { return team.OrganizationID == orgID }), nil
+}
+
+// DeleteTeam supprime une équipe et ses appartenances
+func (r *TeamsRepository) DeleteTeam(ctx context.Context, orgID, id string) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	team, ok := r.db.teams[id]
+	if !ok || team.OrganizationID != orgID {
+		return storage.ErrTeamNotFound
+	}
+
+	delete(r.db.teams, id)
+	delete(r.db.teamMembers, id)
+	delete(r.db.teamGrants, id)
+	for _, project := range r.db.projects {
+		if project.TeamID == id {
+			project.TeamID = ""
+		}
+	}
+	for _, secret := range r.db.secrets {
+		if secret.TeamID == id {
+			secret.TeamID = ""
+		}
+	}
+	return nil
+}
+
+// AddTeamMember ajoute un membre à une équipe
+func (r *TeamsRepository) AddTeamMember(ctx context.Context, member *models.TeamMember) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if _, ok := r.db.teams[member.TeamID]; !ok {
+		return storage.ErrTeamNotFound
+	}
+	if r.db.teamMembers[member.TeamID] == nil {
+		r.db.teamMembers[member.TeamID] = make(map[string]*models.TeamMember)
+	}
+	if existing, ok := r.db.teamMembers[member.TeamID][member.UserID]; ok {
+		*member = *existing
+		return nil
+	}
+
+	member.AddedAt = time.Now()
+	copied := *member
+	r.db.teamMembers[member.TeamID][member.UserID] = &copied
+	return nil
+}
+
+// RemoveTeamMember retire un membre d'une équipe
+func (r *TeamsRepository) RemoveTeamMember(ctx context.Context, teamID, userID string) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	delete(r.db.teamMembers[teamID], userID)
+	return nil
+}
+
+// ListUserTeams liste les équipes de l'organisation dont l'utilisateur est membre
+func (r *TeamsRepository) ListUserTeams(ctx context.Context, orgID, userID string) ([]*models.Team, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	return r.listTeams(func(team *models.Team) bool {
+		_, member := r.db.teamMembers[team.ID][userID]
+		return team.OrganizationID == orgID && member
+	}), nil
+}
+
+// SetTeamGrant accorde un rôle à une équipe sur un projet ou un environnement
+func (r *TeamsRepository) SetTeamGrant(ctx context.Context, grant *models.TeamGrant) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if _, ok := r.db.teams[grant.TeamID]; !ok {
+		return storage.ErrTeamNotFound
+	}
+
+	grant.GrantedAt = time.Now()
+	copied := *grant
+	grants := r.db.teamGrants[grant.TeamID]
+	for i, existing := range grants {
+		if existing.ProjectID == grant.ProjectID && existing.Environment == grant.Environment {
+			grants[i] = &copied
+			return nil
+		}
+	}
+	r.db.teamGrants[grant.TeamID] = append(grants, &copied)
+	return nil
+}
+
+// RemoveTeamGrant retire l'octroi d'une équipe sur un projet ou un environnement
+func (r *TeamsRepository) RemoveTeamGrant(ctx context.Context, teamID, projectID, env string) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	r.db.teamGrants[teamID] = slices.DeleteFunc(r.db.teamGrants[teamID], func(grant *models.TeamGrant) bool {
+		return grant.ProjectID == projectID && grant.Environment == env
+	})
+	return nil
+}
+
+// ListUserGrants liste les octrois des équipes de l'organisation dont l'utilisateur est membre
+func (r *TeamsRepository) ListUserGrants(ctx context.Context, orgID, userID string) ([]*models.TeamGrant, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	grants := []*models.TeamGrant{}
+	for id, team := range r.db.teams {
+		if _, member := r.db.teamMembers[id][userID]; !member || team.OrganizationID != orgID {
+			continue
+		}
+		for _, grant := range r.db.teamGrants[id] {
+			grant := *grant
+			grants = append(grants, &grant)
+		}
+	}
+	sortGrants(grants)
+	return grants, nil
+}
+
+// sortGrants trie les octrois par projet puis par environnement, comme MySQL
+func sortGrants(grants []*models.TeamGrant) {
+	sort.Slice(grants, func(i, j int) bool {
+		if grants[i].ProjectID != grants[j].ProjectID {
+			return grants[i].ProjectID < grants[j].ProjectID
+		}
+		if grants[i].Environment != grants[j].Environment {
+			return grants[i].Environment < grants[j].Environment
+		}
+		return grants[i].TeamID < grants[j].TeamID
+	})
+}
+
+// listTeams renvoie, par nom, des copies des équipes retenues par keep
+func (r *TeamsRepository) listTeams(keep func(*models.Team) bool) []*models.Team {
+	teams := []*models.Team{}
+	for _, team := range r.db.teams {
+		if keep(team) {
+			copied := *team
+			teams = append(teams, &copied)
+		}
+	}
+	sort.Slice(teams, func(i, j int) bool { return teams[i].Name < teams[j].Name })
+	return teams
+}
@@ -0,0 +1,115 @@
+// filepath: internal/storage/memory/trusted_devices_repository.go
+
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// TrustedDevicesRepository est l'implémentation en mémoire de storage.TrustedDevicesRepository
+type TrustedDevicesRepository struct {
+	db *DB
+}
+
+var _ storage.TrustedDevicesRepository = (*TrustedDevicesRepository)(nil)
+
+// NewTrustedDevicesRepository crée un nouveau repository d'appareils de confiance en mémoire
+func NewTrustedDevicesRepository(db *DB) *TrustedDevicesRepository {
+	return &TrustedDevicesRepository{db: db}
+}
+
+// CreateTrustedDevice enregistre un appareil
+func (r *TrustedDevicesRepository) CreateTrustedDevice(ctx context.Context, device *models.TrustedDevice) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if device.ID == "" {
+		device.ID = uuid.New().String()
+	}
+	device.CreatedAt = time.Now()
+	r.db.trustedDevices[device.ID] = copyTrustedDevice(device)
+	return nil
+}
+
+// GetTrustedDeviceByHash récupère l'appareil correspondant à l'empreinte du cookie
+func (r *TrustedDevicesRepository) GetTrustedDeviceByHash(ctx context.Context, tokenHash string) (*models.TrustedDevice, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	for _, device := range r.db.trustedDevices {
+		if device.TokenHash == tokenHash {
+			return copyTrustedDevice(device), nil
+		}
+	}
+	return nil, storage.ErrDeviceNotFound
+}
+
+// ListTrustedDevices liste les appareils de l'utilisateur, du plus récent au plus ancien
+func (r *TrustedDevicesRepository) ListTrustedDevices(ctx context.Context, userID string) ([]*models.TrustedDevice, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	devices := []*models.TrustedDevice{}
+	for _, device := range r.db.trustedDevices {
+		if device.UserID == userID {
+			devices = append(devices, copyTrustedDevice(device))
+		}
+	}
+	sort.Slice(devices, func(i, j int) bool { return devices[i].CreatedAt.After(devices[j].CreatedAt) })
+	return devices, nil
+}
+
+// DeleteTrustedDevice révoque la confiance accordée à un appareil de l'utilisateur
+func (r *TrustedDevicesRepository) DeleteTrustedDevice(ctx context.Context, userID, id string) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	device, ok := r.db.trustedDevices[id]
+	if !ok || device.UserID != userID {
+		return storage.ErrDeviceNotFound
+	}
+	delete(r.db.trustedDevices, id)
+	return nil
+}
+
+// DeleteUserTrustedDevices révoque tous les appareils de l'utilisateur
+func (r *TrustedDevicesRepository) DeleteUserTrustedDevices(ctx context.Context, userID string) (int64, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	var deleted int64
+	for id, device := range r.db.trustedDevices {
+		if device.UserID == userID {
+			delete(r.db.trustedDevices, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// TouchTrustedDevice enregistre la date de dernière utilisation
+func (r *TrustedDevicesRepository) TouchTrustedDevice(ctx context.Context, id string, at time.Time) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if device, ok := r.db.trustedDevices[id]; ok {
+		device.LastUsedAt = &at
+	}
+	return nil
+}
+
+func copyTrustedDevice(device *models.TrustedDevice) *models.TrustedDevice {
+	copied := *device
+	if device.LastUsedAt != nil {
+		lastUsedAt := *device.LastUsedAt
+		copied.LastUsedAt = &lastUsedAt
+	}
+	return &copied
+}
@@ -0,0 +1,123 @@
+// filepath: internal/storage/memory/usage_repository.go
+
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// UsageRepository est l'implémentation en mémoire de storage.UsageRepository
+type UsageRepository struct {
+	db *DB
+}
+
+var _ storage.UsageRepository = (*UsageRepository)(nil)
+
+// NewUsageRepository crée un nouveau repository d'usage en mémoire
+func NewUsageRepository(db *DB) *UsageRepository {
+	return &UsageRepository{db: db}
+}
+
+// RecordAPICall comptabilise un appel API
+func (r *UsageRepository) RecordAPICall(ctx context.Context, call *models.APICall) error {
+	return r.RecordAPICallCounts(ctx, []*models.APICallCount{call.Count()})
+}
+
+// RecordAPICallCounts comptabilise un lot d'appels accumulés
+func (r *UsageRepository) RecordAPICallCounts(ctx context.Context, counts []*models.APICallCount) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	for _, count := range counts {
+		copied := *count
+		r.db.apiCalls = append(r.db.apiCalls, &copied)
+	}
+	return nil
+}
+
+// GetAPICallsTotal renvoie le nombre total d'appels d'une organisation
+func (r *UsageRepository) GetAPICallsTotal(ctx context.Context, orgID string) (int64, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	var total int64
+	for _, count := range r.db.apiCalls {
+		if count.OrganizationID == orgID {
+			total += count.Calls
+		}
+	}
+	return total, nil
+}
+
+// GetUsageBreakdown agrège les appels d'une organisation entre deux jours inclus
+func (r *UsageRepository) GetUsageBreakdown(
+	ctx context.Context,
+	orgID string,
+	from, to time.Time,
+) (*models.UsageBreakdown, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	fromDay := from.UTC().Format("2006-01-02")
+	toDay := to.UTC().Format("2006-01-02")
+
+	byPrincipal := map[string]int64{}
+	byRoute := map[string]int64{}
+	byDay := map[string]int64{}
+	var total int64
+
+	for _, count := range r.db.apiCalls {
+		if count.OrganizationID != orgID || count.Day < fromDay || count.Day > toDay {
+			continue
+		}
+		byPrincipal[count.PrincipalType+":"+count.PrincipalID] += count.Calls
+		byRoute[count.Route] += count.Calls
+		byDay[count.Day] += count.Calls
+		total += count.Calls
+	}
+
+	return &models.UsageBreakdown{
+		OrganizationID: orgID,
+		From:           from,
+		To:             to,
+		Total:          total,
+		ByPrincipal:    sortedCounts(byPrincipal, true),
+		ByRoute:        sortedCounts(byRoute, true),
+		ByDay:          sortedCounts(byDay, false),
+	}, nil
+}
+
+// GetLastActivityDays renvoie, pour chaque principal du type donné, le
+// dernier jour où il a appelé l'API de l'organisation
+func (r *UsageRepository) GetLastActivityDays(ctx context.Context, orgID, principalType string) (map[string]string, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	days := make(map[string]string)
+	for _, count := range r.db.apiCalls {
+		if count.OrganizationID == orgID && count.PrincipalType == principalType && count.Day > days[count.PrincipalID] {
+			days[count.PrincipalID] = count.Day
+		}
+	}
+	return days, nil
+}
+
+// sortedCounts trie les compteurs par nombre d'appels décroissant ou par clé
+func sortedCounts(counts map[string]int64, byCalls bool) []*models.UsageCount {
+	result := make([]*models.UsageCount, 0, len(counts))
+	for key, calls := range counts {
+		result = append(result, &models.UsageCount{Key: key, Calls: calls})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if byCalls && result[i].Calls != result[j].Calls {
+			return result[i].Calls > result[j].Calls
+		}
+		return result[i].Key < result[j].Key
+	})
+	return result
+}
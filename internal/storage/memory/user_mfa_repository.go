@@ -0,0 +1,88 @@
+// filepath: internal/storage/memory/user_mfa_repository.go
+
+package memory
+
+import (
+	"context"
+	"time"
+
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// UserMFARepository est l'implémentation en mémoire de storage.UserMFARepository
+type UserMFARepository struct {
+	db *DB
+}
+
+var _ storage.UserMFARepository = (*UserMFARepository)(nil)
+
+// NewUserMFARepository crée un nouveau repository d'authentification multifacteur en mémoire
+func NewUserMFARepository(db *DB) *UserMFARepository {
+	return &UserMFARepository{db: db}
+}
+
+// SaveUserMFA crée ou remplace l'authentification multifacteur d'un utilisateur
+func (r *UserMFARepository) SaveUserMFA(ctx context.Context, mfa *models.UserMFA) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if mfa.CreatedAt.IsZero() {
+		mfa.CreatedAt = time.Now()
+	}
+	copied := *mfa
+	r.db.userMFA[mfa.UserID] = &copied
+	return nil
+}
+
+// GetUserMFA renvoie l'authentification multifacteur d'un utilisateur
+func (r *UserMFARepository) GetUserMFA(ctx context.Context, userID string) (*models.UserMFA, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	mfa, ok := r.db.userMFA[userID]
+	if !ok {
+		return nil, storage.ErrMFANotFound
+	}
+	copied := *mfa
+	return &copied, nil
+}
+
+// AcceptMFAStep enregistre un code accepté s'il n'a pas déjà servi
+func (r *UserMFARepository) AcceptMFAStep(ctx context.Context, userID string, step int64) (bool, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	mfa, ok := r.db.userMFA[userID]
+	if !ok || mfa.LastStep >= step {
+		return false, nil
+	}
+	mfa.LastStep = step
+	mfa.FailedAttempts = 0
+	mfa.LockedUntil = nil
+	return true, nil
+}
+
+// SetMFAFailures enregistre le nombre de codes refusés et l'éventuel verrouillage
+func (r *UserMFARepository) SetMFAFailures(ctx context.Context, userID string, failedAttempts int, lockedUntil *time.Time) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if mfa, ok := r.db.userMFA[userID]; ok {
+		mfa.FailedAttempts = failedAttempts
+		mfa.LockedUntil = lockedUntil
+	}
+	return nil
+}
+
+// DeleteUserMFA supprime l'authentification multifacteur d'un utilisateur
+func (r *UserMFARepository) DeleteUserMFA(ctx context.Context, userID string) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if _, ok := r.db.userMFA[userID]; !ok {
+		return storage.ErrMFANotFound
+	}
+	delete(r.db.userMFA, userID)
+	return nil
+}
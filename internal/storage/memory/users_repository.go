@@ -0,0 +1,280 @@
+// filepath: internal/storage/memory/users_repository.go
+
+package memory
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// UsersRepository est l'implémentation en mémoire de storage.UsersRepository
+type UsersRepository struct {
+	db *DB
+}
+
+var _ storage.UsersRepository = (*UsersRepository)(nil)
+
+// NewUsersRepository crée un nouveau repository en mémoire pour les utilisateurs
+func NewUsersRepository(db *DB) *UsersRepository {
+	return &UsersRepository{db: db}
+}
+
+// CreateUser crée un nouvel utilisateur
+func (r *UsersRepository) CreateUser(ctx context.Context, user *models.User) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	for _, existing := range r.db.users {
+		if strings.EqualFold(existing.Email, user.Email) {
+			return storage.ErrEmailAlreadyExists
+		}
+	}
+
+	if user.ID == "" {
+		user.ID = uuid.New().String()
+	}
+	now := time.Now()
+	if user.CreatedAt.IsZero() {
+		user.CreatedAt = now
+	}
+	if user.UpdatedAt.IsZero() {
+		user.UpdatedAt = now
+	}
+	if user.Role == "" {
+		user.Role = "user"
+	}
+
+	copied := *user
+	r.db.users[user.ID] = &copied
+	return nil
+}
+
+// GetUserByID récupère un utilisateur par son ID
+func (r *UsersRepository) GetUserByID(ctx context.Context, id string) (*models.User, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	user, ok := r.db.users[id]
+	if !ok {
+		return nil, storage.ErrUserNotFound
+	}
+	copied := *user
+	return &copied, nil
+}
+
+// GetUserByEmail récupère un utilisateur par son email
+func (r *UsersRepository) GetUserByEmail(ctx context.Context, email string) (*models.User, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	for _, user := range r.db.users {
+		if strings.EqualFold(user.Email, email) {
+			copied := *user
+			return &copied, nil
+		}
+	}
+	return nil, storage.ErrUserNotFound
+}
+
+// GetUsersByIDs récupère plusieurs utilisateurs ; les ID inconnus sont ignorés
+func (r *UsersRepository) GetUsersByIDs(ctx context.Context, ids []string) ([]*models.User, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	users := []*models.User{}
+	for _, id := range ids {
+		if user, ok := r.db.users[id]; ok {
+			copied := *user
+			users = append(users, &copied)
+		}
+	}
+	return users, nil
+}
+
+// UpdateUser met à jour les informations d'un utilisateur
+func (r *UsersRepository) UpdateUser(ctx context.Context, user *models.User) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	existing, ok := r.db.users[user.ID]
+	if !ok {
+		return storage.ErrUserNotFound
+	}
+	existing.Email = user.Email
+	existing.FirstName = user.FirstName
+	existing.LastName = user.LastName
+	existing.Role = user.Role
+	existing.UpdatedAt = time.Now()
+	return nil
+}
+
+// UpdatePassword met à jour le mot de passe d'un utilisateur
+func (r *UsersRepository) UpdatePassword(ctx context.Context, userID, hashedPassword string) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	existing, ok := r.db.users[userID]
+	if !ok {
+		return storage.ErrUserNotFound
+	}
+	existing.HashedPassword = hashedPassword
+	existing.PasswordResetRequired = false
+	existing.UpdatedAt = time.Now()
+	return nil
+}
+
+// ForcePasswordReset exige un nouveau mot de passe et révoque les tokens antérieurs
+func (r *UsersRepository) ForcePasswordReset(ctx context.Context, userID string, tokensNotBefore time.Time) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	existing, ok := r.db.users[userID]
+	if !ok {
+		return storage.ErrUserNotFound
+	}
+	existing.PasswordResetRequired = true
+	existing.TokensNotBefore = &tokensNotBefore
+	existing.UpdatedAt = time.Now()
+	return nil
+}
+
+// DeleteUser supprime un utilisateur
+func (r *UsersRepository) DeleteUser(ctx context.Context, id string) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if _, ok := r.db.users[id]; !ok {
+		return storage.ErrUserNotFound
+	}
+	delete(r.db.users, id)
+	return nil
+}
+
+// ListUsers liste les utilisateurs du plus récent au plus ancien
+func (r *UsersRepository) ListUsers(ctx context.Context, limit, offset int) ([]*models.User, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	users := make([]*models.User, 0, len(r.db.users))
+	for _, user := range r.db.users {
+		copied := *user
+		users = append(users, &copied)
+	}
+	sort.Slice(users, func(i, j int) bool {
+		return users[i].CreatedAt.After(users[j].CreatedAt)
+	})
+
+	return paginate(users, limit, offset), nil
+}
+
+// CountUsers compte le nombre total d'utilisateurs
+func (r *UsersRepository) CountUsers(ctx context.Context) (int, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	return len(r.db.users), nil
+}
+
+// GetUserOrganizations récupère toutes les organisations d'un utilisateur
+func (r *UsersRepository) GetUserOrganizations(ctx context.Context, userID string) ([]*models.Organization, error) {
+	return NewOrganizationsRepository(r.db).ListUserOrganizations(ctx, userID)
+}
+
+// GetUserRole récupère le rôle d'un utilisateur dans une organisation.
+// Une organisation dans la corbeille n'a plus de membres.
+func (r *UsersRepository) GetUserRole(ctx context.Context, userID, orgID string) (string, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	membership, ok := r.db.userOrganizations[membershipKey(userID, orgID)]
+	if org := r.db.organizations[orgID]; !ok || (org != nil && org.DeletedAt != nil) {
+		return "", storage.ErrUserNotFound
+	}
+	return membership.Role, nil
+}
+
+// GetUserRoles récupère les rôles d'un utilisateur dans ses organisations
+func (r *UsersRepository) GetUserRoles(ctx context.Context, userID string) (map[string]string, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	roles := make(map[string]string)
+	for _, membership := range r.db.userOrganizations {
+		if membership.UserID != userID {
+			continue
+		}
+		if org := r.db.organizations[membership.OrganizationID]; org != nil && org.DeletedAt != nil {
+			continue
+		}
+		roles[membership.OrganizationID] = membership.Role
+	}
+	return roles, nil
+}
+
+// SearchOrganizationMembers recherche les membres d'une organisation par
+// début de nom, de prénom ou d'email
+func (r *UsersRepository) SearchOrganizationMembers(
+	ctx context.Context,
+	orgID string,
+	search models.MemberSearch,
+) ([]*models.OrganizationMember, error) {
+	members, err := NewOrganizationsRepository(r.db).ListOrganizationMembers(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	query := strings.ToLower(search.Query)
+	matches := []*models.OrganizationMember{}
+	for _, member := range members {
+		if len(search.Roles) > 0 && !slices.Contains(search.Roles, member.Role) {
+			continue
+		}
+		if query != "" &&
+			!strings.HasPrefix(strings.ToLower(member.Email), query) &&
+			!strings.HasPrefix(strings.ToLower(member.FirstName), query) &&
+			!strings.HasPrefix(strings.ToLower(member.LastName), query) {
+			continue
+		}
+		matches = append(matches, member)
+	}
+
+	return paginate(matches, search.Limit, search.Offset), nil
+}
+
+// AssignUserToOrganization assigne un utilisateur à une organisation avec un rôle
+func (r *UsersRepository) AssignUserToOrganization(ctx context.Context, userID, orgID, role string) error {
+	return NewOrganizationsRepository(r.db).AddUserToOrganization(ctx, userID, orgID, role)
+}
+
+// RemoveUserFromOrganization supprime un utilisateur d'une organisation
+func (r *UsersRepository) RemoveUserFromOrganization(ctx context.Context, userID, orgID string) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	key := membershipKey(userID, orgID)
+	if _, ok := r.db.userOrganizations[key]; !ok {
+		return storage.ErrUserNotFound
+	}
+	delete(r.db.userOrganizations, key)
+	return nil
+}
+
+// paginate applique limit/offset à une liste déjà triée
+func paginate[T any](items []T, limit, offset int) []T {
+	if offset >= len(items) {
+		return []T{}
+	}
+	items = items[offset:]
+	if limit >= 0 && limit < len(items) {
+		items = items[:limit]
+	}
+	return items
+}
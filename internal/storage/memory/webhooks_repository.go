@@ -0,0 +1,155 @@
+// filepath: internal/storage/memory/webhooks_repository.go
+
+package memory
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+)
+
+// WebhooksRepository est l'implémentation en mémoire de storage.WebhooksRepository
+type WebhooksRepository struct {
+	db *DB
+}
+
+var _ storage.WebhooksRepository = (*WebhooksRepository)(nil)
+
+// NewWebhooksRepository crée un nouveau repository de webhooks en mémoire
+func NewWebhooksRepository(db *DB) *WebhooksRepository {
+	return &WebhooksRepository{db: db}
+}
+
+// CreateWebhook enregistre un webhook
+func (r *WebhooksRepository) CreateWebhook(ctx context.Context, webhook *models.Webhook) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if webhook.ID == "" {
+		webhook.ID = uuid.New().String()
+	}
+	webhook.CreatedAt = time.Now()
+	r.db.webhooks[webhook.ID] = copyWebhook(webhook)
+	return nil
+}
+
+// GetWebhook récupère un webhook de l'organisation
+func (r *WebhooksRepository) GetWebhook(ctx context.Context, orgID, id string) (*models.Webhook, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	webhook, ok := r.db.webhooks[id]
+	if !ok || webhook.OrganizationID != orgID {
+		return nil, storage.ErrWebhookNotFound
+	}
+	return copyWebhook(webhook), nil
+}
+
+// ListWebhooks liste les webhooks de l'organisation par date de création
+func (r *WebhooksRepository) ListWebhooks(ctx context.Context, orgID string) ([]*models.Webhook, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	webhooks := []*models.Webhook{}
+	for _, webhook := range r.db.webhooks {
+		if webhook.OrganizationID == orgID {
+			webhooks = append(webhooks, copyWebhook(webhook))
+		}
+	}
+	sort.Slice(webhooks, func(i, j int) bool { return webhooks[i].CreatedAt.Before(webhooks[j].CreatedAt) })
+	return webhooks, nil
+}
+
+// DeleteWebhook supprime un webhook et l'historique de ses livraisons
+func (r *WebhooksRepository) DeleteWebhook(ctx context.Context, orgID, id string) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	webhook, ok := r.db.webhooks[id]
+	if !ok || webhook.OrganizationID != orgID {
+		return storage.ErrWebhookNotFound
+	}
+	delete(r.db.webhooks, id)
+	r.db.webhookDeliveries = slices.DeleteFunc(r.db.webhookDeliveries, func(delivery *models.WebhookDelivery) bool {
+		return delivery.WebhookID == id
+	})
+	return nil
+}
+
+// RecordWebhookDelivery enregistre une livraison
+func (r *WebhooksRepository) RecordWebhookDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if delivery.ID == "" {
+		delivery.ID = uuid.New().String()
+	}
+	copied := *delivery
+	r.db.webhookDeliveries = append(r.db.webhookDeliveries, &copied)
+	return nil
+}
+
+// GetWebhookDelivery récupère une livraison d'un webhook de l'organisation
+func (r *WebhooksRepository) GetWebhookDelivery(ctx context.Context, orgID, id string) (*models.WebhookDelivery, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	for _, delivery := range r.db.webhookDeliveries {
+		if delivery.ID == id && delivery.OrganizationID == orgID {
+			copied := *delivery
+			return &copied, nil
+		}
+	}
+	return nil, storage.ErrDeliveryNotFound
+}
+
+// ListWebhookDeliveries liste les livraisons d'un webhook, de la plus récente à la plus ancienne
+func (r *WebhooksRepository) ListWebhookDeliveries(
+	ctx context.Context,
+	orgID, webhookID string,
+	limit, offset int,
+) ([]*models.WebhookDelivery, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	deliveries := []*models.WebhookDelivery{}
+	for _, delivery := range r.db.webhookDeliveries {
+		if delivery.WebhookID == webhookID && delivery.OrganizationID == orgID {
+			copied := *delivery
+			deliveries = append(deliveries, &copied)
+		}
+	}
+	// Les livraisons sont ajoutées dans l'ordre : la plus récente est la dernière
+	slices.Reverse(deliveries)
+	return paginate(deliveries, limit, offset), nil
+}
+
+// PurgeWebhookDeliveries supprime les livraisons antérieures à before
+func (r *WebhooksRepository) PurgeWebhookDeliveries(ctx context.Context, before time.Time) (int64, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	kept := r.db.webhookDeliveries[:0]
+	var purged int64
+	for _, delivery := range r.db.webhookDeliveries {
+		if delivery.DeliveredAt.Before(before) {
+			purged++
+			continue
+		}
+		kept = append(kept, delivery)
+	}
+	r.db.webhookDeliveries = kept
+	return purged, nil
+}
+
+func copyWebhook(webhook *models.Webhook) *models.Webhook {
+	copied := *webhook
+	copied.Events = slices.Clone(webhook.Events)
+	return &copied
+}
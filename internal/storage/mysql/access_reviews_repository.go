@@ -0,0 +1,229 @@
+// filepath: internal/storage/mysql/access_reviews_repository.go
+
+/*************************************************************************/
+/*                                                                       */
+/*   Ce fichier implémente le repository MySQL des revues des accès      */
+/*   Il gère les campagnes et les décisions sur chaque accès             */
+/*                                                                       */
+/*************************************************************************/
+
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+
+	"secrets-manager/internal/models"
+	repo "secrets-manager/internal/storage"
+)
+
+// AccessReviewsRepository gère les campagnes de revue des accès dans MySQL
+type AccessReviewsRepository struct {
+	db *sql.DB
+}
+
+var _ repo.AccessReviewsRepository = (*AccessReviewsRepository)(nil)
+
+// NewAccessReviewsRepository crée un nouveau repository de revues des accès
+func NewAccessReviewsRepository(db *sql.DB) *AccessReviewsRepository {
+	return &AccessReviewsRepository{
+		db: db,
+	}
+}
+
+// CreateAccessReview enregistre une campagne et ses accès dans une transaction
+func (r *AccessReviewsRepository) CreateAccessReview(ctx context.Context, review *models.AccessReview) error {
+	if review.ID == "" {
+		review.ID = uuid.New().String()
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO access_reviews (id, organization_id, created_by, status, created_at)
+		VALUES (?, ?, ?, ?, ?)
+	`, review.ID, review.OrganizationID, review.CreatedBy, review.Status, review.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range review.Items {
+		item.ReviewID = review.ID
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO access_review_items (
+				review_id, principal_type, principal_id, email, role,
+				last_activity, dormant, decision
+			) VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		`, item.ReviewID, item.PrincipalType, item.PrincipalID, item.Email, item.Role,
+			item.LastActivity, item.Dormant, item.Decision)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetAccessReview récupère une campagne et ses accès
+func (r *AccessReviewsRepository) GetAccessReview(ctx context.Context, orgID, id string) (*models.AccessReview, error) {
+	query := `
+		SELECT ` + accessReviewColumns + `
+		FROM access_reviews ar
+		WHERE ar.id = ? AND ar.organization_id = ?
+	`
+
+	review, err := scanAccessReview(r.db.QueryRowContext(ctx, query, id, orgID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repo.ErrAccessReviewNotFound
+		}
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT review_id, principal_type, principal_id, email, role,
+			   last_activity, dormant, decision, decided_by, decided_at
+		FROM access_review_items
+		WHERE review_id = ?
+		ORDER BY email, principal_id
+	`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	review.Items = []*models.AccessReviewItem{}
+	for rows.Next() {
+		item := &models.AccessReviewItem{}
+		var decidedAt sql.NullTime
+		err := rows.Scan(
+			&item.ReviewID,
+			&item.PrincipalType,
+			&item.PrincipalID,
+			&item.Email,
+			&item.Role,
+			&item.LastActivity,
+			&item.Dormant,
+			&item.Decision,
+			&item.DecidedBy,
+			&decidedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if decidedAt.Valid {
+			item.DecidedAt = &decidedAt.Time
+		}
+		review.Items = append(review.Items, item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return review, nil
+}
+
+// ListAccessReviews liste les campagnes d'une organisation avec leur avancement
+func (r *AccessReviewsRepository) ListAccessReviews(ctx context.Context, orgID string) ([]*models.AccessReview, error) {
+	query := `
+		SELECT ` + accessReviewColumns + `
+		FROM access_reviews ar
+		WHERE ar.organization_id = ?
+		ORDER BY ar.created_at DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, orgID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reviews := []*models.AccessReview{}
+	for rows.Next() {
+		review, err := scanAccessReview(rows)
+		if err != nil {
+			return nil, err
+		}
+		reviews = append(reviews, review)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return reviews, nil
+}
+
+// DecideAccessReviewItem enregistre la décision sur un accès encore en attente
+func (r *AccessReviewsRepository) DecideAccessReviewItem(ctx context.Context, item *models.AccessReviewItem) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE access_review_items
+		SET decision = ?, decided_by = ?, decided_at = ?
+		WHERE review_id = ? AND principal_type = ? AND principal_id = ? AND decision = ?
+	`, item.Decision, item.DecidedBy, item.DecidedAt,
+		item.ReviewID, item.PrincipalType, item.PrincipalID, models.AccessDecisionPending)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return repo.ErrAccessAlreadyReviewed
+	}
+
+	return nil
+}
+
+// CompleteAccessReview termine la campagne s'il ne reste aucun accès en attente
+func (r *AccessReviewsRepository) CompleteAccessReview(ctx context.Context, id string) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE access_reviews
+		SET status = ?, completed_at = NOW()
+		WHERE id = ? AND status = ? AND NOT EXISTS (
+			SELECT 1 FROM access_review_items WHERE review_id = ? AND decision = ?
+		)
+	`, models.AccessReviewCompleted, id, models.AccessReviewOpen, id, models.AccessDecisionPending)
+	return err
+}
+
+// Colonnes lues par scanAccessReview, dans le même ordre (access_reviews ar)
+const accessReviewColumns = `ar.id, ar.organization_id, ar.created_by, ar.status, ar.created_at, ar.completed_at,
+			   (SELECT COUNT(*) FROM access_review_items i WHERE i.review_id = ar.id),
+			   (SELECT COUNT(*) FROM access_review_items i WHERE i.review_id = ar.id AND i.decision = 'pending')`
+
+// scanAccessReview lit une ligne sélectionnée avec accessReviewColumns
+func scanAccessReview(row rowScanner) (*models.AccessReview, error) {
+	review := &models.AccessReview{}
+	var completedAt sql.NullTime
+
+	err := row.Scan(
+		&review.ID,
+		&review.OrganizationID,
+		&review.CreatedBy,
+		&review.Status,
+		&review.CreatedAt,
+		&completedAt,
+		&review.TotalItems,
+		&review.PendingItems,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if completedAt.Valid {
+		review.CompletedAt = &completedAt.Time
+	}
+
+	return review, nil
+}
@@ -0,0 +1,156 @@
+// filepath: internal/storage/mysql/admin_audit_repository.go
+
+/*************************************************************************/
+/*                                                                       */
+/*   Ce fichier implémente le repository MySQL de l'audit admin          */
+/*   Il conserve les requêtes des routes d'administration                */
+/*                                                                       */
+/*************************************************************************/
+
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+
+	"secrets-manager/internal/models"
+	repo "secrets-manager/internal/storage"
+)
+
+// AdminAuditRepository gère le journal d'audit d'administration dans MySQL
+type AdminAuditRepository struct {
+	db *sql.DB
+}
+
+var _ repo.AdminAuditRepository = (*AdminAuditRepository)(nil)
+
+// NewAdminAuditRepository crée un nouveau repository d'audit d'administration
+func NewAdminAuditRepository(db *sql.DB) *AdminAuditRepository {
+	return &AdminAuditRepository{
+		db: db,
+	}
+}
+
+// CreateAdminAuditLog enregistre une entrée du journal
+func (r *AdminAuditRepository) CreateAdminAuditLog(ctx context.Context, entry *models.AdminAuditLog) error {
+	if entry.ID == "" {
+		entry.ID = uuid.New().String()
+	}
+
+	query := `
+		INSERT INTO admin_audit_logs (
+			id, principal, method, route, path, request_body,
+			status_code, duration_ms, ip_address, user_agent, timestamp, impersonation_id
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := r.db.ExecContext(
+		ctx,
+		query,
+		entry.ID,
+		entry.Principal,
+		entry.Method,
+		entry.Route,
+		entry.Path,
+		entry.RequestBody,
+		entry.StatusCode,
+		entry.DurationMS,
+		entry.IPAddress,
+		entry.UserAgent,
+		entry.Timestamp,
+		sql.NullString{String: entry.ImpersonationID, Valid: entry.ImpersonationID != ""},
+	)
+
+	return err
+}
+
+// ListAdminAuditLogs liste les entrées depuis since, de la plus récente à la plus ancienne
+func (r *AdminAuditRepository) ListAdminAuditLogs(
+	ctx context.Context,
+	since time.Time,
+	limit, offset int,
+) ([]*models.AdminAuditLog, error) {
+	query := `
+		SELECT ` + adminAuditColumns + `
+		FROM admin_audit_logs
+		WHERE timestamp >= ?
+		ORDER BY timestamp DESC
+		LIMIT ? OFFSET ?
+	`
+
+	return r.list(ctx, query, since, limit, offset)
+}
+
+// ListImpersonationAuditLogs liste les requêtes faites sous une usurpation
+// d'identité, de la plus récente à la plus ancienne
+func (r *AdminAuditRepository) ListImpersonationAuditLogs(
+	ctx context.Context,
+	impersonationID string,
+	limit, offset int,
+) ([]*models.AdminAuditLog, error) {
+	query := `
+		SELECT ` + adminAuditColumns + `
+		FROM admin_audit_logs
+		WHERE impersonation_id = ?
+		ORDER BY timestamp DESC
+		LIMIT ? OFFSET ?
+	`
+
+	return r.list(ctx, query, impersonationID, limit, offset)
+}
+
+// Colonnes lues par list, dans le même ordre
+const adminAuditColumns = `id, principal, method, route, path, request_body,
+			   status_code, duration_ms, ip_address, user_agent, timestamp, impersonation_id`
+
+func (r *AdminAuditRepository) list(ctx context.Context, query string, args ...interface{}) ([]*models.AdminAuditLog, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []*models.AdminAuditLog{}
+	for rows.Next() {
+		entry := &models.AdminAuditLog{}
+		var impersonationID sql.NullString
+		err := rows.Scan(
+			&entry.ID,
+			&entry.Principal,
+			&entry.Method,
+			&entry.Route,
+			&entry.Path,
+			&entry.RequestBody,
+			&entry.StatusCode,
+			&entry.DurationMS,
+			&entry.IPAddress,
+			&entry.UserAgent,
+			&entry.Timestamp,
+			&impersonationID,
+		)
+		if err != nil {
+			return nil, err
+		}
+		entry.ImpersonationID = impersonationID.String
+		entries = append(entries, entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// PurgeAdminAuditLogs supprime les entrées antérieures à before
+func (r *AdminAuditRepository) PurgeAdminAuditLogs(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM admin_audit_logs WHERE timestamp < ?", before)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
@@ -0,0 +1,129 @@
+// filepath: internal/storage/mysql/age_keys_repository.go
+
+/*************************************************************************/
+/*                                                                       */
+/*   Ce fichier implémente le repository MySQL des clés age (sops) des   */
+/*   dépôts de code des projets                                          */
+/*                                                                       */
+/*************************************************************************/
+
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+
+	"secrets-manager/internal/models"
+	repo "secrets-manager/internal/storage"
+)
+
+// AgeKeysRepository gère les clés age dans MySQL
+type AgeKeysRepository struct {
+	db *sql.DB
+}
+
+var _ repo.AgeKeysRepository = (*AgeKeysRepository)(nil)
+
+// NewAgeKeysRepository crée un nouveau repository de clés age
+func NewAgeKeysRepository(db *sql.DB) *AgeKeysRepository {
+	return &AgeKeysRepository{
+		db: db,
+	}
+}
+
+// CreateAgeKey enregistre la nouvelle clé active d'un dépôt et retire la précédente
+func (r *AgeKeysRepository) CreateAgeKey(ctx context.Context, key *models.AgeKey) error {
+	if key.ID == "" {
+		key.ID = uuid.New().String()
+	}
+	key.Status = models.AgeKeyActive
+	key.CreatedAt = time.Now()
+	key.RetiredAt = nil
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE age_keys SET status = ?, retired_at = ?
+		WHERE organization_id = ? AND project_id = ? AND repository = ? AND status = ?
+	`, models.AgeKeyRetired, key.CreatedAt, key.OrganizationID, key.ProjectID, key.Repository, models.AgeKeyActive)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO age_keys (id, organization_id, project_id, repository, recipient, status, created_by, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`, key.ID, key.OrganizationID, key.ProjectID, key.Repository, key.Recipient, key.Status,
+		key.CreatedBy, key.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ListAgeKeys liste les clés d'un dépôt du projet, de la plus récente à la plus ancienne
+func (r *AgeKeysRepository) ListAgeKeys(ctx context.Context, orgID, projectID, repository string) ([]*models.AgeKey, error) {
+	query := `
+		SELECT id, organization_id, project_id, repository, recipient, status, created_by, created_at, retired_at
+		FROM age_keys
+		WHERE organization_id = ? AND project_id = ? AND (? = '' OR repository = ?)
+		ORDER BY created_at DESC, status = 'active' DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, orgID, projectID, repository, repository)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys := []*models.AgeKey{}
+	for rows.Next() {
+		key := &models.AgeKey{}
+		var retiredAt sql.NullTime
+		err := rows.Scan(
+			&key.ID,
+			&key.OrganizationID,
+			&key.ProjectID,
+			&key.Repository,
+			&key.Recipient,
+			&key.Status,
+			&key.CreatedBy,
+			&key.CreatedAt,
+			&retiredAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if retiredAt.Valid {
+			key.RetiredAt = &retiredAt.Time
+		}
+		keys = append(keys, key)
+	}
+	return keys, rows.Err()
+}
+
+// DeleteAgeKey supprime une clé du projet
+func (r *AgeKeysRepository) DeleteAgeKey(ctx context.Context, orgID, projectID, id string) error {
+	result, err := r.db.ExecContext(ctx, `
+		DELETE FROM age_keys WHERE id = ? AND organization_id = ? AND project_id = ?
+	`, id, orgID, projectID)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return repo.ErrAgeKeyNotFound
+	}
+	return nil
+}
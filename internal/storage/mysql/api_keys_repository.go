@@ -0,0 +1,178 @@
+// filepath: internal/storage/mysql/api_keys_repository.go
+
+/*************************************************************************/
+/*                                                                       */
+/*   Ce fichier implémente le repository MySQL des clés d'API des        */
+/*   organisations (accès machine à machine)                             */
+/*                                                                       */
+/*************************************************************************/
+
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"secrets-manager/internal/models"
+	repo "secrets-manager/internal/storage"
+)
+
+// APIKeysRepository gère les clés d'API dans MySQL
+type APIKeysRepository struct {
+	db *sql.DB
+}
+
+var _ repo.APIKeysRepository = (*APIKeysRepository)(nil)
+
+// NewAPIKeysRepository crée un nouveau repository de clés d'API
+func NewAPIKeysRepository(db *sql.DB) *APIKeysRepository {
+	return &APIKeysRepository{
+		db: db,
+	}
+}
+
+// CreateAPIKey enregistre une clé
+func (r *APIKeysRepository) CreateAPIKey(ctx context.Context, key *models.APIKey) error {
+	if key.ID == "" {
+		key.ID = uuid.New().String()
+	}
+	key.CreatedAt = time.Now()
+
+	query := `
+		INSERT INTO api_keys (id, organization_id, project_id, environment, name, key_hash, prefix,
+			permission, signed_requests, wrapped_signing_key, created_by, expires_at, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := r.db.ExecContext(ctx, query, key.ID, key.OrganizationID, key.ProjectID, key.Environment,
+		key.Name, key.KeyHash, key.Prefix, key.Permission, key.SignedRequests, key.WrappedSigningKey, key.CreatedBy,
+		key.ExpiresAt, key.CreatedAt)
+	return err
+}
+
+// GetAPIKeyByHash récupère la clé correspondant à l'empreinte
+func (r *APIKeysRepository) GetAPIKeyByHash(ctx context.Context, keyHash string) (*models.APIKey, error) {
+	query := `
+		SELECT ` + apiKeyColumns + `
+		FROM api_keys
+		WHERE key_hash = ?
+	`
+
+	key, err := scanAPIKey(r.db.QueryRowContext(ctx, query, keyHash))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repo.ErrAPIKeyNotFound
+	}
+	return key, err
+}
+
+// GetAPIKey récupère une clé par son identifiant
+func (r *APIKeysRepository) GetAPIKey(ctx context.Context, id string) (*models.APIKey, error) {
+	query := `
+		SELECT ` + apiKeyColumns + `
+		FROM api_keys
+		WHERE id = ?
+	`
+
+	key, err := scanAPIKey(r.db.QueryRowContext(ctx, query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repo.ErrAPIKeyNotFound
+	}
+	return key, err
+}
+
+// ListAPIKeys liste les clés de l'organisation
+func (r *APIKeysRepository) ListAPIKeys(ctx context.Context, orgID string) ([]*models.APIKey, error) {
+	query := `
+		SELECT ` + apiKeyColumns + `
+		FROM api_keys
+		WHERE organization_id = ?
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, orgID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys := []*models.APIKey{}
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, rows.Err()
+}
+
+// DeleteAPIKey révoque une clé de l'organisation
+func (r *APIKeysRepository) DeleteAPIKey(ctx context.Context, orgID, id string) error {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM api_keys WHERE id = ? AND organization_id = ?", id, orgID)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return repo.ErrAPIKeyNotFound
+	}
+	return nil
+}
+
+// TouchAPIKey enregistre la date de dernière utilisation
+func (r *APIKeysRepository) TouchAPIKey(ctx context.Context, id string, at time.Time) error {
+	_, err := r.db.ExecContext(ctx, "UPDATE api_keys SET last_used_at = ? WHERE id = ?", at, id)
+	return err
+}
+
+// ClaimRequestSignature retient la signature d'une requête jusqu'à
+// expiresAt : la clé primaire refuse qu'une autre instance la retienne aussi
+func (r *APIKeysRepository) ClaimRequestSignature(ctx context.Context, keyID, signature string,
+	expiresAt time.Time) error {
+	_, err := r.db.ExecContext(ctx,
+		"INSERT INTO api_key_request_signatures (api_key_id, signature, expires_at) VALUES (?, ?, ?)",
+		keyID, signature, expiresAt)
+	if isDuplicateEntry(err) {
+		return repo.ErrSignatureReplayed
+	}
+	return err
+}
+
+// PurgeExpiredRequestSignatures oublie les signatures expirées
+func (r *APIKeysRepository) PurgeExpiredRequestSignatures(ctx context.Context, now time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM api_key_request_signatures WHERE expires_at <= ?", now)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// Colonnes lues par scanAPIKey, dans le même ordre
+const apiKeyColumns = `id, organization_id, project_id, environment, name, key_hash, prefix, permission,
+	signed_requests, wrapped_signing_key, created_by, expires_at, last_used_at, created_at`
+
+func scanAPIKey(row rowScanner) (*models.APIKey, error) {
+	key := &models.APIKey{}
+	var expiresAt, lastUsedAt sql.NullTime
+
+	err := row.Scan(&key.ID, &key.OrganizationID, &key.ProjectID, &key.Environment, &key.Name, &key.KeyHash,
+		&key.Prefix, &key.Permission, &key.SignedRequests, &key.WrappedSigningKey, &key.CreatedBy, &expiresAt,
+		&lastUsedAt, &key.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if expiresAt.Valid {
+		key.ExpiresAt = &expiresAt.Time
+	}
+	if lastUsedAt.Valid {
+		key.LastUsedAt = &lastUsedAt.Time
+	}
+	return key, nil
+}
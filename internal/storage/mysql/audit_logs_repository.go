@@ -0,0 +1,94 @@
+// filepath: internal/storage/mysql/audit_logs_repository.go
+
+/*************************************************************************/
+/*                                                                       */
+/*   Ce fichier implémente le repository MySQL du journal d'audit des    */
+/*   organisations                                                       */
+/*                                                                       */
+/*************************************************************************/
+
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+
+	"secrets-manager/internal/models"
+	repo "secrets-manager/internal/storage"
+)
+
+// AuditLogsRepository gère le journal d'audit dans MySQL
+type AuditLogsRepository struct {
+	db *sql.DB
+}
+
+var _ repo.AuditLogsRepository = (*AuditLogsRepository)(nil)
+
+// NewAuditLogsRepository crée un nouveau repository du journal d'audit
+func NewAuditLogsRepository(db *sql.DB) *AuditLogsRepository {
+	return &AuditLogsRepository{
+		db: db,
+	}
+}
+
+// CreateAuditLogs enregistre un lot d'entrées dans une seule transaction
+func (r *AuditLogsRepository) CreateAuditLogs(ctx context.Context, logs []*models.AuditLog) error {
+	if len(logs) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, log := range logs {
+		if log.ID == "" {
+			log.ID = uuid.New().String()
+		}
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO audit_logs (id, user_id, organization_id, action, resource_type, resource_id,
+				timestamp, ip_address, user_agent, reason)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		`, log.ID, log.UserID, log.OrganizationID, log.Action, log.ResourceType, log.ResourceID,
+			log.Timestamp, log.IPAddress, log.UserAgent, log.Reason)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// ListAuditLogs liste les entrées de l'organisation depuis since, de la plus récente à la plus ancienne
+func (r *AuditLogsRepository) ListAuditLogs(ctx context.Context, orgID string, since time.Time, limit int) ([]*models.AuditLog, error) {
+	query := `
+		SELECT id, user_id, organization_id, action, resource_type, resource_id, timestamp,
+			ip_address, user_agent, reason
+		FROM audit_logs
+		WHERE organization_id = ? AND timestamp >= ?
+		ORDER BY timestamp DESC
+		LIMIT ?
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, orgID, since, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	logs := []*models.AuditLog{}
+	for rows.Next() {
+		log := &models.AuditLog{}
+		err := rows.Scan(&log.ID, &log.UserID, &log.OrganizationID, &log.Action, &log.ResourceType,
+			&log.ResourceID, &log.Timestamp, &log.IPAddress, &log.UserAgent, &log.Reason)
+		if err != nil {
+			return nil, err
+		}
+		logs = append(logs, log)
+	}
+	return logs, rows.Err()
+}
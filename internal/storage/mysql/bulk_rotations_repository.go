@@ -0,0 +1,224 @@
+// filepath: internal/storage/mysql/bulk_rotations_repository.go
+
+/*************************************************************************/
+/*                                                                       */
+/*   Ce fichier implémente le repository MySQL des rotations groupées    */
+/*   Il suit l'avancement de la rotation de chaque secret                */
+/*                                                                       */
+/*************************************************************************/
+
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"secrets-manager/internal/models"
+	repo "secrets-manager/internal/storage"
+)
+
+// BulkRotationsRepository gère les rotations groupées dans MySQL
+type BulkRotationsRepository struct {
+	db *sql.DB
+}
+
+var _ repo.BulkRotationsRepository = (*BulkRotationsRepository)(nil)
+
+// NewBulkRotationsRepository crée un nouveau repository de rotations groupées
+func NewBulkRotationsRepository(db *sql.DB) *BulkRotationsRepository {
+	return &BulkRotationsRepository{
+		db: db,
+	}
+}
+
+// CreateBulkRotation enregistre une rotation groupée et ses secrets dans une transaction
+func (r *BulkRotationsRepository) CreateBulkRotation(ctx context.Context, rotation *models.BulkRotation) error {
+	if rotation.ID == "" {
+		rotation.ID = uuid.New().String()
+	}
+	rotation.CreatedAt = time.Now()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO bulk_rotations (id, organization_id, path, provider, requested_by, status, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`, rotation.ID, rotation.OrganizationID, rotation.Path, rotation.Provider, rotation.RequestedBy,
+		rotation.Status, rotation.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range rotation.Items {
+		if item.ID == "" {
+			item.ID = uuid.New().String()
+		}
+		item.RotationID = rotation.ID
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO bulk_rotation_items (
+				id, rotation_id, project_id, environment, secret_name,
+				rotator_id, status, error, completed_at
+			) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		`, item.ID, item.RotationID, item.ProjectID, item.Environment, item.SecretName,
+			item.RotatorID, item.Status, item.Error, item.CompletedAt)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetBulkRotation récupère une rotation groupée et ses secrets
+func (r *BulkRotationsRepository) GetBulkRotation(ctx context.Context, orgID, id string) (*models.BulkRotation, error) {
+	query := `
+		SELECT ` + bulkRotationColumns + `
+		FROM bulk_rotations br
+		WHERE br.id = ? AND br.organization_id = ?
+	`
+
+	rotation, err := scanBulkRotation(r.db.QueryRowContext(ctx, query, id, orgID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repo.ErrBulkRotationNotFound
+		}
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, rotation_id, project_id, environment, secret_name,
+			   rotator_id, status, error, completed_at
+		FROM bulk_rotation_items
+		WHERE rotation_id = ?
+		ORDER BY project_id, environment, secret_name
+	`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rotation.Items = []*models.BulkRotationItem{}
+	for rows.Next() {
+		item := &models.BulkRotationItem{}
+		var completedAt sql.NullTime
+		err := rows.Scan(
+			&item.ID,
+			&item.RotationID,
+			&item.ProjectID,
+			&item.Environment,
+			&item.SecretName,
+			&item.RotatorID,
+			&item.Status,
+			&item.Error,
+			&completedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if completedAt.Valid {
+			item.CompletedAt = &completedAt.Time
+		}
+		rotation.Items = append(rotation.Items, item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return rotation, nil
+}
+
+// ListUnfinishedBulkRotations liste les rotations groupées en attente ou interrompues
+func (r *BulkRotationsRepository) ListUnfinishedBulkRotations(ctx context.Context) ([]*models.BulkRotation, error) {
+	query := `
+		SELECT ` + bulkRotationColumns + `
+		FROM bulk_rotations br
+		WHERE br.status <> ?
+		ORDER BY br.created_at
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, models.BulkRotationCompleted)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rotations := []*models.BulkRotation{}
+	for rows.Next() {
+		rotation, err := scanBulkRotation(rows)
+		if err != nil {
+			return nil, err
+		}
+		rotations = append(rotations, rotation)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return rotations, nil
+}
+
+// UpdateBulkRotationItem enregistre le résultat de la rotation d'un secret
+func (r *BulkRotationsRepository) UpdateBulkRotationItem(ctx context.Context, item *models.BulkRotationItem) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE bulk_rotation_items
+		SET status = ?, error = ?, completed_at = ?
+		WHERE id = ? AND rotation_id = ?
+	`, item.Status, item.Error, item.CompletedAt, item.ID, item.RotationID)
+	return err
+}
+
+// SetBulkRotationStatus enregistre l'état d'une rotation groupée
+func (r *BulkRotationsRepository) SetBulkRotationStatus(ctx context.Context, id, status string, completedAt *time.Time) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE bulk_rotations
+		SET status = ?, completed_at = ?
+		WHERE id = ?
+	`, status, completedAt, id)
+	return err
+}
+
+// Colonnes lues par scanBulkRotation, dans le même ordre (bulk_rotations br)
+const bulkRotationColumns = `br.id, br.organization_id, br.path, br.provider, br.requested_by, br.status,
+			   br.created_at, br.completed_at,
+			   (SELECT COUNT(*) FROM bulk_rotation_items i WHERE i.rotation_id = br.id),
+			   (SELECT COUNT(*) FROM bulk_rotation_items i WHERE i.rotation_id = br.id AND i.status = 'pending'),
+			   (SELECT COUNT(*) FROM bulk_rotation_items i WHERE i.rotation_id = br.id AND i.status = 'failed')`
+
+// scanBulkRotation lit une ligne sélectionnée avec bulkRotationColumns
+func scanBulkRotation(row rowScanner) (*models.BulkRotation, error) {
+	rotation := &models.BulkRotation{}
+	var completedAt sql.NullTime
+
+	err := row.Scan(
+		&rotation.ID,
+		&rotation.OrganizationID,
+		&rotation.Path,
+		&rotation.Provider,
+		&rotation.RequestedBy,
+		&rotation.Status,
+		&rotation.CreatedAt,
+		&completedAt,
+		&rotation.TotalItems,
+		&rotation.PendingItems,
+		&rotation.FailedItems,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if completedAt.Valid {
+		rotation.CompletedAt = &completedAt.Time
+	}
+
+	return rotation, nil
+}
@@ -0,0 +1,120 @@
+// filepath: internal/storage/mysql/confirmations_repository.go
+
+/*************************************************************************/
+/*                                                                       */
+/*   Ce fichier implémente le repository MySQL des confirmations         */
+/*   Il conserve les demandes d'opérations destructives en attente       */
+/*                                                                       */
+/*************************************************************************/
+
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"secrets-manager/internal/models"
+	repo "secrets-manager/internal/storage"
+)
+
+// ConfirmationsRepository gère les confirmations d'opérations destructives dans MySQL
+type ConfirmationsRepository struct {
+	db *sql.DB
+}
+
+var _ repo.ConfirmationsRepository = (*ConfirmationsRepository)(nil)
+
+// NewConfirmationsRepository crée un nouveau repository de confirmations
+func NewConfirmationsRepository(db *sql.DB) *ConfirmationsRepository {
+	return &ConfirmationsRepository{
+		db: db,
+	}
+}
+
+// CreateConfirmation enregistre une demande de confirmation
+func (r *ConfirmationsRepository) CreateConfirmation(ctx context.Context, confirmation *models.DeletionConfirmation) error {
+	if confirmation.ID == "" {
+		confirmation.ID = uuid.New().String()
+	}
+
+	query := `
+		INSERT INTO deletion_confirmations (
+			id, token_hash, organization_id, action, target,
+			requested_by, expires_at, created_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, NOW())
+	`
+
+	_, err := r.db.ExecContext(
+		ctx,
+		query,
+		confirmation.ID,
+		confirmation.TokenHash,
+		confirmation.OrganizationID,
+		confirmation.Action,
+		confirmation.Target,
+		confirmation.RequestedBy,
+		confirmation.ExpiresAt,
+	)
+
+	return err
+}
+
+// ConsumeConfirmation renvoie et supprime la confirmation correspondant à l'empreinte.
+// La suppression dans la même transaction garantit un usage unique du token.
+func (r *ConfirmationsRepository) ConsumeConfirmation(ctx context.Context, tokenHash string) (*models.DeletionConfirmation, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	query := `
+		SELECT id, token_hash, organization_id, action, target,
+			   requested_by, expires_at, created_at
+		FROM deletion_confirmations
+		WHERE token_hash = ?
+		FOR UPDATE
+	`
+
+	confirmation := &models.DeletionConfirmation{}
+	err = tx.QueryRowContext(ctx, query, tokenHash).Scan(
+		&confirmation.ID,
+		&confirmation.TokenHash,
+		&confirmation.OrganizationID,
+		&confirmation.Action,
+		&confirmation.Target,
+		&confirmation.RequestedBy,
+		&confirmation.ExpiresAt,
+		&confirmation.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repo.ErrConfirmationNotFound
+		}
+		return nil, err
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM deletion_confirmations WHERE id = ?", confirmation.ID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return confirmation, nil
+}
+
+// PurgeExpiredConfirmations supprime les confirmations expirées
+func (r *ConfirmationsRepository) PurgeExpiredConfirmations(ctx context.Context, now time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM deletion_confirmations WHERE expires_at < ?", now)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
@@ -7,8 +7,9 @@ import (
 	"time"
 
 	"secrets-manager/internal/config"
+	"secrets-manager/internal/logging"
 
-	_ "github.com/go-sql-driver/mysql"
+	"github.com/go-sql-driver/mysql"
 )
 
 // NewConnection établit une nouvelle connexion à la base de données MySQL
@@ -16,11 +17,18 @@ func NewConnection(cfg config.DatabaseConfig) (*sql.DB, error) {
 	dsn := fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?parseTime=true",
 		cfg.User, cfg.Password, cfg.Host, cfg.Port, cfg.DBName)
 
-	db, err := sql.Open("mysql", dsn)
+	mysqlCfg, err := mysql.ParseDSN(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("erreur d'ouverture de la connexion: %w", err)
+	}
+	connector, err := mysql.NewConnector(mysqlCfg)
 	if err != nil {
 		return nil, fmt.Errorf("erreur d'ouverture de la connexion: %w", err)
 	}
 
+	// Chronométrer chaque requête pour journaliser les requêtes lentes
+	db := sql.OpenDB(&timedConnector{Connector: connector, threshold: cfg.SlowQueryThreshold})
+
 	// Configurer le pool de connexions
 	db.SetMaxOpenConns(25)
 	db.SetMaxIdleConns(25)
@@ -32,5 +40,8 @@ func NewConnection(cfg config.DatabaseConfig) (*sql.DB, error) {
 		return nil, fmt.Errorf("erreur de ping à la base de données: %w", err)
 	}
 
+	logging.For(logging.ComponentStorage).Info("connexion MySQL établie",
+		"host", cfg.Host, "port", cfg.Port, "database", cfg.DBName)
+
 	return db, nil
 }
@@ -0,0 +1,231 @@
+// filepath: internal/storage/mysql/custom_roles_repository.go
+
+/*************************************************************************/
+/*                                                                       */
+/*   Ce fichier implémente le repository MySQL des rôles personnalisés   */
+/*   des organisations et de leur attribution aux membres                */
+/*                                                                       */
+/*************************************************************************/
+
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"secrets-manager/internal/models"
+	repo "secrets-manager/internal/storage"
+)
+
+// CustomRolesRepository gère les rôles personnalisés dans MySQL
+type CustomRolesRepository struct {
+	db *sql.DB
+}
+
+var _ repo.CustomRolesRepository = (*CustomRolesRepository)(nil)
+
+// NewCustomRolesRepository crée un nouveau repository de rôles personnalisés
+func NewCustomRolesRepository(db *sql.DB) *CustomRolesRepository {
+	return &CustomRolesRepository{
+		db: db,
+	}
+}
+
+// CreateCustomRole enregistre un rôle personnalisé
+func (r *CustomRolesRepository) CreateCustomRole(ctx context.Context, role *models.CustomRole) error {
+	if role.ID == "" {
+		role.ID = uuid.New().String()
+	}
+	role.CreatedAt = time.Now()
+	role.UpdatedAt = role.CreatedAt
+	permissions, err := json.Marshal(role.Permissions)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO custom_roles (id, organization_id, name, description, permissions, created_by,
+			created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err = r.db.ExecContext(ctx, query, role.ID, role.OrganizationID, role.Name, role.Description,
+		string(permissions), role.CreatedBy, role.CreatedAt, role.UpdatedAt)
+	if isDuplicateEntry(err) {
+		return repo.ErrCustomRoleExists
+	}
+	return err
+}
+
+// GetCustomRole récupère un rôle de l'organisation
+func (r *CustomRolesRepository) GetCustomRole(ctx context.Context, orgID, id string) (*models.CustomRole, error) {
+	query := `
+		SELECT ` + customRoleColumns + `
+		FROM custom_roles
+		WHERE id = ? AND organization_id = ?
+	`
+
+	role, err := scanCustomRole(r.db.QueryRowContext(ctx, query, id, orgID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repo.ErrCustomRoleNotFound
+	}
+	return role, err
+}
+
+// ListCustomRoles liste les rôles de l'organisation par nom
+func (r *CustomRolesRepository) ListCustomRoles(ctx context.Context, orgID string) ([]*models.CustomRole, error) {
+	query := `
+		SELECT ` + customRoleColumns + `
+		FROM custom_roles
+		WHERE organization_id = ?
+		ORDER BY name
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, orgID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	roles := []*models.CustomRole{}
+	for rows.Next() {
+		role, err := scanCustomRole(rows)
+		if err != nil {
+			return nil, err
+		}
+		roles = append(roles, role)
+	}
+	return roles, rows.Err()
+}
+
+// UpdateCustomRole remplace le nom, la description et les permissions d'un rôle
+func (r *CustomRolesRepository) UpdateCustomRole(ctx context.Context, role *models.CustomRole) error {
+	existing, err := r.GetCustomRole(ctx, role.OrganizationID, role.ID)
+	if err != nil {
+		return err
+	}
+	role.CreatedBy = existing.CreatedBy
+	role.CreatedAt = existing.CreatedAt
+	role.UpdatedAt = time.Now()
+	permissions, err := json.Marshal(role.Permissions)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE custom_roles SET name = ?, description = ?, permissions = ?, updated_at = ?
+		WHERE id = ? AND organization_id = ?
+	`
+
+	_, err = r.db.ExecContext(ctx, query, role.Name, role.Description, string(permissions), role.UpdatedAt,
+		role.ID, role.OrganizationID)
+	if isDuplicateEntry(err) {
+		return repo.ErrCustomRoleExists
+	}
+	return err
+}
+
+// DeleteCustomRole supprime un rôle et ses attributions
+func (r *CustomRolesRepository) DeleteCustomRole(ctx context.Context, orgID, id string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, "DELETE FROM custom_roles WHERE id = ? AND organization_id = ?", id, orgID)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return repo.ErrCustomRoleNotFound
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM custom_role_assignments WHERE role_id = ?", id); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// AssignCustomRole attribue un rôle à un membre
+func (r *CustomRolesRepository) AssignCustomRole(ctx context.Context, assignment *models.CustomRoleAssignment) error {
+	assignment.AssignedAt = time.Now()
+
+	query := `
+		INSERT INTO custom_role_assignments (organization_id, user_id, role_id, assigned_by, assigned_at)
+		SELECT organization_id, ?, id, ?, ? FROM custom_roles WHERE id = ? AND organization_id = ?
+		ON DUPLICATE KEY UPDATE role_id = VALUES(role_id), assigned_by = VALUES(assigned_by),
+			assigned_at = VALUES(assigned_at)
+	`
+
+	result, err := r.db.ExecContext(ctx, query, assignment.UserID, assignment.AssignedBy, assignment.AssignedAt,
+		assignment.RoleID, assignment.OrganizationID)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		// Aucune ligne insérée ni modifiée : le rôle n'existe pas, ou
+		// l'attribution est inchangée
+		if _, err := r.GetCustomRole(ctx, assignment.OrganizationID, assignment.RoleID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UnassignCustomRole retire son rôle personnalisé à un membre
+func (r *CustomRolesRepository) UnassignCustomRole(ctx context.Context, orgID, userID string) error {
+	_, err := r.db.ExecContext(ctx, `
+		DELETE FROM custom_role_assignments WHERE organization_id = ? AND user_id = ?
+	`, orgID, userID)
+	return err
+}
+
+// GetMemberCustomRole renvoie le rôle personnalisé d'un membre
+func (r *CustomRolesRepository) GetMemberCustomRole(ctx context.Context, orgID, userID string) (*models.CustomRole, error) {
+	query := `
+		SELECT ` + customRoleColumns + `
+		FROM custom_roles
+		WHERE id = (
+			SELECT role_id FROM custom_role_assignments WHERE organization_id = ? AND user_id = ?
+		)
+	`
+
+	role, err := scanCustomRole(r.db.QueryRowContext(ctx, query, orgID, userID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repo.ErrCustomRoleNotFound
+	}
+	return role, err
+}
+
+// Colonnes lues par scanCustomRole, dans le même ordre
+const customRoleColumns = `id, organization_id, name, description, permissions, created_by, created_at, updated_at`
+
+// scanCustomRole lit une ligne sélectionnée avec customRoleColumns
+func scanCustomRole(row rowScanner) (*models.CustomRole, error) {
+	role := &models.CustomRole{}
+	var permissions []byte
+
+	err := row.Scan(&role.ID, &role.OrganizationID, &role.Name, &role.Description, &permissions, &role.CreatedBy,
+		&role.CreatedAt, &role.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(permissions, &role.Permissions); err != nil {
+		return nil, err
+	}
+	return role, nil
+}
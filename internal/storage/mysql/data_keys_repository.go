@@ -0,0 +1,173 @@
+// filepath: internal/storage/mysql/data_keys_repository.go
+
+/*************************************************************************/
+/*                                                                       */
+/*   Ce fichier implémente le repository MySQL des clés de données des   */
+/*   projets et de l'audit de leurs opérations                           */
+/*                                                                       */
+/*************************************************************************/
+
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"secrets-manager/internal/models"
+	repo "secrets-manager/internal/storage"
+)
+
+// DataKeysRepository gère les clés de données dans MySQL
+type DataKeysRepository struct {
+	db *sql.DB
+}
+
+var _ repo.DataKeysRepository = (*DataKeysRepository)(nil)
+
+// NewDataKeysRepository crée un nouveau repository de clés de données
+func NewDataKeysRepository(db *sql.DB) *DataKeysRepository {
+	return &DataKeysRepository{
+		db: db,
+	}
+}
+
+// CreateDataKey enregistre une clé de données
+func (r *DataKeysRepository) CreateDataKey(ctx context.Context, key *models.DataKey) error {
+	if key.ID == "" {
+		key.ID = uuid.New().String()
+	}
+	key.CreatedAt = time.Now()
+
+	query := `
+		INSERT INTO data_keys (id, organization_id, project_id, name, transit_key, created_by, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := r.db.ExecContext(ctx, query, key.ID, key.OrganizationID, key.ProjectID, key.Name,
+		key.TransitKey, key.CreatedBy, key.CreatedAt)
+	if isDuplicateEntry(err) {
+		return repo.ErrDataKeyExists
+	}
+	return err
+}
+
+// GetDataKey renvoie une clé du projet par son nom
+func (r *DataKeysRepository) GetDataKey(ctx context.Context, orgID, projectID, name string) (*models.DataKey, error) {
+	query := `
+		SELECT ` + dataKeyColumns + `
+		FROM data_keys
+		WHERE organization_id = ? AND project_id = ? AND name = ?
+	`
+
+	key, err := scanDataKey(r.db.QueryRowContext(ctx, query, orgID, projectID, name))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repo.ErrDataKeyNotFound
+	}
+	return key, err
+}
+
+// ListDataKeys liste les clés du projet par nom
+func (r *DataKeysRepository) ListDataKeys(ctx context.Context, orgID, projectID string) ([]*models.DataKey, error) {
+	query := `
+		SELECT ` + dataKeyColumns + `
+		FROM data_keys
+		WHERE organization_id = ? AND project_id = ?
+		ORDER BY name
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, orgID, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys := []*models.DataKey{}
+	for rows.Next() {
+		key, err := scanDataKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, rows.Err()
+}
+
+// RecordDataKeyOperation enregistre un chiffrement ou un déchiffrement
+func (r *DataKeysRepository) RecordDataKeyOperation(ctx context.Context, operation *models.DataKeyOperation) error {
+	if operation.ID == "" {
+		operation.ID = uuid.New().String()
+	}
+
+	query := `
+		INSERT INTO data_key_operations (id, data_key_id, organization_id, operation, actor_id, size,
+			ip_address, occurred_at, error)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := r.db.ExecContext(ctx, query, operation.ID, operation.DataKeyID, operation.OrganizationID,
+		operation.Operation, operation.ActorID, operation.Size, operation.IPAddress, operation.OccurredAt,
+		operation.Error)
+	return err
+}
+
+// ListDataKeyOperations liste les dernières opérations d'une clé, de la plus récente à la plus ancienne
+func (r *DataKeysRepository) ListDataKeyOperations(ctx context.Context, dataKeyID string, limit int) ([]*models.DataKeyOperation, error) {
+	query := `
+		SELECT id, data_key_id, organization_id, operation, actor_id, size, ip_address, occurred_at, error
+		FROM data_key_operations
+		WHERE data_key_id = ?
+		ORDER BY occurred_at DESC
+		LIMIT ?
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, dataKeyID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	operations := []*models.DataKeyOperation{}
+	for rows.Next() {
+		operation := &models.DataKeyOperation{}
+		err := rows.Scan(
+			&operation.ID,
+			&operation.DataKeyID,
+			&operation.OrganizationID,
+			&operation.Operation,
+			&operation.ActorID,
+			&operation.Size,
+			&operation.IPAddress,
+			&operation.OccurredAt,
+			&operation.Error,
+		)
+		if err != nil {
+			return nil, err
+		}
+		operations = append(operations, operation)
+	}
+	return operations, rows.Err()
+}
+
+// Colonnes lues par scanDataKey, dans le même ordre
+const dataKeyColumns = `id, organization_id, project_id, name, transit_key, created_by, created_at`
+
+func scanDataKey(row rowScanner) (*models.DataKey, error) {
+	key := &models.DataKey{}
+	err := row.Scan(
+		&key.ID,
+		&key.OrganizationID,
+		&key.ProjectID,
+		&key.Name,
+		&key.TransitKey,
+		&key.CreatedBy,
+		&key.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return key, nil
+}
@@ -0,0 +1,167 @@
+// filepath: internal/storage/mysql/device_authorizations_repository.go
+
+/*************************************************************************/
+/*                                                                       */
+/*   Ce fichier implémente le repository MySQL des autorisations         */
+/*   d'appareils (flux device code de la CLI)                            */
+/*                                                                       */
+/*************************************************************************/
+
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"secrets-manager/internal/models"
+	repo "secrets-manager/internal/storage"
+)
+
+// DeviceAuthorizationsRepository gère les autorisations d'appareils dans MySQL
+type DeviceAuthorizationsRepository struct {
+	db *sql.DB
+}
+
+var _ repo.DeviceAuthorizationsRepository = (*DeviceAuthorizationsRepository)(nil)
+
+// NewDeviceAuthorizationsRepository crée un nouveau repository d'autorisations d'appareils
+func NewDeviceAuthorizationsRepository(db *sql.DB) *DeviceAuthorizationsRepository {
+	return &DeviceAuthorizationsRepository{
+		db: db,
+	}
+}
+
+// CreateDeviceAuthorization enregistre une demande de connexion d'un appareil
+func (r *DeviceAuthorizationsRepository) CreateDeviceAuthorization(ctx context.Context, authorization *models.DeviceAuthorization) error {
+	if authorization.ID == "" {
+		authorization.ID = uuid.New().String()
+	}
+
+	query := `
+		INSERT INTO device_authorizations (
+			id, device_code_hash, user_code, client_name, status,
+			user_id, expires_at, created_at
+		) VALUES (?, ?, ?, ?, ?, '', ?, NOW())
+	`
+
+	_, err := r.db.ExecContext(
+		ctx,
+		query,
+		authorization.ID,
+		authorization.DeviceCodeHash,
+		authorization.UserCode,
+		authorization.ClientName,
+		authorization.Status,
+		authorization.ExpiresAt,
+	)
+	if isDuplicateEntry(err) {
+		return repo.ErrAlreadyExists
+	}
+
+	return err
+}
+
+// PollDeviceAuthorization renvoie la demande et enregistre la date d'interrogation
+func (r *DeviceAuthorizationsRepository) PollDeviceAuthorization(ctx context.Context, deviceCodeHash string, now time.Time) (*models.DeviceAuthorization, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	query := `
+		SELECT id, device_code_hash, user_code, client_name, status,
+			   user_id, last_polled_at, expires_at, created_at
+		FROM device_authorizations
+		WHERE device_code_hash = ?
+		FOR UPDATE
+	`
+
+	authorization := &models.DeviceAuthorization{}
+	var lastPolledAt sql.NullTime
+	err = tx.QueryRowContext(ctx, query, deviceCodeHash).Scan(
+		&authorization.ID,
+		&authorization.DeviceCodeHash,
+		&authorization.UserCode,
+		&authorization.ClientName,
+		&authorization.Status,
+		&authorization.UserID,
+		&lastPolledAt,
+		&authorization.ExpiresAt,
+		&authorization.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repo.ErrDeviceCodeNotFound
+		}
+		return nil, err
+	}
+	if lastPolledAt.Valid {
+		authorization.LastPolledAt = &lastPolledAt.Time
+	}
+
+	if _, err := tx.ExecContext(ctx, "UPDATE device_authorizations SET last_polled_at = ? WHERE id = ?",
+		now, authorization.ID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return authorization, nil
+}
+
+// DecideDeviceAuthorization approuve ou refuse une demande en attente non expirée
+func (r *DeviceAuthorizationsRepository) DecideDeviceAuthorization(ctx context.Context, userCode, status, userID string, now time.Time) error {
+	query := `
+		UPDATE device_authorizations
+		SET status = ?, user_id = ?
+		WHERE user_code = ? AND status = ? AND expires_at > ?
+	`
+
+	result, err := r.db.ExecContext(ctx, query, status, userID, userCode, models.DeviceAuthorizationPending, now)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return repo.ErrDeviceCodeNotFound
+	}
+
+	return nil
+}
+
+// DeleteDeviceAuthorization supprime une demande
+func (r *DeviceAuthorizationsRepository) DeleteDeviceAuthorization(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM device_authorizations WHERE id = ?", id)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return repo.ErrDeviceCodeNotFound
+	}
+
+	return nil
+}
+
+// PurgeExpiredDeviceAuthorizations supprime les demandes expirées
+func (r *DeviceAuthorizationsRepository) PurgeExpiredDeviceAuthorizations(ctx context.Context, now time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM device_authorizations WHERE expires_at < ?", now)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
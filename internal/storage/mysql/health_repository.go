@@ -0,0 +1,146 @@
+// filepath: internal/storage/mysql/health_repository.go
+
+/*************************************************************************/
+/*                                                                       */
+/*   Ce fichier implémente le repository MySQL de l'historique de santé  */
+/*   des composants : vérifications agrégées par jour et incidents       */
+/*                                                                       */
+/*************************************************************************/
+
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+
+	"secrets-manager/internal/models"
+	repo "secrets-manager/internal/storage"
+)
+
+// HealthRepository gère l'historique de santé dans MySQL
+type HealthRepository struct {
+	db *sql.DB
+}
+
+var _ repo.HealthRepository = (*HealthRepository)(nil)
+
+// NewHealthRepository crée un nouveau repository de l'historique de santé
+func NewHealthRepository(db *sql.DB) *HealthRepository {
+	return &HealthRepository{
+		db: db,
+	}
+}
+
+// RecordHealthCheck comptabilise une vérification d'un composant dans son jour (UTC)
+func (r *HealthRepository) RecordHealthCheck(ctx context.Context, component string, at time.Time, healthy bool) error {
+	failures := 0
+	if !healthy {
+		failures = 1
+	}
+
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO health_checks (component, day, checks, failures)
+		VALUES (?, ?, 1, ?)
+		ON DUPLICATE KEY UPDATE checks = checks + 1, failures = failures + VALUES(failures)
+	`, component, at.UTC().Format("2006-01-02"), failures)
+	return err
+}
+
+// ListHealthCheckDays liste les agrégats journaliers depuis le jour de since
+func (r *HealthRepository) ListHealthCheckDays(ctx context.Context, since time.Time) ([]*models.HealthCheckDay, error) {
+	query := `
+		SELECT component, DATE_FORMAT(day, '%Y-%m-%d'), checks, failures
+		FROM health_checks
+		WHERE day >= ?
+		ORDER BY component, day
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, since.UTC().Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	days := []*models.HealthCheckDay{}
+	for rows.Next() {
+		day := &models.HealthCheckDay{}
+		if err := rows.Scan(&day.Component, &day.Day, &day.Checks, &day.Failures); err != nil {
+			return nil, err
+		}
+		days = append(days, day)
+	}
+	return days, rows.Err()
+}
+
+// PurgeHealthChecks supprime les agrégats des jours antérieurs à before
+func (r *HealthRepository) PurgeHealthChecks(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx, `
+		DELETE FROM health_checks WHERE day < ?
+	`, before.UTC().Format("2006-01-02"))
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// OpenHealthIncident ouvre un incident, sauf si le composant en a déjà un en cours
+func (r *HealthRepository) OpenHealthIncident(ctx context.Context, incident *models.HealthIncident) error {
+	if incident.ID == "" {
+		incident.ID = uuid.New().String()
+	}
+	incident.ResolvedAt = nil
+
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO health_incidents (id, component, open_component, started_at)
+		VALUES (?, ?, ?, ?)
+	`, incident.ID, incident.Component, incident.Component, incident.StartedAt)
+	if isDuplicateEntry(err) {
+		return repo.ErrIncidentOpen
+	}
+	return err
+}
+
+// ResolveHealthIncidents résout les incidents en cours du composant
+func (r *HealthRepository) ResolveHealthIncidents(ctx context.Context, component string, at time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE health_incidents SET open_component = NULL, resolved_at = ?
+		WHERE open_component = ?
+	`, at, component)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// ListHealthIncidents liste les incidents en cours et ceux résolus depuis since
+func (r *HealthRepository) ListHealthIncidents(ctx context.Context, since time.Time) ([]*models.HealthIncident, error) {
+	query := `
+		SELECT id, component, started_at, resolved_at
+		FROM health_incidents
+		WHERE resolved_at IS NULL OR resolved_at >= ?
+		ORDER BY started_at DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	incidents := []*models.HealthIncident{}
+	for rows.Next() {
+		incident := &models.HealthIncident{}
+		var resolvedAt sql.NullTime
+		if err := rows.Scan(&incident.ID, &incident.Component, &incident.StartedAt, &resolvedAt); err != nil {
+			return nil, err
+		}
+		if resolvedAt.Valid {
+			incident.ResolvedAt = &resolvedAt.Time
+		}
+		incidents = append(incidents, incident)
+	}
+	return incidents, rows.Err()
+}
@@ -0,0 +1,128 @@
+// filepath: internal/storage/mysql/impersonations_repository.go
+
+/*************************************************************************/
+/*                                                                       */
+/*   Ce fichier implémente le repository MySQL des usurpations           */
+/*   d'identité par le support (tokens act_as de courte durée)           */
+/*                                                                       */
+/*************************************************************************/
+
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"secrets-manager/internal/models"
+	repo "secrets-manager/internal/storage"
+)
+
+// ImpersonationsRepository gère les usurpations d'identité dans MySQL
+type ImpersonationsRepository struct {
+	db *sql.DB
+}
+
+var _ repo.ImpersonationsRepository = (*ImpersonationsRepository)(nil)
+
+// NewImpersonationsRepository crée un nouveau repository d'usurpations d'identité
+func NewImpersonationsRepository(db *sql.DB) *ImpersonationsRepository {
+	return &ImpersonationsRepository{
+		db: db,
+	}
+}
+
+// CreateImpersonation enregistre une usurpation
+func (r *ImpersonationsRepository) CreateImpersonation(ctx context.Context, impersonation *models.Impersonation) error {
+	if impersonation.ID == "" {
+		impersonation.ID = uuid.New().String()
+	}
+
+	query := `
+		INSERT INTO impersonations (id, user_id, operator, principal, reason, created_at, expires_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := r.db.ExecContext(ctx, query, impersonation.ID, impersonation.UserID, impersonation.Operator,
+		impersonation.Principal, impersonation.Reason, impersonation.CreatedAt, impersonation.ExpiresAt)
+	return err
+}
+
+// GetImpersonation récupère une usurpation
+func (r *ImpersonationsRepository) GetImpersonation(ctx context.Context, id string) (*models.Impersonation, error) {
+	query := `
+		SELECT ` + impersonationColumns + `
+		FROM impersonations
+		WHERE id = ?
+	`
+
+	impersonation, err := scanImpersonation(r.db.QueryRowContext(ctx, query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repo.ErrImpersonationNotFound
+	}
+	return impersonation, err
+}
+
+// ListUserImpersonations liste les usurpations du compte d'un utilisateur
+func (r *ImpersonationsRepository) ListUserImpersonations(ctx context.Context, userID string) ([]*models.Impersonation, error) {
+	query := `
+		SELECT ` + impersonationColumns + `
+		FROM impersonations
+		WHERE user_id = ?
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	impersonations := []*models.Impersonation{}
+	for rows.Next() {
+		impersonation, err := scanImpersonation(rows)
+		if err != nil {
+			return nil, err
+		}
+		impersonations = append(impersonations, impersonation)
+	}
+	return impersonations, rows.Err()
+}
+
+// EndImpersonation termine une usurpation en cours
+func (r *ImpersonationsRepository) EndImpersonation(ctx context.Context, id string, at time.Time) error {
+	result, err := r.db.ExecContext(ctx, "UPDATE impersonations SET ended_at = ? WHERE id = ? AND ended_at IS NULL",
+		at, id)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return repo.ErrImpersonationNotFound
+	}
+	return nil
+}
+
+// Colonnes lues par scanImpersonation, dans le même ordre
+const impersonationColumns = `id, user_id, operator, principal, reason, created_at, expires_at, ended_at`
+
+func scanImpersonation(row rowScanner) (*models.Impersonation, error) {
+	impersonation := &models.Impersonation{}
+	var endedAt sql.NullTime
+
+	err := row.Scan(&impersonation.ID, &impersonation.UserID, &impersonation.Operator, &impersonation.Principal,
+		&impersonation.Reason, &impersonation.CreatedAt, &impersonation.ExpiresAt, &endedAt)
+	if err != nil {
+		return nil, err
+	}
+	if endedAt.Valid {
+		impersonation.EndedAt = &endedAt.Time
+	}
+	return impersonation, nil
+}
@@ -0,0 +1,126 @@
+// filepath: internal/storage/mysql/indexes.go
+
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+
+	"secrets-manager/internal/logging"
+)
+
+// Code d'erreur MySQL d'une violation d'index unique
+const errDuplicateEntry = 1062
+
+// Index est un index dont dépendent les requêtes des repositories
+type Index struct {
+	Table   string
+	Columns []string
+	Unique  bool
+}
+
+// String décrit l'index sous la forme table(col1, col2)
+func (i Index) String() string {
+	return i.Table + "(" + strings.Join(i.Columns, ", ") + ")"
+}
+
+// ExpectedIndexes liste les index créés par les migrations. Un index existant
+// les satisfait s'il commence par les mêmes colonnes, quel que soit son nom.
+var ExpectedIndexes = []Index{
+	{Table: "users", Columns: []string{"email"}, Unique: true},
+	{Table: "user_organizations", Columns: []string{"user_id", "organization_id"}, Unique: true},
+	{Table: "user_organizations", Columns: []string{"organization_id"}},
+	{Table: "projects", Columns: []string{"organization_id"}},
+	{Table: "secret_metadata", Columns: []string{"organization_id", "project_id", "environment", "name"}, Unique: true},
+}
+
+// MissingIndexes renvoie les index attendus absents du schéma courant
+func MissingIndexes(ctx context.Context, db *sql.DB) ([]Index, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT table_name, non_unique = 0, GROUP_CONCAT(column_name ORDER BY seq_in_index)
+		FROM information_schema.statistics
+		WHERE table_schema = DATABASE()
+		GROUP BY table_name, index_name, non_unique
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var existing []Index
+	for rows.Next() {
+		var index Index
+		var columns string
+		if err := rows.Scan(&index.Table, &index.Unique, &columns); err != nil {
+			return nil, err
+		}
+		index.Columns = strings.Split(columns, ",")
+		existing = append(existing, index)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return missingIndexes(ExpectedIndexes, existing), nil
+}
+
+// WarnMissingIndexes journalise un avertissement pour chaque index attendu
+// absent. Le serveur démarre tout de même : les requêtes restent correctes
+// mais parcourent des tables entières et l'unicité n'est plus garantie.
+func WarnMissingIndexes(ctx context.Context, db *sql.DB) {
+	logger := logging.For(logging.ComponentStorage)
+
+	missing, err := MissingIndexes(ctx, db)
+	if err != nil {
+		logger.Warn("impossible de vérifier les index", "error", err)
+		return
+	}
+	for _, index := range missing {
+		logger.Warn("index manquant, appliquer les migrations", "index", index.String(), "unique", index.Unique)
+	}
+}
+
+// missingIndexes renvoie les index de expected qu'aucun index de existing ne satisfait
+func missingIndexes(expected, existing []Index) []Index {
+	var missing []Index
+	for _, want := range expected {
+		found := false
+		for _, have := range existing {
+			if satisfies(have, want) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, want)
+		}
+	}
+	return missing
+}
+
+// satisfies indique si l'index have peut servir les requêtes qui attendent
+// want. Un index unique doit porter exactement les mêmes colonnes.
+func satisfies(have, want Index) bool {
+	if !strings.EqualFold(have.Table, want.Table) || len(have.Columns) < len(want.Columns) {
+		return false
+	}
+	if want.Unique && (!have.Unique || len(have.Columns) != len(want.Columns)) {
+		return false
+	}
+	for i, column := range want.Columns {
+		if !strings.EqualFold(have.Columns[i], column) {
+			return false
+		}
+	}
+	return true
+}
+
+// isDuplicateEntry indique si err est une violation d'index unique
+func isDuplicateEntry(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == errDuplicateEntry
+}
@@ -0,0 +1,44 @@
+// filepath: internal/storage/mysql/indexes_test.go
+
+package storage
+
+import "testing"
+
+func TestMissingIndexes(t *testing.T) {
+	expected := []Index{
+		{Table: "users", Columns: []string{"email"}, Unique: true},
+		{Table: "projects", Columns: []string{"organization_id"}},
+	}
+
+	tests := []struct {
+		name     string
+		existing []Index
+		missing  int
+	}{
+		{"aucun index", nil, 2},
+		{"index exacts", []Index{
+			{Table: "users", Columns: []string{"email"}, Unique: true},
+			{Table: "projects", Columns: []string{"organization_id"}},
+		}, 0},
+		{"préfixe d'un index composite", []Index{
+			{Table: "users", Columns: []string{"email"}, Unique: true},
+			{Table: "projects", Columns: []string{"organization_id", "deleted_at", "name"}},
+		}, 0},
+		{"index non unique sur l'email", []Index{
+			{Table: "users", Columns: []string{"email"}},
+			{Table: "projects", Columns: []string{"organization_id"}},
+		}, 1},
+		{"colonnes dans le mauvais ordre", []Index{
+			{Table: "users", Columns: []string{"email"}, Unique: true},
+			{Table: "projects", Columns: []string{"name", "organization_id"}},
+		}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if missing := missingIndexes(expected, tt.existing); len(missing) != tt.missing {
+				t.Errorf("Expected %d missing indexes, got %v", tt.missing, missing)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+// filepath: internal/storage/mysql/instrumented.go
+
+package storage
+
+import (
+	"context"
+	"database/sql/driver"
+	"strings"
+	"time"
+
+	"secrets-manager/internal/logging"
+	"secrets-manager/internal/metrics"
+)
+
+// Longueur maximale du texte SQL journalisé
+const maxLoggedQueryLength = 200
+
+var slowQueriesTotal = metrics.NewCounter("db_slow_queries_total",
+	"Nombre de requêtes SQL ayant dépassé le seuil de latence", "operation")
+
+// timedConnector enveloppe un connecteur pour mesurer chaque requête SQL.
+// Les repositories continuent d'utiliser *sql.DB sans modification.
+type timedConnector struct {
+	driver.Connector
+	threshold time.Duration
+}
+
+func (c *timedConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.Connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &timedConn{Conn: conn, threshold: c.threshold}, nil
+}
+
+// timedConn délègue à la connexion du driver en chronométrant Exec et Query
+type timedConn struct {
+	driver.Conn
+	threshold time.Duration
+}
+
+func (c *timedConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	execer, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+
+	start := time.Now()
+	result, err := execer.ExecContext(ctx, query, args)
+	c.observe(ctx, "exec", query, time.Since(start))
+	return result, err
+}
+
+func (c *timedConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+
+	start := time.Now()
+	rows, err := queryer.QueryContext(ctx, query, args)
+	c.observe(ctx, "query", query, time.Since(start))
+	return rows, err
+}
+
+func (c *timedConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	if preparer, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		return preparer.PrepareContext(ctx, query)
+	}
+	return c.Conn.Prepare(query)
+}
+
+func (c *timedConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if beginner, ok := c.Conn.(driver.ConnBeginTx); ok {
+		return beginner.BeginTx(ctx, opts)
+	}
+	// Repli pour les drivers sans BeginTx
+	return c.Conn.Begin()
+}
+
+func (c *timedConn) Ping(ctx context.Context) error {
+	if pinger, ok := c.Conn.(driver.Pinger); ok {
+		return pinger.Ping(ctx)
+	}
+	return nil
+}
+
+func (c *timedConn) ResetSession(ctx context.Context) error {
+	if resetter, ok := c.Conn.(driver.SessionResetter); ok {
+		return resetter.ResetSession(ctx)
+	}
+	return nil
+}
+
+func (c *timedConn) IsValid() bool {
+	if validator, ok := c.Conn.(driver.Validator); ok {
+		return validator.IsValid()
+	}
+	return true
+}
+
+func (c *timedConn) CheckNamedValue(nv *driver.NamedValue) error {
+	if checker, ok := c.Conn.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(nv)
+	}
+	return driver.ErrSkip
+}
+
+// observe journalise et comptabilise une requête dépassant le seuil
+func (c *timedConn) observe(ctx context.Context, operation, query string, duration time.Duration) {
+	if c.threshold <= 0 || duration < c.threshold {
+		return
+	}
+
+	slowQueriesTotal.Inc(operation)
+
+	args := append(logging.ArgsFromContext(ctx),
+		"operation", operation,
+		"query", truncateQuery(query),
+		"duration", duration,
+		"threshold", c.threshold)
+	logging.For(logging.ComponentStorage).Warn("requête SQL lente", args...)
+}
+
+// truncateQuery compacte les espaces et tronque le texte SQL
+func truncateQuery(query string) string {
+	query = strings.Join(strings.Fields(query), " ")
+	if len(query) > maxLoggedQueryLength {
+		return query[:maxLoggedQueryLength] + "..."
+	}
+	return query
+}
@@ -0,0 +1,184 @@
+// filepath: internal/storage/mysql/invitations_repository.go
+
+/*************************************************************************/
+/*                                                                       */
+/*   Ce fichier implémente le repository MySQL des invitations à         */
+/*   rejoindre les organisations                                         */
+/*                                                                       */
+/*************************************************************************/
+
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"secrets-manager/internal/models"
+	repo "secrets-manager/internal/storage"
+)
+
+// InvitationsRepository gère les invitations dans MySQL
+type InvitationsRepository struct {
+	db *sql.DB
+}
+
+var _ repo.InvitationsRepository = (*InvitationsRepository)(nil)
+
+// NewInvitationsRepository crée un nouveau repository d'invitations
+func NewInvitationsRepository(db *sql.DB) *InvitationsRepository {
+	return &InvitationsRepository{
+		db: db,
+	}
+}
+
+// CreateInvitation enregistre une invitation
+func (r *InvitationsRepository) CreateInvitation(ctx context.Context, invitation *models.Invitation) error {
+	if invitation.ID == "" {
+		invitation.ID = uuid.New().String()
+	}
+	invitation.CreatedAt = time.Now()
+
+	// L'insertion n'a lieu que si aucune invitation de la même adresse
+	// n'est en attente dans l'organisation
+	query := `
+		INSERT INTO invitations (id, organization_id, email, role, token_hash, invited_by, expires_at, created_at)
+		SELECT ?, ?, ?, ?, ?, ?, ?, ? FROM DUAL
+		WHERE NOT EXISTS (
+			SELECT 1 FROM invitations
+			WHERE organization_id = ? AND email = ?
+			  AND accepted_at IS NULL AND revoked_at IS NULL AND expires_at > ?
+		)
+	`
+
+	result, err := r.db.ExecContext(ctx, query, invitation.ID, invitation.OrganizationID, invitation.Email,
+		invitation.Role, invitation.TokenHash, invitation.InvitedBy, invitation.ExpiresAt, invitation.CreatedAt,
+		invitation.OrganizationID, invitation.Email, invitation.CreatedAt)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return repo.ErrInvitationPending
+	}
+	return nil
+}
+
+// GetInvitationByHash récupère l'invitation correspondant à l'empreinte
+func (r *InvitationsRepository) GetInvitationByHash(ctx context.Context, tokenHash string) (*models.Invitation, error) {
+	query := `
+		SELECT ` + invitationColumns + `
+		FROM invitations
+		WHERE token_hash = ?
+	`
+
+	invitation, err := scanInvitation(r.db.QueryRowContext(ctx, query, tokenHash))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repo.ErrInvitationNotFound
+	}
+	return invitation, err
+}
+
+// ListPendingInvitations liste les invitations en attente de l'organisation
+func (r *InvitationsRepository) ListPendingInvitations(ctx context.Context, orgID string, now time.Time) ([]*models.Invitation, error) {
+	query := `
+		SELECT ` + invitationColumns + `
+		FROM invitations
+		WHERE organization_id = ? AND accepted_at IS NULL AND revoked_at IS NULL AND expires_at > ?
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, orgID, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	invitations := []*models.Invitation{}
+	for rows.Next() {
+		invitation, err := scanInvitation(rows)
+		if err != nil {
+			return nil, err
+		}
+		invitations = append(invitations, invitation)
+	}
+	return invitations, rows.Err()
+}
+
+// RevokeInvitation révoque une invitation en attente de l'organisation
+func (r *InvitationsRepository) RevokeInvitation(ctx context.Context, orgID, id string, now time.Time) error {
+	query := `
+		UPDATE invitations SET revoked_at = ?
+		WHERE id = ? AND organization_id = ? AND accepted_at IS NULL AND revoked_at IS NULL AND expires_at > ?
+	`
+	return r.updatePending(ctx, query, now, id, orgID, now)
+}
+
+// AcceptInvitation marque une invitation en attente comme acceptée
+func (r *InvitationsRepository) AcceptInvitation(ctx context.Context, id, userID string, now time.Time) error {
+	query := `
+		UPDATE invitations SET accepted_at = ?, accepted_by = ?
+		WHERE id = ? AND accepted_at IS NULL AND revoked_at IS NULL AND expires_at > ?
+	`
+	return r.updatePending(ctx, query, now, userID, id, now)
+}
+
+// HasPendingInvitation indique si l'adresse est invitée dans une organisation
+func (r *InvitationsRepository) HasPendingInvitation(ctx context.Context, email string, now time.Time) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM invitations
+			WHERE email = ? AND accepted_at IS NULL AND revoked_at IS NULL AND expires_at > ?
+		)
+	`
+
+	var pending bool
+	err := r.db.QueryRowContext(ctx, query, email, now).Scan(&pending)
+	return pending, err
+}
+
+// updatePending exécute une modification d'une invitation en attente
+// (ErrInvitationNotFound si aucune ligne n'est modifiée)
+func (r *InvitationsRepository) updatePending(ctx context.Context, query string, args ...any) error {
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return repo.ErrInvitationNotFound
+	}
+	return nil
+}
+
+// Colonnes lues par scanInvitation, dans le même ordre
+const invitationColumns = `id, organization_id, email, role, token_hash, invited_by, expires_at,
+	accepted_at, accepted_by, revoked_at, created_at`
+
+func scanInvitation(row rowScanner) (*models.Invitation, error) {
+	invitation := &models.Invitation{}
+	var acceptedAt, revokedAt sql.NullTime
+
+	err := row.Scan(&invitation.ID, &invitation.OrganizationID, &invitation.Email, &invitation.Role,
+		&invitation.TokenHash, &invitation.InvitedBy, &invitation.ExpiresAt, &acceptedAt, &invitation.AcceptedBy,
+		&revokedAt, &invitation.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if acceptedAt.Valid {
+		invitation.AcceptedAt = &acceptedAt.Time
+	}
+	if revokedAt.Valid {
+		invitation.RevokedAt = &revokedAt.Time
+	}
+	return invitation, nil
+}
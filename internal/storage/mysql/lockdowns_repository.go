@@ -0,0 +1,129 @@
+// filepath: internal/storage/mysql/lockdowns_repository.go
+
+/*************************************************************************/
+/*                                                                       */
+/*   Ce fichier implémente le repository MySQL des confinements des      */
+/*   organisations compromises                                           */
+/*                                                                       */
+/*************************************************************************/
+
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"secrets-manager/internal/models"
+	repo "secrets-manager/internal/storage"
+)
+
+// LockdownsRepository gère les confinements dans MySQL
+type LockdownsRepository struct {
+	db *sql.DB
+}
+
+var _ repo.LockdownsRepository = (*LockdownsRepository)(nil)
+
+// NewLockdownsRepository crée un nouveau repository de confinements
+func NewLockdownsRepository(db *sql.DB) *LockdownsRepository {
+	return &LockdownsRepository{
+		db: db,
+	}
+}
+
+// CreateLockdown enregistre un confinement. La ligne de l'organisation est
+// verrouillée pour que deux déclenchements simultanés n'en créent pas deux.
+func (r *LockdownsRepository) CreateLockdown(ctx context.Context, lockdown *models.Lockdown) error {
+	if lockdown.ID == "" {
+		lockdown.ID = uuid.New().String()
+	}
+	if lockdown.StartedAt.IsZero() {
+		lockdown.StartedAt = time.Now()
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var orgID string
+	err = tx.QueryRowContext(ctx, "SELECT id FROM organizations WHERE id = ? FOR UPDATE", lockdown.OrganizationID).Scan(&orgID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return repo.ErrOrganizationNotFound
+	}
+	if err != nil {
+		return err
+	}
+	var active bool
+	err = tx.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM lockdowns WHERE organization_id = ? AND lifted_at IS NULL)",
+		lockdown.OrganizationID).Scan(&active)
+	if err != nil {
+		return err
+	}
+	if active {
+		return repo.ErrLockdownActive
+	}
+
+	query := `
+		INSERT INTO lockdowns (id, organization_id, reason, report_days, affected_users, revoked_tokens, started_by, started_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	_, err = tx.ExecContext(ctx, query, lockdown.ID, lockdown.OrganizationID, lockdown.Reason, lockdown.ReportDays,
+		lockdown.AffectedUsers, lockdown.RevokedTokens, lockdown.StartedBy, lockdown.StartedAt)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// GetActiveLockdown renvoie le confinement en cours de l'organisation
+func (r *LockdownsRepository) GetActiveLockdown(ctx context.Context, orgID string) (*models.Lockdown, error) {
+	query := `
+		SELECT id, organization_id, reason, report_days, affected_users, revoked_tokens, started_by, started_at,
+			lifted_by, lifted_at
+		FROM lockdowns
+		WHERE organization_id = ? AND lifted_at IS NULL
+	`
+
+	lockdown := &models.Lockdown{}
+	err := r.db.QueryRowContext(ctx, query, orgID).Scan(&lockdown.ID, &lockdown.OrganizationID, &lockdown.Reason,
+		&lockdown.ReportDays, &lockdown.AffectedUsers, &lockdown.RevokedTokens, &lockdown.StartedBy,
+		&lockdown.StartedAt, &lockdown.LiftedBy, &lockdown.LiftedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repo.ErrLockdownNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return lockdown, nil
+}
+
+// SetLockdownRevocations enregistre le nombre de membres et de tokens révoqués
+func (r *LockdownsRepository) SetLockdownRevocations(ctx context.Context, id string, affectedUsers, revokedTokens int) error {
+	_, err := r.db.ExecContext(ctx, "UPDATE lockdowns SET affected_users = ?, revoked_tokens = ? WHERE id = ?",
+		affectedUsers, revokedTokens, id)
+	return err
+}
+
+// LiftLockdown lève le confinement en cours de l'organisation
+func (r *LockdownsRepository) LiftLockdown(ctx context.Context, orgID, liftedBy string, at time.Time) error {
+	result, err := r.db.ExecContext(ctx,
+		"UPDATE lockdowns SET lifted_by = ?, lifted_at = ? WHERE organization_id = ? AND lifted_at IS NULL",
+		liftedBy, at, orgID)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return repo.ErrLockdownNotFound
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+// filepath: internal/storage/mysql/log_forwarders_repository.go
+
+/*************************************************************************/
+/*                                                                       */
+/*   Ce fichier implémente le repository MySQL des destinations des      */
+/*   journaux d'audit des organisations                                  */
+/*                                                                       */
+/*************************************************************************/
+
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"secrets-manager/internal/models"
+	repo "secrets-manager/internal/storage"
+)
+
+// LogForwardersRepository gère les destinations des journaux d'audit dans MySQL
+type LogForwardersRepository struct {
+	db *sql.DB
+}
+
+var _ repo.LogForwardersRepository = (*LogForwardersRepository)(nil)
+
+// NewLogForwardersRepository crée un nouveau repository des destinations de journaux
+func NewLogForwardersRepository(db *sql.DB) *LogForwardersRepository {
+	return &LogForwardersRepository{
+		db: db,
+	}
+}
+
+// CreateLogForwarder enregistre une destination
+func (r *LogForwardersRepository) CreateLogForwarder(ctx context.Context, forwarder *models.LogForwarder) error {
+	if forwarder.ID == "" {
+		forwarder.ID = uuid.New().String()
+	}
+	forwarder.CreatedAt = time.Now()
+
+	methods, err := json.Marshal(forwarder.Methods)
+	if err != nil {
+		return err
+	}
+	projects, err := json.Marshal(forwarder.Projects)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO log_forwarders (id, organization_id, kind, destination, region, endpoint, access_key_id,
+			secret, methods, projects, failures_only, created_by, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err = r.db.ExecContext(ctx, query, forwarder.ID, forwarder.OrganizationID, forwarder.Kind,
+		forwarder.Destination, forwarder.Region, forwarder.Endpoint, forwarder.AccessKeyID, forwarder.Secret,
+		string(methods), string(projects), forwarder.FailuresOnly, forwarder.CreatedBy, forwarder.CreatedAt)
+	return err
+}
+
+// GetLogForwarder récupère une destination de l'organisation
+func (r *LogForwardersRepository) GetLogForwarder(ctx context.Context, orgID, id string) (*models.LogForwarder, error) {
+	query := `
+		SELECT ` + logForwarderColumns + `
+		FROM log_forwarders
+		WHERE id = ? AND organization_id = ?
+	`
+
+	forwarder, err := scanLogForwarder(r.db.QueryRowContext(ctx, query, id, orgID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repo.ErrForwarderNotFound
+	}
+	return forwarder, err
+}
+
+// ListLogForwarders liste les destinations de l'organisation par date de création
+func (r *LogForwardersRepository) ListLogForwarders(ctx context.Context, orgID string) ([]*models.LogForwarder, error) {
+	query := `
+		SELECT ` + logForwarderColumns + `
+		FROM log_forwarders
+		WHERE organization_id = ?
+		ORDER BY created_at
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, orgID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	forwarders := []*models.LogForwarder{}
+	for rows.Next() {
+		forwarder, err := scanLogForwarder(rows)
+		if err != nil {
+			return nil, err
+		}
+		forwarders = append(forwarders, forwarder)
+	}
+	return forwarders, rows.Err()
+}
+
+// DeleteLogForwarder supprime une destination
+func (r *LogForwardersRepository) DeleteLogForwarder(ctx context.Context, orgID, id string) error {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM log_forwarders WHERE id = ? AND organization_id = ?", id, orgID)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return repo.ErrForwarderNotFound
+	}
+	return nil
+}
+
+// Colonnes lues par scanLogForwarder, dans le même ordre
+const logForwarderColumns = `id, organization_id, kind, destination, region, endpoint, access_key_id, secret,
+	methods, projects, failures_only, created_by, created_at`
+
+func scanLogForwarder(row rowScanner) (*models.LogForwarder, error) {
+	forwarder := &models.LogForwarder{}
+	var methods, projects []byte
+
+	err := row.Scan(
+		&forwarder.ID,
+		&forwarder.OrganizationID,
+		&forwarder.Kind,
+		&forwarder.Destination,
+		&forwarder.Region,
+		&forwarder.Endpoint,
+		&forwarder.AccessKeyID,
+		&forwarder.Secret,
+		&methods,
+		&projects,
+		&forwarder.FailuresOnly,
+		&forwarder.CreatedBy,
+		&forwarder.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(methods, &forwarder.Methods); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(projects, &forwarder.Projects); err != nil {
+		return nil, err
+	}
+	return forwarder, nil
+}
@@ -0,0 +1,72 @@
+// filepath: internal/storage/mysql/login_alert_policies_repository.go
+
+/*************************************************************************/
+/*                                                                       */
+/*   Ce fichier implémente le repository MySQL des politiques d'alerte   */
+/*   de connexion des organisations                                      */
+/*                                                                       */
+/*************************************************************************/
+
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"secrets-manager/internal/models"
+	repo "secrets-manager/internal/storage"
+)
+
+// LoginAlertPoliciesRepository gère les politiques d'alerte de connexion dans MySQL
+type LoginAlertPoliciesRepository struct {
+	db *sql.DB
+}
+
+var _ repo.LoginAlertPoliciesRepository = (*LoginAlertPoliciesRepository)(nil)
+
+// NewLoginAlertPoliciesRepository crée un nouveau repository de politiques d'alerte
+func NewLoginAlertPoliciesRepository(db *sql.DB) *LoginAlertPoliciesRepository {
+	return &LoginAlertPoliciesRepository{
+		db: db,
+	}
+}
+
+// GetLoginAlertPolicy renvoie la politique de l'organisation
+func (r *LoginAlertPoliciesRepository) GetLoginAlertPolicy(ctx context.Context, orgID string) (*models.LoginAlertPolicy, error) {
+	query := `
+		SELECT organization_id, notify_new_location, notify_impossible_travel, step_up, updated_at
+		FROM login_alert_policies
+		WHERE organization_id = ?
+	`
+
+	policy := &models.LoginAlertPolicy{}
+	err := r.db.QueryRowContext(ctx, query, orgID).Scan(&policy.OrganizationID, &policy.NotifyNewLocation,
+		&policy.NotifyImpossibleTravel, &policy.StepUp, &policy.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repo.ErrLoginPolicyNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// SaveLoginAlertPolicy crée ou remplace la politique de l'organisation
+func (r *LoginAlertPoliciesRepository) SaveLoginAlertPolicy(ctx context.Context, policy *models.LoginAlertPolicy) error {
+	policy.UpdatedAt = time.Now()
+
+	query := `
+		INSERT INTO login_alert_policies (organization_id, notify_new_location, notify_impossible_travel,
+			step_up, updated_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE notify_new_location = VALUES(notify_new_location),
+			notify_impossible_travel = VALUES(notify_impossible_travel), step_up = VALUES(step_up),
+			updated_at = VALUES(updated_at)
+	`
+
+	_, err := r.db.ExecContext(ctx, query, policy.OrganizationID, policy.NotifyNewLocation,
+		policy.NotifyImpossibleTravel, policy.StepUp, policy.UpdatedAt)
+	return err
+}
@@ -0,0 +1,124 @@
+// filepath: internal/storage/mysql/login_events_repository.go
+
+/*************************************************************************/
+/*                                                                       */
+/*   Ce fichier implémente le repository MySQL des connexions des        */
+/*   utilisateurs, situées d'après leur adresse IP                       */
+/*                                                                       */
+/*************************************************************************/
+
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+
+	"secrets-manager/internal/models"
+	repo "secrets-manager/internal/storage"
+)
+
+// LoginEventsRepository gère les connexions dans MySQL
+type LoginEventsRepository struct {
+	db *sql.DB
+}
+
+var _ repo.LoginEventsRepository = (*LoginEventsRepository)(nil)
+
+// NewLoginEventsRepository crée un nouveau repository de connexions
+func NewLoginEventsRepository(db *sql.DB) *LoginEventsRepository {
+	return &LoginEventsRepository{
+		db: db,
+	}
+}
+
+// RecordLoginEvent enregistre une connexion
+func (r *LoginEventsRepository) RecordLoginEvent(ctx context.Context, event *models.LoginEvent) error {
+	if event.ID == "" {
+		event.ID = uuid.New().String()
+	}
+
+	query := `
+		INSERT INTO login_events (id, user_id, ip_address, user_agent, country, city, latitude, longitude,
+			anomaly, step_up, occurred_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := r.db.ExecContext(ctx, query, event.ID, event.UserID, event.IPAddress, event.UserAgent,
+		event.Country, event.City, event.Latitude, event.Longitude, event.Anomaly, event.StepUp, event.OccurredAt)
+	return err
+}
+
+// LastLocatedLoginEvent renvoie la dernière connexion située de l'utilisateur
+func (r *LoginEventsRepository) LastLocatedLoginEvent(ctx context.Context, userID string) (*models.LoginEvent, error) {
+	query := `
+		SELECT ` + loginEventColumns + `
+		FROM login_events
+		WHERE user_id = ? AND country <> ''
+		ORDER BY occurred_at DESC
+		LIMIT 1
+	`
+
+	event, err := scanLoginEvent(r.db.QueryRowContext(ctx, query, userID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repo.ErrLoginEventNotFound
+	}
+	return event, err
+}
+
+// LoginLocationKnown indique si l'utilisateur s'est déjà connecté depuis cette ville
+func (r *LoginEventsRepository) LoginLocationKnown(ctx context.Context, userID, country, city string) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM login_events
+			WHERE user_id = ? AND country = ? AND city = ?
+		)
+	`
+
+	var known bool
+	err := r.db.QueryRowContext(ctx, query, userID, country, city).Scan(&known)
+	return known, err
+}
+
+// ListLoginEvents liste les dernières connexions de l'utilisateur
+func (r *LoginEventsRepository) ListLoginEvents(ctx context.Context, userID string, limit int) ([]*models.LoginEvent, error) {
+	query := `
+		SELECT ` + loginEventColumns + `
+		FROM login_events
+		WHERE user_id = ?
+		ORDER BY occurred_at DESC
+		LIMIT ?
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := []*models.LoginEvent{}
+	for rows.Next() {
+		event, err := scanLoginEvent(rows)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
+
+// Colonnes lues par scanLoginEvent, dans le même ordre
+const loginEventColumns = `id, user_id, ip_address, user_agent, country, city, latitude, longitude, anomaly,
+	step_up, occurred_at`
+
+func scanLoginEvent(row rowScanner) (*models.LoginEvent, error) {
+	event := &models.LoginEvent{}
+	err := row.Scan(&event.ID, &event.UserID, &event.IPAddress, &event.UserAgent, &event.Country, &event.City,
+		&event.Latitude, &event.Longitude, &event.Anomaly, &event.StepUp, &event.OccurredAt)
+	if err != nil {
+		return nil, err
+	}
+	return event, nil
+}
@@ -0,0 +1,121 @@
+// filepath: internal/storage/mysql/maintenance_windows_repository.go
+
+/*************************************************************************/
+/*                                                                       */
+/*   Ce fichier implémente le repository MySQL des fenêtres de           */
+/*   maintenance des environnements                                      */
+/*                                                                       */
+/*************************************************************************/
+
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+
+	"secrets-manager/internal/models"
+	repo "secrets-manager/internal/storage"
+)
+
+// MaintenanceWindowsRepository gère les fenêtres de maintenance dans MySQL
+type MaintenanceWindowsRepository struct {
+	db *sql.DB
+}
+
+var _ repo.MaintenanceWindowsRepository = (*MaintenanceWindowsRepository)(nil)
+
+// NewMaintenanceWindowsRepository crée un nouveau repository de fenêtres de maintenance
+func NewMaintenanceWindowsRepository(db *sql.DB) *MaintenanceWindowsRepository {
+	return &MaintenanceWindowsRepository{
+		db: db,
+	}
+}
+
+// CreateMaintenanceWindow enregistre une fenêtre de maintenance
+func (r *MaintenanceWindowsRepository) CreateMaintenanceWindow(ctx context.Context, window *models.MaintenanceWindow) error {
+	if window.ID == "" {
+		window.ID = uuid.New().String()
+	}
+	window.CreatedAt = time.Now()
+
+	query := `
+		INSERT INTO maintenance_windows (id, organization_id, environment, schedule, duration_minutes,
+			timezone, created_by, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := r.db.ExecContext(ctx, query, window.ID, window.OrganizationID, window.Environment, window.Schedule,
+		window.DurationMinutes, window.Timezone, window.CreatedBy, window.CreatedAt)
+	return err
+}
+
+// ListMaintenanceWindows liste les fenêtres de l'organisation par environnement
+func (r *MaintenanceWindowsRepository) ListMaintenanceWindows(ctx context.Context, orgID string) ([]*models.MaintenanceWindow, error) {
+	return r.list(ctx, `
+		SELECT `+maintenanceWindowColumns+`
+		FROM maintenance_windows
+		WHERE organization_id = ?
+		ORDER BY environment, created_at
+	`, orgID)
+}
+
+// ListEnvironmentMaintenanceWindows liste les fenêtres d'un environnement de l'organisation
+func (r *MaintenanceWindowsRepository) ListEnvironmentMaintenanceWindows(ctx context.Context, orgID, env string) ([]*models.MaintenanceWindow, error) {
+	return r.list(ctx, `
+		SELECT `+maintenanceWindowColumns+`
+		FROM maintenance_windows
+		WHERE organization_id = ? AND environment = ?
+		ORDER BY created_at
+	`, orgID, env)
+}
+
+// DeleteMaintenanceWindow supprime une fenêtre de maintenance
+func (r *MaintenanceWindowsRepository) DeleteMaintenanceWindow(ctx context.Context, orgID, id string) error {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM maintenance_windows WHERE id = ? AND organization_id = ?", id, orgID)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return repo.ErrWindowNotFound
+	}
+	return nil
+}
+
+func (r *MaintenanceWindowsRepository) list(ctx context.Context, query string, args ...any) ([]*models.MaintenanceWindow, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	windows := []*models.MaintenanceWindow{}
+	for rows.Next() {
+		window := &models.MaintenanceWindow{}
+		err := rows.Scan(
+			&window.ID,
+			&window.OrganizationID,
+			&window.Environment,
+			&window.Schedule,
+			&window.DurationMinutes,
+			&window.Timezone,
+			&window.CreatedBy,
+			&window.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		windows = append(windows, window)
+	}
+	return windows, rows.Err()
+}
+
+// Colonnes lues par list, dans le même ordre
+const maintenanceWindowColumns = `id, organization_id, environment, schedule, duration_minutes, timezone,
+	created_by, created_at`
@@ -0,0 +1,166 @@
+// filepath: internal/storage/mysql/migrations.go
+
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"secrets-manager/internal/logging"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// Migration est un fichier de migration SQL numéroté (NNNN_description.sql)
+type Migration struct {
+	Version int
+	Name    string
+	SQL     string
+}
+
+// Migrations renvoie les migrations embarquées, triées par version
+func Migrations() ([]Migration, error) {
+	entries, err := migrationFiles.ReadDir("migrations")
+	if err != nil {
+		return nil, err
+	}
+
+	migrations := make([]Migration, 0, len(entries))
+	for _, entry := range entries {
+		name := entry.Name()
+		prefix, _, ok := strings.Cut(name, "_")
+		if !ok {
+			return nil, fmt.Errorf("nom de migration invalide: %s", name)
+		}
+		version, err := strconv.Atoi(prefix)
+		if err != nil {
+			return nil, fmt.Errorf("version de migration invalide: %s", name)
+		}
+
+		content, err := migrationFiles.ReadFile(path.Join("migrations", name))
+		if err != nil {
+			return nil, err
+		}
+
+		migrations = append(migrations, Migration{
+			Version: version,
+			Name:    strings.TrimSuffix(name, ".sql"),
+			SQL:     string(content),
+		})
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// LatestSchemaVersion renvoie la version de schéma attendue par le binaire
+func LatestSchemaVersion() (int, error) {
+	migrations, err := Migrations()
+	if err != nil {
+		return 0, err
+	}
+	if len(migrations) == 0 {
+		return 0, nil
+	}
+	return migrations[len(migrations)-1].Version, nil
+}
+
+// CurrentSchemaVersion renvoie la version de schéma appliquée en base
+// (0 si aucune migration n'a été appliquée)
+func CurrentSchemaVersion(ctx context.Context, db *sql.DB) (int, error) {
+	if err := ensureMigrationsTable(ctx, db); err != nil {
+		return 0, err
+	}
+
+	var version sql.NullInt64
+	err := db.QueryRowContext(ctx, "SELECT MAX(version) FROM schema_migrations").Scan(&version)
+	if err != nil {
+		return 0, err
+	}
+
+	return int(version.Int64), nil
+}
+
+// Migrate applique les migrations qui ne l'ont pas encore été
+func Migrate(ctx context.Context, db *sql.DB) error {
+	migrations, err := Migrations()
+	if err != nil {
+		return err
+	}
+
+	current, err := CurrentSchemaVersion(ctx, db)
+	if err != nil {
+		return err
+	}
+
+	logger := logging.For(logging.ComponentStorage)
+	for _, migration := range migrations {
+		if migration.Version <= current {
+			continue
+		}
+
+		logger.Info("application de la migration", "migration", migration.Name)
+		if err := applyMigration(ctx, db, migration); err != nil {
+			return fmt.Errorf("migration %s: %w", migration.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// applyMigration exécute chaque instruction d'une migration puis l'enregistre.
+// MySQL valide implicitement les DDL : une migration interrompue doit donc
+// rester idempotente (IF NOT EXISTS) pour pouvoir être rejouée.
+func applyMigration(ctx context.Context, db *sql.DB, migration Migration) error {
+	for _, statement := range splitStatements(migration.SQL) {
+		if _, err := db.ExecContext(ctx, statement); err != nil {
+			return err
+		}
+	}
+
+	_, err := db.ExecContext(ctx,
+		"INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, NOW())",
+		migration.Version, migration.Name)
+	return err
+}
+
+func ensureMigrationsTable(ctx context.Context, db *sql.DB) error {
+	_, err := db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version    INT          NOT NULL PRIMARY KEY,
+			name       VARCHAR(255) NOT NULL,
+			applied_at DATETIME     NOT NULL
+		)
+	`)
+	return err
+}
+
+// splitStatements découpe un script SQL en instructions séparées par ";"
+// en ignorant les lignes de commentaire
+func splitStatements(script string) []string {
+	var lines []string
+	for _, line := range strings.Split(script, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "--") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	var statements []string
+	for _, statement := range strings.Split(strings.Join(lines, "\n"), ";") {
+		if statement = strings.TrimSpace(statement); statement != "" {
+			statements = append(statements, statement)
+		}
+	}
+	return statements
+}
@@ -0,0 +1,107 @@
+-- Schéma initial de secrets-manager
+
+CREATE TABLE IF NOT EXISTS users (
+    id              VARCHAR(36)  NOT NULL PRIMARY KEY,
+    email           VARCHAR(255) NOT NULL,
+    hashed_password VARCHAR(255) NOT NULL,
+    first_name      VARCHAR(100) NOT NULL DEFAULT '',
+    last_name       VARCHAR(100) NOT NULL DEFAULT '',
+    role            VARCHAR(32)  NOT NULL DEFAULT 'user',
+    created_at      DATETIME     NOT NULL,
+    updated_at      DATETIME     NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS plans (
+    id            VARCHAR(36)   NOT NULL PRIMARY KEY,
+    name          VARCHAR(64)   NOT NULL,
+    description   TEXT          NOT NULL,
+    price         DECIMAL(10,2) NOT NULL DEFAULT 0,
+    billing_cycle VARCHAR(16)   NOT NULL DEFAULT 'monthly',
+    secrets_limit INT           NOT NULL,
+    features      JSON          NULL,
+    created_at    DATETIME      NOT NULL,
+    updated_at    DATETIME      NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS organizations (
+    id          VARCHAR(36)  NOT NULL PRIMARY KEY,
+    name        VARCHAR(255) NOT NULL,
+    description TEXT         NOT NULL,
+    plan_id     VARCHAR(36)  NOT NULL DEFAULT '',
+    created_at  DATETIME     NOT NULL,
+    updated_at  DATETIME     NOT NULL,
+    owner_id    VARCHAR(36)  NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS user_organizations (
+    user_id         VARCHAR(36) NOT NULL,
+    organization_id VARCHAR(36) NOT NULL,
+    role            VARCHAR(32) NOT NULL,
+    created_at      DATETIME    NOT NULL,
+    updated_at      DATETIME    NOT NULL,
+    PRIMARY KEY (user_id, organization_id)
+);
+
+CREATE TABLE IF NOT EXISTS projects (
+    id              VARCHAR(36)  NOT NULL PRIMARY KEY,
+    name            VARCHAR(255) NOT NULL,
+    description     TEXT         NOT NULL,
+    organization_id VARCHAR(36)  NOT NULL,
+    created_at      DATETIME     NOT NULL,
+    updated_at      DATETIME     NOT NULL,
+    created_by      VARCHAR(36)  NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS environments (
+    id          VARCHAR(36) NOT NULL PRIMARY KEY,
+    name        VARCHAR(64) NOT NULL,
+    description TEXT        NOT NULL,
+    project_id  VARCHAR(36) NOT NULL,
+    created_at  DATETIME    NOT NULL,
+    updated_at  DATETIME    NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS secret_metadata (
+    id              VARCHAR(36)  NOT NULL PRIMARY KEY,
+    name            VARCHAR(255) NOT NULL,
+    description     TEXT         NOT NULL,
+    organization_id VARCHAR(36)  NOT NULL,
+    project_id      VARCHAR(36)  NOT NULL,
+    environment     VARCHAR(64)  NOT NULL,
+    created_by      VARCHAR(36)  NOT NULL,
+    created_at      DATETIME     NOT NULL,
+    updated_at      DATETIME     NOT NULL,
+    version         INT          NOT NULL DEFAULT 1
+);
+
+CREATE TABLE IF NOT EXISTS subscriptions (
+    id              VARCHAR(36) NOT NULL PRIMARY KEY,
+    organization_id VARCHAR(36) NOT NULL,
+    plan_id         VARCHAR(36) NOT NULL,
+    status          VARCHAR(16) NOT NULL,
+    secrets_limit   INT         NOT NULL,
+    start_date      DATETIME    NOT NULL,
+    end_date        DATETIME    NOT NULL,
+    created_at      DATETIME    NOT NULL,
+    updated_at      DATETIME    NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS usage_statistics (
+    id              VARCHAR(36) NOT NULL PRIMARY KEY,
+    organization_id VARCHAR(36) NOT NULL,
+    secret_count    INT         NOT NULL DEFAULT 0,
+    api_calls       BIGINT      NOT NULL DEFAULT 0,
+    last_updated    DATETIME    NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS audit_logs (
+    id              VARCHAR(36)  NOT NULL PRIMARY KEY,
+    user_id         VARCHAR(36)  NOT NULL,
+    organization_id VARCHAR(36)  NOT NULL,
+    action          VARCHAR(32)  NOT NULL,
+    resource_type   VARCHAR(32)  NOT NULL,
+    resource_id     VARCHAR(255) NOT NULL,
+    timestamp       DATETIME     NOT NULL,
+    ip_address      VARCHAR(45)  NOT NULL DEFAULT '',
+    user_agent      VARCHAR(512) NOT NULL DEFAULT ''
+);
@@ -0,0 +1,11 @@
+-- Appels API agrégés par jour, principal et route
+
+CREATE TABLE IF NOT EXISTS api_usage (
+    organization_id VARCHAR(36)  NOT NULL,
+    day             DATE         NOT NULL,
+    principal_type  VARCHAR(32)  NOT NULL,
+    principal_id    VARCHAR(36)  NOT NULL,
+    route           VARCHAR(255) NOT NULL,
+    calls           BIGINT       NOT NULL DEFAULT 0,
+    PRIMARY KEY (organization_id, day, principal_type, principal_id, route)
+);
@@ -0,0 +1,16 @@
+-- Journal d'audit des routes d'administration (rétention longue)
+
+CREATE TABLE IF NOT EXISTS admin_audit_logs (
+    id           VARCHAR(36)  NOT NULL PRIMARY KEY,
+    principal    VARCHAR(100) NOT NULL,
+    method       VARCHAR(10)  NOT NULL,
+    route        VARCHAR(255) NOT NULL,
+    path         VARCHAR(1024) NOT NULL,
+    request_body MEDIUMTEXT   NOT NULL,
+    status_code  INT          NOT NULL,
+    duration_ms  BIGINT       NOT NULL,
+    ip_address   VARCHAR(45)  NOT NULL DEFAULT '',
+    user_agent   VARCHAR(512) NOT NULL DEFAULT '',
+    timestamp    DATETIME     NOT NULL,
+    INDEX idx_admin_audit_logs_timestamp (timestamp)
+);
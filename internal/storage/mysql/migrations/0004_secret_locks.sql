@@ -0,0 +1,6 @@
+-- Verrouillage des secrets critiques par un administrateur
+
+ALTER TABLE secret_metadata
+    ADD COLUMN locked_by   VARCHAR(36) NULL,
+    ADD COLUMN locked_at   DATETIME    NULL,
+    ADD COLUMN lock_reason TEXT        NULL;
@@ -0,0 +1,13 @@
+-- Confirmations en deux étapes des opérations destructives
+
+CREATE TABLE IF NOT EXISTS deletion_confirmations (
+    id              VARCHAR(36)  NOT NULL PRIMARY KEY,
+    token_hash      CHAR(64)     NOT NULL,
+    organization_id VARCHAR(36)  NOT NULL,
+    action          VARCHAR(64)  NOT NULL,
+    target          VARCHAR(255) NOT NULL,
+    requested_by    VARCHAR(36)  NOT NULL,
+    expires_at      DATETIME     NOT NULL,
+    created_at      DATETIME     NOT NULL,
+    UNIQUE INDEX idx_deletion_confirmations_token_hash (token_hash)
+);
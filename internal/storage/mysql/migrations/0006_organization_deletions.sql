@@ -0,0 +1,16 @@
+-- Suppressions d'organisations exécutées en tâche de fond, par étapes
+
+CREATE TABLE IF NOT EXISTS organization_deletions (
+    id               VARCHAR(36)  NOT NULL PRIMARY KEY,
+    organization_id  VARCHAR(36)  NOT NULL,
+    requested_by     VARCHAR(36)  NOT NULL,
+    status           VARCHAR(16)  NOT NULL,
+    stage            VARCHAR(32)  NOT NULL,
+    completed_stages INT          NOT NULL DEFAULT 0,
+    secrets_deleted  INT          NOT NULL DEFAULT 0,
+    error            TEXT         NULL,
+    created_at       DATETIME     NOT NULL,
+    updated_at       DATETIME     NOT NULL,
+    completed_at     DATETIME     NULL,
+    INDEX idx_organization_deletions_status (status)
+);
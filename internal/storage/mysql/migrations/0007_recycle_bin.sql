@@ -0,0 +1,11 @@
+-- Corbeille : les organisations et projets supprimés restent restaurables
+-- jusqu'à leur purge définitive
+
+ALTER TABLE organizations
+    ADD COLUMN deleted_at DATETIME NULL;
+
+ALTER TABLE projects
+    ADD COLUMN deleted_at DATETIME NULL;
+
+ALTER TABLE organization_deletions
+    ADD COLUMN purge_after DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP;
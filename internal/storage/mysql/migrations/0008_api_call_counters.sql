@@ -0,0 +1,21 @@
+-- Compteur global d'appels API réparti sur plusieurs lignes par organisation
+-- pour éviter la contention sur usage_statistics.api_calls. La vue
+-- organization_api_calls fournit le total par organisation.
+
+CREATE TABLE IF NOT EXISTS api_call_counters (
+    organization_id VARCHAR(36) NOT NULL,
+    shard           TINYINT     NOT NULL,
+    calls           BIGINT      NOT NULL DEFAULT 0,
+    PRIMARY KEY (organization_id, shard)
+);
+
+INSERT INTO api_call_counters (organization_id, shard, calls)
+SELECT organization_id, 0, SUM(api_calls)
+FROM usage_statistics
+WHERE api_calls > 0
+GROUP BY organization_id;
+
+CREATE OR REPLACE VIEW organization_api_calls AS
+SELECT organization_id, SUM(calls) AS api_calls
+FROM api_call_counters
+GROUP BY organization_id;
@@ -0,0 +1,14 @@
+-- Index des listes de membres et de projets : chaque liste est lue en une
+-- seule requête filtrée par organisation
+
+CREATE INDEX idx_user_organizations_organization
+    ON user_organizations (organization_id, user_id);
+
+CREATE INDEX idx_users_name
+    ON users (last_name, first_name);
+
+CREATE INDEX idx_projects_organization
+    ON projects (organization_id, deleted_at, name);
+
+CREATE INDEX idx_secret_metadata_project
+    ON secret_metadata (organization_id, project_id, environment);
@@ -0,0 +1,25 @@
+-- Index uniques garantissant en base l'unicité vérifiée par les repositories.
+-- La migration échoue si des emails sont en double : les résoudre avant de
+-- la rejouer. user_organizations est déjà unique par sa clé primaire
+-- (user_id, organization_id).
+
+CREATE UNIQUE INDEX idx_users_email
+    ON users (email);
+
+-- Des créations simultanées ont pu dupliquer des métadonnées de secrets :
+-- seule la version la plus récente de chaque chemin est conservée. Le
+-- compteur de secrets est corrigé par la réconciliation périodique.
+DELETE older
+FROM secret_metadata older
+JOIN secret_metadata newer
+    ON newer.organization_id = older.organization_id
+    AND newer.project_id = older.project_id
+    AND newer.environment = older.environment
+    AND newer.name = older.name
+    AND (newer.version > older.version OR (newer.version = older.version AND newer.id > older.id));
+
+CREATE UNIQUE INDEX idx_secret_metadata_path
+    ON secret_metadata (organization_id, project_id, environment, name);
+
+-- Couvert par idx_secret_metadata_path
+DROP INDEX idx_secret_metadata_project ON secret_metadata;
@@ -0,0 +1,5 @@
+-- Recherche de membres par début de prénom : l'email et le nom sont déjà
+-- couverts par idx_users_email et idx_users_name
+
+CREATE INDEX idx_users_first_name
+    ON users (first_name);
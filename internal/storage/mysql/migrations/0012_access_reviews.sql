@@ -0,0 +1,26 @@
+-- Campagnes de revue des accès : chaque membre d'une organisation est
+-- confirmé ou révoqué par un administrateur
+
+CREATE TABLE IF NOT EXISTS access_reviews (
+    id              VARCHAR(36) NOT NULL PRIMARY KEY,
+    organization_id VARCHAR(36) NOT NULL,
+    created_by      VARCHAR(36) NOT NULL,
+    status          VARCHAR(16) NOT NULL,
+    created_at      DATETIME    NOT NULL,
+    completed_at    DATETIME    NULL,
+    INDEX idx_access_reviews_organization (organization_id, created_at)
+);
+
+CREATE TABLE IF NOT EXISTS access_review_items (
+    review_id      VARCHAR(36)  NOT NULL,
+    principal_type VARCHAR(32)  NOT NULL,
+    principal_id   VARCHAR(36)  NOT NULL,
+    email          VARCHAR(255) NOT NULL DEFAULT '',
+    role           VARCHAR(32)  NOT NULL,
+    last_activity  VARCHAR(10)  NOT NULL DEFAULT '',
+    dormant        BOOLEAN      NOT NULL DEFAULT FALSE,
+    decision       VARCHAR(16)  NOT NULL,
+    decided_by     VARCHAR(36)  NOT NULL DEFAULT '',
+    decided_at     DATETIME     NULL,
+    PRIMARY KEY (review_id, principal_type, principal_id)
+);
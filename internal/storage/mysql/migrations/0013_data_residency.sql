@@ -0,0 +1,5 @@
+-- Résidence des données : les secrets d'une organisation ne sont stockés
+-- que dans le backend Vault de sa région
+
+ALTER TABLE organizations
+    ADD COLUMN region VARCHAR(32) NOT NULL DEFAULT 'default';
@@ -0,0 +1,273 @@
+-- Réplication des métadonnées vers une région de secours : chaque
+-- modification des tables répliquées est enregistrée dans metadata_changes
+-- (table et clé primaire de la ligne) puis rejouée de façon asynchrone sur la
+-- base de secours. Les triggers n'enregistrent rien pendant l'application
+-- des changements (@metadata_replication défini) pour éviter les boucles.
+
+CREATE TABLE IF NOT EXISTS metadata_changes (
+    id         BIGINT      NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    table_name VARCHAR(64) NOT NULL,
+    row_key    JSON        NOT NULL,
+    created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6)
+);
+
+-- Position de la base de secours dans le flux de changements du primaire.
+-- promoted_at est renseigné par smadmin failover et bloque toute réplication.
+CREATE TABLE IF NOT EXISTS replication_position (
+    name           VARCHAR(32) NOT NULL PRIMARY KEY,
+    last_change_id BIGINT      NOT NULL DEFAULT 0,
+    promoted_at    DATETIME    NULL,
+    updated_at     DATETIME    NOT NULL
+);
+
+DROP TRIGGER IF EXISTS users_replicate_insert;
+
+CREATE TRIGGER users_replicate_insert AFTER INSERT ON users FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'users', JSON_OBJECT('id', NEW.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS users_replicate_update;
+
+CREATE TRIGGER users_replicate_update AFTER UPDATE ON users FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'users', JSON_OBJECT('id', NEW.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS users_replicate_delete;
+
+CREATE TRIGGER users_replicate_delete AFTER DELETE ON users FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'users', JSON_OBJECT('id', OLD.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS plans_replicate_insert;
+
+CREATE TRIGGER plans_replicate_insert AFTER INSERT ON plans FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'plans', JSON_OBJECT('id', NEW.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS plans_replicate_update;
+
+CREATE TRIGGER plans_replicate_update AFTER UPDATE ON plans FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'plans', JSON_OBJECT('id', NEW.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS plans_replicate_delete;
+
+CREATE TRIGGER plans_replicate_delete AFTER DELETE ON plans FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'plans', JSON_OBJECT('id', OLD.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS organizations_replicate_insert;
+
+CREATE TRIGGER organizations_replicate_insert AFTER INSERT ON organizations FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'organizations', JSON_OBJECT('id', NEW.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS organizations_replicate_update;
+
+CREATE TRIGGER organizations_replicate_update AFTER UPDATE ON organizations FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'organizations', JSON_OBJECT('id', NEW.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS organizations_replicate_delete;
+
+CREATE TRIGGER organizations_replicate_delete AFTER DELETE ON organizations FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'organizations', JSON_OBJECT('id', OLD.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS user_organizations_replicate_insert;
+
+CREATE TRIGGER user_organizations_replicate_insert AFTER INSERT ON user_organizations FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'user_organizations', JSON_OBJECT('user_id', NEW.user_id, 'organization_id', NEW.organization_id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS user_organizations_replicate_update;
+
+CREATE TRIGGER user_organizations_replicate_update AFTER UPDATE ON user_organizations FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'user_organizations', JSON_OBJECT('user_id', NEW.user_id, 'organization_id', NEW.organization_id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS user_organizations_replicate_delete;
+
+CREATE TRIGGER user_organizations_replicate_delete AFTER DELETE ON user_organizations FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'user_organizations', JSON_OBJECT('user_id', OLD.user_id, 'organization_id', OLD.organization_id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS projects_replicate_insert;
+
+CREATE TRIGGER projects_replicate_insert AFTER INSERT ON projects FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'projects', JSON_OBJECT('id', NEW.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS projects_replicate_update;
+
+CREATE TRIGGER projects_replicate_update AFTER UPDATE ON projects FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'projects', JSON_OBJECT('id', NEW.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS projects_replicate_delete;
+
+CREATE TRIGGER projects_replicate_delete AFTER DELETE ON projects FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'projects', JSON_OBJECT('id', OLD.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS environments_replicate_insert;
+
+CREATE TRIGGER environments_replicate_insert AFTER INSERT ON environments FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'environments', JSON_OBJECT('id', NEW.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS environments_replicate_update;
+
+CREATE TRIGGER environments_replicate_update AFTER UPDATE ON environments FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'environments', JSON_OBJECT('id', NEW.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS environments_replicate_delete;
+
+CREATE TRIGGER environments_replicate_delete AFTER DELETE ON environments FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'environments', JSON_OBJECT('id', OLD.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS secret_metadata_replicate_insert;
+
+CREATE TRIGGER secret_metadata_replicate_insert AFTER INSERT ON secret_metadata FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'secret_metadata', JSON_OBJECT('id', NEW.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS secret_metadata_replicate_update;
+
+CREATE TRIGGER secret_metadata_replicate_update AFTER UPDATE ON secret_metadata FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'secret_metadata', JSON_OBJECT('id', NEW.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS secret_metadata_replicate_delete;
+
+CREATE TRIGGER secret_metadata_replicate_delete AFTER DELETE ON secret_metadata FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'secret_metadata', JSON_OBJECT('id', OLD.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS subscriptions_replicate_insert;
+
+CREATE TRIGGER subscriptions_replicate_insert AFTER INSERT ON subscriptions FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'subscriptions', JSON_OBJECT('id', NEW.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS subscriptions_replicate_update;
+
+CREATE TRIGGER subscriptions_replicate_update AFTER UPDATE ON subscriptions FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'subscriptions', JSON_OBJECT('id', NEW.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS subscriptions_replicate_delete;
+
+CREATE TRIGGER subscriptions_replicate_delete AFTER DELETE ON subscriptions FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'subscriptions', JSON_OBJECT('id', OLD.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS usage_statistics_replicate_insert;
+
+CREATE TRIGGER usage_statistics_replicate_insert AFTER INSERT ON usage_statistics FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'usage_statistics', JSON_OBJECT('id', NEW.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS usage_statistics_replicate_update;
+
+CREATE TRIGGER usage_statistics_replicate_update AFTER UPDATE ON usage_statistics FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'usage_statistics', JSON_OBJECT('id', NEW.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS usage_statistics_replicate_delete;
+
+CREATE TRIGGER usage_statistics_replicate_delete AFTER DELETE ON usage_statistics FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'usage_statistics', JSON_OBJECT('id', OLD.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS organization_deletions_replicate_insert;
+
+CREATE TRIGGER organization_deletions_replicate_insert AFTER INSERT ON organization_deletions FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'organization_deletions', JSON_OBJECT('id', NEW.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS organization_deletions_replicate_update;
+
+CREATE TRIGGER organization_deletions_replicate_update AFTER UPDATE ON organization_deletions FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'organization_deletions', JSON_OBJECT('id', NEW.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS organization_deletions_replicate_delete;
+
+CREATE TRIGGER organization_deletions_replicate_delete AFTER DELETE ON organization_deletions FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'organization_deletions', JSON_OBJECT('id', OLD.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS access_reviews_replicate_insert;
+
+CREATE TRIGGER access_reviews_replicate_insert AFTER INSERT ON access_reviews FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'access_reviews', JSON_OBJECT('id', NEW.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS access_reviews_replicate_update;
+
+CREATE TRIGGER access_reviews_replicate_update AFTER UPDATE ON access_reviews FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'access_reviews', JSON_OBJECT('id', NEW.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS access_reviews_replicate_delete;
+
+CREATE TRIGGER access_reviews_replicate_delete AFTER DELETE ON access_reviews FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'access_reviews', JSON_OBJECT('id', OLD.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS access_review_items_replicate_insert;
+
+CREATE TRIGGER access_review_items_replicate_insert AFTER INSERT ON access_review_items FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'access_review_items', JSON_OBJECT('review_id', NEW.review_id, 'principal_type', NEW.principal_type, 'principal_id', NEW.principal_id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS access_review_items_replicate_update;
+
+CREATE TRIGGER access_review_items_replicate_update AFTER UPDATE ON access_review_items FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'access_review_items', JSON_OBJECT('review_id', NEW.review_id, 'principal_type', NEW.principal_type, 'principal_id', NEW.principal_id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS access_review_items_replicate_delete;
+
+CREATE TRIGGER access_review_items_replicate_delete AFTER DELETE ON access_review_items FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'access_review_items', JSON_OBJECT('review_id', OLD.review_id, 'principal_type', OLD.principal_type, 'principal_id', OLD.principal_id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
@@ -0,0 +1,37 @@
+-- Chiffrement de bout en bout : clé publique des projets et marquage des
+-- secrets dont la valeur est chiffrée par le client
+
+CREATE TABLE IF NOT EXISTS project_encryption_keys (
+    project_id VARCHAR(36)  NOT NULL PRIMARY KEY,
+    algorithm  VARCHAR(64)  NOT NULL,
+    public_key VARCHAR(255) NOT NULL,
+    key_id     VARCHAR(64)  NOT NULL,
+    created_by VARCHAR(36)  NOT NULL,
+    created_at DATETIME     NOT NULL
+);
+
+ALTER TABLE secret_metadata
+    ADD COLUMN e2e BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Réplication vers la région de secours (voir 0014)
+
+DROP TRIGGER IF EXISTS project_encryption_keys_replicate_insert;
+
+CREATE TRIGGER project_encryption_keys_replicate_insert AFTER INSERT ON project_encryption_keys FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'project_encryption_keys', JSON_OBJECT('project_id', NEW.project_id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS project_encryption_keys_replicate_update;
+
+CREATE TRIGGER project_encryption_keys_replicate_update AFTER UPDATE ON project_encryption_keys FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'project_encryption_keys', JSON_OBJECT('project_id', NEW.project_id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS project_encryption_keys_replicate_delete;
+
+CREATE TRIGGER project_encryption_keys_replicate_delete AFTER DELETE ON project_encryption_keys FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'project_encryption_keys', JSON_OBJECT('project_id', OLD.project_id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
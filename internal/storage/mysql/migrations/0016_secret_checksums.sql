@@ -0,0 +1,6 @@
+-- Somme de contrôle déterministe (HMAC) de la valeur des secrets, pour la
+-- détection de dérive. Les secrets existants la reçoivent à leur prochaine
+-- écriture ou à la première lecture de leurs métadonnées.
+
+ALTER TABLE secret_metadata
+    ADD COLUMN checksum VARCHAR(80) NULL;
@@ -0,0 +1,60 @@
+-- Réglages de notification par utilisateur : webhook Slack et canaux
+-- (email, Slack) choisis pour chaque type d'événement
+
+CREATE TABLE IF NOT EXISTS notification_settings (
+    user_id           VARCHAR(36)  NOT NULL PRIMARY KEY,
+    slack_webhook_url VARCHAR(512) NOT NULL DEFAULT '',
+    updated_at        DATETIME     NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    user_id    VARCHAR(36) NOT NULL,
+    event_type VARCHAR(64) NOT NULL,
+    email      BOOLEAN     NOT NULL DEFAULT TRUE,
+    slack      BOOLEAN     NOT NULL DEFAULT FALSE,
+    PRIMARY KEY (user_id, event_type)
+);
+
+-- Réplication vers la région de secours (voir 0014)
+
+DROP TRIGGER IF EXISTS notification_settings_replicate_insert;
+
+CREATE TRIGGER notification_settings_replicate_insert AFTER INSERT ON notification_settings FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'notification_settings', JSON_OBJECT('user_id', NEW.user_id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS notification_settings_replicate_update;
+
+CREATE TRIGGER notification_settings_replicate_update AFTER UPDATE ON notification_settings FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'notification_settings', JSON_OBJECT('user_id', NEW.user_id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS notification_settings_replicate_delete;
+
+CREATE TRIGGER notification_settings_replicate_delete AFTER DELETE ON notification_settings FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'notification_settings', JSON_OBJECT('user_id', OLD.user_id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS notification_preferences_replicate_insert;
+
+CREATE TRIGGER notification_preferences_replicate_insert AFTER INSERT ON notification_preferences FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'notification_preferences', JSON_OBJECT('user_id', NEW.user_id, 'event_type', NEW.event_type) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS notification_preferences_replicate_update;
+
+CREATE TRIGGER notification_preferences_replicate_update AFTER UPDATE ON notification_preferences FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'notification_preferences', JSON_OBJECT('user_id', NEW.user_id, 'event_type', NEW.event_type) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS notification_preferences_replicate_delete;
+
+CREATE TRIGGER notification_preferences_replicate_delete AFTER DELETE ON notification_preferences FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'notification_preferences', JSON_OBJECT('user_id', OLD.user_id, 'event_type', OLD.event_type) FROM DUAL
+    WHERE @metadata_replication IS NULL;
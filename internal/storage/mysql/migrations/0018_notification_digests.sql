@@ -0,0 +1,18 @@
+-- Résumés quotidiens ou hebdomadaires des notifications : fréquence choisie
+-- par l'utilisateur et historique des événements notifiés
+
+ALTER TABLE notification_settings
+    ADD COLUMN digest VARCHAR(16) NOT NULL DEFAULT '',
+    ADD COLUMN last_digest_at DATETIME NULL;
+
+CREATE TABLE IF NOT EXISTS notification_events (
+    id              VARCHAR(36)  NOT NULL PRIMARY KEY,
+    organization_id VARCHAR(36)  NOT NULL,
+    type            VARCHAR(64)  NOT NULL,
+    actor_id        VARCHAR(36)  NOT NULL DEFAULT '',
+    subject         VARCHAR(512) NOT NULL,
+    message         TEXT         NOT NULL,
+    occurred_at     DATETIME     NOT NULL,
+    INDEX idx_notification_events_organization (organization_id, occurred_at),
+    INDEX idx_notification_events_occurred (occurred_at)
+);
@@ -0,0 +1,53 @@
+-- Webhooks des organisations et historique de leurs livraisons (réponses
+-- des consommateurs, rejeux)
+
+CREATE TABLE IF NOT EXISTS webhooks (
+    id              VARCHAR(36)  NOT NULL PRIMARY KEY,
+    organization_id VARCHAR(36)  NOT NULL,
+    url             VARCHAR(512) NOT NULL,
+    secret          VARCHAR(128) NOT NULL,
+    events          JSON         NOT NULL,
+    created_by      VARCHAR(36)  NOT NULL,
+    created_at      DATETIME     NOT NULL,
+    INDEX idx_webhooks_organization (organization_id)
+);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id              VARCHAR(36) NOT NULL PRIMARY KEY,
+    webhook_id      VARCHAR(36) NOT NULL,
+    organization_id VARCHAR(36) NOT NULL,
+    event_type      VARCHAR(64) NOT NULL,
+    payload         MEDIUMTEXT  NOT NULL,
+    status_code     INT         NOT NULL DEFAULT 0,
+    response_body   TEXT        NOT NULL,
+    error           TEXT        NOT NULL,
+    duration_ms     BIGINT      NOT NULL DEFAULT 0,
+    redelivery_of   VARCHAR(36) NULL,
+    delivered_at    DATETIME    NOT NULL,
+    INDEX idx_webhook_deliveries_webhook (webhook_id, delivered_at),
+    INDEX idx_webhook_deliveries_delivered (delivered_at)
+);
+
+-- Réplication vers la région de secours (voir 0014) ; l'historique des
+-- livraisons n'est pas répliqué, comme les journaux d'audit
+
+DROP TRIGGER IF EXISTS webhooks_replicate_insert;
+
+CREATE TRIGGER webhooks_replicate_insert AFTER INSERT ON webhooks FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'webhooks', JSON_OBJECT('id', NEW.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS webhooks_replicate_update;
+
+CREATE TRIGGER webhooks_replicate_update AFTER UPDATE ON webhooks FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'webhooks', JSON_OBJECT('id', NEW.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS webhooks_replicate_delete;
+
+CREATE TRIGGER webhooks_replicate_delete AFTER DELETE ON webhooks FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'webhooks', JSON_OBJECT('id', OLD.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
@@ -0,0 +1,18 @@
+-- Demandes de connexion des appareils sans navigateur (flux device code de
+-- la CLI). Durée de vie de quelques minutes : la table n'est pas répliquée,
+-- comme les confirmations des opérations destructives.
+
+CREATE TABLE IF NOT EXISTS device_authorizations (
+    id               VARCHAR(36)  NOT NULL PRIMARY KEY,
+    device_code_hash CHAR(64)     NOT NULL,
+    user_code        VARCHAR(16)  NOT NULL,
+    client_name      VARCHAR(128) NOT NULL,
+    status           VARCHAR(16)  NOT NULL,
+    user_id          VARCHAR(36)  NOT NULL DEFAULT '',
+    last_polled_at   DATETIME     NULL,
+    expires_at       DATETIME     NOT NULL,
+    created_at       DATETIME     NOT NULL,
+    UNIQUE INDEX idx_device_authorizations_device_code (device_code_hash),
+    UNIQUE INDEX idx_device_authorizations_user_code (user_code),
+    INDEX idx_device_authorizations_expires (expires_at)
+);
@@ -0,0 +1,40 @@
+-- Tokens d'accès personnels des utilisateurs (scripts agissant au nom
+-- d'un utilisateur), avec leurs portées et leur expiration
+
+CREATE TABLE IF NOT EXISTS personal_access_tokens (
+    id           VARCHAR(36)  NOT NULL PRIMARY KEY,
+    user_id      VARCHAR(36)  NOT NULL,
+    name         VARCHAR(128) NOT NULL,
+    token_hash   CHAR(64)     NOT NULL,
+    prefix       VARCHAR(16)  NOT NULL,
+    scopes       JSON         NOT NULL,
+    expires_at   DATETIME     NOT NULL,
+    last_used_at DATETIME     NULL,
+    created_at   DATETIME     NOT NULL,
+    UNIQUE INDEX idx_personal_access_tokens_hash (token_hash),
+    INDEX idx_personal_access_tokens_user (user_id)
+);
+
+-- Réplication vers la région de secours (voir 0014) : les tokens restent
+-- valables après un basculement
+
+DROP TRIGGER IF EXISTS personal_access_tokens_replicate_insert;
+
+CREATE TRIGGER personal_access_tokens_replicate_insert AFTER INSERT ON personal_access_tokens FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'personal_access_tokens', JSON_OBJECT('id', NEW.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS personal_access_tokens_replicate_update;
+
+CREATE TRIGGER personal_access_tokens_replicate_update AFTER UPDATE ON personal_access_tokens FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'personal_access_tokens', JSON_OBJECT('id', NEW.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS personal_access_tokens_replicate_delete;
+
+CREATE TRIGGER personal_access_tokens_replicate_delete AFTER DELETE ON personal_access_tokens FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'personal_access_tokens', JSON_OBJECT('id', OLD.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
@@ -0,0 +1,17 @@
+-- Lectures des valeurs des secrets agrégées par jour, charge de travail
+-- (en-tête X-Workload, vide si non déclarée) et principal. Ces compteurs ne
+-- sont pas répliqués, comme api_usage.
+
+CREATE TABLE IF NOT EXISTS secret_reads (
+    organization_id VARCHAR(36)  NOT NULL,
+    project_id      VARCHAR(36)  NOT NULL,
+    environment     VARCHAR(64)  NOT NULL,
+    secret_name     VARCHAR(255) NOT NULL,
+    day             DATE         NOT NULL,
+    workload        VARCHAR(128) NOT NULL,
+    principal_type  VARCHAR(32)  NOT NULL,
+    principal_id    VARCHAR(36)  NOT NULL,
+    read_count      BIGINT       NOT NULL DEFAULT 0,
+    last_read_at    TIMESTAMP    NOT NULL,
+    PRIMARY KEY (organization_id, project_id, environment, secret_name, day, workload, principal_type, principal_id)
+);
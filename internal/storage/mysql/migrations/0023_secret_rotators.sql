@@ -0,0 +1,43 @@
+-- Rotations gérées des secrets chez un fournisseur externe (AWS IAM,
+-- application GitHub, moteur de bases de données de Vault). Les
+-- identifiants du fournisseur restent dans le secret lié credentials_secret.
+
+CREATE TABLE IF NOT EXISTS secret_rotators (
+    id                 VARCHAR(36)  NOT NULL PRIMARY KEY,
+    organization_id    VARCHAR(36)  NOT NULL,
+    project_id         VARCHAR(36)  NOT NULL,
+    environment        VARCHAR(64)  NOT NULL,
+    secret_name        VARCHAR(255) NOT NULL,
+    provider           VARCHAR(32)  NOT NULL,
+    config             JSON         NOT NULL,
+    credentials_secret VARCHAR(255) NOT NULL,
+    created_by         VARCHAR(36)  NOT NULL,
+    created_at         DATETIME     NOT NULL,
+    updated_at         DATETIME     NOT NULL,
+    last_rotated_at    DATETIME     NULL,
+    last_error         TEXT         NOT NULL,
+    UNIQUE INDEX idx_secret_rotators_path (organization_id, project_id, environment, secret_name)
+);
+
+-- Réplication vers la région de secours (voir 0014)
+
+DROP TRIGGER IF EXISTS secret_rotators_replicate_insert;
+
+CREATE TRIGGER secret_rotators_replicate_insert AFTER INSERT ON secret_rotators FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'secret_rotators', JSON_OBJECT('id', NEW.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS secret_rotators_replicate_update;
+
+CREATE TRIGGER secret_rotators_replicate_update AFTER UPDATE ON secret_rotators FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'secret_rotators', JSON_OBJECT('id', NEW.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS secret_rotators_replicate_delete;
+
+CREATE TRIGGER secret_rotators_replicate_delete AFTER DELETE ON secret_rotators FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'secret_rotators', JSON_OBJECT('id', OLD.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
@@ -0,0 +1,8 @@
+-- Rotation double (blue/green) : l'ancienne valeur reste lisible sous
+-- {name}_previous jusqu'à previous_expires_at, puis elle est retirée
+
+ALTER TABLE secret_rotators
+    ADD COLUMN strategy            VARCHAR(16) NOT NULL DEFAULT 'replace',
+    ADD COLUMN overlap_seconds     BIGINT      NOT NULL DEFAULT 0,
+    ADD COLUMN previous_expires_at DATETIME    NULL,
+    ADD INDEX idx_secret_rotators_previous (previous_expires_at);
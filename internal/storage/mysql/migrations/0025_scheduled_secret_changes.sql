@@ -0,0 +1,39 @@
+-- Changements de valeur planifiés : la nouvelle valeur attend dans Vault
+-- (sous {org}/{projet}/.scheduled/{env}/{nom}) jusqu'à effective_at, puis
+-- une tâche périodique la promeut. Un secret a au plus un changement planifié.
+
+CREATE TABLE IF NOT EXISTS scheduled_secret_changes (
+    id              VARCHAR(36)  NOT NULL PRIMARY KEY,
+    organization_id VARCHAR(36)  NOT NULL,
+    project_id      VARCHAR(36)  NOT NULL,
+    environment     VARCHAR(64)  NOT NULL,
+    secret_name     VARCHAR(255) NOT NULL,
+    effective_at    DATETIME     NOT NULL,
+    created_by      VARCHAR(36)  NOT NULL,
+    created_at      DATETIME     NOT NULL,
+    UNIQUE INDEX idx_scheduled_secret_changes_path (organization_id, project_id, environment, secret_name),
+    INDEX idx_scheduled_secret_changes_due (effective_at)
+);
+
+-- Réplication vers la région de secours (voir 0014)
+
+DROP TRIGGER IF EXISTS scheduled_secret_changes_replicate_insert;
+
+CREATE TRIGGER scheduled_secret_changes_replicate_insert AFTER INSERT ON scheduled_secret_changes FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'scheduled_secret_changes', JSON_OBJECT('id', NEW.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS scheduled_secret_changes_replicate_update;
+
+CREATE TRIGGER scheduled_secret_changes_replicate_update AFTER UPDATE ON scheduled_secret_changes FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'scheduled_secret_changes', JSON_OBJECT('id', NEW.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS scheduled_secret_changes_replicate_delete;
+
+CREATE TRIGGER scheduled_secret_changes_replicate_delete AFTER DELETE ON scheduled_secret_changes FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'scheduled_secret_changes', JSON_OBJECT('id', OLD.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
@@ -0,0 +1,38 @@
+-- Fenêtres de maintenance des environnements : les changements planifiés et
+-- le retrait des valeurs précédentes des rotations d'un environnement qui a
+-- des fenêtres n'y sont appliqués que pendant l'une d'elles.
+
+CREATE TABLE IF NOT EXISTS maintenance_windows (
+    id               VARCHAR(36)  NOT NULL PRIMARY KEY,
+    organization_id  VARCHAR(36)  NOT NULL,
+    environment      VARCHAR(64)  NOT NULL,
+    schedule         VARCHAR(128) NOT NULL,
+    duration_minutes INT          NOT NULL,
+    timezone         VARCHAR(64)  NOT NULL,
+    created_by       VARCHAR(36)  NOT NULL,
+    created_at       DATETIME     NOT NULL,
+    INDEX idx_maintenance_windows_environment (organization_id, environment)
+);
+
+-- Réplication vers la région de secours (voir 0014)
+
+DROP TRIGGER IF EXISTS maintenance_windows_replicate_insert;
+
+CREATE TRIGGER maintenance_windows_replicate_insert AFTER INSERT ON maintenance_windows FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'maintenance_windows', JSON_OBJECT('id', NEW.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS maintenance_windows_replicate_update;
+
+CREATE TRIGGER maintenance_windows_replicate_update AFTER UPDATE ON maintenance_windows FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'maintenance_windows', JSON_OBJECT('id', NEW.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS maintenance_windows_replicate_delete;
+
+CREATE TRIGGER maintenance_windows_replicate_delete AFTER DELETE ON maintenance_windows FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'maintenance_windows', JSON_OBJECT('id', OLD.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
@@ -0,0 +1,52 @@
+-- Clés de données des projets pour le chiffrement d'enveloppe de petits
+-- contenus (états Terraform, fichiers sops). La clé elle-même est une clé
+-- transit de Vault ; seule sa référence est conservée ici.
+
+CREATE TABLE IF NOT EXISTS data_keys (
+    id              VARCHAR(36)  NOT NULL PRIMARY KEY,
+    organization_id VARCHAR(36)  NOT NULL,
+    project_id      VARCHAR(36)  NOT NULL,
+    name            VARCHAR(128) NOT NULL,
+    transit_key     VARCHAR(128) NOT NULL,
+    created_by      VARCHAR(36)  NOT NULL,
+    created_at      DATETIME     NOT NULL,
+    UNIQUE INDEX idx_data_keys_name (organization_id, project_id, name)
+);
+
+-- Audit de chaque chiffrement et déchiffrement (sans le contenu)
+CREATE TABLE IF NOT EXISTS data_key_operations (
+    id              VARCHAR(36)  NOT NULL PRIMARY KEY,
+    data_key_id     VARCHAR(36)  NOT NULL,
+    organization_id VARCHAR(36)  NOT NULL,
+    operation       VARCHAR(16)  NOT NULL,
+    actor_id        VARCHAR(36)  NOT NULL,
+    size            INT          NOT NULL,
+    ip_address      VARCHAR(45)  NOT NULL,
+    occurred_at     DATETIME(6)  NOT NULL,
+    error           VARCHAR(255) NOT NULL DEFAULT '',
+    INDEX idx_data_key_operations_key (data_key_id, occurred_at)
+);
+
+-- Réplication vers la région de secours (voir 0014). L'audit, comme les
+-- lectures des secrets, n'est pas répliqué.
+
+DROP TRIGGER IF EXISTS data_keys_replicate_insert;
+
+CREATE TRIGGER data_keys_replicate_insert AFTER INSERT ON data_keys FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'data_keys', JSON_OBJECT('id', NEW.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS data_keys_replicate_update;
+
+CREATE TRIGGER data_keys_replicate_update AFTER UPDATE ON data_keys FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'data_keys', JSON_OBJECT('id', NEW.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS data_keys_replicate_delete;
+
+CREATE TRIGGER data_keys_replicate_delete AFTER DELETE ON data_keys FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'data_keys', JSON_OBJECT('id', OLD.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
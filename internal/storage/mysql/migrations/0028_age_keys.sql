@@ -0,0 +1,40 @@
+-- Clés age (sops) des dépôts de code. Seul le destinataire (clé publique)
+-- est conservé ici ; l'identité est stockée dans Vault, sous le dossier
+-- .age-keys du projet. Après une rotation, les clés retirées restent
+-- servies pour déchiffrer les fichiers qui n'ont pas été rechiffrés.
+
+CREATE TABLE IF NOT EXISTS age_keys (
+    id              VARCHAR(36)  NOT NULL PRIMARY KEY,
+    organization_id VARCHAR(36)  NOT NULL,
+    project_id      VARCHAR(36)  NOT NULL,
+    repository      VARCHAR(128) NOT NULL,
+    recipient       VARCHAR(128) NOT NULL,
+    status          VARCHAR(16)  NOT NULL,
+    created_by      VARCHAR(36)  NOT NULL,
+    created_at      DATETIME(6)  NOT NULL,
+    retired_at      DATETIME(6)  NULL,
+    INDEX idx_age_keys_repository (organization_id, project_id, repository, created_at)
+);
+
+-- Réplication vers la région de secours (voir 0014)
+
+DROP TRIGGER IF EXISTS age_keys_replicate_insert;
+
+CREATE TRIGGER age_keys_replicate_insert AFTER INSERT ON age_keys FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'age_keys', JSON_OBJECT('id', NEW.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS age_keys_replicate_update;
+
+CREATE TRIGGER age_keys_replicate_update AFTER UPDATE ON age_keys FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'age_keys', JSON_OBJECT('id', NEW.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS age_keys_replicate_delete;
+
+CREATE TRIGGER age_keys_replicate_delete AFTER DELETE ON age_keys FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'age_keys', JSON_OBJECT('id', OLD.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
@@ -0,0 +1,24 @@
+-- Historique de santé des composants pour la page de statut publique :
+-- vérifications agrégées par composant et par jour, et incidents. Chaque
+-- région mesure ses propres composants, ces tables ne sont pas répliquées.
+
+CREATE TABLE IF NOT EXISTS health_checks (
+    component VARCHAR(64) NOT NULL,
+    day       DATE        NOT NULL,
+    checks    BIGINT      NOT NULL DEFAULT 0,
+    failures  BIGINT      NOT NULL DEFAULT 0,
+    PRIMARY KEY (component, day)
+);
+
+-- open_component vaut component tant que l'incident est en cours et NULL une
+-- fois résolu : l'index unique empêche deux instances d'ouvrir chacune un
+-- incident pour la même panne.
+CREATE TABLE IF NOT EXISTS health_incidents (
+    id             VARCHAR(36) NOT NULL PRIMARY KEY,
+    component      VARCHAR(64) NOT NULL,
+    open_component VARCHAR(64) NULL,
+    started_at     DATETIME    NOT NULL,
+    resolved_at    DATETIME    NULL,
+    UNIQUE INDEX idx_health_incidents_open (open_component),
+    INDEX idx_health_incidents_resolved (resolved_at)
+);
@@ -0,0 +1,41 @@
+-- Historique de la configuration des organisations (résidence, fenêtres de
+-- maintenance, webhooks, abonnement) : chaque modification est une version
+-- numérotée avec son auteur et l'état de l'élément avant et après.
+
+CREATE TABLE IF NOT EXISTS settings_changes (
+    id              VARCHAR(36) NOT NULL PRIMARY KEY,
+    organization_id VARCHAR(36) NOT NULL,
+    version         BIGINT      NOT NULL,
+    resource        VARCHAR(64) NOT NULL,
+    resource_id     VARCHAR(64) NOT NULL,
+    action          VARCHAR(16) NOT NULL,
+    changed_by      VARCHAR(36) NOT NULL,
+    before_state    JSON        NULL,
+    after_state     JSON        NULL,
+    created_at      DATETIME(6) NOT NULL,
+    UNIQUE INDEX idx_settings_changes_version (organization_id, version),
+    INDEX idx_settings_changes_resource (organization_id, resource, version)
+);
+
+-- Réplication vers la région de secours (voir 0014)
+
+DROP TRIGGER IF EXISTS settings_changes_replicate_insert;
+
+CREATE TRIGGER settings_changes_replicate_insert AFTER INSERT ON settings_changes FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'settings_changes', JSON_OBJECT('id', NEW.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS settings_changes_replicate_update;
+
+CREATE TRIGGER settings_changes_replicate_update AFTER UPDATE ON settings_changes FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'settings_changes', JSON_OBJECT('id', NEW.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS settings_changes_replicate_delete;
+
+CREATE TRIGGER settings_changes_replicate_delete AFTER DELETE ON settings_changes FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'settings_changes', JSON_OBJECT('id', OLD.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
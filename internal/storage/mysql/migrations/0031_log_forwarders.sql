@@ -0,0 +1,42 @@
+-- Destinations des journaux d'audit des organisations (HTTPS, S3, syslog),
+-- avec leurs filtres. secret contient le token Bearer ou la clé secrète S3.
+
+CREATE TABLE IF NOT EXISTS log_forwarders (
+    id              VARCHAR(36)  NOT NULL PRIMARY KEY,
+    organization_id VARCHAR(36)  NOT NULL,
+    kind            VARCHAR(16)  NOT NULL,
+    destination     VARCHAR(512) NOT NULL,
+    region          VARCHAR(64)  NOT NULL DEFAULT '',
+    endpoint        VARCHAR(512) NOT NULL DEFAULT '',
+    access_key_id   VARCHAR(128) NOT NULL DEFAULT '',
+    secret          VARCHAR(512) NOT NULL DEFAULT '',
+    methods         JSON         NOT NULL,
+    projects        JSON         NOT NULL,
+    failures_only   BOOLEAN      NOT NULL DEFAULT FALSE,
+    created_by      VARCHAR(36)  NOT NULL,
+    created_at      DATETIME     NOT NULL,
+    INDEX idx_log_forwarders_organization (organization_id)
+);
+
+-- Réplication vers la région de secours (voir 0014)
+
+DROP TRIGGER IF EXISTS log_forwarders_replicate_insert;
+
+CREATE TRIGGER log_forwarders_replicate_insert AFTER INSERT ON log_forwarders FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'log_forwarders', JSON_OBJECT('id', NEW.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS log_forwarders_replicate_update;
+
+CREATE TRIGGER log_forwarders_replicate_update AFTER UPDATE ON log_forwarders FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'log_forwarders', JSON_OBJECT('id', NEW.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS log_forwarders_replicate_delete;
+
+CREATE TRIGGER log_forwarders_replicate_delete AFTER DELETE ON log_forwarders FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'log_forwarders', JSON_OBJECT('id', OLD.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
@@ -0,0 +1,72 @@
+-- Rotations groupées des secrets d'une organisation et avancement de
+-- chaque secret
+
+CREATE TABLE IF NOT EXISTS bulk_rotations (
+    id              VARCHAR(36)  NOT NULL PRIMARY KEY,
+    organization_id VARCHAR(36)  NOT NULL,
+    path            VARCHAR(512) NOT NULL DEFAULT '',
+    provider        VARCHAR(64)  NOT NULL DEFAULT '',
+    requested_by    VARCHAR(36)  NOT NULL,
+    status          VARCHAR(16)  NOT NULL,
+    created_at      DATETIME     NOT NULL,
+    completed_at    DATETIME     NULL,
+    INDEX idx_bulk_rotations_organization (organization_id, created_at),
+    INDEX idx_bulk_rotations_status (status)
+);
+
+CREATE TABLE IF NOT EXISTS bulk_rotation_items (
+    id           VARCHAR(36)   NOT NULL PRIMARY KEY,
+    rotation_id  VARCHAR(36)   NOT NULL,
+    project_id   VARCHAR(36)   NOT NULL,
+    environment  VARCHAR(64)   NOT NULL,
+    secret_name  VARCHAR(255)  NOT NULL,
+    rotator_id   VARCHAR(36)   NOT NULL DEFAULT '',
+    status       VARCHAR(16)   NOT NULL,
+    error        VARCHAR(1024) NOT NULL DEFAULT '',
+    completed_at DATETIME      NULL,
+    INDEX idx_bulk_rotation_items_rotation (rotation_id)
+);
+
+-- Réplication vers la région de secours (voir 0014)
+
+DROP TRIGGER IF EXISTS bulk_rotations_replicate_insert;
+
+CREATE TRIGGER bulk_rotations_replicate_insert AFTER INSERT ON bulk_rotations FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'bulk_rotations', JSON_OBJECT('id', NEW.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS bulk_rotations_replicate_update;
+
+CREATE TRIGGER bulk_rotations_replicate_update AFTER UPDATE ON bulk_rotations FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'bulk_rotations', JSON_OBJECT('id', NEW.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS bulk_rotations_replicate_delete;
+
+CREATE TRIGGER bulk_rotations_replicate_delete AFTER DELETE ON bulk_rotations FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'bulk_rotations', JSON_OBJECT('id', OLD.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS bulk_rotation_items_replicate_insert;
+
+CREATE TRIGGER bulk_rotation_items_replicate_insert AFTER INSERT ON bulk_rotation_items FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'bulk_rotation_items', JSON_OBJECT('id', NEW.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS bulk_rotation_items_replicate_update;
+
+CREATE TRIGGER bulk_rotation_items_replicate_update AFTER UPDATE ON bulk_rotation_items FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'bulk_rotation_items', JSON_OBJECT('id', NEW.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS bulk_rotation_items_replicate_delete;
+
+CREATE TRIGGER bulk_rotation_items_replicate_delete AFTER DELETE ON bulk_rotation_items FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'bulk_rotation_items', JSON_OBJECT('id', OLD.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
@@ -0,0 +1,34 @@
+-- Authentification multifacteur (TOTP) des utilisateurs
+
+CREATE TABLE IF NOT EXISTS user_mfa (
+    user_id         VARCHAR(36) NOT NULL PRIMARY KEY,
+    secret          VARCHAR(64) NOT NULL,
+    enabled_at      DATETIME    NULL,
+    last_step       BIGINT      NOT NULL DEFAULT 0,
+    failed_attempts INT         NOT NULL DEFAULT 0,
+    locked_until    DATETIME    NULL,
+    created_at      DATETIME    NOT NULL
+);
+
+-- Réplication vers la région de secours (voir 0014)
+
+DROP TRIGGER IF EXISTS user_mfa_replicate_insert;
+
+CREATE TRIGGER user_mfa_replicate_insert AFTER INSERT ON user_mfa FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'user_mfa', JSON_OBJECT('user_id', NEW.user_id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS user_mfa_replicate_update;
+
+CREATE TRIGGER user_mfa_replicate_update AFTER UPDATE ON user_mfa FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'user_mfa', JSON_OBJECT('user_id', NEW.user_id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS user_mfa_replicate_delete;
+
+CREATE TRIGGER user_mfa_replicate_delete AFTER DELETE ON user_mfa FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'user_mfa', JSON_OBJECT('user_id', OLD.user_id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
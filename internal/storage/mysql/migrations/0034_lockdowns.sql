@@ -0,0 +1,43 @@
+-- Confinement des organisations compromises : révocation des sessions,
+-- changement de mot de passe obligatoire et gel des écritures
+
+ALTER TABLE users
+    ADD COLUMN password_reset_required BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN tokens_not_before DATETIME NULL;
+
+CREATE TABLE IF NOT EXISTS lockdowns (
+    id              VARCHAR(36)   NOT NULL PRIMARY KEY,
+    organization_id VARCHAR(36)   NOT NULL,
+    reason          VARCHAR(1024) NOT NULL DEFAULT '',
+    report_days     INT           NOT NULL,
+    affected_users  INT           NOT NULL DEFAULT 0,
+    revoked_tokens  INT           NOT NULL DEFAULT 0,
+    started_by      VARCHAR(36)   NOT NULL,
+    started_at      DATETIME      NOT NULL,
+    lifted_by       VARCHAR(36)   NOT NULL DEFAULT '',
+    lifted_at       DATETIME      NULL,
+    INDEX idx_lockdowns_organization (organization_id, lifted_at)
+);
+
+-- Réplication vers la région de secours (voir 0014)
+
+DROP TRIGGER IF EXISTS lockdowns_replicate_insert;
+
+CREATE TRIGGER lockdowns_replicate_insert AFTER INSERT ON lockdowns FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'lockdowns', JSON_OBJECT('id', NEW.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS lockdowns_replicate_update;
+
+CREATE TRIGGER lockdowns_replicate_update AFTER UPDATE ON lockdowns FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'lockdowns', JSON_OBJECT('id', NEW.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS lockdowns_replicate_delete;
+
+CREATE TRIGGER lockdowns_replicate_delete AFTER DELETE ON lockdowns FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'lockdowns', JSON_OBJECT('id', OLD.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
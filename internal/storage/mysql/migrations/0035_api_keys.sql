@@ -0,0 +1,43 @@
+-- Clés d'API des organisations (accès machine à machine des pipelines CI),
+-- restreintes à un projet et un environnement, en lecture ou en écriture
+
+CREATE TABLE IF NOT EXISTS api_keys (
+    id              VARCHAR(36)  NOT NULL PRIMARY KEY,
+    organization_id VARCHAR(36)  NOT NULL,
+    project_id      VARCHAR(36)  NOT NULL DEFAULT '',
+    environment     VARCHAR(64)  NOT NULL DEFAULT '',
+    name            VARCHAR(128) NOT NULL,
+    key_hash        CHAR(64)     NOT NULL,
+    prefix          VARCHAR(16)  NOT NULL,
+    permission      VARCHAR(16)  NOT NULL,
+    created_by      VARCHAR(36)  NOT NULL,
+    expires_at      DATETIME     NULL,
+    last_used_at    DATETIME     NULL,
+    created_at      DATETIME     NOT NULL,
+    UNIQUE INDEX idx_api_keys_hash (key_hash),
+    INDEX idx_api_keys_organization (organization_id)
+);
+
+-- Réplication vers la région de secours (voir 0014) : les clés restent
+-- valables après un basculement
+
+DROP TRIGGER IF EXISTS api_keys_replicate_insert;
+
+CREATE TRIGGER api_keys_replicate_insert AFTER INSERT ON api_keys FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'api_keys', JSON_OBJECT('id', NEW.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS api_keys_replicate_update;
+
+CREATE TRIGGER api_keys_replicate_update AFTER UPDATE ON api_keys FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'api_keys', JSON_OBJECT('id', NEW.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS api_keys_replicate_delete;
+
+CREATE TRIGGER api_keys_replicate_delete AFTER DELETE ON api_keys FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'api_keys', JSON_OBJECT('id', OLD.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
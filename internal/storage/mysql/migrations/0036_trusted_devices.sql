@@ -0,0 +1,41 @@
+-- Appareils de confiance des utilisateurs : après un code TOTP, la
+-- connexion depuis le même navigateur en est dispensée pendant une durée
+-- configurable (TRUSTED_DEVICE_DAYS)
+
+CREATE TABLE IF NOT EXISTS trusted_devices (
+    id           VARCHAR(36)  NOT NULL PRIMARY KEY,
+    user_id      VARCHAR(36)  NOT NULL,
+    name         VARCHAR(128) NOT NULL DEFAULT '',
+    token_hash   CHAR(64)     NOT NULL,
+    fingerprint  CHAR(64)     NOT NULL,
+    user_agent   VARCHAR(512) NOT NULL DEFAULT '',
+    ip_address   VARCHAR(45)  NOT NULL DEFAULT '',
+    expires_at   DATETIME     NOT NULL,
+    last_used_at DATETIME     NULL,
+    created_at   DATETIME     NOT NULL,
+    UNIQUE INDEX idx_trusted_devices_hash (token_hash),
+    INDEX idx_trusted_devices_user (user_id)
+);
+
+-- Réplication vers la région de secours (voir 0014)
+
+DROP TRIGGER IF EXISTS trusted_devices_replicate_insert;
+
+CREATE TRIGGER trusted_devices_replicate_insert AFTER INSERT ON trusted_devices FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'trusted_devices', JSON_OBJECT('id', NEW.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS trusted_devices_replicate_update;
+
+CREATE TRIGGER trusted_devices_replicate_update AFTER UPDATE ON trusted_devices FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'trusted_devices', JSON_OBJECT('id', NEW.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS trusted_devices_replicate_delete;
+
+CREATE TRIGGER trusted_devices_replicate_delete AFTER DELETE ON trusted_devices FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'trusted_devices', JSON_OBJECT('id', OLD.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
@@ -0,0 +1,51 @@
+-- Connexions des utilisateurs situées par GeoIP, pour détecter les
+-- déplacements impossibles et les nouveaux lieux, et politiques d'alerte
+-- des organisations
+
+CREATE TABLE IF NOT EXISTS login_events (
+    id          VARCHAR(36)  NOT NULL PRIMARY KEY,
+    user_id     VARCHAR(36)  NOT NULL,
+    ip_address  VARCHAR(45)  NOT NULL DEFAULT '',
+    user_agent  VARCHAR(512) NOT NULL DEFAULT '',
+    country     CHAR(2)      NOT NULL DEFAULT '',
+    city        VARCHAR(128) NOT NULL DEFAULT '',
+    latitude    DOUBLE       NOT NULL DEFAULT 0,
+    longitude   DOUBLE       NOT NULL DEFAULT 0,
+    anomaly     VARCHAR(32)  NOT NULL DEFAULT '',
+    step_up     BOOLEAN      NOT NULL DEFAULT FALSE,
+    occurred_at DATETIME(3)  NOT NULL,
+    INDEX idx_login_events_user (user_id, occurred_at),
+    INDEX idx_login_events_location (user_id, country, city)
+);
+
+CREATE TABLE IF NOT EXISTS login_alert_policies (
+    organization_id          VARCHAR(36) NOT NULL PRIMARY KEY,
+    notify_new_location      BOOLEAN     NOT NULL DEFAULT TRUE,
+    notify_impossible_travel BOOLEAN     NOT NULL DEFAULT TRUE,
+    step_up                  BOOLEAN     NOT NULL DEFAULT FALSE,
+    updated_at               DATETIME    NOT NULL
+);
+
+-- Réplication vers la région de secours (voir 0014) : seules les
+-- politiques le sont, les connexions restent dans la région primaire
+
+DROP TRIGGER IF EXISTS login_alert_policies_replicate_insert;
+
+CREATE TRIGGER login_alert_policies_replicate_insert AFTER INSERT ON login_alert_policies FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'login_alert_policies', JSON_OBJECT('organization_id', NEW.organization_id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS login_alert_policies_replicate_update;
+
+CREATE TRIGGER login_alert_policies_replicate_update AFTER UPDATE ON login_alert_policies FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'login_alert_policies', JSON_OBJECT('organization_id', NEW.organization_id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS login_alert_policies_replicate_delete;
+
+CREATE TRIGGER login_alert_policies_replicate_delete AFTER DELETE ON login_alert_policies FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'login_alert_policies', JSON_OBJECT('organization_id', OLD.organization_id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
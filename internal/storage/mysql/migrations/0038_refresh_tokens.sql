@@ -0,0 +1,44 @@
+-- Tokens de rafraîchissement : seule l'empreinte est conservée, avec
+-- l'appareil de la session. Chaque rafraîchissement remplace le token
+-- (rotated_at) ; présenter un token remplacé révoque toute la session.
+
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+    id                 VARCHAR(36)  NOT NULL PRIMARY KEY,
+    session_id         VARCHAR(36)  NOT NULL,
+    user_id            VARCHAR(36)  NOT NULL,
+    token_hash         CHAR(64)     NOT NULL,
+    mfa                BOOLEAN      NOT NULL DEFAULT FALSE,
+    user_agent         VARCHAR(512) NOT NULL DEFAULT '',
+    ip_address         VARCHAR(45)  NOT NULL DEFAULT '',
+    session_started_at DATETIME     NOT NULL,
+    expires_at         DATETIME     NOT NULL,
+    rotated_at         DATETIME     NULL,
+    revoked_at         DATETIME     NULL,
+    created_at         DATETIME     NOT NULL,
+    UNIQUE INDEX idx_refresh_tokens_hash (token_hash),
+    INDEX idx_refresh_tokens_session (session_id),
+    INDEX idx_refresh_tokens_user (user_id, revoked_at)
+);
+
+-- Réplication vers la région de secours (voir 0014)
+
+DROP TRIGGER IF EXISTS refresh_tokens_replicate_insert;
+
+CREATE TRIGGER refresh_tokens_replicate_insert AFTER INSERT ON refresh_tokens FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'refresh_tokens', JSON_OBJECT('id', NEW.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS refresh_tokens_replicate_update;
+
+CREATE TRIGGER refresh_tokens_replicate_update AFTER UPDATE ON refresh_tokens FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'refresh_tokens', JSON_OBJECT('id', NEW.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS refresh_tokens_replicate_delete;
+
+CREATE TRIGGER refresh_tokens_replicate_delete AFTER DELETE ON refresh_tokens FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'refresh_tokens', JSON_OBJECT('id', OLD.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
@@ -0,0 +1,34 @@
+-- Politiques de session des organisations : durée maximale, délai
+-- d'inactivité et nombre de sessions simultanées de leurs membres
+-- (0 : sans limite)
+
+CREATE TABLE IF NOT EXISTS session_policies (
+    organization_id      VARCHAR(36) NOT NULL PRIMARY KEY,
+    max_session_hours    INT         NOT NULL DEFAULT 0,
+    idle_timeout_minutes INT         NOT NULL DEFAULT 0,
+    max_sessions         INT         NOT NULL DEFAULT 0,
+    updated_at           DATETIME    NOT NULL
+);
+
+-- Réplication vers la région de secours (voir 0014)
+
+DROP TRIGGER IF EXISTS session_policies_replicate_insert;
+
+CREATE TRIGGER session_policies_replicate_insert AFTER INSERT ON session_policies FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'session_policies', JSON_OBJECT('organization_id', NEW.organization_id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS session_policies_replicate_update;
+
+CREATE TRIGGER session_policies_replicate_update AFTER UPDATE ON session_policies FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'session_policies', JSON_OBJECT('organization_id', NEW.organization_id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS session_policies_replicate_delete;
+
+CREATE TRIGGER session_policies_replicate_delete AFTER DELETE ON session_policies FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'session_policies', JSON_OBJECT('organization_id', OLD.organization_id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
@@ -0,0 +1,35 @@
+-- Environnements protégés : la lecture de la valeur de leurs secrets exige
+-- un motif, enregistré dans le journal d'audit
+
+CREATE TABLE IF NOT EXISTS read_reason_policies (
+    organization_id VARCHAR(36) NOT NULL PRIMARY KEY,
+    environments    JSON        NOT NULL,
+    updated_at      DATETIME    NOT NULL
+);
+
+ALTER TABLE audit_logs
+    ADD COLUMN reason VARCHAR(500) NOT NULL DEFAULT '',
+    ADD INDEX idx_audit_logs_organization (organization_id, timestamp);
+
+-- Réplication vers la région de secours (voir 0014)
+
+DROP TRIGGER IF EXISTS read_reason_policies_replicate_insert;
+
+CREATE TRIGGER read_reason_policies_replicate_insert AFTER INSERT ON read_reason_policies FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'read_reason_policies', JSON_OBJECT('organization_id', NEW.organization_id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS read_reason_policies_replicate_update;
+
+CREATE TRIGGER read_reason_policies_replicate_update AFTER UPDATE ON read_reason_policies FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'read_reason_policies', JSON_OBJECT('organization_id', NEW.organization_id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS read_reason_policies_replicate_delete;
+
+CREATE TRIGGER read_reason_policies_replicate_delete AFTER DELETE ON read_reason_policies FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'read_reason_policies', JSON_OBJECT('organization_id', OLD.organization_id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
@@ -0,0 +1,42 @@
+-- Invitations à rejoindre une organisation : seule l'empreinte du token
+-- envoyé par email est conservée. Une invitation acceptée (accepted_at) ou
+-- révoquée (revoked_at) n'est plus utilisable.
+
+CREATE TABLE IF NOT EXISTS invitations (
+    id              VARCHAR(36)  NOT NULL PRIMARY KEY,
+    organization_id VARCHAR(36)  NOT NULL,
+    email           VARCHAR(255) NOT NULL,
+    role            VARCHAR(20)  NOT NULL,
+    token_hash      CHAR(64)     NOT NULL,
+    invited_by      VARCHAR(36)  NOT NULL,
+    expires_at      DATETIME     NOT NULL,
+    accepted_at     DATETIME     NULL,
+    accepted_by     VARCHAR(36)  NOT NULL DEFAULT '',
+    revoked_at      DATETIME     NULL,
+    created_at      DATETIME     NOT NULL,
+    UNIQUE INDEX idx_invitations_hash (token_hash),
+    INDEX idx_invitations_organization (organization_id, email)
+);
+
+-- Réplication vers la région de secours (voir 0014)
+
+DROP TRIGGER IF EXISTS invitations_replicate_insert;
+
+CREATE TRIGGER invitations_replicate_insert AFTER INSERT ON invitations FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'invitations', JSON_OBJECT('id', NEW.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS invitations_replicate_update;
+
+CREATE TRIGGER invitations_replicate_update AFTER UPDATE ON invitations FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'invitations', JSON_OBJECT('id', NEW.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS invitations_replicate_delete;
+
+CREATE TRIGGER invitations_replicate_delete AFTER DELETE ON invitations FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'invitations', JSON_OBJECT('id', OLD.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
@@ -0,0 +1,10 @@
+-- Mesures de la valeur des secrets prises à l'écriture pour évaluer leur
+-- hygiène : longueur, entropie et empreinte salée par organisation, qui
+-- révèle les valeurs réutilisées. Les secrets existants sont mesurés à
+-- leur prochaine écriture.
+
+ALTER TABLE secret_metadata
+    ADD COLUMN value_hash    CHAR(64) NULL,
+    ADD COLUMN value_length  INT      NOT NULL DEFAULT 0,
+    ADD COLUMN value_entropy DOUBLE   NOT NULL DEFAULT 0,
+    ADD INDEX idx_secret_metadata_value_hash (organization_id, value_hash);
@@ -0,0 +1,37 @@
+-- README des projets et de leurs environnements, en markdown : procédure de
+-- rotation, responsables, contacts. environment est vide pour le README du
+-- projet.
+
+CREATE TABLE IF NOT EXISTS readmes (
+    organization_id VARCHAR(36)  NOT NULL,
+    project_id      VARCHAR(36)  NOT NULL,
+    environment     VARCHAR(64)  NOT NULL DEFAULT '',
+    content         MEDIUMTEXT   NOT NULL,
+    updated_by      VARCHAR(36)  NOT NULL,
+    updated_at      DATETIME     NOT NULL,
+    PRIMARY KEY (project_id, environment),
+    INDEX idx_readmes_organization (organization_id)
+);
+
+-- Réplication vers la région de secours (voir 0014)
+
+DROP TRIGGER IF EXISTS readmes_replicate_insert;
+
+CREATE TRIGGER readmes_replicate_insert AFTER INSERT ON readmes FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'readmes', JSON_OBJECT('project_id', NEW.project_id, 'environment', NEW.environment) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS readmes_replicate_update;
+
+CREATE TRIGGER readmes_replicate_update AFTER UPDATE ON readmes FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'readmes', JSON_OBJECT('project_id', NEW.project_id, 'environment', NEW.environment) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS readmes_replicate_delete;
+
+CREATE TRIGGER readmes_replicate_delete AFTER DELETE ON readmes FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'readmes', JSON_OBJECT('project_id', OLD.project_id, 'environment', OLD.environment) FROM DUAL
+    WHERE @metadata_replication IS NULL;
@@ -0,0 +1,75 @@
+-- Équipes des organisations et responsables des projets et des secrets.
+-- owner_id désigne un membre, team_id une équipe ; vides, un secret relève
+-- des responsables de son projet.
+
+CREATE TABLE IF NOT EXISTS teams (
+    id              VARCHAR(36)  NOT NULL PRIMARY KEY,
+    organization_id VARCHAR(36)  NOT NULL,
+    name            VARCHAR(100) NOT NULL,
+    description     VARCHAR(500) NOT NULL DEFAULT '',
+    created_by      VARCHAR(36)  NOT NULL,
+    created_at      DATETIME     NOT NULL,
+    UNIQUE INDEX idx_teams_name (organization_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS team_members (
+    team_id  VARCHAR(36) NOT NULL,
+    user_id  VARCHAR(36) NOT NULL,
+    added_by VARCHAR(36) NOT NULL,
+    added_at DATETIME    NOT NULL,
+    PRIMARY KEY (team_id, user_id),
+    INDEX idx_team_members_user (user_id)
+);
+
+ALTER TABLE projects
+    ADD COLUMN owner_id VARCHAR(36) NOT NULL DEFAULT '',
+    ADD COLUMN team_id  VARCHAR(36) NOT NULL DEFAULT '';
+
+ALTER TABLE secret_metadata
+    ADD COLUMN owner_id VARCHAR(36) NOT NULL DEFAULT '',
+    ADD COLUMN team_id  VARCHAR(36) NOT NULL DEFAULT '',
+    ADD INDEX idx_secret_metadata_team (organization_id, team_id);
+
+-- Réplication vers la région de secours (voir 0014)
+
+DROP TRIGGER IF EXISTS teams_replicate_insert;
+
+CREATE TRIGGER teams_replicate_insert AFTER INSERT ON teams FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'teams', JSON_OBJECT('id', NEW.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS teams_replicate_update;
+
+CREATE TRIGGER teams_replicate_update AFTER UPDATE ON teams FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'teams', JSON_OBJECT('id', NEW.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS teams_replicate_delete;
+
+CREATE TRIGGER teams_replicate_delete AFTER DELETE ON teams FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'teams', JSON_OBJECT('id', OLD.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS team_members_replicate_insert;
+
+CREATE TRIGGER team_members_replicate_insert AFTER INSERT ON team_members FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'team_members', JSON_OBJECT('team_id', NEW.team_id, 'user_id', NEW.user_id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS team_members_replicate_update;
+
+CREATE TRIGGER team_members_replicate_update AFTER UPDATE ON team_members FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'team_members', JSON_OBJECT('team_id', NEW.team_id, 'user_id', NEW.user_id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS team_members_replicate_delete;
+
+CREATE TRIGGER team_members_replicate_delete AFTER DELETE ON team_members FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'team_members', JSON_OBJECT('team_id', OLD.team_id, 'user_id', OLD.user_id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
@@ -0,0 +1,42 @@
+-- Comptes de service des organisations : identités machine qui échangent
+-- leur secret contre des JWT limités à des projets et environnements
+-- (resources, tableau JSON de {project_id, environment})
+
+CREATE TABLE IF NOT EXISTS service_accounts (
+    id              VARCHAR(36)  NOT NULL PRIMARY KEY,
+    organization_id VARCHAR(36)  NOT NULL,
+    name            VARCHAR(128) NOT NULL,
+    description     VARCHAR(500) NOT NULL DEFAULT '',
+    secret_hash     CHAR(64)     NOT NULL,
+    secret_prefix   VARCHAR(16)  NOT NULL,
+    permission      VARCHAR(16)  NOT NULL,
+    resources       JSON         NOT NULL,
+    created_by      VARCHAR(36)  NOT NULL,
+    last_used_at    DATETIME     NULL,
+    created_at      DATETIME     NOT NULL,
+    INDEX idx_service_accounts_organization (organization_id)
+);
+
+-- Réplication vers la région de secours (voir 0014) : les comptes restent
+-- utilisables après un basculement
+
+DROP TRIGGER IF EXISTS service_accounts_replicate_insert;
+
+CREATE TRIGGER service_accounts_replicate_insert AFTER INSERT ON service_accounts FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'service_accounts', JSON_OBJECT('id', NEW.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS service_accounts_replicate_update;
+
+CREATE TRIGGER service_accounts_replicate_update AFTER UPDATE ON service_accounts FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'service_accounts', JSON_OBJECT('id', NEW.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS service_accounts_replicate_delete;
+
+CREATE TRIGGER service_accounts_replicate_delete AFTER DELETE ON service_accounts FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'service_accounts', JSON_OBJECT('id', OLD.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
@@ -0,0 +1,37 @@
+-- Rôles accordés aux équipes sur les projets, dans un environnement ou dans
+-- tous (environment vide). Le rôle effectif d'un membre est le plus élevé
+-- de son rôle dans l'organisation et des rôles accordés à ses équipes.
+
+CREATE TABLE IF NOT EXISTS team_grants (
+    team_id     VARCHAR(36) NOT NULL,
+    project_id  VARCHAR(36) NOT NULL,
+    environment VARCHAR(64) NOT NULL DEFAULT '',
+    role        VARCHAR(20) NOT NULL,
+    granted_by  VARCHAR(36) NOT NULL,
+    granted_at  DATETIME    NOT NULL,
+    PRIMARY KEY (team_id, project_id, environment),
+    INDEX idx_team_grants_project (project_id)
+);
+
+-- Réplication vers la région de secours (voir 0014)
+
+DROP TRIGGER IF EXISTS team_grants_replicate_insert;
+
+CREATE TRIGGER team_grants_replicate_insert AFTER INSERT ON team_grants FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'team_grants', JSON_OBJECT('team_id', NEW.team_id, 'project_id', NEW.project_id, 'environment', NEW.environment) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS team_grants_replicate_update;
+
+CREATE TRIGGER team_grants_replicate_update AFTER UPDATE ON team_grants FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'team_grants', JSON_OBJECT('team_id', NEW.team_id, 'project_id', NEW.project_id, 'environment', NEW.environment) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS team_grants_replicate_delete;
+
+CREATE TRIGGER team_grants_replicate_delete AFTER DELETE ON team_grants FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'team_grants', JSON_OBJECT('team_id', OLD.team_id, 'project_id', OLD.project_id, 'environment', OLD.environment) FROM DUAL
+    WHERE @metadata_replication IS NULL;
@@ -0,0 +1,71 @@
+-- Rôles personnalisés des organisations : ensembles de permissions fines
+-- (permissions, tableau JSON de noms tels que "secrets.read") attribués
+-- aux membres en plus de leur rôle dans l'organisation
+
+CREATE TABLE IF NOT EXISTS custom_roles (
+    id              VARCHAR(36)  NOT NULL PRIMARY KEY,
+    organization_id VARCHAR(36)  NOT NULL,
+    name            VARCHAR(64)  NOT NULL,
+    description     VARCHAR(500) NOT NULL DEFAULT '',
+    permissions     JSON         NOT NULL,
+    created_by      VARCHAR(36)  NOT NULL,
+    created_at      DATETIME     NOT NULL,
+    updated_at      DATETIME     NOT NULL,
+    UNIQUE KEY uq_custom_roles_name (organization_id, name)
+);
+
+-- Un membre a au plus un rôle personnalisé par organisation
+
+CREATE TABLE IF NOT EXISTS custom_role_assignments (
+    organization_id VARCHAR(36) NOT NULL,
+    user_id         VARCHAR(36) NOT NULL,
+    role_id         VARCHAR(36) NOT NULL,
+    assigned_by     VARCHAR(36) NOT NULL,
+    assigned_at     DATETIME    NOT NULL,
+    PRIMARY KEY (organization_id, user_id),
+    INDEX idx_custom_role_assignments_role (role_id)
+);
+
+-- Réplication vers la région de secours (voir 0014)
+
+DROP TRIGGER IF EXISTS custom_roles_replicate_insert;
+
+CREATE TRIGGER custom_roles_replicate_insert AFTER INSERT ON custom_roles FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'custom_roles', JSON_OBJECT('id', NEW.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS custom_roles_replicate_update;
+
+CREATE TRIGGER custom_roles_replicate_update AFTER UPDATE ON custom_roles FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'custom_roles', JSON_OBJECT('id', NEW.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS custom_roles_replicate_delete;
+
+CREATE TRIGGER custom_roles_replicate_delete AFTER DELETE ON custom_roles FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'custom_roles', JSON_OBJECT('id', OLD.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS custom_role_assignments_replicate_insert;
+
+CREATE TRIGGER custom_role_assignments_replicate_insert AFTER INSERT ON custom_role_assignments FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'custom_role_assignments', JSON_OBJECT('organization_id', NEW.organization_id, 'user_id', NEW.user_id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS custom_role_assignments_replicate_update;
+
+CREATE TRIGGER custom_role_assignments_replicate_update AFTER UPDATE ON custom_role_assignments FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'custom_role_assignments', JSON_OBJECT('organization_id', NEW.organization_id, 'user_id', NEW.user_id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS custom_role_assignments_replicate_delete;
+
+CREATE TRIGGER custom_role_assignments_replicate_delete AFTER DELETE ON custom_role_assignments FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'custom_role_assignments', JSON_OBJECT('organization_id', OLD.organization_id, 'user_id', OLD.user_id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
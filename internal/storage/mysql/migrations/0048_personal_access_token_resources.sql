@@ -0,0 +1,5 @@
+-- Tokens d'accès personnels restreints à des projets, voire à des
+-- environnements (NULL : tous les projets de l'utilisateur)
+
+ALTER TABLE personal_access_tokens
+    ADD COLUMN resources JSON NULL AFTER scopes;
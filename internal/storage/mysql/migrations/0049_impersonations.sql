@@ -0,0 +1,46 @@
+-- Usurpations d'identité par le support : un administrateur de la
+-- plateforme agit au nom d'un utilisateur avec un token de courte durée
+-- (claim act_as), révocable en terminant l'usurpation (ended_at)
+
+CREATE TABLE IF NOT EXISTS impersonations (
+    id         VARCHAR(36)  NOT NULL PRIMARY KEY,
+    user_id    VARCHAR(36)  NOT NULL,
+    operator   VARCHAR(255) NOT NULL,
+    principal  VARCHAR(100) NOT NULL,
+    reason     VARCHAR(500) NOT NULL,
+    created_at DATETIME     NOT NULL,
+    expires_at DATETIME     NOT NULL,
+    ended_at   DATETIME     NULL,
+    INDEX idx_impersonations_user (user_id, created_at)
+);
+
+-- Requêtes de l'API faites sous une usurpation, inscrites au journal
+-- d'audit d'administration
+
+ALTER TABLE admin_audit_logs
+    ADD COLUMN impersonation_id VARCHAR(36) NULL,
+    ADD INDEX idx_admin_audit_logs_impersonation (impersonation_id, timestamp);
+
+-- Réplication vers la région de secours (voir 0014) : une usurpation
+-- terminée reste refusée après un basculement
+
+DROP TRIGGER IF EXISTS impersonations_replicate_insert;
+
+CREATE TRIGGER impersonations_replicate_insert AFTER INSERT ON impersonations FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'impersonations', JSON_OBJECT('id', NEW.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS impersonations_replicate_update;
+
+CREATE TRIGGER impersonations_replicate_update AFTER UPDATE ON impersonations FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'impersonations', JSON_OBJECT('id', NEW.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS impersonations_replicate_delete;
+
+CREATE TRIGGER impersonations_replicate_delete AFTER DELETE ON impersonations FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'impersonations', JSON_OBJECT('id', OLD.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
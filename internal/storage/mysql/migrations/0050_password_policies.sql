@@ -0,0 +1,67 @@
+-- Politiques de mot de passe des organisations (longueur minimale, classes
+-- de caractères, fuites connues, réutilisation) et historique des mots de
+-- passe remplacés, consulté pour en interdire la réutilisation
+
+CREATE TABLE IF NOT EXISTS password_policies (
+    organization_id   VARCHAR(36) NOT NULL PRIMARY KEY,
+    min_length        INT         NOT NULL DEFAULT 0,
+    require_uppercase BOOLEAN     NOT NULL DEFAULT FALSE,
+    require_lowercase BOOLEAN     NOT NULL DEFAULT FALSE,
+    require_digit     BOOLEAN     NOT NULL DEFAULT FALSE,
+    require_symbol    BOOLEAN     NOT NULL DEFAULT FALSE,
+    reject_breached   BOOLEAN     NOT NULL DEFAULT FALSE,
+    history_size      INT         NOT NULL DEFAULT 0,
+    updated_at        DATETIME    NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS password_history (
+    id              VARCHAR(36)  NOT NULL PRIMARY KEY,
+    user_id         VARCHAR(36)  NOT NULL,
+    hashed_password VARCHAR(255) NOT NULL,
+    created_at      DATETIME     NOT NULL,
+    INDEX idx_password_history_user (user_id, created_at)
+);
+
+-- Réplication vers la région de secours (voir 0014)
+
+DROP TRIGGER IF EXISTS password_policies_replicate_insert;
+
+CREATE TRIGGER password_policies_replicate_insert AFTER INSERT ON password_policies FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'password_policies', JSON_OBJECT('organization_id', NEW.organization_id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS password_policies_replicate_update;
+
+CREATE TRIGGER password_policies_replicate_update AFTER UPDATE ON password_policies FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'password_policies', JSON_OBJECT('organization_id', NEW.organization_id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS password_policies_replicate_delete;
+
+CREATE TRIGGER password_policies_replicate_delete AFTER DELETE ON password_policies FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'password_policies', JSON_OBJECT('organization_id', OLD.organization_id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS password_history_replicate_insert;
+
+CREATE TRIGGER password_history_replicate_insert AFTER INSERT ON password_history FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'password_history', JSON_OBJECT('id', NEW.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS password_history_replicate_update;
+
+CREATE TRIGGER password_history_replicate_update AFTER UPDATE ON password_history FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'password_history', JSON_OBJECT('id', NEW.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS password_history_replicate_delete;
+
+CREATE TRIGGER password_history_replicate_delete AFTER DELETE ON password_history FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'password_history', JSON_OBJECT('id', OLD.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
@@ -0,0 +1,37 @@
+-- Politique d'inscription réglée depuis le listener d'administration (une
+-- seule ligne, id = 1), qui remplace celle de la configuration (REGISTRATION_*)
+
+CREATE TABLE IF NOT EXISTS registration_policy (
+    id         TINYINT     NOT NULL PRIMARY KEY,
+    mode       VARCHAR(20) NOT NULL,
+    allow_list JSON        NOT NULL,
+    deny_list  JSON        NOT NULL,
+    updated_at DATETIME    NOT NULL
+);
+
+-- Recherche des invitations en attente d'une adresse à l'inscription
+
+CREATE INDEX idx_invitations_email ON invitations (email);
+
+-- Réplication vers la région de secours (voir 0014)
+
+DROP TRIGGER IF EXISTS registration_policy_replicate_insert;
+
+CREATE TRIGGER registration_policy_replicate_insert AFTER INSERT ON registration_policy FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'registration_policy', JSON_OBJECT('id', NEW.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS registration_policy_replicate_update;
+
+CREATE TRIGGER registration_policy_replicate_update AFTER UPDATE ON registration_policy FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'registration_policy', JSON_OBJECT('id', NEW.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS registration_policy_replicate_delete;
+
+CREATE TRIGGER registration_policy_replicate_delete AFTER DELETE ON registration_policy FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'registration_policy', JSON_OBJECT('id', OLD.id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
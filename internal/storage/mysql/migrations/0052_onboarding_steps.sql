@@ -0,0 +1,33 @@
+-- Étapes franchies de la prise en main : subject_id est l'utilisateur
+-- (email_verified) ou l'organisation (autres étapes)
+
+CREATE TABLE IF NOT EXISTS onboarding_steps (
+    subject_id   VARCHAR(36) NOT NULL,
+    step         VARCHAR(40) NOT NULL,
+    completed_by VARCHAR(36) NOT NULL,
+    completed_at DATETIME    NOT NULL,
+    PRIMARY KEY (subject_id, step)
+);
+
+-- Réplication vers la région de secours (voir 0014)
+
+DROP TRIGGER IF EXISTS onboarding_steps_replicate_insert;
+
+CREATE TRIGGER onboarding_steps_replicate_insert AFTER INSERT ON onboarding_steps FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'onboarding_steps', JSON_OBJECT('subject_id', NEW.subject_id, 'step', NEW.step) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS onboarding_steps_replicate_update;
+
+CREATE TRIGGER onboarding_steps_replicate_update AFTER UPDATE ON onboarding_steps FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'onboarding_steps', JSON_OBJECT('subject_id', NEW.subject_id, 'step', NEW.step) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS onboarding_steps_replicate_delete;
+
+CREATE TRIGGER onboarding_steps_replicate_delete AFTER DELETE ON onboarding_steps FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'onboarding_steps', JSON_OBJECT('subject_id', OLD.subject_id, 'step', OLD.step) FROM DUAL
+    WHERE @metadata_replication IS NULL;
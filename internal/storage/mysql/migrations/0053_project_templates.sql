@@ -0,0 +1,33 @@
+-- Modèles de projet : environnements, règle de nommage et secrets à
+-- renseigner appliqués à chaque projet créé dans l'organisation
+
+CREATE TABLE IF NOT EXISTS project_templates (
+    organization_id VARCHAR(36)  NOT NULL PRIMARY KEY,
+    environments    JSON         NOT NULL,
+    name_pattern    VARCHAR(200) NOT NULL DEFAULT '',
+    placeholders    JSON         NOT NULL,
+    updated_at      DATETIME     NOT NULL
+);
+
+-- Réplication vers la région de secours (voir 0014)
+
+DROP TRIGGER IF EXISTS project_templates_replicate_insert;
+
+CREATE TRIGGER project_templates_replicate_insert AFTER INSERT ON project_templates FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'project_templates', JSON_OBJECT('organization_id', NEW.organization_id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS project_templates_replicate_update;
+
+CREATE TRIGGER project_templates_replicate_update AFTER UPDATE ON project_templates FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'project_templates', JSON_OBJECT('organization_id', NEW.organization_id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS project_templates_replicate_delete;
+
+CREATE TRIGGER project_templates_replicate_delete AFTER DELETE ON project_templates FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'project_templates', JSON_OBJECT('organization_id', OLD.organization_id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
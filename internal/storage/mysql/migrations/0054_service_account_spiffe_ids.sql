@@ -0,0 +1,7 @@
+-- Authentification des comptes de service par certificat client sur le
+-- listener mTLS : identifiant SPIFFE (SAN URI) associé à au plus un compte
+-- (NULL : le compte ne s'authentifie que par son secret)
+
+ALTER TABLE service_accounts
+    ADD COLUMN spiffe_id VARCHAR(512) NULL AFTER resources,
+    ADD UNIQUE INDEX idx_service_accounts_spiffe_id (spiffe_id);
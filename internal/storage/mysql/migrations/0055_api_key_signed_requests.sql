@@ -0,0 +1,6 @@
+-- Clés d'API à requêtes signées : la clé n'est jamais présentée, chaque
+-- requête porte une signature HMAC de la méthode, du chemin, de
+-- l'horodatage et du corps
+
+ALTER TABLE api_keys
+    ADD COLUMN signed_requests BOOLEAN NOT NULL DEFAULT FALSE AFTER permission;
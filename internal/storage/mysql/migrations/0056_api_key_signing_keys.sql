@@ -0,0 +1,18 @@
+-- Clés de signature des clés d'API à requêtes signées : une clé de données
+-- propre à chaque clé, conservée enveloppée par le moteur transit de Vault,
+-- remplace l'empreinte de la clé. Les clés créées sans clé de signature ne
+-- signent plus de requêtes et doivent être recréées.
+
+ALTER TABLE api_keys
+    ADD COLUMN wrapped_signing_key VARCHAR(255) NOT NULL DEFAULT '' AFTER signed_requests;
+
+-- Signatures déjà acceptées, partagées entre les instances de l'API pour
+-- refuser les rejeux tant que leur horodatage est valide
+
+CREATE TABLE IF NOT EXISTS api_key_request_signatures (
+    api_key_id VARCHAR(36) NOT NULL,
+    signature  CHAR(64)    NOT NULL,
+    expires_at DATETIME    NOT NULL,
+    PRIMARY KEY (api_key_id, signature),
+    INDEX idx_api_key_request_signatures_expires (expires_at)
+);
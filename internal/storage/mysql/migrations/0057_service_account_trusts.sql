@@ -0,0 +1,43 @@
+-- Confiance mTLS des organisations : domaine de confiance SPIFFE et
+-- autorités (PEM) qui signent les certificats clients de leurs comptes de
+-- service. Un domaine n'appartient qu'à une organisation ; l'identifiant
+-- SPIFFE d'un compte n'est plus unique que dans son organisation.
+--
+-- Les comptes déjà associés à un identifiant SPIFFE ne s'authentifient plus
+-- par certificat tant que leur organisation n'a pas déclaré son domaine.
+
+CREATE TABLE IF NOT EXISTS service_account_trusts (
+    organization_id VARCHAR(36)  NOT NULL PRIMARY KEY,
+    trust_domain    VARCHAR(255) NOT NULL,
+    ca_bundle       MEDIUMTEXT   NOT NULL,
+    updated_by      VARCHAR(36)  NOT NULL,
+    updated_at      DATETIME     NOT NULL,
+    UNIQUE INDEX idx_service_account_trusts_domain (trust_domain)
+);
+
+ALTER TABLE service_accounts
+    DROP INDEX idx_service_accounts_spiffe_id,
+    ADD UNIQUE INDEX idx_service_accounts_org_spiffe_id (organization_id, spiffe_id);
+
+-- Réplication vers la région de secours (voir 0014)
+
+DROP TRIGGER IF EXISTS service_account_trusts_replicate_insert;
+
+CREATE TRIGGER service_account_trusts_replicate_insert AFTER INSERT ON service_account_trusts FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'service_account_trusts', JSON_OBJECT('organization_id', NEW.organization_id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS service_account_trusts_replicate_update;
+
+CREATE TRIGGER service_account_trusts_replicate_update AFTER UPDATE ON service_account_trusts FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'service_account_trusts', JSON_OBJECT('organization_id', NEW.organization_id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
+
+DROP TRIGGER IF EXISTS service_account_trusts_replicate_delete;
+
+CREATE TRIGGER service_account_trusts_replicate_delete AFTER DELETE ON service_account_trusts FOR EACH ROW
+    INSERT INTO metadata_changes (table_name, row_key)
+    SELECT 'service_account_trusts', JSON_OBJECT('organization_id', OLD.organization_id) FROM DUAL
+    WHERE @metadata_replication IS NULL;
@@ -0,0 +1,87 @@
+// filepath: internal/storage/mysql/notification_events_repository.go
+
+/*************************************************************************/
+/*                                                                       */
+/*   Ce fichier implémente le repository MySQL des événements notifiés   */
+/*   Il conserve l'historique utilisé par les résumés périodiques        */
+/*                                                                       */
+/*************************************************************************/
+
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+
+	"secrets-manager/internal/models"
+	repo "secrets-manager/internal/storage"
+)
+
+// NotificationEventsRepository gère l'historique des événements notifiés dans MySQL
+type NotificationEventsRepository struct {
+	db *sql.DB
+}
+
+var _ repo.NotificationEventsRepository = (*NotificationEventsRepository)(nil)
+
+// NewNotificationEventsRepository crée un nouveau repository d'événements notifiés
+func NewNotificationEventsRepository(db *sql.DB) *NotificationEventsRepository {
+	return &NotificationEventsRepository{
+		db: db,
+	}
+}
+
+// RecordNotificationEvent enregistre un événement
+func (r *NotificationEventsRepository) RecordNotificationEvent(ctx context.Context, event *models.NotificationEvent) error {
+	if event.ID == "" {
+		event.ID = uuid.New().String()
+	}
+
+	query := `
+		INSERT INTO notification_events (id, organization_id, type, actor_id, subject, message, occurred_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := r.db.ExecContext(ctx, query, event.ID, event.OrganizationID, event.Type, event.ActorID,
+		event.Subject, event.Message, event.OccurredAt)
+	return err
+}
+
+// ListNotificationEvents liste les événements d'une organisation sur une période
+func (r *NotificationEventsRepository) ListNotificationEvents(ctx context.Context, orgID string, since, until time.Time) ([]*models.NotificationEvent, error) {
+	query := `
+		SELECT id, organization_id, type, actor_id, subject, message, occurred_at
+		FROM notification_events
+		WHERE organization_id = ? AND occurred_at >= ? AND occurred_at < ?
+		ORDER BY occurred_at
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, orgID, since, until)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := []*models.NotificationEvent{}
+	for rows.Next() {
+		event := &models.NotificationEvent{}
+		if err := rows.Scan(&event.ID, &event.OrganizationID, &event.Type, &event.ActorID,
+			&event.Subject, &event.Message, &event.OccurredAt); err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
+
+// PurgeNotificationEvents supprime les événements survenus avant before
+func (r *NotificationEventsRepository) PurgeNotificationEvents(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM notification_events WHERE occurred_at < ?", before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
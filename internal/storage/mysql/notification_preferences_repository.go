@@ -0,0 +1,176 @@
+// filepath: internal/storage/mysql/notification_preferences_repository.go
+
+/*************************************************************************/
+/*                                                                       */
+/*   Ce fichier implémente le repository MySQL des notifications         */
+/*   Il gère les canaux choisis par chaque utilisateur par événement     */
+/*                                                                       */
+/*************************************************************************/
+
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"secrets-manager/internal/models"
+	repo "secrets-manager/internal/storage"
+)
+
+// NotificationPreferencesRepository gère les réglages de notification dans MySQL
+type NotificationPreferencesRepository struct {
+	db *sql.DB
+}
+
+var _ repo.NotificationPreferencesRepository = (*NotificationPreferencesRepository)(nil)
+
+// NewNotificationPreferencesRepository crée un nouveau repository de réglages de notification
+func NewNotificationPreferencesRepository(db *sql.DB) *NotificationPreferencesRepository {
+	return &NotificationPreferencesRepository{
+		db: db,
+	}
+}
+
+// GetNotificationPreferences récupère les réglages d'un utilisateur
+func (r *NotificationPreferencesRepository) GetNotificationPreferences(ctx context.Context, userID string) (*models.NotificationPreferences, error) {
+	query := `
+		SELECT ` + notificationSettingsColumns + `
+		FROM notification_settings
+		WHERE user_id = ?
+	`
+
+	prefs, err := scanNotificationSettings(r.db.QueryRowContext(ctx, query, userID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.loadEvents(ctx, prefs); err != nil {
+		return nil, err
+	}
+	return prefs, nil
+}
+
+// SetNotificationPreferences remplace les réglages d'un utilisateur dans une transaction
+func (r *NotificationPreferencesRepository) SetNotificationPreferences(ctx context.Context, prefs *models.NotificationPreferences) error {
+	prefs.UpdatedAt = time.Now()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO notification_settings (user_id, slack_webhook_url, digest, updated_at)
+		VALUES (?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE slack_webhook_url = VALUES(slack_webhook_url), digest = VALUES(digest),
+			updated_at = VALUES(updated_at)
+	`, prefs.UserID, prefs.SlackWebhookURL, prefs.Digest, prefs.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM notification_preferences WHERE user_id = ?", prefs.UserID); err != nil {
+		return err
+	}
+	for eventType, channels := range prefs.Events {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO notification_preferences (user_id, event_type, email, slack)
+			VALUES (?, ?, ?, ?)
+		`, prefs.UserID, eventType, channels.Email, channels.Slack)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// ListDigestSubscribers liste les réglages des utilisateurs qui reçoivent des résumés
+func (r *NotificationPreferencesRepository) ListDigestSubscribers(ctx context.Context) ([]*models.NotificationPreferences, error) {
+	query := `
+		SELECT ` + notificationSettingsColumns + `
+		FROM notification_settings
+		WHERE digest <> ''
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	subscribers := []*models.NotificationPreferences{}
+	for rows.Next() {
+		prefs, err := scanNotificationSettings(rows)
+		if err != nil {
+			return nil, err
+		}
+		subscribers = append(subscribers, prefs)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, prefs := range subscribers {
+		if err := r.loadEvents(ctx, prefs); err != nil {
+			return nil, err
+		}
+	}
+	return subscribers, nil
+}
+
+// MarkDigestSent enregistre la date du dernier résumé envoyé à un utilisateur
+func (r *NotificationPreferencesRepository) MarkDigestSent(ctx context.Context, userID string, at time.Time) error {
+	query := "UPDATE notification_settings SET last_digest_at = ? WHERE user_id = ?"
+
+	_, err := r.db.ExecContext(ctx, query, at, userID)
+	return err
+}
+
+// loadEvents lit les canaux choisis pour chaque type d'événement
+func (r *NotificationPreferencesRepository) loadEvents(ctx context.Context, prefs *models.NotificationPreferences) error {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT event_type, email, slack
+		FROM notification_preferences
+		WHERE user_id = ?
+	`, prefs.UserID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	prefs.Events = make(map[string]models.NotificationChannels)
+	for rows.Next() {
+		var eventType string
+		var channels models.NotificationChannels
+		if err := rows.Scan(&eventType, &channels.Email, &channels.Slack); err != nil {
+			return err
+		}
+		prefs.Events[eventType] = channels
+	}
+	return rows.Err()
+}
+
+// Colonnes lues par scanNotificationSettings, dans le même ordre
+const notificationSettingsColumns = `user_id, slack_webhook_url, digest, last_digest_at, updated_at`
+
+// scanNotificationSettings lit une ligne sélectionnée avec notificationSettingsColumns
+func scanNotificationSettings(row rowScanner) (*models.NotificationPreferences, error) {
+	prefs := &models.NotificationPreferences{}
+	var lastDigestAt sql.NullTime
+
+	err := row.Scan(&prefs.UserID, &prefs.SlackWebhookURL, &prefs.Digest, &lastDigestAt, &prefs.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if lastDigestAt.Valid {
+		prefs.LastDigestAt = &lastDigestAt.Time
+	}
+	return prefs, nil
+}
@@ -0,0 +1,92 @@
+// filepath: internal/storage/mysql/onboarding_repository.go
+
+/*************************************************************************/
+/*                                                                       */
+/*   Ce fichier implémente le repository MySQL des étapes franchies      */
+/*   de la prise en main                                                 */
+/*                                                                       */
+/*************************************************************************/
+
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"secrets-manager/internal/models"
+	repo "secrets-manager/internal/storage"
+)
+
+// OnboardingRepository gère les étapes de la prise en main dans MySQL
+type OnboardingRepository struct {
+	db *sql.DB
+}
+
+var _ repo.OnboardingRepository = (*OnboardingRepository)(nil)
+
+// NewOnboardingRepository crée un nouveau repository de prise en main
+func NewOnboardingRepository(db *sql.DB) *OnboardingRepository {
+	return &OnboardingRepository{
+		db: db,
+	}
+}
+
+// CompleteOnboardingStep enregistre une étape franchie et renvoie vrai si
+// elle ne l'était pas encore
+func (r *OnboardingRepository) CompleteOnboardingStep(ctx context.Context, step *models.OnboardingStep) (bool, error) {
+	if step.CompletedAt.IsZero() {
+		step.CompletedAt = time.Now()
+	}
+
+	// L'étape déjà franchie garde son auteur et sa date
+	query := `
+		INSERT IGNORE INTO onboarding_steps (subject_id, step, completed_by, completed_at)
+		VALUES (?, ?, ?, ?)
+	`
+
+	result, err := r.db.ExecContext(ctx, query, step.SubjectID, step.Step, step.CompletedBy, step.CompletedAt)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// ListOnboardingSteps liste les étapes franchies par les sujets
+func (r *OnboardingRepository) ListOnboardingSteps(ctx context.Context, subjectIDs ...string) ([]*models.OnboardingStep, error) {
+	if len(subjectIDs) == 0 {
+		return []*models.OnboardingStep{}, nil
+	}
+
+	query := `
+		SELECT subject_id, step, completed_by, completed_at
+		FROM onboarding_steps
+		WHERE subject_id IN (?` + strings.Repeat(", ?", len(subjectIDs)-1) + `)
+	`
+	args := make([]interface{}, 0, len(subjectIDs))
+	for _, id := range subjectIDs {
+		args = append(args, id)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	steps := []*models.OnboardingStep{}
+	for rows.Next() {
+		step := &models.OnboardingStep{}
+		if err := rows.Scan(&step.SubjectID, &step.Step, &step.CompletedBy, &step.CompletedAt); err != nil {
+			return nil, err
+		}
+		steps = append(steps, step)
+	}
+
+	return steps, rows.Err()
+}
@@ -0,0 +1,177 @@
+// filepath: internal/storage/mysql/organization_deletions_repository.go
+
+/*************************************************************************/
+/*                                                                       */
+/*   Ce fichier implémente le repository MySQL des suppressions          */
+/*   Il suit l'avancement des suppressions d'organisations               */
+/*                                                                       */
+/*************************************************************************/
+
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+
+	"secrets-manager/internal/models"
+	repo "secrets-manager/internal/storage"
+)
+
+// OrganizationDeletionsRepository suit les suppressions d'organisations dans MySQL
+type OrganizationDeletionsRepository struct {
+	db *sql.DB
+}
+
+var _ repo.OrganizationDeletionsRepository = (*OrganizationDeletionsRepository)(nil)
+
+// NewOrganizationDeletionsRepository crée un nouveau repository de suppressions
+func NewOrganizationDeletionsRepository(db *sql.DB) *OrganizationDeletionsRepository {
+	return &OrganizationDeletionsRepository{
+		db: db,
+	}
+}
+
+// CreateOrganizationDeletion enregistre une nouvelle suppression en attente
+func (r *OrganizationDeletionsRepository) CreateOrganizationDeletion(ctx context.Context, deletion *models.OrganizationDeletion) error {
+	if deletion.ID == "" {
+		deletion.ID = uuid.New().String()
+	}
+
+	query := `
+		INSERT INTO organization_deletions (
+			id, organization_id, requested_by, status, stage,
+			completed_stages, secrets_deleted, purge_after, error, created_at, updated_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, NOW(), NOW())
+	`
+
+	_, err := r.db.ExecContext(
+		ctx,
+		query,
+		deletion.ID,
+		deletion.OrganizationID,
+		deletion.RequestedBy,
+		deletion.Status,
+		deletion.Stage,
+		deletion.CompletedStages,
+		deletion.SecretsDeleted,
+		deletion.PurgeAfter,
+		deletion.Error,
+	)
+
+	return err
+}
+
+// GetOrganizationDeletion récupère une suppression par son ID
+func (r *OrganizationDeletionsRepository) GetOrganizationDeletion(ctx context.Context, id string) (*models.OrganizationDeletion, error) {
+	query := `
+		SELECT ` + organizationDeletionColumns + `
+		FROM organization_deletions
+		WHERE id = ?
+	`
+
+	deletion, err := scanOrganizationDeletion(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repo.ErrDeletionNotFound
+		}
+		return nil, err
+	}
+
+	return deletion, nil
+}
+
+// UpdateOrganizationDeletion enregistre l'avancement d'une suppression
+func (r *OrganizationDeletionsRepository) UpdateOrganizationDeletion(ctx context.Context, deletion *models.OrganizationDeletion) error {
+	query := `
+		UPDATE organization_deletions
+		SET status = ?, stage = ?, completed_stages = ?, secrets_deleted = ?,
+			purge_after = ?, error = ?, updated_at = NOW(), completed_at = ?
+		WHERE id = ?
+	`
+
+	_, err := r.db.ExecContext(
+		ctx,
+		query,
+		deletion.Status,
+		deletion.Stage,
+		deletion.CompletedStages,
+		deletion.SecretsDeleted,
+		deletion.PurgeAfter,
+		deletion.Error,
+		deletion.CompletedAt,
+		deletion.ID,
+	)
+
+	return err
+}
+
+// ListUnfinishedOrganizationDeletions liste les suppressions en attente ou interrompues
+func (r *OrganizationDeletionsRepository) ListUnfinishedOrganizationDeletions(ctx context.Context) ([]*models.OrganizationDeletion, error) {
+	query := `
+		SELECT ` + organizationDeletionColumns + `
+		FROM organization_deletions
+		WHERE status IN (?, ?)
+		ORDER BY created_at
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, models.DeletionPending, models.DeletionRunning)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deletions := []*models.OrganizationDeletion{}
+	for rows.Next() {
+		deletion, err := scanOrganizationDeletion(rows)
+		if err != nil {
+			return nil, err
+		}
+		deletions = append(deletions, deletion)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return deletions, nil
+}
+
+// Colonnes lues par scanOrganizationDeletion, dans le même ordre
+const organizationDeletionColumns = `id, organization_id, requested_by, status, stage,
+			   completed_stages, secrets_deleted, purge_after, error, created_at, updated_at, completed_at`
+
+// scanOrganizationDeletion lit une ligne sélectionnée avec organizationDeletionColumns
+func scanOrganizationDeletion(row rowScanner) (*models.OrganizationDeletion, error) {
+	deletion := &models.OrganizationDeletion{}
+	var errMsg sql.NullString
+	var completedAt sql.NullTime
+
+	err := row.Scan(
+		&deletion.ID,
+		&deletion.OrganizationID,
+		&deletion.RequestedBy,
+		&deletion.Status,
+		&deletion.Stage,
+		&deletion.CompletedStages,
+		&deletion.SecretsDeleted,
+		&deletion.PurgeAfter,
+		&errMsg,
+		&deletion.CreatedAt,
+		&deletion.UpdatedAt,
+		&completedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	deletion.Error = errMsg.String
+	if completedAt.Valid {
+		deletion.CompletedAt = &completedAt.Time
+	}
+	deletion.TotalStages = len(models.OrganizationDeletionStages)
+
+	return deletion, nil
+}
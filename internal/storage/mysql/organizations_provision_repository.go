@@ -0,0 +1,112 @@
+// filepath: internal/storage/mysql/organizations_provision_repository.go
+
+/*************************************************************************/
+/*                                                                       */
+/*   Ce fichier implémente la création d'une organisation avec ses       */
+/*   ressources de départ dans une seule transaction MySQL               */
+/*                                                                       */
+/*************************************************************************/
+
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"secrets-manager/internal/models"
+)
+
+// ProvisionOrganization crée l'organisation, l'appartenance admin de son
+// propriétaire, l'abonnement, le projet et les environnements de la
+// provision dans une seule transaction. Les identifiants et les dates sont
+// renseignés sur la provision.
+func (r *OrganizationsRepository) ProvisionOrganization(ctx context.Context, provision *models.OrganizationProvision) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Vérifier si le nom existe déjà
+	org := provision.Organization
+	var exists bool
+	err = tx.QueryRowContext(ctx,
+		"SELECT EXISTS(SELECT 1 FROM organizations WHERE name = ?)", org.Name).Scan(&exists)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return ErrOrganizationNameExists
+	}
+
+	now := time.Now()
+	prepareProvision(provision, now)
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO organizations (id, name, description, plan_id, created_at, updated_at, owner_id)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`, org.ID, org.Name, org.Description, org.PlanID, org.CreatedAt, org.UpdatedAt, org.OwnerID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO user_organizations (user_id, organization_id, role, created_at, updated_at)
+		VALUES (?, ?, 'admin', ?, ?)
+	`, org.OwnerID, org.ID, now, now)
+	if err != nil {
+		return err
+	}
+
+	subscription := provision.Subscription
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO subscriptions (
+			id, organization_id, plan_id, status, secrets_limit,
+			start_date, end_date, created_at, updated_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, subscription.ID, subscription.OrganizationID, subscription.PlanID, subscription.Status,
+		subscription.SecretsLimit, subscription.StartDate, subscription.EndDate, subscription.CreatedAt,
+		subscription.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	project := provision.Project
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO projects (id, name, description, organization_id, created_at, updated_at, created_by,
+			owner_id, team_id)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, project.ID, project.Name, project.Description, project.OrganizationID, project.CreatedAt,
+		project.UpdatedAt, project.CreatedBy, project.OwnerID, project.TeamID)
+	if err != nil {
+		return err
+	}
+
+	if err := insertEnvironments(ctx, tx, project, provision.Environments, now); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// prepareProvision renseigne les identifiants, les dates et les liens
+// entre les ressources de la provision (ceux des environnements le sont à
+// leur création)
+func prepareProvision(provision *models.OrganizationProvision, now time.Time) {
+	org := provision.Organization
+	org.ID = uuid.New().String()
+	org.CreatedAt, org.UpdatedAt = now, now
+	org.PlanID = provision.Subscription.PlanID
+
+	subscription := provision.Subscription
+	subscription.ID = uuid.New().String()
+	subscription.OrganizationID = org.ID
+	subscription.CreatedAt, subscription.UpdatedAt = now, now
+
+	project := provision.Project
+	project.ID = uuid.New().String()
+	project.OrganizationID = org.ID
+	project.CreatedAt, project.UpdatedAt = now, now
+}
@@ -18,13 +18,16 @@ import (
 	"github.com/google/uuid"
 
 	"secrets-manager/internal/models"
+	repo "secrets-manager/internal/storage"
 )
 
 // ErrOrganizationNotFound indique qu'une organisation n'a pas été trouvée
-var ErrOrganizationNotFound = errors.New("organisation non trouvée")
+var ErrOrganizationNotFound = repo.ErrOrganizationNotFound
 
 // ErrOrganizationNameExists indique qu'une organisation avec ce nom existe déjà
-var ErrOrganizationNameExists = errors.New("une organisation avec ce nom existe déjà")
+var ErrOrganizationNameExists = repo.ErrOrganizationNameExists
+
+var _ repo.OrganizationsRepository = (*OrganizationsRepository)(nil)
 
 // OrganizationsRepository gère l'accès aux données d'organisation dans MySQL
 type OrganizationsRepository struct {
@@ -351,6 +354,55 @@ func (r *OrganizationsRepository) ListOrganizationUsers(ctx context.Context, org
 	return userOrgs, nil
 }
 
+// ListOrganizationMembers liste les membres d'une organisation avec leur
+// profil et leur rôle en une seule requête
+func (r *OrganizationsRepository) ListOrganizationMembers(ctx context.Context, orgID string) ([]*models.OrganizationMember, error) {
+	query := `
+		SELECT ` + organizationMemberColumns + `
+		FROM user_organizations uo
+		JOIN users u ON u.id = uo.user_id
+		WHERE uo.organization_id = ?
+		ORDER BY u.last_name, u.first_name, u.id
+	`
+
+	return queryOrganizationMembers(ctx, r.db, query, orgID)
+}
+
+// Colonnes lues par queryOrganizationMembers (users u JOIN user_organizations uo)
+const organizationMemberColumns = `u.id, u.email, u.first_name, u.last_name, uo.role, uo.created_at`
+
+// queryOrganizationMembers exécute une requête sélectionnant organizationMemberColumns
+func queryOrganizationMembers(ctx context.Context, db *sql.DB, query string, args ...interface{}) ([]*models.OrganizationMember, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	members := []*models.OrganizationMember{}
+	for rows.Next() {
+		member := &models.OrganizationMember{}
+		err := rows.Scan(
+			&member.UserID,
+			&member.Email,
+			&member.FirstName,
+			&member.LastName,
+			&member.Role,
+			&member.JoinedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, member)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
+
 // AddUserToOrganization ajoute un utilisateur à une organisation
 func (r *OrganizationsRepository) AddUserToOrganization(ctx context.Context, userID, orgID, role string) error {
 	// Vérifier si l'utilisateur est déjà dans l'organisation
@@ -522,3 +574,171 @@ func (r *OrganizationsRepository) CountOrganizationSecrets(ctx context.Context,
 	
 	return count, nil
 }
+
+// DeleteOrganizationStage supprime les données d'une étape de suppression.
+// Chaque étape est idempotente pour permettre la reprise d'une suppression interrompue.
+func (r *OrganizationsRepository) DeleteOrganizationStage(ctx context.Context, orgID, stage string) error {
+	var queries []string
+	switch stage {
+	case models.DeletionStageMemberships:
+		queries = []string{
+			"DELETE FROM user_organizations WHERE organization_id = ?",
+			"DELETE FROM team_members WHERE team_id IN (SELECT id FROM teams WHERE organization_id = ?)",
+			"DELETE FROM team_grants WHERE team_id IN (SELECT id FROM teams WHERE organization_id = ?)",
+			"DELETE FROM teams WHERE organization_id = ?",
+			"DELETE FROM service_accounts WHERE organization_id = ?",
+			"DELETE FROM service_account_trusts WHERE organization_id = ?",
+			"DELETE FROM custom_role_assignments WHERE organization_id = ?",
+			"DELETE FROM custom_roles WHERE organization_id = ?",
+		}
+	case models.DeletionStageSecretMetadata:
+		queries = []string{"DELETE FROM secret_metadata WHERE organization_id = ?"}
+	case models.DeletionStageProjects:
+		queries = []string{
+			"DELETE FROM environments WHERE project_id IN (SELECT id FROM projects WHERE organization_id = ?)",
+			"DELETE FROM project_encryption_keys WHERE project_id IN (SELECT id FROM projects WHERE organization_id = ?)",
+			"DELETE FROM readmes WHERE organization_id = ?",
+			"DELETE FROM projects WHERE organization_id = ?",
+		}
+	case models.DeletionStageSubscriptions:
+		queries = []string{
+			"DELETE FROM subscriptions WHERE organization_id = ?",
+			"DELETE FROM usage_statistics WHERE organization_id = ?",
+		}
+	case models.DeletionStageOrganization:
+		queries = []string{
+			"DELETE FROM webhook_deliveries WHERE organization_id = ?",
+			"DELETE FROM webhooks WHERE organization_id = ?",
+			"DELETE FROM notification_events WHERE organization_id = ?",
+			"DELETE FROM organizations WHERE id = ?",
+		}
+	default:
+		// Étapes hors base de données (ex: valeurs Vault)
+		return nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, query := range queries {
+		if _, err := tx.ExecContext(ctx, query, orgID); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// SuspendOrganization place l'organisation dans la corbeille
+func (r *OrganizationsRepository) SuspendOrganization(ctx context.Context, orgID string) error {
+	return r.setDeletedAt(ctx, "UPDATE organizations SET deleted_at = NOW() WHERE id = ? AND deleted_at IS NULL", orgID)
+}
+
+// RestoreOrganization sort l'organisation de la corbeille
+func (r *OrganizationsRepository) RestoreOrganization(ctx context.Context, orgID string) error {
+	return r.setDeletedAt(ctx, "UPDATE organizations SET deleted_at = NULL WHERE id = ? AND deleted_at IS NOT NULL", orgID)
+}
+
+// setDeletedAt exécute une mise à jour de deleted_at et renvoie
+// ErrOrganizationNotFound si aucune organisation n'a changé d'état
+func (r *OrganizationsRepository) setDeletedAt(ctx context.Context, query, orgID string) error {
+	result, err := r.db.ExecContext(ctx, query, orgID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrOrganizationNotFound
+	}
+
+	return nil
+}
+
+// ListSuspendedOrganizations liste les organisations de la corbeille
+func (r *OrganizationsRepository) ListSuspendedOrganizations(ctx context.Context) ([]*models.Organization, error) {
+	query := `
+		SELECT id, name, description, plan_id, created_at, updated_at, owner_id, deleted_at
+		FROM organizations
+		WHERE deleted_at IS NOT NULL
+		ORDER BY deleted_at
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	orgs := []*models.Organization{}
+	for rows.Next() {
+		org := &models.Organization{}
+		err := rows.Scan(
+			&org.ID,
+			&org.Name,
+			&org.Description,
+			&org.PlanID,
+			&org.CreatedAt,
+			&org.UpdatedAt,
+			&org.OwnerID,
+			&org.DeletedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		orgs = append(orgs, org)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return orgs, nil
+}
+
+// GetOrganizationRegion récupère la région de résidence d'une organisation
+func (r *OrganizationsRepository) GetOrganizationRegion(ctx context.Context, orgID string) (string, error) {
+	query := "SELECT region FROM organizations WHERE id = ?"
+
+	var region string
+	err := r.db.QueryRowContext(ctx, query, orgID).Scan(&region)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", ErrOrganizationNotFound
+		}
+		return "", err
+	}
+
+	return region, nil
+}
+
+// UpdateOrganizationRegion change la région de résidence d'une organisation
+func (r *OrganizationsRepository) UpdateOrganizationRegion(ctx context.Context, orgID, region string) error {
+	query := `
+		UPDATE organizations
+		SET region = ?, updated_at = NOW()
+		WHERE id = ?
+	`
+
+	result, err := r.db.ExecContext(ctx, query, region, orgID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrOrganizationNotFound
+	}
+
+	return nil
+}
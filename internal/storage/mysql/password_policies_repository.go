@@ -0,0 +1,136 @@
+// filepath: internal/storage/mysql/password_policies_repository.go
+
+/*************************************************************************/
+/*                                                                       */
+/*   Ce fichier implémente le repository MySQL des politiques de mot     */
+/*   de passe des organisations et de l'historique des mots de passe     */
+/*                                                                       */
+/*************************************************************************/
+
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"secrets-manager/internal/models"
+	repo "secrets-manager/internal/storage"
+)
+
+// PasswordPoliciesRepository gère les politiques de mot de passe dans MySQL
+type PasswordPoliciesRepository struct {
+	db *sql.DB
+}
+
+var _ repo.PasswordPoliciesRepository = (*PasswordPoliciesRepository)(nil)
+
+// NewPasswordPoliciesRepository crée un nouveau repository de politiques de mot de passe
+func NewPasswordPoliciesRepository(db *sql.DB) *PasswordPoliciesRepository {
+	return &PasswordPoliciesRepository{
+		db: db,
+	}
+}
+
+// GetPasswordPolicy renvoie la politique de l'organisation
+func (r *PasswordPoliciesRepository) GetPasswordPolicy(ctx context.Context, orgID string) (*models.PasswordPolicy, error) {
+	query := `
+		SELECT organization_id, min_length, require_uppercase, require_lowercase, require_digit, require_symbol,
+			reject_breached, history_size, updated_at
+		FROM password_policies
+		WHERE organization_id = ?
+	`
+
+	policy := &models.PasswordPolicy{}
+	err := r.db.QueryRowContext(ctx, query, orgID).Scan(&policy.OrganizationID, &policy.MinLength,
+		&policy.RequireUppercase, &policy.RequireLowercase, &policy.RequireDigit, &policy.RequireSymbol,
+		&policy.RejectBreached, &policy.HistorySize, &policy.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repo.ErrPasswordPolicyNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// SavePasswordPolicy crée ou remplace la politique de l'organisation
+func (r *PasswordPoliciesRepository) SavePasswordPolicy(ctx context.Context, policy *models.PasswordPolicy) error {
+	policy.UpdatedAt = time.Now()
+
+	query := `
+		INSERT INTO password_policies (organization_id, min_length, require_uppercase, require_lowercase,
+			require_digit, require_symbol, reject_breached, history_size, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE min_length = VALUES(min_length), require_uppercase = VALUES(require_uppercase),
+			require_lowercase = VALUES(require_lowercase), require_digit = VALUES(require_digit),
+			require_symbol = VALUES(require_symbol), reject_breached = VALUES(reject_breached),
+			history_size = VALUES(history_size), updated_at = VALUES(updated_at)
+	`
+
+	_, err := r.db.ExecContext(ctx, query, policy.OrganizationID, policy.MinLength, policy.RequireUppercase,
+		policy.RequireLowercase, policy.RequireDigit, policy.RequireSymbol, policy.RejectBreached,
+		policy.HistorySize, policy.UpdatedAt)
+	return err
+}
+
+// AddPasswordHistory enregistre l'empreinte d'un mot de passe remplacé et
+// oublie les plus anciennes au-delà de models.MaxPasswordHistory
+func (r *PasswordPoliciesRepository) AddPasswordHistory(ctx context.Context, userID, hashedPassword string,
+	at time.Time) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx,
+		"INSERT INTO password_history (id, user_id, hashed_password, created_at) VALUES (?, ?, ?, ?)",
+		uuid.New().String(), userID, hashedPassword, at)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		DELETE FROM password_history
+		WHERE user_id = ? AND id NOT IN (
+			SELECT id FROM (
+				SELECT id FROM password_history WHERE user_id = ? ORDER BY created_at DESC LIMIT ?
+			) AS kept
+		)
+	`
+	if _, err := tx.ExecContext(ctx, query, userID, userID, models.MaxPasswordHistory); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// ListPasswordHistory renvoie les empreintes des derniers mots de passe remplacés
+func (r *PasswordPoliciesRepository) ListPasswordHistory(ctx context.Context, userID string, limit int) ([]string, error) {
+	query := `
+		SELECT hashed_password
+		FROM password_history
+		WHERE user_id = ?
+		ORDER BY created_at DESC
+		LIMIT ?
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	hashes := []string{}
+	for rows.Next() {
+		var hash string
+		if err := rows.Scan(&hash); err != nil {
+			return nil, err
+		}
+		hashes = append(hashes, hash)
+	}
+	return hashes, rows.Err()
+}
@@ -0,0 +1,159 @@
+// filepath: internal/storage/mysql/personal_access_tokens_repository.go
+
+/*************************************************************************/
+/*                                                                       */
+/*   Ce fichier implémente le repository MySQL des tokens d'accès        */
+/*   personnels des utilisateurs                                         */
+/*                                                                       */
+/*************************************************************************/
+
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"secrets-manager/internal/models"
+	repo "secrets-manager/internal/storage"
+)
+
+// PersonalAccessTokensRepository gère les tokens d'accès personnels dans MySQL
+type PersonalAccessTokensRepository struct {
+	db *sql.DB
+}
+
+var _ repo.PersonalAccessTokensRepository = (*PersonalAccessTokensRepository)(nil)
+
+// NewPersonalAccessTokensRepository crée un nouveau repository de tokens d'accès personnels
+func NewPersonalAccessTokensRepository(db *sql.DB) *PersonalAccessTokensRepository {
+	return &PersonalAccessTokensRepository{
+		db: db,
+	}
+}
+
+// CreatePersonalAccessToken enregistre un token
+func (r *PersonalAccessTokensRepository) CreatePersonalAccessToken(ctx context.Context, token *models.PersonalAccessToken) error {
+	if token.ID == "" {
+		token.ID = uuid.New().String()
+	}
+	token.CreatedAt = time.Now()
+
+	scopes, err := json.Marshal(token.Scopes)
+	if err != nil {
+		return err
+	}
+	var resources sql.NullString
+	if len(token.Resources) > 0 {
+		encoded, err := json.Marshal(token.Resources)
+		if err != nil {
+			return err
+		}
+		resources = sql.NullString{String: string(encoded), Valid: true}
+	}
+
+	query := `
+		INSERT INTO personal_access_tokens (id, user_id, name, token_hash, prefix, scopes, resources, expires_at,
+			created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err = r.db.ExecContext(ctx, query, token.ID, token.UserID, token.Name, token.TokenHash, token.Prefix,
+		string(scopes), resources, token.ExpiresAt, token.CreatedAt)
+	return err
+}
+
+// GetPersonalAccessTokenByHash récupère le token correspondant à l'empreinte
+func (r *PersonalAccessTokensRepository) GetPersonalAccessTokenByHash(ctx context.Context, tokenHash string) (*models.PersonalAccessToken, error) {
+	query := `
+		SELECT ` + personalAccessTokenColumns + `
+		FROM personal_access_tokens
+		WHERE token_hash = ?
+	`
+
+	token, err := scanPersonalAccessToken(r.db.QueryRowContext(ctx, query, tokenHash))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repo.ErrTokenNotFound
+	}
+	return token, err
+}
+
+// ListPersonalAccessTokens liste les tokens de l'utilisateur
+func (r *PersonalAccessTokensRepository) ListPersonalAccessTokens(ctx context.Context, userID string) ([]*models.PersonalAccessToken, error) {
+	query := `
+		SELECT ` + personalAccessTokenColumns + `
+		FROM personal_access_tokens
+		WHERE user_id = ?
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tokens := []*models.PersonalAccessToken{}
+	for rows.Next() {
+		token, err := scanPersonalAccessToken(rows)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, token)
+	}
+	return tokens, rows.Err()
+}
+
+// DeletePersonalAccessToken révoque un token de l'utilisateur
+func (r *PersonalAccessTokensRepository) DeletePersonalAccessToken(ctx context.Context, userID, id string) error {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM personal_access_tokens WHERE id = ? AND user_id = ?", id, userID)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return repo.ErrTokenNotFound
+	}
+	return nil
+}
+
+// TouchPersonalAccessToken enregistre la date de dernière utilisation
+func (r *PersonalAccessTokensRepository) TouchPersonalAccessToken(ctx context.Context, id string, at time.Time) error {
+	_, err := r.db.ExecContext(ctx, "UPDATE personal_access_tokens SET last_used_at = ? WHERE id = ?", at, id)
+	return err
+}
+
+// Colonnes lues par scanPersonalAccessToken, dans le même ordre
+const personalAccessTokenColumns = `id, user_id, name, token_hash, prefix, scopes, resources, expires_at, last_used_at,
+	created_at`
+
+func scanPersonalAccessToken(row rowScanner) (*models.PersonalAccessToken, error) {
+	token := &models.PersonalAccessToken{}
+	var scopes, resources []byte
+	var lastUsedAt sql.NullTime
+
+	err := row.Scan(&token.ID, &token.UserID, &token.Name, &token.TokenHash, &token.Prefix, &scopes, &resources,
+		&token.ExpiresAt, &lastUsedAt, &token.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(scopes, &token.Scopes); err != nil {
+		return nil, err
+	}
+	if resources != nil {
+		if err := json.Unmarshal(resources, &token.Resources); err != nil {
+			return nil, err
+		}
+	}
+	if lastUsedAt.Valid {
+		token.LastUsedAt = &lastUsedAt.Time
+	}
+	return token, nil
+}
@@ -0,0 +1,87 @@
+// filepath: internal/storage/mysql/project_templates_repository.go
+
+/*************************************************************************/
+/*                                                                       */
+/*   Ce fichier implémente le repository MySQL des modèles appliqués     */
+/*   aux projets créés dans les organisations                            */
+/*                                                                       */
+/*************************************************************************/
+
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"secrets-manager/internal/models"
+	repo "secrets-manager/internal/storage"
+)
+
+// ProjectTemplatesRepository gère les modèles de projet dans MySQL
+type ProjectTemplatesRepository struct {
+	db *sql.DB
+}
+
+var _ repo.ProjectTemplatesRepository = (*ProjectTemplatesRepository)(nil)
+
+// NewProjectTemplatesRepository crée un nouveau repository de modèles de projet
+func NewProjectTemplatesRepository(db *sql.DB) *ProjectTemplatesRepository {
+	return &ProjectTemplatesRepository{
+		db: db,
+	}
+}
+
+// GetProjectTemplate renvoie le modèle de l'organisation
+func (r *ProjectTemplatesRepository) GetProjectTemplate(ctx context.Context, orgID string) (*models.ProjectTemplate, error) {
+	query := `
+		SELECT organization_id, environments, name_pattern, placeholders, updated_at
+		FROM project_templates
+		WHERE organization_id = ?
+	`
+
+	template := &models.ProjectTemplate{}
+	var environments, placeholders []byte
+	err := r.db.QueryRowContext(ctx, query, orgID).Scan(&template.OrganizationID, &environments,
+		&template.NamePattern, &placeholders, &template.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repo.ErrProjectTemplateNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(environments, &template.Environments); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(placeholders, &template.Placeholders); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+// SaveProjectTemplate crée ou remplace le modèle de l'organisation
+func (r *ProjectTemplatesRepository) SaveProjectTemplate(ctx context.Context, template *models.ProjectTemplate) error {
+	template.UpdatedAt = time.Now()
+
+	environments, err := json.Marshal(template.Environments)
+	if err != nil {
+		return err
+	}
+	placeholders, err := json.Marshal(template.Placeholders)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO project_templates (organization_id, environments, name_pattern, placeholders, updated_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE environments = VALUES(environments), name_pattern = VALUES(name_pattern),
+			placeholders = VALUES(placeholders), updated_at = VALUES(updated_at)
+	`
+
+	_, err = r.db.ExecContext(ctx, query, template.OrganizationID, string(environments), template.NamePattern,
+		string(placeholders), template.UpdatedAt)
+	return err
+}
@@ -0,0 +1,535 @@
+// filepath: internal/storage/mysql/projects_repository.go
+
+/*************************************************************************/
+/*                                                                       */
+/*   Ce fichier implémente le repository MySQL pour les projets          */
+/*   Il gère les projets, leurs environnements et leur suppression       */
+/*                                                                       */
+/*************************************************************************/
+
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"secrets-manager/internal/models"
+	repo "secrets-manager/internal/storage"
+)
+
+// ErrProjectNotFound indique qu'un projet n'a pas été trouvé
+var ErrProjectNotFound = repo.ErrProjectNotFound
+
+// ProjectsRepository gère l'accès aux projets dans MySQL
+type ProjectsRepository struct {
+	db *sql.DB
+}
+
+var _ repo.ProjectsRepository = (*ProjectsRepository)(nil)
+
+// NewProjectsRepository crée un nouveau repository pour les projets
+func NewProjectsRepository(db *sql.DB) *ProjectsRepository {
+	return &ProjectsRepository{
+		db: db,
+	}
+}
+
+// CreateProject crée un projet
+func (r *ProjectsRepository) CreateProject(ctx context.Context, project *models.Project) error {
+	if project.ID == "" {
+		project.ID = uuid.New().String()
+	}
+
+	query := `
+		INSERT INTO projects (id, name, description, organization_id, created_at, updated_at, created_by,
+			owner_id, team_id)
+		VALUES (?, ?, ?, ?, NOW(), NOW(), ?, ?, ?)
+	`
+
+	_, err := r.db.ExecContext(
+		ctx,
+		query,
+		project.ID,
+		project.Name,
+		project.Description,
+		project.OrganizationID,
+		project.CreatedBy,
+		project.OwnerID,
+		project.TeamID,
+	)
+
+	return err
+}
+
+// CreateProjectWithEnvironments crée un projet et ses environnements dans
+// une seule transaction
+func (r *ProjectsRepository) CreateProjectWithEnvironments(ctx context.Context, project *models.Project,
+	environments []*models.Environment) error {
+	if project.ID == "" {
+		project.ID = uuid.New().String()
+	}
+	now := time.Now()
+	project.CreatedAt, project.UpdatedAt = now, now
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO projects (id, name, description, organization_id, created_at, updated_at, created_by,
+			owner_id, team_id)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, project.ID, project.Name, project.Description, project.OrganizationID, project.CreatedAt,
+		project.UpdatedAt, project.CreatedBy, project.OwnerID, project.TeamID)
+	if err != nil {
+		return err
+	}
+	if err := insertEnvironments(ctx, tx, project, environments, now); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// insertEnvironments crée les environnements du projet dans la transaction
+func insertEnvironments(ctx context.Context, tx *sql.Tx, project *models.Project, environments []*models.Environment,
+	now time.Time) error {
+	for _, env := range environments {
+		env.ID = uuid.New().String()
+		env.ProjectID = project.ID
+		env.CreatedAt, env.UpdatedAt = now, now
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO environments (id, name, description, project_id, created_at, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?)
+		`, env.ID, env.Name, env.Description, env.ProjectID, env.CreatedAt, env.UpdatedAt)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetProject récupère un projet d'une organisation (hors corbeille)
+func (r *ProjectsRepository) GetProject(ctx context.Context, orgID, projectID string) (*models.Project, error) {
+	query := `
+		SELECT ` + projectColumns + `
+		FROM projects
+		WHERE id = ? AND organization_id = ? AND deleted_at IS NULL
+	`
+
+	project, err := scanProject(r.db.QueryRowContext(ctx, query, projectID, orgID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrProjectNotFound
+		}
+		return nil, err
+	}
+
+	return project, nil
+}
+
+// ListOrganizationProjects liste les projets d'une organisation (hors corbeille)
+func (r *ProjectsRepository) ListOrganizationProjects(ctx context.Context, orgID string) ([]*models.Project, error) {
+	query := `
+		SELECT ` + projectColumns + `
+		FROM projects
+		WHERE organization_id = ? AND deleted_at IS NULL
+		ORDER BY name
+	`
+
+	return r.queryProjects(ctx, query, orgID)
+}
+
+// ListProjectSummaries liste les projets d'une organisation (hors corbeille)
+// avec leurs compteurs et leur dernière activité, agrégés en une seule requête
+func (r *ProjectsRepository) ListProjectSummaries(ctx context.Context, orgID string) ([]*models.ProjectSummary, error) {
+	query := `
+		SELECT ` + projectColumns + `,
+			COALESCE(s.secrets_count, 0), COALESCE(s.environments, ''), s.last_updated
+		FROM projects
+		LEFT JOIN (
+			SELECT project_id,
+				COUNT(*) AS secrets_count,
+				GROUP_CONCAT(DISTINCT environment ORDER BY environment) AS environments,
+				MAX(updated_at) AS last_updated
+			FROM secret_metadata
+			WHERE organization_id = ?
+			GROUP BY project_id
+		) s ON s.project_id = projects.id
+		WHERE organization_id = ? AND deleted_at IS NULL
+		ORDER BY name
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, orgID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	summaries := []*models.ProjectSummary{}
+	for rows.Next() {
+		summary := &models.ProjectSummary{Environments: []string{}}
+		project := &summary.Project
+		var environments string
+		var lastUpdated sql.NullTime
+		err := rows.Scan(
+			&project.ID,
+			&project.Name,
+			&project.Description,
+			&project.OrganizationID,
+			&project.CreatedAt,
+			&project.UpdatedAt,
+			&project.CreatedBy,
+			&project.DeletedAt,
+			&project.OwnerID,
+			&project.TeamID,
+			&summary.SecretsCount,
+			&environments,
+			&lastUpdated,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if environments != "" {
+			summary.Environments = strings.Split(environments, ",")
+		}
+		summary.Touch(project.UpdatedAt)
+		summary.Touch(lastUpdated.Time)
+		summaries = append(summaries, summary)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return summaries, nil
+}
+
+// SuspendProject place le projet dans la corbeille
+func (r *ProjectsRepository) SuspendProject(ctx context.Context, orgID, projectID string) error {
+	return r.setDeletedAt(ctx,
+		"UPDATE projects SET deleted_at = NOW() WHERE id = ? AND organization_id = ? AND deleted_at IS NULL",
+		orgID, projectID)
+}
+
+// RestoreProject sort le projet de la corbeille
+func (r *ProjectsRepository) RestoreProject(ctx context.Context, orgID, projectID string) error {
+	return r.setDeletedAt(ctx,
+		"UPDATE projects SET deleted_at = NULL WHERE id = ? AND organization_id = ? AND deleted_at IS NOT NULL",
+		orgID, projectID)
+}
+
+// setDeletedAt exécute une mise à jour de deleted_at et renvoie
+// ErrProjectNotFound si aucun projet n'a changé d'état
+func (r *ProjectsRepository) setDeletedAt(ctx context.Context, query, orgID, projectID string) error {
+	result, err := r.db.ExecContext(ctx, query, projectID, orgID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrProjectNotFound
+	}
+
+	return nil
+}
+
+// IsProjectSuspended indique si le projet est dans la corbeille.
+// Un projet inconnu n'est pas considéré comme suspendu.
+func (r *ProjectsRepository) IsProjectSuspended(ctx context.Context, orgID, projectID string) (bool, error) {
+	var suspended bool
+	err := r.db.QueryRowContext(ctx,
+		"SELECT EXISTS(SELECT 1 FROM projects WHERE id = ? AND organization_id = ? AND deleted_at IS NOT NULL)",
+		projectID, orgID).Scan(&suspended)
+	return suspended, err
+}
+
+// ListSuspendedProjects liste les projets placés dans la corbeille avant before
+func (r *ProjectsRepository) ListSuspendedProjects(ctx context.Context, before time.Time) ([]*models.Project, error) {
+	query := `
+		SELECT ` + projectColumns + `
+		FROM projects
+		WHERE deleted_at IS NOT NULL AND deleted_at < ?
+		ORDER BY deleted_at
+	`
+
+	return r.queryProjects(ctx, query, before)
+}
+
+// queryProjects exécute une requête sélectionnant projectColumns
+func (r *ProjectsRepository) queryProjects(ctx context.Context, query string, args ...interface{}) ([]*models.Project, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	projects := []*models.Project{}
+	for rows.Next() {
+		project, err := scanProject(rows)
+		if err != nil {
+			return nil, err
+		}
+		projects = append(projects, project)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return projects, nil
+}
+
+// Colonnes lues par scanProject, dans le même ordre
+const projectColumns = `id, name, description, organization_id, created_at, updated_at, created_by, deleted_at,
+	owner_id, team_id`
+
+// scanProject lit une ligne sélectionnée avec projectColumns
+func scanProject(row rowScanner) (*models.Project, error) {
+	project := &models.Project{}
+	err := row.Scan(
+		&project.ID,
+		&project.Name,
+		&project.Description,
+		&project.OrganizationID,
+		&project.CreatedAt,
+		&project.UpdatedAt,
+		&project.CreatedBy,
+		&project.DeletedAt,
+		&project.OwnerID,
+		&project.TeamID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+// DeleteProject supprime le projet, ses environnements et les métadonnées de ses secrets
+func (r *ProjectsRepository) DeleteProject(ctx context.Context, orgID, projectID string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Les métadonnées supprimées ne comptent plus dans les statistiques d'usage
+	result, err := tx.ExecContext(ctx,
+		"DELETE FROM secret_metadata WHERE organization_id = ? AND project_id = ?", orgID, projectID)
+	if err != nil {
+		return err
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if deleted > 0 {
+		_, err = tx.ExecContext(ctx, `
+			UPDATE usage_statistics
+			SET secret_count = GREATEST(0, secret_count - ?), last_updated = NOW()
+			WHERE organization_id = ?
+		`, deleted, orgID)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM environments
+		WHERE project_id IN (SELECT id FROM projects WHERE id = ? AND organization_id = ?)
+	`, projectID, orgID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM project_encryption_keys
+		WHERE project_id IN (SELECT id FROM projects WHERE id = ? AND organization_id = ?)
+	`, projectID, orgID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, "DELETE FROM readmes WHERE project_id = ? AND organization_id = ?", projectID, orgID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, "DELETE FROM projects WHERE id = ? AND organization_id = ?", projectID, orgID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetProjectEncryptionKey récupère la clé publique de chiffrement de bout en bout d'un projet
+func (r *ProjectsRepository) GetProjectEncryptionKey(ctx context.Context, projectID string) (*models.ProjectEncryptionKey, error) {
+	query := `
+		SELECT project_id, algorithm, public_key, key_id, created_by, created_at
+		FROM project_encryption_keys
+		WHERE project_id = ?
+	`
+
+	key := &models.ProjectEncryptionKey{}
+	err := r.db.QueryRowContext(ctx, query, projectID).Scan(
+		&key.ProjectID,
+		&key.Algorithm,
+		&key.PublicKey,
+		&key.KeyID,
+		&key.CreatedBy,
+		&key.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// SetProjectEncryptionKey enregistre ou remplace la clé publique d'un projet
+func (r *ProjectsRepository) SetProjectEncryptionKey(ctx context.Context, key *models.ProjectEncryptionKey) error {
+	if key.CreatedAt.IsZero() {
+		key.CreatedAt = time.Now()
+	}
+
+	query := `
+		INSERT INTO project_encryption_keys (project_id, algorithm, public_key, key_id, created_by, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE algorithm = VALUES(algorithm), public_key = VALUES(public_key),
+			key_id = VALUES(key_id), created_by = VALUES(created_by), created_at = VALUES(created_at)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		key.ProjectID, key.Algorithm, key.PublicKey, key.KeyID, key.CreatedBy, key.CreatedAt)
+	return err
+}
+
+// SetProjectOwnership désigne les responsables d'un projet
+func (r *ProjectsRepository) SetProjectOwnership(ctx context.Context, orgID, projectID string, ownership models.Ownership) error {
+	// updated_at change pour que la liste des projets en cache soit revalidée
+	query := `
+		UPDATE projects SET owner_id = ?, team_id = ?, updated_at = NOW()
+		WHERE id = ? AND organization_id = ? AND deleted_at IS NULL
+	`
+
+	result, err := r.db.ExecContext(ctx, query, ownership.OwnerID, ownership.TeamID, projectID, orgID)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		// Un projet dont les responsables ne changent pas n'est pas compté par MySQL
+		if _, err := r.GetProject(ctx, orgID, projectID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Colonnes lues par scanReadme, dans le même ordre
+const readmeColumns = `organization_id, project_id, environment, content, updated_by, updated_at`
+
+// scanReadme lit une ligne sélectionnée avec readmeColumns
+func scanReadme(row rowScanner) (*models.Readme, error) {
+	readme := &models.Readme{}
+	err := row.Scan(
+		&readme.OrganizationID,
+		&readme.ProjectID,
+		&readme.Environment,
+		&readme.Content,
+		&readme.UpdatedBy,
+		&readme.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return readme, nil
+}
+
+// GetReadme récupère le README d'un projet (env vide) ou d'un environnement
+func (r *ProjectsRepository) GetReadme(ctx context.Context, projectID, env string) (*models.Readme, error) {
+	query := `
+		SELECT ` + readmeColumns + `
+		FROM readmes
+		WHERE project_id = ? AND environment = ?
+	`
+
+	readme, err := scanReadme(r.db.QueryRowContext(ctx, query, projectID, env))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return readme, nil
+}
+
+// ListReadmes liste les README des projets d'une organisation
+func (r *ProjectsRepository) ListReadmes(ctx context.Context, orgID string) ([]*models.Readme, error) {
+	query := `
+		SELECT ` + readmeColumns + `
+		FROM readmes
+		WHERE organization_id = ?
+		ORDER BY project_id, environment
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, orgID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	readmes := []*models.Readme{}
+	for rows.Next() {
+		readme, err := scanReadme(rows)
+		if err != nil {
+			return nil, err
+		}
+		readmes = append(readmes, readme)
+	}
+
+	return readmes, rows.Err()
+}
+
+// SetReadme enregistre ou remplace un README
+func (r *ProjectsRepository) SetReadme(ctx context.Context, readme *models.Readme) error {
+	if readme.UpdatedAt.IsZero() {
+		readme.UpdatedAt = time.Now()
+	}
+
+	query := `
+		INSERT INTO readmes (organization_id, project_id, environment, content, updated_by, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE content = VALUES(content), updated_by = VALUES(updated_by),
+			updated_at = VALUES(updated_at)
+	`
+
+	_, err := r.db.ExecContext(ctx, query, readme.OrganizationID, readme.ProjectID, readme.Environment,
+		readme.Content, readme.UpdatedBy, readme.UpdatedAt)
+	return err
+}
+
+// DeleteReadme supprime un README ; sans effet s'il n'existe pas
+func (r *ProjectsRepository) DeleteReadme(ctx context.Context, projectID, env string) error {
+	_, err := r.db.ExecContext(ctx, "DELETE FROM readmes WHERE project_id = ? AND environment = ?", projectID, env)
+	return err
+}
@@ -0,0 +1,77 @@
+// filepath: internal/storage/mysql/read_reason_policies_repository.go
+
+/*************************************************************************/
+/*                                                                       */
+/*   Ce fichier implémente le repository MySQL des environnements        */
+/*   protégés, dont la lecture des secrets exige un motif                */
+/*                                                                       */
+/*************************************************************************/
+
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"secrets-manager/internal/models"
+	repo "secrets-manager/internal/storage"
+)
+
+// ReadReasonPoliciesRepository gère les environnements protégés dans MySQL
+type ReadReasonPoliciesRepository struct {
+	db *sql.DB
+}
+
+var _ repo.ReadReasonPoliciesRepository = (*ReadReasonPoliciesRepository)(nil)
+
+// NewReadReasonPoliciesRepository crée un nouveau repository d'environnements protégés
+func NewReadReasonPoliciesRepository(db *sql.DB) *ReadReasonPoliciesRepository {
+	return &ReadReasonPoliciesRepository{
+		db: db,
+	}
+}
+
+// GetReadReasonPolicy renvoie la politique de l'organisation
+func (r *ReadReasonPoliciesRepository) GetReadReasonPolicy(ctx context.Context, orgID string) (*models.ReadReasonPolicy, error) {
+	query := `
+		SELECT organization_id, environments, updated_at
+		FROM read_reason_policies
+		WHERE organization_id = ?
+	`
+
+	policy := &models.ReadReasonPolicy{}
+	var environments []byte
+	err := r.db.QueryRowContext(ctx, query, orgID).Scan(&policy.OrganizationID, &environments, &policy.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repo.ErrReadPolicyNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(environments, &policy.Environments); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// SaveReadReasonPolicy crée ou remplace la politique de l'organisation
+func (r *ReadReasonPoliciesRepository) SaveReadReasonPolicy(ctx context.Context, policy *models.ReadReasonPolicy) error {
+	policy.UpdatedAt = time.Now()
+
+	environments, err := json.Marshal(policy.Environments)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO read_reason_policies (organization_id, environments, updated_at)
+		VALUES (?, ?, ?)
+		ON DUPLICATE KEY UPDATE environments = VALUES(environments), updated_at = VALUES(updated_at)
+	`
+
+	_, err = r.db.ExecContext(ctx, query, policy.OrganizationID, string(environments), policy.UpdatedAt)
+	return err
+}
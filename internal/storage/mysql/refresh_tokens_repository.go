@@ -0,0 +1,177 @@
+// filepath: internal/storage/mysql/refresh_tokens_repository.go
+
+/*************************************************************************/
+/*                                                                       */
+/*   Ce fichier implémente le repository MySQL des tokens de             */
+/*   rafraîchissement, qui matérialisent les sessions des utilisateurs   */
+/*                                                                       */
+/*************************************************************************/
+
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"secrets-manager/internal/models"
+	repo "secrets-manager/internal/storage"
+)
+
+// RefreshTokensRepository gère les tokens de rafraîchissement dans MySQL
+type RefreshTokensRepository struct {
+	db *sql.DB
+}
+
+var _ repo.RefreshTokensRepository = (*RefreshTokensRepository)(nil)
+
+// NewRefreshTokensRepository crée un nouveau repository de tokens de rafraîchissement
+func NewRefreshTokensRepository(db *sql.DB) *RefreshTokensRepository {
+	return &RefreshTokensRepository{
+		db: db,
+	}
+}
+
+// CreateRefreshToken enregistre un token, en ouvrant une session s'il n'en a pas
+func (r *RefreshTokensRepository) CreateRefreshToken(ctx context.Context, token *models.RefreshToken) error {
+	if token.ID == "" {
+		token.ID = uuid.New().String()
+	}
+	token.CreatedAt = time.Now()
+	if token.SessionID == "" {
+		token.SessionID = token.ID
+		token.SessionStartedAt = token.CreatedAt
+	}
+
+	query := `
+		INSERT INTO refresh_tokens (id, session_id, user_id, token_hash, mfa, user_agent, ip_address,
+			session_started_at, expires_at, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := r.db.ExecContext(ctx, query, token.ID, token.SessionID, token.UserID, token.TokenHash, token.MFA,
+		token.UserAgent, token.IPAddress, token.SessionStartedAt, token.ExpiresAt, token.CreatedAt)
+	return err
+}
+
+// GetRefreshTokenByHash récupère le token correspondant à l'empreinte
+func (r *RefreshTokensRepository) GetRefreshTokenByHash(ctx context.Context, tokenHash string) (*models.RefreshToken, error) {
+	query := `
+		SELECT ` + refreshTokenColumns + `
+		FROM refresh_tokens
+		WHERE token_hash = ?
+	`
+
+	token, err := scanRefreshToken(r.db.QueryRowContext(ctx, query, tokenHash))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repo.ErrRefreshTokenNotFound
+	}
+	return token, err
+}
+
+// RotateRefreshToken marque le token comme remplacé s'il ne l'était pas déjà
+func (r *RefreshTokensRepository) RotateRefreshToken(ctx context.Context, id string, at time.Time) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE refresh_tokens SET rotated_at = ?
+		WHERE id = ? AND rotated_at IS NULL AND revoked_at IS NULL
+	`, at, id)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected == 1, nil
+}
+
+// ListActiveRefreshTokens liste le token courant de chaque session active de l'utilisateur
+func (r *RefreshTokensRepository) ListActiveRefreshTokens(ctx context.Context, userID string, now time.Time) ([]*models.RefreshToken, error) {
+	query := `
+		SELECT ` + refreshTokenColumns + `
+		FROM refresh_tokens
+		WHERE user_id = ? AND rotated_at IS NULL AND revoked_at IS NULL AND expires_at > ?
+		ORDER BY session_started_at DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tokens := []*models.RefreshToken{}
+	for rows.Next() {
+		token, err := scanRefreshToken(rows)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, token)
+	}
+	return tokens, rows.Err()
+}
+
+// RevokeSession révoque une session de l'utilisateur
+func (r *RefreshTokensRepository) RevokeSession(ctx context.Context, userID, sessionID string, at time.Time) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE refresh_tokens SET revoked_at = ?
+		WHERE session_id = ? AND user_id = ? AND revoked_at IS NULL
+	`, at, sessionID, userID)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return repo.ErrSessionNotFound
+	}
+	return nil
+}
+
+// RevokeUserSessions révoque les sessions de l'utilisateur, sauf exceptSessionID
+func (r *RefreshTokensRepository) RevokeUserSessions(ctx context.Context, userID, exceptSessionID string, at time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE refresh_tokens SET revoked_at = ?
+		WHERE user_id = ? AND session_id <> ? AND revoked_at IS NULL
+	`, at, userID, exceptSessionID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// SessionRevoked indique si une session a été révoquée
+func (r *RefreshTokensRepository) SessionRevoked(ctx context.Context, sessionID string) (bool, error) {
+	var revoked bool
+	err := r.db.QueryRowContext(ctx, `
+		SELECT EXISTS (SELECT 1 FROM refresh_tokens WHERE session_id = ? AND revoked_at IS NOT NULL)
+	`, sessionID).Scan(&revoked)
+	return revoked, err
+}
+
+// Colonnes lues par scanRefreshToken, dans le même ordre
+const refreshTokenColumns = `id, session_id, user_id, token_hash, mfa, user_agent, ip_address, session_started_at,
+	expires_at, rotated_at, revoked_at, created_at`
+
+func scanRefreshToken(row rowScanner) (*models.RefreshToken, error) {
+	token := &models.RefreshToken{}
+	var rotatedAt, revokedAt sql.NullTime
+
+	err := row.Scan(&token.ID, &token.SessionID, &token.UserID, &token.TokenHash, &token.MFA, &token.UserAgent,
+		&token.IPAddress, &token.SessionStartedAt, &token.ExpiresAt, &rotatedAt, &revokedAt, &token.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if rotatedAt.Valid {
+		token.RotatedAt = &rotatedAt.Time
+	}
+	if revokedAt.Valid {
+		token.RevokedAt = &revokedAt.Time
+	}
+	return token, nil
+}
@@ -0,0 +1,95 @@
+// filepath: internal/storage/mysql/registration_policy_repository.go
+
+/*************************************************************************/
+/*                                                                       */
+/*   Ce fichier implémente le repository MySQL de la politique           */
+/*   d'inscription réglée depuis le listener d'administration            */
+/*                                                                       */
+/*************************************************************************/
+
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"secrets-manager/internal/models"
+	repo "secrets-manager/internal/storage"
+)
+
+// La politique d'inscription est l'unique ligne de registration_policy
+const registrationPolicyID = 1
+
+// RegistrationPolicyRepository gère la politique d'inscription dans MySQL
+type RegistrationPolicyRepository struct {
+	db *sql.DB
+}
+
+var _ repo.RegistrationPolicyRepository = (*RegistrationPolicyRepository)(nil)
+
+// NewRegistrationPolicyRepository crée un nouveau repository de politique d'inscription
+func NewRegistrationPolicyRepository(db *sql.DB) *RegistrationPolicyRepository {
+	return &RegistrationPolicyRepository{
+		db: db,
+	}
+}
+
+// GetRegistrationPolicy renvoie la politique enregistrée
+func (r *RegistrationPolicyRepository) GetRegistrationPolicy(ctx context.Context) (*models.RegistrationPolicy, error) {
+	query := `
+		SELECT mode, allow_list, deny_list, updated_at
+		FROM registration_policy
+		WHERE id = ?
+	`
+
+	policy := &models.RegistrationPolicy{}
+	var allowList, denyList []byte
+	err := r.db.QueryRowContext(ctx, query, registrationPolicyID).Scan(&policy.Mode, &allowList, &denyList,
+		&policy.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repo.ErrRegistrationPolicyNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(allowList, &policy.AllowList); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(denyList, &policy.DenyList); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// SaveRegistrationPolicy crée ou remplace la politique
+func (r *RegistrationPolicyRepository) SaveRegistrationPolicy(ctx context.Context, policy *models.RegistrationPolicy) error {
+	policy.UpdatedAt = time.Now()
+
+	allowList, err := json.Marshal(policy.AllowList)
+	if err != nil {
+		return err
+	}
+	denyList, err := json.Marshal(policy.DenyList)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO registration_policy (id, mode, allow_list, deny_list, updated_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE mode = VALUES(mode), allow_list = VALUES(allow_list),
+			deny_list = VALUES(deny_list), updated_at = VALUES(updated_at)
+	`
+
+	_, err = r.db.ExecContext(ctx, query, registrationPolicyID, policy.Mode, allowList, denyList, policy.UpdatedAt)
+	return err
+}
+
+// DeleteRegistrationPolicy supprime la politique enregistrée
+func (r *RegistrationPolicyRepository) DeleteRegistrationPolicy(ctx context.Context) error {
+	_, err := r.db.ExecContext(ctx, "DELETE FROM registration_policy WHERE id = ?", registrationPolicyID)
+	return err
+}
@@ -0,0 +1,418 @@
+// filepath: internal/storage/mysql/replication.go
+
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"secrets-manager/internal/logging"
+	"secrets-manager/internal/metrics"
+)
+
+// replicationStream est le nom du flux de métadonnées dans replication_position
+const replicationStream = "metadata"
+
+// ReplicatedTables liste les tables de métadonnées répliquées vers la région
+// de secours et les colonnes de leur clé primaire. Chaque table a ses
+// triggers *_replicate_* (migration 0014 et suivantes).
+var ReplicatedTables = map[string][]string{
+	"users":                    {"id"},
+	"plans":                    {"id"},
+	"organizations":            {"id"},
+	"user_organizations":       {"user_id", "organization_id"},
+	"projects":                 {"id"},
+	"environments":             {"id"},
+	"secret_metadata":          {"id"},
+	"subscriptions":            {"id"},
+	"usage_statistics":         {"id"},
+	"organization_deletions":   {"id"},
+	"access_reviews":           {"id"},
+	"access_review_items":      {"review_id", "principal_type", "principal_id"},
+	"project_encryption_keys":  {"project_id"},
+	"notification_settings":    {"user_id"},
+	"notification_preferences": {"user_id", "event_type"},
+	"webhooks":                 {"id"},
+	"personal_access_tokens":   {"id"},
+	"secret_rotators":          {"id"},
+	"scheduled_secret_changes": {"id"},
+	"maintenance_windows":      {"id"},
+	"data_keys":                {"id"},
+	"age_keys":                 {"id"},
+	"settings_changes":         {"id"},
+	"log_forwarders":           {"id"},
+	"bulk_rotations":           {"id"},
+	"bulk_rotation_items":      {"id"},
+	"user_mfa":                 {"user_id"},
+	"lockdowns":                {"id"},
+	"api_keys":                 {"id"},
+	"trusted_devices":          {"id"},
+	"login_alert_policies":     {"organization_id"},
+	"refresh_tokens":           {"id"},
+	"session_policies":         {"organization_id"},
+	"read_reason_policies":     {"organization_id"},
+	"invitations":              {"id"},
+	"readmes":                  {"project_id", "environment"},
+	"teams":                    {"id"},
+	"team_members":             {"team_id", "user_id"},
+	"team_grants":              {"team_id", "project_id", "environment"},
+	"service_accounts":         {"id"},
+	"service_account_trusts":   {"organization_id"},
+	"custom_roles":             {"id"},
+	"custom_role_assignments":  {"organization_id", "user_id"},
+	"impersonations":           {"id"},
+	"password_policies":        {"organization_id"},
+	"password_history":         {"id"},
+	"registration_policy":      {"id"},
+	"onboarding_steps":         {"subject_id", "step"},
+	"project_templates":        {"organization_id"},
+}
+
+// ErrStandbyPromoted indique que la base de secours a été promue par un
+// basculement : elle ne reçoit plus les changements de l'ancien primaire
+var ErrStandbyPromoted = errors.New("la base de secours a été promue, réplication arrêtée")
+
+var (
+	replicationPending = metrics.NewGauge("metadata_replication_pending_changes",
+		"Changements de métadonnées pas encore appliqués sur la base de secours")
+	replicationLag = metrics.NewGauge("metadata_replication_lag_seconds",
+		"Âge du plus ancien changement de métadonnées non répliqué")
+	replicationApplied = metrics.NewCounter("metadata_replication_applied_total",
+		"Lignes de métadonnées appliquées sur la base de secours")
+)
+
+// ReplicationStatus décrit l'avance de la base de secours sur le flux du primaire
+type ReplicationStatus struct {
+	AppliedChangeID int64
+	LatestChangeID  int64
+	// OldestPendingAt est la date du plus ancien changement non répliqué
+	OldestPendingAt *time.Time
+	// PromotedAt est renseigné une fois la base de secours promue
+	PromotedAt *time.Time
+}
+
+// Pending renvoie le nombre de changements non encore répliqués
+func (s *ReplicationStatus) Pending() int64 {
+	if s.LatestChangeID < s.AppliedChangeID {
+		return 0
+	}
+	return s.LatestChangeID - s.AppliedChangeID
+}
+
+// Lag renvoie l'âge du plus ancien changement non répliqué
+func (s *ReplicationStatus) Lag(now time.Time) time.Duration {
+	if s.OldestPendingAt == nil {
+		return 0
+	}
+	return now.Sub(*s.OldestPendingAt)
+}
+
+// changeKey identifie une ligne modifiée
+type changeKey struct {
+	table string
+	key   string
+}
+
+// Replicator rejoue sur la base de secours les changements de métadonnées
+// enregistrés par les triggers du primaire. Chaque changement ne désigne
+// qu'une ligne : son état courant est relu sur le primaire puis recopié (ou
+// supprimé s'il n'existe plus), ce qui rend l'application idempotente.
+type Replicator struct {
+	primary   *sql.DB
+	standby   *sql.DB
+	batchSize int
+}
+
+// NewReplicator crée un réplicateur du primaire vers la base de secours
+func NewReplicator(primary, standby *sql.DB) *Replicator {
+	return &Replicator{
+		primary:   primary,
+		standby:   standby,
+		batchSize: 500,
+	}
+}
+
+// Replicate applique les changements en attente par lots jusqu'à rattraper le primaire
+func (r *Replicator) Replicate(ctx context.Context) error {
+	for {
+		applied, err := r.replicateBatch(ctx)
+		if err != nil {
+			return err
+		}
+		if applied < r.batchSize {
+			break
+		}
+	}
+
+	status, err := r.Status(ctx)
+	if err != nil {
+		return err
+	}
+	replicationPending.Set(float64(status.Pending()))
+	replicationLag.Set(status.Lag(time.Now()).Seconds())
+	return nil
+}
+
+// replicateBatch applique un lot de changements dans une transaction de la
+// base de secours, avec la nouvelle position. Renvoie le nombre de changements lus.
+func (r *Replicator) replicateBatch(ctx context.Context) (int, error) {
+	tx, err := r.standby.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	position, promotedAt, err := lockReplicationPosition(ctx, tx)
+	if err != nil {
+		return 0, err
+	}
+	if promotedAt != nil {
+		return 0, ErrStandbyPromoted
+	}
+
+	rows, err := r.primary.QueryContext(ctx,
+		"SELECT id, table_name, row_key FROM metadata_changes WHERE id > ? ORDER BY id LIMIT ?",
+		position, r.batchSize)
+	if err != nil {
+		return 0, err
+	}
+	var changes []changeKey
+	seen := make(map[changeKey]bool)
+	read := 0
+	for rows.Next() {
+		var change changeKey
+		if err := rows.Scan(&position, &change.table, &change.key); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		read++
+		if !seen[change] {
+			seen[change] = true
+			changes = append(changes, change)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+	if read == 0 {
+		return 0, nil
+	}
+
+	// Les triggers de la base de secours ne doivent pas enregistrer ces écritures
+	if _, err := tx.ExecContext(ctx, "SET @metadata_replication = 1"); err != nil {
+		return 0, err
+	}
+	for _, change := range changes {
+		if err := r.apply(ctx, tx, change); err != nil {
+			return 0, fmt.Errorf("réplication de %s %s: %w", change.table, change.key, err)
+		}
+	}
+	if _, err := tx.ExecContext(ctx, "SET @metadata_replication = NULL"); err != nil {
+		return 0, err
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		"UPDATE replication_position SET last_change_id = ?, updated_at = NOW() WHERE name = ?",
+		position, replicationStream); err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	replicationApplied.Add(float64(len(changes)))
+	return read, nil
+}
+
+// apply recopie l'état courant d'une ligne du primaire sur la base de secours
+func (r *Replicator) apply(ctx context.Context, tx *sql.Tx, change changeKey) error {
+	keyColumns, ok := ReplicatedTables[change.table]
+	if !ok {
+		logging.For(logging.ComponentStorage).Warn("changement ignoré, table non répliquée", "table", change.table)
+		return nil
+	}
+
+	var key map[string]string
+	if err := json.Unmarshal([]byte(change.key), &key); err != nil {
+		return err
+	}
+	where, args, err := keyCondition(keyColumns, key)
+	if err != nil {
+		return err
+	}
+
+	rows, err := r.primary.QueryContext(ctx, "SELECT * FROM "+change.table+" WHERE "+where, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		// La ligne n'existe plus sur le primaire
+		_, err := tx.ExecContext(ctx, "DELETE FROM "+change.table+" WHERE "+where, args...)
+		return err
+	}
+
+	values := make([]interface{}, len(columns))
+	pointers := make([]interface{}, len(columns))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+	if err := rows.Scan(pointers...); err != nil {
+		return err
+	}
+	for i, value := range values {
+		// Les colonnes JSON refusent les chaînes binaires
+		if b, ok := value.([]byte); ok {
+			values[i] = string(b)
+		}
+	}
+
+	// REPLACE supprime aussi les lignes en conflit sur un index unique
+	// (email réattribué par exemple) : la base de secours converge vers le primaire
+	query := fmt.Sprintf("REPLACE INTO %s (`%s`) VALUES (%s)", change.table,
+		strings.Join(columns, "`, `"), strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", "))
+	_, err = tx.ExecContext(ctx, query, values...)
+	return err
+}
+
+// keyCondition construit la condition WHERE d'une clé primaire
+func keyCondition(columns []string, key map[string]string) (string, []interface{}, error) {
+	conditions := make([]string, 0, len(columns))
+	args := make([]interface{}, 0, len(columns))
+	for _, column := range columns {
+		value, ok := key[column]
+		if !ok {
+			return "", nil, fmt.Errorf("colonne de clé %s absente du changement", column)
+		}
+		conditions = append(conditions, "`"+column+"` = ?")
+		args = append(args, value)
+	}
+	return strings.Join(conditions, " AND "), args, nil
+}
+
+// lockReplicationPosition verrouille et renvoie la position de la base de secours
+func lockReplicationPosition(ctx context.Context, tx *sql.Tx) (int64, *time.Time, error) {
+	if _, err := tx.ExecContext(ctx,
+		"INSERT IGNORE INTO replication_position (name, last_change_id, updated_at) VALUES (?, 0, NOW())",
+		replicationStream); err != nil {
+		return 0, nil, err
+	}
+
+	var position int64
+	var promotedAt sql.NullTime
+	err := tx.QueryRowContext(ctx,
+		"SELECT last_change_id, promoted_at FROM replication_position WHERE name = ? FOR UPDATE",
+		replicationStream).Scan(&position, &promotedAt)
+	if err != nil {
+		return 0, nil, err
+	}
+	if promotedAt.Valid {
+		return position, &promotedAt.Time, nil
+	}
+	return position, nil, nil
+}
+
+// Status compare la position de la base de secours au flux du primaire.
+// Le primaire peut être nil (injoignable) : seule la position est renseignée.
+func (r *Replicator) Status(ctx context.Context) (*ReplicationStatus, error) {
+	status := &ReplicationStatus{}
+
+	var promotedAt sql.NullTime
+	err := r.standby.QueryRowContext(ctx,
+		"SELECT last_change_id, promoted_at FROM replication_position WHERE name = ?",
+		replicationStream).Scan(&status.AppliedChangeID, &promotedAt)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	if promotedAt.Valid {
+		status.PromotedAt = &promotedAt.Time
+	}
+
+	if r.primary == nil {
+		return status, nil
+	}
+	if err := r.primary.QueryRowContext(ctx, "SELECT COALESCE(MAX(id), 0) FROM metadata_changes").
+		Scan(&status.LatestChangeID); err != nil {
+		return nil, err
+	}
+	var oldest sql.NullTime
+	if err := r.primary.QueryRowContext(ctx, "SELECT MIN(created_at) FROM metadata_changes WHERE id > ?",
+		status.AppliedChangeID).Scan(&oldest); err != nil {
+		return nil, err
+	}
+	if oldest.Valid {
+		status.OldestPendingAt = &oldest.Time
+	}
+	return status, nil
+}
+
+// Seed enregistre un changement pour chaque ligne existante des tables
+// répliquées : la base de secours reçoit ainsi les données antérieures à
+// l'activation de la réplication
+func (r *Replicator) Seed(ctx context.Context) (int64, error) {
+	var total int64
+	for table, columns := range ReplicatedTables {
+		pairs := make([]string, 0, len(columns))
+		for _, column := range columns {
+			pairs = append(pairs, "'"+column+"', `"+column+"`")
+		}
+		result, err := r.primary.ExecContext(ctx, fmt.Sprintf(
+			"INSERT INTO metadata_changes (table_name, row_key) SELECT '%s', JSON_OBJECT(%s) FROM %s",
+			table, strings.Join(pairs, ", "), table))
+		if err != nil {
+			return total, fmt.Errorf("%s: %w", table, err)
+		}
+		count, err := result.RowsAffected()
+		if err != nil {
+			return total, err
+		}
+		total += count
+	}
+	return total, nil
+}
+
+// Purge supprime du primaire les changements déjà appliqués sur la base de
+// secours et plus anciens que before
+func (r *Replicator) Purge(ctx context.Context, before time.Time) (int64, error) {
+	status, err := r.Status(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return PurgeMetadataChanges(ctx, r.primary, status.AppliedChangeID, before)
+}
+
+// PurgeMetadataChanges supprime les changements jusqu'à upTo et plus anciens
+// que before. Sans base de secours, upTo couvre tout le flux.
+func PurgeMetadataChanges(ctx context.Context, db *sql.DB, upTo int64, before time.Time) (int64, error) {
+	result, err := db.ExecContext(ctx,
+		"DELETE FROM metadata_changes WHERE id <= ? AND created_at < ?", upTo, before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// PromoteStandby marque la base de secours comme promue : le réplicateur de
+// l'ancien primaire ne peut plus y écrire, même s'il redémarre
+func PromoteStandby(ctx context.Context, standby *sql.DB) error {
+	_, err := standby.ExecContext(ctx, `
+		INSERT INTO replication_position (name, last_change_id, promoted_at, updated_at)
+		VALUES (?, 0, NOW(), NOW())
+		ON DUPLICATE KEY UPDATE promoted_at = COALESCE(promoted_at, NOW()), updated_at = NOW()
+	`, replicationStream)
+	return err
+}
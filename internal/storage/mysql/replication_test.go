@@ -0,0 +1,48 @@
+// filepath: internal/storage/mysql/replication_test.go
+
+package storage
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReplicatedTablesHaveTriggers(t *testing.T) {
+	migrations, err := Migrations()
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	var script string
+	for _, migration := range migrations {
+		script += migration.SQL
+	}
+
+	for table, columns := range ReplicatedTables {
+		for _, op := range []string{"INSERT", "UPDATE", "DELETE"} {
+			trigger := "CREATE TRIGGER " + table + "_replicate_" + strings.ToLower(op) + " AFTER " + op + " ON " + table + " "
+			if !strings.Contains(script, trigger) {
+				t.Errorf("Expected trigger %s on %s", strings.ToLower(op), table)
+			}
+		}
+		for _, column := range columns {
+			if !strings.Contains(script, "'"+column+"', NEW."+column) {
+				t.Errorf("Expected key column %s of %s in the triggers", column, table)
+			}
+		}
+	}
+}
+
+func TestKeyCondition(t *testing.T) {
+	where, args, err := keyCondition([]string{"user_id", "organization_id"},
+		map[string]string{"user_id": "u1", "organization_id": "o1"})
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if where != "`user_id` = ? AND `organization_id` = ?" || len(args) != 2 || args[0] != "u1" {
+		t.Errorf("Unexpected condition %q %v", where, args)
+	}
+
+	if _, _, err := keyCondition([]string{"id"}, map[string]string{}); err == nil {
+		t.Error("Expected error for a missing key column")
+	}
+}
@@ -0,0 +1,173 @@
+// filepath: internal/storage/mysql/scheduled_secret_changes_repository.go
+
+/*************************************************************************/
+/*                                                                       */
+/*   Ce fichier implémente le repository MySQL des changements de        */
+/*   valeur planifiés. La valeur en attente est conservée dans Vault.    */
+/*                                                                       */
+/*************************************************************************/
+
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"secrets-manager/internal/models"
+	repo "secrets-manager/internal/storage"
+)
+
+// ScheduledSecretChangesRepository gère les changements planifiés dans MySQL
+type ScheduledSecretChangesRepository struct {
+	db *sql.DB
+}
+
+var _ repo.ScheduledSecretChangesRepository = (*ScheduledSecretChangesRepository)(nil)
+
+// NewScheduledSecretChangesRepository crée un nouveau repository de changements planifiés
+func NewScheduledSecretChangesRepository(db *sql.DB) *ScheduledSecretChangesRepository {
+	return &ScheduledSecretChangesRepository{
+		db: db,
+	}
+}
+
+// SaveScheduledSecretChange crée ou remplace le changement planifié d'un
+// secret. Un changement remplacé garde son identifiant.
+func (r *ScheduledSecretChangesRepository) SaveScheduledSecretChange(ctx context.Context, change *models.ScheduledSecretChange) error {
+	if change.ID == "" {
+		change.ID = uuid.New().String()
+	}
+	change.CreatedAt = time.Now()
+
+	query := `
+		INSERT INTO scheduled_secret_changes (id, organization_id, project_id, environment, secret_name,
+			effective_at, created_by, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE effective_at = VALUES(effective_at), created_by = VALUES(created_by),
+			created_at = VALUES(created_at)
+	`
+
+	_, err := r.db.ExecContext(ctx, query, change.ID, change.OrganizationID, change.ProjectID,
+		change.Environment, change.SecretName, change.EffectiveAt, change.CreatedBy, change.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	saved, err := r.GetScheduledSecretChange(ctx, change.OrganizationID, change.ProjectID, change.Environment, change.SecretName)
+	if err != nil {
+		return err
+	}
+	*change = *saved
+	return nil
+}
+
+// GetScheduledSecretChange renvoie le changement planifié d'un secret
+func (r *ScheduledSecretChangesRepository) GetScheduledSecretChange(ctx context.Context, orgID, projectID, env, name string) (*models.ScheduledSecretChange, error) {
+	query := `
+		SELECT ` + scheduledSecretChangeColumns + `
+		FROM scheduled_secret_changes
+		WHERE organization_id = ? AND project_id = ? AND environment = ? AND secret_name = ?
+	`
+
+	change, err := scanScheduledSecretChange(r.db.QueryRowContext(ctx, query, orgID, projectID, env, name))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repo.ErrScheduleNotFound
+	}
+	return change, err
+}
+
+// DeleteScheduledSecretChange supprime un changement planifié
+func (r *ScheduledSecretChangesRepository) DeleteScheduledSecretChange(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM scheduled_secret_changes WHERE id = ?`, id)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return repo.ErrScheduleNotFound
+	}
+	return nil
+}
+
+// ListDueScheduledSecretChanges liste les changements à appliquer, du plus
+// ancien au plus récent
+func (r *ScheduledSecretChangesRepository) ListDueScheduledSecretChanges(ctx context.Context, before time.Time) ([]*models.ScheduledSecretChange, error) {
+	query := `
+		SELECT ` + scheduledSecretChangeColumns + `
+		FROM scheduled_secret_changes
+		WHERE effective_at <= ?
+		ORDER BY effective_at
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, before)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	changes := []*models.ScheduledSecretChange{}
+	for rows.Next() {
+		change, err := scanScheduledSecretChange(rows)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, change)
+	}
+	return changes, rows.Err()
+}
+
+// ListOrganizationScheduledSecretChanges liste les changements de
+// l'organisation à appliquer avant before, du plus ancien au plus récent
+func (r *ScheduledSecretChangesRepository) ListOrganizationScheduledSecretChanges(ctx context.Context, orgID string, before time.Time) ([]*models.ScheduledSecretChange, error) {
+	query := `
+		SELECT ` + scheduledSecretChangeColumns + `
+		FROM scheduled_secret_changes
+		WHERE organization_id = ? AND effective_at <= ?
+		ORDER BY effective_at
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, orgID, before)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	changes := []*models.ScheduledSecretChange{}
+	for rows.Next() {
+		change, err := scanScheduledSecretChange(rows)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, change)
+	}
+	return changes, rows.Err()
+}
+
+// Colonnes lues par scanScheduledSecretChange, dans le même ordre
+const scheduledSecretChangeColumns = `id, organization_id, project_id, environment, secret_name, effective_at,
+	created_by, created_at`
+
+func scanScheduledSecretChange(row rowScanner) (*models.ScheduledSecretChange, error) {
+	change := &models.ScheduledSecretChange{}
+	err := row.Scan(
+		&change.ID,
+		&change.OrganizationID,
+		&change.ProjectID,
+		&change.Environment,
+		&change.SecretName,
+		&change.EffectiveAt,
+		&change.CreatedBy,
+		&change.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return change, nil
+}
@@ -0,0 +1,208 @@
+// filepath: internal/storage/mysql/secret_reads_repository.go
+
+/*************************************************************************/
+/*                                                                       */
+/*   Ce fichier implémente le repository MySQL des lectures de secrets   */
+/*   Il agrège les lectures par secret, jour, charge de travail et       */
+/*   principal                                                           */
+/*                                                                       */
+/*************************************************************************/
+
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"strings"
+	"time"
+
+	"secrets-manager/internal/models"
+	repo "secrets-manager/internal/storage"
+)
+
+// SecretReadsRepository gère les compteurs de lecture des secrets dans MySQL
+type SecretReadsRepository struct {
+	db *sql.DB
+}
+
+var _ repo.SecretReadsRepository = (*SecretReadsRepository)(nil)
+
+// NewSecretReadsRepository crée un nouveau repository de lectures de secrets
+func NewSecretReadsRepository(db *sql.DB) *SecretReadsRepository {
+	return &SecretReadsRepository{
+		db: db,
+	}
+}
+
+// RecordSecretReads comptabilise un lot de lectures dans une seule transaction
+func (r *SecretReadsRepository) RecordSecretReads(ctx context.Context, reads []*models.SecretRead) error {
+	if len(reads) == 0 {
+		return nil
+	}
+
+	// Verrouiller les lignes toujours dans le même ordre évite les interblocages
+	sorted := make([]*models.SecretRead, len(reads))
+	copy(sorted, reads)
+	sort.Slice(sorted, func(i, j int) bool { return secretReadKey(sorted[i]) < secretReadKey(sorted[j]) })
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, read := range sorted {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO secret_reads (organization_id, project_id, environment, secret_name, day,
+				workload, principal_type, principal_id, read_count, last_read_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, 1, ?)
+			ON DUPLICATE KEY UPDATE read_count = read_count + 1, last_read_at = GREATEST(last_read_at, VALUES(last_read_at))
+		`, read.OrganizationID, read.ProjectID, read.Environment, read.SecretName, secretReadDay(read),
+			read.Workload, read.PrincipalType, read.PrincipalID, read.Timestamp)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// ListSecretConsumers résume les lectures d'un secret par charge de travail et principal
+func (r *SecretReadsRepository) ListSecretConsumers(
+	ctx context.Context,
+	orgID, projectID, env, name string,
+	since time.Time,
+) ([]*models.SecretConsumer, error) {
+	query := `
+		SELECT workload, principal_type, principal_id, SUM(read_count), MAX(last_read_at)
+		FROM secret_reads
+		WHERE organization_id = ? AND project_id = ? AND environment = ? AND secret_name = ? AND day >= ?
+		GROUP BY workload, principal_type, principal_id
+		ORDER BY MAX(last_read_at) DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, orgID, projectID, env, name, since.UTC().Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	consumers := []*models.SecretConsumer{}
+	for rows.Next() {
+		consumer := &models.SecretConsumer{}
+		if err := rows.Scan(&consumer.Workload, &consumer.PrincipalType, &consumer.PrincipalID,
+			&consumer.Reads, &consumer.LastReadAt); err != nil {
+			return nil, err
+		}
+		consumers = append(consumers, consumer)
+	}
+	return consumers, rows.Err()
+}
+
+// ListProjectDependencies résume les lectures des secrets d'un projet par
+// environnement, secret et charge de travail
+func (r *SecretReadsRepository) ListProjectDependencies(
+	ctx context.Context,
+	orgID, projectID string,
+	since time.Time,
+) ([]*models.SecretDependency, error) {
+	query := `
+		SELECT environment, secret_name, workload, SUM(read_count), MAX(last_read_at)
+		FROM secret_reads
+		WHERE organization_id = ? AND project_id = ? AND day >= ?
+		GROUP BY environment, secret_name, workload
+		ORDER BY environment, secret_name, workload
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, orgID, projectID, since.UTC().Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	dependencies := []*models.SecretDependency{}
+	for rows.Next() {
+		dependency := &models.SecretDependency{}
+		if err := rows.Scan(&dependency.Environment, &dependency.SecretName, &dependency.Workload,
+			&dependency.Reads, &dependency.LastReadAt); err != nil {
+			return nil, err
+		}
+		dependencies = append(dependencies, dependency)
+	}
+	return dependencies, rows.Err()
+}
+
+// ListOrganizationDailyReads totalise les lectures des secrets de
+// l'organisation par projet, environnement et jour
+func (r *SecretReadsRepository) ListOrganizationDailyReads(
+	ctx context.Context,
+	orgID string,
+	since time.Time,
+) ([]*models.DailySecretReads, error) {
+	query := `
+		SELECT project_id, environment, DATE_FORMAT(day, '%Y-%m-%d'), SUM(read_count)
+		FROM secret_reads
+		WHERE organization_id = ? AND day >= ?
+		GROUP BY project_id, environment, day
+		ORDER BY project_id, environment, day
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, orgID, since.UTC().Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	totals := []*models.DailySecretReads{}
+	for rows.Next() {
+		total := &models.DailySecretReads{}
+		if err := rows.Scan(&total.ProjectID, &total.Environment, &total.Day, &total.Reads); err != nil {
+			return nil, err
+		}
+		totals = append(totals, total)
+	}
+	return totals, rows.Err()
+}
+
+// ListOrganizationSecretAccess résume les lectures des secrets de
+// l'organisation par principal et secret
+func (r *SecretReadsRepository) ListOrganizationSecretAccess(
+	ctx context.Context,
+	orgID string,
+	since time.Time,
+) ([]*models.SecretAccess, error) {
+	query := `
+		SELECT principal_type, principal_id, project_id, environment, secret_name, SUM(read_count), MAX(last_read_at)
+		FROM secret_reads
+		WHERE organization_id = ? AND day >= ?
+		GROUP BY principal_type, principal_id, project_id, environment, secret_name
+		ORDER BY principal_type, principal_id, project_id, environment, secret_name
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, orgID, since.UTC().Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	accesses := []*models.SecretAccess{}
+	for rows.Next() {
+		access := &models.SecretAccess{}
+		if err := rows.Scan(&access.PrincipalType, &access.PrincipalID, &access.ProjectID, &access.Environment,
+			&access.SecretName, &access.Reads, &access.LastReadAt); err != nil {
+			return nil, err
+		}
+		accesses = append(accesses, access)
+	}
+	return accesses, rows.Err()
+}
+
+func secretReadDay(read *models.SecretRead) string {
+	return read.Timestamp.UTC().Format("2006-01-02")
+}
+
+func secretReadKey(read *models.SecretRead) string {
+	return strings.Join([]string{read.OrganizationID, read.ProjectID, read.Environment, read.SecretName,
+		secretReadDay(read), read.Workload, read.PrincipalType, read.PrincipalID}, "\x00")
+}
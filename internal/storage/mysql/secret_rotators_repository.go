@@ -0,0 +1,250 @@
+// filepath: internal/storage/mysql/secret_rotators_repository.go
+
+/*************************************************************************/
+/*                                                                       */
+/*   Ce fichier implémente le repository MySQL des rotateurs de secrets  */
+/*   Un secret a au plus un rotateur, identifié par son chemin           */
+/*                                                                       */
+/*************************************************************************/
+
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"secrets-manager/internal/models"
+	repo "secrets-manager/internal/storage"
+)
+
+// SecretRotatorsRepository gère les rotateurs de secrets dans MySQL
+type SecretRotatorsRepository struct {
+	db *sql.DB
+}
+
+var _ repo.SecretRotatorsRepository = (*SecretRotatorsRepository)(nil)
+
+// NewSecretRotatorsRepository crée un nouveau repository de rotateurs
+func NewSecretRotatorsRepository(db *sql.DB) *SecretRotatorsRepository {
+	return &SecretRotatorsRepository{
+		db: db,
+	}
+}
+
+// SaveSecretRotator crée ou remplace le rotateur d'un secret. Le résultat
+// de la dernière rotation est conservé.
+func (r *SecretRotatorsRepository) SaveSecretRotator(ctx context.Context, rotator *models.SecretRotator) error {
+	if rotator.ID == "" {
+		rotator.ID = uuid.New().String()
+	}
+	now := time.Now()
+	rotator.CreatedAt = now
+	rotator.UpdatedAt = now
+
+	config, err := json.Marshal(rotator.Config)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO secret_rotators (id, organization_id, project_id, environment, secret_name, provider,
+			config, credentials_secret, strategy, overlap_seconds, created_by, created_at, updated_at, last_error)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, '')
+		ON DUPLICATE KEY UPDATE provider = VALUES(provider), config = VALUES(config),
+			credentials_secret = VALUES(credentials_secret), strategy = VALUES(strategy),
+			overlap_seconds = VALUES(overlap_seconds), updated_at = VALUES(updated_at)
+	`
+
+	_, err = r.db.ExecContext(ctx, query, rotator.ID, rotator.OrganizationID, rotator.ProjectID,
+		rotator.Environment, rotator.SecretName, rotator.Provider, string(config), rotator.CredentialsSecret,
+		rotator.Strategy, rotator.OverlapSeconds, rotator.CreatedBy, rotator.CreatedAt, rotator.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	// Un rotateur remplacé garde son identifiant et sa date de création
+	saved, err := r.GetSecretRotator(ctx, rotator.OrganizationID, rotator.ProjectID, rotator.Environment, rotator.SecretName)
+	if err != nil {
+		return err
+	}
+	*rotator = *saved
+	return nil
+}
+
+// GetSecretRotator renvoie le rotateur d'un secret
+func (r *SecretRotatorsRepository) GetSecretRotator(ctx context.Context, orgID, projectID, env, name string) (*models.SecretRotator, error) {
+	query := `
+		SELECT ` + secretRotatorColumns + `
+		FROM secret_rotators
+		WHERE organization_id = ? AND project_id = ? AND environment = ? AND secret_name = ?
+	`
+
+	rotator, err := scanSecretRotator(r.db.QueryRowContext(ctx, query, orgID, projectID, env, name))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repo.ErrRotatorNotFound
+	}
+	return rotator, err
+}
+
+// DeleteSecretRotator supprime le rotateur d'un secret
+func (r *SecretRotatorsRepository) DeleteSecretRotator(ctx context.Context, orgID, projectID, env, name string) error {
+	result, err := r.db.ExecContext(ctx, `
+		DELETE FROM secret_rotators
+		WHERE organization_id = ? AND project_id = ? AND environment = ? AND secret_name = ?
+	`, orgID, projectID, env, name)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return repo.ErrRotatorNotFound
+	}
+	return nil
+}
+
+// RecordSecretRotation enregistre le résultat d'une rotation
+func (r *SecretRotatorsRepository) RecordSecretRotation(ctx context.Context, id string, at time.Time, lastError string) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE secret_rotators SET last_rotated_at = ?, last_error = ? WHERE id = ?
+	`, at, lastError, id)
+	return err
+}
+
+// SetPreviousExpiry enregistre la date de retrait de la valeur précédente
+func (r *SecretRotatorsRepository) SetPreviousExpiry(ctx context.Context, id string, expiresAt *time.Time) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE secret_rotators SET previous_expires_at = ? WHERE id = ?
+	`, expiresAt, id)
+	return err
+}
+
+// ListExpiredPreviousValues liste les rotateurs dont la valeur précédente
+// doit être retirée, de la plus ancienne échéance à la plus récente
+func (r *SecretRotatorsRepository) ListExpiredPreviousValues(ctx context.Context, before time.Time) ([]*models.SecretRotator, error) {
+	query := `
+		SELECT ` + secretRotatorColumns + `
+		FROM secret_rotators
+		WHERE previous_expires_at IS NOT NULL AND previous_expires_at <= ?
+		ORDER BY previous_expires_at
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, before)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rotators := []*models.SecretRotator{}
+	for rows.Next() {
+		rotator, err := scanSecretRotator(rows)
+		if err != nil {
+			return nil, err
+		}
+		rotators = append(rotators, rotator)
+	}
+	return rotators, rows.Err()
+}
+
+// ListOrganizationPreviousExpiries liste les rotateurs de l'organisation
+// dont la valeur précédente sera retirée avant before
+func (r *SecretRotatorsRepository) ListOrganizationPreviousExpiries(ctx context.Context, orgID string, before time.Time) ([]*models.SecretRotator, error) {
+	query := `
+		SELECT ` + secretRotatorColumns + `
+		FROM secret_rotators
+		WHERE organization_id = ? AND previous_expires_at IS NOT NULL AND previous_expires_at <= ?
+		ORDER BY previous_expires_at
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, orgID, before)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rotators := []*models.SecretRotator{}
+	for rows.Next() {
+		rotator, err := scanSecretRotator(rows)
+		if err != nil {
+			return nil, err
+		}
+		rotators = append(rotators, rotator)
+	}
+	return rotators, rows.Err()
+}
+
+// ListOrganizationSecretRotators liste les rotateurs des secrets de l'organisation
+func (r *SecretRotatorsRepository) ListOrganizationSecretRotators(ctx context.Context, orgID string) ([]*models.SecretRotator, error) {
+	query := `
+		SELECT ` + secretRotatorColumns + `
+		FROM secret_rotators
+		WHERE organization_id = ?
+		ORDER BY project_id, environment, secret_name
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, orgID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rotators := []*models.SecretRotator{}
+	for rows.Next() {
+		rotator, err := scanSecretRotator(rows)
+		if err != nil {
+			return nil, err
+		}
+		rotators = append(rotators, rotator)
+	}
+	return rotators, rows.Err()
+}
+
+// Colonnes lues par scanSecretRotator, dans le même ordre
+const secretRotatorColumns = `id, organization_id, project_id, environment, secret_name, provider, config,
+	credentials_secret, created_by, created_at, updated_at, last_rotated_at, last_error, strategy,
+	overlap_seconds, previous_expires_at`
+
+func scanSecretRotator(row rowScanner) (*models.SecretRotator, error) {
+	rotator := &models.SecretRotator{}
+	var config []byte
+	var lastRotatedAt, previousExpiresAt sql.NullTime
+
+	err := row.Scan(
+		&rotator.ID,
+		&rotator.OrganizationID,
+		&rotator.ProjectID,
+		&rotator.Environment,
+		&rotator.SecretName,
+		&rotator.Provider,
+		&config,
+		&rotator.CredentialsSecret,
+		&rotator.CreatedBy,
+		&rotator.CreatedAt,
+		&rotator.UpdatedAt,
+		&lastRotatedAt,
+		&rotator.LastError,
+		&rotator.Strategy,
+		&rotator.OverlapSeconds,
+		&previousExpiresAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(config, &rotator.Config); err != nil {
+		return nil, err
+	}
+	if lastRotatedAt.Valid {
+		rotator.LastRotatedAt = &lastRotatedAt.Time
+	}
+	if previousExpiresAt.Valid {
+		rotator.PreviousExpiresAt = &previousExpiresAt.Time
+	}
+	return rotator, nil
+}
@@ -13,10 +13,12 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"strings"
 
 	"github.com/google/uuid"
 
 	"secrets-manager/internal/models"
+	repo "secrets-manager/internal/storage"
 )
 
 // SecretsRepository gère l'accès aux métadonnées des secrets dans MySQL
@@ -24,6 +26,8 @@ type SecretsRepository struct {
 	db *sql.DB
 }
 
+var _ repo.SecretsRepository = (*SecretsRepository)(nil)
+
 // NewSecretsRepository crée un nouveau repository pour les secrets
 func NewSecretsRepository(db *sql.DB) *SecretsRepository {
 	return &SecretsRepository{
@@ -41,8 +45,9 @@ func (r *SecretsRepository) CreateSecretMetadata(ctx context.Context, metadata *
 	query := `
 		INSERT INTO secret_metadata (
 			id, name, description, organization_id, project_id, 
-			environment, created_by, created_at, updated_at, version
-		) VALUES (?, ?, ?, ?, ?, ?, ?, NOW(), NOW(), ?)
+			environment, created_by, created_at, updated_at, version, e2e, checksum,
+			value_hash, value_length, value_entropy, owner_id, team_id
+		) VALUES (?, ?, ?, ?, ?, ?, ?, NOW(), NOW(), ?, ?, NULLIF(?, ''), NULLIF(?, ''), ?, ?, ?, ?)
 	`
 
 	_, err := r.db.ExecContext(
@@ -56,8 +61,18 @@ func (r *SecretsRepository) CreateSecretMetadata(ctx context.Context, metadata *
 		metadata.Environment,
 		metadata.CreatedBy,
 		metadata.Version,
+		metadata.E2E,
+		metadata.Checksum,
+		metadata.ValueHash,
+		metadata.ValueLength,
+		metadata.ValueEntropy,
+		metadata.OwnerID,
+		metadata.TeamID,
 	)
 
+	if isDuplicateEntry(err) {
+		return repo.ErrSecretAlreadyExists
+	}
 	if err != nil {
 		return err
 	}
@@ -69,25 +84,12 @@ func (r *SecretsRepository) CreateSecretMetadata(ctx context.Context, metadata *
 // GetSecretMetadata récupère les métadonnées d'un secret par son ID
 func (r *SecretsRepository) GetSecretMetadata(ctx context.Context, id string) (*models.SecretMetadata, error) {
 	query := `
-		SELECT id, name, description, organization_id, project_id, 
-			   environment, created_by, created_at, updated_at, version
+		SELECT ` + secretMetadataColumns + `
 		FROM secret_metadata
 		WHERE id = ?
 	`
 
-	metadata := &models.SecretMetadata{}
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&metadata.ID,
-		&metadata.Name,
-		&metadata.Description,
-		&metadata.OrganizationID,
-		&metadata.ProjectID,
-		&metadata.Environment,
-		&metadata.CreatedBy,
-		&metadata.CreatedAt,
-		&metadata.UpdatedAt,
-		&metadata.Version,
-	)
+	metadata, err := scanSecretMetadata(r.db.QueryRowContext(ctx, query, id))
 
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -105,25 +107,12 @@ func (r *SecretsRepository) GetSecretMetadataByPath(
 	orgID, projectID, env, name string,
 ) (*models.SecretMetadata, error) {
 	query := `
-		SELECT id, name, description, organization_id, project_id, 
-			   environment, created_by, created_at, updated_at, version
+		SELECT ` + secretMetadataColumns + `
 		FROM secret_metadata
 		WHERE organization_id = ? AND project_id = ? AND environment = ? AND name = ?
 	`
 
-	metadata := &models.SecretMetadata{}
-	err := r.db.QueryRowContext(ctx, query, orgID, projectID, env, name).Scan(
-		&metadata.ID,
-		&metadata.Name,
-		&metadata.Description,
-		&metadata.OrganizationID,
-		&metadata.ProjectID,
-		&metadata.Environment,
-		&metadata.CreatedBy,
-		&metadata.CreatedAt,
-		&metadata.UpdatedAt,
-		&metadata.Version,
-	)
+	metadata, err := scanSecretMetadata(r.db.QueryRowContext(ctx, query, orgID, projectID, env, name))
 
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -141,8 +130,7 @@ func (r *SecretsRepository) ListProjectSecrets(
 	orgID, projectID, env string,
 ) ([]*models.SecretMetadata, error) {
 	query := `
-		SELECT id, name, description, organization_id, project_id, 
-			   environment, created_by, created_at, updated_at, version
+		SELECT ` + secretMetadataColumns + `
 		FROM secret_metadata
 		WHERE organization_id = ? AND project_id = ? AND environment = ?
 	`
@@ -155,19 +143,7 @@ func (r *SecretsRepository) ListProjectSecrets(
 
 	var secrets []*models.SecretMetadata
 	for rows.Next() {
-		metadata := &models.SecretMetadata{}
-		err := rows.Scan(
-			&metadata.ID,
-			&metadata.Name,
-			&metadata.Description,
-			&metadata.OrganizationID,
-			&metadata.ProjectID,
-			&metadata.Environment,
-			&metadata.CreatedBy,
-			&metadata.CreatedAt,
-			&metadata.UpdatedAt,
-			&metadata.Version,
-		)
+		metadata, err := scanSecretMetadata(rows)
 		if err != nil {
 			return nil, err
 		}
@@ -181,11 +157,119 @@ func (r *SecretsRepository) ListProjectSecrets(
 	return secrets, nil
 }
 
+// ListSecretsByProjects liste en une requête les secrets de plusieurs projets
+// d'une organisation, dans un environnement ou dans tous (env vide)
+func (r *SecretsRepository) ListSecretsByProjects(
+	ctx context.Context,
+	orgID string,
+	projectIDs []string,
+	env string,
+) ([]*models.SecretMetadata, error) {
+	if len(projectIDs) == 0 {
+		return []*models.SecretMetadata{}, nil
+	}
+
+	query := `
+		SELECT ` + secretMetadataColumns + `
+		FROM secret_metadata
+		WHERE organization_id = ? AND project_id IN (?` + strings.Repeat(", ?", len(projectIDs)-1) + `)
+	`
+	args := []interface{}{orgID}
+	for _, id := range projectIDs {
+		args = append(args, id)
+	}
+	if env != "" {
+		query += " AND environment = ?"
+		args = append(args, env)
+	}
+	query += " ORDER BY project_id, environment, name"
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	secrets := []*models.SecretMetadata{}
+	for rows.Next() {
+		metadata, err := scanSecretMetadata(rows)
+		if err != nil {
+			return nil, err
+		}
+		secrets = append(secrets, metadata)
+	}
+
+	return secrets, rows.Err()
+}
+
+// ListSecretsByValueHashes liste les secrets d'une organisation, tous
+// projets confondus, dont l'empreinte de la valeur figure parmi hashes
+func (r *SecretsRepository) ListSecretsByValueHashes(
+	ctx context.Context,
+	orgID string,
+	hashes []string,
+) ([]*models.SecretMetadata, error) {
+	if len(hashes) == 0 {
+		return []*models.SecretMetadata{}, nil
+	}
+
+	query := `
+		SELECT ` + secretMetadataColumns + `
+		FROM secret_metadata
+		WHERE organization_id = ? AND value_hash IN (?` + strings.Repeat(", ?", len(hashes)-1) + `)
+		ORDER BY value_hash, project_id, environment, name
+	`
+	args := []interface{}{orgID}
+	for _, hash := range hashes {
+		args = append(args, hash)
+	}
+
+	return r.querySecretMetadata(ctx, query, args...)
+}
+
+// ListDuplicateSecretValues liste les secrets d'une organisation dont la
+// valeur est partagée avec au moins un autre secret, groupés par empreinte
+func (r *SecretsRepository) ListDuplicateSecretValues(ctx context.Context, orgID string) ([]*models.SecretMetadata, error) {
+	query := `
+		SELECT ` + secretMetadataColumns + `
+		FROM secret_metadata
+		WHERE organization_id = ? AND value_hash IN (
+			SELECT value_hash FROM secret_metadata
+			WHERE organization_id = ? AND value_hash IS NOT NULL
+			GROUP BY value_hash
+			HAVING COUNT(*) > 1
+		)
+		ORDER BY value_hash, project_id, environment, name
+	`
+	return r.querySecretMetadata(ctx, query, orgID, orgID)
+}
+
+// querySecretMetadata exécute une requête qui sélectionne secretMetadataColumns
+func (r *SecretsRepository) querySecretMetadata(ctx context.Context, query string, args ...interface{}) ([]*models.SecretMetadata, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	secrets := []*models.SecretMetadata{}
+	for rows.Next() {
+		metadata, err := scanSecretMetadata(rows)
+		if err != nil {
+			return nil, err
+		}
+		secrets = append(secrets, metadata)
+	}
+
+	return secrets, rows.Err()
+}
+
 // UpdateSecretMetadata met à jour les métadonnées d'un secret
 func (r *SecretsRepository) UpdateSecretMetadata(ctx context.Context, metadata *models.SecretMetadata) error {
 	query := `
 		UPDATE secret_metadata
-		SET name = ?, description = ?, updated_at = NOW(), version = ?
+		SET name = ?, description = ?, updated_at = NOW(), version = ?, e2e = ?, checksum = NULLIF(?, ''),
+		    value_hash = NULLIF(?, ''), value_length = ?, value_entropy = ?
 		WHERE id = ?
 	`
 
@@ -195,12 +279,33 @@ func (r *SecretsRepository) UpdateSecretMetadata(ctx context.Context, metadata *
 		metadata.Name,
 		metadata.Description,
 		metadata.Version,
+		metadata.E2E,
+		metadata.Checksum,
+		metadata.ValueHash,
+		metadata.ValueLength,
+		metadata.ValueEntropy,
 		metadata.ID,
 	)
 
 	return err
 }
 
+// SetSecretChecksum enregistre la somme de contrôle d'un secret
+func (r *SecretsRepository) SetSecretChecksum(ctx context.Context, id, checksum string) error {
+	query := "UPDATE secret_metadata SET checksum = ? WHERE id = ?"
+
+	_, err := r.db.ExecContext(ctx, query, checksum, id)
+	return err
+}
+
+// SetSecretOwnership désigne les responsables d'un secret
+func (r *SecretsRepository) SetSecretOwnership(ctx context.Context, id string, ownership models.Ownership) error {
+	query := "UPDATE secret_metadata SET owner_id = ?, team_id = ? WHERE id = ?"
+
+	_, err := r.db.ExecContext(ctx, query, ownership.OwnerID, ownership.TeamID, id)
+	return err
+}
+
 // DeleteSecretMetadata supprime les métadonnées d'un secret
 func (r *SecretsRepository) DeleteSecretMetadata(ctx context.Context, id string, orgID string) error {
 	query := "DELETE FROM secret_metadata WHERE id = ?"
@@ -232,6 +337,84 @@ func (r *SecretsRepository) DeleteSecretMetadataByPath(
 	return r.DeleteSecretMetadata(ctx, metadata.ID, orgID)
 }
 
+// LockSecret verrouille un secret en conservant l'auteur et la raison
+func (r *SecretsRepository) LockSecret(ctx context.Context, id, userID, reason string) error {
+	query := `
+		UPDATE secret_metadata
+		SET locked_by = ?, locked_at = NOW(), lock_reason = ?
+		WHERE id = ?
+	`
+
+	_, err := r.db.ExecContext(ctx, query, userID, reason, id)
+	return err
+}
+
+// UnlockSecret retire le verrou d'un secret
+func (r *SecretsRepository) UnlockSecret(ctx context.Context, id string) error {
+	query := `
+		UPDATE secret_metadata
+		SET locked_by = NULL, locked_at = NULL, lock_reason = NULL
+		WHERE id = ?
+	`
+
+	_, err := r.db.ExecContext(ctx, query, id)
+	return err
+}
+
+// Colonnes lues par scanSecretMetadata, dans le même ordre
+const secretMetadataColumns = `id, name, description, organization_id, project_id,
+			   environment, created_by, created_at, updated_at, version,
+			   locked_by, locked_at, lock_reason, e2e, checksum,
+			   value_hash, value_length, value_entropy, owner_id, team_id`
+
+// rowScanner est implémenté par *sql.Row et *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanSecretMetadata lit une ligne sélectionnée avec secretMetadataColumns
+func scanSecretMetadata(row rowScanner) (*models.SecretMetadata, error) {
+	metadata := &models.SecretMetadata{}
+	var lockedBy, lockReason, checksum, valueHash sql.NullString
+	var lockedAt sql.NullTime
+
+	err := row.Scan(
+		&metadata.ID,
+		&metadata.Name,
+		&metadata.Description,
+		&metadata.OrganizationID,
+		&metadata.ProjectID,
+		&metadata.Environment,
+		&metadata.CreatedBy,
+		&metadata.CreatedAt,
+		&metadata.UpdatedAt,
+		&metadata.Version,
+		&lockedBy,
+		&lockedAt,
+		&lockReason,
+		&metadata.E2E,
+		&checksum,
+		&valueHash,
+		&metadata.ValueLength,
+		&metadata.ValueEntropy,
+		&metadata.OwnerID,
+		&metadata.TeamID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if lockedAt.Valid {
+		metadata.LockedAt = &lockedAt.Time
+		metadata.LockedBy = lockedBy.String
+		metadata.LockReason = lockReason.String
+	}
+	metadata.Checksum = checksum.String
+	metadata.ValueHash = valueHash.String
+
+	return metadata, nil
+}
+
 // Méthodes pour la gestion des statistiques
 
 func (r *SecretsRepository) incrementSecretsCount(ctx context.Context, orgID string) error {
@@ -292,6 +475,73 @@ func (r *SecretsRepository) GetSecretsCount(ctx context.Context, orgID string) (
 	return count, nil
 }
 
+// ListSecretCounts compare les compteurs enregistrés aux métadonnées de chaque organisation
+func (r *SecretsRepository) ListSecretCounts(ctx context.Context) ([]*models.SecretCount, error) {
+	query := `
+		SELECT o.id, COALESCE(us.secret_count, 0), COALESCE(sm.actual, 0)
+		FROM organizations o
+		LEFT JOIN usage_statistics us ON us.organization_id = o.id
+		LEFT JOIN (
+			SELECT organization_id, COUNT(*) AS actual
+			FROM secret_metadata
+			GROUP BY organization_id
+		) sm ON sm.organization_id = o.id
+		ORDER BY o.id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := []*models.SecretCount{}
+	for rows.Next() {
+		count := &models.SecretCount{}
+		if err := rows.Scan(&count.OrganizationID, &count.Recorded, &count.Actual); err != nil {
+			return nil, err
+		}
+		counts = append(counts, count)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+// SetSecretsCount corrige le compteur de secrets d'une organisation
+func (r *SecretsRepository) SetSecretsCount(ctx context.Context, orgID string, count int) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE usage_statistics
+		SET secret_count = ?, last_updated = NOW()
+		WHERE organization_id = ?
+	`, count, orgID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	// Une valeur identique n'est pas comptée comme modifiée par MySQL :
+	// n'insérer que si la ligne n'existe vraiment pas
+	if rows == 0 {
+		_, err = r.db.ExecContext(ctx, `
+			INSERT INTO usage_statistics (id, organization_id, secret_count, api_calls, last_updated)
+			SELECT ?, ?, ?, 0, NOW()
+			FROM DUAL
+			WHERE NOT EXISTS (SELECT 1 FROM usage_statistics WHERE organization_id = ?)
+		`, uuid.New().String(), orgID, count, orgID)
+		return err
+	}
+
+	return nil
+}
+
 // GetSecretsLimit obtient la limite de secrets pour une organisation
 func (r *SecretsRepository) GetSecretsLimit(ctx context.Context, orgID string) (int, error) {
 	query := `
@@ -0,0 +1,283 @@
+// filepath: internal/storage/mysql/service_accounts_repository.go
+
+/*************************************************************************/
+/*                                                                       */
+/*   Ce fichier implémente le repository MySQL des comptes de service    */
+/*   des organisations (identités machine à JWT restreints)              */
+/*                                                                       */
+/*************************************************************************/
+
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"secrets-manager/internal/models"
+	repo "secrets-manager/internal/storage"
+)
+
+// ServiceAccountsRepository gère les comptes de service dans MySQL
+type ServiceAccountsRepository struct {
+	db *sql.DB
+}
+
+var _ repo.ServiceAccountsRepository = (*ServiceAccountsRepository)(nil)
+
+// NewServiceAccountsRepository crée un nouveau repository de comptes de service
+func NewServiceAccountsRepository(db *sql.DB) *ServiceAccountsRepository {
+	return &ServiceAccountsRepository{
+		db: db,
+	}
+}
+
+// CreateServiceAccount enregistre un compte de service
+func (r *ServiceAccountsRepository) CreateServiceAccount(ctx context.Context, account *models.ServiceAccount) error {
+	if account.ID == "" {
+		account.ID = uuid.New().String()
+	}
+	account.CreatedAt = time.Now()
+	resources, err := json.Marshal(account.Resources)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO service_accounts (id, organization_id, name, description, secret_hash, secret_prefix,
+			permission, resources, spiffe_id, created_by, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err = r.db.ExecContext(ctx, query, account.ID, account.OrganizationID, account.Name, account.Description,
+		account.SecretHash, account.SecretPrefix, account.Permission, string(resources),
+		sql.NullString{String: account.SPIFFEID, Valid: account.SPIFFEID != ""},
+		account.CreatedBy, account.CreatedAt)
+	if isDuplicateEntry(err) {
+		return repo.ErrSPIFFEIDTaken
+	}
+	return err
+}
+
+// GetServiceAccount récupère un compte de service
+func (r *ServiceAccountsRepository) GetServiceAccount(ctx context.Context, id string) (*models.ServiceAccount, error) {
+	query := `
+		SELECT ` + serviceAccountColumns + `
+		FROM service_accounts
+		WHERE id = ?
+	`
+
+	account, err := scanServiceAccount(r.db.QueryRowContext(ctx, query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repo.ErrServiceAccountNotFound
+	}
+	return account, err
+}
+
+// GetServiceAccountBySPIFFEID récupère le compte de l'organisation associé à
+// l'identifiant SPIFFE
+func (r *ServiceAccountsRepository) GetServiceAccountBySPIFFEID(ctx context.Context,
+	orgID, spiffeID string) (*models.ServiceAccount, error) {
+	query := `
+		SELECT ` + serviceAccountColumns + `
+		FROM service_accounts
+		WHERE organization_id = ? AND spiffe_id = ?
+	`
+
+	account, err := scanServiceAccount(r.db.QueryRowContext(ctx, query, orgID, spiffeID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repo.ErrServiceAccountNotFound
+	}
+	return account, err
+}
+
+// SetServiceAccountSPIFFEID associe un identifiant SPIFFE à un compte de
+// l'organisation. L'index unique sur (organization_id, spiffe_id) (NULL sans
+// identifiant) empêche de l'associer à deux comptes de l'organisation.
+func (r *ServiceAccountsRepository) SetServiceAccountSPIFFEID(ctx context.Context, orgID, id, spiffeID string) error {
+	result, err := r.db.ExecContext(ctx, "UPDATE service_accounts SET spiffe_id = ? WHERE id = ? AND organization_id = ?",
+		sql.NullString{String: spiffeID, Valid: spiffeID != ""}, id, orgID)
+	if isDuplicateEntry(err) {
+		return repo.ErrSPIFFEIDTaken
+	}
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		// Sans changement, MySQL ne compte pas la ligne : vérifier qu'elle existe
+		var exists bool
+		err := r.db.QueryRowContext(ctx,
+			"SELECT EXISTS(SELECT 1 FROM service_accounts WHERE id = ? AND organization_id = ?)", id, orgID).Scan(&exists)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return repo.ErrServiceAccountNotFound
+		}
+	}
+	return nil
+}
+
+// ListServiceAccounts liste les comptes de l'organisation
+func (r *ServiceAccountsRepository) ListServiceAccounts(ctx context.Context, orgID string) ([]*models.ServiceAccount, error) {
+	query := `
+		SELECT ` + serviceAccountColumns + `
+		FROM service_accounts
+		WHERE organization_id = ?
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, orgID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	accounts := []*models.ServiceAccount{}
+	for rows.Next() {
+		account, err := scanServiceAccount(rows)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, account)
+	}
+	return accounts, rows.Err()
+}
+
+// DeleteServiceAccount supprime un compte de l'organisation
+func (r *ServiceAccountsRepository) DeleteServiceAccount(ctx context.Context, orgID, id string) error {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM service_accounts WHERE id = ? AND organization_id = ?", id, orgID)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return repo.ErrServiceAccountNotFound
+	}
+	return nil
+}
+
+// TouchServiceAccount enregistre la date de dernière utilisation
+func (r *ServiceAccountsRepository) TouchServiceAccount(ctx context.Context, id string, at time.Time) error {
+	_, err := r.db.ExecContext(ctx, "UPDATE service_accounts SET last_used_at = ? WHERE id = ?", at, id)
+	return err
+}
+
+// GetServiceAccountTrust récupère la confiance mTLS de l'organisation
+func (r *ServiceAccountsRepository) GetServiceAccountTrust(ctx context.Context,
+	orgID string) (*models.ServiceAccountTrust, error) {
+	query := `
+		SELECT ` + serviceAccountTrustColumns + `
+		FROM service_account_trusts
+		WHERE organization_id = ?
+	`
+
+	trust, err := scanServiceAccountTrust(r.db.QueryRowContext(ctx, query, orgID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repo.ErrTrustDomainNotFound
+	}
+	return trust, err
+}
+
+// GetServiceAccountTrustByDomain récupère la confiance mTLS du domaine de confiance
+func (r *ServiceAccountsRepository) GetServiceAccountTrustByDomain(ctx context.Context,
+	trustDomain string) (*models.ServiceAccountTrust, error) {
+	query := `
+		SELECT ` + serviceAccountTrustColumns + `
+		FROM service_account_trusts
+		WHERE trust_domain = ?
+	`
+
+	trust, err := scanServiceAccountTrust(r.db.QueryRowContext(ctx, query, trustDomain))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repo.ErrTrustDomainNotFound
+	}
+	return trust, err
+}
+
+// SaveServiceAccountTrust crée ou remplace la confiance mTLS de
+// l'organisation. INSERT ... ON DUPLICATE KEY UPDATE modifierait la ligne de
+// l'organisation propriétaire du domaine : la mise à jour et la création
+// sont séparées, l'index unique sur trust_domain refuse un domaine déjà pris.
+func (r *ServiceAccountsRepository) SaveServiceAccountTrust(ctx context.Context,
+	trust *models.ServiceAccountTrust) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE service_account_trusts SET trust_domain = ?, ca_bundle = ?, updated_by = ?, updated_at = ?
+		WHERE organization_id = ?
+	`, trust.TrustDomain, trust.CABundle, trust.UpdatedBy, trust.UpdatedAt, trust.OrganizationID)
+	if isDuplicateEntry(err) {
+		return repo.ErrTrustDomainTaken
+	}
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil || affected > 0 {
+		return err
+	}
+
+	// Sans changement, MySQL ne compte pas la ligne : vérifier qu'elle existe
+	var exists bool
+	err = r.db.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM service_account_trusts WHERE organization_id = ?)",
+		trust.OrganizationID).Scan(&exists)
+	if err != nil || exists {
+		return err
+	}
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO service_account_trusts (organization_id, trust_domain, ca_bundle, updated_by, updated_at)
+		VALUES (?, ?, ?, ?, ?)
+	`, trust.OrganizationID, trust.TrustDomain, trust.CABundle, trust.UpdatedBy, trust.UpdatedAt)
+	if isDuplicateEntry(err) {
+		return repo.ErrTrustDomainTaken
+	}
+	return err
+}
+
+// Colonnes lues par scanServiceAccountTrust, dans le même ordre
+const serviceAccountTrustColumns = `organization_id, trust_domain, ca_bundle, updated_by, updated_at`
+
+func scanServiceAccountTrust(row rowScanner) (*models.ServiceAccountTrust, error) {
+	trust := &models.ServiceAccountTrust{}
+	err := row.Scan(&trust.OrganizationID, &trust.TrustDomain, &trust.CABundle, &trust.UpdatedBy, &trust.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return trust, nil
+}
+
+// Colonnes lues par scanServiceAccount, dans le même ordre
+const serviceAccountColumns = `id, organization_id, name, description, secret_hash, secret_prefix, permission,
+	resources, spiffe_id, created_by, last_used_at, created_at`
+
+func scanServiceAccount(row rowScanner) (*models.ServiceAccount, error) {
+	account := &models.ServiceAccount{}
+	var resources []byte
+	var spiffeID sql.NullString
+	var lastUsedAt sql.NullTime
+
+	err := row.Scan(&account.ID, &account.OrganizationID, &account.Name, &account.Description, &account.SecretHash,
+		&account.SecretPrefix, &account.Permission, &resources, &spiffeID, &account.CreatedBy, &lastUsedAt,
+		&account.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	account.SPIFFEID = spiffeID.String
+	if err := json.Unmarshal(resources, &account.Resources); err != nil {
+		return nil, err
+	}
+	if lastUsedAt.Valid {
+		account.LastUsedAt = &lastUsedAt.Time
+	}
+	return account, nil
+}
@@ -0,0 +1,72 @@
+// filepath: internal/storage/mysql/session_policies_repository.go
+
+/*************************************************************************/
+/*                                                                       */
+/*   Ce fichier implémente le repository MySQL des politiques de         */
+/*   session des organisations                                           */
+/*                                                                       */
+/*************************************************************************/
+
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"secrets-manager/internal/models"
+	repo "secrets-manager/internal/storage"
+)
+
+// SessionPoliciesRepository gère les politiques de session dans MySQL
+type SessionPoliciesRepository struct {
+	db *sql.DB
+}
+
+var _ repo.SessionPoliciesRepository = (*SessionPoliciesRepository)(nil)
+
+// NewSessionPoliciesRepository crée un nouveau repository de politiques de session
+func NewSessionPoliciesRepository(db *sql.DB) *SessionPoliciesRepository {
+	return &SessionPoliciesRepository{
+		db: db,
+	}
+}
+
+// GetSessionPolicy renvoie la politique de l'organisation
+func (r *SessionPoliciesRepository) GetSessionPolicy(ctx context.Context, orgID string) (*models.SessionPolicy, error) {
+	query := `
+		SELECT organization_id, max_session_hours, idle_timeout_minutes, max_sessions, updated_at
+		FROM session_policies
+		WHERE organization_id = ?
+	`
+
+	policy := &models.SessionPolicy{}
+	err := r.db.QueryRowContext(ctx, query, orgID).Scan(&policy.OrganizationID, &policy.MaxSessionHours,
+		&policy.IdleTimeoutMinutes, &policy.MaxSessions, &policy.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repo.ErrSessionPolicyNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// SaveSessionPolicy crée ou remplace la politique de l'organisation
+func (r *SessionPoliciesRepository) SaveSessionPolicy(ctx context.Context, policy *models.SessionPolicy) error {
+	policy.UpdatedAt = time.Now()
+
+	query := `
+		INSERT INTO session_policies (organization_id, max_session_hours, idle_timeout_minutes, max_sessions,
+			updated_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE max_session_hours = VALUES(max_session_hours),
+			idle_timeout_minutes = VALUES(idle_timeout_minutes), max_sessions = VALUES(max_sessions),
+			updated_at = VALUES(updated_at)
+	`
+
+	_, err := r.db.ExecContext(ctx, query, policy.OrganizationID, policy.MaxSessionHours,
+		policy.IdleTimeoutMinutes, policy.MaxSessions, policy.UpdatedAt)
+	return err
+}
@@ -0,0 +1,123 @@
+// filepath: internal/storage/mysql/settings_history_repository.go
+
+/*************************************************************************/
+/*                                                                       */
+/*   Ce fichier implémente le repository MySQL de l'historique de la     */
+/*   configuration des organisations                                     */
+/*                                                                       */
+/*************************************************************************/
+
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+
+	"secrets-manager/internal/models"
+	repo "secrets-manager/internal/storage"
+)
+
+// SettingsHistoryRepository gère l'historique de configuration dans MySQL
+type SettingsHistoryRepository struct {
+	db *sql.DB
+}
+
+var _ repo.SettingsHistoryRepository = (*SettingsHistoryRepository)(nil)
+
+// NewSettingsHistoryRepository crée un nouveau repository de l'historique de configuration
+func NewSettingsHistoryRepository(db *sql.DB) *SettingsHistoryRepository {
+	return &SettingsHistoryRepository{
+		db: db,
+	}
+}
+
+// RecordSettingsChange enregistre une modification sous la version suivante
+func (r *SettingsHistoryRepository) RecordSettingsChange(ctx context.Context, change *models.SettingsChange) error {
+	if change.ID == "" {
+		change.ID = uuid.New().String()
+	}
+	change.CreatedAt = time.Now()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Le verrou sur la dernière version sérialise les modifications de l'organisation
+	err = tx.QueryRowContext(ctx, `
+		SELECT COALESCE(MAX(version), 0) + 1 FROM settings_changes WHERE organization_id = ? FOR UPDATE
+	`, change.OrganizationID).Scan(&change.Version)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO settings_changes (id, organization_id, version, resource, resource_id, action,
+			changed_by, before_state, after_state, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, change.ID, change.OrganizationID, change.Version, change.Resource, change.ResourceID, change.Action,
+		change.ChangedBy, nullJSON(change.Before), nullJSON(change.After), change.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ListSettingsChanges liste les modifications de l'organisation, de la plus récente à la plus ancienne
+func (r *SettingsHistoryRepository) ListSettingsChanges(
+	ctx context.Context,
+	orgID, resource string,
+	limit, offset int,
+) ([]*models.SettingsChange, error) {
+	query := `
+		SELECT id, organization_id, version, resource, resource_id, action,
+			   changed_by, before_state, after_state, created_at
+		FROM settings_changes
+		WHERE organization_id = ? AND (? = '' OR resource = ?)
+		ORDER BY version DESC
+		LIMIT ? OFFSET ?
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, orgID, resource, resource, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	changes := []*models.SettingsChange{}
+	for rows.Next() {
+		change := &models.SettingsChange{}
+		var before, after []byte
+		err := rows.Scan(
+			&change.ID,
+			&change.OrganizationID,
+			&change.Version,
+			&change.Resource,
+			&change.ResourceID,
+			&change.Action,
+			&change.ChangedBy,
+			&before,
+			&after,
+			&change.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		change.Before, change.After = before, after
+		changes = append(changes, change)
+	}
+	return changes, rows.Err()
+}
+
+// nullJSON renvoie NULL pour un état absent
+func nullJSON(state []byte) any {
+	if len(state) == 0 {
+		return nil
+	}
+	return string(state)
+}
@@ -0,0 +1,316 @@
+// filepath: internal/storage/mysql/teams_repository.go
+
+/*************************************************************************/
+/*                                                                       */
+/*   Ce fichier implémente le repository MySQL des équipes des           */
+/*   organisations, de leurs membres et de leurs octrois                 */
+/*                                                                       */
+/*************************************************************************/
+
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"secrets-manager/internal/models"
+	repo "secrets-manager/internal/storage"
+)
+
+// TeamsRepository gère les équipes dans MySQL
+type TeamsRepository struct {
+	db *sql.DB
+}
+
+var _ repo.TeamsRepository = (*TeamsRepository)(nil)
+
+// NewTeamsRepository crée un nouveau repository d'équipes
+func NewTeamsRepository(db *sql.DB) *TeamsRepository {
+	return &TeamsRepository{
+		db: db,
+	}
+}
+
+// CreateTeam enregistre une équipe
+func (r *TeamsRepository) CreateTeam(ctx context.Context, team *models.Team) error {
+	if team.ID == "" {
+		team.ID = uuid.New().String()
+	}
+	team.CreatedAt = time.Now()
+
+	query := `
+		INSERT INTO teams (id, organization_id, name, description, created_by, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := r.db.ExecContext(ctx, query, team.ID, team.OrganizationID, team.Name, team.Description,
+		team.CreatedBy, team.CreatedAt)
+	if isDuplicateEntry(err) {
+		return repo.ErrTeamExists
+	}
+	return err
+}
+
+// GetTeam récupère une équipe de l'organisation avec ses membres
+func (r *TeamsRepository) GetTeam(ctx context.Context, orgID, id string) (*models.Team, error) {
+	query := `
+		SELECT ` + teamColumns + `
+		FROM teams
+		WHERE id = ? AND organization_id = ?
+	`
+
+	team, err := scanTeam(r.db.QueryRowContext(ctx, query, id, orgID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repo.ErrTeamNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT team_id, user_id, added_by, added_at
+		FROM team_members
+		WHERE team_id = ?
+		ORDER BY added_at
+	`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	team.Members = []*models.TeamMember{}
+	for rows.Next() {
+		member := &models.TeamMember{}
+		if err := rows.Scan(&member.TeamID, &member.UserID, &member.AddedBy, &member.AddedAt); err != nil {
+			return nil, err
+		}
+		team.Members = append(team.Members, member)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	team.Grants, err = r.queryGrants(ctx, `
+		SELECT `+teamGrantColumns+`
+		FROM team_grants
+		WHERE team_id = ?
+		ORDER BY project_id, environment
+	`, id)
+	if err != nil {
+		return nil, err
+	}
+	return team, nil
+}
+
+// ListTeams liste les équipes de l'organisation par nom
+func (r *TeamsRepository) ListTeams(ctx context.Context, orgID string) ([]*models.Team, error) {
+	query := `
+		SELECT ` + teamColumns + `
+		FROM teams
+		WHERE organization_id = ?
+		ORDER BY name
+	`
+
+	return r.queryTeams(ctx, query, orgID)
+}
+
+// DeleteTeam supprime une équipe et ses appartenances
+func (r *TeamsRepository) DeleteTeam(ctx context.Context, orgID, id string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, "DELETE FROM teams WHERE id = ? AND organization_id = ?", id, orgID)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return repo.ErrTeamNotFound
+	}
+
+	for _, query := range []string{
+		"DELETE FROM team_members WHERE team_id = ?",
+		"DELETE FROM team_grants WHERE team_id = ?",
+		"UPDATE projects SET team_id = '' WHERE team_id = ?",
+		"UPDATE secret_metadata SET team_id = '' WHERE team_id = ?",
+	} {
+		if _, err := tx.ExecContext(ctx, query, id); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// AddTeamMember ajoute un membre à une équipe
+func (r *TeamsRepository) AddTeamMember(ctx context.Context, member *models.TeamMember) error {
+	member.AddedAt = time.Now()
+
+	// L'appartenance existante est conservée telle quelle
+	query := `
+		INSERT IGNORE INTO team_members (team_id, user_id, added_by, added_at)
+		SELECT id, ?, ?, ? FROM teams WHERE id = ?
+	`
+
+	result, err := r.db.ExecContext(ctx, query, member.UserID, member.AddedBy, member.AddedAt, member.TeamID)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected > 0 {
+		return nil
+	}
+
+	err = r.db.QueryRowContext(ctx, `
+		SELECT added_by, added_at FROM team_members WHERE team_id = ? AND user_id = ?
+	`, member.TeamID, member.UserID).Scan(&member.AddedBy, &member.AddedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return repo.ErrTeamNotFound
+	}
+	return err
+}
+
+// RemoveTeamMember retire un membre d'une équipe
+func (r *TeamsRepository) RemoveTeamMember(ctx context.Context, teamID, userID string) error {
+	_, err := r.db.ExecContext(ctx, "DELETE FROM team_members WHERE team_id = ? AND user_id = ?", teamID, userID)
+	return err
+}
+
+// ListUserTeams liste les équipes de l'organisation dont l'utilisateur est membre
+func (r *TeamsRepository) ListUserTeams(ctx context.Context, orgID, userID string) ([]*models.Team, error) {
+	query := `
+		SELECT ` + teamColumns + `
+		FROM teams
+		WHERE organization_id = ?
+		  AND id IN (SELECT team_id FROM team_members WHERE user_id = ?)
+		ORDER BY name
+	`
+
+	return r.queryTeams(ctx, query, orgID, userID)
+}
+
+// SetTeamGrant accorde un rôle à une équipe sur un projet ou un environnement
+func (r *TeamsRepository) SetTeamGrant(ctx context.Context, grant *models.TeamGrant) error {
+	grant.GrantedAt = time.Now()
+
+	query := `
+		INSERT INTO team_grants (team_id, project_id, environment, role, granted_by, granted_at)
+		SELECT id, ?, ?, ?, ?, ? FROM teams WHERE id = ?
+		ON DUPLICATE KEY UPDATE role = VALUES(role), granted_by = VALUES(granted_by), granted_at = VALUES(granted_at)
+	`
+
+	result, err := r.db.ExecContext(ctx, query, grant.ProjectID, grant.Environment, grant.Role, grant.GrantedBy,
+		grant.GrantedAt, grant.TeamID)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		// Aucune ligne insérée ni modifiée : l'équipe n'existe pas, ou
+		// l'octroi est inchangé
+		var exists bool
+		err := r.db.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM teams WHERE id = ?)", grant.TeamID).Scan(&exists)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return repo.ErrTeamNotFound
+		}
+	}
+	return nil
+}
+
+// RemoveTeamGrant retire l'octroi d'une équipe sur un projet ou un environnement
+func (r *TeamsRepository) RemoveTeamGrant(ctx context.Context, teamID, projectID, env string) error {
+	_, err := r.db.ExecContext(ctx, `
+		DELETE FROM team_grants WHERE team_id = ? AND project_id = ? AND environment = ?
+	`, teamID, projectID, env)
+	return err
+}
+
+// ListUserGrants liste les octrois des équipes de l'organisation dont l'utilisateur est membre
+func (r *TeamsRepository) ListUserGrants(ctx context.Context, orgID, userID string) ([]*models.TeamGrant, error) {
+	query := `
+		SELECT ` + teamGrantColumns + `
+		FROM team_grants
+		WHERE team_id IN (
+			SELECT t.id FROM teams t
+			JOIN team_members m ON m.team_id = t.id
+			WHERE t.organization_id = ? AND m.user_id = ?
+		)
+		ORDER BY project_id, environment, team_id
+	`
+
+	return r.queryGrants(ctx, query, orgID, userID)
+}
+
+// queryGrants exécute une requête sélectionnant teamGrantColumns
+func (r *TeamsRepository) queryGrants(ctx context.Context, query string, args ...interface{}) ([]*models.TeamGrant, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	grants := []*models.TeamGrant{}
+	for rows.Next() {
+		grant := &models.TeamGrant{}
+		if err := rows.Scan(&grant.TeamID, &grant.ProjectID, &grant.Environment, &grant.Role, &grant.GrantedBy,
+			&grant.GrantedAt); err != nil {
+			return nil, err
+		}
+		grants = append(grants, grant)
+	}
+	return grants, rows.Err()
+}
+
+// Colonnes lues par queryGrants, dans le même ordre
+const teamGrantColumns = `team_id, project_id, environment, role, granted_by, granted_at`
+
+// queryTeams exécute une requête sélectionnant teamColumns
+func (r *TeamsRepository) queryTeams(ctx context.Context, query string, args ...interface{}) ([]*models.Team, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	teams := []*models.Team{}
+	for rows.Next() {
+		team, err := scanTeam(rows)
+		if err != nil {
+			return nil, err
+		}
+		teams = append(teams, team)
+	}
+	return teams, rows.Err()
+}
+
+// Colonnes lues par scanTeam, dans le même ordre
+const teamColumns = `id, organization_id, name, description, created_by, created_at`
+
+// scanTeam lit une ligne sélectionnée avec teamColumns
+func scanTeam(row rowScanner) (*models.Team, error) {
+	team := &models.Team{}
+	err := row.Scan(&team.ID, &team.OrganizationID, &team.Name, &team.Description, &team.CreatedBy, &team.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return team, nil
+}
@@ -0,0 +1,145 @@
+// filepath: internal/storage/mysql/trusted_devices_repository.go
+
+/*************************************************************************/
+/*                                                                       */
+/*   Ce fichier implémente le repository MySQL des appareils de          */
+/*   confiance des utilisateurs, dispensés de code TOTP                  */
+/*                                                                       */
+/*************************************************************************/
+
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"secrets-manager/internal/models"
+	repo "secrets-manager/internal/storage"
+)
+
+// TrustedDevicesRepository gère les appareils de confiance dans MySQL
+type TrustedDevicesRepository struct {
+	db *sql.DB
+}
+
+var _ repo.TrustedDevicesRepository = (*TrustedDevicesRepository)(nil)
+
+// NewTrustedDevicesRepository crée un nouveau repository d'appareils de confiance
+func NewTrustedDevicesRepository(db *sql.DB) *TrustedDevicesRepository {
+	return &TrustedDevicesRepository{
+		db: db,
+	}
+}
+
+// CreateTrustedDevice enregistre un appareil
+func (r *TrustedDevicesRepository) CreateTrustedDevice(ctx context.Context, device *models.TrustedDevice) error {
+	if device.ID == "" {
+		device.ID = uuid.New().String()
+	}
+	device.CreatedAt = time.Now()
+
+	query := `
+		INSERT INTO trusted_devices (id, user_id, name, token_hash, fingerprint, user_agent, ip_address,
+			expires_at, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := r.db.ExecContext(ctx, query, device.ID, device.UserID, device.Name, device.TokenHash,
+		device.Fingerprint, device.UserAgent, device.IPAddress, device.ExpiresAt, device.CreatedAt)
+	return err
+}
+
+// GetTrustedDeviceByHash récupère l'appareil correspondant à l'empreinte du cookie
+func (r *TrustedDevicesRepository) GetTrustedDeviceByHash(ctx context.Context, tokenHash string) (*models.TrustedDevice, error) {
+	query := `
+		SELECT ` + trustedDeviceColumns + `
+		FROM trusted_devices
+		WHERE token_hash = ?
+	`
+
+	device, err := scanTrustedDevice(r.db.QueryRowContext(ctx, query, tokenHash))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repo.ErrDeviceNotFound
+	}
+	return device, err
+}
+
+// ListTrustedDevices liste les appareils de l'utilisateur
+func (r *TrustedDevicesRepository) ListTrustedDevices(ctx context.Context, userID string) ([]*models.TrustedDevice, error) {
+	query := `
+		SELECT ` + trustedDeviceColumns + `
+		FROM trusted_devices
+		WHERE user_id = ?
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	devices := []*models.TrustedDevice{}
+	for rows.Next() {
+		device, err := scanTrustedDevice(rows)
+		if err != nil {
+			return nil, err
+		}
+		devices = append(devices, device)
+	}
+	return devices, rows.Err()
+}
+
+// DeleteTrustedDevice révoque la confiance accordée à un appareil de l'utilisateur
+func (r *TrustedDevicesRepository) DeleteTrustedDevice(ctx context.Context, userID, id string) error {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM trusted_devices WHERE id = ? AND user_id = ?", id, userID)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return repo.ErrDeviceNotFound
+	}
+	return nil
+}
+
+// DeleteUserTrustedDevices révoque tous les appareils de l'utilisateur
+func (r *TrustedDevicesRepository) DeleteUserTrustedDevices(ctx context.Context, userID string) (int64, error) {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM trusted_devices WHERE user_id = ?", userID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// TouchTrustedDevice enregistre la date de dernière utilisation
+func (r *TrustedDevicesRepository) TouchTrustedDevice(ctx context.Context, id string, at time.Time) error {
+	_, err := r.db.ExecContext(ctx, "UPDATE trusted_devices SET last_used_at = ? WHERE id = ?", at, id)
+	return err
+}
+
+// Colonnes lues par scanTrustedDevice, dans le même ordre
+const trustedDeviceColumns = `id, user_id, name, token_hash, fingerprint, user_agent, ip_address, expires_at,
+	last_used_at, created_at`
+
+func scanTrustedDevice(row rowScanner) (*models.TrustedDevice, error) {
+	device := &models.TrustedDevice{}
+	var lastUsedAt sql.NullTime
+
+	err := row.Scan(&device.ID, &device.UserID, &device.Name, &device.TokenHash, &device.Fingerprint,
+		&device.UserAgent, &device.IPAddress, &device.ExpiresAt, &lastUsedAt, &device.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if lastUsedAt.Valid {
+		device.LastUsedAt = &lastUsedAt.Time
+	}
+	return device, nil
+}
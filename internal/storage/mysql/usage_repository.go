@@ -0,0 +1,220 @@
+// filepath: internal/storage/mysql/usage_repository.go
+
+/*************************************************************************/
+/*                                                                       */
+/*   Ce fichier implémente le repository MySQL de l'usage de l'API       */
+/*   Il agrège les appels par organisation, principal, route et jour     */
+/*                                                                       */
+/*************************************************************************/
+
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math/rand/v2"
+	"sort"
+	"strings"
+	"time"
+
+	"secrets-manager/internal/models"
+	repo "secrets-manager/internal/storage"
+)
+
+// UsageRepository gère les statistiques d'appels API dans MySQL
+type UsageRepository struct {
+	db *sql.DB
+}
+
+var _ repo.UsageRepository = (*UsageRepository)(nil)
+
+// NewUsageRepository crée un nouveau repository d'usage
+func NewUsageRepository(db *sql.DB) *UsageRepository {
+	return &UsageRepository{
+		db: db,
+	}
+}
+
+// RecordAPICall comptabilise un appel API dans le détail journalier
+// et dans le compteur global de l'organisation
+func (r *UsageRepository) RecordAPICall(ctx context.Context, call *models.APICall) error {
+	return r.RecordAPICallCounts(ctx, []*models.APICallCount{call.Count()})
+}
+
+// RecordAPICallCounts comptabilise un lot d'appels dans une seule transaction.
+// Le compteur global est incrémenté sur une ligne tirée au hasard parmi
+// repo.APICallShards pour que les écritures concurrentes ne se bloquent pas.
+func (r *UsageRepository) RecordAPICallCounts(ctx context.Context, counts []*models.APICallCount) error {
+	if len(counts) == 0 {
+		return nil
+	}
+
+	// Verrouiller les lignes toujours dans le même ordre évite les interblocages
+	sorted := make([]*models.APICallCount, len(counts))
+	copy(sorted, counts)
+	sort.Slice(sorted, func(i, j int) bool { return usageKey(sorted[i]) < usageKey(sorted[j]) })
+
+	totals := map[string]int64{}
+	var orgIDs []string
+	for _, count := range sorted {
+		if _, ok := totals[count.OrganizationID]; !ok {
+			orgIDs = append(orgIDs, count.OrganizationID)
+		}
+		totals[count.OrganizationID] += count.Calls
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, count := range sorted {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO api_usage (organization_id, day, principal_type, principal_id, route, calls)
+			VALUES (?, ?, ?, ?, ?, ?)
+			ON DUPLICATE KEY UPDATE calls = calls + VALUES(calls)
+		`, count.OrganizationID, count.Day, count.PrincipalType, count.PrincipalID, count.Route, count.Calls)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, orgID := range orgIDs {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO api_call_counters (organization_id, shard, calls)
+			VALUES (?, ?, ?)
+			ON DUPLICATE KEY UPDATE calls = calls + VALUES(calls)
+		`, orgID, rand.IntN(repo.APICallShards), totals[orgID])
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetAPICallsTotal renvoie le nombre total d'appels d'une organisation
+// (somme des lignes du compteur, via la vue organization_api_calls)
+func (r *UsageRepository) GetAPICallsTotal(ctx context.Context, orgID string) (int64, error) {
+	var total int64
+	err := r.db.QueryRowContext(ctx,
+		"SELECT api_calls FROM organization_api_calls WHERE organization_id = ?", orgID).Scan(&total)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	return total, nil
+}
+
+// usageKey identifie la ligne de api_usage d'un décompte
+func usageKey(count *models.APICallCount) string {
+	return strings.Join([]string{count.OrganizationID, count.Day, count.PrincipalType, count.PrincipalID, count.Route}, "\x00")
+}
+
+// GetUsageBreakdown agrège les appels d'une organisation entre deux jours inclus
+func (r *UsageRepository) GetUsageBreakdown(
+	ctx context.Context,
+	orgID string,
+	from, to time.Time,
+) (*models.UsageBreakdown, error) {
+	breakdown := &models.UsageBreakdown{
+		OrganizationID: orgID,
+		From:           from,
+		To:             to,
+	}
+
+	fromDay := from.UTC().Format("2006-01-02")
+	toDay := to.UTC().Format("2006-01-02")
+
+	var err error
+	breakdown.ByPrincipal, err = r.countBy(ctx, "CONCAT(principal_type, ':', principal_id)", "calls DESC", orgID, fromDay, toDay)
+	if err != nil {
+		return nil, err
+	}
+
+	breakdown.ByRoute, err = r.countBy(ctx, "route", "calls DESC", orgID, fromDay, toDay)
+	if err != nil {
+		return nil, err
+	}
+
+	breakdown.ByDay, err = r.countBy(ctx, "DATE_FORMAT(day, '%Y-%m-%d')", "grouping_key ASC", orgID, fromDay, toDay)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, day := range breakdown.ByDay {
+		breakdown.Total += day.Calls
+	}
+
+	return breakdown, nil
+}
+
+// countBy agrège les appels selon une expression de regroupement
+// (expression et ordre sont des constantes internes, jamais des entrées utilisateur)
+func (r *UsageRepository) countBy(
+	ctx context.Context,
+	keyExpr, orderBy, orgID, fromDay, toDay string,
+) ([]*models.UsageCount, error) {
+	query := `
+		SELECT ` + keyExpr + ` AS grouping_key, SUM(calls) AS calls
+		FROM api_usage
+		WHERE organization_id = ? AND day BETWEEN ? AND ?
+		GROUP BY grouping_key
+		ORDER BY ` + orderBy
+
+	rows, err := r.db.QueryContext(ctx, query, orgID, fromDay, toDay)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := []*models.UsageCount{}
+	for rows.Next() {
+		count := &models.UsageCount{}
+		if err := rows.Scan(&count.Key, &count.Calls); err != nil {
+			return nil, err
+		}
+		counts = append(counts, count)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+// GetLastActivityDays renvoie, pour chaque principal du type donné, le
+// dernier jour où il a appelé l'API de l'organisation
+func (r *UsageRepository) GetLastActivityDays(ctx context.Context, orgID, principalType string) (map[string]string, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT principal_id, DATE_FORMAT(MAX(day), '%Y-%m-%d')
+		FROM api_usage
+		WHERE organization_id = ? AND principal_type = ?
+		GROUP BY principal_id
+	`, orgID, principalType)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	days := make(map[string]string)
+	for rows.Next() {
+		var principalID, day string
+		if err := rows.Scan(&principalID, &day); err != nil {
+			return nil, err
+		}
+		days[principalID] = day
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return days, nil
+}
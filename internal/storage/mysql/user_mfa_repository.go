@@ -0,0 +1,124 @@
+// filepath: internal/storage/mysql/user_mfa_repository.go
+
+/*************************************************************************/
+/*                                                                       */
+/*   Ce fichier implémente le repository MySQL de l'authentification     */
+/*   multifacteur (TOTP) des utilisateurs                                */
+/*                                                                       */
+/*************************************************************************/
+
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"secrets-manager/internal/models"
+	repo "secrets-manager/internal/storage"
+)
+
+// UserMFARepository gère l'authentification multifacteur dans MySQL
+type UserMFARepository struct {
+	db *sql.DB
+}
+
+var _ repo.UserMFARepository = (*UserMFARepository)(nil)
+
+// NewUserMFARepository crée un nouveau repository d'authentification multifacteur
+func NewUserMFARepository(db *sql.DB) *UserMFARepository {
+	return &UserMFARepository{
+		db: db,
+	}
+}
+
+// SaveUserMFA crée ou remplace l'authentification multifacteur d'un utilisateur
+func (r *UserMFARepository) SaveUserMFA(ctx context.Context, mfa *models.UserMFA) error {
+	if mfa.CreatedAt.IsZero() {
+		mfa.CreatedAt = time.Now()
+	}
+
+	query := `
+		INSERT INTO user_mfa (user_id, secret, enabled_at, last_step, failed_attempts, locked_until, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE secret = VALUES(secret), enabled_at = VALUES(enabled_at),
+			last_step = VALUES(last_step), failed_attempts = VALUES(failed_attempts),
+			locked_until = VALUES(locked_until), created_at = VALUES(created_at)
+	`
+
+	_, err := r.db.ExecContext(ctx, query, mfa.UserID, mfa.Secret, mfa.EnabledAt, mfa.LastStep,
+		mfa.FailedAttempts, mfa.LockedUntil, mfa.CreatedAt)
+	return err
+}
+
+// GetUserMFA renvoie l'authentification multifacteur d'un utilisateur
+func (r *UserMFARepository) GetUserMFA(ctx context.Context, userID string) (*models.UserMFA, error) {
+	query := `
+		SELECT user_id, secret, enabled_at, last_step, failed_attempts, locked_until, created_at
+		FROM user_mfa
+		WHERE user_id = ?
+	`
+
+	mfa := &models.UserMFA{}
+	var enabledAt, lockedUntil sql.NullTime
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(&mfa.UserID, &mfa.Secret, &enabledAt, &mfa.LastStep,
+		&mfa.FailedAttempts, &lockedUntil, &mfa.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repo.ErrMFANotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if enabledAt.Valid {
+		mfa.EnabledAt = &enabledAt.Time
+	}
+	if lockedUntil.Valid {
+		mfa.LockedUntil = &lockedUntil.Time
+	}
+	return mfa, nil
+}
+
+// AcceptMFAStep enregistre un code accepté s'il n'a pas déjà servi. La
+// condition sur last_step empêche deux requêtes concurrentes d'utiliser le
+// même code.
+func (r *UserMFARepository) AcceptMFAStep(ctx context.Context, userID string, step int64) (bool, error) {
+	query := `
+		UPDATE user_mfa
+		SET last_step = ?, failed_attempts = 0, locked_until = NULL
+		WHERE user_id = ? AND last_step < ?
+	`
+
+	result, err := r.db.ExecContext(ctx, query, step, userID, step)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// SetMFAFailures enregistre le nombre de codes refusés et l'éventuel verrouillage
+func (r *UserMFARepository) SetMFAFailures(ctx context.Context, userID string, failedAttempts int, lockedUntil *time.Time) error {
+	_, err := r.db.ExecContext(ctx, "UPDATE user_mfa SET failed_attempts = ?, locked_until = ? WHERE user_id = ?",
+		failedAttempts, lockedUntil, userID)
+	return err
+}
+
+// DeleteUserMFA supprime l'authentification multifacteur d'un utilisateur
+func (r *UserMFARepository) DeleteUserMFA(ctx context.Context, userID string) error {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM user_mfa WHERE user_id = ?", userID)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return repo.ErrMFANotFound
+	}
+	return nil
+}
@@ -13,18 +13,22 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 
 	"secrets-manager/internal/models"
+	repo "secrets-manager/internal/storage"
 )
 
 // ErrUserNotFound indique qu'un utilisateur n'a pas été trouvé
-var ErrUserNotFound = errors.New("utilisateur non trouvé")
+var ErrUserNotFound = repo.ErrUserNotFound
 
 // ErrEmailAlreadyExists indique qu'un email est déjà utilisé
-var ErrEmailAlreadyExists = errors.New("cet email est déjà utilisé")
+var ErrEmailAlreadyExists = repo.ErrEmailAlreadyExists
+
+var _ repo.UsersRepository = (*UsersRepository)(nil)
 
 // UsersRepository gère l'accès aux données utilisateur dans MySQL
 type UsersRepository struct {
@@ -92,6 +96,10 @@ func (r *UsersRepository) CreateUser(ctx context.Context, user *models.User) err
 		user.CreatedAt,
 		user.UpdatedAt,
 	)
+	// L'index unique sur l'email arbitre les inscriptions simultanées
+	if isDuplicateEntry(err) {
+		return ErrEmailAlreadyExists
+	}
 
 	return err
 }
@@ -100,7 +108,7 @@ func (r *UsersRepository) CreateUser(ctx context.Context, user *models.User) err
 func (r *UsersRepository) GetUserByID(ctx context.Context, id string) (*models.User, error) {
 	query := `
 		SELECT id, email, hashed_password, first_name, last_name, 
-			   role, created_at, updated_at
+			   role, created_at, updated_at, password_reset_required, tokens_not_before
 		FROM users
 		WHERE id = ?
 	`
@@ -115,6 +123,8 @@ func (r *UsersRepository) GetUserByID(ctx context.Context, id string) (*models.U
 		&user.Role,
 		&user.CreatedAt,
 		&user.UpdatedAt,
+		&user.PasswordResetRequired,
+		&user.TokensNotBefore,
 	)
 
 	if err != nil {
@@ -131,7 +141,7 @@ func (r *UsersRepository) GetUserByID(ctx context.Context, id string) (*models.U
 func (r *UsersRepository) GetUserByEmail(ctx context.Context, email string) (*models.User, error) {
 	query := `
 		SELECT id, email, hashed_password, first_name, last_name, 
-			   role, created_at, updated_at
+			   role, created_at, updated_at, password_reset_required, tokens_not_before
 		FROM users
 		WHERE email = ?
 	`
@@ -146,6 +156,8 @@ func (r *UsersRepository) GetUserByEmail(ctx context.Context, email string) (*mo
 		&user.Role,
 		&user.CreatedAt,
 		&user.UpdatedAt,
+		&user.PasswordResetRequired,
+		&user.TokensNotBefore,
 	)
 
 	if err != nil {
@@ -158,6 +170,52 @@ func (r *UsersRepository) GetUserByEmail(ctx context.Context, email string) (*mo
 	return user, nil
 }
 
+// GetUsersByIDs récupère plusieurs utilisateurs en une requête
+func (r *UsersRepository) GetUsersByIDs(ctx context.Context, ids []string) ([]*models.User, error) {
+	if len(ids) == 0 {
+		return []*models.User{}, nil
+	}
+
+	query := `
+		SELECT id, email, hashed_password, first_name, last_name,
+			   role, created_at, updated_at, password_reset_required, tokens_not_before
+		FROM users
+		WHERE id IN (?` + strings.Repeat(", ?", len(ids)-1) + `)
+	`
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	users := []*models.User{}
+	for rows.Next() {
+		user := &models.User{}
+		if err := rows.Scan(
+			&user.ID,
+			&user.Email,
+			&user.HashedPassword,
+			&user.FirstName,
+			&user.LastName,
+			&user.Role,
+			&user.CreatedAt,
+			&user.UpdatedAt,
+			&user.PasswordResetRequired,
+			&user.TokensNotBefore,
+		); err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+
+	return users, rows.Err()
+}
+
 // UpdateUser met à jour les informations d'un utilisateur
 func (r *UsersRepository) UpdateUser(ctx context.Context, user *models.User) error {
 	query := `
@@ -196,7 +254,7 @@ func (r *UsersRepository) UpdateUser(ctx context.Context, user *models.User) err
 func (r *UsersRepository) UpdatePassword(ctx context.Context, userID, hashedPassword string) error {
 	query := `
 		UPDATE users
-		SET hashed_password = ?, updated_at = NOW()
+		SET hashed_password = ?, password_reset_required = FALSE, updated_at = NOW()
 		WHERE id = ?
 	`
 
@@ -217,6 +275,31 @@ func (r *UsersRepository) UpdatePassword(ctx context.Context, userID, hashedPass
 	return nil
 }
 
+// ForcePasswordReset exige un nouveau mot de passe et révoque les tokens antérieurs
+func (r *UsersRepository) ForcePasswordReset(ctx context.Context, userID string, tokensNotBefore time.Time) error {
+	query := `
+		UPDATE users
+		SET password_reset_required = TRUE, tokens_not_before = ?, updated_at = NOW()
+		WHERE id = ?
+	`
+
+	result, err := r.db.ExecContext(ctx, query, tokensNotBefore, userID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrUserNotFound
+	}
+
+	return nil
+}
+
 // DeleteUser supprime un utilisateur
 func (r *UsersRepository) DeleteUser(ctx context.Context, id string) error {
 	// Vérifier les contraintes de clé étrangère avant la suppression
@@ -246,7 +329,7 @@ func (r *UsersRepository) DeleteUser(ctx context.Context, id string) error {
 func (r *UsersRepository) ListUsers(ctx context.Context, limit, offset int) ([]*models.User, error) {
 	query := `
 		SELECT id, email, hashed_password, first_name, last_name, 
-			   role, created_at, updated_at
+			   role, created_at, updated_at, password_reset_required, tokens_not_before
 		FROM users
 		ORDER BY created_at DESC
 		LIMIT ? OFFSET ?
@@ -270,6 +353,8 @@ func (r *UsersRepository) ListUsers(ctx context.Context, limit, offset int) ([]*
 			&user.Role,
 			&user.CreatedAt,
 			&user.UpdatedAt,
+			&user.PasswordResetRequired,
+			&user.TokensNotBefore,
 		)
 		if err != nil {
 			return nil, err
@@ -334,12 +419,14 @@ func (r *UsersRepository) GetUserOrganizations(ctx context.Context, userID strin
 	return orgs, nil
 }
 
-// GetUserRole récupère le rôle d'un utilisateur dans une organisation
+// GetUserRole récupère le rôle d'un utilisateur dans une organisation.
+// Une organisation dans la corbeille n'a plus de membres.
 func (r *UsersRepository) GetUserRole(ctx context.Context, userID, orgID string) (string, error) {
 	query := `
-		SELECT role
-		FROM user_organizations
-		WHERE user_id = ? AND organization_id = ?
+		SELECT uo.role
+		FROM user_organizations uo
+		JOIN organizations o ON o.id = uo.organization_id
+		WHERE uo.user_id = ? AND uo.organization_id = ? AND o.deleted_at IS NULL
 	`
 
 	var role string
@@ -354,6 +441,32 @@ func (r *UsersRepository) GetUserRole(ctx context.Context, userID, orgID string)
 	return role, nil
 }
 
+// GetUserRoles récupère les rôles d'un utilisateur dans ses organisations
+func (r *UsersRepository) GetUserRoles(ctx context.Context, userID string) (map[string]string, error) {
+	query := `
+		SELECT uo.organization_id, uo.role
+		FROM user_organizations uo
+		JOIN organizations o ON o.id = uo.organization_id
+		WHERE uo.user_id = ? AND o.deleted_at IS NULL
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	roles := make(map[string]string)
+	for rows.Next() {
+		var orgID, role string
+		if err := rows.Scan(&orgID, &role); err != nil {
+			return nil, err
+		}
+		roles[orgID] = role
+	}
+	return roles, rows.Err()
+}
+
 // AssignUserToOrganization assigne un utilisateur à une organisation avec un rôle
 func (r *UsersRepository) AssignUserToOrganization(ctx context.Context, userID, orgID, role string) error {
 	// Vérifier si l'assignation existe déjà
@@ -406,3 +519,42 @@ func (r *UsersRepository) RemoveUserFromOrganization(ctx context.Context, userID
 
 	return nil
 }
+
+// SearchOrganizationMembers recherche les membres d'une organisation par
+// début de nom, de prénom ou d'email. La recherche par préfixe reste servie
+// par les index de users.
+func (r *UsersRepository) SearchOrganizationMembers(
+	ctx context.Context,
+	orgID string,
+	search models.MemberSearch,
+) ([]*models.OrganizationMember, error) {
+	query := `
+		SELECT ` + organizationMemberColumns + `
+		FROM user_organizations uo
+		JOIN users u ON u.id = uo.user_id
+		WHERE uo.organization_id = ?
+	`
+	args := []interface{}{orgID}
+
+	if search.Query != "" {
+		prefix := likePrefix(search.Query)
+		query += " AND (u.email LIKE ? OR u.first_name LIKE ? OR u.last_name LIKE ?)"
+		args = append(args, prefix, prefix, prefix)
+	}
+	if len(search.Roles) > 0 {
+		query += " AND uo.role IN (?" + strings.Repeat(", ?", len(search.Roles)-1) + ")"
+		for _, role := range search.Roles {
+			args = append(args, role)
+		}
+	}
+
+	query += " ORDER BY u.last_name, u.first_name, u.id LIMIT ? OFFSET ?"
+	args = append(args, search.Limit, search.Offset)
+
+	return queryOrganizationMembers(ctx, r.db, query, args...)
+}
+
+// likePrefix renvoie le motif LIKE correspondant aux valeurs commençant par s
+func likePrefix(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s) + "%"
+}
@@ -0,0 +1,256 @@
+// filepath: internal/storage/mysql/webhooks_repository.go
+
+/*************************************************************************/
+/*                                                                       */
+/*   Ce fichier implémente le repository MySQL des webhooks              */
+/*   Il conserve aussi l'historique des livraisons aux consommateurs     */
+/*                                                                       */
+/*************************************************************************/
+
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"secrets-manager/internal/models"
+	repo "secrets-manager/internal/storage"
+)
+
+// WebhooksRepository gère les webhooks et leurs livraisons dans MySQL
+type WebhooksRepository struct {
+	db *sql.DB
+}
+
+var _ repo.WebhooksRepository = (*WebhooksRepository)(nil)
+
+// NewWebhooksRepository crée un nouveau repository de webhooks
+func NewWebhooksRepository(db *sql.DB) *WebhooksRepository {
+	return &WebhooksRepository{
+		db: db,
+	}
+}
+
+// CreateWebhook enregistre un webhook
+func (r *WebhooksRepository) CreateWebhook(ctx context.Context, webhook *models.Webhook) error {
+	if webhook.ID == "" {
+		webhook.ID = uuid.New().String()
+	}
+	webhook.CreatedAt = time.Now()
+
+	events, err := json.Marshal(webhook.Events)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO webhooks (id, organization_id, url, secret, events, created_by, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err = r.db.ExecContext(ctx, query, webhook.ID, webhook.OrganizationID, webhook.URL, webhook.Secret,
+		string(events), webhook.CreatedBy, webhook.CreatedAt)
+	return err
+}
+
+// GetWebhook récupère un webhook de l'organisation
+func (r *WebhooksRepository) GetWebhook(ctx context.Context, orgID, id string) (*models.Webhook, error) {
+	query := `
+		SELECT ` + webhookColumns + `
+		FROM webhooks
+		WHERE id = ? AND organization_id = ?
+	`
+
+	webhook, err := scanWebhook(r.db.QueryRowContext(ctx, query, id, orgID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repo.ErrWebhookNotFound
+	}
+	return webhook, err
+}
+
+// ListWebhooks liste les webhooks de l'organisation par date de création
+func (r *WebhooksRepository) ListWebhooks(ctx context.Context, orgID string) ([]*models.Webhook, error) {
+	query := `
+		SELECT ` + webhookColumns + `
+		FROM webhooks
+		WHERE organization_id = ?
+		ORDER BY created_at
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, orgID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	webhooks := []*models.Webhook{}
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, webhook)
+	}
+	return webhooks, rows.Err()
+}
+
+// DeleteWebhook supprime un webhook et l'historique de ses livraisons
+func (r *WebhooksRepository) DeleteWebhook(ctx context.Context, orgID, id string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, "DELETE FROM webhooks WHERE id = ? AND organization_id = ?", id, orgID)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return repo.ErrWebhookNotFound
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM webhook_deliveries WHERE webhook_id = ?", id); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// RecordWebhookDelivery enregistre une livraison
+func (r *WebhooksRepository) RecordWebhookDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	if delivery.ID == "" {
+		delivery.ID = uuid.New().String()
+	}
+
+	query := `
+		INSERT INTO webhook_deliveries (
+			id, webhook_id, organization_id, event_type, payload, status_code,
+			response_body, error, duration_ms, redelivery_of, delivered_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, NULLIF(?, ''), ?)
+	`
+
+	_, err := r.db.ExecContext(
+		ctx,
+		query,
+		delivery.ID,
+		delivery.WebhookID,
+		delivery.OrganizationID,
+		delivery.EventType,
+		delivery.Payload,
+		delivery.StatusCode,
+		delivery.ResponseBody,
+		delivery.Error,
+		delivery.DurationMS,
+		delivery.RedeliveryOf,
+		delivery.DeliveredAt,
+	)
+	return err
+}
+
+// GetWebhookDelivery récupère une livraison d'un webhook de l'organisation
+func (r *WebhooksRepository) GetWebhookDelivery(ctx context.Context, orgID, id string) (*models.WebhookDelivery, error) {
+	query := `
+		SELECT ` + webhookDeliveryColumns + `
+		FROM webhook_deliveries
+		WHERE id = ? AND organization_id = ?
+	`
+
+	delivery, err := scanWebhookDelivery(r.db.QueryRowContext(ctx, query, id, orgID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repo.ErrDeliveryNotFound
+	}
+	return delivery, err
+}
+
+// ListWebhookDeliveries liste les livraisons d'un webhook, de la plus récente à la plus ancienne
+func (r *WebhooksRepository) ListWebhookDeliveries(
+	ctx context.Context,
+	orgID, webhookID string,
+	limit, offset int,
+) ([]*models.WebhookDelivery, error) {
+	query := `
+		SELECT ` + webhookDeliveryColumns + `
+		FROM webhook_deliveries
+		WHERE webhook_id = ? AND organization_id = ?
+		ORDER BY delivered_at DESC
+		LIMIT ? OFFSET ?
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, webhookID, orgID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := []*models.WebhookDelivery{}
+	for rows.Next() {
+		delivery, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries, rows.Err()
+}
+
+// PurgeWebhookDeliveries supprime les livraisons antérieures à before
+func (r *WebhooksRepository) PurgeWebhookDeliveries(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM webhook_deliveries WHERE delivered_at < ?", before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// Colonnes lues par scanWebhook, dans le même ordre
+const webhookColumns = `id, organization_id, url, secret, events, created_by, created_at`
+
+func scanWebhook(row rowScanner) (*models.Webhook, error) {
+	webhook := &models.Webhook{}
+	var events []byte
+
+	err := row.Scan(&webhook.ID, &webhook.OrganizationID, &webhook.URL, &webhook.Secret, &events,
+		&webhook.CreatedBy, &webhook.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(events, &webhook.Events); err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+// Colonnes lues par scanWebhookDelivery, dans le même ordre
+const webhookDeliveryColumns = `id, webhook_id, organization_id, event_type, payload, status_code,
+	response_body, error, duration_ms, COALESCE(redelivery_of, ''), delivered_at`
+
+func scanWebhookDelivery(row rowScanner) (*models.WebhookDelivery, error) {
+	delivery := &models.WebhookDelivery{}
+
+	err := row.Scan(
+		&delivery.ID,
+		&delivery.WebhookID,
+		&delivery.OrganizationID,
+		&delivery.EventType,
+		&delivery.Payload,
+		&delivery.StatusCode,
+		&delivery.ResponseBody,
+		&delivery.Error,
+		&delivery.DurationMS,
+		&delivery.RedeliveryOf,
+		&delivery.DeliveredAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
@@ -4,8 +4,151 @@ package storage
 
 import (
 	"context"
+	"time"
+
+	"secrets-manager/internal/models"
 )
 
+// UsersRepository gère la persistance des utilisateurs
+type UsersRepository interface {
+	CreateUser(ctx context.Context, user *models.User) error
+	GetUserByID(ctx context.Context, id string) (*models.User, error)
+	GetUserByEmail(ctx context.Context, email string) (*models.User, error)
+	// GetUsersByIDs récupère plusieurs utilisateurs en une requête ; les ID
+	// inconnus sont ignorés
+	GetUsersByIDs(ctx context.Context, ids []string) ([]*models.User, error)
+	UpdateUser(ctx context.Context, user *models.User) error
+	// UpdatePassword remplace le mot de passe et lève l'obligation d'en changer
+	UpdatePassword(ctx context.Context, userID, hashedPassword string) error
+	// ForcePasswordReset exige un nouveau mot de passe à la prochaine
+	// connexion et révoque les tokens émis avant tokensNotBefore
+	ForcePasswordReset(ctx context.Context, userID string, tokensNotBefore time.Time) error
+	DeleteUser(ctx context.Context, id string) error
+	ListUsers(ctx context.Context, limit, offset int) ([]*models.User, error)
+	CountUsers(ctx context.Context) (int, error)
+	GetUserOrganizations(ctx context.Context, userID string) ([]*models.Organization, error)
+	GetUserRole(ctx context.Context, userID, orgID string) (string, error)
+	// GetUserRoles renvoie le rôle de l'utilisateur dans chacune de ses
+	// organisations (hors corbeille), par ID d'organisation
+	GetUserRoles(ctx context.Context, userID string) (map[string]string, error)
+	// SearchOrganizationMembers recherche les membres d'une organisation par
+	// début de nom ou d'email, triés par nom
+	SearchOrganizationMembers(ctx context.Context, orgID string, search models.MemberSearch) ([]*models.OrganizationMember, error)
+	AssignUserToOrganization(ctx context.Context, userID, orgID, role string) error
+	RemoveUserFromOrganization(ctx context.Context, userID, orgID string) error
+}
+
+// OrganizationsRepository gère la persistance des organisations et de leurs membres
+type OrganizationsRepository interface {
+	CreateOrganization(ctx context.Context, org *models.Organization) error
+	GetOrganizationByID(ctx context.Context, id string) (*models.Organization, error)
+	ListUserOrganizations(ctx context.Context, userID string) ([]*models.Organization, error)
+	UpdateOrganization(ctx context.Context, org *models.Organization) error
+	DeleteOrganization(ctx context.Context, id string) error
+	ListOrganizationUsers(ctx context.Context, orgID string) ([]*models.UserOrganization, error)
+	// ListOrganizationMembers liste les membres avec leur profil et leur rôle
+	// en une seule requête
+	ListOrganizationMembers(ctx context.Context, orgID string) ([]*models.OrganizationMember, error)
+	AddUserToOrganization(ctx context.Context, userID, orgID, role string) error
+	RemoveUserFromOrganization(ctx context.Context, userID, orgID string) error
+	ChangeOrganizationOwner(ctx context.Context, orgID, newOwnerID string) error
+	UpdateOrganizationPlan(ctx context.Context, orgID, planID string) error
+	GetOrganizationPlan(ctx context.Context, orgID string) (string, error)
+	// GetOrganizationRegion renvoie la région de résidence des secrets de
+	// l'organisation (models.DefaultRegion si aucune n'a été choisie)
+	GetOrganizationRegion(ctx context.Context, orgID string) (string, error)
+	UpdateOrganizationRegion(ctx context.Context, orgID, region string) error
+	CountOrganizationSecrets(ctx context.Context, orgID string) (int, error)
+	// DeleteOrganizationStage supprime les données d'une étape de suppression
+	// (models.DeletionStage*). Chaque étape est idempotente.
+	DeleteOrganizationStage(ctx context.Context, orgID, stage string) error
+	// SuspendOrganization place l'organisation dans la corbeille : ses membres
+	// n'y ont plus accès mais ses données sont conservées
+	SuspendOrganization(ctx context.Context, orgID string) error
+	// RestoreOrganization sort l'organisation de la corbeille
+	RestoreOrganization(ctx context.Context, orgID string) error
+	// ListSuspendedOrganizations liste les organisations de la corbeille
+	ListSuspendedOrganizations(ctx context.Context) ([]*models.Organization, error)
+	// ProvisionOrganization crée une organisation et ses ressources de
+	// départ dans une seule transaction (voir models.OrganizationProvision)
+	ProvisionOrganization(ctx context.Context, provision *models.OrganizationProvision) error
+}
+
+// ProjectsRepository gère la persistance des projets et de leurs environnements
+type ProjectsRepository interface {
+	CreateProject(ctx context.Context, project *models.Project) error
+	GetProject(ctx context.Context, orgID, projectID string) (*models.Project, error)
+	ListOrganizationProjects(ctx context.Context, orgID string) ([]*models.Project, error)
+	// ListProjectSummaries liste les projets (hors corbeille) avec leur nombre
+	// de secrets, leurs environnements et leur dernière activité en une seule requête
+	ListProjectSummaries(ctx context.Context, orgID string) ([]*models.ProjectSummary, error)
+	// CreateProjectWithEnvironments crée le projet et ses environnements
+	// dans une seule transaction
+	CreateProjectWithEnvironments(ctx context.Context, project *models.Project, environments []*models.Environment) error
+	// DeleteProject supprime le projet, ses environnements et les métadonnées de ses secrets
+	DeleteProject(ctx context.Context, orgID, projectID string) error
+	// SuspendProject place le projet dans la corbeille. GetProject et
+	// ListOrganizationProjects ignorent les projets suspendus.
+	SuspendProject(ctx context.Context, orgID, projectID string) error
+	// RestoreProject sort le projet de la corbeille
+	RestoreProject(ctx context.Context, orgID, projectID string) error
+	IsProjectSuspended(ctx context.Context, orgID, projectID string) (bool, error)
+	// ListSuspendedProjects liste les projets placés dans la corbeille avant before
+	ListSuspendedProjects(ctx context.Context, before time.Time) ([]*models.Project, error)
+	// GetProjectEncryptionKey renvoie la clé publique de chiffrement de bout
+	// en bout du projet, ou nil, nil si le mode n'est pas activé
+	GetProjectEncryptionKey(ctx context.Context, projectID string) (*models.ProjectEncryptionKey, error)
+	// SetProjectEncryptionKey enregistre ou remplace la clé publique du projet
+	SetProjectEncryptionKey(ctx context.Context, key *models.ProjectEncryptionKey) error
+	// GetReadme renvoie le README du projet (env vide) ou de l'un de ses
+	// environnements, ou nil, nil s'il n'existe pas
+	GetReadme(ctx context.Context, projectID, env string) (*models.Readme, error)
+	// ListReadmes liste les README des projets d'une organisation
+	ListReadmes(ctx context.Context, orgID string) ([]*models.Readme, error)
+	// SetReadme enregistre ou remplace un README
+	SetReadme(ctx context.Context, readme *models.Readme) error
+	// DeleteReadme supprime un README ; sans effet s'il n'existe pas
+	DeleteReadme(ctx context.Context, projectID, env string) error
+	// SetProjectOwnership désigne les responsables d'un projet (ErrProjectNotFound)
+	SetProjectOwnership(ctx context.Context, orgID, projectID string, ownership models.Ownership) error
+}
+
+// SecretsRepository gère la persistance des métadonnées de secrets.
+// Les méthodes Get renvoient nil, nil lorsque le secret n'existe pas.
+type SecretsRepository interface {
+	CreateSecretMetadata(ctx context.Context, metadata *models.SecretMetadata) error
+	GetSecretMetadata(ctx context.Context, id string) (*models.SecretMetadata, error)
+	GetSecretMetadataByPath(ctx context.Context, orgID, projectID, env, name string) (*models.SecretMetadata, error)
+	ListProjectSecrets(ctx context.Context, orgID, projectID, env string) ([]*models.SecretMetadata, error)
+	// ListSecretsByProjects liste en une requête les secrets de plusieurs
+	// projets d'une organisation, dans un environnement ou dans tous (env vide)
+	ListSecretsByProjects(ctx context.Context, orgID string, projectIDs []string, env string) ([]*models.SecretMetadata, error)
+	// ListSecretsByValueHashes liste les secrets d'une organisation, tous
+	// projets confondus, dont l'empreinte de la valeur figure parmi hashes
+	ListSecretsByValueHashes(ctx context.Context, orgID string, hashes []string) ([]*models.SecretMetadata, error)
+	// ListDuplicateSecretValues liste les secrets d'une organisation dont la
+	// valeur est partagée avec au moins un autre secret, groupés par empreinte
+	ListDuplicateSecretValues(ctx context.Context, orgID string) ([]*models.SecretMetadata, error)
+	UpdateSecretMetadata(ctx context.Context, metadata *models.SecretMetadata) error
+	// SetSecretChecksum enregistre la somme de contrôle d'un secret sans
+	// modifier sa version ni sa date de mise à jour
+	SetSecretChecksum(ctx context.Context, id, checksum string) error
+	// SetSecretOwnership désigne les responsables d'un secret sans modifier
+	// sa version ni sa date de mise à jour
+	SetSecretOwnership(ctx context.Context, id string, ownership models.Ownership) error
+	DeleteSecretMetadata(ctx context.Context, id string, orgID string) error
+	DeleteSecretMetadataByPath(ctx context.Context, orgID, projectID, env, name string) error
+	LockSecret(ctx context.Context, id, userID, reason string) error
+	UnlockSecret(ctx context.Context, id string) error
+	GetSecretsCount(ctx context.Context, orgID string) (int, error)
+	GetSecretsLimit(ctx context.Context, orgID string) (int, error)
+	// ListSecretCounts renvoie, pour chaque organisation, le compteur enregistré
+	// et le nombre réel de métadonnées de secrets
+	ListSecretCounts(ctx context.Context) ([]*models.SecretCount, error)
+	// SetSecretsCount corrige le compteur de secrets d'une organisation
+	SetSecretsCount(ctx context.Context, orgID string, count int) error
+}
+
 // SecretsCountRepository gère le comptage et la limitation des secrets
 type SecretsCountRepository interface {
 	// GetSecretsCount récupère le nombre de secrets pour une organisation
@@ -21,6 +164,13 @@ type SecretsCountRepository interface {
 	GetSecretsLimit(ctx context.Context, orgID string) (int, error)
 }
 
+// SubscriptionsRepository donne accès aux abonnements des organisations
+type SubscriptionsRepository interface {
+	// GetActiveSubscription renvoie l'abonnement actif de l'organisation,
+	// nil si elle n'en a pas
+	GetActiveSubscription(ctx context.Context, orgID string) (*models.Subscription, error)
+}
+
 // SubscriptionService gère les abonnements et limites
 type SecretsSubscriptionService struct {
 	repo SecretsCountRepository
@@ -40,3 +190,781 @@ func (s *SecretsSubscriptionService) CanCreateSecret(ctx context.Context, orgID
 
 	return count < limit, nil
 }
+
+// UsageRepository enregistre et agrège les appels API par principal, route et jour
+type UsageRepository interface {
+	// RecordAPICall comptabilise un appel API
+	RecordAPICall(ctx context.Context, call *models.APICall) error
+
+	// RecordAPICallCounts comptabilise un lot d'appels accumulés
+	RecordAPICallCounts(ctx context.Context, counts []*models.APICallCount) error
+
+	// GetAPICallsTotal renvoie le nombre total d'appels d'une organisation
+	GetAPICallsTotal(ctx context.Context, orgID string) (int64, error)
+
+	// GetUsageBreakdown agrège les appels d'une organisation entre deux jours inclus
+	GetUsageBreakdown(ctx context.Context, orgID string, from, to time.Time) (*models.UsageBreakdown, error)
+
+	// GetLastActivityDays renvoie, pour chaque principal du type donné, le
+	// dernier jour (AAAA-MM-JJ) où il a appelé l'API de l'organisation
+	GetLastActivityDays(ctx context.Context, orgID, principalType string) (map[string]string, error)
+}
+
+// SecretReadsRepository comptabilise les lectures des valeurs des secrets par
+// charge de travail et principal
+type SecretReadsRepository interface {
+	// RecordSecretReads comptabilise un lot de lectures
+	RecordSecretReads(ctx context.Context, reads []*models.SecretRead) error
+
+	// ListSecretConsumers résume les lectures d'un secret depuis le jour de
+	// since, par charge de travail et principal, de la plus récente à la plus ancienne
+	ListSecretConsumers(ctx context.Context, orgID, projectID, env, name string, since time.Time) ([]*models.SecretConsumer, error)
+
+	// ListProjectDependencies résume les lectures des secrets d'un projet
+	// depuis le jour de since, par environnement, secret et charge de travail
+	ListProjectDependencies(ctx context.Context, orgID, projectID string, since time.Time) ([]*models.SecretDependency, error)
+
+	// ListOrganizationDailyReads totalise les lectures des secrets de
+	// l'organisation depuis le jour de since, par projet, environnement et jour
+	ListOrganizationDailyReads(ctx context.Context, orgID string, since time.Time) ([]*models.DailySecretReads, error)
+
+	// ListOrganizationSecretAccess résume les lectures des secrets de
+	// l'organisation depuis le jour de since, par principal et secret
+	ListOrganizationSecretAccess(ctx context.Context, orgID string, since time.Time) ([]*models.SecretAccess, error)
+}
+
+// SecretRotatorsRepository gère la configuration des rotations gérées des secrets
+type SecretRotatorsRepository interface {
+	// SaveSecretRotator crée ou remplace le rotateur d'un secret
+	SaveSecretRotator(ctx context.Context, rotator *models.SecretRotator) error
+
+	// GetSecretRotator renvoie le rotateur d'un secret (ErrRotatorNotFound s'il n'en a pas)
+	GetSecretRotator(ctx context.Context, orgID, projectID, env, name string) (*models.SecretRotator, error)
+
+	// DeleteSecretRotator supprime le rotateur d'un secret
+	DeleteSecretRotator(ctx context.Context, orgID, projectID, env, name string) error
+
+	// RecordSecretRotation enregistre le résultat d'une rotation (lastError vide si elle a réussi)
+	RecordSecretRotation(ctx context.Context, id string, at time.Time, lastError string) error
+
+	// SetPreviousExpiry enregistre la date de retrait de la valeur précédente
+	// d'une rotation double (nil une fois retirée)
+	SetPreviousExpiry(ctx context.Context, id string, expiresAt *time.Time) error
+
+	// ListExpiredPreviousValues liste les rotateurs dont la valeur précédente
+	// doit être retirée avant before
+	ListExpiredPreviousValues(ctx context.Context, before time.Time) ([]*models.SecretRotator, error)
+
+	// ListOrganizationPreviousExpiries liste les rotateurs de l'organisation
+	// dont la valeur précédente sera retirée avant before
+	ListOrganizationPreviousExpiries(ctx context.Context, orgID string, before time.Time) ([]*models.SecretRotator, error)
+
+	// ListOrganizationSecretRotators liste les rotateurs des secrets de l'organisation
+	ListOrganizationSecretRotators(ctx context.Context, orgID string) ([]*models.SecretRotator, error)
+}
+
+// ScheduledSecretChangesRepository gère les changements de valeur planifiés
+// des secrets (au plus un par secret)
+type ScheduledSecretChangesRepository interface {
+	// SaveScheduledSecretChange crée ou remplace le changement planifié d'un secret
+	SaveScheduledSecretChange(ctx context.Context, change *models.ScheduledSecretChange) error
+
+	// GetScheduledSecretChange renvoie le changement planifié d'un secret
+	// (ErrScheduleNotFound s'il n'en a pas)
+	GetScheduledSecretChange(ctx context.Context, orgID, projectID, env, name string) (*models.ScheduledSecretChange, error)
+
+	// DeleteScheduledSecretChange supprime un changement planifié appliqué ou annulé
+	DeleteScheduledSecretChange(ctx context.Context, id string) error
+
+	// ListDueScheduledSecretChanges liste les changements à appliquer avant
+	// before, du plus ancien au plus récent
+	ListDueScheduledSecretChanges(ctx context.Context, before time.Time) ([]*models.ScheduledSecretChange, error)
+
+	// ListOrganizationScheduledSecretChanges liste les changements de
+	// l'organisation à appliquer avant before
+	ListOrganizationScheduledSecretChanges(ctx context.Context, orgID string, before time.Time) ([]*models.ScheduledSecretChange, error)
+}
+
+// MaintenanceWindowsRepository gère les fenêtres de maintenance des environnements
+type MaintenanceWindowsRepository interface {
+	CreateMaintenanceWindow(ctx context.Context, window *models.MaintenanceWindow) error
+
+	// ListMaintenanceWindows liste les fenêtres de l'organisation par environnement
+	ListMaintenanceWindows(ctx context.Context, orgID string) ([]*models.MaintenanceWindow, error)
+
+	// ListEnvironmentMaintenanceWindows liste les fenêtres d'un environnement de l'organisation
+	ListEnvironmentMaintenanceWindows(ctx context.Context, orgID, env string) ([]*models.MaintenanceWindow, error)
+
+	// DeleteMaintenanceWindow supprime une fenêtre (ErrWindowNotFound si elle n'existe pas)
+	DeleteMaintenanceWindow(ctx context.Context, orgID, id string) error
+}
+
+// DataKeysRepository gère les clés de données des projets et l'audit de leurs opérations
+type DataKeysRepository interface {
+	// CreateDataKey enregistre une clé (ErrDataKeyExists si le nom est pris dans le projet)
+	CreateDataKey(ctx context.Context, key *models.DataKey) error
+
+	// GetDataKey renvoie une clé du projet par son nom (ErrDataKeyNotFound si elle n'existe pas)
+	GetDataKey(ctx context.Context, orgID, projectID, name string) (*models.DataKey, error)
+
+	// ListDataKeys liste les clés du projet par nom
+	ListDataKeys(ctx context.Context, orgID, projectID string) ([]*models.DataKey, error)
+
+	// RecordDataKeyOperation enregistre un chiffrement ou un déchiffrement
+	RecordDataKeyOperation(ctx context.Context, operation *models.DataKeyOperation) error
+
+	// ListDataKeyOperations liste les limit dernières opérations d'une clé,
+	// de la plus récente à la plus ancienne
+	ListDataKeyOperations(ctx context.Context, dataKeyID string, limit int) ([]*models.DataKeyOperation, error)
+}
+
+// AgeKeysRepository gère les clés age (sops) des dépôts de code des projets
+type AgeKeysRepository interface {
+	// CreateAgeKey enregistre la nouvelle clé active d'un dépôt et retire,
+	// dans la même transaction, la clé active précédente
+	CreateAgeKey(ctx context.Context, key *models.AgeKey) error
+
+	// ListAgeKeys liste les clés d'un dépôt du projet (de tous les dépôts si
+	// repository est vide), de la plus récente à la plus ancienne
+	ListAgeKeys(ctx context.Context, orgID, projectID, repository string) ([]*models.AgeKey, error)
+
+	// DeleteAgeKey supprime une clé du projet (ErrAgeKeyNotFound si elle n'existe pas)
+	DeleteAgeKey(ctx context.Context, orgID, projectID, id string) error
+}
+
+// SettingsHistoryRepository conserve les versions successives de la
+// configuration des organisations
+type SettingsHistoryRepository interface {
+	// RecordSettingsChange enregistre une modification sous la version
+	// suivante de la configuration de l'organisation
+	RecordSettingsChange(ctx context.Context, change *models.SettingsChange) error
+
+	// ListSettingsChanges liste les modifications de l'organisation, d'un seul
+	// élément si resource n'est pas vide, de la plus récente à la plus ancienne
+	ListSettingsChanges(ctx context.Context, orgID, resource string, limit, offset int) ([]*models.SettingsChange, error)
+}
+
+// HealthRepository conserve l'historique de santé des composants du service
+type HealthRepository interface {
+	// RecordHealthCheck comptabilise une vérification d'un composant dans son jour (UTC)
+	RecordHealthCheck(ctx context.Context, component string, at time.Time, healthy bool) error
+
+	// ListHealthCheckDays liste les agrégats journaliers depuis le jour de since,
+	// par composant puis par jour
+	ListHealthCheckDays(ctx context.Context, since time.Time) ([]*models.HealthCheckDay, error)
+
+	// PurgeHealthChecks supprime les agrégats des jours antérieurs à before
+	// et renvoie leur nombre
+	PurgeHealthChecks(ctx context.Context, before time.Time) (int64, error)
+
+	// OpenHealthIncident ouvre un incident (ErrIncidentOpen si le composant en
+	// a déjà un en cours, ouvert par exemple par une autre instance)
+	OpenHealthIncident(ctx context.Context, incident *models.HealthIncident) error
+
+	// ResolveHealthIncidents résout les incidents en cours du composant et
+	// renvoie leur nombre
+	ResolveHealthIncidents(ctx context.Context, component string, at time.Time) (int64, error)
+
+	// ListHealthIncidents liste les incidents en cours et ceux résolus depuis
+	// since, du plus récent au plus ancien
+	ListHealthIncidents(ctx context.Context, since time.Time) ([]*models.HealthIncident, error)
+}
+
+// LogForwardersRepository gère les destinations des journaux d'audit des organisations
+type LogForwardersRepository interface {
+	CreateLogForwarder(ctx context.Context, forwarder *models.LogForwarder) error
+
+	// GetLogForwarder renvoie une destination de l'organisation, secret compris
+	// (ErrForwarderNotFound si elle n'existe pas)
+	GetLogForwarder(ctx context.Context, orgID, id string) (*models.LogForwarder, error)
+
+	// ListLogForwarders liste les destinations de l'organisation, secret compris
+	ListLogForwarders(ctx context.Context, orgID string) ([]*models.LogForwarder, error)
+
+	DeleteLogForwarder(ctx context.Context, orgID, id string) error
+}
+
+// APICallShards est le nombre de lignes sur lesquelles est réparti le compteur
+// global d'appels de chaque organisation
+const APICallShards = 16
+
+// AdminAuditRepository gère le journal d'audit des routes d'administration
+type AdminAuditRepository interface {
+	// CreateAdminAuditLog enregistre une entrée du journal
+	CreateAdminAuditLog(ctx context.Context, entry *models.AdminAuditLog) error
+
+	// ListAdminAuditLogs liste les entrées depuis since, de la plus récente à la plus ancienne
+	ListAdminAuditLogs(ctx context.Context, since time.Time, limit, offset int) ([]*models.AdminAuditLog, error)
+
+	// ListImpersonationAuditLogs liste les requêtes faites sous une
+	// usurpation d'identité, de la plus récente à la plus ancienne
+	ListImpersonationAuditLogs(ctx context.Context, impersonationID string, limit, offset int) ([]*models.AdminAuditLog, error)
+
+	// PurgeAdminAuditLogs supprime les entrées antérieures à before et renvoie leur nombre
+	PurgeAdminAuditLogs(ctx context.Context, before time.Time) (int64, error)
+}
+
+// ImpersonationsRepository gère les usurpations d'identité par le support
+type ImpersonationsRepository interface {
+	CreateImpersonation(ctx context.Context, impersonation *models.Impersonation) error
+
+	// GetImpersonation renvoie une usurpation, même terminée
+	// (ErrImpersonationNotFound si elle n'existe pas)
+	GetImpersonation(ctx context.Context, id string) (*models.Impersonation, error)
+
+	// ListUserImpersonations liste les usurpations du compte d'un
+	// utilisateur, de la plus récente à la plus ancienne
+	ListUserImpersonations(ctx context.Context, userID string) ([]*models.Impersonation, error)
+
+	// EndImpersonation termine une usurpation en cours : ses tokens ne sont
+	// plus acceptés (ErrImpersonationNotFound si elle n'existe pas ou est
+	// déjà terminée)
+	EndImpersonation(ctx context.Context, id string, at time.Time) error
+}
+
+// ConfirmationsRepository gère les confirmations des opérations destructives
+type ConfirmationsRepository interface {
+	CreateConfirmation(ctx context.Context, confirmation *models.DeletionConfirmation) error
+	// ConsumeConfirmation renvoie et supprime la confirmation correspondant à l'empreinte
+	// (ErrConfirmationNotFound si elle n'existe pas)
+	ConsumeConfirmation(ctx context.Context, tokenHash string) (*models.DeletionConfirmation, error)
+	PurgeExpiredConfirmations(ctx context.Context, now time.Time) (int64, error)
+}
+
+// DeviceAuthorizationsRepository gère les demandes de connexion des appareils (flux device code)
+type DeviceAuthorizationsRepository interface {
+	// CreateDeviceAuthorization enregistre une demande ; ErrAlreadyExists si
+	// le user code est déjà attribué
+	CreateDeviceAuthorization(ctx context.Context, authorization *models.DeviceAuthorization) error
+
+	// PollDeviceAuthorization renvoie la demande correspondant à l'empreinte du
+	// device code, avec la date de l'interrogation précédente, et enregistre
+	// now comme date de la dernière interrogation (ErrDeviceCodeNotFound si elle n'existe pas)
+	PollDeviceAuthorization(ctx context.Context, deviceCodeHash string, now time.Time) (*models.DeviceAuthorization, error)
+
+	// DecideDeviceAuthorization approuve ou refuse une demande en attente non
+	// expirée (ErrDeviceCodeNotFound sinon)
+	DecideDeviceAuthorization(ctx context.Context, userCode, status, userID string, now time.Time) error
+
+	// DeleteDeviceAuthorization supprime une demande (ErrDeviceCodeNotFound si
+	// elle n'existe plus) : les tokens ne sont délivrés qu'une fois
+	DeleteDeviceAuthorization(ctx context.Context, id string) error
+
+	// PurgeExpiredDeviceAuthorizations supprime les demandes expirées et renvoie leur nombre
+	PurgeExpiredDeviceAuthorizations(ctx context.Context, now time.Time) (int64, error)
+}
+
+// PersonalAccessTokensRepository gère les tokens d'accès personnels des utilisateurs
+type PersonalAccessTokensRepository interface {
+	CreatePersonalAccessToken(ctx context.Context, token *models.PersonalAccessToken) error
+
+	// GetPersonalAccessTokenByHash renvoie le token correspondant à l'empreinte,
+	// même expiré (ErrTokenNotFound s'il n'existe pas)
+	GetPersonalAccessTokenByHash(ctx context.Context, tokenHash string) (*models.PersonalAccessToken, error)
+
+	// ListPersonalAccessTokens liste les tokens de l'utilisateur, du plus récent au plus ancien
+	ListPersonalAccessTokens(ctx context.Context, userID string) ([]*models.PersonalAccessToken, error)
+
+	// DeletePersonalAccessToken révoque un token de l'utilisateur (ErrTokenNotFound s'il n'existe pas)
+	DeletePersonalAccessToken(ctx context.Context, userID, id string) error
+
+	// TouchPersonalAccessToken enregistre la date de dernière utilisation
+	TouchPersonalAccessToken(ctx context.Context, id string, at time.Time) error
+}
+
+// APIKeysRepository gère les clés d'API des organisations
+type APIKeysRepository interface {
+	CreateAPIKey(ctx context.Context, key *models.APIKey) error
+
+	// GetAPIKeyByHash renvoie la clé correspondant à l'empreinte, même
+	// expirée (ErrAPIKeyNotFound si elle n'existe pas)
+	GetAPIKeyByHash(ctx context.Context, keyHash string) (*models.APIKey, error)
+
+	// GetAPIKey renvoie la clé d'identifiant id, quelle que soit son
+	// organisation, même expirée (ErrAPIKeyNotFound si elle n'existe pas)
+	GetAPIKey(ctx context.Context, id string) (*models.APIKey, error)
+
+	// ListAPIKeys liste les clés de l'organisation, de la plus récente à la plus ancienne
+	ListAPIKeys(ctx context.Context, orgID string) ([]*models.APIKey, error)
+
+	// ClaimRequestSignature retient la signature d'une requête de la clé
+	// jusqu'à expiresAt, pour toutes les instances de l'API
+	// (ErrSignatureReplayed si elle l'est déjà)
+	ClaimRequestSignature(ctx context.Context, keyID, signature string, expiresAt time.Time) error
+
+	// PurgeExpiredRequestSignatures oublie les signatures expirées et renvoie leur nombre
+	PurgeExpiredRequestSignatures(ctx context.Context, now time.Time) (int64, error)
+
+	// DeleteAPIKey révoque une clé de l'organisation (ErrAPIKeyNotFound si elle n'existe pas)
+	DeleteAPIKey(ctx context.Context, orgID, id string) error
+
+	// TouchAPIKey enregistre la date de dernière utilisation
+	TouchAPIKey(ctx context.Context, id string, at time.Time) error
+}
+
+// ServiceAccountsRepository gère les comptes de service des organisations
+type ServiceAccountsRepository interface {
+	// CreateServiceAccount enregistre un compte (ErrSPIFFEIDTaken si son
+	// identifiant SPIFFE est déjà associé à un autre compte de l'organisation)
+	CreateServiceAccount(ctx context.Context, account *models.ServiceAccount) error
+
+	// GetServiceAccount renvoie un compte de service, quelle que soit son
+	// organisation (ErrServiceAccountNotFound s'il n'existe pas)
+	GetServiceAccount(ctx context.Context, id string) (*models.ServiceAccount, error)
+
+	// GetServiceAccountBySPIFFEID renvoie le compte de l'organisation associé
+	// à l'identifiant SPIFFE (ErrServiceAccountNotFound s'il n'y en a pas)
+	GetServiceAccountBySPIFFEID(ctx context.Context, orgID, spiffeID string) (*models.ServiceAccount, error)
+
+	// SetServiceAccountSPIFFEID associe un identifiant SPIFFE à un compte de
+	// l'organisation, vide pour le retirer (ErrServiceAccountNotFound,
+	// ErrSPIFFEIDTaken s'il est associé à un autre compte de l'organisation)
+	SetServiceAccountSPIFFEID(ctx context.Context, orgID, id, spiffeID string) error
+
+	// ListServiceAccounts liste les comptes de l'organisation, du plus récent au plus ancien
+	ListServiceAccounts(ctx context.Context, orgID string) ([]*models.ServiceAccount, error)
+
+	// DeleteServiceAccount supprime un compte de l'organisation : ses tokens
+	// ne sont plus acceptés (ErrServiceAccountNotFound s'il n'existe pas)
+	DeleteServiceAccount(ctx context.Context, orgID, id string) error
+
+	// GetServiceAccountTrust renvoie la confiance mTLS de l'organisation
+	// (ErrTrustDomainNotFound si elle n'en a pas)
+	GetServiceAccountTrust(ctx context.Context, orgID string) (*models.ServiceAccountTrust, error)
+
+	// GetServiceAccountTrustByDomain renvoie la confiance mTLS du domaine de
+	// confiance, quelle que soit son organisation
+	// (ErrTrustDomainNotFound s'il n'appartient à aucune)
+	GetServiceAccountTrustByDomain(ctx context.Context, trustDomain string) (*models.ServiceAccountTrust, error)
+
+	// SaveServiceAccountTrust crée ou remplace la confiance mTLS de
+	// l'organisation (ErrTrustDomainTaken si le domaine appartient à une
+	// autre organisation)
+	SaveServiceAccountTrust(ctx context.Context, trust *models.ServiceAccountTrust) error
+
+	// TouchServiceAccount enregistre la date de dernière utilisation
+	TouchServiceAccount(ctx context.Context, id string, at time.Time) error
+}
+
+// CustomRolesRepository gère les rôles personnalisés des organisations et
+// leur attribution aux membres
+type CustomRolesRepository interface {
+	// CreateCustomRole enregistre un rôle (ErrCustomRoleExists si le nom est
+	// déjà pris dans l'organisation)
+	CreateCustomRole(ctx context.Context, role *models.CustomRole) error
+
+	// GetCustomRole renvoie un rôle de l'organisation (ErrCustomRoleNotFound
+	// s'il n'existe pas)
+	GetCustomRole(ctx context.Context, orgID, id string) (*models.CustomRole, error)
+
+	// ListCustomRoles liste les rôles de l'organisation par nom
+	ListCustomRoles(ctx context.Context, orgID string) ([]*models.CustomRole, error)
+
+	// UpdateCustomRole remplace le nom, la description et les permissions
+	// d'un rôle (ErrCustomRoleNotFound, ErrCustomRoleExists)
+	UpdateCustomRole(ctx context.Context, role *models.CustomRole) error
+
+	// DeleteCustomRole supprime un rôle et ses attributions
+	// (ErrCustomRoleNotFound s'il n'existe pas)
+	DeleteCustomRole(ctx context.Context, orgID, id string) error
+
+	// AssignCustomRole attribue un rôle à un membre, en remplaçant son rôle
+	// personnalisé actuel (ErrCustomRoleNotFound si le rôle n'existe pas)
+	AssignCustomRole(ctx context.Context, assignment *models.CustomRoleAssignment) error
+
+	// UnassignCustomRole retire son rôle personnalisé à un membre ; sans
+	// effet s'il n'en a pas
+	UnassignCustomRole(ctx context.Context, orgID, userID string) error
+
+	// GetMemberCustomRole renvoie le rôle personnalisé d'un membre
+	// (ErrCustomRoleNotFound s'il n'en a pas)
+	GetMemberCustomRole(ctx context.Context, orgID, userID string) (*models.CustomRole, error)
+}
+
+// TrustedDevicesRepository gère les appareils de confiance des utilisateurs,
+// dispensés de code TOTP à la connexion
+type TrustedDevicesRepository interface {
+	CreateTrustedDevice(ctx context.Context, device *models.TrustedDevice) error
+
+	// GetTrustedDeviceByHash renvoie l'appareil correspondant à l'empreinte du
+	// cookie, même expiré (ErrDeviceNotFound s'il n'existe pas)
+	GetTrustedDeviceByHash(ctx context.Context, tokenHash string) (*models.TrustedDevice, error)
+
+	// ListTrustedDevices liste les appareils de l'utilisateur, du plus récent au plus ancien
+	ListTrustedDevices(ctx context.Context, userID string) ([]*models.TrustedDevice, error)
+
+	// DeleteTrustedDevice révoque la confiance accordée à un appareil de
+	// l'utilisateur (ErrDeviceNotFound s'il n'existe pas)
+	DeleteTrustedDevice(ctx context.Context, userID, id string) error
+
+	// DeleteUserTrustedDevices révoque tous les appareils de l'utilisateur
+	// et renvoie leur nombre
+	DeleteUserTrustedDevices(ctx context.Context, userID string) (int64, error)
+
+	// TouchTrustedDevice enregistre la date de dernière utilisation
+	TouchTrustedDevice(ctx context.Context, id string, at time.Time) error
+}
+
+// RefreshTokensRepository conserve les tokens de rafraîchissement des
+// sessions des utilisateurs
+type RefreshTokensRepository interface {
+	// CreateRefreshToken enregistre un token ; sans SessionID, il ouvre une
+	// nouvelle session identifiée par l'ID du token
+	CreateRefreshToken(ctx context.Context, token *models.RefreshToken) error
+
+	// GetRefreshTokenByHash renvoie le token correspondant à l'empreinte,
+	// même remplacé, révoqué ou expiré (ErrRefreshTokenNotFound s'il n'existe pas)
+	GetRefreshTokenByHash(ctx context.Context, tokenHash string) (*models.RefreshToken, error)
+
+	// RotateRefreshToken marque le token comme remplacé et renvoie faux s'il
+	// l'était déjà ou si sa session a été révoquée : deux rafraîchissements
+	// concurrents avec le même token ne peuvent pas réussir tous les deux
+	RotateRefreshToken(ctx context.Context, id string, at time.Time) (bool, error)
+
+	// ListActiveRefreshTokens liste le token courant de chaque session non
+	// révoquée et non expirée de l'utilisateur, de la plus récente à la plus ancienne
+	ListActiveRefreshTokens(ctx context.Context, userID string, now time.Time) ([]*models.RefreshToken, error)
+
+	// RevokeSession révoque une session de l'utilisateur (ErrSessionNotFound
+	// si elle n'existe pas ou est déjà révoquée)
+	RevokeSession(ctx context.Context, userID, sessionID string, at time.Time) error
+
+	// RevokeUserSessions révoque les sessions de l'utilisateur, sauf
+	// exceptSessionID, et renvoie le nombre de tokens révoqués
+	RevokeUserSessions(ctx context.Context, userID, exceptSessionID string, at time.Time) (int64, error)
+
+	// SessionRevoked indique si une session a été révoquée
+	SessionRevoked(ctx context.Context, sessionID string) (bool, error)
+}
+
+// SessionPoliciesRepository gère les politiques de session des organisations
+type SessionPoliciesRepository interface {
+	// GetSessionPolicy renvoie la politique de l'organisation
+	// (ErrSessionPolicyNotFound si elle n'en a pas réglé)
+	GetSessionPolicy(ctx context.Context, orgID string) (*models.SessionPolicy, error)
+
+	// SaveSessionPolicy crée ou remplace la politique de l'organisation
+	SaveSessionPolicy(ctx context.Context, policy *models.SessionPolicy) error
+}
+
+// PasswordPoliciesRepository gère les politiques de mot de passe des
+// organisations et l'historique des mots de passe des utilisateurs
+type PasswordPoliciesRepository interface {
+	// GetPasswordPolicy renvoie la politique de l'organisation
+	// (ErrPasswordPolicyNotFound si elle n'en a pas réglé)
+	GetPasswordPolicy(ctx context.Context, orgID string) (*models.PasswordPolicy, error)
+
+	// SavePasswordPolicy crée ou remplace la politique de l'organisation
+	SavePasswordPolicy(ctx context.Context, policy *models.PasswordPolicy) error
+
+	// AddPasswordHistory enregistre l'empreinte d'un mot de passe remplacé ;
+	// seules les models.MaxPasswordHistory plus récentes sont conservées
+	AddPasswordHistory(ctx context.Context, userID, hashedPassword string, at time.Time) error
+
+	// ListPasswordHistory renvoie les empreintes des limit derniers mots de
+	// passe remplacés, du plus récent au plus ancien
+	ListPasswordHistory(ctx context.Context, userID string, limit int) ([]string, error)
+}
+
+// RegistrationPolicyRepository conserve la politique d'inscription réglée
+// depuis le listener d'administration, qui remplace celle de la configuration
+type RegistrationPolicyRepository interface {
+	// GetRegistrationPolicy renvoie la politique enregistrée
+	// (ErrRegistrationPolicyNotFound si aucune ne l'a été)
+	GetRegistrationPolicy(ctx context.Context) (*models.RegistrationPolicy, error)
+
+	// SaveRegistrationPolicy crée ou remplace la politique
+	SaveRegistrationPolicy(ctx context.Context, policy *models.RegistrationPolicy) error
+
+	// DeleteRegistrationPolicy supprime la politique enregistrée : celle de
+	// la configuration s'applique de nouveau
+	DeleteRegistrationPolicy(ctx context.Context) error
+}
+
+// OnboardingRepository conserve les étapes franchies de la prise en main
+// (voir models.OnboardingSteps), par utilisateur ou par organisation
+type OnboardingRepository interface {
+	// CompleteOnboardingStep enregistre une étape franchie et renvoie vrai
+	// si elle ne l'était pas encore ; sinon, la première date est conservée
+	CompleteOnboardingStep(ctx context.Context, step *models.OnboardingStep) (bool, error)
+
+	// ListOnboardingSteps liste les étapes franchies par les sujets
+	// (utilisateurs ou organisations)
+	ListOnboardingSteps(ctx context.Context, subjectIDs ...string) ([]*models.OnboardingStep, error)
+}
+
+// LoginEventsRepository conserve les connexions des utilisateurs et leur
+// position pour détecter les connexions inhabituelles
+type LoginEventsRepository interface {
+	RecordLoginEvent(ctx context.Context, event *models.LoginEvent) error
+
+	// LastLocatedLoginEvent renvoie la dernière connexion située de
+	// l'utilisateur (ErrLoginEventNotFound s'il n'en a aucune)
+	LastLocatedLoginEvent(ctx context.Context, userID string) (*models.LoginEvent, error)
+
+	// LoginLocationKnown indique si l'utilisateur s'est déjà connecté depuis
+	// cette ville de ce pays
+	LoginLocationKnown(ctx context.Context, userID, country, city string) (bool, error)
+
+	// ListLoginEvents liste les dernières connexions de l'utilisateur, de la
+	// plus récente à la plus ancienne
+	ListLoginEvents(ctx context.Context, userID string, limit int) ([]*models.LoginEvent, error)
+}
+
+// ReadReasonPoliciesRepository gère les environnements protégés des
+// organisations, dont la lecture des secrets exige un motif
+type ReadReasonPoliciesRepository interface {
+	// GetReadReasonPolicy renvoie la politique de l'organisation
+	// (ErrReadPolicyNotFound si elle n'en a pas réglé)
+	GetReadReasonPolicy(ctx context.Context, orgID string) (*models.ReadReasonPolicy, error)
+
+	// SaveReadReasonPolicy crée ou remplace la politique de l'organisation
+	SaveReadReasonPolicy(ctx context.Context, policy *models.ReadReasonPolicy) error
+}
+
+// ProjectTemplatesRepository gère les modèles appliqués aux projets créés
+// dans les organisations
+type ProjectTemplatesRepository interface {
+	// GetProjectTemplate renvoie le modèle de l'organisation
+	// (ErrProjectTemplateNotFound si elle n'en a pas réglé)
+	GetProjectTemplate(ctx context.Context, orgID string) (*models.ProjectTemplate, error)
+
+	// SaveProjectTemplate crée ou remplace le modèle de l'organisation
+	SaveProjectTemplate(ctx context.Context, template *models.ProjectTemplate) error
+}
+
+// AuditLogsRepository gère le journal d'audit des organisations
+type AuditLogsRepository interface {
+	// CreateAuditLogs enregistre un lot d'entrées
+	CreateAuditLogs(ctx context.Context, logs []*models.AuditLog) error
+
+	// ListAuditLogs liste les entrées de l'organisation depuis since, de la
+	// plus récente à la plus ancienne, limit au plus
+	ListAuditLogs(ctx context.Context, orgID string, since time.Time, limit int) ([]*models.AuditLog, error)
+}
+
+// InvitationsRepository gère les invitations à rejoindre les organisations
+type InvitationsRepository interface {
+	// CreateInvitation enregistre une invitation (ErrInvitationPending si une
+	// invitation de la même adresse dans l'organisation est encore en attente)
+	CreateInvitation(ctx context.Context, invitation *models.Invitation) error
+
+	// GetInvitationByHash renvoie l'invitation correspondant à l'empreinte,
+	// quel que soit son état (ErrInvitationNotFound si elle n'existe pas)
+	GetInvitationByHash(ctx context.Context, tokenHash string) (*models.Invitation, error)
+
+	// ListPendingInvitations liste les invitations de l'organisation en
+	// attente à la date now, de la plus récente à la plus ancienne
+	ListPendingInvitations(ctx context.Context, orgID string, now time.Time) ([]*models.Invitation, error)
+
+	// RevokeInvitation révoque une invitation en attente de l'organisation
+	// (ErrInvitationNotFound si elle n'est plus en attente)
+	RevokeInvitation(ctx context.Context, orgID, id string, now time.Time) error
+
+	// AcceptInvitation marque une invitation en attente comme acceptée par
+	// userID (ErrInvitationNotFound si elle n'est plus en attente) : un
+	// token ne sert qu'une fois
+	AcceptInvitation(ctx context.Context, id, userID string, now time.Time) error
+
+	// HasPendingInvitation indique si l'adresse est invitée dans au moins une
+	// organisation à la date now
+	HasPendingInvitation(ctx context.Context, email string, now time.Time) (bool, error)
+}
+
+// LoginAlertPoliciesRepository gère les politiques d'alerte de connexion des organisations
+type LoginAlertPoliciesRepository interface {
+	// GetLoginAlertPolicy renvoie la politique de l'organisation
+	// (ErrLoginPolicyNotFound si elle n'en a pas réglé)
+	GetLoginAlertPolicy(ctx context.Context, orgID string) (*models.LoginAlertPolicy, error)
+
+	// SaveLoginAlertPolicy crée ou remplace la politique de l'organisation
+	SaveLoginAlertPolicy(ctx context.Context, policy *models.LoginAlertPolicy) error
+}
+
+// UserMFARepository gère l'authentification multifacteur (TOTP) des utilisateurs
+type UserMFARepository interface {
+	// SaveUserMFA crée ou remplace l'authentification multifacteur d'un utilisateur
+	SaveUserMFA(ctx context.Context, mfa *models.UserMFA) error
+
+	// GetUserMFA renvoie l'authentification multifacteur d'un utilisateur
+	// (ErrMFANotFound s'il n'en a pas)
+	GetUserMFA(ctx context.Context, userID string) (*models.UserMFA, error)
+
+	// AcceptMFAStep enregistre un code accepté et remet à zéro les échecs,
+	// sauf si un pas postérieur ou égal a déjà été accepté (renvoie false)
+	AcceptMFAStep(ctx context.Context, userID string, step int64) (bool, error)
+
+	// SetMFAFailures enregistre le nombre de codes refusés et l'éventuel verrouillage
+	SetMFAFailures(ctx context.Context, userID string, failedAttempts int, lockedUntil *time.Time) error
+
+	// DeleteUserMFA supprime l'authentification multifacteur d'un utilisateur
+	DeleteUserMFA(ctx context.Context, userID string) error
+}
+
+// LockdownsRepository gère les confinements des organisations compromises
+type LockdownsRepository interface {
+	// CreateLockdown enregistre un confinement (ErrLockdownActive si un
+	// confinement est déjà en cours)
+	CreateLockdown(ctx context.Context, lockdown *models.Lockdown) error
+
+	// GetActiveLockdown renvoie le confinement en cours de l'organisation
+	// (ErrLockdownNotFound s'il n'y en a pas)
+	GetActiveLockdown(ctx context.Context, orgID string) (*models.Lockdown, error)
+
+	// SetLockdownRevocations enregistre le nombre de membres et de tokens révoqués
+	SetLockdownRevocations(ctx context.Context, id string, affectedUsers, revokedTokens int) error
+
+	// LiftLockdown lève le confinement en cours (ErrLockdownNotFound s'il n'y en a pas)
+	LiftLockdown(ctx context.Context, orgID, liftedBy string, at time.Time) error
+}
+
+// OrganizationDeletionsRepository suit les suppressions asynchrones d'organisations
+type OrganizationDeletionsRepository interface {
+	// CreateOrganizationDeletion enregistre une nouvelle suppression en attente
+	CreateOrganizationDeletion(ctx context.Context, deletion *models.OrganizationDeletion) error
+
+	// GetOrganizationDeletion récupère une suppression (ErrDeletionNotFound si elle n'existe pas)
+	GetOrganizationDeletion(ctx context.Context, id string) (*models.OrganizationDeletion, error)
+
+	// UpdateOrganizationDeletion enregistre l'avancement d'une suppression
+	UpdateOrganizationDeletion(ctx context.Context, deletion *models.OrganizationDeletion) error
+
+	// ListUnfinishedOrganizationDeletions liste les suppressions en attente ou interrompues
+	ListUnfinishedOrganizationDeletions(ctx context.Context) ([]*models.OrganizationDeletion, error)
+}
+
+// AccessReviewsRepository gère les campagnes de revue des accès
+type AccessReviewsRepository interface {
+	// CreateAccessReview enregistre une campagne et ses accès à revoir
+	CreateAccessReview(ctx context.Context, review *models.AccessReview) error
+
+	// GetAccessReview récupère une campagne et ses accès (ErrAccessReviewNotFound si elle n'existe pas)
+	GetAccessReview(ctx context.Context, orgID, id string) (*models.AccessReview, error)
+
+	// ListAccessReviews liste les campagnes d'une organisation, sans leurs accès,
+	// de la plus récente à la plus ancienne
+	ListAccessReviews(ctx context.Context, orgID string) ([]*models.AccessReview, error)
+
+	// DecideAccessReviewItem enregistre la décision sur un accès encore en
+	// attente (ErrAccessAlreadyReviewed sinon)
+	DecideAccessReviewItem(ctx context.Context, item *models.AccessReviewItem) error
+
+	// CompleteAccessReview termine la campagne s'il ne reste aucun accès en attente
+	CompleteAccessReview(ctx context.Context, id string) error
+}
+
+// BulkRotationsRepository suit les rotations groupées des secrets d'une organisation
+type BulkRotationsRepository interface {
+	// CreateBulkRotation enregistre une rotation groupée et ses secrets
+	CreateBulkRotation(ctx context.Context, rotation *models.BulkRotation) error
+
+	// GetBulkRotation récupère une rotation groupée et l'avancement de chaque
+	// secret (ErrBulkRotationNotFound si elle n'existe pas)
+	GetBulkRotation(ctx context.Context, orgID, id string) (*models.BulkRotation, error)
+
+	// ListUnfinishedBulkRotations liste les rotations groupées en attente ou
+	// interrompues, sans leurs secrets, de la plus ancienne à la plus récente
+	ListUnfinishedBulkRotations(ctx context.Context) ([]*models.BulkRotation, error)
+
+	// UpdateBulkRotationItem enregistre le résultat de la rotation d'un secret
+	UpdateBulkRotationItem(ctx context.Context, item *models.BulkRotationItem) error
+
+	// SetBulkRotationStatus enregistre l'état d'une rotation groupée
+	SetBulkRotationStatus(ctx context.Context, id, status string, completedAt *time.Time) error
+}
+
+// NotificationPreferencesRepository gère les réglages de notification des utilisateurs
+type NotificationPreferencesRepository interface {
+	// GetNotificationPreferences renvoie les réglages d'un utilisateur, ou
+	// nil, nil s'il n'en a jamais enregistré
+	GetNotificationPreferences(ctx context.Context, userID string) (*models.NotificationPreferences, error)
+
+	// SetNotificationPreferences remplace les réglages d'un utilisateur
+	// (sauf la date du dernier résumé)
+	SetNotificationPreferences(ctx context.Context, prefs *models.NotificationPreferences) error
+
+	// ListDigestSubscribers liste les réglages des utilisateurs qui reçoivent des résumés
+	ListDigestSubscribers(ctx context.Context) ([]*models.NotificationPreferences, error)
+
+	// MarkDigestSent enregistre la date du dernier résumé envoyé à un utilisateur
+	MarkDigestSent(ctx context.Context, userID string, at time.Time) error
+}
+
+// NotificationEventsRepository conserve les événements notifiés pour les résumés
+type NotificationEventsRepository interface {
+	RecordNotificationEvent(ctx context.Context, event *models.NotificationEvent) error
+
+	// ListNotificationEvents liste les événements d'une organisation survenus
+	// dans [since, until), du plus ancien au plus récent
+	ListNotificationEvents(ctx context.Context, orgID string, since, until time.Time) ([]*models.NotificationEvent, error)
+
+	// PurgeNotificationEvents supprime les événements survenus avant before
+	PurgeNotificationEvents(ctx context.Context, before time.Time) (int64, error)
+}
+
+// WebhooksRepository gère les webhooks des organisations et l'historique de leurs livraisons
+type WebhooksRepository interface {
+	CreateWebhook(ctx context.Context, webhook *models.Webhook) error
+
+	// GetWebhook renvoie un webhook de l'organisation, secret compris
+	// (ErrWebhookNotFound s'il n'existe pas)
+	GetWebhook(ctx context.Context, orgID, id string) (*models.Webhook, error)
+
+	// ListWebhooks liste les webhooks de l'organisation, secret compris
+	ListWebhooks(ctx context.Context, orgID string) ([]*models.Webhook, error)
+
+	// DeleteWebhook supprime un webhook et l'historique de ses livraisons
+	DeleteWebhook(ctx context.Context, orgID, id string) error
+
+	RecordWebhookDelivery(ctx context.Context, delivery *models.WebhookDelivery) error
+
+	// GetWebhookDelivery renvoie une livraison d'un webhook de l'organisation
+	// (ErrDeliveryNotFound si elle n'existe pas)
+	GetWebhookDelivery(ctx context.Context, orgID, id string) (*models.WebhookDelivery, error)
+
+	// ListWebhookDeliveries liste les livraisons d'un webhook, de la plus récente à la plus ancienne
+	ListWebhookDeliveries(ctx context.Context, orgID, webhookID string, limit, offset int) ([]*models.WebhookDelivery, error)
+
+	// PurgeWebhookDeliveries supprime les livraisons antérieures à before et renvoie leur nombre
+	PurgeWebhookDeliveries(ctx context.Context, before time.Time) (int64, error)
+}
+
+// TeamsRepository gère les équipes des organisations et leurs membres
+type TeamsRepository interface {
+	// CreateTeam enregistre une équipe (ErrTeamExists si le nom est déjà
+	// pris dans l'organisation)
+	CreateTeam(ctx context.Context, team *models.Team) error
+
+	// GetTeam renvoie une équipe de l'organisation avec ses membres et ses
+	// octrois (ErrTeamNotFound si elle n'existe pas)
+	GetTeam(ctx context.Context, orgID, id string) (*models.Team, error)
+
+	// ListTeams liste les équipes de l'organisation par nom, sans leurs membres
+	ListTeams(ctx context.Context, orgID string) ([]*models.Team, error)
+
+	// DeleteTeam supprime une équipe, ses appartenances et ses octrois ; les
+	// projets et secrets qu'elle possédait n'ont plus d'équipe responsable
+	DeleteTeam(ctx context.Context, orgID, id string) error
+
+	// AddTeamMember ajoute un membre à une équipe ; sans effet s'il en fait déjà partie
+	AddTeamMember(ctx context.Context, member *models.TeamMember) error
+
+	// RemoveTeamMember retire un membre d'une équipe ; sans effet s'il n'en fait pas partie
+	RemoveTeamMember(ctx context.Context, teamID, userID string) error
+
+	// ListUserTeams liste les équipes de l'organisation dont l'utilisateur est membre
+	ListUserTeams(ctx context.Context, orgID, userID string) ([]*models.Team, error)
+
+	// SetTeamGrant accorde un rôle à une équipe sur un projet (ou l'un de
+	// ses environnements), en remplaçant l'octroi existant (ErrTeamNotFound
+	// si l'équipe n'existe pas)
+	SetTeamGrant(ctx context.Context, grant *models.TeamGrant) error
+
+	// RemoveTeamGrant retire l'octroi d'une équipe sur un projet ou un
+	// environnement ; sans effet s'il n'existe pas
+	RemoveTeamGrant(ctx context.Context, teamID, projectID, env string) error
+
+	// ListUserGrants liste les octrois des équipes de l'organisation dont
+	// l'utilisateur est membre
+	ListUserGrants(ctx context.Context, orgID, userID string) ([]*models.TeamGrant, error)
+}
@@ -13,6 +13,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"math/rand/v2"
 
 	"github.com/google/uuid"
 )
@@ -112,44 +113,26 @@ func (r *SecretCountRepository) GetSecretsLimit(ctx context.Context, orgID strin
 	return limit, nil
 }
 
-// IncrementAPICallCount incrémente le compteur d'appels API pour une organisation
+// IncrementAPICallCount incrémente le compteur d'appels API pour une organisation,
+// sur une des APICallShards lignes du compteur
 func (r *SecretCountRepository) IncrementAPICallCount(ctx context.Context, orgID string) error {
-	// Tentative de mise à jour
 	query := `
-		UPDATE usage_statistics 
-		SET api_calls = api_calls + 1, last_updated = NOW() 
-		WHERE organization_id = ?
+		INSERT INTO api_call_counters (organization_id, shard, calls)
+		VALUES (?, ?, 1)
+		ON DUPLICATE KEY UPDATE calls = calls + 1
 	`
 
-	result, err := r.db.ExecContext(ctx, query, orgID)
-	if err != nil {
-		return err
-	}
-
-	// Si aucune ligne n'a été mise à jour, insérer un nouveau record
-	rows, err := result.RowsAffected()
-	if err != nil {
-		return err
-	}
-
-	if rows == 0 {
-		insertQuery := `
-			INSERT INTO usage_statistics (id, organization_id, secret_count, api_calls, last_updated)
-			VALUES (?, ?, 0, 1, NOW())
-		`
-		_, err = r.db.ExecContext(ctx, insertQuery, uuid.New().String(), orgID)
-		return err
-	}
-
-	return nil
+	_, err := r.db.ExecContext(ctx, query, orgID, rand.IntN(APICallShards))
+	return err
 }
 
 // GetUsageStatistics récupère les statistiques d'usage pour une organisation
 func (r *SecretCountRepository) GetUsageStatistics(ctx context.Context, orgID string) (int, int, error) {
 	query := `
-		SELECT secret_count, api_calls
-		FROM usage_statistics 
-		WHERE organization_id = ?
+		SELECT us.secret_count, COALESCE(oac.api_calls, 0)
+		FROM usage_statistics us
+		LEFT JOIN organization_api_calls oac ON oac.organization_id = us.organization_id
+		WHERE us.organization_id = ?
 	`
 
 	var secretCount, apiCalls int
@@ -23,17 +23,22 @@ import (
 // ErrSubscriptionLimitReached indique que la limite d'un abonnement a été atteinte
 var ErrSubscriptionLimitReached = errors.New("limite de secrets atteinte pour cet abonnement")
 
+var _ SubscriptionsRepository = (*SubscriptionService)(nil)
+
 // SubscriptionService gère les abonnements et leurs limites
 type SubscriptionService struct {
 	db            *sql.DB
 	secretsRepo   *SecretCountRepository
+	// history historise les changements d'abonnement ; nil les ignore
+	history SettingsHistoryRepository
 }
 
 // NewSubscriptionService crée un nouveau service d'abonnement
-func NewSubscriptionService(db *sql.DB) *SubscriptionService {
+func NewSubscriptionService(db *sql.DB, history SettingsHistoryRepository) *SubscriptionService {
 	return &SubscriptionService{
 		db:          db,
 		secretsRepo: NewSecretCountRepository(db),
+		history:     history,
 	}
 }
 
@@ -113,8 +118,34 @@ func (s *SubscriptionService) CreateSubscription(ctx context.Context, subscripti
 		subscription.StartDate,
 		subscription.EndDate,
 	)
+	if err != nil {
+		return err
+	}
 
-	return err
+	if existingSub != nil {
+		return s.recordChange(ctx, subscription.OrganizationID, subscription.ID, models.SettingsUpdated, existingSub, subscription)
+	}
+	return s.recordChange(ctx, subscription.OrganizationID, subscription.ID, models.SettingsCreated, nil, subscription)
+}
+
+// recordChange historise un changement d'abonnement, fait par le système
+// (facturation, outils d'exploitation) et non par un membre
+func (s *SubscriptionService) recordChange(ctx context.Context, orgID, subscriptionID, action string, before, after *models.Subscription) error {
+	if s.history == nil {
+		return nil
+	}
+	var beforeState, afterState any
+	if before != nil {
+		beforeState = before
+	}
+	if after != nil {
+		afterState = after
+	}
+	change, err := models.NewSettingsChange(orgID, models.SettingsSubscription, subscriptionID, action, "", beforeState, afterState)
+	if err != nil {
+		return err
+	}
+	return s.history.RecordSettingsChange(ctx, change)
 }
 
 // CancelSubscription annule un abonnement
@@ -228,14 +259,42 @@ func (s *SubscriptionService) ListAvailablePlans(ctx context.Context) ([]*models
 
 // UpdateSubscriptionLimit met à jour la limite de secrets d'un abonnement
 func (s *SubscriptionService) UpdateSubscriptionLimit(ctx context.Context, subscriptionID string, newLimit int) error {
+	before := &models.Subscription{}
+	err := s.db.QueryRowContext(ctx, `
+		SELECT id, organization_id, plan_id, status, secrets_limit,
+			   start_date, end_date, created_at, updated_at
+		FROM subscriptions
+		WHERE id = ?
+	`, subscriptionID).Scan(
+		&before.ID,
+		&before.OrganizationID,
+		&before.PlanID,
+		&before.Status,
+		&before.SecretsLimit,
+		&before.StartDate,
+		&before.EndDate,
+		&before.CreatedAt,
+		&before.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
 	query := `
 		UPDATE subscriptions
 		SET secrets_limit = ?, updated_at = NOW()
 		WHERE id = ?
 	`
 
-	_, err := s.db.ExecContext(ctx, query, newLimit, subscriptionID)
-	return err
+	_, err = s.db.ExecContext(ctx, query, newLimit, subscriptionID)
+	if err != nil {
+		return err
+	}
+
+	after := *before
+	after.SecretsLimit = newLimit
+	after.UpdatedAt = time.Now()
+	return s.recordChange(ctx, before.OrganizationID, subscriptionID, models.SettingsUpdated, before, &after)
 }
 
 // UpgradeToPlan met à niveau l'abonnement d'une organisation vers un nouveau plan
@@ -0,0 +1,93 @@
+// filepath: internal/storage/usage_buffer.go
+
+package storage
+
+import (
+	"context"
+	"sync"
+
+	"secrets-manager/internal/models"
+)
+
+// usageBufferKey regroupe les appels qui incrémentent la même ligne d'usage
+type usageBufferKey struct {
+	organizationID string
+	day            string
+	principalType  string
+	principalID    string
+	route          string
+}
+
+// UsageBuffer accumule les appels API en mémoire et les écrit par lots à
+// chaque Flush, au lieu d'une écriture par requête. Les lectures sont
+// déléguées au repository sous-jacent et ignorent les appels non encore écrits.
+type UsageBuffer struct {
+	UsageRepository
+
+	mu      sync.Mutex
+	pending map[usageBufferKey]int64
+}
+
+var _ UsageRepository = (*UsageBuffer)(nil)
+
+// NewUsageBuffer crée un accumulateur d'appels devant repo
+func NewUsageBuffer(repo UsageRepository) *UsageBuffer {
+	return &UsageBuffer{
+		UsageRepository: repo,
+		pending:         make(map[usageBufferKey]int64),
+	}
+}
+
+// RecordAPICall accumule un appel en mémoire jusqu'au prochain Flush
+func (b *UsageBuffer) RecordAPICall(ctx context.Context, call *models.APICall) error {
+	b.add(call.Count())
+	return nil
+}
+
+// Flush écrit les appels accumulés en un seul lot. En cas d'échec, ils sont
+// conservés pour la tentative suivante.
+func (b *UsageBuffer) Flush(ctx context.Context) error {
+	b.mu.Lock()
+	pending := b.pending
+	b.pending = make(map[usageBufferKey]int64)
+	b.mu.Unlock()
+
+	if len(pending) == 0 {
+		return nil
+	}
+
+	counts := make([]*models.APICallCount, 0, len(pending))
+	for key, calls := range pending {
+		counts = append(counts, &models.APICallCount{
+			OrganizationID: key.organizationID,
+			Day:            key.day,
+			PrincipalType:  key.principalType,
+			PrincipalID:    key.principalID,
+			Route:          key.route,
+			Calls:          calls,
+		})
+	}
+
+	if err := b.UsageRepository.RecordAPICallCounts(ctx, counts); err != nil {
+		for _, count := range counts {
+			b.add(count)
+		}
+		return err
+	}
+	return nil
+}
+
+// add ajoute un décompte aux appels en attente
+func (b *UsageBuffer) add(count *models.APICallCount) {
+	key := usageBufferKey{
+		organizationID: count.OrganizationID,
+		day:            count.Day,
+		principalType:  count.PrincipalType,
+		principalID:    count.PrincipalID,
+		route:          count.Route,
+	}
+
+	b.mu.Lock()
+	b.pending[key] += count.Calls
+	b.mu.Unlock()
+}
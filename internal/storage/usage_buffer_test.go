@@ -0,0 +1,66 @@
+// filepath: internal/storage/usage_buffer_test.go
+
+package storage_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/storage"
+	"secrets-manager/internal/storage/memory"
+)
+
+// failingUsage fait échouer l'écriture des lots tant que err est défini
+type failingUsage struct {
+	storage.UsageRepository
+	err error
+}
+
+func (f *failingUsage) RecordAPICallCounts(ctx context.Context, counts []*models.APICallCount) error {
+	if f.err != nil {
+		return f.err
+	}
+	return f.UsageRepository.RecordAPICallCounts(ctx, counts)
+}
+
+func TestUsageBufferFlush(t *testing.T) {
+	ctx := context.Background()
+	usage := &failingUsage{UsageRepository: memory.NewUsageRepository(memory.NewDB())}
+	buffer := storage.NewUsageBuffer(usage)
+
+	now := time.Now()
+	for _, route := range []string{"GET /a", "GET /a", "GET /a", "POST /b"} {
+		call := &models.APICall{OrganizationID: "org1", PrincipalType: "user", PrincipalID: "u1", Route: route, Timestamp: now}
+		if err := buffer.RecordAPICall(ctx, call); err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+	}
+
+	// Rien n'est écrit avant le Flush ; un échec conserve les appels
+	usage.err = errors.New("base indisponible")
+	if err := buffer.Flush(ctx); err == nil {
+		t.Fatal("Expected error but got none")
+	}
+	if total, _ := buffer.GetAPICallsTotal(ctx, "org1"); total != 0 {
+		t.Errorf("Expected no calls before a successful flush, got %d", total)
+	}
+
+	usage.err = nil
+	if err := buffer.Flush(ctx); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if total, _ := buffer.GetAPICallsTotal(ctx, "org1"); total != 4 {
+		t.Errorf("Expected 4 calls, got %d", total)
+	}
+
+	breakdown, err := buffer.GetUsageBreakdown(ctx, "org1", now, now)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if len(breakdown.ByRoute) != 2 || breakdown.ByRoute[0].Key != "GET /a" || breakdown.ByRoute[0].Calls != 3 {
+		t.Errorf("Unexpected breakdown by route: %+v", breakdown.ByRoute)
+	}
+}
@@ -0,0 +1,240 @@
+// filepath: internal/vault/breaker.go
+
+package vault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"secrets-manager/internal/metrics"
+)
+
+// États du disjoncteur, exposés tels quels dans la métrique vault_circuit_state
+const (
+	CircuitClosed   = 0
+	CircuitHalfOpen = 1
+	CircuitOpen     = 2
+)
+
+var (
+	circuitState = metrics.NewGauge("vault_circuit_state",
+		"État du disjoncteur Vault (0 fermé, 1 semi-ouvert, 2 ouvert)")
+	circuitRejections = metrics.NewCounter("vault_circuit_rejections_total",
+		"Appels Vault refusés par le disjoncteur ouvert", "op")
+	circuitCacheHits = metrics.NewCounter("vault_circuit_cache_hits_total",
+		"Listes de secrets servies depuis le cache en mode dégradé")
+)
+
+// CircuitOpenError est renvoyée sans appeler Vault tant que le disjoncteur est ouvert
+type CircuitOpenError struct {
+	retryAfter time.Duration
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("Vault indisponible, nouvel essai dans %s", e.retryAfter.Round(time.Second))
+}
+
+func (e *CircuitOpenError) Unwrap() error { return ErrUnavailable }
+
+// RetryAfter renvoie le délai avant la prochaine tentative vers Vault
+func (e *CircuitOpenError) RetryAfter() time.Duration { return e.retryAfter }
+
+// BreakerConfig règle le disjoncteur
+type BreakerConfig struct {
+	// Threshold est le nombre d'échecs consécutifs qui ouvre le disjoncteur
+	Threshold int
+	// Cooldown est la durée d'ouverture avant un appel d'essai
+	Cooldown time.Duration
+}
+
+// Breaker protège un SecretStore : après Threshold indisponibilités
+// consécutives, les appels échouent immédiatement pendant Cooldown au lieu
+// d'attendre Vault. En mode dégradé, les listes de clés déjà connues sont
+// servies depuis le cache ; les lectures de valeurs échouent avec
+// CircuitOpenError (503 avec Retry-After côté API).
+type Breaker struct {
+	store  SecretStore
+	config BreakerConfig
+
+	mu       sync.Mutex
+	state    int
+	failures int
+	openedAt time.Time
+	now      func() time.Time
+
+	// Dernière liste de clés connue par chemin
+	listCache map[string][]string
+}
+
+var _ SecretStore = (*Breaker)(nil)
+
+// NewBreaker crée un disjoncteur autour de store
+func NewBreaker(store SecretStore, config BreakerConfig) *Breaker {
+	if config.Threshold <= 0 {
+		config.Threshold = 5
+	}
+	if config.Cooldown <= 0 {
+		config.Cooldown = 30 * time.Second
+	}
+
+	circuitState.Set(CircuitClosed)
+	return &Breaker{
+		store:     store,
+		config:    config,
+		now:       time.Now,
+		listCache: make(map[string][]string),
+	}
+}
+
+// State renvoie l'état courant du disjoncteur
+func (b *Breaker) State() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// allow indique si un appel peut être transmis à Vault
+func (b *Breaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case CircuitOpen:
+		elapsed := b.now().Sub(b.openedAt)
+		if elapsed < b.config.Cooldown {
+			return &CircuitOpenError{retryAfter: b.config.Cooldown - elapsed}
+		}
+		// Laisser passer un unique appel d'essai
+		b.setState(CircuitHalfOpen)
+		return nil
+	case CircuitHalfOpen:
+		// Un appel d'essai est déjà en cours
+		return &CircuitOpenError{retryAfter: time.Second}
+	default:
+		return nil
+	}
+}
+
+// record met à jour le disjoncteur avec le résultat d'un appel.
+// Seules les indisponibilités comptent : un secret absent ou un refus
+// de Vault ne disent rien de sa santé.
+func (b *Breaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err == nil || !errors.Is(err, ErrUnavailable) {
+		b.failures = 0
+		if b.state != CircuitClosed {
+			logger.Info("Vault de nouveau disponible, disjoncteur fermé")
+			b.setState(CircuitClosed)
+		}
+		return
+	}
+
+	b.failures++
+	if b.state == CircuitHalfOpen || b.failures >= b.config.Threshold {
+		if b.state != CircuitOpen {
+			logger.Warn("Vault indisponible, disjoncteur ouvert",
+				"failures", b.failures, "cooldown", b.config.Cooldown, "error", err)
+		}
+		b.openedAt = b.now()
+		b.setState(CircuitOpen)
+	}
+}
+
+func (b *Breaker) setState(state int) {
+	b.state = state
+	circuitState.Set(float64(state))
+}
+
+// GetSecret lit un secret, ou échoue immédiatement si le disjoncteur est ouvert
+func (b *Breaker) GetSecret(ctx context.Context, path string) (map[string]interface{}, error) {
+	if err := b.allow(); err != nil {
+		circuitRejections.Inc("get")
+		return nil, err
+	}
+
+	data, err := b.store.GetSecret(ctx, path)
+	b.record(err)
+	return data, err
+}
+
+// GetSecretVersion lit une version d'un secret, ou échoue immédiatement si le disjoncteur est ouvert
+func (b *Breaker) GetSecretVersion(ctx context.Context, path string, version int) (map[string]interface{}, error) {
+	if err := b.allow(); err != nil {
+		circuitRejections.Inc("get")
+		return nil, err
+	}
+
+	data, err := b.store.GetSecretVersion(ctx, path, version)
+	b.record(err)
+	return data, err
+}
+
+// WriteSecret écrit un secret, ou échoue immédiatement si le disjoncteur est ouvert
+func (b *Breaker) WriteSecret(ctx context.Context, path string, data map[string]interface{}) error {
+	if err := b.allow(); err != nil {
+		circuitRejections.Inc("write")
+		return err
+	}
+
+	err := b.store.WriteSecret(ctx, path, data)
+	b.record(err)
+	return err
+}
+
+// DeleteSecret supprime un secret, ou échoue immédiatement si le disjoncteur est ouvert
+func (b *Breaker) DeleteSecret(ctx context.Context, path string) error {
+	if err := b.allow(); err != nil {
+		circuitRejections.Inc("delete")
+		return err
+	}
+
+	err := b.store.DeleteSecret(ctx, path)
+	b.record(err)
+	return err
+}
+
+// ListSecrets liste les clés d'un chemin. Si Vault est indisponible,
+// la dernière liste connue pour ce chemin est renvoyée.
+func (b *Breaker) ListSecrets(ctx context.Context, path string) ([]string, error) {
+	if err := b.allow(); err != nil {
+		if keys, ok := b.cachedList(path); ok {
+			circuitCacheHits.Inc()
+			return keys, nil
+		}
+		circuitRejections.Inc("list")
+		return nil, err
+	}
+
+	keys, err := b.store.ListSecrets(ctx, path)
+	b.record(err)
+	if err != nil {
+		if errors.Is(err, ErrUnavailable) {
+			if cached, ok := b.cachedList(path); ok {
+				circuitCacheHits.Inc()
+				return cached, nil
+			}
+		}
+		return nil, err
+	}
+
+	b.mu.Lock()
+	b.listCache[path] = append([]string(nil), keys...)
+	b.mu.Unlock()
+	return keys, nil
+}
+
+func (b *Breaker) cachedList(path string) ([]string, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	keys, ok := b.listCache[path]
+	if !ok {
+		return nil, false
+	}
+	return append([]string(nil), keys...), true
+}
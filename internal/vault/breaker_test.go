@@ -0,0 +1,85 @@
+// filepath: internal/vault/breaker_test.go
+
+package vault_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/vault"
+)
+
+func TestBreakerOpensAndServesCachedLists(t *testing.T) {
+	ctx := context.Background()
+	store := vault.NewMemoryStore()
+	breaker := vault.NewBreaker(store, vault.BreakerConfig{Threshold: 2, Cooldown: 50 * time.Millisecond})
+	service := vault.NewService(breaker)
+
+	if err := service.StoreSecret(ctx, &models.Secret{
+		Name: "API_KEY", Value: "abc", OrganizationID: "org1", ProjectID: "proj1", Environment: "dev",
+	}); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+
+	// Mettre la liste en cache tant que Vault répond
+	if _, err := service.ListProjectSecrets(ctx, "org1", "proj1", "dev"); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+
+	outage := errors.Join(vault.ErrUnavailable, errors.New("sealed"))
+	store.FailOn("get", outage)
+	store.FailOn("list", outage)
+
+	for i := 0; i < 2; i++ {
+		if _, err := service.GetSecret(ctx, "org1", "proj1", "dev", "API_KEY"); !errors.Is(err, vault.ErrUnavailable) {
+			t.Fatalf("Expected ErrUnavailable, got %v", err)
+		}
+	}
+	if breaker.State() != vault.CircuitOpen {
+		t.Fatalf("Expected open circuit, got state %d", breaker.State())
+	}
+
+	// Disjoncteur ouvert : échec immédiat avec un délai de réessai
+	_, err := service.GetSecret(ctx, "org1", "proj1", "dev", "API_KEY")
+	var openErr *vault.CircuitOpenError
+	if !errors.As(err, &openErr) || openErr.RetryAfter() <= 0 {
+		t.Errorf("Expected CircuitOpenError with retry hint, got %v", err)
+	}
+
+	// Mode dégradé : les noms connus sont servis sans valeur
+	secrets, err := service.ListProjectSecrets(ctx, "org1", "proj1", "dev")
+	if !errors.Is(err, vault.ErrDegraded) {
+		t.Errorf("Expected ErrDegraded, got %v", err)
+	}
+	if len(secrets) != 1 || secrets[0].Name != "API_KEY" || secrets[0].Value != "" {
+		t.Errorf("Expected cached name without value, got %+v", secrets)
+	}
+
+	// Après le délai, un appel réussi referme le disjoncteur
+	store.FailOn("get", nil)
+	store.FailOn("list", nil)
+	time.Sleep(60 * time.Millisecond)
+
+	if _, err := service.GetSecret(ctx, "org1", "proj1", "dev", "API_KEY"); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if breaker.State() != vault.CircuitClosed {
+		t.Errorf("Expected closed circuit, got state %d", breaker.State())
+	}
+}
+
+func TestBreakerIgnoresNotFound(t *testing.T) {
+	breaker := vault.NewBreaker(vault.NewMemoryStore(), vault.BreakerConfig{Threshold: 1, Cooldown: time.Minute})
+
+	for i := 0; i < 3; i++ {
+		if _, err := breaker.GetSecret(context.Background(), "org1/proj1/dev/MISSING"); !errors.Is(err, vault.ErrSecretNotFound) {
+			t.Fatalf("Expected ErrSecretNotFound, got %v", err)
+		}
+	}
+	if breaker.State() != vault.CircuitClosed {
+		t.Errorf("Expected closed circuit, got state %d", breaker.State())
+	}
+}
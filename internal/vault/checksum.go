@@ -0,0 +1,74 @@
+// filepath: internal/vault/checksum.go
+
+package vault
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	"secrets-manager/internal/models"
+)
+
+// Checksummer calcule la somme de contrôle déterministe de la valeur d'un
+// secret : HMAC-SHA256 du chemin et de la valeur, avec une clé propre au
+// serveur. La même valeur donne toujours la même somme pour un chemin donné,
+// ce qui permet aux agents et à Terraform de détecter une dérive sans
+// télécharger la valeur ; sans la clé, la somme ne permet pas de tester des
+// valeurs candidates, et le chemin empêche de repérer deux secrets qui
+// partagent la même valeur.
+//
+// Les sommes ont la forme "hmac-sha256:<empreinte de la clé>:<hmac>" : après
+// un changement de clé, les sommes enregistrées avec l'ancienne sont
+// reconnues périmées (Current) et recalculées.
+type Checksummer struct {
+	key    []byte
+	prefix string
+}
+
+// NewChecksummer crée un calculateur à partir d'une clé d'au moins 32 octets en base64
+func NewChecksummer(key string) (*Checksummer, error) {
+	raw, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		return nil, errors.New("clé de somme de contrôle invalide (base64 attendu)")
+	}
+	if len(raw) < 32 {
+		return nil, errors.New("clé de somme de contrôle trop courte (32 octets minimum)")
+	}
+	fingerprint := sha256.Sum256(raw)
+	return &Checksummer{
+		key:    raw,
+		prefix: "hmac-sha256:" + hex.EncodeToString(fingerprint[:4]) + ":",
+	}, nil
+}
+
+// Sum renvoie la somme de contrôle de la valeur du secret
+func (c *Checksummer) Sum(secret *models.Secret) string {
+	mac := hmac.New(sha256.New, c.key)
+	mac.Write([]byte(buildSecretPath(secret.OrganizationID, secret.ProjectID, secret.Environment, secret.Name)))
+	mac.Write([]byte{0})
+	mac.Write([]byte(secret.Value))
+	return c.prefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Fingerprint renvoie l'empreinte d'une valeur dans une organisation :
+// contrairement à Sum, elle ne dépend pas du chemin, si bien que deux
+// secrets de l'organisation partageant la même valeur ont la même
+// empreinte. Elle n'est jamais exposée par l'API.
+func (c *Checksummer) Fingerprint(orgID, value string) string {
+	mac := hmac.New(sha256.New, c.key)
+	mac.Write([]byte("fingerprint"))
+	mac.Write([]byte{0})
+	mac.Write([]byte(orgID))
+	mac.Write([]byte{0})
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Current indique si une somme enregistrée a été calculée avec la clé actuelle
+func (c *Checksummer) Current(checksum string) bool {
+	return strings.HasPrefix(checksum, c.prefix)
+}
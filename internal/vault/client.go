@@ -4,11 +4,22 @@ package vault
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net"
+	"net/http"
+	"time"
 
 	vault "github.com/hashicorp/vault/api"
+
+	"secrets-manager/internal/logging"
 )
 
+var logger = logging.For(logging.ComponentVault)
+
+// KVMount est le point de montage KV v2 qui contient les secrets
+const KVMount = "secret"
+
 // Client encapsule l'interaction avec Vault
 type Client struct {
 	client *vault.Client
@@ -20,13 +31,22 @@ type Config struct {
 	Address   string
 	Token     string
 	Namespace string
+	// Timeout borne chaque appel à Vault (60s par défaut côté client Vault)
+	Timeout time.Duration
 	// Autres paramètres de configuration
 }
 
 // NewClient crée un nouveau client Vault
 func NewClient(config *Config) (*Client, error) {
+	if config.Address == "" {
+		return nil, fmt.Errorf("adresse Vault requise")
+	}
+
 	cfg := vault.DefaultConfig()
 	cfg.Address = config.Address
+	if config.Timeout > 0 {
+		cfg.Timeout = config.Timeout
+	}
 
 	client, err := vault.NewClient(cfg)
 	if err != nil {
@@ -46,13 +66,38 @@ func NewClient(config *Config) (*Client, error) {
 
 // GetSecret récupère un secret de Vault
 func (c *Client) GetSecret(ctx context.Context, path string) (map[string]interface{}, error) {
-	secret, err := c.client.KVv2("secret").Get(ctx, path)
+	logger.Debug("lecture du secret", "path", path)
+	secret, err := c.client.KVv2(KVMount).Get(ctx, path)
 	if err != nil {
-		return nil, fmt.Errorf("impossible de récupérer le secret: %w", err)
+		if errors.Is(err, vault.ErrSecretNotFound) {
+			return nil, fmt.Errorf("%w: %s", ErrSecretNotFound, path)
+		}
+		logger.Warn("échec de lecture du secret", "path", path, "error", err)
+		return nil, fmt.Errorf("impossible de récupérer le secret: %w", classifyError(err))
 	}
 
 	if secret == nil {
-		return nil, fmt.Errorf("secret non trouvé: %s", path)
+		return nil, fmt.Errorf("%w: %s", ErrSecretNotFound, path)
+	}
+
+	return secret.Data, nil
+}
+
+// GetSecretVersion récupère une version précise d'un secret de Vault
+func (c *Client) GetSecretVersion(ctx context.Context, path string, version int) (map[string]interface{}, error) {
+	logger.Debug("lecture d'une version du secret", "path", path, "version", version)
+	secret, err := c.client.KVv2(KVMount).GetVersion(ctx, path, version)
+	if err != nil {
+		if errors.Is(err, vault.ErrSecretNotFound) {
+			return nil, fmt.Errorf("%w: %s (version %d)", ErrSecretNotFound, path, version)
+		}
+		logger.Warn("échec de lecture d'une version du secret", "path", path, "version", version, "error", err)
+		return nil, fmt.Errorf("impossible de récupérer la version du secret: %w", classifyError(err))
+	}
+
+	// Une version supprimée ou détruite n'a plus de données
+	if secret == nil || secret.Data == nil {
+		return nil, fmt.Errorf("%w: %s (version %d)", ErrSecretNotFound, path, version)
 	}
 
 	return secret.Data, nil
@@ -60,9 +105,11 @@ func (c *Client) GetSecret(ctx context.Context, path string) (map[string]interfa
 
 // WriteSecret écrit un secret dans Vault
 func (c *Client) WriteSecret(ctx context.Context, path string, data map[string]interface{}) error {
-	_, err := c.client.KVv2("secret").Put(ctx, path, data)
+	logger.Debug("écriture du secret", "path", path)
+	_, err := c.client.KVv2(KVMount).Put(ctx, path, data)
 	if err != nil {
-		return fmt.Errorf("impossible d'écrire le secret: %w", err)
+		logger.Warn("échec d'écriture du secret", "path", path, "error", err)
+		return fmt.Errorf("impossible d'écrire le secret: %w", classifyError(err))
 	}
 
 	return nil
@@ -70,9 +117,11 @@ func (c *Client) WriteSecret(ctx context.Context, path string, data map[string]i
 
 // DeleteSecret supprime un secret de Vault
 func (c *Client) DeleteSecret(ctx context.Context, path string) error {
-	err := c.client.KVv2("secret").Delete(ctx, path)
+	logger.Debug("suppression du secret", "path", path)
+	err := c.client.KVv2(KVMount).Delete(ctx, path)
 	if err != nil {
-		return fmt.Errorf("impossible de supprimer le secret: %w", err)
+		logger.Warn("échec de suppression du secret", "path", path, "error", err)
+		return fmt.Errorf("impossible de supprimer le secret: %w", classifyError(err))
 	}
 
 	return nil
@@ -82,12 +131,14 @@ func (c *Client) DeleteSecret(ctx context.Context, path string) error {
 // Note: Cette méthode utilise maintenant la méthode List directement du client Vault
 func (c *Client) ListSecrets(ctx context.Context, path string) ([]string, error) {
 	// Construire le chemin complet pour le stockage KV v2
-	fullPath := fmt.Sprintf("secret/metadata/%s", path)
+	fullPath := fmt.Sprintf("%s/metadata/%s", KVMount, path)
 
 	// Appeler l'API List directement
+	logger.Debug("liste des secrets", "path", fullPath)
 	secret, err := c.client.Logical().List(fullPath)
 	if err != nil {
-		return nil, fmt.Errorf("impossible de lister les secrets: %w", err)
+		logger.Warn("échec de la liste des secrets", "path", fullPath, "error", err)
+		return nil, fmt.Errorf("impossible de lister les secrets: %w", classifyError(err))
 	}
 
 	if secret == nil || secret.Data == nil {
@@ -115,3 +166,103 @@ func (c *Client) ListSecrets(ctx context.Context, path string) ([]string, error)
 
 	return result, nil
 }
+
+// classifyError rattache une erreur de Vault à ErrUnavailable (réessayable)
+// ou à ErrUpstream selon le code HTTP renvoyé.
+func classifyError(err error) error {
+	var respErr *vault.ResponseError
+	if errors.As(err, &respErr) {
+		switch respErr.StatusCode {
+		case http.StatusTooManyRequests, http.StatusInternalServerError,
+			http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return fmt.Errorf("%w: %w", ErrUnavailable, err)
+		}
+		return fmt.Errorf("%w: %w", ErrUpstream, err)
+	}
+
+	// Erreur réseau : Vault injoignable
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return fmt.Errorf("%w: %w", ErrUnavailable, err)
+	}
+
+	return fmt.Errorf("%w: %w", ErrUpstream, err)
+}
+
+// ClusterHealth est l'état d'un cluster Vault rapporté par sys/health
+type ClusterHealth struct {
+	Initialized        bool   `json:"initialized"`
+	Sealed             bool   `json:"sealed"`
+	Standby            bool   `json:"standby"`
+	PerformanceStandby bool   `json:"performance_standby"`
+	ClusterName        string `json:"cluster_name,omitempty"`
+	Version            string `json:"version"`
+	// ReplicationPerformanceMode vaut "primary", "secondary" ou "disabled"
+	ReplicationPerformanceMode string `json:"replication_performance_mode"`
+	ReplicationDRMode          string `json:"replication_dr_mode"`
+	// LastWAL est le dernier index WAL appliqué (Vault Enterprise)
+	LastWAL uint64 `json:"last_wal,omitempty"`
+	// ReplicationLagMillis est l'âge du dernier canari reçu du primaire
+	// (réplicas de performance uniquement)
+	ReplicationLagMillis int64 `json:"replication_lag_ms,omitempty"`
+}
+
+// Status renvoie l'état du cluster et de sa réplication
+func (c *Client) Status(ctx context.Context) (*ClusterHealth, error) {
+	health, err := c.client.Sys().HealthWithContext(ctx)
+	if err != nil {
+		return nil, classifyError(err)
+	}
+	return &ClusterHealth{
+		Initialized:                health.Initialized,
+		Sealed:                     health.Sealed,
+		Standby:                    health.Standby,
+		PerformanceStandby:         health.PerformanceStandby,
+		ClusterName:                health.ClusterName,
+		Version:                    health.Version,
+		ReplicationPerformanceMode: health.ReplicationPerformanceMode,
+		ReplicationDRMode:          health.ReplicationDRMode,
+		LastWAL:                    health.LastWAL,
+		ReplicationLagMillis:       health.ReplicationPrimaryCanaryAgeMillis,
+	}, nil
+}
+
+// Health vérifie que Vault est joignable, initialisé et descellé
+func (c *Client) Health(ctx context.Context) error {
+	health, err := c.Status(ctx)
+	if err != nil {
+		return err
+	}
+	return health.Check()
+}
+
+// Check renvoie une erreur si le cluster n'est pas initialisé ou est scellé
+func (h *ClusterHealth) Check() error {
+	if !h.Initialized {
+		return fmt.Errorf("%w: Vault n'est pas initialisé", ErrUnavailable)
+	}
+	if h.Sealed {
+		return fmt.Errorf("%w: Vault est scellé", ErrUnavailable)
+	}
+	return nil
+}
+
+// CheckKVMount vérifie que le point de montage existe et est un KV version 2
+func (c *Client) CheckKVMount(ctx context.Context, mount string) error {
+	secret, err := c.client.Logical().ReadWithContext(ctx, "sys/internal/ui/mounts/"+mount)
+	if err != nil {
+		return classifyError(err)
+	}
+	if secret == nil || secret.Data == nil {
+		return fmt.Errorf("point de montage %s introuvable", mount)
+	}
+
+	if kind, _ := secret.Data["type"].(string); kind != "kv" {
+		return fmt.Errorf("le point de montage %s est de type %q, kv attendu", mount, kind)
+	}
+	options, _ := secret.Data["options"].(map[string]interface{})
+	if version, _ := options["version"].(string); version != "2" {
+		return fmt.Errorf("le point de montage %s n'est pas un KV version 2", mount)
+	}
+	return nil
+}
@@ -0,0 +1,258 @@
+// filepath: internal/vault/clusters.go
+
+package vault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"secrets-manager/internal/metrics"
+)
+
+// Rôles des clusters Vault
+const (
+	ClusterPrimary = "primary"
+	ClusterReplica = "performance_replica"
+)
+
+var (
+	clusterHealthy = metrics.NewGauge("vault_cluster_healthy",
+		"Santé des clusters Vault lors de la dernière vérification (1 sain, 0 indisponible)", "cluster")
+	clusterFallbacks = metrics.NewCounter("vault_cluster_read_fallbacks_total",
+		"Lectures reprises sur le cluster primaire après un échec d'un réplica", "cluster")
+)
+
+// HealthProber interroge l'état d'un cluster Vault (Client l'implémente)
+type HealthProber interface {
+	Status(ctx context.Context) (*ClusterHealth, error)
+}
+
+var _ HealthProber = (*Client)(nil)
+
+// Cluster est un cluster Vault : son stockage de secrets et la sonde de sa santé
+type Cluster struct {
+	Name  string
+	Store SecretStore
+	// Prober interroge la santé du cluster ; nil le considère toujours sain
+	Prober HealthProber
+}
+
+// ClusterStatus est l'état d'un cluster lors de la dernière vérification
+type ClusterStatus struct {
+	Name          string         `json:"name"`
+	Role          string         `json:"role"`
+	Healthy       bool           `json:"healthy"`
+	Error         string         `json:"error,omitempty"`
+	LatencyMillis int64          `json:"latency_ms"`
+	CheckedAt     *time.Time     `json:"checked_at,omitempty"`
+	Health        *ClusterHealth `json:"health,omitempty"`
+}
+
+// clusterState associe un cluster à son dernier état connu
+type clusterState struct {
+	cluster Cluster
+	status  ClusterStatus
+	latency time.Duration
+}
+
+// Clusters est un SecretStore réparti sur un cluster primaire et ses réplicas
+// de performance. Les écritures et suppressions vont toujours au primaire ;
+// les lectures vont au cluster sain le plus proche (plus faible latence
+// mesurée par Check) et sont reprises sur le primaire si le réplica échoue ou
+// n'a pas encore reçu le secret.
+type Clusters struct {
+	mu       sync.RWMutex
+	primary  *clusterState
+	replicas []*clusterState
+	now      func() time.Time
+}
+
+var _ SecretStore = (*Clusters)(nil)
+
+// NewClusters crée un ensemble de clusters. Les réplicas ne reçoivent des
+// lectures qu'après une première vérification réussie.
+func NewClusters(primary Cluster, replicas ...Cluster) *Clusters {
+	c := &Clusters{
+		primary: &clusterState{
+			cluster: primary,
+			status:  ClusterStatus{Name: primary.Name, Role: ClusterPrimary, Healthy: true},
+		},
+		now: time.Now,
+	}
+	for _, replica := range replicas {
+		c.replicas = append(c.replicas, &clusterState{
+			cluster: replica,
+			status:  ClusterStatus{Name: replica.Name, Role: ClusterReplica, Error: "non vérifié"},
+		})
+	}
+	return c
+}
+
+// Check vérifie la santé et la latence de chaque cluster. Un réplica n'est
+// sain que s'il est descellé et reçoit la réplication de performance.
+func (c *Clusters) Check(ctx context.Context) error {
+	states := append([]*clusterState{c.primary}, c.replicas...)
+
+	var wg sync.WaitGroup
+	for _, state := range states {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.check(ctx, state)
+		}()
+	}
+	wg.Wait()
+	return nil
+}
+
+// check vérifie un cluster et met à jour son état
+func (c *Clusters) check(ctx context.Context, state *clusterState) {
+	if state.cluster.Prober == nil {
+		return
+	}
+
+	start := c.now()
+	health, err := state.cluster.Prober.Status(ctx)
+	latency := c.now().Sub(start)
+	if err == nil {
+		err = health.Check()
+	}
+	if err == nil && state.status.Role == ClusterReplica && health.ReplicationPerformanceMode != "secondary" {
+		err = fmt.Errorf("réplication de performance inactive (mode %q)", health.ReplicationPerformanceMode)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	checkedAt := c.now()
+	wasHealthy := state.status.Healthy
+	state.latency = latency
+	state.status.LatencyMillis = latency.Milliseconds()
+	state.status.CheckedAt = &checkedAt
+	state.status.Health = health
+	state.status.Healthy = err == nil
+	state.status.Error = ""
+	if err != nil {
+		state.status.Error = err.Error()
+	}
+
+	if wasHealthy && err != nil {
+		logger.Warn("cluster Vault indisponible", "cluster", state.cluster.Name, "error", err)
+	} else if !wasHealthy && err == nil {
+		logger.Info("cluster Vault disponible", "cluster", state.cluster.Name)
+	}
+	healthy := 0.0
+	if err == nil {
+		healthy = 1
+	}
+	clusterHealthy.Set(healthy, state.cluster.Name)
+}
+
+// Statuses renvoie l'état de chaque cluster, primaire en tête
+func (c *Clusters) Statuses() []ClusterStatus {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	statuses := []ClusterStatus{c.primary.status}
+	for _, replica := range c.replicas {
+		statuses = append(statuses, replica.status)
+	}
+	return statuses
+}
+
+// nearest renvoie le cluster sain de plus faible latence, le primaire à défaut
+func (c *Clusters) nearest() *clusterState {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	candidates := make([]*clusterState, 0, len(c.replicas)+1)
+	for _, state := range append([]*clusterState{c.primary}, c.replicas...) {
+		if state.status.Healthy {
+			candidates = append(candidates, state)
+		}
+	}
+	if len(candidates) == 0 {
+		return c.primary
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].latency < candidates[j].latency
+	})
+	return candidates[0]
+}
+
+// markUnhealthy écarte un réplica des lectures jusqu'à la prochaine vérification réussie
+func (c *Clusters) markUnhealthy(state *clusterState, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if state.status.Healthy {
+		logger.Warn("réplica Vault écarté des lectures", "cluster", state.cluster.Name, "error", err)
+	}
+	state.status.Healthy = false
+	state.status.Error = err.Error()
+	clusterHealthy.Set(0, state.cluster.Name)
+}
+
+// read exécute une lecture sur le cluster le plus proche, puis sur le
+// primaire si un réplica est indisponible ou ne connaît pas encore le secret
+// (retard de réplication)
+func (c *Clusters) read(fn func(store SecretStore) error) error {
+	state := c.nearest()
+	err := fn(state.cluster.Store)
+	if err == nil || state == c.primary {
+		return err
+	}
+	if !errors.Is(err, ErrSecretNotFound) && !errors.Is(err, ErrUnavailable) {
+		return err
+	}
+
+	if errors.Is(err, ErrUnavailable) {
+		c.markUnhealthy(state, err)
+	}
+	clusterFallbacks.Inc(state.cluster.Name)
+	return fn(c.primary.cluster.Store)
+}
+
+// GetSecret lit un secret sur le cluster le plus proche
+func (c *Clusters) GetSecret(ctx context.Context, path string) (map[string]interface{}, error) {
+	var data map[string]interface{}
+	err := c.read(func(store SecretStore) (err error) {
+		data, err = store.GetSecret(ctx, path)
+		return err
+	})
+	return data, err
+}
+
+// GetSecretVersion lit une version d'un secret sur le cluster le plus proche
+func (c *Clusters) GetSecretVersion(ctx context.Context, path string, version int) (map[string]interface{}, error) {
+	var data map[string]interface{}
+	err := c.read(func(store SecretStore) (err error) {
+		data, err = store.GetSecretVersion(ctx, path, version)
+		return err
+	})
+	return data, err
+}
+
+// ListSecrets liste les clés d'un chemin sur le cluster le plus proche
+func (c *Clusters) ListSecrets(ctx context.Context, path string) ([]string, error) {
+	var keys []string
+	err := c.read(func(store SecretStore) (err error) {
+		keys, err = store.ListSecrets(ctx, path)
+		return err
+	})
+	return keys, err
+}
+
+// WriteSecret écrit un secret sur le cluster primaire
+func (c *Clusters) WriteSecret(ctx context.Context, path string, data map[string]interface{}) error {
+	return c.primary.cluster.Store.WriteSecret(ctx, path, data)
+}
+
+// DeleteSecret supprime un secret sur le cluster primaire
+func (c *Clusters) DeleteSecret(ctx context.Context, path string) error {
+	return c.primary.cluster.Store.DeleteSecret(ctx, path)
+}
@@ -0,0 +1,82 @@
+// filepath: internal/vault/clusters_test.go
+
+package vault_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"secrets-manager/internal/vault"
+)
+
+// fakeProber simule sys/health d'un cluster
+type fakeProber struct {
+	health *vault.ClusterHealth
+	delay  time.Duration
+	err    error
+}
+
+func (p *fakeProber) Status(ctx context.Context) (*vault.ClusterHealth, error) {
+	time.Sleep(p.delay)
+	return p.health, p.err
+}
+
+func TestClustersRouteReadsToNearestHealthyReplica(t *testing.T) {
+	ctx := context.Background()
+	primary, replica := vault.NewMemoryStore(), vault.NewMemoryStore()
+	replicaProber := &fakeProber{health: &vault.ClusterHealth{Initialized: true, ReplicationPerformanceMode: "secondary"}}
+	clusters := vault.NewClusters(
+		vault.Cluster{Name: "paris", Store: primary, Prober: &fakeProber{
+			health: &vault.ClusterHealth{Initialized: true, ReplicationPerformanceMode: "primary"},
+			delay:  20 * time.Millisecond,
+		}},
+		vault.Cluster{Name: "lyon", Store: replica, Prober: replicaProber},
+	)
+
+	// Les écritures vont au primaire
+	if err := clusters.WriteSecret(ctx, "org1/p/dev/A", map[string]interface{}{"value": "primary"}); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if _, err := replica.GetSecret(ctx, "org1/p/dev/A"); !errors.Is(err, vault.ErrSecretNotFound) {
+		t.Fatalf("Expected write on the primary only, got %v", err)
+	}
+
+	// Réplica non vérifié : lecture sur le primaire
+	if data, err := clusters.GetSecret(ctx, "org1/p/dev/A"); err != nil || data["value"] != "primary" {
+		t.Fatalf("Expected primary value, got %v (%v)", data, err)
+	}
+
+	if err := clusters.Check(ctx); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if err := replica.WriteSecret(ctx, "org1/p/dev/A", map[string]interface{}{"value": "replica"}); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if data, _ := clusters.GetSecret(ctx, "org1/p/dev/A"); data["value"] != "replica" {
+		t.Errorf("Expected read on the nearest replica, got %v", data)
+	}
+
+	// Retard de réplication : le secret absent du réplica est lu sur le primaire
+	if err := clusters.WriteSecret(ctx, "org1/p/dev/B", map[string]interface{}{"value": "new"}); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if data, err := clusters.GetSecret(ctx, "org1/p/dev/B"); err != nil || data["value"] != "new" {
+		t.Errorf("Expected fallback to the primary, got %v (%v)", data, err)
+	}
+
+	// Réplica scellé : écarté des lectures et signalé dans les statuts
+	replicaProber.health = &vault.ClusterHealth{Initialized: true, Sealed: true, ReplicationPerformanceMode: "secondary"}
+	if err := clusters.Check(ctx); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if data, _ := clusters.GetSecret(ctx, "org1/p/dev/A"); data["value"] != "primary" {
+		t.Errorf("Expected read on the primary, got %v", data)
+	}
+	statuses := clusters.Statuses()
+	if len(statuses) != 2 || !statuses[0].Healthy || statuses[0].Role != vault.ClusterPrimary ||
+		statuses[1].Healthy || statuses[1].Error == "" {
+		t.Errorf("Unexpected statuses: %+v", statuses)
+	}
+}
@@ -0,0 +1,99 @@
+// filepath: internal/vault/diff.go
+
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+
+	"secrets-manager/internal/models"
+)
+
+// DiffSecretVersions compare deux versions d'un secret. Les valeurs qui sont
+// des objets JSON sont comparées champ par champ (les champs imbriqués sont
+// nommés "parent.enfant") ; les autres sont seulement marquées modifiées ou non.
+func (s *Service) DiffSecretVersions(ctx context.Context, orgID, projectID, env, name string, from, to int) (*models.SecretVersionDiff, error) {
+	path := buildSecretPath(orgID, projectID, env, name)
+
+	fromData, err := s.client.GetSecretVersion(ctx, path, from)
+	if err != nil {
+		return nil, err
+	}
+	toData, err := s.client.GetSecretVersion(ctx, path, to)
+	if err != nil {
+		return nil, err
+	}
+
+	fromValue, _ := fromData["value"].(string)
+	toValue, _ := toData["value"].(string)
+	fromDesc, _ := fromData["description"].(string)
+	toDesc, _ := toData["description"].(string)
+
+	diff := &models.SecretVersionDiff{
+		Name:               name,
+		FromVersion:        from,
+		ToVersion:          to,
+		Changed:            fromValue != toValue,
+		DescriptionChanged: fromDesc != toDesc,
+	}
+
+	fromFields, fromOK := parseStructured(fromValue)
+	toFields, toOK := parseStructured(toValue)
+	if fromOK && toOK {
+		diff.Structured = true
+		diff.Fields = diffFields("", fromFields, toFields)
+		diff.Changed = len(diff.Fields) > 0
+	}
+
+	return diff, nil
+}
+
+// parseStructured décode une valeur de secret si c'est un objet JSON
+func parseStructured(value string) (map[string]interface{}, bool) {
+	if !strings.HasPrefix(strings.TrimSpace(value), "{") {
+		return nil, false
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal([]byte(value), &fields); err != nil {
+		return nil, false
+	}
+	return fields, true
+}
+
+// diffFields compare récursivement deux objets et renvoie les champs modifiés triés
+func diffFields(prefix string, from, to map[string]interface{}) []*models.SecretFieldChange {
+	var changes []*models.SecretFieldChange
+
+	for key, before := range from {
+		field := prefix + key
+		after, ok := to[key]
+		if !ok {
+			changes = append(changes, &models.SecretFieldChange{Field: field, Change: models.FieldRemoved})
+			continue
+		}
+
+		beforeObj, beforeIsObj := before.(map[string]interface{})
+		afterObj, afterIsObj := after.(map[string]interface{})
+		if beforeIsObj && afterIsObj {
+			changes = append(changes, diffFields(field+".", beforeObj, afterObj)...)
+			continue
+		}
+
+		if !reflect.DeepEqual(before, after) {
+			changes = append(changes, &models.SecretFieldChange{Field: field, Change: models.FieldChanged})
+		}
+	}
+
+	for key := range to {
+		if _, ok := from[key]; !ok {
+			changes = append(changes, &models.SecretFieldChange{Field: prefix + key, Change: models.FieldAdded})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Field < changes[j].Field })
+	return changes
+}
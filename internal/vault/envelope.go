@@ -0,0 +1,69 @@
+// filepath: internal/vault/envelope.go
+
+package vault
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+)
+
+// ErrInvalidEnvelope indique une enveloppe altérée, tronquée ou scellée
+// pour un autre contexte
+var ErrInvalidEnvelope = errors.New("enveloppe invalide")
+
+// SealEnvelope chiffre plaintext (AES-256-GCM) avec une nouvelle clé de
+// données générée par la clé transit keyName. Seule la clé enveloppée est
+// renvoyée avec le chiffré (nonce en tête) ; aad lie l'enveloppe à son
+// contexte, elle ne s'ouvre pas avec un autre.
+func SealEnvelope(ctx context.Context, transit Transit, keyName string, plaintext, aad []byte) (wrapped string, ciphertext []byte, err error) {
+	dataKey, wrapped, err := transit.GenerateDataKey(ctx, keyName)
+	if err != nil {
+		return "", nil, err
+	}
+	defer clear(dataKey)
+
+	gcm, err := newGCM(dataKey)
+	if err != nil {
+		return "", nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize(), gcm.NonceSize()+len(plaintext)+gcm.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", nil, err
+	}
+	return wrapped, gcm.Seal(nonce, nonce, plaintext, aad), nil
+}
+
+// OpenEnvelope déchiffre une enveloppe produite par SealEnvelope avec la
+// même clé transit et le même aad
+func OpenEnvelope(ctx context.Context, transit Transit, keyName, wrapped string, ciphertext, aad []byte) ([]byte, error) {
+	dataKey, err := transit.DecryptDataKey(ctx, keyName, wrapped)
+	if err != nil {
+		return nil, err
+	}
+	defer clear(dataKey)
+
+	gcm, err := newGCM(dataKey)
+	if err != nil {
+		return nil, ErrInvalidEnvelope
+	}
+	if len(ciphertext) < gcm.NonceSize() {
+		return nil, ErrInvalidEnvelope
+	}
+	nonce, sealed := ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, sealed, aad)
+	if err != nil {
+		return nil, ErrInvalidEnvelope
+	}
+	return plaintext, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,151 @@
+// filepath: internal/vault/memory_store.go
+
+package vault
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// MemoryStore est une implémentation en mémoire de SecretStore.
+// Elle reproduit le comportement de KV v2 (versions, listes par dossier)
+// et permet d'écrire des tests sans Vault.
+type MemoryStore struct {
+	mu       sync.RWMutex
+	secrets  map[string][]map[string]interface{}
+	failures map[string]error
+}
+
+var _ SecretStore = (*MemoryStore)(nil)
+
+// NewMemoryStore crée un stockage de secrets en mémoire vide
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		secrets:  make(map[string][]map[string]interface{}),
+		failures: make(map[string]error),
+	}
+}
+
+// GetSecret récupère la dernière version d'un secret
+func (m *MemoryStore) GetSecret(ctx context.Context, path string) (map[string]interface{}, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if err := m.failures["get"]; err != nil {
+		return nil, err
+	}
+
+	versions, ok := m.secrets[path]
+	if !ok || len(versions) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrSecretNotFound, path)
+	}
+
+	return copyData(versions[len(versions)-1]), nil
+}
+
+// GetSecretVersion récupère une version précise d'un secret (à partir de 1)
+func (m *MemoryStore) GetSecretVersion(ctx context.Context, path string, version int) (map[string]interface{}, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if err := m.failures["get"]; err != nil {
+		return nil, err
+	}
+
+	versions := m.secrets[path]
+	if version < 1 || version > len(versions) {
+		return nil, fmt.Errorf("%w: %s (version %d)", ErrSecretNotFound, path, version)
+	}
+
+	return copyData(versions[version-1]), nil
+}
+
+// WriteSecret ajoute une nouvelle version d'un secret
+func (m *MemoryStore) WriteSecret(ctx context.Context, path string, data map[string]interface{}) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.failures["write"]; err != nil {
+		return err
+	}
+
+	m.secrets[path] = append(m.secrets[path], copyData(data))
+	return nil
+}
+
+// DeleteSecret supprime toutes les versions d'un secret
+func (m *MemoryStore) DeleteSecret(ctx context.Context, path string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.failures["delete"]; err != nil {
+		return err
+	}
+
+	delete(m.secrets, path)
+	return nil
+}
+
+// ListSecrets liste les clés directement sous un chemin
+func (m *MemoryStore) ListSecrets(ctx context.Context, path string) ([]string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if err := m.failures["list"]; err != nil {
+		return nil, err
+	}
+
+	prefix := strings.TrimSuffix(path, "/") + "/"
+	seen := make(map[string]bool)
+	for key := range m.secrets {
+		rest, ok := strings.CutPrefix(key, prefix)
+		if !ok {
+			continue
+		}
+		if i := strings.Index(rest, "/"); i >= 0 {
+			rest = rest[:i+1]
+		}
+		seen[rest] = true
+	}
+
+	keys := make([]string, 0, len(seen))
+	for key := range seen {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys, nil
+}
+
+// Versions renvoie le nombre de versions écrites pour un chemin
+func (m *MemoryStore) Versions(path string) int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return len(m.secrets[path])
+}
+
+// FailOn force l'opération donnée (get, write, delete, list) à renvoyer err.
+// Un err nil rétablit le fonctionnement normal.
+func (m *MemoryStore) FailOn(operation string, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err == nil {
+		delete(m.failures, operation)
+		return
+	}
+	m.failures[operation] = err
+}
+
+// copyData copie superficiellement les données d'un secret
+func copyData(data map[string]interface{}) map[string]interface{} {
+	copied := make(map[string]interface{}, len(data))
+	for k, v := range data {
+		copied[k] = v
+	}
+	return copied
+}
@@ -0,0 +1,95 @@
+// filepath: internal/vault/memory_transit.go
+
+package vault
+
+import (
+	"context"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Préfixe des clés enveloppées par MemoryTransit, comme "vault:v1:" pour Vault
+const memoryWrapPrefix = "memory:v1:"
+
+// MemoryTransit est une implémentation en mémoire de Transit pour les
+// tests. Les clés de chiffrement sont aléatoires et perdues à l'arrêt.
+type MemoryTransit struct {
+	mu   sync.RWMutex
+	keys map[string][]byte
+}
+
+var _ Transit = (*MemoryTransit)(nil)
+
+// NewMemoryTransit crée un moteur transit en mémoire sans clé
+func NewMemoryTransit() *MemoryTransit {
+	return &MemoryTransit{keys: make(map[string][]byte)}
+}
+
+// CreateTransitKey crée la clé name si elle n'existe pas
+func (m *MemoryTransit) CreateTransitKey(ctx context.Context, name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.keys[name]; ok {
+		return nil
+	}
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return err
+	}
+	m.keys[name] = key
+	return nil
+}
+
+// GenerateDataKey génère une clé de données enveloppée par la clé name
+func (m *MemoryTransit) GenerateDataKey(ctx context.Context, name string) ([]byte, string, error) {
+	gcm, err := m.keyGCM(name)
+	if err != nil {
+		return nil, "", err
+	}
+	plaintext := make([]byte, 32)
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(plaintext); err != nil {
+		return nil, "", err
+	}
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, "", err
+	}
+	wrapped := memoryWrapPrefix + base64.StdEncoding.EncodeToString(gcm.Seal(nonce, nonce, plaintext, nil))
+	return plaintext, wrapped, nil
+}
+
+// DecryptDataKey déchiffre une clé de données enveloppée par la clé name
+func (m *MemoryTransit) DecryptDataKey(ctx context.Context, name, wrapped string) ([]byte, error) {
+	gcm, err := m.keyGCM(name)
+	if err != nil {
+		return nil, err
+	}
+	encoded, ok := strings.CutPrefix(wrapped, memoryWrapPrefix)
+	if !ok {
+		return nil, fmt.Errorf("%w: clé enveloppée invalide", ErrUpstream)
+	}
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(raw) < gcm.NonceSize() {
+		return nil, fmt.Errorf("%w: clé enveloppée invalide", ErrUpstream)
+	}
+	plaintext, err := gcm.Open(nil, raw[:gcm.NonceSize()], raw[gcm.NonceSize():], nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: déchiffrement impossible", ErrUpstream)
+	}
+	return plaintext, nil
+}
+
+func (m *MemoryTransit) keyGCM(name string) (cipher.AEAD, error) {
+	m.mu.RLock()
+	key, ok := m.keys[name]
+	m.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: clé transit %s inconnue", ErrUpstream, name)
+	}
+	return newGCM(key)
+}
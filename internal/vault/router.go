@@ -0,0 +1,123 @@
+// filepath: internal/vault/router.go
+
+package vault
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"secrets-manager/internal/models"
+)
+
+// ErrRegionUnavailable indique que la région de résidence d'une organisation
+// n'a pas de backend configuré. Les secrets ne sont jamais lus ni écrits
+// ailleurs que dans leur région.
+var ErrRegionUnavailable = fmt.Errorf("%w: région de résidence non configurée", ErrUnavailable)
+
+// RegionResolver renvoie la région de résidence d'une organisation
+type RegionResolver func(ctx context.Context, orgID string) (string, error)
+
+// Router est un SecretStore qui envoie chaque appel vers le backend de la
+// région de résidence de l'organisation, désignée par le premier segment du
+// chemin (orgID/projet/env/nom).
+type Router struct {
+	stores  map[string]SecretStore
+	resolve RegionResolver
+}
+
+var _ SecretStore = (*Router)(nil)
+
+// NewRouter crée un routeur entre les backends de chaque région. stores doit
+// contenir le backend principal sous models.DefaultRegion.
+func NewRouter(stores map[string]SecretStore, resolve RegionResolver) *Router {
+	return &Router{stores: stores, resolve: resolve}
+}
+
+// Regions liste les régions configurées, triées
+func (r *Router) Regions() []string {
+	regions := make([]string, 0, len(r.stores))
+	for region := range r.stores {
+		regions = append(regions, region)
+	}
+	sort.Strings(regions)
+	return regions
+}
+
+// HasRegion indique si la région a un backend configuré
+func (r *Router) HasRegion(region string) bool {
+	_, ok := r.stores[region]
+	return ok
+}
+
+// GetSecret récupère un secret dans la région de son organisation
+func (r *Router) GetSecret(ctx context.Context, path string) (map[string]interface{}, error) {
+	store, err := r.store(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	return store.GetSecret(ctx, path)
+}
+
+// GetSecretVersion récupère une version d'un secret dans la région de son organisation
+func (r *Router) GetSecretVersion(ctx context.Context, path string, version int) (map[string]interface{}, error) {
+	store, err := r.store(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	return store.GetSecretVersion(ctx, path, version)
+}
+
+// WriteSecret écrit un secret dans la région de son organisation
+func (r *Router) WriteSecret(ctx context.Context, path string, data map[string]interface{}) error {
+	store, err := r.store(ctx, path)
+	if err != nil {
+		return err
+	}
+	return store.WriteSecret(ctx, path, data)
+}
+
+// DeleteSecret supprime un secret dans la région de son organisation
+func (r *Router) DeleteSecret(ctx context.Context, path string) error {
+	store, err := r.store(ctx, path)
+	if err != nil {
+		return err
+	}
+	return store.DeleteSecret(ctx, path)
+}
+
+// ListSecrets liste les clés d'un chemin dans la région de son organisation
+func (r *Router) ListSecrets(ctx context.Context, path string) ([]string, error) {
+	store, err := r.store(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	return store.ListSecrets(ctx, path)
+}
+
+// store renvoie le backend de la région de l'organisation du chemin
+func (r *Router) store(ctx context.Context, path string) (SecretStore, error) {
+	// Sans région supplémentaire, inutile de consulter la base
+	if len(r.stores) == 1 {
+		if store, ok := r.stores[models.DefaultRegion]; ok {
+			return store, nil
+		}
+	}
+
+	orgID, _, _ := strings.Cut(strings.Trim(path, "/"), "/")
+	if orgID == "" {
+		return nil, fmt.Errorf("%w: chemin sans organisation: %q", ErrUpstream, path)
+	}
+
+	region, err := r.resolve(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("impossible de déterminer la région de l'organisation %s: %w", orgID, err)
+	}
+
+	store, ok := r.stores[region]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrRegionUnavailable, region)
+	}
+	return store, nil
+}
@@ -0,0 +1,57 @@
+// filepath: internal/vault/router_test.go
+
+package vault_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/vault"
+)
+
+func TestRouterPinsSecretsToRegion(t *testing.T) {
+	ctx := context.Background()
+	primary, eu := vault.NewMemoryStore(), vault.NewMemoryStore()
+	regions := map[string]string{"org-eu": "eu", "org-us": "us"}
+	router := vault.NewRouter(map[string]vault.SecretStore{
+		models.DefaultRegion: primary,
+		"eu":                 eu,
+	}, func(ctx context.Context, orgID string) (string, error) {
+		if region, ok := regions[orgID]; ok {
+			return region, nil
+		}
+		return models.DefaultRegion, nil
+	})
+	service := vault.NewService(router)
+
+	for _, orgID := range []string{"org-eu", "org-default"} {
+		if err := service.StoreSecret(ctx, &models.Secret{
+			Name: "API_KEY", Value: "abc", OrganizationID: orgID, ProjectID: "proj1", Environment: "dev",
+		}); err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+	}
+
+	if _, err := eu.GetSecret(ctx, "org-eu/proj1/dev/API_KEY"); err != nil {
+		t.Errorf("Expected secret in the eu backend, got %v", err)
+	}
+	if _, err := primary.GetSecret(ctx, "org-eu/proj1/dev/API_KEY"); !errors.Is(err, vault.ErrSecretNotFound) {
+		t.Errorf("Expected no copy in the primary backend, got %v", err)
+	}
+	if _, err := primary.GetSecret(ctx, "org-default/proj1/dev/API_KEY"); err != nil {
+		t.Errorf("Expected secret in the primary backend, got %v", err)
+	}
+
+	// Une région sans backend ne retombe jamais sur le backend principal
+	err := service.StoreSecret(ctx, &models.Secret{
+		Name: "API_KEY", Value: "abc", OrganizationID: "org-us", ProjectID: "proj1", Environment: "dev",
+	})
+	if !errors.Is(err, vault.ErrRegionUnavailable) {
+		t.Errorf("Expected ErrRegionUnavailable, got %v", err)
+	}
+	if keys, _ := primary.ListSecrets(ctx, "org-us/proj1/dev"); len(keys) != 0 {
+		t.Errorf("Expected nothing written for org-us, got %v", keys)
+	}
+}
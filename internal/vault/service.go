@@ -4,7 +4,9 @@ package vault
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"secrets-manager/internal/models"
@@ -12,27 +14,84 @@ import (
 
 // Service fournit une abstraction de haut niveau pour interagir avec Vault
 type Service struct {
-	client *Client
+	client SecretStore
 }
 
 // NewService crée un nouveau service Vault
-func NewService(client *Client) *Service {
+func NewService(client SecretStore) *Service {
 	return &Service{
 		client: client,
 	}
 }
 
+// scheduledFolder contient, dans chaque projet, les valeurs planifiées en
+// attente de promotion (voir StoreScheduledValue)
+const scheduledFolder = ".scheduled"
+
+// ageKeysFolder contient, dans chaque projet, les identités age (sops) des
+// dépôts de code (voir StoreAgeIdentity)
+const ageKeysFolder = ".age-keys"
+
 // StoreSecret stocke un secret dans Vault avec métadonnées
 func (s *Service) StoreSecret(ctx context.Context, secret *models.Secret) error {
 	// Construire le chemin basé sur org/projet/env
 	path := buildSecretPath(secret.OrganizationID, secret.ProjectID, secret.Environment, secret.Name)
+	return s.writeSecret(ctx, path, secret)
+}
+
+// StoreScheduledValue stocke la valeur planifiée d'un secret, à l'écart des
+// secrets de l'environnement : elle n'est ni lue ni listée avant sa promotion
+func (s *Service) StoreScheduledValue(ctx context.Context, secret *models.Secret) error {
+	path := buildScheduledPath(secret.OrganizationID, secret.ProjectID, secret.Environment, secret.Name)
+	return s.writeSecret(ctx, path, secret)
+}
+
+// GetScheduledValue récupère la valeur planifiée d'un secret
+func (s *Service) GetScheduledValue(ctx context.Context, orgID, projectID, env, name string) (*models.Secret, error) {
+	return s.readSecret(ctx, buildScheduledPath(orgID, projectID, env, name), orgID, projectID, env, name)
+}
 
+// DeleteScheduledValue supprime la valeur planifiée d'un secret
+func (s *Service) DeleteScheduledValue(ctx context.Context, orgID, projectID, env, name string) error {
+	return s.client.DeleteSecret(ctx, buildScheduledPath(orgID, projectID, env, name))
+}
+
+// StoreAgeIdentity stocke l'identité age d'une clé de dépôt
+func (s *Service) StoreAgeIdentity(ctx context.Context, key *models.AgeKey, identity string) error {
+	return s.client.WriteSecret(ctx, buildAgeIdentityPath(key), map[string]interface{}{
+		"identity":   identity,
+		"created_at": time.Now().Unix(),
+		"created_by": key.CreatedBy,
+	})
+}
+
+// GetAgeIdentity récupère l'identité age d'une clé de dépôt
+func (s *Service) GetAgeIdentity(ctx context.Context, key *models.AgeKey) (string, error) {
+	data, err := s.client.GetSecret(ctx, buildAgeIdentityPath(key))
+	if err != nil {
+		return "", err
+	}
+	identity, ok := data["identity"].(string)
+	if !ok {
+		return "", fmt.Errorf("%w: identité age absente", ErrUpstream)
+	}
+	return identity, nil
+}
+
+// DeleteAgeIdentity supprime l'identité age d'une clé de dépôt
+func (s *Service) DeleteAgeIdentity(ctx context.Context, key *models.AgeKey) error {
+	return s.client.DeleteSecret(ctx, buildAgeIdentityPath(key))
+}
+
+func (s *Service) writeSecret(ctx context.Context, path string, secret *models.Secret) error {
 	// Préparer les données et métadonnées
 	data := map[string]interface{}{
 		"value":       secret.Value,
 		"created_at":  time.Now().Unix(),
 		"created_by":  secret.CreatedBy,
 		"description": secret.Description,
+		// e2e : la valeur est une enveloppe chiffrée par le client
+		"e2e": secret.E2E,
 	}
 
 	return s.client.WriteSecret(ctx, path, data)
@@ -40,8 +99,10 @@ func (s *Service) StoreSecret(ctx context.Context, secret *models.Secret) error
 
 // GetSecret récupère un secret et le convertit en modèle Secret
 func (s *Service) GetSecret(ctx context.Context, orgID, projectID, env, name string) (*models.Secret, error) {
-	path := buildSecretPath(orgID, projectID, env, name)
+	return s.readSecret(ctx, buildSecretPath(orgID, projectID, env, name), orgID, projectID, env, name)
+}
 
+func (s *Service) readSecret(ctx context.Context, path, orgID, projectID, env, name string) (*models.Secret, error) {
 	data, err := s.client.GetSecret(ctx, path)
 	if err != nil {
 		return nil, err
@@ -67,12 +128,18 @@ func (s *Service) GetSecret(ctx context.Context, orgID, projectID, env, name str
 		secret.CreatedBy = createdBy
 	}
 
+	if e2e, ok := data["e2e"].(bool); ok {
+		secret.E2E = e2e
+	}
+
 	// Autres extractions...
 
 	return secret, nil
 }
 
-// ListProjectSecrets liste tous les secrets d'un projet
+// ListProjectSecrets liste tous les secrets d'un projet.
+// Si Vault est indisponible, les noms connus sont renvoyés sans valeur
+// avec l'erreur ErrDegraded.
 func (s *Service) ListProjectSecrets(ctx context.Context, orgID, projectID, env string) ([]*models.Secret, error) {
 	path := fmt.Sprintf("%s/%s/%s", orgID, projectID, env)
 
@@ -82,14 +149,29 @@ func (s *Service) ListProjectSecrets(ctx context.Context, orgID, projectID, env
 	}
 
 	secrets := make([]*models.Secret, 0, len(keys))
+	degraded := false
 	for _, key := range keys {
 		secret, err := s.GetSecret(ctx, orgID, projectID, env, key)
+		if errors.Is(err, ErrUnavailable) {
+			// Mode dégradé : renvoyer le nom connu sans la valeur
+			degraded = true
+			secrets = append(secrets, &models.Secret{
+				OrganizationID: orgID,
+				ProjectID:      projectID,
+				Environment:    env,
+				Name:           key,
+			})
+			continue
+		}
 		if err != nil {
 			continue // Ignorer les erreurs individuelles
 		}
 		secrets = append(secrets, secret)
 	}
 
+	if degraded {
+		return secrets, ErrDegraded
+	}
 	return secrets, nil
 }
 
@@ -99,7 +181,75 @@ func (s *Service) DeleteSecret(ctx context.Context, orgID, projectID, env, name
 	return s.client.DeleteSecret(ctx, path)
 }
 
+// DeleteProjectSecrets supprime les valeurs de tous les secrets d'un projet,
+// tous environnements confondus, et renvoie le nombre de secrets supprimés
+func (s *Service) DeleteProjectSecrets(ctx context.Context, orgID, projectID string) (int, error) {
+	return s.deleteTree(ctx, fmt.Sprintf("%s/%s", orgID, projectID))
+}
+
+// DeleteOrganizationSecrets supprime tous les secrets d'une organisation dans Vault
+// et renvoie le nombre de secrets supprimés
+func (s *Service) DeleteOrganizationSecrets(ctx context.Context, orgID string) (int, error) {
+	return s.deleteTree(ctx, orgID)
+}
+
+// CountOrganizationSecrets compte les secrets d'une organisation présents
+// dans Vault, sans les valeurs planifiées ni les identités age
+func (s *Service) CountOrganizationSecrets(ctx context.Context, orgID string) (int, error) {
+	count := 0
+	_, err := s.walkTree(ctx, orgID, func(ctx context.Context, path string) error {
+		if !strings.Contains(path, "/"+scheduledFolder+"/") && !strings.Contains(path, "/"+ageKeysFolder+"/") {
+			count++
+		}
+		return nil
+	})
+	return count, err
+}
+
+// deleteTree supprime récursivement les secrets sous un chemin
+func (s *Service) deleteTree(ctx context.Context, path string) (int, error) {
+	return s.walkTree(ctx, path, s.client.DeleteSecret)
+}
+
+// walkTree appelle visit sur chaque secret sous un chemin, récursivement,
+// et renvoie le nombre de secrets visités avec succès
+func (s *Service) walkTree(ctx context.Context, path string, visit func(ctx context.Context, path string) error) (int, error) {
+	keys, err := s.client.ListSecrets(ctx, path)
+	if err != nil {
+		return 0, err
+	}
+
+	visited := 0
+	for _, key := range keys {
+		if folder, ok := strings.CutSuffix(key, "/"); ok {
+			n, err := s.walkTree(ctx, path+"/"+folder, visit)
+			visited += n
+			if err != nil {
+				return visited, err
+			}
+			continue
+		}
+
+		if err := visit(ctx, path+"/"+key); err != nil {
+			return visited, err
+		}
+		visited++
+	}
+
+	return visited, nil
+}
+
 // Fonction utilitaire pour construire le chemin du secret
 func buildSecretPath(orgID, projectID, env, name string) string {
 	return fmt.Sprintf("%s/%s/%s/%s", orgID, projectID, env, name)
 }
+
+// buildScheduledPath construit le chemin de la valeur planifiée d'un secret
+func buildScheduledPath(orgID, projectID, env, name string) string {
+	return fmt.Sprintf("%s/%s/%s/%s/%s", orgID, projectID, scheduledFolder, env, name)
+}
+
+// buildAgeIdentityPath construit le chemin de l'identité d'une clé age
+func buildAgeIdentityPath(key *models.AgeKey) string {
+	return fmt.Sprintf("%s/%s/%s/%s/%s", key.OrganizationID, key.ProjectID, ageKeysFolder, key.Repository, key.ID)
+}
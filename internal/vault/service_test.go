@@ -0,0 +1,129 @@
+// filepath: internal/vault/service_test.go
+
+package vault_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/vault"
+	"secrets-manager/internal/vault/vaulttest"
+)
+
+func TestServiceStoreAndGetSecret(t *testing.T) {
+	h := vaulttest.New(t)
+	h.Seed(&models.Secret{
+		Name:           "DB_PASSWORD",
+		Value:          "s3cr3t",
+		Description:    "Mot de passe MySQL",
+		OrganizationID: "org1",
+		ProjectID:      "proj1",
+		Environment:    "prod",
+		CreatedBy:      "user1",
+	})
+
+	secret, err := h.Service.GetSecret(context.Background(), "org1", "proj1", "prod", "DB_PASSWORD")
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if secret.Value != "s3cr3t" {
+		t.Errorf("Expected value s3cr3t, got %s", secret.Value)
+	}
+	if secret.Description != "Mot de passe MySQL" || secret.CreatedBy != "user1" {
+		t.Errorf("Unexpected metadata: %+v", secret)
+	}
+}
+
+func TestServiceGetMissingSecret(t *testing.T) {
+	h := vaulttest.New(t)
+
+	_, err := h.Service.GetSecret(context.Background(), "org1", "proj1", "prod", "MISSING")
+	if !errors.Is(err, vault.ErrSecretNotFound) {
+		t.Errorf("Expected ErrSecretNotFound, got %v", err)
+	}
+}
+
+func TestServiceListAndDeleteSecrets(t *testing.T) {
+	h := vaulttest.New(t)
+	h.Seed(
+		&models.Secret{Name: "A", Value: "1", OrganizationID: "org1", ProjectID: "proj1", Environment: "dev"},
+		&models.Secret{Name: "B", Value: "2", OrganizationID: "org1", ProjectID: "proj1", Environment: "dev"},
+		&models.Secret{Name: "C", Value: "3", OrganizationID: "org1", ProjectID: "proj1", Environment: "prod"},
+	)
+	ctx := context.Background()
+
+	secrets, err := h.Service.ListProjectSecrets(ctx, "org1", "proj1", "dev")
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if len(secrets) != 2 {
+		t.Fatalf("Expected 2 secrets, got %d", len(secrets))
+	}
+
+	if err := h.Service.DeleteSecret(ctx, "org1", "proj1", "dev", "A"); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+
+	secrets, err = h.Service.ListProjectSecrets(ctx, "org1", "proj1", "dev")
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if len(secrets) != 1 || secrets[0].Name != "B" {
+		t.Errorf("Expected only secret B to remain, got %+v", secrets)
+	}
+}
+
+func TestServiceStoreFailure(t *testing.T) {
+	h := vaulttest.New(t)
+	h.Store.FailOn("write", errors.New("vault scellé"))
+
+	err := h.Service.StoreSecret(context.Background(), &models.Secret{Name: "A", OrganizationID: "o", ProjectID: "p", Environment: "e"})
+	if err == nil {
+		t.Error("Expected error but got none")
+	}
+}
+
+func TestServiceDiffSecretVersions(t *testing.T) {
+	h := vaulttest.New(t)
+	secret := func(value string) *models.Secret {
+		return &models.Secret{Name: "DB", Value: value, OrganizationID: "org1", ProjectID: "proj1", Environment: "prod"}
+	}
+	h.Seed(
+		secret(`{"user":"app","password":"a","tls":{"mode":"require"}}`),
+		secret(`{"user":"app","password":"b","tls":{"mode":"verify-full"},"port":3306}`),
+		secret("plain"),
+		secret("plain"),
+	)
+
+	diff, err := h.Service.DiffSecretVersions(context.Background(), "org1", "proj1", "prod", "DB", 1, 2)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if !diff.Structured || !diff.Changed {
+		t.Errorf("Expected a structured change, got %+v", diff)
+	}
+	expected := []string{"password:changed", "port:added", "tls.mode:changed"}
+	if len(diff.Fields) != len(expected) {
+		t.Fatalf("Expected %d field changes, got %d", len(expected), len(diff.Fields))
+	}
+	for i, change := range diff.Fields {
+		if got := change.Field + ":" + change.Change; got != expected[i] {
+			t.Errorf("Expected %s, got %s", expected[i], got)
+		}
+	}
+
+	diff, err = h.Service.DiffSecretVersions(context.Background(), "org1", "proj1", "prod", "DB", 3, 4)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if diff.Structured || diff.Changed {
+		t.Errorf("Expected an unchanged scalar secret, got %+v", diff)
+	}
+
+	_, err = h.Service.DiffSecretVersions(context.Background(), "org1", "proj1", "prod", "DB", 1, 9)
+	if !errors.Is(err, vault.ErrSecretNotFound) {
+		t.Errorf("Expected ErrSecretNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,42 @@
+// filepath: internal/vault/store.go
+
+package vault
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	// ErrSecretNotFound indique que le secret n'existe pas dans le stockage
+	ErrSecretNotFound = errors.New("secret non trouvé")
+	// ErrUpstream enveloppe les erreurs renvoyées par Vault
+	ErrUpstream = errors.New("erreur de Vault")
+	// ErrUnavailable indique une indisponibilité passagère de Vault (scellé,
+	// surchargé, injoignable) : la requête peut être réessayée
+	ErrUnavailable = errors.New("Vault indisponible")
+	// ErrDegraded accompagne un résultat partiel servi sans Vault
+	ErrDegraded = errors.New("résultat partiel, Vault indisponible")
+)
+
+// SecretStore est le stockage bas niveau des valeurs de secrets.
+// Client l'implémente pour Vault KV v2, MemoryStore pour les tests.
+type SecretStore interface {
+	// GetSecret récupère les données d'un secret (ErrSecretNotFound s'il n'existe pas)
+	GetSecret(ctx context.Context, path string) (map[string]interface{}, error)
+
+	// GetSecretVersion récupère une version précise d'un secret (à partir de 1)
+	GetSecretVersion(ctx context.Context, path string, version int) (map[string]interface{}, error)
+
+	// WriteSecret écrit une nouvelle version d'un secret
+	WriteSecret(ctx context.Context, path string, data map[string]interface{}) error
+
+	// DeleteSecret supprime un secret
+	DeleteSecret(ctx context.Context, path string) error
+
+	// ListSecrets liste les clés directement sous un chemin
+	// (les sous-dossiers se terminent par "/")
+	ListSecrets(ctx context.Context, path string) ([]string, error)
+}
+
+var _ SecretStore = (*Client)(nil)
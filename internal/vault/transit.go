@@ -0,0 +1,128 @@
+// filepath: internal/vault/transit.go
+
+package vault
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	"secrets-manager/internal/models"
+)
+
+// TransitMount est le point de montage du moteur transit de Vault
+const TransitMount = "transit"
+
+// Transit enveloppe des clés de données avec des clés de chiffrement qui ne
+// quittent jamais Vault (moteur transit). Client l'implémente, MemoryTransit
+// pour les tests.
+type Transit interface {
+	// CreateTransitKey crée la clé de chiffrement name (sans effet si elle existe)
+	CreateTransitKey(ctx context.Context, name string) error
+
+	// GenerateDataKey génère une clé de données AES-256 et la renvoie en
+	// clair et enveloppée par la clé name
+	GenerateDataKey(ctx context.Context, name string) (plaintext []byte, wrapped string, err error)
+
+	// DecryptDataKey renvoie en clair une clé de données enveloppée par la clé name
+	DecryptDataKey(ctx context.Context, name, wrapped string) ([]byte, error)
+}
+
+var _ Transit = (*Client)(nil)
+
+// CreateTransitKey crée une clé transit aes256-gcm96
+func (c *Client) CreateTransitKey(ctx context.Context, name string) error {
+	logger.Debug("création de la clé transit", "key", name)
+	_, err := c.client.Logical().WriteWithContext(ctx, TransitMount+"/keys/"+name, map[string]interface{}{
+		"type": "aes256-gcm96",
+	})
+	if err != nil {
+		logger.Warn("échec de création de la clé transit", "key", name, "error", err)
+		return fmt.Errorf("impossible de créer la clé transit: %w", classifyError(err))
+	}
+	return nil
+}
+
+// GenerateDataKey demande à Vault une clé de données de 256 bits
+func (c *Client) GenerateDataKey(ctx context.Context, name string) ([]byte, string, error) {
+	secret, err := c.client.Logical().WriteWithContext(ctx, TransitMount+"/datakey/plaintext/"+name, map[string]interface{}{
+		"bits": 256,
+	})
+	if err != nil {
+		logger.Warn("échec de génération de la clé de données", "key", name, "error", err)
+		return nil, "", fmt.Errorf("impossible de générer la clé de données: %w", classifyError(err))
+	}
+	if secret == nil || secret.Data == nil {
+		return nil, "", fmt.Errorf("%w: réponse transit vide", ErrUpstream)
+	}
+
+	wrapped, _ := secret.Data["ciphertext"].(string)
+	plaintext, err := decodeTransitPlaintext(secret.Data["plaintext"])
+	if err != nil || wrapped == "" {
+		return nil, "", fmt.Errorf("%w: réponse transit inattendue", ErrUpstream)
+	}
+	return plaintext, wrapped, nil
+}
+
+// DecryptDataKey déchiffre une clé de données avec la clé transit name
+func (c *Client) DecryptDataKey(ctx context.Context, name, wrapped string) ([]byte, error) {
+	secret, err := c.client.Logical().WriteWithContext(ctx, TransitMount+"/decrypt/"+name, map[string]interface{}{
+		"ciphertext": wrapped,
+	})
+	if err != nil {
+		logger.Warn("échec de déchiffrement de la clé de données", "key", name, "error", err)
+		return nil, fmt.Errorf("impossible de déchiffrer la clé de données: %w", classifyError(err))
+	}
+	if secret == nil || secret.Data == nil {
+		return nil, fmt.Errorf("%w: réponse transit vide", ErrUpstream)
+	}
+
+	plaintext, err := decodeTransitPlaintext(secret.Data["plaintext"])
+	if err != nil {
+		return nil, fmt.Errorf("%w: réponse transit inattendue", ErrUpstream)
+	}
+	return plaintext, nil
+}
+
+// decodeTransitPlaintext décode le champ plaintext (base64) d'une réponse transit
+func decodeTransitPlaintext(value interface{}) ([]byte, error) {
+	encoded, ok := value.(string)
+	if !ok {
+		return nil, fmt.Errorf("champ plaintext absent")
+	}
+	return base64.StdEncoding.DecodeString(encoded)
+}
+
+// TransitRouter choisit le moteur transit de la région de résidence de
+// chaque organisation, comme Router pour les secrets
+type TransitRouter struct {
+	transits map[string]Transit
+	resolve  RegionResolver
+}
+
+// NewTransitRouter crée un routeur entre les moteurs transit de chaque
+// région. transits doit contenir le moteur principal sous models.DefaultRegion.
+func NewTransitRouter(transits map[string]Transit, resolve RegionResolver) *TransitRouter {
+	return &TransitRouter{transits: transits, resolve: resolve}
+}
+
+// For renvoie le moteur transit de la région de l'organisation
+// (ErrRegionUnavailable si la région n'a pas de backend)
+func (r *TransitRouter) For(ctx context.Context, orgID string) (Transit, error) {
+	// Sans région supplémentaire, inutile de consulter la base
+	if len(r.transits) == 1 {
+		if transit, ok := r.transits[models.DefaultRegion]; ok {
+			return transit, nil
+		}
+	}
+
+	region, err := r.resolve(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("impossible de déterminer la région de l'organisation %s: %w", orgID, err)
+	}
+	transit, ok := r.transits[region]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrRegionUnavailable, region)
+	}
+	return transit, nil
+}
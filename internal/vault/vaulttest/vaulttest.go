@@ -0,0 +1,43 @@
+// filepath: internal/vault/vaulttest/vaulttest.go
+
+// Package vaulttest fournit un harnais de test pour le service Vault
+// adossé à un stockage en mémoire.
+package vaulttest
+
+import (
+	"context"
+	"testing"
+
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/vault"
+)
+
+// Harness regroupe un service Vault et son stockage en mémoire
+type Harness struct {
+	Store   *vault.MemoryStore
+	Service *vault.Service
+	t       testing.TB
+}
+
+// New crée un service Vault hermétique pour les tests
+func New(t testing.TB) *Harness {
+	t.Helper()
+
+	store := vault.NewMemoryStore()
+	return &Harness{
+		Store:   store,
+		Service: vault.NewService(store),
+		t:       t,
+	}
+}
+
+// Seed enregistre des secrets via le service et échoue le test en cas d'erreur
+func (h *Harness) Seed(secrets ...*models.Secret) {
+	h.t.Helper()
+
+	for _, secret := range secrets {
+		if err := h.Service.StoreSecret(context.Background(), secret); err != nil {
+			h.t.Fatalf("impossible d'enregistrer le secret %s: %v", secret.Name, err)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+// filepath: internal/version/version.go
+
+// Package version expose les informations de build du binaire.
+// Les valeurs sont injectées à la compilation :
+//
+//	go build -ldflags "-X secrets-manager/internal/version.Version=1.2.0 \
+//	  -X secrets-manager/internal/version.Commit=$(git rev-parse --short HEAD) \
+//	  -X secrets-manager/internal/version.BuildDate=$(date -u +%Y-%m-%dT%H:%M:%SZ)" ./cmd/api
+package version
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+// Valeurs injectées via -ldflags
+var (
+	Version   = "dev"
+	Commit    = ""
+	BuildDate = ""
+)
+
+// APIVersion est la version du contrat de l'API REST
+const APIVersion = "v1"
+
+// Info regroupe les informations de version renvoyées par l'API
+type Info struct {
+	Version    string   `json:"version"`
+	Commit     string   `json:"commit"`
+	BuildDate  string   `json:"build_date"`
+	GoVersion  string   `json:"go_version"`
+	APIVersion string   `json:"api_version"`
+	Backends   []string `json:"backends"`
+}
+
+// Get renvoie les informations de version pour les backends actifs.
+// À défaut de -ldflags, le commit est lu depuis les informations VCS du build.
+func Get(backends ...string) Info {
+	info := Info{
+		Version:    Version,
+		Commit:     Commit,
+		BuildDate:  BuildDate,
+		GoVersion:  runtime.Version(),
+		APIVersion: APIVersion,
+		Backends:   backends,
+	}
+
+	if info.Commit == "" || info.BuildDate == "" {
+		if buildInfo, ok := debug.ReadBuildInfo(); ok {
+			for _, setting := range buildInfo.Settings {
+				switch {
+				case setting.Key == "vcs.revision" && info.Commit == "":
+					info.Commit = setting.Value
+				case setting.Key == "vcs.time" && info.BuildDate == "":
+					info.BuildDate = setting.Value
+				}
+			}
+		}
+	}
+
+	if info.Backends == nil {
+		info.Backends = []string{}
+	}
+
+	return info
+}
@@ -0,0 +1,252 @@
+// filepath: internal/webhooks/sender.go
+
+// Package webhooks livre les événements d'une organisation à ses webhooks.
+// Chaque livraison est signée et conservée avec la réponse du consommateur
+// pour permettre aux intégrateurs de diagnostiquer et rejouer les échecs.
+//
+// Signature : l'en-tête X-Secrets-Manager-Signature vaut
+// "t=<timestamp unix>,v1=<hex>" où hex est le HMAC-SHA256, avec le secret
+// du webhook, de "<timestamp>.<corps>". Le consommateur recalcule le HMAC
+// et rejette les timestamps trop anciens.
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"secrets-manager/internal/events"
+	"secrets-manager/internal/logging"
+	"secrets-manager/internal/metrics"
+	"secrets-manager/internal/models"
+	"secrets-manager/internal/netguard"
+	"secrets-manager/internal/storage"
+)
+
+// En-têtes des livraisons
+const (
+	HeaderSignature = "X-Secrets-Manager-Signature"
+	HeaderEvent     = "X-Secrets-Manager-Event"
+	HeaderDelivery  = "X-Secrets-Manager-Delivery"
+)
+
+// maxResponseBody est la taille conservée de la réponse du consommateur
+const maxResponseBody = 4096
+
+// ErrInvalidURL indique une URL de webhook qui n'est pas une URL https
+var ErrInvalidURL = errors.New("l'URL du webhook doit être une URL https")
+
+var (
+	logger = logging.For(logging.ComponentJobs)
+
+	deliveriesTotal = metrics.NewCounter("webhook_deliveries_total",
+		"Livraisons de webhooks par type d'événement et résultat", "event", "result")
+)
+
+// Sender livre les événements aux webhooks et enregistre chaque livraison
+type Sender struct {
+	webhooks storage.WebhooksRepository
+	guard    netguard.Guard
+	http     *http.Client
+}
+
+// NewSender crée l'expéditeur des webhooks. guard refuse les destinations
+// internes, à l'enregistrement (CheckURL) et à chaque connexion. client nil
+// utilise un client protégé par guard avec un délai de 10 secondes ; un
+// client fourni doit l'être aussi (guard.Transport). Les redirections ne
+// sont jamais suivies.
+func NewSender(webhooks storage.WebhooksRepository, guard netguard.Guard, client *http.Client) *Sender {
+	if client == nil {
+		client = guard.Client(10 * time.Second)
+	}
+	noRedirect := *client
+	noRedirect.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	return &Sender{webhooks: webhooks, guard: guard, http: &noRedirect}
+}
+
+// CheckURL vérifie l'URL d'un webhook : https, et un hôte qui ne désigne
+// pas une adresse interne (netguard.ErrForbiddenDestination)
+func (s *Sender) CheckURL(ctx context.Context, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme != "https" || u.Hostname() == "" {
+		return ErrInvalidURL
+	}
+	return s.guard.CheckHost(ctx, u.Hostname())
+}
+
+// NewSecret génère le secret de signature d'un nouveau webhook
+func NewSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(b), nil
+}
+
+// Sign calcule la valeur de l'en-tête de signature d'un corps
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	ts := strconv.FormatInt(timestamp.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(ts))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "t=" + ts + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Payload est le corps JSON d'une livraison. Type est un type versionné
+// (events.SecretCreatedV1...) et Data suit son schéma (voir events.Catalog).
+type Payload struct {
+	ID             string    `json:"id"`
+	Type           string    `json:"type"`
+	OrganizationID string    `json:"organization_id"`
+	OccurredAt     time.Time `json:"occurred_at"`
+	Data           any       `json:"data"`
+}
+
+// Publish livre un événement de notification aux webhooks de l'organisation
+// abonnés à son type versionné. Un échec de livraison est enregistré dans
+// l'historique et n'interrompt pas les autres livraisons.
+func (s *Sender) Publish(ctx context.Context, event models.NotificationEvent) error {
+	if event.Schema == "" {
+		return nil
+	}
+
+	webhooks, err := s.webhooks.ListWebhooks(ctx, event.OrganizationID)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(Payload{
+		ID:             event.ID,
+		Type:           event.Schema,
+		OrganizationID: event.OrganizationID,
+		OccurredAt:     event.OccurredAt,
+		Data:           event.Data,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, webhook := range webhooks {
+		if !webhook.Subscribes(event.Schema) {
+			continue
+		}
+		if _, err := s.Send(ctx, webhook, event.Schema, body, ""); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Test envoie au webhook un événement d'exemple signé
+func (s *Sender) Test(ctx context.Context, webhook *models.Webhook) (*models.WebhookDelivery, error) {
+	body, err := json.Marshal(Payload{
+		ID:             uuid.New().String(),
+		Type:           events.WebhookPingV1,
+		OrganizationID: webhook.OrganizationID,
+		OccurredAt:     time.Now().UTC(),
+		Data: events.WebhookPing{
+			WebhookID: webhook.ID,
+			Message:   "Événement de test envoyé depuis secrets-manager",
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.Send(ctx, webhook, events.WebhookPingV1, body, "")
+}
+
+// Redeliver renvoie le corps d'une livraison précédente avec une nouvelle
+// signature. La nouvelle livraison référence l'ancienne.
+func (s *Sender) Redeliver(ctx context.Context, previous *models.WebhookDelivery) (*models.WebhookDelivery, error) {
+	webhook, err := s.webhooks.GetWebhook(ctx, previous.OrganizationID, previous.WebhookID)
+	if err != nil {
+		return nil, err
+	}
+	return s.Send(ctx, webhook, previous.EventType, []byte(previous.Payload), previous.ID)
+}
+
+// Send livre body au webhook et enregistre la livraison. L'erreur renvoyée
+// ne concerne que l'enregistrement : un échec HTTP est consigné dans la
+// livraison (Error, StatusCode).
+func (s *Sender) Send(
+	ctx context.Context,
+	webhook *models.Webhook,
+	eventType string,
+	body []byte,
+	redeliveryOf string,
+) (*models.WebhookDelivery, error) {
+	delivery := &models.WebhookDelivery{
+		ID:             uuid.New().String(),
+		WebhookID:      webhook.ID,
+		OrganizationID: webhook.OrganizationID,
+		EventType:      eventType,
+		Payload:        string(body),
+		RedeliveryOf:   redeliveryOf,
+		DeliveredAt:    time.Now(),
+	}
+
+	statusCode, responseBody, err := s.post(ctx, webhook, delivery, body)
+	delivery.DurationMS = time.Since(delivery.DeliveredAt).Milliseconds()
+	delivery.StatusCode = statusCode
+	delivery.ResponseBody = responseBody
+	switch {
+	case err != nil:
+		delivery.Error = err.Error()
+	case !delivery.Succeeded():
+		delivery.Error = fmt.Sprintf("statut %d", statusCode)
+	}
+
+	result := "ok"
+	if delivery.Error != "" {
+		result = "error"
+		logger.Warn("livraison d'un webhook échouée",
+			"webhook_id", webhook.ID, "event", eventType, "delivery_id", delivery.ID, "error", delivery.Error)
+	}
+	deliveriesTotal.Inc(eventType, result)
+
+	if err := s.webhooks.RecordWebhookDelivery(ctx, delivery); err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
+
+// post envoie la requête signée et renvoie le statut et le début de la réponse
+func (s *Sender) post(ctx context.Context, webhook *models.Webhook, delivery *models.WebhookDelivery, body []byte) (int, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "secrets-manager-webhooks")
+	req.Header.Set(HeaderEvent, delivery.EventType)
+	req.Header.Set(HeaderDelivery, delivery.ID)
+	req.Header.Set(HeaderSignature, Sign(webhook.Secret, time.Now(), body))
+
+	resp, err := s.http.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+
+	responseBody, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	if err != nil {
+		return resp.StatusCode, "", err
+	}
+	return resp.StatusCode, string(responseBody), nil
+}